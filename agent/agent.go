@@ -154,6 +154,11 @@ const (
 	MongoOplogSize    = "MONGO_OPLOG_SIZE"
 	NUMACtlPreference = "NUMA_CTL_PREFERENCE"
 
+	// SeparateUnitAgents, when "true", causes the machine agent to
+	// deploy unit agents as separate processes rather than running
+	// them itself.
+	SeparateUnitAgents = "SEPARATE_UNIT_AGENTS"
+
 	AgentLoginRateLimit  = "AGENT_LOGIN_RATE_LIMIT"
 	AgentLoginMinPause   = "AGENT_LOGIN_MIN_PAUSE"
 	AgentLoginMaxPause   = "AGENT_LOGIN_MAX_PAUSE"
@@ -165,6 +170,10 @@ const (
 	AgentConnUpperThreshold = "AGENT_CONN_UPPER_THRESHOLD"
 	AgentConnLookbackWindow = "AGENT_CONN_LOOKBACK_WINDOW"
 
+	AgentMaxConcurrentRequests = "AGENT_MAX_CONCURRENT_REQUESTS"
+	AgentRequestWeight         = "AGENT_REQUEST_WEIGHT"
+	ClientRequestWeight        = "CLIENT_REQUEST_WEIGHT"
+
 	MgoStatsEnabled = "MGO_STATS_ENABLED"
 
 	// LoggingOverride will set the logging for this agent to the value
@@ -188,8 +188,12 @@ func InitializeState(
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "getting environ provider")
 	}
+	hostedCloudSpec, err := environs.ResolvePluginCredential(cloudSpec)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	hostedModelEnv, err := provider.Open(environs.OpenParams{
-		Cloud:  cloudSpec,
+		Cloud:  hostedCloudSpec,
 		Config: hostedModelConfig,
 	})
 	if err != nil {
@@ -0,0 +1,174 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// BootstrapInfoFile is the name of the file, in the data
+	// directory, holding a machine's bootstrap info.
+	BootstrapInfoFile = "bootstrap-info.yaml"
+
+	// bootstrapInfoVersion is the version of the bootstrap info
+	// format written by this version of juju.
+	bootstrapInfoVersion = 1
+)
+
+// BootstrapInfo holds the last known details needed by a machine's
+// agents to connect to their controller. It is kept outside of the
+// agent configuration files so that agents can be re-pointed at a
+// rebuilt controller without re-provisioning the machine.
+type BootstrapInfo struct {
+	// Version is the version of the format the info was written in.
+	Version int `yaml:"version"`
+
+	// Controller is the tag of the controller.
+	Controller string `yaml:"controller,omitempty"`
+
+	// APIAddresses holds the addresses of the controller's API servers.
+	APIAddresses []string `yaml:"api-addresses"`
+
+	// CACert holds the CA certificate used to validate API connections.
+	CACert string `yaml:"ca-cert"`
+}
+
+// BootstrapInfoPath returns the path to the bootstrap info file in
+// the given data directory.
+func BootstrapInfoPath(dataDir string) string {
+	return filepath.Join(dataDir, BootstrapInfoFile)
+}
+
+// BootstrapInfoFromConfig returns the bootstrap info held in the agent
+// configuration.
+func BootstrapInfoFromConfig(config Config) (BootstrapInfo, error) {
+	addrs, err := config.APIAddresses()
+	if err != nil {
+		return BootstrapInfo{}, errors.Trace(err)
+	}
+	info := BootstrapInfo{
+		Version:      bootstrapInfoVersion,
+		APIAddresses: addrs,
+		CACert:       config.CACert(),
+	}
+	if tag := config.Controller(); tag.Id() != "" {
+		info.Controller = tag.String()
+	}
+	return info, nil
+}
+
+// ReadBootstrapInfo reads the bootstrap info from the given data
+// directory. It returns an error satisfying errors.IsNotFound if no
+// bootstrap info has been written.
+func ReadBootstrapInfo(dataDir string) (BootstrapInfo, error) {
+	data, err := ioutil.ReadFile(BootstrapInfoPath(dataDir))
+	if os.IsNotExist(err) {
+		return BootstrapInfo{}, errors.NotFoundf("bootstrap info")
+	} else if err != nil {
+		return BootstrapInfo{}, errors.Annotate(err, "cannot read bootstrap info")
+	}
+	var info BootstrapInfo
+	if err := goyaml.Unmarshal(data, &info); err != nil {
+		return BootstrapInfo{}, errors.Annotate(err, "cannot unmarshal bootstrap info")
+	}
+	if info.Version > bootstrapInfoVersion {
+		return BootstrapInfo{}, errors.NotSupportedf("bootstrap info version %d", info.Version)
+	}
+	return info, nil
+}
+
+// WriteBootstrapInfo atomically replaces the bootstrap info in the
+// given data directory. The info is synced to disk before WriteBootstrapInfo
+// returns, so that it survives the machine crashing.
+func WriteBootstrapInfo(dataDir string, info BootstrapInfo) error {
+	info.Version = bootstrapInfoVersion
+	data, err := goyaml.Marshal(&info)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal bootstrap info")
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeFileSync(BootstrapInfoPath(dataDir), data, 0600); err != nil {
+		return errors.Annotate(err, "cannot write bootstrap info")
+	}
+	return nil
+}
+
+// UpdateBootstrapInfo writes the bootstrap info held in the agent
+// configuration to the configuration's data directory, if it differs
+// from the bootstrap info already written there.
+func UpdateBootstrapInfo(config Config) error {
+	info, err := BootstrapInfoFromConfig(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	existing, err := ReadBootstrapInfo(config.DataDir())
+	if err == nil && reflect.DeepEqual(existing, info) {
+		return nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		// The existing info is no use to anyone,
+		// so replace it.
+		logger.Warningf("replacing bootstrap info: %v", err)
+	}
+	logger.Debugf("updating bootstrap info with API addresses %q", info.APIAddresses)
+	return errors.Trace(WriteBootstrapInfo(config.DataDir(), info))
+}
+
+// writeFileSync atomically replaces the file at path with the given
+// data, syncing the file and the directory holding it.
+func writeFileSync(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(path))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tmpPath := f.Name()
+	if err := writeAndSync(f, data, perm); err != nil {
+		os.Remove(tmpPath)
+		return errors.Trace(err)
+	}
+	if err := utils.ReplaceFile(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return errors.Trace(err)
+	}
+	return errors.Trace(syncDir(dir))
+}
+
+func writeAndSync(f *os.File, data []byte, perm os.FileMode) error {
+	defer f.Close()
+	if err := f.Chmod(perm); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return errors.Trace(err)
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+// syncDir syncs the directory, so that renames within it are
+// persisted. Directories cannot be synced on Windows.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer d.Close()
+	return errors.Trace(d.Sync())
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/testing"
+)
+
+type bootstrapInfoSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&bootstrapInfoSuite{})
+
+func (s *bootstrapInfoSuite) TestReadNotFound(c *gc.C) {
+	_, err := agent.ReadBootstrapInfo(c.MkDir())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *bootstrapInfoSuite) TestWriteRead(c *gc.C) {
+	dataDir := c.MkDir()
+	info := agent.BootstrapInfo{
+		Controller:   testing.ControllerTag.String(),
+		APIAddresses: []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+		CACert:       "ca cert",
+	}
+	err := agent.WriteBootstrapInfo(dataDir, info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fi, err := os.Stat(agent.BootstrapInfoPath(dataDir))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fi.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	read, err := agent.ReadBootstrapInfo(dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	info.Version = 1
+	c.Assert(read, jc.DeepEquals, info)
+
+	// Nothing but the bootstrap info is left behind.
+	infos, err := ioutil.ReadDir(dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 1)
+}
+
+func (s *bootstrapInfoSuite) TestReadNewerVersion(c *gc.C) {
+	dataDir := c.MkDir()
+	err := ioutil.WriteFile(agent.BootstrapInfoPath(dataDir), []byte("version: 2\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = agent.ReadBootstrapInfo(dataDir)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "bootstrap info version 2 not supported")
+}
+
+func (s *bootstrapInfoSuite) TestUpdateBootstrapInfo(c *gc.C) {
+	params := attributeParams
+	params.Paths.DataDir = c.MkDir()
+	conf, err := agent.NewAgentConfig(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = agent.UpdateBootstrapInfo(conf)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := agent.ReadBootstrapInfo(params.Paths.DataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, agent.BootstrapInfo{
+		Version:      1,
+		Controller:   testing.ControllerTag.String(),
+		APIAddresses: []string{"localhost:1235"},
+		CACert:       "ca cert",
+	})
+
+	conf.SetCACert("new ca cert")
+	err = agent.UpdateBootstrapInfo(conf)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = agent.ReadBootstrapInfo(params.Paths.DataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.CACert, gc.Equals, "new ca cert")
+}
+
+func (s *bootstrapInfoSuite) TestUpdateBootstrapInfoReplacesInvalid(c *gc.C) {
+	params := attributeParams
+	params.Paths.DataDir = c.MkDir()
+	conf, err := agent.NewAgentConfig(params)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(agent.BootstrapInfoPath(params.Paths.DataDir), []byte("{"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = agent.UpdateBootstrapInfo(conf)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := agent.ReadBootstrapInfo(params.Paths.DataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.APIAddresses, jc.DeepEquals, []string{"localhost:1235"})
+}
@@ -19,6 +19,7 @@ import (
 	agenttools "github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/testing"
 	coretest "github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/bindelta"
 )
 
 type ToolsSuite struct {
@@ -40,7 +41,7 @@ func (t *ToolsSuite) TestPackageDependencies(c *gc.C) {
 	// resulting slice has that prefix removed to keep the output short.
 	c.Assert(testing.FindJujuCoreImports(c, "github.com/juju/juju/agent/tools"),
 		gc.DeepEquals,
-		[]string{"tools"})
+		[]string{"tools", "tools/bindelta"})
 }
 
 // gzyesses holds the result of running:
@@ -148,6 +149,62 @@ func (t *ToolsSuite) TestUnpackToolsContents(c *gc.C) {
 	t.assertToolsContents(c, testTools, files)
 }
 
+func (t *ToolsSuite) TestUnpackToolsDelta(c *gc.C) {
+	baseFiles := []*testing.TarFile{
+		testing.NewTarFile("bar", agenttools.DirPerm, "bar contents"),
+		testing.NewTarFile("foo", agenttools.DirPerm, "foo contents"),
+	}
+	baseData, baseChecksum := testing.TarGz(baseFiles...)
+	baseTools := &coretest.Tools{
+		URL:     "http://foo/bar",
+		Version: version.MustParseBinary("1.2.3-quantal-amd64"),
+		Size:    int64(len(baseData)),
+		SHA256:  baseChecksum,
+	}
+	err := agenttools.UnpackTools(t.dataDir, baseTools, bytes.NewReader(baseData))
+	c.Assert(err, jc.ErrorIsNil)
+
+	files := []*testing.TarFile{
+		testing.NewTarFile("bar", agenttools.DirPerm, "bar2 contents"),
+		testing.NewTarFile("x", agenttools.DirPerm, "x contents"),
+	}
+	data, checksum := testing.TarGz(files...)
+	var delta bytes.Buffer
+	err = bindelta.MakeToolsDelta(&delta, bytes.NewReader(baseData), bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	testTools := &coretest.Tools{
+		URL:     "http://foo/baz",
+		Version: version.MustParseBinary("1.2.4-quantal-amd64"),
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	}
+
+	err = agenttools.UnpackToolsDelta(t.dataDir, baseTools.Version, testTools, &delta)
+	c.Assert(err, jc.ErrorIsNil)
+	assertDirNames(c, t.toolsDir(), []string{"1.2.3-quantal-amd64", "1.2.4-quantal-amd64"})
+	t.assertToolsContents(c, testTools, files)
+}
+
+func (t *ToolsSuite) TestUnpackToolsDeltaBadBase(c *gc.C) {
+	baseData, _ := testing.TarGz(testing.NewTarFile("bar", agenttools.DirPerm, "bar contents"))
+	data, checksum := testing.TarGz(testing.NewTarFile("bar", agenttools.DirPerm, "bar2 contents"))
+	var delta bytes.Buffer
+	err := bindelta.MakeToolsDelta(&delta, bytes.NewReader(baseData), bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	testTools := &coretest.Tools{
+		URL:     "http://foo/baz",
+		Version: version.MustParseBinary("1.2.4-quantal-amd64"),
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	}
+
+	// There are no base tools to build from.
+	base := version.MustParseBinary("1.2.3-quantal-amd64")
+	err = agenttools.UnpackToolsDelta(t.dataDir, base, testTools, &delta)
+	c.Assert(err, gc.ErrorMatches, `building "bar": reading base file: .*`)
+	assertDirNames(c, t.toolsDir(), []string{})
+}
+
 func (t *ToolsSuite) TestReadToolsErrors(c *gc.C) {
 	vers := version.MustParseBinary("1.2.3-precise-amd64")
 	testTools, err := agenttools.ReadTools(t.dataDir, vers)
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/version"
 
 	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/bindelta"
 )
 
 const (
@@ -78,10 +79,57 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 		return fmt.Errorf("tarball sha256 mismatch, expected %s, got %s", tools.SHA256, gzipSHA256)
 	}
 
+	// Checksum matches, now reset the file and untar it.
+	_, err = f.Seek(0, 0)
+	if err != nil {
+		return err
+	}
+	return installTools(dataDir, tools, func(dir string) error {
+		tr := tar.NewReader(f)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if strings.ContainsAny(hdr.Name, "/\\") {
+				return fmt.Errorf("bad name %q in tools archive", hdr.Name)
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				return fmt.Errorf("bad file type %c in file %q in tools archive", hdr.Typeflag, hdr.Name)
+			}
+			name := path.Join(dir, hdr.Name)
+			if err := writeFile(name, os.FileMode(hdr.Mode&0777), tr); err != nil {
+				return errors.Annotatef(err, "tar extract %q failed", name)
+			}
+		}
+		return nil
+	})
+}
+
+// UnpackToolsDelta reads a tools delta, as served by the controller
+// when asked for tools as a delta from the base version, and builds the
+// tools from it and the base tools into the appropriate tools directory
+// within dataDir. Each file built is verified against the hash recorded
+// in the delta. If a valid tools directory already exists,
+// UnpackToolsDelta returns without error.
+func UnpackToolsDelta(dataDir string, base version.Binary, tools *coretools.Tools, r io.Reader) error {
+	baseDir := SharedToolsDir(dataDir, base)
+	return installTools(dataDir, tools, func(dir string) error {
+		return bindelta.ApplyToolsDelta(r, baseDir, dir)
+	})
+}
+
+// installTools makes a temporary directory in the tools directory,
+// fills it with the tools' files by calling fill, and then moves it
+// into place as the directory for the given tools.
+func installTools(dataDir string, tools *coretools.Tools, fill func(dir string) error) error {
 	// Make a temporary directory in the tools directory,
 	// first ensuring that the tools directory exists.
 	toolsDir := path.Join(dataDir, "tools")
-	err = os.MkdirAll(toolsDir, dirPerm)
+	err := os.MkdirAll(toolsDir, dirPerm)
 	if err != nil {
 		return err
 	}
@@ -91,31 +139,9 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 	}
 	defer removeAll(dir)
 
-	// Checksum matches, now reset the file and untar it.
-	_, err = f.Seek(0, 0)
-	if err != nil {
+	if err := fill(dir); err != nil {
 		return err
 	}
-	tr := tar.NewReader(f)
-	for {
-		hdr, err := tr.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return err
-		}
-		if strings.ContainsAny(hdr.Name, "/\\") {
-			return fmt.Errorf("bad name %q in tools archive", hdr.Name)
-		}
-		if hdr.Typeflag != tar.TypeReg {
-			return fmt.Errorf("bad file type %c in file %q in tools archive", hdr.Typeflag, hdr.Name)
-		}
-		name := path.Join(dir, hdr.Name)
-		if err := writeFile(name, os.FileMode(hdr.Mode&0777), tr); err != nil {
-			return errors.Annotatef(err, "tar extract %q failed", name)
-		}
-	}
 	toolsMetadataData, err := json.Marshal(tools)
 	if err != nil {
 		return err
@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionschedules provides the client side API for the
+// ActionSchedules facade, used to enqueue actions periodically, or
+// once at a future time.
+package actionschedules
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ActionSchedules API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ActionSchedules
+// API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ActionSchedules")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddSchedule adds a schedule that enqueues an action on a unit, and
+// returns it.
+func (c *Client) AddSchedule(arg params.AddActionSchedule) (params.ActionSchedule, error) {
+	args := params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{arg},
+	}
+	var results params.ActionScheduleResults
+	if err := c.facade.FacadeCall("AddSchedules", args, &results); err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ActionSchedule{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ActionSchedule{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// Schedules returns the model's action schedules.
+func (c *Client) Schedules() ([]params.ActionSchedule, error) {
+	var result params.ActionSchedulesResult
+	if err := c.facade.FacadeCall("Schedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Schedules, nil
+}
+
+// RemoveSchedule removes the action schedule with the given id.
+func (c *Client) RemoveSchedule(id string) error {
+	args := params.ActionScheduleIds{Ids: []string{id}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveSchedules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
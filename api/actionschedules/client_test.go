@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionschedules"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestAddSchedule(c *gc.C) {
+	at := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	arg := params.AddActionSchedule{
+		Receiver: "unit-mysql-0",
+		Name:     "backup",
+		At:       &at,
+	}
+	schedule := params.ActionSchedule{
+		Id:       "1",
+		Receiver: "unit-mysql-0",
+		Name:     "backup",
+		NextRun:  &at,
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ActionSchedules")
+			c.Check(request, gc.Equals, "AddSchedules")
+			c.Check(a, jc.DeepEquals, params.AddActionSchedules{
+				Schedules: []params.AddActionSchedule{arg},
+			})
+			*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+				Results: []params.ActionScheduleResult{{Result: &schedule}},
+			}
+			return nil
+		},
+	)
+	result, err := actionschedules.NewClient(apiCaller).AddSchedule(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedule)
+}
+
+func (s *clientSuite) TestAddScheduleError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+				Results: []params.ActionScheduleResult{{Error: &params.Error{Message: "bad cron"}}},
+			}
+			return nil
+		},
+	)
+	_, err := actionschedules.NewClient(apiCaller).AddSchedule(params.AddActionSchedule{})
+	c.Assert(err, gc.ErrorMatches, "bad cron")
+}
+
+func (s *clientSuite) TestSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{Id: "1", Cron: "0 2 * * *"}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ActionSchedules")
+			c.Check(request, gc.Equals, "Schedules")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ActionSchedulesResult)) = params.ActionSchedulesResult{Schedules: schedules}
+			return nil
+		},
+	)
+	result, err := actionschedules.NewClient(apiCaller).Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedules)
+}
+
+func (s *clientSuite) TestRemoveSchedule(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ActionSchedules")
+			c.Check(request, gc.Equals, "RemoveSchedules")
+			c.Check(a, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"2"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "not found"}}},
+			}
+			return nil
+		},
+	)
+	err := actionschedules.NewClient(apiCaller).RemoveSchedule("2")
+	c.Assert(err, gc.ErrorMatches, "not found")
+}
+
+func (s *clientSuite) TestSchedulesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	_, err := actionschedules.NewClient(apiCaller).Schedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/faultinject"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
@@ -191,6 +192,9 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		defer cancel()
 		ctx = ctx1
 	}
+	if err := faultinject.Inject(faultinject.APIConnection); err != nil {
+		return nil, errors.Annotate(err, "cannot open API connection")
+	}
 	dialResult, err := dialAPI(ctx, info, opts)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -495,6 +499,11 @@ func (st *state) apiEndpoint(path, query string) (*url.URL, error) {
 
 // Ping implements api.Connection.
 func (s *state) Ping() error {
+	// A fault injected here causes the connection to be dropped
+	// by the monitor.
+	if err := faultinject.Inject(faultinject.APIConnection); err != nil {
+		return errors.Trace(err)
+	}
 	return s.APICall("Pinger", s.pingerFacadeVersion, "", "Ping", nil, nil)
 }
 
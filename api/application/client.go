@@ -8,6 +8,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -342,6 +344,21 @@ func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
 	return results.Units, err
 }
 
+// PreviewAddUnits reports where the units described by args would be
+// placed, without adding them.
+func (c *Client) PreviewAddUnits(args AddUnitsParams) (params.AddApplicationUnitsPreviewResult, error) {
+	var result params.AddApplicationUnitsPreviewResult
+	if c.BestAPIVersion() < 6 {
+		return result, errors.NotSupportedf("previewing unit placement")
+	}
+	err := c.facade.FacadeCall("PreviewAddUnits", params.AddApplicationUnits{
+		ApplicationName: args.ApplicationName,
+		NumUnits:        args.NumUnits,
+		Placement:       args.Placement,
+	}, &result)
+	return result, errors.Trace(err)
+}
+
 // DestroyUnitsDeprecated decreases the number of units dedicated to an
 // application.
 //
@@ -390,6 +407,9 @@ func (c *Client) DestroyUnits(in DestroyUnitsParams) ([]params.DestroyUnitResult
 		return allResults, nil
 	}
 
+	if in.HookTimeout > 0 && c.BestAPIVersion() < 7 {
+		return nil, errors.New("this controller does not support --hook-timeout")
+	}
 	args := interface{}(argsV5)
 	if c.BestAPIVersion() < 5 {
 		if in.DestroyStorage {
@@ -441,6 +461,11 @@ type DestroyApplicationsParams struct {
 	// DestroyStorage controls whether or not storage attached
 	// to units of the applications will be destroyed.
 	DestroyStorage bool
+
+	// HookTimeout, if non-zero, bounds the time the applications'
+	// units may take to run their end-of-life hooks, after which
+	// they are forcibly removed.
+	HookTimeout time.Duration
 }
 
 // DestroyApplications destroys the given applications.
@@ -458,10 +483,14 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 			continue
 		}
 		index = append(index, i)
-		argsV5.Applications = append(argsV5.Applications, params.DestroyApplicationParams{
+		arg := params.DestroyApplicationParams{
 			ApplicationTag: names.NewApplicationTag(name).String(),
 			DestroyStorage: in.DestroyStorage,
-		})
+		}
+		if in.HookTimeout > 0 {
+			arg.HookTimeout = &in.HookTimeout
+		}
+		argsV5.Applications = append(argsV5.Applications, arg)
 	}
 	if len(argsV5.Applications) == 0 {
 		return allResults, nil
@@ -494,6 +523,74 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 	return allResults, nil
 }
 
+// ApplicationRemovalReport returns the report of the cleanup steps
+// skipped when the units of the given application were forcibly
+// removed, because their end-of-life hooks did not complete within the
+// timeout given when the application was removed.
+func (c *Client) ApplicationRemovalReport(application string) (params.ApplicationRemovalReport, error) {
+	if c.BestAPIVersion() < 7 {
+		return params.ApplicationRemovalReport{}, errors.NotSupportedf("application removal reports")
+	}
+	var results params.ApplicationRemovalReportResults
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("ApplicationRemovalReports", args, &results); err != nil {
+		return params.ApplicationRemovalReport{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ApplicationRemovalReport{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ApplicationRemovalReport{}, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
+// ResolveUnitErrors marks the given units, which must be in an error
+// state, as resolved, re-executing their failed hooks if retry is true.
+func (c *Client) ResolveUnitErrors(units []string, retry bool) error {
+	if len(units) == 0 {
+		return errors.NotValidf("empty unit list")
+	}
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("resolving units in bulk")
+	}
+	tags := make([]params.Entity, len(units))
+	for i, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+		tags[i].Tag = names.NewUnitTag(unit).String()
+	}
+	var results params.ErrorResults
+	args := params.UnitsResolved{
+		Tags:  params.Entities{tags},
+		Retry: retry,
+	}
+	if err := c.facade.FacadeCall("ResolveUnitErrors", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// ResolveAllUnitErrors marks every unit in an error state as resolved,
+// re-executing their failed hooks if retry is true. If application is
+// not empty, only the units of that application are marked resolved.
+func (c *Client) ResolveAllUnitErrors(application string, retry bool) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("resolving all units")
+	}
+	var results params.ErrorResults
+	args := params.UnitsResolved{
+		All:         true,
+		Application: application,
+		Retry:       retry,
+	}
+	return errors.Trace(c.facade.FacadeCall("ResolveUnitErrors", args, &results))
+}
+
 // GetConstraints returns the constraints for the given applications.
 func (c *Client) GetConstraints(applications ...string) ([]constraints.Value, error) {
 	var allConstraints []constraints.Value
@@ -529,6 +626,230 @@ func (c *Client) GetConstraints(applications ...string) ([]constraints.Value, er
 	return allConstraints, nil
 }
 
+// GetEffectiveConstraints reports how the constraints of the given
+// application combine with the model constraints to produce the
+// constraints used when provisioning machines for its units.
+func (c *Client) GetEffectiveConstraints(application string) (params.ApplicationEffectiveConstraints, error) {
+	if c.BestAPIVersion() < 6 {
+		return params.ApplicationEffectiveConstraints{}, errors.NotSupportedf("reporting effective constraints")
+	}
+	var results params.ApplicationEffectiveConstraintsResults
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("GetEffectiveConstraints", args, &results); err != nil {
+		return params.ApplicationEffectiveConstraints{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ApplicationEffectiveConstraints{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ApplicationEffectiveConstraints{}, errors.Trace(result.Error)
+	}
+	return result, nil
+}
+
+// SetTrust grants or revokes the given application's access to the
+// model's cloud credential.
+func (c *Client) SetTrust(application string, trusted bool) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("trusting applications")
+	}
+	var results params.ErrorResults
+	args := params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrustArg{{
+			ApplicationName: application,
+			Trusted:         trusted,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetApplicationsTrust", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GetTrust reports whether the given application has been granted
+// access to the model's cloud credential, and how that access has
+// been used.
+func (c *Client) GetTrust(application string) (params.ApplicationTrustResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return params.ApplicationTrustResult{}, errors.NotSupportedf("trusting applications")
+	}
+	var results params.ApplicationTrustResults
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("GetApplicationsTrust", args, &results); err != nil {
+		return params.ApplicationTrustResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ApplicationTrustResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ApplicationTrustResult{}, errors.Trace(result.Error)
+	}
+	return result, nil
+}
+
+// RelationSettingsUsage returns the number of relation settings and
+// their size stored by each of the given application's units, along
+// with the limits configured for the model.
+func (c *Client) RelationSettingsUsage(application string) (params.RelationSettingsUsageResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return params.RelationSettingsUsageResult{}, errors.NotSupportedf("relation settings usage")
+	}
+	var results params.RelationSettingsUsageResults
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("RelationSettingsUsage", args, &results); err != nil {
+		return params.RelationSettingsUsageResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.RelationSettingsUsageResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.RelationSettingsUsageResult{}, errors.Trace(result.Error)
+	}
+	return result, nil
+}
+
+// SetHookEnvironment replaces the environment variables set for hook
+// executions of the given application's units, in addition to those set
+// in the model's hook-environment config. An empty map removes all of
+// the application's overrides.
+func (c *Client) SetHookEnvironment(application string, env map[string]string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("hook environment")
+	}
+	var results params.ErrorResults
+	args := params.ApplicationHookEnvironmentArgs{
+		Args: []params.ApplicationHookEnvironmentArg{{
+			ApplicationName: application,
+			Environment:     env,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetApplicationsHookEnvironment", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GetHookEnvironment returns the environment variables set for hook
+// executions of the given application's units, in addition to those
+// set in the model's hook-environment config.
+func (c *Client) GetHookEnvironment(application string) (map[string]string, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("hook environment")
+	}
+	var results params.HookEnvironmentResults
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("GetApplicationsHookEnvironment", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Environment, nil
+}
+
+// SetAddressPolicy sets the policy governing which of a machine's
+// addresses are published as the private and public addresses of the
+// given application's units. The zero policy restores the default.
+func (c *Client) SetAddressPolicy(application string, policy params.AddressPolicy) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("address policy")
+	}
+	var results params.ErrorResults
+	args := params.ApplicationAddressPolicyArgs{
+		Args: []params.ApplicationAddressPolicyArg{{
+			ApplicationName: application,
+			Policy:          policy,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetApplicationsAddressPolicy", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GetAddressPolicy returns the policy governing which of a machine's
+// addresses are published as the private and public addresses of the
+// given application's units.
+func (c *Client) GetAddressPolicy(application string) (params.AddressPolicy, error) {
+	if c.BestAPIVersion() < 10 {
+		return params.AddressPolicy{}, errors.NotSupportedf("address policy")
+	}
+	var results params.AddressPolicyResults
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("GetApplicationsAddressPolicy", args, &results); err != nil {
+		return params.AddressPolicy{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.AddressPolicy{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.AddressPolicy{}, errors.Trace(result.Error)
+	}
+	return result.Policy, nil
+}
+
+// SetLogging sets the logging config of the given application's units,
+// and whether their logs are written to a log file of their own on the
+// controllers. The zero value restores the defaults.
+func (c *Client) SetLogging(application string, logging params.ApplicationLogging) error {
+	if c.BestAPIVersion() < 13 {
+		return errors.NotSupportedf("application logging")
+	}
+	var results params.ErrorResults
+	args := params.ApplicationLoggingArgs{
+		Args: []params.ApplicationLoggingArg{{
+			ApplicationName: application,
+			Logging:         logging,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetApplicationsLogging", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GetLogging returns the logging config of the given application's
+// units, and whether their logs are written to a log file of their own
+// on the controllers.
+func (c *Client) GetLogging(application string) (params.ApplicationLogging, error) {
+	if c.BestAPIVersion() < 13 {
+		return params.ApplicationLogging{}, errors.NotSupportedf("application logging")
+	}
+	var results params.ApplicationLoggingResults
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("GetApplicationsLogging", args, &results); err != nil {
+		return params.ApplicationLogging{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ApplicationLogging{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ApplicationLogging{}, errors.Trace(result.Error)
+	}
+	return result.Logging, nil
+}
+
 // SetConstraints specifies the constraints for the given application.
 func (c *Client) SetConstraints(application string, constraints constraints.Value) error {
 	params := params.SetConstraints{
@@ -545,6 +866,36 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeWithFloatingIP exposes the application as Expose does, and
+// sets the given provider floating IP address to follow the
+// application's leader.
+func (c *Client) ExposeWithFloatingIP(application, address string) error {
+	if c.BestAPIVersion() < 11 {
+		return errors.NotSupportedf("floating IPs")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		FloatingIP:      address,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
+// ExposeToCIDRs exposes the application as Expose does, but allows
+// access to its open ports only from the given source CIDRs. If the
+// floating IP address is not empty, it is set to follow the
+// application's leader, as with ExposeWithFloatingIP.
+func (c *Client) ExposeToCIDRs(application string, cidrs []string, floatingIP string) error {
+	if c.BestAPIVersion() < 12 {
+		return errors.NotSupportedf("exposing to CIDRs")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		FloatingIP:      floatingIP,
+		ExposedCIDRs:    cidrs,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -606,6 +957,42 @@ func (c *Client) DestroyRelationId(relationId int) error {
 	return c.facade.FacadeCall("DestroyRelation", params, nil)
 }
 
+// PreviewAddRelation reports whether a relation between the specified
+// endpoints could be added, and which units would enter its scope,
+// without adding it.
+func (c *Client) PreviewAddRelation(endpoints ...string) (params.RelationPreviewResult, error) {
+	var result params.RelationPreviewResult
+	if c.BestAPIVersion() < 9 {
+		return result, errors.NotSupportedf("previewing relations")
+	}
+	args := params.AddRelation{Endpoints: endpoints}
+	err := c.facade.FacadeCall("PreviewAddRelation", args, &result)
+	return result, errors.Trace(err)
+}
+
+// PreviewDestroyRelation reports whether the relation between the
+// specified endpoints could be removed, and which units would leave
+// its scope, without removing it.
+func (c *Client) PreviewDestroyRelation(endpoints ...string) (params.RelationPreviewResult, error) {
+	return c.previewDestroyRelation(params.DestroyRelation{Endpoints: endpoints})
+}
+
+// PreviewDestroyRelationId reports whether the relation with the
+// specified id could be removed, and which units would leave its
+// scope, without removing it.
+func (c *Client) PreviewDestroyRelationId(relationId int) (params.RelationPreviewResult, error) {
+	return c.previewDestroyRelation(params.DestroyRelation{RelationId: relationId})
+}
+
+func (c *Client) previewDestroyRelation(args params.DestroyRelation) (params.RelationPreviewResult, error) {
+	var result params.RelationPreviewResult
+	if c.BestAPIVersion() < 9 {
+		return result, errors.NotSupportedf("previewing relations")
+	}
+	err := c.facade.FacadeCall("PreviewDestroyRelation", args, &result)
+	return result, errors.Trace(err)
+}
+
 // SetRelationSuspended updates the suspended status of the relation with the specified id.
 func (c *Client) SetRelationSuspended(relationIds []int, suspended bool, message string) error {
 	var args params.RelationSuspendedArgs
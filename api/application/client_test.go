@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -191,6 +193,660 @@ func (s *applicationSuite) TestAddUnits(c *gc.C) {
 	c.Assert(units, jc.DeepEquals, []string{"foo/0"})
 }
 
+func (s *applicationSuite) TestPreviewAddUnits(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PreviewAddUnits")
+				args, ok := a.(params.AddApplicationUnits)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.ApplicationName, gc.Equals, "foo")
+				c.Assert(args.NumUnits, gc.Equals, 2)
+				c.Assert(args.Placement, jc.DeepEquals, []*instance.Placement{{"scope", "directive"}})
+				result := response.(*params.AddApplicationUnitsPreviewResult)
+				result.Units = []params.UnitPlacementPreview{{MachineId: "0"}, {}}
+				result.ExistingMachines = []string{"0"}
+				result.NewMachines = 1
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	result, err := client.PreviewAddUnits(application.AddUnitsParams{
+		ApplicationName: "foo",
+		NumUnits:        2,
+		Placement:       []*instance.Placement{{"scope", "directive"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AddApplicationUnitsPreviewResult{
+		Units:            []params.UnitPlacementPreview{{MachineId: "0"}, {}},
+		ExistingMachines: []string{"0"},
+		NewMachines:      1,
+	})
+}
+
+func (s *applicationSuite) TestPreviewAddUnitsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.PreviewAddUnits(application.AddUnitsParams{ApplicationName: "foo", NumUnits: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestPreviewAddRelation(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PreviewAddRelation")
+				c.Assert(a, jc.DeepEquals, params.AddRelation{Endpoints: []string{"wordpress", "mysql"}})
+				result := response.(*params.RelationPreviewResult)
+				result.Key = "wordpress:db mysql:server"
+				result.Units = []string{"mysql/0", "wordpress/0"}
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+
+	result, err := client.PreviewAddRelation("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationPreviewResult{
+		Key:   "wordpress:db mysql:server",
+		Units: []string{"mysql/0", "wordpress/0"},
+	})
+}
+
+func (s *applicationSuite) TestPreviewDestroyRelationId(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PreviewDestroyRelation")
+				c.Assert(a, jc.DeepEquals, params.DestroyRelation{RelationId: 123})
+				result := response.(*params.RelationPreviewResult)
+				result.Problems = []string{"relation is already dying"}
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+
+	result, err := client.PreviewDestroyRelationId(123)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{"relation is already dying"})
+}
+
+func (s *applicationSuite) TestPreviewRelationNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.PreviewAddRelation("wordpress", "mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.PreviewDestroyRelation("wordpress", "mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestGetEffectiveConstraints(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "GetEffectiveConstraints")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{"application-foo"}},
+				})
+				results := response.(*params.ApplicationEffectiveConstraintsResults)
+				results.Results = []params.ApplicationEffectiveConstraints{{
+					Model:     constraints.MustParse("mem=4G"),
+					Effective: constraints.MustParse("mem=4G"),
+					Inherited: []string{"mem"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	result, err := client.GetEffectiveConstraints("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ApplicationEffectiveConstraints{
+		Model:     constraints.MustParse("mem=4G"),
+		Effective: constraints.MustParse("mem=4G"),
+		Inherited: []string{"mem"},
+	})
+}
+
+func (s *applicationSuite) TestGetEffectiveConstraintsError(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				results := response.(*params.ApplicationEffectiveConstraintsResults)
+				results.Results = []params.ApplicationEffectiveConstraints{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	_, err := client.GetEffectiveConstraints("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestGetEffectiveConstraintsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.GetEffectiveConstraints("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetTrust(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "SetApplicationsTrust")
+				c.Assert(a, jc.DeepEquals, params.ApplicationTrustArgs{
+					Args: []params.ApplicationTrustArg{{ApplicationName: "foo", Trusted: true}},
+				})
+				results := response.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	err := client.SetTrust("foo", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetTrustError(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				results := response.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	err := client.SetTrust("foo", false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestSetTrustNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.SetTrust("foo", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestGetTrust(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "GetApplicationsTrust")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{"application-foo"}},
+				})
+				results := response.(*params.ApplicationTrustResults)
+				results.Results = []params.ApplicationTrustResult{{
+					Trusted:   true,
+					GrantedBy: "admin",
+					UseCount:  2,
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	result, err := client.GetTrust("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ApplicationTrustResult{
+		Trusted:   true,
+		GrantedBy: "admin",
+		UseCount:  2,
+	})
+}
+
+func (s *applicationSuite) TestGetTrustNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.GetTrust("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestDestroyApplicationsHookTimeout(c *gc.C) {
+	timeout := time.Minute
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "DestroyApplication")
+				c.Assert(a, jc.DeepEquals, params.DestroyApplicationsParams{
+					Applications: []params.DestroyApplicationParams{{
+						ApplicationTag: "application-foo",
+						HookTimeout:    &timeout,
+					}},
+				})
+				results := response.(*params.DestroyApplicationResults)
+				results.Results = []params.DestroyApplicationResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+		HookTimeout:  time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+}
+
+func (s *applicationSuite) TestDestroyApplicationsHookTimeoutNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+		HookTimeout:  time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support --hook-timeout")
+}
+
+func (s *applicationSuite) TestApplicationRemovalReport(c *gc.C) {
+	forced := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ApplicationRemovalReports")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{"application-foo"}},
+				})
+				results := response.(*params.ApplicationRemovalReportResults)
+				results.Results = []params.ApplicationRemovalReportResult{{
+					Result: &params.ApplicationRemovalReport{
+						Application: "foo",
+						HookTimeout: time.Minute,
+						Forced:      forced,
+						Skipped:     []string{"unit foo/0: stop hook did not complete"},
+					},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	report, err := client.ApplicationRemovalReport("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.ApplicationRemovalReport{
+		Application: "foo",
+		HookTimeout: time.Minute,
+		Forced:      forced,
+		Skipped:     []string{"unit foo/0: stop hook did not complete"},
+	})
+}
+
+func (s *applicationSuite) TestApplicationRemovalReportNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.ApplicationRemovalReport("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestResolveUnitErrors(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ResolveUnitErrors")
+				c.Assert(a, jc.DeepEquals, params.UnitsResolved{
+					Tags: params.Entities{
+						Entities: []params.Entity{{"unit-foo-0"}, {"unit-foo-1"}},
+					},
+					Retry: true,
+				})
+				results := response.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{}, {Error: &params.Error{Message: "boom"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.ResolveUnitErrors([]string{"foo/0", "foo/1"}, true)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestResolveUnitErrorsInvalidUnit(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.ResolveUnitErrors([]string{"foo"}, true)
+	c.Assert(err, gc.ErrorMatches, `unit name "foo" not valid`)
+	err = client.ResolveUnitErrors(nil, true)
+	c.Assert(err, gc.ErrorMatches, "empty unit list not valid")
+}
+
+func (s *applicationSuite) TestResolveAllUnitErrors(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ResolveUnitErrors")
+				c.Assert(a, jc.DeepEquals, params.UnitsResolved{
+					All:         true,
+					Application: "foo",
+				})
+				return errors.New("boom")
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.ResolveAllUnitErrors("foo", false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestResolveAllUnitErrorsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.ResolveAllUnitErrors("", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestRelationSettingsUsage(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "RelationSettingsUsage")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{"application-foo"}},
+				})
+				results := response.(*params.RelationSettingsUsageResults)
+				results.Results = []params.RelationSettingsUsageResult{{
+					MaxKeys: 10,
+					MaxSize: 1024,
+					Usage: []params.RelationSettingsUsage{{
+						RelationKey: "foo:db bar:db",
+						RelationId:  1,
+						Unit:        "foo/0",
+						Keys:        2,
+						Size:        42,
+					}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	result, err := client.RelationSettingsUsage("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationSettingsUsageResult{
+		MaxKeys: 10,
+		MaxSize: 1024,
+		Usage: []params.RelationSettingsUsage{{
+			RelationKey: "foo:db bar:db",
+			RelationId:  1,
+			Unit:        "foo/0",
+			Keys:        2,
+			Size:        42,
+		}},
+	})
+}
+
+func (s *applicationSuite) TestRelationSettingsUsageNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.RelationSettingsUsage("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetHookEnvironment(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetApplicationsHookEnvironment")
+				c.Assert(a, jc.DeepEquals, params.ApplicationHookEnvironmentArgs{
+					Args: []params.ApplicationHookEnvironmentArg{{
+						ApplicationName: "foo",
+						Environment:     map[string]string{"SITE_ID": "lon1"},
+					}},
+				})
+				results := response.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	err := client.SetHookEnvironment("foo", map[string]string{"SITE_ID": "lon1"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestGetHookEnvironment(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "GetApplicationsHookEnvironment")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{"application-foo"}},
+				})
+				results := response.(*params.HookEnvironmentResults)
+				results.Results = []params.HookEnvironmentResult{{
+					Environment: map[string]string{"SITE_ID": "lon1"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	env, err := client.GetHookEnvironment("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, jc.DeepEquals, map[string]string{"SITE_ID": "lon1"})
+}
+
+func (s *applicationSuite) TestSetAddressPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetApplicationsAddressPolicy")
+				c.Assert(a, jc.DeepEquals, params.ApplicationAddressPolicyArgs{
+					Args: []params.ApplicationAddressPolicyArg{{
+						ApplicationName: "foo",
+						Policy:          params.AddressPolicy{Prefer: "fan"},
+					}},
+				})
+				results := response.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 10,
+	})
+	err := client.SetAddressPolicy("foo", params.AddressPolicy{Prefer: "fan"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestGetAddressPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "GetApplicationsAddressPolicy")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{"application-foo"}},
+				})
+				results := response.(*params.AddressPolicyResults)
+				results.Results = []params.AddressPolicyResult{{
+					Policy: params.AddressPolicy{PreferIPv6: true, Space: "public"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 10,
+	})
+	policy, err := client.GetAddressPolicy("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, params.AddressPolicy{PreferIPv6: true, Space: "public"})
+}
+
+func (s *applicationSuite) TestAddressPolicyNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.GetAddressPolicy("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetAddressPolicy("foo", params.AddressPolicy{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetLogging(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetApplicationsLogging")
+				c.Assert(a, jc.DeepEquals, params.ApplicationLoggingArgs{
+					Args: []params.ApplicationLoggingArg{{
+						ApplicationName: "foo",
+						Logging:         params.ApplicationLogging{Config: "unit=DEBUG"},
+					}},
+				})
+				results := response.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	err := client.SetLogging("foo", params.ApplicationLogging{Config: "unit=DEBUG"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestGetLogging(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "GetApplicationsLogging")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{"application-foo"}},
+				})
+				results := response.(*params.ApplicationLoggingResults)
+				results.Results = []params.ApplicationLoggingResult{{
+					Logging: params.ApplicationLogging{DedicatedFile: true},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	logging, err := client.GetLogging("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(logging, jc.DeepEquals, params.ApplicationLogging{DedicatedFile: true})
+}
+
+func (s *applicationSuite) TestLoggingNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.GetLogging("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetLogging("foo", params.ApplicationLogging{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestExposeWithFloatingIP(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "Expose")
+				c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+					ApplicationName: "foo",
+					FloatingIP:      "203.0.113.10",
+				})
+				return nil
+			},
+		),
+		BestVersion: 11,
+	})
+	err := client.ExposeWithFloatingIP("foo", "203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestExposeWithFloatingIPNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.ExposeWithFloatingIP("foo", "203.0.113.10")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestExposeToCIDRs(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "Expose")
+				c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+					ApplicationName: "foo",
+					ExposedCIDRs:    []string{"10.0.0.0/24"},
+				})
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+	err := client.ExposeToCIDRs("foo", []string{"10.0.0.0/24"}, "")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestExposeToCIDRsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 11,
+	})
+	err := client.ExposeToCIDRs("foo", []string{"10.0.0.0/24"}, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestHookEnvironmentNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.GetHookEnvironment("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetHookEnvironment("foo", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestAddUnitsAttachStorageV4(c *gc.C) {
 	var called bool
 	client := application.NewClient(basetesting.BestVersionCaller{
@@ -34,6 +34,7 @@ type ModelStatus struct {
 	Machines           []Machine
 	Volumes            []Volume
 	Filesystems        []Filesystem
+	DestroyError       string
 }
 
 // Machine holds information about a machine in a juju model.
@@ -62,6 +63,7 @@ type ModelInfo struct {
 	Users           []UserInfo
 	Machines        []Machine
 	AgentVersion    *version.Number
+	Expires         *time.Time
 }
 
 // Status represents the status of a machine, application, or unit.
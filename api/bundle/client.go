@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle provides the client side API for the Bundle facade.
+package bundle
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Bundle API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Bundle API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Bundle")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ExportBundle returns the current model as bundle YAML, which can be
+// deployed to recreate the model's applications, machines and
+// relations.
+func (c *Client) ExportBundle() (string, error) {
+	if c.BestAPIVersion() < 2 {
+		return "", errors.NotSupportedf("exporting bundles with this version of Juju")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
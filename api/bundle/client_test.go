@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestExportBundle(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.StringResult)) = params.StringResult{
+				Result: "applications:\n  mysql:\n    charm: cs:mysql-42\n",
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	result, err := bundle.NewClient(apiCaller).ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "applications:\n  mysql:\n    charm: cs:mysql-42\n")
+}
+
+func (s *clientSuite) TestExportBundleError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.StringResult)) = params.StringResult{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	_, err := bundle.NewClient(apiCaller).ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *clientSuite) TestExportBundleNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 1,
+	}
+	_, err := bundle.NewClient(apiCaller).ExportBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package changelog provides the client side API for the ChangeLog
+// facade, used to read the changes applied to a model.
+package changelog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Filter selects the entries of a model's change log. Fields with
+// zero values match all entries.
+type Filter struct {
+	// Kind selects entries of the given kind, eg "deploy".
+	Kind string
+
+	// Entity selects entries for the entity with the given tag.
+	Entity string
+
+	// Actor selects entries for changes made by the named user.
+	Actor string
+
+	// Since selects entries recorded at or after the given time.
+	Since time.Time
+
+	// Limit, if positive, selects only the latest matching entries.
+	Limit int
+}
+
+// Client allows access to the ChangeLog API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ChangeLog API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ChangeLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ChangeLog returns the entries of the model's change log selected
+// by the filter, oldest first.
+func (c *Client) ChangeLog(filter Filter) ([]params.ChangeLogEntry, error) {
+	args := params.ChangeLogFilter{
+		Kind:   filter.Kind,
+		Entity: filter.Entity,
+		Actor:  filter.Actor,
+		Limit:  filter.Limit,
+	}
+	if !filter.Since.IsZero() {
+		args.Since = &filter.Since
+	}
+	var result params.ChangeLogResult
+	if err := c.facade.FacadeCall("ChangeLog", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
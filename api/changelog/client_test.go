@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package changelog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/changelog"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestChangeLog(c *gc.C) {
+	since := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	entries := []params.ChangeLogEntry{{
+		Seq:    1,
+		Time:   since.Add(time.Minute),
+		Actor:  "bob",
+		Kind:   "deploy",
+		Entity: "application-mysql",
+		Params: map[string]string{"charm": "cs:mysql-42"},
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ChangeLog")
+			c.Check(request, gc.Equals, "ChangeLog")
+			c.Check(a, jc.DeepEquals, params.ChangeLogFilter{
+				Kind:  "deploy",
+				Actor: "bob",
+				Since: &since,
+				Limit: 5,
+			})
+			*(result.(*params.ChangeLogResult)) = params.ChangeLogResult{Entries: entries}
+			return nil
+		},
+	)
+	result, err := changelog.NewClient(apiCaller).ChangeLog(changelog.Filter{
+		Kind:  "deploy",
+		Actor: "bob",
+		Since: since,
+		Limit: 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+}
+
+func (s *clientSuite) TestChangeLogNoFilter(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(a, jc.DeepEquals, params.ChangeLogFilter{})
+			return errors.New("boom")
+		},
+	)
+	_, err := changelog.NewClient(apiCaller).ChangeLog(changelog.Filter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -44,6 +44,17 @@ type CharmInfo struct {
 	Meta     *charm.Meta
 	Actions  *charm.Actions
 	Metrics  *charm.Metrics
+
+	// GitSource is set if the charm was built from a git repository.
+	GitSource *GitSource
+}
+
+// GitSource identifies the git repository and commit from which a
+// charm was built.
+type GitSource struct {
+	Repository string
+	Ref        string
+	Revision   string
 }
 
 // CharmInfo returns information about the requested charm.
@@ -65,6 +76,13 @@ func (c *Client) CharmInfo(charmURL string) (*CharmInfo, error) {
 		Actions:  convertCharmActions(info.Actions),
 		Metrics:  convertCharmMetrics(info.Metrics),
 	}
+	if info.GitSource != nil {
+		result.GitSource = &GitSource{
+			Repository: info.GitSource.Repository,
+			Ref:        info.GitSource.Ref,
+			Revision:   info.GitSource.Revision,
+		}
+	}
 	return result, nil
 }
 
@@ -48,6 +48,26 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusPage returns a page of the status of the juju model, holding
+// no more than limit machines, containers, applications and units.
+// The first page is requested with an empty cursor; each page holds the
+// cursor for the next, which is empty after the last page.
+func (c *Client) StatusPage(patterns []string, cursor string, limit int) (*params.FullStatusPage, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("paginated status")
+	}
+	var result params.FullStatusPage
+	p := params.StatusPageParams{
+		Patterns: patterns,
+		Cursor:   cursor,
+		Limit:    limit,
+	}
+	if err := c.facade.FacadeCall("FullStatusPage", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // CACert returns the CA certificate associated with
 // the connection.
 func (c *Client) CACert() (string, error) {
@@ -55,9 +75,19 @@ func (c *Client) CACert() (string, error) {
 }
 
 // StatusHistory retrieves the last <size> results of
-// <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
-// for <name> unit
+// <kind:combined|agent|workload|machine|machineinstance|container|containerinstance|volume|filesystem> status
+// for <name> entity
 func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	if c.facade.BestAPIVersion() < 5 {
+		switch {
+		case kind == status.KindVolume || kind == status.KindFilesystem:
+			return status.History{}, errors.NotSupportedf("%s status history", kind)
+		case filter.ToDate != nil:
+			return status.History{}, errors.NotSupportedf("status history end date")
+		case filter.Offset != 0:
+			return status.History{}, errors.NotSupportedf("status history offset")
+		}
+	}
 	var results params.StatusHistoryResults
 	args := params.StatusHistoryRequest{
 		Kind: string(kind),
@@ -66,6 +96,8 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 			Date:    filter.FromDate,
 			Delta:   filter.Delta,
 			Exclude: filter.Exclude.Values(),
+			ToDate:  filter.ToDate,
+			Offset:  filter.Offset,
 		},
 		Tag: tag.String(),
 	}
@@ -126,6 +158,27 @@ func (c *Client) RetryProvisioning(machines ...names.MachineTag) ([]params.Error
 	return results.Results, err
 }
 
+// RetryProvisioningWithOptions updates the provisioning status of the
+// machines allowing the provisioner to retry, optionally avoiding the
+// availability zone or instance type with which the last attempt
+// failed for lack of capacity.
+func (c *Client) RetryProvisioningWithOptions(switchZone, switchInstanceType bool, machines ...names.MachineTag) ([]params.ErrorResult, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("retrying provisioning with options")
+	}
+	args := params.RetryProvisioningArgs{
+		Entities:           make([]params.Entity, len(machines)),
+		SwitchZone:         switchZone,
+		SwitchInstanceType: switchInstanceType,
+	}
+	for i, machine := range machines {
+		args.Entities[i] = params.Entity{Tag: machine.String()}
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("RetryProvisioningWithOptions", args, &results)
+	return results.Results, err
+}
+
 // PublicAddress returns the public address of the specified
 // machine or unit. For a machine, target is an id not a tag.
 func (c *Client) PublicAddress(target string) (string, error) {
@@ -425,6 +478,41 @@ func (c *Client) AddCharmWithAuthorization(curl *charm.URL, channel csparams.Cha
 	return nil
 }
 
+// GitCredentials holds the credentials with which the controller
+// fetches a charm from a git repository.
+type GitCredentials struct {
+	// PrivateKey is the SSH private key used to authenticate to a
+	// git+ssh repository, if any.
+	PrivateKey string
+
+	// KnownHosts holds the SSH host keys of a git+ssh repository's
+	// host, in known_hosts format. It is required for git+ssh
+	// repositories.
+	KnownHosts string
+}
+
+// AddCharmFromGit asks the controller to build a charm from the given
+// ref of the git repository with the given git+ssh:// or git+https://
+// URL, and add it to the model. It returns the URL of the charm added.
+// If the series is empty, the charm's default series is used.
+func (c *Client) AddCharmFromGit(repository, ref, series string, creds GitCredentials) (*charm.URL, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("deploying charms from git repositories")
+	}
+	args := params.AddCharmFromGit{
+		Repository: repository,
+		Ref:        ref,
+		Series:     series,
+		PrivateKey: creds.PrivateKey,
+		KnownHosts: creds.KnownHosts,
+	}
+	var result params.AddCharmFromGitResult
+	if err := c.facade.FacadeCall("AddCharmFromGit", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ParseURL(result.URL)
+}
+
 // ResolveCharm resolves the best available charm URLs with series, for charm
 // locations without a series specified.
 func (c *Client) ResolveCharm(ref *charm.URL) (*charm.URL, error) {
@@ -77,6 +77,7 @@ func (c *ModelStatusAPI) ModelStatus(tags ...names.ModelTag) ([]base.ModelStatus
 			TotalMachineCount:  len(r.Machines),
 			Volumes:            volumes,
 			Filesystems:        filesystems,
+			DestroyError:       r.DestroyError,
 		}
 		results[i].Machines = make([]base.Machine, len(r.Machines))
 		for j, mm := range r.Machines {
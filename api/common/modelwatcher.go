@@ -81,3 +81,13 @@ func (e *ModelWatcher) UpdateStatusHookInterval() (time.Duration, error) {
 	}
 	return modelConfig.UpdateStatusHookInterval(), nil
 }
+
+// LeaderFirstConfigChanged returns whether config-changed hooks are
+// delivered to application leaders before their followers.
+func (e *ModelWatcher) LeaderFirstConfigChanged() (bool, error) {
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return false, err
+	}
+	return modelConfig.LeaderFirstConfigChanged(), nil
+}
@@ -159,6 +159,24 @@ func (c *Client) DestroyController(args DestroyControllerParams) error {
 	}, nil)
 }
 
+// ConfigSet updates and removes controller config attributes, and
+// returns the names of the changed attributes that only take effect
+// once the controller agents are restarted.
+func (c *Client) ConfigSet(values map[string]interface{}, remove []string) ([]string, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.Errorf("this controller version does not support updating controller config")
+	}
+	var result params.ControllerConfigSetResult
+	err := c.facade.FacadeCall("ConfigSet", params.ControllerConfigSet{
+		Config: values,
+		Remove: remove,
+	}, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.RestartRequired, nil
+}
+
 // ListBlockedModels returns a list of all models within the controller
 // which have at least one block in place.
 func (c *Client) ListBlockedModels() ([]params.ModelBlockInfo, error) {
@@ -77,6 +77,45 @@ func (s *Suite) TestDestroyControllerError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "nope")
 }
 
+func (s *Suite) TestConfigSet(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.ControllerConfigSetResult)) = params.ControllerConfigSetResult{
+				RestartRequired: []string{"api-port"},
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+
+	restart, err := client.ConfigSet(map[string]interface{}{
+		"auditing-enabled": "true",
+		"api-port":         "17071",
+	}, []string{"identity-url"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restart, jc.DeepEquals, []string{"api-port"})
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		FuncName: "Controller.ConfigSet",
+		Args: []interface{}{params.ControllerConfigSet{
+			Config: map[string]interface{}{
+				"auditing-enabled": "true",
+				"api-port":         "17071",
+			},
+			Remove: []string{"identity-url"},
+		}},
+	}})
+}
+
+func (s *Suite) TestConfigSetAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 4}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ConfigSet(map[string]interface{}{"auditing-enabled": "true"}, nil)
+	c.Assert(err, gc.ErrorMatches, "this controller version does not support updating controller config")
+}
+
 func (s *Suite) TestInitiateMigration(c *gc.C) {
 	s.checkInitiateMigration(c, makeSpec())
 }
@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerhealth provides a client for the ControllerHealth
+// facade, which reports on the health of a controller.
+package controllerhealth
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ControllerHealth facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new Client based on an existing authenticated
+// controller API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ControllerHealth")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Health returns the health of the controller.
+func (c *Client) Health() (params.ControllerHealth, error) {
+	var result params.ControllerHealth
+	if err := c.facade.FacadeCall("Health", nil, &result); err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	return result, nil
+}
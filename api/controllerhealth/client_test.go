@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestHealth(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ControllerHealth")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Health")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ControllerHealth{})
+			*(result.(*params.ControllerHealth)) = params.ControllerHealth{
+				APIConnections: 7,
+				ReplicaSet: []params.ReplicaSetMemberHealth{{
+					Address: "10.0.0.1:37017",
+					State:   "PRIMARY",
+					Healthy: true,
+				}},
+			}
+			return nil
+		},
+	)
+	client := controllerhealth.NewClient(apiCaller)
+	health, err := client.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, params.ControllerHealth{
+		APIConnections: 7,
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			Address: "10.0.0.1:37017",
+			State:   "PRIMARY",
+			Healthy: true,
+		}},
+	})
+}
+
+func (s *clientSuite) TestHealthError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := controllerhealth.NewClient(apiCaller)
+	_, err := client.Health()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
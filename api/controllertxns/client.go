@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllertxns provides a client for the ControllerTxns
+// facade, which finds and resumes stuck transactions in a controller's
+// database.
+package controllertxns
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ControllerTxns facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new Client based on an existing authenticated
+// controller API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ControllerTxns")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// StuckTransactions returns the transactions that the controller has
+// been unable to complete.
+func (c *Client) StuckTransactions() ([]params.StuckTransaction, error) {
+	var result params.StuckTransactions
+	if err := c.facade.FacadeCall("StuckTransactions", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Transactions, nil
+}
+
+// ResumeTransactions attempts to complete the transactions with the
+// given ids.
+func (c *Client) ResumeTransactions(ids ...string) error {
+	args := params.ResumeTransactionsArgs{Ids: ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResumeTransactions", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return errors.Errorf("expected %d results, got %d", len(ids), len(results.Results))
+	}
+	return results.Combine()
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllertxns_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controllertxns"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestStuckTransactions(c *gc.C) {
+	started := time.Date(2017, 11, 1, 9, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ControllerTxns")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "StuckTransactions")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.StuckTransactions{})
+			*(result.(*params.StuckTransactions)) = params.StuckTransactions{
+				Transactions: []params.StuckTransaction{{
+					Id:      "5a0a4c3e0000000000000001",
+					State:   "prepared",
+					Started: started,
+				}},
+			}
+			return nil
+		},
+	)
+	client := controllertxns.NewClient(apiCaller)
+	stuck, err := client.StuckTransactions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, jc.DeepEquals, []params.StuckTransaction{{
+		Id:      "5a0a4c3e0000000000000001",
+		State:   "prepared",
+		Started: started,
+	}})
+}
+
+func (s *clientSuite) TestStuckTransactionsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := controllertxns.NewClient(apiCaller)
+	_, err := client.StuckTransactions()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestResumeTransactions(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ControllerTxns")
+			c.Check(request, gc.Equals, "ResumeTransactions")
+			c.Check(a, jc.DeepEquals, params.ResumeTransactionsArgs{
+				Ids: []string{"a", "b"},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+	)
+	client := controllertxns.NewClient(apiCaller)
+	err := client.ResumeTransactions("a", "b")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
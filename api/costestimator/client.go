@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package costestimator provides the client side API for the
+// CostEstimator facade, used to estimate the hourly cost of a planned
+// deployment.
+package costestimator
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the CostEstimator API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the CostEstimator API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "CostEstimator")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// EstimateCost returns the estimated hourly cost of the given machines
+// and storage.
+func (c *Client) EstimateCost(args params.CostEstimateArgs) (params.CostEstimateResult, error) {
+	var result params.CostEstimateResult
+	if err := c.facade.FacadeCall("EstimateCost", args, &result); err != nil {
+		return params.CostEstimateResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costestimator_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/costestimator"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestEstimateCost(c *gc.C) {
+	args := params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{
+			Constraints: constraints.MustParse("mem=4G"),
+			Count:       2,
+		}},
+		Storage: []params.CostEstimateStorage{{
+			Pool:  "ebs",
+			Size:  1024,
+			Count: 2,
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "CostEstimator")
+			c.Check(request, gc.Equals, "EstimateCost")
+			c.Check(a, jc.DeepEquals, args)
+			*(result.(*params.CostEstimateResult)) = params.CostEstimateResult{
+				Currency:   "USD",
+				HourlyCost: 0.5,
+			}
+			return nil
+		},
+	)
+	result, err := costestimator.NewClient(apiCaller).EstimateCost(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CostEstimateResult{
+		Currency:   "USD",
+		HourlyCost: 0.5,
+	})
+}
+
+func (s *clientSuite) TestEstimateCostError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return &params.Error{Message: "boom"}
+		},
+	)
+	_, err := costestimator.NewClient(apiCaller).EstimateCost(params.CostEstimateArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
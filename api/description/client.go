@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package description provides the client side API for the Description
+// facade, used to describe a model and its applications.
+package description
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Description API end point.
+type Client struct {
+	base.ClientFacade
+	facade   base.FacadeCaller
+	modelTag names.ModelTag
+}
+
+// NewClient creates a new client for accessing the Description API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Description")
+	modelTag, _ := st.ModelTag()
+	return &Client{ClientFacade: frontend, facade: backend, modelTag: modelTag}
+}
+
+// SetModelDescription sets the description of the model. An empty
+// description removes it.
+func (c *Client) SetModelDescription(description string) error {
+	return errors.Trace(c.setDescription(c.modelTag, description))
+}
+
+// SetApplicationDescription sets the description of the named
+// application. An empty description removes it.
+func (c *Client) SetApplicationDescription(application, description string) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	return errors.Trace(c.setDescription(names.NewApplicationTag(application), description))
+}
+
+func (c *Client) setDescription(tag names.Tag, description string) error {
+	args := params.SetDescriptions{
+		Args: []params.SetDescription{{
+			Tag:         tag.String(),
+			Description: description,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetDescriptions", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ModelDescription returns the description of the model.
+func (c *Client) ModelDescription() (string, error) {
+	return c.description(c.modelTag)
+}
+
+// ApplicationDescription returns the description of the named
+// application.
+func (c *Client) ApplicationDescription(application string) (string, error) {
+	if !names.IsValidApplication(application) {
+		return "", errors.NotValidf("application name %q", application)
+	}
+	return c.description(names.NewApplicationTag(application))
+}
+
+func (c *Client) description(tag names.Tag) (string, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("Descriptions", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/description"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestSetModelDescription(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Description")
+			c.Check(request, gc.Equals, "SetDescriptions")
+			c.Check(a, jc.DeepEquals, params.SetDescriptions{
+				Args: []params.SetDescription{{
+					Tag:         coretesting.ModelTag.String(),
+					Description: "staging",
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	)
+	err := description.NewClient(apiCaller).SetModelDescription("staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestSetApplicationDescription(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(a, jc.DeepEquals, params.SetDescriptions{
+				Args: []params.SetDescription{{
+					Tag:         "application-mysql",
+					Description: "owned by the db team",
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+				}},
+			}
+			return nil
+		},
+	)
+	err := description.NewClient(apiCaller).SetApplicationDescription("mysql", "owned by the db team")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *clientSuite) TestSetApplicationDescriptionInvalidName(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	)
+	err := description.NewClient(apiCaller).SetApplicationDescription("no/good", "")
+	c.Assert(err, gc.ErrorMatches, `application name "no/good" not valid`)
+}
+
+func (s *clientSuite) TestApplicationDescription(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "Descriptions")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{{Result: "owned by the db team"}},
+			}
+			return nil
+		},
+	)
+	desc, err := description.NewClient(apiCaller).ApplicationDescription("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(desc, gc.Equals, "owned by the db team")
+}
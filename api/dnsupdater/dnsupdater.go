@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsupdater provides the client side API for the DNSUpdater
+// facade, used by the worker that maintains DNS records for the
+// addresses of exposed applications.
+package dnsupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const dnsUpdaterFacade = "DNSUpdater"
+
+// ApplicationAddresses holds the public addresses of the alive units
+// of an application, and whether the application is exposed.
+type ApplicationAddresses struct {
+	Name      string
+	Exposed   bool
+	Addresses []string
+}
+
+// API provides access to the DNSUpdater API facade.
+type API struct {
+	*common.ModelWatcher
+
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side DNSUpdater facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, dnsUpdaterFacade)
+	return &API{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// ApplicationAddresses returns the public addresses of the alive units
+// of every application in the model.
+func (api *API) ApplicationAddresses() ([]ApplicationAddresses, error) {
+	var results params.ApplicationAddressesResults
+	if err := api.facade.FacadeCall("ApplicationAddresses", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps := make([]ApplicationAddresses, len(results.Results))
+	for i, result := range results.Results {
+		tag, err := names.ParseApplicationTag(result.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		apps[i] = ApplicationAddresses{
+			Name:      tag.Id(),
+			Exposed:   result.Exposed,
+			Addresses: result.Addresses,
+		}
+	}
+	return apps, nil
+}
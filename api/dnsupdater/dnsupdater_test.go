@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/dnsupdater"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type dnsUpdaterSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&dnsUpdaterSuite{})
+
+func (s *dnsUpdaterSuite) TestApplicationAddresses(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "DNSUpdater")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ApplicationAddresses")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ApplicationAddressesResults{})
+			*(result.(*params.ApplicationAddressesResults)) = params.ApplicationAddressesResults{
+				Results: []params.ApplicationAddresses{{
+					ApplicationTag: "application-wordpress",
+					Exposed:        true,
+					Addresses:      []string{"10.0.0.1"},
+				}},
+			}
+			return nil
+		},
+	)
+	apps, err := dnsupdater.NewAPI(apiCaller).ApplicationAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, jc.DeepEquals, []dnsupdater.ApplicationAddresses{{
+		Name:      "wordpress",
+		Exposed:   true,
+		Addresses: []string{"10.0.0.1"},
+	}})
+}
+
+func (s *dnsUpdaterSuite) TestApplicationAddressesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	_, err := dnsupdater.NewAPI(apiCaller).ApplicationAddresses()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
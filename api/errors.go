@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// TypedError converts an error returned by an API call into the typed
+// error corresponding to its error code, so that programs can branch
+// on the kind of failure rather than matching error messages. For
+// example, an error with the params.CodeNotFound code satisfies
+// errors.IsNotFound, and one with the params.CodeQuotaLimitExceeded
+// code satisfies common.IsQuotaLimitExceededError. The message of the
+// error is kept; errors without a code, or whose code has no typed
+// error, are returned unchanged.
+func TypedError(err error) error {
+	if params.ErrCode(err) == "" {
+		return err
+	}
+	restored := common.RestoreError(err)
+	if _, ok := restored.(*params.Error); ok {
+		return err
+	}
+	return restored
+}
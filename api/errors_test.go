@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type errorsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&errorsSuite{})
+
+func (s *errorsSuite) TestTypedError(c *gc.C) {
+	for i, t := range []struct {
+		code  string
+		check func(error) bool
+	}{
+		{params.CodeNotFound, errors.IsNotFound},
+		{params.CodeModelNotFound, errors.IsNotFound},
+		{params.CodeAlreadyExists, errors.IsAlreadyExists},
+		{params.CodeNotProvisioned, errors.IsNotProvisioned},
+		{params.CodeNotValid, errors.IsNotValid},
+		{params.CodeUnauthorized, errors.IsUnauthorized},
+		{params.CodeQuotaLimitExceeded, common.IsQuotaLimitExceededError},
+		{params.CodeForbidden, common.IsForbiddenError},
+	} {
+		c.Logf("test %d: %s", i, t.code)
+		err := api.TypedError(errors.Annotate(&params.Error{
+			Message: "oops",
+			Code:    t.code,
+		}, "context"))
+		c.Check(err, jc.Satisfies, t.check)
+		c.Check(err, gc.ErrorMatches, "oops")
+	}
+}
+
+func (s *errorsSuite) TestTypedErrorUnknownCode(c *gc.C) {
+	perr := &params.Error{Message: "oops", Code: params.CodeModelFrozen}
+	err := api.TypedError(perr)
+	c.Assert(err, gc.Equals, error(perr))
+	c.Assert(err, jc.Satisfies, params.IsCodeModelFrozen)
+}
+
+func (s *errorsSuite) TestTypedErrorNoCode(c *gc.C) {
+	err := errors.Annotate(errors.New("oops"), "context")
+	c.Assert(api.TypedError(err), gc.Equals, err)
+	c.Assert(api.TypedError(nil), jc.ErrorIsNil)
+}
@@ -13,30 +13,38 @@ package api
 var facadeVersions = map[string]int{
 	"Action":                       2,
 	"ActionPruner":                 1,
+	"ActionSchedules":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  5,
+	"Application":                  13,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       2,
+	"ChangeLog":                    1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       5,
 	"Cloud":                        2,
-	"Controller":                   4,
+	"Controller":                   5,
+	"ControllerHealth":             1,
+	"ControllerTxns":               1,
+	"CostEstimator":                1,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
+	"Description":                  1,
 	"DiskManager":                  2,
+	"DNSUpdater":                   1,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   4,
+	"Firewaller":                   6,
 	"FirewallRules":                1,
+	"FloatingIPUpdater":            1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -50,7 +58,8 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               4,
+	"MachineManager":               7,
+	"MachineNetworking":            1,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -62,14 +71,16 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelConfig":                  1,
-	"ModelManager":                 4,
+	"ModelConfig":                  2,
+	"ModelFreeze":                  1,
+	"ModelManager":                 6,
+	"ModelSuspension":              1,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  5,
+	"Provisioner":                  6,
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
@@ -79,17 +90,20 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"SecurityGroupGC":              1,
 	"Singular":                     1,
 	"Spaces":                       3,
-	"SSHClient":                    2,
+	"SSHClient":                    3,
 	"StatusHistory":                2,
-	"Storage":                      4,
-	"StorageProvisioner":           4,
+	"Storage":                      7,
+	"StorageProvisioner":           5,
+	"StorageUsage":                 1,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
+	"TagSync":                      1,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       7,
+	"Uniter":                       14,
 	"Upgrader":                     1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
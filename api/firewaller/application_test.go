@@ -76,6 +76,22 @@ func (s *applicationSuite) TestRefresh(c *gc.C) {
 	c.Assert(s.apiApplication.Life(), gc.Equals, params.Dying)
 }
 
+func (s *applicationSuite) TestExposedCIDRs(c *gc.C) {
+	err := s.application.SetExposedToCIDRs([]string{"10.0.0.0/24", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiApplication.ExposedCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.ExposedCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
 func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	err := s.application.SetExposed()
 	c.Assert(err, jc.ErrorIsNil)
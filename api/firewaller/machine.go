@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -49,6 +50,32 @@ func (m *Machine) WatchUnits() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchContainers starts a StringsWatcher to watch the containers of
+// the machine. It returns a NotSupported error if the controller does
+// not support watching containers.
+func (m *Machine) WatchContainers() (watcher.StringsWatcher, error) {
+	if m.st.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("watching containers")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("WatchContainers", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(m.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // InstanceId returns the provider specific instance id for this
 // machine, or a CodeNotProvisioned error, if not set.
 func (m *Machine) InstanceId() (instance.Id, error) {
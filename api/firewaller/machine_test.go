@@ -94,6 +94,26 @@ func (s *machineSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *machineSuite) TestWatchContainers(c *gc.C) {
+	w, err := s.apiMachine.WatchContainers()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Add a container and check it's detected.
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machines[0].Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(container.Id())
+	wc.AssertNoChange()
+}
+
 func (s *machineSuite) TestActiveSubnets(c *gc.C) {
 	// No ports opened at first, no active subnets.
 	subnets, err := s.apiMachine.ActiveSubnets()
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package floatingipupdater provides the client side API for the
+// FloatingIPUpdater facade, used by the worker that associates the
+// floating IP addresses of exposed applications with the machines of
+// their leaders.
+package floatingipupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+)
+
+const floatingIPUpdaterFacade = "FloatingIPUpdater"
+
+// FloatingIP holds the floating IP address of an application, the
+// unit whose machine it is associated with, and the application's
+// leader and the instance of its machine. The leader and its instance
+// are empty if unknown.
+type FloatingIP struct {
+	Application      string
+	Address          string
+	Unit             string
+	Leader           string
+	LeaderInstanceId instance.Id
+}
+
+// API provides access to the FloatingIPUpdater API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side FloatingIPUpdater facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{facade: base.NewFacadeCaller(caller, floatingIPUpdaterFacade)}
+}
+
+// FloatingIPs returns the floating IP addresses of the alive, exposed
+// applications in the model, together with their leaders.
+func (api *API) FloatingIPs() ([]FloatingIP, error) {
+	var results params.FloatingIPResults
+	if err := api.facade.FacadeCall("FloatingIPs", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	fips := make([]FloatingIP, len(results.Results))
+	for i, result := range results.Results {
+		appTag, err := names.ParseApplicationTag(result.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fips[i] = FloatingIP{
+			Application:      appTag.Id(),
+			Address:          result.Address,
+			LeaderInstanceId: instance.Id(result.LeaderInstanceId),
+		}
+		if fips[i].Unit, err = unitName(result.UnitTag); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fips[i].Leader, err = unitName(result.LeaderTag); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return fips, nil
+}
+
+func unitName(tag string) (string, error) {
+	if tag == "" {
+		return "", nil
+	}
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return "", err
+	}
+	return unitTag.Id(), nil
+}
+
+// SetFloatingIPUnit records that the given floating IP address of the
+// named application has been associated with the machine of the named
+// unit.
+func (api *API) SetFloatingIPUnit(application, address, unitName string) error {
+	args := params.SetFloatingIPUnitArgs{
+		Args: []params.SetFloatingIPUnitArg{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Address:        address,
+			UnitTag:        names.NewUnitTag(unitName).String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := api.facade.FacadeCall("SetFloatingIPUnits", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package floatingipupdater_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/floatingipupdater"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type floatingIPUpdaterSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&floatingIPUpdaterSuite{})
+
+func (s *floatingIPUpdaterSuite) TestFloatingIPs(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FloatingIPUpdater")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "FloatingIPs")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.FloatingIPResults{})
+			*(result.(*params.FloatingIPResults)) = params.FloatingIPResults{
+				Results: []params.FloatingIP{{
+					ApplicationTag:   "application-postgresql",
+					Address:          "203.0.113.10",
+					UnitTag:          "unit-postgresql-0",
+					LeaderTag:        "unit-postgresql-1",
+					LeaderInstanceId: "i-1",
+				}, {
+					ApplicationTag: "application-mysql",
+					Address:        "203.0.113.11",
+				}},
+			}
+			return nil
+		},
+	)
+	fips, err := floatingipupdater.NewAPI(apiCaller).FloatingIPs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fips, jc.DeepEquals, []floatingipupdater.FloatingIP{{
+		Application:      "postgresql",
+		Address:          "203.0.113.10",
+		Unit:             "postgresql/0",
+		Leader:           "postgresql/1",
+		LeaderInstanceId: "i-1",
+	}, {
+		Application: "mysql",
+		Address:     "203.0.113.11",
+	}})
+}
+
+func (s *floatingIPUpdaterSuite) TestFloatingIPsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	_, err := floatingipupdater.NewAPI(apiCaller).FloatingIPs()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *floatingIPUpdaterSuite) TestSetFloatingIPUnit(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FloatingIPUpdater")
+			c.Check(request, gc.Equals, "SetFloatingIPUnits")
+			c.Check(a, jc.DeepEquals, params.SetFloatingIPUnitArgs{
+				Args: []params.SetFloatingIPUnitArg{{
+					ApplicationTag: "application-postgresql",
+					Address:        "203.0.113.10",
+					UnitTag:        "unit-postgresql-1",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "floating IP has changed"},
+				}},
+			}
+			return nil
+		},
+	)
+	err := floatingipupdater.NewAPI(apiCaller).SetFloatingIPUnit("postgresql", "203.0.113.10", "postgresql/1")
+	c.Assert(err, gc.ErrorMatches, "floating IP has changed")
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"io"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// MachineConsole returns the URL of a console of the given type, for
+// example "novnc", for the machine's instance, as provided by the
+// cloud. Console URLs typically contain a short-lived access token.
+func (client *Client) MachineConsole(machineId, consoleType string) (string, error) {
+	if client.BestAPIVersion() < 5 {
+		return "", errors.NotSupportedf("instance consoles")
+	}
+	if !names.IsValidMachine(machineId) {
+		return "", errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.MachineConsoleArgs{
+		Args: []params.MachineConsoleArg{{
+			Entity: params.Entity{Tag: names.NewMachineTag(machineId).String()},
+			Type:   consoleType,
+		}},
+	}
+	var results params.MachineConsoleResults
+	if err := client.facade.FacadeCall("MachineConsoles", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.URL, nil
+}
+
+// ConnectSerialConsole connects to the serial console of the machine's
+// instance. The console is proxied through the controller, which closes
+// the connection once the session expires.
+func ConnectSerialConsole(connector base.StreamConnector, machineId string) (io.ReadWriteCloser, error) {
+	if !names.IsValidMachine(machineId) {
+		return nil, errors.NotValidf("machine ID %q", machineId)
+	}
+	stream, err := connector.ConnectStream("/console", url.Values{"machine": {machineId}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &consoleStream{stream: stream}, nil
+}
+
+// consoleStream adapts a console stream, which carries data as
+// params.ConsoleMessage values, to an io.ReadWriteCloser.
+type consoleStream struct {
+	stream base.Stream
+	buf    []byte
+}
+
+// Read is part of the io.Reader interface.
+func (s *consoleStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		var m params.ConsoleMessage
+		if err := s.stream.ReadJSON(&m); err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return 0, io.EOF
+			}
+			return 0, errors.Trace(err)
+		}
+		s.buf = m.Data
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// Write is part of the io.Writer interface.
+func (s *consoleStream) Write(p []byte) (int, error) {
+	if err := s.stream.WriteJSON(params.ConsoleMessage{Data: p}); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(p), nil
+}
+
+// Close is part of the io.Closer interface.
+func (s *consoleStream) Close() error {
+	return s.stream.Close()
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConsoleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ConsoleSuite{})
+
+func (s *ConsoleSuite) TestMachineConsole(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "MachineConsoles")
+			c.Assert(a, jc.DeepEquals, params.MachineConsoleArgs{
+				Args: []params.MachineConsoleArg{{
+					Entity: params.Entity{Tag: "machine-1"},
+					Type:   "novnc",
+				}},
+			})
+			out := response.(*params.MachineConsoleResults)
+			*out = params.MachineConsoleResults{
+				Results: []params.MachineConsoleResult{{
+					Type: "novnc",
+					URL:  "https://console.example.com/?token=abc",
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	consoleURL, err := client.MachineConsole("1", "novnc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(consoleURL, gc.Equals, "https://console.example.com/?token=abc")
+}
+
+func (s *ConsoleSuite) TestMachineConsoleNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.MachineConsole("1", "novnc")
+	c.Assert(err, gc.ErrorMatches, "instance consoles not supported")
+}
+
+func (s *ConsoleSuite) TestConnectSerialConsole(c *gc.C) {
+	stream := &fakeConsoleStream{
+		messages: []params.ConsoleMessage{
+			{Data: []byte("login: ")},
+			{Data: []byte("ubuntu\r\n")},
+		},
+	}
+	connector := &fakeConsoleConnector{c: c, stream: stream}
+	console, err := machinemanager.ConnectSerialConsole(connector, "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = io.WriteString(console, "ubuntu\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.written, jc.DeepEquals, []interface{}{
+		params.ConsoleMessage{Data: []byte("ubuntu\n")},
+	})
+
+	data, err := ioutil.ReadAll(console)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "login: ubuntu\r\n")
+
+	err = console.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *ConsoleSuite) TestConnectSerialConsoleInvalidMachine(c *gc.C) {
+	_, err := machinemanager.ConnectSerialConsole(&fakeConsoleConnector{c: c}, "foo")
+	c.Assert(err, gc.ErrorMatches, `machine ID "foo" not valid`)
+}
+
+type fakeConsoleConnector struct {
+	c      *gc.C
+	stream *fakeConsoleStream
+}
+
+func (f *fakeConsoleConnector) ConnectStream(path string, values url.Values) (base.Stream, error) {
+	f.c.Assert(path, gc.Equals, "/console")
+	f.c.Assert(values, jc.DeepEquals, url.Values{"machine": {"0/lxd/1"}})
+	return f.stream, nil
+}
+
+type fakeConsoleStream struct {
+	base.Stream
+	messages []params.ConsoleMessage
+	written  []interface{}
+	closed   bool
+}
+
+func (f *fakeConsoleStream) ReadJSON(v interface{}) error {
+	if len(f.messages) == 0 {
+		return &websocket.CloseError{Code: websocket.CloseNormalClosure}
+	}
+	*(v.(*params.ConsoleMessage)) = f.messages[0]
+	f.messages = f.messages[1:]
+	return nil
+}
+
+func (f *fakeConsoleStream) WriteJSON(v interface{}) error {
+	f.written = append(f.written, v)
+	return nil
+}
+
+func (f *fakeConsoleStream) Close() error {
+	f.closed = true
+	return nil
+}
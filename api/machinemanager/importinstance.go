@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CloudInstance returns details of the cloud instance with the given
+// provider id, so that it may be imported into the model as a
+// machine. An error satisfying errors.IsAlreadyExists is returned if
+// the instance already backs a machine in the model.
+func (client *Client) CloudInstance(instanceId string) (params.CloudInstanceResult, error) {
+	if client.BestAPIVersion() < 5 {
+		return params.CloudInstanceResult{}, errors.NotSupportedf("importing instances")
+	}
+	args := params.CloudInstanceArgs{InstanceIds: []string{instanceId}}
+	var results params.CloudInstanceResults
+	if err := client.facade.FacadeCall("CloudInstances", args, &results); err != nil {
+		return params.CloudInstanceResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.CloudInstanceResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.CloudInstanceResult{}, result.Error
+	}
+	return result, nil
+}
+
+// TagMachineInstance tags the cloud instance of the given machine as
+// being managed by Juju.
+func (client *Client) TagMachineInstance(machineId string) error {
+	if client.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("tagging instances")
+	}
+	if !names.IsValidMachine(machineId) {
+		return errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("TagMachineInstances", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
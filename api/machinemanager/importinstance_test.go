@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ImportInstanceSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ImportInstanceSuite{})
+
+func (s *ImportInstanceSuite) TestCloudInstance(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "CloudInstances")
+			c.Assert(a, jc.DeepEquals, params.CloudInstanceArgs{InstanceIds: []string{"legacy-0"}})
+			c.Assert(response, gc.FitsTypeOf, &params.CloudInstanceResults{})
+			out := response.(*params.CloudInstanceResults)
+			*out = params.CloudInstanceResults{
+				Results: []params.CloudInstanceResult{{
+					InstanceId: "legacy-0",
+					Addresses:  []params.Address{{Value: "10.0.0.5", Type: "ipv4"}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	result, err := client.CloudInstance("legacy-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudInstanceResult{
+		InstanceId: "legacy-0",
+		Addresses:  []params.Address{{Value: "10.0.0.5", Type: "ipv4"}},
+	})
+}
+
+func (s *ImportInstanceSuite) TestCloudInstanceError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			out := response.(*params.CloudInstanceResults)
+			*out = params.CloudInstanceResults{
+				Results: []params.CloudInstanceResult{{
+					Error: &params.Error{Message: "boom", Code: params.CodeAlreadyExists},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.CloudInstance("legacy-0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(err, jc.Satisfies, params.IsCodeAlreadyExists)
+}
+
+func (s *ImportInstanceSuite) TestCloudInstanceNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.CloudInstance("legacy-0")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ImportInstanceSuite) TestTagMachineInstance(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "TagMachineInstances")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-3"}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	err := client.TagMachineInstance("3")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportInstanceSuite) TestTagMachineInstanceInvalidMachine(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{BestVersion: 5})
+	err := client.TagMachineInstance("foo")
+	c.Assert(err, gc.ErrorMatches, `machine ID "foo" not valid`)
+}
@@ -27,6 +27,13 @@ func NewClient(st base.APICallCloser) *Client {
 
 // AddMachines adds new machines with the supplied parameters, creating any requested disks.
 func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	if client.BestAPIVersion() < 7 {
+		for _, p := range machineParams {
+			if p.ImageStream != "" || p.ImageId != "" {
+				return nil, errors.NotSupportedf("adding machines with an image stream or image id")
+			}
+		}
+	}
 	args := params.AddMachines{
 		MachineParams: machineParams,
 	}
@@ -135,3 +142,46 @@ func (client *Client) UpdateMachineSeries(machineName, series string, force bool
 	}
 	return results.OneError()
 }
+
+// ConsoleLog returns the console output of the machine's instance, as
+// reported by the cloud provider. If maxLines is greater than zero, at
+// most that many lines are returned, taken from the end of the output.
+func (client *Client) ConsoleLog(machineId string, maxLines int) (string, error) {
+	if client.BestAPIVersion() < 5 {
+		return "", errors.NotSupportedf("retrieving console output")
+	}
+	if !names.IsValidMachine(machineId) {
+		return "", errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.ConsoleLogArgs{
+		Args: []params.ConsoleLogArg{{
+			Entity:   params.Entity{Tag: names.NewMachineTag(machineId).String()},
+			MaxLines: maxLines,
+		}},
+	}
+	var results params.ConsoleLogResults
+	if err := client.facade.FacadeCall("ConsoleLogs", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Output, nil
+}
+
+// PendingRemovals returns the dying and dead machines in the model,
+// along with what the removal of each is waiting on.
+func (client *Client) PendingRemovals() ([]params.PendingRemoval, error) {
+	if client.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("listing machines pending removal")
+	}
+	var results params.PendingRemovalResults
+	if err := client.facade.FacadeCall("PendingRemovals", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
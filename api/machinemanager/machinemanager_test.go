@@ -13,6 +13,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -117,6 +118,42 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 	}
 }
 
+func (s *MachinemanagerSuite) TestAddMachinesWithImage(c *gc.C) {
+	machines := []params.AddMachineParams{{
+		Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		ImageStream: "daily",
+		ImageId:     "ami-0123",
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "AddMachines")
+			c.Check(a, jc.DeepEquals, params.AddMachines{MachineParams: machines})
+			*(response.(*params.AddMachinesResults)) = params.AddMachinesResults{
+				Machines: []params.AddMachinesResult{{Machine: "0"}},
+			}
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	results, err := client.AddMachines(machines)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.AddMachinesResult{{Machine: "0"}})
+}
+
+func (s *MachinemanagerSuite) TestAddMachinesWithImageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.AddMachines([]params.AddMachineParams{{ImageStream: "daily"}})
+	c.Assert(err, gc.ErrorMatches, "adding machines with an image stream or image id not supported")
+}
+
 func (s *MachinemanagerSuite) TestDestroyMachines(c *gc.C) {
 	s.testDestroyMachines(c, "DestroyMachine", (*machinemanager.Client).DestroyMachines)
 }
@@ -210,3 +247,99 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestConsoleLog(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "ConsoleLogs")
+			c.Assert(a, jc.DeepEquals, params.ConsoleLogArgs{
+				Args: []params.ConsoleLogArg{{
+					Entity:   params.Entity{Tag: "machine-0-lxd-1"},
+					MaxLines: 20,
+				}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ConsoleLogResults{})
+			out := response.(*params.ConsoleLogResults)
+			*out = params.ConsoleLogResults{
+				Results: []params.ConsoleLogResult{{Output: "cloud-init finished"}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	output, err := client.ConsoleLog("0/lxd/1", 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, "cloud-init finished")
+}
+
+func (s *MachinemanagerSuite) TestConsoleLogError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			out := response.(*params.ConsoleLogResults)
+			*out = params.ConsoleLogResults{
+				Results: []params.ConsoleLogResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.ConsoleLog("0", 0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestConsoleLogNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.ConsoleLog("0", 0)
+	c.Assert(err, gc.ErrorMatches, "retrieving console output not supported")
+}
+
+func (s *MachinemanagerSuite) TestPendingRemovals(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "PendingRemovals")
+			c.Check(a, gc.IsNil)
+			c.Assert(response, gc.FitsTypeOf, &params.PendingRemovalResults{})
+			out := response.(*params.PendingRemovalResults)
+			*out = params.PendingRemovalResults{
+				Results: []params.PendingRemoval{{
+					MachineTag:      "machine-1",
+					Life:            params.Dead,
+					InstanceMessage: "stopping instance failed: boom",
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	results, err := client.PendingRemovals()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.PendingRemoval{{
+		MachineTag:      "machine-1",
+		Life:            params.Dead,
+		InstanceMessage: "stopping instance failed: boom",
+	}})
+}
+
+func (s *MachinemanagerSuite) TestPendingRemovalsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	_, err := client.PendingRemovals()
+	c.Assert(err, gc.ErrorMatches, "listing machines pending removal not supported")
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinenetworking provides the client side API for the
+// MachineNetworking facade, used to query the network devices of
+// machines.
+package machinenetworking
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the MachineNetworking API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the MachineNetworking
+// API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "MachineNetworking")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// LinkLayerDevices returns the link-layer network devices of the given
+// machines, with the IP addresses assigned to each, in the order of the
+// machine IDs given.
+func (c *Client) LinkLayerDevices(machineIds ...string) ([]params.MachineLinkLayerDevicesResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machineIds)),
+	}
+	for i, id := range machineIds {
+		if !names.IsValidMachine(id) {
+			return nil, errors.NotValidf("machine ID %q", id)
+		}
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	var results params.MachineLinkLayerDevicesResults
+	if err := c.facade.FacadeCall("LinkLayerDevices", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(machineIds) {
+		return nil, errors.Errorf("expected %d results, got %d", len(machineIds), len(results.Results))
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinenetworking_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/machinenetworking"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestLinkLayerDevices(c *gc.C) {
+	devices := []params.LinkLayerDeviceInfo{{
+		Name: "eth0",
+		Type: "ethernet",
+		Addresses: []params.LinkLayerDeviceAddressInfo{{
+			Value:        "10.0.0.4",
+			ConfigMethod: "static",
+		}},
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "MachineNetworking")
+			c.Check(request, gc.Equals, "LinkLayerDevices")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-0-lxd-1"}},
+			})
+			*(result.(*params.MachineLinkLayerDevicesResults)) = params.MachineLinkLayerDevicesResults{
+				Results: []params.MachineLinkLayerDevicesResult{
+					{Devices: devices},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		},
+	)
+	results, err := machinenetworking.NewClient(apiCaller).LinkLayerDevices("0", "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.MachineLinkLayerDevicesResult{
+		{Devices: devices},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *clientSuite) TestLinkLayerDevicesInvalidMachine(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	)
+	_, err := machinenetworking.NewClient(apiCaller).LinkLayerDevices("foo")
+	c.Assert(err, gc.ErrorMatches, `machine ID "foo" not valid`)
+}
+
+func (s *clientSuite) TestLinkLayerDevicesResultCount(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return nil
+		},
+	)
+	_, err := machinenetworking.NewClient(apiCaller).LinkLayerDevices("0")
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
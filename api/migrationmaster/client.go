@@ -141,12 +141,21 @@ func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	if err != nil {
 		return migration.ModelInfo{}, errors.Trace(err)
 	}
+	var credTag names.CloudCredentialTag
+	if info.CloudCredentialTag != "" {
+		credTag, err = names.ParseCloudCredentialTag(info.CloudCredentialTag)
+		if err != nil {
+			return migration.ModelInfo{}, errors.Trace(err)
+		}
+	}
 	return migration.ModelInfo{
 		UUID:                   info.UUID,
 		Name:                   info.Name,
 		Owner:                  owner,
 		AgentVersion:           info.AgentVersion,
 		ControllerAgentVersion: info.ControllerAgentVersion,
+		CloudCredential:        credTag,
+		StorageProviders:       info.StorageProviders,
 	}, nil
 }
 
@@ -182,11 +191,24 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 		return empty, errors.Trace(err)
 	}
 
+	var gitSources map[string]migration.CharmGitSource
+	for _, source := range serialized.CharmGitSources {
+		if gitSources == nil {
+			gitSources = make(map[string]migration.CharmGitSource)
+		}
+		gitSources[source.Charm] = migration.CharmGitSource{
+			Repository: source.Repository,
+			Ref:        source.Ref,
+			Revision:   source.Revision,
+		}
+	}
+
 	return migration.SerializedModel{
-		Bytes:     serialized.Bytes,
-		Charms:    serialized.Charms,
-		Tools:     tools,
-		Resources: resources,
+		Bytes:           serialized.Bytes,
+		Charms:          serialized.Charms,
+		Tools:           tools,
+		Resources:       resources,
+		CharmGitSources: gitSources,
 	}, nil
 }
 
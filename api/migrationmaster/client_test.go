@@ -178,6 +178,8 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 			OwnerTag:               owner.String(),
 			AgentVersion:           version.MustParse("1.2.3"),
 			ControllerAgentVersion: version.MustParse("1.2.4"),
+			CloudCredentialTag:     "cloudcred-cloud_owner_cred",
+			StorageProviders:       []string{"ebs"},
 		}
 		return nil
 	})
@@ -193,6 +195,8 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 		Owner:                  owner,
 		AgentVersion:           version.MustParse("1.2.3"),
 		ControllerAgentVersion: version.MustParse("1.2.4"),
+		CloudCredential:        names.NewCloudCredentialTag("cloud/owner/cred"),
+		StorageProviders:       []string{"ebs"},
 	})
 }
 
@@ -340,6 +344,33 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestExportCharmGitSources(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		out := result.(*params.SerializedModel)
+		*out = params.SerializedModel{
+			Bytes:  []byte("foo"),
+			Charms: []string{"cs:foo-1", "local:xenial/bar-2"},
+			CharmGitSources: []params.SerializedModelCharmGitSource{{
+				Charm:      "local:xenial/bar-2",
+				Repository: "git+ssh://git@example.com/bar.git",
+				Ref:        "stable",
+				Revision:   "0123456789abcdef",
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	out, err := client.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.CharmGitSources, jc.DeepEquals, map[string]migration.CharmGitSource{
+		"local:xenial/bar-2": {
+			Repository: "git+ssh://git@example.com/bar.git",
+			Ref:        "stable",
+			Revision:   "0123456789abcdef",
+		},
+	})
+}
+
 func (s *ClientSuite) TestExportError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("blam")
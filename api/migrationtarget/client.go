@@ -50,6 +50,10 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
+		StorageProviders:       model.StorageProviders,
+	}
+	if model.CloudCredential != (names.CloudCredentialTag{}) {
+		args.CloudCredentialTag = model.CloudCredential.String()
 	}
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
@@ -74,13 +78,19 @@ func (c *Client) Activate(modelUUID string) error {
 }
 
 // UploadCharm sends the content to the API server using an HTTP post in order
-// to add the charm binary to the model specified.
-func (c *Client) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+// to add the charm binary to the model specified. If gitSource is not nil,
+// it is recorded as the git repository and commit the charm was built from.
+func (c *Client) UploadCharm(modelUUID string, curl *charm.URL, gitSource *coremigration.CharmGitSource, content io.ReadSeeker) (*charm.URL, error) {
 	args := url.Values{}
 	args.Add("schema", curl.Schema)
 	args.Add("user", curl.User)
 	args.Add("series", curl.Series)
 	args.Add("revision", strconv.Itoa(curl.Revision))
+	if gitSource != nil {
+		args.Add("git-repository", gitSource.Repository)
+		args.Add("git-ref", gitSource.Ref)
+		args.Add("git-revision", gitSource.Revision)
+	}
 	apiURI := url.URL{Path: "/migrate/charms", RawQuery: args.Encode()}
 
 	contentType := "application/zip"
@@ -62,6 +62,8 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 		Name:                   "name",
 		AgentVersion:           vers,
 		ControllerAgentVersion: controllerVers,
+		CloudCredential:        names.NewCloudCredentialTag("cloud/owner/cred"),
+		StorageProviders:       []string{"ebs"},
 	})
 	c.Assert(err, gc.ErrorMatches, "boom")
 
@@ -71,6 +73,8 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 		OwnerTag:               ownerTag.String(),
 		AgentVersion:           vers,
 		ControllerAgentVersion: controllerVers,
+		CloudCredentialTag:     "cloudcred-cloud_owner_cred",
+		StorageProviders:       []string{"ebs"},
 	}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Prechecks", []interface{}{"", expectedArg}},
@@ -184,7 +188,7 @@ func (s *ClientSuite) TestUploadCharm(c *gc.C) {
 		httpClient: &httprequest.Client{Doer: doer},
 	}
 	client := migrationtarget.NewClient(caller)
-	outCurl, err := client.UploadCharm("uuid", curl, strings.NewReader(charmBody))
+	outCurl, err := client.UploadCharm("uuid", curl, nil, strings.NewReader(charmBody))
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(outCurl, gc.DeepEquals, curl)
 	c.Assert(doer.method, gc.Equals, "POST")
@@ -192,6 +196,27 @@ func (s *ClientSuite) TestUploadCharm(c *gc.C) {
 	c.Assert(doer.body, gc.Equals, charmBody)
 }
 
+func (s *ClientSuite) TestUploadCharmGitSource(c *gc.C) {
+	const charmBody = "charming"
+	curl := charm.MustParseURL("local:xenial/foo-2")
+	doer := newFakeDoer(c, params.CharmsResponse{
+		CharmURL: curl.String(),
+	})
+	caller := &fakeHTTPCaller{
+		httpClient: &httprequest.Client{Doer: doer},
+	}
+	client := migrationtarget.NewClient(caller)
+	_, err := client.UploadCharm("uuid", curl, &coremigration.CharmGitSource{
+		Repository: "git+ssh://git@example.com/foo.git",
+		Ref:        "stable",
+		Revision:   "0123456789abcdef",
+	}, strings.NewReader(charmBody))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doer.url, gc.Equals, "/migrate/charms?"+
+		"git-ref=stable&git-repository=git%2Bssh%3A%2F%2Fgit%40example.com%2Ffoo.git"+
+		"&git-revision=0123456789abcdef&revision=2&schema=local&series=xenial&user=")
+}
+
 func (s *ClientSuite) TestUploadTools(c *gc.C) {
 	const toolsBody = "toolie"
 	vers := version.MustParseBinary("2.0.0-xenial-amd64")
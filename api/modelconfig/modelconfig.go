@@ -90,3 +90,42 @@ func (c *Client) SLALevel() (string, error) {
 	}
 	return result.Result, nil
 }
+
+// ModelConfigHistory returns the recorded changes to the model's
+// config, oldest first.
+func (c *Client) ModelConfigHistory() ([]params.ModelConfigRevision, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("model config history")
+	}
+	var result params.ModelConfigHistoryResult
+	err := c.facade.FacadeCall("ModelConfigHistory", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Revisions, nil
+}
+
+// ModelConfigDiff returns the differences between the model's config
+// at the given revision and its current config.
+func (c *Client) ModelConfigDiff(revision int) ([]params.ModelConfigChange, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("model config history")
+	}
+	var result params.ModelConfigChangesResult
+	args := params.ModelConfigRevisionArg{Revision: revision}
+	err := c.facade.FacadeCall("ModelConfigDiff", args, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Changes, nil
+}
+
+// ModelConfigRollback restores the model's config to how it was at
+// the given revision.
+func (c *Client) ModelConfigRollback(revision int) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("model config rollback")
+	}
+	args := params.ModelConfigRevisionArg{Revision: revision}
+	return c.facade.FacadeCall("ModelConfigRollback", args, nil)
+}
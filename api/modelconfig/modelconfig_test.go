@@ -180,3 +180,82 @@ func (s *modelconfigSuite) TestGetSupport(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(level, gc.Equals, "level")
 }
+
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(request, gc.Equals, "ModelConfigHistory")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ModelConfigHistoryResult{})
+			result.(*params.ModelConfigHistoryResult).Revisions = []params.ModelConfigRevision{{
+				Revision: 1,
+				User:     "bob",
+				Changes:  []params.ModelConfigChange{{Type: "added", Key: "foo", NewValue: "bar"}},
+			}}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	revisions, err := client.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, jc.DeepEquals, []params.ModelConfigRevision{{
+		Revision: 1,
+		User:     "bob",
+		Changes:  []params.ModelConfigChange{{Type: "added", Key: "foo", NewValue: "bar"}},
+	}})
+}
+
+func (s *modelconfigSuite) TestModelConfigDiff(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "ModelConfigDiff")
+			c.Check(a, jc.DeepEquals, params.ModelConfigRevisionArg{Revision: 3})
+			c.Assert(result, gc.FitsTypeOf, &params.ModelConfigChangesResult{})
+			result.(*params.ModelConfigChangesResult).Changes = []params.ModelConfigChange{
+				{Type: "deleted", Key: "foo", OldValue: "bar"},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	changes, err := client.ModelConfigDiff(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []params.ModelConfigChange{
+		{Type: "deleted", Key: "foo", OldValue: "bar"},
+	})
+}
+
+func (s *modelconfigSuite) TestModelConfigRollback(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "ModelConfigRollback")
+			c.Check(a, jc.DeepEquals, params.ModelConfigRevisionArg{Revision: 2})
+			called = true
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.ModelConfigRollback(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigSuite) TestModelConfigHistoryNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelConfigHistory()
+	c.Assert(err, gc.ErrorMatches, "model config history not supported")
+	err = client.ModelConfigRollback(1)
+	c.Assert(err, gc.ErrorMatches, "model config rollback not supported")
+}
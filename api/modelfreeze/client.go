@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelfreeze provides the client side API for the ModelFreeze
+// facade, used to make a model read-only for users.
+package modelfreeze
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Freeze describes the read-only freeze of a model.
+type Freeze struct {
+	// Frozen is true if the model is frozen.
+	Frozen bool
+
+	// Message is the message the model was frozen with.
+	Message string
+
+	// By is the name of the user that froze the model.
+	By string
+
+	// Since is when the model was frozen.
+	Since time.Time
+}
+
+// Client allows access to the ModelFreeze API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelFreeze API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelFreeze")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Freeze makes the model read-only for users other than controller
+// superusers. The message is shown to users whose changes are
+// rejected.
+func (c *Client) Freeze(message string) error {
+	args := params.ModelFreezeParams{Message: message}
+	return errors.Trace(c.facade.FacadeCall("Freeze", args, nil))
+}
+
+// Unfreeze removes the read-only freeze of the model.
+func (c *Client) Unfreeze() error {
+	return errors.Trace(c.facade.FacadeCall("Unfreeze", nil, nil))
+}
+
+// FreezeStatus returns the read-only freeze of the model.
+func (c *Client) FreezeStatus() (Freeze, error) {
+	var result params.ModelFreezeResult
+	if err := c.facade.FacadeCall("FreezeStatus", nil, &result); err != nil {
+		return Freeze{}, errors.Trace(err)
+	}
+	if !result.Frozen {
+		return Freeze{}, nil
+	}
+	freeze := Freeze{
+		Frozen:  true,
+		Message: result.Message,
+	}
+	if result.By != "" {
+		tag, err := names.ParseUserTag(result.By)
+		if err != nil {
+			return Freeze{}, errors.Trace(err)
+		}
+		freeze.By = tag.Id()
+	}
+	if result.Since != nil {
+		freeze.Since = *result.Since
+	}
+	return freeze, nil
+}
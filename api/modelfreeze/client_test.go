@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelfreeze_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelfreeze"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestFreeze(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "ModelFreeze")
+			c.Check(request, gc.Equals, "Freeze")
+			c.Check(a, jc.DeepEquals, params.ModelFreezeParams{Message: "incident 42"})
+			return nil
+		},
+	)
+	err := modelfreeze.NewClient(apiCaller).Freeze("incident 42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestUnfreeze(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "Unfreeze")
+			return &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}
+		},
+	)
+	err := modelfreeze.NewClient(apiCaller).Unfreeze()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *clientSuite) TestFreezeStatus(c *gc.C) {
+	since := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "FreezeStatus")
+			*(result.(*params.ModelFreezeResult)) = params.ModelFreezeResult{
+				Frozen:  true,
+				Message: "incident 42",
+				By:      "user-bob",
+				Since:   &since,
+			}
+			return nil
+		},
+	)
+	freeze, err := modelfreeze.NewClient(apiCaller).FreezeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(freeze, jc.DeepEquals, modelfreeze.Freeze{
+		Frozen:  true,
+		Message: "incident 42",
+		By:      "bob",
+		Since:   since,
+	})
+}
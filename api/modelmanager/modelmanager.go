@@ -4,6 +4,8 @@
 package modelmanager
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -44,6 +46,51 @@ func (c *Client) CreateModel(
 	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
+) (base.ModelInfo, error) {
+	return c.createModel("", name, owner, cloud, cloudRegion, cloudCredential, config, 0, nil)
+}
+
+// CreateModelFromTemplate creates a new model like CreateModel, taking
+// the cloud region, credential, config and constraints that are not
+// specified in the args from the named controller model template.
+func (c *Client) CreateModelFromTemplate(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+) (base.ModelInfo, error) {
+	if c.BestAPIVersion() < 5 {
+		return base.ModelInfo{}, errors.NotSupportedf("model templates on this controller")
+	}
+	return c.createModel(template, name, owner, cloud, cloudRegion, cloudCredential, config, 0, nil)
+}
+
+// CreateExpiringModel creates a new model like CreateModelFromTemplate,
+// which the controller destroys once expiresIn has passed. If template
+// is empty, no model template is used. If destroyStorage is nil, a
+// model with persistent storage is not destroyed when it expires;
+// otherwise the storage is destroyed or released as indicated.
+func (c *Client) CreateExpiringModel(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+	expiresIn time.Duration,
+	destroyStorage *bool,
+) (base.ModelInfo, error) {
+	if c.BestAPIVersion() < 5 {
+		return base.ModelInfo{}, errors.NotSupportedf("model expiry on this controller")
+	}
+	if expiresIn <= 0 {
+		return base.ModelInfo{}, errors.NotValidf("model expiry %v", expiresIn)
+	}
+	return c.createModel(template, name, owner, cloud, cloudRegion, cloudCredential, config, expiresIn, destroyStorage)
+}
+
+func (c *Client) createModel(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+	expiresIn time.Duration,
+	destroyStorage *bool,
 ) (base.ModelInfo, error) {
 	var result base.ModelInfo
 	if !names.IsValidUser(owner) {
@@ -67,6 +114,10 @@ func (c *Client) CreateModel(
 		CloudTag:           cloudTag,
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
+		Template:           template,
+
+		ExpiresIn:              expiresIn,
+		DestroyStorageOnExpiry: destroyStorage,
 	}
 	var modelInfo params.ModelInfo
 	err := c.facade.FacadeCall("CreateModel", createArgs, &modelInfo)
@@ -105,6 +156,7 @@ func convertParamsModelInfo(modelInfo params.ModelInfo) (base.ModelInfo, error)
 		Owner:           ownerTag.Id(),
 		Life:            string(modelInfo.Life),
 		AgentVersion:    modelInfo.AgentVersion,
+		Expires:         modelInfo.Expires,
 	}
 	result.Status = base.Status{
 		Status: modelInfo.Status.Status,
@@ -277,8 +329,12 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 
 // DestroyModel puts the specified model into a "dying" state, which will
 // cause the model's resources to be cleaned up, after which the model will
-// be removed.
-func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
+// be removed. If force is true, the model's cloud resources are removed
+// even if they are still in use.
+func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool, force bool) error {
+	if force && c.BestAPIVersion() < 6 {
+		return errors.New("this Juju controller does not support forced model destruction")
+	}
 	var args interface{}
 	if c.BestAPIVersion() < 4 {
 		if destroyStorage == nil || !*destroyStorage {
@@ -290,6 +346,7 @@ func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
 			Models: []params.DestroyModelParams{{
 				ModelTag:       tag.String(),
 				DestroyStorage: destroyStorage,
+				Force:          force,
 			}},
 		}
 	}
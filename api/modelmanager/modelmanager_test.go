@@ -6,6 +6,7 @@ package modelmanager_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -93,6 +94,89 @@ func (s *modelmanagerSuite) TestCreateModel(c *gc.C) {
 	})
 }
 
+func (s *modelmanagerSuite) TestCreateModelFromTemplate(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "CreateModel")
+			c.Check(arg, jc.DeepEquals, params.ModelCreateArgs{
+				Name:     "new-model",
+				OwnerTag: "user-bob",
+				Config:   map[string]interface{}{"abc": 123},
+				Template: "small-staging",
+			})
+			out := result.(*params.ModelInfo)
+			out.Name = "new-model"
+			out.UUID = "youyoueyedee"
+			out.CloudTag = "cloud-nimbus"
+			out.CloudRegion = "catbus"
+			out.OwnerTag = "user-bob"
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	newModel, err := client.CreateModelFromTemplate(
+		"small-staging",
+		"new-model",
+		"bob",
+		"",
+		"",
+		names.CloudCredentialTag{},
+		map[string]interface{}{"abc": 123},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newModel.CloudRegion, gc.Equals, "catbus")
+}
+
+func (s *modelmanagerSuite) TestCreateModelFromTemplateNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.CreateModelFromTemplate("small-staging", "new-model", "bob", "", "", names.CloudCredentialTag{}, nil)
+	c.Assert(err, gc.ErrorMatches, "model templates on this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *modelmanagerSuite) TestCreateExpiringModel(c *gc.C) {
+	expires := time.Date(2017, 9, 4, 12, 0, 0, 0, time.UTC)
+	destroyStorage := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "CreateModel")
+			c.Check(arg, jc.DeepEquals, params.ModelCreateArgs{
+				Name:                   "new-model",
+				OwnerTag:               "user-bob",
+				ExpiresIn:              72 * time.Hour,
+				DestroyStorageOnExpiry: &destroyStorage,
+			})
+			out := result.(*params.ModelInfo)
+			out.Name = "new-model"
+			out.UUID = "youyoueyedee"
+			out.CloudTag = "cloud-nimbus"
+			out.OwnerTag = "user-bob"
+			out.Expires = &expires
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	newModel, err := client.CreateExpiringModel(
+		"", "new-model", "bob", "", "", names.CloudCredentialTag{}, nil,
+		72*time.Hour, &destroyStorage,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newModel.Expires, jc.DeepEquals, &expires)
+}
+
+func (s *modelmanagerSuite) TestCreateExpiringModelNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.CreateExpiringModel("", "new-model", "bob", "", "", names.CloudCredentialTag{}, nil, time.Hour, nil)
+	c.Assert(err, gc.ErrorMatches, "model expiry on this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelmanagerSuite) TestListModelsBadUser(c *gc.C) {
 	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
 	_, err := client.ListModels("not a user")
@@ -148,15 +232,16 @@ func (s *modelmanagerSuite) TestListModels(c *gc.C) {
 func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	true_ := true
 	false_ := false
-	s.testDestroyModel(c, nil)
-	s.testDestroyModel(c, &true_)
-	s.testDestroyModel(c, &false_)
+	s.testDestroyModel(c, nil, false)
+	s.testDestroyModel(c, &true_, false)
+	s.testDestroyModel(c, &false_, false)
+	s.testDestroyModel(c, nil, true)
 }
 
-func (s *modelmanagerSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
+func (s *modelmanagerSuite) testDestroyModel(c *gc.C, destroyStorage *bool, force bool) {
 	var called bool
 	apiCaller := basetesting.BestVersionCaller{
-		BestVersion: 4,
+		BestVersion: 6,
 		APICallerFunc: basetesting.APICallerFunc(
 			func(objType string,
 				version int,
@@ -170,6 +255,7 @@ func (s *modelmanagerSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
 					Models: []params.DestroyModelParams{{
 						ModelTag:       coretesting.ModelTag.String(),
 						DestroyStorage: destroyStorage,
+						Force:          force,
 					}},
 				})
 				results := resp.(*params.ErrorResults)
@@ -182,7 +268,7 @@ func (s *modelmanagerSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
 		),
 	}
 	client := modelmanager.NewClient(apiCaller)
-	err := client.DestroyModel(coretesting.ModelTag, destroyStorage)
+	err := client.DestroyModel(coretesting.ModelTag, destroyStorage, force)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
@@ -211,7 +297,7 @@ func (s *modelmanagerSuite) TestDestroyModelV3(c *gc.C) {
 	)
 	client := modelmanager.NewClient(apiCaller)
 	destroyStorage := true
-	err := client.DestroyModel(coretesting.ModelTag, &destroyStorage)
+	err := client.DestroyModel(coretesting.ModelTag, &destroyStorage, false)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
@@ -219,11 +305,24 @@ func (s *modelmanagerSuite) TestDestroyModelV3(c *gc.C) {
 func (s *modelmanagerSuite) TestDestroyModelV3DestroyStorageNotTrue(c *gc.C) {
 	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
 	for _, destroyStorage := range []*bool{nil, new(bool)} {
-		err := client.DestroyModel(coretesting.ModelTag, destroyStorage)
+		err := client.DestroyModel(coretesting.ModelTag, destroyStorage, false)
 		c.Assert(err, gc.ErrorMatches, "this Juju controller requires destroyStorage to be true")
 	}
 }
 
+func (s *modelmanagerSuite) TestDestroyModelV3Force(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
+	destroyStorage := true
+	err := client.DestroyModel(coretesting.ModelTag, &destroyStorage, true)
+	c.Assert(err, gc.ErrorMatches, "this Juju controller does not support forced model destruction")
+}
+
+func (s *modelmanagerSuite) TestDestroyModelV5Force(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 5})
+	err := client.DestroyModel(coretesting.ModelTag, nil, true)
+	c.Assert(err, gc.ErrorMatches, "this Juju controller does not support forced model destruction")
+}
+
 func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelsuspension provides the client side API for the
+// ModelSuspension facade, used to stop the instances of a model while
+// it is not in use and to start them again.
+package modelsuspension
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Suspension describes the suspension of a model.
+type Suspension struct {
+	// Suspended is true if the model is suspended.
+	Suspended bool
+
+	// By is the name of the user that suspended the model.
+	By string
+
+	// Since is when the model was suspended.
+	Since time.Time
+
+	// Instances holds the IDs of the instances that were stopped
+	// when the model was suspended.
+	Instances []string
+}
+
+// Client allows access to the ModelSuspension API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelSuspension API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelSuspension")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Suspend stops the instances of the model, and rejects changes to
+// the model until it is resumed.
+func (c *Client) Suspend() error {
+	return errors.Trace(c.facade.FacadeCall("Suspend", nil, nil))
+}
+
+// Resume starts the instances stopped when the model was suspended.
+func (c *Client) Resume() error {
+	return errors.Trace(c.facade.FacadeCall("Resume", nil, nil))
+}
+
+// SuspensionStatus returns the suspension of the model.
+func (c *Client) SuspensionStatus() (Suspension, error) {
+	var result params.ModelSuspensionResult
+	if err := c.facade.FacadeCall("SuspensionStatus", nil, &result); err != nil {
+		return Suspension{}, errors.Trace(err)
+	}
+	if !result.Suspended {
+		return Suspension{}, nil
+	}
+	suspension := Suspension{
+		Suspended: true,
+		Instances: result.Instances,
+	}
+	if result.By != "" {
+		tag, err := names.ParseUserTag(result.By)
+		if err != nil {
+			return Suspension{}, errors.Trace(err)
+		}
+		suspension.By = tag.Id()
+	}
+	if result.Since != nil {
+		suspension.Since = *result.Since
+	}
+	return suspension, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspension_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelsuspension"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestSuspend(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "ModelSuspension")
+			c.Check(request, gc.Equals, "Suspend")
+			c.Check(a, gc.IsNil)
+			return nil
+		},
+	)
+	err := modelsuspension.NewClient(apiCaller).Suspend()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestResume(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "Resume")
+			return &params.Error{Message: "model is not suspended"}
+		},
+	)
+	err := modelsuspension.NewClient(apiCaller).Resume()
+	c.Assert(err, gc.ErrorMatches, "model is not suspended")
+}
+
+func (s *clientSuite) TestSuspensionStatus(c *gc.C) {
+	since := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "SuspensionStatus")
+			*(result.(*params.ModelSuspensionResult)) = params.ModelSuspensionResult{
+				Suspended: true,
+				By:        "user-bob",
+				Since:     &since,
+				Instances: []string{"inst-0"},
+			}
+			return nil
+		},
+	)
+	suspension, err := modelsuspension.NewClient(apiCaller).SuspensionStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(suspension, jc.DeepEquals, modelsuspension.Suspension{
+		Suspended: true,
+		By:        "bob",
+		Since:     since,
+		Instances: []string{"inst-0"},
+	})
+}
@@ -145,16 +145,43 @@ func (st *State) ContainerConfig() (result params.ContainerConfig, err error) {
 	return result, err
 }
 
+// machinePageSize is the number of machines considered in each call
+// to MachinesWithTransientErrorsPage.
+const machinePageSize = 500
+
 // MachinesWithTransientErrors returns a slice of machines and corresponding status information
-// for those machines which have transient provisioning errors.
+// for those machines which have transient provisioning errors. If the
+// controller supports it, the machines are considered a page at a time,
+// so that the size of each response is bounded.
 func (st *State) MachinesWithTransientErrors() ([]MachineStatusResult, error) {
-	var results params.StatusResults
-	err := st.facade.FacadeCall("MachinesWithTransientErrors", nil, &results)
-	if err != nil {
-		return []MachineStatusResult{}, err
+	if st.facade.BestAPIVersion() < 6 {
+		var results params.StatusResults
+		err := st.facade.FacadeCall("MachinesWithTransientErrors", nil, &results)
+		if err != nil {
+			return []MachineStatusResult{}, err
+		}
+		return st.machineStatusResults(results.Results), nil
+	}
+	var statuses []params.StatusResult
+	args := params.MachinePageParams{Limit: machinePageSize}
+	for {
+		var page params.StatusResultsPage
+		err := st.facade.FacadeCall("MachinesWithTransientErrorsPage", args, &page)
+		if err != nil {
+			return []MachineStatusResult{}, err
+		}
+		statuses = append(statuses, page.Results...)
+		if page.NextCursor == "" {
+			break
+		}
+		args.Cursor = page.NextCursor
 	}
-	machines := make([]MachineStatusResult, len(results.Results))
-	for i, status := range results.Results {
+	return st.machineStatusResults(statuses), nil
+}
+
+func (st *State) machineStatusResults(statuses []params.StatusResult) []MachineStatusResult {
+	machines := make([]MachineStatusResult, len(statuses))
+	for i, status := range statuses {
 		if status.Error != nil {
 			continue
 		}
@@ -165,7 +192,7 @@ func (st *State) MachinesWithTransientErrors() ([]MachineStatusResult, error) {
 		}
 		machines[i].Status = status
 	}
-	return machines, nil
+	return machines
 }
 
 // FindTools returns al ist of tools matching the specified version number and
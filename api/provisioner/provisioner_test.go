@@ -942,3 +942,56 @@ func (s *prepareContainerSuite) TestPrepareContainerInterfaceInfoSingleNIC(c *gc
 		}},
 	}})
 }
+
+type transientErrorsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&transientErrorsSuite{})
+
+func (s *transientErrorsSuite) TestMachinesWithTransientErrorsPaged(c *gc.C) {
+	var cursors []string
+	apiCaller := apibasetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Provisioner")
+			c.Check(request, gc.Equals, "MachinesWithTransientErrorsPage")
+			args := a.(params.MachinePageParams)
+			c.Check(args.Limit, gc.Equals, 500)
+			cursors = append(cursors, args.Cursor)
+			page := result.(*params.StatusResultsPage)
+			switch args.Cursor {
+			case "":
+				page.Results = []params.StatusResult{{Id: "1", Life: "alive", Status: "provisioning error"}}
+				page.NextCursor = "499"
+			case "499":
+				page.Results = []params.StatusResult{{Id: "700", Life: "alive", Status: "provisioning error"}}
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	result, err := provisioner.NewState(apiCaller).MachinesWithTransientErrors()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cursors, jc.DeepEquals, []string{"", "499"})
+	c.Assert(result, gc.HasLen, 2)
+	c.Assert(result[0].Machine.Id(), gc.Equals, "1")
+	c.Assert(result[1].Machine.Id(), gc.Equals, "700")
+}
+
+func (s *transientErrorsSuite) TestMachinesWithTransientErrorsUnpaged(c *gc.C) {
+	apiCaller := apibasetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "MachinesWithTransientErrors")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.StatusResults)) = params.StatusResults{
+				Results: []params.StatusResult{{Id: "1", Life: "alive", Status: "provisioning error"}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	result, err := provisioner.NewState(apiCaller).MachinesWithTransientErrors()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Assert(result[0].Machine.Id(), gc.Equals, "1")
+}
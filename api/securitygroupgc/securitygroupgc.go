@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package securitygroupgc provides the client side API for the
+// SecurityGroupGC facade, used by the worker that deletes the
+// per-machine security groups left behind by machines that no longer
+// exist.
+package securitygroupgc
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const securityGroupGCFacade = "SecurityGroupGC"
+
+// API provides access to the SecurityGroupGC API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side SecurityGroupGC facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		facade: base.NewFacadeCaller(caller, securityGroupGCFacade),
+	}
+}
+
+// MachineIds returns the IDs of all machines in the model.
+func (api *API) MachineIds() ([]string, error) {
+	var result params.StringsResult
+	if err := api.facade.FacadeCall("MachineIds", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securitygroupgc_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/securitygroupgc"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type securityGroupGCSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&securityGroupGCSuite{})
+
+func (s *securityGroupGCSuite) TestMachineIds(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "SecurityGroupGC")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "MachineIds")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.StringsResult{})
+			*(result.(*params.StringsResult)) = params.StringsResult{
+				Result: []string{"0", "1"},
+			}
+			return nil
+		},
+	)
+	ids, err := securitygroupgc.NewAPI(apiCaller).MachineIds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"0", "1"})
+}
+
+func (s *securityGroupGCSuite) TestMachineIdsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.StringsResult)) = params.StringsResult{
+				Error: &params.Error{Message: "denied"},
+			}
+			return nil
+		},
+	)
+	_, err := securitygroupgc.NewAPI(apiCaller).MachineIds()
+	c.Assert(err, gc.ErrorMatches, "denied")
+}
+
+func (s *securityGroupGCSuite) TestMachineIdsCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	_, err := securitygroupgc.NewAPI(apiCaller).MachineIds()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -100,6 +100,31 @@ func (facade *Facade) PublicKeys(target string) ([]string, error) {
 	return out.Results[0].PublicKeys, nil
 }
 
+// HostMachine returns the id of the machine hosting the SSH target's
+// machine if that machine is a container, or an empty string if it is
+// not. The target may be provided as a machine ID or unit name.
+func (facade *Facade) HostMachine(target string) (string, error) {
+	if facade.BestAPIVersion() < 3 {
+		return "", errors.NotSupportedf("HostMachine")
+	}
+	entities, err := targetToEntities(target)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var out params.StringResults
+	err = facade.caller.FacadeCall("HostMachine", entities, &out)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return "", countError(len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return out.Results[0].Result, nil
+}
+
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the associated model.
 func (facade *Facade) Proxy() (bool, error) {
@@ -111,6 +136,18 @@ func (facade *Facade) Proxy() (bool, error) {
 	return out.UseProxy, nil
 }
 
+// ReverseTunnel returns whether SSH connections must be made through
+// the tunnels that the model's machine agents open to the controller,
+// because the controller cannot connect to the machines directly.
+func (facade *Facade) ReverseTunnel() (bool, error) {
+	var out params.SSHProxyResult
+	err := facade.caller.FacadeCall("Proxy", nil, &out)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return out.UseReverseTunnel, nil
+}
+
 func targetToEntities(target string) (params.Entities, error) {
 	tag, err := targetToTag(target)
 	if err != nil {
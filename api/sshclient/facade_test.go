@@ -251,6 +251,55 @@ func (s *FacadeSuite) TestPublicKeysExtraResults(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "expected 1 result, got 2")
 }
 
+func (s *FacadeSuite) TestHostMachine(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.StringResults) = params.StringResults{
+				Results: []params.StringResult{{Result: "0"}},
+			}
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	host, err := facade.HostMachine("foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(host, gc.Equals, "0")
+	stub.CheckCalls(c, []jujutesting.StubCall{{"SSHClient.HostMachine", []interface{}{
+		params.Entities{[]params.Entity{{names.NewUnitTag("foo/0").String()}}},
+	}}})
+}
+
+func (s *FacadeSuite) TestHostMachineTargetError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*result.(*params.StringResults) = params.StringResults{
+				Results: []params.StringResult{{Error: common.ServerError(errors.New("boom"))}},
+			}
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	_, err := facade.HostMachine("foo/0")
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *FacadeSuite) TestHostMachineNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call %q", request)
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	_, err := facade.HostMachine("foo/0")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *FacadeSuite) TestProxy(c *gc.C) {
 	checkProxy(c, true)
 	checkProxy(c, false)
@@ -280,3 +329,19 @@ func (s *FacadeSuite) TestProxyError(c *gc.C) {
 	_, err := facade.Proxy()
 	c.Check(err, gc.ErrorMatches, "boom")
 }
+
+func (s *FacadeSuite) TestReverseTunnel(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		*result.(*params.SSHProxyResult) = params.SSHProxyResult{
+			UseReverseTunnel: true,
+		}
+		return nil
+	})
+	facade := sshclient.NewFacade(apiCaller)
+	result, err := facade.ReverseTunnel()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, jc.IsTrue)
+	stub.CheckCalls(c, []jujutesting.StubCall{{"SSHClient.Proxy", []interface{}{nil}}})
+}
@@ -8,8 +8,10 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/watcher"
 )
 
 // Client allows access to the storage API end point.
@@ -257,6 +259,98 @@ func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	return results.Results, nil
 }
 
+// Grow requests that the specified storage instance be grown to the
+// specified size, in MiB.
+func (c *Client) Grow(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("growing storage by this juju controller")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.StoragesGrowArgs{[]params.StorageGrowArg{{
+		StorageTag: names.NewStorageTag(storageId).String(),
+		Size:       size,
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Grow", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CreateSnapshot snapshots the volume underlying the storage instance
+// with the specified ID, and returns the details of the snapshot.
+func (c *Client) CreateSnapshot(storageId string) (params.StorageSnapshotDetails, error) {
+	if c.BestAPIVersion() < 6 {
+		return params.StorageSnapshotDetails{}, errors.NotSupportedf("snapshotting storage by this juju controller")
+	}
+	if !names.IsValidStorage(storageId) {
+		return params.StorageSnapshotDetails{}, errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.Entities{[]params.Entity{{
+		Tag: names.NewStorageTag(storageId).String(),
+	}}}
+	var results params.StorageSnapshotResults
+	if err := c.facade.FacadeCall("CreateSnapshots", args, &results); err != nil {
+		return params.StorageSnapshotDetails{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.StorageSnapshotDetails{}, errors.Errorf(
+			"expected 1 result, got %d",
+			len(results.Results),
+		)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.StorageSnapshotDetails{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// ListSnapshots returns the details of all of the storage snapshots
+// in the model.
+func (c *Client) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("listing storage snapshots by this juju controller")
+	}
+	var results params.StorageSnapshotResults
+	if err := c.facade.FacadeCall("ListSnapshots", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots := make([]params.StorageSnapshotDetails, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		snapshots[i] = *result.Result
+	}
+	return snapshots, nil
+}
+
+// RestoreSnapshot restores a volume from the storage snapshot with the
+// specified ID, and returns the tag of the new, detached storage
+// instance for it.
+func (c *Client) RestoreSnapshot(snapshotId string) (names.StorageTag, error) {
+	if c.BestAPIVersion() < 6 {
+		return names.StorageTag{}, errors.NotSupportedf("restoring storage snapshots by this juju controller")
+	}
+	args := params.RestoreStorageSnapshotsArgs{Snapshots: []string{snapshotId}}
+	var results params.ImportStorageResults
+	if err := c.facade.FacadeCall("RestoreSnapshots", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf(
+			"expected 1 result, got %d",
+			len(results.Results),
+		)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
+
 // Import imports storage into the model.
 func (c *Client) Import(
 	kind storage.StorageKind,
@@ -287,3 +381,35 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// WatchVolumeAttachments returns a watcher that notifies of changes to
+// the model's volume attachments, including their removal.
+func (c *Client) WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("watching volume attachments by this juju controller")
+	}
+	var result params.MachineStorageIdsWatchResult
+	if err := c.facade.FacadeCall("WatchVolumeAttachments", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewVolumeAttachmentsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// WatchFilesystemAttachments returns a watcher that notifies of changes
+// to the model's filesystem attachments, including their removal.
+func (c *Client) WatchFilesystemAttachments() (watcher.MachineStorageIdsWatcher, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("watching filesystem attachments by this juju controller")
+	}
+	var result params.MachineStorageIdsWatchResult
+	if err := c.facade.FacadeCall("WatchFilesystemAttachments", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewFilesystemAttachmentsWatcher(c.facade.RawAPICaller(), result), nil
+}
@@ -694,6 +694,184 @@ func (s *storageMockSuite) TestRemoveInvalidStorageId(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `storage ID "foo/bar" not valid`)
 }
 
+func (s *storageMockSuite) TestGrow(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Grow")
+				c.Check(a, jc.DeepEquals, params.StoragesGrowArgs{[]params.StorageGrowArg{
+					{StorageTag: "storage-foo-0", Size: 2048},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Grow("foo/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestGrowNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Grow("foo/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "growing storage by this juju controller not supported")
+}
+
+func (s *storageMockSuite) TestCreateSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotResults{})
+				results := result.(*params.StorageSnapshotResults)
+				results.Results = []params.StorageSnapshotResult{{
+					Result: &params.StorageSnapshotDetails{
+						Id:         "0",
+						SnapshotId: "snap-0",
+						StorageTag: "storage-foo-0",
+					},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	snapshot, err := client.CreateSnapshot("foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, params.StorageSnapshotDetails{
+		Id:         "0",
+		SnapshotId: "snap-0",
+		StorageTag: "storage-foo-0",
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshot("foo/0")
+	c.Assert(err, gc.ErrorMatches, "snapshotting storage by this juju controller not supported")
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, gc.IsNil)
+				c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotResults{})
+				results := result.(*params.StorageSnapshotResults)
+				results.Results = []params.StorageSnapshotResult{
+					{Result: &params.StorageSnapshotDetails{Id: "0"}},
+					{Result: &params.StorageSnapshotDetails{Id: "1"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	snapshots, err := client.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []params.StorageSnapshotDetails{
+		{Id: "0"}, {Id: "1"},
+	})
+}
+
+func (s *storageMockSuite) TestRestoreSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RestoreSnapshots")
+				c.Check(a, jc.DeepEquals, params.RestoreStorageSnapshotsArgs{
+					Snapshots: []string{"0"},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+				results := result.(*params.ImportStorageResults)
+				results.Results = []params.ImportStorageResult{{
+					Result: &params.ImportStorageDetails{
+						StorageTag: "storage-foo-1",
+					},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	storageTag, err := client.RestoreSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("foo/1"))
+}
+
+func (s *storageMockSuite) TestRestoreSnapshotError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				results := result.(*params.ImportStorageResults)
+				results.Results = []params.ImportStorageResult{{
+					Error: &params.Error{Message: "qux"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.RestoreSnapshot("0")
+	c.Assert(err, gc.ErrorMatches, "qux")
+}
+
 func (s *storageMockSuite) TestDetach(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -847,3 +1025,56 @@ func (s *storageMockSuite) TestImportArityMismatch(c *gc.C) {
 	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
+
+func (s *storageMockSuite) TestWatchVolumeAttachmentsError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "WatchVolumeAttachments")
+				c.Check(a, gc.IsNil)
+				c.Assert(result, gc.FitsTypeOf, &params.MachineStorageIdsWatchResult{})
+				result.(*params.MachineStorageIdsWatchResult).Error = &params.Error{Message: "qux"}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.WatchVolumeAttachments()
+	c.Assert(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestWatchFilesystemAttachmentsError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "WatchFilesystemAttachments")
+				return errors.New("boom")
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.WatchFilesystemAttachments()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestWatchAttachmentsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.WatchVolumeAttachments()
+	c.Assert(err, gc.ErrorMatches, "watching volume attachments by this juju controller not supported")
+	_, err = client.WatchFilesystemAttachments()
+	c.Assert(err, gc.ErrorMatches, "watching filesystem attachments by this juju controller not supported")
+}
@@ -107,6 +107,17 @@ func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, er
 	return w, nil
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the model,
+// including requests to grow them. WatchVolumeResizes returns an error
+// satisfying errors.IsNotSupported if the controller does not support
+// growing volumes.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("growing volumes")
+	}
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments
 // scoped to the entity with the tag passed to NewState.
 func (st *State) WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error) {
@@ -263,6 +274,29 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// ResizeVolumeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) ResizeVolumeParams(tags []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("growing volumes")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeVolumeParamsResults
+	err := st.facade.FacadeCall("ResizeVolumeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -6,6 +6,7 @@ package storageprovisioner_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -431,6 +432,57 @@ func (s *provisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 5)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeVolumeParams")
+			c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ResizeVolumeParamsResults{})
+			*(result.(*params.ResizeVolumeParamsResults)) = params.ResizeVolumeParamsResults{
+				Results: []params.ResizeVolumeParamsResult{{
+					Result: params.ResizeVolumeParams{
+						VolumeTag: "volume-100",
+						VolumeId:  "bar",
+						Provider:  "foo",
+						Size:      2048,
+					},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewModelTag("87927ace-9e41-4fd5-8103-1a6fb5ff7eb4"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.ResizeVolumeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(resizeParams, jc.DeepEquals, []params.ResizeVolumeParamsResult{{
+		Result: params.ResizeVolumeParams{
+			VolumeTag: "volume-100",
+			VolumeId:  "bar",
+			Provider:  "foo",
+			Size:      2048,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestResizeVolumeParamsNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	st, err := storageprovisioner.NewState(apiCaller, names.NewModelTag("87927ace-9e41-4fd5-8103-1a6fb5ff7eb4"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.ResizeVolumeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = st.WatchVolumeResizes()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package storageusage implements the client-side API facade used
+// by the storageusage worker.
+package storageusage
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// FilesystemAttachment describes a filesystem mounted on a machine.
+type FilesystemAttachment struct {
+	Filesystem names.FilesystemTag
+	MountPoint string
+}
+
+// FilesystemUsage describes the utilization of a filesystem mounted
+// on a machine. Sizes are in MiB.
+type FilesystemUsage struct {
+	Filesystem names.FilesystemTag
+	Size       uint64
+	Used       uint64
+	Available  uint64
+	Updated    time.Time
+}
+
+// Facade provides access to the StorageUsage API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side StorageUsage facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "StorageUsage"),
+	}
+}
+
+// FilesystemAttachments returns the provisioned filesystem attachments
+// of the specified machine.
+func (f *Facade) FilesystemAttachments(machine names.MachineTag) ([]FilesystemAttachment, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: machine.String()}}}
+	var results params.MachineFilesystemAttachmentsResults
+	if err := f.caller.FacadeCall("FilesystemAttachments", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachments := make([]FilesystemAttachment, len(results.Results[0].Attachments))
+	for i, attachment := range results.Results[0].Attachments {
+		tag, err := names.ParseFilesystemTag(attachment.FilesystemTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachments[i] = FilesystemAttachment{
+			Filesystem: tag,
+			MountPoint: attachment.Info.MountPoint,
+		}
+	}
+	return attachments, nil
+}
+
+// SetFilesystemUsage records the utilization of filesystems mounted
+// on the specified machine.
+func (f *Facade) SetFilesystemUsage(machine names.MachineTag, usage []FilesystemUsage) error {
+	args := params.FilesystemUsageArgs{
+		Args: make([]params.FilesystemUsageArg, len(usage)),
+	}
+	for i, u := range usage {
+		args.Args[i] = params.FilesystemUsageArg{
+			MachineTag:    machine.String(),
+			FilesystemTag: u.Filesystem.String(),
+			Usage: params.FilesystemUsage{
+				Size:      u.Size,
+				Used:      u.Used,
+				Available: u.Available,
+				Updated:   u.Updated,
+			},
+		}
+	}
+	var results params.ErrorResults
+	if err := f.caller.FacadeCall("SetFilesystemUsage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
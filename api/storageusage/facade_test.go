@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageusage_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/storageusage"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestFilesystemAttachments(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "StorageUsage")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.MachineFilesystemAttachmentsResults) = params.MachineFilesystemAttachmentsResults{
+			Results: []params.MachineFilesystemAttachmentsResult{{
+				Attachments: []params.FilesystemAttachment{{
+					FilesystemTag: "filesystem-0",
+					MachineTag:    "machine-42",
+					Info:          params.FilesystemAttachmentInfo{MountPoint: "/srv/data"},
+				}},
+			}},
+		}
+		return nil
+	})
+	facade := storageusage.NewFacade(apiCaller)
+
+	attachments, err := facade.FilesystemAttachments(names.NewMachineTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, jc.DeepEquals, []storageusage.FilesystemAttachment{{
+		Filesystem: names.NewFilesystemTag("0"),
+		MountPoint: "/srv/data",
+	}})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"FilesystemAttachments", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestFilesystemAttachmentsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.MachineFilesystemAttachmentsResults) = params.MachineFilesystemAttachmentsResults{
+			Results: []params.MachineFilesystemAttachmentsResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := storageusage.NewFacade(apiCaller)
+
+	_, err := facade.FilesystemAttachments(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestSetFilesystemUsage(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "StorageUsage")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	facade := storageusage.NewFacade(apiCaller)
+
+	updated := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := facade.SetFilesystemUsage(names.NewMachineTag("42"), []storageusage.FilesystemUsage{{
+		Filesystem: names.NewFilesystemTag("0"),
+		Size:       1024,
+		Used:       256,
+		Available:  768,
+		Updated:    updated,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetFilesystemUsage", []interface{}{params.FilesystemUsageArgs{
+			Args: []params.FilesystemUsageArg{{
+				MachineTag:    "machine-42",
+				FilesystemTag: "filesystem-0",
+				Usage: params.FilesystemUsage{
+					Size:      1024,
+					Used:      256,
+					Available: 768,
+					Updated:   updated,
+				},
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestSetFilesystemUsageCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := storageusage.NewFacade(apiCaller)
+
+	err := facade.SetFilesystemUsage(names.NewMachineTag("42"), nil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tagsync provides the client side API for the TagSync facade,
+// used by the worker that sets the resource tags defined by model and
+// application annotations on instances.
+package tagsync
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/watcher"
+)
+
+const tagSyncFacade = "TagSync"
+
+// InstanceTags holds the tags that should be set on the instance of
+// a provisioned machine.
+type InstanceTags struct {
+	MachineId  string
+	InstanceId instance.Id
+	Tags       map[string]string
+}
+
+// API provides access to the TagSync API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side TagSync facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		facade: base.NewFacadeCaller(caller, tagSyncFacade),
+	}
+}
+
+// WatchAnnotations returns a watcher that notifies when the
+// annotations of any entity in the model change.
+func (api *API) WatchAnnotations() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchAnnotations", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
+
+// InstanceTags returns the tags, defined by annotations, that should
+// be set on the instance of each provisioned machine in the model.
+func (api *API) InstanceTags() ([]InstanceTags, error) {
+	var results params.InstanceTagsResults
+	if err := api.facade.FacadeCall("InstanceTags", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	instances := make([]InstanceTags, len(results.Results))
+	for i, result := range results.Results {
+		tag, err := names.ParseMachineTag(result.MachineTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		instances[i] = InstanceTags{
+			MachineId:  tag.Id(),
+			InstanceId: instance.Id(result.InstanceId),
+			Tags:       result.Tags,
+		}
+	}
+	return instances, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagsync_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/tagsync"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type tagSyncSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&tagSyncSuite{})
+
+func (s *tagSyncSuite) TestInstanceTags(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "TagSync")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "InstanceTags")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.InstanceTagsResults{})
+			*(result.(*params.InstanceTagsResults)) = params.InstanceTagsResults{
+				Results: []params.InstanceTags{{
+					MachineTag: "machine-1",
+					InstanceId: "i-1",
+					Tags:       map[string]string{"owner": "bob"},
+				}},
+			}
+			return nil
+		},
+	)
+	instances, err := tagsync.NewAPI(apiCaller).InstanceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, jc.DeepEquals, []tagsync.InstanceTags{{
+		MachineId:  "1",
+		InstanceId: "i-1",
+		Tags:       map[string]string{"owner": "bob"},
+	}})
+}
+
+func (s *tagSyncSuite) TestInstanceTagsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	_, err := tagsync.NewAPI(apiCaller).InstanceTags()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *tagSyncSuite) TestWatchAnnotationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "WatchAnnotations")
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "denied"},
+			}
+			return nil
+		},
+	)
+	_, err := tagsync.NewAPI(apiCaller).WatchAnnotations()
+	c.Assert(err, gc.ErrorMatches, "denied")
+}
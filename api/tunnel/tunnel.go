@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tunnel provides access to the tunnels that machine agents
+// open to the controller, in models where the controller cannot
+// connect to the machines itself.
+package tunnel
+
+import (
+	"io"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Channel is a machine agent's reverse channel, over which the
+// controller asks the agent to open tunnels.
+type Channel struct {
+	stream base.Stream
+}
+
+// OpenChannel opens the reverse channel of the authenticated machine
+// agent. The controller refuses the channel unless the model's agents
+// connect in reverse mode.
+func OpenChannel(connector base.StreamConnector) (*Channel, error) {
+	stream, err := connector.ConnectStream("/tunnel", nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Channel{stream: stream}, nil
+}
+
+// Next blocks until the controller asks for a tunnel to be opened, and
+// returns the id of the request, which must be passed to Accept or
+// Reject.
+func (c *Channel) Next() (string, error) {
+	var request params.TunnelRequest
+	if err := c.stream.ReadJSON(&request); err != nil {
+		return "", errors.Trace(err)
+	}
+	return request.ID, nil
+}
+
+// Close closes the channel.
+func (c *Channel) Close() error {
+	return c.stream.Close()
+}
+
+// Accept accepts the tunnel request with the given id, returning the
+// tunnel's connection to the client.
+func Accept(connector base.StreamConnector, id string) (io.ReadWriteCloser, error) {
+	stream, err := connector.ConnectStream("/tunnel/accept", url.Values{"id": {id}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &tunnelStream{stream: stream}, nil
+}
+
+// Reject refuses the tunnel request with the given id, passing the
+// reason on to the client.
+func Reject(connector base.StreamConnector, id, reason string) error {
+	stream, err := connector.ConnectStream("/tunnel/accept", url.Values{
+		"id":    {id},
+		"error": {reason},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return stream.Close()
+}
+
+// ConnectMachine opens a tunnel to the SSH server of the machine
+// identified by the given machine or unit tag, over the machine's
+// reverse channel.
+func ConnectMachine(connector base.StreamConnector, entity names.Tag) (io.ReadWriteCloser, error) {
+	stream, err := connector.ConnectStream("/machine-tunnel", url.Values{"entity": {entity.String()}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &tunnelStream{stream: stream}, nil
+}
+
+// tunnelStream adapts a tunnel stream, which carries data as
+// params.TunnelMessage values, to an io.ReadWriteCloser.
+type tunnelStream struct {
+	stream base.Stream
+	buf    []byte
+}
+
+// Read is part of the io.Reader interface.
+func (s *tunnelStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		var m params.TunnelMessage
+		if err := s.stream.ReadJSON(&m); err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return 0, io.EOF
+			}
+			return 0, errors.Trace(err)
+		}
+		s.buf = m.Data
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// Write is part of the io.Writer interface.
+func (s *tunnelStream) Write(p []byte) (int, error) {
+	if err := s.stream.WriteJSON(params.TunnelMessage{Data: p}); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(p), nil
+}
+
+// Close is part of the io.Closer interface.
+func (s *tunnelStream) Close() error {
+	return s.stream.Close()
+}
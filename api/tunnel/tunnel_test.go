@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tunnel_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/tunnel"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type TunnelSuite struct{}
+
+var _ = gc.Suite(&TunnelSuite{})
+
+func (s *TunnelSuite) TestChannel(c *gc.C) {
+	stream := &fakeStream{
+		messages: []interface{}{params.TunnelRequest{ID: "abc"}},
+	}
+	connector := &fakeConnector{c: c, path: "/tunnel", stream: stream}
+	channel, err := tunnel.OpenChannel(connector)
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := channel.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "abc")
+	_, err = channel.Next()
+	c.Assert(err, gc.ErrorMatches, "websocket: close 1000.*")
+
+	err = channel.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *TunnelSuite) TestOpenChannelError(c *gc.C) {
+	connector := &fakeConnector{c: c, path: "/tunnel", err: errors.New("reverse channels not supported")}
+	_, err := tunnel.OpenChannel(connector)
+	c.Assert(err, gc.ErrorMatches, "reverse channels not supported")
+}
+
+func (s *TunnelSuite) TestAccept(c *gc.C) {
+	stream := &fakeStream{
+		messages: []interface{}{params.TunnelMessage{Data: []byte("SSH-2.0")}},
+	}
+	connector := &fakeConnector{
+		c:      c,
+		path:   "/tunnel/accept",
+		values: url.Values{"id": {"abc"}},
+		stream: stream,
+	}
+	conn, err := tunnel.Accept(connector, "abc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRelays(c, conn, stream, "SSH-2.0")
+}
+
+func (s *TunnelSuite) TestReject(c *gc.C) {
+	stream := &fakeStream{}
+	connector := &fakeConnector{
+		c:      c,
+		path:   "/tunnel/accept",
+		values: url.Values{"id": {"abc"}, "error": {"connection refused"}},
+		stream: stream,
+	}
+	err := tunnel.Reject(connector, "abc", "connection refused")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *TunnelSuite) TestConnectMachine(c *gc.C) {
+	stream := &fakeStream{
+		messages: []interface{}{
+			params.TunnelMessage{Data: []byte("SSH-")},
+			params.TunnelMessage{Data: []byte("2.0")},
+		},
+	}
+	connector := &fakeConnector{
+		c:      c,
+		path:   "/machine-tunnel",
+		values: url.Values{"entity": {"unit-mysql-0"}},
+		stream: stream,
+	}
+	conn, err := tunnel.ConnectMachine(connector, names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRelays(c, conn, stream, "SSH-2.0")
+}
+
+func (s *TunnelSuite) assertRelays(c *gc.C, conn io.ReadWriteCloser, stream *fakeStream, expect string) {
+	_, err := io.WriteString(conn, "hello")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.written, jc.DeepEquals, []interface{}{
+		params.TunnelMessage{Data: []byte("hello")},
+	})
+
+	data, err := ioutil.ReadAll(conn)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expect)
+
+	err = conn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+type fakeConnector struct {
+	c      *gc.C
+	path   string
+	values url.Values
+	stream *fakeStream
+	err    error
+}
+
+func (f *fakeConnector) ConnectStream(path string, values url.Values) (base.Stream, error) {
+	f.c.Assert(path, gc.Equals, f.path)
+	f.c.Assert(values, jc.DeepEquals, f.values)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.stream, nil
+}
+
+type fakeStream struct {
+	base.Stream
+	messages []interface{}
+	written  []interface{}
+	closed   bool
+}
+
+func (f *fakeStream) ReadJSON(v interface{}) error {
+	if len(f.messages) == 0 {
+		return &websocket.CloseError{Code: websocket.CloseNormalClosure}
+	}
+	switch v := v.(type) {
+	case *params.TunnelRequest:
+		*v = f.messages[0].(params.TunnelRequest)
+	case *params.TunnelMessage:
+		*v = f.messages[0].(params.TunnelMessage)
+	}
+	f.messages = f.messages[1:]
+	return nil
+}
+
+func (f *fakeStream) WriteJSON(v interface{}) error {
+	f.written = append(f.written, v)
+	return nil
+}
+
+func (f *fakeStream) Close() error {
+	f.closed = true
+	return nil
+}
@@ -122,3 +122,33 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestActionLogAndProgress(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.ActionLog(action.ActionTag(), "starting")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionProgress(action.ActionTag(), 60, "more than half")
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := m.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Progress(), gc.Equals, 60)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "starting")
+	c.Assert(messages[1].Message, gc.Equals, "more than half")
+}
+
+func (s *actionSuite) TestActionLogNotRunning(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.ActionLog(action.ActionTag(), "starting")
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+}
@@ -85,6 +85,56 @@ func (s *Application) CharmModifiedVersion() (int, error) {
 	return result.Result, nil
 }
 
+// FloatingIPUnit returns the name of the unit whose machine the
+// application's floating IP address is associated with, or the empty
+// string if there is none.
+func (s *Application) FloatingIPUnit() (string, error) {
+	if s.st.BestAPIVersion() < 12 {
+		return "", errors.NotSupportedf("floating IPs")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("FloatingIPUnits", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// ConfigReadyHash returns the hash of the charm config settings that
+// the application's leader last reported itself ready for, or the
+// empty string if it has never done so.
+func (s *Application) ConfigReadyHash() (string, error) {
+	if s.st.BestAPIVersion() < 13 {
+		return "", errors.NotSupportedf("config ready hashes")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("ConfigReadyHashes", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // CharmURL returns the service's charm URL, and whether units should
 // upgrade to the charm with that URL even if they are in an error
 // state (force flag).
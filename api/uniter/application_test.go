@@ -93,6 +93,34 @@ func (s *applicationSuite) TestCharmModifiedVersion(c *gc.C) {
 	c.Assert(ver, gc.Equals, s.wordpressApplication.CharmModifiedVersion())
 }
 
+func (s *applicationSuite) TestFloatingIPUnit(c *gc.C) {
+	unitName, err := s.apiApplication.FloatingIPUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitName, gc.Equals, "")
+
+	err = s.wordpressApplication.SetFloatingIP("203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressApplication.SetFloatingIPUnit("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	unitName, err = s.apiApplication.FloatingIPUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitName, gc.Equals, "wordpress/0")
+}
+
+func (s *applicationSuite) TestConfigReadyHash(c *gc.C) {
+	hash, err := s.apiApplication.ConfigReadyHash()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hash, gc.Equals, "")
+
+	s.claimLeadership(c, s.wordpressUnit, s.wordpressApplication)
+	token := s.State.LeadershipChecker().LeadershipCheck("wordpress", "wordpress/0")
+	err = s.wordpressApplication.SetConfigReadyHash(token, "abc")
+	c.Assert(err, jc.ErrorIsNil)
+	hash, err = s.apiApplication.ConfigReadyHash()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hash, gc.Equals, "abc")
+}
+
 func (s *applicationSuite) TestSetApplicationStatus(c *gc.C) {
 	message := "a test message"
 	stat, err := s.wordpressApplication.Status()
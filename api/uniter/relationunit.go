@@ -152,6 +152,87 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 	return result.Settings, nil
 }
 
+// ReadRemoteSettings returns the settings of each of the named remote
+// units in the relation, keyed by unit name, using a single API call.
+// Units whose settings cannot be read are omitted from the result.
+func (ru *RelationUnit) ReadRemoteSettings(unames []string) (map[string]params.Settings, error) {
+	if len(unames) == 0 {
+		return map[string]params.Settings{}, nil
+	}
+	args := params.RelationUnitPairs{
+		RelationUnitPairs: make([]params.RelationUnitPair, 0, len(unames)),
+	}
+	for _, uname := range unames {
+		if !names.IsValidUnit(uname) {
+			return nil, errors.Errorf("%q is not a valid unit", uname)
+		}
+		args.RelationUnitPairs = append(args.RelationUnitPairs, params.RelationUnitPair{
+			Relation:   ru.relation.tag.String(),
+			LocalUnit:  ru.unit.tag.String(),
+			RemoteUnit: names.NewUnitTag(uname).String(),
+		})
+	}
+	var results params.SettingsResults
+	err := ru.st.facade.FacadeCall("ReadRemoteSettings", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(unames) {
+		return nil, fmt.Errorf("expected %d results, got %d", len(unames), len(results.Results))
+	}
+	settings := make(map[string]params.Settings)
+	for i, result := range results.Results {
+		if result.Error == nil {
+			settings[unames[i]] = result.Settings
+		}
+	}
+	return settings, nil
+}
+
+// PeerSeed returns the seed data written to this peer relation by the
+// application's leader. An error is returned if the relation is not a
+// peer relation.
+func (ru *RelationUnit) PeerSeed() (params.Settings, error) {
+	var results params.SettingsResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("PeerSeeds", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Settings, nil
+}
+
+// UpdatePeerSeed updates the seed data of this peer relation. Keys
+// set to empty values will be deleted. Only the leader of the unit's
+// application may update the seed data.
+func (ru *RelationUnit) UpdatePeerSeed(settings params.Settings) error {
+	var result params.ErrorResults
+	args := params.RelationUnitsSettings{
+		RelationUnits: []params.RelationUnitSettings{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+			Settings: settings,
+		}},
+	}
+	err := ru.st.facade.FacadeCall("UpdatePeerSeeds", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // Watch returns a watcher that notifies of changes to counterpart
 // units in the relation.
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
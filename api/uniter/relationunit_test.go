@@ -4,7 +4,10 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -240,6 +243,72 @@ func (s *relationUnitSuite) TestReadSettingsInvalidUnitTag(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "\"mysql\" is not a valid unit")
 }
 
+func (s *relationUnitSuite) TestReadRemoteSettings(c *gc.C) {
+	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = myRelUnit.EnterScope(map[string]interface{}{
+		"some": "settings",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertInScope(c, myRelUnit, true)
+
+	_, apiRelUnit := s.getRelationUnits(c)
+	gotSettings, err := apiRelUnit.ReadRemoteSettings([]string{"mysql/0", "mysql/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	// mysql/1 doesn't exist, so its settings are omitted.
+	c.Assert(gotSettings, jc.DeepEquals, map[string]params.Settings{
+		"mysql/0": {"some": "settings"},
+	})
+
+	gotSettings, err = apiRelUnit.ReadRemoteSettings(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotSettings, gc.HasLen, 0)
+
+	_, err = apiRelUnit.ReadRemoteSettings([]string{"mysql"})
+	c.Assert(err, gc.ErrorMatches, "\"mysql\" is not a valid unit")
+}
+
+func (s *relationUnitSuite) TestPeerSeed(c *gc.C) {
+	_, riak, _, riakUnit := s.addMachineAppCharmAndUnit(c, "riak")
+	password, err := utils.RandomPassword()
+	c.Assert(err, jc.ErrorIsNil)
+	err = riakUnit.SetPassword(password)
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.OpenAPIAs(c, riakUnit.Tag(), password)
+	riakUniter, err := st.Uniter()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("riak", "riak/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rels, err := riak.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	apiRelation, err := riakUniter.Relation(rels[0].Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	apiUnit, err := riakUniter.Unit(riakUnit.Tag().(names.UnitTag))
+	c.Assert(err, jc.ErrorIsNil)
+	apiRelUnit, err := apiRelation.Unit(apiUnit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	seed, err := apiRelUnit.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seed, gc.HasLen, 0)
+
+	err = apiRelUnit.UpdatePeerSeed(params.Settings{"token": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	seed, err = apiRelUnit.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seed, gc.DeepEquals, params.Settings{"token": "s3cret"})
+}
+
+func (s *relationUnitSuite) TestPeerSeedNonPeerRelation(c *gc.C) {
+	_, apiRelUnit := s.getRelationUnits(c)
+	_, err := apiRelUnit.PeerSeed()
+	c.Assert(err, gc.ErrorMatches, `seed data for non-peer relation "wordpress:db mysql:server" not valid`)
+	err = apiRelUnit.UpdatePeerSeed(params.Settings{"token": "s3cret"})
+	c.Assert(err, gc.ErrorMatches, `seed data for non-peer relation "wordpress:db mysql:server" not valid`)
+}
+
 func (s *relationUnitSuite) TestWatchRelationUnits(c *gc.C) {
 	// Enter scope with mysqlUnit.
 	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -776,3 +778,107 @@ func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]param
 
 	return results.Results, nil
 }
+
+// HookEnvironment returns the extra environment variables to set for
+// the unit's hook executions, as configured for its model and
+// application.
+func (u *Unit) HookEnvironment() (map[string]string, error) {
+	if u.st.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("hook environment")
+	}
+	var results params.HookEnvironmentResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("HookEnvironment", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Environment, nil
+}
+
+// AcquireCharmLock acquires the named lock, shared by all the units of
+// the unit's application, until the given time to live has passed. If
+// the unit already holds the lock, its expiry time is extended; if
+// another unit holds it, an error satisfying params.IsCodeLockHeld is
+// returned.
+func (u *Unit) AcquireCharmLock(name string, ttl time.Duration) error {
+	if u.st.BestAPIVersion() < 11 {
+		return errors.NotSupportedf("charm locks")
+	}
+	return u.charmLockCall("AcquireCharmLocks", params.CharmLockArg{
+		UnitTag:         u.tag.String(),
+		Name:            name,
+		DurationSeconds: ttl.Seconds(),
+	})
+}
+
+// ReleaseCharmLock releases the named lock, which must be held by the
+// unit.
+func (u *Unit) ReleaseCharmLock(name string) error {
+	if u.st.BestAPIVersion() < 11 {
+		return errors.NotSupportedf("charm locks")
+	}
+	return u.charmLockCall("ReleaseCharmLocks", params.CharmLockArg{
+		UnitTag: u.tag.String(),
+		Name:    name,
+	})
+}
+
+// SetConfigReadyHash records that the unit, which must be the leader
+// of its application, has finished preparing for the charm config
+// settings with the given hash. Followers defer their config-changed
+// hooks until the hash matches their own settings.
+func (u *Unit) SetConfigReadyHash(hash string) error {
+	if u.st.BestAPIVersion() < 13 {
+		return errors.NotSupportedf("config ready hashes")
+	}
+	var result params.ErrorResults
+	args := params.EntityConfigHashes{
+		Entities: []params.EntityConfigHash{{Tag: u.tag.String(), Hash: hash}},
+	}
+	if err := u.st.facade.FacadeCall("SetConfigReadyHashes", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// RelatedApplicationStatuses returns the workload status of each
+// application related to the unit's application, keyed by application
+// name.
+func (u *Unit) RelatedApplicationStatuses() (map[string]params.StatusResult, error) {
+	if u.st.BestAPIVersion() < 14 {
+		return nil, errors.NotSupportedf("related application statuses")
+	}
+	var results params.RelatedApplicationStatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("RelatedApplicationStatuses", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Statuses, nil
+}
+
+func (u *Unit) charmLockCall(method string, arg params.CharmLockArg) error {
+	var result params.ErrorResults
+	args := params.CharmLockArgs{Args: []params.CharmLockArg{arg}}
+	if err := u.st.facade.FacadeCall(method, args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
@@ -505,6 +505,77 @@ func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	c.Assert(called, gc.Equals, 2)
 }
 
+func (s *unitSuite) TestHookEnvironment(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"hook-environment": "SITE_ID=lon1"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressApplication.SetHookEnvironment(map[string]string{"COMPLIANCE": "pci"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	env, err := s.apiUnit.HookEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, jc.DeepEquals, map[string]string{"SITE_ID": "lon1", "COMPLIANCE": "pci"})
+}
+
+func (s *unitSuite) TestCharmLocks(c *gc.C) {
+	other, err := s.wordpressApplication.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.apiUnit.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err := s.wordpressApplication.CharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Holder, gc.Equals, "wordpress/0")
+
+	err = other.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.Satisfies, state.IsCharmLockHeldError)
+
+	err = s.apiUnit.ReleaseCharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.ReleaseCharmLock("migrate")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+
+	err = other.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot acquire lock "migrate": lock "migrate" is held by unit "wordpress/1"`)
+	c.Assert(err, jc.Satisfies, params.IsCodeLockHeld)
+}
+
+func (s *unitSuite) TestSetConfigReadyHash(c *gc.C) {
+	err := s.apiUnit.SetConfigReadyHash("abc")
+	c.Assert(err, gc.ErrorMatches, `cannot set config ready hash of application "wordpress": .*"wordpress/0" is not leader of "wordpress"`)
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.SetConfigReadyHash("abc")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressApplication.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressApplication.ConfigReadyHash(), gc.Equals, "abc")
+}
+
+func (s *unitSuite) TestRelatedApplicationStatuses(c *gc.C) {
+	statuses, err := s.apiUnit.RelatedApplicationStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses, gc.HasLen, 0)
+
+	_, mysqlApplication, _, _ := s.addMachineAppCharmAndUnit(c, "mysql")
+	s.addRelation(c, "wordpress", "mysql")
+	now := time.Now()
+	err = mysqlApplication.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "waiting for storage",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statuses, err = s.apiUnit.RelatedApplicationStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses, gc.HasLen, 1)
+	c.Assert(statuses["mysql"].Status, gc.Equals, "blocked")
+	c.Assert(statuses["mysql"].Info, gc.Equals, "waiting for storage")
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	// Make sure ConfigSettings returns an error when
 	// no charm URL is set, as its state counterpart does.
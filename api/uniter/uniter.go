@@ -289,6 +289,44 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// ActionLog records a progress message for a running action.
+func (st *State) ActionLog(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("logging action messages")
+	}
+	var outcome params.ErrorResults
+	args := params.ActionMessageParams{
+		Messages: []params.ActionMessageParam{{
+			Tag:     tag.String(),
+			Message: message,
+		}},
+	}
+	if err := st.facade.FacadeCall("LogActionsMessages", args, &outcome); err != nil {
+		return errors.Trace(err)
+	}
+	return outcome.OneError()
+}
+
+// ActionProgress records the percentage of a running action that is
+// complete, and a progress message if one is given.
+func (st *State) ActionProgress(tag names.ActionTag, percent int, message string) error {
+	if st.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("reporting action progress")
+	}
+	var outcome params.ErrorResults
+	args := params.ActionProgressParams{
+		Progress: []params.ActionProgressParam{{
+			Tag:     tag.String(),
+			Percent: percent,
+			Message: message,
+		}},
+	}
+	if err := st.facade.FacadeCall("SetActionsProgress", args, &outcome); err != nil {
+		return errors.Trace(err)
+	}
+	return outcome.OneError()
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -407,6 +445,24 @@ func ErrIfNotVersionFn(minVersion int, bestAPIVersion int) func(string) error {
 	}
 }
 
+// CloudSpec returns the cloud spec, including the cloud credential, of
+// the model hosting the unit. The unit's application must have been
+// trusted with the credential.
+func (st *State) CloudSpec() (*params.CloudSpec, error) {
+	if st.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("retrieving cloud credentials")
+	}
+	var result params.CloudSpecResult
+	err := st.facade.FacadeCall("CloudSpec", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := result.Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Result, nil
+}
+
 // SLALevel returns the SLA level set on the model.
 func (st *State) SLALevel() (string, error) {
 	if st.BestAPIVersion() < 5 {
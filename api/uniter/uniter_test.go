@@ -7,9 +7,11 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 )
@@ -136,3 +138,31 @@ func (s *uniterSuite) TestSLALevel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(level, gc.Equals, "essential")
 }
+
+func (s *uniterSuite) TestCloudSpec(c *gc.C) {
+	_, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AllowCredentialGetKey: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressApplication.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.NotNil)
+	c.Assert(spec.Type, gc.Equals, "dummy")
+
+	trust, err := s.wordpressApplication.Trust()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trust.UseCount, gc.Equals, 1)
+	c.Assert(trust.LastUsedBy, gc.Equals, "wordpress/0")
+}
+
+func (s *uniterSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	_, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AllowCredentialGetKey: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
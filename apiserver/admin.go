@@ -133,6 +133,16 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			apiRoot = restrictRoot(apiRoot, migrationClientMethodsOnly)
 		}
 	}
+	if authResult.userLogin && !authResult.controllerOnlyLogin && !isSuperuser(authResult.userInfo) {
+		// Users may not change a frozen model, but controller
+		// superusers may break the glass.
+		apiRoot = restrictRoot(apiRoot, frozenModelReadOnly(a.modelFreeze))
+	}
+	if authResult.userLogin && !authResult.controllerOnlyLogin {
+		// The instances of a suspended model are stopped, so
+		// changes may not be made to it until it is resumed.
+		apiRoot = restrictRoot(apiRoot, suspendedModelReadOnly(a.modelSuspended))
+	}
 
 	loginResult := params.LoginResult{
 		Servers:       params.FromNetworkHostsPorts(hostPorts),
@@ -160,6 +170,30 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	return loginResult, nil
 }
 
+// modelFreeze returns the freeze message of the logged in model, and
+// whether the model is frozen.
+func (a *admin) modelFreeze() (string, bool, error) {
+	model, err := a.root.state.Model()
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	freeze, frozen := model.Freeze()
+	return freeze.Message, frozen, nil
+}
+
+func (a *admin) modelSuspended() (bool, error) {
+	model, err := a.root.state.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	_, suspended := model.Suspension()
+	return suspended, nil
+}
+
+func isSuperuser(userInfo *params.AuthUserInfo) bool {
+	return userInfo != nil && userInfo.ControllerAccess == string(permission.SuperuserAccess)
+}
+
 type authResult struct {
 	anonymousLogin      bool
 	userLogin           bool
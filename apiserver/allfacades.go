@@ -32,29 +32,39 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/resourceshookcontext"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
+	"github.com/juju/juju/apiserver/facades/agent/storageusage"
 	"github.com/juju/juju/apiserver/facades/agent/unitassigner"
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
 	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/facades/client/actionschedules"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
+	"github.com/juju/juju/apiserver/facades/client/changelog"
 	"github.com/juju/juju/apiserver/facades/client/charms"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/controllerhealth"
+	"github.com/juju/juju/apiserver/facades/client/controllertxns"
+	"github.com/juju/juju/apiserver/facades/client/costestimator"
+	"github.com/juju/juju/apiserver/facades/client/description"
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
 	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/modelmanager"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/machinenetworking"
+	"github.com/juju/juju/apiserver/facades/client/metricsdebug" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelconfig"  // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelfreeze"
+	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelsuspension"
 	"github.com/juju/juju/apiserver/facades/client/payloads"
 	"github.com/juju/juju/apiserver/facades/client/resources"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
@@ -68,7 +78,9 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
+	"github.com/juju/juju/apiserver/facades/controller/dnsupdater"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
+	"github.com/juju/juju/apiserver/facades/controller/floatingipupdater"
 	"github.com/juju/juju/apiserver/facades/controller/imagemetadata"
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/facades/controller/lifeflag"
@@ -80,8 +92,10 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
+	"github.com/juju/juju/apiserver/facades/controller/securitygroupgc"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
+	"github.com/juju/juju/apiserver/facades/controller/tagsync"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
@@ -117,6 +131,7 @@ func AllFacades() *facade.Registry {
 
 	reg("Action", 2, action.NewActionAPI)
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionSchedules", 1, actionschedules.NewFacade)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -128,17 +143,31 @@ func AllFacades() *facade.Registry {
 	reg("Application", 2, application.NewFacadeV4)
 	reg("Application", 3, application.NewFacadeV4)
 	reg("Application", 4, application.NewFacadeV4)
-	reg("Application", 5, application.NewFacade) // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
+	reg("Application", 5, application.NewFacadeV5)   // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
+	reg("Application", 6, application.NewFacadeV6)   // adds PreviewAddUnits, GetEffectiveConstraints, {Set,Get}ApplicationsTrust, RelationSettingsUsage & {Set,Get}ApplicationsHookEnvironment
+	reg("Application", 7, application.NewFacadeV7)   // adds DestroyApplication hook timeout & ApplicationRemovalReports
+	reg("Application", 8, application.NewFacadeV8)   // adds ResolveUnitErrors
+	reg("Application", 9, application.NewFacadeV9)   // adds PreviewAddRelation & PreviewDestroyRelation
+	reg("Application", 10, application.NewFacadeV10) // adds {Set,Get}ApplicationsAddressPolicy
+	reg("Application", 11, application.NewFacadeV11) // adds FloatingIP to Expose
+	reg("Application", 12, application.NewFacadeV12) // adds ExposedCIDRs to Expose
+	reg("Application", 13, application.NewFacade)    // adds {Set,Get}ApplicationsLogging
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacade)
+	reg("Bundle", 2, bundle.NewFacadeV2) // adds ExportBundle
+	reg("ChangeLog", 1, changelog.NewFacade)
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
-	reg("Client", 1, client.NewFacade)
+	reg("Client", 1, client.NewFacadeV1)
+	reg("Client", 2, client.NewFacadeV2)
+	reg("Client", 3, client.NewFacadeV3) // adds RetryProvisioningWithOptions
+	reg("Client", 4, client.NewFacadeV4) // adds AddCharmFromGit
+	reg("Client", 5, client.NewFacade)   // adds volume and filesystem status history, ToDate and Offset
 	reg("Cloud", 1, cloud.NewFacade)
 	if featureflag.Enabled(feature.CAAS) {
 		reg("Cloud", 2, cloud.NewFacadeV2)
@@ -146,13 +175,22 @@ func AllFacades() *facade.Registry {
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
+	reg("Controller", 5, controller.NewControllerAPIv5)
+	reg("ControllerHealth", 1, controllerhealth.NewFacade)
+	reg("ControllerTxns", 1, controllertxns.NewFacade)
+	reg("CostEstimator", 1, costestimator.NewFacade)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
+	reg("Description", 1, description.NewFacade)
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("DNSUpdater", 1, dnsupdater.NewFacade)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
+	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5) // adds GetExposedCIDRs
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6) // adds WatchContainers
 	reg("FirewallRules", 1, firewallrules.NewFacade)
+	reg("FloatingIPUpdater", 1, floatingipupdater.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
@@ -174,6 +212,10 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 2, machinemanager.NewFacade)
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Version 3 adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Version 4 adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Version 5 adds ConsoleLogs, MachineConsoles, CloudInstances and TagMachineInstances.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6) // Version 6 adds PendingRemovals.
+	reg("MachineManager", 7, machinemanager.NewFacadeV7) // Version 7 adds image stream and image id to AddMachines.
+	reg("MachineNetworking", 1, machinenetworking.NewFacade)
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPI)
@@ -189,9 +231,14 @@ func AllFacades() *facade.Registry {
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
 
 	reg("ModelConfig", 1, modelconfig.NewFacade)
+	reg("ModelConfig", 2, modelconfig.NewFacadeV2) // Version 2 adds ModelConfigHistory, ModelConfigDiff and ModelConfigRollback.
+	reg("ModelFreeze", 1, modelfreeze.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
+	reg("ModelManager", 5, modelmanager.NewFacadeV5)
+	reg("ModelManager", 6, modelmanager.NewFacadeV6) // adds Force to DestroyModels
+	reg("ModelSuspension", 1, modelsuspension.NewFacade)
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -205,6 +252,7 @@ func AllFacades() *facade.Registry {
 	reg("Provisioner", 3, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 4, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 5, provisioner.NewProvisionerAPIV5) // v5 adds DistributionGroupByMachineId()
+	reg("Provisioner", 6, provisioner.NewProvisionerAPIV6) // v6 adds MachinesWithTransientErrorsPage()
 	reg("ProxyUpdater", 1, proxyupdater.NewAPI)
 	reg("Reboot", 2, reboot.NewRebootAPI)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
@@ -218,10 +266,12 @@ func AllFacades() *facade.Registry {
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("SecurityGroupGC", 1, securitygroupgc.NewFacade)
 	reg("Singular", 1, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
 	reg("SSHClient", 2, sshclient.NewFacade) // v2 adds AllAddresses() method.
+	reg("SSHClient", 3, sshclient.NewFacade) // v3 adds HostMachine() method.
 
 	reg("Spaces", 2, spaces.NewAPIV2)
 	reg("Spaces", 3, spaces.NewAPI)
@@ -230,17 +280,30 @@ func AllFacades() *facade.Registry {
 
 	reg("Storage", 3, storage.NewFacadeV3)
 	reg("Storage", 4, storage.NewFacadeV4) // changes Destroy() method signature.
+	reg("Storage", 5, storage.NewFacadeV5) // adds Grow.
+	reg("Storage", 6, storage.NewFacadeV6) // adds CreateSnapshots, ListSnapshots and RestoreSnapshots.
+	reg("Storage", 7, storage.NewFacadeV7) // adds WatchVolumeAttachments and WatchFilesystemAttachments.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5) // adds WatchVolumeResizes and ResizeVolumeParams
+	reg("StorageUsage", 1, storageusage.NewFacade)
 	reg("Subnets", 2, subnets.NewAPI)
+	reg("TagSync", 1, tagsync.NewFacade)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
 	reg("Uniter", 6, uniter.NewUniterAPIV6)
-	reg("Uniter", 7, uniter.NewUniterAPI)
+	reg("Uniter", 7, uniter.NewUniterAPIV7)
+	reg("Uniter", 8, uniter.NewUniterAPIV8)   // adds CloudSpec & HookEnvironment
+	reg("Uniter", 9, uniter.NewUniterAPIV9)   // adds LogActionsMessages & SetActionsProgress
+	reg("Uniter", 10, uniter.NewUniterAPIV10) // adds PeerSeeds & UpdatePeerSeeds
+	reg("Uniter", 11, uniter.NewUniterAPIV11) // adds AcquireCharmLocks & ReleaseCharmLocks
+	reg("Uniter", 12, uniter.NewUniterAPIV12) // adds FloatingIPUnits
+	reg("Uniter", 13, uniter.NewUniterAPIV13) // adds ConfigReadyHashes & SetConfigReadyHashes
+	reg("Uniter", 14, uniter.NewUniterAPI)    // adds RelatedApplicationStatuses
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
@@ -64,6 +64,9 @@ const (
 	defaultConnUpperThreshold     = 100000 // connections per second
 	defaultLogSinkRateLimitBurst  = 1000
 	defaultLogSinkRateLimitRefill = time.Millisecond
+	defaultMaxConcurrentRequests  = 200 // concurrent API requests
+	defaultAgentRequestWeight     = 4
+	defaultClientRequestWeight    = 1
 )
 
 // Server holds the server side of the API.
@@ -96,6 +99,9 @@ type Server struct {
 	logSinkWriter          io.WriteCloser
 	logsinkRateLimitConfig logsink.RateLimitConfig
 	dbloggers              dbloggers
+	appLogWriters          appLogWriters
+	tunnels                *tunnelRegistry
+	requests               *requestScheduler
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -218,6 +224,17 @@ type RateLimitConfig struct {
 	ConnLookbackWindow time.Duration
 	ConnLowerThreshold int
 	ConnUpperThreshold int
+
+	// MaxConcurrentRequests is the number of API requests that
+	// are served concurrently; further requests wait for a slot.
+	// Zero means that requests never wait.
+	MaxConcurrentRequests int
+
+	// AgentRequestWeight and ClientRequestWeight determine the
+	// ratio in which waiting requests from agents and from users
+	// are admitted when all request slots are in use.
+	AgentRequestWeight  int
+	ClientRequestWeight int
 }
 
 // DefaultRateLimitConfig returns a RateLimtConfig struct with
@@ -233,6 +250,10 @@ func DefaultRateLimitConfig() RateLimitConfig {
 		ConnLookbackWindow: defaultConnLookbackWindow,
 		ConnLowerThreshold: defaultConnLowerThreshold,
 		ConnUpperThreshold: defaultConnUpperThreshold,
+
+		MaxConcurrentRequests: defaultMaxConcurrentRequests,
+		AgentRequestWeight:    defaultAgentRequestWeight,
+		ClientRequestWeight:   defaultClientRequestWeight,
 	}
 }
 
@@ -261,6 +282,15 @@ func (c RateLimitConfig) Validate() error {
 	if c.ConnLookbackWindow < 0 || c.ConnLookbackWindow > 5*time.Second {
 		return errors.NotValidf("conn-lookback-window %d < 0 or > 5s", c.ConnMaxPause)
 	}
+	if c.MaxConcurrentRequests < 0 || c.MaxConcurrentRequests > 10000 {
+		return errors.NotValidf("max-concurrent-requests %d < 0 or > 10000", c.MaxConcurrentRequests)
+	}
+	if c.AgentRequestWeight <= 0 || c.AgentRequestWeight > 100 {
+		return errors.NotValidf("agent-request-weight %d <= 0 or > 100", c.AgentRequestWeight)
+	}
+	if c.ClientRequestWeight <= 0 || c.ClientRequestWeight > 100 {
+		return errors.NotValidf("client-request-weight %d <= 0 or > 100", c.ClientRequestWeight)
+	}
 	return nil
 }
 
@@ -374,6 +404,12 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 			dbLoggerBufferSize:    cfg.LogSinkConfig.DBLoggerBufferSize,
 			dbLoggerFlushInterval: cfg.LogSinkConfig.DBLoggerFlushInterval,
 		},
+		tunnels: newTunnelRegistry(),
+		requests: newRequestScheduler(
+			cfg.RateLimitConfig.MaxConcurrentRequests,
+			cfg.RateLimitConfig.AgentRequestWeight,
+			cfg.RateLimitConfig.ClientRequestWeight,
+		),
 	}
 
 	srv.tlsConfig = srv.newTLSConfig(cfg)
@@ -401,6 +437,7 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		return nil, errors.Annotate(err, "creating logsink writer")
 	}
 	srv.logSinkWriter = logSinkWriter
+	srv.appLogWriters.logDir = filepath.Join(srv.logDir, "applications")
 
 	if cfg.PrometheusRegisterer != nil {
 		apiserverCollectior := NewMetricsCollector(&metricAdaptor{srv})
@@ -434,6 +471,19 @@ func (a *metricAdaptor) ConnectionPauseTime() time.Duration {
 	return a.srv.lis.(*throttlingListener).pauseTime()
 }
 
+func (a *metricAdaptor) RequestStats() RequestStats {
+	active, waiting, queued := a.srv.requests.stats()
+	stats := RequestStats{
+		Active:  int64(active),
+		Waiting: make(map[string]int64),
+		Queued:  queued,
+	}
+	for priority, n := range waiting {
+		stats.Waiting[priority.String()] = int64(n)
+	}
+	return stats
+}
+
 func (srv *Server) newTLSConfig(cfg ServerConfig) *tls.Config {
 	tlsConfig := utils.SecureTLSConfig()
 	if cfg.AutocertDNSName == "" {
@@ -540,6 +590,7 @@ func (srv *Server) run() {
 		srv.wg.Wait() // wait for any outstanding requests to complete.
 		srv.tomb.Done()
 		srv.dbloggers.dispose()
+		srv.appLogWriters.dispose()
 		srv.logSinkWriter.Close()
 	}()
 
@@ -632,9 +683,13 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/pubsub", pubsubHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/console", srv.trackRequests(newConsoleHandler(httpCtxt)))
+	add("/model/:modeluuid/tunnel", srv.trackRequests(newTunnelChannelHandler(httpCtxt)))
+	add("/model/:modeluuid/tunnel/accept", srv.trackRequests(newTunnelAcceptHandler(httpCtxt)))
+	add("/model/:modeluuid/machine-tunnel", srv.trackRequests(newMachineTunnelHandler(httpCtxt)))
 
 	logSinkHandler := logsink.NewHTTPHandler(
-		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
+		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers, &srv.appLogWriters),
 		httpCtxt.stop(),
 		&srv.logsinkRateLimitConfig,
 	)
@@ -730,9 +785,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			stateAuthFunc: httpCtxt.stateForMigrationImporting,
 		},
 	)
+	toolsDeltas := newToolsDeltaCache(maxCachedToolsDeltas)
 	add("/model/:modeluuid/tools/:version",
 		&toolsDownloadHandler{
-			ctxt: httpCtxt,
+			ctxt:   httpCtxt,
+			deltas: toolsDeltas,
 		},
 	)
 	add("/model/:modeluuid/backups",
@@ -763,7 +820,8 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	)
 	add("/tools/:version",
 		&toolsDownloadHandler{
-			ctxt: httpCtxt,
+			ctxt:   httpCtxt,
+			deltas: toolsDeltas,
 		},
 	)
 	add("/register",
@@ -19,6 +19,22 @@ type ServerMetricsSource interface {
 	ConnectionCount() int64
 	ConcurrentLoginAttempts() int64
 	ConnectionPauseTime() time.Duration
+	RequestStats() RequestStats
+}
+
+// RequestStats describes the API requests being served, and those
+// waiting for a request slot.
+type RequestStats struct {
+	// Active is the number of requests being served.
+	Active int64
+
+	// Waiting holds the number of requests waiting for a request
+	// slot, keyed by priority class.
+	Waiting map[string]int64
+
+	// Queued is the total number of requests that have ever had
+	// to wait for a request slot.
+	Queued int64
 }
 
 // Collector is a prometheus.Collector that collects metrics based
@@ -30,6 +46,9 @@ type Collector struct {
 	connectionCountGauge     prometheus.Gauge
 	connectionPauseTimeGauge prometheus.Gauge
 	concurrentLoginsGauge    prometheus.Gauge
+	activeRequestsGauge      prometheus.Gauge
+	waitingRequestsGauge     *prometheus.GaugeVec
+	queuedRequestsCounter    prometheus.Counter
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -56,6 +75,21 @@ func NewMetricsCollector(src ServerMetricsSource) *Collector {
 			Name:      "active_login_attempts",
 			Help:      "Current number of active agent login attempts",
 		}),
+		activeRequestsGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "active_requests",
+			Help:      "Current number of API requests being served",
+		}),
+		waitingRequestsGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "waiting_requests",
+			Help:      "Current number of API requests waiting for a request slot, by priority",
+		}, []string{"priority"}),
+		queuedRequestsCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "queued_requests_total",
+			Help:      "Total number of API requests that have had to wait for a request slot",
+		}),
 	}
 }
 
@@ -65,6 +99,9 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.connectionCountGauge.Describe(ch)
 	c.connectionPauseTimeGauge.Describe(ch)
 	c.concurrentLoginsGauge.Describe(ch)
+	c.activeRequestsGauge.Describe(ch)
+	c.waitingRequestsGauge.Describe(ch)
+	c.queuedRequestsCounter.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -72,6 +109,11 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.connectionCountGauge.Set(float64(c.src.ConnectionCount()))
 	c.connectionPauseTimeGauge.Set(float64(c.src.ConnectionPauseTime()) / float64(time.Second))
 	c.concurrentLoginsGauge.Set(float64(c.src.ConcurrentLoginAttempts()))
+	requests := c.src.RequestStats()
+	c.activeRequestsGauge.Set(float64(requests.Active))
+	for priority, n := range requests.Waiting {
+		c.waitingRequestsGauge.WithLabelValues(priority).Set(float64(n))
+	}
 
 	ch <- prometheus.MustNewConstMetric(
 		c.connectionCounter.Desc(),
@@ -81,4 +123,11 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.connectionCountGauge.Collect(ch)
 	c.connectionPauseTimeGauge.Collect(ch)
 	c.concurrentLoginsGauge.Collect(ch)
+	c.activeRequestsGauge.Collect(ch)
+	c.waitingRequestsGauge.Collect(ch)
+	ch <- prometheus.MustNewConstMetric(
+		c.queuedRequestsCounter.Desc(),
+		prometheus.CounterValue,
+		float64(requests.Queued),
+	)
 }
@@ -37,11 +37,14 @@ func (s *apiservermetricsSuite) TestDescribe(c *gc.C) {
 	for desc := range ch {
 		descs = append(descs, desc)
 	}
-	c.Assert(descs, gc.HasLen, 4)
+	c.Assert(descs, gc.HasLen, 7)
 	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_apiserver_connections_total".*`)
 	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_apiserver_connection_count".*`)
 	c.Assert(descs[2].String(), gc.Matches, `.*fqName: "juju_apiserver_connection_pause_seconds".*`)
 	c.Assert(descs[3].String(), gc.Matches, `.*fqName: "juju_apiserver_active_login_attempts".*`)
+	c.Assert(descs[4].String(), gc.Matches, `.*fqName: "juju_apiserver_active_requests".*`)
+	c.Assert(descs[5].String(), gc.Matches, `.*fqName: "juju_apiserver_waiting_requests".*`)
+	c.Assert(descs[6].String(), gc.Matches, `.*fqName: "juju_apiserver_queued_requests_total".*`)
 }
 
 func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
@@ -55,9 +58,9 @@ func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
 	for metric := range ch {
 		metrics = append(metrics, metric)
 	}
-	c.Assert(metrics, gc.HasLen, 4)
+	c.Assert(metrics, gc.HasLen, 7)
 
-	var dtoMetrics [4]dto.Metric
+	var dtoMetrics [7]dto.Metric
 	for i, metric := range metrics {
 		err := metric.Write(&dtoMetrics[i])
 		c.Assert(err, jc.ErrorIsNil)
@@ -66,11 +69,20 @@ func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
 	float64ptr := func(v float64) *float64 {
 		return &v
 	}
-	c.Assert(dtoMetrics, jc.DeepEquals, [4]dto.Metric{
+	stringptr := func(v string) *string {
+		return &v
+	}
+	c.Assert(dtoMetrics, jc.DeepEquals, [7]dto.Metric{
 		{Counter: &dto.Counter{Value: float64ptr(200)}},
 		{Gauge: &dto.Gauge{Value: float64ptr(2)}},
 		{Gauge: &dto.Gauge{Value: float64ptr(0.02)}},
 		{Gauge: &dto.Gauge{Value: float64ptr(3)}},
+		{Gauge: &dto.Gauge{Value: float64ptr(150)}},
+		{
+			Label: []*dto.LabelPair{{Name: stringptr("priority"), Value: stringptr("client")}},
+			Gauge: &dto.Gauge{Value: float64ptr(12)},
+		},
+		{Counter: &dto.Counter{Value: float64ptr(40)}},
 	})
 }
 
@@ -91,3 +103,11 @@ func (a *stubCollector) ConcurrentLoginAttempts() int64 {
 func (a *stubCollector) ConnectionPauseTime() time.Duration {
 	return 20 * time.Millisecond
 }
+
+func (a *stubCollector) RequestStats() apiserver.RequestStats {
+	return apiserver.RequestStats{
+		Active:  150,
+		Waiting: map[string]int64{"client": 12},
+		Queued:  40,
+	}
+}
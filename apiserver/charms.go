@@ -24,6 +24,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/charmcheck"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
@@ -216,6 +217,25 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 		}
 	}
 
+	// The git source of a charm built from a git repository is
+	// only given when the charm is migrated with its model.
+	var gitSource *state.CharmGitSource
+	if repository := query.Get("git-repository"); repository != "" {
+		if isImporting, err := modelIsImporting(st); err != nil {
+			return nil, errors.Trace(err)
+		} else if !isImporting {
+			return nil, errors.BadRequestf("git sources may only be given during model migration import")
+		}
+		if schema != "local" {
+			return nil, errors.BadRequestf("git sources may only be given for local charms")
+		}
+		gitSource = &state.CharmGitSource{
+			Repository: repository,
+			Ref:        query.Get("git-ref"),
+			Revision:   query.Get("git-revision"),
+		}
+	}
+
 	// Make sure the content type is zip.
 	contentType := r.Header.Get("Content-Type")
 	if contentType != "application/zip" {
@@ -232,6 +252,9 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	if err != nil {
 		return nil, err
 	}
+	if err := charmcheck.CheckArchive(charmFileName); err != nil {
+		return nil, errors.NewBadRequest(err, "")
+	}
 	archive, err := charm.ReadCharmArchive(charmFileName)
 	if err != nil {
 		return nil, errors.BadRequestf("invalid charm archive: %v", err)
@@ -290,7 +313,7 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 
 	// Now we need to repackage it with the reserved URL, upload it to
 	// provider storage and update the state.
-	err = h.repackageAndUploadCharm(st, archive, curl)
+	err = h.repackageAndUploadCharm(st, archive, curl, gitSource)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -386,8 +409,9 @@ func (d byDepth) Less(i, j int) bool { return depth(d[i]) < depth(d[j]) }
 
 // repackageAndUploadCharm expands the given charm archive to a
 // temporary directoy, repackages it with the given curl's revision,
-// then uploads it to storage, and finally updates the state.
-func (h *charmsHandler) repackageAndUploadCharm(st *state.State, archive *charm.CharmArchive, curl *charm.URL) error {
+// then uploads it to storage, and finally updates the state, recording
+// the git source of the charm if it is not nil.
+func (h *charmsHandler) repackageAndUploadCharm(st *state.State, archive *charm.CharmArchive, curl *charm.URL, gitSource *state.CharmGitSource) error {
 	// Create a temp dir to contain the extracted charm dir.
 	tempDir, err := ioutil.TempDir("", "charm-download")
 	if err != nil {
@@ -416,11 +440,12 @@ func (h *charmsHandler) repackageAndUploadCharm(st *state.State, archive *charm.
 	bundleSHA256 := hex.EncodeToString(hash.Sum(nil))
 
 	info := application.CharmArchive{
-		ID:     curl,
-		Charm:  archive,
-		Data:   &repackagedArchive,
-		Size:   int64(repackagedArchive.Len()),
-		SHA256: bundleSHA256,
+		ID:        curl,
+		Charm:     archive,
+		Data:      &repackagedArchive,
+		Size:      int64(repackagedArchive.Len()),
+		SHA256:    bundleSHA256,
+		GitSource: gitSource,
 	}
 	// Store the charm archive in environment storage.
 	return application.StoreCharmArchive(st, info)
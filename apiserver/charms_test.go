@@ -4,6 +4,7 @@
 package apiserver_test
 
 import (
+	"archive/zip"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -167,6 +168,25 @@ func (s *charmsSuite) TestUploadFailsWithInvalidZip(c *gc.C) {
 	s.assertErrorResponse(c, resp, http.StatusBadRequest, ".*expected Content-Type: application/zip, got: application/octet-stream$")
 }
 
+func (s *charmsSuite) TestUploadRejectsBrokenCharm(c *gc.C) {
+	tempFile, err := ioutil.TempFile(c.MkDir(), "charm")
+	c.Assert(err, jc.ErrorIsNil)
+	defer tempFile.Close()
+	zipw := zip.NewWriter(tempFile)
+	w, err := zipw.Create("metadata.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte("name: broken\nsummary: broken\ndescription: broken\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	header := &zip.FileHeader{Name: "hooks/install"}
+	header.SetMode(0644)
+	_, err = zipw.CreateHeader(header)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zipw.Close(), jc.ErrorIsNil)
+
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", tempFile.Name())
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `invalid charm: hooks/install: hook is not executable .*`)
+}
+
 func (s *charmsSuite) TestUploadBumpsRevision(c *gc.C) {
 	// Add the dummy charm with revision 1.
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
@@ -442,6 +462,48 @@ func (s *charmsSuite) TestMigrateCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *charmsSuite) TestMigrateCharmGitSource(c *gc.C) {
+	controllerTag := names.NewControllerTag(s.ControllerConfig.ControllerUUID())
+	_, err := s.State.SetUserAccess(s.userTag, controllerTag, permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newSt := s.Factory.MakeModel(c, nil)
+	defer newSt.Close()
+	importedModel, err := newSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = importedModel.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+	s.extraHeaders = map[string]string{
+		params.MigrationModelHTTPHeader: importedModel.UUID(),
+	}
+
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	url := s.charmsURL(c, "series=quantal&git-repository=git%2Bssh%3A%2F%2Fgit%40example.com%2Fdummy.git&git-ref=stable&git-revision=0123456789abcdef")
+	url.Path = "/migrate/charms"
+	resp := s.uploadRequest(c, url.String(), "application/zip", ch.Path)
+	expectedURL := charm.MustParseURL("local:quantal/dummy-1")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+
+	sch, err := newSt.Charm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	source, ok := sch.GitSource()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(source, jc.DeepEquals, state.CharmGitSource{
+		Repository: "git+ssh://git@example.com/dummy.git",
+		Ref:        "stable",
+		Revision:   "0123456789abcdef",
+	})
+}
+
+func (s *charmsSuite) TestUploadCharmGitSourceNotMigrating(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal&git-repository=git%2Bssh%3A%2F%2Fgit%40example.com%2Fdummy.git"), "application/zip", ch.Path)
+	s.assertErrorResponse(
+		c, resp, http.StatusBadRequest,
+		"cannot upload charm: git sources may only be given during model migration import",
+	)
+}
+
 func (s *charmsSuite) TestMigrateCharmNotMigrating(c *gc.C) {
 	controllerTag := names.NewControllerTag(s.ControllerConfig.ControllerUUID())
 	_, err := s.State.SetUserAccess(s.userTag, controllerTag, permission.SuperuserAccess)
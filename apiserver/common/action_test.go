@@ -185,6 +185,52 @@ func (s *actionsSuite) TestFinishActions(c *gc.C) {
 	})
 }
 
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionMessageParams{
+		Messages: []params.ActionMessageParam{
+			{Tag: "success", Message: "hello"},
+			{Tag: "notfound"},
+			{Tag: "logFail", Message: "hello"},
+		},
+	}
+	expectErr := errors.New("explosivo")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{},
+		"logFail": fakeAction{logErr: expectErr},
+	})
+	results := common.LogActionsMessages(args, actionFn)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(actionNotFoundErr)},
+			{common.ServerError(expectErr)},
+		},
+	})
+}
+
+func (s *actionsSuite) TestSetActionsProgress(c *gc.C) {
+	args := params.ActionProgressParams{
+		Progress: []params.ActionProgressParam{
+			{Tag: "success", Percent: 50},
+			{Tag: "notfound"},
+			{Tag: "progressFail", Percent: 50, Message: "hello"},
+		},
+	}
+	expectErr := errors.New("explosivo")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success":      fakeAction{},
+		"progressFail": fakeAction{logErr: expectErr},
+	})
+	results := common.SetActionsProgress(args, actionFn)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(actionNotFoundErr)},
+			{common.ServerError(expectErr)},
+		},
+	})
+}
+
 func (s *actionsSuite) TestWatchActionNotifications(c *gc.C) {
 	args := entities("invalid-actionreceiver", "machine-1", "machine-2", "machine-3")
 	canAccess := makeCanAccess(map[names.Tag]bool{
@@ -334,6 +380,7 @@ type fakeAction struct {
 	name      string
 	beginErr  error
 	finishErr error
+	logErr    error
 	status    state.ActionStatus
 }
 
@@ -361,6 +408,14 @@ func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
 
+func (mock fakeAction) Log(string) error {
+	return mock.logErr
+}
+
+func (mock fakeAction) SetProgress(int, string) error {
+	return mock.logErr
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
@@ -21,10 +21,18 @@ type AddressAndCertGetter interface {
 	WatchAPIHostPorts() state.NotifyWatcher
 }
 
+// AgentAddressAndCertGetter is an AddressAndCertGetter that can also
+// report the controller addresses that agents should use.
+type AgentAddressAndCertGetter interface {
+	AddressAndCertGetter
+	APIHostPortsForAgents() ([][]network.HostPort, error)
+}
+
 // APIAddresser implements the APIAddresses method
 type APIAddresser struct {
 	resources facade.Resources
 	getter    AddressAndCertGetter
+	hostPorts APIHostPortsGetter
 }
 
 // NewAPIAddresser returns a new APIAddresser that uses the given getter to
@@ -33,12 +41,35 @@ func NewAPIAddresser(getter AddressAndCertGetter, resources facade.Resources) *A
 	return &APIAddresser{
 		getter:    getter,
 		resources: resources,
+		hostPorts: getter,
+	}
+}
+
+// NewAgentAPIAddresser returns a new APIAddresser for use by agents,
+// which reports only the addresses that agents should use to connect
+// to the controller.
+func NewAgentAPIAddresser(getter AgentAddressAndCertGetter, resources facade.Resources) *APIAddresser {
+	return &APIAddresser{
+		getter:    getter,
+		resources: resources,
+		hostPorts: agentHostPortsGetter{getter},
 	}
 }
 
+// agentHostPortsGetter adapts an AgentAddressAndCertGetter to report
+// the agent addresses as its API host ports.
+type agentHostPortsGetter struct {
+	getter AgentAddressAndCertGetter
+}
+
+// APIHostPorts is part of the APIHostPortsGetter interface.
+func (g agentHostPortsGetter) APIHostPorts() ([][]network.HostPort, error) {
+	return g.getter.APIHostPortsForAgents()
+}
+
 // APIHostPorts returns the API server addresses.
 func (api *APIAddresser) APIHostPorts() (params.APIHostPortsResult, error) {
-	servers, err := api.getter.APIHostPorts()
+	servers, err := api.hostPorts.APIHostPorts()
 	if err != nil {
 		return params.APIHostPortsResult{}, err
 	}
@@ -60,7 +91,7 @@ func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
 
 // APIAddresses returns the list of addresses used to connect to the API.
 func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
-	addrs, err := apiAddresses(api.getter)
+	addrs, err := apiAddresses(api.hostPorts)
 	if err != nil {
 		return params.StringsResult{}, err
 	}
@@ -36,6 +36,9 @@ func (s *stateAddresserSuite) SetUpTest(c *gc.C) {
 // Verify that AddressAndCertGetter is satisfied by *state.State.
 var _ common.AddressAndCertGetter = (*state.State)(nil)
 
+// Verify that AgentAddressAndCertGetter is satisfied by *state.State.
+var _ common.AgentAddressAndCertGetter = (*state.State)(nil)
+
 func (s *stateAddresserSuite) TestStateAddresses(c *gc.C) {
 	result, err := s.addresser.StateAddresses()
 	c.Assert(err, jc.ErrorIsNil)
@@ -88,6 +91,22 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) TestAgentAPIAddresses(c *gc.C) {
+	s.fake.agentHostPorts = [][]network.HostPort{
+		network.NewHostPorts(1, "agentaddresses"),
+	}
+	addresser := common.NewAgentAPIAddresser(s.fake, common.NewResources())
+
+	result, err := addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.DeepEquals, []string{"agentaddresses:1"})
+
+	hostPorts, err := addresser.APIHostPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hostPorts.Servers, gc.HasLen, 1)
+	c.Assert(hostPorts.Servers[0][0].Value, gc.Equals, "agentaddresses")
+}
+
 func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	result := s.addresser.CACert()
 	c.Assert(string(result.Result), gc.Equals, "a cert")
@@ -101,7 +120,8 @@ func (s *apiAddresserSuite) TestModelUUID(c *gc.C) {
 var _ common.AddressAndCertGetter = fakeAddresses{}
 
 type fakeAddresses struct {
-	hostPorts [][]network.HostPort
+	hostPorts      [][]network.HostPort
+	agentHostPorts [][]network.HostPort
 }
 
 func (fakeAddresses) Addresses() ([]string, error) {
@@ -120,6 +140,10 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 	return f.hostPorts, nil
 }
 
+func (f fakeAddresses) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	return f.agentHostPorts, nil
+}
+
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
@@ -0,0 +1,264 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmcheck statically analyses charm archives as they are
+// added to a model, so that broken charms are rejected with actionable
+// errors, rather than failing in the uniter at install time.
+package charmcheck
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+)
+
+// MaxArchiveSize is the size, in bytes, of the largest charm archive
+// that is accepted. Large artifacts should be delivered as resources.
+var MaxArchiveSize int64 = 100 << 20
+
+// Error describes the problems found in a charm archive.
+type Error struct {
+	Problems []string
+}
+
+// Error is part of the error interface.
+func (e *Error) Error() string {
+	if len(e.Problems) == 1 {
+		return "invalid charm: " + e.Problems[0]
+	}
+	return fmt.Sprintf("invalid charm: %d problems found:\n  - %s",
+		len(e.Problems), strings.Join(e.Problems, "\n  - "),
+	)
+}
+
+// IsError reports whether the cause of err is an *Error.
+func IsError(err error) bool {
+	_, ok := errors.Cause(err).(*Error)
+	return ok
+}
+
+// CheckArchive checks the charm archive at the specified path, which
+// must have the charm's files at the root of the archive. If any
+// problems are found, an *Error describing all of them is returned.
+func CheckArchive(archivePath string) error {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.Size() > MaxArchiveSize {
+		return &Error{[]string{fmt.Sprintf(
+			"archive is %d bytes, larger than the maximum of %d bytes; "+
+				"deliver large files as resources instead",
+			info.Size(), MaxArchiveSize,
+		)}}
+	}
+	zipr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return &Error{[]string{fmt.Sprintf("cannot open archive: %v", err)}}
+	}
+	defer zipr.Close()
+
+	c := checker{files: make(map[string]*zip.File)}
+	for _, f := range zipr.File {
+		c.checkFile(f)
+	}
+	c.checkMetadata()
+	c.checkHooks()
+	c.checkActions()
+	if len(c.problems) > 0 {
+		return &Error{c.problems}
+	}
+	return nil
+}
+
+// checker accumulates the problems found in a charm archive.
+type checker struct {
+	files    map[string]*zip.File
+	problems []string
+}
+
+func (c *checker) problem(format string, args ...interface{}) {
+	c.problems = append(c.problems, fmt.Sprintf(format, args...))
+}
+
+// checkFile checks that the archive entry is not forbidden, and
+// records it for the later checks.
+func (c *checker) checkFile(f *zip.File) {
+	name := strings.TrimSuffix(f.Name, "/")
+	if path.IsAbs(name) || strings.Contains(name, "\\") || escapesRoot(name) {
+		c.problem("%s: path is outside the charm directory", f.Name)
+		return
+	}
+	mode := f.Mode()
+	switch {
+	case mode&(os.ModeDevice|os.ModeNamedPipe|os.ModeSocket|os.ModeCharDevice) != 0:
+		c.problem("%s: special files are not allowed", name)
+	case mode&(os.ModeSetuid|os.ModeSetgid) != 0:
+		c.problem("%s: setuid and setgid files are not allowed", name)
+	case mode&os.ModeSymlink != 0:
+		target, err := readFile(f)
+		if err != nil {
+			c.problem("%s: cannot read symlink: %v", name, err)
+		} else if path.IsAbs(target) || escapesRoot(path.Join(path.Dir(name), target)) {
+			c.problem("%s: symlink target %q is outside the charm directory", name, target)
+		}
+	}
+	c.files[path.Clean(name)] = f
+}
+
+// escapesRoot reports whether the relative path refers to a location
+// outside the directory it is relative to.
+func escapesRoot(name string) bool {
+	name = path.Clean(name)
+	return name == ".." || strings.HasPrefix(name, "../")
+}
+
+// checkMetadata checks that the charm's metadata and config are valid.
+func (c *checker) checkMetadata() {
+	f, ok := c.files["metadata.yaml"]
+	if !ok {
+		c.problem("metadata.yaml: file not found")
+	} else if err := readWith(f, func(r io.Reader) error {
+		_, err := charm.ReadMeta(r)
+		return err
+	}); err != nil {
+		c.problem("metadata.yaml: %v", err)
+	}
+	if f, ok := c.files["config.yaml"]; ok {
+		if err := readWith(f, func(r io.Reader) error {
+			_, err := charm.ReadConfig(r)
+			return err
+		}); err != nil {
+			c.problem("config.yaml: %v", err)
+		}
+	}
+}
+
+// checkHooks checks that each hook is an executable file. Other files
+// in the hooks directory, such as libraries, are not checked.
+func (c *checker) checkHooks() {
+	for _, name := range c.sortedNames() {
+		if path.Dir(name) != "hooks" || !isHook(path.Base(name)) {
+			continue
+		}
+		c.checkExecutable(name, "hook")
+	}
+}
+
+// isHook reports whether the file name is that of a unit, relation or
+// storage hook.
+func isHook(name string) bool {
+	for _, kind := range hooks.UnitHooks() {
+		if name == string(kind) {
+			return true
+		}
+	}
+	for _, kind := range hooks.RelationHooks() {
+		if strings.HasSuffix(name, "-"+string(kind)) {
+			return true
+		}
+	}
+	return strings.HasSuffix(name, "-storage-attached") ||
+		strings.HasSuffix(name, "-storage-detaching")
+}
+
+// checkActions checks that the implementation of each action declared
+// in actions.yaml, if present, is an executable file.
+func (c *checker) checkActions() {
+	f, ok := c.files["actions.yaml"]
+	if !ok {
+		return
+	}
+	var actions *charm.Actions
+	if err := readWith(f, func(r io.Reader) error {
+		var err error
+		actions, err = charm.ReadActionsYaml(r)
+		return err
+	}); err != nil {
+		c.problem("actions.yaml: %v", err)
+		return
+	}
+	declared := set.NewStrings()
+	for name := range actions.ActionSpecs {
+		declared.Add(name)
+	}
+	for _, name := range declared.SortedValues() {
+		filename := path.Join("actions", name)
+		if _, ok := c.files[filename]; !ok {
+			continue
+		}
+		c.checkExecutable(filename, "action")
+	}
+}
+
+// checkExecutable checks that the named file, following any symlinks,
+// is an executable regular file.
+func (c *checker) checkExecutable(name, kind string) {
+	f := c.files[name]
+	origin := name
+	seen := set.NewStrings()
+	for f.Mode()&os.ModeSymlink != 0 {
+		if seen.Contains(name) {
+			c.problem("%s: %s symlink loop", origin, kind)
+			return
+		}
+		seen.Add(name)
+		target, err := readFile(f)
+		if err != nil {
+			// Reported by checkFile.
+			return
+		}
+		name = path.Clean(path.Join(path.Dir(name), target))
+		var ok bool
+		if f, ok = c.files[name]; !ok {
+			c.problem("%s: %s symlink target %q not found", origin, kind, target)
+			return
+		}
+	}
+	if f.Mode().IsDir() {
+		c.problem("%s: %s is a directory", origin, kind)
+		return
+	}
+	if f.Mode()&0111 == 0 {
+		c.problem("%s: %s is not executable (set the executable bit with \"chmod +x\")", origin, kind)
+	}
+}
+
+func (c *checker) sortedNames() []string {
+	names := make([]string, 0, len(c.files))
+	for name := range c.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readWith calls read with the contents of the archive entry.
+func readWith(f *zip.File, read func(io.Reader) error) error {
+	r, err := f.Open()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	return read(r)
+}
+
+func readFile(f *zip.File) (string, error) {
+	var data []byte
+	err := readWith(f, func(r io.Reader) error {
+		var err error
+		data, err = ioutil.ReadAll(r)
+		return err
+	})
+	return string(data), err
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmcheck_test
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/charmcheck"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type charmCheckSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&charmCheckSuite{})
+
+const validMetadata = `
+name: dummy
+summary: a dummy charm
+description: a dummy charm
+provides:
+  db:
+    interface: mysql
+`
+
+type archiveFile struct {
+	name    string
+	mode    os.FileMode
+	content string
+}
+
+func (s *charmCheckSuite) writeArchive(c *gc.C, files ...archiveFile) string {
+	path := filepath.Join(c.MkDir(), "charm.zip")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	zipw := zip.NewWriter(f)
+	for _, file := range files {
+		header := &zip.FileHeader{Name: file.name}
+		header.SetMode(file.mode)
+		w, err := zipw.CreateHeader(header)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = w.Write([]byte(file.content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(zipw.Close(), jc.ErrorIsNil)
+	return path
+}
+
+func validFiles() []archiveFile {
+	return []archiveFile{
+		{name: "metadata.yaml", mode: 0644, content: validMetadata},
+		{name: "config.yaml", mode: 0644, content: "options:\n  title:\n    type: string\n"},
+		{name: "actions.yaml", mode: 0644, content: "snapshot:\n  description: take a snapshot\n"},
+		{name: "hooks/", mode: os.ModeDir | 0755},
+		{name: "hooks/install", mode: 0755, content: "#!/bin/sh\n"},
+		{name: "hooks/db-relation-joined", mode: os.ModeSymlink | 0777, content: "install"},
+		{name: "hooks/charmhelpers/__init__.py", mode: 0644},
+		{name: "actions/snapshot", mode: 0755, content: "#!/bin/sh\n"},
+	}
+}
+
+func (s *charmCheckSuite) TestValid(c *gc.C) {
+	path := s.writeArchive(c, validFiles()...)
+	err := charmcheck.CheckArchive(path)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmCheckSuite) TestArchiveTooLarge(c *gc.C) {
+	s.PatchValue(&charmcheck.MaxArchiveSize, int64(10))
+	path := s.writeArchive(c, validFiles()...)
+	err := charmcheck.CheckArchive(path)
+	c.Assert(err, gc.ErrorMatches, `invalid charm: archive is \d+ bytes, larger than the maximum of 10 bytes; deliver large files as resources instead`)
+	c.Assert(err, jc.Satisfies, charmcheck.IsError)
+}
+
+func (s *charmCheckSuite) TestNotAnArchive(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "charm.zip")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	f.Close()
+	err = charmcheck.CheckArchive(path)
+	c.Assert(err, gc.ErrorMatches, `invalid charm: cannot open archive: .*`)
+}
+
+func (s *charmCheckSuite) TestMissingMetadata(c *gc.C) {
+	path := s.writeArchive(c, validFiles()[1:]...)
+	err := charmcheck.CheckArchive(path)
+	c.Assert(err, gc.ErrorMatches, `invalid charm: metadata.yaml: file not found`)
+}
+
+func (s *charmCheckSuite) TestInvalidYAML(c *gc.C) {
+	files := validFiles()
+	files[0].content = "name: [dummy"
+	files[1].content = "options:\n  title:\n    type: widget\n"
+	files[2].content = "snapshot: ["
+	path := s.writeArchive(c, files...)
+	err := charmcheck.CheckArchive(path)
+	c.Assert(err, gc.ErrorMatches, `invalid charm: 3 problems found:
+  - metadata.yaml: .*
+  - config.yaml: .*
+  - actions.yaml: .*`)
+}
+
+func (s *charmCheckSuite) TestForbiddenEntries(c *gc.C) {
+	files := append(validFiles(),
+		archiveFile{name: "../escape", mode: 0644},
+		archiveFile{name: "/etc/passwd", mode: 0644},
+		archiveFile{name: "bin/tool", mode: os.ModeSetuid | 0755},
+		archiveFile{name: "fifo", mode: os.ModeNamedPipe | 0644},
+		archiveFile{name: "link", mode: os.ModeSymlink | 0777, content: "../../etc/shadow"},
+		archiveFile{name: "abslink", mode: os.ModeSymlink | 0777, content: "/etc/shadow"},
+	)
+	path := s.writeArchive(c, files...)
+	err := charmcheck.CheckArchive(path)
+	c.Assert(err, gc.ErrorMatches, `invalid charm: 6 problems found:
+  - \.\./escape: path is outside the charm directory
+  - /etc/passwd: path is outside the charm directory
+  - bin/tool: setuid and setgid files are not allowed
+  - fifo: special files are not allowed
+  - link: symlink target "\.\./\.\./etc/shadow" is outside the charm directory
+  - abslink: symlink target "/etc/shadow" is outside the charm directory`)
+}
+
+func (s *charmCheckSuite) TestHooks(c *gc.C) {
+	files := append(validFiles(),
+		archiveFile{name: "hooks/config-changed", mode: 0644},
+		archiveFile{name: "hooks/start", mode: os.ModeSymlink | 0777, content: "missing"},
+		archiveFile{name: "hooks/stop", mode: os.ModeSymlink | 0777, content: "config-changed"},
+		archiveFile{name: "hooks/README", mode: 0644},
+	)
+	path := s.writeArchive(c, files...)
+	err := charmcheck.CheckArchive(path)
+	c.Assert(err, gc.ErrorMatches, `invalid charm: 3 problems found:
+  - hooks/config-changed: hook is not executable \(set the executable bit with "chmod \+x"\)
+  - hooks/start: hook symlink target "missing" not found
+  - hooks/stop: hook is not executable \(set the executable bit with "chmod \+x"\)`)
+}
+
+func (s *charmCheckSuite) TestActions(c *gc.C) {
+	files := validFiles()
+	files[2].content = `
+backup:
+  description: take a backup
+restore:
+  description: restore a backup
+snapshot:
+  description: take a snapshot
+`
+	files = append(files, archiveFile{name: "actions/backup", mode: 0644})
+	path := s.writeArchive(c, files...)
+	err := charmcheck.CheckArchive(path)
+	c.Assert(err, gc.ErrorMatches, `invalid charm: actions/backup: action is not executable \(set the executable bit with "chmod \+x"\)`)
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package deploypolicy checks application deployments and new machines
+// against the deployment policy configured for the controller, so that
+// standards can be enforced across all of a controller's models.
+package deploypolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+)
+
+// Backend provides the controller and model details that deployments
+// are checked against.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	ModelConfig() (*config.Config, error)
+	ModelConstraints() (constraints.Value, error)
+}
+
+// Deployment describes an application deployment, or a new machine.
+type Deployment struct {
+	// Charm is the URL of the charm being deployed, or nil if a
+	// machine is being added.
+	Charm *charm.URL
+
+	// Series is the series being deployed to, if known.
+	Series string
+
+	// Constraints holds the constraints requested for the deployment.
+	// They are combined with the model's constraints before checking.
+	Constraints constraints.Value
+}
+
+// Check returns an error describing the first rule of the controller's
+// deployment policy that the deployment breaks, or nil if it breaks
+// none of them.
+func Check(backend Backend, d Deployment) error {
+	cfg, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if d.Charm != nil {
+		if err := checkCharmSource(cfg.DeployAllowedCharmSources(), d.Charm); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := checkSeries(cfg.DeployDeniedSeries(), d.Series); err != nil {
+		return errors.Trace(err)
+	}
+	if required := cfg.DeployRequiredResourceTags(); len(required) > 0 {
+		modelConfig, err := backend.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tags, _ := modelConfig.ResourceTags()
+		if err := checkResourceTags(required, tags); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if minimum := cfg.DeployMinimumConstraints(); !constraints.IsEmpty(&minimum) {
+		modelCons, err := backend.ModelConstraints()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cons, err := constraints.Merge(modelCons, d.Constraints)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := checkConstraints(minimum, cons); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func violationf(format string, args ...interface{}) error {
+	return errors.Errorf("deployment policy: "+format, args...)
+}
+
+func checkCharmSource(allowed []string, curl *charm.URL) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	url := curl.String()
+	for _, prefix := range allowed {
+		if strings.HasPrefix(url, prefix) {
+			return nil
+		}
+	}
+	return violationf("charm %q is not from an allowed source (allowed: %s)", url, strings.Join(allowed, " "))
+}
+
+func checkSeries(denied []string, series string) error {
+	if series == "" {
+		return nil
+	}
+	for _, s := range denied {
+		if s == series {
+			return violationf("series %q is not allowed", series)
+		}
+	}
+	return nil
+}
+
+func checkResourceTags(required []string, tags map[string]string) error {
+	var missing []string
+	for _, name := range required {
+		if _, ok := tags[name]; !ok {
+			missing = append(missing, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(missing) > 0 {
+		return violationf("model %s must include %s", config.ResourceTagsKey, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func checkConstraints(minimum, cons constraints.Value) error {
+	for _, check := range []struct {
+		name    string
+		unit    string
+		minimum *uint64
+		actual  *uint64
+	}{
+		{constraints.Mem, "M", minimum.Mem, cons.Mem},
+		{constraints.Cores, "", minimum.CpuCores, cons.CpuCores},
+		{constraints.CpuPower, "", minimum.CpuPower, cons.CpuPower},
+		{constraints.RootDisk, "M", minimum.RootDisk, cons.RootDisk},
+	} {
+		if check.minimum == nil {
+			continue
+		}
+		if check.actual == nil {
+			return violationf("%s constraint of at least %d%s required", check.name, *check.minimum, check.unit)
+		}
+		if *check.actual < *check.minimum {
+			return violationf(
+				"%s constraint of %d%s below minimum of %d%s",
+				check.name, *check.actual, check.unit, *check.minimum, check.unit,
+			)
+		}
+	}
+	return nil
+}
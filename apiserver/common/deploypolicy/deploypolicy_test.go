@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deploypolicy_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common/deploypolicy"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type deployPolicySuite struct {
+	coretesting.BaseSuite
+	backend *mockBackend
+}
+
+var _ = gc.Suite(&deployPolicySuite{})
+
+func (s *deployPolicySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		controllerConfig: coretesting.FakeControllerConfig(),
+		modelConfig:      coretesting.FakeConfig(),
+	}
+}
+
+func (s *deployPolicySuite) TestNoPolicy(c *gc.C) {
+	err := deploypolicy.Check(s.backend, deploypolicy.Deployment{
+		Charm:  charm.MustParseURL("cs:trusty/mysql-1"),
+		Series: "trusty",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// The model is not consulted unless the policy requires it.
+	c.Assert(s.backend.modelCalls, gc.Equals, 0)
+}
+
+func (s *deployPolicySuite) TestCharmSource(c *gc.C) {
+	s.backend.controllerConfig[controller.DeployAllowedCharmSources] = "cs:~my-team/ local:"
+	for _, url := range []string{"cs:~my-team/xenial/mysql-1", "local:xenial/mysql-0"} {
+		err := deploypolicy.Check(s.backend, deploypolicy.Deployment{Charm: charm.MustParseURL(url)})
+		c.Check(err, jc.ErrorIsNil)
+	}
+	err := deploypolicy.Check(s.backend, deploypolicy.Deployment{Charm: charm.MustParseURL("cs:xenial/mysql-1")})
+	c.Assert(err, gc.ErrorMatches, `deployment policy: charm "cs:xenial/mysql-1" is not from an allowed source \(allowed: cs:~my-team/ local:\)`)
+
+	// Machines have no charm to check.
+	err = deploypolicy.Check(s.backend, deploypolicy.Deployment{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *deployPolicySuite) TestDeniedSeries(c *gc.C) {
+	s.backend.controllerConfig[controller.DeployDeniedSeries] = "precise trusty"
+	err := deploypolicy.Check(s.backend, deploypolicy.Deployment{Series: "xenial"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = deploypolicy.Check(s.backend, deploypolicy.Deployment{Series: "trusty"})
+	c.Assert(err, gc.ErrorMatches, `deployment policy: series "trusty" is not allowed`)
+}
+
+func (s *deployPolicySuite) TestRequiredResourceTags(c *gc.C) {
+	s.backend.controllerConfig[controller.DeployRequiredResourceTags] = "owner cost-centre"
+	s.backend.modelConfig[config.ResourceTagsKey] = "owner=bob"
+	err := deploypolicy.Check(s.backend, deploypolicy.Deployment{})
+	c.Assert(err, gc.ErrorMatches, `deployment policy: model resource-tags must include "cost-centre"`)
+
+	s.backend.modelConfig[config.ResourceTagsKey] = "owner=bob cost-centre=42"
+	err = deploypolicy.Check(s.backend, deploypolicy.Deployment{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *deployPolicySuite) TestMinimumConstraints(c *gc.C) {
+	s.backend.controllerConfig[controller.DeployMinimumConstraints] = "mem=4G cores=2"
+	s.backend.modelConstraints = constraints.MustParse("cores=4")
+
+	err := deploypolicy.Check(s.backend, deploypolicy.Deployment{})
+	c.Assert(err, gc.ErrorMatches, `deployment policy: mem constraint of at least 4096M required`)
+
+	err = deploypolicy.Check(s.backend, deploypolicy.Deployment{
+		Constraints: constraints.MustParse("mem=2G"),
+	})
+	c.Assert(err, gc.ErrorMatches, `deployment policy: mem constraint of 2048M below minimum of 4096M`)
+
+	err = deploypolicy.Check(s.backend, deploypolicy.Deployment{
+		Constraints: constraints.MustParse("mem=8G cores=1"),
+	})
+	c.Assert(err, gc.ErrorMatches, `deployment policy: cores constraint of 1 below minimum of 2`)
+
+	// The model's constraints apply where the deployment sets none.
+	err = deploypolicy.Check(s.backend, deploypolicy.Deployment{
+		Constraints: constraints.MustParse("mem=8G"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *deployPolicySuite) TestBackendError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	err := deploypolicy.Check(s.backend, deploypolicy.Deployment{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	controllerConfig controller.Config
+	modelConfig      coretesting.Attrs
+	modelConstraints constraints.Value
+	modelCalls       int
+	err              error
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	return b.controllerConfig, b.err
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.modelCalls++
+	return config.New(config.UseDefaults, b.modelConfig)
+}
+
+func (b *mockBackend) ModelConstraints() (constraints.Value, error) {
+	b.modelCalls++
+	return b.modelConstraints, nil
+}
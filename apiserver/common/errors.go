@@ -41,6 +41,51 @@ func isNoAddressSetError(err error) bool {
 	return ok
 }
 
+type quotaLimitExceededError struct {
+	msg string
+}
+
+func (e *quotaLimitExceededError) Error() string {
+	return e.msg
+}
+
+// QuotaLimitExceededError returns an error indicating that a request
+// would exceed a configured limit.
+func QuotaLimitExceededError(format string, args ...interface{}) error {
+	return &quotaLimitExceededError{msg: fmt.Sprintf(format, args...)}
+}
+
+// IsQuotaLimitExceededError reports whether the cause of the error
+// was returned by QuotaLimitExceededError, or restored from an API
+// error with the quota limit exceeded code.
+func IsQuotaLimitExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*quotaLimitExceededError)
+	return ok
+}
+
+type forbiddenError struct {
+	msg string
+}
+
+func (e *forbiddenError) Error() string {
+	return e.msg
+}
+
+// ForbiddenError returns an error indicating that a request is
+// forbidden by policy, rather than by the permissions of the user
+// making it.
+func ForbiddenError(format string, args ...interface{}) error {
+	return &forbiddenError{msg: fmt.Sprintf(format, args...)}
+}
+
+// IsForbiddenError reports whether the cause of the error was returned
+// by ForbiddenError, or restored from an API error with the forbidden
+// code.
+func IsForbiddenError(err error) bool {
+	_, ok := errors.Cause(err).(*forbiddenError)
+	return ok
+}
+
 type unknownModelError struct {
 	uuid string
 }
@@ -113,6 +158,28 @@ func OperationBlockedError(msg string) error {
 	}
 }
 
+// ModelFrozenError returns an error which signifies that a change
+// has been rejected because the model is frozen; the message should
+// be the one the model was frozen with.
+func ModelFrozenError(msg string) error {
+	if msg == "" {
+		msg = "no reason given"
+	}
+	return &params.Error{
+		Message: "model is frozen: " + msg,
+		Code:    params.CodeModelFrozen,
+	}
+}
+
+// ModelSuspendedError returns an error which signifies that a change
+// has been rejected because the model is suspended.
+func ModelSuspendedError() error {
+	return &params.Error{
+		Message: "model is suspended",
+		Code:    params.CodeModelSuspended,
+	}
+}
+
 var singletonErrorCodes = map[error]string{
 	state.ErrCannotEnterScopeYet: params.CodeCannotEnterScopeYet,
 	state.ErrCannotEnterScope:    params.CodeCannotEnterScope,
@@ -178,7 +245,9 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		// This should really be http.StatusForbidden but earlier versions
 		// of juju clients rely on the 400 status, so we leave it like that.
 		status = http.StatusBadRequest
-	case params.CodeForbidden:
+	case params.CodeForbidden,
+		params.CodeModelFrozen,
+		params.CodeModelSuspended:
 		status = http.StatusForbidden
 	case params.CodeDischargeRequired:
 		status = http.StatusUnauthorized
@@ -223,6 +292,10 @@ func ServerError(err error) *params.Error {
 		code = params.CodeModelNotEmpty
 	case isNoAddressSetError(err):
 		code = params.CodeNoAddressSet
+	case IsQuotaLimitExceededError(err):
+		code = params.CodeQuotaLimitExceeded
+	case IsForbiddenError(err):
+		code = params.CodeForbidden
 	case errors.IsNotProvisioned(err):
 		code = params.CodeNotProvisioned
 	case IsUpgradeInProgressError(err):
@@ -241,6 +314,10 @@ func ServerError(err error) *params.Error {
 		code = params.CodeMethodNotAllowed
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case state.IsCharmLockHeldError(err):
+		code = params.CodeLockHeld
+	case errors.IsNotValid(err):
+		code = params.CodeNotValid
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -278,7 +355,9 @@ func DestroyErr(desc string, ids []string, errs []error) error {
 }
 
 // RestoreError makes a best effort at converting the given error
-// back into an error originally converted by ServerError().
+// back into an error originally converted by ServerError(). Errors
+// whose codes have no corresponding error type are returned unchanged,
+// so their codes can still be checked with the params.IsCode functions.
 func RestoreError(err error) error {
 	err = errors.Cause(err)
 
@@ -300,9 +379,8 @@ func RestoreError(err error) error {
 	switch {
 	case params.IsCodeUnauthorized(err):
 		return errors.NewUnauthorized(nil, msg)
-	case params.IsCodeNotFound(err):
-		// TODO(ericsnow) UnknownModelError should be handled here too.
-		// ...by parsing msg?
+	case params.IsCodeNotFound(err),
+		params.IsCodeModelNotFound(err):
 		return errors.NewNotFound(nil, msg)
 	case params.IsCodeUserNotFound(err):
 		return errors.NewUserNotFound(nil, msg)
@@ -338,6 +416,12 @@ func RestoreError(err error) error {
 		return err
 	case params.IsCodeNotSupported(err):
 		return errors.NewNotSupported(nil, msg)
+	case params.IsCodeNotValid(err):
+		return errors.NewNotValid(nil, msg)
+	case params.IsCodeQuotaLimitExceeded(err):
+		return &quotaLimitExceededError{msg: msg}
+	case params.IsCodeForbidden(err):
+		return &forbiddenError{msg: msg}
 	case params.IsBadRequest(err):
 		return errors.NewBadRequest(nil, msg)
 	case params.IsMethodNotAllowed(err):
@@ -83,6 +83,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeNoAddressSet,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeNoAddressSet,
+}, {
+	err:        common.QuotaLimitExceededError("too many settings"),
+	code:       params.CodeQuotaLimitExceeded,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeQuotaLimitExceeded,
 }, {
 	err:        common.ErrBadCreds,
 	code:       params.CodeUnauthorized,
@@ -148,6 +153,16 @@ var errorTransformTests = []struct {
 	code:       params.CodeOperationBlocked,
 	status:     http.StatusBadRequest,
 	helperFunc: params.IsCodeOperationBlocked,
+}, {
+	err:        common.ModelFrozenError("incident 42"),
+	code:       params.CodeModelFrozen,
+	status:     http.StatusForbidden,
+	helperFunc: params.IsCodeModelFrozen,
+}, {
+	err:        common.ModelSuspendedError(),
+	code:       params.CodeModelSuspended,
+	status:     http.StatusForbidden,
+	helperFunc: params.IsCodeModelSuspended,
 }, {
 	err:        errors.NotSupportedf("needed feature"),
 	code:       params.CodeNotSupported,
@@ -190,6 +205,21 @@ var errorTransformTests = []struct {
 	code:       params.CodeModelNotFound,
 	status:     http.StatusNotFound,
 	helperFunc: params.IsCodeModelNotFound,
+}, {
+	err:        errors.NotValidf("config"),
+	code:       params.CodeNotValid,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeNotValid,
+}, {
+	err:        &state.ErrCharmLockHeld{Name: "migrate", Holder: "mysql/0"},
+	code:       params.CodeLockHeld,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeLockHeld,
+}, {
+	err:        common.ForbiddenError("too many units for policy"),
+	code:       params.CodeForbidden,
+	status:     http.StatusForbidden,
+	helperFunc: params.IsCodeForbidden,
 }, {
 	err:    nil,
 	code:   "",
@@ -239,10 +269,13 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
-			params.CodeRetry:
+			params.CodeRetry,
+			params.CodeLockHeld:
 			continue
-		case params.CodeOperationBlocked:
-			// ServerError doesn't actually have a case for this code.
+		case params.CodeOperationBlocked,
+			params.CodeModelFrozen,
+			params.CodeModelSuspended:
+			// ServerError doesn't actually have a case for these codes.
 			continue
 		}
 
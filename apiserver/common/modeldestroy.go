@@ -74,13 +74,16 @@ func DestroyController(
 }
 
 // DestroyModel sets the model to Dying, such that the model's resources will
-// be destroyed and the model removed from the controller.
+// be destroyed and the model removed from the controller. If force is true,
+// the model's cloud resources are removed even if they are still in use.
 func DestroyModel(
 	st ModelManagerBackend,
 	destroyStorage *bool,
+	force bool,
 ) error {
 	return destroyModel(st, state.DestroyModelParams{
 		DestroyStorage: destroyStorage,
+		Force:          force,
 	})
 }
 
@@ -40,7 +40,7 @@ func (s *destroyModelSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *destroyModelSuite) TestDestroyModelSendsMetrics(c *gc.C) {
-	err := common.DestroyModel(s.modelManager, nil)
+	err := common.DestroyModel(s.modelManager, nil, false)
 	c.Assert(err, jc.ErrorIsNil)
 	s.metricSender.CheckCalls(c, []jtesting.StubCall{
 		{"SendMetrics", []interface{}{s.modelManager}},
@@ -59,7 +59,7 @@ func (s *destroyModelSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
 	s.modelManager.ResetCalls()
 	s.modelManager.models[0].ResetCalls()
 
-	err := common.DestroyModel(s.modelManager, destroyStorage)
+	err := common.DestroyModel(s.modelManager, destroyStorage, false)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.modelManager.CheckCalls(c, []jtesting.StubCall{
@@ -76,10 +76,21 @@ func (s *destroyModelSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
 	})
 }
 
+func (s *destroyModelSuite) TestDestroyModelForce(c *gc.C) {
+	err := common.DestroyModel(s.modelManager, nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.modelManager.models[0].CheckCalls(c, []jtesting.StubCall{
+		{"Destroy", []interface{}{state.DestroyModelParams{
+			Force: true,
+		}}},
+	})
+}
+
 func (s *destroyModelSuite) TestDestroyModelBlocked(c *gc.C) {
 	s.modelManager.SetErrors(errors.New("nope"))
 
-	err := common.DestroyModel(s.modelManager, nil)
+	err := common.DestroyModel(s.modelManager, nil, false)
 	c.Assert(err, gc.ErrorMatches, "nope")
 
 	s.modelManager.CheckCallNames(c, "GetBlockForType")
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -59,6 +60,7 @@ type ModelManagerBackend interface {
 	ExportPartial(state.ExportConfig) (description.Model, error)
 	SetUserAccess(subject names.UserTag, target names.Tag, access permission.Access) (permission.UserAccess, error)
 	SetModelMeterStatus(string, string) error
+	SetModelConstraints(constraints.Value) error
 	ReloadSpaces(environ environs.Environ) error
 	LatestMigration() (state.ModelMigration, error)
 	DumpAll() (map[string]interface{}, error)
@@ -89,6 +91,9 @@ type Model interface {
 	Destroy(state.DestroyModelParams) error
 	SLALevel() string
 	SLAOwner() string
+	Description() string
+	Expiry() (state.ModelExpiry, bool)
+	SetExpiry(deadline time.Time, destroyStorage *bool) error
 	MigrationMode() state.MigrationMode
 	Name() string
 	UUID() string
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
+	jujustatus "github.com/juju/juju/status"
 )
 
 // ModelStatusAPI implements the ModelStatus() API.
@@ -107,6 +108,17 @@ func (c *ModelStatusAPI) modelStatus(tag string) (params.ModelStatus, error) {
 	}
 	modelFilesystems := ModelFilesystemInfo(filesystems)
 
+	var destroyError string
+	if model.Life() != state.Alive {
+		modelStatus, err := model.Status()
+		if err != nil {
+			return status, errors.Trace(err)
+		}
+		if modelStatus.Status == jujustatus.Error {
+			destroyError = modelStatus.Message
+		}
+	}
+
 	return params.ModelStatus{
 		ModelTag:           tag,
 		OwnerTag:           model.Owner().String(),
@@ -116,6 +128,7 @@ func (c *ModelStatusAPI) modelStatus(tag string) (params.ModelStatus, error) {
 		Machines:           modelMachines,
 		Volumes:            modelVolumes,
 		Filesystems:        modelFilesystems,
+		DestroyError:       destroyError,
 	}, nil
 }
 
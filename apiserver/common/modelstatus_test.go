@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing"
@@ -218,6 +219,32 @@ func (s *modelStatusSuite) TestModelStatus(c *gc.C) {
 	}})
 }
 
+func (s *modelStatusSuite) TestModelStatusDestroyError(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	otherFactory := factory.NewFactory(otherSt)
+	otherFactory.MakeMachine(c, nil)
+
+	otherModel, err := otherSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = otherModel.Destroy(state.DestroyModelParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = otherModel.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "2 security groups could not be removed: juju-a, juju-b",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := params.Entities{
+		Entities: []params.Entity{{Tag: otherModel.ModelTag().String()}},
+	}
+	results, err := s.controller.ModelStatus(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Life, gc.Equals, params.Dying)
+	c.Assert(results.Results[0].DestroyError, gc.Equals, "2 security groups could not be removed: juju-a, juju-b")
+}
+
 type statePolicy struct{}
 
 func (statePolicy) Prechecker() (environs.InstancePrechecker, error) {
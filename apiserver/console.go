@@ -0,0 +1,195 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/utils/featureflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// consoleSessionTimeout is the maximum length of time a console
+// session proxied by the controller may remain open.
+const consoleSessionTimeout = time.Hour
+
+// consoleDialer is used to connect to the instance consoles provided
+// by clouds. Serial console proxies, such as nova's, require the
+// "binary" websocket subprotocol.
+var consoleDialer = &gorillaws.Dialer{
+	Subprotocols:     []string{"binary"},
+	HandshakeTimeout: 30 * time.Second,
+}
+
+func newConsoleHandler(h httpContext) http.Handler {
+	return &consoleHandler{
+		ctxt:       h,
+		newEnviron: stateenvirons.GetNewEnvironFunc(environs.New),
+		timeout:    consoleSessionTimeout,
+	}
+}
+
+// consoleHandler proxies the serial console of a machine's instance,
+// as provided by the cloud, to a model administrator. Data is relayed
+// as params.ConsoleMessage values. The session is closed once it has
+// been open for longer than the handler's timeout.
+type consoleHandler struct {
+	ctxt       httpContext
+	newEnviron stateenvirons.NewEnvironFunc
+	timeout    time.Duration
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *consoleHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(socket *websocket.Conn) {
+		defer socket.Close()
+
+		machineId := req.URL.Query().Get("machine")
+		console, err := h.connect(req, machineId)
+		h.sendError(socket, req, err)
+		if err != nil {
+			return
+		}
+		defer console.Close()
+		h.relay(socket, console, machineId)
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// connect authorizes the request and connects to the serial console
+// of the specified machine's instance.
+func (h *consoleHandler) connect(req *http.Request, machineId string) (*gorillaws.Conn, error) {
+	st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer releaser()
+
+	if err := checkCanAdminModel(st, entity.Tag()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !names.IsValidMachine(machineId) {
+		return nil, errors.NotValidf("machine ID %q", machineId)
+	}
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := h.newEnviron(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	accessor, ok := env.(environs.InstanceConsoleAccessor)
+	if !ok {
+		return nil, errors.NotSupportedf("instance consoles with this provider")
+	}
+	console, err := accessor.InstanceConsole(instId, environs.SerialConsole)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	conn, _, err := consoleDialer.Dial(console.URL, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to console of machine %s", machineId)
+	}
+	logger.Infof("user %q connected to console of machine %s", entity.Tag().Id(), machineId)
+	return conn, nil
+}
+
+// checkCanAdminModel checks that the user is a controller superuser or
+// an administrator of the model.
+func checkCanAdminModel(st *state.State, tag names.Tag) error {
+	for _, check := range []struct {
+		access permission.Access
+		target names.Tag
+	}{
+		{permission.SuperuserAccess, st.ControllerTag()},
+		{permission.AdminAccess, st.ModelTag()},
+	} {
+		ok, err := common.HasPermission(st.UserPermission, tag, check.access, check.target)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok {
+			return nil
+		}
+	}
+	return errors.Trace(common.ErrPerm)
+}
+
+// relay copies data between the client and the console until either
+// side closes its connection, the session times out, or the server
+// is stopped.
+func (h *consoleHandler) relay(socket *websocket.Conn, console *gorillaws.Conn, machineId string) {
+	done := make(chan error, 2)
+	go func() {
+		for {
+			_, data, err := console.ReadMessage()
+			if err != nil {
+				done <- errors.Annotate(err, "reading from console")
+				return
+			}
+			if err := socket.WriteJSON(params.ConsoleMessage{Data: data}); err != nil {
+				done <- errors.Annotate(err, "writing to client")
+				return
+			}
+		}
+	}()
+	go func() {
+		for {
+			var m params.ConsoleMessage
+			if err := socket.ReadJSON(&m); err != nil {
+				done <- errors.Annotate(err, "reading from client")
+				return
+			}
+			if err := console.WriteMessage(gorillaws.BinaryMessage, m.Data); err != nil {
+				done <- errors.Annotate(err, "writing to console")
+				return
+			}
+		}
+	}()
+
+	timeout := time.NewTimer(h.timeout)
+	defer timeout.Stop()
+	reason := "console session closed"
+	select {
+	case err := <-done:
+		logger.Debugf("console session for machine %s ended: %v", machineId, err)
+	case <-timeout.C:
+		logger.Infof("console session for machine %s expired", machineId)
+		reason = "console session expired"
+	case <-h.ctxt.stop():
+	}
+	// Closing the connections unblocks the relaying goroutines.
+	deadline := time.Now().Add(websocket.WriteWait)
+	socket.WriteControl(gorillaws.CloseMessage, gorillaws.FormatCloseMessage(gorillaws.CloseNormalClosure, reason), deadline)
+	console.Close()
+	socket.Close()
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *consoleHandler) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
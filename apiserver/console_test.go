@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/websocket/websockettest"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type consoleSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&consoleSuite{})
+
+func (s *consoleSuite) TestNoAuth(c *gc.C) {
+	s.assertConsoleError(c, "0", nil, "no credentials provided")
+}
+
+func (s *consoleSuite) TestRequiresAdmin(c *gc.C) {
+	header := utils.BasicAuthHeader(s.userTag.String(), s.password)
+	s.assertConsoleError(c, "0", header, "permission denied")
+}
+
+func (s *consoleSuite) TestInvalidMachine(c *gc.C) {
+	s.assertConsoleError(c, "foo", s.adminHeader(c), `machine ID "foo" not valid`)
+}
+
+func (s *consoleSuite) TestMachineNotFound(c *gc.C) {
+	s.assertConsoleError(c, "42", s.adminHeader(c), "machine 42 not found")
+}
+
+func (s *consoleSuite) TestMachineNotProvisioned(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConsoleError(c, m.Id(), s.adminHeader(c), fmt.Sprintf("machine %s not provisioned", m.Id()))
+}
+
+func (s *consoleSuite) TestNotSupported(c *gc.C) {
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("inst-0"),
+	})
+	s.assertConsoleError(c, m.Id(), s.adminHeader(c), "instance consoles with this provider not supported")
+}
+
+func (s *consoleSuite) adminHeader(c *gc.C) http.Header {
+	return utils.BasicAuthHeader(s.AdminUserTag(c).String(), "dummy-secret")
+}
+
+func (s *consoleSuite) assertConsoleError(c *gc.C, machineId string, header http.Header, message string) {
+	conn := s.dialWebsocket(c, machineId, header)
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, message)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *consoleSuite) dialWebsocket(c *gc.C, machineId string, header http.Header) *websocket.Conn {
+	path := fmt.Sprintf("/model/%s/console", s.modelUUID)
+	server := s.makeURL(c, "wss", path, url.Values{"machine": {machineId}}).String()
+	return dialWebsocketFromURL(c, server, header)
+}
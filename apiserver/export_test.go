@@ -133,6 +133,24 @@ func TestingMigratingRoot() rpc.Root {
 	return restrictRoot(r, migrationClientMethodsOnly)
 }
 
+// TestingFrozenRoot returns a restricted srvRoot for a user login to
+// a model, which is frozen with the given message if frozen is true.
+func TestingFrozenRoot(message string, frozen bool) rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, frozenModelReadOnly(func() (string, bool, error) {
+		return message, frozen, nil
+	}))
+}
+
+// TestingSuspendedRoot returns a restricted srvRoot for a user login
+// to a model, which is suspended if suspended is true.
+func TestingSuspendedRoot(suspended bool) rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, suspendedModelReadOnly(func() (bool, error) {
+		return suspended, nil
+	}))
+}
+
 // TestingAnonymousRoot returns a restricted srvRoot as if
 // logged in anonymously.
 func TestingAnonymousRoot() rpc.Root {
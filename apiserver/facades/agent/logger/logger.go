@@ -4,6 +4,7 @@
 package logger
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -54,7 +55,9 @@ func NewLoggerAPI(
 // WatchLoggingConfig starts a watcher to track changes to the logging config
 // for the agents specified..  Unfortunately the current infrastruture makes
 // watching parts of the config non-trivial, so currently any change to the
-// config will cause the watcher to notify the client.
+// config will cause the watcher to notify the client. The watchers of unit
+// agents also notify of any change to the unit's application, whose
+// logging settings apply to the unit.
 func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatchResults {
 	result := make([]params.NotifyWatchResult, len(arg.Entities))
 	for i, entity := range arg.Entities {
@@ -65,7 +68,12 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 		}
 		err = common.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
-			watch := api.model.WatchForModelConfigChanges()
+			var watch state.NotifyWatcher
+			watch, err = api.loggingConfigWatcher(tag)
+			if err != nil {
+				result[i].Error = common.ServerError(err)
+				continue
+			}
 			// Consume the initial event. Technically, API calls to Watch
 			// 'transmit' the initial event in the Watch response. But
 			// NotifyWatchers have no state to transmit.
@@ -81,7 +89,57 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 	return params.NotifyWatchResults{Results: result}
 }
 
+// loggingConfigWatcher returns a watcher that notifies of changes that
+// may affect the logging config of the agent with the given tag.
+func (api *LoggerAPI) loggingConfigWatcher(tag names.Tag) (state.NotifyWatcher, error) {
+	unitTag, ok := tag.(names.UnitTag)
+	if !ok {
+		return api.model.WatchForModelConfigChanges(), nil
+	}
+	app, err := api.unitApplication(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMultiNotifyWatcher(
+		api.model.WatchForModelConfigChanges(),
+		app.Watch(),
+	), nil
+}
+
+// unitApplication returns the application of the unit with the given tag.
+func (api *LoggerAPI) unitApplication(tag names.UnitTag) (*state.Application, error) {
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.state.Application(appName)
+}
+
+// agentLoggingConfig returns the logging config for the agent with the
+// given tag, given the model's logging config. The logging config of a
+// unit's application is applied on top of the model's.
+func (api *LoggerAPI) agentLoggingConfig(tag names.Tag, modelConfig string) (string, error) {
+	unitTag, ok := tag.(names.UnitTag)
+	if !ok {
+		return modelConfig, nil
+	}
+	app, err := api.unitApplication(unitTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	appConfig := app.Logging().Config
+	switch {
+	case appConfig == "":
+		return modelConfig, nil
+	case modelConfig == "":
+		return appConfig, nil
+	}
+	return modelConfig + ";" + appConfig, nil
+}
+
 // LoggingConfig reports the logging configuration for the agents specified.
+// The logging configuration of a unit agent includes that of the unit's
+// application.
 func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	if len(arg.Entities) == 0 {
 		return params.StringResults{}
@@ -97,8 +155,7 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 		err = common.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
 			if configErr == nil {
-				results[i].Result = config.LoggingConfig()
-				err = nil
+				results[i].Result, err = api.agentLoggingConfig(tag, config.LoggingConfig())
 			} else {
 				err = configErr
 			}
@@ -144,3 +144,54 @@ func (s *loggerSuite) TestLoggingConfigForAgent(c *gc.C) {
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result, gc.Equals, newLoggingConfig)
 }
+
+func (s *loggerSuite) unitLogger(c *gc.C) (*state.Application, *state.Unit, *logger.LoggerAPI) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	authorizer := s.authorizer
+	authorizer.Tag = unit.Tag()
+	api, err := logger.NewLoggerAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return app, unit, api
+}
+
+func (s *loggerSuite) TestLoggingConfigForUnitAgent(c *gc.C) {
+	s.setLoggingConfig(c, "<root>=WARN")
+	app, unit, api := s.unitLogger(c)
+	err := app.SetLogging(state.ApplicationLogging{Config: "unit=DEBUG"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := api.LoggingConfig(params.Entities{
+		Entities: []params.Entity{{Tag: unit.Tag().String()}},
+	})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.Equals, "<root>=WARN;unit=DEBUG")
+}
+
+func (s *loggerSuite) TestWatchLoggingConfigForUnitAgent(c *gc.C) {
+	app, unit, api := s.unitLogger(c)
+	results := api.WatchLoggingConfig(params.Entities{
+		Entities: []params.Entity{{Tag: unit.Tag().String()}},
+	})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	resource := s.resources.Get(results.Results[0].NotifyWatcherId)
+	c.Assert(resource, gc.NotNil)
+
+	w := resource.(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	err := app.SetLogging(state.ApplicationLogging{Config: "unit=DEBUG"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	s.setLoggingConfig(c, "<root>=WARN")
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
@@ -14,6 +14,7 @@ import (
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/juju/keys"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
@@ -119,6 +120,38 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataForMachineImage(c *gc.C) {
+	useTestImageData(c, testImagesData)
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		ImageStream: "daily",
+		ImageId:     "ami-26745463",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	missing, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		ImageStream: "daily",
+		ImageId:     "ami-missing",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ProvisioningInfo(params.Entities{Entities: []params.Entity{
+		{Tag: m.Tag().String()},
+		{Tag: missing.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.ImageMetadata, jc.DeepEquals, s.expectedDataSoureImageMetadata()[0][1:])
+	c.Assert(result.Results[1].Error, gc.ErrorMatches,
+		`cannot get available image metadata: image "ami-missing" in "daily" image stream for series "quantal" not found`)
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
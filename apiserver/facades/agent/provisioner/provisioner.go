@@ -120,7 +120,7 @@ func NewProvisionerAPI(st *state.State, resources facade.Resources, authorizer f
 		PasswordChanger:         common.NewPasswordChanger(st, getAuthFunc),
 		LifeGetter:              common.NewLifeGetter(st, getAuthFunc),
 		StateAddresser:          common.NewStateAddresser(st),
-		APIAddresser:            common.NewAPIAddresser(st, resources),
+		APIAddresser:            common.NewAgentAPIAddresser(st, resources),
 		ModelWatcher:            common.NewModelWatcher(model, resources, authorizer),
 		ModelMachinesWatcher:    common.NewModelMachinesWatcher(st, resources, authorizer),
 		ControllerConfigAPI:     common.NewStateControllerConfig(st),
@@ -153,6 +153,21 @@ func NewProvisionerAPIV5(st *state.State, resources facade.Resources, authorizer
 	return &ProvisionerAPIV5{provisionerAPI}, nil
 }
 
+// ProvisionerAPIV6 provides v6 of the provisioner facade, which adds
+// MachinesWithTransientErrorsPage.
+type ProvisionerAPIV6 struct {
+	*ProvisionerAPIV5
+}
+
+// NewProvisionerAPIV6 creates a new server-side Provisioner API facade.
+func NewProvisionerAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV6, error) {
+	provisionerAPI, err := NewProvisionerAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ProvisionerAPIV6{provisionerAPI}, nil
+}
+
 func (p *ProvisionerAPI) getMachine(canAccess common.AuthFunc, tag names.MachineTag) (*state.Machine, error) {
 	if !canAccess(tag) {
 		return nil, common.ErrPerm
@@ -303,11 +318,42 @@ func (p *ProvisionerAPI) MachinesWithTransientErrors() (params.StatusResults, er
 	if err != nil {
 		return results, err
 	}
-	// TODO (wallyworld) - add state.State API for more efficient machines query
 	machines, err := p.st.AllMachines()
 	if err != nil {
 		return results, err
 	}
+	results.Results = transientErrorResults(canAccessFunc, machines)
+	return results, nil
+}
+
+// MachinesWithTransientErrorsPage returns status data for the machines
+// with provisioning errors which are transient, from a page of the
+// model's machines. A page may hold no results even when there are
+// more pages.
+func (p *ProvisionerAPIV6) MachinesWithTransientErrorsPage(args params.MachinePageParams) (params.StatusResultsPage, error) {
+	var results params.StatusResultsPage
+	if args.Limit <= 0 {
+		return results, errors.NotValidf("page limit %d", args.Limit)
+	}
+	canAccessFunc, err := p.getAuthFunc()
+	if err != nil {
+		return results, err
+	}
+	machines, err := p.st.AllMachinesPage(args.Cursor, args.Limit)
+	if err != nil {
+		return results, err
+	}
+	results.Results = transientErrorResults(canAccessFunc, machines)
+	if len(machines) == args.Limit {
+		results.NextCursor = machines[len(machines)-1].Id()
+	}
+	return results, nil
+}
+
+// transientErrorResults returns status data for those of the given
+// machines that are accessible and have transient provisioning errors.
+func transientErrorResults(canAccessFunc common.AuthFunc, machines []*state.Machine) []params.StatusResult {
+	var results []params.StatusResult
 	for _, machine := range machines {
 		if !canAccessFunc(machine.Tag()) {
 			continue
@@ -334,9 +380,9 @@ func (p *ProvisionerAPI) MachinesWithTransientErrors() (params.StatusResults, er
 		}
 		result.Id = machine.Id()
 		result.Life = params.Life(machine.Life().String())
-		results.Results = append(results.Results, result)
+		results = append(results, result)
 	}
-	return results, nil
+	return results
 }
 
 // Series returns the deployed series for each given machine entity.
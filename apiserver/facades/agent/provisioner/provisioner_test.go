@@ -535,6 +535,57 @@ func (s *withoutControllerSuite) TestMachinesWithTransientErrorsPermission(c *gc
 	})
 }
 
+func (s *withoutControllerSuite) TestMachinesWithTransientErrorsPage(c *gc.C) {
+	now := time.Now()
+	for _, i := range []int{1, 3} {
+		err := s.machines[i].SetInstanceStatus(status.StatusInfo{
+			Status:  status.ProvisioningError,
+			Message: "transient error",
+			Data:    map[string]interface{}{"transient": true},
+			Since:   &now,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	aProvisioner, err := provisioner.NewProvisionerAPIV6(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	transient := func(id string) params.StatusResult {
+		return params.StatusResult{
+			Id: id, Life: "alive", Status: "provisioning error",
+			Info: "transient error",
+			Data: map[string]interface{}{"transient": true},
+		}
+	}
+	args := params.MachinePageParams{Limit: 2}
+	result, err := aProvisioner.MachinesWithTransientErrorsPage(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StatusResultsPage{
+		Results:    []params.StatusResult{transient("1")},
+		NextCursor: "1",
+	})
+
+	args.Cursor = result.NextCursor
+	result, err = aProvisioner.MachinesWithTransientErrorsPage(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StatusResultsPage{
+		Results:    []params.StatusResult{transient("3")},
+		NextCursor: "3",
+	})
+
+	// The last page holds only machine 4, which has no error.
+	args.Cursor = result.NextCursor
+	result, err = aProvisioner.MachinesWithTransientErrorsPage(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StatusResultsPage{})
+}
+
+func (s *withoutControllerSuite) TestMachinesWithTransientErrorsPageInvalidLimit(c *gc.C) {
+	aProvisioner, err := provisioner.NewProvisionerAPIV6(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = aProvisioner.MachinesWithTransientErrorsPage(params.MachinePageParams{})
+	c.Assert(err, gc.ErrorMatches, "page limit 0 not valid")
+}
+
 func (s *withoutControllerSuite) TestEnsureDead(c *gc.C) {
 	err := s.machines[1].EnsureDead()
 	c.Assert(err, jc.ErrorIsNil)
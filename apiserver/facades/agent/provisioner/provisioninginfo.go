@@ -250,8 +250,17 @@ func (p *ProvisionerAPI) machineSubnetsAndZones(m *state.Machine) (map[string][]
 	}
 	includeSpaces := mcons.IncludeSpaces()
 	if len(includeSpaces) < 1 {
-		// Nothing to do.
-		return nil, nil
+		// Without a spaces constraint, machines are placed on the
+		// management network, if there is one.
+		managementSpace, err := p.managementSpace()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if managementSpace == "" {
+			// Nothing to do.
+			return nil, nil
+		}
+		includeSpaces = []string{managementSpace}
 	}
 	// TODO(dimitern): For the network model MVP we only use the first
 	// included space and ignore the rest.
@@ -356,9 +365,36 @@ func (p *ProvisionerAPI) machineEndpointBindings(m *state.Machine) (map[string]s
 			}
 		}
 	}
+
+	// Agents reach the controller over the management space, so the
+	// machine needs an address in it whatever its workloads bind to.
+	managementSpace, err := p.managementSpace()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if managementSpace != "" {
+		spaceProviderId, nameKnown := spacesNamesToProviderIds[managementSpace]
+		if !nameKnown {
+			return nil, errors.Errorf("unknown management space %q with no provider ID specified", managementSpace)
+		}
+		if combinedBindings == nil {
+			combinedBindings = make(map[string]string)
+		}
+		combinedBindings[environs.ManagementEndpointBinding] = spaceProviderId
+	}
 	return combinedBindings, nil
 }
 
+// managementSpace returns the name of the model's management space, or
+// "" if there is none.
+func (p *ProvisionerAPI) managementSpace() (string, error) {
+	modelConfig, err := p.m.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return modelConfig.ManagementSpace(), nil
+}
+
 func (p *ProvisionerAPI) allSpaceNamesToProviderIds() (map[string]string, error) {
 	allSpaces, err := p.st.AllSpaces()
 	if err != nil {
@@ -395,16 +431,40 @@ func (p *ProvisionerAPI) availableImageMetadata(m *state.Machine, env environs.E
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if imageId := m.ImageId(); imageId != "" {
+		data = filterImageMetadata(data, imageId)
+		if len(data) == 0 {
+			return nil, errors.NotFoundf("image %q in %q image stream for series %q",
+				imageId, imageConstraint.Stream, m.Series())
+		}
+	}
 	sort.Sort(metadataList(data))
 	logger.Debugf("available image metadata for provisioning: %v", data)
 	return data, nil
 }
 
+// filterImageMetadata returns the image metadata for the image with
+// the given id.
+func filterImageMetadata(data []params.CloudImageMetadata, imageId string) []params.CloudImageMetadata {
+	var result []params.CloudImageMetadata
+	for _, m := range data {
+		if m.ImageId == imageId {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
 // constructImageConstraint returns model-specific criteria used to look for image metadata.
+// The machine's image stream, if set, overrides the model's.
 func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs.Environ) (*imagemetadata.ImageConstraint, error) {
+	stream := m.ImageStream()
+	if stream == "" {
+		stream = env.Config().ImageStream()
+	}
 	lookup := simplestreams.LookupParams{
 		Series: []string{m.Series()},
-		Stream: env.Config().ImageStream(),
+		Stream: stream,
 	}
 
 	mcons, err := m.Constraints()
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
@@ -239,6 +240,35 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithManagementSpace(c *gc.C) {
+	s.addSpacesAndSubnets(c)
+	err := s.State.UpdateModelConfig(map[string]interface{}{"management-space": "space1"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: machine.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	info := result.Results[0].Result
+	// Without a spaces constraint, the machine is placed in the
+	// management space, and is given an address in it.
+	c.Check(info.SubnetsToZones, jc.DeepEquals, map[string][]string{
+		"subnet-0": []string{"zone0"},
+	})
+	c.Check(info.EndpointBindings, jc.DeepEquals, map[string]string{
+		environs.ManagementEndpointBinding: "first space id",
+	})
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstraints(c *gc.C) {
 	// Add an empty space.
 	_, err := s.State.AddSpace("empty", "", nil, true)
@@ -21,6 +21,11 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -45,6 +50,11 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.MachineTag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -713,6 +723,103 @@ func (s *StorageProvisionerAPIv4) RemoveVolumeParams(args params.Entities) (para
 	return results, nil
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the model,
+// including requests to grow them. Only the model's storage provisioner
+// grows volumes, so machine scopes are not supported.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	canAccess, err := s.getScopeAuthFunc()
+	if err != nil {
+		return params.StringsWatchResults{}, common.ServerError(common.ErrPerm)
+	}
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (string, []string, error) {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return "", nil, common.ErrPerm
+		}
+		if _, ok := tag.(names.ModelTag); !ok {
+			return "", nil, errors.NotSupportedf("watching volume resizes for %s", names.ReadableString(tag))
+		}
+		w := s.st.WatchModelVolumeResizes()
+		if changes, ok := <-w.Changes(); ok {
+			return s.resources.Register(w), changes, nil
+		}
+		return "", nil, watcher.EnsureErr(w)
+	}
+	for i, arg := range args.Entities {
+		var result params.StringsWatchResult
+		id, changes, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.StringsWatcherId = id
+			result.Changes = changes
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// ResizeVolumeParams returns the parameters for growing the volumes with
+// the specified tags. An error satisfying params.IsCodeNotFound is returned
+// for volumes that have no pending request to grow them.
+func (s *StorageProvisionerAPIv5) ResizeVolumeParams(args params.Entities) (params.ResizeVolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeVolumeParamsResults{}, err
+	}
+	results := params.ResizeVolumeParamsResults{
+		Results: make([]params.ResizeVolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeVolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok || volume.Life() != state.Alive {
+			return params.ResizeVolumeParams{}, errors.NotFoundf(
+				"resize request for %s", names.ReadableString(tag),
+			)
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		return params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  volumeInfo.VolumeId,
+			Provider:  string(provider),
+			Size:      size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeVolumeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
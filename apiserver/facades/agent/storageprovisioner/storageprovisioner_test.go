@@ -37,7 +37,7 @@ type provisionerSuite struct {
 	factory    *factory.Factory
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
-	api        *storageprovisioner.StorageProvisionerAPIv4
+	api        *storageprovisioner.StorageProvisionerAPIv5
 }
 
 func (s *provisionerSuite) SetUpTest(c *gc.C) {
@@ -63,7 +63,9 @@ func (s *provisionerSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv5(
+		storageprovisioner.NewStorageProvisionerAPIv4(v3),
+	)
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -903,6 +905,71 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{s.IAASModel.ModelTag().String()},
+		{"machine-0"},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[0].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"1", "2", "3", "4"}},
+			{Error: &params.Error{
+				Code:    params.CodeNotSupported,
+				Message: "watching volume resizes for machine 0 not supported",
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	err = s.IAASModel.GrowVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("2")
+	wc.AssertNoChange()
+}
+
+func (s *provisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.IAASModel.GrowVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-1"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{
+			{Result: params.ResizeVolumeParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "modelscoped",
+				Size:      8192,
+			}},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: "resize request for volume 1 not found",
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package storageusage implements the API facade used by the
+// storageusage worker, which reports the utilization of the
+// filesystems attached to a machine.
+package storageusage
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the State API used by the storageusage facade.
+type Backend interface {
+	MachineFilesystemAttachments(names.MachineTag) ([]state.FilesystemAttachment, error)
+	SetFilesystemAttachmentUsage(names.MachineTag, names.FilesystemTag, state.FilesystemUsage) error
+}
+
+// Facade implements the API required by the storageusage worker.
+type Facade struct {
+	backend      Backend
+	getCanAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the storageusage worker.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend: backend,
+		getCanAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// FilesystemAttachments returns the provisioned filesystem attachments
+// of each of the specified machines.
+func (facade *Facade) FilesystemAttachments(args params.Entities) (params.MachineFilesystemAttachmentsResults, error) {
+	results := params.MachineFilesystemAttachmentsResults{
+		Results: make([]params.MachineFilesystemAttachmentsResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		attachments, err := facade.backend.MachineFilesystemAttachments(tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, attachment := range attachments {
+			if attachment.Life() != state.Alive {
+				continue
+			}
+			info, err := attachment.Info()
+			if errors.IsNotProvisioned(err) {
+				continue
+			} else if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				break
+			}
+			results.Results[i].Attachments = append(results.Results[i].Attachments, params.FilesystemAttachment{
+				FilesystemTag: attachment.Filesystem().String(),
+				MachineTag:    tag.String(),
+				Info: params.FilesystemAttachmentInfo{
+					MountPoint: info.MountPoint,
+					ReadOnly:   info.ReadOnly,
+				},
+			})
+		}
+	}
+	return results, nil
+}
+
+// SetFilesystemUsage records the utilization of the specified
+// filesystem attachments.
+func (facade *Facade) SetFilesystemUsage(args params.FilesystemUsageArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil || !canAccess(machineTag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		filesystemTag, err := names.ParseFilesystemTag(arg.FilesystemTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = facade.backend.SetFilesystemAttachmentUsage(machineTag, filesystemTag, state.FilesystemUsage{
+			Size:      arg.Usage.Size,
+			Used:      arg.Usage.Used,
+			Available: arg.Usage.Available,
+			Updated:   arg.Usage.Updated,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
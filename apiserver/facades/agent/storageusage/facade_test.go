@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageusage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/storageusage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *storageusage.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		attachments: []state.FilesystemAttachment{
+			&mockFilesystemAttachment{
+				filesystem: names.NewFilesystemTag("0"),
+				life:       state.Alive,
+				info:       &state.FilesystemAttachmentInfo{MountPoint: "/srv/data"},
+			},
+			&mockFilesystemAttachment{
+				filesystem: names.NewFilesystemTag("1"),
+				life:       state.Alive,
+			},
+			&mockFilesystemAttachment{
+				filesystem: names.NewFilesystemTag("2"),
+				life:       state.Dying,
+				info:       &state.FilesystemAttachmentInfo{MountPoint: "/srv/logs"},
+			},
+		},
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := storageusage.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := storageusage.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestFilesystemAttachments(c *gc.C) {
+	result, err := s.facade.FilesystemAttachments(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachineFilesystemAttachmentsResults{
+		Results: []params.MachineFilesystemAttachmentsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Attachments: []params.FilesystemAttachment{{
+				FilesystemTag: "filesystem-0",
+				MachineTag:    "machine-1",
+				Info:          params.FilesystemAttachmentInfo{MountPoint: "/srv/data"},
+			}}},
+		},
+	})
+	s.backend.stub.CheckCall(c, 0, "MachineFilesystemAttachments", names.NewMachineTag("1"))
+}
+
+func (s *facadeSuite) TestFilesystemAttachmentsError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	result, err := s.facade.FilesystemAttachments(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestSetFilesystemUsage(c *gc.C) {
+	updated := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	usage := params.FilesystemUsage{
+		Size:      1024,
+		Used:      256,
+		Available: 768,
+		Updated:   updated,
+	}
+	result, err := s.facade.SetFilesystemUsage(params.FilesystemUsageArgs{
+		Args: []params.FilesystemUsageArg{{
+			MachineTag:    "machine-0",
+			FilesystemTag: "filesystem-0",
+			Usage:         usage,
+		}, {
+			MachineTag:    "machine-1",
+			FilesystemTag: "volume-0",
+			Usage:         usage,
+		}, {
+			MachineTag:    "machine-1",
+			FilesystemTag: "filesystem-0",
+			Usage:         usage,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"volume-0" is not a valid filesystem tag`)
+	c.Assert(result.Results[2].Error, gc.IsNil)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SetFilesystemAttachmentUsage",
+		[]interface{}{
+			names.NewMachineTag("1"),
+			names.NewFilesystemTag("0"),
+			state.FilesystemUsage{
+				Size:      1024,
+				Used:      256,
+				Available: 768,
+				Updated:   updated,
+			},
+		},
+	}})
+}
+
+type mockBackend struct {
+	stub        jujutesting.Stub
+	attachments []state.FilesystemAttachment
+}
+
+func (b *mockBackend) MachineFilesystemAttachments(tag names.MachineTag) ([]state.FilesystemAttachment, error) {
+	b.stub.AddCall("MachineFilesystemAttachments", tag)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.attachments, nil
+}
+
+func (b *mockBackend) SetFilesystemAttachmentUsage(m names.MachineTag, f names.FilesystemTag, usage state.FilesystemUsage) error {
+	b.stub.AddCall("SetFilesystemAttachmentUsage", m, f, usage)
+	return b.stub.NextErr()
+}
+
+type mockFilesystemAttachment struct {
+	state.FilesystemAttachment
+	filesystem names.FilesystemTag
+	life       state.Life
+	info       *state.FilesystemAttachmentInfo
+}
+
+func (a *mockFilesystemAttachment) Filesystem() names.FilesystemTag {
+	return a.filesystem
+}
+
+func (a *mockFilesystemAttachment) Life() state.Life {
+	return a.life
+}
+
+func (a *mockFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error) {
+	if a.info == nil {
+		return state.FilesystemAttachmentInfo{}, errors.NotProvisionedf("filesystem attachment")
+	}
+	return *a.info, nil
+}
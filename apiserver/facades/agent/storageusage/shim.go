@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageusage
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade wraps New to express the supplied *state.State's
+// IAASModel as a Backend.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	im, err := st.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := New(im, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
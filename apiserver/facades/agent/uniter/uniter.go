@@ -8,6 +8,7 @@ package uniter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -15,14 +16,17 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/common/firewall"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/facade"
 	leadershipapiserver "github.com/juju/juju/apiserver/facades/agent/leadership"
 	"github.com/juju/juju/apiserver/facades/agent/meterstatus"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -33,7 +37,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v7) of the Uniter API.
+// UniterAPI implements the latest version (v14) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -52,12 +56,51 @@ type UniterAPI struct {
 	accessApplication common.GetAuthFunc
 	unit              *state.Unit
 	accessMachine     common.GetAuthFunc
+	cloudSpec         cloudspec.CloudSpecAPI
 	StorageAPI
 }
 
+// UniterAPIV13 doesn't have the RelatedApplicationStatuses method.
+type UniterAPIV13 struct {
+	UniterAPI
+}
+
+// UniterAPIV12 doesn't have the ConfigReadyHashes or
+// SetConfigReadyHashes methods.
+type UniterAPIV12 struct {
+	UniterAPIV13
+}
+
+// UniterAPIV11 doesn't have the FloatingIPUnits method.
+type UniterAPIV11 struct {
+	UniterAPIV12
+}
+
+// UniterAPIV10 doesn't have the AcquireCharmLocks or
+// ReleaseCharmLocks methods.
+type UniterAPIV10 struct {
+	UniterAPIV11
+}
+
+// UniterAPIV9 doesn't have the PeerSeeds or UpdatePeerSeeds methods.
+type UniterAPIV9 struct {
+	UniterAPIV10
+}
+
+// UniterAPIV8 doesn't have the LogActionsMessages or
+// SetActionsProgress methods.
+type UniterAPIV8 struct {
+	UniterAPIV9
+}
+
+// UniterAPIV7 doesn't have the CloudSpec method.
+type UniterAPIV7 struct {
+	UniterAPIV8
+}
+
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
 type UniterAPIV6 struct {
-	UniterAPI
+	UniterAPIV7
 }
 
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
@@ -151,7 +194,7 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 		LifeGetter:                 common.NewLifeGetter(st, accessUnitOrApplication),
 		DeadEnsurer:                common.NewDeadEnsurer(st, accessUnit),
 		AgentEntityWatcher:         common.NewAgentEntityWatcher(st, resources, accessUnitOrApplication),
-		APIAddresser:               common.NewAPIAddresser(st, resources),
+		APIAddresser:               common.NewAgentAPIAddresser(st, resources),
 		ModelWatcher:               common.NewModelWatcher(m, resources, authorizer),
 		RebootRequester:            common.NewRebootRequester(st, accessMachine),
 		LeadershipSettingsAccessor: leadershipSettingsAccessorFactory(st, resources, authorizer),
@@ -168,18 +211,99 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 		accessApplication: accessApplication,
 		accessMachine:     accessMachine,
 		unit:              unit,
-		StorageAPI:        *storageAPI,
+		cloudSpec: cloudspec.NewCloudSpec(
+			cloudspec.MakeCloudSpecGetterForModel(st),
+			common.AuthFuncForTag(m.ModelTag()),
+		),
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
+// NewUniterAPIV13 creates an instance of the V13 uniter API.
+func NewUniterAPIV13(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV13, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV13{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV12 creates an instance of the V12 uniter API.
+func NewUniterAPIV12(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV12, error) {
+	uniterAPI, err := NewUniterAPIV13(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV12{
+		UniterAPIV13: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV11 creates an instance of the V11 uniter API.
+func NewUniterAPIV11(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV11, error) {
+	uniterAPI, err := NewUniterAPIV12(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV11{
+		UniterAPIV12: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV10 creates an instance of the V10 uniter API.
+func NewUniterAPIV10(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV10, error) {
+	uniterAPI, err := NewUniterAPIV11(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV10{
+		UniterAPIV11: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV9 creates an instance of the V9 uniter API.
+func NewUniterAPIV9(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV9, error) {
+	uniterAPI, err := NewUniterAPIV10(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{
+		UniterAPIV10: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPIV9(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPIV9: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV7 creates an instance of the V7 uniter API.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPIV8(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{
+		UniterAPIV8: *uniterAPI,
 	}, nil
 }
 
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
 func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV6{
-		UniterAPI: *uniterAPI,
+		UniterAPIV7: *uniterAPI,
 	}, nil
 }
 
@@ -1006,6 +1130,39 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records progress messages for the given running
+// Actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
+// SetActionsProgress records the progress of the given running Actions.
+func (u *UniterAPI) SetActionsProgress(args params.ActionProgressParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.SetActionsProgress(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1372,6 +1529,10 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 	if err != nil {
 		return params.ErrorResults{}, err
 	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
 	for i, arg := range args.RelationUnits {
 		unit, err := names.ParseUnitTag(arg.Unit)
 		if err != nil {
@@ -1390,6 +1551,9 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 						settings.Set(k, v)
 					}
 				}
+				err = checkRelationSettingsLimits(cfg, relUnit.Relation(), unit, settings)
+			}
+			if err == nil {
 				_, err = settings.Write()
 			}
 		}
@@ -1398,6 +1562,129 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 	return result, nil
 }
 
+// PeerSeeds returns the seed data written by the application's leader
+// to each given pair of peer relation and unit.
+func (u *UniterAPI) PeerSeeds(args params.RelationUnits) (params.SettingsResults, error) {
+	result := params.SettingsResults{
+		Results: make([]params.SettingsResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SettingsResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			var seed map[string]string
+			seed, err = relUnit.Relation().PeerSeed()
+			if err == nil {
+				result.Results[i].Settings = params.Settings(seed)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// UpdatePeerSeeds updates the seed data of each given pair of peer
+// relation and unit. Only the leader of the unit's application may
+// update seed data. Keys with empty values are considered a signal
+// to delete these values.
+func (u *UniterAPI) UpdatePeerSeeds(args params.RelationUnitsSettings) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	updateOne := func(arg params.RelationUnitSettings) error {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			return common.ErrPerm
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rel := relUnit.Relation()
+		seed, err := rel.PeerSeed()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		merged := make(map[string]interface{})
+		for k, v := range seed {
+			merged[k] = v
+		}
+		for k, v := range arg.Settings {
+			if v == "" {
+				delete(merged, k)
+			} else {
+				merged[k] = v
+			}
+		}
+		if err := checkPeerSeedLimits(cfg, rel, merged); err != nil {
+			return errors.Trace(err)
+		}
+		token := u.st.LeadershipChecker().LeadershipCheck(u.unit.ApplicationName(), unit.Id())
+		return rel.UpdatePeerSeed(token, arg.Settings)
+	}
+	for i, arg := range args.RelationUnits {
+		err := updateOne(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// checkPeerSeedLimits returns a quota limit exceeded error if the given
+// seed data of a peer relation exceeds the limits on relation settings
+// set in the model config.
+func checkPeerSeedLimits(cfg *config.Config, rel *state.Relation, seed map[string]interface{}) error {
+	keys, size := state.SettingsUsage(seed)
+	if limit := cfg.MaxRelationSettingsKeys(); limit > 0 && keys > limit {
+		return common.QuotaLimitExceededError(
+			"seed data for relation %q exceeds %s: %d keys (limit %d)",
+			rel.String(), config.MaxRelationSettingsKeys, keys, limit,
+		)
+	}
+	if limit := cfg.MaxRelationSettingsSize(); limit > 0 && size > limit {
+		return common.QuotaLimitExceededError(
+			"seed data for relation %q exceeds %s: %d bytes (limit %d)",
+			rel.String(), config.MaxRelationSettingsSize, size, limit,
+		)
+	}
+	return nil
+}
+
+// checkRelationSettingsLimits returns a quota limit exceeded error if
+// the given relation settings of the unit exceed the limits set in the
+// model config. A limit of zero means there is no limit.
+func checkRelationSettingsLimits(cfg *config.Config, rel *state.Relation, unit names.UnitTag, settings *state.Settings) error {
+	keys, size := state.SettingsUsage(settings.Map())
+	if limit := cfg.MaxRelationSettingsKeys(); limit > 0 && keys > limit {
+		return common.QuotaLimitExceededError(
+			"relation settings for unit %q in relation %q exceed %s: %d keys (limit %d)",
+			unit.Id(), rel.String(), config.MaxRelationSettingsKeys, keys, limit,
+		)
+	}
+	if limit := cfg.MaxRelationSettingsSize(); limit > 0 && size > limit {
+		return common.QuotaLimitExceededError(
+			"relation settings for unit %q in relation %q exceed %s: %d bytes (limit %d)",
+			unit.Id(), rel.String(), config.MaxRelationSettingsSize, size, limit,
+		)
+	}
+	return nil
+}
+
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
 // changes to every unit in the supplied relation that is visible to
 // the supplied unit. See also state/watcher.go:RelationUnit.Watch().
@@ -1824,6 +2111,109 @@ func (u *UniterAPI) SLALevel() (params.StringResult, error) {
 	return result, err
 }
 
+// CloudSpec returns the cloud spec, including the cloud credential, of
+// the model hosting the calling unit. It is only available if the
+// controller allows credential access, and then only to units of
+// applications that have been trusted with the credential; each
+// successful call is recorded against the application so that use of
+// the credential can be audited.
+//
+// The credential is not scoped or temporary: it is the model's
+// credential as stored, and may be used by the unit for anything the
+// credential allows.
+func (u *UniterAPI) CloudSpec() (params.CloudSpecResult, error) {
+	controllerConfig, err := u.st.ControllerConfig()
+	if err != nil {
+		return params.CloudSpecResult{}, errors.Trace(err)
+	}
+	if !controllerConfig.AllowCredentialGet() {
+		return params.CloudSpecResult{}, errors.Errorf(
+			"cloud credential access is disabled by the controller's %q config", controller.AllowCredentialGetKey,
+		)
+	}
+	app, err := u.unit.Application()
+	if err != nil {
+		return params.CloudSpecResult{}, errors.Trace(err)
+	}
+	trusted, err := app.IsTrusted()
+	if err != nil {
+		return params.CloudSpecResult{}, errors.Trace(err)
+	}
+	if !trusted {
+		logger.Warningf("unit %q of untrusted application %q denied access to cloud credential", u.unit.Name(), app.Name())
+		return params.CloudSpecResult{}, common.ErrPerm
+	}
+	result := u.cloudSpec.GetCloudSpec(u.m.ModelTag())
+	if result.Error != nil {
+		return result, nil
+	}
+	if err := app.RecordTrustedCredentialAccess(u.unit.Name()); err != nil {
+		if errors.IsNotFound(err) {
+			// Trust was removed while the spec was being read.
+			return params.CloudSpecResult{}, common.ErrPerm
+		}
+		return params.CloudSpecResult{}, errors.Trace(err)
+	}
+	logger.Infof("unit %q retrieved cloud credential for model %q", u.unit.Name(), u.m.UUID())
+	return result, nil
+}
+
+// HookEnvironment returns, for each given unit, the extra environment
+// variables to set for its hook executions: those set in the model's
+// hook-environment config, overridden by those set for the unit's
+// application.
+func (u *UniterAPI) HookEnvironment(args params.Entities) (params.HookEnvironmentResults, error) {
+	result := params.HookEnvironmentResults{
+		Results: make([]params.HookEnvironmentResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.HookEnvironmentResults{}, err
+	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.HookEnvironmentResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var env map[string]string
+			env, err = u.unitHookEnvironment(cfg, tag)
+			result.Results[i].Environment = env
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) unitHookEnvironment(cfg *config.Config, tag names.UnitTag) (map[string]string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := app.HookEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[string]string)
+	for k, v := range cfg.HookEnvironment() {
+		env[k] = v
+	}
+	for k, v := range overrides {
+		env[k] = v
+	}
+	return env, nil
+}
+
 // NetworkInfo returns network interfaces/addresses for specified bindings.
 func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
 	canAccess, err := u.accessUnit()
@@ -2177,6 +2567,37 @@ func (u *UniterAPIV5) RelationById(args params.RelationIds) (params.RelationResu
 	return relationResultsToV5(v6Results), nil
 }
 
+// FloatingIPUnits returns, for each given application, the name of the
+// unit whose machine the application's floating IP address is
+// associated with, or the empty string if there is none.
+func (u *UniterAPI) FloatingIPUnits(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessApplication()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := u.getApplication(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		_, result.Results[i].Result = application.FloatingIP()
+	}
+	return result, nil
+}
+
 // WatchApplicationRelations returns a StringsWatcher, for each given
 // application, that notifies of changes to the lifecycles of
 // relations involving that application. This method is obsolete -
@@ -2228,8 +2649,234 @@ func (u *UniterAPIV4) watchOneApplicationRelations(tag names.ApplicationTag) (pa
 // SLALevel isn't on the V4 API.
 func (u *UniterAPIV4) SLALevel(_, _ struct{}) {}
 
+// AcquireCharmLocks acquires each given lock, shared by the units of
+// the given unit's application, on behalf of the unit. A lock held by
+// another unit is reported with a CodeLockHeld error.
+func (u *UniterAPI) AcquireCharmLocks(args params.CharmLockArgs) (params.ErrorResults, error) {
+	return u.charmLocks(args, func(unit *state.Unit, arg params.CharmLockArg) error {
+		ttl := time.Duration(arg.DurationSeconds * float64(time.Second))
+		return unit.AcquireCharmLock(arg.Name, ttl)
+	})
+}
+
+// ReleaseCharmLocks releases each given lock held by the given unit.
+func (u *UniterAPI) ReleaseCharmLocks(args params.CharmLockArgs) (params.ErrorResults, error) {
+	return u.charmLocks(args, func(unit *state.Unit, arg params.CharmLockArg) error {
+		return unit.ReleaseCharmLock(arg.Name)
+	})
+}
+
+// ConfigReadyHashes returns, for each given application, the hash of
+// the config settings that its leader last reported it had finished
+// handling, or the empty string if it has not reported any.
+func (u *UniterAPI) ConfigReadyHashes(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessApplication()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := u.getApplication(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = application.ConfigReadyHash()
+	}
+	return result, nil
+}
+
+// SetConfigReadyHashes records, for each given unit, that it has
+// finished handling the config settings with the given hash. Only
+// the leader of the unit's application may do so.
+func (u *UniterAPI) SetConfigReadyHashes(args params.EntityConfigHashes) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		application, err := unit.Application()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		token := u.st.LeadershipChecker().LeadershipCheck(application.Name(), tag.Id())
+		err = application.SetConfigReadyHash(token, entity.Hash)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RelatedApplicationStatuses returns, for each given unit, the workload
+// status of each application related to the unit's application, keyed
+// by application name. Only the aggregated status and message of each
+// application are returned; the statuses of individual units, and any
+// status data, are not.
+func (u *UniterAPI) RelatedApplicationStatuses(args params.Entities) (params.RelatedApplicationStatusResults, error) {
+	result := params.RelatedApplicationStatusResults{
+		Results: make([]params.RelatedApplicationStatusResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.RelatedApplicationStatusResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		statuses, err := u.relatedApplicationStatuses(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Statuses = statuses
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) relatedApplicationStatuses(tag names.UnitTag) (map[string]params.StatusResult, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, err
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return nil, err
+	}
+	relations, err := application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]params.StatusResult)
+	for _, rel := range relations {
+		for _, ep := range rel.Endpoints() {
+			name := ep.ApplicationName
+			if name == application.Name() {
+				continue
+			}
+			if _, ok := statuses[name]; ok {
+				continue
+			}
+			info, err := u.applicationStatus(name)
+			if errors.IsNotFound(err) {
+				// The application is being removed.
+				continue
+			} else if err != nil {
+				return nil, errors.Annotatef(err, "getting status of application %q", name)
+			}
+			statuses[name] = params.StatusResult{
+				Id:     name,
+				Status: info.Status.String(),
+				Info:   info.Message,
+				Since:  info.Since,
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// applicationStatus returns the status of the named application, which
+// may be a remote application consumed from another model.
+func (u *UniterAPI) applicationStatus(name string) (status.StatusInfo, error) {
+	application, err := u.st.Application(name)
+	if err == nil {
+		return application.Status()
+	} else if !errors.IsNotFound(err) {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	remoteApplication, err := u.st.RemoteApplication(name)
+	if err != nil {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	return remoteApplication.Status()
+}
+
+func (u *UniterAPI) charmLocks(
+	args params.CharmLockArgs,
+	f func(*state.Unit, params.CharmLockArg) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			err = f(unit, arg)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // NetworkInfo isn't on the V4 API.
 func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
+
+// CloudSpec isn't on the V7 API.
+func (u *UniterAPIV7) CloudSpec(_, _ struct{}) {}
+
+// HookEnvironment isn't on the V7 API.
+func (u *UniterAPIV7) HookEnvironment(_, _ struct{}) {}
+
+// FloatingIPUnits isn't on the V11 API.
+func (u *UniterAPIV11) FloatingIPUnits(_, _ struct{}) {}
+
+// ConfigReadyHashes isn't on the V12 API.
+func (u *UniterAPIV12) ConfigReadyHashes(_, _ struct{}) {}
+
+// SetConfigReadyHashes isn't on the V12 API.
+func (u *UniterAPIV12) SetConfigReadyHashes(_, _ struct{}) {}
+
+// RelatedApplicationStatuses isn't on the V13 API.
+func (u *UniterAPIV13) RelatedApplicationStatuses(_, _ struct{}) {}
+
+// AcquireCharmLocks isn't on the V10 API.
+func (u *UniterAPIV10) AcquireCharmLocks(_, _ struct{}) {}
+
+// ReleaseCharmLocks isn't on the V10 API.
+func (u *UniterAPIV10) ReleaseCharmLocks(_, _ struct{}) {}
+
+// PeerSeeds isn't on the V9 API.
+func (u *UniterAPIV9) PeerSeeds(_, _ struct{}) {}
+
+// UpdatePeerSeeds isn't on the V9 API.
+func (u *UniterAPIV9) UpdatePeerSeeds(_, _ struct{}) {}
+
+// LogActionsMessages isn't on the V8 API.
+func (u *UniterAPIV8) LogActionsMessages(_, _ struct{}) {}
+
+// SetActionsProgress isn't on the V8 API.
+func (u *UniterAPIV8) SetActionsProgress(_, _ struct{}) {}
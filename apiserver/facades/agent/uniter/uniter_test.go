@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -898,6 +899,140 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestFloatingIPUnits(c *gc.C) {
+	err := s.wordpress.SetFloatingIP("203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetFloatingIPUnit("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-foo"},
+	}}
+	result, err := s.uniter.FloatingIPUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "wordpress/0"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestConfigReadyHashes(c *gc.C) {
+	token := s.State.LeadershipChecker().LeadershipCheck("wordpress", "wordpress/0")
+	err := s.wordpress.SetConfigReadyHash(token, "abc")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-foo"},
+	}}
+	result, err := s.uniter.ConfigReadyHashes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "abc"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetConfigReadyHashes(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.EntityConfigHashes{Entities: []params.EntityConfigHash{
+		{Tag: "unit-mysql-0", Hash: "abc"},
+		{Tag: "unit-wordpress-0", Hash: "abc"},
+		{Tag: "application-wordpress", Hash: "abc"},
+	}}
+	result, err := s.uniter.SetConfigReadyHashes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.ConfigReadyHash(), gc.Equals, "abc")
+}
+
+func (s *uniterSuite) TestSetConfigReadyHashesNotLeader(c *gc.C) {
+	_, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SetConfigReadyHashes(params.EntityConfigHashes{
+		Entities: []params.EntityConfigHash{{Tag: "unit-wordpress-0", Hash: "abc"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `cannot set config ready hash of application "wordpress": .*"wordpress/0" is not leader of "wordpress"`)
+
+	err = s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.ConfigReadyHash(), gc.Equals, "")
+}
+
+func (s *uniterSuite) TestRelatedApplicationStatuses(c *gc.C) {
+	s.addRelation(c, "wordpress", "mysql")
+	now := time.Now()
+	err := s.mysql.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.RelatedApplicationStatuses(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	statuses := result.Results[1].Statuses
+	c.Assert(statuses, gc.HasLen, 1)
+	mysqlStatus := statuses["mysql"]
+	c.Assert(mysqlStatus.Since, gc.NotNil)
+	mysqlStatus.Since = nil
+	c.Assert(mysqlStatus, gc.DeepEquals, params.StatusResult{
+		Id:     "mysql",
+		Status: "active",
+		Info:   "ready",
+	})
+}
+
+func (s *uniterSuite) TestRelatedApplicationStatusesNoRelations(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}}
+	result, err := s.uniter.RelatedApplicationStatuses(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.RelatedApplicationStatusResults{
+		Results: []params.RelatedApplicationStatusResult{
+			{Statuses: map[string]params.StatusResult{}},
+		},
+	})
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPorts, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1599,6 +1734,45 @@ func (s *uniterSuite) TestFinishActionsFailure(c *gc.C) {
 	c.Assert(results[0].Name(), gc.Equals, testName)
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := s.uniter.LogActionsMessages(params.ActionMessageParams{
+		Messages: []params.ActionMessageParam{
+			{Tag: action.ActionTag().String(), Message: "hello"},
+			{Tag: bad.ActionTag().String(), Message: "hello"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: nil},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	res, err = s.uniter.SetActionsProgress(params.ActionProgressParams{
+		Progress: []params.ActionProgressParam{
+			{Tag: action.ActionTag().String(), Percent: 40, Message: "nearly half"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}})
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = m.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Progress(), gc.Equals, 40)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "hello")
+	c.Assert(messages[1].Message, gc.Equals, "nearly half")
+}
+
 func (s *uniterSuite) TestFinishActionsAuthAccess(c *gc.C) {
 	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2297,6 +2471,193 @@ func (s *uniterSuite) TestUpdateSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUpdateSettingsTooManyKeys(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{config.MaxRelationSettingsKeys: 2}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"other": "stuff", "more": "stuff"},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{&params.Error{
+			Message: `relation settings for unit "wordpress/0" in relation "wordpress:db mysql:server" exceed max-relation-settings-keys: 3 keys (limit 2)`,
+			Code:    params.CodeQuotaLimitExceeded,
+		}}},
+	})
+
+	// Verify the settings were not saved.
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"some": "settings",
+	})
+}
+
+func (s *uniterSuite) TestUpdateSettingsTooLarge(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{config.MaxRelationSettingsSize: 16}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"key": "a-value-that-is-too-long"},
+	}, {
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"key": "short"},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{&params.Error{
+			Message: `relation settings for unit "wordpress/0" in relation "wordpress:db mysql:server" exceed max-relation-settings-size: 27 bytes (limit 16)`,
+			Code:    params.CodeQuotaLimitExceeded,
+		}}, {nil}},
+	})
+
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"key": "short",
+	})
+}
+
+func (s *uniterSuite) setupPeerSeed(c *gc.C) (*uniter.UniterAPI, *state.Relation) {
+	riak := s.AddTestingApplication(c, "riak", s.AddTestingCharm(c, "riak"))
+	riakUnit, err := riak.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	rels, err := riak.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	relUnit, err := rels[0].Unit(riakUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	authorizer := apiservertesting.FakeAuthorizer{Tag: riakUnit.Tag()}
+	api, err := uniter.NewUniterAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api, rels[0]
+}
+
+func (s *uniterSuite) TestPeerSeeds(c *gc.C) {
+	api, ring := s.setupPeerSeed(c)
+	err := s.State.LeadershipClaimer().ClaimLeadership("riak", "riak/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.State.LeadershipChecker().LeadershipCheck("riak", "riak/0")
+	err = ring.UpdatePeerSeed(token, map[string]string{"token": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	wpRel := s.addRelation(c, "wordpress", "mysql")
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: ring.Tag().String(), Unit: "unit-riak-0"},
+		{Relation: ring.Tag().String(), Unit: "unit-riak-1"},
+		{Relation: wpRel.Tag().String(), Unit: "unit-riak-0"},
+		{Relation: "relation-42", Unit: "unit-riak-0"},
+		{Relation: ring.Tag().String(), Unit: "application-riak"},
+	}}
+	result, err := api.PeerSeeds(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.SettingsResults{
+		Results: []params.SettingsResult{
+			{Settings: params.Settings{"token": "s3cret"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{
+				Message: `application "riak" is not a member of "wordpress:db mysql:server"`,
+				Code:    params.CodeNotFound,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestUpdatePeerSeeds(c *gc.C) {
+	api, ring := s.setupPeerSeed(c)
+	err := s.State.LeadershipClaimer().ClaimLeadership("riak", "riak/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{
+		{Relation: ring.Tag().String(), Unit: "unit-riak-0", Settings: params.Settings{"token": "s3cret"}},
+		{Relation: ring.Tag().String(), Unit: "unit-riak-1", Settings: nil},
+		{Relation: "relation-42", Unit: "unit-riak-0", Settings: nil},
+	}}
+	result, err := api.UpdatePeerSeeds(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	seed, err := ring.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seed, gc.DeepEquals, map[string]string{"token": "s3cret"})
+}
+
+func (s *uniterSuite) TestUpdatePeerSeedsNotLeader(c *gc.C) {
+	api, ring := s.setupPeerSeed(c)
+	err := s.State.LeadershipClaimer().ClaimLeadership("riak", "riak/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.UpdatePeerSeeds(params.RelationUnitsSettings{
+		RelationUnits: []params.RelationUnitSettings{{
+			Relation: ring.Tag().String(),
+			Unit:     "unit-riak-0",
+			Settings: params.Settings{"token": "s3cret"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `cannot update seed data for relation "riak:ring": .*"riak/0" is not leader of "riak"`)
+
+	seed, err := ring.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seed, gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestUpdatePeerSeedsTooManyKeys(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{config.MaxRelationSettingsKeys: 1}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	api, ring := s.setupPeerSeed(c)
+	err = s.State.LeadershipClaimer().ClaimLeadership("riak", "riak/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.State.LeadershipChecker().LeadershipCheck("riak", "riak/0")
+	err = ring.UpdatePeerSeed(token, map[string]string{"token": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.UpdatePeerSeeds(params.RelationUnitsSettings{
+		RelationUnits: []params.RelationUnitSettings{{
+			Relation: ring.Tag().String(),
+			Unit:     "unit-riak-0",
+			Settings: params.Settings{"other": "stuff"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{&params.Error{
+			Message: `seed data for relation "riak:ring" exceeds max-relation-settings-keys: 2 keys (limit 1)`,
+			Code:    params.CodeQuotaLimitExceeded,
+		}}},
+	})
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -2715,6 +3076,146 @@ func (s *uniterSuite) TestSLALevel(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, params.StringResult{Result: "essential"})
 }
 
+func (s *uniterSuite) allowCredentialGet(c *gc.C) {
+	_, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AllowCredentialGetKey: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestCloudSpec(c *gc.C) {
+	s.allowCredentialGet(c)
+	err := s.wordpress.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+	c.Assert(result.Result.Type, gc.Equals, "dummy")
+
+	trust, err := s.wordpress.Trust()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trust.UseCount, gc.Equals, 1)
+	c.Assert(trust.LastUsedBy, gc.Equals, "wordpress/0")
+	c.Assert(trust.LastUsed.IsZero(), jc.IsFalse)
+}
+
+func (s *uniterSuite) TestCloudSpecDisabled(c *gc.C) {
+	err := s.wordpress.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, `cloud credential access is disabled by the controller's "allow-credential-get" config`)
+
+	trust, err := s.wordpress.Trust()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trust.UseCount, gc.Equals, 0)
+}
+
+func (s *uniterSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	s.allowCredentialGet(c)
+	_, err := s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *uniterSuite) TestCloudSpecTrustRemoved(c *gc.C) {
+	s.allowCredentialGet(c)
+	err := s.wordpress.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.RemoveTrust()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *uniterSuite) TestHookEnvironment(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		config.HookEnvironmentKey: "SITE_ID=lon1 COMPLIANCE=pci",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetHookEnvironment(map[string]string{"SITE_ID": "lon2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.HookEnvironment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HookEnvironmentResults{
+		Results: []params.HookEnvironmentResult{
+			{Environment: map[string]string{"SITE_ID": "lon2", "COMPLIANCE": "pci"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestAcquireCharmLocks(c *gc.C) {
+	other, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.AcquireCharmLock("restart", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.CharmLockArgs{Args: []params.CharmLockArg{
+		{UnitTag: "unit-wordpress-0", Name: "migrate", DurationSeconds: 60},
+		{UnitTag: "unit-wordpress-0", Name: "restart", DurationSeconds: 60},
+		{UnitTag: "unit-wordpress-0", Name: "", DurationSeconds: 60},
+		{UnitTag: "unit-mysql-0", Name: "migrate", DurationSeconds: 60},
+		{UnitTag: "application-wordpress", Name: "migrate", DurationSeconds: 60},
+	}}
+	result, err := s.uniter.AcquireCharmLocks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{&params.Error{
+				Message: `cannot acquire lock "restart": lock "restart" is held by unit "wordpress/1"`,
+				Code:    params.CodeLockHeld,
+			}},
+			{&params.Error{
+				Message: `cannot acquire lock "": empty lock name not valid`,
+				Code:    params.CodeNotValid,
+			}},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	lock, err := s.wordpress.CharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Holder, gc.Equals, "wordpress/0")
+}
+
+func (s *uniterSuite) TestReleaseCharmLocks(c *gc.C) {
+	err := s.wordpressUnit.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.CharmLockArgs{Args: []params.CharmLockArg{
+		{UnitTag: "unit-wordpress-0", Name: "migrate"},
+		{UnitTag: "unit-wordpress-0", Name: "restart"},
+		{UnitTag: "unit-mysql-0", Name: "migrate"},
+	}}
+	result, err := s.uniter.ReleaseCharmLocks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{&params.Error{
+				Message: `cannot release lock "restart": lock "restart" not found`,
+				Code:    params.CodeNotFound,
+			}},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	_, err = s.wordpress.CharmLock("migrate")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *uniterSuite) setupRemoteRelationScenario(c *gc.C) (names.Tag, *state.RelationUnit) {
 	s.makeRemoteWordpress(c)
 
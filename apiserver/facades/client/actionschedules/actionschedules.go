@@ -0,0 +1,171 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionschedules provides the API for managing the schedules
+// that enqueue actions periodically, or once at a future time.
+package actionschedules
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality required by API.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AddActionSchedule(state.AddActionScheduleParams) (state.ActionSchedule, error)
+	ActionSchedules() ([]state.ActionSchedule, error)
+	RemoveActionSchedule(id string) error
+}
+
+// BlockChecker checks whether changes to the model are allowed.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+// API provides access to the ActionSchedules API facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(st, authorizer, common.NewBlockChecker(st))
+}
+
+// NewAPI returns a new ActionSchedules API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, check BlockChecker) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      check,
+	}, nil
+}
+
+func (api *API) checkPermission(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AddSchedules adds schedules that enqueue actions on units, either
+// periodically according to a cron expression, or once at a future
+// time.
+func (api *API) AddSchedules(args params.AddActionSchedules) (params.ActionScheduleResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := make([]params.ActionScheduleResult, len(args.Schedules))
+	for i, arg := range args.Schedules {
+		schedule, err := api.addSchedule(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = &schedule
+	}
+	return params.ActionScheduleResults{Results: results}, nil
+}
+
+func (api *API) addSchedule(arg params.AddActionSchedule) (params.ActionSchedule, error) {
+	tag, err := names.ParseUnitTag(arg.Receiver)
+	if err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	addParams := state.AddActionScheduleParams{
+		Receiver:   tag.Id(),
+		Name:       arg.Name,
+		Parameters: arg.Parameters,
+		Cron:       arg.Cron,
+		Owner:      api.authorizer.GetAuthTag().Id(),
+	}
+	if arg.At != nil {
+		addParams.At = *arg.At
+	}
+	schedule, err := api.backend.AddActionSchedule(addParams)
+	if err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	return toParams(schedule), nil
+}
+
+// Schedules returns the model's action schedules, with the history of
+// their recent runs.
+func (api *API) Schedules() (params.ActionSchedulesResult, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.ActionSchedulesResult{}, errors.Trace(err)
+	}
+	schedules, err := api.backend.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedulesResult{}, errors.Trace(err)
+	}
+	result := params.ActionSchedulesResult{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = toParams(schedule)
+	}
+	return result, nil
+}
+
+// RemoveSchedules removes the action schedules with the given ids.
+// Actions already enqueued by the schedules are not affected.
+func (api *API) RemoveSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		results[i].Error = common.ServerError(api.backend.RemoveActionSchedule(id))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func toParams(schedule state.ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Id:         schedule.Id,
+		Receiver:   names.NewUnitTag(schedule.Receiver).String(),
+		Name:       schedule.Name,
+		Parameters: schedule.Parameters,
+		Cron:       schedule.Cron,
+		Owner:      schedule.Owner,
+		Created:    schedule.Created,
+	}
+	if !schedule.NextRun.IsZero() {
+		nextRun := schedule.NextRun
+		result.NextRun = &nextRun
+	}
+	for _, run := range schedule.History {
+		paramsRun := params.ActionScheduleRun{
+			Time:  run.Time,
+			Error: run.Error,
+		}
+		if run.ActionId != "" {
+			paramsRun.Action = names.NewActionTag(run.ActionId).String()
+		}
+		result.History = append(result.History, paramsRun)
+	}
+	return result
+}
@@ -0,0 +1,245 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/actionschedules"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type actionSchedulesSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	blocks     *mockBlockChecker
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&actionSchedulesSuite{})
+
+var (
+	created = time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	nextRun = time.Date(2017, 9, 2, 2, 0, 0, 0, time.UTC)
+)
+
+func (s *actionSchedulesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		schedule: state.ActionSchedule{
+			Id:         "1",
+			Receiver:   "mysql/0",
+			Name:       "backup",
+			Parameters: map[string]interface{}{"full": true},
+			Cron:       "0 2 * * *",
+			NextRun:    nextRun,
+			Owner:      "write",
+			Created:    created,
+			History: []state.ActionScheduleRun{{
+				Time:     created.Add(-time.Hour),
+				ActionId: "a1b2c3d4-0000-4000-8000-000000000000",
+			}, {
+				Time:  created,
+				Error: "skipped: action a1b2c3d4-0000-4000-8000-000000000000 is still running",
+			}},
+		},
+	}
+	s.blocks = &mockBlockChecker{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	}
+}
+
+func (s *actionSchedulesSuite) newAPI(c *gc.C) *actionschedules.API {
+	api, err := actionschedules.NewAPI(s.backend, s.authorizer, s.blocks)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *actionSchedulesSuite) expectedSchedule() params.ActionSchedule {
+	return params.ActionSchedule{
+		Id:         "1",
+		Receiver:   "unit-mysql-0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{"full": true},
+		Cron:       "0 2 * * *",
+		NextRun:    &nextRun,
+		Owner:      "write",
+		Created:    created,
+		History: []params.ActionScheduleRun{{
+			Time:   created.Add(-time.Hour),
+			Action: "action-a1b2c3d4-0000-4000-8000-000000000000",
+		}, {
+			Time:  created,
+			Error: "skipped: action a1b2c3d4-0000-4000-8000-000000000000 is still running",
+		}},
+	}
+}
+
+func (s *actionSchedulesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := actionschedules.NewAPI(s.backend, s.authorizer, s.blocks)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *actionSchedulesSuite) TestAddSchedules(c *gc.C) {
+	at := created.Add(time.Hour)
+	result, err := s.newAPI(c).AddSchedules(params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{{
+			Receiver:   "unit-mysql-0",
+			Name:       "backup",
+			Parameters: map[string]interface{}{"full": true},
+			Cron:       "0 2 * * *",
+		}, {
+			Receiver: "unit-mysql-1",
+			Name:     "backup",
+			At:       &at,
+		}, {
+			Receiver: "application-mysql",
+			Name:     "backup",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := s.expectedSchedule()
+	c.Assert(result, jc.DeepEquals, params.ActionScheduleResults{
+		Results: []params.ActionScheduleResult{
+			{Result: &expected},
+			{Result: &expected},
+			{Error: &params.Error{Message: `"application-mysql" is not a valid unit tag`}},
+		},
+	})
+	s.blocks.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckCalls(c, []testing.StubCall{{
+		FuncName: "AddActionSchedule",
+		Args: []interface{}{state.AddActionScheduleParams{
+			Receiver:   "mysql/0",
+			Name:       "backup",
+			Parameters: map[string]interface{}{"full": true},
+			Cron:       "0 2 * * *",
+			Owner:      "write",
+		}},
+	}, {
+		FuncName: "AddActionSchedule",
+		Args: []interface{}{state.AddActionScheduleParams{
+			Receiver: "mysql/1",
+			Name:     "backup",
+			At:       at,
+			Owner:    "write",
+		}},
+	}})
+}
+
+func (s *actionSchedulesSuite) TestAddSchedulesError(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("unit %q", "mysql/0"))
+	result, err := s.newAPI(c).AddSchedules(params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{{Receiver: "unit-mysql-0", Name: "backup", Cron: "* * * * *"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *actionSchedulesSuite) TestAddSchedulesRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).AddSchedules(params.AddActionSchedules{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *actionSchedulesSuite) TestAddSchedulesBlocked(c *gc.C) {
+	s.blocks.SetErrors(errors.New("blocked"))
+	_, err := s.newAPI(c).AddSchedules(params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{{Receiver: "unit-mysql-0", Name: "backup"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *actionSchedulesSuite) TestSchedules(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	result, err := s.newAPI(c).Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedulesResult{
+		Schedules: []params.ActionSchedule{s.expectedSchedule()},
+	})
+}
+
+func (s *actionSchedulesSuite) TestSchedulesOnceRun(c *gc.C) {
+	s.backend.schedule.Cron = ""
+	s.backend.schedule.NextRun = time.Time{}
+	result, err := s.newAPI(c).Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Schedules, gc.HasLen, 1)
+	c.Assert(result.Schedules[0].NextRun, gc.IsNil)
+}
+
+func (s *actionSchedulesSuite) TestSchedulesRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).Schedules()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *actionSchedulesSuite) TestRemoveSchedules(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotFoundf("action schedule %q", "2"))
+	result, err := s.newAPI(c).RemoveSchedules(params.ActionScheduleIds{Ids: []string{"1", "2"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `action schedule "2" not found`)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"RemoveActionSchedule", []interface{}{"1"}},
+		{"RemoveActionSchedule", []interface{}{"2"}},
+	})
+}
+
+func (s *actionSchedulesSuite) TestRemoveSchedulesRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).RemoveSchedules(params.ActionScheduleIds{Ids: []string{"1"}})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	schedule state.ActionSchedule
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AddActionSchedule(args state.AddActionScheduleParams) (state.ActionSchedule, error) {
+	b.MethodCall(b, "AddActionSchedule", args)
+	return b.schedule, b.NextErr()
+}
+
+func (b *mockBackend) ActionSchedules() ([]state.ActionSchedule, error) {
+	b.MethodCall(b, "ActionSchedules")
+	return []state.ActionSchedule{b.schedule}, b.NextErr()
+}
+
+func (b *mockBackend) RemoveActionSchedule(id string) error {
+	b.MethodCall(b, "RemoveActionSchedule", id)
+	return b.NextErr()
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (b *mockBlockChecker) ChangeAllowed() error {
+	b.MethodCall(b, "ChangeAllowed")
+	return b.NextErr()
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// SetApplicationsAddressPolicy sets the policy governing which of a
+// machine's addresses are published as the private and public
+// addresses of each of the given applications' units.
+func (api *API) SetApplicationsAddressPolicy(args params.ApplicationAddressPolicyArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.backend.Application(arg.ApplicationName)
+		if err == nil {
+			err = app.SetAddressPolicy(state.AddressPolicy{
+				Prefer:     state.AddressKind(arg.Policy.Prefer),
+				PreferIPv6: arg.Policy.PreferIPv6,
+				Space:      arg.Policy.Space,
+			})
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// GetApplicationsAddressPolicy returns the policy governing which of a
+// machine's addresses are published as the private and public
+// addresses of each of the given applications' units.
+func (api *API) GetApplicationsAddressPolicy(args params.Entities) (params.AddressPolicyResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.AddressPolicyResults{}, errors.Trace(err)
+	}
+	results := params.AddressPolicyResults{
+		Results: make([]params.AddressPolicyResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		policy, err := api.applicationAddressPolicy(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Policy = params.AddressPolicy{
+			Prefer:     string(policy.Prefer),
+			PreferIPv6: policy.PreferIPv6,
+			Space:      policy.Space,
+		}
+	}
+	return results, nil
+}
+
+func (api *API) applicationAddressPolicy(entity string) (state.AddressPolicy, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return state.AddressPolicy{}, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return state.AddressPolicy{}, err
+	}
+	return app.AddressPolicy(), nil
+}
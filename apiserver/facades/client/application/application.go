@@ -9,6 +9,8 @@ package application
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -20,6 +22,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/deploypolicy"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -38,13 +41,53 @@ var logger = loggo.GetLogger("juju.apiserver.application")
 
 // APIv4 provides the Application API facade for versions 1-4.
 type APIv4 struct {
+	*APIv5
+}
+
+// APIv5 provides the Application API facade for version 5.
+type APIv5 struct {
+	*APIv6
+}
+
+// APIv6 provides the Application API facade for version 6.
+type APIv6 struct {
+	*APIv7
+}
+
+// APIv7 provides the Application API facade for version 7.
+type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Application API facade for version 8.
+type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+type APIv12 struct {
 	*API
 }
 
 // API implements the application interface and is the concrete
 // implementation of the api end point.
 //
-// API provides the Application API facade for version 5.
+// API provides the Application API facade for version 13.
 type API struct {
 	backend    Backend
 	authorizer facade.Authorizer
@@ -67,7 +110,87 @@ func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &APIv4{api}, nil
+	return &APIv4{&APIv5{&APIv6{&APIv7{&APIv8{&APIv9{&APIv10{&APIv11{&APIv12{api}}}}}}}}}, nil
+}
+
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{&APIv6{&APIv7{&APIv8{&APIv9{&APIv10{&APIv11{&APIv12{api}}}}}}}}, nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration
+// for version 6.
+func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{&APIv7{&APIv8{&APIv9{&APIv10{&APIv11{&APIv12{api}}}}}}}, nil
+}
+
+// NewFacadeV7 provides the signature required for facade registration
+// for version 7.
+func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{&APIv8{&APIv9{&APIv10{&APIv11{&APIv12{api}}}}}}, nil
+}
+
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
+func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{&APIv9{&APIv10{&APIv11{&APIv12{api}}}}}, nil
+}
+
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{&APIv10{&APIv11{&APIv12{api}}}}, nil
+}
+
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{&APIv11{&APIv12{api}}}, nil
+}
+
+// NewFacadeV11 provides the signature required for facade registration
+// for version 11.
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{&APIv12{api}}, nil
+}
+
+// NewFacadeV12 provides the signature required for facade registration
+// for version 12.
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
 }
 
 // NewFacade provides the signature required for facade registration.
@@ -126,6 +249,21 @@ func (api *API) checkCanWrite() error {
 	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
 }
 
+// recordChange adds an entry, for a change applied through the facade,
+// to the model's change log. The change has already been made, so a
+// failure to record it is logged rather than returned.
+func (api *API) recordChange(kind state.ChangeKind, entity names.Tag, args map[string]string) {
+	entry := state.ChangeLogEntry{
+		Actor:  api.authorizer.GetAuthTag().Id(),
+		Kind:   kind,
+		Entity: entity.String(),
+		Params: args,
+	}
+	if err := api.backend.RecordChange(entry); err != nil {
+		logger.Warningf("cannot record %s of %s: %v", kind, names.ReadableString(entity), err)
+	}
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -166,6 +304,13 @@ func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, err
 	for i, arg := range args.Applications {
 		err := deployApplication(api.backend, api.stateCharm, arg, api.deployApplicationFunc)
 		result.Results[i].Error = common.ServerError(err)
+		if err == nil {
+			api.recordChange(state.ChangeDeploy, names.NewApplicationTag(arg.ApplicationName), map[string]string{
+				"charm":     arg.CharmURL,
+				"series":    arg.Series,
+				"num-units": strconv.Itoa(arg.NumUnits),
+			})
+		}
 
 		if err != nil && len(arg.Resources) != 0 {
 			// Remove any pending resources - these would have been
@@ -238,6 +383,18 @@ func deployApplication(
 		return errors.Trace(err)
 	}
 
+	series := args.Series
+	if series == "" && len(ch.Meta().Series) > 0 {
+		series = ch.Meta().Series[0]
+	}
+	if err := deploypolicy.Check(backend, deploypolicy.Deployment{
+		Charm:       curl,
+		Series:      series,
+		Constraints: args.Constraints,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Parse storage tags in AttachStorage.
 	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
 		return errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
@@ -351,6 +508,9 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 		); err != nil {
 			return errors.Trace(err)
 		}
+		api.recordChange(state.ChangeUpgradeCharm, names.NewApplicationTag(args.ApplicationName), map[string]string{
+			"charm": args.CharmURL,
+		})
 	}
 	// Set the minimum number of units for the given application.
 	if args.MinUnits != nil {
@@ -363,14 +523,23 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 		if err = applicationSetSettingsYAML(args.ApplicationName, app, args.SettingsYAML); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
+		api.recordChange(state.ChangeConfig, names.NewApplicationTag(args.ApplicationName), map[string]string{
+			"yaml": args.SettingsYAML,
+		})
 	} else if len(args.SettingsStrings) > 0 {
 		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings); err != nil {
 			return errors.Trace(err)
 		}
+		api.recordChange(state.ChangeConfig, names.NewApplicationTag(args.ApplicationName), args.SettingsStrings)
 	}
 	// Update application's constraints.
 	if args.Constraints != nil {
-		return app.SetConstraints(*args.Constraints)
+		if err := app.SetConstraints(*args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+		api.recordChange(state.ChangeConstraints, names.NewApplicationTag(args.ApplicationName), map[string]string{
+			"constraints": args.Constraints.String(),
+		})
 	}
 	return nil
 }
@@ -437,7 +606,7 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		return errors.Trace(err)
 	}
 	channel := csparams.Channel(args.Channel)
-	return api.applicationSetCharm(
+	if err := api.applicationSetCharm(
 		args.ApplicationName,
 		application,
 		args.CharmURL,
@@ -448,7 +617,15 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
-	)
+	); err != nil {
+		return errors.Trace(err)
+	}
+	changeArgs := map[string]string{"charm": args.CharmURL}
+	if args.Channel != "" {
+		changeArgs["channel"] = args.Channel
+	}
+	api.recordChange(state.ChangeUpgradeCharm, names.NewApplicationTag(args.ApplicationName), changeArgs)
+	return nil
 }
 
 // GetConfig returns the application config for each of the applications
@@ -635,9 +812,11 @@ func (api *API) Set(p params.ApplicationSet) error {
 	if err != nil {
 		return err
 	}
-
-	return app.UpdateConfigSettings(changes)
-
+	if err := app.UpdateConfigSettings(changes); err != nil {
+		return err
+	}
+	api.recordChange(state.ChangeConfig, names.NewApplicationTag(p.ApplicationName), p.Options)
+	return nil
 }
 
 // Unset implements the server side of Client.Unset.
@@ -653,10 +832,16 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 		return err
 	}
 	settings := make(charm.Settings)
+	unset := make(map[string]string)
 	for _, option := range p.Options {
 		settings[option] = nil
+		unset[option] = ""
 	}
-	return app.UpdateConfigSettings(settings)
+	if err := app.UpdateConfigSettings(settings); err != nil {
+		return err
+	}
+	api.recordChange(state.ChangeConfig, names.NewApplicationTag(p.ApplicationName), unset)
+	return nil
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -682,7 +867,9 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If a floating IP is
+// given, it is set to follow the application's leader. If CIDRs are
+// given, the ports are opened only to those source CIDRs.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -694,7 +881,21 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	if args.FloatingIP != "" {
+		if err := app.SetFloatingIP(args.FloatingIP); err != nil {
+			return err
+		}
+	}
+	if len(args.ExposedCIDRs) > 0 {
+		err = app.SetExposedToCIDRs(args.ExposedCIDRs)
+	} else {
+		err = app.SetExposed()
+	}
+	if err != nil {
+		return err
+	}
+	api.recordChange(state.ChangeExpose, names.NewApplicationTag(args.ApplicationName), nil)
+	return nil
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -710,7 +911,11 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
-	return app.ClearExposed()
+	if err := app.ClearExposed(); err != nil {
+		return err
+	}
+	api.recordChange(state.ChangeUnexpose, names.NewApplicationTag(args.ApplicationName), nil)
+	return nil
 }
 
 // AddUnits adds a given number of units to an application.
@@ -729,9 +934,48 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	for i, unit := range units {
 		unitNames[i] = unit.UnitTag().Id()
 	}
+	api.recordChange(state.ChangeAddUnits, names.NewApplicationTag(args.ApplicationName), map[string]string{
+		"units": strings.Join(unitNames, ","),
+	})
 	return params.AddApplicationUnitsResults{Units: unitNames}, nil
 }
 
+// PreviewAddUnits reports which existing machines and containers would
+// be reused, and how many new ones would be created, were the given
+// number of units added to an application. No changes are made.
+func (api *API) PreviewAddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsPreviewResult, error) {
+	var result params.AddApplicationUnitsPreviewResult
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.NumUnits < 1 {
+		return result, errors.New("must add at least one unit")
+	}
+	application, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	placements, err := application.PreviewUnitPlacement(args.NumUnits, args.Placement)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Units = make([]params.UnitPlacementPreview, len(placements))
+	for i, p := range placements {
+		result.Units[i] = params.UnitPlacementPreview{
+			MachineId:     p.MachineId,
+			ParentId:      p.ParentId,
+			ContainerType: string(p.ContainerType),
+			Directive:     p.Directive,
+		}
+		if p.NewMachine() {
+			result.NewMachines++
+		} else {
+			result.ExistingMachines = append(result.ExistingMachines, p.MachineId)
+		}
+	}
+	return result, nil
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]Unit, error) {
 	if args.NumUnits < 1 {
@@ -991,9 +1235,15 @@ func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (param
 		}
 		op := app.DestroyOperation()
 		op.DestroyStorage = arg.DestroyStorage
+		if arg.HookTimeout != nil {
+			op.HookTimeout = *arg.HookTimeout
+		}
 		if err := api.backend.ApplyOperation(op); err != nil {
 			return nil, err
 		}
+		api.recordChange(state.ChangeRemoveApplication, tag, map[string]string{
+			"destroy-storage": strconv.FormatBool(arg.DestroyStorage),
+		})
 		return &info, nil
 	}
 	results := make([]params.DestroyApplicationResult, len(args.Applications))
@@ -1008,6 +1258,124 @@ func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (param
 	return params.DestroyApplicationResults{results}, nil
 }
 
+// ApplicationRemovalReports returns the reports of the cleanup steps
+// skipped when the units of the given applications were forcibly
+// removed, because their end-of-life hooks did not complete within
+// the timeout given when the applications were destroyed.
+func (api *API) ApplicationRemovalReports(args params.Entities) (params.ApplicationRemovalReportResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationRemovalReportResults{}, errors.Trace(err)
+	}
+	results := make([]params.ApplicationRemovalReportResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		report, err := api.applicationRemovalReport(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = report
+	}
+	return params.ApplicationRemovalReportResults{results}, nil
+}
+
+func (api *API) applicationRemovalReport(tagString string) (*params.ApplicationRemovalReport, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	report, err := api.backend.ApplicationRemovalReport(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ApplicationRemovalReport{
+		Application: report.Application,
+		HookTimeout: report.HookTimeout,
+		Forced:      report.Forced,
+		Skipped:     report.Skipped,
+	}, nil
+}
+
+// ResolveUnitErrors marks units in an error state as resolved, so
+// that they may continue, re-executing their failed hooks if Retry is
+// set. If All is set, every unit in an error state is marked resolved,
+// restricted to the units of Application if that is set; otherwise the
+// units identified by Tags are marked resolved.
+func (api *API) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if p.All {
+		if len(p.Tags.Entities) > 0 {
+			return params.ErrorResults{}, errors.BadRequestf("cannot specify units with all")
+		}
+		return params.ErrorResults{}, errors.Trace(api.resolveAllUnitErrors(p.Application, !p.Retry))
+	}
+	if p.Application != "" {
+		return params.ErrorResults{}, errors.BadRequestf("cannot specify application without all")
+	}
+	results := make([]params.ErrorResult, len(p.Tags.Entities))
+	for i, entity := range p.Tags.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Error = common.ServerError(unit.Resolve(!p.Retry))
+	}
+	return params.ErrorResults{results}, nil
+}
+
+// resolveAllUnitErrors marks every unit in an error state as resolved,
+// restricted to the units of the named application if it is not empty.
+// Every such unit is attempted, and the failures reported together.
+func (api *API) resolveAllUnitErrors(appName string, noretryHooks bool) error {
+	var apps []Application
+	if appName != "" {
+		app, err := api.backend.Application(appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		apps = []Application{app}
+	} else {
+		var err error
+		apps, err = api.backend.AllApplications()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	var failed []string
+	for _, app := range apps {
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, unit := range units {
+			statusInfo, err := unit.Status()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if statusInfo.Status != status.Error {
+				continue
+			}
+			if err := unit.Resolve(noretryHooks); err != nil {
+				failed = append(failed, err.Error())
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("cannot resolve all units: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
 // GetConstraints returns the constraints for a given application.
 func (api *API) GetConstraints(args params.Entities) (params.ApplicationGetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -1041,6 +1409,59 @@ func (api *API) getConstraints(entity string) (constraints.Value, error) {
 	}
 }
 
+// GetEffectiveConstraints reports, for each given application, how its
+// constraints combine with the model constraints to produce the
+// constraints used when provisioning machines for its units.
+func (api *API) GetEffectiveConstraints(args params.Entities) (params.ApplicationEffectiveConstraintsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationEffectiveConstraintsResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationEffectiveConstraintsResults{
+		Results: make([]params.ApplicationEffectiveConstraints, len(args.Entities)),
+	}
+	modelCons, err := api.backend.ModelConstraints()
+	if err != nil {
+		return params.ApplicationEffectiveConstraintsResults{}, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		result, err := api.getEffectiveConstraints(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Model = modelCons
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) getEffectiveConstraints(entity string) (params.ApplicationEffectiveConstraints, error) {
+	var result params.ApplicationEffectiveConstraints
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return result, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return result, err
+	}
+	appCons, err := app.Constraints()
+	if err != nil {
+		return result, err
+	}
+	res, err := app.EffectiveConstraints()
+	if err != nil {
+		return result, err
+	}
+	return params.ApplicationEffectiveConstraints{
+		Application: appCons,
+		Effective:   res.Effective,
+		Inherited:   res.Inherited,
+		Overridden:  res.Overridden,
+		Unsupported: res.Unsupported,
+	}, nil
+}
+
 // SetConstraints sets the constraints for a given application.
 func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
@@ -1053,7 +1474,13 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err != nil {
 		return err
 	}
-	return app.SetConstraints(args.Constraints)
+	if err := app.SetConstraints(args.Constraints); err != nil {
+		return err
+	}
+	api.recordChange(state.ChangeConstraints, names.NewApplicationTag(args.ApplicationName), map[string]string{
+		"constraints": args.Constraints.String(),
+	})
+	return nil
 }
 
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
@@ -1110,6 +1537,9 @@ func (api *API) AddRelation(args params.AddRelation) (_ params.AddRelationResult
 			Scope:     string(outEp.Relation.Scope),
 		}
 	}
+	api.recordChange(state.ChangeAddRelation, rel.Tag(), map[string]string{
+		"endpoints": strings.Join(args.Endpoints, " "),
+	})
 	return params.AddRelationResults{Endpoints: outEps}, nil
 }
 
@@ -1122,23 +1552,88 @@ func (api *API) DestroyRelation(args params.DestroyRelation) (err error) {
 	if err := api.check.RemoveAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	var (
-		rel Relation
-		eps []state.Endpoint
-	)
+	rel, err := api.destroyRelationTarget(args)
+	if err != nil {
+		return err
+	}
+	if err := rel.Destroy(); err != nil {
+		return err
+	}
+	api.recordChange(state.ChangeRemoveRelation, rel.Tag(), nil)
+	return nil
+}
+
+// destroyRelationTarget returns the relation identified by the
+// arguments to DestroyRelation.
+func (api *API) destroyRelationTarget(args params.DestroyRelation) (Relation, error) {
 	if len(args.Endpoints) > 0 {
-		eps, err = api.backend.InferEndpoints(args.Endpoints...)
+		eps, err := api.backend.InferEndpoints(args.Endpoints...)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		rel, err = api.backend.EndpointsRelation(eps...)
-	} else {
-		rel, err = api.backend.Relation(args.RelationId)
+		return api.backend.EndpointsRelation(eps...)
+	}
+	return api.backend.Relation(args.RelationId)
+}
+
+// PreviewAddRelation reports whether a relation between the specified
+// endpoints could be added, and which units would enter its scope.
+// No changes are made.
+func (api *API) PreviewAddRelation(args params.AddRelation) (params.RelationPreviewResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationPreviewResult{}, errors.Trace(err)
 	}
+	eps, err := api.backend.InferEndpoints(args.Endpoints...)
 	if err != nil {
-		return err
+		// Endpoints that cannot be matched are as much a problem
+		// with the relation as endpoints that do not relate.
+		return params.RelationPreviewResult{
+			Problems: []string{err.Error()},
+		}, nil
+	}
+	preview, err := api.backend.PreviewAddRelation(eps...)
+	if err != nil {
+		return params.RelationPreviewResult{}, errors.Trace(err)
 	}
-	return rel.Destroy()
+	return relationPreviewResult(preview), nil
+}
+
+// PreviewDestroyRelation reports whether the relation between the
+// specified endpoints, or with the specified id, could be removed,
+// and which units would leave its scope. No changes are made.
+func (api *API) PreviewDestroyRelation(args params.DestroyRelation) (params.RelationPreviewResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationPreviewResult{}, errors.Trace(err)
+	}
+	rel, err := api.destroyRelationTarget(args)
+	if err != nil {
+		return params.RelationPreviewResult{}, errors.Trace(err)
+	}
+	preview, err := rel.PreviewDestroy()
+	if err != nil {
+		return params.RelationPreviewResult{}, errors.Trace(err)
+	}
+	return relationPreviewResult(preview), nil
+}
+
+func relationPreviewResult(preview *state.RelationPreview) params.RelationPreviewResult {
+	result := params.RelationPreviewResult{
+		Key:       preview.Key,
+		Endpoints: make(map[string]params.CharmRelation),
+		Units:     preview.Units,
+		Problems:  preview.Problems,
+	}
+	for _, ep := range preview.Endpoints {
+		result.Endpoints[ep.ApplicationName] = params.CharmRelation{
+			Name:      ep.Relation.Name,
+			Role:      string(ep.Relation.Role),
+			Interface: ep.Relation.Interface,
+			Optional:  ep.Relation.Optional,
+			Limit:     ep.Relation.Limit,
+			Scope:     string(ep.Relation.Scope),
+		}
+	}
+	return result
 }
 
 // SetRelationsSuspended sets the suspended status of the specified relations.
@@ -1372,6 +1867,51 @@ func (api *API) maybeUpdateExistingApplicationEndpoints(
 // rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
 // removes the method as far as the RPC machinery is concerned.
 
+// SetApplicationsLogging isn't on the V12 API.
+func (u *APIv12) SetApplicationsLogging(_, _ struct{}) {}
+
+// GetApplicationsLogging isn't on the V12 API.
+func (u *APIv12) GetApplicationsLogging(_, _ struct{}) {}
+
+// SetApplicationsAddressPolicy isn't on the V9 API.
+func (u *APIv9) SetApplicationsAddressPolicy(_, _ struct{}) {}
+
+// GetApplicationsAddressPolicy isn't on the V9 API.
+func (u *APIv9) GetApplicationsAddressPolicy(_, _ struct{}) {}
+
+// PreviewAddRelation isn't on the V8 API.
+func (u *APIv8) PreviewAddRelation(_, _ struct{}) {}
+
+// PreviewDestroyRelation isn't on the V8 API.
+func (u *APIv8) PreviewDestroyRelation(_, _ struct{}) {}
+
+// ResolveUnitErrors isn't on the V7 API.
+func (u *APIv7) ResolveUnitErrors(_, _ struct{}) {}
+
+// ApplicationRemovalReports isn't on the V6 API.
+func (u *APIv6) ApplicationRemovalReports(_, _ struct{}) {}
+
+// PreviewAddUnits isn't on the V5 API.
+func (u *APIv5) PreviewAddUnits(_, _ struct{}) {}
+
+// GetEffectiveConstraints isn't on the V5 API.
+func (u *APIv5) GetEffectiveConstraints(_, _ struct{}) {}
+
+// SetApplicationsTrust isn't on the V5 API.
+func (u *APIv5) SetApplicationsTrust(_, _ struct{}) {}
+
+// GetApplicationsTrust isn't on the V5 API.
+func (u *APIv5) GetApplicationsTrust(_, _ struct{}) {}
+
+// RelationSettingsUsage isn't on the V5 API.
+func (u *APIv5) RelationSettingsUsage(_, _ struct{}) {}
+
+// SetApplicationsHookEnvironment isn't on the V5 API.
+func (u *APIv5) SetApplicationsHookEnvironment(_, _ struct{}) {}
+
+// GetApplicationsHookEnvironment isn't on the V5 API.
+func (u *APIv5) GetApplicationsHookEnvironment(_, _ struct{}) {}
+
 // UpdateApplicationSeries isn't on the V4 API.
 func (u *APIv4) UpdateApplicationSeries(_, _ struct{}) {}
 
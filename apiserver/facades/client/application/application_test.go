@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -620,6 +621,78 @@ func (s *applicationSuite) TestAddCharmWithAuthorization(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 }
 
+func (s *applicationSuite) TestAddCharmMirrorsCharm(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/wordpress-3", "wordpress")
+	err := s.APIState.Client().AddCharm(curl, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	mc, err := s.State.MirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mc.SHA256, gc.Equals, sch.BundleSha256())
+	storage := statestorage.NewStorage(s.State.ControllerModelUUID(), s.State.MongoSession())
+	s.assertUploaded(c, storage, mc.StoragePath, sch.BundleSha256())
+}
+
+func (s *applicationSuite) TestAddCharmFromMirror(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/wordpress-3", "wordpress")
+	err := s.APIState.Client().AddCharm(curl, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	mc, err := s.State.MirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var blobs blobs
+	s.PatchValue(application.NewStateStorage, func(uuid string, session *mgo.Session) statestorage.Storage {
+		storage := statestorage.NewStorage(uuid, session)
+		return &recordingStorage{Storage: storage, blobs: &blobs}
+	})
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	err = application.AddCharmWithAuthorization(otherState, params.AddCharmWithAuthorization{
+		URL:     curl.String(),
+		Channel: string(csparams.StableChannel),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The charm is only added to the new model's storage, and the
+	// mirror is left alone.
+	sch, err := otherState.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.BundleSha256(), gc.Equals, mc.SHA256)
+	c.Assert(blobs.m, jc.DeepEquals, map[string]bool{sch.StoragePath(): true})
+	mc2, err := s.State.MirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mc2, jc.DeepEquals, mc)
+}
+
+func (s *applicationSuite) TestAddCharmReplacesStaleMirroredCharm(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/wordpress-3", "wordpress")
+	storage := statestorage.NewStorage(s.State.ControllerModelUUID(), s.State.MongoSession())
+	err := storage.Put("charm-mirror/stale", strings.NewReader("stale"), 5)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddMirroredCharm(state.MirroredCharm{
+		URL:         curl,
+		StoragePath: "charm-mirror/stale",
+		SHA256:      "bogus",
+		SHA384:      "bogus",
+		Size:        5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.APIState.Client().AddCharm(curl, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUploaded(c, statestorage.NewStorage(s.State.ModelUUID(), s.State.MongoSession()), sch.StoragePath(), sch.BundleSha256())
+	mc, err := s.State.MirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mc.SHA256, gc.Equals, sch.BundleSha256())
+	_, _, err = storage.Get("charm-mirror/stale")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *applicationSuite) TestAddCharmConcurrently(c *gc.C) {
 	c.Skip("see lp:1596960 -- bad test for bad code")
 
@@ -1590,6 +1663,22 @@ func (s *applicationSuite) TestApplicationSet(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestApplicationSetRecordsChange(c *gc.C) {
+	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.Set(params.ApplicationSet{ApplicationName: "dummy", Options: map[string]string{
+		"title": "foobar",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	entries, err := s.State.ChangeLog(state.ChangeLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Actor, gc.Equals, s.AdminUserTag(c).Id())
+	c.Assert(entries[0].Kind, gc.Equals, state.ChangeConfig)
+	c.Assert(entries[0].Entity, gc.Equals, "application-dummy")
+	c.Assert(entries[0].Params, jc.DeepEquals, map[string]string{"title": "foobar"})
+}
+
 func (s *applicationSuite) assertApplicationSetBlocked(c *gc.C, dummy *state.Application, msg string) {
 	err := s.applicationAPI.Set(params.ApplicationSet{
 		ApplicationName: "dummy",
@@ -1939,7 +2028,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1951,6 +2040,58 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeFloatingIP(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		FloatingIP:      "203.0.113.10",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	address, unitName := app.FloatingIP()
+	c.Assert(address, gc.Equals, "203.0.113.10")
+	c.Assert(unitName, gc.Equals, "")
+}
+
+func (s *applicationSuite) TestApplicationExposeFloatingIPInvalid(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		FloatingIP:      "bad",
+	})
+	c.Assert(err, gc.ErrorMatches, `floating IP address "bad" not valid`)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsFalse)
+}
+
+func (s *applicationSuite) TestApplicationExposeToCIDRs(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposedCIDRs:    []string{"10.0.0.0/24", "192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+}
+
+func (s *applicationSuite) TestApplicationExposeToCIDRsInvalid(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposedCIDRs:    []string{"10.0.0.0/33"},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0/33" not valid`)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsFalse)
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -1991,7 +2132,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2006,7 +2147,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -16,8 +18,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -212,6 +217,12 @@ func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 		Charm:          &state.Charm{},
 		ConfigSettings: charm.Settings{"stringOption": "value"},
 	})
+	c.Assert(s.backend.changes, jc.DeepEquals, []state.ChangeLogEntry{{
+		Actor:  "admin",
+		Kind:   state.ChangeUpgradeCharm,
+		Entity: "application-postgresql",
+		Params: map[string]string{"charm": "cs:postgresql"},
+	}})
 }
 
 func (s *ApplicationSuite) TestSetCharmConfigSettingsYAML(c *gc.C) {
@@ -271,12 +282,18 @@ func (s *ApplicationSuite) TestDestroyRelationId(c *gc.C) {
 	s.backend.CheckCallNames(c, "ModelTag", "Relation")
 	s.backend.CheckCall(c, 1, "Relation", 123)
 	s.relation.CheckCallNames(c, "Destroy")
+	c.Assert(s.backend.changes, jc.DeepEquals, []state.ChangeLogEntry{{
+		Actor:  "admin",
+		Kind:   state.ChangeRemoveRelation,
+		Entity: "relation-wordpress.db#mysql.db",
+	}})
 }
 
 func (s *ApplicationSuite) TestDestroyRelationIdRelationNotFound(c *gc.C) {
 	s.backend.SetErrors(nil, errors.NotFoundf(`relation "123"`))
 	err := s.api.DestroyRelation(params.DestroyRelation{RelationId: 123})
 	c.Assert(err, gc.ErrorMatches, `relation "123" not found`)
+	c.Assert(s.backend.changes, gc.HasLen, 0)
 }
 
 func (s *ApplicationSuite) TestDestroyApplication(c *gc.C) {
@@ -315,6 +332,12 @@ func (s *ApplicationSuite) TestDestroyApplication(c *gc.C) {
 		"ApplyOperation",
 	)
 	s.backend.CheckCall(c, 9, "ApplyOperation", &state.DestroyApplicationOperation{})
+	c.Assert(s.backend.changes, jc.DeepEquals, []state.ChangeLogEntry{{
+		Actor:  "admin",
+		Kind:   state.ChangeRemoveApplication,
+		Entity: "application-postgresql",
+		Params: map[string]string{"destroy-storage": "false"},
+	}})
 }
 
 func (s *ApplicationSuite) TestDestroyApplicationDestroyStorage(c *gc.C) {
@@ -354,6 +377,159 @@ func (s *ApplicationSuite) TestDestroyApplicationDestroyStorage(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyApplicationHookTimeout(c *gc.C) {
+	timeout := time.Minute
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			DestroyStorage: true,
+			HookTimeout:    &timeout,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCall(c, 7, "ApplyOperation", &state.DestroyApplicationOperation{
+		DestroyStorage: true,
+		HookTimeout:    time.Minute,
+	})
+}
+
+func (s *ApplicationSuite) TestApplicationRemovalReports(c *gc.C) {
+	forced := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.removalReports = map[string]*state.ApplicationRemovalReport{
+		"postgresql": {
+			Application: "postgresql",
+			HookTimeout: time.Minute,
+			Forced:      forced,
+			Skipped:     []string{"unit postgresql/0: stop hook did not complete"},
+		},
+	}
+	results, err := s.api.ApplicationRemovalReports(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-mysql"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ApplicationRemovalReportResults{
+		Results: []params.ApplicationRemovalReportResult{{
+			Result: &params.ApplicationRemovalReport{
+				Application: "postgresql",
+				HookTimeout: time.Minute,
+				Forced:      forced,
+				Skipped:     []string{"unit postgresql/0: stop hook did not complete"},
+			},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `removal report for application "mysql" not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-mysql-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
+func (s *ApplicationSuite) setUnitStatus(name string, st status.Status) *mockUnit {
+	for _, app := range s.backend.applications {
+		units := app.(*mockApplication).units
+		for i := range units {
+			if units[i].tag.Id() == name {
+				units[i].status = st
+				return &units[i]
+			}
+		}
+	}
+	panic("unit " + name + " not found")
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrors(c *gc.C) {
+	unit := s.setUnitStatus("postgresql/0", status.Error)
+	results, err := s.api.ResolveUnitErrors(params.UnitsResolved{
+		Tags: params.Entities{Entities: []params.Entity{
+			{Tag: "unit-postgresql-0"},
+			{Tag: "unit-postgresql-1"},
+			{Tag: "unit-mysql-0"},
+			{Tag: "application-postgresql"},
+		}},
+		Retry: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `unit "postgresql/1" is not in an error state`}},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: `unit "mysql/0" not found`}},
+			{Error: &params.Error{Message: `"application-postgresql" is not a valid unit tag`}},
+		},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	unit.CheckCall(c, 0, "Resolve", false)
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsAll(c *gc.C) {
+	unit0 := s.setUnitStatus("postgresql/1", status.Error)
+	unit1 := s.setUnitStatus("postgresql-subordinate/0", status.Error)
+	results, err := s.api.ResolveUnitErrors(params.UnitsResolved{All: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{})
+	s.backend.CheckCallNames(c, "AllApplications")
+	unit0.CheckCallNames(c, "Status", "Resolve")
+	unit0.CheckCall(c, 1, "Resolve", true)
+	unit1.CheckCallNames(c, "Status", "Resolve")
+	s.backend.applications["postgresql"].(*mockApplication).units[0].CheckCallNames(c, "Status")
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsAllApplication(c *gc.C) {
+	unit0 := s.setUnitStatus("postgresql/1", status.Error)
+	unit1 := s.setUnitStatus("postgresql-subordinate/0", status.Error)
+	_, err := s.api.ResolveUnitErrors(params.UnitsResolved{
+		All:         true,
+		Application: "postgresql",
+		Retry:       true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "Application", "postgresql")
+	unit0.CheckCall(c, 1, "Resolve", false)
+	unit1.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsAllFailures(c *gc.C) {
+	unit0 := s.setUnitStatus("postgresql/0", status.Error)
+	unit0.SetErrors(nil, errors.New("boom"))
+	unit1 := s.setUnitStatus("postgresql/1", status.Error)
+	_, err := s.api.ResolveUnitErrors(params.UnitsResolved{All: true})
+	c.Assert(err, gc.ErrorMatches, "cannot resolve all units: boom")
+	unit1.CheckCallNames(c, "Status", "Resolve")
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsInvalidArgs(c *gc.C) {
+	_, err := s.api.ResolveUnitErrors(params.UnitsResolved{
+		All:  true,
+		Tags: params.Entities{Entities: []params.Entity{{Tag: "unit-postgresql-0"}}},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot specify units with all")
+	_, err = s.api.ResolveUnitErrors(params.UnitsResolved{Application: "postgresql"})
+	c.Assert(err, gc.ErrorMatches, "cannot specify application without all")
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.ResolveUnitErrors(params.UnitsResolved{All: true})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.api.ResolveUnitErrors(params.UnitsResolved{All: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *ApplicationSuite) TestDestroyApplicationNotFound(c *gc.C) {
 	delete(s.backend.applications, "postgresql")
 	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
@@ -443,6 +619,36 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeployPolicy(c *gc.C) {
+	s.backend.controllerConfig = controller.Config{
+		controller.DeployAllowedCharmSources: "cs:~my-team/",
+		controller.DeployDeniedSeries:        "precise",
+	}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+		}, {
+			ApplicationName: "bar",
+			CharmURL:        "cs:~my-team/bar-1",
+			Series:          "precise",
+			NumUnits:        1,
+		}, {
+			ApplicationName: "baz",
+			CharmURL:        "cs:~my-team/baz-2",
+			Series:          "xenial",
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `deployment policy: charm "local:foo-0" is not from an allowed source \(allowed: cs:~my-team/\)`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `deployment policy: series "precise" is not allowed`)
+	c.Assert(results.Results[2].Error, gc.IsNil)
+}
+
 func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
 	results, err := s.api.AddUnits(params.AddApplicationUnits{
 		ApplicationName: "postgresql",
@@ -460,6 +666,363 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestPreviewAddUnits(c *gc.C) {
+	placement := []*instance.Placement{{Scope: instance.MachineScope, Directive: "3"}}
+	result, err := s.api.PreviewAddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        3,
+		Placement:       placement,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AddApplicationUnitsPreviewResult{
+		Units:            []params.UnitPlacementPreview{{MachineId: "3"}, {}, {}},
+		ExistingMachines: []string{"3"},
+		NewMachines:      2,
+	})
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCalls(c, []testing.StubCall{{"PreviewUnitPlacement", []interface{}{3, placement}}})
+}
+
+func (s *ApplicationSuite) TestPreviewAddRelation(c *gc.C) {
+	result, err := s.api.PreviewAddRelation(params.AddRelation{Endpoints: []string{"postgresql", "bar"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationPreviewResult{
+		Key: "postgresql bar",
+		Endpoints: map[string]params.CharmRelation{
+			"postgresql": {},
+			"bar":        {},
+		},
+		Units: []string{"postgresql/0", "postgresql/1"},
+	})
+	s.blockChecker.CheckNoCalls(c)
+	s.backend.CheckCallNames(c, "ModelTag", "InferEndpoints", "PreviewAddRelation")
+	s.backend.CheckCall(c, 2, "PreviewAddRelation", s.endpoints)
+	c.Assert(s.backend.changes, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestPreviewAddRelationNoEndpoints(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("no relations found"))
+	result, err := s.api.PreviewAddRelation(params.AddRelation{Endpoints: []string{"postgresql", "bar"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationPreviewResult{
+		Problems: []string{"no relations found"},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "InferEndpoints")
+}
+
+func (s *ApplicationSuite) TestPreviewAddRelationPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("nobody"))
+	_, err := s.api.PreviewAddRelation(params.AddRelation{Endpoints: []string{"postgresql", "bar"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestPreviewDestroyRelation(c *gc.C) {
+	result, err := s.api.PreviewDestroyRelation(params.DestroyRelation{RelationId: 123})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationPreviewResult{
+		Key:       "wordpress:db mysql:db",
+		Endpoints: map[string]params.CharmRelation{},
+		Problems:  []string{"relation is already dying"},
+	})
+	s.blockChecker.CheckNoCalls(c)
+	s.backend.CheckCallNames(c, "ModelTag", "Relation")
+	s.relation.CheckCallNames(c, "PreviewDestroy")
+}
+
+func (s *ApplicationSuite) TestPreviewDestroyRelationNotFound(c *gc.C) {
+	_, err := s.api.PreviewDestroyRelation(params.DestroyRelation{RelationId: 456})
+	c.Assert(err, gc.ErrorMatches, "relation not found")
+}
+
+func (s *ApplicationSuite) TestPreviewAddUnitsInvalidCount(c *gc.C) {
+	_, err := s.api.PreviewAddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, "must add at least one unit")
+}
+
+func (s *ApplicationSuite) TestGetEffectiveConstraints(c *gc.C) {
+	results, err := s.api.GetEffectiveConstraints(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-foo"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ApplicationEffectiveConstraints{
+		Model:       constraints.MustParse("mem=4G cores=2"),
+		Application: constraints.MustParse("cores=4"),
+		Effective:   constraints.MustParse("mem=4G cores=4"),
+		Inherited:   []string{"mem"},
+		Overridden:  []string{"cores"},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "Constraints", "EffectiveConstraints")
+}
+
+func (s *ApplicationSuite) TestSetApplicationsTrust(c *gc.C) {
+	results, err := s.api.SetApplicationsTrust(params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrustArg{
+			{ApplicationName: "postgresql", Trusted: true},
+			{ApplicationName: "postgresql", Trusted: false},
+			{ApplicationName: "foo", Trusted: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "foo" not found`)
+
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCalls(c, []testing.StubCall{
+		{"SetTrust", []interface{}{names.NewUserTag("admin")}},
+		{"RemoveTrust", nil},
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationsTrustRequiresAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("write"))
+	_, err := s.api.SetApplicationsTrust(params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrustArg{{ApplicationName: "postgresql", Trusted: true}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationsTrustBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetApplicationsTrust(params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrustArg{{ApplicationName: "postgresql", Trusted: true}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestGetApplicationsTrust(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.SetErrors(nil, errors.NotFoundf("trust"))
+	results, err := s.api.GetApplicationsTrust(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-postgresql"},
+			{Tag: "application-foo"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	granted := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	lastUsed := time.Date(2017, 10, 2, 12, 0, 0, 0, time.UTC)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ApplicationTrustResult{
+		Trusted:    true,
+		GrantedBy:  "admin",
+		Granted:    &granted,
+		LastUsed:   &lastUsed,
+		LastUsedBy: "postgresql/0",
+		UseCount:   3,
+	})
+	c.Assert(results.Results[1], jc.DeepEquals, params.ApplicationTrustResult{})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestGetApplicationsTrustRequiresAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.api.GetApplicationsTrust(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestSetApplicationsHookEnvironment(c *gc.C) {
+	results, err := s.api.SetApplicationsHookEnvironment(params.ApplicationHookEnvironmentArgs{
+		Args: []params.ApplicationHookEnvironmentArg{
+			{ApplicationName: "postgresql", Environment: map[string]string{"SITE_ID": "lon1"}},
+			{ApplicationName: "foo", Environment: map[string]string{"SITE_ID": "lon1"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCalls(c, []testing.StubCall{
+		{"SetHookEnvironment", []interface{}{map[string]string{"SITE_ID": "lon1"}}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationsHookEnvironmentRequiresWrite(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.api.SetApplicationsHookEnvironment(params.ApplicationHookEnvironmentArgs{
+		Args: []params.ApplicationHookEnvironmentArg{{ApplicationName: "postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationsHookEnvironmentBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetApplicationsHookEnvironment(params.ApplicationHookEnvironmentArgs{
+		Args: []params.ApplicationHookEnvironmentArg{{ApplicationName: "postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+}
+
+func (s *ApplicationSuite) TestGetApplicationsHookEnvironment(c *gc.C) {
+	results, err := s.api.GetApplicationsHookEnvironment(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-foo"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.HookEnvironmentResult{
+		Environment: map[string]string{"SITE_ID": "lon1"},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestSetApplicationsAddressPolicy(c *gc.C) {
+	results, err := s.api.SetApplicationsAddressPolicy(params.ApplicationAddressPolicyArgs{
+		Args: []params.ApplicationAddressPolicyArg{
+			{ApplicationName: "postgresql", Policy: params.AddressPolicy{Prefer: "fan", Space: "public"}},
+			{ApplicationName: "foo", Policy: params.AddressPolicy{PreferIPv6: true}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCalls(c, []testing.StubCall{
+		{"SetAddressPolicy", []interface{}{state.AddressPolicy{Prefer: state.AddressKindFan, Space: "public"}}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationsAddressPolicyRequiresWrite(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.api.SetApplicationsAddressPolicy(params.ApplicationAddressPolicyArgs{
+		Args: []params.ApplicationAddressPolicyArg{{ApplicationName: "postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestGetApplicationsAddressPolicy(c *gc.C) {
+	results, err := s.api.GetApplicationsAddressPolicy(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-foo"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.AddressPolicyResult{
+		Policy: params.AddressPolicy{Prefer: "fan", PreferIPv6: true},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestSetApplicationsLogging(c *gc.C) {
+	results, err := s.api.SetApplicationsLogging(params.ApplicationLoggingArgs{
+		Args: []params.ApplicationLoggingArg{
+			{ApplicationName: "postgresql", Logging: params.ApplicationLogging{Config: "unit=DEBUG", DedicatedFile: true}},
+			{ApplicationName: "foo", Logging: params.ApplicationLogging{DedicatedFile: true}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCalls(c, []testing.StubCall{
+		{"SetLogging", []interface{}{state.ApplicationLogging{Config: "unit=DEBUG", DedicatedFile: true}}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationsLoggingRequiresWrite(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.api.SetApplicationsLogging(params.ApplicationLoggingArgs{
+		Args: []params.ApplicationLoggingArg{{ApplicationName: "postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestGetApplicationsLogging(c *gc.C) {
+	results, err := s.api.GetApplicationsLogging(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-foo"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ApplicationLoggingResult{
+		Logging: params.ApplicationLogging{Config: "unit=DEBUG", DedicatedFile: true},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestRelationSettingsUsage(c *gc.C) {
+	results, err := s.api.RelationSettingsUsage(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-foo"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.RelationSettingsUsageResult{
+		MaxKeys: 10,
+		MaxSize: 1024,
+		Usage: []params.RelationSettingsUsage{{
+			RelationKey: "postgresql:db wordpress:db",
+			RelationId:  1,
+			Unit:        "postgresql/0",
+			Keys:        2,
+			Size:        42,
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestRelationSettingsUsageModelConfigError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom")) // ModelTag, ModelConfig
+	_, err := s.api.RelationSettingsUsage(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ApplicationSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
 	_, err := s.api.AddUnits(params.AddApplicationUnits{
 		ApplicationName: "foo",
@@ -10,7 +10,9 @@ import (
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -23,25 +25,32 @@ import (
 type Backend interface {
 	storagecommon.StorageInterface
 
+	AllApplications() ([]Application, error)
 	AllModelUUIDs() ([]string, error)
 	Application(string) (Application, error)
+	ApplicationRemovalReport(string) (*state.ApplicationRemovalReport, error)
 	ApplyOperation(state.ModelOperation) error
 	AddApplication(state.AddApplicationArgs) (Application, error)
 	RemoteApplication(string) (RemoteApplication, error)
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
+	PreviewAddRelation(...state.Endpoint) (*state.RelationPreview, error)
 	Charm(*charm.URL) (Charm, error)
 	EndpointsRelation(...state.Endpoint) (Relation, error)
 	Relation(int) (Relation, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	Machine(string) (Machine, error)
+	ModelConfig() (*config.Config, error)
+	ModelConstraints() (constraints.Value, error)
 	ModelTag() names.ModelTag
 	Unit(string) (Unit, error)
 	SaveController(info crossmodel.ControllerInfo, modelUUID string) (ExternalController, error)
 	ControllerTag() names.ControllerTag
+	ControllerConfig() (controller.Config, error)
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	RecordChange(state.ChangeLogEntry) error
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -58,6 +67,7 @@ type BlockChecker interface {
 // the same names.
 type Application interface {
 	AddUnit(state.AddUnitParams) (Unit, error)
+	AddressPolicy() state.AddressPolicy
 	AllUnits() ([]Unit, error)
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
@@ -67,14 +77,27 @@ type Application interface {
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
+	EffectiveConstraints() (constraints.Resolution, error)
 	Endpoints() ([]state.Endpoint, error)
+	HookEnvironment() (map[string]string, error)
 	IsPrincipal() bool
+	Logging() state.ApplicationLogging
+	PreviewUnitPlacement(int, []*instance.Placement) ([]state.UnitPlacement, error)
+	RelationSettingsUsage() ([]state.RelationSettingsUsage, error)
+	RemoveTrust() error
 	Series() string
+	SetAddressPolicy(state.AddressPolicy) error
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
+	SetExposedToCIDRs([]string) error
+	SetFloatingIP(string) error
+	SetHookEnvironment(map[string]string) error
+	SetLogging(state.ApplicationLogging) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	SetTrust(names.UserTag) error
+	Trust() (state.ApplicationTrust, error)
 	UpdateApplicationSeries(string, bool) error
 	UpdateConfigSettings(charm.Settings) error
 }
@@ -103,6 +126,7 @@ type Relation interface {
 	Tag() names.Tag
 	Destroy() error
 	Endpoint(string) (state.Endpoint, error)
+	PreviewDestroy() (*state.RelationPreview, error)
 	SetSuspended(bool, string) error
 	Suspended() bool
 	SuspendedReason() string
@@ -118,6 +142,8 @@ type Unit interface {
 	DestroyOperation() *state.DestroyUnitOperation
 	IsPrincipal() bool
 	Life() state.Life
+	Resolve(bool) error
+	Status() (status.StatusInfo, error)
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
@@ -179,6 +205,18 @@ func CharmToStateCharm(ch Charm) *state.Charm {
 	return ch.(stateCharmShim).Charm
 }
 
+func (s stateShim) AllApplications() ([]Application, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Application, len(apps))
+	for i, a := range apps {
+		out[i] = stateApplicationShim{a, s.State}
+	}
+	return out, nil
+}
+
 func (s stateShim) Application(name string) (Application, error) {
 	a, err := s.State.Application(name)
 	if err != nil {
@@ -0,0 +1,326 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common/charmcheck"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// gitSchemes holds the repository URL schemes accepted by
+// AddCharmFromGit, and the URL schemes git is given for them.
+var gitSchemes = map[string]string{
+	"git+ssh://":   "ssh://",
+	"git+https://": "https://",
+}
+
+// AddCharmFromGit builds a charm from the given ref of a git
+// repository and adds it to the model as a local charm, recording the
+// repository and commit it was built from. If a charm has already been
+// built from the same commit for the same series, that charm is used
+// instead. The repository's host must be allowed by the controller's
+// config, and only the credentials in args are used to fetch from it.
+func AddCharmFromGit(st *state.State, args params.AddCharmFromGit) (params.AddCharmFromGitResult, error) {
+	var result params.AddCharmFromGitResult
+	gitURL, err := gitRepositoryURL(args.Repository)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := checkGitHost(gitURL, controllerConfig.DeployAllowedGitHosts()); err != nil {
+		return result, errors.Trace(err)
+	}
+	if strings.HasPrefix(args.Repository, "git+ssh://") {
+		if strings.TrimSpace(args.KnownHosts) == "" {
+			return result, errors.NewNotValid(nil, "known host keys required for git+ssh repositories")
+		}
+	} else if args.PrivateKey != "" {
+		return result, errors.NewNotValid(nil, "private key only supported for git+ssh repositories")
+	}
+	ref := args.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if strings.HasPrefix(ref, "-") {
+		return result, errors.NotValidf("git ref %q", ref)
+	}
+
+	tempDir, err := ioutil.TempDir("", "charm-git")
+	if err != nil {
+		return result, errors.Annotate(err, "cannot create temp directory")
+	}
+	defer os.RemoveAll(tempDir)
+	authPath := filepath.Join(tempDir, "auth")
+	if err := os.Mkdir(authPath, 0700); err != nil {
+		return result, errors.Trace(err)
+	}
+	env, err := gitEnv(authPath, args)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	checkoutPath := filepath.Join(tempDir, "checkout")
+	revision, err := cloneGitCharm(gitURL, ref, checkoutPath, env)
+	if err != nil {
+		return result, errors.Annotatef(err, "cannot fetch %q from %s", ref, args.Repository)
+	}
+	// The repository history must not end up in the charm archive.
+	if err := os.RemoveAll(filepath.Join(checkoutPath, ".git")); err != nil {
+		return result, errors.Trace(err)
+	}
+	charmDir, err := charm.ReadCharmDir(checkoutPath)
+	if err != nil {
+		return result, errors.Annotatef(err, "cannot read charm from %s at %s", args.Repository, revision)
+	}
+	if err := charm.ValidateName(charmDir.Meta().Name); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := checkMinVersion(charmDir); err != nil {
+		return result, errors.Trace(err)
+	}
+	series, err := gitCharmSeries(st, charmDir, args.Series)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Revision = revision
+
+	existing, err := st.GitSourceCharms(args.Repository, revision)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, ch := range existing {
+		curl := ch.URL()
+		if curl.Name == charmDir.Meta().Name && curl.Series == series {
+			result.URL = curl.String()
+			return result, nil
+		}
+	}
+
+	curl, err := st.PrepareLocalCharmUpload(&charm.URL{
+		Schema:   "local",
+		Name:     charmDir.Meta().Name,
+		Series:   series,
+		Revision: charmDir.Revision(),
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	charmDir.SetRevision(curl.Revision)
+
+	// Bundle the charm and calculate its sha256 hash at the same time.
+	archivePath := filepath.Join(tempDir, "charm.zip")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if err := charmDir.ArchiveTo(io.MultiWriter(hash, f)); err != nil {
+		return result, errors.Annotate(err, "cannot archive charm")
+	}
+	if err := charmcheck.CheckArchive(archivePath); err != nil {
+		return result, errors.Annotatef(err, "cannot add charm %q", curl)
+	}
+	archive, err := charm.ReadCharmArchive(archivePath)
+	if err != nil {
+		return result, errors.Annotatef(err, "cannot read charm %q", curl)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return result, errors.Annotate(err, "cannot rewind charm archive")
+	}
+
+	err = StoreCharmArchive(st, CharmArchive{
+		ID:     curl,
+		Charm:  archive,
+		Data:   f,
+		Size:   info.Size(),
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+		GitSource: &state.CharmGitSource{
+			Repository: args.Repository,
+			Ref:        args.Ref,
+			Revision:   revision,
+		},
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.URL = curl.String()
+	return result, nil
+}
+
+// gitRepositoryURL returns the URL to give git for the repository with
+// the given URL, which must have one of the gitSchemes.
+func gitRepositoryURL(repository string) (string, error) {
+	for scheme, gitScheme := range gitSchemes {
+		if strings.HasPrefix(repository, scheme) && len(repository) > len(scheme) {
+			return gitScheme + strings.TrimPrefix(repository, scheme), nil
+		}
+	}
+	return "", errors.NotValidf("git repository %q (expected git+ssh:// or git+https:// URL)", repository)
+}
+
+// checkGitHost returns an error unless the host of the repository
+// with the given git URL is one of the allowed hosts.
+func checkGitHost(gitURL string, allowed []string) error {
+	u, err := url.Parse(gitURL)
+	if err != nil || u.Hostname() == "" {
+		return errors.NotValidf("git repository URL %q", gitURL)
+	}
+	for _, host := range allowed {
+		if strings.EqualFold(host, u.Hostname()) {
+			return nil
+		}
+	}
+	return errors.Errorf(
+		"charms may not be built from git repositories on %q (see the %s controller config)",
+		u.Hostname(), controller.DeployAllowedGitHosts,
+	)
+}
+
+// gitProxyVars holds the names of the environment variables passed
+// on to git from the controller's environment, besides PATH.
+var gitProxyVars = []string{
+	"http_proxy", "HTTP_PROXY",
+	"https_proxy", "HTTPS_PROXY",
+	"no_proxy", "NO_PROXY",
+}
+
+// gitEnv returns the environment in which to run git to fetch from
+// the repository, writing the credentials in args to files in dir.
+// Git and ssh see none of the controller's own config, keys or agent,
+// and ssh only connects to a host whose key is in args.KnownHosts.
+func gitEnv(dir string, args params.AddCharmFromGit) ([]string, error) {
+	knownHostsPath := filepath.Join(dir, "known_hosts")
+	if err := ioutil.WriteFile(knownHostsPath, []byte(args.KnownHosts), 0600); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sshArgs := []string{
+		"ssh", "-F", "/dev/null",
+		"-o", "BatchMode=yes",
+		"-o", "StrictHostKeyChecking=yes",
+		"-o", "UserKnownHostsFile=" + utils.ShQuote(knownHostsPath),
+		"-o", "GlobalKnownHostsFile=/dev/null",
+		"-o", "IdentitiesOnly=yes",
+	}
+	if args.PrivateKey != "" {
+		keyPath := filepath.Join(dir, "id")
+		key := strings.TrimSpace(args.PrivateKey) + "\n"
+		if err := ioutil.WriteFile(keyPath, []byte(key), 0600); err != nil {
+			return nil, errors.Trace(err)
+		}
+		sshArgs = append(sshArgs, "-o", "IdentityFile="+utils.ShQuote(keyPath))
+	} else {
+		sshArgs = append(sshArgs, "-o", "PubkeyAuthentication=no")
+	}
+
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"XDG_CONFIG_HOME=" + dir,
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_SSH_COMMAND=" + strings.Join(sshArgs, " "),
+	}
+	for _, name := range gitProxyVars {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env, nil
+}
+
+// gitCharmSeries returns the series for the URL of the given charm. If
+// no series is requested, the charm's default series is used, falling
+// back to the model's default series.
+func gitCharmSeries(st *state.State, ch charm.Charm, series string) (string, error) {
+	supported := ch.Meta().Series
+	if series != "" {
+		if len(supported) > 0 {
+			if _, err := charm.SeriesForCharm(series, supported); err != nil {
+				return "", errors.Trace(err)
+			}
+		}
+		return series, nil
+	}
+	if len(supported) > 0 {
+		return supported[0], nil
+	}
+	model, err := st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return config.PreferredSeries(modelConfig), nil
+}
+
+// cloneGitCharm checks out the given ref of the git repository at the
+// given URL into dir, fetching only what is needed to do so, and
+// returns the ID of the commit checked out. Git is run with the given
+// environment, as returned by gitEnv. It is a variable so that it can
+// be replaced in tests.
+var cloneGitCharm = func(url, ref, dir string, env []string) (string, error) {
+	if err := runGit("", env, "init", "--quiet", dir); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := runGit(dir, env, "fetch", "--quiet", "--depth", "1", "--", url, ref); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := runGit(dir, env, "checkout", "--quiet", "FETCH_HEAD"); err != nil {
+		return "", errors.Trace(err)
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", errors.Annotate(err, "cannot get commit ID")
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// runGit runs git with the given arguments in dir, with the given
+// environment. Git is not allowed to prompt for credentials, as there
+// is nobody to answer.
+func runGit(dir string, env []string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.Annotatef(err, "running git %s: %s", args[0], msg)
+		}
+		return errors.Annotatef(err, "running git %s", args[0])
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable/csclient"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/state"
+)
+
+// openMirroredCharm returns the path of a copy of the archive of the
+// charm with the given URL from the controller's charm mirror, and a
+// function that removes the copy. The hash of the archive is checked
+// against the charm store, using the given client, so that the mirror
+// only gives charms to those the store would give them to, and only
+// gives the charm the store has. An error satisfying errors.IsNotFound
+// is returned if the charm is not mirrored, or its archive does not
+// match the charm store's, in which case it is removed from the mirror.
+func openMirroredCharm(st *state.State, client *csclient.Client, curl *charm.URL) (string, func() error, error) {
+	mc, err := st.MirroredCharm(curl)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	var hash csparams.HashResponse
+	if err := client.Get("/"+curl.Path()+"/meta/hash", &hash); err != nil {
+		return "", nil, errors.Annotatef(err, "cannot retrieve charm %q: cannot get hash", curl)
+	}
+	if hash.Sum != mc.SHA384 {
+		logger.Warningf("mirrored charm %q does not match the charm store, discarding it", curl)
+		discardMirroredCharm(st, mc)
+		return "", nil, errors.NotFoundf("mirrored charm %q", curl)
+	}
+	path, err := copyMirroredCharm(st, mc)
+	if err != nil {
+		logger.Warningf("cannot read mirrored charm %q, discarding it: %v", curl, err)
+		discardMirroredCharm(st, mc)
+		return "", nil, errors.NotFoundf("mirrored charm %q", curl)
+	}
+	return path, func() error { return os.Remove(path) }, nil
+}
+
+// copyMirroredCharm copies the archive of the mirrored charm from
+// storage to a temporary file, verifying its hash, and returns the path
+// of the file.
+func copyMirroredCharm(st *state.State, mc state.MirroredCharm) (_ string, err error) {
+	storage := newStateStorage(st.ControllerModelUUID(), st.MongoSession())
+	r, _, err := storage.Get(mc.StoragePath)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer r.Close()
+	f, err := ioutil.TempFile("", "charm-mirror")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	h := sha512.New384()
+	if _, err := io.Copy(io.MultiWriter(f, h), r); err != nil {
+		return "", errors.Trace(err)
+	}
+	if sum := fmt.Sprintf("%x", h.Sum(nil)); sum != mc.SHA384 {
+		return "", errors.Errorf("hash mismatch, expected %s, got %s", mc.SHA384, sum)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return f.Name(), nil
+}
+
+// discardMirroredCharm removes the charm from the controller's charm
+// mirror, logging any failure.
+func discardMirroredCharm(st *state.State, mc state.MirroredCharm) {
+	if err := st.RemoveMirroredCharm(mc.URL); err != nil {
+		logger.Errorf("cannot remove mirrored charm %q: %v", mc.URL, err)
+		return
+	}
+	storage := newStateStorage(st.ControllerModelUUID(), st.MongoSession())
+	if err := storage.Remove(mc.StoragePath); err != nil && !errors.IsNotFound(err) {
+		logger.Errorf("cannot remove mirrored charm archive from storage: %v", err)
+	}
+}
+
+// mirrorCharmArchive adds the charm archive at the given path, which
+// was downloaded from the charm store, to the controller's charm
+// mirror, unless the charm is already mirrored.
+func mirrorCharmArchive(st *state.State, curl *charm.URL, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	h256, h384 := sha256.New(), sha512.New384()
+	size, err := io.Copy(io.MultiWriter(h256, h384), f)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("charm-mirror/%s-%s", curl, uuid)
+	storage := newStateStorage(st.ControllerModelUUID(), st.MongoSession())
+	if err := storage.Put(storagePath, f, size); err != nil {
+		return errors.Annotate(err, "cannot add charm to mirror storage")
+	}
+	err = st.AddMirroredCharm(state.MirroredCharm{
+		URL:         curl,
+		StoragePath: storagePath,
+		SHA256:      fmt.Sprintf("%x", h256.Sum(nil)),
+		SHA384:      fmt.Sprintf("%x", h384.Sum(nil)),
+		Size:        size,
+	})
+	if err != nil {
+		if err := storage.Remove(storagePath); err != nil {
+			logger.Errorf("cannot remove charm archive from mirror storage: %v", err)
+		}
+		if errors.IsAlreadyExists(err) {
+			// The charm was mirrored concurrently.
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
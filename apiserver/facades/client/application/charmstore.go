@@ -6,11 +6,15 @@ package application
 import (
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charmrepo.v2-unstable"
@@ -19,7 +23,9 @@ import (
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/apiserver/common/charmcheck"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -67,7 +73,7 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	}
 
 	// Open a charm store client.
-	repo, err := openCSRepo(args)
+	csClient, err := openCSClient(args)
 	if err != nil {
 		return err
 	}
@@ -79,10 +85,17 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	if err != nil {
 		return errors.Trace(err)
 	}
-	repo = config.SpecializeCharmRepo(repo, modelConfig).(*charmrepo.CharmStore)
 
-	// Get the charm and its information from the store.
-	downloadedCharm, err := repo.Get(charmURL)
+	// Take the charm from the controller's charm mirror if it is
+	// there, so that it is only downloaded from the store once for
+	// all the models on the controller.
+	archivePath, closeArchive, err := openMirroredCharm(st, csClient, charmURL)
+	mirrored := err == nil
+	if errors.IsNotFound(err) {
+		// Download the charm from the store, sharing the download with
+		// any concurrent requests for the same charm.
+		archivePath, closeArchive, err = downloadCharm(csClient, args, charmURL, modelConfig.TestMode())
+	}
 	if err != nil {
 		cause := errors.Cause(err)
 		if httpbakery.IsDischargeError(cause) || httpbakery.IsInteractionError(cause) {
@@ -90,22 +103,26 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 		}
 		return errors.Trace(err)
 	}
+	defer closeArchive()
+	if err := charmcheck.CheckArchive(archivePath); err != nil {
+		return errors.Annotatef(err, "cannot add charm %q", charmURL)
+	}
+	downloadedCharm, err := charm.ReadCharmArchive(archivePath)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read charm %q", charmURL)
+	}
 
 	if err := checkMinVersion(downloadedCharm); err != nil {
 		return errors.Trace(err)
 	}
-
-	// Open it and calculate the SHA256 hash.
-	downloadedBundle, ok := downloadedCharm.(*charm.CharmArchive)
-	if !ok {
-		return errors.Errorf("expected a charm archive, got %T", downloadedCharm)
+	if !mirrored {
+		if err := mirrorCharmArchive(st, charmURL, archivePath); err != nil {
+			logger.Warningf("cannot mirror charm %q: %v", charmURL, err)
+		}
 	}
 
-	// Clean up the downloaded charm - we don't need to cache it in
-	// the filesystem as well as in blob storage.
-	defer os.Remove(downloadedBundle.Path)
-
-	archive, err := os.Open(downloadedBundle.Path)
+	// Open it and calculate the SHA256 hash.
+	archive, err := os.Open(archivePath)
 	if err != nil {
 		return errors.Annotate(err, "cannot read downloaded charm")
 	}
@@ -133,13 +150,144 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	return StoreCharmArchive(st, ca)
 }
 
-func openCSRepo(args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
-	csClient, err := openCSClient(args)
+// downloadCharm downloads the archive of the charm with the given URL
+// from the charm store, and returns its path and a function that
+// releases it.
+func downloadCharm(
+	client *csclient.Client,
+	args params.AddCharmWithAuthorization,
+	curl *charm.URL,
+	testMode bool,
+) (string, func() error, error) {
+	downloader, err := charmDownloader()
 	if err != nil {
-		return nil, err
+		return "", nil, errors.Trace(err)
+	}
+	download, err := downloader.Download(charmstore.DownloadRequest{
+		URL:    curl,
+		Auth:   downloadAuth(args),
+		Source: csArchiveSource{client, testMode},
+	})
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return download.Path, download.Close, nil
+}
+
+var (
+	charmDownloadersMu sync.Mutex
+	charmDownloaders   = make(map[string]*charmstore.Downloader)
+
+	// charmResolutions caches the resolution of charm references
+	// by ResolveCharms.
+	charmResolutions = charmstore.NewResolutionCache(clock.WallClock, charmResolutionTTL)
+)
+
+const (
+	// maxConcurrentCharmDownloads is the maximum number of charms
+	// downloaded from the charm store at once.
+	maxConcurrentCharmDownloads = 4
+
+	// maxCachedCharmDownloads is the maximum number of downloaded
+	// charm archives kept, so that deploying the same charm to
+	// several models does not download it each time.
+	maxCachedCharmDownloads = 20
+
+	// charmDownloadAttempts is the number of times the transfer of a
+	// charm archive is attempted, each resuming from where the last
+	// failed.
+	charmDownloadAttempts = 5
+
+	// charmDownloadRetryDelay is the time waited between attempts to
+	// transfer a charm archive.
+	charmDownloadRetryDelay = 5 * time.Second
+
+	// charmResolutionTTL is how long the resolution of a charm
+	// reference is cached for.
+	charmResolutionTTL = time.Minute
+)
+
+// charmDownloader returns the downloader used to fetch charm archives
+// from the charm store. Its archives are kept alongside the charm
+// repository cache directory.
+func charmDownloader() (*charmstore.Downloader, error) {
+	dir := charmrepo.CacheDir + "-downloads"
+	charmDownloadersMu.Lock()
+	defer charmDownloadersMu.Unlock()
+	if d, ok := charmDownloaders[dir]; ok {
+		return d, nil
+	}
+	d, err := charmstore.NewDownloader(charmstore.DownloaderConfig{
+		Dir:           dir,
+		MaxConcurrent: maxConcurrentCharmDownloads,
+		MaxCached:     maxCachedCharmDownloads,
+		Attempts:      charmDownloadAttempts,
+		RetryDelay:    charmDownloadRetryDelay,
+		Clock:         clock.WallClock,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create charm downloader")
+	}
+	charmDownloaders[dir] = d
+	return d, nil
+}
+
+// downloadAuth returns the charmstore.DownloadRequest Auth for
+// downloading a charm with the given arguments.
+func downloadAuth(args params.AddCharmWithAuthorization) string {
+	auth := csclient.ServerURL + "\x00" + args.Channel
+	if args.CharmStoreMacaroon != nil {
+		auth += "\x00" + fmt.Sprintf("%x", args.CharmStoreMacaroon.Signature())
+	}
+	return auth
+}
+
+// csArchiveSource is a charmstore.ArchiveSource that downloads charm
+// archives from the charm store.
+type csArchiveSource struct {
+	client   *csclient.Client
+	testMode bool
+}
+
+// OpenArchive is part of the charmstore.ArchiveSource interface.
+func (s csArchiveSource) OpenArchive(curl *charm.URL, offset int64) (io.ReadCloser, charmstore.ArchiveInfo, error) {
+	path := "/" + curl.Path() + "/archive"
+	if s.testMode {
+		path += "?stats=0"
+	}
+	if offset > 0 {
+		resp, err := s.get(path, offset)
+		if err == nil {
+			info := archiveInfo(resp)
+			if resp.StatusCode == http.StatusPartialContent {
+				info.Start = offset
+			}
+			return resp.Body, info, nil
+		}
+		logger.Debugf("cannot resume download of charm %q: %v", curl, err)
+	}
+	resp, err := s.get(path, 0)
+	if err != nil {
+		return nil, charmstore.ArchiveInfo{}, errors.Annotatef(err, "cannot retrieve charm %q: cannot get archive", curl)
+	}
+	return resp.Body, archiveInfo(resp), nil
+}
+
+func (s csArchiveSource) get(path string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequest("GET", "", nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	return s.client.Do(req, path)
+}
+
+func archiveInfo(resp *http.Response) charmstore.ArchiveInfo {
+	return charmstore.ArchiveInfo{
+		SHA384: resp.Header.Get(csparams.ContentHashHeader),
 	}
-	repo := NewCharmStoreRepo(csClient)
-	return repo, nil
 }
 
 func openCSClient(args params.AddCharmWithAuthorization) (*csclient.Client, error) {
@@ -206,6 +354,10 @@ type CharmArchive struct {
 
 	// Macaroon is the authorization macaroon for accessing the charmstore.
 	Macaroon macaroon.Slice
+
+	// GitSource records the git repository and commit the charm was
+	// built from, if it was built from one.
+	GitSource *state.CharmGitSource
 }
 
 // StoreCharmArchive stores a charm archive in environment storage.
@@ -225,6 +377,7 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 		StoragePath: storagePath,
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
+		GitSource:   archive.GitSource,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -301,8 +454,9 @@ func resolveCharm(ref *charm.URL, repo charmrepo.Interface) (*charm.URL, error)
 		return nil, fmt.Errorf("only charm store charm references are supported, with cs: schema")
 	}
 
-	// Resolve the charm location with the repository.
-	resolved, _, err := repo.Resolve(ref)
+	// Resolve the charm location with the repository, or use a
+	// recent resolution of the same reference.
+	resolved, _, err := charmResolutions.Resolve(csclient.ServerURL, ref, repo.Resolve)
 	if err != nil {
 		return nil, err
 	}
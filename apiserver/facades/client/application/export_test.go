@@ -6,4 +6,6 @@ package application
 var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
+	CloneGitCharm           = &cloneGitCharm
+	GitEnv                  = gitEnv
 )
@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SetApplicationsHookEnvironment replaces the environment variables
+// set for hook executions of each of the given applications' units, in
+// addition to those set in the model's hook-environment config.
+func (api *API) SetApplicationsHookEnvironment(args params.ApplicationHookEnvironmentArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.backend.Application(arg.ApplicationName)
+		if err == nil {
+			err = app.SetHookEnvironment(arg.Environment)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// GetApplicationsHookEnvironment returns the environment variables set
+// for hook executions of each of the given applications' units, in
+// addition to those set in the model's hook-environment config.
+func (api *API) GetApplicationsHookEnvironment(args params.Entities) (params.HookEnvironmentResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookEnvironmentResults{}, errors.Trace(err)
+	}
+	results := params.HookEnvironmentResults{
+		Results: make([]params.HookEnvironmentResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		env, err := api.applicationHookEnvironment(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Environment = env
+	}
+	return results, nil
+}
+
+func (api *API) applicationHookEnvironment(entity string) (map[string]string, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	return app.HookEnvironment()
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// SetApplicationsLogging sets the logging config of each of the given
+// applications' units, and whether their logs are written to log files
+// of their own on the controllers.
+func (api *API) SetApplicationsLogging(args params.ApplicationLoggingArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.backend.Application(arg.ApplicationName)
+		if err == nil {
+			err = app.SetLogging(state.ApplicationLogging{
+				Config:        arg.Logging.Config,
+				DedicatedFile: arg.Logging.DedicatedFile,
+			})
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// GetApplicationsLogging returns the logging config of each of the
+// given applications' units, and whether their logs are written to log
+// files of their own on the controllers.
+func (api *API) GetApplicationsLogging(args params.Entities) (params.ApplicationLoggingResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationLoggingResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationLoggingResults{
+		Results: make([]params.ApplicationLoggingResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		logging, err := api.applicationLogging(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Logging = params.ApplicationLogging{
+			Config:        logging.Config,
+			DedicatedFile: logging.DedicatedFile,
+		}
+	}
+	return results, nil
+}
+
+func (api *API) applicationLogging(entity string) (state.ApplicationLogging, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return state.ApplicationLogging{}, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return state.ApplicationLogging{}, err
+	}
+	return app.Logging(), nil
+}
@@ -5,8 +5,10 @@ package application_test
 
 import (
 	"io"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
@@ -16,8 +18,11 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -149,6 +154,122 @@ func (a *mockApplication) IsPrincipal() bool {
 	return !a.subordinate
 }
 
+func (a *mockApplication) Constraints() (constraints.Value, error) {
+	a.MethodCall(a, "Constraints")
+	if err := a.NextErr(); err != nil {
+		return constraints.Value{}, err
+	}
+	return constraints.MustParse("cores=4"), nil
+}
+
+func (a *mockApplication) EffectiveConstraints() (constraints.Resolution, error) {
+	a.MethodCall(a, "EffectiveConstraints")
+	if err := a.NextErr(); err != nil {
+		return constraints.Resolution{}, err
+	}
+	return constraints.Resolution{
+		Effective:  constraints.MustParse("mem=4G cores=4"),
+		Inherited:  []string{"mem"},
+		Overridden: []string{"cores"},
+	}, nil
+}
+
+func (a *mockApplication) SetTrust(user names.UserTag) error {
+	a.MethodCall(a, "SetTrust", user)
+	return a.NextErr()
+}
+
+func (a *mockApplication) RemoveTrust() error {
+	a.MethodCall(a, "RemoveTrust")
+	return a.NextErr()
+}
+
+func (a *mockApplication) Trust() (state.ApplicationTrust, error) {
+	a.MethodCall(a, "Trust")
+	if err := a.NextErr(); err != nil {
+		return state.ApplicationTrust{}, err
+	}
+	return state.ApplicationTrust{
+		Application: a.name,
+		GrantedBy:   names.NewUserTag("admin"),
+		Granted:     time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC),
+		LastUsed:    time.Date(2017, 10, 2, 12, 0, 0, 0, time.UTC),
+		LastUsedBy:  a.name + "/0",
+		UseCount:    3,
+	}, nil
+}
+
+func (a *mockApplication) SetAddressPolicy(policy state.AddressPolicy) error {
+	a.MethodCall(a, "SetAddressPolicy", policy)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetExposedToCIDRs(cidrs []string) error {
+	a.MethodCall(a, "SetExposedToCIDRs", cidrs)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetFloatingIP(address string) error {
+	a.MethodCall(a, "SetFloatingIP", address)
+	return a.NextErr()
+}
+
+func (a *mockApplication) AddressPolicy() state.AddressPolicy {
+	a.MethodCall(a, "AddressPolicy")
+	return state.AddressPolicy{Prefer: state.AddressKindFan, PreferIPv6: true}
+}
+
+func (a *mockApplication) SetLogging(logging state.ApplicationLogging) error {
+	a.MethodCall(a, "SetLogging", logging)
+	return a.NextErr()
+}
+
+func (a *mockApplication) Logging() state.ApplicationLogging {
+	a.MethodCall(a, "Logging")
+	return state.ApplicationLogging{Config: "unit=DEBUG", DedicatedFile: true}
+}
+
+func (a *mockApplication) SetHookEnvironment(env map[string]string) error {
+	a.MethodCall(a, "SetHookEnvironment", env)
+	return a.NextErr()
+}
+
+func (a *mockApplication) HookEnvironment() (map[string]string, error) {
+	a.MethodCall(a, "HookEnvironment")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return map[string]string{"SITE_ID": "lon1"}, nil
+}
+
+func (a *mockApplication) RelationSettingsUsage() ([]state.RelationSettingsUsage, error) {
+	a.MethodCall(a, "RelationSettingsUsage")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return []state.RelationSettingsUsage{{
+		RelationKey: a.name + ":db wordpress:db",
+		RelationId:  1,
+		Unit:        a.name + "/0",
+		Keys:        2,
+		Size:        42,
+	}}, nil
+}
+
+func (a *mockApplication) PreviewUnitPlacement(count int, placement []*instance.Placement) ([]state.UnitPlacement, error) {
+	a.MethodCall(a, "PreviewUnitPlacement", count, placement)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]state.UnitPlacement, count)
+	for i := range result {
+		if i < len(placement) {
+			result[i] = state.UnitPlacement{MachineId: placement[i].Directive}
+		}
+	}
+	return result, nil
+}
+
 func (a *mockApplication) UpdateApplicationSeries(series string, force bool) error {
 	a.MethodCall(a, "UpdateApplicationSeries", series, force)
 	return a.NextErr()
@@ -266,12 +387,30 @@ type mockBackend struct {
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
+	controllerConfig           controller.Config
+	removalReports             map[string]*state.ApplicationRemovalReport
+	changes                    []state.ChangeLogEntry
+}
+
+// RecordChange does not record a method call, so that the calls
+// checked by tests are those made to apply the change.
+func (m *mockBackend) RecordChange(entry state.ChangeLogEntry) error {
+	m.changes = append(m.changes, entry)
+	return nil
 }
 
 func (m *mockBackend) ControllerTag() names.ControllerTag {
 	return coretesting.ControllerTag
 }
 
+func (m *mockBackend) ControllerConfig() (controller.Config, error) {
+	cfg := coretesting.FakeControllerConfig()
+	for key, value := range m.controllerConfig {
+		cfg[key] = value
+	}
+	return cfg, nil
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return nil, false, nil
 }
@@ -300,9 +439,9 @@ func (m *mockBackend) Unit(name string) (application.Unit, error) {
 		}
 	}
 	if unitApp != nil {
-		for _, u := range unitApp.units {
-			if u.tag.Id() == name {
-				return &u, nil
+		for i := range unitApp.units {
+			if unitApp.units[i].tag.Id() == name {
+				return &unitApp.units[i], nil
 			}
 		}
 	}
@@ -331,6 +470,18 @@ func (m *mockBackend) EndpointsRelation(endpoints ...state.Endpoint) (applicatio
 	return nil, errors.NotFoundf("relation")
 }
 
+func (m *mockBackend) PreviewAddRelation(endpoints ...state.Endpoint) (*state.RelationPreview, error) {
+	m.MethodCall(m, "PreviewAddRelation", endpoints)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &state.RelationPreview{
+		Key:       "postgresql bar",
+		Endpoints: endpoints,
+		Units:     []string{"postgresql/0", "postgresql/1"},
+	}, nil
+}
+
 func (m *mockBackend) Relation(id int) (application.Relation, error) {
 	m.MethodCall(m, "Relation", id)
 	if err := m.NextErr(); err != nil {
@@ -446,6 +597,35 @@ func (m *mockBackend) RemoteApplication(name string) (application.RemoteApplicat
 	return app, nil
 }
 
+func (m *mockBackend) ApplicationRemovalReport(name string) (*state.ApplicationRemovalReport, error) {
+	m.MethodCall(m, "ApplicationRemovalReport", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	report, ok := m.removalReports[name]
+	if !ok {
+		return nil, errors.NotFoundf("removal report for application %q", name)
+	}
+	return report, nil
+}
+
+func (m *mockBackend) AllApplications() ([]application.Application, error) {
+	m.MethodCall(m, "AllApplications")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	var appNames []string
+	for name := range m.applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+	apps := make([]application.Application, len(appNames))
+	for i, name := range appNames {
+		apps[i] = m.applications[name]
+	}
+	return apps, nil
+}
+
 func (m *mockBackend) Application(name string) (application.Application, error) {
 	m.MethodCall(m, "Application", name)
 	if err := m.NextErr(); err != nil {
@@ -497,6 +677,25 @@ func (m *mockBackend) ModelUUID() string {
 	return m.modelUUID
 }
 
+func (m *mockBackend) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		config.MaxRelationSettingsKeys: 10,
+		config.MaxRelationSettingsSize: 1024,
+	}))
+}
+
+func (m *mockBackend) ModelConstraints() (constraints.Value, error) {
+	m.MethodCall(m, "ModelConstraints")
+	if err := m.NextErr(); err != nil {
+		return constraints.Value{}, err
+	}
+	return constraints.MustParse("mem=4G cores=2"), nil
+}
+
 func (m *mockBackend) ModelTag() names.ModelTag {
 	m.MethodCall(m, "ModelTag")
 	m.PopNoErr()
@@ -561,10 +760,35 @@ func (r *mockRelation) Destroy() error {
 	return r.NextErr()
 }
 
+func (r *mockRelation) PreviewDestroy() (*state.RelationPreview, error) {
+	r.MethodCall(r, "PreviewDestroy")
+	if err := r.NextErr(); err != nil {
+		return nil, err
+	}
+	return &state.RelationPreview{
+		Key:      "wordpress:db mysql:db",
+		Problems: []string{"relation is already dying"},
+	}, nil
+}
+
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag names.UnitTag
+	tag    names.UnitTag
+	status status.Status
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	u.MethodCall(u, "Status")
+	return status.StatusInfo{Status: u.status}, u.NextErr()
+}
+
+func (u *mockUnit) Resolve(noretryHooks bool) error {
+	u.MethodCall(u, "Resolve", noretryHooks)
+	if u.status != status.Error {
+		return errors.Errorf("unit %q is not in an error state", u.tag.Id())
+	}
+	return u.NextErr()
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
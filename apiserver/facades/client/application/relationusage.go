@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RelationSettingsUsage reports, for each given application, the number
+// of relation settings and their size stored by each of its units,
+// along with the limits configured for the model.
+func (api *API) RelationSettingsUsage(args params.Entities) (params.RelationSettingsUsageResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationSettingsUsageResults{}, errors.Trace(err)
+	}
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return params.RelationSettingsUsageResults{}, errors.Trace(err)
+	}
+	results := params.RelationSettingsUsageResults{
+		Results: make([]params.RelationSettingsUsageResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		usage, err := api.relationSettingsUsage(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = params.RelationSettingsUsageResult{
+			MaxKeys: cfg.MaxRelationSettingsKeys(),
+			MaxSize: cfg.MaxRelationSettingsSize(),
+			Usage:   usage,
+		}
+	}
+	return results, nil
+}
+
+func (api *API) relationSettingsUsage(entity string) ([]params.RelationSettingsUsage, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	usage, err := app.RelationSettingsUsage()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]params.RelationSettingsUsage, len(usage))
+	for i, u := range usage {
+		result[i] = params.RelationSettingsUsage{
+			RelationKey: u.RelationKey,
+			RelationId:  u.RelationId,
+			Unit:        u.Unit,
+			Keys:        u.Keys,
+			Size:        u.Size,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+func (api *API) checkCanAdmin() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.AdminAccess)
+}
+
+// SetApplicationsTrust grants or revokes access to the model's cloud
+// credential for each of the given applications. Only model admins may
+// change the trust of an application.
+func (api *API) SetApplicationsTrust(args params.ApplicationTrustArgs) (params.ErrorResults, error) {
+	if err := api.checkCanAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.backend.Application(arg.ApplicationName)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if arg.Trusted {
+			err = app.SetTrust(user)
+		} else {
+			err = app.RemoveTrust()
+		}
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		logger.Infof("%s set trust for application %q to %v", user.Id(), arg.ApplicationName, arg.Trusted)
+	}
+	return results, nil
+}
+
+// GetApplicationsTrust reports, for each given application, whether it
+// has been trusted with access to the model's cloud credential, and
+// how that access has been used.
+func (api *API) GetApplicationsTrust(args params.Entities) (params.ApplicationTrustResults, error) {
+	if err := api.checkCanAdmin(); err != nil {
+		return params.ApplicationTrustResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationTrustResults{
+		Results: make([]params.ApplicationTrustResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		result, err := api.getApplicationTrust(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) getApplicationTrust(entity string) (params.ApplicationTrustResult, error) {
+	var result params.ApplicationTrustResult
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return result, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return result, err
+	}
+	trust, err := app.Trust()
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return result, err
+	}
+	result = params.ApplicationTrustResult{
+		Trusted:    true,
+		GrantedBy:  trust.GrantedBy.Id(),
+		Granted:    &trust.Granted,
+		LastUsedBy: trust.LastUsedBy,
+		UseCount:   trust.UseCount,
+	}
+	if !trust.LastUsed.IsZero() {
+		result.LastUsed = &trust.LastUsed
+	}
+	return result, nil
+}
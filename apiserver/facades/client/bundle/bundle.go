@@ -24,6 +24,12 @@ func NewFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bund
 	return NewBundle(auth)
 }
 
+// NewFacadeV2 provides the required signature for version 2 facade
+// registration.
+func NewFacadeV2(st *state.State, _ facade.Resources, auth facade.Authorizer) (*BundleAPIV2, error) {
+	return NewBundleAPIV2(stateShim{st}, auth)
+}
+
 // NewBundle creates and returns a new Bundle API facade.
 func NewBundle(auth facade.Authorizer) (Bundle, error) {
 	if !auth.AuthClient() {
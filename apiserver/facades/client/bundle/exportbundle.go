@@ -0,0 +1,304 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality required to export a model
+// as a bundle.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ExportPartial(state.ExportConfig) (description.Model, error)
+	CharmConfig(curl string) (*charm.Config, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// CharmConfig is part of the Backend interface.
+func (s stateShim) CharmConfig(curl string) (*charm.Config, error) {
+	url, err := charm.ParseURL(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := s.State.Charm(url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch.Config(), nil
+}
+
+// BundleAPIV2 adds ExportBundle to the Bundle API.
+type BundleAPIV2 struct {
+	bundleAPI
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewBundleAPIV2 creates and returns a new version 2 Bundle API facade.
+func NewBundleAPIV2(backend Backend, auth facade.Authorizer) (*BundleAPIV2, error) {
+	if !auth.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &BundleAPIV2{
+		backend:    backend,
+		authorizer: auth,
+	}, nil
+}
+
+// ExportBundle returns the model as bundle YAML, which can be deployed
+// to recreate the model's applications, machines and relations.
+func (b *BundleAPIV2) ExportBundle() (params.StringResult, error) {
+	allowed, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	if !allowed {
+		return params.StringResult{}, common.ErrPerm
+	}
+	model, err := b.backend.ExportPartial(state.ExportConfig{
+		SkipActions:            true,
+		SkipCloudImageMetadata: true,
+		SkipCredentials:        true,
+		SkipIPAddresses:        true,
+		SkipSSHHostKeys:        true,
+		SkipStatusHistory:      true,
+		SkipLinkLayerDevices:   true,
+	})
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	data, err := b.bundleData(model)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	return params.StringResult{Result: string(out)}, nil
+}
+
+// bundleData returns the bundle that recreates the given model.
+func (b *BundleAPIV2) bundleData(model description.Model) (*charm.BundleData, error) {
+	if len(model.Applications()) == 0 {
+		return nil, errors.New("nothing to export as there are no applications")
+	}
+	defaultSeries, _ := model.Config()["default-series"].(string)
+	data := &charm.BundleData{
+		Series:       defaultSeries,
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+
+	usedMachines := set.NewStrings()
+	for _, app := range model.Applications() {
+		spec := &charm.ApplicationSpec{
+			Charm:            app.CharmURL(),
+			Expose:           app.Exposed(),
+			Annotations:      app.Annotations(),
+			Constraints:      constraintsString(app.Constraints()),
+			Storage:          storageStrings(app.StorageConstraints()),
+			EndpointBindings: endpointBindings(app.EndpointBindings()),
+		}
+		if app.Series() != defaultSeries {
+			spec.Series = app.Series()
+		}
+		config, err := b.backend.CharmConfig(app.CharmURL())
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting config for application %q", app.Name())
+		}
+		spec.Options = changedOptions(config, app.Settings())
+		if !app.Subordinate() {
+			spec.NumUnits = len(app.Units())
+			for _, unit := range app.Units() {
+				placement, host := unitPlacement(unit.Machine().Id())
+				spec.To = append(spec.To, placement)
+				usedMachines.Add(host)
+			}
+			sort.Strings(spec.To)
+		}
+		data.Applications[app.Name()] = spec
+	}
+
+	for _, machine := range model.Machines() {
+		if !usedMachines.Contains(machine.Id()) {
+			continue
+		}
+		spec := &charm.MachineSpec{
+			Annotations: machine.Annotations(),
+			Constraints: constraintsString(machine.Constraints()),
+		}
+		if machine.Series() != defaultSeries {
+			spec.Series = machine.Series()
+		}
+		data.Machines[machine.Id()] = spec
+	}
+
+	for _, rel := range model.Relations() {
+		endpoints := rel.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established by deploying the
+			// application.
+			continue
+		}
+		var eps []string
+		for _, ep := range endpoints {
+			if _, ok := data.Applications[ep.ApplicationName()]; !ok {
+				// Relations to remote applications cannot be
+				// recreated by the bundle.
+				break
+			}
+			eps = append(eps, ep.ApplicationName()+":"+ep.Name())
+		}
+		if len(eps) == 2 {
+			sort.Strings(eps)
+			data.Relations = append(data.Relations, eps)
+		}
+	}
+	sort.Slice(data.Relations, func(i, j int) bool {
+		return strings.Join(data.Relations[i], " ") < strings.Join(data.Relations[j], " ")
+	})
+	return data, nil
+}
+
+// unitPlacement returns the bundle placement directive for a unit on
+// the machine with the given id, and the id of the top level machine
+// hosting it.
+func unitPlacement(machineId string) (placement, host string) {
+	if !names.IsContainerMachine(machineId) {
+		return machineId, machineId
+	}
+	// Bundles only describe top level machines, so a unit in a
+	// container is placed in a new container on the same host.
+	parts := strings.Split(machineId, "/")
+	return fmt.Sprintf("%s:%s", parts[len(parts)-2], parts[0]), parts[0]
+}
+
+// changedOptions returns the application settings whose values differ
+// from the charm's defaults.
+func changedOptions(config *charm.Config, settings map[string]interface{}) map[string]interface{} {
+	var options map[string]interface{}
+	for name, value := range settings {
+		if value == nil {
+			continue
+		}
+		if option, ok := config.Options[name]; ok && sameOptionValue(option.Default, value) {
+			continue
+		}
+		if options == nil {
+			options = make(map[string]interface{})
+		}
+		options[name] = value
+	}
+	return options
+}
+
+// sameOptionValue reports whether the two option values are equal,
+// regardless of the sizes of any integer values, which depend on how
+// they were stored.
+func sameOptionValue(a, b interface{}) bool {
+	return reflect.DeepEqual(normalizeInt(a), normalizeInt(b))
+}
+
+func normalizeInt(v interface{}) interface{} {
+	switch value := reflect.ValueOf(v); value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	}
+	return v
+}
+
+func constraintsString(cons description.Constraints) string {
+	if cons == nil {
+		return ""
+	}
+	var result constraints.Value
+	if arch := cons.Architecture(); arch != "" {
+		result.Arch = &arch
+	}
+	if container := instance.ContainerType(cons.Container()); container != "" {
+		result.Container = &container
+	}
+	if cores := cons.CpuCores(); cores != 0 {
+		result.CpuCores = &cores
+	}
+	if power := cons.CpuPower(); power != 0 {
+		result.CpuPower = &power
+	}
+	if inst := cons.InstanceType(); inst != "" {
+		result.InstanceType = &inst
+	}
+	if mem := cons.Memory(); mem != 0 {
+		result.Mem = &mem
+	}
+	if disk := cons.RootDisk(); disk != 0 {
+		result.RootDisk = &disk
+	}
+	if spaces := cons.Spaces(); len(spaces) > 0 {
+		result.Spaces = &spaces
+	}
+	if tags := cons.Tags(); len(tags) > 0 {
+		result.Tags = &tags
+	}
+	if virt := cons.VirtType(); virt != "" {
+		result.VirtType = &virt
+	}
+	return result.String()
+}
+
+// storageStrings returns the application storage constraints in the
+// form used by bundles, "<pool>,<count>,<size>".
+func storageStrings(cons map[string]description.StorageConstraint) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for name, sc := range cons {
+		var parts []string
+		if sc.Pool() != "" {
+			parts = append(parts, sc.Pool())
+		}
+		parts = append(parts, fmt.Sprint(sc.Count()), fmt.Sprintf("%dM", sc.Size()))
+		result[name] = strings.Join(parts, ",")
+	}
+	return result
+}
+
+// endpointBindings returns the application's endpoints that are bound
+// to a space.
+func endpointBindings(bindings map[string]string) map[string]string {
+	var result map[string]string
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[endpoint] = space
+	}
+	return result
+}
@@ -0,0 +1,243 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/bundle"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type exportBundleSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	model      description.Model
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.model = description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("admin"),
+		Config: map[string]interface{}{"default-series": "xenial"},
+	})
+	s.backend = &mockBackend{
+		model: s.model,
+		configs: map[string]*charm.Config{
+			"cs:xenial/mysql-42": {Options: map[string]charm.Option{
+				"port":    {Type: "int", Default: int64(3306)},
+				"flavour": {Type: "string", Default: "mysql"},
+			}},
+			"cs:trusty/wordpress-5": {Options: map[string]charm.Option{
+				"blog-title": {Type: "string", Default: "My Title"},
+			}},
+			"cs:xenial/ntp-3": {Options: map[string]charm.Option{}},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *exportBundleSuite) facade(c *gc.C) *bundle.BundleAPIV2 {
+	facade, err := bundle.NewBundleAPIV2(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *exportBundleSuite) addModelContents() {
+	mysql := s.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/mysql-42",
+		Settings: map[string]interface{}{
+			"port":    3306,
+			"flavour": "percona",
+		},
+		EndpointBindings: map[string]string{"server": "db-space", "cluster": ""},
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"data": {Pool: "ebs", Count: 1, Size: 10240},
+		},
+	})
+	mysql.SetConstraints(description.ConstraintsArgs{Memory: 4096})
+	mysql.SetAnnotations(map[string]string{"gui-x": "10"})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+
+	wordpress := s.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		Series:   "trusty",
+		CharmURL: "cs:trusty/wordpress-5",
+		Exposed:  true,
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: names.NewMachineTag("1"),
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/1"),
+		Machine: names.NewMachineTag("0/lxd/0"),
+	})
+
+	s.model.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("ntp"),
+		Series:      "xenial",
+		CharmURL:    "cs:xenial/ntp-3",
+		Subordinate: true,
+	})
+
+	m0 := s.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "xenial",
+	})
+	m0.SetConstraints(description.ConstraintsArgs{CpuCores: 2})
+	m0.AddContainer(description.MachineArgs{
+		Id:     names.NewMachineTag("0/lxd/0"),
+		Series: "trusty",
+	})
+	s.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("1"),
+		Series: "trusty",
+	})
+	// Machine 2 hosts no units, and is not exported.
+	s.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("2"),
+		Series: "xenial",
+	})
+
+	addRelation := func(id int, endpoints ...[2]string) {
+		rel := s.model.AddRelation(description.RelationArgs{Id: id})
+		for _, ep := range endpoints {
+			rel.AddEndpoint(description.EndpointArgs{
+				ApplicationName: ep[0],
+				Name:            ep[1],
+			})
+		}
+	}
+	addRelation(0, [2]string{"wordpress", "db"}, [2]string{"mysql", "server"})
+	addRelation(1, [2]string{"mysql", "cluster"})
+	addRelation(2, [2]string{"ntp", "juju-info"}, [2]string{"mysql", "juju-info"})
+	addRelation(3, [2]string{"wordpress", "db"}, [2]string{"remote-db", "server"})
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	s.addModelContents()
+	result, err := s.facade(c).ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Series: "xenial",
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {
+				Charm:            "cs:xenial/mysql-42",
+				NumUnits:         1,
+				To:               []string{"0"},
+				Options:          map[string]interface{}{"flavour": "percona"},
+				Annotations:      map[string]string{"gui-x": "10"},
+				Constraints:      "mem=4096M",
+				Storage:          map[string]string{"data": "ebs,1,10240M"},
+				EndpointBindings: map[string]string{"server": "db-space"},
+			},
+			"wordpress": {
+				Charm:    "cs:trusty/wordpress-5",
+				Series:   "trusty",
+				NumUnits: 2,
+				To:       []string{"1", "lxd:0"},
+				Expose:   true,
+			},
+			"ntp": {
+				Charm: "cs:xenial/ntp-3",
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			"0": {Constraints: "cores=2"},
+			"1": {Series: "trusty"},
+		},
+		Relations: [][]string{
+			{"mysql:juju-info", "ntp:juju-info"},
+			{"mysql:server", "wordpress:db"},
+		},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "ExportPartial", "CharmConfig", "CharmConfig", "CharmConfig")
+}
+
+func (s *exportBundleSuite) TestExportBundleVerifies(c *gc.C) {
+	s.addModelContents()
+	result, err := s.facade(c).ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	err = data.Verify(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exportBundleSuite) TestExportBundleNoApplications(c *gc.C) {
+	_, err := s.facade(c).ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "nothing to export as there are no applications")
+}
+
+func (s *exportBundleSuite) TestExportBundleCharmConfigError(c *gc.C) {
+	s.addModelContents()
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	_, err := s.facade(c).ExportBundle()
+	c.Assert(err, gc.ErrorMatches, `getting config for application ".*": boom`)
+}
+
+func (s *exportBundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.facade(c).ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *exportBundleSuite) TestNewBundleAPIV2RequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := bundle.NewBundleAPIV2(s.backend, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	testing.Stub
+	model   description.Model
+	configs map[string]*charm.Config
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ExportPartial(cfg state.ExportConfig) (description.Model, error) {
+	b.MethodCall(b, "ExportPartial", cfg)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.model, nil
+}
+
+func (b *mockBackend) CharmConfig(curl string) (*charm.Config, error) {
+	b.MethodCall(b, "CharmConfig", curl)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.configs[curl], nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package changelog provides the API for reading a model's change log,
+// the ordered record of the changes users have applied to the model.
+package changelog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality required by API.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	ChangeLog(state.ChangeLogFilter) ([]state.ChangeLogEntry, error)
+}
+
+// API provides access to the ChangeLog API facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(st, authorizer)
+}
+
+// NewAPI returns a new ChangeLog API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	ok, err = api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ChangeLog returns the entries of the model's change log selected by
+// the filter, oldest first.
+func (api *API) ChangeLog(args params.ChangeLogFilter) (params.ChangeLogResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ChangeLogResult{}, errors.Trace(err)
+	}
+	if args.Limit < 0 {
+		return params.ChangeLogResult{}, errors.NotValidf("negative limit %d", args.Limit)
+	}
+	filter := state.ChangeLogFilter{
+		Kind:   state.ChangeKind(args.Kind),
+		Entity: args.Entity,
+		Actor:  args.Actor,
+		Limit:  args.Limit,
+	}
+	if args.Since != nil {
+		filter.Since = *args.Since
+	}
+	entries, err := api.backend.ChangeLog(filter)
+	if err != nil {
+		return params.ChangeLogResult{}, errors.Trace(err)
+	}
+	result := params.ChangeLogResult{
+		Entries: make([]params.ChangeLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.ChangeLogEntry{
+			Seq:    entry.Seq,
+			Time:   entry.Time,
+			Actor:  entry.Actor,
+			Kind:   string(entry.Kind),
+			Entity: entry.Entity,
+			Params: entry.Params,
+		}
+	}
+	return result, nil
+}
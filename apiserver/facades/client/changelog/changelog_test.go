@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package changelog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/changelog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type changeLogSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&changeLogSuite{})
+
+func (s *changeLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *changeLogSuite) newAPI(c *gc.C) *changelog.API {
+	api, err := changelog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *changeLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := changelog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *changeLogSuite) TestChangeLog(c *gc.C) {
+	when := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.entries = []state.ChangeLogEntry{{
+		Seq:    7,
+		Time:   when,
+		Actor:  "bob",
+		Kind:   state.ChangeDeploy,
+		Entity: "application-mysql",
+		Params: map[string]string{"charm": "cs:mysql-42"},
+	}}
+	since := when.Add(-time.Hour)
+	result, err := s.newAPI(c).ChangeLog(params.ChangeLogFilter{
+		Kind:   "deploy",
+		Entity: "application-mysql",
+		Actor:  "bob",
+		Since:  &since,
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ChangeLogResult{
+		Entries: []params.ChangeLogEntry{{
+			Seq:    7,
+			Time:   when,
+			Actor:  "bob",
+			Kind:   "deploy",
+			Entity: "application-mysql",
+			Params: map[string]string{"charm": "cs:mysql-42"},
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{{
+		FuncName: "ChangeLog",
+		Args: []interface{}{state.ChangeLogFilter{
+			Kind:   state.ChangeDeploy,
+			Entity: "application-mysql",
+			Actor:  "bob",
+			Since:  since,
+			Limit:  10,
+		}},
+	}})
+}
+
+func (s *changeLogSuite) TestChangeLogSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("superuser-bob")
+	_, err := s.newAPI(c).ChangeLog(params.ChangeLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ChangeLog")
+}
+
+func (s *changeLogSuite) TestChangeLogRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).ChangeLog(params.ChangeLogFilter{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *changeLogSuite) TestChangeLogNegativeLimit(c *gc.C) {
+	_, err := s.newAPI(c).ChangeLog(params.ChangeLogFilter{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit -1 not valid")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *changeLogSuite) TestChangeLogError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).ChangeLog(params.ChangeLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	testing.Stub
+	entries []state.ChangeLogEntry
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ChangeLog(filter state.ChangeLogFilter) ([]state.ChangeLogEntry, error) {
+	b.MethodCall(b, "ChangeLog", filter)
+	return b.entries, b.NextErr()
+}
@@ -94,6 +94,13 @@ func (a *API) CharmInfo(args params.CharmURL) (params.CharmInfo, error) {
 		Actions:  convertCharmActions(aCharm.Actions()),
 		Metrics:  convertCharmMetrics(aCharm.Metrics()),
 	}
+	if source, ok := aCharm.GitSource(); ok {
+		info.GitSource = &params.CharmGitSource{
+			Repository: source.Repository,
+			Ref:        source.Ref,
+			Revision:   source.Revision,
+		}
+	}
 	return info, nil
 }
 
@@ -32,6 +32,7 @@ type Backend interface {
 	AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error)
 	AllRemoteApplications() ([]*state.RemoteApplication, error)
 	AllMachines() ([]*state.Machine, error)
+	AllMachineIds() ([]string, error)
 	AllModelUUIDs() ([]string, error)
 	AllIPAddresses() ([]*state.Address, error)
 	AllLinkLayerDevices() ([]*state.LinkLayerDevice, error)
@@ -40,15 +41,18 @@ type Backend interface {
 	APIHostPorts() ([][]network.HostPort, error)
 	Application(string) (*state.Application, error)
 	ApplicationLeaders() (map[string]string, error)
+	ApplicationUnitCounts() (map[string]int, error)
 	Charm(*charm.URL) (*state.Charm, error)
 	ControllerTag() names.ControllerTag
 	EndpointsRelation(...state.Endpoint) (*state.Relation, error)
 	FindEntity(names.Tag) (state.Entity, error)
+	Filesystem(names.FilesystemTag) (status.StatusHistoryGetter, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	IsController() bool
 	LatestMigration() (state.ModelMigration, error)
 	LatestPlaceholderCharm(*charm.URL) (*state.Charm, error)
 	Machine(string) (*state.Machine, error)
+	MachinesById([]string) ([]*state.Machine, error)
 	Model() (*state.Model, error)
 	ModelConfig() (*config.Config, error)
 	ModelConfigValues() (config.ConfigValues, error)
@@ -64,6 +68,7 @@ type Backend interface {
 	Subnet(string) (*state.Subnet, error)
 	Unit(string) (Unit, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	Volume(names.VolumeTag) (status.StatusHistoryGetter, error)
 	Watch(params state.WatchParams) *state.Multiwatcher
 }
 
@@ -116,6 +121,22 @@ func (s *stateShim) Unit(name string) (Unit, error) {
 	return u, nil
 }
 
+func (s *stateShim) Volume(tag names.VolumeTag) (status.StatusHistoryGetter, error) {
+	im, err := s.model.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return im.Volume(tag)
+}
+
+func (s *stateShim) Filesystem(tag names.FilesystemTag) (status.StatusHistoryGetter, error) {
+	im, err := s.model.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return im.Filesystem(tag)
+}
+
 func (s *stateShim) Watch(params state.WatchParams) *state.Multiwatcher {
 	return s.State.Watch(params)
 }
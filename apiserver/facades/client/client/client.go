@@ -116,6 +116,79 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
+// ClientV1 serves the Client facade at version 1, which doesn't have
+// FullStatusPage.
+type ClientV1 struct {
+	*ClientV2
+}
+
+// ClientV2 serves the Client facade at version 2, which doesn't have
+// RetryProvisioningWithOptions.
+type ClientV2 struct {
+	*ClientV3
+}
+
+// ClientV3 serves the Client facade at version 3, which doesn't have
+// AddCharmFromGit.
+type ClientV3 struct {
+	*ClientV4
+}
+
+// ClientV4 serves the Client facade at version 4, whose StatusHistory
+// doesn't support volumes, filesystems, ToDate or Offset.
+type ClientV4 struct {
+	*Client
+}
+
+// NewFacadeV1 provides the signature required for facade registration
+// of version 1.
+func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
+	client, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV1{client}, nil
+}
+
+// NewFacadeV2 provides the signature required for facade registration
+// of version 2.
+func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
+	client, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV2{client}, nil
+}
+
+// NewFacadeV3 provides the signature required for facade registration
+// of version 3.
+func NewFacadeV3(ctx facade.Context) (*ClientV3, error) {
+	client, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV3{client}, nil
+}
+
+// NewFacadeV4 provides the signature required for facade registration
+// of version 4.
+func NewFacadeV4(ctx facade.Context) (*ClientV4, error) {
+	client, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV4{client}, nil
+}
+
+// AddCharmFromGit isn't on the V3 API.
+func (c *ClientV3) AddCharmFromGit(_, _ struct{}) {}
+
+// RetryProvisioningWithOptions isn't on the V2 API.
+func (c *ClientV2) RetryProvisioningWithOptions(_, _ struct{}) {}
+
+// FullStatusPage isn't on the V1 API.
+func (c *ClientV1) FullStatusPage(_, _ struct{}) {}
+
 // NewFacade provides the required signature for facade registration.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	st := ctx.State()
@@ -664,6 +737,17 @@ func (c *Client) AddCharmWithAuthorization(args params.AddCharmWithAuthorization
 	return application.AddCharmWithAuthorization(c.api.state(), args)
 }
 
+// AddCharmFromGit builds a charm from a ref of a git repository and
+// adds it to the model as a local charm, recording the repository and
+// commit it was built from.
+func (c *Client) AddCharmFromGit(args params.AddCharmFromGit) (params.AddCharmFromGitResult, error) {
+	if err := c.checkCanWrite(); err != nil {
+		return params.AddCharmFromGitResult{}, err
+	}
+
+	return application.AddCharmFromGit(c.api.state(), args)
+}
+
 // ResolveCharm resolves the best available charm URLs with series, for charm
 // locations without a series specified.
 func (c *Client) ResolveCharms(args params.ResolveCharms) (params.ResolveCharmResults, error) {
@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+// RetryProvisioningWithOptions marks the provisioning errors of the
+// given machines as transient, so that the provisioner retries them.
+// The retries may be asked to avoid the availability zone or instance
+// type with which the last attempt failed for lack of capacity.
+func (c *Client) RetryProvisioningWithOptions(args params.RetryProvisioningArgs) (params.ErrorResults, error) {
+	if err := c.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := c.retryProvisioning(entity.Tag, args.SwitchZone, args.SwitchInstanceType)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (c *Client) retryProvisioning(tagString string, switchZone, switchInstanceType bool) error {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return common.ErrPerm
+	}
+	machine, err := c.api.stateAccessor.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	statusInfo, err := machine.InstanceStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if statusInfo.Status != status.Error && statusInfo.Status != status.ProvisioningError {
+		return errors.Errorf("%s is not in an error state", names.ReadableString(tag))
+	}
+	data := make(map[string]interface{}, len(statusInfo.Data)+3)
+	for key, value := range statusInfo.Data {
+		data[key] = value
+	}
+	data["transient"] = true
+	data["switch-zone"] = switchZone
+	data["switch-instance-type"] = switchInstanceType
+	now := time.Now()
+	return machine.SetInstanceStatus(status.StatusInfo{
+		Status:  statusInfo.Status,
+		Message: statusInfo.Message,
+		Data:    data,
+		Since:   &now,
+	})
+}
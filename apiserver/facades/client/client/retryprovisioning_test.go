@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type retryProvisioningSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&retryProvisioningSuite{})
+
+func (s *retryProvisioningSuite) addFailedMachine(c *gc.C) *state.Machine {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = machine.SetInstanceStatus(status.StatusInfo{
+		Status:  status.ProvisioningError,
+		Message: "no valid host",
+		Data: map[string]interface{}{
+			"error-class":       "az-capacity",
+			"availability-zone": "zone-a",
+		},
+		Since: &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return machine
+}
+
+func (s *retryProvisioningSuite) TestRetryProvisioningWithOptions(c *gc.C) {
+	machine := s.addFailedMachine(c)
+	results, err := s.APIState.Client().RetryProvisioningWithOptions(
+		true, false, machine.Tag().(names.MachineTag),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.IsNil)
+
+	statusInfo, err := machine.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.ProvisioningError)
+	c.Assert(statusInfo.Message, gc.Equals, "no valid host")
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+		"error-class":          "az-capacity",
+		"availability-zone":    "zone-a",
+		"transient":            true,
+		"switch-zone":          true,
+		"switch-instance-type": false,
+	})
+}
+
+func (s *retryProvisioningSuite) TestRetryProvisioningWithOptionsNotInError(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.APIState.Client().RetryProvisioningWithOptions(
+		true, true, machine.Tag().(names.MachineTag),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `machine [0-9]+ is not in an error state`)
+}
+
+func (s *retryProvisioningSuite) TestRetryProvisioningWithOptionsBlocked(c *gc.C) {
+	machine := s.addFailedMachine(c)
+	s.BlockAllChanges(c, "TestRetryProvisioningWithOptionsBlocked")
+	_, err := s.APIState.Client().RetryProvisioningWithOptions(
+		true, true, machine.Tag().(names.MachineTag),
+	)
+	s.AssertBlocked(c, err, "TestRetryProvisioningWithOptionsBlocked")
+}
@@ -100,6 +100,34 @@ func (c *Client) machineStatusHistory(machineTag names.MachineTag, filter status
 	return agentStatusFromStatusInfo(sInfo, kind), nil
 }
 
+// volumeStatusHistory returns status history for the given volume.
+func (c *Client) volumeStatusHistory(volumeTag names.VolumeTag, filter status.StatusHistoryFilter) ([]params.DetailedStatus, error) {
+	volume, err := c.api.stateAccessor.Volume(volumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entityStatusHistory(volume, filter, status.KindVolume)
+}
+
+// filesystemStatusHistory returns status history for the given filesystem.
+func (c *Client) filesystemStatusHistory(filesystemTag names.FilesystemTag, filter status.StatusHistoryFilter) ([]params.DetailedStatus, error) {
+	filesystem, err := c.api.stateAccessor.Filesystem(filesystemTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entityStatusHistory(filesystem, filter, status.KindFilesystem)
+}
+
+// entityStatusHistory returns the status history of an entity that
+// only has one kind of history.
+func entityStatusHistory(entity status.StatusHistoryGetter, filter status.StatusHistoryFilter, kind status.HistoryKind) ([]params.DetailedStatus, error) {
+	sInfo, err := entity.StatusHistory(filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return agentStatusFromStatusInfo(sInfo, kind), nil
+}
+
 // StatusHistory returns a slice of past statuses for several entities.
 func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.StatusHistoryResults {
 
@@ -112,6 +140,8 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 			FromDate: request.Filter.Date,
 			Delta:    request.Filter.Delta,
 			Exclude:  set.NewStrings(request.Filter.Exclude...),
+			ToDate:   request.Filter.ToDate,
+			Offset:   request.Filter.Offset,
 		}
 		if err := c.checkCanRead(); err != nil {
 			history := params.StatusHistoryResult{
@@ -142,6 +172,16 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
 			}
+		case status.KindVolume:
+			var v names.VolumeTag
+			if v, err = names.ParseVolumeTag(request.Tag); err == nil {
+				hist, err = c.volumeStatusHistory(v, filter)
+			}
+		case status.KindFilesystem:
+			var f names.FilesystemTag
+			if f, err = names.ParseFilesystemTag(request.Tag); err == nil {
+				hist, err = c.filesystemStatusHistory(f, filter)
+			}
 		default:
 			var m names.MachineTag
 			if m, err = names.ParseMachineTag(request.Tag); err == nil {
@@ -162,6 +202,42 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// StatusHistory returns a slice of past statuses for several entities.
+// Version 4 of the facade does not support the history of volumes and
+// filesystems, or the ToDate and Offset filters.
+func (c *ClientV4) StatusHistory(args params.StatusHistoryRequests) params.StatusHistoryResults {
+	results := params.StatusHistoryResults{
+		Results: make([]params.StatusHistoryResult, len(args.Requests)),
+	}
+	for i, request := range args.Requests {
+		if err := checkStatusHistoryRequestV4(request); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := c.Client.StatusHistory(params.StatusHistoryRequests{
+			Requests: []params.StatusHistoryRequest{request},
+		})
+		results.Results[i] = result.Results[0]
+	}
+	return results
+}
+
+// checkStatusHistoryRequestV4 returns an error if the request uses
+// features added in version 5 of the facade.
+func checkStatusHistoryRequestV4(request params.StatusHistoryRequest) error {
+	switch kind := status.HistoryKind(request.Kind); kind {
+	case status.KindVolume, status.KindFilesystem:
+		return errors.NotSupportedf("%s status history", kind)
+	}
+	if request.Filter.ToDate != nil {
+		return errors.NotSupportedf("status history end date")
+	}
+	if request.Filter.Offset != 0 {
+		return errors.NotSupportedf("status history offset")
+	}
+	return nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -169,52 +245,76 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}
 
 	var noStatus params.FullStatus
-	var context statusContext
+	context, err := c.newStatusContext(args.Patterns)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
+	modelStatus, err := c.modelStatus()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "cannot determine model status")
+	}
+	return params.FullStatus{
+		Model:              modelStatus,
+		Machines:           context.processMachines(),
+		Applications:       context.processApplications(),
+		RemoteApplications: context.processRemoteApplications(),
+		Offers:             context.processOffers(),
+		Relations:          context.processRelations(),
+	}, nil
+}
+
+// newStatusContext fetches the entities of the model that are needed
+// for status, filtered by the given patterns.
+func (c *Client) newStatusContext(patterns []string) (*statusContext, error) {
+	context := &statusContext{}
 	var err error
 	if context.model, err = c.api.stateAccessor.Model(); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch model")
+		return nil, errors.Annotate(err, "could not fetch model")
 	}
 	if context.status, err = context.model.LoadModelStatus(); err != nil {
-		return noStatus, errors.Annotate(err, "could not load model status values")
+		return nil, errors.Annotate(err, "could not load model status values")
 	}
 	if context.applications, context.units, context.latestCharms, err =
-		fetchAllApplicationsAndUnits(c.api.stateAccessor, context.model, len(args.Patterns) <= 0); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch applications and units")
+		fetchAllApplicationsAndUnits(c.api.stateAccessor, context.model, len(patterns) <= 0); err != nil {
+		return nil, errors.Annotate(err, "could not fetch applications and units")
 	}
 	if context.consumerRemoteApplications, err =
 		fetchConsumerRemoteApplications(c.api.stateAccessor); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch remote applications")
+		return nil, errors.Annotate(err, "could not fetch remote applications")
 	}
 	// Only admins can see offer details.
 	if err := c.checkIsAdmin(); err == nil {
 		if context.offers, err =
 			fetchOffers(c.api.stateAccessor, context.applications); err != nil {
-			return noStatus, errors.Annotate(err, "could not fetch application offers")
+			return nil, errors.Annotate(err, "could not fetch application offers")
 		}
 	}
 	if context.machines, err = fetchMachines(c.api.stateAccessor, nil); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch machines")
+		return nil, errors.Annotate(err, "could not fetch machines")
 	}
 	// These may be empty when machines have not finished deployment.
 	if context.ipAddresses, context.spaces, context.linkLayerDevices, err =
 		fetchNetworkInterfaces(c.api.stateAccessor); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch IP addresses and link layer devices")
+		return nil, errors.Annotate(err, "could not fetch IP addresses and link layer devices")
 	}
 	if context.relations, context.relationsById, err = fetchRelations(c.api.stateAccessor); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch relations")
+		return nil, errors.Annotate(err, "could not fetch relations")
 	}
 	if len(context.applications) > 0 {
 		if context.leaders, err = c.api.stateAccessor.ApplicationLeaders(); err != nil {
-			return noStatus, errors.Annotate(err, " could not fetch leaders")
+			return nil, errors.Annotate(err, " could not fetch leaders")
 		}
 	}
+	if context.storageWarnings, err = fetchStorageUsageWarnings(context.model); err != nil {
+		return nil, errors.Annotate(err, "could not fetch storage usage")
+	}
 
 	logger.Debugf("Applications: %v", context.applications)
 	logger.Debugf("Remote applications: %v", context.consumerRemoteApplications)
 	logger.Debugf("Offers: %v", context.offers)
 
-	if len(args.Patterns) > 0 {
-		predicate := BuildPredicateFor(args.Patterns)
+	if len(patterns) > 0 {
+		predicate := BuildPredicateFor(patterns)
 
 		// First, attempt to match machines. Any units on those
 		// machines are implicitly matched.
@@ -223,7 +323,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			for _, m := range machineList {
 				matches, err := predicate(m)
 				if err != nil {
-					return noStatus, errors.Annotate(
+					return nil, errors.Annotate(
 						err, "could not filter machines",
 					)
 				}
@@ -253,7 +353,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 				if !unit.IsPrincipal() {
 					continue
 				} else if matches, err := unitChainPredicate(unit); err != nil {
-					return noStatus, errors.Annotate(err, "could not filter units")
+					return nil, errors.Annotate(err, "could not filter units")
 				} else if !matches {
 					delete(unitMap, name)
 					continue
@@ -271,7 +371,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 				// There are matched units for this application.
 				continue
 			} else if matches, err := predicate(app); err != nil {
-				return noStatus, errors.Annotate(err, "could not filter applications")
+				return nil, errors.Annotate(err, "could not filter applications")
 			} else if !matches {
 				delete(context.applications, appName)
 			}
@@ -285,7 +385,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			for _, m := range machineList {
 				machineContainers, err := m.Containers()
 				if err != nil {
-					return noStatus, err
+					return nil, err
 				}
 				machineContainersSet := set.NewStrings(machineContainers...)
 
@@ -301,18 +401,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		}
 	}
 
-	modelStatus, err := c.modelStatus()
-	if err != nil {
-		return noStatus, errors.Annotate(err, "cannot determine model status")
-	}
-	return params.FullStatus{
-		Model:              modelStatus,
-		Machines:           context.processMachines(),
-		Applications:       context.processApplications(),
-		RemoteApplications: context.processRemoteApplications(),
-		Offers:             context.processOffers(),
-		Relations:          context.processRelations(),
-	}, nil
+	return context, nil
 }
 
 // newToolsVersionAvailable will return a string representing a tools
@@ -348,6 +437,7 @@ func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
 	}
 
 	info.SLA = m.SLALevel()
+	info.Description = m.Description()
 
 	info.ModelStatus = params.DetailedStatus{
 		Status: status.Status.String(),
@@ -393,6 +483,10 @@ type statusContext struct {
 	units         map[string]map[string]*state.Unit
 	latestCharms  map[charm.URL]*state.Charm
 	leaders       map[string]string
+
+	// storageWarnings: unit name -> warnings about nearly full
+	// filesystems attached to the unit's storage.
+	storageWarnings map[string][]string
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -400,12 +494,17 @@ type statusContext struct {
 //
 // If machineIds is non-nil, only machines whose IDs are in the set are returned.
 func fetchMachines(st Backend, machineIds set.Strings) (map[string][]*state.Machine, error) {
-	v := make(map[string][]*state.Machine)
 	machines, err := st.AllMachines()
 	if err != nil {
 		return nil, err
 	}
-	// AllMachines gives us machines sorted by id.
+	return groupMachines(machines, machineIds), nil
+}
+
+// groupMachines returns a map from top level machine id to machines,
+// as described for fetchMachines. The machines must be sorted by id.
+func groupMachines(machines []*state.Machine, machineIds set.Strings) map[string][]*state.Machine {
+	v := make(map[string][]*state.Machine)
 	for _, m := range machines {
 		if machineIds != nil && !machineIds.Contains(m.Id()) {
 			continue
@@ -424,7 +523,7 @@ func fetchMachines(st Backend, machineIds set.Strings) (map[string][]*state.Mach
 			v[topParentId] = machines
 		}
 	}
-	return v, nil
+	return v
 }
 
 // fetchNetworkInterfaces returns maps from machine id to ip.addresses, machine
@@ -434,12 +533,26 @@ func fetchMachines(st Backend, machineIds set.Strings) (map[string][]*state.Mach
 // All are required to determine a machine's network interfaces configuration,
 // so we want all or none.
 func fetchNetworkInterfaces(st Backend) (map[string][]*state.Address, map[string]map[string]set.Strings, map[string][]*state.LinkLayerDevice, error) {
-	ipAddresses := make(map[string][]*state.Address)
-	spaces := make(map[string]map[string]set.Strings)
 	ipAddrs, err := st.AllIPAddresses()
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	llDevs, err := st.AllLinkLayerDevices()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return groupNetworkInterfaces(st, ipAddrs, llDevs)
+}
+
+// groupNetworkInterfaces returns the maps described for
+// fetchNetworkInterfaces, for the given addresses and devices.
+func groupNetworkInterfaces(
+	st Backend,
+	ipAddrs []*state.Address,
+	llDevs []*state.LinkLayerDevice,
+) (map[string][]*state.Address, map[string]map[string]set.Strings, map[string][]*state.LinkLayerDevice, error) {
+	ipAddresses := make(map[string][]*state.Address)
+	spaces := make(map[string]map[string]set.Strings)
 	for _, ipAddr := range ipAddrs {
 		if ipAddr.LoopbackConfigMethod() {
 			continue
@@ -470,10 +583,6 @@ func fetchNetworkInterfaces(st Backend) (map[string][]*state.Address, map[string
 	}
 
 	linkLayerDevices := make(map[string][]*state.LinkLayerDevice)
-	llDevs, err := st.AllLinkLayerDevices()
-	if err != nil {
-		return nil, nil, nil, err
-	}
 	for _, llDev := range llDevs {
 		if llDev.IsLoopbackDevice() {
 			continue
@@ -537,18 +646,26 @@ func fetchAllApplicationsAndUnits(
 			}
 		}
 	}
+	if err := fetchLatestCharms(st, latestCharms); err != nil {
+		return nil, nil, nil, err
+	}
+	return appMap, unitMap, latestCharms, nil
+}
+
+// fetchLatestCharms records the latest placeholder charm, if any, for
+// each of the base charm URLs in latestCharms.
+func fetchLatestCharms(st Backend, latestCharms map[charm.URL]*state.Charm) error {
 	for baseURL := range latestCharms {
 		ch, err := st.LatestPlaceholderCharm(&baseURL)
 		if errors.IsNotFound(err) {
 			continue
 		}
 		if err != nil {
-			return nil, nil, nil, err
+			return err
 		}
 		latestCharms[baseURL] = ch
 	}
-
-	return appMap, unitMap, latestCharms, nil
+	return nil
 }
 
 // fetchConsumerRemoteApplications returns a map from application name to remote application.
@@ -614,6 +731,12 @@ func fetchRelations(st Backend) (map[string][]*state.Relation, map[int]*state.Re
 	if err != nil {
 		return nil, nil, err
 	}
+	return groupRelations(st, relations)
+}
+
+// groupRelations returns the maps described for fetchRelations, for
+// the given relations.
+func groupRelations(st Backend, relations []*state.Relation) (map[string][]*state.Relation, map[int]*state.Relation, error) {
 	out := make(map[string][]*state.Relation)
 	outById := make(map[int]*state.Relation)
 	for _, relation := range relations {
@@ -641,6 +764,67 @@ func fetchRelations(st Backend) (map[string][]*state.Relation, map[int]*state.Re
 	return out, outById, nil
 }
 
+// fetchStorageUsageWarnings returns warnings, keyed by unit name, about
+// the unit-owned filesystems whose last reported usage is at or above
+// the model's storage-usage-warning-threshold. There are no warnings
+// if the threshold is not set.
+func fetchStorageUsageWarnings(model *state.Model) (map[string][]string, error) {
+	cfg, err := model.Config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	threshold := cfg.StorageUsageWarningThreshold()
+	if threshold <= 0 {
+		return nil, nil
+	}
+	im, err := model.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	filesystems, err := im.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return storageUsageWarnings(im, threshold, filesystems)
+}
+
+// storageUsageWarnings returns the warnings described for
+// fetchStorageUsageWarnings, for the given filesystems.
+func storageUsageWarnings(im *state.IAASModel, threshold int, filesystems []state.Filesystem) (map[string][]string, error) {
+	warnings := make(map[string][]string)
+	for _, f := range filesystems {
+		storageTag, err := f.Storage()
+		if err != nil {
+			// The filesystem is not assigned to storage.
+			continue
+		}
+		attachments, err := im.FilesystemAttachments(f.FilesystemTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, attachment := range attachments {
+			usage, ok := attachment.Usage()
+			if !ok || usage.PercentUsed() < threshold {
+				continue
+			}
+			storage, err := im.StorageInstance(storageTag)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			owner, ok := storage.Owner()
+			if !ok || owner.Kind() != names.UnitTagKind {
+				continue
+			}
+			warnings[owner.Id()] = append(warnings[owner.Id()], fmt.Sprintf(
+				"storage %s is %d%% full", storageTag.Id(), usage.PercentUsed(),
+			))
+		}
+	}
+	return warnings, nil
+}
+
 func (c *statusContext) processMachines() map[string]params.MachineStatus {
 	machinesMap := make(map[string]params.MachineStatus)
 	cache := make(map[string]params.MachineStatus)
@@ -870,10 +1054,11 @@ func (context *statusContext) processApplication(application *state.Application)
 	}
 
 	var processedStatus = params.ApplicationStatus{
-		Charm:   applicationCharm.URL().String(),
-		Series:  application.Series(),
-		Exposed: application.IsExposed(),
-		Life:    processLife(application),
+		Charm:       applicationCharm.URL().String(),
+		Series:      application.Series(),
+		Exposed:     application.IsExposed(),
+		Life:        processLife(application),
+		Description: application.Description(),
 	}
 
 	if latestCharm, ok := context.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
@@ -1039,6 +1224,12 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	for _, port := range unitPorts {
 		result.OpenedPorts = append(result.OpenedPorts, port.String())
 	}
+	if len(unitPorts) > 0 {
+		result.PortConflicts, err = unit.PortConflicts()
+		if err != nil {
+			logger.Debugf("error fetching port conflicts of %q: %v", unit.Name(), err)
+		}
+	}
 	if unit.IsPrincipal() {
 		result.Machine, _ = unit.AssignedMachineId()
 	}
@@ -1054,6 +1245,15 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	}
 
 	result.AgentStatus, result.WorkloadStatus = context.processUnitAndAgentStatus(unit)
+	if warnings := context.storageWarnings[unit.Name()]; len(warnings) > 0 {
+		// The warnings are appended to the message the charm set,
+		// rather than replacing its status.
+		messages := warnings
+		if result.WorkloadStatus.Info != "" {
+			messages = append([]string{result.WorkloadStatus.Info}, warnings...)
+		}
+		result.WorkloadStatus.Info = strings.Join(messages, "; ")
+	}
 
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
 		result.Subordinates = make(map[string]params.UnitStatus)
@@ -0,0 +1,401 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// FullStatusPage returns a page of the information needed for juju
+// status, so that the status of large models can be fetched over
+// several calls. Pages hold top level machines, with their containers,
+// ordered by id, followed by applications, with their units, ordered
+// by name. The model, remote applications, offers and relations are
+// only returned in the first page.
+//
+// Only the entities in the requested page are read, unless patterns
+// are given: an entity may then be matched because of another entity
+// outside the page, such as a machine hosting a matched unit, so the
+// whole model is read and filtered before the page is selected.
+func (c *Client) FullStatusPage(args params.StatusPageParams) (params.FullStatusPage, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.FullStatusPage{}, err
+	}
+	if args.Limit <= 0 {
+		return params.FullStatusPage{}, errors.NotValidf("page limit %d", args.Limit)
+	}
+	var after names.Tag
+	if args.Cursor != "" {
+		tag, err := names.ParseTag(args.Cursor)
+		if err != nil {
+			return params.FullStatusPage{}, errors.NotValidf("cursor %q", args.Cursor)
+		}
+		switch tag.(type) {
+		case names.MachineTag, names.ApplicationTag:
+		default:
+			return params.FullStatusPage{}, errors.NotValidf("cursor %q", args.Cursor)
+		}
+		after = tag
+	}
+
+	var context *statusContext
+	var next names.Tag
+	var err error
+	if len(args.Patterns) > 0 {
+		if context, err = c.newStatusContext(args.Patterns); err != nil {
+			return params.FullStatusPage{}, errors.Trace(err)
+		}
+		next = context.selectPage(after, args.Limit)
+	} else {
+		if context, next, err = c.newStatusPageContext(after, args.Limit); err != nil {
+			return params.FullStatusPage{}, errors.Trace(err)
+		}
+	}
+	status := params.FullStatus{
+		Machines:     context.processMachines(),
+		Applications: context.processApplications(),
+	}
+	if after == nil {
+		if status.Model, err = c.modelStatus(); err != nil {
+			return params.FullStatusPage{}, errors.Annotate(err, "cannot determine model status")
+		}
+		modelContext, err := c.newModelStatusContext()
+		if err != nil {
+			return params.FullStatusPage{}, errors.Trace(err)
+		}
+		status.RemoteApplications = modelContext.processRemoteApplications()
+		status.Offers = modelContext.processOffers()
+		status.Relations = modelContext.processRelations()
+	}
+	result := params.FullStatusPage{Status: status}
+	if next != nil {
+		result.NextCursor = next.String()
+	}
+	return result, nil
+}
+
+// newStatusPageContext fetches the entities of the model that are in
+// the page following the entity identified by after, as selected by
+// selectPageEntries. Only the ids of the model's machines and the unit
+// counts of its applications are read to select the page. It returns
+// the context and the tag of the page's last entry, or nil if there are
+// no more entries after it.
+func (c *Client) newStatusPageContext(after names.Tag, limit int) (*statusContext, names.Tag, error) {
+	st := c.api.stateAccessor
+	machineIds, err := st.AllMachineIds()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch machine ids")
+	}
+	unitCounts, err := st.ApplicationUnitCounts()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch application unit counts")
+	}
+	hosted := make(map[string][]string)
+	for _, id := range machineIds {
+		topParentId := state.TopParentId(id)
+		hosted[topParentId] = append(hosted[topParentId], id)
+	}
+	var entries []statusPageEntry
+	for id, ids := range hosted {
+		entries = append(entries, statusPageEntry{names.NewMachineTag(id), len(ids)})
+	}
+	for name, count := range unitCounts {
+		entries = append(entries, statusPageEntry{names.NewApplicationTag(name), 1 + count})
+	}
+	page, next := selectPageEntries(entries, after, limit)
+
+	var pageMachineIds, pageApplicationNames []string
+	for _, entry := range page {
+		switch tag := entry.tag.(type) {
+		case names.MachineTag:
+			pageMachineIds = append(pageMachineIds, hosted[tag.Id()]...)
+		case names.ApplicationTag:
+			pageApplicationNames = append(pageApplicationNames, tag.Id())
+		}
+	}
+
+	context := &statusContext{}
+	if context.model, err = st.Model(); err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch model")
+	}
+	machines, err := st.MachinesById(pageMachineIds)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch machines")
+	}
+	context.machines = groupMachines(machines, nil)
+	if context.applications, context.units, context.latestCharms, err =
+		fetchApplicationsAndUnits(st, pageApplicationNames); err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch applications and units")
+	}
+	var unitNames []string
+	var unitTags []names.UnitTag
+	for _, units := range context.units {
+		for name, unit := range units {
+			unitNames = append(unitNames, name)
+			unitTags = append(unitTags, unit.UnitTag())
+		}
+	}
+	if context.status, err = context.model.LoadEntitiesStatus(
+		pageMachineIds, pageApplicationNames, unitNames,
+	); err != nil {
+		return nil, nil, errors.Annotate(err, "could not load status values")
+	}
+	// These may be empty when machines have not finished deployment.
+	if context.ipAddresses, context.spaces, context.linkLayerDevices, err =
+		fetchMachineNetworkInterfaces(st, machines); err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch IP addresses and link layer devices")
+	}
+	if context.relations, context.relationsById, err =
+		fetchApplicationRelations(st, context.applications); err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch relations")
+	}
+	if len(context.applications) > 0 {
+		if context.leaders, err = st.ApplicationLeaders(); err != nil {
+			return nil, nil, errors.Annotate(err, "could not fetch leaders")
+		}
+	}
+	if context.storageWarnings, err = fetchUnitStorageUsageWarnings(context.model, unitTags); err != nil {
+		return nil, nil, errors.Annotate(err, "could not fetch storage usage")
+	}
+	return context, next, nil
+}
+
+// newModelStatusContext fetches the entities of the model that are
+// needed for the remote applications, offers and relations in the first
+// page of status. The applications are read without their units.
+func (c *Client) newModelStatusContext() (*statusContext, error) {
+	st := c.api.stateAccessor
+	context := &statusContext{
+		applications: make(map[string]*state.Application),
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Annotate(err, "could not fetch applications")
+	}
+	for _, app := range applications {
+		context.applications[app.Name()] = app
+	}
+	if context.consumerRemoteApplications, err = fetchConsumerRemoteApplications(st); err != nil {
+		return nil, errors.Annotate(err, "could not fetch remote applications")
+	}
+	// Only admins can see offer details.
+	if err := c.checkIsAdmin(); err == nil {
+		if context.offers, err = fetchOffers(st, context.applications); err != nil {
+			return nil, errors.Annotate(err, "could not fetch application offers")
+		}
+	}
+	if context.relations, context.relationsById, err = fetchRelations(st); err != nil {
+		return nil, errors.Annotate(err, "could not fetch relations")
+	}
+	return context, nil
+}
+
+// fetchApplicationsAndUnits returns the maps described for
+// fetchAllApplicationsAndUnits, for the named applications.
+func fetchApplicationsAndUnits(
+	st Backend,
+	applicationNames []string,
+) (map[string]*state.Application, map[string]map[string]*state.Unit, map[charm.URL]*state.Charm, error) {
+	appMap := make(map[string]*state.Application)
+	unitMap := make(map[string]map[string]*state.Unit)
+	latestCharms := make(map[charm.URL]*state.Charm)
+	for _, name := range applicationNames {
+		app, err := st.Application(name)
+		if errors.IsNotFound(err) {
+			// The application was removed after the page was selected.
+			continue
+		} else if err != nil {
+			return nil, nil, nil, err
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		appMap[name] = app
+		unitMap[name] = make(map[string]*state.Unit)
+		for _, unit := range units {
+			unitMap[name][unit.Name()] = unit
+		}
+		charmURL, _ := app.CharmURL()
+		if charmURL.Schema == "cs" {
+			latestCharms[*charmURL.WithRevision(-1)] = nil
+		}
+	}
+	if err := fetchLatestCharms(st, latestCharms); err != nil {
+		return nil, nil, nil, err
+	}
+	return appMap, unitMap, latestCharms, nil
+}
+
+// fetchMachineNetworkInterfaces returns the maps described for
+// fetchNetworkInterfaces, for the given machines.
+func fetchMachineNetworkInterfaces(st Backend, machines []*state.Machine) (map[string][]*state.Address, map[string]map[string]set.Strings, map[string][]*state.LinkLayerDevice, error) {
+	var ipAddrs []*state.Address
+	var llDevs []*state.LinkLayerDevice
+	for _, m := range machines {
+		addrs, err := m.AllAddresses()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		devs, err := m.AllLinkLayerDevices()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ipAddrs = append(ipAddrs, addrs...)
+		llDevs = append(llDevs, devs...)
+	}
+	return groupNetworkInterfaces(st, ipAddrs, llDevs)
+}
+
+// fetchApplicationRelations returns the maps described for
+// fetchRelations, for the relations of the given applications.
+func fetchApplicationRelations(st Backend, applications map[string]*state.Application) (map[string][]*state.Relation, map[int]*state.Relation, error) {
+	var relations []*state.Relation
+	seen := set.NewInts()
+	for _, app := range applications {
+		appRelations, err := app.Relations()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, relation := range appRelations {
+			if !seen.Contains(relation.Id()) {
+				seen.Add(relation.Id())
+				relations = append(relations, relation)
+			}
+		}
+	}
+	return groupRelations(st, relations)
+}
+
+// fetchUnitStorageUsageWarnings returns the warnings described for
+// fetchStorageUsageWarnings, for the storage of the given units.
+func fetchUnitStorageUsageWarnings(model *state.Model, units []names.UnitTag) (map[string][]string, error) {
+	cfg, err := model.Config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	threshold := cfg.StorageUsageWarningThreshold()
+	if threshold <= 0 || len(units) == 0 {
+		return nil, nil
+	}
+	im, err := model.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var filesystems []state.Filesystem
+	for _, unit := range units {
+		attachments, err := im.UnitStorageAttachments(unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, attachment := range attachments {
+			f, err := im.StorageInstanceFilesystem(attachment.StorageInstance())
+			if errors.IsNotFound(err) {
+				// The storage is not a filesystem.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			filesystems = append(filesystems, f)
+		}
+	}
+	return storageUsageWarnings(im, threshold, filesystems)
+}
+
+// statusPageEntry is a top level machine or application in a page of
+// status, with the number of entities it includes.
+type statusPageEntry struct {
+	tag  names.Tag
+	size int
+}
+
+// selectPage restricts the context's machines and applications to the
+// page following the entity identified by after, as selected by
+// selectPageEntries, and returns the tag of the page's last entry, or
+// nil if there are no more entries after it.
+func (c *statusContext) selectPage(after names.Tag, limit int) names.Tag {
+	var entries []statusPageEntry
+	for id, machines := range c.machines {
+		if len(machines) > 0 {
+			entries = append(entries, statusPageEntry{names.NewMachineTag(id), len(machines)})
+		}
+	}
+	for name := range c.applications {
+		entries = append(entries, statusPageEntry{names.NewApplicationTag(name), 1 + len(c.units[name])})
+	}
+	page, next := selectPageEntries(entries, after, limit)
+
+	machines := make(map[string][]*state.Machine)
+	applications := make(map[string]*state.Application)
+	for _, entry := range page {
+		switch tag := entry.tag.(type) {
+		case names.MachineTag:
+			machines[tag.Id()] = c.machines[tag.Id()]
+		case names.ApplicationTag:
+			applications[tag.Id()] = c.applications[tag.Id()]
+		}
+	}
+	c.machines = machines
+	c.applications = applications
+	return next
+}
+
+// selectPageEntries returns the entries in the page following the
+// entity identified by after, holding no more than limit entities
+// unless the first entry alone exceeds it, and the tag of the page's
+// last entry, or nil if there are no more entries after it.
+func selectPageEntries(entries []statusPageEntry, after names.Tag, limit int) ([]statusPageEntry, names.Tag) {
+	sort.Slice(entries, func(i, j int) bool {
+		return statusEntryBefore(entries[i].tag, entries[j].tag)
+	})
+
+	// The cursor need not match an entry, as the entity it identifies
+	// may have been removed since the previous page was fetched.
+	first := 0
+	if after != nil {
+		first = sort.Search(len(entries), func(i int) bool {
+			return statusEntryBefore(after, entries[i].tag)
+		})
+	}
+	last, total := first, 0
+	for ; last < len(entries); last++ {
+		total += entries[last].size
+		if total > limit && last > first {
+			break
+		}
+	}
+	if last == len(entries) || last == first {
+		return entries[first:last], nil
+	}
+	return entries[first:last], entries[last-1].tag
+}
+
+// statusEntryBefore reports whether the entry with tag a comes before
+// the entry with tag b in pages of status. Machines come before
+// applications, and are ordered numerically by id.
+func statusEntryBefore(a, b names.Tag) bool {
+	_, aIsMachine := a.(names.MachineTag)
+	_, bIsMachine := b.(names.MachineTag)
+	if aIsMachine != bIsMachine {
+		return aIsMachine
+	}
+	if aIsMachine {
+		// Top level machine ids are always numeric.
+		aId, aErr := strconv.Atoi(a.Id())
+		bId, bErr := strconv.Atoi(b.Id())
+		if aErr == nil && bErr == nil {
+			return aId < bId
+		}
+	}
+	return a.Id() < b.Id()
+}
@@ -18,9 +18,11 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -73,6 +75,142 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusStorageUsageWarning(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		config.StorageUsageWarningThreshold: 80,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "storage-filesystem"}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {Count: 1, Size: 1024, Pool: "rootfs"},
+		},
+	})
+	u := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err = u.SetStatus(status.StatusInfo{Status: status.Active, Message: "ready"})
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	f, err := s.IAASModel.StorageInstanceFilesystem(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.SetFilesystemAttachmentUsage(names.NewMachineTag(machineId), f.FilesystemTag(), state.FilesystemUsage{
+		Size:      1024,
+		Used:      900,
+		Available: 124,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	fullStatus, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unit := fullStatus.Applications[app.Name()].Units[u.Name()]
+	c.Assert(unit.WorkloadStatus.Status, gc.Equals, "active")
+	c.Assert(unit.WorkloadStatus.Info, gc.Equals, "ready; storage data/0 is 88% full")
+}
+
+func (s *statusSuite) TestFullStatusPage(c *gc.C) {
+	m0 := s.addMachine(c)
+	m1 := s.addMachine(c)
+	container := s.Factory.MakeMachineNested(c, m1.Id(), nil)
+	app := s.Factory.MakeApplication(c, nil)
+	client := s.APIState.Client()
+
+	page, err := client.StatusPage(nil, "", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.Status.Model.Name, gc.Equals, "controller")
+	c.Check(page.Status.Machines, gc.HasLen, 1)
+	c.Check(page.Status.Machines[m0.Id()].Id, gc.Equals, m0.Id())
+	c.Check(page.Status.Applications, gc.HasLen, 0)
+	c.Check(page.NextCursor, gc.Equals, m0.Tag().String())
+
+	page, err = client.StatusPage(nil, page.NextCursor, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.Status.Model.Name, gc.Equals, "")
+	c.Check(page.Status.Machines, gc.HasLen, 1)
+	c.Check(page.Status.Machines[m1.Id()].Containers, gc.HasLen, 1)
+	_, ok := page.Status.Machines[m1.Id()].Containers[container.Id()]
+	c.Check(ok, jc.IsTrue)
+	c.Check(page.Status.Applications, gc.HasLen, 0)
+	c.Check(page.NextCursor, gc.Equals, m1.Tag().String())
+
+	page, err = client.StatusPage(nil, page.NextCursor, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.Status.Machines, gc.HasLen, 0)
+	c.Check(page.Status.Applications, gc.HasLen, 1)
+	_, ok = page.Status.Applications[app.Name()]
+	c.Check(ok, jc.IsTrue)
+	c.Check(page.NextCursor, gc.Equals, "")
+}
+
+func (s *statusSuite) TestFullStatusPageRemovedCursor(c *gc.C) {
+	s.addMachine(c)
+	m1 := s.addMachine(c)
+	m2 := s.addMachine(c)
+	err := m1.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m1.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+
+	// The next page follows the cursor's machine, even once it is removed.
+	page, err := client.StatusPage(nil, m1.Tag().String(), 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.Status.Machines, gc.HasLen, 1)
+	_, ok := page.Status.Machines[m2.Id()]
+	c.Check(ok, jc.IsTrue)
+	c.Check(page.NextCursor, gc.Equals, "")
+}
+
+func (s *statusSuite) TestFullStatusPageMatchesFullStatus(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	s.addMachine(c)
+	client := s.APIState.Client()
+
+	// A page holding the whole model, read without the rest of the
+	// model, must match the full status.
+	full, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	page, err := client.StatusPage(nil, "", 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.NextCursor, gc.Equals, "")
+	c.Check(page.Status.Machines, jc.DeepEquals, full.Machines)
+	c.Check(page.Status.Applications, jc.DeepEquals, full.Applications)
+	c.Check(page.Status.Relations, jc.DeepEquals, full.Relations)
+	_, ok := page.Status.Applications[unit.ApplicationName()].Units[unit.Name()]
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *statusSuite) TestFullStatusPageWithPatterns(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	s.addMachine(c)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+
+	// The unit's machine is on the first page, and its application on
+	// the second.
+	page, err := client.StatusPage([]string{unit.Name()}, "", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.Status.Machines, gc.HasLen, 1)
+	_, ok := page.Status.Machines[machineId]
+	c.Check(ok, jc.IsTrue)
+	c.Check(page.Status.Applications, gc.HasLen, 0)
+
+	page, err = client.StatusPage([]string{unit.Name()}, page.NextCursor, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.Status.Machines, gc.HasLen, 0)
+	c.Check(page.Status.Applications, gc.HasLen, 1)
+	c.Check(page.NextCursor, gc.Equals, "")
+}
+
+func (s *statusSuite) TestFullStatusPageInvalidArgs(c *gc.C) {
+	client := s.APIState.Client()
+	_, err := client.StatusPage(nil, "", 0)
+	c.Assert(err, gc.ErrorMatches, "page limit 0 not valid")
+	_, err = client.StatusPage(nil, "unit-mysql-0", 10)
+	c.Assert(err, gc.ErrorMatches, `cursor "unit-mysql-0" not valid`)
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
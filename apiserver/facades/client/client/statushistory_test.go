@@ -220,10 +220,83 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryVolume(c *gc.C) {
+	s.st.volumeHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status: status.Attached,
+		},
+		{
+			Status: status.Attaching,
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "volume-0",
+			Kind:   status.KindVolume.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.volumeHistory))
+	for _, hist := range h.Results[0].History.Statuses {
+		c.Assert(hist.Kind, gc.Equals, status.KindVolume.String())
+	}
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryVolumeBadTag(c *gc.C) {
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindVolume.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.ErrorMatches, `fetching status history for "unit-unit-0": "unit-unit-0" is not a valid volume tag`)
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryV4(c *gc.C) {
+	s.st.volumeHistory = statusInfoWithDates([]status.StatusInfo{{Status: status.Attached}})
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{{Status: status.Active}})
+	now := time.Now()
+	api := &client.ClientV4{Client: s.api}
+	h := api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "volume-0",
+			Kind:   status.KindVolume.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}, {
+			Tag:    "unit-unit-0",
+			Kind:   status.KindWorkload.String(),
+			Filter: params.StatusHistoryFilter{Size: 10, Offset: 1},
+		}, {
+			Tag:    "unit-unit-0",
+			Kind:   status.KindWorkload.String(),
+			Filter: params.StatusHistoryFilter{Size: 10, ToDate: &now},
+		}, {
+			Tag:    "unit-unit-0",
+			Kind:   status.KindWorkload.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 4)
+	c.Check(h.Results[0].Error, gc.ErrorMatches, "volume status history not supported")
+	c.Check(h.Results[1].Error, gc.ErrorMatches, "status history offset not supported")
+	c.Check(h.Results[2].Error, gc.ErrorMatches, "status history end date not supported")
+	c.Assert(h.Results[3].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[3].History.Statuses, s.st.unitHistory)
+}
+
 type mockState struct {
 	client.Backend
-	unitHistory  []status.StatusInfo
-	agentHistory []status.StatusInfo
+	unitHistory   []status.StatusInfo
+	agentHistory  []status.StatusInfo
+	volumeHistory []status.StatusInfo
+}
+
+func (m *mockState) Volume(tag names.VolumeTag) (status.StatusHistoryGetter, error) {
+	if tag.Id() != "0" {
+		return nil, errors.NotFoundf("%v", tag)
+	}
+	return statuses(m.volumeHistory), nil
 }
 
 func (m *mockState) ModelUUID() string {
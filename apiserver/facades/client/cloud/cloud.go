@@ -225,6 +225,10 @@ func (api *CloudAPI) AddCredentials(args params.TaggedCredentials) (params.Error
 			cloud.AuthType(arg.Credential.AuthType),
 			arg.Credential.Attributes,
 		)
+		if err := api.checkPluginCredential(in); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		if err := api.backend.UpdateCloudCredential(tag, in); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -258,6 +262,10 @@ func (api *CloudAPI) UpdateCredentials(args params.TaggedCredentials) (params.Er
 			cloud.AuthType(arg.Credential.AuthType),
 			arg.Credential.Attributes,
 		)
+		if err := api.checkPluginCredential(in); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		if err := api.backend.UpdateCloudCredential(tag, in); err != nil {
 			if errors.IsNotFound(err) {
 				err = errors.Errorf(
@@ -271,6 +279,23 @@ func (api *CloudAPI) UpdateCredentials(args params.TaggedCredentials) (params.Er
 	return results, nil
 }
 
+// checkPluginCredential returns common.ErrPerm if the credential is
+// to be obtained from a credential plugin and the authenticated user
+// is not a controller superuser, as the controller runs the plugins.
+func (api *CloudAPI) checkPluginCredential(in cloud.Credential) error {
+	if in.AuthType() != cloud.PluginAuthType {
+		return nil
+	}
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // RevokeCredentials revokes a set of cloud credentials.
 func (api *CloudAPI) RevokeCredentials(args params.Entities) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -209,6 +209,55 @@ func (s *cloudSuite) TestUpdateCredentialsAdminAccess(c *gc.C) {
 	c.Assert(results.Results[0].Error, gc.IsNil)
 }
 
+func (s *cloudSuite) TestUpdateCredentialsPluginRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bruce")
+	results, err := s.api.UpdateCredentials(params.TaggedCredentials{Credentials: []params.TaggedCredential{{
+		Tag: "cloudcred-meep_bruce_three",
+		Credential: params.CloudCredential{
+			AuthType:   "plugin",
+			Attributes: map[string]string{"plugin": "exec", "command": "/bin/sh -c reboot"},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "ControllerTag")
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.DeepEquals, &params.Error{
+		Message: "permission denied", Code: params.CodeUnauthorized,
+	})
+}
+
+func (s *cloudSuite) TestUpdateCredentialsPluginSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.UpdateCredentials(params.TaggedCredentials{Credentials: []params.TaggedCredential{{
+		Tag: "cloudcred-meep_admin_three",
+		Credential: params.CloudCredential{
+			AuthType:   "plugin",
+			Attributes: map[string]string{"plugin": "exec", "command": "/usr/local/bin/fetch-token"},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "ControllerTag", "UpdateCloudCredential")
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *cloudSuite) TestAddCredentialsPluginRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bruce")
+	results, err := s.api.AddCredentials(params.TaggedCredentials{Credentials: []params.TaggedCredential{{
+		Tag: "cloudcred-meep_bruce_three",
+		Credential: params.CloudCredential{
+			AuthType:   "plugin",
+			Attributes: map[string]string{"plugin": "file", "path": "/etc/shadow"},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "ControllerTag")
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.DeepEquals, &params.Error{
+		Message: "permission denied", Code: params.CodeUnauthorized,
+	})
+}
+
 func (s *cloudSuite) TestRevokeCredentials(c *gc.C) {
 	s.authorizer.Tag = names.NewUserTag("bruce")
 	results, err := s.api.RevokeCredentials(params.Entities{Entities: []params.Entity{{
@@ -21,14 +21,26 @@ import (
 	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	jujucontroller "github.com/juju/juju/controller"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
 
+// newEnviron returns the Environ of the controller model. It is a
+// variable so that it can be replaced in tests.
+var newEnviron = stateenvirons.GetNewEnvironFunc(environs.New)
+
+// ControllerAPIv5 provides the v5 Controller API.
+type ControllerAPIv5 struct {
+	*ControllerAPIv4
+}
+
 // ControllerAPIv4 provides the v4 Controller API.
 type ControllerAPIv4 struct {
 	*ControllerAPIv3
@@ -47,6 +59,15 @@ type ControllerAPIv3 struct {
 	resources  facade.Resources
 }
 
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v4, err := NewControllerAPIv4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v4}, nil
+}
+
 // NewControllerAPIv4 creates a new ControllerAPIv4.
 func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
 	v3, err := NewControllerAPIv3(ctx)
@@ -104,6 +125,65 @@ func (s *ControllerAPIv3) checkHasAdmin() error {
 	return nil
 }
 
+// ConfigSet updates and removes controller config attributes. Changes
+// to some attributes take effect in the running controller agents,
+// which watch the controller config; the result holds the names of
+// those that only take effect once the agents are restarted.
+func (s *ControllerAPIv5) ConfigSet(args params.ControllerConfigSet) (params.ControllerConfigSetResult, error) {
+	var result params.ControllerConfigSetResult
+	if err := s.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	restart, err := s.state.UpdateControllerConfig(args.Config, args.Remove)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	// The rules are updated whenever the ports are set, rather than
+	// only when they change, so that a failed update can be retried.
+	if setsAPIPorts(args) {
+		if err := s.updateAPIPortRules(); err != nil {
+			return result, errors.Annotate(err, "updating controller firewall rules")
+		}
+	}
+	result.RestartRequired = restart
+	return result, nil
+}
+
+// setsAPIPorts reports whether the given controller config changes
+// set or remove any of the API port attributes.
+func setsAPIPorts(args params.ControllerConfigSet) bool {
+	for _, attr := range []string{jujucontroller.APIPort, jujucontroller.AdditionalAPIPort} {
+		if _, ok := args.Config[attr]; ok {
+			return true
+		}
+		for _, removed := range args.Remove {
+			if removed == attr {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// updateAPIPortRules updates the provider's firewall rules, if it has
+// any for the controllers, to allow connections to the API ports in
+// the controller config.
+func (s *ControllerAPIv5) updateAPIPortRules() error {
+	cfg, err := s.state.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env, err := newEnviron(s.state)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	firewaller, ok := env.(environs.ControllerFirewaller)
+	if !ok {
+		return nil
+	}
+	return firewaller.SetControllerAPIPorts(cfg.ControllerUUID(), cfg.APIPorts())
+}
+
 // AllModels allows controller administrators to get the list of all the
 // models in the controller.
 func (s *ControllerAPIv3) AllModels() (params.UserModelList, error) {
@@ -37,7 +37,7 @@ type controllerSuite struct {
 	statetesting.StateSuite
 
 	statePool  *state.StatePool
-	controller *controller.ControllerAPIv4
+	controller *controller.ControllerAPIv5
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 }
@@ -66,7 +66,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv5(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.statePool,
@@ -309,6 +309,96 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestConfigSet(c *gc.C) {
+	result, err := s.controller.ConfigSet(params.ControllerConfigSet{
+		Config: map[string]interface{}{
+			"auditing-enabled": true,
+			"state-port":       37018,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.RestartRequired, jc.DeepEquals, []string{"state-port"})
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+	c.Assert(cfg.StatePort(), gc.Equals, 37018)
+}
+
+type controllerFirewallEnviron struct {
+	environs.Environ
+	controllerUUID string
+	ports          []int
+	err            error
+}
+
+func (e *controllerFirewallEnviron) SetControllerAPIPorts(controllerUUID string, ports []int) error {
+	e.controllerUUID = controllerUUID
+	e.ports = ports
+	return e.err
+}
+
+func (s *controllerSuite) TestConfigSetAPIPortUpdatesFirewall(c *gc.C) {
+	env := &controllerFirewallEnviron{}
+	controller.SetEnviron(s, env)
+	oldConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.controller.ConfigSet(params.ControllerConfigSet{
+		Config: map[string]interface{}{
+			"api-port":            443,
+			"additional-api-port": oldConfig.APIPort(),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.controllerUUID, gc.Equals, oldConfig.ControllerUUID())
+	c.Assert(env.ports, jc.DeepEquals, []int{443, oldConfig.APIPort()})
+
+	_, err = s.controller.ConfigSet(params.ControllerConfigSet{
+		Remove: []string{"additional-api-port"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.ports, jc.DeepEquals, []int{443})
+}
+
+func (s *controllerSuite) TestConfigSetAPIPortFirewallError(c *gc.C) {
+	controller.SetEnviron(s, &controllerFirewallEnviron{err: errors.New("boom")})
+	oldConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.controller.ConfigSet(params.ControllerConfigSet{
+		Config: map[string]interface{}{
+			"additional-api-port": oldConfig.APIPort() + 1,
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "updating controller firewall rules: boom")
+}
+
+func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	endpoint, err := controller.NewControllerAPIv5(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.ConfigSet(params.ControllerConfigSet{
+		Config: map[string]interface{}{"auditing-enabled": true},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestConfigSetImmutable(c *gc.C) {
+	_, err := s.controller.ConfigSet(params.ControllerConfigSet{
+		Remove: []string{"controller-uuid"},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot change controller-uuid after bootstrap")
+}
+
 func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
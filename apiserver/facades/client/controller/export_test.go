@@ -5,6 +5,7 @@ package controller
 
 import (
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 )
 
@@ -17,3 +18,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetEnviron(p patcher, env environs.Environ) {
+	p.PatchValue(&newEnviron, func(*state.State) (environs.Environ, error) {
+		return env, nil
+	})
+}
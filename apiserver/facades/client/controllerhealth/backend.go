@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// Backend exposes the state functionality needed by the
+// ControllerHealth facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ReplicaSetMembers() ([]replicaset.MemberStatus, error)
+	DatabaseSizes() ([]params.DatabaseSize, error)
+
+	// CurrentUpgradeInfo returns an error satisfying
+	// errors.IsNotFound if there is no upgrade in progress.
+	CurrentUpgradeInfo() (UpgradeInfo, error)
+
+	// LastBackup returns an error satisfying errors.IsNotFound
+	// if there has been no backup of the controller.
+	LastBackup() (time.Time, error)
+}
+
+// UpgradeInfo describes an upgrade in progress.
+type UpgradeInfo interface {
+	PreviousVersion() version.Number
+	TargetVersion() version.Number
+	Status() state.UpgradeStatus
+	Started() time.Time
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend returns a Backend backed by the given controller
+// state.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ReplicaSetMembers() ([]replicaset.MemberStatus, error) {
+	session := s.MongoSession().Copy()
+	defer session.Close()
+	status, err := replicaset.CurrentStatus(session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return status.Members, nil
+}
+
+func (s stateShim) DatabaseSizes() ([]params.DatabaseSize, error) {
+	session := s.MongoSession().Copy()
+	defer session.Close()
+	dbNames, err := session.DatabaseNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sizes := make([]params.DatabaseSize, len(dbNames))
+	for i, name := range dbNames {
+		// Mongo reports sizes as doubles or integers, depending
+		// on their magnitude.
+		var stats struct {
+			DataSize    float64 `bson:"dataSize"`
+			StorageSize float64 `bson:"storageSize"`
+			IndexSize   float64 `bson:"indexSize"`
+		}
+		if err := session.DB(name).Run(bson.D{{"dbStats", 1}}, &stats); err != nil {
+			return nil, errors.Annotatef(err, "getting stats for database %q", name)
+		}
+		sizes[i] = params.DatabaseSize{
+			Name:        name,
+			DataSize:    int64(stats.DataSize),
+			StorageSize: int64(stats.StorageSize),
+			IndexSize:   int64(stats.IndexSize),
+		}
+	}
+	return sizes, nil
+}
+
+func (s stateShim) CurrentUpgradeInfo() (UpgradeInfo, error) {
+	info, err := s.State.CurrentUpgradeInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info, nil
+}
+
+func (s stateShim) LastBackup() (time.Time, error) {
+	stor := backups.NewStorage(s.State)
+	defer stor.Close()
+	metadata, err := backups.NewBackups(stor).List()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	var last time.Time
+	for _, meta := range metadata {
+		if meta.Finished != nil && meta.Finished.After(last) {
+			last = *meta.Finished
+		}
+	}
+	if last.IsZero() {
+		return time.Time{}, errors.NotFoundf("backup")
+	}
+	return last, nil
+}
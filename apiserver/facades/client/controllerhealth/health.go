@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerhealth defines an API end point for reporting on
+// the health of a controller.
+package controllerhealth
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// ConnectionCounter reports the number of connections to an API
+// server.
+type ConnectionCounter interface {
+	ConnectionCount() int64
+}
+
+// API implements the ControllerHealth facade.
+type API struct {
+	backend     Backend
+	connections ConnectionCounter
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	res := ctx.Resources().Get("connectionCounter")
+	value, ok := res.(common.ValueResource)
+	if !ok {
+		return nil, errors.Errorf("invalid connectionCounter resource: %v", res)
+	}
+	connections, ok := value.Value.(ConnectionCounter)
+	if !ok {
+		return nil, errors.Errorf("invalid connectionCounter resource: %v", value.Value)
+	}
+	backend := NewStateBackend(ctx.StatePool().SystemState())
+	return NewAPI(backend, connections, ctx.Auth())
+}
+
+// NewAPI returns a new ControllerHealth API facade, which may only be
+// used by controller superusers.
+func NewAPI(backend Backend, connections ConnectionCounter, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:     backend,
+		connections: connections,
+	}, nil
+}
+
+// Health returns the health of the controller.
+func (api *API) Health() (params.ControllerHealth, error) {
+	result := params.ControllerHealth{
+		APIConnections: api.connections.ConnectionCount(),
+	}
+
+	members, err := api.backend.ReplicaSetMembers()
+	if err != nil {
+		return params.ControllerHealth{}, errors.Annotate(err, "getting replica set status")
+	}
+	for _, member := range members {
+		result.ReplicaSet = append(result.ReplicaSet, params.ReplicaSetMemberHealth{
+			Address: member.Address,
+			State:   member.State.String(),
+			Healthy: member.Healthy,
+		})
+	}
+
+	result.Databases, err = api.backend.DatabaseSizes()
+	if err != nil {
+		return params.ControllerHealth{}, errors.Annotate(err, "getting database sizes")
+	}
+
+	upgrade, err := api.backend.CurrentUpgradeInfo()
+	if err == nil {
+		result.Upgrade = &params.UpgradeHealth{
+			PreviousVersion: upgrade.PreviousVersion(),
+			TargetVersion:   upgrade.TargetVersion(),
+			Status:          string(upgrade.Status()),
+			Started:         upgrade.Started(),
+		}
+	} else if !errors.IsNotFound(err) {
+		return params.ControllerHealth{}, errors.Annotate(err, "getting upgrade status")
+	}
+
+	lastBackup, err := api.backend.LastBackup()
+	if err == nil {
+		result.LastBackup = &lastBackup
+	} else if !errors.IsNotFound(err) {
+		return params.ControllerHealth{}, errors.Annotate(err, "getting last backup")
+	}
+	return result, nil
+}
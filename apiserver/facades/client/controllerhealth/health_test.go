@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type healthSuite struct {
+	gitjujutesting.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *controllerhealth.API
+}
+
+var _ = gc.Suite(&healthSuite{})
+
+func (s *healthSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		members: []replicaset.MemberStatus{{
+			Address: "10.0.0.1:37017",
+			State:   replicaset.PrimaryState,
+			Healthy: true,
+		}, {
+			Address: "10.0.0.2:37017",
+			State:   replicaset.SecondaryState,
+			Healthy: false,
+		}},
+		sizes: []params.DatabaseSize{{
+			Name:        "juju",
+			DataSize:    1024,
+			StorageSize: 4096,
+			IndexSize:   512,
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	var err error
+	s.api, err = controllerhealth.NewAPI(s.backend, connectionCounter(3), s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *healthSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := controllerhealth.NewAPI(s.backend, connectionCounter(3), s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *healthSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := controllerhealth.NewAPI(s.backend, connectionCounter(3), s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *healthSuite) TestHealth(c *gc.C) {
+	lastBackup := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	started := time.Date(2017, 5, 2, 12, 0, 0, 0, time.UTC)
+	s.backend.lastBackup = lastBackup
+	s.backend.upgrade = &mockUpgradeInfo{
+		previous: version.MustParse("2.2.0"),
+		target:   version.MustParse("2.2.1"),
+		status:   state.UpgradeRunning,
+		started:  started,
+	}
+
+	health, err := s.api.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, params.ControllerHealth{
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			Address: "10.0.0.1:37017",
+			State:   "PRIMARY",
+			Healthy: true,
+		}, {
+			Address: "10.0.0.2:37017",
+			State:   "SECONDARY",
+			Healthy: false,
+		}},
+		APIConnections: 3,
+		Databases:      s.backend.sizes,
+		Upgrade: &params.UpgradeHealth{
+			PreviousVersion: version.MustParse("2.2.0"),
+			TargetVersion:   version.MustParse("2.2.1"),
+			Status:          "running",
+			Started:         started,
+		},
+		LastBackup: &lastBackup,
+	})
+}
+
+func (s *healthSuite) TestHealthNoUpgradeOrBackup(c *gc.C) {
+	health, err := s.api.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health.Upgrade, gc.IsNil)
+	c.Assert(health.LastBackup, gc.IsNil)
+}
+
+func (s *healthSuite) TestHealthReplicaSetError(c *gc.C) {
+	s.backend.SetErrors(errors.New("no reachable servers"))
+	_, err := s.api.Health()
+	c.Assert(err, gc.ErrorMatches, "getting replica set status: no reachable servers")
+}
+
+type connectionCounter int64
+
+func (c connectionCounter) ConnectionCount() int64 {
+	return int64(c)
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+
+	members    []replicaset.MemberStatus
+	sizes      []params.DatabaseSize
+	upgrade    *mockUpgradeInfo
+	lastBackup time.Time
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ReplicaSetMembers() ([]replicaset.MemberStatus, error) {
+	b.MethodCall(b, "ReplicaSetMembers")
+	return b.members, b.NextErr()
+}
+
+func (b *mockBackend) DatabaseSizes() ([]params.DatabaseSize, error) {
+	b.MethodCall(b, "DatabaseSizes")
+	return b.sizes, b.NextErr()
+}
+
+func (b *mockBackend) CurrentUpgradeInfo() (controllerhealth.UpgradeInfo, error) {
+	b.MethodCall(b, "CurrentUpgradeInfo")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	if b.upgrade == nil {
+		return nil, errors.NotFoundf("current upgrade info")
+	}
+	return b.upgrade, nil
+}
+
+func (b *mockBackend) LastBackup() (time.Time, error) {
+	b.MethodCall(b, "LastBackup")
+	if err := b.NextErr(); err != nil {
+		return time.Time{}, err
+	}
+	if b.lastBackup.IsZero() {
+		return time.Time{}, errors.NotFoundf("backup")
+	}
+	return b.lastBackup, nil
+}
+
+type mockUpgradeInfo struct {
+	previous version.Number
+	target   version.Number
+	status   state.UpgradeStatus
+	started  time.Time
+}
+
+func (u *mockUpgradeInfo) PreviousVersion() version.Number { return u.previous }
+func (u *mockUpgradeInfo) TargetVersion() version.Number   { return u.target }
+func (u *mockUpgradeInfo) Status() state.UpgradeStatus     { return u.status }
+func (u *mockUpgradeInfo) Started() time.Time              { return u.started }
@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllertxns defines an API end point for finding and
+// resuming stuck transactions in a controller's database.
+package controllertxns
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality needed by the
+// ControllerTxns facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	StuckTransactions(startedBefore time.Time) ([]state.StuckTransaction, error)
+	ResumeTransaction(id string) error
+}
+
+// API implements the ControllerTxns facade.
+type API struct {
+	backend Backend
+	clock   clock.Clock
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.StatePool().SystemState(), clock.WallClock, ctx.Auth())
+}
+
+// NewAPI returns a new ControllerTxns API facade, which may only be
+// used by controller superusers.
+func NewAPI(backend Backend, clock clock.Clock, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		clock:   clock,
+	}, nil
+}
+
+// StuckTransactions returns the transactions that have been incomplete
+// for longer than state.StuckTransactionAge. The controller attempts
+// to resume such transactions itself, so those returned are the ones
+// it has been unable to resolve.
+func (api *API) StuckTransactions() (params.StuckTransactions, error) {
+	stuck, err := api.backend.StuckTransactions(api.clock.Now().Add(-state.StuckTransactionAge))
+	if err != nil {
+		return params.StuckTransactions{}, errors.Trace(err)
+	}
+	result := params.StuckTransactions{
+		Transactions: make([]params.StuckTransaction, len(stuck)),
+	}
+	for i, txn := range stuck {
+		ops := make([]params.StuckTransactionOp, len(txn.Ops))
+		for j, op := range txn.Ops {
+			ops[j] = params.StuckTransactionOp{
+				Collection: op.Collection,
+				Id:         op.Id,
+			}
+		}
+		result.Transactions[i] = params.StuckTransaction{
+			Id:      txn.Id,
+			State:   txn.State,
+			Started: txn.Started,
+			Ops:     ops,
+		}
+	}
+	return result, nil
+}
+
+// ResumeTransactions attempts to complete the transactions with the
+// given ids.
+func (api *API) ResumeTransactions(args params.ResumeTransactionsArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		results.Results[i].Error = common.ServerError(api.backend.ResumeTransaction(id))
+	}
+	return results, nil
+}
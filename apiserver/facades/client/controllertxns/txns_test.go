@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllertxns_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/controllertxns"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type txnsSuite struct {
+	gitjujutesting.IsolationSuite
+
+	backend    *mockBackend
+	clock      *gitjujutesting.Clock
+	authorizer apiservertesting.FakeAuthorizer
+	api        *controllertxns.API
+}
+
+var _ = gc.Suite(&txnsSuite{})
+
+func (s *txnsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		stuck: []state.StuckTransaction{{
+			Id:      "5a0a4c3e0000000000000001",
+			State:   "prepared",
+			Started: time.Date(2017, 11, 1, 9, 0, 0, 0, time.UTC),
+			Ops: []state.StuckTransactionOp{
+				{Collection: "machines", Id: "uuid:0"},
+				{Collection: "units", Id: "uuid:mysql/0"},
+			},
+		}},
+	}
+	s.clock = gitjujutesting.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	var err error
+	s.api, err = controllertxns.NewAPI(s.backend, s.clock, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *txnsSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := controllertxns.NewAPI(s.backend, s.clock, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *txnsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := controllertxns.NewAPI(s.backend, s.clock, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *txnsSuite) TestStuckTransactions(c *gc.C) {
+	result, err := s.api.StuckTransactions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StuckTransactions{
+		Transactions: []params.StuckTransaction{{
+			Id:      "5a0a4c3e0000000000000001",
+			State:   "prepared",
+			Started: time.Date(2017, 11, 1, 9, 0, 0, 0, time.UTC),
+			Ops: []params.StuckTransactionOp{
+				{Collection: "machines", Id: "uuid:0"},
+				{Collection: "units", Id: "uuid:mysql/0"},
+			},
+		}},
+	})
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"StuckTransactions", []interface{}{time.Date(2017, 11, 1, 11, 50, 0, 0, time.UTC)}},
+	})
+}
+
+func (s *txnsSuite) TestStuckTransactionsError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.StuckTransactions()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *txnsSuite) TestResumeTransactions(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotFoundf(`transaction "bad"`))
+	result, err := s.api.ResumeTransactions(params.ResumeTransactionsArgs{
+		Ids: []string{"5a0a4c3e0000000000000001", "bad"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `transaction "bad" not found`,
+			}},
+		},
+	})
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ResumeTransaction", []interface{}{"5a0a4c3e0000000000000001"}},
+		{"ResumeTransaction", []interface{}{"bad"}},
+	})
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	stuck []state.StuckTransaction
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) StuckTransactions(startedBefore time.Time) ([]state.StuckTransaction, error) {
+	b.MethodCall(b, "StuckTransactions", startedBefore)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.stuck, nil
+}
+
+func (b *mockBackend) ResumeTransaction(id string) error {
+	b.MethodCall(b, "ResumeTransaction", id)
+	return b.NextErr()
+}
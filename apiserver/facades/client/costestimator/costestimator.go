@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package costestimator provides the API for estimating the hourly
+// cost of a planned deployment, from the instance types that would be
+// provisioned and the storage that would be created.
+package costestimator
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/pricing"
+	"github.com/juju/juju/permission"
+)
+
+// Backend exposes the model functionality required by API.
+type Backend interface {
+	ModelTag() names.ModelTag
+	CloudRegion() string
+	ModelConfig() (*config.Config, error)
+	ModelConstraints() (constraints.Value, error)
+
+	// StorageProviderType returns the provider type of the named
+	// storage pool, which may also be the name of a provider type.
+	StorageProviderType(pool string) (string, error)
+}
+
+// Environ exposes the provider functionality required by API.
+type Environ interface {
+	ConstraintsValidator() (constraints.Validator, error)
+	InstanceTypes(constraints.Value) (instances.InstanceTypesWithCostMetadata, error)
+}
+
+// API provides access to the CostEstimator API facade.
+type API struct {
+	backend      Backend
+	newEnviron   func() (Environ, error)
+	fetchPricing func(url string) (*pricing.Metadata, error)
+}
+
+// NewAPI returns a new CostEstimator API facade.
+func NewAPI(
+	backend Backend,
+	newEnviron func() (Environ, error),
+	fetchPricing func(url string) (*pricing.Metadata, error),
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	canRead, err := authorizer.HasPermission(permission.ReadAccess, backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !canRead {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:      backend,
+		newEnviron:   newEnviron,
+		fetchPricing: fetchPricing,
+	}, nil
+}
+
+// EstimateCost returns the estimated hourly cost of the machines and
+// storage of a planned deployment. Each machine's instance type is
+// resolved as the provisioner would, from its constraints merged with
+// the model's, and is priced from the model's pricing metadata or,
+// failing that, the prices reported by the provider. Storage is only
+// priced from the pricing metadata.
+func (api *API) EstimateCost(args params.CostEstimateArgs) (params.CostEstimateResult, error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return params.CostEstimateResult{}, errors.Trace(err)
+	}
+	var prices pricing.Prices
+	var currency string
+	if url := cfg.PricingMetadataURL(); url != "" {
+		metadata, err := api.fetchPricing(url)
+		if err != nil {
+			return params.CostEstimateResult{}, errors.Trace(err)
+		}
+		prices, _ = metadata.Region(api.backend.CloudRegion())
+		currency = metadata.Currency
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		return params.CostEstimateResult{}, errors.Annotate(err, "getting environ")
+	}
+	validator, err := env.ConstraintsValidator()
+	if err != nil {
+		return params.CostEstimateResult{}, errors.Trace(err)
+	}
+	modelCons, err := api.backend.ModelConstraints()
+	if err != nil {
+		return params.CostEstimateResult{}, errors.Trace(err)
+	}
+
+	var result params.CostEstimateResult
+	result.Machines = make([]params.CostEstimateMachineResult, len(args.Machines))
+	for i, m := range args.Machines {
+		machineResult := params.CostEstimateMachineResult{Count: m.Count}
+		cons, err := validator.Merge(modelCons, m.Constraints)
+		if err == nil {
+			machineResult.InstanceType, machineResult.HourlyCost, machineResult.Priced, err = instanceTypeCost(
+				env, cons, prices, &currency,
+			)
+		}
+		if err != nil {
+			machineResult.Error = common.ServerError(err)
+		} else if machineResult.Priced {
+			result.HourlyCost += machineResult.HourlyCost * float64(m.Count)
+		}
+		result.Machines[i] = machineResult
+	}
+
+	defaultPool, _ := cfg.StorageDefaultBlockSource()
+	for _, s := range args.Storage {
+		storageResult := params.CostEstimateStorageResult{
+			Pool:  s.Pool,
+			Size:  s.Size,
+			Count: s.Count,
+		}
+		if storageResult.Pool == "" {
+			storageResult.Pool = defaultPool
+		}
+		providerType, err := api.backend.StorageProviderType(storageResult.Pool)
+		if err != nil {
+			storageResult.Error = common.ServerError(err)
+		} else if currency != "" {
+			storageResult.HourlyCost, storageResult.Priced = prices.StorageCost(
+				storageResult.Pool, providerType, s.Size,
+			)
+		}
+		if storageResult.Priced {
+			result.HourlyCost += storageResult.HourlyCost * float64(s.Count)
+		}
+		result.Storage = append(result.Storage, storageResult)
+	}
+	result.Currency = currency
+	return result, nil
+}
+
+// instanceTypeCost returns the instance type that the provider would
+// use for a machine with the given constraints, and its hourly cost if
+// known. Provider prices are only used if they are hourly, and in the
+// same currency as any other prices; currency is set to the currency
+// of the first price used.
+func instanceTypeCost(
+	env Environ,
+	cons constraints.Value,
+	prices pricing.Prices,
+	currency *string,
+) (string, float64, bool, error) {
+	itypes, err := env.InstanceTypes(cons)
+	if err != nil {
+		return "", 0, false, errors.Trace(err)
+	}
+	if len(itypes.InstanceTypes) == 0 {
+		return "", 0, false, errors.NotFoundf("instance type matching constraints %q", cons)
+	}
+	// Matching instance types are ordered by cost, so the first is
+	// the one the provisioner would choose.
+	itype := itypes.InstanceTypes[0]
+	if cost, ok := prices.InstanceTypeCost(itype.Name); ok && *currency != "" {
+		return itype.Name, cost, true, nil
+	}
+	if itype.Cost == 0 || itypes.CostCurrency == "" || !strings.HasSuffix(itypes.CostUnit, "/hour") {
+		return itype.Name, 0, false, nil
+	}
+	if *currency == "" {
+		*currency = itypes.CostCurrency
+	} else if *currency != itypes.CostCurrency {
+		return itype.Name, 0, false, nil
+	}
+	cost := float64(itype.Cost)
+	if itypes.CostDivisor != 0 {
+		cost /= float64(itypes.CostDivisor)
+	}
+	return itype.Name, cost, true, nil
+}
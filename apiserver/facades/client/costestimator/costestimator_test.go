@@ -0,0 +1,296 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costestimator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/costestimator"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/pricing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type costEstimatorSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	environ    *mockEnviron
+	metadata   *pricing.Metadata
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&costEstimatorSuite{})
+
+func (s *costEstimatorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		region:    "us-east-1",
+		modelCons: constraints.MustParse("arch=amd64"),
+		providerTypes: map[string]string{
+			"ebs":  "ebs",
+			"fast": "ebs",
+		},
+	}
+	s.backend.setConfig(c, coretesting.Attrs{
+		"storage-default-block-source": "ebs",
+	})
+	s.environ = &mockEnviron{
+		instanceTypes: map[string]instances.InstanceTypesWithCostMetadata{
+			"arch=amd64": {
+				InstanceTypes: []instances.InstanceType{
+					{Name: "m4.large", Cost: 125},
+					{Name: "m4.xlarge", Cost: 250},
+				},
+				CostUnit:     "$USD/hour",
+				CostCurrency: "USD",
+				CostDivisor:  1000,
+			},
+			"arch=amd64 mem=16384M": {
+				InstanceTypes: []instances.InstanceType{
+					{Name: "m4.xlarge", Cost: 250},
+				},
+				CostUnit:     "$USD/hour",
+				CostCurrency: "USD",
+				CostDivisor:  1000,
+			},
+		},
+	}
+	s.metadata = &pricing.Metadata{
+		Format:   pricing.Format,
+		Currency: "USD",
+		Regions: map[string]pricing.Prices{
+			"us-east-1": {
+				InstanceTypes: map[string]float64{"m4.xlarge": 0.75},
+				Storage:       map[string]float64{"ebs": 0.5},
+			},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *costEstimatorSuite) newAPI(c *gc.C) *costestimator.API {
+	newEnviron := func() (costestimator.Environ, error) {
+		return s.environ, nil
+	}
+	fetchPricing := func(url string) (*pricing.Metadata, error) {
+		s.backend.AddCall("FetchPricing", url)
+		return s.metadata, s.backend.NextErr()
+	}
+	api, err := costestimator.NewAPI(s.backend, newEnviron, fetchPricing, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *costEstimatorSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := costestimator.NewAPI(s.backend, nil, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *costEstimatorSuite) TestNewAPIRequiresReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := costestimator.NewAPI(s.backend, nil, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *costEstimatorSuite) TestEstimateCostProviderPrices(c *gc.C) {
+	result, err := s.newAPI(c).EstimateCost(params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{
+			Count: 2,
+		}, {
+			Constraints: constraints.MustParse("mem=16G"),
+			Count:       1,
+		}},
+		Storage: []params.CostEstimateStorage{{
+			Size:  1024,
+			Count: 3,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CostEstimateResult{
+		Currency:   "USD",
+		HourlyCost: 0.5,
+		Machines: []params.CostEstimateMachineResult{{
+			InstanceType: "m4.large",
+			Count:        2,
+			HourlyCost:   0.125,
+			Priced:       true,
+		}, {
+			InstanceType: "m4.xlarge",
+			Count:        1,
+			HourlyCost:   0.25,
+			Priced:       true,
+		}},
+		Storage: []params.CostEstimateStorageResult{{
+			Pool:  "ebs",
+			Size:  1024,
+			Count: 3,
+		}},
+	})
+	s.backend.CheckCallNames(c, "StorageProviderType")
+}
+
+func (s *costEstimatorSuite) TestEstimateCostPricingMetadata(c *gc.C) {
+	s.backend.setConfig(c, coretesting.Attrs{
+		"storage-default-block-source": "ebs",
+		"pricing-metadata-url":         "https://example.com/pricing.json",
+	})
+	result, err := s.newAPI(c).EstimateCost(params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{
+			Count: 2,
+		}, {
+			Constraints: constraints.MustParse("mem=16G"),
+			Count:       2,
+		}},
+		Storage: []params.CostEstimateStorage{{
+			Pool:  "fast",
+			Size:  730 * 1024,
+			Count: 1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Instance types without a price in the metadata use the
+	// provider's price.
+	c.Assert(result, jc.DeepEquals, params.CostEstimateResult{
+		Currency:   "USD",
+		HourlyCost: 2.25,
+		Machines: []params.CostEstimateMachineResult{{
+			InstanceType: "m4.large",
+			Count:        2,
+			HourlyCost:   0.125,
+			Priced:       true,
+		}, {
+			InstanceType: "m4.xlarge",
+			Count:        2,
+			HourlyCost:   0.75,
+			Priced:       true,
+		}},
+		Storage: []params.CostEstimateStorageResult{{
+			Pool:       "fast",
+			Size:       730 * 1024,
+			Count:      1,
+			HourlyCost: 0.5,
+			Priced:     true,
+		}},
+	})
+	s.backend.CheckCall(c, 0, "FetchPricing", "https://example.com/pricing.json")
+}
+
+func (s *costEstimatorSuite) TestEstimateCostCurrencyMismatch(c *gc.C) {
+	s.backend.setConfig(c, coretesting.Attrs{
+		"pricing-metadata-url": "https://example.com/pricing.json",
+	})
+	s.metadata.Currency = "EUR"
+	result, err := s.newAPI(c).EstimateCost(params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{Count: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CostEstimateResult{
+		Currency: "EUR",
+		Machines: []params.CostEstimateMachineResult{{
+			InstanceType: "m4.large",
+			Count:        1,
+		}},
+	})
+}
+
+func (s *costEstimatorSuite) TestEstimateCostNoInstanceType(c *gc.C) {
+	result, err := s.newAPI(c).EstimateCost(params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{
+			Constraints: constraints.MustParse("cores=128"),
+			Count:       1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, gc.HasLen, 1)
+	c.Assert(result.Machines[0].Error, gc.ErrorMatches, `no instance types matching "arch=amd64 cores=128"`)
+	c.Assert(result.HourlyCost, gc.Equals, 0.0)
+}
+
+func (s *costEstimatorSuite) TestEstimateCostUnknownPool(c *gc.C) {
+	result, err := s.newAPI(c).EstimateCost(params.CostEstimateArgs{
+		Storage: []params.CostEstimateStorage{{
+			Pool:  "unknown",
+			Size:  1024,
+			Count: 1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Storage, gc.HasLen, 1)
+	c.Assert(result.Storage[0].Error, gc.ErrorMatches, `pool "unknown" not found`)
+}
+
+func (s *costEstimatorSuite) TestEstimateCostPricingError(c *gc.C) {
+	s.backend.setConfig(c, coretesting.Attrs{
+		"pricing-metadata-url": "https://example.com/pricing.json",
+	})
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).EstimateCost(params.CostEstimateArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	testing.Stub
+	region        string
+	cfg           *config.Config
+	modelCons     constraints.Value
+	providerTypes map[string]string
+}
+
+func (b *mockBackend) setConfig(c *gc.C, attrs coretesting.Attrs) {
+	b.cfg = coretesting.CustomModelConfig(c, attrs)
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) CloudRegion() string {
+	return b.region
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return b.cfg, nil
+}
+
+func (b *mockBackend) ModelConstraints() (constraints.Value, error) {
+	return b.modelCons, nil
+}
+
+func (b *mockBackend) StorageProviderType(pool string) (string, error) {
+	b.AddCall("StorageProviderType", pool)
+	providerType, ok := b.providerTypes[pool]
+	if !ok {
+		return "", errors.NotFoundf("pool %q", pool)
+	}
+	return providerType, nil
+}
+
+type mockEnviron struct {
+	instanceTypes map[string]instances.InstanceTypesWithCostMetadata
+}
+
+func (e *mockEnviron) ConstraintsValidator() (constraints.Validator, error) {
+	return constraints.NewValidator(), nil
+}
+
+func (e *mockEnviron) InstanceTypes(cons constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	itypes, ok := e.instanceTypes[cons.String()]
+	if !ok {
+		return instances.InstanceTypesWithCostMetadata{}, errors.Errorf("no instance types matching %q", cons)
+	}
+	return itypes, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costestimator
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/pricing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newEnviron := func() (Environ, error) {
+		return stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	}
+	return NewAPI(backend{model, st}, newEnviron, pricing.Fetch, authorizer)
+}
+
+type backend struct {
+	*state.Model
+	st *state.State
+}
+
+func (b backend) ModelConstraints() (constraints.Value, error) {
+	return b.st.ModelConstraints()
+}
+
+func (b backend) StorageProviderType(pool string) (string, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(b.st)
+	if err != nil {
+		return "", errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	cfg, err := poolmanager.New(state.NewStateSettings(b.st), registry).Get(pool)
+	if errors.IsNotFound(err) {
+		// Provider types may be used as pool names.
+		if _, err := registry.StorageProvider(storage.ProviderType(pool)); err != nil {
+			return "", errors.Trace(err)
+		}
+		return pool, nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(cfg.Provider()), nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package description provides the API for setting the free-text
+// descriptions of a model and its applications.
+package description
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Describable is an entity with a free-text description.
+type Describable interface {
+	Description() string
+	SetDescription(string) error
+}
+
+// Backend exposes the state functionality required by API.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	Model() (Describable, error)
+	Application(name string) (Describable, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) Model() (Describable, error) {
+	return s.State.Model()
+}
+
+func (s stateShim) Application(name string) (Describable, error) {
+	return s.State.Application(name)
+}
+
+// API provides access to the Description API facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, authorizer)
+}
+
+// NewAPI returns a new Description API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	ok, err = api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// entity returns the model or application with the given tag, and
+// the access needed to change its description. Only model admins may
+// describe the model, while users with write access may describe its
+// applications.
+func (api *API) entity(tagString string) (Describable, permission.Access, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.ModelTag:
+		if tag != api.backend.ModelTag() {
+			return nil, "", common.ErrPerm
+		}
+		model, err := api.backend.Model()
+		return model, permission.AdminAccess, errors.Trace(err)
+	case names.ApplicationTag:
+		application, err := api.backend.Application(tag.Id())
+		return application, permission.WriteAccess, errors.Trace(err)
+	}
+	return nil, "", errors.NotValidf("%q as a model or application tag", tagString)
+}
+
+// Descriptions returns the descriptions of the given model and
+// applications.
+func (api *API) Descriptions(args params.Entities) (params.StringResults, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		entity, _, err := api.entity(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = entity.Description()
+	}
+	return results, nil
+}
+
+// SetDescriptions sets the descriptions of the given model and
+// applications.
+func (api *API) SetDescriptions(args params.SetDescriptions) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		entity, access, err := api.entity(arg.Tag)
+		if err == nil {
+			err = api.checkAccess(access)
+		}
+		if err == nil {
+			err = entity.SetDescription(arg.Description)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
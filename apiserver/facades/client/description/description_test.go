@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/description"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type descriptionSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&descriptionSuite{})
+
+func (s *descriptionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		model: &mockDescribable{name: "model", description: "staging"},
+		applications: map[string]*mockDescribable{
+			"mysql": {name: "mysql", description: "owned by the db team"},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *descriptionSuite) newAPI(c *gc.C) *description.API {
+	api, err := description.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *descriptionSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := description.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *descriptionSuite) TestDescriptions(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	results, err := s.newAPI(c).Descriptions(params.Entities{
+		Entities: []params.Entity{
+			{Tag: coretesting.ModelTag.String()},
+			{Tag: "application-mysql"},
+			{Tag: "application-foo"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "staging"},
+			{Result: "owned by the db team"},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: `application "foo" not found`}},
+			{Error: &params.Error{Code: params.CodeNotValid, Message: `"unit-mysql-0" as a model or application tag not valid`}},
+		},
+	})
+}
+
+func (s *descriptionSuite) TestDescriptionsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).Descriptions(params.Entities{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *descriptionSuite) TestSetDescriptions(c *gc.C) {
+	results, err := s.newAPI(c).SetDescriptions(params.SetDescriptions{
+		Args: []params.SetDescription{
+			{Tag: coretesting.ModelTag.String(), Description: "production"},
+			{Tag: "application-mysql", Description: ""},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {}},
+	})
+	c.Assert(s.backend.model.description, gc.Equals, "production")
+	c.Assert(s.backend.applications["mysql"].description, gc.Equals, "")
+}
+
+func (s *descriptionSuite) TestSetDescriptionsWriteAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write")
+	results, err := s.newAPI(c).SetDescriptions(params.SetDescriptions{
+		Args: []params.SetDescription{
+			{Tag: coretesting.ModelTag.String(), Description: "production"},
+			{Tag: "application-mysql", Description: "owned by the web team"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}},
+			{},
+		},
+	})
+	c.Assert(s.backend.model.description, gc.Equals, "staging")
+	c.Assert(s.backend.applications["mysql"].description, gc.Equals, "owned by the web team")
+}
+
+func (s *descriptionSuite) TestSetDescriptionsOtherModel(c *gc.C) {
+	results, err := s.newAPI(c).SetDescriptions(params.SetDescriptions{
+		Args: []params.SetDescription{
+			{Tag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "permission denied")
+}
+
+func (s *descriptionSuite) TestSetDescriptionsError(c *gc.C) {
+	s.backend.applications["mysql"].SetErrors(errors.NotValidf("description longer than 1024 bytes"))
+	results, err := s.newAPI(c).SetDescriptions(params.SetDescriptions{
+		Args: []params.SetDescription{
+			{Tag: "application-mysql", Description: "too long"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "description longer than 1024 bytes not valid")
+}
+
+type mockBackend struct {
+	model        *mockDescribable
+	applications map[string]*mockDescribable
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) Model() (description.Describable, error) {
+	return b.model, nil
+}
+
+func (b *mockBackend) Application(name string) (description.Describable, error) {
+	application, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return application, nil
+}
+
+type mockDescribable struct {
+	testing.Stub
+	name        string
+	description string
+}
+
+func (d *mockDescribable) Description() string {
+	return d.description
+}
+
+func (d *mockDescribable) SetDescription(description string) error {
+	d.MethodCall(d, "SetDescription", description)
+	if err := d.NextErr(); err != nil {
+		return err
+	}
+	d.description = description
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+// MachineConsoles returns details of how to connect to consoles of the
+// instances of the given machines, as provided by the cloud. Serial
+// consoles are normally accessed through the controller's console
+// proxy rather than directly, since the cloud's console endpoints are
+// often not reachable from clients.
+func (mm *MachineManagerAPIV5) MachineConsoles(args params.MachineConsoleArgs) (params.MachineConsoleResults, error) {
+	return machineConsoles(mm.MachineManagerAPI, environs.GetEnviron, args)
+}
+
+func machineConsoles(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.MachineConsoleArgs,
+) (params.MachineConsoleResults, error) {
+	// Console access grants full control of the instance, so it is
+	// restricted to model administrators.
+	if err := mm.checkCanAdmin(); err != nil {
+		return params.MachineConsoleResults{}, err
+	}
+	results := params.MachineConsoleResults{
+		Results: make([]params.MachineConsoleResult, len(args.Args)),
+	}
+	if len(args.Args) == 0 {
+		return results, nil
+	}
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return params.MachineConsoleResults{}, errors.Trace(err)
+	}
+	env, err := getEnviron(backend, environs.New)
+	if err != nil {
+		return params.MachineConsoleResults{}, errors.Trace(err)
+	}
+	accessor, ok := env.(environs.InstanceConsoleAccessor)
+	for i, arg := range args.Args {
+		if !ok {
+			results.Results[i].Error = common.ServerError(
+				errors.NotSupportedf("instance consoles with this provider"),
+			)
+			continue
+		}
+		console, err := mm.machineConsole(accessor, arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = params.MachineConsoleResult{
+			Type: string(console.Type),
+			URL:  console.URL,
+		}
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) machineConsole(accessor environs.InstanceConsoleAccessor, arg params.MachineConsoleArg) (environs.InstanceConsole, error) {
+	consoleType := environs.InstanceConsoleType(arg.Type)
+	switch consoleType {
+	case environs.SerialConsole, environs.NoVNCConsole:
+	default:
+		return environs.InstanceConsole{}, errors.NotValidf("console type %q", arg.Type)
+	}
+	machineTag, err := names.ParseMachineTag(arg.Entity.Tag)
+	if err != nil {
+		return environs.InstanceConsole{}, errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return environs.InstanceConsole{}, errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return environs.InstanceConsole{}, errors.Trace(err)
+	}
+	console, err := accessor.InstanceConsole(instId, consoleType)
+	return console, errors.Trace(err)
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/permission"
+)
+
+// ConsoleLogs returns the console output of the instances of the
+// given machines, as reported by the cloud provider. This allows
+// failures during instance start-up, such as cloud-init errors, to be
+// investigated without access to the cloud's own console.
+func (mm *MachineManagerAPIV5) ConsoleLogs(args params.ConsoleLogArgs) (params.ConsoleLogResults, error) {
+	return consoleLogs(mm.MachineManagerAPI, environs.GetEnviron, args)
+}
+
+func consoleLogs(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.ConsoleLogArgs,
+) (params.ConsoleLogResults, error) {
+	// Console output frequently contains sensitive information, so
+	// it is restricted to model administrators.
+	if err := mm.checkCanAdmin(); err != nil {
+		return params.ConsoleLogResults{}, err
+	}
+	results := params.ConsoleLogResults{
+		Results: make([]params.ConsoleLogResult, len(args.Args)),
+	}
+	if len(args.Args) == 0 {
+		return results, nil
+	}
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return params.ConsoleLogResults{}, errors.Trace(err)
+	}
+	env, err := getEnviron(backend, environs.New)
+	if err != nil {
+		return params.ConsoleLogResults{}, errors.Trace(err)
+	}
+	consoleLogger, ok := env.(environs.InstanceConsoleLogger)
+	for i, arg := range args.Args {
+		if !ok {
+			results.Results[i].Error = common.ServerError(
+				errors.NotSupportedf("retrieving console output with this provider"),
+			)
+			continue
+		}
+		output, err := mm.consoleLog(consoleLogger, arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Output = output
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) consoleLog(consoleLogger environs.InstanceConsoleLogger, arg params.ConsoleLogArg) (string, error) {
+	machineTag, err := names.ParseMachineTag(arg.Entity.Tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	output, err := consoleLogger.InstanceConsoleLog(instId, arg.MaxLines)
+	return output, errors.Trace(err)
+}
+
+func (mm *MachineManagerAPI) checkCanAdmin() error {
+	isAdmin, err := mm.authorizer.HasPermission(permission.AdminAccess, mm.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+type consoleLogSuite struct {
+	backend    *consoleLogBackend
+	authorizer testing.FakeAuthorizer
+	env        *mockConsoleLogEnviron
+}
+
+var _ = gc.Suite(&consoleLogSuite{})
+
+func (s *consoleLogSuite) SetUpTest(c *gc.C) {
+	s.backend = &consoleLogBackend{
+		mockBackend: &mockBackend{},
+		machines: map[string]*mockMachine{
+			"0": {instId: "inst-0"},
+			"1": {},
+		},
+	}
+	s.authorizer = testing.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	s.env = &mockConsoleLogEnviron{
+		output: map[instance.Id]string{"inst-0": "cloud-init finished"},
+	}
+}
+
+func (s *consoleLogSuite) consoleLogs(c *gc.C, env environs.Environ, args params.ConsoleLogArgs) (params.ConsoleLogResults, error) {
+	api, err := machinemanager.NewMachineManagerAPI(s.backend, &mockPool{}, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.ConsoleLogs(api, getEnviron, args)
+}
+
+func (s *consoleLogSuite) TestConsoleLogs(c *gc.C) {
+	results, err := s.consoleLogs(c, s.env, params.ConsoleLogArgs{
+		Args: []params.ConsoleLogArg{
+			{Entity: params.Entity{Tag: "machine-0"}, MaxLines: 10},
+			{Entity: params.Entity{Tag: "machine-1"}},
+			{Entity: params.Entity{Tag: "machine-2"}},
+			{Entity: params.Entity{Tag: "application-foo"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ConsoleLogResults{
+		Results: []params.ConsoleLogResult{
+			{Output: "cloud-init finished"},
+			{Error: &params.Error{Message: "machine not provisioned", Code: params.CodeNotProvisioned}},
+			{Error: &params.Error{Message: "machine 2 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `"application-foo" is not a valid machine tag`}},
+		},
+	})
+	s.env.CheckCall(c, 0, "InstanceConsoleLog", instance.Id("inst-0"), 10)
+}
+
+func (s *consoleLogSuite) TestConsoleLogsNotSupported(c *gc.C) {
+	results, err := s.consoleLogs(c, &mockEnviron{}, params.ConsoleLogArgs{
+		Args: []params.ConsoleLogArg{{Entity: params.Entity{Tag: "machine-0"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "retrieving console output with this provider not supported")
+}
+
+func (s *consoleLogSuite) TestConsoleLogsPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.consoleLogs(c, s.env, params.ConsoleLogArgs{
+		Args: []params.ConsoleLogArg{{Entity: params.Entity{Tag: "machine-0"}}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type consoleLogBackend struct {
+	*mockBackend
+	machines map[string]*mockMachine
+}
+
+func (b *consoleLogBackend) Machine(id string) (machinemanager.Machine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %v", id)
+	}
+	return m, nil
+}
+
+type mockConsoleLogEnviron struct {
+	mockEnviron
+	output map[instance.Id]string
+}
+
+func (e *mockConsoleLogEnviron) InstanceConsoleLog(id instance.Id, maxLines int) (string, error) {
+	e.MethodCall(e, "InstanceConsoleLog", id, maxLines)
+	return e.output[id], e.NextErr()
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+type machineConsoleSuite struct {
+	backend    *consoleLogBackend
+	authorizer testing.FakeAuthorizer
+	env        *mockConsoleEnviron
+}
+
+var _ = gc.Suite(&machineConsoleSuite{})
+
+func (s *machineConsoleSuite) SetUpTest(c *gc.C) {
+	s.backend = &consoleLogBackend{
+		mockBackend: &mockBackend{},
+		machines: map[string]*mockMachine{
+			"0": {instId: "inst-0"},
+			"1": {},
+		},
+	}
+	s.authorizer = testing.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	s.env = &mockConsoleEnviron{}
+}
+
+func (s *machineConsoleSuite) machineConsoles(c *gc.C, env environs.Environ, args params.MachineConsoleArgs) (params.MachineConsoleResults, error) {
+	api, err := machinemanager.NewMachineManagerAPI(s.backend, &mockPool{}, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.MachineConsoles(api, getEnviron, args)
+}
+
+func (s *machineConsoleSuite) TestMachineConsoles(c *gc.C) {
+	results, err := s.machineConsoles(c, s.env, params.MachineConsoleArgs{
+		Args: []params.MachineConsoleArg{
+			{Entity: params.Entity{Tag: "machine-0"}, Type: "novnc"},
+			{Entity: params.Entity{Tag: "machine-0"}, Type: "spice"},
+			{Entity: params.Entity{Tag: "machine-1"}, Type: "serial"},
+			{Entity: params.Entity{Tag: "machine-2"}, Type: "serial"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MachineConsoleResults{
+		Results: []params.MachineConsoleResult{
+			{Type: "novnc", URL: "https://console.example.com/inst-0?token=novnc"},
+			{Error: &params.Error{Message: `console type "spice" not valid`}},
+			{Error: &params.Error{Message: "machine not provisioned", Code: params.CodeNotProvisioned}},
+			{Error: &params.Error{Message: "machine 2 not found", Code: params.CodeNotFound}},
+		},
+	})
+	s.env.CheckCalls(c, []jujutesting.StubCall{
+		{"InstanceConsole", []interface{}{instance.Id("inst-0"), environs.NoVNCConsole}},
+	})
+}
+
+func (s *machineConsoleSuite) TestMachineConsolesNotSupported(c *gc.C) {
+	results, err := s.machineConsoles(c, &mockEnviron{}, params.MachineConsoleArgs{
+		Args: []params.MachineConsoleArg{{Entity: params.Entity{Tag: "machine-0"}, Type: "serial"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "instance consoles with this provider not supported")
+}
+
+func (s *machineConsoleSuite) TestMachineConsolesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write")
+	_, err := s.machineConsoles(c, s.env, params.MachineConsoleArgs{
+		Args: []params.MachineConsoleArg{{Entity: params.Entity{Tag: "machine-0"}, Type: "serial"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockConsoleEnviron struct {
+	mockEnviron
+}
+
+func (e *mockConsoleEnviron) InstanceConsole(id instance.Id, consoleType environs.InstanceConsoleType) (environs.InstanceConsole, error) {
+	e.MethodCall(e, "InstanceConsole", id, consoleType)
+	return environs.InstanceConsole{
+		Type: consoleType,
+		URL:  "https://console.example.com/" + string(id) + "?token=" + string(consoleType),
+	}, e.NextErr()
+}
@@ -4,3 +4,7 @@
 package machinemanager
 
 var InstanceTypes = instanceTypes
+var ConsoleLogs = consoleLogs
+var MachineConsoles = machineConsoles
+var CloudInstances = cloudInstances
+var TagMachineInstances = tagMachineInstances
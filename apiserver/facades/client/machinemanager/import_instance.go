@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+)
+
+// CloudInstances returns details of the given cloud instances, as
+// reported by the provider, so that they may be imported into the
+// model as machines. Instances that already back a machine in the
+// model cannot be imported again.
+func (mm *MachineManagerAPIV5) CloudInstances(args params.CloudInstanceArgs) (params.CloudInstanceResults, error) {
+	return cloudInstances(mm.MachineManagerAPI, environs.GetEnviron, args)
+}
+
+// TagMachineInstances tags the cloud instances of the given machines
+// with the same tags that Juju sets on the instances it starts. It is
+// used to mark imported instances as being managed by Juju.
+func (mm *MachineManagerAPIV5) TagMachineInstances(args params.Entities) (params.ErrorResults, error) {
+	return tagMachineInstances(mm.MachineManagerAPI, environs.GetEnviron, args)
+}
+
+func cloudInstances(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.CloudInstanceArgs,
+) (params.CloudInstanceResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.CloudInstanceResults{}, err
+	}
+	results := params.CloudInstanceResults{
+		Results: make([]params.CloudInstanceResult, len(args.InstanceIds)),
+	}
+	if len(args.InstanceIds) == 0 {
+		return results, nil
+	}
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return params.CloudInstanceResults{}, errors.Trace(err)
+	}
+	env, err := getEnviron(backend, environs.New)
+	if err != nil {
+		return params.CloudInstanceResults{}, errors.Trace(err)
+	}
+	managed, err := mm.machineInstanceIds()
+	if err != nil {
+		return params.CloudInstanceResults{}, errors.Trace(err)
+	}
+	for i, id := range args.InstanceIds {
+		result, err := cloudInstance(env, managed, instance.Id(id))
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func cloudInstance(env environs.Environ, managed map[instance.Id]string, id instance.Id) (params.CloudInstanceResult, error) {
+	if id == "" {
+		return params.CloudInstanceResult{}, errors.NotValidf("empty instance id")
+	}
+	if machineId, ok := managed[id]; ok {
+		return params.CloudInstanceResult{}, errors.AlreadyExistsf("instance %q (machine %s)", id, machineId)
+	}
+	insts, err := env.Instances([]instance.Id{id})
+	if err == environs.ErrNoInstances {
+		return params.CloudInstanceResult{}, errors.NotFoundf("instance %q", id)
+	} else if err != nil {
+		return params.CloudInstanceResult{}, errors.Trace(err)
+	}
+	addrs, err := insts[0].Addresses()
+	if err != nil {
+		return params.CloudInstanceResult{}, errors.Annotatef(err, "getting addresses of instance %q", id)
+	}
+	return params.CloudInstanceResult{
+		InstanceId: string(id),
+		Status:     insts[0].Status().Message,
+		Addresses:  params.FromNetworkAddresses(addrs...),
+	}, nil
+}
+
+// machineInstanceIds returns the ids of the machines in the model,
+// keyed by the ids of their instances.
+func (mm *MachineManagerAPI) machineInstanceIds() (map[instance.Id]string, error) {
+	machines, err := mm.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make(map[instance.Id]string)
+	for _, m := range machines {
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids[instId] = m.Id()
+	}
+	return ids, nil
+}
+
+func tagMachineInstances(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.Entities,
+) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return results, nil
+	}
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	env, err := getEnviron(backend, environs.New)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	cfg, err := mm.st.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(cfg.UUID()),
+		names.NewControllerTag(mm.st.ControllerUUID()),
+		cfg,
+	)
+	tagger, ok := env.(environs.InstanceTagger)
+	for i, entity := range args.Entities {
+		if !ok {
+			results.Results[i].Error = common.ServerError(
+				errors.NotSupportedf("tagging instances with this provider"),
+			)
+			continue
+		}
+		machineTag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		instanceTags := make(map[string]string)
+		for k, v := range resourceTags {
+			instanceTags[k] = v
+		}
+		instanceTags[tags.JujuMachine] = fmt.Sprintf("%s-%s", cfg.Name(), machineTag.String())
+		err = mm.tagMachineInstance(tagger, machineTag, instanceTags)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) tagMachineInstance(tagger environs.InstanceTagger, tag names.MachineTag, instanceTags map[string]string) error {
+	machine, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(tagger.TagInstance(instId, instanceTags), "tagging instance %q", instId)
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+)
+
+const importControllerUUID = "deadbeef-1bad-500d-9000-4b1d0d06f00d"
+
+type importInstanceSuite struct {
+	backend    *importInstanceBackend
+	authorizer testing.FakeAuthorizer
+	env        *mockImportEnviron
+}
+
+var _ = gc.Suite(&importInstanceSuite{})
+
+func (s *importInstanceSuite) SetUpTest(c *gc.C) {
+	s.backend = &importInstanceBackend{
+		consoleLogBackend: &consoleLogBackend{
+			mockBackend: &mockBackend{},
+			machines: map[string]*mockMachine{
+				"0": {id: "0", instId: "inst-0"},
+				"1": {id: "1"},
+			},
+		},
+	}
+	s.authorizer = testing.FakeAuthorizer{Tag: names.NewUserTag("write")}
+	s.env = &mockImportEnviron{
+		instances: map[instance.Id]instance.Instance{
+			"inst-0": &mockInstance{id: "inst-0"},
+			"legacy-0": &mockInstance{
+				id:     "legacy-0",
+				status: "running",
+				addrs:  network.NewAddresses("10.0.0.5"),
+			},
+		},
+	}
+}
+
+func (s *importInstanceSuite) api(c *gc.C) *machinemanager.MachineManagerAPI {
+	api, err := machinemanager.NewMachineManagerAPI(s.backend, &mockPool{}, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *importInstanceSuite) getEnviron(env environs.Environ) func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+	return func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+}
+
+func (s *importInstanceSuite) TestCloudInstances(c *gc.C) {
+	results, err := machinemanager.CloudInstances(s.api(c), s.getEnviron(s.env), params.CloudInstanceArgs{
+		InstanceIds: []string{"legacy-0", "inst-0", "missing", ""},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.CloudInstanceResults{
+		Results: []params.CloudInstanceResult{
+			{
+				InstanceId: "legacy-0",
+				Status:     "running",
+				Addresses:  params.FromNetworkAddresses(network.NewAddresses("10.0.0.5")...),
+			},
+			{Error: &params.Error{Message: `instance "inst-0" (machine 0) already exists`, Code: params.CodeAlreadyExists}},
+			{Error: &params.Error{Message: `instance "missing" not found`, Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: "empty instance id not valid"}},
+		},
+	})
+}
+
+func (s *importInstanceSuite) TestCloudInstancesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := machinemanager.CloudInstances(s.api(c), s.getEnviron(s.env), params.CloudInstanceArgs{
+		InstanceIds: []string{"legacy-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *importInstanceSuite) TestTagMachineInstances(c *gc.C) {
+	results, err := machinemanager.TagMachineInstances(s.api(c), s.getEnviron(s.env), params.Entities{
+		Entities: []params.Entity{{"machine-0"}, {"machine-1"}, {"application-foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "machine not provisioned", Code: params.CodeNotProvisioned}},
+			{Error: &params.Error{Message: `"application-foo" is not a valid machine tag`}},
+		},
+	})
+
+	cfg, err := s.backend.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	s.env.CheckCalls(c, []jujutesting.StubCall{
+		{"TagInstance", []interface{}{instance.Id("inst-0"), map[string]string{
+			"juju-model-uuid":      cfg.UUID(),
+			"juju-controller-uuid": importControllerUUID,
+			"juju-machine-id":      cfg.Name() + "-machine-0",
+		}}},
+	})
+}
+
+func (s *importInstanceSuite) TestTagMachineInstancesNotSupported(c *gc.C) {
+	results, err := machinemanager.TagMachineInstances(s.api(c), s.getEnviron(&mockEnviron{}), params.Entities{
+		Entities: []params.Entity{{"machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "tagging instances with this provider not supported")
+}
+
+type importInstanceBackend struct {
+	*consoleLogBackend
+}
+
+func (b *importInstanceBackend) AllMachines() ([]machinemanager.Machine, error) {
+	machines := make([]machinemanager.Machine, 0, len(b.machines))
+	for _, m := range b.machines {
+		machines = append(machines, m)
+	}
+	return machines, nil
+}
+
+func (b *importInstanceBackend) ControllerUUID() string {
+	return importControllerUUID
+}
+
+func (b *importInstanceBackend) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, dummy.SampleConfig())
+}
+
+func (b *importInstanceBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	return nil, false, nil
+}
+
+type mockImportEnviron struct {
+	mockEnviron
+	instances map[instance.Id]instance.Instance
+}
+
+func (e *mockImportEnviron) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	e.MethodCall(e, "Instances", ids)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	insts := make([]instance.Instance, len(ids))
+	found := false
+	for i, id := range ids {
+		if inst, ok := e.instances[id]; ok {
+			insts[i] = inst
+			found = true
+		}
+	}
+	if !found {
+		return nil, environs.ErrNoInstances
+	}
+	return insts, nil
+}
+
+func (e *mockImportEnviron) TagInstance(id instance.Id, tags map[string]string) error {
+	e.MethodCall(e, "TagInstance", id, tags)
+	return e.NextErr()
+}
+
+type mockInstance struct {
+	instance.Instance
+	id     instance.Id
+	status string
+	addrs  []network.Address
+}
+
+func (i *mockInstance) Id() instance.Id {
+	return i.id
+}
+
+func (i *mockInstance) Status() instance.InstanceStatus {
+	return instance.InstanceStatus{Message: i.status}
+}
+
+func (i *mockInstance) Addresses() ([]network.Address, error) {
+	return i.addrs, nil
+}
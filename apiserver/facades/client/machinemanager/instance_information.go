@@ -25,22 +25,11 @@ func instanceTypes(mm *MachineManagerAPI,
 	getEnviron environGetFunc,
 	cons params.ModelInstanceTypesConstraints,
 ) (params.InstanceTypesResults, error) {
-	model, err := mm.st.Model()
+	backend, err := mm.environConfigGetter()
 	if err != nil {
 		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
 
-	cloudSpec := func() (environs.CloudSpec, error) {
-		cloudName := model.Cloud()
-		regionName := model.CloudRegion()
-		credentialTag, _ := model.CloudCredential()
-		return stateenvirons.CloudSpec(mm.st, cloudName, regionName, credentialTag)
-	}
-	backend := common.EnvironConfigGetterFuncs{
-		CloudSpecFunc:   cloudSpec,
-		ModelConfigFunc: model.Config,
-	}
-
 	env, err := getEnviron(backend, environs.New)
 	result := make([]params.InstanceTypesResult, len(cons.Constraints))
 	// TODO(perrito666) Cache the results to avoid excessive querying of the cloud.
@@ -59,3 +48,22 @@ func instanceTypes(mm *MachineManagerAPI,
 
 	return params.InstanceTypesResults{Results: result}, nil
 }
+
+// environConfigGetter returns an environs.EnvironConfigGetter for the
+// model the facade is serving.
+func (mm *MachineManagerAPI) environConfigGetter() (environs.EnvironConfigGetter, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudSpec := func() (environs.CloudSpec, error) {
+		cloudName := model.Cloud()
+		regionName := model.CloudRegion()
+		credentialTag, _ := model.CloudCredential()
+		return stateenvirons.CloudSpec(mm.st, cloudName, regionName, credentialTag)
+	}
+	return common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}, nil
+}
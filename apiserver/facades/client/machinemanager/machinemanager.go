@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/deploypolicy"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -57,6 +58,45 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	return &MachineManagerAPIV4{machineManagerAPI}, nil
 }
 
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+}
+
+// NewFacadeV5 creates a new server-side MachineManager API facade.
+func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV4, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV4}, nil
+}
+
+type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV5
+}
+
+// NewFacadeV6 creates a new server-side MachineManager API facade.
+func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIV5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIV5}, nil
+}
+
+type MachineManagerAPIV7 struct {
+	*MachineManagerAPIV6
+}
+
+// NewFacadeV7 creates a new server-side MachineManager API facade.
+func NewFacadeV7(ctx facade.Context) (*MachineManagerAPIV7, error) {
+	machineManagerAPIV6, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV7{machineManagerAPIV6}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
@@ -70,6 +110,17 @@ func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*
 	}, nil
 }
 
+func (mm *MachineManagerAPI) checkCanRead() error {
+	canRead, err := mm.authorizer.HasPermission(permission.ReadAccess, mm.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (mm *MachineManagerAPI) checkCanWrite() error {
 	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
 	if err != nil {
@@ -119,6 +170,10 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		}
 	}
 
+	if p.ContainerType != "" && (p.ImageStream != "" || p.ImageId != "") {
+		return nil, errors.New("image stream and image id cannot be specified for containers")
+	}
+
 	if p.ContainerType != "" || p.Placement != nil {
 		// Guard against dubious client by making sure that
 		// the following attributes can only be set when we're
@@ -137,6 +192,13 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		p.Series = config.PreferredSeries(conf)
 	}
 
+	if err := deploypolicy.Check(mm.st, deploypolicy.Deployment{
+		Series:      p.Series,
+		Constraints: p.Constraints,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	var placementDirective string
 	if p.Placement != nil {
 		model, err := mm.st.Model()
@@ -183,6 +245,8 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
+		ImageStream:             p.ImageStream,
+		ImageId:                 p.ImageId,
 	}
 	if p.ContainerType == "" {
 		return mm.st.AddOneMachine(template)
@@ -14,8 +14,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -97,6 +101,62 @@ func (s *MachineManagerSuite) TestAddMachines(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestAddMachinesWithImage(c *gc.C) {
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:      "trusty",
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			ImageStream: "daily",
+			ImageId:     "ami-0123",
+		}, {
+			Series:        "trusty",
+			Jobs:          []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			ContainerType: instance.LXD,
+			ImageStream:   "daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 2)
+	c.Assert(results.Machines[0].Error, gc.IsNil)
+	c.Assert(results.Machines[1].Error, gc.ErrorMatches, "image stream and image id cannot be specified for containers")
+	c.Assert(s.st.calls, gc.Equals, 1)
+	c.Assert(s.st.machineTemplates, jc.DeepEquals, []state.MachineTemplate{{
+		Series:      "trusty",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Volumes:     []state.MachineVolumeParams{},
+		ImageStream: "daily",
+		ImageId:     "ami-0123",
+	}})
+}
+
+func (s *MachineManagerSuite) TestAddMachinesDeployPolicy(c *gc.C) {
+	s.st.controllerConfig = controller.Config{
+		controller.DeployDeniedSeries:       "precise",
+		controller.DeployMinimumConstraints: "mem=4G",
+	}
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:      "precise",
+			Constraints: constraints.MustParse("mem=8G"),
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}, {
+			Series:      "trusty",
+			Constraints: constraints.MustParse("mem=2G"),
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}, {
+			Series:      "trusty",
+			Constraints: constraints.MustParse("mem=8G"),
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 3)
+	c.Assert(results.Machines[0].Error, gc.ErrorMatches, `deployment policy: series "precise" is not allowed`)
+	c.Assert(results.Machines[1].Error, gc.ErrorMatches, `deployment policy: mem constraint of 2048M below minimum of 4096M`)
+	c.Assert(results.Machines[2].Error, gc.IsNil)
+	c.Assert(s.st.calls, gc.Equals, 1)
+}
+
 func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	tag := names.NewUnitTag("mysql/0")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
@@ -321,6 +381,19 @@ type mockState struct {
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	controllerConfig controller.Config
+}
+
+func (st *mockState) ControllerConfig() (controller.Config, error) {
+	cfg := coretesting.FakeControllerConfig()
+	for key, value := range st.controllerConfig {
+		cfg[key] = value
+	}
+	return cfg, nil
+}
+
+func (st *mockState) ModelConstraints() (constraints.Value, error) {
+	return constraints.Value{}, nil
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -415,8 +488,17 @@ type mockMachine struct {
 	jtesting.Stub
 	machinemanager.Machine
 
-	keep   bool
-	series string
+	id         string
+	keep       bool
+	series     string
+	instId     instance.Id
+	life       state.Life
+	instStatus status.StatusInfo
+	containers []string
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
 }
 
 func (m *mockMachine) Destroy() error {
@@ -450,6 +532,26 @@ func (m *mockMachine) UpdateMachineSeries(series string, force bool) error {
 	return m.NextErr()
 }
 
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	m.MethodCall(m, "InstanceId")
+	if m.instId == "" {
+		return "", errors.NotProvisionedf("machine")
+	}
+	return m.instId, m.NextErr()
+}
+
+func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
+	return m.instStatus, nil
+}
+
+func (m *mockMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *mockMachine) Containers() ([]string, error) {
+	return m.containers, nil
+}
+
 type mockUnit struct {
 	tag names.UnitTag
 }
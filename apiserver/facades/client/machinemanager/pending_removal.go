@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// PendingRemovals reports the dying and dead machines in the model,
+// and what the removal of each is waiting on: units yet to run their
+// stop hooks, storage yet to be detached, containers yet to be removed,
+// and the deletion of the provider instance, along with the last error
+// the provisioner encountered deleting it.
+func (mm *MachineManagerAPIV6) PendingRemovals() (params.PendingRemovalResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.PendingRemovalResults{}, err
+	}
+	machines, err := mm.st.AllMachines()
+	if err != nil {
+		return params.PendingRemovalResults{}, errors.Trace(err)
+	}
+	removals, err := mm.st.AllMachineRemovals()
+	if err != nil {
+		return params.PendingRemovalResults{}, errors.Trace(err)
+	}
+	marked := set.NewStrings(removals...)
+
+	results := params.PendingRemovalResults{
+		Results: []params.PendingRemoval{},
+	}
+	for _, machine := range machines {
+		if machine.Life() == state.Alive {
+			continue
+		}
+		result, err := mm.pendingRemoval(machine)
+		if errors.IsNotFound(err) {
+			// The machine was removed since it was listed.
+			continue
+		} else if err != nil {
+			return params.PendingRemovalResults{}, errors.Annotatef(err, "machine %q", machine.Id())
+		}
+		result.MarkedForRemoval = marked.Contains(machine.Id())
+		results.Results = append(results.Results, result)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) pendingRemoval(machine Machine) (params.PendingRemoval, error) {
+	machineTag := names.NewMachineTag(machine.Id())
+	result := params.PendingRemoval{
+		MachineTag: machineTag.String(),
+		Life:       params.Life(machine.Life().String()),
+	}
+
+	instId, err := machine.InstanceId()
+	if err == nil {
+		result.InstanceId = string(instId)
+	} else if !errors.IsNotProvisioned(err) {
+		return params.PendingRemoval{}, errors.Trace(err)
+	}
+	instStatus, err := machine.InstanceStatus()
+	if err == nil {
+		result.InstanceStatus = string(instStatus.Status)
+		result.InstanceMessage = instStatus.Message
+	} else if !errors.IsNotFound(err) {
+		return params.PendingRemoval{}, errors.Trace(err)
+	}
+
+	units, err := machine.Units()
+	if err != nil {
+		return params.PendingRemoval{}, errors.Trace(err)
+	}
+	for _, unit := range units {
+		result.Units = append(result.Units, params.Entity{unit.UnitTag().String()})
+	}
+	containers, err := machine.Containers()
+	if err != nil {
+		return params.PendingRemoval{}, errors.Trace(err)
+	}
+	for _, id := range containers {
+		result.Containers = append(result.Containers, params.Entity{names.NewMachineTag(id).String()})
+	}
+
+	volumeAttachments, err := mm.st.MachineVolumeAttachments(machineTag)
+	if err != nil {
+		return params.PendingRemoval{}, errors.Trace(err)
+	}
+	for _, att := range volumeAttachments {
+		result.Volumes = append(result.Volumes, params.Entity{att.Volume().String()})
+	}
+	filesystemAttachments, err := mm.st.MachineFilesystemAttachments(machineTag)
+	if err != nil {
+		return params.PendingRemoval{}, errors.Trace(err)
+	}
+	for _, att := range filesystemAttachments {
+		result.Filesystems = append(result.Filesystems, params.Entity{att.Filesystem().String()})
+	}
+	return result, nil
+}
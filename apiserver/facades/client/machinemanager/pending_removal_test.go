@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type pendingRemovalSuite struct {
+	backend    *pendingRemovalBackend
+	authorizer testing.FakeAuthorizer
+}
+
+var _ = gc.Suite(&pendingRemovalSuite{})
+
+func (s *pendingRemovalSuite) SetUpTest(c *gc.C) {
+	s.backend = &pendingRemovalBackend{
+		mockBackend: &mockBackend{},
+		machines: []*mockMachine{
+			{id: "0", instId: "inst-0"},
+			{
+				id:     "1",
+				instId: "inst-1",
+				life:   state.Dead,
+				instStatus: status.StatusInfo{
+					Status:  status.Destroying,
+					Message: "stopping instance failed: instance locked",
+				},
+				containers: []string{"1/lxd/0"},
+			},
+		},
+		removals: []string{"1"},
+		volumes: map[string][]state.VolumeAttachment{
+			"1": {&mockVolumeAttachment{volume: names.NewVolumeTag("1/0")}},
+		},
+		filesystems: map[string][]state.FilesystemAttachment{
+			"1": {&mockFilesystemAttachment{filesystem: names.NewFilesystemTag("2")}},
+		},
+	}
+	s.authorizer = testing.FakeAuthorizer{Tag: names.NewUserTag("read")}
+}
+
+func (s *pendingRemovalSuite) api(c *gc.C) *machinemanager.MachineManagerAPIV6 {
+	api, err := machinemanager.NewMachineManagerAPI(s.backend, &mockPool{}, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return &machinemanager.MachineManagerAPIV6{
+		&machinemanager.MachineManagerAPIV5{
+			&machinemanager.MachineManagerAPIV4{api},
+		},
+	}
+}
+
+func (s *pendingRemovalSuite) TestPendingRemovals(c *gc.C) {
+	results, err := s.api(c).PendingRemovals()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.PendingRemovalResults{
+		Results: []params.PendingRemoval{{
+			MachineTag:      "machine-1",
+			Life:            params.Dead,
+			InstanceId:      "inst-1",
+			InstanceStatus:  "destroying",
+			InstanceMessage: "stopping instance failed: instance locked",
+			Units: []params.Entity{
+				{"unit-foo-0"}, {"unit-foo-1"}, {"unit-foo-2"},
+			},
+			Containers:       []params.Entity{{"machine-1-lxd-0"}},
+			Volumes:          []params.Entity{{"volume-1-0"}},
+			Filesystems:      []params.Entity{{"filesystem-2"}},
+			MarkedForRemoval: true,
+		}},
+	})
+}
+
+func (s *pendingRemovalSuite) TestPendingRemovalsNone(c *gc.C) {
+	s.backend.machines = s.backend.machines[:1]
+	results, err := s.api(c).PendingRemovals()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
+
+func (s *pendingRemovalSuite) TestPendingRemovalsNotProvisioned(c *gc.C) {
+	s.backend.machines[1].instId = ""
+	s.backend.removals = nil
+	results, err := s.api(c).PendingRemovals()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].InstanceId, gc.Equals, "")
+	c.Assert(results.Results[0].MarkedForRemoval, jc.IsFalse)
+}
+
+func (s *pendingRemovalSuite) TestPendingRemovalsPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.api(c).PendingRemovals()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type pendingRemovalBackend struct {
+	*mockBackend
+	machines    []*mockMachine
+	removals    []string
+	volumes     map[string][]state.VolumeAttachment
+	filesystems map[string][]state.FilesystemAttachment
+}
+
+func (b *pendingRemovalBackend) AllMachines() ([]machinemanager.Machine, error) {
+	machines := make([]machinemanager.Machine, len(b.machines))
+	for i, m := range b.machines {
+		machines[i] = m
+	}
+	return machines, nil
+}
+
+func (b *pendingRemovalBackend) AllMachineRemovals() ([]string, error) {
+	return b.removals, nil
+}
+
+func (b *pendingRemovalBackend) MachineVolumeAttachments(tag names.MachineTag) ([]state.VolumeAttachment, error) {
+	return b.volumes[tag.Id()], nil
+}
+
+func (b *pendingRemovalBackend) MachineFilesystemAttachments(tag names.MachineTag) ([]state.FilesystemAttachment, error) {
+	return b.filesystems[tag.Id()], nil
+}
+
+type mockVolumeAttachment struct {
+	state.VolumeAttachment
+	volume names.VolumeTag
+}
+
+func (a *mockVolumeAttachment) Volume() names.VolumeTag {
+	return a.volume
+}
+
+type mockFilesystemAttachment struct {
+	state.FilesystemAttachment
+	filesystem names.FilesystemTag
+}
+
+func (a *mockFilesystemAttachment) Filesystem() names.FilesystemTag {
+	return a.filesystem
+}
@@ -8,9 +8,12 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 )
 
 type Backend interface {
@@ -18,13 +21,20 @@ type Backend interface {
 	state.CloudAccessor
 
 	Machine(string) (Machine, error)
+	AllMachines() ([]Machine, error)
+	ControllerUUID() string
+	ControllerConfig() (controller.Config, error)
 	ModelConfig() (*config.Config, error)
+	ModelConstraints() (constraints.Value, error)
 	Model() (Model, error)
 	ModelTag() names.ModelTag
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	AllMachineRemovals() ([]string, error)
+	MachineVolumeAttachments(names.MachineTag) ([]state.VolumeAttachment, error)
+	MachineFilesystemAttachments(names.MachineTag) ([]state.FilesystemAttachment, error)
 }
 
 type Pool interface {
@@ -41,12 +51,17 @@ type Model interface {
 }
 
 type Machine interface {
+	Id() string
 	Destroy() error
 	ForceDestroy() error
 	Series() string
 	Units() ([]Unit, error)
 	SetKeepInstance(keepInstance bool) error
 	UpdateMachineSeries(string, bool) error
+	InstanceId() (instance.Id, error)
+	InstanceStatus() (status.StatusInfo, error)
+	Life() state.Life
+	Containers() ([]string, error)
 }
 
 type stateShim struct {
@@ -62,6 +77,18 @@ func (s stateShim) Machine(name string) (Machine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) AllMachines() ([]Machine, error) {
+	all, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	machines := make([]Machine, len(all))
+	for i, m := range all {
+		machines[i] = machineShim{m}
+	}
+	return machines, nil
+}
+
 func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
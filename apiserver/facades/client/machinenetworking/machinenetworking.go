@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinenetworking provides the API for querying the
+// link-layer network devices and IP addresses of machines, as
+// discovered from the provider and observed by the machine agents.
+package machinenetworking
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality required by API.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Machine(id string) (Machine, error)
+}
+
+// Machine exposes the machine functionality required by API.
+type Machine interface {
+	AllLinkLayerDevices() ([]LinkLayerDevice, error)
+	AllAddresses() ([]Address, error)
+}
+
+// LinkLayerDevice exposes the link-layer device functionality required
+// by API.
+type LinkLayerDevice interface {
+	Name() string
+	Type() state.LinkLayerDeviceType
+	MACAddress() string
+	MTU() uint
+	ProviderID() network.Id
+	ParentName() string
+	IsAutoStart() bool
+	IsUp() bool
+}
+
+// Address exposes the IP address functionality required by API.
+type Address interface {
+	DeviceName() string
+	Value() string
+	SubnetCIDR() string
+	ConfigMethod() state.AddressConfigMethod
+	ProviderID() network.Id
+	GatewayAddress() string
+}
+
+// API provides access to the MachineNetworking API facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, authorizer)
+}
+
+// NewAPI returns a new MachineNetworking API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// LinkLayerDevices returns the link-layer network devices of the given
+// machines, ordered by name, with the IP addresses assigned to each.
+func (api *API) LinkLayerDevices(args params.Entities) (params.MachineLinkLayerDevicesResults, error) {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return params.MachineLinkLayerDevicesResults{}, errors.Trace(err)
+	}
+	if !ok {
+		return params.MachineLinkLayerDevicesResults{}, common.ErrPerm
+	}
+	results := make([]params.MachineLinkLayerDevicesResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		devices, err := api.linkLayerDevices(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Devices = devices
+	}
+	return params.MachineLinkLayerDevicesResults{results}, nil
+}
+
+func (api *API) linkLayerDevices(tagString string) ([]params.LinkLayerDeviceInfo, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	devices, err := machine.AllLinkLayerDevices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addresses, err := machine.AllAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	deviceAddresses := make(map[string][]params.LinkLayerDeviceAddressInfo)
+	for _, addr := range addresses {
+		deviceAddresses[addr.DeviceName()] = append(deviceAddresses[addr.DeviceName()], params.LinkLayerDeviceAddressInfo{
+			Value:          addr.Value(),
+			CIDR:           addr.SubnetCIDR(),
+			ConfigMethod:   string(addr.ConfigMethod()),
+			ProviderId:     string(addr.ProviderID()),
+			GatewayAddress: addr.GatewayAddress(),
+		})
+	}
+	result := make([]params.LinkLayerDeviceInfo, len(devices))
+	for i, dev := range devices {
+		result[i] = params.LinkLayerDeviceInfo{
+			Name:        dev.Name(),
+			Type:        string(dev.Type()),
+			MACAddress:  dev.MACAddress(),
+			MTU:         dev.MTU(),
+			ProviderId:  string(dev.ProviderID()),
+			ParentName:  dev.ParentName(),
+			IsAutoStart: dev.IsAutoStart(),
+			IsUp:        dev.IsUp(),
+			Addresses:   deviceAddresses[dev.Name()],
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinenetworking_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/machinenetworking"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type machineNetworkingSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&machineNetworkingSuite{})
+
+func (s *machineNetworkingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{
+			"0": {
+				devices: []machinenetworking.LinkLayerDevice{
+					&mockDevice{
+						name:       "eth0",
+						deviceType: state.EthernetDevice,
+						mac:        "aa:bb:cc:dd:ee:f0",
+						mtu:        1500,
+						providerId: "nic-0",
+						parentName: "br-eth0",
+						autoStart:  true,
+						up:         true,
+					},
+					&mockDevice{
+						name:       "br-eth0",
+						deviceType: state.BridgeDevice,
+						mac:        "aa:bb:cc:dd:ee:f0",
+						autoStart:  true,
+						up:         true,
+					},
+				},
+				addresses: []machinenetworking.Address{
+					&mockAddress{
+						deviceName:   "br-eth0",
+						value:        "10.0.0.4",
+						cidr:         "10.0.0.0/24",
+						configMethod: state.StaticAddress,
+						providerId:   "ip-0",
+						gateway:      "10.0.0.1",
+					},
+				},
+			},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *machineNetworkingSuite) newAPI(c *gc.C) *machinenetworking.API {
+	api, err := machinenetworking.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *machineNetworkingSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := machinenetworking.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *machineNetworkingSuite) TestLinkLayerDevices(c *gc.C) {
+	results, err := s.newAPI(c).LinkLayerDevices(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MachineLinkLayerDevicesResults{
+		Results: []params.MachineLinkLayerDevicesResult{{
+			Devices: []params.LinkLayerDeviceInfo{{
+				Name:        "br-eth0",
+				Type:        "bridge",
+				MACAddress:  "aa:bb:cc:dd:ee:f0",
+				IsAutoStart: true,
+				IsUp:        true,
+				Addresses: []params.LinkLayerDeviceAddressInfo{{
+					Value:          "10.0.0.4",
+					CIDR:           "10.0.0.0/24",
+					ConfigMethod:   "static",
+					ProviderId:     "ip-0",
+					GatewayAddress: "10.0.0.1",
+				}},
+			}, {
+				Name:        "eth0",
+				Type:        "ethernet",
+				MACAddress:  "aa:bb:cc:dd:ee:f0",
+				MTU:         1500,
+				ProviderId:  "nic-0",
+				ParentName:  "br-eth0",
+				IsAutoStart: true,
+				IsUp:        true,
+			}},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `machine 1 not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-foo-0" is not a valid machine tag`,
+			},
+		}},
+	})
+}
+
+func (s *machineNetworkingSuite) TestLinkLayerDevicesError(c *gc.C) {
+	s.backend.machines["0"].SetErrors(errors.New("boom"))
+	results, err := s.newAPI(c).LinkLayerDevices(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *machineNetworkingSuite) TestLinkLayerDevicesRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).LinkLayerDevices(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Machine(id string) (machinenetworking.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	testing.Stub
+	devices   []machinenetworking.LinkLayerDevice
+	addresses []machinenetworking.Address
+}
+
+func (m *mockMachine) AllLinkLayerDevices() ([]machinenetworking.LinkLayerDevice, error) {
+	m.MethodCall(m, "AllLinkLayerDevices")
+	return m.devices, m.NextErr()
+}
+
+func (m *mockMachine) AllAddresses() ([]machinenetworking.Address, error) {
+	m.MethodCall(m, "AllAddresses")
+	return m.addresses, m.NextErr()
+}
+
+type mockDevice struct {
+	name       string
+	deviceType state.LinkLayerDeviceType
+	mac        string
+	mtu        uint
+	providerId network.Id
+	parentName string
+	autoStart  bool
+	up         bool
+}
+
+func (d *mockDevice) Name() string                    { return d.name }
+func (d *mockDevice) Type() state.LinkLayerDeviceType { return d.deviceType }
+func (d *mockDevice) MACAddress() string              { return d.mac }
+func (d *mockDevice) MTU() uint                       { return d.mtu }
+func (d *mockDevice) ProviderID() network.Id          { return d.providerId }
+func (d *mockDevice) ParentName() string              { return d.parentName }
+func (d *mockDevice) IsAutoStart() bool               { return d.autoStart }
+func (d *mockDevice) IsUp() bool                      { return d.up }
+
+type mockAddress struct {
+	deviceName   string
+	value        string
+	cidr         string
+	configMethod state.AddressConfigMethod
+	providerId   network.Id
+	gateway      string
+}
+
+func (a *mockAddress) DeviceName() string                      { return a.deviceName }
+func (a *mockAddress) Value() string                           { return a.value }
+func (a *mockAddress) SubnetCIDR() string                      { return a.cidr }
+func (a *mockAddress) ConfigMethod() state.AddressConfigMethod { return a.configMethod }
+func (a *mockAddress) ProviderID() network.Id                  { return a.providerId }
+func (a *mockAddress) GatewayAddress() string                  { return a.gateway }
@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinenetworking
+
+import (
+	"github.com/juju/juju/state"
+)
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, err
+	}
+	return machineShim{m}, nil
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+func (m machineShim) AllLinkLayerDevices() ([]LinkLayerDevice, error) {
+	devices, err := m.Machine.AllLinkLayerDevices()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LinkLayerDevice, len(devices))
+	for i, dev := range devices {
+		out[i] = dev
+	}
+	return out, nil
+}
+
+func (m machineShim) AllAddresses() ([]Address, error) {
+	addresses, err := m.Machine.AllAddresses()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Address, len(addresses))
+	for i, addr := range addresses {
+		out[i] = addr
+	}
+	return out, nil
+}
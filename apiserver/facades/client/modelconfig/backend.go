@@ -18,7 +18,10 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
-	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	UpdateModelConfigBy(names.UserTag, map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	ModelConfigHistory() ([]state.ModelConfigRevision, error)
+	ModelConfigChangesSince(revision int) ([]state.ItemChange, error)
+	RollbackModelConfig(user names.UserTag, revision int, additionalValidation ...state.ValidateConfigFunc) error
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
 }
@@ -6,6 +6,7 @@ package modelconfig
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -24,6 +25,15 @@ func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Mo
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelConfigAPIV2, error) {
+	api, err := NewFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // ModelConfigAPI is the endpoint which implements the model config facade.
 type ModelConfigAPI struct {
 	backend Backend
@@ -31,6 +41,12 @@ type ModelConfigAPI struct {
 	check   *common.BlockChecker
 }
 
+// ModelConfigAPIV2 implements version 2 of the model config facade,
+// which adds the model config history methods.
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPI
+}
+
 // NewModelConfigAPI creates a new instance of the ModelConfig Facade.
 func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPI, error) {
 	if !authorizer.AuthClient() {
@@ -130,40 +146,49 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		}
 		return nil
 	}
-	// Only controller admins can set trace level debugging on a model.
-	checkLogTrace := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
-		spec, ok := updateAttrs["logging-config"]
-		if !ok {
-			return nil
+
+	// Replace any deprecated attributes with their new values.
+	attrs := config.ProcessDeprecatedAttributes(args.Config)
+	return c.backend.UpdateModelConfigBy(c.authUser(), attrs, nil, checkAgentVersion, c.checkLogTrace)
+}
+
+// checkLogTrace ensures that only controller admins can set trace
+// level debugging on a model.
+func (c *ModelConfigAPI) checkLogTrace(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+	spec, ok := updateAttrs["logging-config"]
+	if !ok {
+		return nil
+	}
+	logCfg, err := loggo.ParseConfigString(spec.(string))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Does at least one package have TRACE level logging requested.
+	haveTrace := false
+	for _, level := range logCfg {
+		haveTrace = level == loggo.TRACE
+		if haveTrace {
+			break
 		}
-		logCfg, err := loggo.ParseConfigString(spec.(string))
-		if err != nil {
+	}
+	// No TRACE level requested, so no need to check for admin.
+	if !haveTrace {
+		return nil
+	}
+	if err := c.isControllerAdmin(); err != nil {
+		if errors.Cause(err) != common.ErrPerm {
 			return errors.Trace(err)
 		}
-		// Does at least one package have TRACE level logging requested.
-		haveTrace := false
-		for _, level := range logCfg {
-			haveTrace = level == loggo.TRACE
-			if haveTrace {
-				break
-			}
-		}
-		// No TRACE level requested, so no need to check for admin.
-		if !haveTrace {
-			return nil
-		}
-		if err := c.isControllerAdmin(); err != nil {
-			if errors.Cause(err) != common.ErrPerm {
-				return errors.Trace(err)
-			}
-			return errors.New("only controller admins can set a model's logging level to TRACE")
-		}
-		return nil
+		return errors.New("only controller admins can set a model's logging level to TRACE")
 	}
+	return nil
+}
 
-	// Replace any deprecated attributes with their new values.
-	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+// authUser returns the user making the API call, recorded in the
+// model config history as having made any change.
+func (c *ModelConfigAPI) authUser() names.UserTag {
+	user, _ := c.auth.GetAuthTag().(names.UserTag)
+	return user
 }
 
 // ModelUnset implements the server-side part of the
@@ -175,7 +200,7 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys)
+	return c.backend.UpdateModelConfigBy(c.authUser(), nil, args.Keys)
 }
 
 // SetSLALevel sets the sla level on the model.
@@ -197,3 +222,75 @@ func (c *ModelConfigAPI) SLALevel() (params.StringResult, error) {
 	result.Result = level
 	return result, nil
 }
+
+// ModelConfigHistory returns the recorded changes to the model's
+// config, oldest first.
+func (c *ModelConfigAPIV2) ModelConfigHistory() (params.ModelConfigHistoryResult, error) {
+	result := params.ModelConfigHistoryResult{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+	history, err := c.backend.ModelConfigHistory()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Revisions = make([]params.ModelConfigRevision, len(history))
+	for i, rev := range history {
+		result.Revisions[i] = params.ModelConfigRevision{
+			Revision: rev.Revision,
+			User:     rev.User,
+			Time:     rev.Time,
+			Changes:  modelConfigChanges(rev.Changes),
+		}
+	}
+	return result, nil
+}
+
+// ModelConfigDiff returns the differences between the model's config
+// at the given revision and its current config.
+func (c *ModelConfigAPIV2) ModelConfigDiff(arg params.ModelConfigRevisionArg) (params.ModelConfigChangesResult, error) {
+	result := params.ModelConfigChangesResult{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+	changes, err := c.backend.ModelConfigChangesSince(arg.Revision)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Changes = modelConfigChanges(changes)
+	return result, nil
+}
+
+// ModelConfigRollback restores the model's config to how it was at
+// the given revision.
+func (c *ModelConfigAPIV2) ModelConfigRollback(arg params.ModelConfigRevisionArg) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.backend.RollbackModelConfig(c.authUser(), arg.Revision, c.checkLogTrace)
+}
+
+func modelConfigChanges(changes []state.ItemChange) []params.ModelConfigChange {
+	result := make([]params.ModelConfigChange, len(changes))
+	for i, change := range changes {
+		var changeType string
+		switch change.Type {
+		case state.ItemAdded:
+			changeType = "added"
+		case state.ItemModified:
+			changeType = "modified"
+		case state.ItemDeleted:
+			changeType = "deleted"
+		}
+		result[i] = params.ModelConfigChange{
+			Type:     changeType,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		}
+	}
+	return result
+}
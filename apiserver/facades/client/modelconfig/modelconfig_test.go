@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -176,7 +178,7 @@ func (s *modelconfigSuite) TestUserCannotSetLogTrace(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigBy(names.UserTag{}, map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.ModelUnset{[]string{"abc"}}
@@ -186,7 +188,7 @@ func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestBlockModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigBy(names.UserTag{}, map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.blockAllChanges(c, "TestBlockModelUnset")
 
@@ -207,18 +209,92 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetRecordsUser(c *gc.C) {
+	err := s.api.ModelSet(params.ModelSet{map[string]interface{}{"some-key": "value"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.user, gc.Equals, names.NewUserTag("bruce@local"))
+}
+
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.history = []state.ModelConfigRevision{{
+		Revision: 1,
+		User:     "bob",
+		Time:     now,
+		Changes: []state.ItemChange{
+			{Type: state.ItemAdded, Key: "some-key", NewValue: "value"},
+			{Type: state.ItemModified, Key: "ftp-proxy", OldValue: "http://old", NewValue: "http://proxy"},
+		},
+	}}
+	api := &modelconfig.ModelConfigAPIV2{s.api}
+	result, err := api.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelConfigHistoryResult{
+		Revisions: []params.ModelConfigRevision{{
+			Revision: 1,
+			User:     "bob",
+			Time:     now,
+			Changes: []params.ModelConfigChange{
+				{Type: "added", Key: "some-key", NewValue: "value"},
+				{Type: "modified", Key: "ftp-proxy", OldValue: "http://old", NewValue: "http://proxy"},
+			},
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestModelConfigDiff(c *gc.C) {
+	s.backend.changes = []state.ItemChange{
+		{Type: state.ItemDeleted, Key: "some-key", OldValue: "value"},
+	}
+	api := &modelconfig.ModelConfigAPIV2{s.api}
+	result, err := api.ModelConfigDiff(params.ModelConfigRevisionArg{Revision: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.revision, gc.Equals, 3)
+	c.Assert(result.Changes, jc.DeepEquals, []params.ModelConfigChange{
+		{Type: "deleted", Key: "some-key", OldValue: "value"},
+	})
+}
+
+func (s *modelconfigSuite) TestModelConfigRollback(c *gc.C) {
+	api := &modelconfig.ModelConfigAPIV2{s.api}
+	err := api.ModelConfigRollback(params.ModelConfigRevisionArg{Revision: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.revision, gc.Equals, 2)
+	c.Assert(s.backend.user, gc.Equals, names.NewUserTag("bruce@local"))
+}
+
+func (s *modelconfigSuite) TestModelConfigRollbackReadOnly(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	api := &modelconfig.ModelConfigAPIV2{s.api}
+	err := api.ModelConfigRollback(params.ModelConfigRevisionArg{Revision: 2})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelconfigSuite) TestBlockModelConfigRollback(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockModelConfigRollback")
+	api := &modelconfig.ModelConfigAPIV2{s.api}
+	err := api.ModelConfigRollback(params.ModelConfigRevisionArg{Revision: 2})
+	s.assertBlocked(c, err, "TestBlockModelConfigRollback")
+}
+
 type mockBackend struct {
 	cfg config.ConfigValues
 	old *config.Config
 	b   state.BlockType
 	msg string
+
+	user     names.UserTag
+	history  []state.ModelConfigRevision
+	changes  []state.ItemChange
+	revision int
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
 	return m.cfg, nil
 }
 
-func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) error {
+func (m *mockBackend) UpdateModelConfigBy(user names.UserTag, update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) error {
+	m.user = user
 	for _, validateFunc := range validate {
 		if err := validateFunc(update, remove, m.old); err != nil {
 			return err
@@ -233,6 +309,21 @@ func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []
 	return nil
 }
 
+func (m *mockBackend) ModelConfigHistory() ([]state.ModelConfigRevision, error) {
+	return m.history, nil
+}
+
+func (m *mockBackend) ModelConfigChangesSince(revision int) ([]state.ItemChange, error) {
+	m.revision = revision
+	return m.changes, nil
+}
+
+func (m *mockBackend) RollbackModelConfig(user names.UserTag, revision int, validate ...state.ValidateConfigFunc) error {
+	m.user = user
+	m.revision = revision
+	return nil
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if m.b == t {
 		return &mockBlock{t: t, m: m.msg}, true, nil
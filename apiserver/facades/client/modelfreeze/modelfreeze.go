@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelfreeze provides the API for freezing a model, making it
+// read-only for users while its agents continue to operate it.
+package modelfreeze
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the model functionality required by API.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	Refresh() error
+	Freeze() (state.ModelFreeze, bool)
+	SetFreeze(by names.UserTag, message string) error
+	ClearFreeze() error
+}
+
+// API provides access to the ModelFreeze API facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(model, authorizer)
+}
+
+// NewAPI returns a new ModelFreeze API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	return api.checkSuperuser()
+}
+
+func (api *API) checkSuperuser() error {
+	ok, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// FreezeStatus returns the read-only freeze of the model.
+func (api *API) FreezeStatus() (params.ModelFreezeResult, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.ModelFreezeResult{}, errors.Trace(err)
+	}
+	if err := api.backend.Refresh(); err != nil {
+		return params.ModelFreezeResult{}, errors.Trace(err)
+	}
+	freeze, frozen := api.backend.Freeze()
+	if !frozen {
+		return params.ModelFreezeResult{}, nil
+	}
+	return params.ModelFreezeResult{
+		Frozen:  true,
+		Message: freeze.Message,
+		By:      freeze.By.String(),
+		Since:   &freeze.Since,
+	}, nil
+}
+
+// Freeze makes the model read-only for users other than controller
+// superusers, until it is unfrozen. Only model admins may freeze a
+// model.
+func (api *API) Freeze(args params.ModelFreezeParams) error {
+	if err := api.checkAccess(permission.AdminAccess); err != nil {
+		return errors.Trace(err)
+	}
+	userTag, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	return errors.Trace(api.backend.SetFreeze(userTag, args.Message))
+}
+
+// Unfreeze removes the read-only freeze of the model. Only controller
+// superusers may unfreeze a frozen model, so that a freeze cannot be
+// lifted by the users it is held against.
+func (api *API) Unfreeze() error {
+	if err := api.checkAccess(permission.AdminAccess); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.backend.Refresh(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, frozen := api.backend.Freeze(); frozen {
+		if err := api.checkSuperuser(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(api.backend.ClearFreeze())
+}
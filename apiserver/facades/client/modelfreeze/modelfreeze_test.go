@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelfreeze_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelfreeze"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelFreezeSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&modelFreezeSuite{})
+
+func (s *modelFreezeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *modelFreezeSuite) newAPI(c *gc.C) *modelfreeze.API {
+	api, err := modelfreeze.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelFreezeSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelfreeze.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *modelFreezeSuite) TestFreeze(c *gc.C) {
+	err := s.newAPI(c).Freeze(params.ModelFreezeParams{Message: "incident 42"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "SetFreeze", names.NewUserTag("admin"), "incident 42")
+}
+
+func (s *modelFreezeSuite) TestFreezeSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("superuser-bob")
+	err := s.newAPI(c).Freeze(params.ModelFreezeParams{Message: "incident 42"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "SetFreeze")
+}
+
+func (s *modelFreezeSuite) TestFreezeRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write")
+	err := s.newAPI(c).Freeze(params.ModelFreezeParams{Message: "incident 42"})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelFreezeSuite) TestFreezeError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	err := s.newAPI(c).Freeze(params.ModelFreezeParams{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelFreezeSuite) TestUnfreeze(c *gc.C) {
+	s.backend.freeze = &state.ModelFreeze{Message: "incident 42"}
+	err := s.newAPI(c).Unfreeze()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Refresh", "ClearFreeze")
+}
+
+func (s *modelFreezeSuite) TestUnfreezeNotFrozen(c *gc.C) {
+	err := s.newAPI(c).Unfreeze()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Refresh", "ClearFreeze")
+}
+
+func (s *modelFreezeSuite) TestUnfreezeFrozenRejectsModelAdmin(c *gc.C) {
+	s.backend.freeze = &state.ModelFreeze{Message: "incident 42"}
+	// The user has admin access to the model, but is not a
+	// controller superuser.
+	s.authorizer.Tag = names.NewUserTag("admin-" + coretesting.ModelTag.String())
+	err := s.newAPI(c).Unfreeze()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "Refresh")
+}
+
+func (s *modelFreezeSuite) TestUnfreezeRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write")
+	err := s.newAPI(c).Unfreeze()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelFreezeSuite) TestFreezeStatus(c *gc.C) {
+	since := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.freeze = &state.ModelFreeze{
+		Message: "incident 42",
+		By:      names.NewUserTag("bob"),
+		Since:   since,
+	}
+	s.authorizer.Tag = names.NewUserTag("read")
+	result, err := s.newAPI(c).FreezeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelFreezeResult{
+		Frozen:  true,
+		Message: "incident 42",
+		By:      "user-bob",
+		Since:   &since,
+	})
+	s.backend.CheckCallNames(c, "Refresh")
+}
+
+func (s *modelFreezeSuite) TestFreezeStatusNotFrozen(c *gc.C) {
+	result, err := s.newAPI(c).FreezeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelFreezeResult{})
+}
+
+type mockBackend struct {
+	testing.Stub
+	freeze *state.ModelFreeze
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) Refresh() error {
+	b.MethodCall(b, "Refresh")
+	return b.NextErr()
+}
+
+func (b *mockBackend) Freeze() (state.ModelFreeze, bool) {
+	if b.freeze == nil {
+		return state.ModelFreeze{}, false
+	}
+	return *b.freeze, true
+}
+
+func (b *mockBackend) SetFreeze(by names.UserTag, message string) error {
+	b.MethodCall(b, "SetFreeze", by, message)
+	return b.NextErr()
+}
+
+func (b *mockBackend) ClearFreeze() error {
+	b.MethodCall(b, "ClearFreeze")
+	return b.NextErr()
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -103,6 +104,7 @@ func (s *modelInfoSuite) SetUpTest(c *gc.C) {
 		tag:            coretesting.ModelTag,
 		controllerUUID: s.st.controllerUUID,
 		life:           state.Dying,
+		description:    "staging for the web team",
 		status: status.StatusInfo{
 			Status: status.Destroying,
 			Since:  &time.Time{},
@@ -204,6 +206,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 			Owner: "user",
 		},
 		AgentVersion: &expectedAgentVersion,
+		Description:  "staging for the web team",
 	})
 	s.st.CheckCalls(c, []gitjujutesting.StubCall{
 		{"ControllerTag", nil},
@@ -225,6 +228,8 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		{"CloudCredential", nil},
 		{"SLALevel", nil},
 		{"SLAOwner", nil},
+		{"Description", nil},
+		{"Expiry", nil},
 		{"Life", nil},
 		{"Config", nil},
 		{"Status", nil},
@@ -819,6 +824,11 @@ func (st *mockState) LatestMigration() (state.ModelMigration, error) {
 	return st.migration, st.NextErr()
 }
 
+func (st *mockState) SetModelConstraints(cons constraints.Value) error {
+	st.MethodCall(st, "SetModelConstraints", cons)
+	return st.NextErr()
+}
+
 func (st *mockState) SetModelMeterStatus(level, message string) error {
 	st.MethodCall(st, "SetModelMeterStatus", level, message)
 	return st.NextErr()
@@ -904,6 +914,8 @@ type mockModel struct {
 	users           []*mockModelUser
 	migrationStatus state.MigrationMode
 	controllerUUID  string
+	description     string
+	expiry          *state.ModelExpiry
 }
 
 func (m *mockModel) Config() (*config.Config, error) {
@@ -980,6 +992,24 @@ func (m *mockModel) SLAOwner() string {
 	return "user"
 }
 
+func (m *mockModel) Description() string {
+	m.MethodCall(m, "Description")
+	return m.description
+}
+
+func (m *mockModel) Expiry() (state.ModelExpiry, bool) {
+	m.MethodCall(m, "Expiry")
+	if m.expiry == nil {
+		return state.ModelExpiry{}, false
+	}
+	return *m.expiry, true
+}
+
+func (m *mockModel) SetExpiry(deadline time.Time, destroyStorage *bool) error {
+	m.MethodCall(m, "SetExpiry", deadline, destroyStorage)
+	return m.NextErr()
+}
+
 func (m *mockModel) ControllerUUID() string {
 	m.MethodCall(m, "ControllerUUID")
 	return m.controllerUUID
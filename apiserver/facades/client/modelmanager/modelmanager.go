@@ -10,6 +10,7 @@ package modelmanager
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/description"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -37,6 +39,26 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV6 defines the methods on the version 6 facade for the
+// modelmanager API endpoint.
+type ModelManagerV6 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ListModels(user params.Entity) (params.UserModelList, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
+}
+
+// ModelManagerV5 defines the methods on the version 5 facade for the
+// modelmanager API endpoint.
+type ModelManagerV5 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ListModels(user params.Entity) (params.UserModelList, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
+}
+
 // ModelManagerV4 defines the methods on the version 2 facade for the
 // modelmanager API endpoint.
 type ModelManagerV4 interface {
@@ -80,10 +102,22 @@ type ModelManagerAPI struct {
 	isAdmin     bool
 }
 
-// ModelManagerAPIV2 provides a way to wrap the different calls between
+// ModelManagerAPIV5 provides a way to wrap the different calls between
+// version 5 and version 6 of the model manager API
+type ModelManagerAPIV5 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV4 provides a way to wrap the different calls between
+// version 4 and version 5 of the model manager API
+type ModelManagerAPIV4 struct {
+	*ModelManagerAPIV5
+}
+
+// ModelManagerAPIV3 provides a way to wrap the different calls between
 // version 3 and version 4 of the model manager API
 type ModelManagerAPIV3 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV4
 }
 
 // ModelManagerAPIV2 provides a way to wrap the different calls between
@@ -93,13 +127,15 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV4 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV6 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
+	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
 	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV4 is used for API registration.
-func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV6 is used for API registration.
+func NewFacadeV6(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -130,6 +166,24 @@ func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV5 is used for API registration.
+func NewFacadeV5(ctx facade.Context) (*ModelManagerAPIV5, error) {
+	v6, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV5{v6}, nil
+}
+
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelManagerAPIV4, error) {
+	v5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV4{v5}, nil
+}
+
 // NewFacadeV3 is used for API registration.
 func NewFacadeV3(ctx facade.Context) (*ModelManagerAPIV3, error) {
 	v4, err := NewFacadeV4(ctx)
@@ -318,7 +372,6 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 	}
 
 	var cloudTag names.CloudTag
-	cloudRegionName := args.CloudRegion
 	if args.CloudTag != "" {
 		var err error
 		cloudTag, err = names.ParseCloudTag(args.CloudTag)
@@ -328,6 +381,19 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 	} else {
 		cloudTag = names.NewCloudTag(controllerModel.Cloud())
 	}
+
+	if args.ExpiresIn < 0 {
+		return result, errors.NotValidf("negative model expiry %v", args.ExpiresIn)
+	}
+
+	var cons constraints.Value
+	if args.Template != "" {
+		args, cons, err = m.applyModelTemplate(args, cloudTag, ownerTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	cloudRegionName := args.CloudRegion
 	if cloudRegionName == "" && cloudTag.Id() == controllerModel.Cloud() {
 		cloudRegionName = controllerModel.CloudRegion()
 	}
@@ -400,6 +466,9 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 	var model common.Model
 
 	if jujucloud.CloudIsCAAS(cloud) {
+		if !constraints.IsEmpty(&cons) {
+			return result, errors.NotSupportedf("constraints for models in cloud %q", cloudTag.Id())
+		}
 		model, err = m.newCAASModel(
 			cloudSpec,
 			args,
@@ -414,14 +483,78 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 			cloudTag,
 			cloudRegionName,
 			cloudCredentialTag,
-			ownerTag)
+			ownerTag,
+			cons)
 	}
 	if err != nil {
 		return result, errors.Trace(err)
 	}
+	if args.ExpiresIn > 0 {
+		deadline := time.Now().Add(args.ExpiresIn)
+		if err := model.SetExpiry(deadline, args.DestroyStorageOnExpiry); err != nil {
+			return result, errors.Annotate(err, "setting model expiry")
+		}
+	}
 	return m.getModelInfo(model.ModelTag())
 }
 
+// CreateModel creates a new model using the account and
+// model config specified in the args. The v4 implementation
+// does not support model templates or expiry.
+func (m *ModelManagerAPIV4) CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error) {
+	args.Template = ""
+	args.ExpiresIn = 0
+	args.DestroyStorageOnExpiry = nil
+	return m.ModelManagerAPI.CreateModel(args)
+}
+
+// applyModelTemplate returns the model creation args with the choices
+// of the named controller model template filled in where they were not
+// made explicitly, and the model constraints of the template.
+func (m *ModelManagerAPI) applyModelTemplate(
+	args params.ModelCreateArgs,
+	cloudTag names.CloudTag,
+	ownerTag names.UserTag,
+) (params.ModelCreateArgs, constraints.Value, error) {
+	controllerCfg, err := m.state.ControllerConfig()
+	if err != nil {
+		return args, constraints.Value{}, errors.Trace(err)
+	}
+	templates, err := controllerCfg.ModelTemplates()
+	if err != nil {
+		return args, constraints.Value{}, errors.Annotate(err, "reading model templates")
+	}
+	template, ok := templates[args.Template]
+	if !ok {
+		return args, constraints.Value{}, errors.NotFoundf("model template %q", args.Template)
+	}
+
+	if args.CloudRegion == "" {
+		args.CloudRegion = template.Region
+	}
+	if args.CloudCredentialTag == "" && template.Credential != "" {
+		id := fmt.Sprintf("%s/%s/%s", cloudTag.Id(), ownerTag.Id(), template.Credential)
+		if !names.IsValidCloudCredential(id) {
+			return args, constraints.Value{}, errors.NotValidf("model template %q credential %q", args.Template, template.Credential)
+		}
+		args.CloudCredentialTag = names.NewCloudCredentialTag(id).String()
+	}
+	config := make(map[string]interface{})
+	for key, value := range template.Config {
+		config[key] = value
+	}
+	for key, value := range args.Config {
+		config[key] = value
+	}
+	args.Config = config
+
+	cons, err := constraints.Parse(template.Constraints)
+	if err != nil {
+		return args, constraints.Value{}, errors.Trace(err)
+	}
+	return args, cons, nil
+}
+
 func (m *ModelManagerAPI) newCAASModel(cloudSpec environs.CloudSpec,
 	createArgs params.ModelCreateArgs,
 	cloudTag names.CloudTag,
@@ -456,6 +589,7 @@ func (m *ModelManagerAPI) newIAASModel(
 	cloudRegionName string,
 	cloudCredentialTag names.CloudCredentialTag,
 	ownerTag names.UserTag,
+	cons constraints.Value,
 ) (common.Model, error) {
 	newConfig, err := m.newModelConfig(cloudSpec, createArgs, controllerModel)
 	if err != nil {
@@ -471,6 +605,21 @@ func (m *ModelManagerAPI) newIAASModel(
 		return nil, errors.Annotate(err, "failed to open environ")
 	}
 
+	// Check the model constraints before anything is created.
+	if !constraints.IsEmpty(&cons) {
+		validator, err := env.ConstraintsValidator()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unsupported, err := validator.Validate(cons)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid model constraints")
+		}
+		if len(unsupported) > 0 {
+			return nil, errors.NotSupportedf("model constraints %s", strings.Join(unsupported, ", "))
+		}
+	}
+
 	controllerCfg, err := m.state.ControllerConfig()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -501,6 +650,12 @@ func (m *ModelManagerAPI) newIAASModel(
 	}
 	defer st.Close()
 
+	if !constraints.IsEmpty(&cons) {
+		if err := st.SetModelConstraints(cons); err != nil {
+			return nil, errors.Annotate(err, "setting model constraints")
+		}
+	}
+
 	if err = st.ReloadSpaces(env); err != nil {
 		if errors.IsNotSupported(err) {
 			logger.Debugf("Not performing spaces load on a non-networking environment")
@@ -725,6 +880,20 @@ func (m *ModelManagerAPI) ListModels(user params.Entity) (params.UserModelList,
 	return result, nil
 }
 
+// DestroyModels will try to destroy the specified models.
+// If there is a block on destruction, this method will return an error.
+// Forced destruction was added in version 6, so Force is ignored.
+func (m *ModelManagerAPIV5) DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error) {
+	v6Args := params.DestroyModelsParams{
+		Models: make([]params.DestroyModelParams, len(args.Models)),
+	}
+	for i, arg := range args.Models {
+		arg.Force = false
+		v6Args.Models[i] = arg
+	}
+	return m.ModelManagerAPI.DestroyModels(v6Args)
+}
+
 // DestroyModels will try to destroy the specified models.
 // If there is a block on destruction, this method will return an error.
 func (m *ModelManagerAPIV3) DestroyModels(args params.Entities) (params.ErrorResults, error) {
@@ -751,7 +920,7 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 		Results: make([]params.ErrorResult, len(args.Models)),
 	}
 
-	destroyModel := func(modelUUID string, destroyStorage *bool) error {
+	destroyModel := func(modelUUID string, destroyStorage *bool, force bool) error {
 		model, releaseModel, err := m.state.GetModel(modelUUID)
 		if err != nil {
 			return errors.Trace(err)
@@ -767,7 +936,7 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 		}
 		defer releaseSt()
 
-		return errors.Trace(common.DestroyModel(st, destroyStorage))
+		return errors.Trace(common.DestroyModel(st, destroyStorage, force))
 	}
 
 	for i, arg := range args.Models {
@@ -776,7 +945,7 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		if err := destroyModel(tag.Id(), arg.DestroyStorage); err != nil {
+		if err := destroyModel(tag.Id(), arg.DestroyStorage, arg.Force); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
@@ -845,6 +1014,11 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		Level: model.SLALevel(),
 		Owner: model.SLAOwner(),
 	}
+	info.Description = model.Description()
+	if expiry, ok := model.Expiry(); ok {
+		deadline := expiry.Deadline
+		info.Expires = &deadline
+	}
 
 	// If model is not alive - dying or dead - or if it is being imported,
 	// there is no guarantee that the rest of the call will succeed.
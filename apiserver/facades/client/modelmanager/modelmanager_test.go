@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -618,7 +619,9 @@ func (s *modelManagerSuite) TestUnsetModelDefaultsAsNormalUser(c *gc.C) {
 
 func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{
+			&modelmanager.ModelManagerAPIV4{&modelmanager.ModelManagerAPIV5{s.api}},
+		},
 	}
 
 	results := api.DumpModels(params.Entities{[]params.Entity{{
@@ -798,7 +801,9 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{
+		&modelmanager.ModelManagerAPIV4{&modelmanager.ModelManagerAPIV5{s.api}},
+	}
 	results, err := api.DestroyModels(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
 	})
@@ -826,6 +831,41 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 	})
 }
 
+func (s *modelManagerSuite) TestDestroyModelsForce(c *gc.C) {
+	results, err := s.api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag: coretesting.ModelTag.String(),
+			Force:    true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
+		{"UUID", nil},
+		{"Owner", nil},
+		{"Destroy", []interface{}{state.DestroyModelParams{
+			Force: true,
+		}}},
+	})
+}
+
+func (s *modelManagerSuite) TestDestroyModelsV5IgnoresForce(c *gc.C) {
+	api := &modelmanager.ModelManagerAPIV5{s.api}
+	results, err := api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag: coretesting.ModelTag.String(),
+			Force:    true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
+		{"UUID", nil},
+		{"Owner", nil},
+		{"Destroy", []interface{}{state.DestroyModelParams{}}},
+	})
+}
+
 // modelManagerStateSuite contains end-to-end tests.
 // Prefer adding tests to modelManagerSuite above.
 type modelManagerStateSuite struct {
@@ -948,6 +988,109 @@ func (s *modelManagerStateSuite) TestCreateModelValidatesConfig(c *gc.C) {
 	)
 }
 
+func (s *modelManagerStateSuite) setModelTemplates(c *gc.C, templates string) {
+	_, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"model-templates": templates,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelManagerStateSuite) TestCreateModelFromTemplate(c *gc.C) {
+	s.setModelTemplates(c, `
+small-staging:
+  region: dummy-region
+  config:
+    logging-config: <root>=DEBUG
+    default-series: xenial
+  constraints: mem=2G cores=1
+`)
+	admin := s.AdminUserTag(c)
+	s.setAPIUser(c, admin)
+	args := createArgs(admin)
+	args.Template = "small-staging"
+	args.Config["default-series"] = "bionic"
+	model, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.CloudRegion, gc.Equals, "dummy-region")
+
+	st, err := s.State.ForModel(names.NewModelTag(model.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LoggingConfig(), gc.Equals, "<root>=DEBUG")
+	series, _ := cfg.DefaultSeries()
+	c.Assert(series, gc.Equals, "bionic")
+	cons, err := st.ModelConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=2G cores=1"))
+}
+
+func (s *modelManagerStateSuite) TestCreateModelTemplateNotFound(c *gc.C) {
+	admin := s.AdminUserTag(c)
+	s.setAPIUser(c, admin)
+	args := createArgs(admin)
+	args.Template = "small-staging"
+	_, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `model template "small-staging" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelManagerStateSuite) TestCreateModelTemplateUnsupportedConstraints(c *gc.C) {
+	s.setModelTemplates(c, "fast: {constraints: cpu-power=1000}")
+	admin := s.AdminUserTag(c)
+	s.setAPIUser(c, admin)
+	args := createArgs(admin)
+	args.Template = "fast"
+	_, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, "model constraints cpu-power not supported")
+
+	models, err := s.State.AllModelUUIDs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+}
+
+func (s *modelManagerStateSuite) TestCreateModelTemplateCredential(c *gc.C) {
+	s.setModelTemplates(c, "staging: {credential: staging}")
+	admin := s.AdminUserTag(c)
+	s.setAPIUser(c, admin)
+	args := createArgs(admin)
+	args.Template = "staging"
+	_, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `getting credential: .*dummy/admin/staging.* not found`)
+}
+
+func (s *modelManagerStateSuite) TestCreateModelExpiry(c *gc.C) {
+	admin := s.AdminUserTag(c)
+	s.setAPIUser(c, admin)
+	args := createArgs(admin)
+	args.ExpiresIn = 72 * time.Hour
+	destroyStorage := true
+	args.DestroyStorageOnExpiry = &destroyStorage
+	before := time.Now()
+	info, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Expires, gc.NotNil)
+	c.Assert(info.Expires.After(before.Add(71*time.Hour)), jc.IsTrue)
+
+	model, release, err := s.StatePool.GetModel(info.UUID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer release()
+	expiry, expires := model.Expiry()
+	c.Assert(expires, jc.IsTrue)
+	c.Assert(expiry.Deadline.Equal(*info.Expires), jc.IsTrue)
+	c.Assert(expiry.DestroyStorage, jc.DeepEquals, &destroyStorage)
+}
+
+func (s *modelManagerStateSuite) TestCreateModelNegativeExpiry(c *gc.C) {
+	admin := s.AdminUserTag(c)
+	s.setAPIUser(c, admin)
+	args := createArgs(admin)
+	args.ExpiresIn = -time.Hour
+	_, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, "negative model expiry -1h0m0s not valid")
+}
+
 func (s *modelManagerStateSuite) TestCreateModelBadConfig(c *gc.C) {
 	owner := names.NewUserTag("admin")
 	s.setAPIUser(c, owner)
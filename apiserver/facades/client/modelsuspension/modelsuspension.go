@@ -0,0 +1,239 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelsuspension provides the API for suspending a model,
+// stopping its instances while keeping them, and for resuming it.
+package modelsuspension
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.modelsuspension")
+
+// Backend exposes the model functionality required by API.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	IsControllerModel() bool
+	Refresh() error
+	Suspension() (state.ModelSuspension, bool)
+	SetSuspension(by names.UserTag, ids []instance.Id) error
+	ClearSuspension() error
+	AllMachines() ([]Machine, error)
+}
+
+// Machine exposes the machine functionality required by API.
+type Machine interface {
+	Id() string
+	InstanceId() (instance.Id, error)
+	SetProviderAddresses(...network.Address) error
+}
+
+// Environ exposes the provider functionality required by API. The
+// environ must also implement environs.InstanceSuspender for models to
+// be suspended.
+type Environ interface {
+	Instances(ids []instance.Id) ([]instance.Instance, error)
+}
+
+// API provides access to the ModelSuspension API facade.
+type API struct {
+	backend    Backend
+	newEnviron func() (Environ, error)
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new ModelSuspension API facade.
+func NewAPI(backend Backend, newEnviron func() (Environ, error), authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		newEnviron: newEnviron,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	ok, err = api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// SuspensionStatus returns the suspension of the model.
+func (api *API) SuspensionStatus() (params.ModelSuspensionResult, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.ModelSuspensionResult{}, errors.Trace(err)
+	}
+	if err := api.backend.Refresh(); err != nil {
+		return params.ModelSuspensionResult{}, errors.Trace(err)
+	}
+	suspension, suspended := api.backend.Suspension()
+	if !suspended {
+		return params.ModelSuspensionResult{}, nil
+	}
+	result := params.ModelSuspensionResult{
+		Suspended: true,
+		By:        suspension.By.String(),
+		Since:     &suspension.Since,
+	}
+	for _, id := range suspension.Instances {
+		result.Instances = append(result.Instances, string(id))
+	}
+	return result, nil
+}
+
+// Suspend stops the instances of the model's machines, keeping them so
+// that they may be started again when the model is resumed. The
+// suspension is recorded before the instances are stopped, so that a
+// model whose instances could not all be stopped may still be resumed.
+// Only model admins may suspend a model, and the controller model may
+// not be suspended.
+func (api *API) Suspend() error {
+	if err := api.checkAccess(permission.AdminAccess); err != nil {
+		return errors.Trace(err)
+	}
+	userTag, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	if api.backend.IsControllerModel() {
+		return errors.NotSupportedf("suspending the controller model")
+	}
+	suspender, err := api.suspender()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ids []instance.Id
+	for _, m := range machines {
+		if names.IsContainerMachine(m.Id()) {
+			// Containers stop with their hosts.
+			continue
+		}
+		id, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := api.backend.SetSuspension(userTag, ids); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(suspender.SuspendInstances(ids...), "stopping instances")
+}
+
+// Resume starts the instances stopped when the model was suspended,
+// updates the provider addresses of their machines, and removes the
+// suspension. Only model admins may resume a model.
+func (api *API) Resume() error {
+	if err := api.checkAccess(permission.AdminAccess); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.backend.Refresh(); err != nil {
+		return errors.Trace(err)
+	}
+	suspension, suspended := api.backend.Suspension()
+	if !suspended {
+		return errors.New("model is not suspended")
+	}
+	suspender, err := api.suspender()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := suspender.ResumeInstances(suspension.Instances...); err != nil {
+		return errors.Annotate(err, "starting instances")
+	}
+	api.reconcileAddresses(suspender, suspension.Instances)
+	return errors.Trace(api.backend.ClearSuspension())
+}
+
+type suspendingEnviron interface {
+	Environ
+	environs.InstanceSuspender
+}
+
+func (api *API) suspender() (suspendingEnviron, error) {
+	env, err := api.newEnviron()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	suspender, ok := env.(suspendingEnviron)
+	if !ok {
+		return nil, errors.NotSupportedf("suspending models on this cloud")
+	}
+	return suspender, nil
+}
+
+// reconcileAddresses updates the provider addresses of the machines
+// with the specified instances, which may have changed while they were
+// stopped. Failures are logged rather than returned, as the instance
+// poller will update the addresses in time.
+func (api *API) reconcileAddresses(env Environ, ids []instance.Id) {
+	if len(ids) == 0 {
+		return
+	}
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		logger.Warningf("cannot get machines to update addresses: %v", err)
+		return
+	}
+	machinesById := make(map[instance.Id]Machine)
+	for _, m := range machines {
+		if id, err := m.InstanceId(); err == nil {
+			machinesById[id] = m
+		}
+	}
+	insts, err := env.Instances(ids)
+	if err != nil && err != environs.ErrPartialInstances {
+		logger.Warningf("cannot get instances to update addresses: %v", err)
+		return
+	}
+	for _, inst := range insts {
+		if inst == nil {
+			continue
+		}
+		m, ok := machinesById[inst.Id()]
+		if !ok {
+			continue
+		}
+		addrs, err := inst.Addresses()
+		if err != nil {
+			logger.Warningf("cannot get addresses of instance %s: %v", inst.Id(), err)
+			continue
+		}
+		if err := m.SetProviderAddresses(addrs...); err != nil {
+			logger.Warningf("cannot update addresses of machine %s: %v", m.Id(), err)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspension_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelsuspension"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelSuspensionSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	environ    *mockEnviron
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&modelSuspensionSuite{})
+
+func (s *modelSuspensionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.environ = &mockEnviron{}
+	s.backend = &mockBackend{
+		machines: []*mockMachine{{
+			id:         "0",
+			instanceId: "inst-0",
+		}, {
+			id:         "0/lxd/0",
+			instanceId: "juju-lxd-0",
+		}, {
+			id: "1",
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *modelSuspensionSuite) newAPI(c *gc.C) *modelsuspension.API {
+	newEnviron := func() (modelsuspension.Environ, error) {
+		return s.environ, nil
+	}
+	api, err := modelsuspension.NewAPI(s.backend, newEnviron, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelSuspensionSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelsuspension.NewAPI(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *modelSuspensionSuite) TestSuspend(c *gc.C) {
+	err := s.newAPI(c).Suspend()
+	c.Assert(err, jc.ErrorIsNil)
+	// Only provisioned machines that are not containers are stopped.
+	ids := []instance.Id{"inst-0"}
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"AllMachines", nil},
+		{"SetSuspension", []interface{}{names.NewUserTag("admin"), ids}},
+	})
+	s.environ.CheckCalls(c, []testing.StubCall{
+		{"SuspendInstances", []interface{}{ids}},
+	})
+}
+
+func (s *modelSuspensionSuite) TestSuspendRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write")
+	err := s.newAPI(c).Suspend()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *modelSuspensionSuite) TestSuspendControllerModel(c *gc.C) {
+	s.backend.controller = true
+	err := s.newAPI(c).Suspend()
+	c.Assert(err, gc.ErrorMatches, "suspending the controller model not supported")
+	s.backend.CheckNoCalls(c)
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *modelSuspensionSuite) TestSuspendNotSupported(c *gc.C) {
+	newEnviron := func() (modelsuspension.Environ, error) {
+		return &mockBasicEnviron{}, nil
+	}
+	api, err := modelsuspension.NewAPI(s.backend, newEnviron, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.Suspend()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelSuspensionSuite) TestSuspendAlreadySuspended(c *gc.C) {
+	s.backend.SetErrors(nil, errors.AlreadyExistsf("suspension"))
+	err := s.newAPI(c).Suspend()
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *modelSuspensionSuite) TestSuspendInstancesError(c *gc.C) {
+	s.environ.SetErrors(errors.New("boom"))
+	err := s.newAPI(c).Suspend()
+	c.Assert(err, gc.ErrorMatches, "stopping instances: boom")
+	s.backend.CheckCallNames(c, "AllMachines", "SetSuspension")
+}
+
+func (s *modelSuspensionSuite) TestResume(c *gc.C) {
+	ids := []instance.Id{"inst-0"}
+	s.backend.suspension = &state.ModelSuspension{
+		By:        names.NewUserTag("bob"),
+		Instances: ids,
+	}
+	s.environ.addresses = network.NewAddresses("10.0.0.1")
+	err := s.newAPI(c).Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Refresh", "AllMachines", "ClearSuspension")
+	s.environ.CheckCalls(c, []testing.StubCall{
+		{"ResumeInstances", []interface{}{ids}},
+		{"Instances", []interface{}{ids}},
+	})
+	s.backend.machines[0].CheckCalls(c, []testing.StubCall{
+		{"SetProviderAddresses", []interface{}{network.NewAddresses("10.0.0.1")}},
+	})
+}
+
+func (s *modelSuspensionSuite) TestResumeNotSuspended(c *gc.C) {
+	err := s.newAPI(c).Resume()
+	c.Assert(err, gc.ErrorMatches, "model is not suspended")
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *modelSuspensionSuite) TestResumeRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	err := s.newAPI(c).Resume()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelSuspensionSuite) TestResumeInstancesError(c *gc.C) {
+	s.backend.suspension = &state.ModelSuspension{Instances: []instance.Id{"inst-0"}}
+	s.environ.SetErrors(errors.New("boom"))
+	err := s.newAPI(c).Resume()
+	c.Assert(err, gc.ErrorMatches, "starting instances: boom")
+	s.backend.CheckCallNames(c, "Refresh")
+}
+
+func (s *modelSuspensionSuite) TestResumeAddressesError(c *gc.C) {
+	s.backend.suspension = &state.ModelSuspension{Instances: []instance.Id{"inst-0"}}
+	s.environ.SetErrors(nil, errors.New("boom"))
+	err := s.newAPI(c).Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Refresh", "AllMachines", "ClearSuspension")
+}
+
+func (s *modelSuspensionSuite) TestSuspensionStatus(c *gc.C) {
+	since := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.suspension = &state.ModelSuspension{
+		By:        names.NewUserTag("bob"),
+		Since:     since,
+		Instances: []instance.Id{"inst-0", "inst-1"},
+	}
+	s.authorizer.Tag = names.NewUserTag("read")
+	result, err := s.newAPI(c).SuspensionStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelSuspensionResult{
+		Suspended: true,
+		By:        "user-bob",
+		Since:     &since,
+		Instances: []string{"inst-0", "inst-1"},
+	})
+	s.backend.CheckCallNames(c, "Refresh")
+}
+
+func (s *modelSuspensionSuite) TestSuspensionStatusNotSuspended(c *gc.C) {
+	result, err := s.newAPI(c).SuspensionStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelSuspensionResult{})
+}
+
+type mockBackend struct {
+	testing.Stub
+	controller bool
+	suspension *state.ModelSuspension
+	machines   []*mockMachine
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) IsControllerModel() bool {
+	return b.controller
+}
+
+func (b *mockBackend) Refresh() error {
+	b.MethodCall(b, "Refresh")
+	return b.NextErr()
+}
+
+func (b *mockBackend) Suspension() (state.ModelSuspension, bool) {
+	if b.suspension == nil {
+		return state.ModelSuspension{}, false
+	}
+	return *b.suspension, true
+}
+
+func (b *mockBackend) SetSuspension(by names.UserTag, ids []instance.Id) error {
+	b.MethodCall(b, "SetSuspension", by, ids)
+	return b.NextErr()
+}
+
+func (b *mockBackend) ClearSuspension() error {
+	b.MethodCall(b, "ClearSuspension")
+	return b.NextErr()
+}
+
+func (b *mockBackend) AllMachines() ([]modelsuspension.Machine, error) {
+	b.MethodCall(b, "AllMachines")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	machines := make([]modelsuspension.Machine, len(b.machines))
+	for i, m := range b.machines {
+		machines[i] = m
+	}
+	return machines, nil
+}
+
+type mockMachine struct {
+	testing.Stub
+	id         string
+	instanceId instance.Id
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %s", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) SetProviderAddresses(addrs ...network.Address) error {
+	m.MethodCall(m, "SetProviderAddresses", addrs)
+	return m.NextErr()
+}
+
+type mockBasicEnviron struct{}
+
+func (*mockBasicEnviron) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	return nil, errors.NotImplementedf("Instances")
+}
+
+type mockEnviron struct {
+	testing.Stub
+	addresses []network.Address
+}
+
+func (e *mockEnviron) SuspendInstances(ids ...instance.Id) error {
+	e.MethodCall(e, "SuspendInstances", ids)
+	return e.NextErr()
+}
+
+func (e *mockEnviron) ResumeInstances(ids ...instance.Id) error {
+	e.MethodCall(e, "ResumeInstances", ids)
+	return e.NextErr()
+}
+
+func (e *mockEnviron) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	e.MethodCall(e, "Instances", ids)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	insts := make([]instance.Instance, len(ids))
+	for i, id := range ids {
+		insts[i] = &mockInstance{id: id, addresses: e.addresses}
+	}
+	return insts, nil
+}
+
+type mockInstance struct {
+	instance.Instance
+	id        instance.Id
+	addresses []network.Address
+}
+
+func (i *mockInstance) Id() instance.Id {
+	return i.id
+}
+
+func (i *mockInstance) Addresses() ([]network.Address, error) {
+	return i.addresses, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 )
@@ -190,15 +191,43 @@ func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResu
 	return out, nil
 }
 
+// HostMachine returns, for one or more entities, the id of the machine
+// hosting the entity's machine if that machine is a container, or an
+// empty string otherwise. Machines and units are supported.
+//
+// Container addresses are frequently not routable from the client, so
+// clients connect to containers by proxying through their host machine.
+func (facade *Facade) HostMachine(args params.Entities) (params.StringResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	out := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := facade.backend.GetMachineForEntity(entity.Tag)
+		if err != nil {
+			out.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		out.Results[i].Result, _ = machine.ParentId()
+	}
+	return out, nil
+}
+
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the model associated with the API connection.
 func (facade *Facade) Proxy() (params.SSHProxyResult, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHProxyResult{}, errors.Trace(err)
 	}
-	config, err := facade.backend.ModelConfig()
+	cfg, err := facade.backend.ModelConfig()
 	if err != nil {
 		return params.SSHProxyResult{}, err
 	}
-	return params.SSHProxyResult{UseProxy: config.ProxySSH()}, nil
+	return params.SSHProxyResult{
+		UseProxy:         cfg.ProxySSH(),
+		UseReverseTunnel: cfg.AgentConnectionMode() == config.ReverseAgentConnection,
+	}, nil
 }
@@ -157,6 +157,36 @@ func (s *facadeSuite) TestPublicKeys(c *gc.C) {
 	})
 }
 
+func (s *facadeSuite) TestHostMachine(c *gc.C) {
+	uContained := names.NewUnitTag("contained/0").String()
+	args := params.Entities{
+		Entities: []params.Entity{{s.m0}, {s.uOther}, {uContained}},
+	}
+	results, err := s.facade.HostMachine(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: ""},
+			{Error: apiservertesting.NotFoundError("entity")},
+			{Result: "1"},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{s.m0}},
+		{"GetMachineForEntity", []interface{}{s.uOther}},
+		{"GetMachineForEntity", []interface{}{uContained}},
+	})
+}
+
+func (s *facadeSuite) TestHostMachineNotAdmin(c *gc.C) {
+	s.authorizer.AdminTag = names.NewUserTag("someone-else")
+	_, err := s.facade.HostMachine(params.Entities{
+		Entities: []params.Entity{{s.m0}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *facadeSuite) TestProxyTrue(c *gc.C) {
 	s.backend.proxySSH = true
 	result, err := s.facade.Proxy()
@@ -177,9 +207,18 @@ func (s *facadeSuite) TestProxyFalse(c *gc.C) {
 	})
 }
 
+func (s *facadeSuite) TestProxyReverseTunnel(c *gc.C) {
+	s.backend.connectionMode = "reverse"
+	result, err := s.facade.Proxy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.UseProxy, jc.IsFalse)
+	c.Check(result.UseReverseTunnel, jc.IsTrue)
+}
+
 type mockBackend struct {
-	stub     jujutesting.Stub
-	proxySSH bool
+	stub           jujutesting.Stub
+	proxySSH       bool
+	connectionMode string
 }
 
 func (backend *mockBackend) ModelTag() names.ModelTag {
@@ -190,6 +229,9 @@ func (backend *mockBackend) ModelConfig() (*config.Config, error) {
 	backend.stub.AddCall("ModelConfig")
 	attrs := testing.FakeConfig()
 	attrs["proxy-ssh"] = backend.proxySSH
+	if backend.connectionMode != "" {
+		attrs["agent-connection-mode"] = backend.connectionMode
+	}
 	conf, err := config.New(config.NoDefaults, attrs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -220,6 +262,11 @@ func (backend *mockBackend) GetMachineForEntity(tagString string) (sshclient.SSH
 			),
 			allNetworkAddresses: network.NewAddresses("0.3.2.1", "3.3.3.3", "4.4.4.4"),
 		}, nil
+	case names.NewUnitTag("contained/0").String():
+		return &mockMachine{
+			tag:            names.NewMachineTag("1/lxd/0"),
+			privateAddress: "10.0.3.10",
+		}, nil
 	}
 	return nil, errors.NotFoundf("entity")
 }
@@ -272,3 +319,8 @@ func (m *mockMachine) AllNetworkAddresses() ([]network.Address, error) {
 func (m *mockMachine) Addresses() []network.Address {
 	return m.addresses
 }
+
+func (m *mockMachine) ParentId() (string, bool) {
+	parentId := state.ParentId(m.tag.Id())
+	return parentId, parentId != ""
+}
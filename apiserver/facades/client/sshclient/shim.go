@@ -32,6 +32,7 @@ type SSHMachine interface {
 	PrivateAddress() (network.Address, error)
 	Addresses() []network.Address
 	AllNetworkAddresses() ([]network.Address, error)
+	ParentId() (string, bool)
 }
 
 // NewFacade wraps New to express the supplied *state.State as a Backend.
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -25,7 +27,7 @@ type baseStorageSuite struct {
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 
-	api   *storage.APIv4
+	api   *storage.APIv7
 	apiv3 *storage.APIv3
 	state *mockState
 
@@ -40,6 +42,7 @@ type baseStorageSuite struct {
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
+	volumeSnapshot       *mockVolumeSnapshot
 	stub                 testing.Stub
 
 	registry    jujustorage.StaticProviderRegistry
@@ -61,7 +64,7 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.poolManager = s.constructPoolManager()
 
 	var err error
-	s.api, err = storage.NewAPIv4(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	s.api, err = storage.NewAPIv7(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.apiv3, err = storage.NewAPIv3(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
@@ -96,6 +99,11 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	growStorageInstanceCall                 = "growStorageInstance"
+	addVolumeSnapshotCall                   = "addVolumeSnapshot"
+	volumeSnapshotCall                      = "volumeSnapshot"
+	volumeSnapshotsCall                     = "volumeSnapshots"
+	addStorageFromSnapshotCall              = "addStorageFromSnapshot"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -128,6 +136,15 @@ func (s *baseStorageSuite) constructState() *mockState {
 		life:       state.Dead,
 	}
 	s.volume = &mockVolume{tag: s.volumeTag, storage: &s.storageTag}
+	s.volumeSnapshot = &mockVolumeSnapshot{
+		id:         "0",
+		snapshotId: "snap-0",
+		volume:     s.volumeTag,
+		storage:    s.storageTag,
+		kind:       state.StorageKindFilesystem,
+		pool:       "radiance",
+		created:    time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+	}
 	s.volumeAttachment = &mockVolumeAttachment{
 		VolumeTag:  s.volumeTag,
 		MachineTag: s.machineTag,
@@ -276,10 +293,39 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.stub.AddCall(releaseStorageInstanceCall, tag, destroyAttached)
 			return errors.New("cannae do it")
 		},
+		growStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(growStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
 		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		addVolumeSnapshot: func(tag names.VolumeTag, snapshotId string) (state.VolumeSnapshot, error) {
+			s.stub.AddCall(addVolumeSnapshotCall, tag, snapshotId)
+			if err := s.stub.NextErr(); err != nil {
+				return nil, err
+			}
+			snapshot := *s.volumeSnapshot
+			snapshot.volume = tag
+			snapshot.snapshotId = snapshotId
+			return &snapshot, nil
+		},
+		volumeSnapshot: func(id string) (state.VolumeSnapshot, error) {
+			s.stub.AddCall(volumeSnapshotCall, id)
+			if id == s.volumeSnapshot.id {
+				return s.volumeSnapshot, nil
+			}
+			return nil, errors.NotFoundf("volume snapshot %q", id)
+		},
+		volumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.stub.AddCall(volumeSnapshotsCall)
+			return []state.VolumeSnapshot{s.volumeSnapshot}, nil
+		},
+		addStorageFromSnapshot: func(id string, info state.VolumeInfo) (names.StorageTag, error) {
+			s.stub.AddCall(addStorageFromSnapshotCall, id, info)
+			return names.NewStorageTag("data/1"), s.stub.NextErr()
+		},
 	}
 }
 
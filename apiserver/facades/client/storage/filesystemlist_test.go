@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -144,6 +146,33 @@ func (s *filesystemSuite) TestListFilesystemsAttachmentInfo(c *gc.C) {
 	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
 }
 
+func (s *filesystemSuite) TestListFilesystemsAttachmentUsage(c *gc.C) {
+	updated := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.filesystemAttachment.usage = &state.FilesystemUsage{
+		Size:      1024,
+		Used:      512,
+		Available: 512,
+		Updated:   updated,
+	}
+	expected := s.expectedFilesystemDetails()
+	expected.MachineAttachments[s.machineTag.String()] = params.FilesystemAttachmentDetails{
+		Life: "dead",
+		Usage: &params.FilesystemUsage{
+			Size:      1024,
+			Used:      512,
+			Available: 512,
+			Updated:   updated,
+		},
+	}
+	found, err := s.api.ListFilesystems(params.FilesystemFilters{
+		[]params.FilesystemFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
+}
+
 func (s *filesystemSuite) TestListFilesystemsVolumeBacked(c *gc.C) {
 	s.filesystem.volume = &s.volumeTag
 	expected := s.expectedFilesystemDetails()
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -68,6 +70,13 @@ type mockState struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	growStorageInstance                 func(names.StorageTag, uint64) error
+	addVolumeSnapshot                   func(names.VolumeTag, string) (state.VolumeSnapshot, error)
+	volumeSnapshot                      func(string) (state.VolumeSnapshot, error)
+	volumeSnapshots                     func() ([]state.VolumeSnapshot, error)
+	addStorageFromSnapshot              func(string, state.VolumeInfo) (names.StorageTag, error)
+	watchVolumeAttachmentChanges        func() state.StringsWatcher
+	watchFilesystemAttachmentChanges    func() state.StringsWatcher
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -193,6 +202,10 @@ func (st *mockState) ReleaseStorageInstance(tag names.StorageTag, destroyAttache
 	return st.releaseStorageInstance(tag, destroyAttached)
 }
 
+func (st *mockState) GrowStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.growStorageInstance(tag, size)
+}
+
 func (st *mockState) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	panic("should not be called")
 }
@@ -201,6 +214,30 @@ func (st *mockState) AddExistingFilesystem(f state.FilesystemInfo, v *state.Volu
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockState) AddVolumeSnapshot(tag names.VolumeTag, snapshotId string) (state.VolumeSnapshot, error) {
+	return st.addVolumeSnapshot(tag, snapshotId)
+}
+
+func (st *mockState) VolumeSnapshot(id string) (state.VolumeSnapshot, error) {
+	return st.volumeSnapshot(id)
+}
+
+func (st *mockState) VolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.volumeSnapshots()
+}
+
+func (st *mockState) AddStorageFromSnapshot(id string, info state.VolumeInfo) (names.StorageTag, error) {
+	return st.addStorageFromSnapshot(id, info)
+}
+
+func (st *mockState) WatchVolumeAttachmentChanges() state.StringsWatcher {
+	return st.watchVolumeAttachmentChanges()
+}
+
+func (st *mockState) WatchFilesystemAttachmentChanges() state.StringsWatcher {
+	return st.watchFilesystemAttachmentChanges()
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
@@ -242,6 +279,53 @@ func (m *mockVolume) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Attached}, nil
 }
 
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id         string
+	snapshotId string
+	volume     names.VolumeTag
+	storage    names.StorageTag
+	kind       state.StorageKind
+	pool       string
+	created    time.Time
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) SnapshotId() string {
+	return m.snapshotId
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Storage() names.StorageTag {
+	return m.storage
+}
+
+func (m *mockVolumeSnapshot) StorageName() string {
+	return "data"
+}
+
+func (m *mockVolumeSnapshot) Kind() state.StorageKind {
+	return m.kind
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return m.pool
+}
+
+func (m *mockVolumeSnapshot) Size() uint64 {
+	return 1024
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
 type mockFilesystem struct {
 	state.Filesystem
 	tag     names.FilesystemTag
@@ -289,6 +373,7 @@ type mockFilesystemAttachment struct {
 	filesystem names.FilesystemTag
 	machine    names.MachineTag
 	info       *state.FilesystemAttachmentInfo
+	usage      *state.FilesystemUsage
 	life       state.Life
 }
 
@@ -311,6 +396,13 @@ func (m *mockFilesystemAttachment) Life() state.Life {
 	return m.life
 }
 
+func (m *mockFilesystemAttachment) Usage() (state.FilesystemUsage, bool) {
+	if m.usage != nil {
+		return *m.usage, true
+	}
+	return state.FilesystemUsage{}, false
+}
+
 type mockStorageInstance struct {
 	state.StorageInstance
 	kind       state.StorageKind
@@ -19,6 +19,66 @@ import (
 // to change any part of it so that it were no longer *obviously* and
 // *trivially* correct, you would be Doing It Wrong.
 
+// NewFacadeV7 provides the signature required for facade registration.
+func NewFacadeV7(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv7, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+
+	backend, err := getState(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting backend")
+	}
+	return NewAPIv7(backend, registry, pm, resources, authorizer)
+}
+
+// NewFacadeV6 provides the signature required for facade registration.
+func NewFacadeV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv6, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+
+	backend, err := getState(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting backend")
+	}
+	return NewAPIv6(backend, registry, pm, resources, authorizer)
+}
+
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+
+	backend, err := getState(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting backend")
+	}
+	return NewAPIv5(backend, registry, pm, resources, authorizer)
+}
+
 // NewFacadeV4 provides the signature required for facade registration.
 func NewFacadeV4(
 	st *state.State,
@@ -158,6 +218,32 @@ type storageAccess interface {
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// GrowStorageInstance requests that the storage instance with the
+	// specified tag be grown to the specified size, in MiB.
+	GrowStorageInstance(names.StorageTag, uint64) error
+
+	// AddVolumeSnapshot records a snapshot of the volume with the
+	// specified tag, taken by the storage provider.
+	AddVolumeSnapshot(names.VolumeTag, string) (state.VolumeSnapshot, error)
+
+	// VolumeSnapshot returns the volume snapshot with the specified ID.
+	VolumeSnapshot(string) (state.VolumeSnapshot, error)
+
+	// VolumeSnapshots returns all of the volume snapshots in the model.
+	VolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// AddStorageFromSnapshot adds a detached storage instance for a
+	// volume restored from the volume snapshot with the specified ID.
+	AddStorageFromSnapshot(string, state.VolumeInfo) (names.StorageTag, error)
+
+	// WatchVolumeAttachmentChanges is required for watching volume
+	// attachments.
+	WatchVolumeAttachmentChanges() state.StringsWatcher
+
+	// WatchFilesystemAttachmentChanges is required for watching
+	// filesystem attachments.
+	WatchFilesystemAttachmentChanges() state.StringsWatcher
 }
 
 var getState = func(st *state.State) (storageAccess, error) {
@@ -0,0 +1,249 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type snapshotSuite struct {
+	baseStorageSuite
+	volumeSource volumeSnapshotter
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.state.modelTag = coretesting.ModelTag
+	s.volumeSource = volumeSnapshotter{&dummy.VolumeSource{}}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "radiance",
+		Size:     1024,
+	}
+}
+
+var snapshotResourceTags = map[string]string{
+	"juju-model-uuid":      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+}
+
+func (s *snapshotSuite) TestCreateSnapshots(c *gc.C) {
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{{
+		Result: &params.StorageSnapshotDetails{
+			Id:          "0",
+			SnapshotId:  "snap-vol-0",
+			StorageTag:  "storage-data-0",
+			VolumeTag:   "volume-22",
+			StorageName: "data",
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "radiance",
+			Size:        1024,
+			Created:     time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}})
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"SnapshotVolume", []interface{}{"vol-0", snapshotResourceTags}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceFilesystemCall,
+		volumeCall,
+		addVolumeSnapshotCall,
+	)
+	s.stub.CheckCall(c, 4, addVolumeSnapshotCall, s.volumeTag, "snap-vol-0")
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsBlock(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.volumeSource.CheckCallNames(c, "SnapshotVolume")
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		addVolumeSnapshotCall,
+	)
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsNoBackingVolume(c *gc.C) {
+	s.filesystem.volume = nil
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{{
+		Error: &params.Error{
+			Message: "snapshotting filesystem without backing volume not supported",
+			Code:    params.CodeNotSupported,
+		},
+	}})
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return &dummy.VolumeSource{}, nil
+		},
+	}
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{{
+		Error: &params.Error{
+			Message: `snapshotting volume with storage provider "radiance" not supported`,
+			Code:    params.CodeNotSupported,
+		},
+	}})
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsError(c *gc.C) {
+	s.volumeSource.SetErrors(errors.New("nope"))
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{
+		{Error: &params.Error{Message: "snapshotting volume: nope"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *snapshotSuite) TestListSnapshots(c *gc.C) {
+	results, err := s.api.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{{
+		Result: &params.StorageSnapshotDetails{
+			Id:          "0",
+			SnapshotId:  "snap-0",
+			StorageTag:  "storage-data-0",
+			VolumeTag:   "volume-22",
+			StorageName: "data",
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "radiance",
+			Size:        1024,
+			Created:     time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}})
+	s.stub.CheckCallNames(c, volumeSnapshotsCall)
+}
+
+func (s *snapshotSuite) TestRestoreSnapshots(c *gc.C) {
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshotsArgs{
+		Snapshots: []string{"0", "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{StorageTag: "storage-data-1"},
+	}, {
+		Error: &params.Error{
+			Message: `volume snapshot "42" not found`,
+			Code:    params.CodeNotFound,
+		},
+	}})
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"RestoreVolume", []interface{}{"snap-0", snapshotResourceTags}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{volumeSnapshotCall, []interface{}{"0"}},
+		{addStorageFromSnapshotCall, []interface{}{"0", state.VolumeInfo{
+			VolumeId:   "vol-snap-0",
+			Size:       1024,
+			HardwareId: "hw",
+		}}},
+		{volumeSnapshotCall, []interface{}{"42"}},
+	})
+}
+
+func (s *snapshotSuite) TestRestoreSnapshotsError(c *gc.C) {
+	s.volumeSource.SetErrors(errors.New("nope"))
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshotsArgs{
+		Snapshots: []string{"0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{Message: "restoring volume: nope"}},
+	})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, volumeSnapshotCall)
+}
+
+func (s *snapshotSuite) TestRestoreSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestRestoreSnapshotsBlocked")
+	_, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshotsArgs{
+		Snapshots: []string{"0"},
+	})
+	s.assertBlocked(c, err, "TestRestoreSnapshotsBlocked")
+	s.volumeSource.CheckNoCalls(c)
+}
+
+type volumeSnapshotter struct {
+	*dummy.VolumeSource
+}
+
+// SnapshotVolume is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) SnapshotVolume(volumeId string, tags map[string]string) (storage.SnapshotInfo, error) {
+	v.MethodCall(v, "SnapshotVolume", volumeId, tags)
+	return storage.SnapshotInfo{
+		SnapshotId: "snap-" + volumeId,
+		Size:       1024,
+	}, v.NextErr()
+}
+
+// RestoreVolume is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) RestoreVolume(snapshotId string, tags map[string]string) (storage.VolumeInfo, error) {
+	v.MethodCall(v, "RestoreVolume", snapshotId, tags)
+	return storage.VolumeInfo{
+		VolumeId:   "vol-" + snapshotId,
+		Size:       1024,
+		HardwareId: "hw",
+	}, v.NextErr()
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -27,6 +28,7 @@ type APIv3 struct {
 	storage     storageAccess
 	registry    storage.ProviderRegistry
 	poolManager poolmanager.PoolManager
+	resources   facade.Resources
 	authorizer  facade.Authorizer
 }
 
@@ -35,6 +37,66 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
+// APIv6 implements the storage v6 API.
+type APIv6 struct {
+	*APIv5
+}
+
+// APIv7 implements the storage v7 API.
+type APIv7 struct {
+	*APIv6
+}
+
+// NewAPIv7 returns a new storage v7 API facade.
+func NewAPIv7(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv7, error) {
+	apiv6, err := NewAPIv6(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv7{apiv6}, nil
+}
+
+// NewAPIv6 returns a new storage v6 API facade.
+func NewAPIv6(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv6, error) {
+	apiv5, err := NewAPIv5(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv6{apiv5}, nil
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	st storageAccess,
@@ -65,6 +127,7 @@ func NewAPIv3(
 		storage:     st,
 		registry:    registry,
 		poolManager: pm,
+		resources:   resources,
 		authorizer:  authorizer,
 	}, nil
 }
@@ -673,6 +736,14 @@ func createFilesystemDetails(
 					stateInfo,
 				)
 			}
+			if usage, ok := attachment.Usage(); ok {
+				attDetails.Usage = &params.FilesystemUsage{
+					Size:      usage.Size,
+					Used:      usage.Used,
+					Available: usage.Available,
+					Updated:   usage.Updated,
+				}
+			}
 			details.MachineAttachments[attachment.Machine().String()] = attDetails
 		}
 	}
@@ -921,6 +992,38 @@ func (a *APIv3) attachStorage(storageTag names.StorageTag, unitTag names.UnitTag
 	return a.storage.AttachStorage(storageTag, unitTag)
 }
 
+// Grow requests that the specified storage instances be grown to the
+// specified sizes. The storage is grown by the storage provisioner, and
+// any filesystem on it by the machine agent of the attached machine.
+// A "CHANGE" block can block this operation.
+func (a *APIv5) Grow(args params.StoragesGrowArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	growOne := func(arg params.StorageGrowArg) error {
+		tag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return err
+		}
+		if arg.Size == 0 {
+			return errors.NotValidf("zero size")
+		}
+		return a.storage.GrowStorageInstance(tag, arg.Size)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		result[i].Error = common.ServerError(growOne(arg))
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
 // Import imports existing storage into the model.
 // A "CHANGE" block can block this operation.
 func (a *APIv4) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
@@ -954,24 +1057,42 @@ func (a *APIv4) importStorage(arg params.ImportStorageParams) (*params.ImportSto
 		return nil, errors.NotValidf("pool name %q", arg.Pool)
 	}
 
-	cfg, err := a.poolManager.Get(arg.Pool)
+	cfg, provider, err := a.poolProvider(arg.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.importFilesystem(arg, provider, cfg)
+}
+
+// poolProvider returns the config of the named storage pool, and its
+// storage provider. If there is no pool with the given name, the name
+// is taken to be that of a storage provider type.
+func (a *APIv3) poolProvider(pool string) (*storage.Config, storage.Provider, error) {
+	cfg, err := a.poolManager.Get(pool)
 	if errors.IsNotFound(err) {
 		cfg, err = storage.NewConfig(
-			arg.Pool,
-			storage.ProviderType(arg.Pool),
+			pool,
+			storage.ProviderType(pool),
 			map[string]interface{}{},
 		)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	} else if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	provider, err := a.registry.StorageProvider(cfg.Provider())
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
+	}
+	return cfg, provider, nil
+}
+
+func (a *APIv3) resourceTags() map[string]string {
+	return map[string]string{
+		tags.JujuModel:      a.storage.ModelTag().Id(),
+		tags.JujuController: a.storage.ControllerTag().Id(),
 	}
-	return a.importFilesystem(arg, provider, cfg)
 }
 
 func (a *APIv4) importFilesystem(
@@ -979,10 +1100,7 @@ func (a *APIv4) importFilesystem(
 	provider storage.Provider,
 	cfg *storage.Config,
 ) (*params.ImportStorageDetails, error) {
-	resourceTags := map[string]string{
-		tags.JujuModel:      a.storage.ModelTag().Id(),
-		tags.JujuController: a.storage.ControllerTag().Id(),
-	}
+	resourceTags := a.resourceTags()
 	var volumeInfo *state.VolumeInfo
 	filesystemInfo := state.FilesystemInfo{Pool: arg.Pool}
 
@@ -1042,9 +1160,243 @@ func (a *APIv4) importFilesystem(
 	}, nil
 }
 
+// CreateSnapshots snapshots the volumes underlying the specified storage
+// instances, using the storage provider, and records the snapshots in
+// the model. Filesystem storage may only be snapshotted if it is backed
+// by a volume.
+// A "CHANGE" block can block this operation.
+func (a *APIv6) CreateSnapshots(args params.Entities) (params.StorageSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.StorageSnapshotResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		details, err := a.createSnapshot(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.StorageSnapshotResults{Results: results}, nil
+}
+
+func (a *APIv6) createSnapshot(tagString string) (*params.StorageSnapshotDetails, error) {
+	storageTag, err := names.ParseStorageTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, err := a.storageInstanceBackingVolume(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, err := a.volumeSnapshotter(info.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotInfo, err := snapshotter.SnapshotVolume(info.VolumeId, a.resourceTags())
+	if err != nil {
+		return nil, errors.Annotate(err, "snapshotting volume")
+	}
+	snapshot, err := a.storage.AddVolumeSnapshot(volume.VolumeTag(), snapshotInfo.SnapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return createSnapshotDetails(snapshot), nil
+}
+
+// storageInstanceBackingVolume returns the volume underlying the
+// storage instance with the specified tag.
+func (a *APIv6) storageInstanceBackingVolume(tag names.StorageTag) (state.Volume, error) {
+	storageInstance, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch storageInstance.Kind() {
+	case state.StorageKindBlock:
+		return a.storage.StorageInstanceVolume(tag)
+	case state.StorageKindFilesystem:
+		filesystem, err := a.storage.StorageInstanceFilesystem(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeTag, err := filesystem.Volume()
+		if errors.Cause(err) == state.ErrNoBackingVolume {
+			return nil, errors.NotSupportedf("snapshotting filesystem without backing volume")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return a.storage.Volume(volumeTag)
+	}
+	return nil, errors.NotSupportedf("snapshotting %s storage", storageInstance.Kind())
+}
+
+// volumeSnapshotter returns the storage.VolumeSnapshotter for volumes
+// in the named storage pool.
+func (a *APIv6) volumeSnapshotter(pool string) (storage.VolumeSnapshotter, error) {
+	cfg, provider, err := a.poolProvider(pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf(
+			"snapshotting volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	return snapshotter, nil
+}
+
+// ListSnapshots returns the details of all of the storage snapshots
+// in the model.
+func (a *APIv6) ListSnapshots() (params.StorageSnapshotResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+	snapshots, err := a.storage.VolumeSnapshots()
+	if err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+	results := make([]params.StorageSnapshotResult, len(snapshots))
+	for i, snapshot := range snapshots {
+		results[i].Result = createSnapshotDetails(snapshot)
+	}
+	return params.StorageSnapshotResults{Results: results}, nil
+}
+
+// RestoreSnapshots restores new volumes from the specified storage
+// snapshots, using the storage provider, and adds a detached storage
+// instance to the model for each of them. The storage instances may be
+// attached to new units with "juju add-unit --attach-storage".
+// A "CHANGE" block can block this operation.
+func (a *APIv6) RestoreSnapshots(args params.RestoreStorageSnapshotsArgs) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ImportStorageResult, len(args.Snapshots))
+	for i, id := range args.Snapshots {
+		details, err := a.restoreSnapshot(id)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *APIv6) restoreSnapshot(id string) (*params.ImportStorageDetails, error) {
+	snapshot, err := a.storage.VolumeSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, err := a.volumeSnapshotter(snapshot.Pool())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := snapshotter.RestoreVolume(snapshot.SnapshotId(), a.resourceTags())
+	if err != nil {
+		return nil, errors.Annotate(err, "restoring volume")
+	}
+	storageTag, err := a.storage.AddStorageFromSnapshot(id, state.VolumeInfo{
+		HardwareId: info.HardwareId,
+		WWN:        info.WWN,
+		Size:       info.Size,
+		VolumeId:   info.VolumeId,
+		Persistent: info.Persistent,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+func createSnapshotDetails(snapshot state.VolumeSnapshot) *params.StorageSnapshotDetails {
+	return &params.StorageSnapshotDetails{
+		Id:          snapshot.Id(),
+		SnapshotId:  snapshot.SnapshotId(),
+		StorageTag:  snapshot.Storage().String(),
+		VolumeTag:   snapshot.Volume().String(),
+		StorageName: snapshot.StorageName(),
+		Kind:        params.StorageKind(snapshot.Kind()),
+		Pool:        snapshot.Pool(),
+		Size:        snapshot.Size(),
+		Created:     snapshot.Created(),
+	}
+}
+
 // Mask out old methods from the new API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
 // Destroy was dropped in V4, replaced with Remove.
 func (*APIv4) Destroy(_, _ struct{}) {}
+
+// WatchVolumeAttachments returns a watcher that notifies of changes to
+// the model's volume attachments, including their provisioning and
+// removal, so that clients can follow volumes being attached to and
+// detached from machines.
+func (a *APIv7) WatchVolumeAttachments() (params.MachineStorageIdsWatchResult, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.MachineStorageIdsWatchResult{}, errors.Trace(err)
+	}
+	return a.watchAttachments(
+		a.storage.WatchVolumeAttachmentChanges(),
+		storagecommon.ParseVolumeAttachmentIds,
+	)
+}
+
+// WatchFilesystemAttachments returns a watcher that notifies of changes
+// to the model's filesystem attachments, including their provisioning
+// and removal.
+func (a *APIv7) WatchFilesystemAttachments() (params.MachineStorageIdsWatchResult, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.MachineStorageIdsWatchResult{}, errors.Trace(err)
+	}
+	return a.watchAttachments(
+		a.storage.WatchFilesystemAttachmentChanges(),
+		storagecommon.ParseFilesystemAttachmentIds,
+	)
+}
+
+func (a *APIv7) watchAttachments(
+	w state.StringsWatcher,
+	parseAttachmentIds func([]string) ([]params.MachineStorageId, error),
+) (params.MachineStorageIdsWatchResult, error) {
+	stringChanges, ok := <-w.Changes()
+	if !ok {
+		return params.MachineStorageIdsWatchResult{}, watcher.EnsureErr(w)
+	}
+	changes, err := parseAttachmentIds(stringChanges)
+	if err != nil {
+		w.Stop()
+		return params.MachineStorageIdsWatchResult{}, errors.Trace(err)
+	}
+	return params.MachineStorageIdsWatchResult{
+		MachineStorageIdsWatcherId: a.resources.Register(w),
+		Changes:                    changes,
+	}, nil
+}
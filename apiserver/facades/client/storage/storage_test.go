@@ -356,6 +356,44 @@ func (s *storageSuite) TestRemove(c *gc.C) {
 	s.stub.CheckCall(c, 4, releaseStorageInstanceCall, names.NewStorageTag("foo/1"), true)
 }
 
+func (s *storageSuite) TestGrow(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotSupportedf("growing machine-scoped volume"))
+	results, err := s.api.Grow(params.StoragesGrowArgs{[]params.StorageGrowArg{
+		{StorageTag: "storage-foo-0", Size: 2048},
+		{StorageTag: "storage-foo-1", Size: 4096},
+		{StorageTag: "storage-foo-2"},
+		{StorageTag: "volume-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: "growing machine-scoped volume not supported",
+		}},
+		{Error: &params.Error{
+			Code:    params.CodeNotValid,
+			Message: "zero size not valid",
+		}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		growStorageInstanceCall,
+		growStorageInstanceCall,
+	)
+	s.stub.CheckCall(c, 1, growStorageInstanceCall, names.NewStorageTag("foo/0"), uint64(2048))
+	s.stub.CheckCall(c, 2, growStorageInstanceCall, names.NewStorageTag("foo/1"), uint64(4096))
+}
+
+func (s *storageSuite) TestGrowBlocked(c *gc.C) {
+	s.blockAllChanges(c, "growing is blocked")
+	_, err := s.api.Grow(params.StoragesGrowArgs{[]params.StorageGrowArg{
+		{StorageTag: "storage-foo-0", Size: 2048},
+	}})
+	s.assertBlocked(c, err, "growing is blocked")
+}
+
 func (s *storageSuite) TestDestroyV3(c *gc.C) {
 	results, err := s.apiv3.Destroy(params.Entities{[]params.Entity{
 		{Tag: "storage-foo-0"},
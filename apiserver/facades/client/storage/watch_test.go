@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
+)
+
+type watchSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&watchSuite{})
+
+func (s *watchSuite) TestWatchVolumeAttachments(c *gc.C) {
+	ch := make(chan []string, 1)
+	ch <- []string{"0:0", "1:2"}
+	s.state.watchVolumeAttachmentChanges = func() state.StringsWatcher {
+		s.stub.AddCall("WatchVolumeAttachmentChanges")
+		return watchertest.NewStringsWatcher(ch)
+	}
+
+	result, err := s.api.WatchVolumeAttachments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachineStorageIdsWatchResult{
+		MachineStorageIdsWatcherId: "1",
+		Changes: []params.MachineStorageId{
+			{MachineTag: "machine-0", AttachmentTag: "volume-0"},
+			{MachineTag: "machine-1", AttachmentTag: "volume-2"},
+		},
+	})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+	s.stub.CheckCallNames(c, "WatchVolumeAttachmentChanges")
+}
+
+func (s *watchSuite) TestWatchFilesystemAttachments(c *gc.C) {
+	ch := make(chan []string, 1)
+	ch <- []string{"0:0/1", "1:2"}
+	s.state.watchFilesystemAttachmentChanges = func() state.StringsWatcher {
+		s.stub.AddCall("WatchFilesystemAttachmentChanges")
+		return watchertest.NewStringsWatcher(ch)
+	}
+
+	result, err := s.api.WatchFilesystemAttachments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachineStorageIdsWatchResult{
+		MachineStorageIdsWatcherId: "1",
+		Changes: []params.MachineStorageId{
+			{MachineTag: "machine-0", AttachmentTag: "filesystem-0-1"},
+			{MachineTag: "machine-1", AttachmentTag: "filesystem-2"},
+		},
+	})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+	s.stub.CheckCallNames(c, "WatchFilesystemAttachmentChanges")
+}
+
+func (s *watchSuite) TestWatchVolumeAttachmentsInvalidId(c *gc.C) {
+	ch := make(chan []string, 1)
+	ch <- []string{"rubbish"}
+	s.state.watchVolumeAttachmentChanges = func() state.StringsWatcher {
+		return watchertest.NewStringsWatcher(ch)
+	}
+
+	_, err := s.api.WatchVolumeAttachments()
+	c.Assert(err, gc.ErrorMatches, `invalid volume attachment ID "rubbish"`)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *watchSuite) TestWatchVolumeAttachmentsWatcherStopped(c *gc.C) {
+	w := watchertest.NewStringsWatcher(make(chan []string))
+	w.Stop()
+	s.state.watchVolumeAttachmentChanges = func() state.StringsWatcher {
+		return w
+	}
+
+	_, err := s.api.WatchVolumeAttachments()
+	c.Assert(err, gc.ErrorMatches, "expected an error from .* got nil.*")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
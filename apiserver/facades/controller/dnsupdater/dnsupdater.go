@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsupdater provides the API used by the DNS updater worker,
+// which maintains DNS records for the addresses of exposed
+// applications.
+package dnsupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes functionality required by API.
+type Backend interface {
+	state.ModelAccessor
+
+	// ApplicationAddresses returns the public addresses of the alive
+	// units of every application in the model.
+	ApplicationAddresses() ([]ApplicationAddresses, error)
+}
+
+// ApplicationAddresses holds the public addresses of the alive units
+// of an application, and whether the application is exposed.
+type ApplicationAddresses struct {
+	Name      string
+	Exposed   bool
+	Addresses []string
+}
+
+// API provides access to the DNSUpdater API facade.
+type API struct {
+	*common.ModelWatcher
+
+	backend Backend
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(backendShim{st}, resources, authorizer)
+}
+
+// NewAPI returns a new DNSUpdater API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		// ModelConfig() and WatchForModelConfigChanges() are
+		// allowed with unrestricted access.
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+	}, nil
+}
+
+// ApplicationAddresses returns the public addresses of the alive units
+// of every application in the model, and whether each is exposed.
+func (api *API) ApplicationAddresses() (params.ApplicationAddressesResults, error) {
+	apps, err := api.backend.ApplicationAddresses()
+	if err != nil {
+		return params.ApplicationAddressesResults{}, errors.Trace(err)
+	}
+	results := make([]params.ApplicationAddresses, len(apps))
+	for i, app := range apps {
+		results[i] = params.ApplicationAddresses{
+			ApplicationTag: names.NewApplicationTag(app.Name).String(),
+			Exposed:        app.Exposed,
+			Addresses:      app.Addresses,
+		}
+	}
+	return params.ApplicationAddressesResults{Results: results}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/dnsupdater"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type dnsUpdaterSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&dnsUpdaterSuite{})
+
+func (s *dnsUpdaterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+}
+
+func (s *dnsUpdaterSuite) newAPI(c *gc.C) *dnsupdater.API {
+	api, err := dnsupdater.NewAPI(s.backend, common.NewResources(), s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *dnsUpdaterSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := dnsupdater.NewAPI(s.backend, common.NewResources(), s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *dnsUpdaterSuite) TestApplicationAddresses(c *gc.C) {
+	s.backend.apps = []dnsupdater.ApplicationAddresses{{
+		Name:      "wordpress",
+		Exposed:   true,
+		Addresses: []string{"10.0.0.1", "2001:db8::1"},
+	}, {
+		Name: "mysql",
+	}}
+	result, err := s.newAPI(c).ApplicationAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ApplicationAddressesResults{
+		Results: []params.ApplicationAddresses{{
+			ApplicationTag: "application-wordpress",
+			Exposed:        true,
+			Addresses:      []string{"10.0.0.1", "2001:db8::1"},
+		}, {
+			ApplicationTag: "application-mysql",
+		}},
+	})
+}
+
+func (s *dnsUpdaterSuite) TestApplicationAddressesError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	_, err := s.newAPI(c).ApplicationAddresses()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	state.ModelAccessor
+
+	apps []dnsupdater.ApplicationAddresses
+	err  error
+}
+
+func (b *mockBackend) ApplicationAddresses() ([]dnsupdater.ApplicationAddresses, error) {
+	return b.apps, b.err
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	*state.State
+}
+
+// ApplicationAddresses is part of the Backend interface.
+func (shim backendShim) ApplicationAddresses() ([]ApplicationAddresses, error) {
+	apps, err := shim.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ApplicationAddresses, len(apps))
+	for i, app := range apps {
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting units of %q", app.Name())
+		}
+		result[i] = ApplicationAddresses{
+			Name:    app.Name(),
+			Exposed: app.IsExposed(),
+		}
+		for _, unit := range units {
+			if unit.Life() != state.Alive {
+				continue
+			}
+			addr, err := unit.PublicAddress()
+			if network.IsNoAddressError(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Annotatef(err, "getting public address of %q", unit.Name())
+			}
+			result[i].Addresses = append(result[i].Addresses, addr.Value)
+		}
+	}
+	return result, nil
+}
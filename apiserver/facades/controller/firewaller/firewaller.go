@@ -46,6 +46,16 @@ type FirewallerAPIV4 struct {
 	*common.ControllerConfigAPI
 }
 
+// FirewallerAPIV5 provides access to the Firewaller v5 API facade.
+type FirewallerAPIV5 struct {
+	*FirewallerAPIV4
+}
+
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
 // NewStateFirewallerAPIv3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -74,6 +84,24 @@ func NewStateFirewallerAPIV4(context facade.Context) (*FirewallerAPIV4, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV5 creates a new server-side FirewallerAPIV5 facade.
+func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
+	facadev4, err := NewStateFirewallerAPIV4(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV5{facadev4}, nil
+}
+
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{facadev5}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -318,6 +346,66 @@ func (f *FirewallerAPIV3) GetExposed(args params.Entities) (params.BoolResults,
 	return result, nil
 }
 
+// GetExposedCIDRs returns the source CIDRs from which each given
+// exposed application may be accessed. No CIDRs means access from
+// anywhere.
+func (f *FirewallerAPIV5) GetExposedCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = application.ExposedCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchContainers returns a new StringsWatcher for the containers
+// of each given machine, so that the ports of containers that have
+// firewall rules of their own can be opened and closed.
+func (f *FirewallerAPIV6) WatchContainers(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.StringsWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := machine.WatchAllContainers()
+		// Consume the initial event and forward it to the result.
+		if changes, ok := <-watch.Changes(); ok {
+			result.Results[i].StringsWatcherId = f.resources.Register(watch)
+			result.Results[i].Changes = changes
+		} else {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPIV3) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -87,6 +88,88 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposedCIDRs(c *gc.C) {
+	api := &firewaller.FirewallerAPIV5{
+		FirewallerAPIV4: &firewaller.FirewallerAPIV4{FirewallerAPIV3: s.firewaller},
+	}
+	err := s.application.SetExposedToCIDRs([]string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	result, err := api.GetExposedCIDRs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"10.0.0.0/24"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Exposing to anywhere has no CIDRs.
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.GetExposedCIDRs(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{}},
+	})
+}
+
+func (s *firewallerSuite) TestWatchContainers(c *gc.C) {
+	api := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			FirewallerAPIV4: &firewaller.FirewallerAPIV4{FirewallerAPIV3: s.firewaller},
+		},
+	}
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machines[0].Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := api.WatchContainers(params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: s.application.Tag().String()},
+		{Tag: "machine-42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{Changes: []string{container.Id()}, StringsWatcherId: "1"},
+			{StringsWatcherId: "2"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	err = container.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(container.Id())
+	wc.AssertNoChange()
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
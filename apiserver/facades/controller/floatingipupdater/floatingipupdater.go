@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package floatingipupdater provides the API used by the floating IP
+// updater worker, which associates the floating IP addresses of exposed
+// applications with the machines of their leaders.
+package floatingipupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes functionality required by API.
+type Backend interface {
+	// FloatingIPs returns the floating IP addresses of the alive,
+	// exposed applications in the model that have one.
+	FloatingIPs() ([]FloatingIP, error)
+
+	// SetFloatingIPUnit records that the given floating IP address
+	// of the named application has been associated with the machine
+	// of the named unit.
+	SetFloatingIPUnit(application, address, unitName string) error
+}
+
+// FloatingIP holds the floating IP address of an application, the
+// unit whose machine it is associated with, and the application's
+// leader and the instance of its machine. The leader and its instance
+// are empty if unknown.
+type FloatingIP struct {
+	Application      string
+	Address          string
+	Unit             string
+	Leader           string
+	LeaderInstanceId instance.Id
+}
+
+// API provides access to the FloatingIPUpdater API facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(backendShim{st}, authorizer)
+}
+
+// NewAPI returns a new FloatingIPUpdater API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// FloatingIPs returns the floating IP addresses of the alive, exposed
+// applications in the model, together with their leaders.
+func (api *API) FloatingIPs() (params.FloatingIPResults, error) {
+	fips, err := api.backend.FloatingIPs()
+	if err != nil {
+		return params.FloatingIPResults{}, errors.Trace(err)
+	}
+	results := make([]params.FloatingIP, len(fips))
+	for i, fip := range fips {
+		results[i] = params.FloatingIP{
+			ApplicationTag:   names.NewApplicationTag(fip.Application).String(),
+			Address:          fip.Address,
+			LeaderInstanceId: string(fip.LeaderInstanceId),
+		}
+		if fip.Unit != "" {
+			results[i].UnitTag = names.NewUnitTag(fip.Unit).String()
+		}
+		if fip.Leader != "" {
+			results[i].LeaderTag = names.NewUnitTag(fip.Leader).String()
+		}
+	}
+	return params.FloatingIPResults{Results: results}, nil
+}
+
+// SetFloatingIPUnits records the units whose machines the floating IP
+// addresses of applications have been associated with.
+func (api *API) SetFloatingIPUnits(args params.SetFloatingIPUnitArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setFloatingIPUnit(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setFloatingIPUnit(arg params.SetFloatingIPUnitArg) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return api.backend.SetFloatingIPUnit(appTag.Id(), arg.Address, unitTag.Id())
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package floatingipupdater_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/floatingipupdater"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type floatingIPUpdaterSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&floatingIPUpdaterSuite{})
+
+func (s *floatingIPUpdaterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+}
+
+func (s *floatingIPUpdaterSuite) newAPI(c *gc.C) *floatingipupdater.API {
+	api, err := floatingipupdater.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *floatingIPUpdaterSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := floatingipupdater.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *floatingIPUpdaterSuite) TestFloatingIPs(c *gc.C) {
+	s.backend.fips = []floatingipupdater.FloatingIP{{
+		Application:      "postgresql",
+		Address:          "203.0.113.10",
+		Unit:             "postgresql/0",
+		Leader:           "postgresql/1",
+		LeaderInstanceId: "i-1",
+	}, {
+		Application: "mysql",
+		Address:     "203.0.113.11",
+	}}
+	result, err := s.newAPI(c).FloatingIPs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FloatingIPResults{
+		Results: []params.FloatingIP{{
+			ApplicationTag:   "application-postgresql",
+			Address:          "203.0.113.10",
+			UnitTag:          "unit-postgresql-0",
+			LeaderTag:        "unit-postgresql-1",
+			LeaderInstanceId: "i-1",
+		}, {
+			ApplicationTag: "application-mysql",
+			Address:        "203.0.113.11",
+		}},
+	})
+}
+
+func (s *floatingIPUpdaterSuite) TestFloatingIPsError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).FloatingIPs()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *floatingIPUpdaterSuite) TestSetFloatingIPUnits(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("floating IP has changed"))
+	result, err := s.newAPI(c).SetFloatingIPUnits(params.SetFloatingIPUnitArgs{
+		Args: []params.SetFloatingIPUnitArg{{
+			ApplicationTag: "application-postgresql",
+			Address:        "203.0.113.10",
+			UnitTag:        "unit-postgresql-1",
+		}, {
+			ApplicationTag: "application-mysql",
+			Address:        "203.0.113.11",
+			UnitTag:        "unit-mysql-0",
+		}, {
+			ApplicationTag: "unit-mysql-0",
+			UnitTag:        "unit-mysql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{&params.Error{Message: "floating IP has changed"}},
+			{&params.Error{Message: `"unit-mysql-0" is not a valid application tag`}},
+		},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetFloatingIPUnit", []interface{}{"postgresql", "203.0.113.10", "postgresql/1"}},
+		{"SetFloatingIPUnit", []interface{}{"mysql", "203.0.113.11", "mysql/0"}},
+	})
+}
+
+type mockBackend struct {
+	*testing.Stub
+
+	fips []floatingipupdater.FloatingIP
+}
+
+func (b *mockBackend) FloatingIPs() ([]floatingipupdater.FloatingIP, error) {
+	b.MethodCall(b, "FloatingIPs")
+	return b.fips, b.NextErr()
+}
+
+func (b *mockBackend) SetFloatingIPUnit(application, address, unitName string) error {
+	b.MethodCall(b, "SetFloatingIPUnit", application, address, unitName)
+	return b.NextErr()
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package floatingipupdater
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	*state.State
+}
+
+// FloatingIPs is part of the Backend interface.
+func (shim backendShim) FloatingIPs() ([]FloatingIP, error) {
+	apps, err := shim.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	leaders, err := shim.ApplicationLeaders()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting application leaders")
+	}
+	var result []FloatingIP
+	for _, app := range apps {
+		address, unitName := app.FloatingIP()
+		if address == "" || !app.IsExposed() || app.Life() != state.Alive {
+			continue
+		}
+		fip := FloatingIP{
+			Application: app.Name(),
+			Address:     address,
+			Unit:        unitName,
+			Leader:      leaders[app.Name()],
+		}
+		if fip.Leader != "" {
+			fip.LeaderInstanceId, err = shim.unitInstanceId(fip.Leader)
+			if err != nil {
+				return nil, errors.Annotatef(err, "getting instance of %q", fip.Leader)
+			}
+		}
+		result = append(result, fip)
+	}
+	return result, nil
+}
+
+// unitInstanceId returns the instance of the unit's machine, or the
+// empty string if the unit is not on a provisioned machine.
+func (shim backendShim) unitInstanceId(unitName string) (instance.Id, error) {
+	unit, err := shim.Unit(unitName)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	machine, err := shim.Machine(machineId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if errors.IsNotProvisioned(err) {
+		return "", nil
+	}
+	return instId, errors.Trace(err)
+}
+
+// SetFloatingIPUnit is part of the Backend interface.
+func (shim backendShim) SetFloatingIPUnit(application, address, unitName string) error {
+	app, err := shim.Application(application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current, _ := app.FloatingIP(); current != address {
+		return errors.Errorf("floating IP of application %q has changed", application)
+	}
+	return app.SetFloatingIPUnit(unitName)
+}
@@ -20,7 +20,14 @@ type Backend interface {
 	ModelName() (string, error)
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
+	ModelCloudCredential() (names.CloudCredentialTag, bool, error)
+	StoragePoolProviders() ([]string, error)
 	RemoveExportingModelDocs() error
 
+	// CharmGitSource returns the git source of the charm with the
+	// given URL, and whether the charm was built from a git
+	// repository.
+	CharmGitSource(curl string) (state.CharmGitSource, bool, error)
+
 	migration.StateExporter
 }
@@ -5,6 +5,7 @@ package migrationmaster
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/juju/description"
 	"github.com/juju/errors"
@@ -126,11 +127,25 @@ func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 		return empty, errors.Annotate(err, "retrieving agent version")
 	}
 
+	var credTag string
+	if tag, ok, err := api.backend.ModelCloudCredential(); err != nil {
+		return empty, errors.Annotate(err, "retrieving cloud credential")
+	} else if ok {
+		credTag = tag.String()
+	}
+
+	storageProviders, err := api.backend.StoragePoolProviders()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving storage providers")
+	}
+
 	return params.MigrationModelInfo{
-		UUID:         api.backend.ModelUUID(),
-		Name:         name,
-		OwnerTag:     owner.String(),
-		AgentVersion: vers,
+		UUID:               api.backend.ModelUUID(),
+		Name:               name,
+		OwnerTag:           owner.String(),
+		AgentVersion:       vers,
+		CloudCredentialTag: credTag,
+		StorageProviders:   storageProviders,
 	}, nil
 }
 
@@ -187,9 +202,40 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Charms = getUsedCharms(model)
 	serialized.Tools = getUsedTools(model)
 	serialized.Resources = getUsedResources(model)
+	serialized.CharmGitSources, err = api.getCharmGitSources(serialized.Charms)
+	if err != nil {
+		return serialized, errors.Trace(err)
+	}
 	return serialized, nil
 }
 
+// getCharmGitSources returns the git sources of the given charms that
+// were built from git repositories, so that the target controller can
+// record them and the charms can still be refreshed from their refs.
+func (api *API) getCharmGitSources(charmURLs []string) ([]params.SerializedModelCharmGitSource, error) {
+	var result []params.SerializedModelCharmGitSource
+	for _, curl := range charmURLs {
+		// Only local charms are built from git repositories.
+		if !strings.HasPrefix(curl, "local:") {
+			continue
+		}
+		source, ok, err := api.backend.CharmGitSource(curl)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting git source of charm %q", curl)
+		}
+		if !ok {
+			continue
+		}
+		result = append(result, params.SerializedModelCharmGitSource{
+			Charm:      curl,
+			Repository: source.Repository,
+			Ref:        source.Ref,
+			Revision:   source.Revision,
+		})
+	}
+	return result, nil
+}
+
 // Reap removes all documents for the model associated with the API
 // connection.
 func (api *API) Reap() error {
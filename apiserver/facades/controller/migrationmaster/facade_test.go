@@ -122,6 +122,8 @@ func (s *Suite) TestModelInfo(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "model-name")
 	c.Assert(model.OwnerTag, gc.Equals, names.NewUserTag("owner").String())
 	c.Assert(model.AgentVersion, gc.Equals, version.MustParse("1.2.3"))
+	c.Assert(model.CloudCredentialTag, gc.Equals, "cloudcred-cloud_owner_cred")
+	c.Assert(model.StorageProviders, jc.DeepEquals, []string{"ebs"})
 }
 
 func (s *Suite) TestSetPhase(c *gc.C) {
@@ -298,7 +300,45 @@ func (s *Suite) TestExport(c *gc.C) {
 			},
 		},
 	}})
+	c.Check(serialized.CharmGitSources, gc.HasLen, 0)
+}
+
+func (s *Suite) TestExportCharmGitSources(c *gc.C) {
+	for name, curl := range map[string]string{
+		"foo": "cs:foo-0",
+		"bar": "local:xenial/bar-1",
+		"baz": "local:xenial/baz-2",
+	} {
+		s.model.AddApplication(description.ApplicationArgs{
+			Tag:      names.NewApplicationTag(name),
+			CharmURL: curl,
+		})
+	}
+	s.backend.gitSources = map[string]state.CharmGitSource{
+		"local:xenial/bar-1": {
+			Repository: "git+ssh://git@example.com/bar.git",
+			Ref:        "stable",
+			Revision:   "0123456789abcdef",
+		},
+	}
 
+	api := s.mustMakeAPI(c)
+	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.CharmGitSources, jc.DeepEquals, []params.SerializedModelCharmGitSource{{
+		Charm:      "local:xenial/bar-1",
+		Repository: "git+ssh://git@example.com/bar.git",
+		Ref:        "stable",
+		Revision:   "0123456789abcdef",
+	}})
+	// Only local charms can have been built from git repositories.
+	var looked []string
+	for _, call := range s.stub.Calls() {
+		if call.FuncName == "CharmGitSource" {
+			looked = append(looked, call.Args[0].(string))
+		}
+	}
+	c.Check(looked, jc.SameContents, []string{"local:xenial/bar-1", "local:xenial/baz-2"})
 }
 
 func (s *Suite) TestReap(c *gc.C) {
@@ -414,11 +454,12 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationmaster.API {
 type stubBackend struct {
 	migrationmaster.Backend
 
-	stub      *testing.Stub
-	getErr    error
-	removeErr error
-	migration *stubMigration
-	model     description.Model
+	stub       *testing.Stub
+	getErr     error
+	removeErr  error
+	migration  *stubMigration
+	model      description.Model
+	gitSources map[string]state.CharmGitSource
 }
 
 func (b *stubBackend) WatchForMigration() state.NotifyWatcher {
@@ -450,6 +491,14 @@ func (b *stubBackend) AgentVersion() (version.Number, error) {
 	return version.MustParse("1.2.3"), nil
 }
 
+func (b *stubBackend) ModelCloudCredential() (names.CloudCredentialTag, bool, error) {
+	return names.NewCloudCredentialTag("cloud/owner/cred"), true, nil
+}
+
+func (b *stubBackend) StoragePoolProviders() ([]string, error) {
+	return []string{"ebs"}, nil
+}
+
 func (b *stubBackend) RemoveExportingModelDocs() error {
 	b.stub.AddCall("RemoveExportingModelDocs")
 	return b.removeErr
@@ -460,6 +509,12 @@ func (b *stubBackend) Export() (description.Model, error) {
 	return b.model, nil
 }
 
+func (b *stubBackend) CharmGitSource(curl string) (state.CharmGitSource, bool, error) {
+	b.stub.AddCall("CharmGitSource", curl)
+	source, ok := b.gitSources[curl]
+	return source, ok, b.stub.NextErr()
+}
+
 type stubMigration struct {
 	state.ModelMigration
 
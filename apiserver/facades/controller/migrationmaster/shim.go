@@ -5,12 +5,17 @@ package migrationmaster
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 // NewFacade exists to provide the required signature for API
@@ -70,3 +75,45 @@ func (s *backendShim) AgentVersion() (version.Number, error) {
 	}
 	return vers, nil
 }
+
+// ModelCloudCredential implements Backend.
+func (s *backendShim) ModelCloudCredential() (names.CloudCredentialTag, bool, error) {
+	m, err := s.Model()
+	if err != nil {
+		return names.CloudCredentialTag{}, false, errors.Trace(err)
+	}
+	tag, ok := m.CloudCredential()
+	return tag, ok, nil
+}
+
+// StoragePoolProviders implements Backend.
+func (s *backendShim) StoragePoolProviders() ([]string, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pools, err := poolmanager.New(state.NewStateSettings(s.State), registry).List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	providers := set.NewStrings()
+	for _, pool := range pools {
+		providers.Add(string(pool.Provider()))
+	}
+	return providers.SortedValues(), nil
+}
+
+// CharmGitSource implements Backend.
+func (s *backendShim) CharmGitSource(curl string) (state.CharmGitSource, bool, error) {
+	url, err := charm.ParseURL(curl)
+	if err != nil {
+		return state.CharmGitSource{}, false, errors.Trace(err)
+	}
+	ch, err := s.Charm(url)
+	if err != nil {
+		return state.CharmGitSource{}, false, errors.Trace(err)
+	}
+	source, ok := ch.GitSource()
+	return source, ok, nil
+}
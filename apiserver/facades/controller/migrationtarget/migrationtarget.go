@@ -84,6 +84,13 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	var credTag names.CloudCredentialTag
+	if model.CloudCredentialTag != "" {
+		credTag, err = names.ParseCloudCredentialTag(model.CloudCredentialTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	backend, err := migration.PrecheckShim(api.state)
 	if err != nil {
 		return errors.Annotate(err, "creating backend")
@@ -97,6 +104,8 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 			Owner:                  ownerTag,
 			AgentVersion:           model.AgentVersion,
 			ControllerAgentVersion: model.ControllerAgentVersion,
+			CloudCredential:        credTag,
+			StorageProviders:       model.StorageProviders,
 		},
 	)
 }
@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package securitygroupgc provides the API used by the security group
+// garbage collection worker, which deletes the per-machine security
+// groups left behind by machines that no longer exist.
+package securitygroupgc
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes functionality required by API.
+type Backend interface {
+	// MachineIds returns the IDs of all machines in the model,
+	// whatever their life.
+	MachineIds() ([]string, error)
+}
+
+// API provides access to the SecurityGroupGC API facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(backendShim{st}, authorizer)
+}
+
+// NewAPI returns a new SecurityGroupGC API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// MachineIds returns the IDs of all machines in the model. Machines
+// that are dying or dead are included, as their security groups are
+// still being cleaned up by the provisioner.
+func (api *API) MachineIds() (params.StringsResult, error) {
+	ids, err := api.backend.MachineIds()
+	if err != nil {
+		return params.StringsResult{}, errors.Trace(err)
+	}
+	return params.StringsResult{Result: ids}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securitygroupgc_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/securitygroupgc"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type securityGroupGCSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&securityGroupGCSuite{})
+
+func (s *securityGroupGCSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{ids: []string{"0", "1", "2/lxd/0"}}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+}
+
+func (s *securityGroupGCSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := securitygroupgc.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *securityGroupGCSuite) TestMachineIds(c *gc.C) {
+	api, err := securitygroupgc.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.MachineIds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResult{
+		Result: []string{"0", "1", "2/lxd/0"},
+	})
+}
+
+func (s *securityGroupGCSuite) TestMachineIdsError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	api, err := securitygroupgc.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.MachineIds()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	ids []string
+	err error
+}
+
+func (b *mockBackend) MachineIds() ([]string, error) {
+	return b.ids, b.err
+}
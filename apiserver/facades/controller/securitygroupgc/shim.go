@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securitygroupgc
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	*state.State
+}
+
+// MachineIds is part of the Backend interface.
+func (shim backendShim) MachineIds() ([]string, error) {
+	machines, err := shim.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(machines))
+	for i, m := range machines {
+		ids[i] = m.Id()
+	}
+	return ids, nil
+}
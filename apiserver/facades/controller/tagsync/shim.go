@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagsync
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	*state.State
+}
+
+// ModelAnnotations is part of the Backend interface.
+func (shim backendShim) ModelAnnotations() (map[string]string, error) {
+	model, err := shim.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.Annotations(model)
+}
+
+// ApplicationAnnotations is part of the Backend interface.
+func (shim backendShim) ApplicationAnnotations(name string) (map[string]string, error) {
+	model, err := shim.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := shim.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.Annotations(app)
+}
+
+// MachineInstances is part of the Backend interface.
+func (shim backendShim) MachineInstances() ([]MachineInstance, error) {
+	machines, err := shim.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []MachineInstance
+	for _, m := range machines {
+		if m.Life() != state.Alive {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "getting instance of machine %q", m.Id())
+		}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting units of machine %q", m.Id())
+		}
+		seen := make(map[string]bool)
+		var apps []string
+		for _, unit := range units {
+			if name := unit.ApplicationName(); !seen[name] {
+				seen[name] = true
+				apps = append(apps, name)
+			}
+		}
+		sort.Strings(apps)
+		result = append(result, MachineInstance{
+			Id:           m.Id(),
+			InstanceId:   instId,
+			Applications: apps,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tagsync provides the API used by the tag sync worker, which
+// sets the resource tags defined by model and application annotations
+// on the instances of a model's machines.
+package tagsync
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by API.
+type Backend interface {
+	// WatchAnnotations returns a watcher that notifies when the
+	// annotations of any entity in the model change.
+	WatchAnnotations() state.NotifyWatcher
+
+	// ModelAnnotations returns the annotations of the model.
+	ModelAnnotations() (map[string]string, error)
+
+	// ApplicationAnnotations returns the annotations of the named
+	// application.
+	ApplicationAnnotations(name string) (map[string]string, error)
+
+	// MachineInstances returns the instances of the alive,
+	// provisioned machines in the model.
+	MachineInstances() ([]MachineInstance, error)
+}
+
+// MachineInstance holds the instance of a provisioned machine, and
+// the applications with units deployed to it.
+type MachineInstance struct {
+	Id           string
+	InstanceId   instance.Id
+	Applications []string
+}
+
+// API provides access to the TagSync API facade.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(backendShim{st}, resources, authorizer)
+}
+
+// NewAPI returns a new TagSync API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// WatchAnnotations returns a watcher that notifies when the
+// annotations of any entity in the model change.
+func (api *API) WatchAnnotations() (params.NotifyWatchResult, error) {
+	watch := api.backend.WatchAnnotations()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// InstanceTags returns the tags, defined by annotations, that should
+// be set on the instance of each provisioned machine in the model.
+// The tags of the model's annotations apply to every instance, and
+// are overridden by those of the applications with units on the
+// machine, in order of application name.
+func (api *API) InstanceTags() (params.InstanceTagsResults, error) {
+	modelAnnotations, err := api.backend.ModelAnnotations()
+	if err != nil {
+		return params.InstanceTagsResults{}, errors.Annotate(err, "getting model annotations")
+	}
+	modelTags := tags.AnnotationTags(modelAnnotations)
+	machines, err := api.backend.MachineInstances()
+	if err != nil {
+		return params.InstanceTagsResults{}, errors.Trace(err)
+	}
+
+	appTags := make(map[string]map[string]string)
+	results := make([]params.InstanceTags, len(machines))
+	for i, m := range machines {
+		instanceTags := make(map[string]string)
+		for k, v := range modelTags {
+			instanceTags[k] = v
+		}
+		apps := append([]string(nil), m.Applications...)
+		sort.Strings(apps)
+		for _, app := range apps {
+			t, ok := appTags[app]
+			if !ok {
+				annotations, err := api.backend.ApplicationAnnotations(app)
+				if err != nil {
+					return params.InstanceTagsResults{}, errors.Annotatef(err, "getting annotations of %q", app)
+				}
+				t = tags.AnnotationTags(annotations)
+				appTags[app] = t
+			}
+			for k, v := range t {
+				instanceTags[k] = v
+			}
+		}
+		results[i] = params.InstanceTags{
+			MachineTag: names.NewMachineTag(m.Id).String(),
+			InstanceId: string(m.InstanceId),
+			Tags:       instanceTags,
+		}
+	}
+	return params.InstanceTagsResults{Results: results}, nil
+}
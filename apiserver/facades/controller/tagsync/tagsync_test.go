@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagsync_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/tagsync"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type tagSyncSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&tagSyncSuite{})
+
+func (s *tagSyncSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		model: map[string]string{
+			"resource-tag:owner":       "bob",
+			"resource-tag:cost-centre": "1234",
+			"description":              "not a tag",
+		},
+		apps: map[string]map[string]string{
+			"mysql":     {"resource-tag:owner": "db-team"},
+			"wordpress": {"resource-tag:owner": "web-team", "resource-tag:tier": "web"},
+		},
+		machines: []tagsync.MachineInstance{{
+			Id:         "0",
+			InstanceId: "i-0",
+		}, {
+			Id:           "1",
+			InstanceId:   "i-1",
+			Applications: []string{"wordpress", "mysql"},
+		}},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+}
+
+func (s *tagSyncSuite) newAPI(c *gc.C) *tagsync.API {
+	api, err := tagsync.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *tagSyncSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := tagsync.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *tagSyncSuite) TestInstanceTags(c *gc.C) {
+	result, err := s.newAPI(c).InstanceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.InstanceTagsResults{
+		Results: []params.InstanceTags{{
+			MachineTag: "machine-0",
+			InstanceId: "i-0",
+			Tags: map[string]string{
+				"juju-annotation-owner":       "bob",
+				"juju-annotation-cost-centre": "1234",
+			},
+		}, {
+			MachineTag: "machine-1",
+			InstanceId: "i-1",
+			Tags: map[string]string{
+				"juju-annotation-owner":       "web-team",
+				"juju-annotation-cost-centre": "1234",
+				"juju-annotation-tier":        "web",
+			},
+		}},
+	})
+}
+
+func (s *tagSyncSuite) TestInstanceTagsError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	_, err := s.newAPI(c).InstanceTags()
+	c.Assert(err, gc.ErrorMatches, "getting model annotations: boom")
+}
+
+func (s *tagSyncSuite) TestWatchAnnotations(c *gc.C) {
+	result, err := s.newAPI(c).WatchAnnotations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
+}
+
+type mockBackend struct {
+	model    map[string]string
+	apps     map[string]map[string]string
+	machines []tagsync.MachineInstance
+	err      error
+}
+
+func (b *mockBackend) WatchAnnotations() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (b *mockBackend) ModelAnnotations() (map[string]string, error) {
+	return b.model, b.err
+}
+
+func (b *mockBackend) ApplicationAnnotations(name string) (map[string]string, error) {
+	return b.apps[name], nil
+}
+
+func (b *mockBackend) MachineInstances() ([]tagsync.MachineInstance, error) {
+	return b.machines, nil
+}
@@ -103,6 +103,7 @@ type mockModel struct {
 	life  state.Life
 	name  string
 	uuid  string
+	force bool
 
 	status     status.Status
 	statusInfo string
@@ -131,6 +132,10 @@ func (m *mockModel) UUID() string {
 	return m.uuid
 }
 
+func (m *mockModel) ForceDestroyed() bool {
+	return m.force
+}
+
 func (m *mockModel) Destroy() error {
 	m.life = state.Dying
 	return nil
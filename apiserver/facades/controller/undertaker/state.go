@@ -73,4 +73,8 @@ type Model interface {
 
 	// UUID returns the universally unique identifier of the model.
 	UUID() string
+
+	// ForceDestroyed reports whether the model's cloud resources
+	// should be removed even if they are still in use.
+	ForceDestroyed() bool
 }
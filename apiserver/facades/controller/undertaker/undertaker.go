@@ -69,11 +69,12 @@ func (u *UndertakerAPI) ModelInfo() (params.UndertakerModelInfoResult, error) {
 	}
 
 	result.Result = params.UndertakerModelInfo{
-		UUID:       env.UUID(),
-		GlobalName: env.Owner().String() + "/" + env.Name(),
-		Name:       env.Name(),
-		IsSystem:   u.st.IsController(),
-		Life:       params.Life(env.Life().String()),
+		UUID:           env.UUID(),
+		GlobalName:     env.Owner().String() + "/" + env.Name(),
+		Name:           env.Name(),
+		IsSystem:       u.st.IsController(),
+		Life:           params.Life(env.Life().String()),
+		ForceDestroyed: env.ForceDestroyed(),
 	}
 
 	return result, nil
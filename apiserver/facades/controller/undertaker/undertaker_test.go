@@ -81,9 +81,21 @@ func (s *undertakerSuite) TestEnvironInfo(c *gc.C) {
 		c.Assert(info.Name, gc.Equals, test.envName)
 		c.Assert(info.IsSystem, gc.Equals, test.isSystem)
 		c.Assert(info.Life, gc.Equals, params.Dying)
+		c.Assert(info.ForceDestroyed, jc.IsFalse)
 	}
 }
 
+func (s *undertakerSuite) TestEnvironInfoForceDestroyed(c *gc.C) {
+	otherSt, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
+	otherSt.env.life = state.Dying
+	otherSt.env.force = true
+
+	result, err := hostedAPI.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.ForceDestroyed, jc.IsTrue)
+}
+
 func (s *undertakerSuite) TestProcessDyingEnviron(c *gc.C) {
 	otherSt, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
 	env, err := otherSt.Model()
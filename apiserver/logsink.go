@@ -6,6 +6,8 @@ package apiserver
 import (
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -28,8 +30,10 @@ const (
 )
 
 type agentLoggingStrategy struct {
-	dbloggers  *dbloggers
-	fileLogger io.Writer
+	dbloggers     *dbloggers
+	appLogWriters *appLogWriters
+	fileLogger    io.Writer
+	fileName      string
 
 	dblogger   recordLogger
 	releaser   func()
@@ -92,6 +96,48 @@ func (d *dbloggers) dispose() {
 	d.loggers = nil
 }
 
+// appLogWriters contains a map of file writers for the applications
+// whose units' logs are written to files of their own, rather than to
+// the logsink.log file shared by all agents.
+type appLogWriters struct {
+	logDir  string
+	mu      sync.Mutex
+	writers map[string]io.WriteCloser
+}
+
+// get returns the writer for the logs of the units of the named
+// application in the given model, creating it if necessary.
+func (a *appLogWriters) get(modelUUID, appName string) (io.Writer, error) {
+	logPath := filepath.Join(a.logDir, modelUUID, appName+".log")
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if w, ok := a.writers[logPath]; ok {
+		return w, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := logsink.NewFileWriter(logPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if a.writers == nil {
+		a.writers = make(map[string]io.WriteCloser)
+	}
+	a.writers[logPath] = w
+	return w, nil
+}
+
+// dispose closes all the writers in the map, and clears the memory.
+// This must not be called concurrently with any other appLogWriters
+// methods.
+func (a *appLogWriters) dispose() {
+	for _, w := range a.writers {
+		w.Close()
+	}
+	a.writers = nil
+}
+
 type bufferedDbLogger struct {
 	dbl *state.DbLogger
 	*logdb.BufferedLogger
@@ -105,16 +151,21 @@ func (b *bufferedDbLogger) Close() error {
 
 // newAgentLogWriteCloserFunc returns a function that will create a
 // logsink.LoggingStrategy given an *http.Request, that writes log
-// messages to the given writer and also to the state database.
+// messages to the given writer and also to the state database. The
+// messages of units whose application has a dedicated log file are
+// written to that file instead of the given writer.
 func newAgentLogWriteCloserFunc(
 	ctxt httpContext,
 	fileLogger io.Writer,
 	dbloggers *dbloggers,
+	appLogWriters *appLogWriters,
 ) logsink.NewLogWriteCloserFunc {
 	return func(req *http.Request) (logsink.LogWriteCloser, error) {
 		strategy := &agentLoggingStrategy{
-			dbloggers:  dbloggers,
-			fileLogger: fileLogger,
+			dbloggers:     dbloggers,
+			appLogWriters: appLogWriters,
+			fileLogger:    fileLogger,
+			fileName:      "logsink.log",
 		}
 		if err := strategy.init(ctxt, req); err != nil {
 			return nil, errors.Annotate(err, "initialising agent logsink session")
@@ -144,6 +195,9 @@ func (s *agentLoggingStrategy) init(ctxt httpContext, req *http.Request) error {
 	s.version = ver
 	s.entity = entity.Tag()
 	s.filePrefix = st.ModelUUID() + ":"
+	if unitTag, ok := s.entity.(names.UnitTag); ok {
+		s.routeUnitLogs(st, unitTag)
+	}
 	s.dblogger = s.dbloggers.get(st)
 	s.releaser = func() {
 		if removed := releaseState(); removed {
@@ -153,6 +207,34 @@ func (s *agentLoggingStrategy) init(ctxt httpContext, req *http.Request) error {
 	return nil
 }
 
+// routeUnitLogs arranges for the logs of the unit to be written to the
+// dedicated log file of its application, if it has one. The logs are
+// written to the shared file if the application cannot be read, as
+// losing them would be worse. The application's logging settings are
+// read when the unit's agent connects, so changes to them take effect
+// when it next reconnects.
+func (s *agentLoggingStrategy) routeUnitLogs(st *state.State, unitTag names.UnitTag) {
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return
+	}
+	app, err := st.Application(appName)
+	if err != nil {
+		logger.Warningf("cannot read logging settings of application %q: %v", appName, err)
+		return
+	}
+	if !app.Logging().DedicatedFile {
+		return
+	}
+	w, err := s.appLogWriters.get(st.ModelUUID(), appName)
+	if err != nil {
+		logger.Warningf("cannot open log file of application %q: %v", appName, err)
+		return
+	}
+	s.fileLogger = w
+	s.fileName = appName + ".log"
+}
+
 // Close is part of the logsink.LogWriteCloser interface.
 //
 // Close releases the StatePool entry, closing the DB logger
@@ -179,7 +261,7 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 	m.Entity = s.entity.String()
 	fileErr := errors.Annotate(
 		logToFile(s.fileLogger, s.filePrefix, m),
-		"logging to logsink.log failed",
+		"logging to %s failed", s.fileName,
 	)
 	err := dbErr
 	if err == nil {
@@ -195,6 +195,58 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	}
 }
 
+func (s *logsinkSuite) TestLoggingToApplicationFile(c *gc.C) {
+	unit, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetLogging(state.ApplicationLogging{DedicatedFile: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	header := utils.BasicAuthHeader(unit.Tag().String(), password)
+	conn := s.dialWebsocketInternal(c, header)
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	t0 := time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC)
+	err = conn.WriteJSON(&params.LogRecord{
+		Time:     t0,
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.INFO.String(),
+		Message:  "all is well",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The log is still written to the DB, for debug-log.
+	logsColl := s.State.MongoSession().DB("logs").C("logs." + s.State.ModelUUID())
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		n, err := logsColl.Find(bson.M{"n": unit.Tag().String()}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if n == 1 {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for log writes")
+		}
+	}
+	err = conn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUUID := s.State.ModelUUID()
+	logPath := filepath.Join(s.LogDir, "applications", modelUUID, app.Name()+".log")
+	logContents, err := ioutil.ReadFile(logPath)
+	c.Assert(err, jc.ErrorIsNil)
+	line := modelUUID + ": " + unit.Tag().String() + " 2015-06-01 23:02:01 INFO some.where foo.go:42 all is well\n"
+	c.Assert(string(logContents), gc.Equals, line)
+
+	logContents, err = ioutil.ReadFile(filepath.Join(s.LogDir, "logsink.log"))
+	if err == nil {
+		c.Assert(string(logContents), gc.Not(jc.Contains), "all is well")
+	} else {
+		c.Assert(os.IsNotExist(err), jc.IsTrue)
+	}
+}
+
 func (s *logsinkSuite) TestReceiveErrorBreaksConn(c *gc.C) {
 	conn := s.dialWebsocket(c)
 	defer conn.Close()
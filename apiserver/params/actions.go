@@ -54,9 +54,17 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
+	Progress  int                    `json:"progress,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage holds a progress message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -116,6 +124,33 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ActionMessageParams holds the progress messages to be logged for
+// running actions.
+type ActionMessageParams struct {
+	Messages []ActionMessageParam `json:"messages"`
+}
+
+// ActionMessageParam holds a progress message to be logged for a
+// running action.
+type ActionMessageParam struct {
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+// ActionProgressParams holds the progress to be recorded for running
+// actions.
+type ActionProgressParams struct {
+	Progress []ActionProgressParam `json:"progress"`
+}
+
+// ActionProgressParam holds the percentage of a running action that
+// is complete, and an optional progress message.
+type ActionProgressParam struct {
+	Tag     string `json:"tag"`
+	Percent int    `json:"percent"`
+	Message string `json:"message,omitempty"`
+}
+
 // ApplicationsCharmActionsResults holds a slice of ApplicationCharmActionsResult for
 // a bulk result of charm Actions for Applications.
 type ApplicationsCharmActionsResults struct {
@@ -143,3 +178,62 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// AddActionSchedules holds the parameters for adding action schedules.
+type AddActionSchedules struct {
+	Schedules []AddActionSchedule `json:"schedules"`
+}
+
+// AddActionSchedule holds the parameters for adding a schedule that
+// enqueues an action on a unit periodically, according to a cron
+// expression, or once at a future time.
+type AddActionSchedule struct {
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Cron       string                 `json:"cron,omitempty"`
+	At         *time.Time             `json:"at,omitempty"`
+}
+
+// ActionSchedule describes a schedule that enqueues an action on a
+// unit.
+type ActionSchedule struct {
+	Id         string                 `json:"id"`
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Cron       string                 `json:"cron,omitempty"`
+	NextRun    *time.Time             `json:"next-run,omitempty"`
+	Owner      string                 `json:"owner"`
+	Created    time.Time              `json:"created"`
+	History    []ActionScheduleRun    `json:"history,omitempty"`
+}
+
+// ActionScheduleRun records a run of an action schedule: either the
+// tag of the action it enqueued, or why it enqueued none.
+type ActionScheduleRun struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action,omitempty"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Result *ActionSchedule `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds the results of adding action schedules.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionSchedulesResult holds the action schedules of a model.
+type ActionSchedulesResult struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleIds identifies action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
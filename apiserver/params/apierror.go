@@ -98,6 +98,11 @@ const (
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
 	CodeIncompatibleSeries        = "incompatible series"
+	CodeQuotaLimitExceeded        = "quota limit exceeded"
+	CodeModelFrozen               = "model frozen"
+	CodeModelSuspended            = "model suspended"
+	CodeNotValid                  = "not valid"
+	CodeLockHeld                  = "lock held"
 )
 
 // ErrCode returns the error code associated with
@@ -240,6 +245,10 @@ func IsCodeLeaseClaimDenied(err error) bool {
 	return ErrCode(err) == CodeLeaseClaimDenied
 }
 
+func IsCodeLockHeld(err error) bool {
+	return ErrCode(err) == CodeLockHeld
+}
+
 func IsCodeNotSupported(err error) bool {
 	return ErrCode(err) == CodeNotSupported
 }
@@ -260,6 +269,22 @@ func IsCodeIncompatibleSeries(err error) bool {
 	return ErrCode(err) == CodeIncompatibleSeries
 }
 
+func IsCodeQuotaLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaLimitExceeded
+}
+
+func IsCodeModelFrozen(err error) bool {
+	return ErrCode(err) == CodeModelFrozen
+}
+
+func IsCodeModelSuspended(err error) bool {
+	return ErrCode(err) == CodeModelSuspended
+}
+
 func IsCodeForbidden(err error) bool {
 	return ErrCode(err) == CodeForbidden
 }
+
+func IsCodeNotValid(err error) bool {
+	return ErrCode(err) == CodeNotValid
+}
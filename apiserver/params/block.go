@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Block describes a Juju block that protects model from
 // corruption.
 type Block struct {
@@ -44,3 +46,41 @@ type BlockResult struct {
 type BlockResults struct {
 	Results []BlockResult `json:"results,omitempty"`
 }
+
+// ModelFreezeParams holds the parameters for freezing a model.
+type ModelFreezeParams struct {
+	// Message is shown to users whose changes are rejected
+	// while the model is frozen.
+	Message string `json:"message,omitempty"`
+}
+
+// ModelFreezeResult holds the read-only freeze of a model.
+type ModelFreezeResult struct {
+	// Frozen is true if the model is frozen.
+	Frozen bool `json:"frozen"`
+
+	// Message is the message the model was frozen with.
+	Message string `json:"message,omitempty"`
+
+	// By holds the tag of the user that froze the model.
+	By string `json:"by,omitempty"`
+
+	// Since is when the model was frozen.
+	Since *time.Time `json:"since,omitempty"`
+}
+
+// ModelSuspensionResult holds the suspension of a model.
+type ModelSuspensionResult struct {
+	// Suspended is true if the model is suspended.
+	Suspended bool `json:"suspended"`
+
+	// By holds the tag of the user that suspended the model.
+	By string `json:"by,omitempty"`
+
+	// Since is when the model was suspended.
+	Since *time.Time `json:"since,omitempty"`
+
+	// Instances holds the IDs of the instances that were stopped
+	// when the model was suspended.
+	Instances []string `json:"instances,omitempty"`
+}
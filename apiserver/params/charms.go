@@ -92,6 +92,17 @@ type CharmInfo struct {
 	Meta     *CharmMeta             `json:"meta,omitempty"`
 	Actions  *CharmActions          `json:"actions,omitempty"`
 	Metrics  *CharmMetrics          `json:"metrics,omitempty"`
+
+	// GitSource is set if the charm was built from a git repository.
+	GitSource *CharmGitSource `json:"git-source,omitempty"`
+}
+
+// CharmGitSource identifies the git repository and commit from which
+// a charm was built.
+type CharmGitSource struct {
+	Repository string `json:"repository"`
+	Ref        string `json:"ref"`
+	Revision   string `json:"revision"`
 }
 
 // CharmActions mirrors charm.Actions.
@@ -3,6 +3,12 @@
 
 package params
 
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -51,6 +57,11 @@ type ModelStatus struct {
 	Machines           []ModelMachineInfo    `json:"machines,omitempty"`
 	Volumes            []ModelVolumeInfo     `json:"volumes,omitempty"`
 	Filesystems        []ModelFilesystemInfo `json:"filesystems,omitempty"`
+
+	// DestroyError holds the reason the model's cloud resources
+	// could not be removed, if it is being destroyed and they
+	// could not be.
+	DestroyError string `json:"destroy-error,omitempty"`
 }
 
 // ModelStatusResults holds status information about a group of models.
@@ -97,3 +108,88 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// ControllerHealth holds details of the health of a controller.
+type ControllerHealth struct {
+	// ReplicaSet holds the status of the members of the
+	// controller's mongo replica set.
+	ReplicaSet []ReplicaSetMemberHealth `json:"replica-set"`
+
+	// APIConnections is the number of connections to the API server
+	// that answered the request.
+	APIConnections int64 `json:"api-connections"`
+
+	// Databases holds the sizes of the controller's databases.
+	Databases []DatabaseSize `json:"databases"`
+
+	// Upgrade holds details of the upgrade in progress, if any.
+	Upgrade *UpgradeHealth `json:"upgrade,omitempty"`
+
+	// LastBackup is when the last backup of the controller
+	// finished, if there has been one.
+	LastBackup *time.Time `json:"last-backup,omitempty"`
+}
+
+// ReplicaSetMemberHealth holds the status of a replica set member.
+type ReplicaSetMemberHealth struct {
+	Address string `json:"address"`
+	State   string `json:"state"`
+	Healthy bool   `json:"healthy"`
+}
+
+// DatabaseSize holds the sizes, in bytes, of a mongo database.
+type DatabaseSize struct {
+	Name        string `json:"name"`
+	DataSize    int64  `json:"data-size"`
+	StorageSize int64  `json:"storage-size"`
+	IndexSize   int64  `json:"index-size"`
+}
+
+// UpgradeHealth holds details of a controller upgrade in progress.
+type UpgradeHealth struct {
+	PreviousVersion version.Number `json:"previous-version"`
+	TargetVersion   version.Number `json:"target-version"`
+	Status          string         `json:"status"`
+	Started         time.Time      `json:"started"`
+}
+
+// ControllerConfigSet holds the controller config attributes to
+// update and remove.
+type ControllerConfigSet struct {
+	Config map[string]interface{} `json:"config"`
+	Remove []string               `json:"remove,omitempty"`
+}
+
+// ControllerConfigSetResult holds the result of a controller config
+// update.
+type ControllerConfigSetResult struct {
+	// RestartRequired holds the names of the changed attributes that
+	// only take effect once the controller agents are restarted.
+	RestartRequired []string `json:"restart-required,omitempty"`
+}
+
+// StuckTransactions holds the transactions that have been incomplete
+// for long enough to be considered stuck.
+type StuckTransactions struct {
+	Transactions []StuckTransaction `json:"transactions"`
+}
+
+// StuckTransaction describes a transaction that has not completed.
+type StuckTransaction struct {
+	Id      string               `json:"id"`
+	State   string               `json:"state"`
+	Started time.Time            `json:"started"`
+	Ops     []StuckTransactionOp `json:"ops"`
+}
+
+// StuckTransactionOp describes a document operated on by a stuck
+// transaction.
+type StuckTransactionOp struct {
+	Collection string `json:"collection"`
+	Id         string `json:"id"`
+}
+
+// ResumeTransactionsArgs holds the ids of transactions to resume.
+type ResumeTransactionsArgs struct {
+	Ids []string `json:"ids"`
+}
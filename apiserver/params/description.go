@@ -0,0 +1,21 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// SetDescription holds the free-text description to set on a model or
+// application.
+type SetDescription struct {
+	// Tag is the tag of the model or application.
+	Tag string `json:"tag"`
+
+	// Description is the new description. An empty description
+	// removes the current one.
+	Description string `json:"description"`
+}
+
+// SetDescriptions holds the descriptions to set on models and
+// applications.
+type SetDescriptions struct {
+	Args []SetDescription `json:"args"`
+}
@@ -72,3 +72,143 @@ type InstanceType struct {
 	Deprecated   bool     `json:"deprecated,omitempty"`
 	Cost         int      `json:"cost,omitempty"`
 }
+
+// CostEstimateArgs describes a planned deployment whose cost is to be
+// estimated: the machines that would be provisioned, and the storage
+// that would be created.
+type CostEstimateArgs struct {
+	Machines []CostEstimateMachine `json:"machines"`
+	Storage  []CostEstimateStorage `json:"storage,omitempty"`
+}
+
+// CostEstimateMachine describes a number of machines, with the same
+// constraints, that would be provisioned.
+type CostEstimateMachine struct {
+	Constraints constraints.Value `json:"constraints"`
+	Count       int               `json:"count"`
+}
+
+// CostEstimateStorage describes a number of storage instances, of the
+// same size and from the same pool, that would be created. Size is in
+// MiB. If Pool is empty, the model's default block storage pool is
+// used.
+type CostEstimateStorage struct {
+	Pool  string `json:"pool,omitempty"`
+	Size  uint64 `json:"size"`
+	Count int    `json:"count"`
+}
+
+// CostEstimateResult holds the estimated hourly cost of a planned
+// deployment, and that of each of its machines and storage. The total
+// only includes the items that could be priced.
+type CostEstimateResult struct {
+	Currency   string                      `json:"currency,omitempty"`
+	HourlyCost float64                     `json:"hourly-cost"`
+	Machines   []CostEstimateMachineResult `json:"machines"`
+	Storage    []CostEstimateStorageResult `json:"storage,omitempty"`
+}
+
+// CostEstimateMachineResult holds the instance type that would be used
+// for a number of planned machines, and its hourly cost. If the
+// instance type could not be resolved, Error is set; if it has no known
+// price, Priced is false.
+type CostEstimateMachineResult struct {
+	InstanceType string  `json:"instance-type,omitempty"`
+	Count        int     `json:"count"`
+	HourlyCost   float64 `json:"hourly-cost"`
+	Priced       bool    `json:"priced"`
+	Error        *Error  `json:"error,omitempty"`
+}
+
+// CostEstimateStorageResult holds the hourly cost of each of a number
+// of planned storage instances. If the pool has no known price, Priced
+// is false.
+type CostEstimateStorageResult struct {
+	Pool       string  `json:"pool"`
+	Size       uint64  `json:"size"`
+	Count      int     `json:"count"`
+	HourlyCost float64 `json:"hourly-cost"`
+	Priced     bool    `json:"priced"`
+	Error      *Error  `json:"error,omitempty"`
+}
+
+// ConsoleLogArgs holds the arguments for retrieving the console output
+// of the instances of machines.
+type ConsoleLogArgs struct {
+	Args []ConsoleLogArg `json:"args"`
+}
+
+// ConsoleLogArg identifies a machine whose instance console output
+// should be retrieved. If MaxLines is greater than zero, at most that
+// many lines are returned, taken from the end of the output.
+type ConsoleLogArg struct {
+	Entity   Entity `json:"entity"`
+	MaxLines int    `json:"max-lines,omitempty"`
+}
+
+// ConsoleLogResults holds the console output of the instances of
+// machines.
+type ConsoleLogResults struct {
+	Results []ConsoleLogResult `json:"results"`
+}
+
+// ConsoleLogResult holds the console output of a machine's instance,
+// or an error.
+type ConsoleLogResult struct {
+	Output string `json:"output,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// MachineConsoleArgs holds the arguments for requesting access to the
+// consoles of the instances of machines.
+type MachineConsoleArgs struct {
+	Args []MachineConsoleArg `json:"args"`
+}
+
+// MachineConsoleArg identifies a machine and the type of console
+// requested for its instance, for example "serial" or "novnc".
+type MachineConsoleArg struct {
+	Entity Entity `json:"entity"`
+	Type   string `json:"type"`
+}
+
+// MachineConsoleResults holds details of how to connect to the consoles
+// of the instances of machines.
+type MachineConsoleResults struct {
+	Results []MachineConsoleResult `json:"results"`
+}
+
+// MachineConsoleResult holds details of how to connect to the console
+// of a machine's instance, or an error.
+type MachineConsoleResult struct {
+	Type  string `json:"type,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// ConsoleMessage holds data read from or written to an instance console
+// proxied by the controller.
+type ConsoleMessage struct {
+	Data []byte `json:"data"`
+}
+
+// CloudInstanceArgs holds the provider ids of cloud instances that are
+// to be imported into a model as machines.
+type CloudInstanceArgs struct {
+	InstanceIds []string `json:"instance-ids"`
+}
+
+// CloudInstanceResults holds details of cloud instances that are to be
+// imported into a model.
+type CloudInstanceResults struct {
+	Results []CloudInstanceResult `json:"results"`
+}
+
+// CloudInstanceResult holds details of a cloud instance, as reported
+// by the provider, or an error.
+type CloudInstanceResult struct {
+	InstanceId string    `json:"instance-id,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Addresses  []Address `json:"addresses,omitempty"`
+	Error      *Error    `json:"error,omitempty"`
+}
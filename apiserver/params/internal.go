@@ -128,6 +128,21 @@ type ModelCreateArgs struct {
 	// and the owner is the controller owner, the same credential
 	// used for the controller model will be used.
 	CloudCredentialTag string `json:"credential,omitempty"`
+
+	// Template is the name of the controller model template from
+	// which the region, credential, config and constraints of the
+	// model are taken, where they are not specified explicitly.
+	Template string `json:"template,omitempty"`
+
+	// ExpiresIn, if positive, is how long after its creation the
+	// model is automatically destroyed.
+	ExpiresIn time.Duration `json:"expires-in,omitempty"`
+
+	// DestroyStorageOnExpiry records whether the model's persistent
+	// storage is destroyed (true) or released (false) when the model
+	// expires. If it is not set, a model with persistent storage is
+	// not destroyed on expiry.
+	DestroyStorageOnExpiry *bool `json:"destroy-storage-on-expiry,omitempty"`
 }
 
 // Model holds the result of an API call returning a name and UUID
@@ -364,6 +379,19 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// EntityConfigHash holds the hash of the config settings that an
+// entity has finished handling.
+type EntityConfigHash struct {
+	Tag  string `json:"tag"`
+	Hash string `json:"hash"`
+}
+
+// EntityConfigHashes holds the parameters for making a
+// SetConfigReadyHashes call.
+type EntityConfigHashes struct {
+	Entities []EntityConfigHash `json:"entities"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
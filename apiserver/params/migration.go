@@ -67,10 +67,20 @@ type SetMigrationStatusMessageArgs struct {
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
-	Bytes     []byte                    `json:"bytes"`
-	Charms    []string                  `json:"charms"`
-	Tools     []SerializedModelTools    `json:"tools"`
-	Resources []SerializedModelResource `json:"resources"`
+	Bytes           []byte                          `json:"bytes"`
+	Charms          []string                        `json:"charms"`
+	Tools           []SerializedModelTools          `json:"tools"`
+	Resources       []SerializedModelResource       `json:"resources"`
+	CharmGitSources []SerializedModelCharmGitSource `json:"charm-git-sources,omitempty"`
+}
+
+// SerializedModelCharmGitSource holds the git repository and commit
+// from which a charm in a serialized model was built.
+type SerializedModelCharmGitSource struct {
+	Charm      string `json:"charm"`
+	Repository string `json:"repository"`
+	Ref        string `json:"ref,omitempty"`
+	Revision   string `json:"revision"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
@@ -132,6 +142,8 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	CloudCredentialTag     string         `json:"cloud-credential-tag,omitempty"`
+	StorageProviders       []string       `json:"storage-providers,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -72,6 +72,67 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// ModelConfigChange describes a change to a single model config
+// setting. Type is one of "added", "modified" or "deleted".
+type ModelConfigChange struct {
+	Type     string      `json:"type"`
+	Key      string      `json:"key"`
+	OldValue interface{} `json:"old-value,omitempty"`
+	NewValue interface{} `json:"new-value,omitempty"`
+}
+
+// ModelConfigRevision describes a recorded change to a model's config.
+type ModelConfigRevision struct {
+	Revision int                 `json:"revision"`
+	User     string              `json:"user,omitempty"`
+	Time     time.Time           `json:"time"`
+	Changes  []ModelConfigChange `json:"changes"`
+}
+
+// ModelConfigHistoryResult contains the result of the
+// ModelConfigHistory client API call.
+type ModelConfigHistoryResult struct {
+	Revisions []ModelConfigRevision `json:"revisions"`
+}
+
+// ModelConfigRevisionArg identifies a revision of a model's config,
+// for the ModelConfigDiff and ModelConfigRollback client API calls.
+type ModelConfigRevisionArg struct {
+	Revision int `json:"revision"`
+}
+
+// ModelConfigChangesResult contains the result of the ModelConfigDiff
+// client API call.
+type ModelConfigChangesResult struct {
+	Changes []ModelConfigChange `json:"changes"`
+}
+
+// ChangeLogFilter selects the entries of a model's change log returned
+// by the ChangeLog client API call. Empty fields match all entries.
+type ChangeLogFilter struct {
+	Kind   string     `json:"kind,omitempty"`
+	Entity string     `json:"entity,omitempty"`
+	Actor  string     `json:"actor,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+}
+
+// ChangeLogEntry describes a change applied to a model.
+type ChangeLogEntry struct {
+	Seq    int               `json:"seq"`
+	Time   time.Time         `json:"time"`
+	Actor  string            `json:"actor"`
+	Kind   string            `json:"kind"`
+	Entity string            `json:"entity,omitempty"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// ChangeLogResult contains the result of the ChangeLog client API
+// call, oldest entry first.
+type ChangeLogResult struct {
+	Entries []ChangeLogEntry `json:"entries"`
+}
+
 // ModelSLA contains the arguments for the SetSLALevel client API
 // call.
 type ModelSLA struct {
@@ -160,6 +221,12 @@ type ModelInfo struct {
 
 	// AgentVersion is the agent version for this model.
 	AgentVersion *version.Number `json:"agent-version"`
+
+	// Description is the free-text description of the model, if set.
+	Description string `json:"description,omitempty"`
+
+	// Expires is when the model is automatically destroyed, if ever.
+	Expires *time.Time `json:"expires,omitempty"`
 }
 
 // ModelSLAInfo describes the SLA info for a model.
@@ -303,4 +370,9 @@ type DestroyModelParams struct {
 	// storage in the model, an error with the code
 	// params.CodeHasPersistentStorage will be returned.
 	DestroyStorage *bool `json:"destroy-storage,omitempty"`
+
+	// Force controls whether or not the model's cloud resources
+	// are removed even if they are still in use. A model that is
+	// already being destroyed may be destroyed again with Force.
+	Force bool `json:"force,omitempty"`
 }
@@ -705,3 +705,85 @@ type NetworkInfoParams struct {
 	RelationId *int     `json:"relation-id,omitempty"`
 	Bindings   []string `json:"bindings"`
 }
+
+// ApplicationAddresses holds the public addresses of the alive units
+// of an application, and whether the application is exposed.
+type ApplicationAddresses struct {
+	ApplicationTag string   `json:"application-tag"`
+	Exposed        bool     `json:"exposed"`
+	Addresses      []string `json:"addresses"`
+}
+
+// ApplicationAddressesResults holds the public addresses of the
+// applications in a model.
+type ApplicationAddressesResults struct {
+	Results []ApplicationAddresses `json:"results"`
+}
+
+// FloatingIP holds the floating IP address that follows the leader of
+// an exposed application, the unit whose machine it is associated
+// with, and the application's leader and its instance.
+type FloatingIP struct {
+	ApplicationTag   string `json:"application-tag"`
+	Address          string `json:"address"`
+	UnitTag          string `json:"unit-tag,omitempty"`
+	LeaderTag        string `json:"leader-tag,omitempty"`
+	LeaderInstanceId string `json:"leader-instance-id,omitempty"`
+}
+
+// FloatingIPResults holds the floating IP addresses of the
+// applications in a model.
+type FloatingIPResults struct {
+	Results []FloatingIP `json:"results"`
+}
+
+// SetFloatingIPUnitArgs holds the arguments for recording the units
+// whose machines floating IP addresses have been associated with.
+type SetFloatingIPUnitArgs struct {
+	Args []SetFloatingIPUnitArg `json:"args"`
+}
+
+// SetFloatingIPUnitArg records that the floating IP address of an
+// application has been associated with the machine of a unit.
+type SetFloatingIPUnitArg struct {
+	ApplicationTag string `json:"application-tag"`
+	Address        string `json:"address"`
+	UnitTag        string `json:"unit-tag"`
+}
+
+// LinkLayerDeviceInfo describes a link-layer network device of a
+// machine, and the IP addresses assigned to it.
+type LinkLayerDeviceInfo struct {
+	Name        string                       `json:"name"`
+	Type        string                       `json:"type"`
+	MACAddress  string                       `json:"mac-address,omitempty"`
+	MTU         uint                         `json:"mtu,omitempty"`
+	ProviderId  string                       `json:"provider-id,omitempty"`
+	ParentName  string                       `json:"parent-name,omitempty"`
+	IsAutoStart bool                         `json:"is-auto-start"`
+	IsUp        bool                         `json:"is-up"`
+	Addresses   []LinkLayerDeviceAddressInfo `json:"addresses,omitempty"`
+}
+
+// LinkLayerDeviceAddressInfo describes an IP address assigned to a
+// link-layer network device.
+type LinkLayerDeviceAddressInfo struct {
+	Value          string `json:"value"`
+	CIDR           string `json:"cidr,omitempty"`
+	ConfigMethod   string `json:"config-method"`
+	ProviderId     string `json:"provider-id,omitempty"`
+	GatewayAddress string `json:"gateway-address,omitempty"`
+}
+
+// MachineLinkLayerDevicesResult holds the link-layer network devices
+// of a machine, or an error.
+type MachineLinkLayerDevicesResult struct {
+	Devices []LinkLayerDeviceInfo `json:"devices,omitempty"`
+	Error   *Error                `json:"error,omitempty"`
+}
+
+// MachineLinkLayerDevicesResults holds the results of a
+// MachineNetworking.LinkLayerDevices call.
+type MachineLinkLayerDevicesResults struct {
+	Results []MachineLinkLayerDevicesResult `json:"results"`
+}
@@ -132,6 +132,27 @@ type DestroyRelation struct {
 	RelationId int      `json:"relation-id"`
 }
 
+// RelationPreviewResult holds the result of an
+// Application.PreviewAddRelation or Application.PreviewDestroyRelation
+// call.
+type RelationPreviewResult struct {
+	// Key is the key of the relation. It is empty if the endpoints
+	// could not be matched.
+	Key string `json:"key,omitempty"`
+
+	// Endpoints maps application names to the endpoints of the
+	// relation.
+	Endpoints map[string]CharmRelation `json:"endpoints,omitempty"`
+
+	// Units holds the names of the units that would enter, or leave,
+	// the relation's scope.
+	Units []string `json:"units,omitempty"`
+
+	// Problems describes why the relation could not be added or
+	// removed. The change would succeed only if it is empty.
+	Problems []string `json:"problems,omitempty"`
+}
+
 // RelationStatusArgs holds the parameters for updating the status
 // of one or more relations.
 type RelationStatusArgs struct {
@@ -171,6 +192,39 @@ type AddCharmWithAuthorization struct {
 	CharmStoreMacaroon *macaroon.Macaroon `json:"macaroon"`
 }
 
+// AddCharmFromGit holds the arguments for making an AddCharmFromGit API call.
+type AddCharmFromGit struct {
+	// Repository is the URL of the git repository holding the charm,
+	// with a git+ssh or git+https scheme.
+	Repository string `json:"repository"`
+
+	// Ref is the branch, tag or commit to build the charm from. If
+	// empty, the repository's default branch is used.
+	Ref string `json:"ref,omitempty"`
+
+	// Series is the series for the charm URL. If empty, the charm's
+	// default series is used.
+	Series string `json:"series,omitempty"`
+
+	// PrivateKey is the SSH private key with which the controller
+	// authenticates to a git+ssh repository. If empty, no key is used.
+	PrivateKey string `json:"private-key,omitempty"`
+
+	// KnownHosts holds the SSH host keys of a git+ssh repository's
+	// host, in known_hosts format. It is required for git+ssh
+	// repositories, and the host's key must match one of them.
+	KnownHosts string `json:"known-hosts,omitempty"`
+}
+
+// AddCharmFromGitResult holds the result of an AddCharmFromGit API call.
+type AddCharmFromGitResult struct {
+	// URL is the URL of the charm added to the model.
+	URL string `json:"url"`
+
+	// Revision is the ID of the commit the charm was built from.
+	Revision string `json:"revision"`
+}
+
 // AddMachineParams encapsulates the parameters used to create a new machine.
 type AddMachineParams struct {
 	// The following fields hold attributes that will be given to the
@@ -210,6 +264,16 @@ type AddMachineParams struct {
 	Nonce                   string                           `json:"nonce"`
 	HardwareCharacteristics instance.HardwareCharacteristics `json:"hardware-characteristics"`
 	Addrs                   []Address                        `json:"addresses"`
+
+	// ImageStream optionally holds the image stream from which the
+	// image for the machine's instance will be selected, overriding
+	// the model's image-stream setting.
+	ImageStream string `json:"image-stream,omitempty"`
+
+	// ImageId optionally holds the id of the image that the machine's
+	// instance will be started with. The image must be found in the
+	// image stream.
+	ImageId string `json:"image-id,omitempty"`
 }
 
 // AddMachines holds the parameters for making the AddMachines call.
@@ -337,7 +401,9 @@ type ApplicationSetCharm struct {
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
-	ApplicationName string `json:"application"`
+	ApplicationName string   `json:"application"`
+	FloatingIP      string   `json:"floating-ip,omitempty"`
+	ExposedCIDRs    []string `json:"exposed-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -433,6 +499,25 @@ type Resolved struct {
 	Retry    bool   `json:"retry"`
 }
 
+// UnitsResolved holds parameters for the ResolveUnitErrors call.
+type UnitsResolved struct {
+	// Tags holds the units to mark resolved. It must be empty
+	// if All is true.
+	Tags Entities `json:"tags,omitempty"`
+
+	// Retry indicates whether the failed hooks should be
+	// re-executed.
+	Retry bool `json:"retry,omitempty"`
+
+	// All indicates that every unit in an error state should
+	// be marked resolved.
+	All bool `json:"all,omitempty"`
+
+	// Application, if set with All, restricts the units marked
+	// resolved to those of the named application.
+	Application string `json:"application,omitempty"`
+}
+
 // ResolvedResults holds results of the Resolved call.
 type ResolvedResults struct {
 	Application string                 `json:"application"`
@@ -454,6 +539,41 @@ type AddApplicationUnits struct {
 	AttachStorage   []string              `json:"attach-storage,omitempty"`
 }
 
+// UnitPlacementPreview describes where a prospective unit would be
+// placed by Application.AddUnits.
+type UnitPlacementPreview struct {
+	// MachineId holds the id of the existing machine or container that
+	// would host the unit. It is empty if a new machine would be created.
+	MachineId string `json:"machine-id,omitempty"`
+
+	// ParentId holds the id of the existing machine that would host a
+	// new container for the unit, if any.
+	ParentId string `json:"parent-id,omitempty"`
+
+	// ContainerType holds the type of the new container that would be
+	// created for the unit, if any.
+	ContainerType string `json:"container-type,omitempty"`
+
+	// Directive holds the provider-specific placement directive used
+	// to create a new machine for the unit, if any.
+	Directive string `json:"directive,omitempty"`
+}
+
+// AddApplicationUnitsPreviewResult holds the result of an
+// Application.PreviewAddUnits call.
+type AddApplicationUnitsPreviewResult struct {
+	// Units holds the placement of each prospective unit, in order.
+	Units []UnitPlacementPreview `json:"units"`
+
+	// ExistingMachines holds the ids of the existing machines and
+	// containers that would be reused to host units.
+	ExistingMachines []string `json:"existing-machines"`
+
+	// NewMachines holds the number of new machines and containers
+	// that would be created to host units.
+	NewMachines int `json:"new-machines"`
+}
+
 // DestroyApplicationUnits holds parameters for the deprecated
 // Application.DestroyUnits call.
 type DestroyApplicationUnits struct {
@@ -496,6 +616,33 @@ type DestroyApplicationParams struct {
 	// DestroyStorage controls whether or not storage attached to
 	// units of the application should be destroyed.
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
+
+	// HookTimeout, if set, bounds the time the application's units
+	// may take to run their end-of-life hooks, after which they are
+	// forcibly removed.
+	HookTimeout *time.Duration `json:"hook-timeout,omitempty"`
+}
+
+// ApplicationRemovalReportResults holds the results of the
+// Application.ApplicationRemovalReports call.
+type ApplicationRemovalReportResults struct {
+	Results []ApplicationRemovalReportResult `json:"results"`
+}
+
+// ApplicationRemovalReportResult holds an application's removal report,
+// or an error.
+type ApplicationRemovalReportResult struct {
+	Result *ApplicationRemovalReport `json:"result,omitempty"`
+	Error  *Error                    `json:"error,omitempty"`
+}
+
+// ApplicationRemovalReport describes the cleanup steps skipped when an
+// application's units were forcibly removed.
+type ApplicationRemovalReport struct {
+	Application string        `json:"application"`
+	HookTimeout time.Duration `json:"hook-timeout"`
+	Forced      time.Time     `json:"forced"`
+	Skipped     []string      `json:"skipped"`
 }
 
 // Creds holds credentials for identifying an entity.
@@ -565,6 +712,209 @@ type ApplicationConstraint struct {
 	Error       *Error            `json:"error,omitempty"`
 }
 
+// ApplicationEffectiveConstraintsResults holds the results of the
+// GetEffectiveConstraints call.
+type ApplicationEffectiveConstraintsResults struct {
+	Results []ApplicationEffectiveConstraints `json:"results"`
+}
+
+// ApplicationEffectiveConstraints describes how the constraints of a
+// single application combine with the model constraints, or holds an
+// error for trying to get them.
+type ApplicationEffectiveConstraints struct {
+	Model       constraints.Value `json:"model"`
+	Application constraints.Value `json:"application"`
+	Effective   constraints.Value `json:"effective"`
+	Inherited   []string          `json:"inherited,omitempty"`
+	Overridden  []string          `json:"overridden,omitempty"`
+	Unsupported []string          `json:"unsupported,omitempty"`
+	Error       *Error            `json:"error,omitempty"`
+}
+
+// ApplicationTrustArgs holds the arguments for the
+// SetApplicationsTrust call.
+type ApplicationTrustArgs struct {
+	Args []ApplicationTrustArg `json:"args"`
+}
+
+// ApplicationTrustArg holds the trust to set for a single application.
+type ApplicationTrustArg struct {
+	ApplicationName string `json:"application"`
+	Trusted         bool   `json:"trusted"`
+}
+
+// ApplicationTrustResults holds the results of the
+// GetApplicationsTrust call.
+type ApplicationTrustResults struct {
+	Results []ApplicationTrustResult `json:"results"`
+}
+
+// ApplicationTrustResult describes whether an application has been
+// trusted with access to the model's cloud credential and how that
+// access has been used, or holds an error for trying to get it.
+type ApplicationTrustResult struct {
+	Trusted    bool       `json:"trusted"`
+	GrantedBy  string     `json:"granted-by,omitempty"`
+	Granted    *time.Time `json:"granted,omitempty"`
+	LastUsed   *time.Time `json:"last-used,omitempty"`
+	LastUsedBy string     `json:"last-used-by,omitempty"`
+	UseCount   int        `json:"use-count,omitempty"`
+	Error      *Error     `json:"error,omitempty"`
+}
+
+// ApplicationHookEnvironmentArgs holds the parameters for setting the
+// hook environment overrides of one or more applications.
+type ApplicationHookEnvironmentArgs struct {
+	Args []ApplicationHookEnvironmentArg `json:"args"`
+}
+
+// ApplicationHookEnvironmentArg holds the environment variables to set
+// for hook executions of an application's units, in addition to those
+// set in the model config.
+type ApplicationHookEnvironmentArg struct {
+	ApplicationName string            `json:"application"`
+	Environment     map[string]string `json:"environment"`
+}
+
+// HookEnvironmentResults holds the results of a call to get the hook
+// environment of one or more entities.
+type HookEnvironmentResults struct {
+	Results []HookEnvironmentResult `json:"results"`
+}
+
+// HookEnvironmentResult holds environment variables set for hook
+// executions, or an error for trying to get them.
+type HookEnvironmentResult struct {
+	Environment map[string]string `json:"environment,omitempty"`
+	Error       *Error            `json:"error,omitempty"`
+}
+
+// AddressPolicy governs which of a machine's addresses are published
+// as the private and public addresses of an application's units.
+type AddressPolicy struct {
+	// Prefer is the kind of address to prefer: "provider", "bridge"
+	// or "fan".
+	Prefer string `json:"prefer,omitempty"`
+
+	// PreferIPv6 is whether to prefer IPv6 addresses over IPv4.
+	PreferIPv6 bool `json:"prefer-ipv6,omitempty"`
+
+	// Space is the name of the space whose addresses to prefer.
+	Space string `json:"space,omitempty"`
+}
+
+// ApplicationAddressPolicyArgs holds the parameters for setting the
+// address policies of one or more applications.
+type ApplicationAddressPolicyArgs struct {
+	Args []ApplicationAddressPolicyArg `json:"args"`
+}
+
+// ApplicationAddressPolicyArg holds the address policy to set for an
+// application.
+type ApplicationAddressPolicyArg struct {
+	ApplicationName string        `json:"application"`
+	Policy          AddressPolicy `json:"policy"`
+}
+
+// AddressPolicyResults holds the results of a call to get the address
+// policies of one or more applications.
+type AddressPolicyResults struct {
+	Results []AddressPolicyResult `json:"results"`
+}
+
+// AddressPolicyResult holds an application's address policy, or an
+// error for trying to get it.
+type AddressPolicyResult struct {
+	Policy AddressPolicy `json:"policy"`
+	Error  *Error        `json:"error,omitempty"`
+}
+
+// ApplicationLogging holds the logging settings of an application's
+// units.
+type ApplicationLogging struct {
+	// Config is the logging config of the application's units, which
+	// is applied on top of the model's logging config.
+	Config string `json:"config,omitempty"`
+
+	// DedicatedFile is whether the logs of the application's units
+	// are written to a log file of their own on the controllers.
+	DedicatedFile bool `json:"dedicated-file,omitempty"`
+}
+
+// ApplicationLoggingArgs holds the parameters for setting the logging
+// settings of one or more applications.
+type ApplicationLoggingArgs struct {
+	Args []ApplicationLoggingArg `json:"args"`
+}
+
+// ApplicationLoggingArg holds the logging settings to set for an
+// application.
+type ApplicationLoggingArg struct {
+	ApplicationName string             `json:"application"`
+	Logging         ApplicationLogging `json:"logging"`
+}
+
+// ApplicationLoggingResults holds the results of a call to get the
+// logging settings of one or more applications.
+type ApplicationLoggingResults struct {
+	Results []ApplicationLoggingResult `json:"results"`
+}
+
+// ApplicationLoggingResult holds an application's logging settings, or
+// an error for trying to get them.
+type ApplicationLoggingResult struct {
+	Logging ApplicationLogging `json:"logging"`
+	Error   *Error             `json:"error,omitempty"`
+}
+
+// CharmLockArgs holds the arguments for acquiring or releasing one or
+// more charm locks.
+type CharmLockArgs struct {
+	Args []CharmLockArg `json:"args"`
+}
+
+// CharmLockArg holds the arguments for acquiring or releasing a lock
+// shared by the units of an application, on behalf of one of them.
+type CharmLockArg struct {
+	// UnitTag is the unit acquiring or releasing the lock.
+	UnitTag string `json:"unit-tag"`
+
+	// Name is the name of the lock.
+	Name string `json:"name"`
+
+	// DurationSeconds is the number of seconds for which the lock
+	// is held unless it is released or acquired again. It is
+	// ignored when releasing the lock.
+	DurationSeconds float64 `json:"duration,omitempty"`
+}
+
+// RelationSettingsUsageResults holds the results of a
+// RelationSettingsUsage call.
+type RelationSettingsUsageResults struct {
+	Results []RelationSettingsUsageResult `json:"results"`
+}
+
+// RelationSettingsUsageResult holds the relation settings usage of an
+// application's units along with the limits configured for the model,
+// or an error for trying to get it. A limit of zero means there is
+// no limit.
+type RelationSettingsUsageResult struct {
+	MaxKeys int                     `json:"max-keys"`
+	MaxSize int                     `json:"max-size"`
+	Usage   []RelationSettingsUsage `json:"usage,omitempty"`
+	Error   *Error                  `json:"error,omitempty"`
+}
+
+// RelationSettingsUsage describes the settings stored by a unit for a
+// relation.
+type RelationSettingsUsage struct {
+	RelationKey string `json:"relation-key"`
+	RelationId  int    `json:"relation-id"`
+	Unit        string `json:"unit"`
+	Keys        int    `json:"keys"`
+	Size        int    `json:"size"`
+}
+
 // SetConstraints stores parameters for making the SetConstraints call.
 type SetConstraints struct {
 	ApplicationName string            `json:"application"` //optional, if empty, model constraints are set.
@@ -1050,6 +1400,53 @@ type DestroyMachineInfo struct {
 	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
 }
 
+// PendingRemovalResults contains the results of a
+// MachineManager.PendingRemovals API request.
+type PendingRemovalResults struct {
+	Results []PendingRemoval `json:"results"`
+}
+
+// PendingRemoval describes a dying or dead machine, and what its
+// removal is waiting on.
+type PendingRemoval struct {
+	// MachineTag is the tag of the machine.
+	MachineTag string `json:"machine-tag"`
+
+	// Life is the life of the machine.
+	Life Life `json:"life"`
+
+	// InstanceId is the ID of the machine's provider instance, if
+	// it has one.
+	InstanceId string `json:"instance-id,omitempty"`
+
+	// InstanceStatus is the status of the provider instance.
+	InstanceStatus string `json:"instance-status,omitempty"`
+
+	// InstanceMessage is the message of the provider instance
+	// status, which holds the last error encountered deleting the
+	// instance.
+	InstanceMessage string `json:"instance-message,omitempty"`
+
+	// Units is the tags of the units still assigned to the
+	// machine, whose stop hooks have yet to run.
+	Units []Entity `json:"units,omitempty"`
+
+	// Containers is the tags of the machine's remaining containers.
+	Containers []Entity `json:"containers,omitempty"`
+
+	// Volumes is the tags of the volumes still attached to the
+	// machine.
+	Volumes []Entity `json:"volumes,omitempty"`
+
+	// Filesystems is the tags of the filesystems still attached to
+	// the machine.
+	Filesystems []Entity `json:"filesystems,omitempty"`
+
+	// MarkedForRemoval is true if the machine is dead and waiting
+	// for the provisioner to delete its instance.
+	MarkedForRemoval bool `json:"marked-for-removal,omitempty"`
+}
+
 // DestroyApplicationResults contains the results of a DestroyApplication
 // API request.
 type DestroyApplicationResults struct {
@@ -1112,3 +1509,31 @@ type DumpModelRequest struct {
 	Entities   []Entity `json:"entities"`
 	Simplified bool     `json:"simplified"`
 }
+
+// InstanceTags holds the tags that should be set on the instance of
+// a provisioned machine.
+type InstanceTags struct {
+	MachineTag string            `json:"machine-tag"`
+	InstanceId string            `json:"instance-id"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// InstanceTagsResults holds the tags that should be set on the
+// instances of the provisioned machines in a model.
+type InstanceTagsResults struct {
+	Results []InstanceTags `json:"results"`
+}
+
+// RetryProvisioningArgs holds the machines whose provisioning should be
+// retried, and how the retries should differ from the failed attempts.
+type RetryProvisioningArgs struct {
+	Entities []Entity `json:"entities"`
+
+	// SwitchZone requests that the retry avoid the availability zone
+	// in which the last attempt failed for lack of capacity.
+	SwitchZone bool `json:"switch-zone,omitempty"`
+
+	// SwitchInstanceType requests that the retry avoid the instance
+	// type with which the last attempt failed for lack of capacity.
+	SwitchInstanceType bool `json:"switch-instance-type,omitempty"`
+}
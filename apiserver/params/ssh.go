@@ -18,6 +18,11 @@ type SSHHostKeys struct {
 // SSHProxyResult defines the response from the SSHClient.Proxy API.
 type SSHProxyResult struct {
 	UseProxy bool `json:"use-proxy"`
+
+	// UseReverseTunnel is true if SSH connections must be made
+	// through the reverse channels opened by the model's machine
+	// agents, because the controller cannot reach the machines.
+	UseReverseTunnel bool `json:"use-reverse-tunnel,omitempty"`
 }
 
 // SSHAddressResults defines the response from various APIs on the
@@ -58,3 +63,15 @@ type SSHPublicKeysResult struct {
 	Error      *Error   `json:"error,omitempty"`
 	PublicKeys []string `json:"public-keys,omitempty"`
 }
+
+// TunnelRequest is sent by the controller over the reverse channel of
+// a machine agent, asking the agent to accept a tunnel to the SSH
+// server of its machine.
+type TunnelRequest struct {
+	ID string `json:"id"`
+}
+
+// TunnelMessage holds data relayed through a tunnel to a machine.
+type TunnelMessage struct {
+	Data []byte `json:"data"`
+}
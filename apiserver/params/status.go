@@ -18,6 +18,28 @@ type StatusParams struct {
 	Patterns []string `json:"patterns"`
 }
 
+// StatusPageParams holds parameters for the FullStatusPage call.
+type StatusPageParams struct {
+	Patterns []string `json:"patterns"`
+
+	// Cursor identifies the last entity returned in the previous
+	// page. It is empty when requesting the first page.
+	Cursor string `json:"cursor,omitempty"`
+
+	// Limit is the maximum number of machines, containers,
+	// applications and units to return in the page.
+	Limit int `json:"limit"`
+}
+
+// FullStatusPage holds a page of the status of a juju model.
+type FullStatusPage struct {
+	Status FullStatus `json:"status"`
+
+	// NextCursor is the cursor to request the next page with. It
+	// is empty when there are no more pages.
+	NextCursor string `json:"next-cursor,omitempty"`
+}
+
 // TODO(ericsnow) Add FullStatusResult.
 
 // FullStatus holds information about the status of a juju model.
@@ -40,6 +62,7 @@ type ModelStatusInfo struct {
 	ModelStatus      DetailedStatus `json:"model-status"`
 	MeterStatus      MeterStatus    `json:"meter-status"`
 	SLA              string         `json:"sla"`
+	Description      string         `json:"description,omitempty"`
 }
 
 // NetworkInterfaceStatus holds a /etc/network/interfaces-type data and the
@@ -117,6 +140,7 @@ type ApplicationStatus struct {
 	MeterStatuses   map[string]MeterStatus `json:"meter-statuses"`
 	Status          DetailedStatus         `json:"status"`
 	WorkloadVersion string                 `json:"workload-version"`
+	Description     string                 `json:"description,omitempty"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
@@ -162,6 +186,11 @@ type UnitStatus struct {
 	Charm         string                `json:"charm"`
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
+
+	// PortConflicts describes the conflicts between the ports opened
+	// by the unit and those opened by other units on the same machine,
+	// or on machines sharing its network.
+	PortConflicts []string `json:"port-conflicts,omitempty"`
 }
 
 // RelationStatus holds status info about a relation.
@@ -212,6 +241,8 @@ type StatusHistoryFilter struct {
 	Date    *time.Time     `json:"date"`
 	Delta   *time.Duration `json:"delta"`
 	Exclude []string       `json:"exclude"`
+	ToDate  *time.Time     `json:"to-date,omitempty"`
+	Offset  int            `json:"offset,omitempty"`
 }
 
 // StatusHistoryRequest holds the parameters to filter a status history query.
@@ -262,6 +293,26 @@ type StatusResults struct {
 	Results []StatusResult `json:"results"`
 }
 
+// MachinePageParams holds parameters for calls that consider a page of
+// the model's machines at a time.
+type MachinePageParams struct {
+	// Cursor is the id of the last machine in the previous page. It
+	// is empty when requesting the first page.
+	Cursor string `json:"cursor,omitempty"`
+
+	// Limit is the maximum number of machines in the page.
+	Limit int `json:"limit"`
+}
+
+// StatusResultsPage holds the status results for a page of machines.
+type StatusResultsPage struct {
+	Results []StatusResult `json:"results"`
+
+	// NextCursor is the cursor to request the next page with. It
+	// is empty when there are no more pages.
+	NextCursor string `json:"next-cursor,omitempty"`
+}
+
 // ApplicationStatusResult holds results for an application Full Status.
 type ApplicationStatusResult struct {
 	Application StatusResult            `json:"application"`
@@ -274,6 +325,20 @@ type ApplicationStatusResults struct {
 	Results []ApplicationStatusResult `json:"results"`
 }
 
+// RelatedApplicationStatusResult holds the workload statuses of the
+// applications related to a unit's application, keyed by application
+// name.
+type RelatedApplicationStatusResult struct {
+	Statuses map[string]StatusResult `json:"statuses"`
+	Error    *Error                  `json:"error,omitempty"`
+}
+
+// RelatedApplicationStatusResults holds multiple
+// RelatedApplicationStatusResult.
+type RelatedApplicationStatusResults struct {
+	Results []RelatedApplicationStatusResult `json:"results"`
+}
+
 // Life describes the lifecycle state of an entity ("alive", "dying" or "dead").
 type Life multiwatcher.Life
 
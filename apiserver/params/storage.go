@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -301,6 +305,32 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// ResizeVolumeParams holds the parameters for growing a volume.
+type ResizeVolumeParams struct {
+	// VolumeTag is the tag of the volume to grow.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// Size is the size, in MiB, that the volume is to be grown to.
+	Size uint64 `json:"size"`
+}
+
+// ResizeVolumeParamsResult holds parameters for growing a volume.
+type ResizeVolumeParamsResult struct {
+	Result ResizeVolumeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ResizeVolumeParamsResults holds parameters for growing multiple volumes.
+type ResizeVolumeParamsResults struct {
+	Results []ResizeVolumeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -728,6 +758,46 @@ type FilesystemAttachmentDetails struct {
 	// Juju controllers older than 2.2 do not populate this
 	// field, so it may be omitted.
 	Life Life `json:"life,omitempty"`
+
+	// Usage contains the utilization of the filesystem, as last
+	// reported by the machine agent, if any.
+	Usage *FilesystemUsage `json:"usage,omitempty"`
+}
+
+// FilesystemUsage describes the utilization of a mounted filesystem.
+// Sizes are in MiB.
+type FilesystemUsage struct {
+	Size      uint64    `json:"size"`
+	Used      uint64    `json:"used"`
+	Available uint64    `json:"available"`
+	Updated   time.Time `json:"updated"`
+}
+
+// MachineFilesystemAttachmentsResult holds the filesystem attachments
+// of a machine, or an error preventing retrieving them.
+type MachineFilesystemAttachmentsResult struct {
+	Attachments []FilesystemAttachment `json:"attachments,omitempty"`
+	Error       *Error                 `json:"error,omitempty"`
+}
+
+// MachineFilesystemAttachmentsResults holds a set of
+// MachineFilesystemAttachmentsResults.
+type MachineFilesystemAttachmentsResults struct {
+	Results []MachineFilesystemAttachmentsResult `json:"results"`
+}
+
+// FilesystemUsageArg holds the utilization of a filesystem attachment,
+// as measured by the machine agent.
+type FilesystemUsageArg struct {
+	MachineTag    string          `json:"machine-tag"`
+	FilesystemTag string          `json:"filesystem-tag"`
+	Usage         FilesystemUsage `json:"usage"`
+}
+
+// FilesystemUsageArgs holds the arguments for recording the utilization
+// of a set of filesystem attachments.
+type FilesystemUsageArgs struct {
+	Args []FilesystemUsageArg `json:"args"`
 }
 
 // FilesystemDetailsResult contains details about a filesystem, its attachments or
@@ -805,6 +875,74 @@ type RemoveStorageInstance struct {
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
 }
 
+// StoragesGrowArgs holds the parameters for growing storage instances.
+type StoragesGrowArgs struct {
+	Storage []StorageGrowArg `json:"storage"`
+}
+
+// StorageGrowArg holds the parameters for growing a storage instance.
+type StorageGrowArg struct {
+	// StorageTag is the tag of the storage instance to grow.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StorageSnapshotResults contains the results of snapshotting, or
+// listing snapshots of, storage instances.
+type StorageSnapshotResults struct {
+	Results []StorageSnapshotResult `json:"results"`
+}
+
+// StorageSnapshotResult contains the details of a storage snapshot,
+// or an error.
+type StorageSnapshotResult struct {
+	Result *StorageSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// StorageSnapshotDetails contains the details of a snapshot of the
+// volume underlying a storage instance.
+type StorageSnapshotDetails struct {
+	// Id is the ID of the snapshot, unique within the model.
+	Id string `json:"id"`
+
+	// SnapshotId is the storage provider's unique ID for the
+	// snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// StorageTag is the tag of the storage instance whose volume
+	// was snapshotted.
+	StorageTag string `json:"storage-tag"`
+
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// StorageName is the name of the storage, as defined in the
+	// charm.
+	StorageName string `json:"storage-name"`
+
+	// Kind is the kind of the storage that was snapshotted.
+	Kind StorageKind `json:"kind"`
+
+	// Pool is the name of the storage pool of the volume.
+	Pool string `json:"pool"`
+
+	// Size is the size of the volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time `json:"created"`
+}
+
+// RestoreStorageSnapshotsArgs holds the parameters for restoring new
+// storage instances from storage snapshots.
+type RestoreStorageSnapshotsArgs struct {
+	// Snapshots holds the IDs of the snapshots to restore.
+	Snapshots []string `json:"snapshots"`
+}
+
 // BulkImportStorageParams contains the parameters for importing a collection
 // of storage entities.
 type BulkImportStorageParams struct {
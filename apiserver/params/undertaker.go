@@ -10,6 +10,10 @@ type UndertakerModelInfo struct {
 	GlobalName string `json:"global-name"`
 	IsSystem   bool   `json:"is-system"`
 	Life       Life   `json:"life"`
+
+	// ForceDestroyed is true if the model's cloud resources should
+	// be removed even if they are still in use.
+	ForceDestroyed bool `json:"force-destroyed,omitempty"`
 }
 
 // UndertakerModelInfoResult holds the result of an API call that returns an
@@ -0,0 +1,186 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// PortListener is a net.Listener that accepts connections on a set of
+// TCP ports, which may be changed while it is in use so that the API
+// server can move to another port without being restarted.
+type PortListener struct {
+	mu        sync.Mutex
+	primary   int
+	listeners map[int]*portAcceptor
+	accepted  chan acceptResult
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+// portAcceptor accepts connections on one port, and passes them on to
+// the PortListener.
+type portAcceptor struct {
+	net.Listener
+	stopped chan struct{}
+}
+
+// NewPortListener returns a PortListener accepting connections on
+// the given ports, the first of which is reported as its address.
+func NewPortListener(ports ...int) (*PortListener, error) {
+	l := &PortListener{
+		listeners: make(map[int]*portAcceptor),
+		accepted:  make(chan acceptResult),
+		closed:    make(chan struct{}),
+	}
+	if err := l.SetPorts(ports...); err != nil {
+		l.Close()
+		return nil, errors.Trace(err)
+	}
+	return l, nil
+}
+
+// SetPorts changes the ports on which connections are accepted. The
+// new ports are opened before the old ones are closed, and connections
+// already accepted are unaffected. The first port is reported as the
+// listener's address.
+func (l *PortListener) SetPorts(ports ...int) error {
+	if len(ports) == 0 {
+		return errors.New("no ports specified")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-l.closed:
+		return errors.New("listener closed")
+	default:
+	}
+
+	wanted := make(map[int]bool)
+	for _, port := range ports {
+		wanted[port] = true
+		if _, ok := l.listeners[port]; ok {
+			continue
+		}
+		lis, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+		if err != nil {
+			return errors.Annotatef(err, "cannot listen on port %d", port)
+		}
+		logger.Infof("accepting API connections on port %d", port)
+		acceptor := &portAcceptor{
+			Listener: lis,
+			stopped:  make(chan struct{}),
+		}
+		l.listeners[port] = acceptor
+		go l.accept(acceptor)
+	}
+	for port, acceptor := range l.listeners {
+		if wanted[port] {
+			continue
+		}
+		logger.Infof("no longer accepting API connections on port %d", port)
+		acceptor.stop()
+		delete(l.listeners, port)
+	}
+	l.primary = ports[0]
+	return nil
+}
+
+// Ports returns the sorted ports on which connections are accepted.
+func (l *PortListener) Ports() []int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ports := make([]int, 0, len(l.listeners))
+	for port := range l.listeners {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+func (l *PortListener) accept(acceptor *portAcceptor) {
+	for {
+		conn, err := acceptor.Accept()
+		select {
+		case <-acceptor.stopped:
+			// The port has been closed deliberately, so the error
+			// is not reported.
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		default:
+		}
+		select {
+		case l.accepted <- acceptResult{conn, err}:
+		case <-acceptor.stopped:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		case <-l.closed:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (a *portAcceptor) stop() {
+	close(a.stopped)
+	a.Listener.Close()
+}
+
+// Accept is part of the net.Listener interface.
+func (l *PortListener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.accepted:
+		return result.conn, result.err
+	case <-l.closed:
+		return nil, errors.New("listener closed")
+	}
+}
+
+// Close is part of the net.Listener interface. It closes all of the
+// ports.
+func (l *PortListener) Close() error {
+	l.closeOnce.Do(func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		close(l.closed)
+		for port, acceptor := range l.listeners {
+			acceptor.stop()
+			delete(l.listeners, port)
+		}
+	})
+	return nil
+}
+
+// Addr is part of the net.Listener interface. It returns the address
+// of the first of the ports last set.
+func (l *PortListener) Addr() net.Addr {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if acceptor, ok := l.listeners[l.primary]; ok {
+		return acceptor.Addr()
+	}
+	return &net.TCPAddr{Port: l.primary}
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+)
+
+type portListenerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&portListenerSuite{})
+
+// freePorts returns n ports that are not in use.
+func freePorts(c *gc.C, n int) []int {
+	var ports []int
+	for i := 0; i < n; i++ {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		c.Assert(err, jc.ErrorIsNil)
+		defer lis.Close()
+		ports = append(ports, lis.Addr().(*net.TCPAddr).Port)
+	}
+	return ports
+}
+
+func dialPort(port int) (net.Conn, error) {
+	return net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+}
+
+func (s *portListenerSuite) assertAccepts(c *gc.C, l net.Listener, port int) {
+	conn, err := dialPort(port)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	accepted, err := l.Accept()
+	c.Assert(err, jc.ErrorIsNil)
+	defer accepted.Close()
+	c.Assert(accepted.LocalAddr().(*net.TCPAddr).Port, gc.Equals, port)
+}
+
+func (s *portListenerSuite) TestAcceptsOnAllPorts(c *gc.C) {
+	ports := freePorts(c, 2)
+	l, err := apiserver.NewPortListener(ports...)
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+
+	c.Assert(l.Addr().(*net.TCPAddr).Port, gc.Equals, ports[0])
+	s.assertAccepts(c, l, ports[0])
+	s.assertAccepts(c, l, ports[1])
+}
+
+func (s *portListenerSuite) TestSetPorts(c *gc.C) {
+	ports := freePorts(c, 2)
+	l, err := apiserver.NewPortListener(ports[0])
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+
+	// Move to the new port, keeping the old one open.
+	err = l.SetPorts(ports[1], ports[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(l.Addr().(*net.TCPAddr).Port, gc.Equals, ports[1])
+	s.assertAccepts(c, l, ports[0])
+	s.assertAccepts(c, l, ports[1])
+
+	// Close the old port.
+	err = l.SetPorts(ports[1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(l.Ports(), jc.DeepEquals, []int{ports[1]})
+	s.assertAccepts(c, l, ports[1])
+	_, err = dialPort(ports[0])
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *portListenerSuite) TestSetPortsNone(c *gc.C) {
+	l, err := apiserver.NewPortListener(freePorts(c, 1)...)
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+	err = l.SetPorts()
+	c.Assert(err, gc.ErrorMatches, "no ports specified")
+}
+
+func (s *portListenerSuite) TestPortInUse(c *gc.C) {
+	lis, err := net.Listen("tcp", ":0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer lis.Close()
+	port := lis.Addr().(*net.TCPAddr).Port
+
+	_, err = apiserver.NewPortListener(port)
+	c.Assert(err, gc.ErrorMatches, "cannot listen on port "+strconv.Itoa(port)+": .*")
+}
+
+func (s *portListenerSuite) TestClose(c *gc.C) {
+	ports := freePorts(c, 1)
+	l, err := apiserver.NewPortListener(ports...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = l.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = l.Accept()
+	c.Assert(err, gc.ErrorMatches, "listener closed")
+	err = l.SetPorts(ports...)
+	c.Assert(err, gc.ErrorMatches, "listener closed")
+}
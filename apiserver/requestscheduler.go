@@ -0,0 +1,206 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// requestPriority is the priority class of an API request.
+type requestPriority int
+
+const (
+	// agentPriority is the class of requests made by agents, such
+	// as the uniter, provisioner and firewaller.
+	agentPriority requestPriority = iota
+
+	// clientPriority is the class of requests made by users, such
+	// as FullStatus, which can be expensive in large models.
+	clientPriority
+
+	numRequestPriorities
+)
+
+// String is part of fmt.Stringer.
+func (p requestPriority) String() string {
+	switch p {
+	case agentPriority:
+		return "agent"
+	case clientPriority:
+		return "client"
+	}
+	return "unknown"
+}
+
+// priorityForTag returns the priority class of requests made by the
+// entity with the given tag.
+func priorityForTag(tag names.Tag) requestPriority {
+	switch tag.(type) {
+	case names.UserTag:
+		return clientPriority
+	}
+	return agentPriority
+}
+
+// isBlockingMethod reports whether the given method may block until
+// some change happens, rather than doing a bounded amount of work.
+// Such requests are never queued, and do not take a request slot, as
+// they could otherwise hold the slots indefinitely.
+func isBlockingMethod(facadeName, methodName string) bool {
+	if strings.HasSuffix(facadeName, "Watcher") {
+		return true
+	}
+	switch facadeName + "." + methodName {
+	case "LeadershipService.BlockUntilLeadershipReleased",
+		"Singular.Wait",
+		"Pinger.Ping":
+		return true
+	}
+	return false
+}
+
+// requestScheduler limits the number of API requests that are served
+// concurrently. When all request slots are in use, waiting requests
+// are admitted in proportion to the weights of their priority classes,
+// so that agents are never starved by expensive client requests, nor
+// clients by a busy fleet of agents.
+type requestScheduler struct {
+	mu      sync.Mutex
+	limit   int
+	weights [numRequestPriorities]int
+	credits [numRequestPriorities]int
+	waiting [numRequestPriorities][]chan struct{}
+	active  int
+	queued  int64
+}
+
+// newRequestScheduler returns a requestScheduler that serves at most
+// limit requests concurrently, admitting waiting agent and client
+// requests in the ratio of the given weights. If limit is zero, no
+// requests are ever queued.
+func newRequestScheduler(limit, agentWeight, clientWeight int) *requestScheduler {
+	s := &requestScheduler{limit: limit}
+	s.weights[agentPriority] = agentWeight
+	s.weights[clientPriority] = clientWeight
+	s.credits = s.weights
+	return s
+}
+
+// acquire blocks until a request of the given priority may be served.
+// The caller must call release once the request has been served.
+func (s *requestScheduler) acquire(priority requestPriority) {
+	s.mu.Lock()
+	if s.limit == 0 || s.active < s.limit && s.numWaiting() == 0 {
+		s.active++
+		s.mu.Unlock()
+		return
+	}
+	ready := make(chan struct{})
+	s.waiting[priority] = append(s.waiting[priority], ready)
+	s.queued++
+	s.mu.Unlock()
+	<-ready
+}
+
+// release frees the request slot taken by acquire, admitting the next
+// waiting request, if any.
+func (s *requestScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active--
+	for s.active < s.limit && s.numWaiting() > 0 {
+		priority := s.nextPriority()
+		ready := s.waiting[priority][0]
+		s.waiting[priority] = s.waiting[priority][1:]
+		s.credits[priority]--
+		s.active++
+		close(ready)
+	}
+}
+
+// nextPriority returns the priority class of the next waiting request
+// to admit. Each class may be admitted as many times as its weight
+// before the credits of all classes are replenished. It must only be
+// called with s.mu held and some request waiting.
+func (s *requestScheduler) nextPriority() requestPriority {
+	for {
+		for p := requestPriority(0); p < numRequestPriorities; p++ {
+			if len(s.waiting[p]) > 0 && s.credits[p] > 0 {
+				return p
+			}
+		}
+		s.credits = s.weights
+	}
+}
+
+func (s *requestScheduler) numWaiting() int {
+	n := 0
+	for _, w := range s.waiting {
+		n += len(w)
+	}
+	return n
+}
+
+// stats returns the number of requests being served, the number of
+// requests of each priority class waiting to be served, and the total
+// number of requests that have ever had to wait.
+func (s *requestScheduler) stats() (active int, waiting map[requestPriority]int, queued int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	waiting = make(map[requestPriority]int)
+	for p, w := range s.waiting {
+		waiting[requestPriority(p)] = len(w)
+	}
+	return s.active, waiting, s.queued
+}
+
+// scheduleRoot wraps the provided root so that the requests made
+// through it are served according to the given scheduler and
+// priority.
+func scheduleRoot(root rpc.Root, scheduler *requestScheduler, priority requestPriority) *scheduledRoot {
+	return &scheduledRoot{
+		Root:      root,
+		scheduler: scheduler,
+		priority:  priority,
+	}
+}
+
+type scheduledRoot struct {
+	rpc.Root
+	scheduler *requestScheduler
+	priority  requestPriority
+}
+
+// FindMethod implements rpc.Root.
+func (r *scheduledRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.Root.FindMethod(facadeName, version, methodName)
+	if err != nil || isBlockingMethod(facadeName, methodName) {
+		return caller, err
+	}
+	return &scheduledCaller{
+		MethodCaller: caller,
+		scheduler:    r.scheduler,
+		priority:     r.priority,
+	}, nil
+}
+
+type scheduledCaller struct {
+	rpcreflect.MethodCaller
+	scheduler *requestScheduler
+	priority  requestPriority
+}
+
+// Call implements rpcreflect.MethodCaller.
+func (c *scheduledCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	c.scheduler.acquire(c.priority)
+	defer c.scheduler.release()
+	return c.MethodCaller.Call(objId, arg)
+}
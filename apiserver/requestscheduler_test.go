@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type requestSchedulerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&requestSchedulerSuite{})
+
+func (s *requestSchedulerSuite) TestUnlimited(c *gc.C) {
+	scheduler := newRequestScheduler(0, 1, 1)
+	for i := 0; i < 10; i++ {
+		scheduler.acquire(clientPriority)
+	}
+	active, waiting, queued := scheduler.stats()
+	c.Assert(active, gc.Equals, 10)
+	c.Assert(waiting, jc.DeepEquals, map[requestPriority]int{agentPriority: 0, clientPriority: 0})
+	c.Assert(queued, gc.Equals, int64(0))
+}
+
+func (s *requestSchedulerSuite) TestWeightedAdmission(c *gc.C) {
+	scheduler := newRequestScheduler(1, 2, 1)
+	scheduler.acquire(clientPriority)
+
+	admitted := make(chan requestPriority)
+	for i := 0; i < 3; i++ {
+		for _, priority := range []requestPriority{agentPriority, clientPriority} {
+			go func(priority requestPriority) {
+				scheduler.acquire(priority)
+				admitted <- priority
+			}(priority)
+		}
+	}
+	s.waitForWaiting(c, scheduler, 6)
+	_, _, queued := scheduler.stats()
+	c.Assert(queued, gc.Equals, int64(6))
+
+	var order []requestPriority
+	for i := 0; i < 6; i++ {
+		scheduler.release()
+		select {
+		case priority := <-admitted:
+			order = append(order, priority)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for request to be admitted")
+		}
+		select {
+		case priority := <-admitted:
+			c.Fatalf("unexpected %v request admitted", priority)
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+	c.Assert(order, jc.DeepEquals, []requestPriority{
+		agentPriority, agentPriority, clientPriority,
+		agentPriority, clientPriority, clientPriority,
+	})
+}
+
+func (s *requestSchedulerSuite) TestNoQueueJumping(c *gc.C) {
+	scheduler := newRequestScheduler(1, 1, 1)
+	scheduler.acquire(agentPriority)
+
+	admitted := make(chan struct{})
+	go func() {
+		scheduler.acquire(clientPriority)
+		close(admitted)
+	}()
+	s.waitForWaiting(c, scheduler, 1)
+
+	scheduler.release()
+	select {
+	case <-admitted:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request to be admitted")
+	}
+	active, _, _ := scheduler.stats()
+	c.Assert(active, gc.Equals, 1)
+}
+
+func (s *requestSchedulerSuite) waitForWaiting(c *gc.C, scheduler *requestScheduler, expect int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		_, waiting, _ := scheduler.stats()
+		if waiting[agentPriority]+waiting[clientPriority] == expect {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d requests to wait", expect)
+}
+
+func (s *requestSchedulerSuite) TestPriorityForTag(c *gc.C) {
+	c.Assert(priorityForTag(names.NewUserTag("bob")), gc.Equals, clientPriority)
+	c.Assert(priorityForTag(names.NewMachineTag("0")), gc.Equals, agentPriority)
+	c.Assert(priorityForTag(names.NewUnitTag("mysql/0")), gc.Equals, agentPriority)
+	c.Assert(priorityForTag(names.NewApplicationTag("mysql")), gc.Equals, agentPriority)
+}
+
+func (s *requestSchedulerSuite) TestIsBlockingMethod(c *gc.C) {
+	for _, test := range []struct {
+		facade, method string
+		blocking       bool
+	}{
+		{"NotifyWatcher", "Next", true},
+		{"AllWatcher", "Next", true},
+		{"LeadershipService", "BlockUntilLeadershipReleased", true},
+		{"LeadershipService", "ClaimLeadership", false},
+		{"Singular", "Wait", true},
+		{"Pinger", "Ping", true},
+		{"Client", "FullStatus", false},
+		{"Uniter", "WatchConfigSettings", false},
+	} {
+		c.Check(isBlockingMethod(test.facade, test.method), gc.Equals, test.blocking,
+			gc.Commentf("%s.%s", test.facade, test.method))
+	}
+}
@@ -18,6 +18,8 @@ var controllerFacadeNames = set.NewStrings(
 	"ApplicationOffers",
 	"Cloud",
 	"Controller",
+	"ControllerHealth",
+	"ControllerTxns",
 	"MigrationTarget",
 	"ModelManager",
 	"UserManager",
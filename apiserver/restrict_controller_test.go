@@ -34,6 +34,8 @@ func (s *restrictControllerSuite) TestAllowed(c *gc.C) {
 	s.assertMethod(c, "Bundle", 1, "GetChanges")
 	s.assertMethod(c, "HighAvailability", 2, "EnableHA")
 	s.assertMethod(c, "ApplicationOffers", 1, "ApplicationOffers")
+	s.assertMethod(c, "ControllerHealth", 1, "Health")
+	s.assertMethod(c, "ControllerTxns", 1, "StuckTransactions")
 }
 
 func (s *restrictControllerSuite) TestNotAllowed(c *gc.C) {
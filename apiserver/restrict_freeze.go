@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+// modelFreezeGetter returns the freeze message of a model, and whether
+// the model is frozen.
+type modelFreezeGetter func() (string, bool, error)
+
+// frozenModelReadOnly returns a check for restrictRoot that rejects
+// the API calls that may change a model while it is frozen. Whether
+// the model is frozen is checked on each such call, so that freezing
+// a model applies to existing connections.
+func frozenModelReadOnly(getFreeze modelFreezeGetter) func(string, string) error {
+	return func(facadeName, methodName string) error {
+		if IsMethodAllowedDuringFreeze(facadeName, methodName) {
+			return nil
+		}
+		message, frozen, err := getFreeze()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if frozen {
+			return common.ModelFrozenError(message)
+		}
+		return nil
+	}
+}
+
+// IsMethodAllowedDuringFreeze reports whether the given API call
+// may be made by users while the model is frozen.
+func IsMethodAllowedDuringFreeze(facadeName, methodName string) bool {
+	if strings.HasSuffix(facadeName, "Watcher") {
+		// Watchers only report changes.
+		return true
+	}
+	methods, ok := allowedMethodsDuringFreeze[facadeName]
+	if !ok {
+		return false
+	}
+	return methods.Contains(methodName)
+}
+
+// allowedMethodsDuringFreeze stores the api calls, by facade name,
+// that do not change a model, and so are not blocked while the model
+// is frozen.
+var allowedMethodsDuringFreeze = map[string]set.Strings{
+	"Action": set.NewStrings(
+		"Actions",
+		"ApplicationsCharmsActions",
+		"FindActionTagsByPrefix",
+		"FindActionsByNames",
+		"ListAll",
+		"ListCompleted",
+		"ListPending",
+		"ListRunning",
+	),
+	"ActionSchedules": set.NewStrings(
+		"Schedules",
+	),
+	"Annotations": set.NewStrings(
+		"Get",
+	),
+	"Application": set.NewStrings(
+		"ApplicationRemovalReports",
+		"CharmRelations",
+		"Get",
+		"GetApplicationsAddressPolicy",
+		"GetApplicationsHookEnvironment",
+		"GetApplicationsLogging",
+		"GetApplicationsTrust",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetEffectiveConstraints",
+		"PreviewAddRelation",
+		"PreviewAddUnits",
+		"PreviewDestroyRelation",
+		"RelationSettingsUsage",
+	),
+	"Block": set.NewStrings(
+		"List",
+	),
+	"Bundle": set.NewStrings(
+		"ExportBundle",
+		"GetChanges",
+	),
+	"ChangeLog": set.NewStrings(
+		"ChangeLog",
+	),
+	"Charms": set.NewStrings(
+		"CharmInfo",
+		"IsMetered",
+		"List",
+	),
+	"CostEstimator": set.NewStrings(
+		"EstimateCost",
+	),
+	"Description": set.NewStrings(
+		"Descriptions",
+	),
+	"Client": set.NewStrings(
+		"AgentVersion",
+		"FindTools",
+		"FullStatus",     // for "juju status"
+		"FullStatusPage", // for "juju status --page-size"
+		"GetBundleChanges",
+		"GetModelConstraints",
+		"ModelGet",
+		"ModelInfo",
+		"ModelUserInfo",
+		"StatusHistory",
+		"WatchAll",
+	),
+	"FirewallRules": set.NewStrings(
+		"ListFirewallRules",
+	),
+	"ImageManager": set.NewStrings(
+		"ListImages",
+	),
+	"ImageMetadata": set.NewStrings(
+		"List",
+	),
+	"KeyManager": set.NewStrings(
+		"ListKeys",
+	),
+	"MachineManager": set.NewStrings(
+		"CloudInstances",
+		"ConsoleLogs",
+		"InstanceTypes",
+		"PendingRemovals",
+	),
+	"MachineNetworking": set.NewStrings(
+		"LinkLayerDevices",
+	),
+	"MetricsDebug": set.NewStrings(
+		"GetMetrics",
+	),
+	"ModelConfig": set.NewStrings(
+		"ModelConfigDiff",
+		"ModelConfigHistory",
+		"ModelGet",
+		"SLALevel",
+	),
+	"ModelFreeze": set.NewStrings( // so that the model can be unfrozen
+		"Freeze",
+		"FreezeStatus",
+		"Unfreeze",
+	),
+	"ModelSuspension": set.NewStrings(
+		"SuspensionStatus",
+	),
+	"Payloads": set.NewStrings(
+		"List",
+	),
+	"Pinger": set.NewStrings(
+		"Ping",
+	),
+	"Resources": set.NewStrings(
+		"ListResources",
+	),
+	"Spaces": set.NewStrings(
+		"ListSpaces",
+	),
+	"SSHClient": set.NewStrings( // allow all SSH client related calls
+		"PublicAddress",
+		"PrivateAddress",
+		"BestAPIVersion",
+		"AllAddresses",
+		"PublicKeys",
+		"Proxy",
+		"HostMachine",
+	),
+	"Storage": set.NewStrings(
+		"ListFilesystems",
+		"ListPools",
+		"ListSnapshots",
+		"ListStorageDetails",
+		"ListVolumes",
+		"StorageDetails",
+		"WatchFilesystemAttachments",
+		"WatchVolumeAttachments",
+	),
+	"Subnets": set.NewStrings(
+		"AllSpaces",
+		"AllZones",
+		"ListSubnets",
+	),
+}
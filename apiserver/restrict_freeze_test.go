@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/testing"
+)
+
+type restrictFreezeSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictFreezeSuite{})
+
+func (r *restrictFreezeSuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingFrozenRoot("incident 42", true)
+	checkAllowed := func(facade, method string, version int) {
+		caller, err := root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Client", "FullStatus", 1)
+	checkAllowed("Client", "FullStatusPage", 2)
+	checkAllowed("AllWatcher", "Next", 1)
+	checkAllowed("SSHClient", "Proxy", 2)
+	checkAllowed("Pinger", "Ping", 1)
+	checkAllowed("ModelFreeze", "Unfreeze", 1)
+	checkAllowed("Storage", "WatchVolumeAttachments", 7)
+	checkAllowed("Storage", "WatchFilesystemAttachments", 7)
+}
+
+// readOnlyMethod matches the names of client facade methods that,
+// by convention, only read from the model.
+var readOnlyMethod = regexp.MustCompile(`^(Get|List|Preview|Watch|Find)[A-Z]|(Status|StatusPage|History|Diff|Usage|Logs)$`)
+
+// readOnlyMethods holds other client facade methods that only read
+// from the model, but whose names don't say so.
+var readOnlyMethods = map[string][]string{
+	"Application":       {"ApplicationRemovalReports", "CharmRelations", "Get"},
+	"Bundle":            {"ExportBundle"},
+	"MachineManager":    {"CloudInstances", "InstanceTypes", "PendingRemovals"},
+	"MachineNetworking": {"LinkLayerDevices"},
+	"ModelConfig":       {"ModelGet", "SLALevel"},
+}
+
+func (r *restrictFreezeSuite) TestReadOnlyMethodsAllowed(c *gc.C) {
+	const clientFacades = "github.com/juju/juju/apiserver/facades/client/"
+	for _, facade := range apiserver.AllFacades().ListDetails() {
+		if !apiserver.IsModelFacade(facade.Name) {
+			continue
+		}
+		facadeType := facade.Type
+		if facadeType.Kind() == reflect.Ptr {
+			facadeType = facadeType.Elem()
+		}
+		if !strings.HasPrefix(facadeType.PkgPath(), clientFacades) {
+			continue
+		}
+		for _, method := range rpcreflect.ObjTypeOf(facade.Type).MethodNames() {
+			if strings.HasPrefix(method, "Set") || !readOnlyMethod.MatchString(method) {
+				continue
+			}
+			c.Check(apiserver.IsMethodAllowedDuringFreeze(facade.Name, method), jc.IsTrue,
+				gc.Commentf("%s(%d).%s", facade.Name, facade.Version, method))
+		}
+	}
+	for facade, methods := range readOnlyMethods {
+		for _, method := range methods {
+			c.Check(apiserver.IsMethodAllowedDuringFreeze(facade, method), jc.IsTrue,
+				gc.Commentf("%s.%s", facade, method))
+		}
+	}
+}
+
+func (r *restrictFreezeSuite) TestFindDisallowedMethod(c *gc.C) {
+	root := apiserver.TestingFrozenRoot("incident 42", true)
+	caller, err := root.FindMethod("Client", 1, "ModelSet")
+	c.Assert(err, gc.ErrorMatches, "model is frozen: incident 42")
+	c.Assert(err, jc.Satisfies, params.IsCodeModelFrozen)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (r *restrictFreezeSuite) TestNotFrozen(c *gc.C) {
+	root := apiserver.TestingFrozenRoot("", false)
+	caller, err := root.FindMethod("Client", 1, "ModelSet")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller, gc.NotNil)
+}
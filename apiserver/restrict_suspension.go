@@ -0,0 +1,34 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+// suspendedModelReadOnly returns a check for restrictRoot that rejects
+// the API calls that may change a model while it is suspended, other
+// than those that resume it. Whether the model is suspended is checked
+// on each such call, so that suspending a model applies to existing
+// connections.
+func suspendedModelReadOnly(isSuspended func() (bool, error)) func(string, string) error {
+	return func(facadeName, methodName string) error {
+		if facadeName == "ModelSuspension" {
+			return nil
+		}
+		if IsMethodAllowedDuringFreeze(facadeName, methodName) {
+			return nil
+		}
+		suspended, err := isSuspended()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if suspended {
+			return common.ModelSuspendedError()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type restrictSuspensionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictSuspensionSuite{})
+
+func (r *restrictSuspensionSuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingSuspendedRoot(true)
+	checkAllowed := func(facade, method string, version int) {
+		caller, err := root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Client", "FullStatus", 1)
+	checkAllowed("Client", "FullStatusPage", 2)
+	checkAllowed("Storage", "WatchVolumeAttachments", 7)
+	checkAllowed("AllWatcher", "Next", 1)
+	checkAllowed("Pinger", "Ping", 1)
+	checkAllowed("ModelSuspension", "SuspensionStatus", 1)
+	checkAllowed("ModelSuspension", "Resume", 1)
+}
+
+func (r *restrictSuspensionSuite) TestFindDisallowedMethod(c *gc.C) {
+	root := apiserver.TestingSuspendedRoot(true)
+	caller, err := root.FindMethod("Client", 1, "ModelSet")
+	c.Assert(err, gc.ErrorMatches, "model is suspended")
+	c.Assert(err, jc.Satisfies, params.IsCodeModelSuspended)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (r *restrictSuspensionSuite) TestNotSuspended(c *gc.C) {
+	root := apiserver.TestingSuspendedRoot(false)
+	caller, err := root.FindMethod("Client", 1, "ModelSet")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller, gc.NotNil)
+}
@@ -101,6 +101,11 @@ func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID st
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The controller health facade reports the number of API
+	// connections to this server.
+	if err := r.resources.RegisterNamed("connectionCounter", common.ValueResource{srv}); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return r, nil
 }
 
@@ -200,6 +205,9 @@ func rpcRoot(srv *Server, root *apiHandler, authTag names.Tag) (rpc.Root, error)
 			return nil, errors.Trace(err)
 		}
 	}
+	if srv.requests != nil {
+		apiRoot = scheduleRoot(apiRoot, srv.requests, priorityForTag(authTag))
+	}
 	return apiRoot, nil
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/bindelta"
 )
 
 // toolsHandler handles tool upload through HTTPS in the API server.
@@ -35,7 +36,8 @@ type toolsUploadHandler struct {
 
 // toolsHandler handles tool download through HTTPS in the API server.
 type toolsDownloadHandler struct {
-	ctxt httpContext
+	ctxt   httpContext
+	deltas *toolsDeltaCache
 }
 
 func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -58,7 +60,17 @@ func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			}
 			return
 		}
-		if err := h.sendTools(w, http.StatusOK, tarball); err != nil {
+		contentType := "application/x-tar-gz"
+		if base := r.URL.Query().Get("delta-from"); base != "" {
+			delta, err := h.processGetDelta(r, st, base)
+			if err != nil {
+				// The agent falls back to using the whole tarball.
+				logger.Infof("not sending tools delta from %s: %v", base, err)
+			} else if len(delta) < len(tarball) {
+				tarball, contentType = delta, bindelta.ContentType
+			}
+		}
+		if err := h.sendTools(w, http.StatusOK, contentType, tarball); err != nil {
 			logger.Errorf("%v", err)
 		}
 	default:
@@ -135,6 +147,38 @@ func (h *toolsDownloadHandler) processGet(r *http.Request, st *state.State) ([]b
 	return data, nil
 }
 
+// processGetDelta returns the delta from the tools with the given base
+// version to the tools requested, so that agents upgrading from the base
+// version need not download the whole tools tarball. Both tools must be
+// in tools storage.
+func (h *toolsDownloadHandler) processGetDelta(r *http.Request, st *state.State, base string) ([]byte, error) {
+	if h.deltas == nil {
+		return nil, errors.NotSupportedf("tools deltas")
+	}
+	target, err := version.ParseBinary(r.URL.Query().Get(":version"))
+	if err != nil {
+		return nil, errors.Annotate(err, "error parsing version")
+	}
+	baseVersion, err := version.ParseBinary(base)
+	if err != nil {
+		return nil, errors.Annotate(err, "error parsing base version")
+	}
+	storage, err := st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Annotate(err, "error getting tools storage")
+	}
+	defer storage.Close()
+	baseMetadata, err := storage.Metadata(baseVersion.String())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetMetadata, err := storage.Metadata(target.String())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return h.deltas.get(storage, baseMetadata, targetMetadata)
+}
+
 // fetchAndCacheTools fetches tools with the specified version by searching for a URL
 // in simplestreams and GETting it, caching the result in tools storage before returning
 // to the caller.
@@ -186,9 +230,9 @@ func (h *toolsDownloadHandler) fetchAndCacheTools(v version.Binary, stor binarys
 	return ioutil.NopCloser(bytes.NewReader(data)), nil
 }
 
-// sendTools streams the tools tarball to the client.
-func (h *toolsDownloadHandler) sendTools(w http.ResponseWriter, statusCode int, tarball []byte) error {
-	w.Header().Set("Content-Type", "application/x-tar-gz")
+// sendTools streams the tools tarball, or tools delta, to the client.
+func (h *toolsDownloadHandler) sendTools(w http.ResponseWriter, statusCode int, contentType string, tarball []byte) error {
+	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Content-Length", fmt.Sprint(len(tarball)))
 	w.WriteHeader(statusCode)
 	if _, err := w.Write(tarball); err != nil {
@@ -37,6 +37,7 @@ import (
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/bindelta"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -425,6 +426,61 @@ func (s *toolsSuite) TestDownloadTopLevelPath(c *gc.C) {
 	s.testDownload(c, tools, "")
 }
 
+func (s *toolsSuite) storeToolsTarball(c *gc.C, vers string, files ...*testing.TarFile) *coretools.Tools {
+	data, checksum := testing.TarGz(files...)
+	return s.storeFakeTools(c, s.State, string(data), binarystorage.Metadata{
+		Version: vers,
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	})
+}
+
+func (s *toolsSuite) deltaRequest(c *gc.C, vers, base version.Binary) *http.Response {
+	url := s.toolsURL(c, "delta-from="+base.String())
+	url.Path = fmt.Sprintf("/model/%s/tools/%s", s.State.ModelUUID(), vers)
+	return s.sendRequest(c, httpRequestParams{method: "GET", url: url.String()})
+}
+
+func (s *toolsSuite) TestDownloadDelta(c *gc.C) {
+	jujud := strings.Repeat("jujud contents ", 1000)
+	base := s.storeToolsTarball(c, "2.2.0-trusty-amd64",
+		testing.NewTarFile("jujud", 0755, jujud),
+	)
+	tools := s.storeToolsTarball(c, "2.2.1-trusty-amd64",
+		testing.NewTarFile("jujud", 0755, jujud+"more"),
+	)
+
+	resp := s.deltaRequest(c, tools.Version, base.Version)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, bindelta.ContentType)
+
+	baseDir := c.MkDir()
+	err := ioutil.WriteFile(path.Join(baseDir, "jujud"), []byte(jujud), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	targetDir := c.MkDir()
+	err = bindelta.ApplyToolsDelta(resp.Body, baseDir, targetDir)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(path.Join(targetDir, "jujud"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, jujud+"more")
+}
+
+func (s *toolsSuite) TestDownloadDeltaUnknownBase(c *gc.C) {
+	tools := s.storeToolsTarball(c, "2.2.1-trusty-amd64",
+		testing.NewTarFile("jujud", 0755, "jujud contents"),
+	)
+
+	// The whole tarball is sent if there is no delta.
+	resp := s.deltaRequest(c, tools.Version, version.MustParseBinary("2.2.0-trusty-amd64"))
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, "application/x-tar-gz")
+	data, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.HasLen, int(tools.Size))
+}
+
 func (s *toolsSuite) TestDownloadFetchesAndCaches(c *gc.C) {
 	// The tools are not in binarystorage, so the download request causes
 	// the API server to search for the tools in simplestreams, fetch
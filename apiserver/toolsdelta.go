@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/tools/bindelta"
+)
+
+// maxCachedToolsDeltas is the number of tools deltas kept by the
+// tools download handler. Agents are usually upgraded to the same
+// version from only a few versions, so few are needed.
+const maxCachedToolsDeltas = 4
+
+// toolsDeltaCache holds tools deltas, so that the delta for an upgrade
+// is computed only once however many agents download it. Deltas are
+// keyed by the hashes of the tarballs they are computed from, so they
+// may be shared between models.
+type toolsDeltaCache struct {
+	max int
+
+	mu      sync.Mutex
+	entries map[toolsDeltaKey]*toolsDelta
+	order   []toolsDeltaKey
+}
+
+type toolsDeltaKey struct {
+	baseSHA256   string
+	targetSHA256 string
+}
+
+type toolsDelta struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+func newToolsDeltaCache(max int) *toolsDeltaCache {
+	return &toolsDeltaCache{
+		max:     max,
+		entries: make(map[toolsDeltaKey]*toolsDelta),
+	}
+}
+
+// get returns the delta from the base tools to the target tools, both
+// of which are read from the given storage. Concurrent requests for the
+// same delta wait for it to be computed once.
+func (c *toolsDeltaCache) get(storage binarystorage.Storage, base, target binarystorage.Metadata) ([]byte, error) {
+	key := toolsDeltaKey{base.SHA256, target.SHA256}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &toolsDelta{done: make(chan struct{})}
+		c.entries[key] = entry
+		c.order = append(c.order, key)
+		if len(c.order) > c.max {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		entry.data, entry.err = makeToolsDelta(storage, base.Version, target.Version)
+		close(entry.done)
+		if entry.err != nil {
+			c.mu.Lock()
+			if c.entries[key] == entry {
+				delete(c.entries, key)
+				for i, k := range c.order {
+					if k == key {
+						c.order = append(c.order[:i], c.order[i+1:]...)
+						break
+					}
+				}
+			}
+			c.mu.Unlock()
+		}
+	}
+	<-entry.done
+	return entry.data, entry.err
+}
+
+func makeToolsDelta(storage binarystorage.Storage, base, target string) ([]byte, error) {
+	_, baseReader, err := storage.Open(base)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open base tools")
+	}
+	defer baseReader.Close()
+	_, targetReader, err := storage.Open(target)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open target tools")
+	}
+	defer targetReader.Close()
+	var buf bytes.Buffer
+	if err := bindelta.MakeToolsDelta(&buf, baseReader, targetReader); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,440 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/featureflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/state"
+)
+
+// tunnelAcceptTimeout is how long a client waits for a machine agent
+// to accept a tunnel request made over its reverse channel.
+var tunnelAcceptTimeout = 30 * time.Second
+
+// The endpoints in this file support models whose agent-connection-mode
+// is "reverse", in which the controller cannot connect to machines, for
+// example to proxy SSH connections. Instead, each machine agent keeps a
+// reverse channel open to the API server it is connected to. When a
+// client asks for a tunnel to a machine the request is passed down the
+// channel, and the agent opens a new connection to accept it, which is
+// then relayed to the client.
+//
+// Reverse channels are known only to the API server the agent is
+// connected to, so in HA controllers a client may need to retry
+// against the other API servers.
+
+// tunnelRegistry records the reverse channels of the machine agents
+// connected to the API server, and the tunnel requests made over them
+// that are waiting to be accepted.
+type tunnelRegistry struct {
+	mu       sync.Mutex
+	channels map[string]chan params.TunnelRequest
+	pending  map[string]*pendingTunnel
+}
+
+// pendingTunnel is a tunnel request waiting to be accepted.
+type pendingTunnel struct {
+	machineKey string
+	accepted   chan tunnelAccept
+}
+
+// tunnelAccept is a machine agent's answer to a tunnel request: either
+// the connection to relay to the client, or the reason that the agent
+// could not accept the request. The agent's connection is held open
+// until done is closed.
+type tunnelAccept struct {
+	conn *websocket.Conn
+	err  error
+	done chan struct{}
+}
+
+func newTunnelRegistry() *tunnelRegistry {
+	return &tunnelRegistry{
+		channels: make(map[string]chan params.TunnelRequest),
+		pending:  make(map[string]*pendingTunnel),
+	}
+}
+
+// tunnelMachineKey returns the key of a machine's reverse channel.
+func tunnelMachineKey(modelUUID, machineId string) string {
+	return modelUUID + ":" + machineId
+}
+
+// addChannel registers a reverse channel for the machine with the
+// given key, replacing any existing one, and returns the channel on
+// which tunnel requests for the machine are delivered, along with a
+// function that removes it again.
+func (r *tunnelRegistry) addChannel(key string) (<-chan params.TunnelRequest, func()) {
+	requests := make(chan params.TunnelRequest)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.channels[key] = requests
+	return requests, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		// The agent may have reconnected in the meantime.
+		if r.channels[key] == requests {
+			delete(r.channels, key)
+		}
+	}
+}
+
+// request asks the machine over its reverse channel to open a tunnel,
+// and waits for it to accept.
+func (r *tunnelRegistry) request(key, machineId string, timeout time.Duration, stop <-chan struct{}) (tunnelAccept, error) {
+	r.mu.Lock()
+	requests, ok := r.channels[key]
+	r.mu.Unlock()
+	if !ok {
+		return tunnelAccept{}, errors.NotFoundf("reverse channel for machine %s", machineId)
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return tunnelAccept{}, errors.Trace(err)
+	}
+	id := uuid.String()
+	pending := &pendingTunnel{
+		machineKey: key,
+		accepted:   make(chan tunnelAccept, 1),
+	}
+	r.mu.Lock()
+	r.pending[id] = pending
+	r.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case requests <- params.TunnelRequest{ID: id}:
+	case <-timer.C:
+		r.cancel(id, pending)
+		return tunnelAccept{}, errors.Errorf("machine %s did not receive tunnel request", machineId)
+	case <-stop:
+		r.cancel(id, pending)
+		return tunnelAccept{}, errors.New("server stopping")
+	}
+	select {
+	case accept := <-pending.accepted:
+		if accept.err != nil {
+			close(accept.done)
+			return tunnelAccept{}, errors.Annotatef(accept.err, "machine %s refused tunnel", machineId)
+		}
+		return accept, nil
+	case <-timer.C:
+		r.cancel(id, pending)
+		return tunnelAccept{}, errors.Errorf("machine %s did not accept tunnel request", machineId)
+	case <-stop:
+		r.cancel(id, pending)
+		return tunnelAccept{}, errors.New("server stopping")
+	}
+}
+
+// cancel forgets the pending tunnel request with the given id, releasing
+// any connection that the machine agent delivered in the meantime.
+func (r *tunnelRegistry) cancel(id string, pending *pendingTunnel) {
+	r.mu.Lock()
+	delete(r.pending, id)
+	r.mu.Unlock()
+	select {
+	case accept := <-pending.accepted:
+		close(accept.done)
+	default:
+	}
+}
+
+// has reports whether there is a pending tunnel request with the given
+// id for the machine with the given key.
+func (r *tunnelRegistry) has(key, id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending, ok := r.pending[id]
+	return ok && pending.machineKey == key
+}
+
+// accept delivers the machine's answer to the pending tunnel request
+// with the given id.
+func (r *tunnelRegistry) accept(key, id string, accept tunnelAccept) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending, ok := r.pending[id]
+	if !ok || pending.machineKey != key {
+		return errors.NotFoundf("tunnel request %q", id)
+	}
+	delete(r.pending, id)
+	pending.accepted <- accept
+	return nil
+}
+
+// tunnelHandlerBase holds what is common to the tunnel handlers.
+type tunnelHandlerBase struct {
+	ctxt httpContext
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *tunnelHandlerBase) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
+
+// authMachine authorizes a request made by a machine agent, checking
+// that the model's agents connect in reverse mode.
+func (h *tunnelHandlerBase) authMachine(req *http.Request) (string, error) {
+	st, releaser, entity, err := h.ctxt.stateForRequestAuthenticatedTag(req, names.MachineTagKind)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer releaser()
+	model, err := st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if cfg.AgentConnectionMode() != config.ReverseAgentConnection {
+		return "", errors.NotSupportedf("reverse channels in %s agent connection mode", cfg.AgentConnectionMode())
+	}
+	return tunnelMachineKey(st.ModelUUID(), entity.Tag().Id()), nil
+}
+
+func newTunnelChannelHandler(h httpContext) http.Handler {
+	return &tunnelChannelHandler{tunnelHandlerBase{ctxt: h}}
+}
+
+// tunnelChannelHandler serves the reverse channels of machine agents,
+// over which tunnel requests are sent as params.TunnelRequest values.
+type tunnelChannelHandler struct {
+	tunnelHandlerBase
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *tunnelChannelHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(socket *websocket.Conn) {
+		defer socket.Close()
+
+		key, err := h.authMachine(req)
+		h.sendError(socket, req, err)
+		if err != nil {
+			return
+		}
+		requests, remove := h.ctxt.srv.tunnels.addChannel(key)
+		defer remove()
+		logger.Debugf("reverse channel for %s opened", key)
+		h.serve(socket, requests)
+		logger.Debugf("reverse channel for %s closed", key)
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// serve writes tunnel requests to the agent until it closes the
+// channel or the server is stopped.
+func (h *tunnelChannelHandler) serve(socket *websocket.Conn, requests <-chan params.TunnelRequest) {
+	// The agent sends nothing over the channel, but reading is needed
+	// to notice when it is closed.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := socket.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	pinger := time.NewTicker(websocket.PingPeriod)
+	defer pinger.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-h.ctxt.stop():
+			return
+		case request := <-requests:
+			if err := socket.WriteJSON(request); err != nil {
+				logger.Debugf("sending tunnel request: %v", err)
+				return
+			}
+		case <-pinger.C:
+			deadline := time.Now().Add(websocket.WriteWait)
+			if err := socket.WriteControl(gorillaws.PingMessage, []byte{}, deadline); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func newTunnelAcceptHandler(h httpContext) http.Handler {
+	return &tunnelAcceptHandler{tunnelHandlerBase{ctxt: h}}
+}
+
+// tunnelAcceptHandler serves the connections made by machine agents to
+// accept, or refuse, tunnel requests sent over their reverse channels.
+type tunnelAcceptHandler struct {
+	tunnelHandlerBase
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *tunnelAcceptHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(socket *websocket.Conn) {
+		defer socket.Close()
+
+		id := req.URL.Query().Get("id")
+		key, err := h.authMachine(req)
+		if err == nil && !h.ctxt.srv.tunnels.has(key, id) {
+			err = errors.NotFoundf("tunnel request %q", id)
+		}
+		h.sendError(socket, req, err)
+		if err != nil {
+			return
+		}
+		accept := tunnelAccept{
+			conn: socket,
+			done: make(chan struct{}),
+		}
+		if reason := req.URL.Query().Get("error"); reason != "" {
+			accept.err = errors.New(reason)
+		}
+		if err := h.ctxt.srv.tunnels.accept(key, id, accept); err != nil {
+			logger.Debugf("accepting tunnel: %v", err)
+			return
+		}
+		// The connection is now relayed to the client; it must remain
+		// open until that is done.
+		select {
+		case <-accept.done:
+		case <-h.ctxt.stop():
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+func newMachineTunnelHandler(h httpContext) http.Handler {
+	return &machineTunnelHandler{
+		tunnelHandlerBase: tunnelHandlerBase{ctxt: h},
+		timeout:           tunnelAcceptTimeout,
+	}
+}
+
+// machineTunnelHandler opens tunnels to machines for model
+// administrators, over the machines' reverse channels. The machine is
+// given by the "entity" query parameter, which may also be the tag of a
+// unit assigned to it. Data is relayed as params.TunnelMessage values.
+type machineTunnelHandler struct {
+	tunnelHandlerBase
+	timeout time.Duration
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *machineTunnelHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(socket *websocket.Conn) {
+		defer socket.Close()
+
+		accept, err := h.connect(req)
+		h.sendError(socket, req, err)
+		if err != nil {
+			return
+		}
+		defer close(accept.done)
+		relayTunnel(socket, accept.conn, h.ctxt.stop())
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// connect authorizes the request and asks the machine for a tunnel.
+func (h *machineTunnelHandler) connect(req *http.Request) (tunnelAccept, error) {
+	st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return tunnelAccept{}, errors.Trace(err)
+	}
+	defer releaser()
+
+	if err := checkCanAdminModel(st, entity.Tag()); err != nil {
+		return tunnelAccept{}, errors.Trace(err)
+	}
+	machineId, err := tunnelTargetMachine(st, req.URL.Query().Get("entity"))
+	if err != nil {
+		return tunnelAccept{}, errors.Trace(err)
+	}
+	key := tunnelMachineKey(st.ModelUUID(), machineId)
+	accept, err := h.ctxt.srv.tunnels.request(key, machineId, h.timeout, h.ctxt.stop())
+	if err != nil {
+		return tunnelAccept{}, errors.Trace(err)
+	}
+	logger.Infof("user %q opened tunnel to machine %s", entity.Tag().Id(), machineId)
+	return accept, nil
+}
+
+// tunnelTargetMachine returns the id of the machine identified by the
+// given machine or unit tag.
+func tunnelTargetMachine(st *state.State, entity string) (string, error) {
+	tag, err := names.ParseTag(entity)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		if _, err := st.Machine(tag.Id()); err != nil {
+			return "", errors.Trace(err)
+		}
+		return tag.Id(), nil
+	case names.UnitTag:
+		unit, err := st.Unit(tag.Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return unit.AssignedMachineId()
+	}
+	return "", errors.NotValidf("tunnel target %q", entity)
+}
+
+// relayTunnel copies websocket messages between the two connections
+// until either side closes its connection or stop is closed.
+func relayTunnel(a, b *websocket.Conn, stop <-chan struct{}) {
+	done := make(chan error, 2)
+	relay := func(from, to *websocket.Conn) {
+		for {
+			messageType, data, err := from.ReadMessage()
+			if err != nil {
+				done <- err
+				return
+			}
+			if err := to.WriteMessage(messageType, data); err != nil {
+				done <- err
+				return
+			}
+		}
+	}
+	go relay(a, b)
+	go relay(b, a)
+
+	select {
+	case err := <-done:
+		logger.Debugf("tunnel closed: %v", err)
+	case <-stop:
+	}
+	// Closing the connections unblocks the relaying goroutines.
+	deadline := time.Now().Add(websocket.WriteWait)
+	for _, conn := range []*websocket.Conn{a, b} {
+		conn.WriteControl(gorillaws.CloseMessage, gorillaws.FormatCloseMessage(gorillaws.CloseNormalClosure, "tunnel closed"), deadline)
+		conn.Close()
+	}
+}
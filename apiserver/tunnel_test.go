@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket/websockettest"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing/factory"
+)
+
+type tunnelSuite struct {
+	authHTTPSuite
+	machineTag      names.Tag
+	machinePassword string
+	nonce           string
+}
+
+var _ = gc.Suite(&tunnelSuite{})
+
+func (s *tunnelSuite) SetUpTest(c *gc.C) {
+	s.authHTTPSuite.SetUpTest(c)
+	s.nonce = "nonce"
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: s.nonce,
+	})
+	s.machineTag = m.Tag()
+	s.machinePassword = password
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		config.AgentConnectionModeKey: config.ReverseAgentConnection,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *tunnelSuite) TestChannelNoAuth(c *gc.C) {
+	conn := s.dialWebsocket(c, "/tunnel", nil, nil)
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "no credentials provided")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *tunnelSuite) TestChannelRejectsUsers(c *gc.C) {
+	conn := s.dialWebsocket(c, "/tunnel", nil, s.adminHeader(c))
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "tag kind user not valid")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *tunnelSuite) TestChannelRequiresReverseMode(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		config.AgentConnectionModeKey: config.DirectAgentConnection,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	conn := s.dialWebsocket(c, "/tunnel", nil, s.machineHeader())
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "reverse channels in direct agent connection mode not supported")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *tunnelSuite) TestAcceptUnknownRequest(c *gc.C) {
+	conn := s.dialWebsocket(c, "/tunnel/accept", url.Values{"id": {"foo"}}, s.machineHeader())
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, `tunnel request "foo" not found`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *tunnelSuite) TestMachineTunnelNoAuth(c *gc.C) {
+	s.assertMachineTunnelError(c, s.machineTag.String(), nil, "no credentials provided")
+}
+
+func (s *tunnelSuite) TestMachineTunnelRequiresAdmin(c *gc.C) {
+	header := utils.BasicAuthHeader(s.userTag.String(), s.password)
+	s.assertMachineTunnelError(c, s.machineTag.String(), header, "permission denied")
+}
+
+func (s *tunnelSuite) TestMachineTunnelInvalidEntity(c *gc.C) {
+	s.assertMachineTunnelError(c, "application-mysql", s.adminHeader(c), `tunnel target "application-mysql" not valid`)
+}
+
+func (s *tunnelSuite) TestMachineTunnelNoChannel(c *gc.C) {
+	s.assertMachineTunnelError(c, s.machineTag.String(), s.adminHeader(c), "reverse channel for machine 0 not found")
+}
+
+func (s *tunnelSuite) TestMachineTunnel(c *gc.C) {
+	channel := s.dialWebsocket(c, "/tunnel", nil, s.machineHeader())
+	defer channel.Close()
+	websockettest.AssertJSONInitialErrorNil(c, channel)
+
+	client := make(chan *websocket.Conn)
+	go func() {
+		client <- s.dialWebsocket(c, "/machine-tunnel", url.Values{"entity": {s.machineTag.String()}}, s.adminHeader(c))
+	}()
+
+	var request params.TunnelRequest
+	err := channel.ReadJSON(&request)
+	c.Assert(err, jc.ErrorIsNil)
+	agent := s.dialWebsocket(c, "/tunnel/accept", url.Values{"id": {request.ID}}, s.machineHeader())
+	defer agent.Close()
+	websockettest.AssertJSONInitialErrorNil(c, agent)
+
+	conn := <-client
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	err = conn.WriteJSON(params.TunnelMessage{Data: []byte("ping")})
+	c.Assert(err, jc.ErrorIsNil)
+	var m params.TunnelMessage
+	err = agent.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(m.Data), gc.Equals, "ping")
+
+	err = agent.WriteJSON(params.TunnelMessage{Data: []byte("pong")})
+	c.Assert(err, jc.ErrorIsNil)
+	err = conn.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(m.Data), gc.Equals, "pong")
+
+	// Closing the agent's side closes the client's.
+	agent.Close()
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *tunnelSuite) TestMachineTunnelRefused(c *gc.C) {
+	channel := s.dialWebsocket(c, "/tunnel", nil, s.machineHeader())
+	defer channel.Close()
+	websockettest.AssertJSONInitialErrorNil(c, channel)
+
+	client := make(chan *websocket.Conn)
+	go func() {
+		client <- s.dialWebsocket(c, "/machine-tunnel", url.Values{"entity": {s.machineTag.String()}}, s.adminHeader(c))
+	}()
+
+	var request params.TunnelRequest
+	err := channel.ReadJSON(&request)
+	c.Assert(err, jc.ErrorIsNil)
+	agent := s.dialWebsocket(c, "/tunnel/accept", url.Values{
+		"id":    {request.ID},
+		"error": {"connection refused"},
+	}, s.machineHeader())
+	defer agent.Close()
+	websockettest.AssertJSONInitialErrorNil(c, agent)
+
+	conn := <-client
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "machine 0 refused tunnel: connection refused")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *tunnelSuite) adminHeader(c *gc.C) http.Header {
+	return utils.BasicAuthHeader(s.AdminUserTag(c).String(), "dummy-secret")
+}
+
+func (s *tunnelSuite) machineHeader() http.Header {
+	header := utils.BasicAuthHeader(s.machineTag.String(), s.machinePassword)
+	header.Add(params.MachineNonceHeader, s.nonce)
+	return header
+}
+
+func (s *tunnelSuite) assertMachineTunnelError(c *gc.C, entity string, header http.Header, message string) {
+	conn := s.dialWebsocket(c, "/machine-tunnel", url.Values{"entity": {entity}}, header)
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, message)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *tunnelSuite) dialWebsocket(c *gc.C, path string, query url.Values, header http.Header) *websocket.Conn {
+	path = fmt.Sprintf("/model/%s%s", s.modelUUID, path)
+	server := s.makeURL(c, "wss", path, query).String()
+	return dialWebsocketFromURL(c, server, header)
+}
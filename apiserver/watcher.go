@@ -304,7 +304,10 @@ func newMachineStorageIdsWatcher(
 	id := context.ID()
 	auth := context.Auth()
 	resources := context.Resources()
-	if !isAgent(auth) {
+	// Clients may watch attachments through the Storage facade; the
+	// watcher resource only exists if the permission check made by
+	// that Watch call passed.
+	if !isAgent(auth) && !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.StringsWatcher)
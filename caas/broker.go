@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/status"
+)
+
+// ContainerEnvironProvider represents a container substrate, such as
+// Kubernetes, that can host the units of applications without
+// requiring machines. It is the CAAS analogue of
+// environs.EnvironProvider.
+type ContainerEnvironProvider interface {
+	// Open opens a broker for the substrate described by the supplied
+	// parameters and returns it.
+	//
+	// Open should not perform any expensive operations, such as
+	// querying the substrate's API, as it will be called frequently.
+	Open(OpenParams) (Broker, error)
+}
+
+// OpenParams contains the parameters for ContainerEnvironProvider.Open.
+type OpenParams struct {
+	// Cloud is the cloud specification to use to connect to the
+	// substrate.
+	Cloud environs.CloudSpec
+
+	// Namespace is the name of the substrate namespace in which all
+	// of the model's resources are created. A model's resources are
+	// isolated from those of other models by using a distinct
+	// namespace for each.
+	Namespace string
+}
+
+// Broker describes the methods for managing the resources of a model
+// hosted by a container substrate. It is the CAAS analogue of
+// environs.Environ: where an Environ starts machines that units are
+// later assigned to, a Broker runs each unit in its own container
+// (for example, a Kubernetes pod).
+type Broker interface {
+	// Provider returns the ContainerEnvironProvider that created
+	// this Broker.
+	Provider() ContainerEnvironProvider
+
+	// Namespace returns the name of the namespace in which this
+	// broker manages resources.
+	Namespace() string
+
+	// EnsureNamespace creates the broker's namespace if it does not
+	// already exist.
+	EnsureNamespace() error
+
+	// Destroy terminates all containers and other resources in the
+	// broker's namespace, and removes the namespace itself.
+	Destroy() error
+
+	// EnsureUnit creates or updates the container for the named unit
+	// of the named application, so that it matches the supplied spec.
+	// Containers that can't be updated in place are replaced.
+	EnsureUnit(appName, unitName string, spec *ContainerSpec) error
+
+	// DeleteUnit deletes the container for the named unit. It is not
+	// an error to delete a unit that has no container.
+	DeleteUnit(unitName string) error
+
+	// Units returns the units of the named application that have
+	// containers in the substrate.
+	Units(appName string) ([]Unit, error)
+}
+
+// ContainerSpec describes the container that a unit runs in.
+type ContainerSpec struct {
+	// ImageName is the name of the image to run the container from.
+	ImageName string
+
+	// Ports holds the ports that the container exposes.
+	Ports []ContainerPort
+
+	// Config holds environment variables to set in the container.
+	Config map[string]string
+}
+
+// ContainerPort describes a port exposed by a container.
+type ContainerPort struct {
+	Name          string
+	ContainerPort int
+	Protocol      string
+}
+
+// Unit describes the container hosting a unit, as reported by a
+// Broker.
+type Unit struct {
+	// Id is the substrate's identifier for the container.
+	Id string
+
+	// UnitName is the name of the unit that the container hosts.
+	UnitName string
+
+	// Address is the address of the container, if it has one.
+	Address string
+
+	// Ports holds the ports exposed by the container, in the
+	// form "<port>/<protocol>".
+	Ports []string
+
+	// Status is the status of the container.
+	Status status.StatusInfo
+}
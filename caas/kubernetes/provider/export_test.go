@@ -0,0 +1,20 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas"
+)
+
+var (
+	PodName  = podName
+	UnitName = unitName
+)
+
+func NewProvider(newClient func(*rest.Config) (kubernetes.Interface, error)) caas.ContainerEnvironProvider {
+	return &kubernetesEnvironProvider{newClient: newClient}
+}
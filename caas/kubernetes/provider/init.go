@@ -0,0 +1,17 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/juju/caas"
+)
+
+const (
+	// providerType is the name of the Kubernetes container provider.
+	providerType = "kubernetes"
+)
+
+func init() {
+	caas.RegisterContainerProvider(providerType, providerInstance)
+}
@@ -0,0 +1,252 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"k8s.io/client-go/kubernetes"
+	k8serrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/status"
+)
+
+var logger = loggo.GetLogger("juju.kubernetes.provider")
+
+const (
+	// labelApplication is the label applied to every pod created for
+	// a unit, recording the name of the unit's application.
+	labelApplication = "juju-application"
+
+	// labelUnit is the label applied to every pod created for a unit,
+	// recording the unit's name.
+	labelUnit = "juju-unit"
+)
+
+type kubernetesClient struct {
+	kubernetes.Interface
+
+	provider  *kubernetesEnvironProvider
+	namespace string
+}
+
+var _ caas.Broker = (*kubernetesClient)(nil)
+
+func newKubernetesBroker(
+	provider *kubernetesEnvironProvider,
+	client kubernetes.Interface,
+	namespace string,
+) *kubernetesClient {
+	return &kubernetesClient{
+		Interface: client,
+		provider:  provider,
+		namespace: namespace,
+	}
+}
+
+// Provider is part of the Broker interface.
+func (k *kubernetesClient) Provider() caas.ContainerEnvironProvider {
+	return k.provider
+}
+
+// Namespace is part of the Broker interface.
+func (k *kubernetesClient) Namespace() string {
+	return k.namespace
+}
+
+// EnsureNamespace is part of the Broker interface.
+func (k *kubernetesClient) EnsureNamespace() error {
+	ns := &v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: k.namespace}}
+	_, err := k.CoreV1().Namespaces().Create(ns)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "creating namespace %q", k.namespace)
+}
+
+// Destroy is part of the Broker interface.
+func (k *kubernetesClient) Destroy() error {
+	err := k.CoreV1().Namespaces().Delete(k.namespace, &v1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "deleting namespace %q", k.namespace)
+}
+
+// EnsureUnit is part of the Broker interface.
+func (k *kubernetesClient) EnsureUnit(appName, unitName string, spec *caas.ContainerSpec) error {
+	if spec == nil {
+		return errors.NotValidf("missing container spec")
+	}
+	if spec.ImageName == "" {
+		return errors.NotValidf("container spec with empty image name")
+	}
+	pod := &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: podName(unitName),
+			Labels: map[string]string{
+				labelApplication: appName,
+				labelUnit:        podName(unitName),
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{makeContainer(appName, spec)},
+		},
+	}
+	pods := k.CoreV1().Pods(k.namespace)
+	existing, err := pods.Get(pod.Name)
+	if k8serrors.IsNotFound(err) {
+		logger.Debugf("creating pod %q for unit %q", pod.Name, unitName)
+		_, err = pods.Create(pod)
+		return errors.Annotatef(err, "creating pod for unit %q", unitName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Pod specs are immutable, other than the containers' images, so
+	// the pod is replaced if anything else in the spec has changed.
+	if !sameContainers(existing.Spec.Containers, pod.Spec.Containers) {
+		logger.Debugf("replacing pod %q for unit %q", pod.Name, unitName)
+		err := pods.Delete(pod.Name, &v1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting pod for unit %q", unitName)
+		}
+		_, err = pods.Create(pod)
+		return errors.Annotatef(err, "creating pod for unit %q", unitName)
+	}
+	for i, container := range pod.Spec.Containers {
+		existing.Spec.Containers[i].Image = container.Image
+	}
+	logger.Debugf("updating pod %q for unit %q", pod.Name, unitName)
+	_, err = pods.Update(existing)
+	return errors.Annotatef(err, "updating pod for unit %q", unitName)
+}
+
+// sameContainers reports whether the existing containers of a pod
+// differ from the wanted ones only in their images.
+func sameContainers(existing, wanted []v1.Container) bool {
+	if len(existing) != len(wanted) {
+		return false
+	}
+	for i, c := range existing {
+		w := wanted[i]
+		if c.Name != w.Name || len(c.Ports) != len(w.Ports) || len(c.Env) != len(w.Env) {
+			return false
+		}
+		for j, p := range c.Ports {
+			if p.Name != w.Ports[j].Name ||
+				p.ContainerPort != w.Ports[j].ContainerPort ||
+				p.Protocol != w.Ports[j].Protocol {
+				return false
+			}
+		}
+		for j, e := range c.Env {
+			if e.Name != w.Env[j].Name || e.Value != w.Env[j].Value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DeleteUnit is part of the Broker interface.
+func (k *kubernetesClient) DeleteUnit(unitName string) error {
+	err := k.CoreV1().Pods(k.namespace).Delete(podName(unitName), &v1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "deleting pod for unit %q", unitName)
+}
+
+// Units is part of the Broker interface.
+func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
+	pods, err := k.CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector: labelApplication + "=" + appName,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var units []caas.Unit
+	for _, p := range pods.Items {
+		var ports []string
+		for _, c := range p.Spec.Containers {
+			for _, cp := range c.Ports {
+				ports = append(ports, fmt.Sprintf("%d/%s", cp.ContainerPort, strings.ToLower(string(cp.Protocol))))
+			}
+		}
+		units = append(units, caas.Unit{
+			Id:       string(p.UID),
+			UnitName: unitName(p.Labels[labelUnit]),
+			Address:  p.Status.PodIP,
+			Ports:    ports,
+			Status:   podStatus(p.Status),
+		})
+	}
+	return units, nil
+}
+
+func makeContainer(name string, spec *caas.ContainerSpec) v1.Container {
+	container := v1.Container{
+		Name:  name,
+		Image: spec.ImageName,
+	}
+	for _, p := range spec.Ports {
+		container.Ports = append(container.Ports, v1.ContainerPort{
+			Name:          p.Name,
+			ContainerPort: int32(p.ContainerPort),
+			Protocol:      v1.Protocol(strings.ToUpper(p.Protocol)),
+		})
+	}
+	for name, value := range spec.Config {
+		container.Env = append(container.Env, v1.EnvVar{Name: name, Value: value})
+	}
+	// Sort the environment so that the containers of unchanged specs
+	// compare equal.
+	sort.Slice(container.Env, func(i, j int) bool {
+		return container.Env[i].Name < container.Env[j].Name
+	})
+	return container
+}
+
+// podStatus converts the phase of a pod into a juju status.
+func podStatus(podStatus v1.PodStatus) status.StatusInfo {
+	var jujuStatus status.Status
+	switch podStatus.Phase {
+	case v1.PodRunning:
+		jujuStatus = status.Running
+	case v1.PodPending:
+		jujuStatus = status.Allocating
+	case v1.PodFailed:
+		jujuStatus = status.Error
+	case v1.PodSucceeded:
+		jujuStatus = status.Terminated
+	default:
+		jujuStatus = status.Unknown
+	}
+	return status.StatusInfo{
+		Status:  jujuStatus,
+		Message: podStatus.Message,
+	}
+}
+
+// podName returns the name of the pod hosting the unit. Pod names
+// may not contain "/", so it is replaced with "-".
+func podName(unitName string) string {
+	return "juju-" + strings.Replace(unitName, "/", "-", -1)
+}
+
+// unitName returns the name of the unit hosted by the named pod.
+func unitName(podName string) string {
+	name := strings.TrimPrefix(podName, "juju-")
+	if i := strings.LastIndex(name, "-"); i >= 0 {
+		name = name[:i] + "/" + name[i+1:]
+	}
+	return name
+}
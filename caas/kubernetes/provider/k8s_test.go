@@ -0,0 +1,236 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type K8sSuite struct {
+	testing.BaseSuite
+
+	clientset *fake.Clientset
+	config    *rest.Config
+	broker    caas.Broker
+}
+
+var _ = gc.Suite(&K8sSuite{})
+
+func (s *K8sSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clientset = fake.NewSimpleClientset()
+	s.config = nil
+	p := provider.NewProvider(func(config *rest.Config) (kubernetes.Interface, error) {
+		s.config = config
+		return s.clientset, nil
+	})
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"Username": "fred",
+		"Password": "secret",
+	})
+	broker, err := p.Open(caas.OpenParams{
+		Cloud: environs.CloudSpec{
+			Type:       "kubernetes",
+			Name:       "k8s",
+			Endpoint:   "https://1.2.3.4:8443",
+			Credential: &cred,
+		},
+		Namespace: "test",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.broker = broker
+}
+
+func (s *K8sSuite) TestOpen(c *gc.C) {
+	c.Assert(s.broker.Namespace(), gc.Equals, "test")
+	c.Assert(s.config.Host, gc.Equals, "https://1.2.3.4:8443")
+	c.Assert(s.config.Username, gc.Equals, "fred")
+	c.Assert(s.config.Password, gc.Equals, "secret")
+}
+
+func (s *K8sSuite) TestOpenMissingCredential(c *gc.C) {
+	p := provider.NewProvider(func(*rest.Config) (kubernetes.Interface, error) {
+		c.Fatalf("unexpected call")
+		return nil, nil
+	})
+	_, err := p.Open(caas.OpenParams{
+		Cloud: environs.CloudSpec{
+			Type:     "kubernetes",
+			Name:     "k8s",
+			Endpoint: "https://1.2.3.4:8443",
+		},
+		Namespace: "test",
+	})
+	c.Assert(err, gc.ErrorMatches, "validating cloud spec: missing credential not valid")
+}
+
+func (s *K8sSuite) TestEnsureNamespaceIdempotent(c *gc.C) {
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ns, err := s.clientset.CoreV1().Namespaces().Get("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ns.Name, gc.Equals, "test")
+}
+
+func (s *K8sSuite) TestDestroy(c *gc.C) {
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	// Destroying again is not an error.
+	err = s.broker.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sSuite) TestEnsureUnit(c *gc.C) {
+	err := s.broker.EnsureUnit("gitlab", "gitlab/0", &caas.ContainerSpec{
+		ImageName: "gitlab/latest",
+		Ports: []caas.ContainerPort{
+			{Name: "http", ContainerPort: 80, Protocol: "tcp"},
+		},
+		Config: map[string]string{"foo": "bar"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pod, err := s.clientset.CoreV1().Pods("test").Get("juju-gitlab-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Labels, jc.DeepEquals, map[string]string{
+		"juju-application": "gitlab",
+		"juju-unit":        "juju-gitlab-0",
+	})
+	c.Assert(pod.Spec.Containers, jc.DeepEquals, []v1.Container{{
+		Name:  "gitlab",
+		Image: "gitlab/latest",
+		Ports: []v1.ContainerPort{
+			{Name: "http", ContainerPort: 80, Protocol: v1.ProtocolTCP},
+		},
+		Env: []v1.EnvVar{{Name: "foo", Value: "bar"}},
+	}})
+
+}
+
+func (s *K8sSuite) actionVerbs() []string {
+	var verbs []string
+	for _, action := range s.clientset.Actions() {
+		verbs = append(verbs, action.GetVerb())
+	}
+	return verbs
+}
+
+func (s *K8sSuite) TestEnsureUnitUpdatesImage(c *gc.C) {
+	spec := &caas.ContainerSpec{
+		ImageName: "gitlab/latest",
+		Ports: []caas.ContainerPort{
+			{Name: "http", ContainerPort: 80, Protocol: "tcp"},
+		},
+		Config: map[string]string{"foo": "bar", "baz": "qux"},
+	}
+	err := s.broker.EnsureUnit("gitlab", "gitlab/0", spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the image has changed, so the pod is updated in place.
+	s.clientset.ClearActions()
+	spec.ImageName = "gitlab/next"
+	err = s.broker.EnsureUnit("gitlab", "gitlab/0", spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.actionVerbs(), jc.DeepEquals, []string{"get", "update"})
+
+	pod, err := s.clientset.CoreV1().Pods("test").Get("juju-gitlab-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Spec.Containers, gc.HasLen, 1)
+	c.Assert(pod.Spec.Containers[0].Image, gc.Equals, "gitlab/next")
+}
+
+func (s *K8sSuite) TestEnsureUnitReplacesPod(c *gc.C) {
+	err := s.broker.EnsureUnit("gitlab", "gitlab/0", &caas.ContainerSpec{
+		ImageName: "gitlab/latest",
+		Ports: []caas.ContainerPort{
+			{Name: "http", ContainerPort: 80, Protocol: "tcp"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The ports and config can't be changed in place, so the pod
+	// is replaced with one with the new spec.
+	s.clientset.ClearActions()
+	err = s.broker.EnsureUnit("gitlab", "gitlab/0", &caas.ContainerSpec{
+		ImageName: "gitlab/next",
+		Ports: []caas.ContainerPort{
+			{Name: "https", ContainerPort: 443, Protocol: "tcp"},
+		},
+		Config: map[string]string{"foo": "bar"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.actionVerbs(), jc.DeepEquals, []string{"get", "delete", "create"})
+
+	pod, err := s.clientset.CoreV1().Pods("test").Get("juju-gitlab-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Spec.Containers, jc.DeepEquals, []v1.Container{{
+		Name:  "gitlab",
+		Image: "gitlab/next",
+		Ports: []v1.ContainerPort{
+			{Name: "https", ContainerPort: 443, Protocol: v1.ProtocolTCP},
+		},
+		Env: []v1.EnvVar{{Name: "foo", Value: "bar"}},
+	}})
+}
+
+func (s *K8sSuite) TestEnsureUnitNoImage(c *gc.C) {
+	err := s.broker.EnsureUnit("gitlab", "gitlab/0", &caas.ContainerSpec{})
+	c.Assert(err, gc.ErrorMatches, "container spec with empty image name not valid")
+}
+
+func (s *K8sSuite) TestUnits(c *gc.C) {
+	err := s.broker.EnsureUnit("gitlab", "gitlab/0", &caas.ContainerSpec{
+		ImageName: "gitlab/latest",
+		Ports: []caas.ContainerPort{
+			{Name: "http", ContainerPort: 80, Protocol: "tcp"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].UnitName, gc.Equals, "gitlab/0")
+	c.Assert(units[0].Ports, jc.DeepEquals, []string{"80/tcp"})
+	c.Assert(units[0].Status.Status, gc.Equals, status.Unknown)
+}
+
+func (s *K8sSuite) TestDeleteUnit(c *gc.C) {
+	err := s.broker.EnsureUnit("gitlab", "gitlab/0", &caas.ContainerSpec{
+		ImageName: "gitlab/latest",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.DeleteUnit("gitlab/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+
+	// Deleting a unit with no pod is not an error.
+	err = s.broker.DeleteUnit("gitlab/0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sSuite) TestPodNames(c *gc.C) {
+	c.Assert(provider.PodName("mysql-k8s/10"), gc.Equals, "juju-mysql-k8s-10")
+	c.Assert(provider.UnitName("juju-mysql-k8s-10"), gc.Equals, "mysql-k8s/10")
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type kubernetesEnvironProvider struct {
+	// newClient is used to create the Kubernetes client from the
+	// REST configuration. It is overridden in tests.
+	newClient func(*rest.Config) (kubernetes.Interface, error)
+}
+
+var _ caas.ContainerEnvironProvider = (*kubernetesEnvironProvider)(nil)
+
+var providerInstance = &kubernetesEnvironProvider{
+	newClient: newK8sClient,
+}
+
+func newK8sClient(config *rest.Config) (kubernetes.Interface, error) {
+	return kubernetes.NewForConfig(config)
+}
+
+// Open is specified in the ContainerEnvironProvider interface.
+func (p *kubernetesEnvironProvider) Open(args caas.OpenParams) (caas.Broker, error) {
+	logger.Debugf("opening model %q.", args.Namespace)
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	client, err := p.newClient(newRestConfig(args.Cloud))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newKubernetesBroker(p, client, args.Namespace), nil
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Endpoint == "" {
+		return errors.NotValidf("missing endpoint")
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	switch authType := spec.Credential.AuthType(); authType {
+	case cloud.UserPassAuthType,
+		cloud.UserPassWithCertAuthType,
+		cloud.OAuth2AuthType,
+		cloud.OAuth2WithCertAuthType,
+		cloud.CertificateAuthType:
+	default:
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
+
+// newRestConfig returns the Kubernetes REST client configuration for
+// the cloud spec. The credential attributes match those read from the
+// Kubernetes client configuration by add-k8s.
+func newRestConfig(spec environs.CloudSpec) *rest.Config {
+	attrs := spec.Credential.Attributes()
+	return &rest.Config{
+		Host:        spec.Endpoint,
+		Username:    attrs["Username"],
+		Password:    attrs["Password"],
+		BearerToken: attrs["Token"],
+		TLSClientConfig: rest.TLSClientConfig{
+			CertData: []byte(attrs["ClientCertificateData"]),
+			KeyData:  []byte(attrs["ClientKeyData"]),
+		},
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// providers maps from provider type to ContainerEnvironProvider for
+// each registered provider type.
+var providers = map[string]ContainerEnvironProvider{}
+
+// RegisterContainerProvider registers a new container substrate
+// provider. Name gives the name of the provider, and p the interface
+// to that provider.
+//
+// RegisterContainerProvider will panic if the provider name is
+// registered more than once. The returned function can be used to
+// unregister the provider and is used by tests.
+func RegisterContainerProvider(name string, p ContainerEnvironProvider) (unregister func()) {
+	if providers[name] != nil {
+		panic(fmt.Errorf("juju: duplicate container provider name %q", name))
+	}
+	providers[name] = p
+	return func() {
+		delete(providers, name)
+	}
+}
+
+// RegisteredProviders returns the names of the registered container
+// substrate providers.
+func RegisteredProviders() []string {
+	var names []string
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Provider returns the container substrate provider with the
+// specified name.
+func Provider(providerType string) (ContainerEnvironProvider, error) {
+	p, ok := providers[providerType]
+	if !ok {
+		return nil, errors.NewNotFound(
+			nil, fmt.Sprintf("no registered container provider for %q", providerType),
+		)
+	}
+	return p, nil
+}
+
+// New returns a new broker based on the provided parameters.
+func New(args OpenParams) (Broker, error) {
+	if args.Namespace == "" {
+		return nil, errors.NotValidf("empty namespace")
+	}
+	p, err := Provider(args.Cloud.Type)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return p.Open(args)
+}
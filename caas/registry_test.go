@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/testing"
+)
+
+type RegistrySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+type mockProvider struct {
+	params caas.OpenParams
+}
+
+func (p *mockProvider) Open(args caas.OpenParams) (caas.Broker, error) {
+	p.params = args
+	return nil, errors.New("open called")
+}
+
+func (s *RegistrySuite) TestRegisterProvider(c *gc.C) {
+	unregister := caas.RegisterContainerProvider("mock", &mockProvider{})
+	c.Assert(caas.RegisteredProviders(), jc.Contains, "mock")
+	unregister()
+	_, err := caas.Provider("mock")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RegistrySuite) TestRegisterProviderDuplicate(c *gc.C) {
+	unregister := caas.RegisterContainerProvider("mock", &mockProvider{})
+	defer unregister()
+	c.Assert(func() {
+		caas.RegisterContainerProvider("mock", &mockProvider{})
+	}, gc.PanicMatches, `juju: duplicate container provider name "mock"`)
+}
+
+func (s *RegistrySuite) TestNew(c *gc.C) {
+	p := &mockProvider{}
+	unregister := caas.RegisterContainerProvider("mock", p)
+	defer unregister()
+
+	args := caas.OpenParams{
+		Cloud:     environs.CloudSpec{Type: "mock", Name: "k8s"},
+		Namespace: "foo",
+	}
+	_, err := caas.New(args)
+	c.Assert(err, gc.ErrorMatches, "open called")
+	c.Assert(p.params, jc.DeepEquals, args)
+}
+
+func (s *RegistrySuite) TestNewUnknownProvider(c *gc.C) {
+	_, err := caas.New(caas.OpenParams{
+		Cloud:     environs.CloudSpec{Type: "unknown"},
+		Namespace: "foo",
+	})
+	c.Assert(err, gc.ErrorMatches, `no registered container provider for "unknown"`)
+}
+
+func (s *RegistrySuite) TestNewEmptyNamespace(c *gc.C) {
+	_, err := caas.New(caas.OpenParams{
+		Cloud: environs.CloudSpec{Type: "mock"},
+	})
+	c.Assert(err, gc.ErrorMatches, "empty namespace not valid")
+}
@@ -0,0 +1,369 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// ArchiveSource opens charm archives for download.
+type ArchiveSource interface {
+	// OpenArchive opens the archive of the charm with the given URL,
+	// reading from the given offset. Sources that cannot start part
+	// way through an archive return the whole archive, with a start
+	// of zero.
+	OpenArchive(curl *charm.URL, offset int64) (io.ReadCloser, ArchiveInfo, error)
+}
+
+// ArchiveInfo holds details of an archive opened by an ArchiveSource.
+type ArchiveInfo struct {
+	// Start is the offset in the archive from which it is read.
+	Start int64
+
+	// SHA384 is the hex-encoded SHA384 hash of the whole archive,
+	// or empty if it is not known.
+	SHA384 string
+}
+
+// DownloadRequest holds the details of a charm archive to download.
+type DownloadRequest struct {
+	// URL is the URL of the charm, which must include a revision.
+	URL *charm.URL
+
+	// Auth identifies the store, and the authorization, with which
+	// the charm is downloaded. Archives are only shared between
+	// requests with the same Auth, so that a charm downloaded with
+	// one user's authorization is never given to another.
+	Auth string
+
+	// Source is where the archive is downloaded from.
+	Source ArchiveSource
+}
+
+// DownloaderConfig holds the configuration for a Downloader.
+type DownloaderConfig struct {
+	// Dir is the directory in which archives are kept.
+	Dir string
+
+	// MaxConcurrent is the maximum number of archives downloaded
+	// at once.
+	MaxConcurrent int
+
+	// MaxCached is the maximum number of downloaded archives kept
+	// for later requests.
+	MaxCached int
+
+	// Attempts is the number of times the transfer of an archive is
+	// attempted before giving up. Each attempt resumes from where
+	// the previous one failed.
+	Attempts int
+
+	// RetryDelay is the time waited between attempts.
+	RetryDelay time.Duration
+
+	// Clock is used to wait between attempts.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config is not valid.
+func (config DownloaderConfig) Validate() error {
+	if config.Dir == "" {
+		return errors.NotValidf("empty Dir")
+	}
+	if config.MaxConcurrent <= 0 {
+		return errors.NotValidf("MaxConcurrent %d", config.MaxConcurrent)
+	}
+	if config.MaxCached < 0 {
+		return errors.NotValidf("MaxCached %d", config.MaxCached)
+	}
+	if config.Attempts <= 0 {
+		return errors.NotValidf("Attempts %d", config.Attempts)
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Downloader downloads charm archives, with a limit on the number of
+// concurrent transfers. Concurrent requests for the same archive share
+// a single transfer, and downloaded archives are kept so that later
+// requests need not transfer them again. Failed transfers are resumed
+// from where they stopped.
+type Downloader struct {
+	config DownloaderConfig
+	slots  chan struct{}
+
+	mu        sync.Mutex
+	downloads map[string]*download
+	used      int64
+}
+
+// download is a charm archive that has been, or is being, downloaded.
+type download struct {
+	path string
+	done chan struct{}
+	err  error
+
+	// refs is the number of open Archives for the download, which
+	// is not removed while any remain.
+	refs int
+
+	// lastUsed orders downloads for removal from the cache.
+	lastUsed int64
+}
+
+// NewDownloader returns a new Downloader with the given configuration.
+func NewDownloader(config DownloaderConfig) (*Downloader, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := os.MkdirAll(config.Dir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Archives left by an earlier downloader are not accounted for in
+	// the cache, so they are removed. Partial archives are kept for
+	// their transfers to be resumed.
+	leftover, err := filepath.Glob(filepath.Join(config.Dir, "*.charm"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, path := range leftover {
+		if err := os.Remove(path); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &Downloader{
+		config:    config,
+		slots:     make(chan struct{}, config.MaxConcurrent),
+		downloads: make(map[string]*download),
+	}, nil
+}
+
+// Archive is a downloaded charm archive.
+type Archive struct {
+	// Path is the path of the archive file, which remains valid
+	// until the Archive is closed.
+	Path string
+
+	d    *Downloader
+	key  string
+	once sync.Once
+}
+
+// Close releases the archive, allowing the downloader to remove it.
+func (a *Archive) Close() error {
+	a.once.Do(func() {
+		a.d.release(a.key)
+	})
+	return nil
+}
+
+// Download returns the archive of the requested charm, downloading it
+// if it has not been already. The returned Archive must be closed when
+// it is no longer needed.
+func (d *Downloader) Download(req DownloadRequest) (*Archive, error) {
+	if req.URL == nil || req.URL.Revision < 0 {
+		return nil, errors.NotValidf("charm URL without revision")
+	}
+	key := downloadKey(req)
+
+	d.mu.Lock()
+	dl, ok := d.downloads[key]
+	if !ok {
+		dl = &download{
+			path: filepath.Join(d.config.Dir, key+".charm"),
+			done: make(chan struct{}),
+		}
+		d.downloads[key] = dl
+		go d.run(dl, req)
+	}
+	dl.refs++
+	d.used++
+	dl.lastUsed = d.used
+	d.mu.Unlock()
+
+	<-dl.done
+	if dl.err != nil {
+		d.mu.Lock()
+		dl.refs--
+		// The failed download is forgotten, so that it is tried again
+		// by the next request. Its partial archive is kept for the
+		// next attempt to resume from.
+		if d.downloads[key] == dl {
+			delete(d.downloads, key)
+		}
+		d.mu.Unlock()
+		return nil, dl.err
+	}
+	return &Archive{Path: dl.path, d: d, key: key}, nil
+}
+
+// run downloads the archive for the request, once a download slot
+// is free.
+func (d *Downloader) run(dl *download, req DownloadRequest) {
+	defer close(dl.done)
+	d.slots <- struct{}{}
+	defer func() { <-d.slots }()
+
+	partial := dl.path + ".partial"
+	for attempt := 1; ; attempt++ {
+		err := d.transfer(partial, req)
+		if err == nil {
+			dl.err = errors.Trace(os.Rename(partial, dl.path))
+			break
+		}
+		if attempt >= d.config.Attempts || !isTransferError(err) {
+			dl.err = err
+			break
+		}
+		logger.Warningf("downloading charm %q (attempt %d): %v", req.URL, attempt, err)
+		<-d.config.Clock.After(d.config.RetryDelay)
+	}
+	if dl.err == nil {
+		d.evict()
+	}
+}
+
+// transfer downloads the archive for the request into the partial
+// file at the given path, resuming from its current size.
+func (d *Downloader) transfer(path string, req DownloadRequest) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	offset, err := f.Seek(0, os.SEEK_END)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r, info, err := req.Source.OpenArchive(req.URL, offset)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	if info.Start != offset {
+		// The source could not resume the transfer, so the archive
+		// is written from the start.
+		if err := f.Truncate(0); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := f.Seek(info.Start, os.SEEK_SET); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return &transferError{err}
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if info.SHA384 == "" {
+		return nil
+	}
+	hash, err := fileSHA384(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if hash != info.SHA384 {
+		// The archive is corrupt, so it is discarded and the next
+		// attempt starts again.
+		if err := os.Remove(path); err != nil {
+			return errors.Trace(err)
+		}
+		return &transferError{errors.Errorf("hash mismatch for charm %q", req.URL)}
+	}
+	return nil
+}
+
+func fileSHA384(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	h := sha512.New384()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// transferError holds an error that occurred while reading an
+// archive, after which the transfer may be resumed.
+type transferError struct {
+	error
+}
+
+func isTransferError(err error) bool {
+	_, ok := errors.Cause(err).(*transferError)
+	return ok
+}
+
+// release releases a reference to the download with the given key.
+func (d *Downloader) release(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if dl, ok := d.downloads[key]; ok {
+		dl.refs--
+	}
+	d.evictLocked()
+}
+
+func (d *Downloader) evict() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.evictLocked()
+}
+
+// evictLocked removes the least recently used downloaded archives
+// that are not in use, until no more than the configured maximum
+// remain. It must be called with d.mu held.
+func (d *Downloader) evictLocked() {
+	for {
+		var cached int
+		var oldestKey string
+		var oldest *download
+		for key, dl := range d.downloads {
+			select {
+			case <-dl.done:
+			default:
+				continue
+			}
+			cached++
+			if dl.refs > 0 {
+				continue
+			}
+			if oldest == nil || dl.lastUsed < oldest.lastUsed {
+				oldestKey, oldest = key, dl
+			}
+		}
+		if cached <= d.config.MaxCached || oldest == nil {
+			return
+		}
+		delete(d.downloads, oldestKey)
+		if err := os.Remove(oldest.path); err != nil && !os.IsNotExist(err) {
+			logger.Errorf("cannot remove cached charm archive: %v", err)
+		}
+	}
+}
+
+// downloadKey returns the key identifying the archive for the
+// request, which is safe to use as a file name.
+func downloadKey(req DownloadRequest) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s", req.URL, req.Auth)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
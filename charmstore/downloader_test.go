@@ -0,0 +1,363 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/charmstore"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DownloaderSuite struct {
+	testing.IsolationSuite
+
+	clock  *testing.Clock
+	config charmstore.DownloaderConfig
+	curl   *charm.URL
+}
+
+var _ = gc.Suite(&DownloaderSuite{})
+
+func (s *DownloaderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.config = charmstore.DownloaderConfig{
+		Dir:           c.MkDir(),
+		MaxConcurrent: 2,
+		MaxCached:     1,
+		Attempts:      3,
+		RetryDelay:    time.Second,
+		Clock:         s.clock,
+	}
+	s.curl = charm.MustParseURL("cs:trusty/wordpress-3")
+}
+
+func (s *DownloaderSuite) newDownloader(c *gc.C) *charmstore.Downloader {
+	d, err := charmstore.NewDownloader(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return d
+}
+
+func (s *DownloaderSuite) TestValidateConfig(c *gc.C) {
+	s.config.MaxConcurrent = 0
+	_, err := charmstore.NewDownloader(s.config)
+	c.Assert(err, gc.ErrorMatches, "MaxConcurrent 0 not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *DownloaderSuite) TestDownload(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	d := s.newDownloader(c)
+	archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	assertArchiveData(c, archive, "archive data")
+	source.CheckCall(c, 0, "OpenArchive", s.curl, int64(0))
+}
+
+func (s *DownloaderSuite) TestDownloadWithoutRevision(c *gc.C) {
+	d := s.newDownloader(c)
+	_, err := d.Download(charmstore.DownloadRequest{
+		URL:    charm.MustParseURL("cs:trusty/wordpress"),
+		Source: newFakeArchiveSource("archive data"),
+	})
+	c.Assert(err, gc.ErrorMatches, "charm URL without revision not valid")
+}
+
+func (s *DownloaderSuite) TestDownloadCached(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	d := s.newDownloader(c)
+	for i := 0; i < 2; i++ {
+		archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+		c.Assert(err, jc.ErrorIsNil)
+		assertArchiveData(c, archive, "archive data")
+		archive.Close()
+	}
+	source.CheckCallNames(c, "OpenArchive")
+}
+
+func (s *DownloaderSuite) TestDownloadNotSharedBetweenAuths(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	d := s.newDownloader(c)
+	for _, auth := range []string{"alice", "bob"} {
+		archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Auth: auth, Source: source})
+		c.Assert(err, jc.ErrorIsNil)
+		archive.Close()
+	}
+	source.CheckCallNames(c, "OpenArchive", "OpenArchive")
+}
+
+func (s *DownloaderSuite) TestDownloadEvictsLeastRecentlyUsed(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	d := s.newDownloader(c)
+	other := charm.MustParseURL("cs:trusty/mysql-1")
+	for _, curl := range []*charm.URL{s.curl, other, s.curl} {
+		archive, err := d.Download(charmstore.DownloadRequest{URL: curl, Source: source})
+		c.Assert(err, jc.ErrorIsNil)
+		archive.Close()
+	}
+	// Only one archive is cached, so the first is downloaded again.
+	source.CheckCallNames(c, "OpenArchive", "OpenArchive", "OpenArchive")
+	source.CheckCall(c, 2, "OpenArchive", s.curl, int64(0))
+}
+
+func (s *DownloaderSuite) TestDownloadInUseNotEvicted(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	d := s.newDownloader(c)
+	archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	other, err := d.Download(charmstore.DownloadRequest{
+		URL:    charm.MustParseURL("cs:trusty/mysql-1"),
+		Source: source,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	other.Close()
+	assertArchiveData(c, archive, "archive data")
+}
+
+func (s *DownloaderSuite) TestConcurrentDownloadsShared(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	source.block = make(chan struct{})
+	d := s.newDownloader(c)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+			c.Check(err, jc.ErrorIsNil)
+			if err == nil {
+				archive.Close()
+			}
+		}()
+	}
+	waitOpened(c, source)
+	close(source.block)
+	wg.Wait()
+	source.CheckCallNames(c, "OpenArchive")
+}
+
+func (s *DownloaderSuite) TestConcurrentDownloadsLimited(c *gc.C) {
+	s.config.MaxConcurrent = 1
+	source := newFakeArchiveSource("archive data")
+	source.block = make(chan struct{})
+	d := s.newDownloader(c)
+
+	var wg sync.WaitGroup
+	for _, url := range []string{"cs:trusty/wordpress-3", "cs:trusty/mysql-1"} {
+		curl := charm.MustParseURL(url)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			archive, err := d.Download(charmstore.DownloadRequest{URL: curl, Source: source})
+			c.Check(err, jc.ErrorIsNil)
+			if err == nil {
+				archive.Close()
+			}
+		}()
+	}
+	waitOpened(c, source)
+	select {
+	case <-source.opened:
+		c.Fatalf("second download started while the first was in progress")
+	case <-time.After(coretesting.ShortWait):
+	}
+	close(source.block)
+	waitOpened(c, source)
+	wg.Wait()
+	source.CheckCallNames(c, "OpenArchive", "OpenArchive")
+}
+
+func (s *DownloaderSuite) TestDownloadResumes(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	source.failAfter = []int{4}
+	d := s.newDownloader(c)
+
+	result := make(chan error, 1)
+	go func() {
+		archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+		if err == nil {
+			assertArchiveData(c, archive, "archive data")
+			archive.Close()
+		}
+		result <- err
+	}()
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for download")
+	}
+	source.CheckCall(c, 0, "OpenArchive", s.curl, int64(0))
+	source.CheckCall(c, 1, "OpenArchive", s.curl, int64(4))
+}
+
+func (s *DownloaderSuite) TestDownloadRestartsIfNotResumable(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	source.failAfter = []int{4}
+	source.noResume = true
+	d := s.newDownloader(c)
+
+	result := make(chan error, 1)
+	go func() {
+		archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+		if err == nil {
+			assertArchiveData(c, archive, "archive data")
+			archive.Close()
+		}
+		result <- err
+	}()
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for download")
+	}
+}
+
+func (s *DownloaderSuite) TestDownloadGivesUp(c *gc.C) {
+	s.config.Attempts = 1
+	source := newFakeArchiveSource("archive data")
+	source.failAfter = []int{4}
+	d := s.newDownloader(c)
+	_, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+	c.Assert(err, gc.ErrorMatches, "connection reset")
+
+	// The next request resumes the failed transfer.
+	archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	assertArchiveData(c, archive, "archive data")
+	source.CheckCall(c, 1, "OpenArchive", s.curl, int64(4))
+}
+
+func (s *DownloaderSuite) TestDownloadOpenError(c *gc.C) {
+	source := newFakeArchiveSource("archive data")
+	source.SetErrors(errors.New("access denied"))
+	d := s.newDownloader(c)
+	_, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+	c.Assert(err, gc.ErrorMatches, "access denied")
+	source.CheckCallNames(c, "OpenArchive")
+}
+
+func (s *DownloaderSuite) TestDownloadHashMismatch(c *gc.C) {
+	s.config.Attempts = 1
+	source := newFakeArchiveSource("archive data")
+	source.hash = "bad"
+	d := s.newDownloader(c)
+	_, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+	c.Assert(err, gc.ErrorMatches, `hash mismatch for charm "cs:trusty/wordpress-3"`)
+
+	// The corrupt archive is discarded, so the next transfer
+	// starts again.
+	source.hash = ""
+	archive, err := d.Download(charmstore.DownloadRequest{URL: s.curl, Source: source})
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	source.CheckCall(c, 1, "OpenArchive", s.curl, int64(0))
+}
+
+func assertArchiveData(c *gc.C, archive *charmstore.Archive, expect string) {
+	data, err := ioutil.ReadFile(archive.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expect)
+}
+
+func waitOpened(c *gc.C, source *fakeArchiveSource) {
+	select {
+	case <-source.opened:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for archive to be opened")
+	}
+}
+
+// fakeArchiveSource is an implementation of charmstore.ArchiveSource
+// serving the same data for every charm.
+type fakeArchiveSource struct {
+	testing.Stub
+
+	mu        sync.Mutex
+	data      string
+	hash      string
+	noResume  bool
+	failAfter []int
+
+	block  chan struct{}
+	opened chan struct{}
+}
+
+func newFakeArchiveSource(data string) *fakeArchiveSource {
+	return &fakeArchiveSource{
+		data:   data,
+		hash:   fmt.Sprintf("%x", sha512.Sum384([]byte(data))),
+		opened: make(chan struct{}, 10),
+	}
+}
+
+func (s *fakeArchiveSource) OpenArchive(curl *charm.URL, offset int64) (io.ReadCloser, charmstore.ArchiveInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.AddCall("OpenArchive", curl, offset)
+	s.opened <- struct{}{}
+	if err := s.NextErr(); err != nil {
+		return nil, charmstore.ArchiveInfo{}, err
+	}
+	info := charmstore.ArchiveInfo{Start: offset, SHA384: s.hash}
+	if s.noResume {
+		info.Start = 0
+	}
+	var r io.Reader = bytes.NewReader([]byte(s.data[info.Start:]))
+	if len(s.failAfter) > 0 {
+		r = &failingReader{io.LimitReader(r, int64(s.failAfter[0]))}
+		s.failAfter = s.failAfter[1:]
+	}
+	if s.block != nil {
+		r = &blockingReader{r, s.block}
+	}
+	return ioutil.NopCloser(r), info, nil
+}
+
+// failingReader fails when its underlying reader is exhausted, as
+// if the connection was lost.
+type failingReader struct {
+	io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		err = errors.New("connection reset")
+	}
+	return n, err
+}
+
+// blockingReader blocks until its channel is closed.
+type blockingReader struct {
+	io.Reader
+	block <-chan struct{}
+}
+
+func (r *blockingReader) Read(p []byte) (int, error) {
+	<-r.block
+	return r.Reader.Read(p)
+}
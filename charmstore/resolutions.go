@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// ResolutionCache caches the charm URLs that charm references resolve
+// to, for a limited time, so that deploying many applications does not
+// resolve the same references over and over again.
+type ResolutionCache struct {
+	clock clock.Clock
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]resolution
+}
+
+type resolution struct {
+	url     *charm.URL
+	series  []string
+	expires time.Time
+}
+
+// NewResolutionCache returns a new ResolutionCache that keeps
+// resolutions for the given time.
+func NewResolutionCache(clock clock.Clock, ttl time.Duration) *ResolutionCache {
+	return &ResolutionCache{
+		clock:   clock,
+		ttl:     ttl,
+		entries: make(map[string]resolution),
+	}
+}
+
+// Resolve returns the charm URL, and supported series, that the charm
+// reference resolves to in the given store. If the reference was not
+// resolved recently, it is resolved by calling resolve, and the
+// result is cached if successful.
+func (c *ResolutionCache) Resolve(
+	store string,
+	ref *charm.URL,
+	resolve func(*charm.URL) (*charm.URL, []string, error),
+) (*charm.URL, []string, error) {
+	key := store + "\x00" + ref.String()
+	now := c.clock.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.url, entry.series, nil
+	}
+
+	url, series, err := resolve(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[key] = resolution{
+		url:     url,
+		series:  series,
+		expires: now.Add(c.ttl),
+	}
+	return url, series, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/charmstore"
+)
+
+type ResolutionCacheSuite struct {
+	testing.IsolationSuite
+	testing.Stub
+
+	clock *testing.Clock
+	cache *charmstore.ResolutionCache
+}
+
+var _ = gc.Suite(&ResolutionCacheSuite{})
+
+func (s *ResolutionCacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.Stub = testing.Stub{}
+	s.clock = testing.NewClock(time.Time{})
+	s.cache = charmstore.NewResolutionCache(s.clock, time.Minute)
+}
+
+func (s *ResolutionCacheSuite) resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	s.AddCall("resolve", ref)
+	if err := s.NextErr(); err != nil {
+		return nil, nil, err
+	}
+	return ref.WithRevision(3), []string{"trusty"}, nil
+}
+
+func (s *ResolutionCacheSuite) TestResolveCached(c *gc.C) {
+	ref := charm.MustParseURL("cs:trusty/wordpress")
+	for i := 0; i < 2; i++ {
+		url, series, err := s.cache.Resolve("store", ref, s.resolve)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(url.String(), gc.Equals, "cs:trusty/wordpress-3")
+		c.Assert(series, jc.DeepEquals, []string{"trusty"})
+	}
+	s.CheckCallNames(c, "resolve")
+}
+
+func (s *ResolutionCacheSuite) TestResolveExpires(c *gc.C) {
+	ref := charm.MustParseURL("cs:trusty/wordpress")
+	_, _, err := s.cache.Resolve("store", ref, s.resolve)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Minute)
+	_, _, err = s.cache.Resolve("store", ref, s.resolve)
+	c.Assert(err, jc.ErrorIsNil)
+	s.CheckCallNames(c, "resolve", "resolve")
+}
+
+func (s *ResolutionCacheSuite) TestResolveByStore(c *gc.C) {
+	ref := charm.MustParseURL("cs:trusty/wordpress")
+	for _, store := range []string{"store", "other-store"} {
+		_, _, err := s.cache.Resolve(store, ref, s.resolve)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.CheckCallNames(c, "resolve", "resolve")
+}
+
+func (s *ResolutionCacheSuite) TestResolveErrorNotCached(c *gc.C) {
+	s.SetErrors(errors.New("charm not found"))
+	ref := charm.MustParseURL("cs:trusty/wordpress")
+	_, _, err := s.cache.Resolve("store", ref, s.resolve)
+	c.Assert(err, gc.ErrorMatches, "charm not found")
+	_, _, err = s.cache.Resolve("store", ref, s.resolve)
+	c.Assert(err, jc.ErrorIsNil)
+	s.CheckCallNames(c, "resolve", "resolve")
+}
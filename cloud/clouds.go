@@ -62,6 +62,11 @@ const (
 	// that require no credentials, e.g. "lxd", and "manual".
 	EmptyAuthType AuthType = "empty"
 
+	// PluginAuthType is an authentication type for credentials that
+	// are obtained from a credential plugin when they are used, rather
+	// than stored. See PluginCredentialSchema.
+	PluginAuthType AuthType = "plugin"
+
 	// AuthTypesKey is the name of the key in a cloud config or cloud schema
 	// that holds the cloud's auth types.
 	AuthTypesKey = "auth-types"
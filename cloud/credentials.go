@@ -134,7 +134,7 @@ func FinalizeCredential(
 	schemas map[AuthType]CredentialSchema,
 	readFile func(string) ([]byte, error),
 ) (*Credential, error) {
-	schema, ok := schemas[credential.authType]
+	schema, ok := credentialSchema(credential.authType, schemas)
 	if !ok {
 		return nil, errors.NotSupportedf("auth-type %q", credential.authType)
 	}
@@ -324,6 +324,83 @@ type CredentialAttr struct {
 	Options []interface{}
 }
 
+const (
+	// PluginAttr is the name of the plugin credential attribute that
+	// holds the kind of credential plugin: "env", "file" or "exec".
+	PluginAttr = "plugin"
+
+	// PluginAuthTypeAttr is the name of the plugin credential
+	// attribute that holds the auth-type of the credentials obtained
+	// from environment variables.
+	PluginAuthTypeAttr = "auth-type"
+
+	// PluginEnvVarsAttr is the name of the plugin credential attribute
+	// that maps credential attributes to the environment variables
+	// holding their values, as a comma-separated list of
+	// attribute=VARIABLE pairs.
+	PluginEnvVarsAttr = "env-vars"
+
+	// PluginPathAttr is the name of the plugin credential attribute
+	// that holds the path of the file the credential is read from.
+	PluginPathAttr = "path"
+
+	// PluginCommandAttr is the name of the plugin credential attribute
+	// that holds the command that is run to obtain the credential.
+	PluginCommandAttr = "command"
+)
+
+// PluginCredentialSchema is the schema of credentials with the
+// PluginAuthType auth-type, which may be used with any cloud that
+// lists it in its auth-types.
+//
+// The "file" and "exec" plugins read a YAML or JSON document, from the
+// file or from the command's standard output, holding the "auth-type"
+// and "attributes" of the credential, and optionally the time at which
+// it "expires" in RFC3339 format. The credential is obtained again
+// before it expires.
+//
+// The controller only obtains credentials from the plugins listed in
+// its allowed-credential-plugins config, and only controller
+// superusers may upload plugin credentials to it.
+var PluginCredentialSchema = CredentialSchema{{
+	PluginAttr, CredentialAttr{
+		Description: "The kind of credential plugin",
+		Options:     []interface{}{"env", "file", "exec"},
+	},
+}, {
+	PluginAuthTypeAttr, CredentialAttr{
+		Description: "The auth-type of credentials obtained from environment variables",
+		Optional:    true,
+	},
+}, {
+	PluginEnvVarsAttr, CredentialAttr{
+		Description: "The environment variables holding credential attributes, as attribute=VARIABLE pairs",
+		Optional:    true,
+	},
+}, {
+	PluginPathAttr, CredentialAttr{
+		Description: "The path of the file holding the credential",
+		Optional:    true,
+	},
+}, {
+	PluginCommandAttr, CredentialAttr{
+		Description: "The command that outputs the credential",
+		Optional:    true,
+	},
+}}
+
+// credentialSchema returns the schema for the given auth-type. Plugin
+// credentials are supported by all providers.
+func credentialSchema(authType AuthType, schemas map[AuthType]CredentialSchema) (CredentialSchema, bool) {
+	if schema, ok := schemas[authType]; ok {
+		return schema, true
+	}
+	if authType == PluginAuthType {
+		return PluginCredentialSchema, true
+	}
+	return nil, false
+}
+
 type cloudCredentialChecker struct{}
 
 func (c cloudCredentialChecker) Coerce(v interface{}, path []string) (interface{}, error) {
@@ -406,7 +483,7 @@ func RemoveSecrets(
 	credential Credential,
 	schemas map[AuthType]CredentialSchema,
 ) (*Credential, error) {
-	schema, ok := schemas[credential.authType]
+	schema, ok := credentialSchema(credential.authType, schemas)
 	if !ok {
 		return nil, errors.NotSupportedf("auth-type %q", credential.authType)
 	}
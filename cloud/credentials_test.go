@@ -331,6 +331,33 @@ func (s *credentialsSuite) TestFinalizeCredential(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *credentialsSuite) TestFinalizeCredentialPlugin(c *gc.C) {
+	cred := cloud.NewCredential(
+		cloud.PluginAuthType,
+		map[string]string{
+			"plugin":  "exec",
+			"command": "/usr/local/bin/fetch-token --project dev",
+		},
+	)
+	finalized, err := cloud.FinalizeCredential(cred, nil, readFileNotSupported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(finalized.Attributes(), jc.DeepEquals, map[string]string{
+		"plugin":  "exec",
+		"command": "/usr/local/bin/fetch-token --project dev",
+	})
+}
+
+func (s *credentialsSuite) TestFinalizeCredentialPluginInvalid(c *gc.C) {
+	cred := cloud.NewCredential(
+		cloud.PluginAuthType,
+		map[string]string{
+			"plugin": "carrier-pigeon",
+		},
+	)
+	_, err := cloud.FinalizeCredential(cred, nil, readFileNotSupported)
+	c.Assert(err, gc.ErrorMatches, `plugin: expected one of \[env file exec\], got "carrier-pigeon"`)
+}
+
 func (s *credentialsSuite) TestFinalizeCredentialFileAttr(c *gc.C) {
 	cred := cloud.NewCredential(
 		cloud.UserPassAuthType,
@@ -155,6 +155,16 @@ type InstanceConfig struct {
 	// ifup when bridging bonded interfaces. See bugs #1594855 and
 	// #1269921.
 	NetBondReconfigureDelay int
+
+	// EgressBandwidth, if non-zero, is the rate in megabits per second
+	// to which the instance's outgoing network traffic is limited by
+	// traffic shaping on the instance. Providers that limit the
+	// bandwidth of instances natively should clear it.
+	EgressBandwidth uint64
+
+	// AgentUser, if set, is the user that the machine agent runs
+	// as, rather than root. It is never set for controllers.
+	AgentUser string
 }
 
 // ControllerConfig represents controller-specific initialization information
@@ -389,6 +399,7 @@ func (cfg *InstanceConfig) ToolsDir(renderer shell.Renderer) string {
 
 func (cfg *InstanceConfig) InitService(renderer shell.Renderer) (service.Service, error) {
 	conf := service.AgentConf(cfg.agentInfo(), renderer)
+	conf.User = cfg.AgentUser
 
 	name := cfg.MachineAgentServiceName
 	svc, err := newService(name, conf, cfg.Series)
@@ -832,6 +843,11 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 		logger.Debugf("Setting numa ctl preference to %v", icfg.Controller.Config.NUMACtlPreference())
 		// Unfortunately, AgentEnvironment can only take strings as values
 		icfg.AgentEnvironment[agent.NUMACtlPreference] = fmt.Sprintf("%v", icfg.Controller.Config.NUMACtlPreference())
+	} else {
+		// Controller agents need root to manage mongo and the
+		// API server's ports, so only other agents run as the
+		// configured user.
+		icfg.AgentUser = cfg.AgentUser()
 	}
 	return nil
 }
@@ -126,6 +126,20 @@ func (s *CloudInitSuite) TestFinishInstanceConfigNonDefault(c *gc.C) {
 	})
 }
 
+func (s *CloudInitSuite) TestFinishInstanceConfigAgentUser(c *gc.C) {
+	attrs := dummySampleConfig().Merge(testing.Attrs{
+		"agent-user": "juju",
+	})
+	cfg, err := config.New(config.NoDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	icfg := &instancecfg.InstanceConfig{
+		APIInfo: &api.Info{Tag: names.NewLocalUserTag("not-touched")},
+	}
+	err = instancecfg.FinishInstanceConfig(icfg, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.AgentUser, gc.Equals, "juju")
+}
+
 func (s *CloudInitSuite) TestUserData(c *gc.C) {
 	s.testUserData(c, "quantal", false)
 }
@@ -1196,6 +1196,86 @@ DefaultEnvironment="http_proxy=http://user@10.0.0.1" "HTTP_PROXY=http://user@10.
 	c.Assert(found, jc.IsTrue)
 }
 
+func (s *cloudinitSuite) TestEgressShaping(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	instanceCfg.EgressBandwidth = 100
+	cloudcfg, err := cloudinit.New("quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := `(iface=$(ip route show default | awk '{print $5; exit}') && [ -n "$iface" ] && ` +
+		`tc qdisc replace dev "$iface" root tbf rate 100mbit burst 256kb latency 400ms) || true`
+	found := false
+	for _, cmd := range cloudcfg.RunCmds() {
+		if cmd == expected {
+			found = true
+			break
+		}
+	}
+	c.Assert(found, jc.IsTrue)
+}
+
+func (s *cloudinitSuite) TestEgressShapingNotSet(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	cloudcfg, err := cloudinit.New("quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, cmd := range cloudcfg.RunCmds() {
+		c.Assert(cmd, gc.Not(jc.Contains), "tc qdisc")
+	}
+}
+
+func (s *cloudinitSuite) TestAgentUser(c *gc.C) {
+	environConfig, err := minimalModelConfig(c).Apply(map[string]interface{}{
+		"agent-user": "juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	apiInfo := jujutesting.FakeAPIInfo("42")
+	instanceCfg, err := instancecfg.NewInstanceConfig(testing.ControllerTag, "42", "fake-nonce", imagemetadata.ReleasedStream, "xenial", apiInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg.SetTools(tools.List{
+		&tools.Tools{
+			Version: version.MustParseBinary("2.3.4-xenial-amd64"),
+			URL:     "http://tools.testing.invalid/2.3.4-xenial-amd64.tgz",
+		},
+	})
+	err = instancecfg.FinishInstanceConfig(instanceCfg, environConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	cloudcfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	scripts := cloudcfg.RunCmds()
+	for _, expected := range []string{
+		"getent passwd juju >/dev/null || useradd --system --user-group --home-dir '/var/lib/juju' --shell /usr/sbin/nologin juju",
+		"chown -R juju: '/var/lib/juju' '/var/log/juju'",
+		"install -o root -g juju -m 4750 $bin/jujud '/usr/lib/juju/bin/jujud-privhelper'",
+		"ln -sfn '/var/lib/juju/tools/machine-42/jujud' '/usr/bin/juju-run'",
+		"User=juju",
+	} {
+		found := false
+		for _, cmd := range scripts {
+			if strings.Contains(cmd, expected) {
+				found = true
+				break
+			}
+		}
+		c.Check(found, jc.IsTrue, gc.Commentf("%q not found", expected))
+	}
+}
+
 func (s *cloudinitSuite) TestAptMirror(c *gc.C) {
 	environConfig := minimalModelConfig(c)
 	environConfig, err := environConfig.Apply(map[string]interface{}{
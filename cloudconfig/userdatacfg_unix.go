@@ -27,9 +27,11 @@ import (
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/systemd"
 	"github.com/juju/juju/service/upstart"
+	"github.com/juju/juju/utils/privsep"
 )
 
 var logger = loggo.GetLogger("juju.cloudconfig")
@@ -260,6 +262,10 @@ func (w *unixConfigure) ConfigureJuju() error {
 			shquote(w.icfg.ProxySettings.AsSystemdDefaultEnv())))
 	}
 
+	if w.icfg.EgressBandwidth > 0 {
+		w.addEgressShapingCmds()
+	}
+
 	if w.icfg.Controller != nil && w.icfg.Controller.PublicImageSigningKey != "" {
 		keyFile := filepath.Join(agent.DefaultPaths.ConfDir, simplestreams.SimplestreamsPublicKeyFile)
 		w.conf.AddRunTextFile(keyFile, w.icfg.Controller.PublicImageSigningKey, 0644)
@@ -321,9 +327,66 @@ func (w *unixConfigure) ConfigureJuju() error {
 		}
 	}
 
+	if w.icfg.AgentUser != "" {
+		if err := w.addAgentUserCmds(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	return w.addMachineAgentToBoot()
 }
 
+// agentUserGroups holds the groups that the agent user is added to,
+// so that it can manage containers without root privileges.
+var agentUserGroups = []string{"lxd", "libvirtd", "libvirt"}
+
+// addAgentUserCmds creates the user the machine agent runs as, gives
+// it the agent's directories, and installs the setuid helper that
+// does the agent's privileged operations. It also creates the links
+// to jujud that the agent cannot create itself.
+func (w *unixConfigure) addAgentUserCmds() error {
+	user := w.icfg.AgentUser
+	w.conf.AddRunCmd(cloudinit.LogProgressCmd("Setting up agent user %s", user))
+	w.conf.AddScripts(
+		fmt.Sprintf(
+			"getent passwd %[1]s >/dev/null || useradd --system --user-group --home-dir %[2]s --shell /usr/sbin/nologin %[1]s",
+			user, shquote(w.icfg.DataDir),
+		),
+		fmt.Sprintf(
+			"for group in %s; do getent group $group >/dev/null || groupadd --system $group; usermod -a -G $group %s; done",
+			strings.Join(agentUserGroups, " "), user,
+		),
+		fmt.Sprintf("chown -R %s: %s %s", user, shquote(w.icfg.DataDir), shquote(w.icfg.LogDir)),
+		fmt.Sprintf("mkdir -p %s", shquote(path.Dir(privsep.HelperPath))),
+		fmt.Sprintf("install -o root -g %s -m 4750 $bin/jujud %s", user, shquote(privsep.HelperPath)),
+	)
+	jujud := path.Join(w.icfg.ToolsDir(w.conf.ShellRenderer()), "jujud")
+	for _, link := range []func(string) (string, error){
+		paths.JujuRun,
+		paths.JujuDumpLogs,
+		paths.JujuIntrospect,
+	} {
+		linkPath, err := link(w.icfg.Series)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.conf.AddScripts(fmt.Sprintf("ln -sfn %s %s", shquote(jujud), shquote(linkPath)))
+	}
+	return nil
+}
+
+// addEgressShapingCmds limits the rate of traffic leaving the machine
+// through the interface of its default route. Failing to apply the
+// limit does not abort the machine's initialisation.
+func (w *unixConfigure) addEgressShapingCmds() {
+	w.conf.AddRunCmd(cloudinit.LogProgressCmd("Limiting outgoing traffic to %dMbit/s", w.icfg.EgressBandwidth))
+	w.conf.AddScripts(fmt.Sprintf(
+		`(iface=$(ip route show default | awk '{print $5; exit}') && [ -n "$iface" ] && `+
+			`tc qdisc replace dev "$iface" root tbf rate %dmbit burst 256kb latency 400ms) || true`,
+		w.icfg.EgressBandwidth,
+	))
+}
+
 func (w *unixConfigure) configureBootstrap() error {
 	// Add the Juju GUI to the bootstrap node.
 	cleanup, err := w.setUpGUI()
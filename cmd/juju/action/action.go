@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/actionschedules"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -58,6 +59,21 @@ type APIClient interface {
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
 }
 
+// ScheduleAPIClient represents the action schedules API functionality.
+type ScheduleAPIClient interface {
+	io.Closer
+
+	// AddSchedule adds a schedule for an action, returning the
+	// new schedule.
+	AddSchedule(params.AddActionSchedule) (params.ActionSchedule, error)
+
+	// Schedules returns all of the model's action schedules.
+	Schedules() ([]params.ActionSchedule, error)
+
+	// RemoveSchedule removes the action schedule with the given id.
+	RemoveSchedule(id string) error
+}
+
 // ActionCommandBase is the base type for action sub-commands.
 type ActionCommandBase struct {
 	modelcmd.ModelCommandBase
@@ -75,3 +91,17 @@ var newAPIClient = func(c *ActionCommandBase) (APIClient, error) {
 	}
 	return action.NewClient(root), nil
 }
+
+// NewScheduleAPIClient returns a client for the action schedules api
+// endpoint.
+func (c *ActionCommandBase) NewScheduleAPIClient() (ScheduleAPIClient, error) {
+	return newScheduleAPIClient(c)
+}
+
+var newScheduleAPIClient = func(c *ActionCommandBase) (ScheduleAPIClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return actionschedules.NewClient(root), nil
+}
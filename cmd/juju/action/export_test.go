@@ -13,8 +13,9 @@ import (
 )
 
 var (
-	NewActionAPIClient = &newAPIClient
-	AddValueToMap      = addValueToMap
+	NewActionAPIClient   = &newAPIClient
+	NewScheduleAPIClient = &newScheduleAPIClient
+	AddValueToMap        = addValueToMap
 )
 
 type ShowOutputCommand struct {
@@ -98,3 +99,15 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
+
+func NewSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &schedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
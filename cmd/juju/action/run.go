@@ -35,6 +35,9 @@ type runCommand struct {
 	paramsYAML   cmd.FileVar
 	parseStrings bool
 	wait         waitFlag
+	schedule     string
+	at           string
+	atTime       time.Time
 	out          cmd.Output
 	args         [][]string
 }
@@ -56,6 +59,18 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+When waiting for results with --wait, the progress messages logged by the
+action with action-log or action-progress are shown as they arrive.
+
+Instead of being queued immediately, the action may be scheduled to run
+periodically with --schedule, which takes a cron expression of the form
+"minute hour day-of-month month day-of-week" in UTC, or once at a future
+time with --at, which takes an RFC3339 time or a duration from now. The
+controller does not queue a scheduled action while the action it last
+queued for the same schedule is still pending or running. Schedules are
+listed with "juju action-schedules", and removed with
+"juju remove-action-schedule".
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -116,6 +131,19 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/3 backup --schedule "0 2 * * *"
+mysql/3:
+  next-run: 2017-09-02T02:00:00Z
+  schedule-id: "1"
+
+$ juju run-action mysql/3 backup --at 2h
+...
+The backup action will be queued once, two hours from now.
+
+See also:
+    action-schedules
+    remove-action-schedule
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -128,6 +156,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.StringVar(&c.schedule, "schedule", "", "Queue the action periodically, according to a cron expression")
+	f.StringVar(&c.at, "at", "", "Queue the action once at a future time, or after a duration")
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -180,68 +210,52 @@ func (c *runCommand) Init(args []string) error {
 		// c.args={..., [key, key, key, key, value]}
 		c.args = append(c.args, append(keySlice, thisArg[1]))
 	}
-	return nil
+	return c.initSchedule()
 }
 
-func (c *runCommand) Run(ctx *cmd.Context) error {
-	api, err := c.NewActionAPIClient()
-	if err != nil {
-		return err
+// initSchedule validates the --schedule and --at flags.
+func (c *runCommand) initSchedule() error {
+	if c.schedule == "" && c.at == "" {
+		return nil
 	}
-	defer api.Close()
-
-	actionParams := map[string]interface{}{}
-
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return err
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return err
-		}
-
-		conformantParams, err := common.ConformYAML(actionParams)
-		if err != nil {
-			return err
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
+	if c.schedule != "" && c.at != "" {
+		return errors.New("cannot specify both --schedule and --at")
 	}
-
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
+	if c.wait.forever || c.wait.d > 0 {
+		return errors.New("cannot wait for the results of a scheduled action")
+	}
+	if c.at == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(c.at); err == nil {
+		if d <= 0 {
+			return errors.Errorf("--at duration %q must be positive", c.at)
 		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
+		c.atTime = time.Now().Add(d)
+		return nil
+	}
+	at, err := time.Parse(time.RFC3339, c.at)
+	if err != nil {
+		return errors.Errorf("--at value %q is neither an RFC3339 time nor a duration", c.at)
 	}
+	c.atTime = at
+	return nil
+}
 
-	conformantParams, err := common.ConformYAML(actionParams)
+func (c *runCommand) Run(ctx *cmd.Context) error {
+	actionParams, err := c.actionParams(ctx)
 	if err != nil {
 		return err
 	}
+	if c.schedule != "" || !c.atTime.IsZero() {
+		return c.addSchedules(ctx, actionParams)
+	}
 
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
 	}
+	defer api.Close()
 
 	actions := make([]params.Action, len(c.unitTags))
 	for i, unitTag := range c.unitTags {
@@ -315,7 +329,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		if err != nil {
 			return err
 		}
-		result, err = GetActionResult(api, tag.Id(), wait)
+		result, err = GetActionResultWithLog(api, tag.Id(), wait, ctx.Stderr)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -330,3 +344,95 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, output)
 }
+
+// addSchedules adds a schedule for the action on each of the units,
+// and writes the id and next run of each.
+func (c *runCommand) addSchedules(ctx *cmd.Context, actionParams map[string]interface{}) error {
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	output := make(map[string]interface{}, len(c.unitTags))
+	for _, unitTag := range c.unitTags {
+		arg := params.AddActionSchedule{
+			Receiver:   unitTag.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Cron:       c.schedule,
+		}
+		if !c.atTime.IsZero() {
+			at := c.atTime.UTC()
+			arg.At = &at
+		}
+		schedule, err := api.AddSchedule(arg)
+		if err != nil {
+			return errors.Annotatef(err, "scheduling action on %s", unitTag.Id())
+		}
+		info := map[string]string{"schedule-id": schedule.Id}
+		if schedule.NextRun != nil {
+			info["next-run"] = schedule.NextRun.UTC().Format(time.RFC3339)
+		}
+		output[unitTag.Id()] = info
+	}
+	return c.out.Write(ctx, output)
+}
+
+// actionParams returns the action's parameters, read from the params
+// file and the command line.
+func (c *runCommand) actionParams(ctx *cmd.Context) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+
+	if c.paramsYAML.Path != "" {
+		b, err := c.paramsYAML.Read(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = yaml.Unmarshal(b, &actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a YAML map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range c.args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !c.parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return nil, err
+	}
+
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return actionParams, nil
+}
@@ -7,9 +7,11 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -194,6 +196,81 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestInitScheduleErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectError string
+	}{{
+		args:        []string{"--schedule", "0 2 * * *", "--at", "1h"},
+		expectError: "cannot specify both --schedule and --at",
+	}, {
+		args:        []string{"--schedule", "0 2 * * *", "--wait"},
+		expectError: "cannot wait for the results of a scheduled action",
+	}, {
+		args:        []string{"--at", "-1h"},
+		expectError: `--at duration "-1h" must be positive`,
+	}, {
+		args:        []string{"--at", "tomorrow"},
+		expectError: `--at value "tomorrow" is neither an RFC3339 time nor a duration`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+		args := append([]string{"-m", "admin", validUnitId, "some-action"}, t.args...)
+		err := cmdtesting.InitCommand(wrappedCommand, args)
+		c.Check(err, gc.ErrorMatches, t.expectError)
+	}
+}
+
+func (s *RunSuite) TestRunSchedule(c *gc.C) {
+	nextRun := time.Date(2017, 9, 2, 2, 0, 0, 0, time.UTC)
+	fakeClient := &fakeScheduleAPIClient{
+		added: params.ActionSchedule{Id: "1", NextRun: &nextRun},
+	}
+	s.PatchValue(action.NewScheduleAPIClient,
+		func(c *action.ActionCommandBase) (action.ScheduleAPIClient, error) {
+			return fakeClient, nil
+		},
+	)
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validUnitId, "some-action", "--schedule", "0 2 * * *", "out=name",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  next-run: "2017-09-02T02:00:00Z"
+  schedule-id: "1"
+`[1:])
+	fakeClient.CheckCalls(c, []jujutesting.StubCall{
+		{"AddSchedule", []interface{}{params.AddActionSchedule{
+			Receiver:   "unit-mysql-0",
+			Name:       "some-action",
+			Parameters: map[string]interface{}{"out": "name"},
+			Cron:       "0 2 * * *",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *RunSuite) TestRunScheduleAt(c *gc.C) {
+	fakeClient := &fakeScheduleAPIClient{added: params.ActionSchedule{Id: "2"}}
+	s.PatchValue(action.NewScheduleAPIClient,
+		func(c *action.ActionCommandBase) (action.ScheduleAPIClient, error) {
+			return fakeClient, nil
+		},
+	)
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validUnitId, "some-action", "--at", "2017-09-02T02:00:00+02:00",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	fakeClient.CheckCallNames(c, "AddSchedule", "Close")
+	arg := fakeClient.Calls()[0].Args[0].(params.AddActionSchedule)
+	c.Assert(arg.At, gc.NotNil)
+	c.Assert(arg.At.Equal(time.Date(2017, 9, 2, 0, 0, 0, 0, time.UTC)), jc.IsTrue)
+	c.Assert(arg.Cron, gc.Equals, "")
+}
+
 func (s *RunSuite) TestRun(c *gc.C) {
 	tests := []struct {
 		should                 string
@@ -0,0 +1,250 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&schedulesCommand{})
+}
+
+// schedulesCommand lists the model's action schedules.
+type schedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const schedulesDoc = `
+List the schedules that queue actions on units, as added with the
+--schedule and --at options of "juju run-action". For each schedule,
+the outcome of its most recent runs is shown: either the id of the
+action that was queued, or why no action was queued.
+
+Examples:
+
+    juju action-schedules
+    juju action-schedules --format yaml
+
+See also:
+    run-action
+    remove-action-schedule
+`
+
+func (c *schedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSchedulesTabular,
+	})
+}
+
+func (c *schedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "action-schedules",
+		Purpose: "List scheduled actions.",
+		Doc:     schedulesDoc,
+		Aliases: []string{"list-action-schedules"},
+	}
+}
+
+func (c *schedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *schedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.Schedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules to display.")
+		return nil
+	}
+	out := make(map[string]scheduleOutput, len(schedules))
+	for _, s := range schedules {
+		out[s.Id] = formatSchedule(s)
+	}
+	return c.out.Write(ctx, out)
+}
+
+type scheduleOutput struct {
+	Unit       string                 `yaml:"unit" json:"unit"`
+	Action     string                 `yaml:"action" json:"action"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule   string                 `yaml:"schedule" json:"schedule"`
+	NextRun    string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	Owner      string                 `yaml:"owner" json:"owner"`
+	Created    string                 `yaml:"created" json:"created"`
+	History    []scheduleRunOutput    `yaml:"history,omitempty" json:"history,omitempty"`
+}
+
+type scheduleRunOutput struct {
+	Time   string `yaml:"time" json:"time"`
+	Action string `yaml:"action,omitempty" json:"action,omitempty"`
+	Error  string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func formatSchedule(s params.ActionSchedule) scheduleOutput {
+	out := scheduleOutput{
+		Unit:       s.Receiver,
+		Action:     s.Name,
+		Parameters: s.Parameters,
+		Schedule:   s.Cron,
+		Owner:      s.Owner,
+		Created:    s.Created.UTC().Format(time.RFC3339),
+	}
+	if tag, err := names.ParseUnitTag(s.Receiver); err == nil {
+		out.Unit = tag.Id()
+	}
+	if out.Schedule == "" {
+		out.Schedule = "once"
+	}
+	if s.NextRun != nil {
+		out.NextRun = s.NextRun.UTC().Format(time.RFC3339)
+	}
+	for _, run := range s.History {
+		runOut := scheduleRunOutput{
+			Time:  run.Time.UTC().Format(time.RFC3339),
+			Error: run.Error,
+		}
+		if tag, err := names.ParseActionTag(run.Action); err == nil {
+			runOut.Action = tag.Id()
+		}
+		out.History = append(out.History, runOut)
+	}
+	return out
+}
+
+// printSchedulesTabular prints the action schedules in tabular format,
+// with the outcome of the most recent run of each.
+func printSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Id\tUnit\tAction\tSchedule\tNext run\tOwner\tLast run")
+	for _, id := range sortedScheduleIds(schedules) {
+		s := schedules[id]
+		nextRun := s.NextRun
+		if nextRun == "" {
+			nextRun = "-"
+		}
+		lastRun := "-"
+		if n := len(s.History); n > 0 {
+			run := s.History[n-1]
+			if run.Error != "" {
+				lastRun = fmt.Sprintf("%s %s", run.Time, run.Error)
+			} else {
+				lastRun = fmt.Sprintf("%s queued %s", run.Time, run.Action)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			id, s.Unit, s.Action, s.Schedule, nextRun, s.Owner, lastRun,
+		)
+	}
+	return tw.Flush()
+}
+
+// sortedScheduleIds returns the schedule ids in numeric order.
+func sortedScheduleIds(schedules map[string]scheduleOutput) []string {
+	ids := make([]string, 0, len(schedules))
+	for id := range schedules {
+		ids = append(ids, id)
+	}
+	sort.Sort(numericIds(ids))
+	return ids
+}
+
+// numericIds sorts decimal ids by numeric value.
+type numericIds []string
+
+func (s numericIds) Len() int      { return len(s) }
+func (s numericIds) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s numericIds) Less(i, j int) bool {
+	if len(s[i]) != len(s[j]) {
+		return len(s[i]) < len(s[j])
+	}
+	return s[i] < s[j]
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes an action schedule.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	id string
+}
+
+const removeScheduleDoc = `
+Remove an action schedule, so that it queues no more actions. Actions
+that the schedule has already queued are not affected; use
+"juju cancel-action" to cancel them.
+
+Examples:
+
+    juju remove-action-schedule 3
+
+See also:
+    action-schedules
+    cancel-action
+`
+
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-action-schedule",
+		Args:    "<schedule id>",
+		Purpose: "Remove an action schedule.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+func (c *removeScheduleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no schedule id specified")
+	case 1:
+		c.id = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewScheduleAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.RemoveSchedule(c.id); err != nil {
+		return errors.Annotatef(err, "removing action schedule %s", c.id)
+	}
+	ctx.Infof("Removed action schedule %s.", c.id)
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type SchedulesSuite struct {
+	BaseActionSuite
+	api *fakeScheduleAPIClient
+}
+
+var _ = gc.Suite(&SchedulesSuite{})
+
+func (s *SchedulesSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	created := time.Date(2017, 9, 1, 10, 0, 0, 0, time.UTC)
+	nextRun := time.Date(2017, 9, 2, 2, 0, 0, 0, time.UTC)
+	s.api = &fakeScheduleAPIClient{
+		schedules: []params.ActionSchedule{{
+			Id:       "10",
+			Receiver: "unit-mysql-0",
+			Name:     "backup",
+			Cron:     "0 2 * * *",
+			NextRun:  &nextRun,
+			Owner:    "admin",
+			Created:  created,
+			History: []params.ActionScheduleRun{{
+				Time:   created.Add(time.Hour),
+				Action: validActionTagString,
+			}, {
+				Time:  created.Add(2 * time.Hour),
+				Error: "skipped: action " + validActionId + " is still running",
+			}},
+		}, {
+			Id:       "9",
+			Receiver: "unit-mysql-1",
+			Name:     "backup",
+			Owner:    "bob",
+			Created:  created,
+			History: []params.ActionScheduleRun{{
+				Time:   created.Add(time.Hour),
+				Action: validActionTagString,
+			}},
+		}},
+	}
+	s.PatchValue(action.NewScheduleAPIClient,
+		func(c *action.ActionCommandBase) (action.ScheduleAPIClient, error) {
+			return s.api, nil
+		},
+	)
+}
+
+func (s *SchedulesSuite) TestSchedulesTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Id  Unit     Action  Schedule   Next run              Owner  Last run
+9   mysql/1  backup  once       -                     bob    2017-09-01T11:00:00Z queued f47ac10b-58cc-4372-a567-0e02b2c3d479
+10  mysql/0  backup  0 2 * * *  2017-09-02T02:00:00Z  admin  2017-09-01T12:00:00Z skipped: action f47ac10b-58cc-4372-a567-0e02b2c3d479 is still running
+`[1:])
+	s.api.CheckCallNames(c, "Schedules", "Close")
+}
+
+func (s *SchedulesSuite) TestSchedulesYAML(c *gc.C) {
+	s.api.schedules = s.api.schedules[1:]
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"9":
+  unit: mysql/1
+  action: backup
+  schedule: once
+  owner: bob
+  created: "2017-09-01T10:00:00Z"
+  history:
+  - time: "2017-09-01T11:00:00Z"
+    action: f47ac10b-58cc-4372-a567-0e02b2c3d479
+`[1:])
+}
+
+func (s *SchedulesSuite) TestSchedulesNone(c *gc.C) {
+	s.api.schedules = nil
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules to display.\n")
+}
+
+func (s *SchedulesSuite) TestSchedulesError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SchedulesSuite) TestRemoveSchedule(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "10")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed action schedule 10.\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveSchedule", []interface{}{"10"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SchedulesSuite) TestRemoveScheduleError(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf("action schedule 10"))
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "10")
+	c.Assert(err, gc.ErrorMatches, "removing action schedule 10: action schedule 10 not found")
+}
+
+func (s *SchedulesSuite) TestRemoveScheduleInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, gc.ErrorMatches, "no schedule id specified")
+	_, err = cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "1", "2")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["2"\]`)
+}
+
+type fakeScheduleAPIClient struct {
+	jujutesting.Stub
+	schedules []params.ActionSchedule
+	added     params.ActionSchedule
+}
+
+var _ action.ScheduleAPIClient = (*fakeScheduleAPIClient)(nil)
+
+func (f *fakeScheduleAPIClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeScheduleAPIClient) AddSchedule(arg params.AddActionSchedule) (params.ActionSchedule, error) {
+	f.MethodCall(f, "AddSchedule", arg)
+	return f.added, f.NextErr()
+}
+
+func (f *fakeScheduleAPIClient) Schedules() ([]params.ActionSchedule, error) {
+	f.MethodCall(f, "Schedules")
+	return f.schedules, f.NextErr()
+}
+
+func (f *fakeScheduleAPIClient) RemoveSchedule(id string) error {
+	f.MethodCall(f, "RemoveSchedule", id)
+	return f.NextErr()
+}
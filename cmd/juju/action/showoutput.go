@@ -4,6 +4,8 @@
 package action
 
 import (
+	"fmt"
+	"io"
 	"regexp"
 	"time"
 
@@ -121,16 +123,26 @@ func GetActionResult(api APIClient, requestedId string, wait *time.Timer) (param
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	tick := time.NewTimer(2 * time.Second)
 
-	return timerLoop(api, requestedId, wait, tick)
+	return timerLoop(api, requestedId, wait, tick, nil)
+}
+
+// GetActionResultWithLog is like GetActionResult, but also writes the
+// progress messages logged by the action to the given writer as they
+// arrive.
+func GetActionResultWithLog(api APIClient, requestedId string, wait *time.Timer, log io.Writer) (params.ActionResult, error) {
+	tick := time.NewTimer(2 * time.Second)
+	return timerLoop(api, requestedId, wait, tick, log)
 }
 
 // timerLoop loops indefinitely to query the given API, until "wait" times
 // out, using the "tick" timer to delay the API queries.  It writes the
-// result to the given output.
-func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (params.ActionResult, error) {
+// result to the given output. If log is not nil, the progress messages
+// logged by the action are written to it as they arrive.
+func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer, log io.Writer) (params.ActionResult, error) {
 	var (
 		result params.ActionResult
 		err    error
+		logged int
 	)
 
 	// Loop over results until we get "failed" or "completed".  Wait for
@@ -140,6 +152,12 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 		if err != nil {
 			return result, err
 		}
+		if log != nil {
+			for _, m := range result.Log[logged:] {
+				fmt.Fprintln(log, formatActionMessage(m))
+			}
+			logged = len(result.Log)
+		}
 
 		// Whether or not we're waiting for a result, if a completed
 		// result arrives, we're done.
@@ -193,6 +211,12 @@ func fetchResult(api APIClient, requestedId string) (params.ActionResult, error)
 	return result, nil
 }
 
+// formatActionMessage returns a progress message logged by an action,
+// prefixed with the time it was logged.
+func formatActionMessage(m params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", m.Timestamp.UTC().Format(time.RFC3339), m.Message)
+}
+
 // FormatActionResult removes empty values from the given ActionResult and
 // inserts the remaining ones in a map[string]interface{} for cmd.Output to
 // write in an easy-to-read format.
@@ -204,6 +228,16 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if result.Progress != 0 {
+		response["progress"] = fmt.Sprintf("%d%%", result.Progress)
+	}
+	if len(result.Log) != 0 {
+		log := make([]string, len(result.Log))
+		for i, m := range result.Log {
+			log[i] = formatActionMessage(m)
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
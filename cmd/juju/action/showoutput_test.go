@@ -167,6 +167,30 @@ timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
   started: 2015-02-14 08:15:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "pretty-print action output with log and progress",
+		withClientQueryID: validActionId,
+		withAPITimeout:    10 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Status: "running",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 14, 0, 0, time.UTC),
+				Message:   "halfway",
+			}},
+			Progress: 50,
+			Enqueued: time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:  time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		}},
+		expectedOutput: `
+log:
+- 2015-02-14T08:14:00Z halfway
+progress: 50%
+status: running
+timing:
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  started: 2015-02-14 08:15:00 +0000 UTC
 `[1:],
 	}, {
 		should:            "pretty-print action output with no completed time",
@@ -4,8 +4,10 @@
 package application
 
 import (
+	"fmt"
 	"net"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -51,6 +53,15 @@ Examples:
     
     $ juju add-relation wordpress someone/prod.mysql --via 192.168.0.0/16,10.0.0.0/8
 
+    $ juju add-relation wordpress mysql --dry-run
+        reports whether the endpoints are compatible, and which units would
+        enter the relation, without adding it
+
+With --dry-run, the interfaces, roles and scopes of the endpoints are checked,
+along with the relation limits declared by their charms, and the units that
+would enter the relation's scope are listed. Nothing is changed. --dry-run
+cannot be used with offers in a different model.
+
 `
 
 var localEndpointRegEx = regexp.MustCompile("^" + names.RelationSnippet + "$")
@@ -67,6 +78,7 @@ type addRelationCommand struct {
 	viaCIDRs          []string
 	viaValue          string
 	remoteEndpoint    *crossmodel.OfferURL
+	dryRun            bool
 	addRelationAPI    applicationAddRelationAPI
 	consumeDetailsAPI applicationConsumeDetailsAPI
 }
@@ -95,11 +107,15 @@ func (c *addRelationCommand) Init(args []string) error {
 	if c.remoteEndpoint == nil && len(c.viaCIDRs) > 0 {
 		return errors.New("the --via option can only be used when relating to offers in a different model")
 	}
+	if c.remoteEndpoint != nil && c.dryRun {
+		return errors.New("--dry-run cannot be used when relating to offers in a different model")
+	}
 	return nil
 }
 
 func (c *addRelationCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.viaValue, "via", "", "for cross model relations, specify the egress subnets for outbound traffic")
+	f.BoolVar(&c.dryRun, "dry-run", false, "check whether the relation could be added, without adding it")
 }
 
 // applicationAddRelationAPI defines the API methods that application add relation command uses.
@@ -107,6 +123,7 @@ type applicationAddRelationAPI interface {
 	Close() error
 	BestAPIVersion() int
 	AddRelation(endpoints, viaCIDRs []string) (*params.AddRelationResults, error)
+	PreviewAddRelation(endpoints ...string) (params.RelationPreviewResult, error)
 	Consume(crossmodel.ConsumeApplicationArgs) (string, error)
 }
 
@@ -149,6 +166,20 @@ func (c *addRelationCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.dryRun {
+		result, err := client.PreviewAddRelation(c.endpoints...)
+		if errors.IsNotSupported(err) {
+			return errors.New("this juju controller does not support --dry-run")
+		}
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "preview adding a relation")
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return writeRelationPreview(ctx, result, "added", "enter")
+	}
+
 	if c.remoteEndpoint != nil {
 		if client.BestAPIVersion() < 5 {
 			// old client does not have cross-model capability.
@@ -172,6 +203,43 @@ func (c *addRelationCommand) Run(ctx *cmd.Context) error {
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
+// writeRelationPreview writes a report of whether a relation could be
+// added or removed, and which units would enter or leave its scope. It
+// returns cmd.ErrSilent if the change could not be made.
+func writeRelationPreview(ctx *cmd.Context, result params.RelationPreviewResult, change, scopeChange string) error {
+	relation := "relation"
+	if result.Key != "" {
+		relation = fmt.Sprintf("relation %q", result.Key)
+	}
+	if len(result.Problems) > 0 {
+		fmt.Fprintf(ctx.Stdout, "%s cannot be %s:\n", relation, change)
+		for _, problem := range result.Problems {
+			fmt.Fprintf(ctx.Stdout, "  - %s\n", problem)
+		}
+		return cmd.ErrSilent
+	}
+	fmt.Fprintf(ctx.Stdout, "%s can be %s\n", relation, change)
+	appNames := make([]string, 0, len(result.Endpoints))
+	for appName := range result.Endpoints {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+	for _, appName := range appNames {
+		ep := result.Endpoints[appName]
+		details := fmt.Sprintf("%s, interface %s, %s scope", ep.Role, ep.Interface, ep.Scope)
+		if ep.Limit > 0 {
+			details += fmt.Sprintf(", limit %d", ep.Limit)
+		}
+		fmt.Fprintf(ctx.Stdout, "  %s:%s (%s)\n", appName, ep.Name, details)
+	}
+	if len(result.Units) == 0 {
+		fmt.Fprintf(ctx.Stdout, "no units would %s scope\n", scopeChange)
+	} else {
+		fmt.Fprintf(ctx.Stdout, "units that would %s scope: %s\n", scopeChange, strings.Join(result.Units, ", "))
+	}
+	return nil
+}
+
 func (c *addRelationCommand) maybeConsumeOffer(targetClient applicationAddRelationAPI) error {
 	sourceClient, err := c.getOffersAPI(c.remoteEndpoint)
 	if err != nil {
@@ -6,6 +6,7 @@ package application
 import (
 	"strings"
 
+	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -92,9 +93,62 @@ func (s *AddRelationSuite) TestAddRelationUnauthorizedMentionsJujuGrant(c *gc.C)
 	c.Assert(errString, gc.Matches, `.*juju grant.*`)
 }
 
+func (s *AddRelationSuite) runAddRelationDryRun(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := NewAddRelationCommandForTest(s.mockAPI, s.mockAPI)
+	command.SetClientStore(NewMockStore())
+	return cmdtesting.RunCommand(c, command, append(args, "--dry-run")...)
+}
+
+func (s *AddRelationSuite) TestAddRelationDryRun(c *gc.C) {
+	s.mockAPI.preview = params.RelationPreviewResult{
+		Key: "wordpress:db mysql:server",
+		Endpoints: map[string]params.CharmRelation{
+			"wordpress": {Name: "db", Role: "requirer", Interface: "mysql", Scope: "global", Limit: 1},
+			"mysql":     {Name: "server", Role: "provider", Interface: "mysql", Scope: "global"},
+		},
+		Units: []string{"mysql/0", "wordpress/0"},
+	}
+	ctx, err := s.runAddRelationDryRun(c, "wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+relation "wordpress:db mysql:server" can be added
+  mysql:server (provider, interface mysql, global scope)
+  wordpress:db (requirer, interface mysql, global scope, limit 1)
+units that would enter scope: mysql/0, wordpress/0
+`[1:])
+	s.mockAPI.CheckCallNames(c, "PreviewAddRelation", "Close")
+	s.mockAPI.CheckCall(c, 0, "PreviewAddRelation", []string{"wordpress", "mysql"})
+}
+
+func (s *AddRelationSuite) TestAddRelationDryRunProblems(c *gc.C) {
+	s.mockAPI.preview = params.RelationPreviewResult{
+		Key:      "wordpress:db mysql:server",
+		Problems: []string{`relation "wordpress:db mysql:server" already exists`},
+	}
+	ctx, err := s.runAddRelationDryRun(c, "wordpress", "mysql")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+relation "wordpress:db mysql:server" cannot be added:
+  - relation "wordpress:db mysql:server" already exists
+`[1:])
+}
+
+func (s *AddRelationSuite) TestAddRelationDryRunNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("previewing relations"))
+	_, err := s.runAddRelationDryRun(c, "wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --dry-run")
+}
+
+func (s *AddRelationSuite) TestAddRelationDryRunRemote(c *gc.C) {
+	_, err := s.runAddRelationDryRun(c, "wordpress", "fred/model.mysql")
+	c.Assert(err, gc.ErrorMatches, "--dry-run cannot be used when relating to offers in a different model")
+	s.mockAPI.CheckNoCalls(c)
+}
+
 type mockAddAPI struct {
 	*testing.Stub
 	addRelationFunc func(endpoints, viaCIDRs []string) (*params.AddRelationResults, error)
+	preview         params.RelationPreviewResult
 }
 
 func (s mockAddAPI) Close() error {
@@ -107,6 +161,11 @@ func (s mockAddAPI) AddRelation(endpoints, viaCIDRs []string) (*params.AddRelati
 	return s.addRelationFunc(endpoints, viaCIDRs)
 }
 
+func (s mockAddAPI) PreviewAddRelation(endpoints ...string) (params.RelationPreviewResult, error) {
+	s.MethodCall(s, "PreviewAddRelation", endpoints)
+	return s.preview, s.NextErr()
+}
+
 func (s mockAddAPI) BestAPIVersion() int {
 	s.MethodCall(s, "BestAPIVersion")
 	return 4
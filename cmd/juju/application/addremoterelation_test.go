@@ -224,6 +224,11 @@ func (m *mockAddRelationAPI) AddRelation(endpoints, viaCIDRs []string) (*params.
 	return m.addRelation(endpoints, viaCIDRs)
 }
 
+func (m *mockAddRelationAPI) PreviewAddRelation(endpoints ...string) (params.RelationPreviewResult, error) {
+	m.AddCall("PreviewAddRelation", endpoints)
+	return params.RelationPreviewResult{}, m.NextErr()
+}
+
 func (m *mockAddRelationAPI) Close() error {
 	m.AddCall("Close")
 	return nil
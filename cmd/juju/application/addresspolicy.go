@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageAddressPolicySummary = `
+Gets or sets how the addresses of an application's units are chosen.`[1:]
+
+var usageAddressPolicyDetails = `
+The private and public addresses of a unit, as seen by charms and shown
+in status, are by default the preferred addresses of the unit's
+machine. On machines with several network interfaces these may not be
+the addresses the application should use. An application's address
+policy chooses among its machines' addresses instead.
+
+With no key=value pairs, the application's policy is shown. With
+key=value pairs, the application's policy is replaced by the given one.
+The --reset option restores the default choice of addresses.
+
+The policy keys are:
+
+    prefer       the kind of address to prefer: "provider" for
+                 addresses assigned by the cloud, "bridge" for
+                 addresses on bridge devices, or "fan" for addresses
+                 on fan overlay networks
+    prefer-ipv6  whether to prefer IPv6 addresses over IPv4
+    space        the space whose addresses to prefer
+
+A preferred space outweighs a preferred kind of address, which in turn
+outweighs a preference for IPv6. Addresses that are not suitable as a
+private or public address are never chosen as one.
+
+Examples:
+    juju address-policy mysql
+    juju address-policy mysql prefer=fan
+    juju address-policy mysql space=storage prefer-ipv6=true
+    juju address-policy mysql --reset
+
+See also:
+    spaces`[1:]
+
+// NewAddressPolicyCommand returns a command to get or set the address
+// policy of an application.
+func NewAddressPolicyCommand() cmd.Command {
+	cmd := &addressPolicyCommand{}
+	cmd.newAPIFunc = func() (AddressPolicyAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// AddressPolicyAPI defines the API methods that the address-policy
+// command uses.
+type AddressPolicyAPI interface {
+	Close() error
+	GetAddressPolicy(application string) (params.AddressPolicy, error)
+	SetAddressPolicy(application string, policy params.AddressPolicy) error
+}
+
+type addressPolicyCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	newAPIFunc func() (AddressPolicyAPI, error)
+
+	applicationName string
+	policy          *params.AddressPolicy
+	reset           bool
+}
+
+// AddressPolicy is the output format of an application's address policy.
+type AddressPolicy struct {
+	Prefer     string `yaml:"prefer,omitempty" json:"prefer,omitempty"`
+	PreferIPv6 bool   `yaml:"prefer-ipv6,omitempty" json:"prefer-ipv6,omitempty"`
+	Space      string `yaml:"space,omitempty" json:"space,omitempty"`
+}
+
+func (c *addressPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "address-policy",
+		Args:    "<application name> [<key>=<value> ...]",
+		Purpose: usageAddressPolicySummary,
+		Doc:     usageAddressPolicyDetails,
+	}
+}
+
+func (c *addressPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.BoolVar(&c.reset, "reset", false, "Restore the default choice of addresses")
+}
+
+func (c *addressPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	args = args[1:]
+	if len(args) == 0 {
+		if c.reset {
+			c.policy = &params.AddressPolicy{}
+		}
+		return nil
+	}
+	if c.reset {
+		return errors.New("cannot specify --reset with key=value pairs")
+	}
+	values, err := keyvalues.Parse(args, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var policy params.AddressPolicy
+	for key, value := range values {
+		switch key {
+		case "prefer":
+			policy.Prefer = value
+		case "prefer-ipv6":
+			policy.PreferIPv6, err = strconv.ParseBool(value)
+			if err != nil {
+				return errors.NotValidf("prefer-ipv6 value %q", value)
+			}
+		case "space":
+			policy.Space = value
+		default:
+			return errors.NotValidf("address policy key %q", key)
+		}
+	}
+	c.policy = &policy
+	return nil
+}
+
+func (c *addressPolicyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.policy == nil {
+		policy, err := client.GetAddressPolicy(c.applicationName)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support address policies")
+		} else if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, AddressPolicy{
+			Prefer:     policy.Prefer,
+			PreferIPv6: policy.PreferIPv6,
+			Space:      policy.Space,
+		})
+	}
+	err = client.SetAddressPolicy(c.applicationName, *c.policy)
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support address policies")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type AddressPolicySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockAddressPolicyAPI
+}
+
+var _ = gc.Suite(&AddressPolicySuite{})
+
+func (s *AddressPolicySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockAddressPolicyAPI{
+		Stub: &testing.Stub{},
+		policy: params.AddressPolicy{
+			Prefer: "fan",
+			Space:  "storage",
+		},
+	}
+}
+
+func (s *AddressPolicySuite) runAddressPolicy(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewAddressPolicyCommandForTest(s.mockAPI, NewMockStore()), args...)
+}
+
+func (s *AddressPolicySuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "prefer"},
+		err:  `expected "key=value", got "prefer"`,
+	}, {
+		args: []string{"mysql", "colour=blue"},
+		err:  `address policy key "colour" not valid`,
+	}, {
+		args: []string{"mysql", "prefer-ipv6=maybe"},
+		err:  `prefer-ipv6 value "maybe" not valid`,
+	}, {
+		args: []string{"mysql", "--reset", "prefer=fan"},
+		err:  "cannot specify --reset with key=value pairs",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := s.runAddressPolicy(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *AddressPolicySuite) TestGet(c *gc.C) {
+	ctx, err := s.runAddressPolicy(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+prefer: fan
+space: storage
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"GetAddressPolicy", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *AddressPolicySuite) TestGetJSON(c *gc.C) {
+	ctx, err := s.runAddressPolicy(c, "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"prefer":"fan","space":"storage"}`+"\n")
+}
+
+func (s *AddressPolicySuite) TestSet(c *gc.C) {
+	_, err := s.runAddressPolicy(c, "mysql", "prefer=provider", "prefer-ipv6=true")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetAddressPolicy", []interface{}{"mysql", params.AddressPolicy{
+			Prefer:     "provider",
+			PreferIPv6: true,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AddressPolicySuite) TestReset(c *gc.C) {
+	_, err := s.runAddressPolicy(c, "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetAddressPolicy", []interface{}{"mysql", params.AddressPolicy{}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AddressPolicySuite) TestSetError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`address kind "carrier-pigeon" not valid`))
+	_, err := s.runAddressPolicy(c, "mysql", "prefer=carrier-pigeon")
+	c.Assert(err, gc.ErrorMatches, `address kind "carrier-pigeon" not valid`)
+}
+
+func (s *AddressPolicySuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("address policy"))
+	_, err := s.runAddressPolicy(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support address policies")
+}
+
+type mockAddressPolicyAPI struct {
+	*testing.Stub
+	policy params.AddressPolicy
+}
+
+func (m *mockAddressPolicyAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockAddressPolicyAPI) GetAddressPolicy(application string) (params.AddressPolicy, error) {
+	m.MethodCall(m, "GetAddressPolicy", application)
+	return m.policy, m.NextErr()
+}
+
+func (m *mockAddressPolicyAPI) SetAddressPolicy(application string, policy params.AddressPolicy) error {
+	m.MethodCall(m, "SetAddressPolicy", application, policy)
+	return m.NextErr()
+}
@@ -4,6 +4,7 @@
 package application
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -68,6 +69,10 @@ Add a unit of mariadb to LXD container number 3 on machine 24:
 Add a unit of mariadb to LXD container on a new machine:
     juju add-unit mariadb --to lxd
 
+Show which existing machines would be reused, and how many new
+machines would be created, when adding five units of wordpress:
+    juju add-unit wordpress -n 5 --dry-run
+
 See also: 
     remove-unit`[1:]
 
@@ -139,6 +144,7 @@ type addUnitCommand struct {
 	modelcmd.ModelCommandBase
 	UnitCommandBase
 	ApplicationName string
+	DryRun          bool
 	api             serviceAddUnitAPI
 }
 
@@ -154,6 +160,7 @@ func (c *addUnitCommand) Info() *cmd.Info {
 func (c *addUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.UnitCommandBase.SetFlags(f)
 	f.IntVar(&c.NumUnits, "n", 1, "Number of units to add")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show where the units would be placed without adding them")
 }
 
 func (c *addUnitCommand) Init(args []string) error {
@@ -166,6 +173,9 @@ func (c *addUnitCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[1:]); err != nil {
 		return err
 	}
+	if c.DryRun && len(c.AttachStorage) > 0 {
+		return errors.New("--dry-run cannot be used with --attach-storage")
+	}
 	return c.UnitCommandBase.Init(args)
 }
 
@@ -176,6 +186,7 @@ type serviceAddUnitAPI interface {
 	Close() error
 	ModelUUID() string
 	AddUnits(application.AddUnitsParams) ([]string, error)
+	PreviewAddUnits(application.AddUnitsParams) (params.AddApplicationUnitsPreviewResult, error)
 }
 
 func (c *addUnitCommand) getAPI() (serviceAddUnitAPI, error) {
@@ -210,6 +221,9 @@ func (c *addUnitCommand) Run(ctx *cmd.Context) error {
 		}
 		c.Placement[i] = p
 	}
+	if c.DryRun {
+		return c.previewAddUnits(ctx, apiclient)
+	}
 	_, err = apiclient.AddUnits(application.AddUnitsParams{
 		ApplicationName: c.ApplicationName,
 		NumUnits:        c.NumUnits,
@@ -222,6 +236,46 @@ func (c *addUnitCommand) Run(ctx *cmd.Context) error {
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
+// previewAddUnits reports where the requested units would be placed.
+func (c *addUnitCommand) previewAddUnits(ctx *cmd.Context, apiclient serviceAddUnitAPI) error {
+	result, err := apiclient.PreviewAddUnits(application.AddUnitsParams{
+		ApplicationName: c.ApplicationName,
+		NumUnits:        c.NumUnits,
+		Placement:       c.Placement,
+	})
+	if errors.IsNotSupported(err) {
+		return errors.New("this juju controller does not support --dry-run")
+	}
+	if params.IsCodeUnauthorized(err) {
+		common.PermissionsMessage(ctx.Stderr, "preview adding a unit")
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, unit := range result.Units {
+		fmt.Fprintf(ctx.Stdout, "unit %d: %s\n", i+1, describeUnitPlacement(unit))
+	}
+	fmt.Fprintf(ctx.Stdout, "%d existing machine(s) would be reused, %d new machine(s) would be created\n",
+		len(result.ExistingMachines), result.NewMachines)
+	return nil
+}
+
+// describeUnitPlacement returns a human readable description of
+// where a unit would be placed.
+func describeUnitPlacement(p params.UnitPlacementPreview) string {
+	switch {
+	case p.MachineId != "":
+		return fmt.Sprintf("existing machine %s", p.MachineId)
+	case p.ContainerType != "" && p.ParentId != "":
+		return fmt.Sprintf("new %s container on machine %s", p.ContainerType, p.ParentId)
+	case p.ContainerType != "":
+		return fmt.Sprintf("new %s container on a new machine", p.ContainerType)
+	case p.Directive != "":
+		return fmt.Sprintf("new machine (%s)", p.Directive)
+	}
+	return "new machine"
+}
+
 // deployTarget describes the format a machine or container target must match to be valid.
 const deployTarget = "^(" + names.ContainerTypeSnippet + ":)?" + names.MachineSnippet + "$"
 
@@ -61,6 +61,25 @@ func (f *fakeServiceAddUnitAPI) AddUnits(args apiapplication.AddUnitsParams) ([]
 	return nil, nil
 }
 
+func (f *fakeServiceAddUnitAPI) PreviewAddUnits(args apiapplication.AddUnitsParams) (params.AddApplicationUnitsPreviewResult, error) {
+	if f.err != nil {
+		return params.AddApplicationUnitsPreviewResult{}, f.err
+	}
+	if args.ApplicationName != f.application {
+		return params.AddApplicationUnitsPreviewResult{}, errors.NotFoundf("application %q", args.ApplicationName)
+	}
+	f.placement = args.Placement
+	return params.AddApplicationUnitsPreviewResult{
+		Units: []params.UnitPlacementPreview{
+			{MachineId: "1"},
+			{ParentId: "2", ContainerType: "lxd"},
+			{},
+		},
+		ExistingMachines: []string{"1"},
+		NewMachines:      2,
+	}, nil
+}
+
 func (f *fakeServiceAddUnitAPI) ModelGet() (map[string]interface{}, error) {
 	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
 		"type": f.envType,
@@ -222,3 +241,22 @@ func (s *AddUnitSuite) TestNameChecks(c *gc.C) {
 	assertMachineOrNewContainer("0/lxd/10", true)
 	assertMachineOrNewContainer("0/kvm/4", true)
 }
+
+func (s *AddUnitSuite) TestAddUnitDryRun(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewAddUnitCommandForTest(s.fake), "some-application-name", "-n", "3", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+unit 1: existing machine 1
+unit 2: new lxd container on machine 2
+unit 3: new machine
+1 existing machine(s) would be reused, 2 new machine(s) would be created
+`[1:])
+	// No units were added.
+	c.Assert(s.fake.numUnits, gc.Equals, 1)
+}
+
+func (s *AddUnitSuite) TestAddUnitDryRunAttachStorage(c *gc.C) {
+	err := cmdtesting.InitCommand(application.NewAddUnitCommandForTest(s.fake),
+		[]string{"some-application-name", "--dry-run", "--attach-storage", "foo/0"})
+	c.Assert(err, gc.ErrorMatches, "--dry-run cannot be used with --attach-storage")
+}
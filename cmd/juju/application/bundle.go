@@ -64,6 +64,8 @@ func deployBundle(
 	apiRoot DeployAPI,
 	ctx *cmd.Context,
 	bundleStorage map[string]map[string]storage.Constraints,
+	useExistingMachines bool,
+	bundleMachines map[string]string,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 
 	if err := processBundleConfig(data, bundleConfigFile); err != nil {
@@ -117,6 +119,11 @@ func deployBundle(
 			unitStatus[unit] = unitData.Machine
 		}
 	}
+	machineChanges := bundleMachineChanges(data, changes)
+	machineMap, err := bundleMachineMap(data, status, machineChanges, useExistingMachines, bundleMachines)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot map bundle machines")
+	}
 
 	// Instantiate a watcher used to follow the deployment progress.
 	watcher, err := apiRoot.WatchAll()
@@ -139,6 +146,8 @@ func deployBundle(
 		ignoredMachines: make(map[string]bool, len(data.Applications)),
 		ignoredUnits:    make(map[string]bool, len(data.Applications)),
 		watcher:         watcher,
+		machineMap:      machineMap,
+		machineChanges:  machineChanges,
 	}
 
 	// Deploy the bundle.
@@ -244,6 +253,15 @@ type bundleHandler struct {
 	// LXD.  This flag keeps us from writing the warning more than once per
 	// bundle.
 	warnedLXC bool
+
+	// machineMap maps bundle machine ids to the ids of the existing model
+	// machines that are used in their place, as requested with the deploy
+	// --map-machines flag.
+	machineMap map[string]string
+
+	// machineChanges maps the ids of the "addMachine" changes that
+	// create the machines defined in the bundle to the bundle machine ids.
+	machineChanges map[string]string
 }
 
 // addCharm adds a charm to the environment.
@@ -452,6 +470,12 @@ func (h *bundleHandler) addMachine(id string, p bundlechanges.AddMachineParams)
 	if svcLen != 1 {
 		msg = strings.Join(services[:svcLen-1], ", ") + " and " + services[svcLen-1] + " units"
 	}
+	// Use an existing machine if the bundle machine has been mapped to one.
+	if machine, ok := h.machineMap[h.machineChanges[id]]; ok {
+		h.results[id] = machine
+		h.ctx.Infof("use existing machine %s (bundle machine %s) for holding %s", machine, h.machineChanges[id], msg)
+		return nil
+	}
 	// Check whether the desired number of units already exist in the
 	// environment, in which case avoid adding other machines to host those
 	// application units.
@@ -800,6 +824,141 @@ func (h *bundleHandler) resolveMachine(placeholder string) (string, error) {
 	return h.unitStatus[machineOrUnit], nil
 }
 
+// parseMachineMap parses the value of the deploy --map-machines flag: a
+// comma-separated list of bundle-machine=model-machine mappings, which
+// may also include "existing" to map each bundle machine to the model
+// machine with the same id, where there is one.
+func parseMachineMap(value string) (useExisting bool, mapping map[string]string, err error) {
+	mapping = make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "existing" {
+			useExisting = true
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return false, nil, errors.Errorf("expected \"existing\" or \"<bundle-machine>=<machine>\", got %q", item)
+		}
+		bundleMachine, machine := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if !names.IsValidMachine(bundleMachine) || names.IsContainerMachine(bundleMachine) {
+			return false, nil, errors.NotValidf("bundle machine %q", bundleMachine)
+		}
+		if !names.IsValidMachine(machine) {
+			return false, nil, errors.NotValidf("machine %q", machine)
+		}
+		if _, ok := mapping[bundleMachine]; ok {
+			return false, nil, errors.Errorf("bundle machine %q mapped more than once", bundleMachine)
+		}
+		mapping[bundleMachine] = machine
+	}
+	return useExisting, mapping, nil
+}
+
+// bundleMachineMap returns the existing model machines to use in place of
+// the bundle's machines, keyed by bundle machine id. Explicit mappings
+// take precedence over those implied by useExisting. Only the bundle
+// machines holding units, as found in machineChanges, can be mapped.
+func bundleMachineMap(
+	data *charm.BundleData,
+	status *params.FullStatus,
+	machineChanges map[string]string,
+	useExisting bool,
+	mapping map[string]string,
+) (map[string]string, error) {
+	existing := make(map[string]bool)
+	for id, m := range status.Machines {
+		existing[id] = true
+		for containerId := range m.Containers {
+			existing[containerId] = true
+		}
+	}
+	placed := make(map[string]bool)
+	for _, bundleMachine := range machineChanges {
+		placed[bundleMachine] = true
+	}
+	result := make(map[string]string)
+	if useExisting {
+		for bundleMachine := range data.Machines {
+			if existing[bundleMachine] && placed[bundleMachine] {
+				result[bundleMachine] = bundleMachine
+			}
+		}
+	}
+	for bundleMachine, machine := range mapping {
+		if _, ok := data.Machines[bundleMachine]; !ok {
+			return nil, errors.NotFoundf("bundle machine %q", bundleMachine)
+		}
+		if !placed[bundleMachine] {
+			return nil, errors.Errorf("bundle machine %q holds no units and cannot be mapped", bundleMachine)
+		}
+		if !existing[machine] {
+			return nil, errors.NotFoundf("machine %q", machine)
+		}
+		result[bundleMachine] = machine
+	}
+	return result, nil
+}
+
+// bundleMachineChanges returns the bundle machine ids keyed by the ids of
+// the "addMachine" changes creating them. The "addMachine" change for a
+// bundle machine is found by following the placeholders of the units placed
+// on that machine, or on a container inside it. Bundle machines holding no
+// units cannot be told apart, so they are not included.
+func bundleMachineChanges(data *charm.BundleData, changes []bundlechanges.Change) map[string]string {
+	byId := make(map[string]bundlechanges.Change, len(changes))
+	for _, change := range changes {
+		byId[change.Id()] = change
+	}
+	// Collect the unit changes of each application, in the order the
+	// units are added.
+	units := make(map[string][]*bundlechanges.AddUnitChange)
+	for _, change := range changes {
+		unit, ok := change.(*bundlechanges.AddUnitChange)
+		if !ok {
+			continue
+		}
+		application, ok := byId[strings.TrimPrefix(unit.Params.Application, "$")].(*bundlechanges.AddApplicationChange)
+		if !ok {
+			continue
+		}
+		name := application.Params.Application
+		units[name] = append(units[name], unit)
+	}
+	result := make(map[string]string)
+	for name, application := range data.Applications {
+		if len(application.To) == 0 {
+			continue
+		}
+		for i, unit := range units[name] {
+			// Units beyond the placement directives use the last one.
+			to := application.To[len(application.To)-1]
+			if i < len(application.To) {
+				to = application.To[i]
+			}
+			placement, err := charm.ParsePlacement(to)
+			if err != nil || placement.Application != "" {
+				continue
+			}
+			if _, ok := data.Machines[placement.Machine]; !ok {
+				continue
+			}
+			id := strings.TrimPrefix(unit.Params.To, "$")
+			if placement.ContainerType != "" {
+				container, ok := byId[id].(*bundlechanges.AddMachineChange)
+				if !ok {
+					continue
+				}
+				id = strings.TrimPrefix(container.Params.ParentId, "$")
+			}
+			if _, ok := byId[id].(*bundlechanges.AddMachineChange); ok {
+				result[id] = placement.Machine
+			}
+		}
+	}
+	return result
+}
+
 // resolveRelation returns the relation name resolving the included application
 // placeholder.
 func resolveRelation(e string, results map[string]string) string {
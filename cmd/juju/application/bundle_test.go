@@ -51,6 +51,8 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidFlags(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "flags provided but not supported when deploying a bundle: -n")
 	_, err = runDeploy(c, "bundle/wordpress-simple", "--series", "xenial", "--force")
 	c.Assert(err, gc.ErrorMatches, "flags provided but not supported when deploying a bundle: --force, --series")
+	_, err = runDeploy(c, "bundle/wordpress-simple", "--map-machines", "foo")
+	c.Assert(err, gc.ErrorMatches, `invalid --map-machines parameter: expected "existing" or "<bundle-machine>=<machine>", got "foo"`)
 }
 
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleSuccess(c *gc.C) {
@@ -1139,6 +1141,152 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleMachineAttributes(c *gc.C)
 	c.Assert(ann, jc.DeepEquals, map[string]string{"foo": "bar"})
 }
 
+const mapMachinesBundle = `
+        applications:
+            django:
+                charm: cs:xenial/django-42
+                num_units: 3
+                to:
+                    - 1
+                    - 2
+                    - lxd:2
+        machines:
+            1:
+                series: xenial
+            2:
+                series: xenial
+    `
+
+func (s *BundleDeployCharmStoreSuite) addExistingMachines(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		_, err := s.State.AddMachine("xenial", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleMapMachinesExisting(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/django-42", "dummy")
+	s.addExistingMachines(c, 2)
+	_, err := s.DeployBundleYAML(c, mapMachinesBundle, "--map-machines", "existing")
+	c.Assert(err, jc.ErrorIsNil)
+	// Bundle machine 1 is placed on machine 1, while bundle
+	// machine 2 does not exist in the model and is created.
+	s.assertUnitsCreated(c, map[string]string{
+		"django/0": "1",
+		"django/1": "2",
+		"django/2": "2/lxd/0",
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleMapMachinesExplicit(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/django-42", "dummy")
+	s.addExistingMachines(c, 2)
+	_, err := s.DeployBundleYAML(c, mapMachinesBundle, "--map-machines", "existing,2=0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitsCreated(c, map[string]string{
+		"django/0": "1",
+		"django/1": "0",
+		"django/2": "0/lxd/0",
+	})
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 2)
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleMapMachinesFollowsPlacement(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/django-42", "dummy")
+	s.addExistingMachines(c, 2)
+	_, err := s.DeployBundleYAML(c, `
+        applications:
+            django:
+                charm: cs:xenial/django-42
+                num_units: 2
+                to:
+                    - 3
+                    - lxd:1
+        machines:
+            1:
+                series: xenial
+            2:
+                series: xenial
+            3:
+                series: xenial
+    `, "--map-machines", "3=0,1=1")
+	c.Assert(err, jc.ErrorIsNil)
+	// Bundle machine 2 holds no units and is created as a new machine.
+	s.assertUnitsCreated(c, map[string]string{
+		"django/0": "0",
+		"django/1": "1/lxd/0",
+	})
+	_, err = s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleMapMachinesErrors(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/django-42", "dummy")
+	s.addExistingMachines(c, 1)
+	_, err := s.DeployBundleYAML(c, mapMachinesBundle, "--map-machines", "3=0")
+	c.Assert(err, gc.ErrorMatches, `cannot map bundle machines: bundle machine "3" not found`)
+	_, err = s.DeployBundleYAML(c, mapMachinesBundle, "--map-machines", "1=42")
+	c.Assert(err, gc.ErrorMatches, `cannot map bundle machines: machine "42" not found`)
+	_, err = s.DeployBundleYAML(c, `
+        applications:
+            django:
+                charm: cs:xenial/django-42
+                num_units: 1
+                to:
+                    - 1
+        machines:
+            1:
+                series: xenial
+            2:
+                series: xenial
+    `, "--map-machines", "2=0")
+	c.Assert(err, gc.ErrorMatches, `cannot map bundle machines: bundle machine "2" holds no units and cannot be mapped`)
+}
+
+func (s *BundleDeployCharmStoreSuite) TestParseMachineMap(c *gc.C) {
+	for i, t := range []struct {
+		value    string
+		existing bool
+		mapping  map[string]string
+		err      string
+	}{{
+		value:    "existing",
+		existing: true,
+		mapping:  map[string]string{},
+	}, {
+		value:   "1=4, 2=5/lxd/0",
+		mapping: map[string]string{"1": "4", "2": "5/lxd/0"},
+	}, {
+		value:    "2=5,existing",
+		existing: true,
+		mapping:  map[string]string{"2": "5"},
+	}, {
+		value: "new",
+		err:   `expected "existing" or "<bundle-machine>=<machine>", got "new"`,
+	}, {
+		value: "1/lxd/0=4",
+		err:   `bundle machine "1/lxd/0" not valid`,
+	}, {
+		value: "1=foo",
+		err:   `machine "foo" not valid`,
+	}, {
+		value: "1=4,1=5",
+		err:   `bundle machine "1" mapped more than once`,
+	}} {
+		c.Logf("test %d: %q", i, t.value)
+		existing, mapping, err := parseMachineMap(t.value)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(existing, gc.Equals, t.existing)
+		c.Check(mapping, jc.DeepEquals, t.mapping)
+	}
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleTwiceScaleUp(c *gc.C) {
 	testcharms.UploadCharm(c, s.client, "xenial/django-42", "dummy")
 	_, err := s.DeployBundleYAML(c, `
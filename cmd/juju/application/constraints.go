@@ -6,6 +6,7 @@ package application
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -13,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
@@ -29,10 +31,16 @@ Application constraints are combined with model constraints, set with ` +
 machines for applications. Where model and application constraints overlap, the
 application constraints take precedence.
 Constraints for a specific model can be viewed with ` + "`juju get-model-\nconstraints`" + `.
+The --effective option shows how the model and application constraints
+combine to produce the constraints used when provisioning machines for the
+application, including which model constraints are inherited or overridden,
+and which constraints are not supported by the model's cloud and will be
+ignored.
 
 Examples:
     juju get-constraints mysql
     juju get-constraints -m mymodel apache2
+    juju get-constraints --effective mysql
 
 See also: 
     set-constraints
@@ -57,10 +65,16 @@ This command requires that the application to have at least one unit. To apply
 constraints to
 the first unit set them at the model level or pass them as an argument
 when deploying.
+Setting constraints replaces all of the application's existing constraints.
+Setting a constraint to an empty value (e.g. "mem=") overrides the model
+constraint; to remove individual constraints instead, so that the model
+constraints apply again, use --unset with a comma-separated list of
+constraint names.
 
 Examples:
     juju set-constraints mysql mem=8G cores=4
     juju set-constraints -m mymodel apache2 mem=8G arch=amd64
+    juju set-constraints mysql --unset mem,cores
 
 See also: 
     get-constraints
@@ -75,6 +89,7 @@ func NewServiceGetConstraintsCommand() modelcmd.ModelCommand {
 type serviceConstraintsAPI interface {
 	Close() error
 	GetConstraints(...string) ([]constraints.Value, error)
+	GetEffectiveConstraints(string) (params.ApplicationEffectiveConstraints, error)
 	SetConstraints(string, constraints.Value) error
 }
 
@@ -98,6 +113,7 @@ func (c *serviceConstraintsCommand) getAPI() (serviceConstraintsAPI, error) {
 
 type serviceGetConstraintsCommand struct {
 	serviceConstraintsCommand
+	effective bool
 }
 
 func (c *serviceGetConstraintsCommand) Info() *cmd.Info {
@@ -109,13 +125,47 @@ func (c *serviceGetConstraintsCommand) Info() *cmd.Info {
 	}
 }
 
+// effectiveConstraints describes how an application's constraints
+// combine with the model constraints.
+type effectiveConstraints struct {
+	Model       constraints.Value `yaml:"model" json:"model"`
+	Application constraints.Value `yaml:"application" json:"application"`
+	Effective   constraints.Value `yaml:"effective" json:"effective"`
+	Inherited   []string          `yaml:"inherited,omitempty" json:"inherited,omitempty"`
+	Overridden  []string          `yaml:"overridden,omitempty" json:"overridden,omitempty"`
+	Unsupported []string          `yaml:"unsupported,omitempty" json:"unsupported,omitempty"`
+}
+
 func formatConstraints(writer io.Writer, value interface{}) error {
+	if effective, ok := value.(effectiveConstraints); ok {
+		return formatEffectiveConstraints(writer, effective)
+	}
 	fmt.Fprint(writer, value.(constraints.Value).String())
 	return nil
 }
 
+func formatEffectiveConstraints(writer io.Writer, value effectiveConstraints) error {
+	line := func(label, value string) {
+		fmt.Fprintf(writer, "%-13s%s\n", label+":", value)
+	}
+	line("model", value.Model.String())
+	line("application", value.Application.String())
+	line("effective", value.Effective.String())
+	if len(value.Inherited) > 0 {
+		line("inherited", strings.Join(value.Inherited, ","))
+	}
+	if len(value.Overridden) > 0 {
+		line("overridden", strings.Join(value.Overridden, ","))
+	}
+	if len(value.Unsupported) > 0 {
+		line("unsupported", strings.Join(value.Unsupported, ","))
+	}
+	return nil
+}
+
 func (c *serviceGetConstraintsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.effective, "effective", false, "Show the effective constraints, combining the model and application constraints")
 	c.out.AddFlags(f, "constraints", map[string]cmd.Formatter{
 		"constraints": formatConstraints,
 		"yaml":        cmd.FormatYaml,
@@ -142,6 +192,23 @@ func (c *serviceGetConstraintsCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.effective {
+		result, err := apiclient.GetEffectiveConstraints(c.ApplicationName)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support reporting effective constraints")
+		} else if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, effectiveConstraints{
+			Model:       result.Model,
+			Application: result.Application,
+			Effective:   result.Effective,
+			Inherited:   result.Inherited,
+			Overridden:  result.Overridden,
+			Unsupported: result.Unsupported,
+		})
+	}
+
 	cons, err := apiclient.GetConstraints(c.ApplicationName)
 	if err != nil {
 		return err
@@ -152,6 +219,8 @@ func (c *serviceGetConstraintsCommand) Run(ctx *cmd.Context) error {
 type serviceSetConstraintsCommand struct {
 	serviceConstraintsCommand
 	Constraints constraints.Value
+	unset       string
+	unsetNames  []string
 }
 
 // NewServiceSetConstraintsCommand returns a command which sets application constraints.
@@ -168,6 +237,11 @@ func (c *serviceSetConstraintsCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *serviceSetConstraintsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.unset, "unset", "", "Remove the named constraints, in a comma-separated list")
+}
+
 func (c *serviceSetConstraintsCommand) Init(args []string) (err error) {
 	if len(args) == 0 {
 		return errors.Errorf("no application name specified")
@@ -178,6 +252,16 @@ func (c *serviceSetConstraintsCommand) Init(args []string) (err error) {
 
 	c.ApplicationName, args = args[0], args[1:]
 
+	if c.unset != "" {
+		if len(args) > 0 {
+			return errors.New("cannot set and unset constraints at the same time")
+		}
+		c.unsetNames = strings.Split(c.unset, ",")
+		// Check that the names are valid before contacting the controller.
+		_, err := constraints.Value{}.Unset(c.unsetNames...)
+		return err
+	}
+
 	c.Constraints, err = constraints.Parse(args...)
 	return err
 }
@@ -189,6 +273,16 @@ func (c *serviceSetConstraintsCommand) Run(_ *cmd.Context) (err error) {
 	}
 	defer apiclient.Close()
 
-	err = apiclient.SetConstraints(c.ApplicationName, c.Constraints)
+	cons := c.Constraints
+	if len(c.unsetNames) > 0 {
+		current, err := apiclient.GetConstraints(c.ApplicationName)
+		if err != nil {
+			return err
+		}
+		if cons, err = current[0].Unset(c.unsetNames...); err != nil {
+			return err
+		}
+	}
+	err = apiclient.SetConstraints(c.ApplicationName, cons)
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
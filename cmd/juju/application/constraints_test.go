@@ -5,10 +5,13 @@ package application_test
 
 import (
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/testing"
 )
 
@@ -39,6 +42,14 @@ func (s *ServiceConstraintsCommandsSuite) TestSetInit(c *gc.C) {
 		err:  `invalid application name "cpu-power=250"`,
 	}, {
 		args: []string{"mysql", "cpu-power=250"},
+	}, {
+		args: []string{"mysql", "--unset", "mem,cores"},
+	}, {
+		args: []string{"mysql", "--unset", "mem,floors"},
+		err:  `unknown constraint "floors"`,
+	}, {
+		args: []string{"mysql", "--unset", "mem", "cores=2"},
+		err:  `cannot set and unset constraints at the same time`,
 	}} {
 		cmd := application.NewServiceSetConstraintsCommand()
 		cmd.SetClientStore(application.NewMockStore())
@@ -82,3 +93,65 @@ func (s *ServiceConstraintsCommandsSuite) TestGetInit(c *gc.C) {
 		}
 	}
 }
+
+type fakeConstraintsAPI struct {
+	cons      constraints.Value
+	effective params.ApplicationEffectiveConstraints
+	err       error
+}
+
+func (f *fakeConstraintsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeConstraintsAPI) GetConstraints(apps ...string) ([]constraints.Value, error) {
+	return []constraints.Value{f.cons}, nil
+}
+
+func (f *fakeConstraintsAPI) GetEffectiveConstraints(app string) (params.ApplicationEffectiveConstraints, error) {
+	return f.effective, f.err
+}
+
+func (f *fakeConstraintsAPI) SetConstraints(app string, cons constraints.Value) error {
+	f.cons = cons
+	return nil
+}
+
+func (s *ServiceConstraintsCommandsSuite) TestGetEffective(c *gc.C) {
+	api := &fakeConstraintsAPI{
+		effective: params.ApplicationEffectiveConstraints{
+			Model:       constraints.MustParse("mem=4G cores=2 cpu-power=100"),
+			Application: constraints.MustParse("cores=4"),
+			Effective:   constraints.MustParse("mem=4G cores=4 cpu-power=100"),
+			Inherited:   []string{"cpu-power", "mem"},
+			Overridden:  []string{"cores"},
+			Unsupported: []string{"cpu-power"},
+		},
+	}
+	cmd := application.NewServiceGetConstraintsCommandForTest(api, application.NewMockStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "mysql", "--effective")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+model:       cores=2 cpu-power=100 mem=4096M
+application: cores=4
+effective:   cores=4 cpu-power=100 mem=4096M
+inherited:   cpu-power,mem
+overridden:  cores
+unsupported: cpu-power
+`[1:])
+}
+
+func (s *ServiceConstraintsCommandsSuite) TestGetEffectiveNotSupported(c *gc.C) {
+	api := &fakeConstraintsAPI{err: errors.NotSupportedf("reporting effective constraints")}
+	cmd := application.NewServiceGetConstraintsCommandForTest(api, application.NewMockStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "mysql", "--effective")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support reporting effective constraints")
+}
+
+func (s *ServiceConstraintsCommandsSuite) TestSetUnset(c *gc.C) {
+	api := &fakeConstraintsAPI{cons: constraints.MustParse("mem=4G cores=2 arch=amd64")}
+	cmd := application.NewServiceSetConstraintsCommandForTest(api, application.NewMockStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "mysql", "--unset", "mem,cores")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.cons, jc.DeepEquals, constraints.MustParse("arch=amd64"))
+}
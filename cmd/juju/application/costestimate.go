@@ -0,0 +1,312 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/bundlechanges"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	apiparams "github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+// CostEstimatorAPI provides access to the cost estimates used by
+// "juju deploy --estimate-cost".
+type CostEstimatorAPI interface {
+	EstimateCost(apiparams.CostEstimateArgs) (apiparams.CostEstimateResult, error)
+}
+
+// estimateCost writes the estimated hourly cost of provisioning the
+// given machines and storage to the context's stdout.
+func estimateCost(ctx *cmd.Context, apiRoot DeployAPI, args apiparams.CostEstimateArgs) error {
+	if apiRoot.BestFacadeVersion("CostEstimator") < 1 {
+		return errors.New("this juju controller does not support --estimate-cost")
+	}
+	result, err := apiRoot.EstimateCost(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeCostEstimate(ctx.Stdout, result); err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range result.Machines {
+		if m.Error != nil {
+			ctx.Warningf("cannot estimate the cost of %d machine(s): %v", m.Count, m.Error)
+		}
+	}
+	for _, s := range result.Storage {
+		if s.Error != nil {
+			ctx.Warningf("cannot estimate the cost of storage from pool %q: %v", s.Pool, s.Error)
+		}
+	}
+	return nil
+}
+
+// writeCostEstimate writes a tabular cost estimate to the writer.
+func writeCostEstimate(writer io.Writer, result apiparams.CostEstimateResult) error {
+	cost := func(priced bool, hourlyCost float64, count int) string {
+		if !priced {
+			return "unknown"
+		}
+		return formatCost(hourlyCost*float64(count), result.Currency)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	unpriced := false
+	if len(result.Machines) > 0 {
+		w.Println("Instance type", "Count", "Hourly cost")
+		for _, m := range result.Machines {
+			itype := m.InstanceType
+			if itype == "" {
+				itype = "-"
+			}
+			w.Println(itype, m.Count, cost(m.Priced, m.HourlyCost, m.Count))
+			unpriced = unpriced || !m.Priced
+		}
+		w.Println()
+	}
+	if len(result.Storage) > 0 {
+		w.Println("Storage pool", "Size", "Count", "Hourly cost")
+		for _, s := range result.Storage {
+			size := humanize.IBytes(s.Size * humanize.MiByte)
+			w.Println(s.Pool, size, s.Count, cost(s.Priced, s.HourlyCost, s.Count))
+			unpriced = unpriced || !s.Priced
+		}
+		w.Println()
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	total := formatCost(result.HourlyCost, result.Currency)
+	if unpriced {
+		total += " (excluding items of unknown cost)"
+	}
+	fmt.Fprintf(writer, "Estimated hourly cost: %s\n", total)
+	return nil
+}
+
+func formatCost(cost float64, currency string) string {
+	s := fmt.Sprintf("%.3f", cost)
+	if currency != "" {
+		s += " " + currency
+	}
+	return s
+}
+
+// estimateBundleCost writes the estimated hourly cost of deploying the
+// bundle, excluding any bundle machines mapped to existing machines.
+func (c *DeployCommand) estimateBundleCost(
+	ctx *cmd.Context,
+	data *charm.BundleData,
+	apiRoot DeployAPI,
+	bundleStorage map[string]map[string]storage.Constraints,
+) error {
+	existing := set.NewStrings()
+	if c.UseExistingMachines || len(c.BundleMachines) > 0 {
+		status, err := apiRoot.Status(nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot get model status")
+		}
+		machineChanges := bundleMachineChanges(data, bundlechanges.FromData(data))
+		machineMap, err := bundleMachineMap(data, status, machineChanges, c.UseExistingMachines, c.BundleMachines)
+		if err != nil {
+			return errors.Annotate(err, "cannot map bundle machines")
+		}
+		for id := range machineMap {
+			existing.Add(id)
+		}
+	}
+	args, err := bundleCostEstimateArgs(data, bundleStorage, existing)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(estimateCost(ctx, apiRoot, args))
+}
+
+// charmCostEstimateArgs returns the machines and storage that would be
+// provisioned by deploying numUnits units of a charm with the given
+// constraints, placement and storage. Units placed on existing
+// machines or on containers within them need no new machine.
+func charmCostEstimateArgs(
+	cons constraints.Value,
+	numUnits int,
+	placement []*instance.Placement,
+	storageCons map[string]storage.Constraints,
+) apiparams.CostEstimateArgs {
+	newMachines := 0
+	for i := 0; i < numUnits; i++ {
+		if i >= len(placement) {
+			newMachines++
+			continue
+		}
+		p := placement[i]
+		switch {
+		case p.Scope == instance.MachineScope:
+		case isContainerType(p.Scope):
+			if p.Directive == "" {
+				newMachines++
+			}
+		default:
+			// Provider-specific placement, which
+			// always results in a new machine.
+			newMachines++
+		}
+	}
+
+	var args apiparams.CostEstimateArgs
+	if newMachines > 0 {
+		args.Machines = []apiparams.CostEstimateMachine{{
+			Constraints: cons,
+			Count:       newMachines,
+		}}
+	}
+	args.Storage = storageCostEstimates(storageCons, numUnits)
+	return args
+}
+
+// bundleCostEstimateArgs returns the machines and storage that would
+// be provisioned by deploying the bundle. The IDs of bundle machines
+// that are mapped to existing model machines are given by existing.
+func bundleCostEstimateArgs(
+	data *charm.BundleData,
+	bundleStorage map[string]map[string]storage.Constraints,
+	existing set.Strings,
+) (apiparams.CostEstimateArgs, error) {
+	var args apiparams.CostEstimateArgs
+	machines := make(map[string]int)
+	var machineCons []string
+	addMachines := func(cons string, count int) {
+		if _, ok := machines[cons]; !ok {
+			machineCons = append(machineCons, cons)
+		}
+		machines[cons] += count
+	}
+
+	machineIds := make([]string, 0, len(data.Machines))
+	for id := range data.Machines {
+		machineIds = append(machineIds, id)
+	}
+	sort.Strings(machineIds)
+	for _, id := range machineIds {
+		if existing.Contains(id) {
+			continue
+		}
+		var cons string
+		if m := data.Machines[id]; m != nil {
+			cons = m.Constraints
+		}
+		addMachines(cons, 1)
+	}
+
+	appNames := make([]string, 0, len(data.Applications))
+	for name := range data.Applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+	for _, name := range appNames {
+		app := data.Applications[name]
+		if app == nil || app.NumUnits == 0 {
+			continue
+		}
+		// As when deploying, the last placement directive
+		// is used for any units beyond the directives given.
+		newMachines := 0
+		for i := 0; i < app.NumUnits; i++ {
+			var to string
+			if i < len(app.To) {
+				to = app.To[i]
+			} else if len(app.To) > 0 {
+				to = app.To[len(app.To)-1]
+			}
+			if bundlePlacementNeedsMachine(to) {
+				newMachines++
+			}
+		}
+		if newMachines > 0 {
+			addMachines(app.Constraints, newMachines)
+		}
+
+		storageCons := make(map[string]storage.Constraints)
+		for storageName, s := range app.Storage {
+			cons, err := storage.ParseConstraints(s)
+			if err != nil {
+				return apiparams.CostEstimateArgs{}, errors.Annotatef(
+					err, "invalid storage %q for application %q", storageName, name,
+				)
+			}
+			storageCons[storageName] = cons
+		}
+		for storageName, cons := range bundleStorage[name] {
+			storageCons[storageName] = cons
+		}
+		args.Storage = append(args.Storage, storageCostEstimates(storageCons, app.NumUnits)...)
+	}
+
+	for _, consStr := range machineCons {
+		cons, err := constraints.Parse(consStr)
+		if err != nil {
+			return apiparams.CostEstimateArgs{}, errors.Trace(err)
+		}
+		args.Machines = append(args.Machines, apiparams.CostEstimateMachine{
+			Constraints: cons,
+			Count:       machines[consStr],
+		})
+	}
+	return args, nil
+}
+
+// bundlePlacementNeedsMachine reports whether a unit placed with the
+// given bundle placement directive requires a new machine, rather
+// than a bundle machine or an existing unit's machine.
+func bundlePlacementNeedsMachine(to string) bool {
+	directive := to
+	if i := strings.IndexRune(to, ':'); i != -1 {
+		directive = to[i+1:]
+	} else if isContainerType(to) {
+		return true
+	}
+	return directive == "" || directive == "new"
+}
+
+// storageCostEstimates returns the storage created for numUnits units
+// with the given storage constraints. Storage with no size given is
+// omitted, as its size is determined by the charm.
+func storageCostEstimates(storageCons map[string]storage.Constraints, numUnits int) []apiparams.CostEstimateStorage {
+	storageNames := make([]string, 0, len(storageCons))
+	for name := range storageCons {
+		storageNames = append(storageNames, name)
+	}
+	sort.Strings(storageNames)
+	var result []apiparams.CostEstimateStorage
+	for _, name := range storageNames {
+		cons := storageCons[name]
+		if cons.Size == 0 || cons.Count == 0 || numUnits == 0 {
+			continue
+		}
+		result = append(result, apiparams.CostEstimateStorage{
+			Pool:  cons.Pool,
+			Size:  cons.Size,
+			Count: int(cons.Count) * numUnits,
+		})
+	}
+	return result
+}
+
+func isContainerType(s string) bool {
+	_, err := instance.ParseContainerType(s)
+	return err == nil
+}
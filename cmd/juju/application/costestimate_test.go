@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+type costEstimateSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&costEstimateSuite{})
+
+func (s *costEstimateSuite) TestCharmCostEstimateArgs(c *gc.C) {
+	args := charmCostEstimateArgs(
+		constraints.MustParse("mem=8G"),
+		5,
+		[]*instance.Placement{
+			instance.MustParsePlacement("3"),
+			instance.MustParsePlacement("lxd:5"),
+			instance.MustParsePlacement("lxd"),
+			{Scope: "model-uuid", Directive: "zone=us-east-1a"},
+		},
+		map[string]storage.Constraints{
+			"data": {Pool: "ebs", Size: 10240, Count: 2},
+			"logs": {Pool: "ebs", Count: 1},
+		},
+	)
+	c.Assert(args, jc.DeepEquals, params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{
+			Constraints: constraints.MustParse("mem=8G"),
+			Count:       3,
+		}},
+		Storage: []params.CostEstimateStorage{{
+			Pool:  "ebs",
+			Size:  10240,
+			Count: 10,
+		}},
+	})
+}
+
+func (s *costEstimateSuite) TestCharmCostEstimateArgsNoNewMachines(c *gc.C) {
+	args := charmCostEstimateArgs(
+		constraints.Value{},
+		1,
+		[]*instance.Placement{instance.MustParsePlacement("0")},
+		nil,
+	)
+	c.Assert(args, jc.DeepEquals, params.CostEstimateArgs{})
+}
+
+func (s *costEstimateSuite) TestBundleCostEstimateArgs(c *gc.C) {
+	data, err := charm.ReadBundleData(bytes.NewBufferString(`
+applications:
+    mysql:
+        charm: cs:mysql
+        num_units: 1
+        to: ["0"]
+        storage:
+            data: ebs,100G
+    wordpress:
+        charm: cs:wordpress
+        num_units: 4
+        to: ["lxd:0", "new"]
+        constraints: mem=4G
+        storage:
+            logs: 1G
+    logging:
+        charm: cs:logging
+machines:
+    "0":
+        constraints: mem=16G
+    "1":
+        constraints: mem=16G
+    "2":
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	args, err := bundleCostEstimateArgs(
+		data,
+		map[string]map[string]storage.Constraints{
+			"wordpress": {"logs": {Pool: "ebs-ssd", Size: 2048, Count: 1}},
+		},
+		set.NewStrings("2"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args, jc.DeepEquals, params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{
+			Constraints: constraints.MustParse("mem=16G"),
+			Count:       2,
+		}, {
+			Constraints: constraints.MustParse("mem=4G"),
+			Count:       3,
+		}},
+		Storage: []params.CostEstimateStorage{{
+			Pool:  "ebs",
+			Size:  102400,
+			Count: 1,
+		}, {
+			Pool:  "ebs-ssd",
+			Size:  2048,
+			Count: 4,
+		}},
+	})
+}
+
+func (s *costEstimateSuite) TestBundleCostEstimateArgsInvalidStorage(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {
+				Charm:    "cs:mysql",
+				NumUnits: 1,
+				Storage:  map[string]string{"data": "ebs,-1"},
+			},
+		},
+	}
+	_, err := bundleCostEstimateArgs(data, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid storage "data" for application "mysql": .*`)
+}
+
+func (s *costEstimateSuite) TestBundlePlacementNeedsMachine(c *gc.C) {
+	for to, expect := range map[string]bool{
+		"":            true,
+		"new":         true,
+		"lxd":         true,
+		"lxd:new":     true,
+		"0":           false,
+		"lxd:0":       false,
+		"wordpress/0": false,
+		"lxd:mysql/1": false,
+	} {
+		c.Check(bundlePlacementNeedsMachine(to), gc.Equals, expect, gc.Commentf("%q", to))
+	}
+}
+
+func (s *costEstimateSuite) TestWriteCostEstimate(c *gc.C) {
+	var buf bytes.Buffer
+	err := writeCostEstimate(&buf, params.CostEstimateResult{
+		Currency:   "USD",
+		HourlyCost: 0.75,
+		Machines: []params.CostEstimateMachineResult{{
+			InstanceType: "m4.large",
+			Count:        2,
+			HourlyCost:   0.125,
+			Priced:       true,
+		}, {
+			Count: 1,
+			Error: &params.Error{Message: "no instance types"},
+		}},
+		Storage: []params.CostEstimateStorageResult{{
+			Pool:       "ebs",
+			Size:       1024,
+			Count:      4,
+			HourlyCost: 0.125,
+			Priced:     true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+Instance type  Count  Hourly cost
+m4.large       2      0.250 USD
+-              1      unknown
+
+Storage pool  Size     Count  Hourly cost
+ebs           1.0 GiB  4      0.500 USD
+
+Estimated hourly cost: 0.750 USD (excluding items of unknown cost)
+`[1:])
+}
+
+func (s *DeployUnitTestSuite) TestDeployEstimateCost(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+
+	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
+	withLocalCharmDeployable(fakeAPI, multiSeriesURL, charmDir)
+	withCharmDeployable(fakeAPI, multiSeriesURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 2, nil)
+	fakeAPI.Call("BestFacadeVersion", "CostEstimator").Returns(1)
+	fakeAPI.Call("EstimateCost", params.CostEstimateArgs{
+		Machines: []params.CostEstimateMachine{{Count: 2}},
+	}).Returns(params.CostEstimateResult{
+		Currency:   "USD",
+		HourlyCost: 0.25,
+		Machines: []params.CostEstimateMachineResult{{
+			InstanceType: "m4.large",
+			Count:        2,
+			HourlyCost:   0.125,
+			Priced:       true,
+		}},
+	}, error(nil))
+
+	ctx, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty", "-n", "2", "--estimate-cost")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Instance type  Count  Hourly cost
+m4.large       2      0.250 USD
+
+Estimated hourly cost: 0.250 USD
+`[1:])
+	for _, call := range fakeAPI.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "Deploy")
+	}
+}
+
+func (s *DeployUnitTestSuite) TestDeployEstimateCostUnsupported(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+
+	multiSeriesURL := charm.MustParseURL("local:trusty/multi-series-1")
+	withLocalCharmDeployable(fakeAPI, multiSeriesURL, charmDir)
+	withCharmDeployable(fakeAPI, multiSeriesURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil)
+	fakeAPI.Call("BestFacadeVersion", "CostEstimator").Returns(0)
+
+	_, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty", "--estimate-cost")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --estimate-cost")
+}
@@ -5,6 +5,7 @@ package application
 
 import (
 	"archive/zip"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
 	apicharms "github.com/juju/juju/api/charms"
+	"github.com/juju/juju/api/costestimator"
 	"github.com/juju/juju/api/modelconfig"
 	apiparams "github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
@@ -43,6 +45,7 @@ type CharmAdder interface {
 	AddCharm(*charm.URL, params.Channel) error
 	AddCharmWithAuthorization(*charm.URL, params.Channel, *macaroon.Macaroon) error
 	AuthorizeCharmstoreEntity(*charm.URL) (*macaroon.Macaroon, error)
+	AddCharmFromGit(repository, ref, series string, creds api.GitCredentials) (*charm.URL, error)
 }
 
 type ApplicationAPI interface {
@@ -79,6 +82,7 @@ type DeployAPI interface {
 	MeteredDeployAPI
 	ApplicationAPI
 	ModelAPI
+	CostEstimatorAPI
 
 	// ApplicationClient
 	CharmInfo(string) (*apicharms.CharmInfo, error)
@@ -127,6 +131,10 @@ type annotationsClient struct {
 	*annotations.Client
 }
 
+type costEstimatorClient struct {
+	*costestimator.Client
+}
+
 func (a *charmstoreClient) AuthorizeCharmstoreEntity(url *charm.URL) (*macaroon.Macaroon, error) {
 	return authorizeCharmStoreEntity(a.Client, url)
 }
@@ -140,6 +148,7 @@ type deployAPIAdapter struct {
 	*charmRepoClient
 	*charmstoreClient
 	*annotationsClient
+	*costEstimatorClient
 }
 
 func (a *deployAPIAdapter) Client() *api.Client {
@@ -197,14 +206,15 @@ func NewDeployCommandForTest(newAPIRoot func() (DeployAPI, error), steps []Deplo
 			cstoreClient := newCharmStoreClient(bakeryClient).WithChannel(deployCmd.Channel)
 
 			return &deployAPIAdapter{
-				Connection:        apiRoot,
-				apiClient:         &apiClient{Client: apiRoot.Client()},
-				charmsClient:      &charmsClient{Client: apicharms.NewClient(apiRoot)},
-				applicationClient: &applicationClient{Client: application.NewClient(apiRoot)},
-				modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
-				charmstoreClient:  &charmstoreClient{Client: cstoreClient},
-				annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
-				charmRepoClient:   &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
+				Connection:          apiRoot,
+				apiClient:           &apiClient{Client: apiRoot.Client()},
+				charmsClient:        &charmsClient{Client: apicharms.NewClient(apiRoot)},
+				applicationClient:   &applicationClient{Client: application.NewClient(apiRoot)},
+				modelConfigClient:   &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
+				charmstoreClient:    &charmstoreClient{Client: cstoreClient},
+				annotationsClient:   &annotationsClient{Client: annotations.NewClient(apiRoot)},
+				charmRepoClient:     &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
+				costEstimatorClient: &costEstimatorClient{Client: costestimator.NewClient(apiRoot)},
 			}, nil
 		}
 	}
@@ -234,14 +244,15 @@ func NewDeployCommand() modelcmd.ModelCommand {
 		cstoreClient := newCharmStoreClient(bakeryClient).WithChannel(deployCmd.Channel)
 
 		return &deployAPIAdapter{
-			Connection:        apiRoot,
-			apiClient:         &apiClient{Client: apiRoot.Client()},
-			charmsClient:      &charmsClient{Client: apicharms.NewClient(apiRoot)},
-			applicationClient: &applicationClient{Client: application.NewClient(apiRoot)},
-			modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
-			charmstoreClient:  &charmstoreClient{Client: cstoreClient},
-			annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
-			charmRepoClient:   &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
+			Connection:          apiRoot,
+			apiClient:           &apiClient{Client: apiRoot.Client()},
+			charmsClient:        &charmsClient{Client: apicharms.NewClient(apiRoot)},
+			applicationClient:   &applicationClient{Client: application.NewClient(apiRoot)},
+			modelConfigClient:   &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
+			charmstoreClient:    &charmstoreClient{Client: cstoreClient},
+			annotationsClient:   &annotationsClient{Client: annotations.NewClient(apiRoot)},
+			charmRepoClient:     &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
+			costEstimatorClient: &costEstimatorClient{Client: costestimator.NewClient(apiRoot)},
 		}, nil
 	}
 
@@ -260,6 +271,14 @@ type DeployCommand struct {
 	// in the near future, machine and space mappings.
 	BundleConfigFile string
 
+	// MachineMap holds the value of the --map-machines flag, which maps
+	// the machines of a bundle to existing model machines.
+	MachineMap string
+
+	// UseExistingMachines and BundleMachines are parsed from MachineMap.
+	UseExistingMachines bool
+	BundleMachines      map[string]string
+
 	// Channel holds the charmstore channel to use when obtaining
 	// the charm to be deployed.
 	Channel params.Channel
@@ -267,10 +286,21 @@ type DeployCommand struct {
 	// Series is the series of the charm to deploy.
 	Series string
 
+	// GitKey and GitKnownHosts are the paths of the SSH private key
+	// and known_hosts file with which the controller fetches a charm
+	// from a git+ssh repository.
+	GitKey        string
+	GitKnownHosts string
+
 	// Force is used to allow a charm to be deployed onto a machine
 	// running an unsupported series.
 	Force bool
 
+	// EstimateCost causes the estimated hourly cost of the machines
+	// and storage that the deployment would create to be reported,
+	// instead of deploying anything.
+	EstimateCost bool
+
 	ApplicationName string
 	Config          cmd.FileVar
 	ConstraintsStr  string
@@ -334,11 +364,42 @@ unless '--force' is used.
 
   juju deploy /path/to/charm --series wily --force
 
+Charms may also be deployed from a git repository, using a git+ssh:// or
+git+https:// URL. The controller fetches the repository and builds the charm
+from it, so it must be able to reach the repository, and the repository's host
+must be listed in the controller's deploy-allowed-git-hosts config. A branch,
+tag or commit may follow the URL after a '#'; the repository's default branch
+is used if none is given. For git+ssh repositories, the host keys of the
+repository's host must be given with --git-known-hosts, and the controller only
+connects to a host with one of these keys. An SSH private key with access to a
+private repository may be given with --git-key; the controller never uses keys
+of its own.
+
+  juju deploy git+ssh://git@example.com/charms/mysql.git#stable --series xenial \
+      --git-known-hosts ./known_hosts --git-key ~/.ssh/charms_deploy_key
+
+The commit the charm was built from is recorded, and such an application may be
+upgraded to the latest commit of the same ref, or to a different ref, with
+` + "`juju upgrade-charm`" + `.
+
 Local bundles are specified with a direct path to a bundle.yaml file.
 For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+The machines defined in a bundle are normally created when the bundle is
+deployed. The '--map-machines' option instead places them on machines that
+already exist in the model, for example when redeploying a bundle onto
+hardware that is already allocated. It takes a comma-separated list of
+<bundle machine>=<machine> mappings, and may include 'existing' to use, for
+each bundle machine, the model machine with the same id if there is one.
+Explicit mappings take precedence over 'existing'. Only bundle machines
+that hold units, directly or in containers, can be mapped; the others are
+always created.
+
+  juju deploy /path/to/bundle.yaml --map-machines=existing
+  juju deploy /path/to/bundle.yaml --map-machines=existing,3=12,4=14
+
 If an 'application name' is not provided, the application name used is the
 'charm or bundle' name.  A user-supplied 'application name' must consist only of
 lower-case letters (a-z), numbers (0-9), and single hyphens (-).  The name must
@@ -359,6 +420,18 @@ than one resource.
 
 Where 'bar' and 'baz' are resources named in the metadata for the 'foo' charm.
 
+The '--estimate-cost' option shows the estimated hourly cost of the machines
+and storage that a charm or bundle deployment would create, without deploying
+it. Instance types are chosen from the constraints as they would be when
+provisioning, and are priced from the model's 'pricing-metadata-url' setting
+or, where the cloud provides them, the provider's prices. Storage is only
+priced from the pricing metadata, and only storage with a size given by the
+'--storage' option or the bundle is included. A charm is still added to the
+model, but no application is deployed.
+
+  juju deploy mediawiki -n 3 --constraints mem=8G --estimate-cost
+  juju deploy /path/to/bundle.yaml --estimate-cost
+
 When using a placement directive to deploy to an existing machine or container
 ('--to' option), the ` + "`juju status`" + ` command should be used for guidance. A few
 placement directives are provider-dependent (e.g.: 'zone').
@@ -451,7 +524,7 @@ var (
 		"bind", "config", "constraints", "force", "n", "num-units",
 		"series", "to", "resource", "attach-storage",
 	}
-	bundleOnlyFlags = []string{"bundle-config"}
+	bundleOnlyFlags = []string{"bundle-config", "map-machines"}
 )
 
 func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -465,10 +538,14 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.BundleConfigFile, "bundle-config", "", "Config override values for a bundle")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
+	f.StringVar(&c.GitKey, "git-key", "", "Path to the SSH private key with which to fetch a charm from a git+ssh repository")
+	f.StringVar(&c.GitKnownHosts, "git-known-hosts", "", "Path to a known_hosts file holding the host keys of a git+ssh repository's host")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed to a machine running an unsupported series")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.StringVar(&c.MachineMap, "map-machines", "", "Specify the existing machines to use for bundle deployments")
+	f.BoolVar(&c.EstimateCost, "estimate-cost", false, "Show the estimated hourly cost of the deployment instead of deploying")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -498,6 +575,13 @@ func (c *DeployCommand) Init(args []string) error {
 	if err := c.parseBind(); err != nil {
 		return err
 	}
+	if c.MachineMap != "" {
+		var err error
+		c.UseExistingMachines, c.BundleMachines, err = parseMachineMap(c.MachineMap)
+		if err != nil {
+			return errors.Annotate(err, "invalid --map-machines parameter")
+		}
+	}
 	return c.UnitCommandBase.Init(args)
 }
 
@@ -523,6 +607,9 @@ func (c *DeployCommand) deployBundle(
 	apiRoot DeployAPI,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) error {
+	if c.EstimateCost {
+		return errors.Trace(c.estimateBundleCost(ctx, data, apiRoot, bundleStorage))
+	}
 	// TODO(ericsnow) Do something with the CS macaroons that were returned?
 	if _, err := deployBundle(
 		filePath,
@@ -532,6 +619,8 @@ func (c *DeployCommand) deployBundle(
 		apiRoot,
 		ctx,
 		bundleStorage,
+		c.UseExistingMachines,
+		c.BundleMachines,
 	); err != nil {
 		return errors.Trace(err)
 	}
@@ -568,6 +657,11 @@ func (c *DeployCommand) deployCharm(
 			return errors.New("cannot use --num-units or --to with subordinate application")
 		}
 	}
+	if c.EstimateCost {
+		return errors.Trace(estimateCost(ctx, apiRoot, charmCostEstimateArgs(
+			c.Constraints, numUnits, c.Placement, c.Storage,
+		)))
+	}
 	serviceName := c.ApplicationName
 	if serviceName == "" {
 		serviceName = charmInfo.Meta.Name
@@ -703,6 +797,7 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 	defer apiRoot.Close()
 
 	deploy, err := findDeployerFIFO(
+		c.maybeGitCharm,
 		c.maybeReadLocalBundle,
 		func() (deployFn, error) { return c.maybeReadLocalCharm(apiRoot) },
 		c.maybePredeployedLocalCharm,
@@ -772,6 +867,72 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 	}, nil
 }
 
+func (c *DeployCommand) maybeGitCharm() (deployFn, error) {
+	repository, ref, ok := parseGitCharmRef(c.CharmOrBundle)
+	if !ok {
+		logger.Debugf("cannot interpret as a charm from a git repository")
+		return nil, nil
+	}
+
+	return func(ctx *cmd.Context, apiRoot DeployAPI) error {
+		if err := c.validateCharmFlags(); err != nil {
+			return errors.Trace(err)
+		}
+		creds, err := readGitCredentials(ctx, c.GitKey, c.GitKnownHosts)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		curl, err := apiRoot.AddCharmFromGit(repository, ref, c.Series, creds)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Deploying charm %q.", curl.String())
+		return errors.Trace(c.deployCharm(
+			charmstore.CharmID{URL: curl},
+			(*macaroon.Macaroon)(nil), // git charms don't need one.
+			curl.Series,
+			ctx,
+			apiRoot,
+		))
+	}, nil
+}
+
+// parseGitCharmRef splits a reference to a charm in a git repository,
+// of the form git+ssh://host/path[#ref] or git+https://host/path[#ref],
+// into the repository URL and ref. It reports whether the reference is
+// to a charm in a git repository.
+func parseGitCharmRef(s string) (repository, ref string, ok bool) {
+	if !strings.HasPrefix(s, "git+ssh://") && !strings.HasPrefix(s, "git+https://") {
+		return "", "", false
+	}
+	if i := strings.LastIndex(s, "#"); i >= 0 {
+		return s[:i], s[i+1:], true
+	}
+	return s, "", true
+}
+
+// readGitCredentials returns the credentials with which the controller
+// fetches from a git repository, read from the given SSH private key
+// and known_hosts files. Either file name may be empty.
+func readGitCredentials(ctx *cmd.Context, keyFile, knownHostsFile string) (api.GitCredentials, error) {
+	var creds api.GitCredentials
+	if keyFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(keyFile))
+		if err != nil {
+			return creds, errors.Annotate(err, "cannot read git key")
+		}
+		creds.PrivateKey = string(data)
+	}
+	if knownHostsFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(knownHostsFile))
+		if err != nil {
+			return creds, errors.Annotate(err, "cannot read git known hosts")
+		}
+		creds.KnownHosts = string(data)
+	}
+	return creds, nil
+}
+
 func (c *DeployCommand) maybeReadLocalBundle() (deployFn, error) {
 	bundleFile := c.CharmOrBundle
 	var bundleDir string
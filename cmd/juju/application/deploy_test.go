@@ -1471,6 +1471,70 @@ func (s *DeployUnitTestSuite) TestDeployAttachStorageNotSupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --attach-storage")
 }
 
+func (s *DeployUnitTestSuite) TestDeployFromGit(c *gc.C) {
+	dir := c.MkDir()
+	keyPath := filepath.Join(dir, "id")
+	err := ioutil.WriteFile(keyPath, []byte("private-key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	knownHostsPath := filepath.Join(dir, "known_hosts")
+	err = ioutil.WriteFile(knownHostsPath, []byte("example.com ssh-ed25519 AAAA"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fakeAPI := s.fakeAPI()
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	creds := api.GitCredentials{
+		PrivateKey: "private-key",
+		KnownHosts: "example.com ssh-ed25519 AAAA",
+	}
+	fakeAPI.Call("AddCharmFromGit", "git+ssh://git@example.com/dummy.git", "stable", "trusty", creds).Returns(dummyURL, error(nil))
+	charmDir := testcharms.Repo.CharmDir("dummy")
+	withCharmDeployable(
+		fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil,
+	)
+
+	context, err := s.runDeploy(c, fakeAPI, "git+ssh://git@example.com/dummy.git#stable", "--series", "trusty",
+		"--git-key", keyPath, "--git-known-hosts", knownHostsPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, `Deploying charm "local:trusty/dummy-0".`+"\n")
+}
+
+func (s *DeployUnitTestSuite) TestDeployFromGitMissingKnownHosts(c *gc.C) {
+	fakeAPI := s.fakeAPI()
+	_, err := s.runDeploy(c, fakeAPI, "git+ssh://git@example.com/dummy.git",
+		"--git-known-hosts", filepath.Join(c.MkDir(), "missing"))
+	c.Assert(err, gc.ErrorMatches, "cannot read git known hosts: .*")
+}
+
+func (s *DeployUnitTestSuite) TestParseGitCharmRef(c *gc.C) {
+	for i, test := range []struct {
+		ref        string
+		repository string
+		gitRef     string
+		ok         bool
+	}{{
+		ref:        "git+ssh://git@example.com/dummy.git",
+		repository: "git+ssh://git@example.com/dummy.git",
+		ok:         true,
+	}, {
+		ref:        "git+https://example.com/dummy.git#v1.2",
+		repository: "git+https://example.com/dummy.git",
+		gitRef:     "v1.2",
+		ok:         true,
+	}, {
+		ref: "cs:dummy",
+	}, {
+		ref: "./dummy",
+	}, {
+		ref: "ssh://git@example.com/dummy.git",
+	}} {
+		c.Logf("test %d: %s", i, test.ref)
+		repository, gitRef, ok := parseGitCharmRef(test.ref)
+		c.Check(ok, gc.Equals, test.ok)
+		c.Check(repository, gc.Equals, test.repository)
+		c.Check(gitRef, gc.Equals, test.gitRef)
+	}
+}
+
 // fakeDeployAPI is a mock of the API used by the deploy command. It's
 // a little muddled at the moment, but as the DeployAPI interface is
 // sharpened, this will become so as well.
@@ -1538,6 +1602,11 @@ func (f *fakeDeployAPI) AddLocalCharm(url *charm.URL, ch charm.Charm) (*charm.UR
 	return results[0].(*charm.URL), jujutesting.TypeAssertError(results[1])
 }
 
+func (f *fakeDeployAPI) AddCharmFromGit(repository, ref, series string, creds api.GitCredentials) (*charm.URL, error) {
+	results := f.MethodCall(f, "AddCharmFromGit", repository, ref, series, creds)
+	return results[0].(*charm.URL), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) AddCharm(url *charm.URL, channel csclientparams.Channel) error {
 	results := f.MethodCall(f, "AddCharm", url, channel)
 	return jujutesting.TypeAssertError(results[0])
@@ -1622,6 +1691,11 @@ func (f *fakeDeployAPI) AddMachines(machineParams []params.AddMachineParams) ([]
 	return results[0].([]params.AddMachinesResult), jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) EstimateCost(args params.CostEstimateArgs) (params.CostEstimateResult, error) {
+	results := f.MethodCall(f, "EstimateCost", args)
+	return results[0].(params.CostEstimateResult), jujutesting.TypeAssertError(results[1])
+}
+
 func stringToInterface(args []string) []interface{} {
 	interfaceArgs := make([]interface{}, len(args))
 	for i, a := range args {
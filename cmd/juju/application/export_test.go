@@ -46,6 +46,22 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewServiceGetConstraintsCommandForTest returns a GetConstraintsCommand
+// with the api provided as specified.
+func NewServiceGetConstraintsCommandForTest(api serviceConstraintsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &serviceGetConstraintsCommand{serviceConstraintsCommand: serviceConstraintsCommand{api: api}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewServiceSetConstraintsCommandForTest returns a SetConstraintsCommand
+// with the api provided as specified.
+func NewServiceSetConstraintsCommandForTest(api serviceConstraintsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &serviceSetConstraintsCommand{serviceConstraintsCommand: serviceConstraintsCommand{api: api}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewAddRelationCommandForTest returns an AddRelationCommand with the api provided as specified.
 func NewAddRelationCommandForTest(addAPI applicationAddRelationAPI, consumeAPI applicationConsumeDetailsAPI) modelcmd.ModelCommand {
 	cmd := &addRelationCommand{addRelationAPI: addAPI, consumeDetailsAPI: consumeAPI}
@@ -98,6 +114,75 @@ func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI) modelcmd.Model
 	return modelcmd.Wrap(cmd)
 }
 
+// NewTrustCommandForTest returns a TrustCommand with the api provided as specified.
+func NewTrustCommandForTest(api TrustAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &trustCommand{newAPIFunc: func() (TrustAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowRelationUsageCommandForTest returns a showRelationUsageCommand
+// with the api provided as specified.
+func NewShowRelationUsageCommandForTest(api RelationUsageAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showRelationUsageCommand{newAPIFunc: func() (RelationUsageAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowRemovalReportCommandForTest returns a showRemovalReportCommand
+// with the api provided as specified.
+func NewShowRemovalReportCommandForTest(api RemovalReportAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showRemovalReportCommand{newAPIFunc: func() (RemovalReportAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewExportBundleCommandForTest returns an exportBundleCommand with the
+// api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &exportBundleCommand{newAPIFunc: func() (ExportBundleAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewAddressPolicyCommandForTest returns an addressPolicyCommand with
+// the api provided as specified.
+func NewAddressPolicyCommandForTest(api AddressPolicyAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &addressPolicyCommand{newAPIFunc: func() (AddressPolicyAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewApplicationLoggingCommandForTest returns an
+// applicationLoggingCommand with the api provided as specified.
+func NewApplicationLoggingCommandForTest(api ApplicationLoggingAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &applicationLoggingCommand{newAPIFunc: func() (ApplicationLoggingAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewHookEnvCommandForTest returns a hookEnvCommand with the api
+// provided as specified.
+func NewHookEnvCommandForTest(api HookEnvAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &hookEnvCommand{newAPIFunc: func() (HookEnvAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageExportBundleSummary = `
+Exports the current model configuration as a reusable bundle.`[1:]
+
+var usageExportBundleDetails = `
+Writes a bundle describing the applications, machines and relations of
+the current model. Deploying the bundle to an empty model recreates the
+model's applications with the same placements, storage, endpoint
+bindings and exposed flags. Only the application options that differ
+from the charm's defaults are included.
+
+Units on containers are placed in new containers on the same machines,
+and relations to applications offered by other models are omitted.
+
+If --filename is not specified, the bundle is written to stdout.
+
+Examples:
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy`[1:]
+
+// NewExportBundleCommand returns a command to export the current model
+// as a bundle.
+func NewExportBundleCommand() cmd.Command {
+	cmd := &exportBundleCommand{}
+	cmd.newAPIFunc = func() (ExportBundleAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return bundle.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// ExportBundleAPI defines the API methods that the export-bundle
+// command uses.
+type ExportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (ExportBundleAPI, error)
+
+	filename string
+}
+
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: usageExportBundleSummary,
+		Doc:     usageExportBundleDetails,
+	}
+}
+
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.filename, "filename", "", "Bundle file")
+}
+
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ExportBundle()
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support exporting bundles")
+	} else if err != nil {
+		return err
+	}
+	if c.filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, result)
+		return err
+	}
+	path := ctx.AbsPath(c.filename)
+	if err := ioutil.WriteFile(path, []byte(result), 0644); err != nil {
+		return errors.Annotate(err, "writing bundle")
+	}
+	ctx.Infof("Bundle successfully exported to %s", path)
+	return nil
+}
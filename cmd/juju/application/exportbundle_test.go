@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+const exportedBundle = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-42
+    num_units: 1
+    to:
+    - "0"
+machines:
+  "0": {}
+`
+
+type ExportBundleSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockExportBundleAPI
+}
+
+var _ = gc.Suite(&ExportBundleSuite{})
+
+func (s *ExportBundleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockExportBundleAPI{
+		Stub:   &testing.Stub{},
+		bundle: exportedBundle[1:],
+	}
+}
+
+func (s *ExportBundleSuite) runExportBundle(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewExportBundleCommandForTest(s.mockAPI, NewMockStore()), args...)
+}
+
+func (s *ExportBundleSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runExportBundle(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql"\]`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ExportBundleSuite) TestExportToStdout(c *gc.C) {
+	ctx, err := s.runExportBundle(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, exportedBundle[1:])
+	s.mockAPI.CheckCallNames(c, "ExportBundle", "Close")
+}
+
+func (s *ExportBundleSuite) TestExportToFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "bundle.yaml")
+	ctx, err := s.runExportBundle(c, "--filename", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Bundle successfully exported to "+path+"\n")
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, exportedBundle[1:])
+}
+
+func (s *ExportBundleSuite) TestExportNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("exporting bundles with this version of Juju"))
+	_, err := s.runExportBundle(c)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support exporting bundles")
+}
+
+func (s *ExportBundleSuite) TestExportError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("nothing to export as there are no applications"))
+	_, err := s.runExportBundle(c)
+	c.Assert(err, gc.ErrorMatches, "nothing to export as there are no applications")
+}
+
+type mockExportBundleAPI struct {
+	*testing.Stub
+	bundle string
+}
+
+func (m *mockExportBundleAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockExportBundleAPI) ExportBundle() (string, error) {
+	m.MethodCall(m, "ExportBundle")
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	return m.bundle, nil
+}
@@ -4,8 +4,12 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +23,23 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+The --floating-ip option names a floating (or elastic) IP address,
+already allocated in the cloud, that should follow the application's
+leader. Whenever leadership of the application changes, the address is
+associated with the new leader's machine, and the application's units
+run the floating-ip-changed hook. This suits applications, such as
+primary/standby databases, where only the leader should be reachable.
+Clouds that do not support floating IPs ignore the option.
+
+The --to-cidrs option restricts access to the application's open ports
+to the given comma-separated source CIDRs, rather than allowing access
+from anywhere. Exposing the application again without the option
+allows access from anywhere.
+
 Examples:
     juju expose wordpress
+    juju expose postgresql --floating-ip 203.0.113.10
+    juju expose mysql --to-cidrs 10.0.0.0/24,192.168.1.0/24
 
 See also: 
     unexpose`[1:]
@@ -34,6 +53,10 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	FloatingIP      string
+	ToCIDRs         string
+
+	cidrs []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +68,38 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.FloatingIP, "floating-ip", "", "Floating IP address to follow the application's leader")
+	f.StringVar(&c.ToCIDRs, "to-cidrs", "", "Comma-separated source CIDRs to allow access from")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	if c.FloatingIP != "" && net.ParseIP(c.FloatingIP) == nil {
+		return errors.NotValidf("floating IP address %q", c.FloatingIP)
+	}
+	c.cidrs = nil
+	if c.ToCIDRs != "" {
+		for _, cidr := range strings.Split(c.ToCIDRs, ",") {
+			cidr = strings.TrimSpace(cidr)
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+			c.cidrs = append(c.cidrs, cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeWithFloatingIP(serviceName, address string) error
+	ExposeToCIDRs(serviceName string, cidrs []string, address string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +119,18 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.cidrs) > 0 {
+		err = client.ExposeToCIDRs(c.ApplicationName, c.cidrs, c.FloatingIP)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support exposing to CIDRs")
+		}
+	} else if c.FloatingIP != "" {
+		err = client.ExposeWithFloatingIP(c.ApplicationName, c.FloatingIP)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support floating IPs")
+		}
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -61,6 +61,50 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeFloatingIP(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	_, err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--floating-ip", "203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	address, _ := app.FloatingIP()
+	c.Assert(address, gc.Equals, "203.0.113.10")
+}
+
+func (s *ExposeSuite) TestExposeFloatingIPInvalid(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--floating-ip", "nowhere")
+	c.Assert(err, gc.ErrorMatches, `floating IP address "nowhere" not valid`)
+}
+
+func (s *ExposeSuite) TestExposeToCIDRs(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	_, err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0/24, 192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+
+	// Exposing again without CIDRs allows access from anywhere.
+	err = runExpose(c, "some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ExposeSuite) TestExposeToCIDRsInvalid(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0/24,somewhere")
+	c.Assert(err, gc.ErrorMatches, `CIDR "somewhere" not valid`)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
 	_, err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
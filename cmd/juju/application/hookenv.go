@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageHookEnvSummary = `
+Gets or sets extra environment variables for an application's hooks.`[1:]
+
+var usageHookEnvDetails = `
+Extra environment variables may be set for the hook executions of all
+units in a model with the hook-environment model config value. This
+command shows or replaces an application's own variables, which are set
+in addition to, and override, those of the model.
+
+With no key=value pairs, the application's variables are shown. With
+key=value pairs, the application's variables are replaced by the given
+ones. The --reset option removes all of the application's variables.
+
+Variable names must consist of letters, digits and underscores, and must
+not start with a digit. Names starting with JUJU_, and the CHARM_DIR and
+PATH variables, are reserved. Variables set by Juju itself always take
+precedence.
+
+Charms can read the variables with the hook-env-get hook tool.
+
+Examples:
+    juju hook-env mysql
+    juju hook-env mysql SITE_ID=lon1 HTTP_TIMEOUT=30
+    juju hook-env mysql --reset
+
+See also:
+    model-config`[1:]
+
+// NewHookEnvCommand returns a command to get or set the extra hook
+// environment variables of an application.
+func NewHookEnvCommand() cmd.Command {
+	cmd := &hookEnvCommand{}
+	cmd.newAPIFunc = func() (HookEnvAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// HookEnvAPI defines the API methods that the hook-env command uses.
+type HookEnvAPI interface {
+	Close() error
+	GetHookEnvironment(application string) (map[string]string, error)
+	SetHookEnvironment(application string, env map[string]string) error
+}
+
+type hookEnvCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	newAPIFunc func() (HookEnvAPI, error)
+
+	applicationName string
+	environment     map[string]string
+	reset           bool
+}
+
+func (c *hookEnvCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "hook-env",
+		Args:    "<application name> [<key>=<value> ...]",
+		Purpose: usageHookEnvSummary,
+		Doc:     usageHookEnvDetails,
+	}
+}
+
+func (c *hookEnvCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.BoolVar(&c.reset, "reset", false, "Remove all of the application's hook environment variables")
+}
+
+func (c *hookEnvCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	args = args[1:]
+	if len(args) == 0 {
+		return nil
+	}
+	if c.reset {
+		return errors.New("cannot specify --reset with key=value pairs")
+	}
+	env, err := keyvalues.Parse(args, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.environment = env
+	return nil
+}
+
+func (c *hookEnvCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.environment == nil && !c.reset {
+		env, err := client.GetHookEnvironment(c.applicationName)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support hook environments")
+		} else if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, env)
+	}
+	err = client.SetHookEnvironment(c.applicationName, c.environment)
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support hook environments")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type HookEnvSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockHookEnvAPI
+}
+
+var _ = gc.Suite(&HookEnvSuite{})
+
+func (s *HookEnvSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockHookEnvAPI{
+		Stub: &testing.Stub{},
+		env: map[string]string{
+			"SITE_ID":      "lon1",
+			"HTTP_TIMEOUT": "30",
+		},
+	}
+}
+
+func (s *HookEnvSuite) runHookEnv(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewHookEnvCommandForTest(s.mockAPI, NewMockStore()), args...)
+}
+
+func (s *HookEnvSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "SITE_ID"},
+		err:  `expected "key=value", got "SITE_ID"`,
+	}, {
+		args: []string{"mysql", "--reset", "SITE_ID=lon1"},
+		err:  "cannot specify --reset with key=value pairs",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := s.runHookEnv(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *HookEnvSuite) TestGet(c *gc.C) {
+	ctx, err := s.runHookEnv(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+HTTP_TIMEOUT: "30"
+SITE_ID: lon1
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"GetHookEnvironment", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookEnvSuite) TestGetJSON(c *gc.C) {
+	ctx, err := s.runHookEnv(c, "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"HTTP_TIMEOUT":"30","SITE_ID":"lon1"}`+"\n")
+}
+
+func (s *HookEnvSuite) TestSet(c *gc.C) {
+	_, err := s.runHookEnv(c, "mysql", "SITE_ID=ams2", "DEBUG=")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetHookEnvironment", []interface{}{"mysql", map[string]string{
+			"SITE_ID": "ams2",
+			"DEBUG":   "",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookEnvSuite) TestReset(c *gc.C) {
+	_, err := s.runHookEnv(c, "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetHookEnvironment", []interface{}{"mysql", map[string]string(nil)}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookEnvSuite) TestSetError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`environment variable "JUJU_FOO" is reserved`))
+	_, err := s.runHookEnv(c, "mysql", "JUJU_FOO=bar")
+	c.Assert(err, gc.ErrorMatches, `environment variable "JUJU_FOO" is reserved`)
+}
+
+func (s *HookEnvSuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("hook environment"))
+	_, err := s.runHookEnv(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support hook environments")
+}
+
+type mockHookEnvAPI struct {
+	*testing.Stub
+	env map[string]string
+}
+
+func (m *mockHookEnvAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockHookEnvAPI) GetHookEnvironment(application string) (map[string]string, error) {
+	m.MethodCall(m, "GetHookEnvironment", application)
+	return m.env, m.NextErr()
+}
+
+func (m *mockHookEnvAPI) SetHookEnvironment(application string, env map[string]string) error {
+	m.MethodCall(m, "SetHookEnvironment", application, env)
+	return m.NextErr()
+}
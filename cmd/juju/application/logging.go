@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageApplicationLoggingSummary = `
+Gets or sets the logging settings of an application's units.`[1:]
+
+var usageApplicationLoggingDetails = `
+The logging of a unit's agent is by default governed by the model's
+logging-config. An application's logging config is applied on top of
+the model's, so that the logging of its units can be made more or less
+verbose without affecting the rest of the model.
+
+The logs of a unit's agent are by default written to the logsink.log
+file on the controllers, along with the logs of all the model's other
+agents. An application's units can instead have their logs written to a
+file of their own, applications/<model uuid>/<application>.log in the
+controllers' log directory. The logs are still available with
+debug-log either way. Units start writing to the chosen file when their
+agents next connect to the controller.
+
+With no key=value pairs, the application's logging settings are shown.
+With key=value pairs, they are replaced by the given ones. The --reset
+option restores the defaults.
+
+The keys are:
+
+    config          the logging config of the application's units, in
+                    the same format as the model's logging-config
+    dedicated-file  whether the units' logs are written to a file of
+                    their own on the controllers
+
+Examples:
+    juju application-logging mysql
+    juju application-logging mysql config="unit=DEBUG"
+    juju application-logging mysql config="<root>=TRACE" dedicated-file=true
+    juju application-logging mysql --reset
+
+See also:
+    debug-log
+    model-config`[1:]
+
+// NewApplicationLoggingCommand returns a command to get or set the
+// logging settings of an application.
+func NewApplicationLoggingCommand() cmd.Command {
+	cmd := &applicationLoggingCommand{}
+	cmd.newAPIFunc = func() (ApplicationLoggingAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// ApplicationLoggingAPI defines the API methods that the
+// application-logging command uses.
+type ApplicationLoggingAPI interface {
+	Close() error
+	GetLogging(application string) (params.ApplicationLogging, error)
+	SetLogging(application string, logging params.ApplicationLogging) error
+}
+
+type applicationLoggingCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	newAPIFunc func() (ApplicationLoggingAPI, error)
+
+	applicationName string
+	logging         *params.ApplicationLogging
+	reset           bool
+}
+
+// ApplicationLogging is the output format of an application's logging
+// settings.
+type ApplicationLogging struct {
+	Config        string `yaml:"config,omitempty" json:"config,omitempty"`
+	DedicatedFile bool   `yaml:"dedicated-file,omitempty" json:"dedicated-file,omitempty"`
+}
+
+func (c *applicationLoggingCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "application-logging",
+		Args:    "<application name> [<key>=<value> ...]",
+		Purpose: usageApplicationLoggingSummary,
+		Doc:     usageApplicationLoggingDetails,
+	}
+}
+
+func (c *applicationLoggingCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.BoolVar(&c.reset, "reset", false, "Restore the default logging settings")
+}
+
+func (c *applicationLoggingCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	args = args[1:]
+	if len(args) == 0 {
+		if c.reset {
+			c.logging = &params.ApplicationLogging{}
+		}
+		return nil
+	}
+	if c.reset {
+		return errors.New("cannot specify --reset with key=value pairs")
+	}
+	values, err := keyvalues.Parse(args, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var logging params.ApplicationLogging
+	for key, value := range values {
+		switch key {
+		case "config":
+			if _, err := loggo.ParseConfigString(value); err != nil {
+				return errors.NotValidf("logging config %q", value)
+			}
+			logging.Config = value
+		case "dedicated-file":
+			logging.DedicatedFile, err = strconv.ParseBool(value)
+			if err != nil {
+				return errors.NotValidf("dedicated-file value %q", value)
+			}
+		default:
+			return errors.NotValidf("application logging key %q", key)
+		}
+	}
+	c.logging = &logging
+	return nil
+}
+
+func (c *applicationLoggingCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.logging == nil {
+		logging, err := client.GetLogging(c.applicationName)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support application logging settings")
+		} else if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, ApplicationLogging{
+			Config:        logging.Config,
+			DedicatedFile: logging.DedicatedFile,
+		})
+	}
+	err = client.SetLogging(c.applicationName, *c.logging)
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support application logging settings")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
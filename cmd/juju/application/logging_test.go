@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ApplicationLoggingSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockApplicationLoggingAPI
+}
+
+var _ = gc.Suite(&ApplicationLoggingSuite{})
+
+func (s *ApplicationLoggingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockApplicationLoggingAPI{
+		Stub: &testing.Stub{},
+		logging: params.ApplicationLogging{
+			Config:        "unit=DEBUG",
+			DedicatedFile: true,
+		},
+	}
+}
+
+func (s *ApplicationLoggingSuite) runApplicationLogging(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewApplicationLoggingCommandForTest(s.mockAPI, NewMockStore()), args...)
+}
+
+func (s *ApplicationLoggingSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "config"},
+		err:  `expected "key=value", got "config"`,
+	}, {
+		args: []string{"mysql", "colour=blue"},
+		err:  `application logging key "colour" not valid`,
+	}, {
+		args: []string{"mysql", "config=unit=LOUD"},
+		err:  `logging config "unit=LOUD" not valid`,
+	}, {
+		args: []string{"mysql", "dedicated-file=maybe"},
+		err:  `dedicated-file value "maybe" not valid`,
+	}, {
+		args: []string{"mysql", "--reset", "dedicated-file=true"},
+		err:  "cannot specify --reset with key=value pairs",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := s.runApplicationLogging(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ApplicationLoggingSuite) TestGet(c *gc.C) {
+	ctx, err := s.runApplicationLogging(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+config: unit=DEBUG
+dedicated-file: true
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"GetLogging", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ApplicationLoggingSuite) TestGetJSON(c *gc.C) {
+	ctx, err := s.runApplicationLogging(c, "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"config":"unit=DEBUG","dedicated-file":true}`+"\n")
+}
+
+func (s *ApplicationLoggingSuite) TestSet(c *gc.C) {
+	_, err := s.runApplicationLogging(c, "mysql", "config=<root>=WARNING;unit=TRACE", "dedicated-file=true")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetLogging", []interface{}{"mysql", params.ApplicationLogging{
+			Config:        "<root>=WARNING;unit=TRACE",
+			DedicatedFile: true,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ApplicationLoggingSuite) TestReset(c *gc.C) {
+	_, err := s.runApplicationLogging(c, "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetLogging", []interface{}{"mysql", params.ApplicationLogging{}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ApplicationLoggingSuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("application logging"))
+	_, err := s.runApplicationLogging(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support application logging settings")
+}
+
+type mockApplicationLoggingAPI struct {
+	*testing.Stub
+	logging params.ApplicationLogging
+}
+
+func (m *mockApplicationLoggingAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockApplicationLoggingAPI) GetLogging(application string) (params.ApplicationLogging, error) {
+	m.MethodCall(m, "GetLogging", application)
+	return m.logging, m.NextErr()
+}
+
+func (m *mockApplicationLoggingAPI) SetLogging(application string, logging params.ApplicationLogging) error {
+	m.MethodCall(m, "SetLogging", application, logging)
+	return m.NextErr()
+}
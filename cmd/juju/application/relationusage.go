@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageShowRelationUsageSummary = `
+Shows the relation settings stored by each unit of an application.`[1:]
+
+var usageShowRelationUsageDetails = `
+Units exchange data with the units they are related to through relation
+settings. The controller limits the number of settings each unit may
+store for a relation, and their total size, according to the
+max-relation-settings-keys and max-relation-settings-size model config
+values. A hook tool call that would exceed either limit fails.
+
+This command shows, for each relation of the given application, how many
+settings each of its units has stored and their size in bytes, along
+with the limits that apply. A limit of 0 means there is no limit.
+
+Examples:
+    juju show-relation-usage mysql
+    juju show-relation-usage mysql --format yaml
+
+See also:
+    model-config
+    relate`[1:]
+
+// NewShowRelationUsageCommand returns a command to show the relation
+// settings usage of an application's units.
+func NewShowRelationUsageCommand() cmd.Command {
+	cmd := &showRelationUsageCommand{}
+	cmd.newAPIFunc = func() (RelationUsageAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// RelationUsageAPI defines the API methods that the show-relation-usage
+// command uses.
+type RelationUsageAPI interface {
+	Close() error
+	RelationSettingsUsage(application string) (params.RelationSettingsUsageResult, error)
+}
+
+type showRelationUsageCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	newAPIFunc func() (RelationUsageAPI, error)
+
+	applicationName string
+}
+
+func (c *showRelationUsageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-relation-usage",
+		Args:    "<application name>",
+		Purpose: usageShowRelationUsageSummary,
+		Doc:     usageShowRelationUsageDetails,
+	}
+}
+
+func (c *showRelationUsageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRelationUsageTabular,
+	})
+}
+
+func (c *showRelationUsageCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// relationUsage is the serialised form of the relation settings usage
+// of an application, as displayed by "juju show-relation-usage".
+type relationUsage struct {
+	MaxKeys int                 `yaml:"max-keys" json:"max-keys"`
+	MaxSize int                 `yaml:"max-size" json:"max-size"`
+	Units   []unitRelationUsage `yaml:"units,omitempty" json:"units,omitempty"`
+}
+
+type unitRelationUsage struct {
+	Relation   string `yaml:"relation" json:"relation"`
+	RelationId int    `yaml:"relation-id" json:"relation-id"`
+	Unit       string `yaml:"unit" json:"unit"`
+	Keys       int    `yaml:"keys" json:"keys"`
+	Size       int    `yaml:"size" json:"size"`
+}
+
+func (c *showRelationUsageCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.RelationSettingsUsage(c.applicationName)
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support showing relation settings usage")
+	} else if err != nil {
+		return err
+	}
+	usage := relationUsage{
+		MaxKeys: result.MaxKeys,
+		MaxSize: result.MaxSize,
+	}
+	for _, u := range result.Usage {
+		usage.Units = append(usage.Units, unitRelationUsage{
+			Relation:   u.RelationKey,
+			RelationId: u.RelationId,
+			Unit:       u.Unit,
+			Keys:       u.Keys,
+			Size:       u.Size,
+		})
+	}
+	return c.out.Write(ctx, usage)
+}
+
+func formatRelationUsageTabular(writer io.Writer, value interface{}) error {
+	usage, ok := value.(relationUsage)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", usage, value)
+	}
+	limit := func(n int) string {
+		if n == 0 {
+			return "unlimited"
+		}
+		return fmt.Sprint(n)
+	}
+	fmt.Fprintf(writer, "Key limit:  %s\n", limit(usage.MaxKeys))
+	fmt.Fprintf(writer, "Size limit: %s\n", limit(usage.MaxSize))
+	if len(usage.Units) == 0 {
+		return nil
+	}
+	fmt.Fprintln(writer)
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Relation", "Id", "Unit", "Keys", "Size")
+	for _, u := range usage.Units {
+		w.Println(u.Relation, u.RelationId, u.Unit, u.Keys, u.Size)
+	}
+	return tw.Flush()
+}
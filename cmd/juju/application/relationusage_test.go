@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ShowRelationUsageSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockRelationUsageAPI
+}
+
+var _ = gc.Suite(&ShowRelationUsageSuite{})
+
+func (s *ShowRelationUsageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockRelationUsageAPI{
+		Stub: &testing.Stub{},
+		usage: params.RelationSettingsUsageResult{
+			MaxKeys: 1000,
+			MaxSize: 1048576,
+			Usage: []params.RelationSettingsUsage{{
+				RelationKey: "wordpress:db mysql:server",
+				RelationId:  0,
+				Unit:        "mysql/0",
+				Keys:        3,
+				Size:        128,
+			}, {
+				RelationKey: "wordpress:db mysql:server",
+				RelationId:  0,
+				Unit:        "mysql/1",
+				Keys:        1,
+				Size:        16,
+			}},
+		},
+	}
+}
+
+func (s *ShowRelationUsageSuite) runShowRelationUsage(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewShowRelationUsageCommandForTest(s.mockAPI, NewMockStore()), args...)
+}
+
+func (s *ShowRelationUsageSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := s.runShowRelationUsage(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ShowRelationUsageSuite) TestTabular(c *gc.C) {
+	ctx, err := s.runShowRelationUsage(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Key limit:  1000
+Size limit: 1048576
+
+Relation                   Id  Unit     Keys  Size
+wordpress:db mysql:server  0   mysql/0  3     128
+wordpress:db mysql:server  0   mysql/1  1     16
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RelationSettingsUsage", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ShowRelationUsageSuite) TestTabularUnlimited(c *gc.C) {
+	s.mockAPI.usage = params.RelationSettingsUsageResult{}
+	ctx, err := s.runShowRelationUsage(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Key limit:  unlimited
+Size limit: unlimited
+`[1:])
+}
+
+func (s *ShowRelationUsageSuite) TestYAML(c *gc.C) {
+	ctx, err := s.runShowRelationUsage(c, "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+max-keys: 1000
+max-size: 1048576
+units:
+- relation: wordpress:db mysql:server
+  relation-id: 0
+  unit: mysql/0
+  keys: 3
+  size: 128
+- relation: wordpress:db mysql:server
+  relation-id: 0
+  unit: mysql/1
+  keys: 1
+  size: 16
+`[1:])
+}
+
+func (s *ShowRelationUsageSuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("relation settings usage"))
+	_, err := s.runShowRelationUsage(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support showing relation settings usage")
+}
+
+type mockRelationUsageAPI struct {
+	*testing.Stub
+	usage params.RelationSettingsUsageResult
+}
+
+func (m *mockRelationUsageAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockRelationUsageAPI) RelationSettingsUsage(application string) (params.RelationSettingsUsageResult, error) {
+	m.MethodCall(m, "RelationSettingsUsage", application)
+	return m.usage, m.NextErr()
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageShowRemovalReportSummary = `
+Shows the cleanup skipped when an application's units were forcibly removed.`[1:]
+
+var usageShowRemovalReportDetails = `
+An application removed with "juju remove-application --hook-timeout" has
+its remaining units forcibly removed once the timeout expires, skipping
+the stop, relation-broken and storage-detaching hooks that had not yet
+completed. This command shows which cleanup steps were skipped, so that
+anything the charm would have cleaned up can be dealt with by hand.
+
+Examples:
+    juju show-removal-report mysql
+    juju show-removal-report mysql --format yaml
+
+See also:
+    remove-application`[1:]
+
+// NewShowRemovalReportCommand returns a command to show the removal
+// report of an application.
+func NewShowRemovalReportCommand() cmd.Command {
+	cmd := &showRemovalReportCommand{}
+	cmd.newAPIFunc = func() (RemovalReportAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// RemovalReportAPI defines the API methods that the show-removal-report
+// command uses.
+type RemovalReportAPI interface {
+	Close() error
+	ApplicationRemovalReport(application string) (params.ApplicationRemovalReport, error)
+}
+
+type showRemovalReportCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	newAPIFunc func() (RemovalReportAPI, error)
+
+	applicationName string
+}
+
+func (c *showRemovalReportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-removal-report",
+		Args:    "<application name>",
+		Purpose: usageShowRemovalReportSummary,
+		Doc:     usageShowRemovalReportDetails,
+	}
+}
+
+func (c *showRemovalReportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRemovalReportTabular,
+	})
+}
+
+func (c *showRemovalReportCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// removalReport is the serialised form of an application's removal
+// report, as displayed by "juju show-removal-report".
+type removalReport struct {
+	Application string   `yaml:"application" json:"application"`
+	HookTimeout string   `yaml:"hook-timeout" json:"hook-timeout"`
+	Forced      string   `yaml:"forced" json:"forced"`
+	Skipped     []string `yaml:"skipped,omitempty" json:"skipped,omitempty"`
+}
+
+func (c *showRemovalReportCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ApplicationRemovalReport(c.applicationName)
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support showing removal reports")
+	} else if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, removalReport{
+		Application: result.Application,
+		HookTimeout: result.HookTimeout.String(),
+		Forced:      result.Forced.UTC().Format(time.RFC3339),
+		Skipped:     result.Skipped,
+	})
+}
+
+func formatRemovalReportTabular(writer io.Writer, value interface{}) error {
+	report, ok := value.(removalReport)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", report, value)
+	}
+	fmt.Fprintf(writer, "Units of %s forcibly removed at %s, after %s.\n",
+		report.Application, report.Forced, report.HookTimeout)
+	if len(report.Skipped) == 0 {
+		return nil
+	}
+	fmt.Fprintln(writer, "\nSkipped:")
+	for _, step := range report.Skipped {
+		fmt.Fprintf(writer, "  %s\n", step)
+	}
+	return nil
+}
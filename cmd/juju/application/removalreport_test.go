@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ShowRemovalReportSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockRemovalReportAPI
+}
+
+var _ = gc.Suite(&ShowRemovalReportSuite{})
+
+func (s *ShowRemovalReportSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockRemovalReportAPI{
+		Stub: &testing.Stub{},
+		report: params.ApplicationRemovalReport{
+			Application: "mysql",
+			HookTimeout: 10 * time.Minute,
+			Forced:      time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+			Skipped: []string{
+				"unit mysql/0: stop hook did not complete",
+				`unit mysql/0: left relation "wordpress:db mysql:server" without running relation-departed and relation-broken hooks`,
+			},
+		},
+	}
+}
+
+func (s *ShowRemovalReportSuite) runShowRemovalReport(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewShowRemovalReportCommandForTest(s.mockAPI, NewMockStore()), args...)
+}
+
+func (s *ShowRemovalReportSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := s.runShowRemovalReport(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ShowRemovalReportSuite) TestTabular(c *gc.C) {
+	ctx, err := s.runShowRemovalReport(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Units of mysql forcibly removed at 2017-11-01T12:00:00Z, after 10m0s.
+
+Skipped:
+  unit mysql/0: stop hook did not complete
+  unit mysql/0: left relation "wordpress:db mysql:server" without running relation-departed and relation-broken hooks
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"ApplicationRemovalReport", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ShowRemovalReportSuite) TestYAML(c *gc.C) {
+	s.mockAPI.report.Skipped = s.mockAPI.report.Skipped[:1]
+	ctx, err := s.runShowRemovalReport(c, "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+application: mysql
+hook-timeout: 10m0s
+forced: "2017-11-01T12:00:00Z"
+skipped:
+- 'unit mysql/0: stop hook did not complete'
+`[1:])
+}
+
+func (s *ShowRemovalReportSuite) TestNotFound(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotFoundf(`removal report for application "mysql"`))
+	_, err := s.runShowRemovalReport(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `removal report for application "mysql" not found`)
+}
+
+func (s *ShowRemovalReportSuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("application removal reports"))
+	_, err := s.runShowRemovalReport(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support showing removal reports")
+}
+
+type mockRemovalReportAPI struct {
+	*testing.Stub
+	report params.ApplicationRemovalReport
+}
+
+func (m *mockRemovalReportAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockRemovalReportAPI) ApplicationRemovalReport(application string) (params.ApplicationRemovalReport, error) {
+	m.MethodCall(m, "ApplicationRemovalReport", application)
+	return m.report, m.NextErr()
+}
@@ -4,6 +4,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -25,6 +27,7 @@ func NewRemoveApplicationCommand() cmd.Command {
 type removeApplicationCommand struct {
 	modelcmd.ModelCommandBase
 	DestroyStorage   bool
+	HookTimeout      time.Duration
 	ApplicationNames []string
 }
 
@@ -40,9 +43,18 @@ before removing them. Removing units which are co-located with units of
 other charms or a Juju controller will not result in the removal of the
 machine.
 
+Units run their stop and relation-broken hooks as they are removed. If
+--hook-timeout is specified, units that have not been removed once it
+expires are forcibly removed, skipping any cleanup that has not been done.
+The skipped cleanup steps can be reviewed with show-removal-report.
+
 Examples:
     juju remove-application hadoop
-    juju remove-application -m test-model mariadb`[1:]
+    juju remove-application -m test-model mariadb
+    juju remove-application --hook-timeout 10m hadoop
+
+See also:
+    show-removal-report`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -56,6 +68,7 @@ func (c *removeApplicationCommand) Info() *cmd.Info {
 func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to application units")
+	f.DurationVar(&c.HookTimeout, "hook-timeout", 0, "Forcibly remove units whose end-of-life hooks have not completed after this time")
 }
 
 func (c *removeApplicationCommand) Init(args []string) error {
@@ -67,6 +80,9 @@ func (c *removeApplicationCommand) Init(args []string) error {
 			return errors.Errorf("invalid application name %q", arg)
 		}
 	}
+	if c.HookTimeout < 0 {
+		return errors.Errorf("invalid hook timeout %v", c.HookTimeout)
+	}
 	c.ApplicationNames = args
 	return nil
 }
@@ -103,6 +119,9 @@ func (c *removeApplicationCommand) Run(ctx *cmd.Context) error {
 	if c.DestroyStorage && apiVersion < 5 {
 		return errors.New("--destroy-storage is not supported by this controller")
 	}
+	if c.HookTimeout > 0 && apiVersion < 7 {
+		return errors.New("--hook-timeout is not supported by this controller")
+	}
 	return c.removeApplications(ctx, client)
 }
 
@@ -128,6 +147,7 @@ func (c *removeApplicationCommand) removeApplications(
 	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
 		Applications:   c.ApplicationNames,
 		DestroyStorage: c.DestroyStorage,
+		HookTimeout:    c.HookTimeout,
 	})
 	if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
 		return errors.Trace(err)
@@ -61,6 +61,22 @@ func (s *RemoveApplicationSuite) TestLocalApplication(c *gc.C) {
 	c.Assert(multiSeries.Life(), gc.Equals, state.Dying)
 }
 
+func (s *RemoveApplicationSuite) TestHookTimeout(c *gc.C) {
+	s.setupTestApplication(c)
+	ctx, err := runRemoveApplication(c, "--hook-timeout", "10m", "multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, gc.Equals, "removing application multi-series\n")
+	multiSeries, err := s.State.Application("multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(multiSeries.Life(), gc.Equals, state.Dying)
+}
+
+func (s *RemoveApplicationSuite) TestInvalidHookTimeout(c *gc.C) {
+	_, err := runRemoveApplication(c, "--hook-timeout", "-1m", "multi-series")
+	c.Assert(err, gc.ErrorMatches, "invalid hook timeout -1m0s")
+}
+
 func (s *RemoveApplicationSuite) TestDetachStorage(c *gc.C) {
 	s.testStorageRemoval(c, false)
 }
@@ -8,8 +8,10 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -32,9 +34,13 @@ The relation is specified using the relation endpoint names, eg
 It is also possible to specify the relation ID, if known. This is useful to
 terminate a relation originating from a different model, where only the ID is known. 
 
+With --dry-run, the units that would leave the relation's scope are listed,
+and the relation is not removed.
+
 Examples:
     juju remove-relation mysql wordpress
     juju remove-relation 4
+    juju remove-relation mysql wordpress --dry-run
 
 In the case of multiple relations, the relation name should be specified
 at least once - the following examples will all have the same effect:
@@ -66,6 +72,7 @@ type removeRelationCommand struct {
 	modelcmd.ModelCommandBase
 	RelationId int
 	Endpoints  []string
+	DryRun     bool
 	newAPIFunc func() (ApplicationDestroyRelationAPI, error)
 }
 
@@ -78,6 +85,11 @@ func (c *removeRelationCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *removeRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show which units would leave the relation, without removing it")
+}
+
 func (c *removeRelationCommand) Init(args []string) (err error) {
 	if len(args) == 1 {
 		if c.RelationId, err = strconv.Atoi(args[0]); err != nil || c.RelationId < 0 {
@@ -98,9 +110,11 @@ type ApplicationDestroyRelationAPI interface {
 	BestAPIVersion() int
 	DestroyRelation(endpoints ...string) error
 	DestroyRelationId(relationId int) error
+	PreviewDestroyRelation(endpoints ...string) (params.RelationPreviewResult, error)
+	PreviewDestroyRelationId(relationId int) (params.RelationPreviewResult, error)
 }
 
-func (c *removeRelationCommand) Run(_ *cmd.Context) error {
+func (c *removeRelationCommand) Run(ctx *cmd.Context) error {
 	client, err := c.newAPIFunc()
 	if err != nil {
 		return err
@@ -109,6 +123,9 @@ func (c *removeRelationCommand) Run(_ *cmd.Context) error {
 	if len(c.Endpoints) == 0 && client.BestAPIVersion() < 5 {
 		return errors.New("removing a relation using its ID is not supported by this version of Juju")
 	}
+	if c.DryRun {
+		return c.previewRemoveRelation(ctx, client)
+	}
 	if len(c.Endpoints) > 0 {
 		err = client.DestroyRelation(c.Endpoints...)
 	} else {
@@ -116,3 +133,22 @@ func (c *removeRelationCommand) Run(_ *cmd.Context) error {
 	}
 	return block.ProcessBlockedError(err, block.BlockRemove)
 }
+
+// previewRemoveRelation reports which units would leave the relation's
+// scope were it removed.
+func (c *removeRelationCommand) previewRemoveRelation(ctx *cmd.Context, client ApplicationDestroyRelationAPI) error {
+	var result params.RelationPreviewResult
+	var err error
+	if len(c.Endpoints) > 0 {
+		result, err = client.PreviewDestroyRelation(c.Endpoints...)
+	} else {
+		result, err = client.PreviewDestroyRelationId(c.RelationId)
+	}
+	if errors.IsNotSupported(err) {
+		return errors.New("this juju controller does not support --dry-run")
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return writeRelationPreview(ctx, result, "removed", "leave")
+}
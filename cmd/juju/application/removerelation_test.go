@@ -11,6 +11,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -86,12 +87,51 @@ func (s *RemoveRelationSuite) TestRemoveRelationBlocked(c *gc.C) {
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
+func (s *RemoveRelationSuite) TestRemoveRelationDryRun(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, NewRemoveRelationCommandForTest(s.mockAPI), "123", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+relation "wordpress:db mysql:server" can be removed
+  mysql:server (provider, interface mysql, global scope)
+  wordpress:db (requirer, interface mysql, global scope)
+units that would leave scope: mysql/0, wordpress/0
+`[1:])
+	s.mockAPI.CheckCallNames(c, "PreviewDestroyRelationId", "Close")
+	s.mockAPI.CheckCall(c, 0, "PreviewDestroyRelationId", 123)
+}
+
+func (s *RemoveRelationSuite) TestRemoveRelationDryRunEndpoints(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewRemoveRelationCommandForTest(s.mockAPI), "wordpress", "mysql", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "PreviewDestroyRelation", "Close")
+	s.mockAPI.CheckCall(c, 0, "PreviewDestroyRelation", []string{"wordpress", "mysql"})
+}
+
 type mockRemoveAPI struct {
 	*testing.Stub
 	version            int
 	removeRelationFunc func(endpoints ...string) error
 }
 
+func (s mockRemoveAPI) PreviewDestroyRelation(endpoints ...string) (params.RelationPreviewResult, error) {
+	s.MethodCall(s, "PreviewDestroyRelation", endpoints)
+	return previewRemoveResult, s.NextErr()
+}
+
+func (s mockRemoveAPI) PreviewDestroyRelationId(relationId int) (params.RelationPreviewResult, error) {
+	s.MethodCall(s, "PreviewDestroyRelationId", relationId)
+	return previewRemoveResult, s.NextErr()
+}
+
+var previewRemoveResult = params.RelationPreviewResult{
+	Key: "wordpress:db mysql:server",
+	Endpoints: map[string]params.CharmRelation{
+		"wordpress": {Name: "db", Role: "requirer", Interface: "mysql", Scope: "global"},
+		"mysql":     {Name: "server", Role: "provider", Interface: "mysql", Scope: "global"},
+	},
+	Units: []string{"mysql/0", "wordpress/0"},
+}
+
 func (s mockRemoveAPI) Close() error {
 	s.MethodCall(s, "Close")
 	return s.NextErr()
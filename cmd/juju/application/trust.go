@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTrustSummary = `
+Grants an application access to the model's cloud credential.`[1:]
+
+var usageTrustDetails = `
+Some charms, such as those that integrate the model with the underlying
+cloud, need to make calls to the cloud API themselves. Trusting an
+application allows the hooks of its units to retrieve the model's cloud
+credential with the credential-get hook tool. The credential is fetched
+from the controller each time it is requested, and is never stored on
+the unit by Juju. Each retrieval is recorded by the controller.
+
+Only model administrators may trust an application.
+
+The credential given to a trusted application is the model's credential
+as stored, not a scoped or temporary one, so an application should only be
+trusted if it may act with all of the credential's permissions. Credential
+access must also be enabled on the controller by setting its
+"allow-credential-get" config to true. Applications that are trusted
+cannot be migrated to another controller; remove their trust first.
+
+Use --remove to revoke the application's access to the credential, and
+--show to see whether an application is trusted and when its units last
+retrieved the credential.
+
+Examples:
+    juju trust aws-integrator
+    juju trust aws-integrator --show
+    juju trust aws-integrator --remove
+
+See also:
+    expose`[1:]
+
+// NewTrustCommand returns a command to grant an application access
+// to the model's cloud credential.
+func NewTrustCommand() cmd.Command {
+	cmd := &trustCommand{}
+	cmd.newAPIFunc = func() (TrustAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// TrustAPI defines the API methods that the trust command uses.
+type TrustAPI interface {
+	Close() error
+	SetTrust(application string, trusted bool) error
+	GetTrust(application string) (params.ApplicationTrustResult, error)
+}
+
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	newAPIFunc func() (TrustAPI, error)
+
+	applicationName string
+	remove          bool
+	show            bool
+}
+
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.remove, "remove", false, "Revoke the application's access to the cloud credential")
+	f.BoolVar(&c.show, "show", false, "Show whether the application is trusted")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	if c.remove && c.show {
+		return errors.New("--remove and --show cannot be used together")
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// applicationTrust is the serialised form of the trust granted to an
+// application, as displayed by "juju trust --show".
+type applicationTrust struct {
+	Trusted    bool       `yaml:"trusted" json:"trusted"`
+	GrantedBy  string     `yaml:"granted-by,omitempty" json:"granted-by,omitempty"`
+	Granted    *time.Time `yaml:"granted,omitempty" json:"granted,omitempty"`
+	LastUsed   *time.Time `yaml:"last-used,omitempty" json:"last-used,omitempty"`
+	LastUsedBy string     `yaml:"last-used-by,omitempty" json:"last-used-by,omitempty"`
+	UseCount   int        `yaml:"use-count,omitempty" json:"use-count,omitempty"`
+}
+
+func (c *trustCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.show {
+		result, err := client.GetTrust(c.applicationName)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support trusting applications")
+		} else if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, applicationTrust{
+			Trusted:    result.Trusted,
+			GrantedBy:  result.GrantedBy,
+			Granted:    result.Granted,
+			LastUsed:   result.LastUsed,
+			LastUsedBy: result.LastUsedBy,
+			UseCount:   result.UseCount,
+		})
+	}
+
+	err = client.SetTrust(c.applicationName, !c.remove)
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support trusting applications")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
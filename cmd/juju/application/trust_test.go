@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TrustSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockTrustAPI
+}
+
+var _ = gc.Suite(&TrustSuite{})
+
+func (s *TrustSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockTrustAPI{Stub: &testing.Stub{}}
+}
+
+func (s *TrustSuite) runTrust(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewTrustCommandForTest(s.mockAPI, NewMockStore()), args...)
+}
+
+func (s *TrustSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"foo/0"},
+		err:  `application name "foo/0" not valid`,
+	}, {
+		args: []string{"foo", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}, {
+		args: []string{"foo", "--remove", "--show"},
+		err:  "--remove and --show cannot be used together",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := s.runTrust(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *TrustSuite) TestTrust(c *gc.C) {
+	_, err := s.runTrust(c, "aws-integrator")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetTrust", []interface{}{"aws-integrator", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *TrustSuite) TestTrustRemove(c *gc.C) {
+	_, err := s.runTrust(c, "aws-integrator", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetTrust", []interface{}{"aws-integrator", false}},
+		{"Close", nil},
+	})
+}
+
+func (s *TrustSuite) TestTrustNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("trusting applications"))
+	_, err := s.runTrust(c, "aws-integrator")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support trusting applications")
+}
+
+func (s *TrustSuite) TestTrustBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestTrustBlocked"))
+	_, err := s.runTrust(c, "aws-integrator")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestTrustBlocked.*")
+}
+
+func (s *TrustSuite) TestTrustShow(c *gc.C) {
+	granted := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	lastUsed := time.Date(2017, 10, 2, 12, 0, 0, 0, time.UTC)
+	s.mockAPI.trust = params.ApplicationTrustResult{
+		Trusted:    true,
+		GrantedBy:  "admin",
+		Granted:    &granted,
+		LastUsed:   &lastUsed,
+		LastUsedBy: "aws-integrator/0",
+		UseCount:   3,
+	}
+	ctx, err := s.runTrust(c, "aws-integrator", "--show")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+trusted: true
+granted-by: admin
+granted: 2017-10-01T12:00:00Z
+last-used: 2017-10-02T12:00:00Z
+last-used-by: aws-integrator/0
+use-count: 3
+`[1:])
+	s.mockAPI.CheckCallNames(c, "GetTrust", "Close")
+}
+
+func (s *TrustSuite) TestTrustShowNotTrusted(c *gc.C) {
+	ctx, err := s.runTrust(c, "aws-integrator", "--show")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "trusted: false\n")
+}
+
+type mockTrustAPI struct {
+	*testing.Stub
+	trust params.ApplicationTrustResult
+}
+
+func (m *mockTrustAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockTrustAPI) SetTrust(application string, trusted bool) error {
+	m.MethodCall(m, "SetTrust", application, trusted)
+	return m.NextErr()
+}
+
+func (m *mockTrustAPI) GetTrust(application string) (params.ApplicationTrustResult, error) {
+	m.MethodCall(m, "GetTrust", application)
+	return m.trust, m.NextErr()
+}
@@ -107,6 +107,16 @@ type upgradeCharmCommand struct {
 	CharmPath       string
 	Revision        int // defaults to -1 (latest)
 
+	// GitRef is the ref of the git repository from which the
+	// application's charm was built to upgrade to.
+	GitRef string
+
+	// GitKey and GitKnownHosts are the paths of the SSH private key
+	// and known_hosts file with which the controller fetches a charm
+	// from a git+ssh repository.
+	GitKey        string
+	GitKnownHosts string
+
 	// Resources is a map of resource name to filename to be uploaded on upgrade.
 	Resources map[string]string
 
@@ -142,6 +152,17 @@ which to load the updated charm. Note that the directory containing the charm mu
 match what was originally used to deploy the charm as a superficial check that the
 updated charm is compatible.
 
+If the charm was deployed from a git repository, it is rebuilt by the controller
+from the latest commit of the ref it was deployed from, or of the ref given with
+the --git-ref flag. A charm may also be taken from a git repository with --path
+or --switch, in the same form as for "juju deploy". The --git-known-hosts and
+--git-key flags give the controller the credentials for a git+ssh repository,
+as for "juju deploy".
+
+  juju upgrade-charm foo --git-known-hosts ./known_hosts
+  juju upgrade-charm foo --git-ref v2.1 --git-known-hosts ./known_hosts
+  juju upgrade-charm foo --switch git+ssh://git@example.com/charms/foo.git#next
+
 Resources may be uploaded at upgrade time by specifying the --resource flag.
 Following the resource flag should be name=filepath pair.  This flag may be
 repeated more than once to upload more than one resource.
@@ -213,6 +234,9 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.SwitchURL, "switch", "", "Crossgrade to a different charm")
 	f.StringVar(&c.CharmPath, "path", "", "Upgrade to a charm located at path")
 	f.IntVar(&c.Revision, "revision", -1, "Explicit revision of current charm")
+	f.StringVar(&c.GitRef, "git-ref", "", "Upgrade to the given ref of the git repository the charm was deployed from")
+	f.StringVar(&c.GitKey, "git-key", "", "Path to the SSH private key with which to fetch a charm from a git+ssh repository")
+	f.StringVar(&c.GitKnownHosts, "git-known-hosts", "", "Path to a known_hosts file holding the host keys of a git+ssh repository's host")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
@@ -239,6 +263,9 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.GitRef != "" && (c.SwitchURL != "" || c.CharmPath != "" || c.Revision != -1) {
+		return errors.Errorf("--git-ref cannot be used with --switch, --path or --revision")
+	}
 	return nil
 }
 
@@ -278,13 +305,20 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		newRef = c.CharmPath
 	}
 	if c.SwitchURL == "" && c.CharmPath == "" {
-		// If the charm we are upgrading is local, then we must
-		// specify a path or switch url to upgrade with.
 		if oldURL.Schema == "local" {
-			return errors.New("upgrading a local charm requires either --path or --switch")
+			// A local charm built from a git repository is rebuilt
+			// from it, otherwise we must specify a path or switch
+			// url to upgrade with.
+			newRef, err = c.gitSourceRef(c.NewCharmClient(apiRoot), oldURL)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		} else if c.GitRef != "" {
+			return errors.Errorf("charm %q was not deployed from a git repository", oldURL)
+		} else {
+			// No new URL specified, but revision might have been.
+			newRef = oldURL.WithRevision(c.Revision).String()
 		}
-		// No new URL specified, but revision might have been.
-		newRef = oldURL.WithRevision(c.Revision).String()
 	}
 
 	// First, ensure the charm is added to the model.
@@ -306,7 +340,11 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 	deployedSeries := applicationInfo.Series
 
-	chID, csMac, err := c.addCharm(charmAdder, charmRepo, modelConfig, oldURL, newRef, deployedSeries)
+	gitCreds, err := readGitCredentials(ctx, c.GitKey, c.GitKnownHosts)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	chID, csMac, err := c.addCharm(charmAdder, charmRepo, modelConfig, oldURL, newRef, deployedSeries, gitCreds)
 	if err != nil {
 		if termErr, ok := errors.Cause(err).(*common.TermsRequiredError); ok {
 			return errors.Trace(termErr.UserErr())
@@ -346,6 +384,29 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
 
+// gitSourceRef returns the reference to the charm to upgrade the local
+// charm with the given URL to, if it was built from a git repository.
+func (c *upgradeCharmCommand) gitSourceRef(client CharmClient, curl *charm.URL) (string, error) {
+	info, err := client.CharmInfo(curl.String())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if info.GitSource == nil {
+		if c.GitRef != "" {
+			return "", errors.Errorf("charm %q was not deployed from a git repository", curl)
+		}
+		return "", errors.New("upgrading a local charm requires either --path or --switch")
+	}
+	ref := c.GitRef
+	if ref == "" {
+		ref = info.GitSource.Ref
+	}
+	if ref == "" {
+		return info.GitSource.Repository, nil
+	}
+	return info.GitSource.Repository + "#" + ref, nil
+}
+
 // upgradeResources pushes metadata up to the server for each resource defined
 // in the new charm's metadata and returns a map of resource names to pending
 // IDs to include in the upgrage-charm call.
@@ -502,8 +563,25 @@ func (c *upgradeCharmCommand) addCharm(
 	oldURL *charm.URL,
 	charmRef string,
 	deployedSeries string,
+	gitCreds api.GitCredentials,
 ) (charmstore.CharmID, *macaroon.Macaroon, error) {
 	var id charmstore.CharmID
+	// Charm may be built from a git repository, for the deployed series.
+	if repository, ref, ok := parseGitCharmRef(charmRef); ok {
+		newURL, err := charmAdder.AddCharmFromGit(repository, ref, deployedSeries, gitCreds)
+		if err != nil {
+			return id, nil, errors.Trace(err)
+		}
+		if newURL.Name != oldURL.Name {
+			return id, nil, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newURL.Name)
+		}
+		if *newURL == *oldURL {
+			return id, nil, errors.Errorf("already running latest charm %q", newURL)
+		}
+		id.URL = newURL
+		return id, nil, nil
+	}
+
 	// Charm may have been supplied via a path reference. If so, build a
 	// local charm URL from the deployed series.
 	ch, newURL, err := charmrepo.NewCharmAtPathForceSeries(charmRef, deployedSeries, c.ForceSeries)
@@ -231,6 +231,86 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) setUpGitCharm() {
+	s.charmUpgradeClient.charmURL = charm.MustParseURL("local:quantal/foo-1")
+	s.charmClient.charmInfo.GitSource = &charms.GitSource{
+		Repository: "git+ssh://git@example.com/foo.git",
+		Ref:        "stable",
+		Revision:   "0123456789abcdef",
+	}
+	s.charmAdder.gitCharmURL = charm.MustParseURL("local:quantal/foo-2")
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeGitCharm(c *gc.C) {
+	s.setUpGitCharm()
+	_, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAdder.CheckCalls(c, []testing.StubCall{{
+		"AddCharmFromGit", []interface{}{"git+ssh://git@example.com/foo.git", "stable", "", api.GitCredentials{}},
+	}})
+	s.charmUpgradeClient.CheckCall(c, 2, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID:         jujucharmstore.CharmID{URL: s.charmAdder.gitCharmURL},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeGitCharmRef(c *gc.C) {
+	s.setUpGitCharm()
+	_, err := s.runUpgradeCharm(c, "foo", "--git-ref", "v2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAdder.CheckCall(c, 0, "AddCharmFromGit", "git+ssh://git@example.com/foo.git", "v2", "", api.GitCredentials{})
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeGitCharmKnownHosts(c *gc.C) {
+	s.setUpGitCharm()
+	knownHostsPath := filepath.Join(c.MkDir(), "known_hosts")
+	err := ioutil.WriteFile(knownHostsPath, []byte("example.com ssh-ed25519 AAAA"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runUpgradeCharm(c, "foo", "--git-known-hosts", knownHostsPath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAdder.CheckCall(c, 0, "AddCharmFromGit", "git+ssh://git@example.com/foo.git", "stable", "", api.GitCredentials{
+		KnownHosts: "example.com ssh-ed25519 AAAA",
+	})
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeGitCharmSwitch(c *gc.C) {
+	s.charmUpgradeClient.charmURL = charm.MustParseURL("local:quantal/foo-1")
+	s.charmAdder.gitCharmURL = charm.MustParseURL("local:quantal/foo-2")
+	_, err := s.runUpgradeCharm(c, "foo", "--switch", "git+https://example.com/foo.git#next")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAdder.CheckCall(c, 0, "AddCharmFromGit", "git+https://example.com/foo.git", "next", "", api.GitCredentials{})
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeGitCharmUpToDate(c *gc.C) {
+	s.setUpGitCharm()
+	s.charmAdder.gitCharmURL = s.charmUpgradeClient.charmURL
+	_, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `already running latest charm "local:quantal/foo-1"`)
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeLocalCharmNotFromGit(c *gc.C) {
+	s.charmUpgradeClient.charmURL = charm.MustParseURL("local:quantal/foo-1")
+	_, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, gc.ErrorMatches, "upgrading a local charm requires either --path or --switch")
+	s.charmAdder.CheckNoCalls(c)
+}
+
+func (s *UpgradeCharmSuite) TestGitRefLocalCharmNotFromGit(c *gc.C) {
+	s.charmUpgradeClient.charmURL = charm.MustParseURL("local:quantal/foo-1")
+	_, err := s.runUpgradeCharm(c, "foo", "--git-ref", "v2")
+	c.Assert(err, gc.ErrorMatches, `charm "local:quantal/foo-1" was not deployed from a git repository`)
+}
+
+func (s *UpgradeCharmSuite) TestGitRefNotFromGit(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--git-ref", "v2")
+	c.Assert(err, gc.ErrorMatches, `charm "cs:quantal/foo-1" was not deployed from a git repository`)
+}
+
+func (s *UpgradeCharmSuite) TestGitRefWithRevision(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--git-ref", "v2", "--revision", "3")
+	c.Assert(err, gc.ErrorMatches, "--git-ref cannot be used with --switch, --path or --revision")
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -719,6 +799,12 @@ func (*mockAPIConnection) Close() error {
 type mockCharmAdder struct {
 	CharmAdder
 	testing.Stub
+	gitCharmURL *charm.URL
+}
+
+func (m *mockCharmAdder) AddCharmFromGit(repository, ref, series string, creds api.GitCredentials) (*charm.URL, error) {
+	m.MethodCall(m, "AddCharmFromGit", repository, ref, series, creds)
+	return m.gitCharmURL, m.NextErr()
 }
 
 func (m *mockCharmAdder) AddCharm(curl *charm.URL, channel csclientparams.Channel) error {
@@ -30,6 +30,26 @@ func NewEnableCommandForTest(api unblockClientAPI, err error) cmd.Command {
 	})
 }
 
+// NewFreezeCommandForTest returns a new freeze-model command with the
+// apiFunc specified to return the args.
+func NewFreezeCommandForTest(api modelFreezeAPI, err error) cmd.Command {
+	return modelcmd.Wrap(&freezeCommand{
+		apiFunc: func(_ newAPIRoot) (modelFreezeAPI, error) {
+			return api, err
+		},
+	})
+}
+
+// NewUnfreezeCommandForTest returns a new unfreeze-model command with
+// the apiFunc specified to return the args.
+func NewUnfreezeCommandForTest(api modelFreezeAPI, err error) cmd.Command {
+	return modelcmd.Wrap(&unfreezeCommand{
+		apiFunc: func(_ newAPIRoot) (modelFreezeAPI, error) {
+			return api, err
+		},
+	})
+}
+
 type listMockAPI interface {
 	blockListAPI
 	// Can't include two interfaces that specify the same method
@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package block
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelfreeze"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// modelFreezeAPI defines the client API methods that the freeze-model
+// and unfreeze-model commands use.
+type modelFreezeAPI interface {
+	Close() error
+	Freeze(message string) error
+	Unfreeze() error
+	FreezeStatus() (modelfreeze.Freeze, error)
+}
+
+func getModelFreezeAPI(c newAPIRoot) (modelFreezeAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return modelfreeze.NewClient(root), nil
+}
+
+// NewFreezeCommand returns a freeze-model command instance that will
+// use the default API.
+func NewFreezeCommand() cmd.Command {
+	return modelcmd.Wrap(&freezeCommand{apiFunc: getModelFreezeAPI})
+}
+
+// freezeCommand makes a model read-only for users.
+type freezeCommand struct {
+	modelcmd.ModelCommandBase
+	apiFunc func(newAPIRoot) (modelFreezeAPI, error)
+	message string
+}
+
+// Init implements Command.
+func (c *freezeCommand) Init(args []string) error {
+	c.message = strings.Join(args, " ")
+	return nil
+}
+
+// Info implements Command.
+func (c *freezeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "freeze-model",
+		Args:    "[message...]",
+		Purpose: "Make the model read-only for users.",
+		Doc:     freezeDoc,
+	}
+}
+
+// Run implements Command.
+func (c *freezeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.apiFunc(c)
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	freeze, err := api.FreezeStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if freeze.Frozen {
+		ctx.Infof("Replacing freeze made by %s at %s: %s",
+			freeze.By, freeze.Since.UTC().Format(time.RFC3339), freeze.Message)
+	}
+	if err := api.Freeze(c.message); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model is now read-only for users.")
+	return nil
+}
+
+// NewUnfreezeCommand returns an unfreeze-model command instance that
+// will use the default API.
+func NewUnfreezeCommand() cmd.Command {
+	return modelcmd.Wrap(&unfreezeCommand{apiFunc: getModelFreezeAPI})
+}
+
+// unfreezeCommand removes the read-only freeze of a model.
+type unfreezeCommand struct {
+	modelcmd.ModelCommandBase
+	apiFunc func(newAPIRoot) (modelFreezeAPI, error)
+}
+
+// Init implements Command.
+func (c *unfreezeCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Info implements Command.
+func (c *unfreezeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "unfreeze-model",
+		Purpose: "Allow users to change a frozen model again.",
+		Doc:     unfreezeDoc,
+	}
+}
+
+// Run implements Command.
+func (c *unfreezeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.apiFunc(c)
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	return errors.Trace(api.Unfreeze())
+}
+
+const freezeDoc = `
+Freezing a model makes it read-only for users, for example to hold a change
+freeze while an incident is handled. Commands that would change the model
+fail with the given message, while status, watchers and other read-only
+commands continue to work. The model's agents are not affected, and
+continue to operate the model.
+
+Controller superusers are not subject to the freeze, so that they may make
+emergency changes. Model admins may freeze a model, but only controller
+superusers may unfreeze it.
+
+Examples:
+    juju freeze-model "Incident 42 in progress, contact the on-call lead"
+
+See also:
+    unfreeze-model
+    disable-command
+`
+
+const unfreezeDoc = `
+Removes the freeze made by freeze-model, so that users may change the model
+again. Only controller superusers may unfreeze a model.
+
+Examples:
+    juju unfreeze-model
+
+See also:
+    freeze-model
+`
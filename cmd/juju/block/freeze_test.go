@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package block_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/modelfreeze"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&freezeSuite{})
+
+type freezeSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+func (s *freezeSuite) TestFreeze(c *gc.C) {
+	api := &mockFreezeAPI{}
+	ctx, err := cmdtesting.RunCommand(c, block.NewFreezeCommandForTest(api, nil), "incident", "42")
+	c.Assert(err, jc.ErrorIsNil)
+	api.CheckCallNames(c, "FreezeStatus", "Freeze", "Close")
+	api.CheckCall(c, 1, "Freeze", "incident 42")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Model is now read-only for users.\n")
+}
+
+func (s *freezeSuite) TestFreezeReplaces(c *gc.C) {
+	api := &mockFreezeAPI{freeze: modelfreeze.Freeze{
+		Frozen:  true,
+		Message: "incident 41",
+		By:      "bob",
+		Since:   time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := cmdtesting.RunCommand(c, block.NewFreezeCommandForTest(api, nil), "incident 42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Replacing freeze made by bob at 2017-09-01T12:00:00Z: incident 41\n"+
+		"Model is now read-only for users.\n")
+}
+
+func (s *freezeSuite) TestFreezeAPIError(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, block.NewFreezeCommandForTest(nil, errors.New("boom")))
+	c.Assert(err, gc.ErrorMatches, "cannot connect to the API: boom")
+}
+
+func (s *freezeSuite) TestUnfreeze(c *gc.C) {
+	api := &mockFreezeAPI{}
+	_, err := cmdtesting.RunCommand(c, block.NewUnfreezeCommandForTest(api, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	api.CheckCallNames(c, "Unfreeze", "Close")
+}
+
+func (s *freezeSuite) TestUnfreezeInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, block.NewUnfreezeCommandForTest(&mockFreezeAPI{}, nil), "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+type mockFreezeAPI struct {
+	jujutesting.Stub
+	freeze modelfreeze.Freeze
+}
+
+func (m *mockFreezeAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockFreezeAPI) Freeze(message string) error {
+	m.MethodCall(m, "Freeze", message)
+	return m.NextErr()
+}
+
+func (m *mockFreezeAPI) Unfreeze() error {
+	m.MethodCall(m, "Unfreeze")
+	return m.NextErr()
+}
+
+func (m *mockFreezeAPI) FreezeStatus() (modelfreeze.Freeze, error) {
+	m.MethodCall(m, "FreezeStatus")
+	return m.freeze, m.NextErr()
+}
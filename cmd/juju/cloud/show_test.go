@@ -80,6 +80,15 @@ config:
 
 var openstackProviderConfig = `
 The available config options specific to openstack clouds are:
+egress-policy:
+  type: string
+  description: Whether machines may send traffic anywhere ("allow-all"), or only to
+    the destinations Juju needs and those allowed by egress-rules ("restricted").
+egress-rules:
+  type: string
+  description: A space separated list of outgoing traffic to allow when egress-policy
+    is "restricted", each of the form <port>[-<port>][/<protocol>][@<cidr>[,<cidr>...]],
+    e.g. "5432/tcp@10.0.0.0/8".
 external-network:
   type: string
   description: The network label or UUID to create floating IP addresses on when multiple
@@ -21,6 +21,7 @@ import (
 
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/juju/keys"
+	"github.com/juju/juju/utils/httptransport"
 )
 
 type updateCloudsCommand struct {
@@ -65,7 +66,7 @@ func (c *updateCloudsCommand) Info() *cmd.Info {
 
 func (c *updateCloudsCommand) Run(ctxt *cmd.Context) error {
 	fmt.Fprint(ctxt.Stderr, "Fetching latest public cloud list...\n")
-	client := utils.GetHTTPClient(utils.VerifySSLHostnames)
+	client := httptransport.NewClient(utils.VerifySSLHostnames)
 	resp, err := client.Get(c.publicCloudURL)
 	if err != nil {
 		return err
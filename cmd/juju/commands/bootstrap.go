@@ -67,6 +67,13 @@ other models are created.
 If '--bootstrap-constraints' is used, its values will also apply to any
 future controllers provisioned for high availability (HA).
 
+The '--controller-size' option selects a named controller sizing preset:
+small, medium or large. Providers map each preset to suitable instance
+types where they can, and otherwise to CPU and memory constraints. Any
+'--bootstrap-constraints' take precedence over the preset. Without a
+preset or bootstrap constraints, controllers are provisioned with the
+model constraints, or a minimum of 3.5GiB of memory if there are none.
+
 If '--constraints' is used, its values will be set as the default
 constraints for all future workload machines in the model, exactly as if
 the constraints were set with ` + "`juju set-model-constraints`" + `.
@@ -137,6 +144,8 @@ type bootstrapCommand struct {
 	ConstraintsStr          string
 	BootstrapConstraints    constraints.Value
 	BootstrapConstraintsStr string
+	ControllerSize          bootstrap.ControllerSize
+	controllerSizeStr       string
 	BootstrapSeries         string
 	BootstrapImage          string
 	BuildAgent              bool
@@ -174,6 +183,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set model constraints")
 	f.StringVar(&c.BootstrapConstraintsStr, "bootstrap-constraints", "", "Specify bootstrap machine constraints")
+	f.StringVar(&c.controllerSizeStr, "controller-size", "", "Specify a controller sizing preset: small, medium or large")
 	f.StringVar(&c.BootstrapSeries, "bootstrap-series", "", "Specify the series of the bootstrap machine")
 	if featureflag.Enabled(feature.ImageMetadata) {
 		f.StringVar(&c.BootstrapImage, "bootstrap-image", "", "Specify the image of the bootstrap machine")
@@ -211,6 +221,11 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 	if c.BootstrapSeries != "" && !charm.IsValidSeries(c.BootstrapSeries) {
 		return errors.NotValidf("series %q", c.BootstrapSeries)
 	}
+	if c.controllerSizeStr != "" {
+		if c.ControllerSize, err = bootstrap.ParseControllerSize(c.controllerSizeStr); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Parse the placement directive. Bootstrap currently only
 	// supports provider-specific placement directives.
@@ -418,8 +433,11 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		return errors.Annotate(err, "error reading current controller")
 	}
 
+	// If bootstrap fails with --keep-broken, the controller details are
+	// kept so that the controller can be killed later.
+	var keepControllerDetails bool
 	defer func() {
-		if resultErr == nil || errors.IsAlreadyExists(resultErr) {
+		if resultErr == nil || errors.IsAlreadyExists(resultErr) || keepControllerDetails {
 			return
 		}
 		if oldCurrentController != "" {
@@ -491,26 +509,44 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		c.controllerName, cloudRegion,
 	)
 
+	// Record the cloud resources created while bootstrapping, so that
+	// exactly those resources can be removed if bootstrap fails.
+	resourceLogPath := bootstrap.ResourceLogPath(c.controllerName)
+	resourceLog, err := bootstrap.NewResourceLog(resourceLogPath)
+	if err != nil {
+		return errors.Annotate(err, "creating bootstrap log")
+	}
+
 	// If we error out for any reason, clean up the environment.
 	defer func() {
-		if resultErr != nil {
-			if c.KeepBrokenEnvironment {
-				ctx.Infof(`
+		if resultErr == nil {
+			if err := resourceLog.Remove(); err != nil {
+				logger.Warningf("cannot remove bootstrap log: %v", err)
+			}
+			return
+		}
+		if c.KeepBrokenEnvironment {
+			keepControllerDetails = true
+			ctx.Infof(`
 bootstrap failed but --keep-broken was specified so resources are not being destroyed.
 When you have finished diagnosing the problem, remember to clean up the failed controller.
-See `[1:] + "`juju kill-controller`" + `.`)
-			} else {
-				logger.Errorf("%v", resultErr)
-				logger.Debugf("(error details: %v)", errors.Details(resultErr))
-				// Set resultErr to cmd.ErrSilent to prevent
-				// logging the error twice.
-				resultErr = cmd.ErrSilent
-				handleBootstrapError(ctx, func() error {
-					return environsDestroy(
-						c.controllerName, environ, store,
-					)
-				})
-			}
+The resources created during bootstrap are recorded in %s.
+See `[1:]+"`juju kill-controller --from-bootstrap-log %s`"+`.`, resourceLogPath, c.controllerName)
+		} else {
+			logger.Errorf("%v", resultErr)
+			logger.Debugf("(error details: %v)", errors.Details(resultErr))
+			// Set resultErr to cmd.ErrSilent to prevent
+			// logging the error twice.
+			resultErr = cmd.ErrSilent
+			handleBootstrapError(ctx, func() error {
+				if err := removeLoggedResources(resourceLogPath, environ); err != nil {
+					logger.Errorf("cannot remove resources recorded in bootstrap log: %v", err)
+				}
+				if err := environsDestroy(c.controllerName, environ, store); err != nil {
+					return errors.Trace(err)
+				}
+				return resourceLog.Remove()
+			})
 		}
 	}()
 
@@ -538,8 +574,20 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	controllerConstraints := c.BootstrapConstraints
+	if c.ControllerSize != "" {
+		sizeConstraints, err := bootstrap.ControllerSizeConstraints(environ, c.ControllerSize)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("%s controller size constraints: %v", c.ControllerSize, sizeConstraints)
+		controllerConstraints, err = constraintsValidator.Merge(sizeConstraints, controllerConstraints)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	bootstrapConstraints, err := constraintsValidator.Merge(
-		c.Constraints, c.BootstrapConstraints,
+		c.Constraints, controllerConstraints,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -585,6 +633,7 @@ See `[1:] + "`juju kill-controller`" + `.`)
 		GUIDataSourceBaseURL:      guiDataSourceBaseURL,
 		AdminSecret:               config.bootstrap.AdminSecret,
 		CAPrivateKey:              config.bootstrap.CAPrivateKey,
+		ResourceRecorder:          resourceLog,
 		DialOpts: environs.BootstrapDialOpts{
 			Timeout:        config.bootstrap.BootstrapTimeout,
 			RetryDelay:     config.bootstrap.BootstrapRetryDelay,
@@ -1087,6 +1136,16 @@ func checkProviderType(envType string) error {
 	return nil
 }
 
+// removeLoggedResources removes the resources recorded in the bootstrap
+// log at the given path.
+func removeLoggedResources(path string, env environs.Environ) error {
+	resources, err := bootstrap.ReadResourceLog(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return bootstrap.RemoveResources(env, resources)
+}
+
 // handleBootstrapError is called to clean up if bootstrap fails.
 func handleBootstrapError(ctx *cmd.Context, cleanup func() error) {
 	ch := make(chan os.Signal, 1)
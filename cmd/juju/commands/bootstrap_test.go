@@ -361,6 +361,23 @@ var bootstrapTests = []bootstrapTest{{
 	args:                 []string{"--constraints", "mem=4G cores=4", "--bootstrap-constraints", "mem=8G"},
 	constraints:          constraints.MustParse("mem=4G cores=4"),
 	bootstrapConstraints: constraints.MustParse("mem=8G cores=4"),
+}, {
+	info:                 "controller size",
+	args:                 []string{"--controller-size", "medium"},
+	bootstrapConstraints: constraints.MustParse("cores=4 mem=16G"),
+}, {
+	info:                 "controller size and environ constraints",
+	args:                 []string{"--constraints", "mem=4G", "--controller-size", "small"},
+	constraints:          constraints.MustParse("mem=4G"),
+	bootstrapConstraints: constraints.MustParse("cores=2 mem=8G"),
+}, {
+	info:                 "bootstrap constraints override controller size",
+	args:                 []string{"--controller-size", "large", "--bootstrap-constraints", "mem=64G"},
+	bootstrapConstraints: constraints.MustParse("cores=8 mem=64G"),
+}, {
+	info: "invalid controller size",
+	args: []string{"--controller-size", "huge"},
+	err:  `controller size "huge" \(expected one of small, medium, large\) not valid`,
 }, {
 	info:        "unsupported constraint passed through but no error",
 	args:        []string{"--constraints", "mem=4G cores=4 cpu-power=10"},
@@ -1336,7 +1353,14 @@ func (s *BootstrapSuite) TestBootstrapKeepBroken(c *gc.C) {
 		}
 	}
 	stderr := strings.Replace(cmdtesting.Stderr(ctx), "\n", " ", -1)
-	c.Assert(stderr, gc.Matches, `.*See .*juju kill\-controller.*`)
+	c.Assert(stderr, gc.Matches, `.*See .*juju kill\-controller --from-bootstrap-log devcontroller.*`)
+
+	// The controller details and bootstrap log are kept, so that the
+	// controller can be killed later.
+	_, err := s.store.ControllerByName("devcontroller")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = bootstrap.ReadResourceLog(bootstrap.ResourceLogPath("devcontroller"))
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *BootstrapSuite) TestBootstrapUnknownCloudOrProvider(c *gc.C) {
@@ -31,22 +31,30 @@ Show help on a Juju charm tool.
 var expectedCommands = []string{
 	"action-fail",
 	"action-get",
+	"action-log",
+	"action-progress",
 	"action-set",
 	"add-metric",
 	"application-version-set",
 	"close-port",
 	"config-get",
+	"config-ready",
+	"credential-get",
+	"hook-env-get",
 	"is-leader",
 	"juju-log",
 	"juju-reboot",
 	"leader-get",
 	"leader-set",
+	"lock-acquire",
+	"lock-release",
 	"network-get",
 	"open-port",
 	"opened-ports",
 	"payload-register",
 	"payload-status-set",
 	"payload-unregister",
+	"related-status-get",
 	"relation-get",
 	"relation-ids",
 	"relation-list",
@@ -302,6 +302,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newDefaultRunCommand())
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil))
+	r.Register(newTunnelCommand())
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand(nil))
@@ -356,6 +357,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewConsoleCommand())
+	r.Register(machine.NewImportInstanceCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -365,6 +368,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewSetDescriptionCommand())
+	r.Register(model.NewSuspendModelCommand())
+	r.Register(model.NewResumeModelCommand())
+	r.Register(model.NewHistoryCommand())
+	r.Register(model.NewExportTopologyCommand())
+	r.Register(model.NewApplyTopologyCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -378,6 +387,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -387,7 +398,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
+	r.Register(application.NewExportBundleCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewTrustCommand())
+	r.Register(application.NewShowRelationUsageCommand())
+	r.Register(application.NewShowRemovalReportCommand())
+	r.Register(application.NewHookEnvCommand())
+	r.Register(application.NewAddressPolicyCommand())
+	r.Register(application.NewApplicationLoggingCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -395,6 +413,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(block.NewDisableCommand())
 	r.Register(block.NewListCommand())
 	r.Register(block.NewEnableCommand())
+	r.Register(block.NewFreezeCommand())
+	r.Register(block.NewUnfreezeCommand())
 
 	// Manage storage
 	r.Register(storage.NewAddCommand())
@@ -405,6 +425,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewGrowStorageCommandWithAPI())
+	r.Register(storage.NewSnapshotStorageCommandWithAPI())
+	r.Register(storage.NewListStorageSnapshotsCommandWithAPI())
+	r.Register(storage.NewRestoreStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
@@ -436,6 +460,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewRotateKeyPairCommand())
+	r.Register(controller.NewControllerTxnsCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
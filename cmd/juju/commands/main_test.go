@@ -386,6 +386,7 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
 	"add-cloud",
 	"add-credential",
@@ -398,8 +399,11 @@ var commandNames = []string{
 	"add-subnet",
 	"add-unit",
 	"add-user",
+	"address-policy",
 	"agree",
 	"agreements",
+	"application-logging",
+	"apply-topology",
 	"attach",
 	"attach-resource",
 	"attach-storage",
@@ -415,8 +419,10 @@ var commandNames = []string{
 	"clouds",
 	"collect-metrics",
 	"config",
+	"console",
 	"consume",
 	"controller-config",
+	"controller-txns",
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
@@ -436,18 +442,26 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
+	"export-topology",
 	"expose",
 	"find-offers",
 	"firewall-rules",
+	"freeze-model",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
+	"grow-storage",
 	"gui",
 	"help",
 	"help-tool",
+	"history",
+	"hook-env",
 	"import-filesystem",
+	"import-instance",
 	"import-ssh-key",
 	"kill-controller",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -469,6 +483,7 @@ var commandNames = []string{
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"list-wallets",
@@ -489,6 +504,7 @@ var commandNames = []string{
 	"register",
 	"relate", //alias for add-relation
 	"reload-spaces",
+	"remove-action-schedule",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -504,13 +520,17 @@ var commandNames = []string{
 	"resolved",
 	"resources",
 	"restore-backup",
+	"restore-storage",
+	"resume-model",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
+	"rotate-controller-keypair",
 	"run",
 	"run-action",
 	"scp",
 	"set-constraints",
+	"set-description",
 	"set-default-credential",
 	"set-default-region",
 	"set-firewall-rule",
@@ -526,23 +546,31 @@ var commandNames = []string{
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-relation-usage",
+	"show-removal-report",
 	"show-status",
 	"show-status-log",
 	"show-storage",
 	"show-user",
 	"show-wallet",
 	"sla",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"ssh-keys",
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
+	"suspend-model",
 	"suspend-relation",
 	"switch",
 	"sync-tools",
+	"trust",
+	"tunnel",
 	"unexpose",
+	"unfreeze-model",
 	"unregister",
 	"update-clouds",
 	"update-credential",
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -17,27 +18,60 @@ func newResolvedCommand() cmd.Command {
 	return modelcmd.Wrap(&resolvedCommand{})
 }
 
+const resolvedDoc = `
+Marks a unit in an error state as resolved, so that it may continue,
+re-executing the failed hook unless --no-retry is specified.
+
+With --all, every unit in an error state in the model is marked resolved
+instead, or only those of the application given with --application.
+
+Examples:
+    juju resolved mysql/0
+    juju resolved --no-retry mysql/0
+    juju resolved --all
+    juju resolved --all --application mysql
+`
+
 // resolvedCommand marks a unit in an error state as ready to continue.
 type resolvedCommand struct {
 	modelcmd.ModelCommandBase
-	UnitName string
-	NoRetry  bool
+	UnitName    string
+	NoRetry     bool
+	All         bool
+	Application string
 }
 
 func (c *resolvedCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "resolved",
-		Args:    "<unit>",
+		Args:    "<unit> | --all [--application <application>]",
 		Purpose: "Marks unit errors resolved and re-executes failed hooks.",
+		Doc:     resolvedDoc,
 	}
 }
 
 func (c *resolvedCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.NoRetry, "no-retry", false, "Do not re-execute failed hooks on the unit")
+	f.BoolVar(&c.All, "all", false, "Mark all units in an error state resolved")
+	f.StringVar(&c.Application, "application", "", "Restrict --all to the units of this application")
 }
 
 func (c *resolvedCommand) Init(args []string) error {
+	if c.Application != "" {
+		if !c.All {
+			return errors.New("--application requires --all")
+		}
+		if !names.IsValidApplication(c.Application) {
+			return errors.Errorf("invalid application name %q", c.Application)
+		}
+	}
+	if c.All {
+		if len(args) > 0 {
+			return errors.New("cannot specify a unit with --all")
+		}
+		return nil
+	}
 	if len(args) > 0 {
 		c.UnitName = args[0]
 		if !names.IsValidUnit(c.UnitName) {
@@ -51,6 +85,9 @@ func (c *resolvedCommand) Init(args []string) error {
 }
 
 func (c *resolvedCommand) Run(_ *cmd.Context) error {
+	if c.All {
+		return c.resolveAll()
+	}
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return err
@@ -58,3 +95,17 @@ func (c *resolvedCommand) Run(_ *cmd.Context) error {
 	defer client.Close()
 	return block.ProcessBlockedError(client.Resolved(c.UnitName, c.NoRetry), block.BlockChange)
 }
+
+func (c *resolvedCommand) resolveAll() error {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client := application.NewClient(root)
+	defer client.Close()
+	err = client.ResolveAllUnitErrors(c.Application, !c.NoRetry)
+	if errors.IsNotSupported(err) {
+		return errors.New("resolving all units is not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -87,6 +87,15 @@ var resolvedTests = []struct {
 	}, {
 		args: []string{"multi-series/4", "roflcopter"},
 		err:  `unrecognized args: \["roflcopter"\]`,
+	}, {
+		args: []string{"--all", "multi-series/4"},
+		err:  `cannot specify a unit with --all`,
+	}, {
+		args: []string{"--application", "multi-series"},
+		err:  `--application requires --all`,
+	}, {
+		args: []string{"--all", "--application", "multi-series/4"},
+		err:  `invalid application name "multi-series/4"`,
 	},
 }
 
@@ -125,6 +134,36 @@ func (s *ResolvedSuite) TestResolved(c *gc.C) {
 	}
 }
 
+func (s *ResolvedSuite) TestResolvedAll(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	err := runDeploy(c, "-n", "3", ch, "multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	for _, name := range []string{"multi-series/1", "multi-series/2"} {
+		u, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.SetAgentStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: "lol borken",
+			Since:   &now,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err = runResolved(c, []string{"--all", "--application", "multi-series", "--no-retry"})
+	c.Assert(err, jc.ErrorIsNil)
+	for name, mode := range map[string]state.ResolvedMode{
+		"multi-series/0": state.ResolvedNone,
+		"multi-series/1": state.ResolvedNoHooks,
+		"multi-series/2": state.ResolvedNoHooks,
+	} {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(unit.Resolved(), gc.Equals, mode, gc.Commentf("unit %s", name))
+	}
+}
+
 func (s *ResolvedSuite) TestBlockResolved(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
 	err := runDeploy(c, "-n", "5", ch, "multi-series")
@@ -28,6 +28,14 @@ The SSH host keys of the target are verified. The --no-host-key-checks option
 can be used to disable these checks. Use of this option is not recommended as
 it opens up the possibility of a man-in-the-middle attack.
 
+In models whose agent-connection-mode is "reverse", the controller cannot
+connect to the model's machines, and connections are made through the tunnels
+that the machine agents open to the controller instead.
+
+Connections to machines and units inside containers are made through the
+container's host machine, as container addresses are often not routable from
+the client.
+
 Examples:
 Connect to machine 0:
 
@@ -41,6 +49,10 @@ Connect to a mysql unit:
 
     juju ssh mysql/0
 
+Connect to the first LXD container on machine 2:
+
+    juju ssh 2/lxd/0
+
 Connect to a jenkins unit as user jenkins:
 
     juju ssh jenkins@jenkins/0
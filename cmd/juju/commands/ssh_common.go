@@ -5,6 +5,7 @@ package commands
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
@@ -32,6 +33,7 @@ import (
 type SSHCommon struct {
 	modelcmd.ModelCommandBase
 	proxy           bool
+	tunnel          bool
 	pty             bool
 	noHostKeyChecks bool
 	Target          string
@@ -51,7 +53,9 @@ type sshAPIClient interface {
 	PrivateAddress(target string) (string, error)
 	AllAddresses(target string) ([]string, error)
 	PublicKeys(target string) ([]string, error)
+	HostMachine(target string) (string, error)
 	Proxy() (bool, error)
+	ReverseTunnel() (bool, error)
 	Close() error
 }
 
@@ -59,6 +63,11 @@ type resolvedTarget struct {
 	user   string
 	entity string
 	host   string
+
+	// jumpHost is the id of the machine to proxy the connection
+	// through, when the target is inside a container on that
+	// machine.
+	jumpHost string
 }
 
 func (t *resolvedTarget) userHost() string {
@@ -133,7 +142,8 @@ func (c *SSHCommon) setHostChecker(checker jujussh.ReachableChecker) {
 // if SSH proxying is required. It must be called at the top of the
 // command's Run method.
 //
-// The apiClient, apiAddr and proxy fields are initialized after this call.
+// The apiClient, apiAddr, proxy and tunnel fields are initialized after
+// this call.
 func (c *SSHCommon) initRun() error {
 	if err := c.ensureAPIClient(); err != nil {
 		return errors.Trace(err)
@@ -145,6 +155,16 @@ func (c *SSHCommon) initRun() error {
 		c.proxy = proxy
 	}
 
+	// When the controller cannot connect to the model's machines,
+	// they can only be reached through the tunnels their agents
+	// open to the controller.
+	tunnel, err := c.apiClient.ReverseTunnel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("reverse tunnel is %v", tunnel)
+	c.tunnel = tunnel
+
 	// Used mostly for testing, but useful for debugging and/or
 	// backwards-compatibility with some scripts.
 	c.forceAPIv1 = os.Getenv(jujuSSHClientForceAPIv1) != ""
@@ -196,7 +216,20 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 		options.EnablePTY()
 	}
 
-	if c.proxy {
+	jumpHost, err := targetsJumpHost(targets)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if c.tunnel {
+		if err := c.setTunnelCommand(&options); err != nil {
+			return nil, err
+		}
+	} else if jumpHost != "" {
+		if err := c.setJumpCommand(&options, jumpHost); err != nil {
+			return nil, err
+		}
+	} else if c.proxy {
 		if err := c.setProxyCommand(&options); err != nil {
 			return nil, err
 		}
@@ -296,6 +329,70 @@ func (c *SSHCommon) setProxyCommand(options *ssh.Options) error {
 	return nil
 }
 
+// setJumpCommand sets the proxy command option to connect through the
+// machine with the given id, which hosts the container being connected
+// to. The connection to the host machine is itself made with juju ssh,
+// so that it is proxied through the controller if necessary, and so
+// that nested containers are reached through each of their hosts.
+func (c *SSHCommon) setJumpCommand(options *ssh.Options, hostMachine string) error {
+	juju, err := getJujuExecutable()
+	if err != nil {
+		return errors.Errorf("failed to get juju executable path: %v", err)
+	}
+	modelName, err := c.ModelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args := []string{
+		"ssh",
+		"--model=" + modelName,
+		"--proxy=" + fmt.Sprint(c.proxy),
+	}
+	if c.noHostKeyChecks {
+		args = append(args, "--no-host-key-checks")
+	}
+	args = append(args, "--pty=false", hostMachine, "-q", "nc %h %p")
+	options.SetProxyCommand(juju, args...)
+	return nil
+}
+
+// targetsJumpHost returns the id of the machine through which the
+// connections to the given targets must be proxied, if any. Targets
+// inside containers on different machines cannot be reached with a
+// single connection.
+func targetsJumpHost(targets []*resolvedTarget) (string, error) {
+	jumpHost := ""
+	for _, target := range targets {
+		if target.jumpHost == "" {
+			continue
+		}
+		if jumpHost != "" && jumpHost != target.jumpHost {
+			return "", errors.Errorf(
+				"cannot connect to containers on machines %q and %q at once",
+				jumpHost, target.jumpHost,
+			)
+		}
+		jumpHost = target.jumpHost
+	}
+	return jumpHost, nil
+}
+
+// setTunnelCommand sets the proxy command option to connect through
+// the tunnels that machine agents open to the controller. The target
+// host names are the tags of the machines or units to connect to.
+func (c *SSHCommon) setTunnelCommand(options *ssh.Options) error {
+	juju, err := getJujuExecutable()
+	if err != nil {
+		return errors.Errorf("failed to get juju executable path: %v", err)
+	}
+	modelName, err := c.ModelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	options.SetProxyCommand(juju, "tunnel", "--model="+modelName, "%h")
+	return nil
+}
+
 func (c *SSHCommon) ensureAPIClient() error {
 	if c.apiClient != nil {
 		return nil
@@ -320,6 +417,25 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 		// Not a machine or unit agent target - use directly.
 		return out, nil
 	}
+	if c.tunnel {
+		// The tunnel command connects to the target's machine
+		// itself, so there is no address to find.
+		out.host = targetTag(out.entity).String()
+		return out, nil
+	}
+
+	jumpHost, err := c.hostMachine(out.entity)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if jumpHost != "" {
+		// Container addresses are frequently only routable from
+		// the container's host machine, so connect to the container
+		// through the host, using the container's private address.
+		logger.Debugf("target %q is a container on machine %q", out.entity, jumpHost)
+		out.jumpHost = jumpHost
+		return c.resolveWithRetry(*out, c.apiClient.PrivateAddress)
+	}
 
 	getAddress := c.reachableAddressGetter
 	if c.apiClient.BestAPIVersion() < 2 || c.forceAPIv1 {
@@ -344,6 +460,26 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 	return c.resolveWithRetry(*out, getAddress)
 }
 
+// hostMachine returns the id of the machine hosting the given machine
+// or unit target if the target is inside a container, or an empty
+// string if it is not. With API servers that cannot report the host
+// machine of units, only machine targets are recognised as containers.
+func (c *SSHCommon) hostMachine(entity string) (string, error) {
+	if c.apiClient.BestAPIVersion() < 3 {
+		// Container ids are the id of their host machine,
+		// followed by the container type and number.
+		if parts := strings.Split(entity, "/"); names.IsValidMachine(entity) && len(parts) > 2 {
+			return strings.Join(parts[:len(parts)-2], "/"), nil
+		}
+		return "", nil
+	}
+	hostMachine, err := c.apiClient.HostMachine(entity)
+	if err != nil {
+		return "", errors.Annotatef(err, "finding host machine of %q", entity)
+	}
+	return hostMachine, nil
+}
+
 func (c *SSHCommon) resolveAsAgent(target string) (*resolvedTarget, bool) {
 	out := new(resolvedTarget)
 	out.user, out.entity = splitUserTarget(target)
@@ -445,6 +581,14 @@ func targetIsAgent(target string) bool {
 	return names.IsValidMachine(target) || names.IsValidUnit(target)
 }
 
+// targetTag returns the tag of the machine or unit agent target.
+func targetTag(target string) names.Tag {
+	if names.IsValidMachine(target) {
+		return names.NewMachineTag(target)
+	}
+	return names.NewUnitTag(target)
+}
+
 func splitUserTarget(target string) (string, string) {
 	if i := strings.IndexRune(target, '@'); i != -1 {
 		return target[:i], target[i+1:]
@@ -33,6 +33,16 @@ type argsSpec struct {
 	// expected.
 	withProxy bool
 
+	// withTunnel specifies if the juju tunnel ProxyCommand option
+	// is expected.
+	withTunnel bool
+
+	// jumpHost specifies the id of the machine that the juju
+	// ProxyCommand option is expected to connect through, if any.
+	// withProxy then specifies whether that connection is itself
+	// expected to be proxied.
+	jumpHost string
+
 	// enablePty specifies if the forced PTY allocation switches are
 	// expected.
 	enablePty bool
@@ -72,7 +82,15 @@ func (s *argsSpec) check(c *gc.C, output string) {
 		expect("-o StrictHostKeyChecking " + s.hostKeyChecking)
 	}
 
-	if s.withProxy {
+	if s.withTunnel {
+		expect("-o ProxyCommand juju tunnel --model=controller %h")
+	}
+	if s.jumpHost != "" {
+		expect(fmt.Sprintf("-o ProxyCommand juju ssh "+
+			"--model=controller "+
+			"--proxy=%v "+
+			"--pty=false %s -q \"nc %%h %%p\"", s.withProxy, s.jumpHost))
+	} else if s.withProxy {
 		expect("-o ProxyCommand juju ssh " +
 			"--model=controller " +
 			"--proxy=false " +
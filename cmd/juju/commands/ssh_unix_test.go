@@ -16,7 +16,10 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/instance"
 	jujussh "github.com/juju/juju/network/ssh"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
 type SSHSuite struct {
@@ -221,6 +224,95 @@ func (s *SSHSuite) TestSSHCommandModelConfigProxySSH(c *gc.C) {
 
 }
 
+func (s *SSHSuite) TestSSHCommandReverseTunnel(c *gc.C) {
+	s.setupModel(c)
+	s.setHostChecker(nil) // not used when tunnelling
+
+	// When agents connect in reverse mode, connections are made
+	// through the agents' tunnels, to the machine or unit tag.
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"agent-connection-mode": "reverse",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, newSSHCommand(s.hostChecker), "0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		enablePty:       true,
+		withTunnel:      true,
+		args:            "ubuntu@machine-0",
+	}
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+
+	ctx, err = cmdtesting.RunCommand(c, newSSHCommand(s.hostChecker), "--proxy", "mysql/0")
+	c.Check(err, jc.ErrorIsNil)
+	expectedArgs.args = "ubuntu@unit-mysql-0"
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+}
+
+func (s *SSHSuite) TestSSHCommandContainer(c *gc.C) {
+	s.setupModel(c)
+	s.setHostChecker(nil) // not used for containers
+
+	// Containers are reached through their host machine, using
+	// their private address.
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	container, err := s.State.AddMachineInsideMachine(template, "0", instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAddresses(c, container)
+	s.setKeys(c, container)
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: container})
+
+	ctx, err := cmdtesting.RunCommand(c, newSSHCommand(s.hostChecker), "0/lxd/0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0/lxd/0",
+		enablePty:       true,
+		jumpHost:        "0",
+		args:            "ubuntu@0/lxd/0.private",
+	}
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+
+	ctx, err = cmdtesting.RunCommand(c, newSSHCommand(s.hostChecker), "mysql/1", "uname")
+	c.Check(err, jc.ErrorIsNil)
+	expectedArgs.args = "ubuntu@0/lxd/0.private uname"
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+
+	// The connection to the host is proxied through the
+	// controller when requested.
+	ctx, err = cmdtesting.RunCommand(c, newSSHCommand(s.hostChecker), "--proxy", "mysql/1")
+	c.Check(err, jc.ErrorIsNil)
+	expectedArgs.withProxy = true
+	expectedArgs.args = "ubuntu@0/lxd/0.private"
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+}
+
+func (s *SSHSuite) TestTargetsJumpHost(c *gc.C) {
+	jumpHost, err := targetsJumpHost([]*resolvedTarget{
+		{entity: "0"},
+		{entity: "1/lxd/0", jumpHost: "1"},
+		{entity: "1/lxd/1", jumpHost: "1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(jumpHost, gc.Equals, "1")
+
+	_, err = targetsJumpHost([]*resolvedTarget{
+		{entity: "1/lxd/0", jumpHost: "1"},
+		{entity: "2/lxd/0", jumpHost: "2"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot connect to containers on machines "1" and "2" at once`)
+}
+
 func (s *SSHSuite) TestSSHWillWorkInUpgrade(c *gc.C) {
 	// Check the API client interface used by "juju ssh" against what
 	// the API server will allow during upgrades. Ensure that the API
@@ -236,6 +328,10 @@ func (s *SSHSuite) TestSSHWillWorkInUpgrade(c *gc.C) {
 		if name == "Close" {
 			continue
 		}
+		// ReverseTunnel is served by the Proxy API method.
+		if name == "ReverseTunnel" {
+			name = "Proxy"
+		}
 		c.Logf("checking %q", name)
 		c.Check(apiserver.IsMethodAllowedDuringUpgrade("SSHClient", name), jc.IsTrue)
 	}
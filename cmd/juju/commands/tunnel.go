@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	apitunnel "github.com/juju/juju/api/tunnel"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTunnelSummary = `
+Connects standard input and output to the SSH server of a Juju machine.`[1:]
+
+var usageTunnelDetails = `
+In models whose agent-connection-mode is "reverse", the controller cannot
+connect to the model's machines. Instead, each machine agent keeps a channel
+open to the controller, over which it is asked to open tunnels to the
+machine's SSH server.
+
+The tunnel command connects its standard input and output to such a tunnel.
+It is used by "juju ssh", "juju scp" and "juju debug-hooks" as the SSH proxy
+command for these models, and is not usually run directly.
+
+The machine is identified by the <target> argument, which is either a
+machine or unit tag, a unit name or a machine id.
+
+Examples:
+
+    juju tunnel machine-0
+    juju tunnel mysql/0
+
+See also:
+    ssh
+    scp`
+
+func newTunnelCommand() cmd.Command {
+	return modelcmd.Wrap(&tunnelCommand{})
+}
+
+// tunnelCommand relays standard input and output through a tunnel to
+// the SSH server of a machine.
+type tunnelCommand struct {
+	modelcmd.ModelCommandBase
+	connect func(names.Tag) (io.ReadWriteCloser, error)
+
+	target names.Tag
+}
+
+func (c *tunnelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "tunnel",
+		Args:    "<target>",
+		Purpose: usageTunnelSummary,
+		Doc:     usageTunnelDetails,
+	}
+}
+
+func (c *tunnelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no target name specified")
+	}
+	target, args := args[0], args[1:]
+	if targetIsAgent(target) {
+		c.target = targetTag(target)
+	} else {
+		tag, err := names.ParseTag(target)
+		if err != nil || (tag.Kind() != names.MachineTagKind && tag.Kind() != names.UnitTagKind) {
+			return errors.NotValidf("target %q", target)
+		}
+		c.target = tag
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *tunnelCommand) Run(ctx *cmd.Context) error {
+	conn, err := c.connectTunnel()
+	if err != nil {
+		return errors.Annotatef(err, "cannot connect to %s", names.ReadableString(c.target))
+	}
+	defer conn.Close()
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(conn, ctx.Stdin)
+		done <- err
+	}()
+	go func() {
+		_, err := io.Copy(ctx.Stdout, conn)
+		done <- err
+	}()
+	// SSH closes both streams when it is done, so either side
+	// ending ends the tunnel.
+	return errors.Trace(<-done)
+}
+
+func (c *tunnelCommand) connectTunnel() (io.ReadWriteCloser, error) {
+	if c.connect != nil {
+		return c.connect(c.target)
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apitunnel.ConnectMachine(root, c.target)
+}
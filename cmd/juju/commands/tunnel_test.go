@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type TunnelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&TunnelSuite{})
+
+func (s *TunnelSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		expected names.Tag
+		errMatch string
+	}{{
+		errMatch: "no target name specified",
+	}, {
+		args:     []string{"0"},
+		expected: names.NewMachineTag("0"),
+	}, {
+		args:     []string{"mysql/0"},
+		expected: names.NewUnitTag("mysql/0"),
+	}, {
+		args:     []string{"machine-0-lxd-1"},
+		expected: names.NewMachineTag("0/lxd/1"),
+	}, {
+		args:     []string{"unit-mysql-0"},
+		expected: names.NewUnitTag("mysql/0"),
+	}, {
+		args:     []string{"application-mysql"},
+		errMatch: `target "application-mysql" not valid`,
+	}, {
+		args:     []string{"0", "22"},
+		errMatch: `unrecognized args: \["22"\]`,
+	}} {
+		c.Logf("test %d", i)
+		command := &tunnelCommand{}
+		err := cmdtesting.InitCommand(modelcmd.Wrap(command), test.args)
+		if test.errMatch == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(command.target, gc.Equals, test.expected)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
+func (s *TunnelSuite) TestRun(c *gc.C) {
+	conn := &fakeTunnelConn{
+		data:    []byte("SSH-2.0"),
+		written: make(chan struct{}),
+	}
+	var target names.Tag
+	command := modelcmd.Wrap(&tunnelCommand{
+		connect: func(tag names.Tag) (io.ReadWriteCloser, error) {
+			target = tag
+			return conn, nil
+		},
+	})
+	err := cmdtesting.InitCommand(command, []string{"mysql/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("hello")
+	err = command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(target, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "SSH-2.0")
+	c.Assert(conn.buf.String(), gc.Equals, "hello")
+	c.Assert(conn.closed, jc.IsTrue)
+}
+
+func (s *TunnelSuite) TestRunConnectError(c *gc.C) {
+	command := modelcmd.Wrap(&tunnelCommand{
+		connect: func(names.Tag) (io.ReadWriteCloser, error) {
+			return nil, errors.New("reverse channel for machine 0 not found")
+		},
+	})
+	_, err := cmdtesting.RunCommand(c, command, "0")
+	c.Assert(err, gc.ErrorMatches, "cannot connect to machine 0: reverse channel for machine 0 not found")
+}
+
+// fakeTunnelConn returns its data, and then waits for data to be
+// written to it before returning EOF.
+type fakeTunnelConn struct {
+	data    []byte
+	buf     bytes.Buffer
+	written chan struct{}
+	closed  bool
+}
+
+func (f *fakeTunnelConn) Read(p []byte) (int, error) {
+	if len(f.data) == 0 {
+		<-f.written
+		return 0, io.EOF
+	}
+	n := copy(p, f.data)
+	f.data = f.data[n:]
+	return n, nil
+}
+
+func (f *fakeTunnelConn) Write(p []byte) (int, error) {
+	n, err := f.buf.Write(p)
+	close(f.written)
+	return n, err
+}
+
+func (f *fakeTunnelConn) Close() error {
+	f.closed = true
+	return nil
+}
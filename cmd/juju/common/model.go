@@ -36,6 +36,8 @@ type ModelInfo struct {
 	SLA            string                      `json:"sla,omitempty" yaml:"sla,omitempty"`
 	SLAOwner       string                      `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion   string                      `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
+	Description    string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Expires        string                      `json:"expires,omitempty" yaml:"expires,omitempty"`
 }
 
 // ModelMachineInfo contains information about a machine in a model.
@@ -91,10 +93,14 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		Life:           string(info.Life),
 		Cloud:          cloudTag.Id(),
 		CloudRegion:    info.CloudRegion,
+		Description:    info.Description,
 	}
 	if info.AgentVersion != nil {
 		modelInfo.AgentVersion = info.AgentVersion.String()
 	}
+	if info.Expires != nil {
+		modelInfo.Expires = info.Expires.Format(time.RFC3339)
+	}
 	// Although this may be more performance intensive, we have to use reflection
 	// since structs containing map[string]interface {} cannot be compared, i.e
 	// cannot use simple '==' here.
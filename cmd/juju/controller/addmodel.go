@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -53,8 +54,13 @@ type addModelCommand struct {
 	Owner          string
 	CredentialName string
 	CloudRegion    string
+	Template       string
 	Config         common.ConfigFlag
 	noSwitch       bool
+
+	ExpiresIn              time.Duration
+	destroyStorageOnExpiry bool
+	releaseStorageOnExpiry bool
 }
 
 const addModelHelpDoc = `
@@ -93,6 +99,20 @@ to manage multiple clouds, so the only valid cloud is the same cloud
 as the controller model is deployed to. This may change in a future
 release.
 
+A controller administrator may define model templates, in the
+model-templates controller config attribute, which choose the region,
+credential, config and constraints of the models created from them.
+Use --template to create a model from a template; any cloud/region,
+credential or config specified explicitly takes precedence over the
+template's choices. The template is checked against the cloud before
+the model is created.
+
+Use --expires-in to add a temporary model, which the controller destroys
+once the given duration has passed. The model's owner is warned, in the
+model's status, shortly before the model expires. A model with persistent
+storage is only destroyed if --destroy-storage-on-expiry or
+--release-storage-on-expiry is specified.
+
 Examples:
 
     juju add-model mymodel
@@ -100,6 +120,8 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --template small-staging
+    juju add-model mymodel --expires-in 72h --destroy-storage-on-expiry
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -115,8 +137,12 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
 	f.StringVar(&c.Owner, "owner", "", "The owner of the new model if not the current user")
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
+	f.StringVar(&c.Template, "template", "", "Controller model template from which to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created model")
+	f.DurationVar(&c.ExpiresIn, "expires-in", 0, "Destroy the model once this duration has passed")
+	f.BoolVar(&c.destroyStorageOnExpiry, "destroy-storage-on-expiry", false, "Destroy the model's storage when it expires")
+	f.BoolVar(&c.releaseStorageOnExpiry, "release-storage-on-expiry", false, "Release the model's storage when it expires")
 }
 
 func (c *addModelCommand) Init(args []string) error {
@@ -137,6 +163,16 @@ func (c *addModelCommand) Init(args []string) error {
 		return errors.Errorf("%q is not a valid user", c.Owner)
 	}
 
+	if c.ExpiresIn < 0 {
+		return errors.Errorf("--expires-in must be positive")
+	}
+	if c.destroyStorageOnExpiry && c.releaseStorageOnExpiry {
+		return errors.New("--destroy-storage-on-expiry and --release-storage-on-expiry cannot both be specified")
+	}
+	if c.ExpiresIn == 0 && (c.destroyStorageOnExpiry || c.releaseStorageOnExpiry) {
+		return errors.New("storage options on expiry require --expires-in")
+	}
+
 	return cmd.CheckEmpty(args)
 }
 
@@ -146,6 +182,18 @@ type AddModelAPI interface {
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
 	) (base.ModelInfo, error)
+	CreateModelFromTemplate(
+		template, name, owner, cloudName, cloudRegion string,
+		cloudCredential names.CloudCredentialTag,
+		config map[string]interface{},
+	) (base.ModelInfo, error)
+	CreateExpiringModel(
+		template, name, owner, cloudName, cloudRegion string,
+		cloudCredential names.CloudCredentialTag,
+		config map[string]interface{},
+		expiresIn time.Duration,
+		destroyStorage *bool,
+	) (base.ModelInfo, error)
 }
 
 type CloudAPI interface {
@@ -209,15 +257,20 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	// Find a credential to use with the new model.
-	credential, credentialTag, cloudRegion, err := c.findCredential(ctx, cloudClient, &findCredentialParams{
-		cloudTag:    cloudTag,
-		cloudRegion: cloudRegion,
-		cloud:       cloud,
-		modelOwner:  modelOwner,
-	})
-	if err != nil {
-		return errors.Trace(err)
+	// Find a credential to use with the new model. A model template
+	// chooses the credential, unless one is specified.
+	var credential *jujucloud.Credential
+	var credentialTag names.CloudCredentialTag
+	if c.Template == "" || c.CredentialName != "" {
+		credential, credentialTag, cloudRegion, err = c.findCredential(ctx, cloudClient, &findCredentialParams{
+			cloudTag:    cloudTag,
+			cloudRegion: cloudRegion,
+			cloud:       cloud,
+			modelOwner:  modelOwner,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Upload the credential if it was found locally.
@@ -229,7 +282,21 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	addModelClient := c.newAddModelAPI(api)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	var model base.ModelInfo
+	if c.ExpiresIn > 0 {
+		var destroyStorage *bool
+		if c.destroyStorageOnExpiry || c.releaseStorageOnExpiry {
+			destroyStorage = &c.destroyStorageOnExpiry
+		}
+		model, err = addModelClient.CreateExpiringModel(
+			c.Template, c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs,
+			c.ExpiresIn, destroyStorage,
+		)
+	} else if c.Template != "" {
+		model, err = addModelClient.CreateModelFromTemplate(c.Template, c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	} else {
+		model, err = addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	}
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a model")
@@ -279,6 +346,9 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 
 	// "Added '<model>' model [on <cloud>/<region>] [with credential '<credential>'] for user '<user namePart>'"
 	ctx.Infof(messageFormat, messageArgs...)
+	if model.Expires != nil {
+		ctx.Infof("The model expires at %s", model.Expires.Local().Format(time.RFC1123))
+	}
 
 	if _, ok := attrs[config.AuthorizedKeysKey]; !ok {
 		// It is not an error to have no authorized-keys when adding a
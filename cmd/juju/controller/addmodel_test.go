@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -157,6 +158,15 @@ func (s *AddModelSuite) TestInit(c *gc.C) {
 		}, {
 			args: []string{"new-model", "cloud/region", "extra", "args"},
 			err:  `unrecognized args: \["extra" "args"\]`,
+		}, {
+			args: []string{"new-model", "--expires-in", "-1h"},
+			err:  "--expires-in must be positive",
+		}, {
+			args: []string{"new-model", "--expires-in", "1h", "--destroy-storage-on-expiry", "--release-storage-on-expiry"},
+			err:  "--destroy-storage-on-expiry and --release-storage-on-expiry cannot both be specified",
+		}, {
+			args: []string{"new-model", "--destroy-storage-on-expiry"},
+			err:  "storage options on expiry require --expires-in",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -429,6 +439,53 @@ lxd
 `[1:])
 }
 
+func (s *AddModelSuite) TestTemplatePassedThrough(c *gc.C) {
+	// Disable empty auth, so that a credential would otherwise
+	// have to be found for the model.
+	s.PatchValue(&s.fakeCloudAPI.authTypes, []cloud.AuthType{cloud.AccessKeyAuthType})
+	_, err := s.run(c, "test", "--template", "small-staging")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "small-staging")
+	c.Assert(s.fakeAddModelAPI.cloudName, gc.Equals, "aws")
+	c.Assert(s.fakeAddModelAPI.cloudRegion, gc.Equals, "")
+	c.Assert(s.fakeAddModelAPI.cloudCredential, gc.Equals, names.CloudCredentialTag{})
+	s.fakeCloudAPI.CheckCallNames(c, "DefaultCloud", "Cloud")
+}
+
+func (s *AddModelSuite) TestTemplateWithCredential(c *gc.C) {
+	_, err := s.run(c, "test", "--template", "small-staging", "--credential", "secrets", "us-west-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "small-staging")
+	c.Assert(s.fakeAddModelAPI.cloudRegion, gc.Equals, "us-west-1")
+	c.Assert(s.fakeAddModelAPI.cloudCredential, gc.Equals, names.NewCloudCredentialTag("aws/bob/secrets"))
+}
+
+func (s *AddModelSuite) TestExpiresInPassedThrough(c *gc.C) {
+	expires := time.Date(2017, 9, 4, 12, 0, 0, 0, time.UTC)
+	s.fakeAddModelAPI.model.Expires = &expires
+	ctx, err := s.run(c, "test", "--expires-in", "72h", "--template", "small-staging")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "small-staging")
+	c.Assert(s.fakeAddModelAPI.expiresIn, gc.Equals, 72*time.Hour)
+	c.Assert(s.fakeAddModelAPI.destroyStorage, gc.IsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "The model expires at ")
+}
+
+func (s *AddModelSuite) TestExpiresInStorageOptions(c *gc.C) {
+	_, err := s.run(c, "test", "--expires-in", "1h", "--release-storage-on-expiry")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.destroyStorage, gc.NotNil)
+	c.Assert(*s.fakeAddModelAPI.destroyStorage, jc.IsFalse)
+
+	_, err = s.run(c, "test", "--expires-in", "1h", "--destroy-storage-on-expiry")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.destroyStorage, gc.NotNil)
+	c.Assert(*s.fakeAddModelAPI.destroyStorage, jc.IsTrue)
+}
+
 func (s *AddModelSuite) TestComandLineConfigPassedThrough(c *gc.C) {
 	_, err := s.run(c, "test", "--config", "account=magic", "--config", "cloud=special")
 	c.Assert(err, jc.ErrorIsNil)
@@ -588,11 +645,14 @@ func (s *AddModelSuite) TestNoEnvCacheOtherUser(c *gc.C) {
 // fakeAddClient is used to mock out the behavior of the real
 // AddModel command.
 type fakeAddClient struct {
+	template        string
 	owner           string
 	cloudName       string
 	cloudRegion     string
 	cloudCredential names.CloudCredentialTag
 	config          map[string]interface{}
+	expiresIn       time.Duration
+	destroyStorage  *bool
 	err             error
 	model           base.ModelInfo
 }
@@ -615,6 +675,17 @@ func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string,
 	return f.model, nil
 }
 
+func (f *fakeAddClient) CreateModelFromTemplate(template, name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (base.ModelInfo, error) {
+	f.template = template
+	return f.CreateModel(name, owner, cloudName, cloudRegion, cloudCredential, config)
+}
+
+func (f *fakeAddClient) CreateExpiringModel(template, name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}, expiresIn time.Duration, destroyStorage *bool) (base.ModelInfo, error) {
+	f.expiresIn = expiresIn
+	f.destroyStorage = destroyStorage
+	return f.CreateModelFromTemplate(template, name, owner, cloudName, cloudRegion, cloudCredential, config)
+}
+
 // TODO(wallyworld) - improve this stub and add test asserts
 type fakeCloudAPI struct {
 	controller.CloudAPI
@@ -0,0 +1,196 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/api/controllertxns"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewControllerTxnsCommand returns a command that reports and resumes
+// stuck transactions in a controller's database.
+func NewControllerTxnsCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerTxnsCommand{})
+}
+
+// ControllerTxnsAPI defines the methods on the controller transactions
+// API endpoint that the controller-txns command calls.
+type ControllerTxnsAPI interface {
+	StuckTransactions() ([]params.StuckTransaction, error)
+	ResumeTransactions(ids ...string) error
+	Close() error
+}
+
+type controllerTxnsCommand struct {
+	modelcmd.ControllerCommandBase
+	api    ControllerTxnsAPI
+	out    cmd.Output
+	resume bool
+	ids    []string
+}
+
+const controllerTxnsDoc = `
+Lists the database transactions that the controller has been unable to
+complete. A transaction that is interrupted part way through blocks
+every later transaction on the documents it affects, which can leave
+models unable to make progress. The controller periodically attempts to
+resume transactions that have been incomplete for more than ten
+minutes; those listed are the ones it has been unable to resume.
+
+With --resume, the controller attempts to resume the transactions with
+the given ids, or all stuck transactions if no ids are given. Resuming
+a transaction completes it, or aborts it if its assertions no longer
+hold, just as the interrupted agent would have.
+
+Examples:
+    juju controller-txns
+    juju controller-txns --format yaml
+    juju controller-txns --resume
+    juju controller-txns --resume 5a0a4c3e5b7c1f0dbc6a8d21
+
+See also:
+    controllers
+`
+
+// Info implements Command.Info.
+func (c *controllerTxnsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "controller-txns",
+		Args:    "[--resume [<transaction id> ...]]",
+		Purpose: "Lists or resumes stuck transactions in a controller's database.",
+		Doc:     controllerTxnsDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerTxnsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.resume, "resume", false, "Resume stuck transactions")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatStuckTransactionsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *controllerTxnsCommand) Init(args []string) error {
+	if !c.resume {
+		return cmd.CheckEmpty(args)
+	}
+	for _, id := range args {
+		if !bson.IsObjectIdHex(id) {
+			return errors.NotValidf("transaction id %q", id)
+		}
+	}
+	c.ids = args
+	return nil
+}
+
+func (c *controllerTxnsCommand) getAPI() (ControllerTxnsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("ControllerTxns") < 1 {
+		root.Close()
+		return nil, errors.NotSupportedf("reporting stuck transactions by this controller")
+	}
+	return controllertxns.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *controllerTxnsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var stuck []params.StuckTransaction
+	if !c.resume || len(c.ids) == 0 {
+		stuck, err = client.StuckTransactions()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(stuck) == 0 {
+			ctx.Infof("No stuck transactions.")
+			return nil
+		}
+	}
+	if !c.resume {
+		return c.out.Write(ctx, makeStuckTransactions(stuck))
+	}
+
+	ids := c.ids
+	if len(ids) == 0 {
+		for _, txn := range stuck {
+			ids = append(ids, txn.Id)
+		}
+	}
+	if err := client.ResumeTransactions(ids...); err != nil {
+		return errors.Annotate(err, "resuming transactions")
+	}
+	if len(ids) == 1 {
+		ctx.Infof("Resumed transaction %s.", ids[0])
+	} else {
+		ctx.Infof("Resumed %d transactions.", len(ids))
+	}
+	return nil
+}
+
+// StuckTransaction describes a transaction that the controller has
+// been unable to complete.
+type StuckTransaction struct {
+	Id        string    `yaml:"id" json:"id"`
+	State     string    `yaml:"state" json:"state"`
+	Started   time.Time `yaml:"started" json:"started"`
+	Documents []string  `yaml:"documents" json:"documents"`
+}
+
+func makeStuckTransactions(stuck []params.StuckTransaction) []StuckTransaction {
+	result := make([]StuckTransaction, len(stuck))
+	for i, txn := range stuck {
+		result[i] = StuckTransaction{
+			Id:      txn.Id,
+			State:   txn.State,
+			Started: txn.Started,
+		}
+		for _, op := range txn.Ops {
+			result[i].Documents = append(result[i].Documents, fmt.Sprintf("%s/%s", op.Collection, op.Id))
+		}
+	}
+	return result
+}
+
+func formatStuckTransactionsTabular(writer io.Writer, value interface{}) error {
+	stuck, ok := value.([]StuckTransaction)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", stuck, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Transaction", "State", "Started", "Documents")
+	for _, txn := range stuck {
+		w.Println(txn.Id, txn.State, txn.Started.Format(time.RFC3339), strings.Join(txn.Documents, " "))
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type ControllerTxnsSuite struct {
+	baseControllerSuite
+	api *fakeControllerTxnsAPI
+}
+
+var _ = gc.Suite(&ControllerTxnsSuite{})
+
+func (s *ControllerTxnsSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeControllerTxnsAPI{
+		stuck: []params.StuckTransaction{{
+			Id:      "5a0a4c3e5b7c1f0dbc6a8d21",
+			State:   "prepared",
+			Started: time.Date(2017, 11, 1, 9, 0, 0, 0, time.UTC),
+			Ops: []params.StuckTransactionOp{
+				{Collection: "machines", Id: "uuid:0"},
+				{Collection: "units", Id: "uuid:mysql/0"},
+			},
+		}, {
+			Id:      "5a0a4c3e5b7c1f0dbc6a8d22",
+			State:   "applying",
+			Started: time.Date(2017, 11, 1, 9, 5, 0, 0, time.UTC),
+			Ops: []params.StuckTransactionOp{
+				{Collection: "applications", Id: "uuid:mysql"},
+			},
+		}},
+	}
+}
+
+func (s *ControllerTxnsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewControllerTxnsCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *ControllerTxnsSuite) TestInit(c *gc.C) {
+	_, err := s.run(c, "5a0a4c3e5b7c1f0dbc6a8d21")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["5a0a4c3e5b7c1f0dbc6a8d21"\]`)
+	_, err = s.run(c, "--resume", "bad")
+	c.Assert(err, gc.ErrorMatches, `transaction id "bad" not valid`)
+}
+
+func (s *ControllerTxnsSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Transaction               State     Started               Documents
+5a0a4c3e5b7c1f0dbc6a8d21  prepared  2017-11-01T09:00:00Z  machines/uuid:0 units/uuid:mysql/0
+5a0a4c3e5b7c1f0dbc6a8d22  applying  2017-11-01T09:05:00Z  applications/uuid:mysql
+`[1:])
+	s.api.CheckCallNames(c, "StuckTransactions", "Close")
+}
+
+func (s *ControllerTxnsSuite) TestListYAML(c *gc.C) {
+	s.api.stuck = s.api.stuck[1:]
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: 5a0a4c3e5b7c1f0dbc6a8d22
+  state: applying
+  started: 2017-11-01T09:05:00Z
+  documents:
+  - applications/uuid:mysql
+`[1:])
+}
+
+func (s *ControllerTxnsSuite) TestListNone(c *gc.C) {
+	s.api.stuck = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No stuck transactions.\n")
+}
+
+func (s *ControllerTxnsSuite) TestListError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ControllerTxnsSuite) TestResumeAll(c *gc.C) {
+	ctx, err := s.run(c, "--resume")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Resumed 2 transactions.\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"StuckTransactions", nil},
+		{"ResumeTransactions", []interface{}{[]string{
+			"5a0a4c3e5b7c1f0dbc6a8d21", "5a0a4c3e5b7c1f0dbc6a8d22",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ControllerTxnsSuite) TestResumeIds(c *gc.C) {
+	ctx, err := s.run(c, "--resume", "5a0a4c3e5b7c1f0dbc6a8d22")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Resumed transaction 5a0a4c3e5b7c1f0dbc6a8d22.\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"ResumeTransactions", []interface{}{[]string{"5a0a4c3e5b7c1f0dbc6a8d22"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ControllerTxnsSuite) TestResumeError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "--resume", "5a0a4c3e5b7c1f0dbc6a8d22")
+	c.Assert(err, gc.ErrorMatches, "resuming transactions: boom")
+}
+
+type fakeControllerTxnsAPI struct {
+	testing.Stub
+	stuck []params.StuckTransaction
+}
+
+func (f *fakeControllerTxnsAPI) StuckTransactions() ([]params.StuckTransaction, error) {
+	f.MethodCall(f, "StuckTransactions")
+	return f.stuck, f.NextErr()
+}
+
+func (f *fakeControllerTxnsAPI) ResumeTransactions(ids ...string) error {
+	f.MethodCall(f, "ResumeTransactions", ids)
+	return f.NextErr()
+}
+
+func (f *fakeControllerTxnsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
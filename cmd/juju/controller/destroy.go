@@ -532,6 +532,16 @@ func (c *destroyCommandBase) getControllerEnvironFromStore(
 	ctx *cmd.Context,
 	store jujuclient.ClientStore,
 	controllerName string,
+) (environs.Environ, error) {
+	return controllerEnvironFromStore(ctx, store, controllerName)
+}
+
+// controllerEnvironFromStore returns the environ of the named
+// controller's model, using the bootstrap config in the client store.
+func controllerEnvironFromStore(
+	ctx *cmd.Context,
+	store jujuclient.ClientStore,
+	controllerName string,
 ) (environs.Environ, error) {
 	bootstrapConfig, params, err := modelcmd.NewGetBootstrapConfigParamsFunc(
 		ctx, store, environs.GlobalProviderRegistry(),
@@ -25,6 +25,15 @@ func NewListControllersCommandForTest(testStore jujuclient.ClientStore, api func
 	}
 }
 
+// NewListControllersHealthCommandForTest returns a listControllersCommand
+// with the clientstore and controller health API provided as specified.
+func NewListControllersHealthCommandForTest(testStore jujuclient.ClientStore, healthAPI func(string) ControllerHealthAPI) *listControllersCommand {
+	return &listControllersCommand{
+		store:     testStore,
+		healthAPI: healthAPI,
+	}
+}
+
 // NewShowControllerCommandForTest returns a showControllerCommand with the clientstore provided
 // as specified.
 func NewShowControllerCommandForTest(testStore jujuclient.ClientStore, api func(string) ControllerAccessAPI) *showControllerCommand {
@@ -175,3 +184,22 @@ func FmtModelStatus(data ModelData) string {
 func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, error) {
 	return newData(api, ctrUUID)
 }
+
+// NewRotateKeyPairCommandForTest returns a rotateKeyPairCommand with the
+// clientstore and environ constructor provided as specified.
+func NewRotateKeyPairCommandForTest(
+	store jujuclient.ClientStore,
+	newEnviron func(*cmd.Context, jujuclient.ClientStore, string) (environs.Environ, error),
+) cmd.Command {
+	c := &rotateKeyPairCommand{newEnviron: newEnviron}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewControllerTxnsCommandForTest returns a controllerTxnsCommand with
+// the api and clientstore provided as specified.
+func NewControllerTxnsCommandForTest(api ControllerTxnsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerTxnsCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -24,12 +24,15 @@ func NewGetConfigCommand() cmd.Command {
 }
 
 // getConfigCommand is able to output either the entire environment or
-// the requested value in a format of the user's choosing.
+// the requested value in a format of the user's choosing. It can also
+// update and reset controller config attributes.
 type getConfigCommand struct {
 	modelcmd.ControllerCommandBase
-	api controllerAPI
-	key string
-	out cmd.Output
+	api    controllerAPI
+	key    string
+	values map[string]interface{}
+	reset  []string
+	out    cmd.Output
 }
 
 const getControllerHelpDoc = `
@@ -40,11 +43,27 @@ The controller configuration is set during bootstrap, available keys
 and values can be found here:
   https://jujucharms.com/docs/stable/controllers-config
 
+Attributes may be changed by passing key=value pairs, and reset to
+their defaults with --reset. Changes to auditing-enabled, the log
+pruning limits and the deploy policy take effect immediately; the
+command reports any changed attributes that only take effect once the
+controller agents are restarted. The CA certificate and controller UUID
+cannot be changed.
+
+The API port may be moved without restarting the controllers. The old
+port must be kept open as the additional-api-port while agents learn
+the new address, and may be closed once they have reconnected:
+
+    juju controller-config api-port=443 additional-api-port=17070
+    juju controller-config --reset additional-api-port
+
 Examples:
 
     juju controller-config
     juju controller-config api-port
     juju controller-config -c mycontroller
+    juju controller-config auditing-enabled=true max-logs-age=96h
+    juju controller-config --reset max-logs-size
 
 See also:
     controllers
@@ -55,7 +74,7 @@ See also:
 func (c *getConfigCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "controller-config",
-		Args:    "[<attribute key>]",
+		Args:    "[<attribute key> | <key>=<value> ...]",
 		Purpose: "Displays configuration settings for a controller.",
 		Doc:     strings.TrimSpace(getControllerHelpDoc),
 	}
@@ -63,6 +82,7 @@ func (c *getConfigCommand) Info() *cmd.Info {
 
 func (c *getConfigCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys to their defaults")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"json":    cmd.FormatJson,
 		"tabular": formatConfigTabular,
@@ -71,13 +91,34 @@ func (c *getConfigCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 func (c *getConfigCommand) Init(args []string) (err error) {
-	c.key, err = cmd.ZeroOrOneArgs(args)
-	return
+	var reset []string
+	for _, keys := range c.reset {
+		for _, key := range strings.Split(keys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				reset = append(reset, key)
+			}
+		}
+	}
+	c.reset = reset
+	if len(c.reset) == 0 && (len(args) == 0 || !strings.Contains(args[0], "=")) {
+		c.key, err = cmd.ZeroOrOneArgs(args)
+		return
+	}
+	c.values = make(map[string]interface{})
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("expected key=value, got %q", arg)
+		}
+		c.values[parts[0]] = parts[1]
+	}
+	return nil
 }
 
 type controllerAPI interface {
 	Close() error
 	ControllerConfig() (controller.Config, error)
+	ConfigSet(values map[string]interface{}, remove []string) ([]string, error)
 }
 
 func (c *getConfigCommand) getAPI() (controllerAPI, error) {
@@ -102,6 +143,17 @@ func (c *getConfigCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.values != nil {
+		restart, err := client.ConfigSet(c.values, c.reset)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(restart) > 0 {
+			ctx.Infof("Changes to %s take effect once the controller agents are restarted.", strings.Join(restart, ", "))
+		}
+		return nil
+	}
+
 	attrs, err := client.ControllerConfig()
 	if err != nil {
 		return err
@@ -89,8 +89,47 @@ func (s *GetConfigSuite) TestError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error")
 }
 
+func (s *GetConfigSuite) TestInitSet(c *gc.C) {
+	err := cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one=1", "two"})
+	c.Check(err, gc.ErrorMatches, `expected key=value, got "two"`)
+	err = cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"--reset", "one", "two"})
+	c.Check(err, gc.ErrorMatches, `expected key=value, got "two"`)
+}
+
+func (s *GetConfigSuite) TestSetValues(c *gc.C) {
+	api := &fakeControllerAPI{}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	context, err := cmdtesting.RunCommand(c, command, "auditing-enabled=true", "max-logs-age=96h", "--reset", "max-logs-size")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.values, jc.DeepEquals, map[string]interface{}{
+		"auditing-enabled": "true",
+		"max-logs-age":     "96h",
+	})
+	c.Assert(api.remove, jc.DeepEquals, []string{"max-logs-size"})
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
+func (s *GetConfigSuite) TestSetValuesRestartRequired(c *gc.C) {
+	api := &fakeControllerAPI{restart: []string{"api-port", "identity-url"}}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	context, err := cmdtesting.RunCommand(c, command, "api-port=17071", "identity-url=https://login.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals,
+		"Changes to api-port, identity-url take effect once the controller agents are restarted.\n")
+}
+
+func (s *GetConfigSuite) TestSetValuesError(c *gc.C) {
+	command := controller.NewGetConfigCommandForTest(&fakeControllerAPI{err: errors.New("error")}, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "auditing-enabled=true")
+	c.Assert(err, gc.ErrorMatches, "error")
+}
+
 type fakeControllerAPI struct {
-	err error
+	err     error
+	restart []string
+	values  map[string]interface{}
+	remove  []string
 }
 
 func (f *fakeControllerAPI) Close() error {
@@ -107,3 +146,12 @@ func (f *fakeControllerAPI) ControllerConfig() (jujucontroller.Config, error) {
 		"ca-cert":         "multi\nline",
 	}, nil
 }
+
+func (f *fakeControllerAPI) ConfigSet(values map[string]interface{}, remove []string) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.values = values
+	f.remove = remove
+	return f.restart, nil
+}
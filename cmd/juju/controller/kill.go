@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/juju/cmd"
@@ -16,7 +17,9 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/jujuclient"
 )
 
 const killDoc = `
@@ -35,6 +38,11 @@ models do not stop cleanly, there is a default five minute timeout. If no change
 in the model state occurs for the duration of this timeout, the command will
 stop watching and destroy the models directly through the cloud provider.
 
+If bootstrap failed and --keep-broken was specified, the controller can be
+killed with --from-bootstrap-log. The API server is not contacted; instead,
+exactly the cloud resources recorded by the client while bootstrapping the
+controller are removed.
+
 See also:
     destroy-controller
     unregister
@@ -62,8 +70,9 @@ func wrapKillCommand(kill *killCommand) modelcmd.Command {
 type killCommand struct {
 	destroyCommandBase
 
-	clock   clock.Clock
-	timeout time.Duration
+	clock            clock.Clock
+	timeout          time.Duration
+	fromBootstrapLog bool
 }
 
 // SetFlags implements Command.SetFlags.
@@ -71,6 +80,7 @@ func (c *killCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.destroyCommandBase.SetFlags(f)
 	f.Var(newDurationValue(time.Minute*5, &c.timeout), "t", "Timeout before direct destruction")
 	f.Var(newDurationValue(time.Minute*5, &c.timeout), "timeout", "")
+	f.BoolVar(&c.fromBootstrapLog, "from-bootstrap-log", false, "Remove only the resources recorded while bootstrapping the controller")
 }
 
 // Info implements Command.Info.
@@ -103,6 +113,10 @@ func (c *killCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if c.fromBootstrapLog {
+		return c.killFromBootstrapLog(ctx, store, controllerName)
+	}
+
 	// Attempt to connect to the API.
 	api, err := c.getControllerAPIWithTimeout(10 * time.Second)
 	switch errors.Cause(err) {
@@ -146,6 +160,31 @@ func (c *killCommand) Run(ctx *cmd.Context) error {
 	return environs.Destroy(controllerName, controllerEnviron, store)
 }
 
+// killFromBootstrapLog removes the cloud resources recorded in the
+// bootstrap log of a controller that failed to bootstrap, and then
+// removes the controller from the client store.
+func (c *killCommand) killFromBootstrapLog(ctx *cmd.Context, store jujuclient.ClientStore, controllerName string) error {
+	logPath := bootstrap.ResourceLogPath(controllerName)
+	resources, err := bootstrap.ReadResourceLog(logPath)
+	if err != nil {
+		return errors.Annotate(err, "reading bootstrap log")
+	}
+	controllerEnviron, err := c.getControllerEnvironFromStore(ctx, store, controllerName)
+	if err != nil {
+		return errors.Annotate(err, "getting controller environ")
+	}
+	for _, resource := range resources {
+		ctx.Infof("Removing %s %s", resource.Kind, resource.Id)
+	}
+	if err := bootstrap.RemoveResources(controllerEnviron, resources); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(logPath); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return errors.Trace(store.RemoveController(controllerName))
+}
+
 func (c *killCommand) getControllerAPIWithTimeout(timeout time.Duration) (destroyControllerAPI, error) {
 	type result struct {
 		c   destroyControllerAPI
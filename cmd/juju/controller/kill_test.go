@@ -23,6 +23,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/cmdtest"
 	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
 	_ "github.com/juju/juju/provider/dummy"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -408,6 +410,45 @@ func (s *KillSuite) TestKillDestroysControllerWithAPIError(c *gc.C) {
 	checkControllerRemovedFromStore(c, "test1", s.store)
 }
 
+func (s *KillSuite) writeBootstrapLog(c *gc.C, controllerName string, resources ...environs.BootstrapResource) {
+	resourceLog, err := bootstrap.NewResourceLog(bootstrap.ResourceLogPath(controllerName))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, resource := range resources {
+		resourceLog.RecordResource(resource.Kind, resource.Id)
+	}
+}
+
+func (s *KillSuite) TestKillFromBootstrapLog(c *gc.C) {
+	s.writeBootstrapLog(c, "test1")
+	_, err := s.runKillCommand(c, "test1", "-y", "--from-bootstrap-log")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckNoCalls(c)
+	checkControllerRemovedFromStore(c, "test1", s.store)
+	_, err = bootstrap.ReadResourceLog(bootstrap.ResourceLogPath("test1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *KillSuite) TestKillFromBootstrapLogMissing(c *gc.C) {
+	_, err := s.runKillCommand(c, "test1", "-y", "--from-bootstrap-log")
+	c.Assert(err, gc.ErrorMatches, `reading bootstrap log: bootstrap log ".*test1.log" not found`)
+	s.api.CheckNoCalls(c)
+	checkControllerExistsInStore(c, "test1", s.store)
+}
+
+func (s *KillSuite) TestKillFromBootstrapLogRemoveError(c *gc.C) {
+	s.writeBootstrapLog(c, "test1", environs.BootstrapResource{
+		Kind: environs.ResourceKindSecurityGroup,
+		Id:   "sg-0",
+	})
+	ctx, err := s.runKillCommand(c, "test1", "-y", "--from-bootstrap-log")
+	c.Assert(err, gc.ErrorMatches, "removing resources other than instances not supported")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "Removing security-group sg-0")
+	checkControllerExistsInStore(c, "test1", s.store)
+	resources, err := bootstrap.ReadResourceLog(bootstrap.ResourceLogPath("test1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+}
+
 func (s *KillSuite) TestKillCommandConfirmation(c *gc.C) {
 	var stdin, stdout bytes.Buffer
 	ctx, err := cmd.DefaultContext()
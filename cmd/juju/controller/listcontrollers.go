@@ -28,9 +28,16 @@ var helpControllersDetails = `
 The output format may be selected with the '--format' option. In the
 default tabular output, the current controller is marked with an asterisk.
 
+The '--health' option connects to each controller and reports on its
+health instead: the status of its mongo replica set members, the number
+of connections to the API server, the storage used by its databases, any
+upgrade in progress, and when it was last backed up. Reporting health
+requires superuser access to the controller.
+
 Examples:
     juju controllers
     juju controllers --format json --output ~/tmp/controllers.json
+    juju controllers --health
 
 See also:
     models
@@ -58,6 +65,7 @@ func (c *listControllersCommand) Info() *cmd.Info {
 func (c *listControllersCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.refresh, "refresh", false, "Connect to each controller to download the latest details")
+	f.BoolVar(&c.health, "health", false, "Connect to each controller to report on its health")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -85,6 +93,13 @@ func (c *listControllersCommand) Run(ctx *cmd.Context) error {
 	if len(controllers) == 0 && c.out.Name() == "tabular" {
 		return errors.Trace(modelcmd.ErrNoControllersDefined)
 	}
+	if c.health {
+		names := make([]string, 0, len(controllers))
+		for name := range controllers {
+			names = append(names, name)
+		}
+		return c.out.Write(ctx, c.controllersHealth(ctx, names))
+	}
 	if c.refresh && len(controllers) > 0 {
 		var wg sync.WaitGroup
 		wg.Add(len(controllers))
@@ -190,9 +205,11 @@ func ControllerMachineCounts(controllerModelUUID string, modelStatus []base.Mode
 type listControllersCommand struct {
 	modelcmd.CommandBase
 
-	out     cmd.Output
-	store   jujuclient.ClientStore
-	api     func(controllerName string) ControllerAccessAPI
-	refresh bool
-	mu      sync.Mutex
+	out       cmd.Output
+	store     jujuclient.ClientStore
+	api       func(controllerName string) ControllerAccessAPI
+	healthAPI func(controllerName string) ControllerHealthAPI
+	refresh   bool
+	health    bool
+	mu        sync.Mutex
 }
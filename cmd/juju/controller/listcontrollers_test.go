@@ -6,14 +6,17 @@ package controller_test
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
@@ -230,6 +233,111 @@ func (s *ListControllersSuite) TestListControllersJson(c *gc.C) {
 	})
 }
 
+func (s *ListControllersSuite) healthAPI(controllerName string) controller.ControllerHealthAPI {
+	lastBackup := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	switch controllerName {
+	case "aws-test":
+		return &fakeHealthAPI{health: params.ControllerHealth{
+			ReplicaSet: []params.ReplicaSetMemberHealth{
+				{Address: "10.0.0.1:37017", State: "PRIMARY", Healthy: true},
+				{Address: "10.0.0.2:37017", State: "SECONDARY", Healthy: true},
+				{Address: "10.0.0.3:37017", State: "DOWN", Healthy: false},
+			},
+			APIConnections: 12,
+			Databases: []params.DatabaseSize{
+				{Name: "juju", DataSize: 1024, StorageSize: 2048, IndexSize: 512},
+				{Name: "logs", DataSize: 1024, StorageSize: 2048, IndexSize: 512},
+			},
+			LastBackup: &lastBackup,
+		}}
+	case "mallards":
+		return &fakeHealthAPI{health: params.ControllerHealth{
+			ReplicaSet: []params.ReplicaSetMemberHealth{
+				{Address: "10.0.1.1:37017", State: "PRIMARY", Healthy: true},
+			},
+			APIConnections: 3,
+			Databases: []params.DatabaseSize{
+				{Name: "juju", DataSize: 100, StorageSize: 200, IndexSize: 50},
+			},
+			Upgrade: &params.UpgradeHealth{
+				PreviousVersion: version.MustParse("2.2.0"),
+				TargetVersion:   version.MustParse("2.2.1"),
+				Status:          "running",
+				Started:         lastBackup,
+			},
+		}}
+	}
+	return &fakeHealthAPI{err: errors.New("permission denied")}
+}
+
+func (s *ListControllersSuite) runListControllersHealth(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewListControllersHealthCommandForTest(s.store, s.healthAPI)
+	return cmdtesting.RunCommand(c, command, append([]string{"--health"}, args...)...)
+}
+
+func (s *ListControllersSuite) TestListControllersHealth(c *gc.C) {
+	s.createTestClientStore(c)
+	context, err := s.runListControllersHealth(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Controller           Replica set  API connections  Storage  Upgrade                 Last backup
+aws-test             2/3 healthy               12  4.0 KiB  -                       2017-05-01 12:00:00Z
+mallards             1/1 healthy                3    200 B  2.2.0->2.2.1 (running)  -
+mark-test-prodstack  (unknown)                  -        -  -                       -
+
+`[1:])
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, `error getting health of "mark-test-prodstack": permission denied`+"\n")
+}
+
+func (s *ListControllersSuite) TestListControllersHealthYaml(c *gc.C) {
+	s.createTestClientStore(c)
+	context, err := s.runListControllersHealth(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+controllers:
+  aws-test:
+    replica-set:
+    - address: 10.0.0.1:37017
+      state: PRIMARY
+      healthy: true
+    - address: 10.0.0.2:37017
+      state: SECONDARY
+      healthy: true
+    - address: 10.0.0.3:37017
+      state: DOWN
+      healthy: false
+    api-connections: 12
+    databases:
+      juju:
+        data-size: 1024
+        storage-size: 2048
+        index-size: 512
+      logs:
+        data-size: 1024
+        storage-size: 2048
+        index-size: 512
+    last-backup: 2017-05-01T12:00:00Z
+  mallards:
+    replica-set:
+    - address: 10.0.1.1:37017
+      state: PRIMARY
+      healthy: true
+    api-connections: 3
+    databases:
+      juju:
+        data-size: 100
+        storage-size: 200
+        index-size: 50
+    upgrade:
+      previous-version: 2.2.0
+      target-version: 2.2.1
+      status: running
+      started: 2017-05-01T12:00:00Z
+  mark-test-prodstack:
+    error: permission denied
+`[1:])
+}
+
 func (s *ListControllersSuite) TestListControllersReadFromStoreErr(c *gc.C) {
 	msg := "fail getting all controllers"
 	errStore := jujuclienttesting.NewStubStore()
@@ -287,3 +395,16 @@ func (s *ListControllersSuite) assertListControllers(c *gc.C, args ...string) st
 	}
 	return output
 }
+
+type fakeHealthAPI struct {
+	health params.ControllerHealth
+	err    error
+}
+
+func (f *fakeHealthAPI) Health() (params.ControllerHealth, error) {
+	return f.health, f.err
+}
+
+func (*fakeHealthAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ControllerHealthAPI defines the methods on the controller health API
+// endpoint that the controllers command calls.
+type ControllerHealthAPI interface {
+	Health() (params.ControllerHealth, error)
+	Close() error
+}
+
+// ControllerHealthSet contains the health of a set of controllers.
+type ControllerHealthSet struct {
+	Controllers map[string]ControllerHealth `yaml:"controllers" json:"controllers"`
+}
+
+// ControllerHealth holds the health of a controller, or the error
+// encountered getting it.
+type ControllerHealth struct {
+	ReplicaSet     []ReplicaSetMember      `yaml:"replica-set,omitempty" json:"replica-set,omitempty"`
+	APIConnections int64                   `yaml:"api-connections,omitempty" json:"api-connections,omitempty"`
+	Databases      map[string]DatabaseSize `yaml:"databases,omitempty" json:"databases,omitempty"`
+	Upgrade        *UpgradeInProgress      `yaml:"upgrade,omitempty" json:"upgrade,omitempty"`
+	LastBackup     *time.Time              `yaml:"last-backup,omitempty" json:"last-backup,omitempty"`
+	Error          string                  `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// ReplicaSetMember holds the status of a controller's replica set
+// member.
+type ReplicaSetMember struct {
+	Address string `yaml:"address" json:"address"`
+	State   string `yaml:"state" json:"state"`
+	Healthy bool   `yaml:"healthy" json:"healthy"`
+}
+
+// DatabaseSize holds the sizes, in bytes, of a controller database.
+type DatabaseSize struct {
+	DataSize    int64 `yaml:"data-size" json:"data-size"`
+	StorageSize int64 `yaml:"storage-size" json:"storage-size"`
+	IndexSize   int64 `yaml:"index-size" json:"index-size"`
+}
+
+// UpgradeInProgress holds details of a controller upgrade in progress.
+type UpgradeInProgress struct {
+	PreviousVersion string    `yaml:"previous-version" json:"previous-version"`
+	TargetVersion   string    `yaml:"target-version" json:"target-version"`
+	Status          string    `yaml:"status" json:"status"`
+	Started         time.Time `yaml:"started" json:"started"`
+}
+
+func (c *listControllersCommand) getHealthAPI(controllerName string) (ControllerHealthAPI, error) {
+	if c.healthAPI != nil {
+		return c.healthAPI(controllerName), nil
+	}
+	api, err := c.NewAPIRoot(c.store, controllerName, "")
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	if api.BestFacadeVersion("ControllerHealth") < 1 {
+		api.Close()
+		return nil, errors.NotSupportedf("health reporting by this controller")
+	}
+	return controllerhealth.NewClient(api), nil
+}
+
+// controllersHealth gets the health of the named controllers
+// concurrently. Failures are recorded against each controller and
+// written to stderr.
+func (c *listControllersCommand) controllersHealth(ctx *cmd.Context, controllerNames []string) ControllerHealthSet {
+	set := ControllerHealthSet{
+		Controllers: make(map[string]ControllerHealth),
+	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	wg.Add(len(controllerNames))
+	for _, controllerName := range controllerNames {
+		name := controllerName
+		go func() {
+			defer wg.Done()
+			health, err := c.controllerHealth(name)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				fmt.Fprintf(ctx.GetStderr(), "error getting health of %q: %v\n", name, err)
+				health.Error = err.Error()
+			}
+			set.Controllers[name] = health
+		}()
+	}
+	wg.Wait()
+	return set
+}
+
+func (c *listControllersCommand) controllerHealth(controllerName string) (ControllerHealth, error) {
+	client, err := c.getHealthAPI(controllerName)
+	if err != nil {
+		return ControllerHealth{}, errors.Trace(err)
+	}
+	defer client.Close()
+	health, err := client.Health()
+	if err != nil {
+		return ControllerHealth{}, errors.Trace(err)
+	}
+	return convertControllerHealth(health), nil
+}
+
+func convertControllerHealth(health params.ControllerHealth) ControllerHealth {
+	result := ControllerHealth{
+		APIConnections: health.APIConnections,
+		LastBackup:     health.LastBackup,
+	}
+	for _, member := range health.ReplicaSet {
+		result.ReplicaSet = append(result.ReplicaSet, ReplicaSetMember{
+			Address: member.Address,
+			State:   member.State,
+			Healthy: member.Healthy,
+		})
+	}
+	if len(health.Databases) > 0 {
+		result.Databases = make(map[string]DatabaseSize)
+		for _, db := range health.Databases {
+			result.Databases[db.Name] = DatabaseSize{
+				DataSize:    db.DataSize,
+				StorageSize: db.StorageSize,
+				IndexSize:   db.IndexSize,
+			}
+		}
+	}
+	if health.Upgrade != nil {
+		result.Upgrade = &UpgradeInProgress{
+			PreviousVersion: health.Upgrade.PreviousVersion.String(),
+			TargetVersion:   health.Upgrade.TargetVersion.String(),
+			Status:          health.Upgrade.Status,
+			Started:         health.Upgrade.Started,
+		}
+	}
+	return result
+}
+
+// formatControllersHealthTabular writes a tabular summary of the
+// health of the controllers, sorted by controller name.
+func formatControllersHealthTabular(writer io.Writer, set ControllerHealthSet) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Controller", "Replica set", "API connections", "Storage", "Upgrade", "Last backup")
+	tw.SetColumnAlignRight(2)
+	tw.SetColumnAlignRight(3)
+
+	names := make([]string, 0, len(set.Controllers))
+	for name := range set.Controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		health := set.Controllers[name]
+		if health.Error != "" {
+			w.Println(name, notKnownDisplay, noValueDisplay, noValueDisplay, noValueDisplay, noValueDisplay)
+			continue
+		}
+		healthy := 0
+		for _, member := range health.ReplicaSet {
+			if member.Healthy {
+				healthy++
+			}
+		}
+		replicaSet := fmt.Sprintf("%d/%d healthy", healthy, len(health.ReplicaSet))
+		var storage uint64
+		for _, db := range health.Databases {
+			storage += uint64(db.StorageSize)
+		}
+		upgrade := noValueDisplay
+		if health.Upgrade != nil {
+			upgrade = fmt.Sprintf("%s->%s (%s)",
+				health.Upgrade.PreviousVersion,
+				health.Upgrade.TargetVersion,
+				health.Upgrade.Status,
+			)
+		}
+		lastBackup := noValueDisplay
+		if health.LastBackup != nil {
+			lastBackup = common.FormatTime(health.LastBackup, true)
+		}
+		w.Println(name, replicaSet, health.APIConnections, humanize.IBytes(storage), upgrade, lastBackup)
+	}
+	tw.Flush()
+	return nil
+}
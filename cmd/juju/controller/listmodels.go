@@ -295,8 +295,16 @@ func (c *modelsCommand) formatTabular(writer io.Writer, value interface{}) error
 			break
 		}
 	}
+	// Only show descriptions if some model has one.
+	haveDescription := false
+	for _, m := range modelSet.Models {
+		if haveDescription = m.Description != ""; haveDescription {
+			break
+		}
+	}
+	var header []interface{}
 	if haveMachineInfo {
-		w.Println("Cloud/Region", "Status", "Machines", "Cores", "Access", "Last connection")
+		header = []interface{}{"Cloud/Region", "Status", "Machines", "Cores", "Access", "Last connection"}
 		offset := 0
 		if c.listUUID {
 			offset++
@@ -304,8 +312,12 @@ func (c *modelsCommand) formatTabular(writer io.Writer, value interface{}) error
 		tw.SetColumnAlignRight(3 + offset)
 		tw.SetColumnAlignRight(4 + offset)
 	} else {
-		w.Println("Cloud/Region", "Status", "Access", "Last connection")
+		header = []interface{}{"Cloud/Region", "Status", "Access", "Last connection"}
 	}
+	if haveDescription {
+		header = append(header, "Description")
+	}
+	w.Println(header...)
 	for _, model := range modelSet.Models {
 		cloudRegion := strings.Trim(model.Cloud+"/"+model.CloudRegion, "/")
 		owner := names.NewUserTag(model.Owner)
@@ -352,7 +364,15 @@ func (c *modelsCommand) formatTabular(writer io.Writer, value interface{}) error
 		if lastConnection == "" {
 			lastConnection = "never connected"
 		}
-		w.Println(access, lastConnection)
+		if haveDescription {
+			description := model.Description
+			if description == "" {
+				description = "-"
+			}
+			w.Println(access, lastConnection, description)
+		} else {
+			w.Println(access, lastConnection)
+		}
 	}
 	tw.Flush()
 	return nil
@@ -37,6 +37,7 @@ type fakeModelMgrAPIClient struct {
 	all          bool
 	inclMachines bool
 	denyAccess   bool
+	descriptions bool
 	infos        []params.ModelInfoResult
 }
 
@@ -97,6 +98,9 @@ func (f *fakeModelMgrAPIClient) ModelInfo(tags []names.ModelTag) ([]params.Model
 						{Id: "0", Hardware: &params.MachineHardware{Cores: &one}}, {Id: "1"},
 					}
 				}
+				if f.descriptions {
+					result.Description = "staging for the web team"
+				}
 			case "test-model2":
 				last2 := time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)
 				result.Status.Status = status.Active
@@ -311,6 +315,20 @@ func (s *ModelsSuite) TestModelsMachineInfo(c *gc.C) {
 		"\n")
 }
 
+func (s *ModelsSuite) TestModelsDescription(c *gc.C) {
+	s.api.descriptions = true
+	context, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Controller: fake\n"+
+		"\n"+
+		"Model                        Cloud/Region  Status      Access  Last connection  Description\n"+
+		"test-model1*                 dummy         active      read    2015-03-20       staging for the web team\n"+
+		"carlotta/test-model2         dummy         active      write   2015-03-01       -\n"+
+		"daiwik@external/test-model3  dummy         destroying  -       never connected  -\n"+
+		"\n")
+}
+
 func (s *ModelsSuite) TestAllModelsWithOneUnauthorised(c *gc.C) {
 	c.Assert(s.store.Models["fake"].Models, gc.HasLen, 0)
 	s.api.denyAccess = true
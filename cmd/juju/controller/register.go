@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/rand"
+	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
 	"encoding/json"
@@ -76,6 +77,8 @@ name of a public controller.
 To complete the user registration process, you should have been provided
 with a base64-encoded blob of data (the output of 'juju add-user')
 which can be copied and pasted as the <string> argument to 'register'.
+The registration string includes the controller's CA certificate, which
+is used to verify the controller before any secrets are sent to it.
 You will be prompted for a password, which, once set, causes the
 registration string to be voided. In order to start using Juju the user
 can now either add a model or wait for a model to be shared with them.
@@ -268,7 +271,7 @@ func (c *registerCommand) nonPublicControllerDetails(ctx *cmd.Context, registrat
 			&registrationParams.key,
 		),
 	}
-	resp, err := c.secretKeyLogin(registrationParams.controllerAddrs, req, controllerName)
+	resp, err := c.secretKeyLogin(registrationParams.controllerAddrs, registrationParams.caCert, req, controllerName)
 	if err != nil {
 		return errRet(errors.Trace(err))
 	}
@@ -288,6 +291,9 @@ func (c *registerCommand) nonPublicControllerDetails(ctx *cmd.Context, registrat
 	if err := json.Unmarshal(payloadBytes, &responsePayload); err != nil {
 		return errRet(errors.Annotate(err, "unmarshalling response payload"))
 	}
+	if registrationParams.caCert != "" && responsePayload.CACert != registrationParams.caCert {
+		return errRet(errors.New("controller CA certificate does not match the registration string"))
+	}
 	user := registrationParams.userTag.Id()
 	ctx.Infof("Initial password successfully set for %s.", friendlyUserName(user))
 	// If we get to here, then we have a cached macaroon for the registered
@@ -420,6 +426,7 @@ type registrationParams struct {
 	defaultControllerName string
 	userTag               names.UserTag
 	controllerAddrs       []string
+	caCert                string
 	key                   [32]byte
 	nonce                 [24]byte
 	newPassword           string
@@ -455,6 +462,7 @@ func (c *registerCommand) getParameters(ctx *cmd.Context) (*registrationParams,
 	}
 	copy(params.key[:], info.SecretKey)
 	params.defaultControllerName = info.ControllerName
+	params.caCert = info.CACert
 
 	// Prompt the user for the new password to set.
 	newPassword, err := c.promptNewPassword(ctx.Stderr, ctx.Stdin)
@@ -471,7 +479,7 @@ func (c *registerCommand) getParameters(ctx *cmd.Context) (*registrationParams,
 	return &params, nil
 }
 
-func (c *registerCommand) secretKeyLogin(addrs []string, request params.SecretKeyLoginRequest, controllerName string) (*params.SecretKeyLoginResponse, error) {
+func (c *registerCommand) secretKeyLogin(addrs []string, caCert string, request params.SecretKeyLoginRequest, controllerName string) (*params.SecretKeyLoginResponse, error) {
 	cookieJar, err := c.CookieJar(c.store, controllerName)
 	if err != nil {
 		return nil, errors.Annotate(err, "getting API context")
@@ -484,14 +492,16 @@ func (c *registerCommand) secretKeyLogin(addrs []string, request params.SecretKe
 	r := bytes.NewReader(buf)
 
 	// Determine which address to use by attempting to open an API
-	// connection with each of the addresses. Note that we do not
-	// know the CA certificate yet, so we do not want to send any
-	// sensitive information. We make no attempt to log in until
-	// we can verify the server's identity.
+	// connection with each of the addresses. If the registration
+	// string did not include the CA certificate, we do not know it
+	// yet, so we do not want to send any sensitive information. We
+	// make no attempt to log in until we can verify the server's
+	// identity.
 	opts := api.DefaultDialOpts()
-	opts.InsecureSkipVerify = true
+	opts.InsecureSkipVerify = caCert == ""
 	conn, err := c.apiOpen(&api.Info{
 		Addrs:     addrs,
+		CACert:    caCert,
 		SkipLogin: true,
 	}, opts)
 	if err != nil {
@@ -511,7 +521,10 @@ func (c *registerCommand) secretKeyLogin(addrs []string, request params.SecretKe
 		return nil, errors.Trace(err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpClient := utils.GetNonValidatingHTTPClient()
+	httpClient, err := registrationHTTPClient(caCert)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	httpClient.Jar = cookieJar
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
@@ -534,6 +547,26 @@ func (c *registerCommand) secretKeyLogin(addrs []string, request params.SecretKe
 	return &resp, nil
 }
 
+// registrationHTTPClient returns an HTTP client for making the
+// registration request. If the CA certificate is known, the client
+// verifies the controller's certificate with it; otherwise the
+// controller is authenticated by its encrypted response alone.
+func registrationHTTPClient(caCert string) (*http.Client, error) {
+	if caCert == "" {
+		return utils.GetNonValidatingHTTPClient(), nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, errors.NotValidf("CA certificate in registration string")
+	}
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.RootCAs = pool
+	tlsConfig.ServerName = "juju-apiserver"
+	return &http.Client{
+		Transport: utils.NewHttpTLSTransport(tlsConfig),
+	}, nil
+}
+
 func (c *registerCommand) promptNewPassword(stderr io.Writer, stdin io.Reader) (string, error) {
 	password, err := c.readPassword("Enter a new password: ", stderr, stdin)
 	if err != nil {
@@ -4,6 +4,7 @@
 package controller_test
 
 import (
+	"crypto/tls"
 	"encoding/asn1"
 	"encoding/base64"
 	"encoding/json"
@@ -13,6 +14,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -25,6 +27,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
@@ -35,6 +38,8 @@ type RegisterSuite struct {
 	apiConnection            *mockAPIConnection
 	store                    *jujuclient.MemStore
 	apiOpenError             error
+	apiOpenInfo              *api.Info
+	apiOpenOpts              api.DialOpts
 	listModels               func(jujuclient.ClientStore, string, string) ([]base.UserModel, error)
 	listModelsControllerName string
 	listModelsUserName       string
@@ -441,6 +446,114 @@ Enter a name for this controller: »foo
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+// restartServer restarts the test server with a certificate signed by
+// the given CA, as a Juju controller's would be.
+func (s *RegisterSuite) restartServer(c *gc.C, caCert, caKey string) {
+	certPEM, keyPEM, err := cert.NewServer(caCert, caKey, time.Now().AddDate(1, 0, 0), []string{"juju-apiserver"})
+	c.Assert(err, jc.ErrorIsNil)
+	tlsCert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	c.Assert(err, jc.ErrorIsNil)
+
+	handler := s.server.Config.Handler
+	s.server.Close()
+	s.server = httptest.NewUnstartedServer(handler)
+	s.server.TLS = &tls.Config{Certificates: []tls.Certificate{tlsCert}}
+	s.server.StartTLS()
+
+	serverURL, err := url.Parse(s.server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	s.apiConnection.addr = serverURL.Host
+}
+
+func (s *RegisterSuite) TestRegisterVerifiesCACert(c *gc.C) {
+	s.restartServer(c, testing.CACert, testing.CAKey)
+	srv := s.mockServer(c)
+	s.httpHandler = srv
+
+	registrationData := s.encodeRegistrationData(c, jujuclient.RegistrationInfo{
+		User:           "bob",
+		SecretKey:      mockSecretKey,
+		ControllerName: "controller-name",
+		CACert:         testing.CACert,
+	})
+	prompter := cmdtesting.NewSeqPrompter(c, "»", `
+Enter a new password: »hunter2
+
+Confirm password: »hunter2
+
+Enter a name for this controller \[controller-name\]: »
+Initial password successfully set for bob.
+
+Welcome, bob. You are now logged into "controller-name".
+`[1:]+noModelsText)
+	defer prompter.CheckDone()
+	err := s.run(c, prompter, registrationData)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(srv.requests, gc.HasLen, 1)
+
+	// The API connection used to select the controller address
+	// must verify the controller's certificate.
+	c.Assert(s.apiOpenInfo.CACert, gc.Equals, testing.CACert)
+	c.Assert(s.apiOpenOpts.InsecureSkipVerify, jc.IsFalse)
+
+	controller, err := s.store.ControllerByName("controller-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controller.CACert, gc.Equals, testing.CACert)
+}
+
+func (s *RegisterSuite) TestRegisterUntrustedController(c *gc.C) {
+	srv := s.mockServer(c)
+	s.httpHandler = srv
+
+	// The test server's certificate is not signed by the CA
+	// certificate in the registration string.
+	registrationData := s.encodeRegistrationData(c, jujuclient.RegistrationInfo{
+		User:      "bob",
+		SecretKey: mockSecretKey,
+		CACert:    testing.CACert,
+	})
+	prompter := cmdtesting.NewSeqPrompter(c, "»", `
+Enter a new password: »hunter2
+
+Confirm password: »hunter2
+
+Enter a name for this controller: »foo
+`[1:])
+	defer prompter.CheckDone()
+	err := s.run(c, prompter, registrationData)
+	c.Assert(err, gc.ErrorMatches, ".*x509: certificate signed by unknown authority")
+	c.Assert(srv.requests, gc.HasLen, 0)
+
+	_, err = s.store.ControllerByName("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RegisterSuite) TestRegisterCACertMismatch(c *gc.C) {
+	s.restartServer(c, testing.OtherCACert, testing.OtherCAKey)
+	s.httpHandler = s.mockServer(c)
+
+	// The mock server responds with testing.CACert, which does
+	// not match the CA certificate in the registration string.
+	registrationData := s.encodeRegistrationData(c, jujuclient.RegistrationInfo{
+		User:      "bob",
+		SecretKey: mockSecretKey,
+		CACert:    testing.OtherCACert,
+	})
+	prompter := cmdtesting.NewSeqPrompter(c, "»", `
+Enter a new password: »hunter2
+
+Confirm password: »hunter2
+
+Enter a name for this controller: »foo
+`[1:])
+	defer prompter.CheckDone()
+	err := s.run(c, prompter, registrationData)
+	c.Assert(err, gc.ErrorMatches, "controller CA certificate does not match the registration string")
+
+	_, err = s.store.ControllerByName("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *RegisterSuite) TestRegisterPublic(c *gc.C) {
 	s.apiConnection.authTag = names.NewUserTag("bob@external")
 	s.apiConnection.controllerAccess = "login"
@@ -567,6 +680,8 @@ func (s *RegisterSuite) apiOpen(info *api.Info, opts api.DialOpts) (api.Connecti
 	if s.apiOpenError != nil {
 		return nil, s.apiOpenError
 	}
+	s.apiOpenInfo = info
+	s.apiOpenOpts = opts
 	return s.apiConnection, nil
 }
 
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewRotateKeyPairCommand returns a command that rotates the public
+// key of a controller's cloud keypair.
+func NewRotateKeyPairCommand() cmd.Command {
+	return modelcmd.WrapController(&rotateKeyPairCommand{
+		newEnviron: controllerEnvironFromStore,
+	})
+}
+
+type rotateKeyPairCommand struct {
+	modelcmd.ControllerCommandBase
+	newEnviron    func(*cmd.Context, jujuclient.ClientStore, string) (environs.Environ, error)
+	publicKeyPath string
+}
+
+var rotateKeyPairDoc = `
+Replaces the public key of the keypair that the cloud injects into the
+machines of a controller, for emergency access. The keypair is created
+when the controller is bootstrapped, from the first of its authorized
+keys, and removed when the controller is destroyed.
+
+Only machines started after the keypair is rotated are given the new
+key. Use "juju add-ssh-key" and "juju remove-ssh-key" to manage the keys
+of existing machines.
+
+The cloud is accessed directly, using the bootstrap configuration and
+credentials in the client, so this command can only be run from the
+client that bootstrapped the controller. Not all clouds support
+controller keypairs.
+
+Examples:
+    juju rotate-controller-keypair ~/.ssh/emergency.pub
+    juju rotate-controller-keypair -c prod ~/.ssh/emergency.pub
+
+See also:
+    add-ssh-key
+    kill-controller
+`
+
+// Info implements Command.Info.
+func (c *rotateKeyPairCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rotate-controller-keypair",
+		Args:    "<public key file>",
+		Purpose: "Rotates the cloud keypair of a controller.",
+		Doc:     rotateKeyPairDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *rotateKeyPairCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no public key file specified")
+	}
+	c.publicKeyPath, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *rotateKeyPairCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	store := c.ClientStore()
+	details, err := store.ControllerByName(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	path, err := utils.NormalizePath(c.publicKeyPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	publicKey, err := ioutil.ReadFile(ctx.AbsPath(path))
+	if err != nil {
+		return errors.Annotate(err, "reading public key")
+	}
+
+	env, err := c.newEnviron(ctx, store, controllerName)
+	if err != nil {
+		return errors.Annotate(err, "getting controller environ")
+	}
+	rotator, ok := env.(environs.ControllerKeyPairRotator)
+	if !ok {
+		return errors.NotSupportedf("controller keypairs on this cloud")
+	}
+	if err := rotator.RotateControllerKeyPair(details.ControllerUUID, strings.TrimSpace(string(publicKey))); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Rotated the keypair of controller %q", controllerName)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/jujuclient"
+)
+
+type rotateKeyPairSuite struct {
+	baseControllerSuite
+	store   *jujuclient.MemStore
+	env     environs.Environ
+	keyPath string
+}
+
+var _ = gc.Suite(&rotateKeyPairSuite{})
+
+func (s *rotateKeyPairSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		ControllerUUID: "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+	}
+	s.env = &fakeKeyPairRotator{}
+	s.keyPath = filepath.Join(c.MkDir(), "id_rsa.pub")
+	err := ioutil.WriteFile(s.keyPath, []byte("ssh-rsa AAAA emergency\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rotateKeyPairSuite) newCommand() cmd.Command {
+	return controller.NewRotateKeyPairCommandForTest(s.store, func(ctx *cmd.Context, store jujuclient.ClientStore, controllerName string) (environs.Environ, error) {
+		if controllerName != "fake" {
+			return nil, errors.NotFoundf("controller %q", controllerName)
+		}
+		return s.env, nil
+	})
+}
+
+func (s *rotateKeyPairSuite) TestRotate(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), s.keyPath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.env.(*fakeKeyPairRotator).CheckCalls(c, []testing.StubCall{{
+		"RotateControllerKeyPair", []interface{}{
+			"deadbeef-1bad-500d-9000-4b1d0d06f00d", "ssh-rsa AAAA emergency",
+		},
+	}})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Rotated the keypair of controller \"fake\"\n")
+}
+
+func (s *rotateKeyPairSuite) TestRotateError(c *gc.C) {
+	s.env.(*fakeKeyPairRotator).SetErrors(errors.New(`keypair "juju-deadbeef" not found`))
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), s.keyPath)
+	c.Assert(err, gc.ErrorMatches, `keypair "juju-deadbeef" not found`)
+}
+
+func (s *rotateKeyPairSuite) TestRotateNotSupported(c *gc.C) {
+	s.env = &fakeEnviron{}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), s.keyPath)
+	c.Assert(err, gc.ErrorMatches, "controller keypairs on this cloud not supported")
+}
+
+func (s *rotateKeyPairSuite) TestRotateMissingKeyFile(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), filepath.Join(c.MkDir(), "missing.pub"))
+	c.Assert(err, gc.ErrorMatches, "reading public key: .*")
+	s.env.(*fakeKeyPairRotator).CheckNoCalls(c)
+}
+
+func (s *rotateKeyPairSuite) TestInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "no public key file specified")
+	_, err = cmdtesting.RunCommand(c, s.newCommand(), "a.pub", "b.pub")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.pub"\]`)
+}
+
+type fakeEnviron struct {
+	environs.Environ
+}
+
+type fakeKeyPairRotator struct {
+	environs.Environ
+	testing.Stub
+}
+
+func (f *fakeKeyPairRotator) RotateControllerKeyPair(controllerUUID, publicKey string) error {
+	f.MethodCall(f, "RotateControllerKeyPair", controllerUUID, publicKey)
+	return f.NextErr()
+}
@@ -62,6 +62,13 @@ It is possible to override or augment constraints by passing provider-specific
 information about how to allocate the machine. For example, one can direct the
 MAAS provider to acquire a particular node by specifying its hostname.
 
+The image used to start a new machine is normally selected from the model's
+"image-stream". The --image-stream option selects the image from another
+stream, such as "daily", for this machine only, and --image-id pins the
+machine to a specific image from the stream. The image is validated against
+the provider's image metadata when the machine is provisioned. Neither
+option may be used when adding containers.
+
 Examples:
    juju add-machine                      (starts a new machine)
    juju add-machine -n 2                 (starts 2 new machines)
@@ -73,6 +80,8 @@ Examples:
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
+   juju add-machine --image-stream daily (starts a machine from a daily image)
+   juju add-machine --image-id ami-0123  (starts a machine from image ami-0123)
 
 See also:
     remove-machine
@@ -114,6 +123,11 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// ImageStream is the image stream from which the machine's image is
+	// selected, overriding the model's image-stream.
+	ImageStream string
+	// ImageId is the id of the image the machine is started with.
+	ImageId string
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -131,6 +145,8 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.ImageStream, "image-stream", "", "The image stream to select the machine's image from")
+	f.StringVar(&c.ImageId, "image-id", "", "The id of the image to start the machine with")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -152,6 +168,11 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.ImageStream != "" || c.ImageId != "" {
+		if c.Placement != nil && c.Placement.Scope != "model-uuid" {
+			return errors.New("cannot use --image-stream or --image-id when adding containers or manually provisioned machines")
+		}
+	}
 	return nil
 }
 
@@ -231,7 +252,8 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	defer client.Close()
 
 	var machineManager MachineManagerAPI
-	if len(c.Disks) > 0 {
+	useImage := c.ImageStream != "" || c.ImageId != ""
+	if len(c.Disks) > 0 || useImage {
 		machineManager, err = c.getMachineManagerAPI()
 		if err != nil {
 			return errors.Trace(err)
@@ -240,6 +262,9 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		if machineManager.BestAPIVersion() < 1 {
 			return errors.New("cannot add machines with disks: not supported by the API server")
 		}
+		if useImage && machineManager.BestAPIVersion() < 7 {
+			return errors.New("cannot add machines with an image stream or image id: not supported by the API server")
+		}
 	}
 
 	logger.Infof("load config")
@@ -289,6 +314,8 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		Constraints: c.Constraints,
 		Jobs:        jobs,
 		Disks:       c.Disks,
+		ImageStream: c.ImageStream,
+		ImageId:     c.ImageId,
 	}
 	machines := make([]params.AddMachineParams, c.NumMachines)
 	for i := 0; i < c.NumMachines; i++ {
@@ -296,8 +323,9 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 
 	var results []params.AddMachinesResult
-	// If storage is specified, we attempt to use a new API on the service facade.
-	if len(c.Disks) > 0 {
+	// If storage or an image is specified, we attempt to use a new API
+	// on the machine manager facade.
+	if machineManager != nil {
 		results, err = machineManager.AddMachines(machines)
 	} else {
 		results, err = client.AddMachines(machines)
@@ -90,6 +90,16 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"something:special"},
 			count:     1,
 			placement: "something:special",
+		}, {
+			args:      []string{"--image-stream", "daily", "zone=us-east-1a"},
+			count:     1,
+			placement: "model-uuid:zone=us-east-1a",
+		}, {
+			args:        []string{"--image-stream", "daily", "lxd"},
+			errorString: "cannot use --image-stream or --image-id when adding containers or manually provisioned machines",
+		}, {
+			args:        []string{"--image-id", "ami-0123", "ssh:user@10.10.0.3"},
+			errorString: "cannot use --image-stream or --image-id when adding containers or manually provisioned machines",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -211,6 +221,23 @@ func (s *AddMachineSuite) TestAddMachineWithDisksUnsupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot add machines with disks: not supported by the API server")
 }
 
+func (s *AddMachineSuite) TestAddMachineWithImage(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 7
+	_, err := s.run(c, "--image-stream", "daily", "--image-id", "ami-0123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 1)
+	param := s.fakeMachineManager.args[0]
+	c.Assert(param.ImageStream, gc.Equals, "daily")
+	c.Assert(param.ImageId, gc.Equals, "ami-0123")
+}
+
+func (s *AddMachineSuite) TestAddMachineWithImageUnsupported(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 6
+	_, err := s.run(c, "--image-stream", "daily")
+	c.Assert(err, gc.ErrorMatches, "cannot add machines with an image stream or image id: not supported by the API server")
+}
+
 type fakeAddMachineAPI struct {
 	successOrder     []bool
 	currentOp        int
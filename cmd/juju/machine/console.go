@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewConsoleCommand returns a command used to access the console of a
+// machine's instance.
+func NewConsoleCommand() cmd.Command {
+	return modelcmd.Wrap(&consoleCommand{})
+}
+
+// consoleAPI defines the API methods used by the console command.
+type consoleAPI interface {
+	MachineConsole(machineId, consoleType string) (string, error)
+	Close() error
+}
+
+// consoleCommand connects to the console of a machine's instance.
+type consoleCommand struct {
+	modelcmd.ModelCommandBase
+	api           consoleAPI
+	connectSerial func(machineId string) (io.ReadWriteCloser, error)
+
+	machineId   string
+	consoleType string
+}
+
+const consoleCommandDoc = `
+Provides emergency access to the console of a machine's instance, as
+provided by the cloud. This is useful for investigating machines that
+can no longer be reached with "juju ssh", for example due to network
+misconfiguration.
+
+By default an interactive serial console is opened. The serial console
+is proxied through the controller, so the cloud's console endpoint does
+not need to be reachable from the client. Console sessions are closed
+by the controller after an hour.
+
+With --type novnc, the URL of a graphical console is displayed, which
+may be opened in a web browser. The URL contains a short-lived access
+token.
+
+Accessing consoles requires admin access to the model. Not all clouds
+provide instance consoles.
+
+Examples:
+
+    juju console 3
+    juju console 3 --type novnc
+
+See also:
+    show-machine
+    ssh
+`
+
+// Info implements Command.Info.
+func (c *consoleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "console",
+		Args:    "<machine>",
+		Purpose: "Access the console of a machine's instance.",
+		Doc:     consoleCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *consoleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.consoleType, "type", "serial", "The type of console: serial or novnc")
+}
+
+// Init implements Command.Init.
+func (c *consoleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine specified")
+	}
+	c.machineId, args = args[0], args[1:]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.Errorf("invalid machine %q", c.machineId)
+	}
+	switch c.consoleType {
+	case "serial", "novnc":
+	default:
+		return errors.Errorf("invalid console type %q, expected serial or novnc", c.consoleType)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *consoleCommand) Run(ctx *cmd.Context) error {
+	if c.consoleType == "serial" {
+		return c.runSerial(ctx)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	consoleURL, err := client.MachineConsole(c.machineId, c.consoleType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintln(ctx.Stdout, consoleURL)
+	return nil
+}
+
+func (c *consoleCommand) runSerial(ctx *cmd.Context) error {
+	console, err := c.getConnectSerial()(c.machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer console.Close()
+
+	ctx.Infof("Connected to the serial console of machine %s.", c.machineId)
+	go io.Copy(console, ctx.Stdin)
+	if _, err := io.Copy(ctx.Stdout, console); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Console session closed.")
+	return nil
+}
+
+func (c *consoleCommand) getAPI() (consoleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+func (c *consoleCommand) getConnectSerial() func(string) (io.ReadWriteCloser, error) {
+	if c.connectSerial != nil {
+		return c.connectSerial
+	}
+	return func(machineId string) (io.ReadWriteCloser, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return machinemanager.ConnectSerialConsole(root, machineId)
+	}
+}
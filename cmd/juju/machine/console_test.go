@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type ConsoleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeConsoleAPI
+}
+
+var _ = gc.Suite(&ConsoleSuite{})
+
+func (s *ConsoleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeConsoleAPI{url: "https://console.example.com/?token=abc"}
+}
+
+func (s *ConsoleSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no machine specified",
+	}, {
+		args: []string{"foo"},
+		err:  `invalid machine "foo"`,
+	}, {
+		args: []string{"0", "1"},
+		err:  `unrecognized args: \["1"\]`,
+	}, {
+		args: []string{"0", "--type", "spice"},
+		err:  `invalid console type "spice", expected serial or novnc`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewConsoleCommandForTest(s.api, nil), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConsoleSuite) TestNoVNC(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewConsoleCommandForTest(s.api, nil), "0/lxd/1", "--type", "novnc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "https://console.example.com/?token=abc\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"MachineConsole", []interface{}{"0/lxd/1", "novnc"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ConsoleSuite) TestNoVNCError(c *gc.C) {
+	s.api.SetErrors(errors.New("instance consoles with this provider not supported"))
+	_, err := cmdtesting.RunCommand(c, machine.NewConsoleCommandForTest(s.api, nil), "0", "--type", "novnc")
+	c.Assert(err, gc.ErrorMatches, "instance consoles with this provider not supported")
+}
+
+func (s *ConsoleSuite) TestSerial(c *gc.C) {
+	console := &fakeSerialConsole{Reader: strings.NewReader("login: ")}
+	connect := func(machineId string) (io.ReadWriteCloser, error) {
+		c.Assert(machineId, gc.Equals, "3")
+		return console, nil
+	}
+	command := machine.NewConsoleCommandForTest(s.api, connect)
+	err := cmdtesting.InitCommand(command, []string{"3"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("")
+	err = command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "login: ")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Connected to the serial console of machine 3.\n"+
+		"Console session closed.\n")
+	c.Assert(console.closed, jc.IsTrue)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ConsoleSuite) TestSerialConnectError(c *gc.C) {
+	connect := func(machineId string) (io.ReadWriteCloser, error) {
+		return nil, errors.New("permission denied")
+	}
+	_, err := cmdtesting.RunCommand(c, machine.NewConsoleCommandForTest(s.api, connect), "3")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeConsoleAPI struct {
+	jujutesting.Stub
+	url string
+}
+
+func (f *fakeConsoleAPI) MachineConsole(machineId, consoleType string) (string, error) {
+	f.MethodCall(f, "MachineConsole", machineId, consoleType)
+	return f.url, f.NextErr()
+}
+
+func (f *fakeConsoleAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+type fakeSerialConsole struct {
+	io.Reader
+	written bytes.Buffer
+	closed  bool
+}
+
+func (f *fakeSerialConsole) Write(p []byte) (int, error) {
+	return f.written.Write(p)
+}
+
+func (f *fakeSerialConsole) Close() error {
+	f.closed = true
+	return nil
+}
@@ -4,6 +4,8 @@
 package machine
 
 import (
+	"io"
+
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/api"
@@ -35,12 +37,55 @@ func NewListCommandForTest(api statusAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewListPendingRemovalCommandForTest returns a listMachinesCommand
+// with the specified api for listing machines pending removal.
+func NewListPendingRemovalCommandForTest(api pendingRemovalAPI) cmd.Command {
+	cmd := newListMachinesCommand(nil)
+	cmd.pendingRemovalAPI = api
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a showMachineCommand with specified api
 func NewShowCommandForTest(api statusAPI) cmd.Command {
 	cmd := newShowMachineCommand(api)
 	return modelcmd.Wrap(cmd)
 }
 
+// NewShowConsoleLogCommandForTest returns a showMachineCommand with
+// the specified api for retrieving console output.
+func NewShowConsoleLogCommandForTest(api consoleLogAPI) cmd.Command {
+	cmd := newShowMachineCommand(nil)
+	cmd.consoleLogAPI = api
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowNetworkingCommandForTest returns a showMachineCommand with
+// the specified api for retrieving network devices.
+func NewShowNetworkingCommandForTest(api networkingAPI) cmd.Command {
+	cmd := newShowMachineCommand(nil)
+	cmd.networkingAPI = api
+	return modelcmd.Wrap(cmd)
+}
+
+// NewConsoleCommandForTest returns a consoleCommand with the specified
+// api and serial console connection function.
+func NewConsoleCommandForTest(api consoleAPI, connectSerial func(string) (io.ReadWriteCloser, error)) cmd.Command {
+	return modelcmd.Wrap(&consoleCommand{
+		api:           api,
+		connectSerial: connectSerial,
+	})
+}
+
+// NewImportInstanceCommandForTest returns an importInstanceCommand with
+// the specified apis.
+func NewImportInstanceCommandForTest(api importInstanceAPI, clientAPI AddMachineAPI, mcAPI ModelConfigAPI) cmd.Command {
+	return modelcmd.Wrap(&importInstanceCommand{
+		api:            api,
+		clientAPI:      clientAPI,
+		modelConfigAPI: mcAPI,
+	})
+}
+
 type RemoveCommand struct {
 	*removeCommand
 }
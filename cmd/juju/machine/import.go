@@ -0,0 +1,209 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// NewImportInstanceCommand returns a command that imports an existing
+// cloud instance into a model as a machine.
+func NewImportInstanceCommand() cmd.Command {
+	return modelcmd.Wrap(&importInstanceCommand{})
+}
+
+// importInstanceAPI defines the machine manager API methods used by
+// the import-instance command.
+type importInstanceAPI interface {
+	CloudInstance(instanceId string) (params.CloudInstanceResult, error)
+	TagMachineInstance(machineId string) error
+	Close() error
+}
+
+// importInstanceCommand adopts an existing cloud instance as a machine.
+type importInstanceCommand struct {
+	modelcmd.ModelCommandBase
+	api            importInstanceAPI
+	clientAPI      AddMachineAPI
+	modelConfigAPI ModelConfigAPI
+
+	instanceId string
+	host       string
+}
+
+const importInstanceDoc = `
+Brings an existing instance of the model's cloud under Juju's management,
+so that servers created outside of Juju can be managed by it gradually.
+
+The instance is given by its id in the cloud, for example the id of an
+OpenStack server. Juju connects to the instance over SSH, installs a
+machine agent on it and records it in the model as a new machine, along
+with its hardware characteristics. Finally the instance is tagged in the
+cloud in the same way as the instances that Juju starts itself.
+
+By default Juju connects to the instance's public address, as reported
+by the cloud. A different address, and the user to connect as, may be
+given with --host. The user must be able to run sudo on the instance.
+
+Unlike machines added with "juju add-machine ssh:...", imported machines
+are backed by cloud instances: removing the machine from the model stops
+the instance, unless --keep-instance is given to "juju remove-machine".
+
+Examples:
+
+    juju import-instance 0a8d3fa6-e1d5-4b42-a0a5-7b5f1a9c4b21
+    juju import-instance i-0bd34ab2 --host admin@10.0.0.5
+
+See also:
+    add-machine
+    remove-machine
+`
+
+// Info implements Command.Info.
+func (c *importInstanceCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-instance",
+		Args:    "<provider-id>",
+		Purpose: "Import an existing cloud instance into the model as a machine.",
+		Doc:     importInstanceDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importInstanceCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.host, "host", "", "The [user@]host to connect to, instead of the instance's public address")
+}
+
+// Init implements Command.Init.
+func (c *importInstanceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no instance specified")
+	}
+	c.instanceId, args = args[0], args[1:]
+	if c.host != "" {
+		if _, host := splitUserHost(c.host); host == "" {
+			return errors.Errorf("invalid host %q", c.host)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *importInstanceCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	inst, err := api.CloudInstance(c.instanceId)
+	if err != nil {
+		return errors.Annotatef(err, "cannot import instance %q", c.instanceId)
+	}
+	user, host := splitUserHost(c.host)
+	if host == "" {
+		addr, ok := network.SelectPublicAddress(params.NetworkAddresses(inst.Addresses...))
+		if !ok {
+			return errors.Errorf("instance %q has no usable address, specify one with --host", c.instanceId)
+		}
+		host = addr.Value
+	}
+
+	modelConfigClient, err := c.getModelConfigAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer modelConfigClient.Close()
+	configAttrs, err := modelConfigClient.ModelGet()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := config.New(config.NoDefaults, configAttrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot read authorized-keys")
+	}
+
+	client, err := c.getClientAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	machineId, err := sshProvisioner(manual.ProvisionMachineArgs{
+		Host:           host,
+		User:           user,
+		Client:         client,
+		Stdin:          ctx.Stdin,
+		Stdout:         ctx.Stdout,
+		Stderr:         ctx.Stderr,
+		AuthorizedKeys: authKeys,
+		InstanceId:     instance.Id(inst.InstanceId),
+		UpdateBehavior: &params.UpdateBehavior{
+			EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
+			EnableOSUpgrade:       config.EnableOSUpgrade(),
+		},
+	})
+	if params.IsCodeOperationBlocked(err) {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("imported instance %s as machine %s", inst.InstanceId, machineId)
+
+	// The machine has been imported by now, so failing to tag its
+	// instance is not fatal.
+	if err := api.TagMachineInstance(machineId); err != nil {
+		ctx.Warningf("instance %s not tagged: %v", inst.InstanceId, err)
+	}
+	return nil
+}
+
+func (c *importInstanceCommand) getAPI() (importInstanceAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+func (c *importInstanceCommand) getClientAPI() (AddMachineAPI, error) {
+	if c.clientAPI != nil {
+		return c.clientAPI, nil
+	}
+	return c.NewAPIClient()
+}
+
+func (c *importInstanceCommand) getModelConfigAPI() (ModelConfigAPI, error) {
+	if c.modelConfigAPI != nil {
+		return c.modelConfigAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(root), nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/testing"
+)
+
+type ImportInstanceSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api            *fakeImportInstanceAPI
+	fakeAddMachine *fakeAddMachineAPI
+	provisionArgs  []manual.ProvisionMachineArgs
+}
+
+var _ = gc.Suite(&ImportInstanceSuite{})
+
+func (s *ImportInstanceSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeImportInstanceAPI{
+		result: params.CloudInstanceResult{
+			InstanceId: "legacy-0",
+			Addresses: []params.Address{
+				{Value: "10.0.0.5", Type: "ipv4", Scope: "local-cloud"},
+				{Value: "203.0.113.5", Type: "ipv4", Scope: "public"},
+			},
+		},
+	}
+	s.fakeAddMachine = &fakeAddMachineAPI{}
+	s.provisionArgs = nil
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		s.provisionArgs = append(s.provisionArgs, args)
+		return "7", nil
+	})
+}
+
+func (s *ImportInstanceSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := machine.NewImportInstanceCommandForTest(s.api, s.fakeAddMachine, s.fakeAddMachine)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *ImportInstanceSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no instance specified",
+	}, {
+		args: []string{"legacy-0", "legacy-1"},
+		err:  `unrecognized args: \["legacy-1"\]`,
+	}, {
+		args: []string{"legacy-0", "--host", "admin@"},
+		err:  `invalid host "admin@"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ImportInstanceSuite) TestImportInstance(c *gc.C) {
+	ctx, err := s.run(c, "legacy-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "imported instance legacy-0 as machine 7\n")
+	c.Assert(s.provisionArgs, gc.HasLen, 1)
+	c.Assert(s.provisionArgs[0].Host, gc.Equals, "203.0.113.5")
+	c.Assert(s.provisionArgs[0].User, gc.Equals, "")
+	c.Assert(s.provisionArgs[0].InstanceId, gc.Equals, instance.Id("legacy-0"))
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"CloudInstance", []interface{}{"legacy-0"}},
+		{"TagMachineInstance", []interface{}{"7"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ImportInstanceSuite) TestImportInstanceWithHost(c *gc.C) {
+	_, err := s.run(c, "legacy-0", "--host", "admin@10.0.0.5")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provisionArgs, gc.HasLen, 1)
+	c.Assert(s.provisionArgs[0].Host, gc.Equals, "10.0.0.5")
+	c.Assert(s.provisionArgs[0].User, gc.Equals, "admin")
+}
+
+func (s *ImportInstanceSuite) TestImportInstanceNoAddress(c *gc.C) {
+	s.api.result.Addresses = nil
+	_, err := s.run(c, "legacy-0")
+	c.Assert(err, gc.ErrorMatches, `instance "legacy-0" has no usable address, specify one with --host`)
+	c.Assert(s.provisionArgs, gc.HasLen, 0)
+}
+
+func (s *ImportInstanceSuite) TestImportInstanceAlreadyManaged(c *gc.C) {
+	s.api.SetErrors(&params.Error{
+		Message: `instance "legacy-0" (machine 0) already exists`,
+		Code:    params.CodeAlreadyExists,
+	})
+	_, err := s.run(c, "legacy-0")
+	c.Assert(err, gc.ErrorMatches, `cannot import instance "legacy-0": instance "legacy-0" \(machine 0\) already exists`)
+	c.Assert(s.provisionArgs, gc.HasLen, 0)
+}
+
+func (s *ImportInstanceSuite) TestImportInstanceProvisioningError(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		return "", errors.New("failed to initialize warp core")
+	})
+	_, err := s.run(c, "legacy-0")
+	c.Assert(err, gc.ErrorMatches, "failed to initialize warp core")
+	s.api.CheckCallNames(c, "CloudInstance", "Close")
+}
+
+func (s *ImportInstanceSuite) TestImportInstanceTagError(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("tagging instances with this provider not supported"))
+	ctx, err := s.run(c, "legacy-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, ""+
+		"imported instance legacy-0 as machine 7\n"+
+		".*instance legacy-0 not tagged: tagging instances with this provider not supported\n",
+	)
+}
+
+type fakeImportInstanceAPI struct {
+	jujutesting.Stub
+	result params.CloudInstanceResult
+}
+
+func (f *fakeImportInstanceAPI) CloudInstance(instanceId string) (params.CloudInstanceResult, error) {
+	f.MethodCall(f, "CloudInstance", instanceId)
+	if err := f.NextErr(); err != nil {
+		return params.CloudInstanceResult{}, err
+	}
+	return f.result, nil
+}
+
+func (f *fakeImportInstanceAPI) TagMachineInstance(machineId string) error {
+	f.MethodCall(f, "TagMachineInstance", machineId)
+	return f.NextErr()
+}
+
+func (f *fakeImportInstanceAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -5,7 +5,11 @@ package machine
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -17,8 +21,15 @@ By default, the tabular format is used.
 The following sections are included: ID, STATE, DNS, INS-ID, SERIES, AZ
 Note: AZ above is the cloud region's availability zone.
 
+The --pending-removal option lists the dying and dead machines instead,
+along with what the removal of each is waiting on: units yet to run
+their stop hooks, storage yet to be detached, containers yet to be
+removed, and the deletion of the cloud instance, with the last error
+the cloud reported deleting it.
+
 Examples:
      juju machines
+     juju machines --pending-removal
 
 See also: 
     status`
@@ -35,9 +46,19 @@ func newListMachinesCommand(api statusAPI) *listMachinesCommand {
 	return listCmd
 }
 
+// pendingRemovalAPI defines the API methods for listing the machines
+// pending removal.
+type pendingRemovalAPI interface {
+	PendingRemovals() ([]params.PendingRemoval, error)
+	Close() error
+}
+
 // listMachineCommand holds infomation about machines in a model.
 type listMachinesCommand struct {
 	baselistMachinesCommand
+
+	pendingRemoval    bool
+	pendingRemovalAPI pendingRemovalAPI
 }
 
 // Info implements Command.Info.
@@ -50,7 +71,53 @@ func (c *listMachinesCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *listMachinesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.pendingRemoval, "pending-removal", false, "List the machines pending removal, and what they are waiting on")
+	c.out.AddFlags(f, c.defaultFormat, map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
 // Init ensures the machines Command does not take arguments.
 func (c *listMachinesCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
+
+// Run implements Command.Run.
+func (c *listMachinesCommand) Run(ctx *cmd.Context) error {
+	if !c.pendingRemoval {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+	client, err := c.getPendingRemovalAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	removals, err := client.PendingRemovals()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatted, err := formatPendingRemovals(removals)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+func (c *listMachinesCommand) getPendingRemovalAPI() (pendingRemovalAPI, error) {
+	if c.pendingRemovalAPI != nil {
+		return c.pendingRemovalAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
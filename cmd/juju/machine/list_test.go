@@ -200,3 +200,74 @@ func (s *MachineListCommandSuite) TestListMachineArgsError(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, newMachineListCommand(), "0")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["0"\]`)
 }
+
+type fakePendingRemovalAPI struct {
+	removals []params.PendingRemoval
+}
+
+func (api *fakePendingRemovalAPI) PendingRemovals() ([]params.PendingRemoval, error) {
+	return api.removals, nil
+}
+
+func (*fakePendingRemovalAPI) Close() error {
+	return nil
+}
+
+func newPendingRemovalAPI() *fakePendingRemovalAPI {
+	return &fakePendingRemovalAPI{
+		removals: []params.PendingRemoval{{
+			MachineTag: "machine-1",
+			Life:       params.Dying,
+			InstanceId: "juju-badd06-1",
+			Units:      []params.Entity{{"unit-foo-0"}},
+			Containers: []params.Entity{{"machine-1-lxd-0"}},
+			Volumes:    []params.Entity{{"volume-1-0"}},
+		}, {
+			MachineTag:       "machine-0",
+			Life:             params.Dead,
+			InstanceId:       "juju-badd06-0",
+			InstanceStatus:   "destroying",
+			InstanceMessage:  "stopping instance failed: instance locked",
+			MarkedForRemoval: true,
+		}},
+	}
+}
+
+func (s *MachineListCommandSuite) TestListPendingRemoval(c *gc.C) {
+	command := machine.NewListPendingRemovalCommandForTest(newPendingRemovalAPI())
+	context, err := cmdtesting.RunCommand(c, command, "--pending-removal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  Life   Inst id        Waiting on                                                             Message\n"+
+		"0        dead   juju-badd06-0  instance deletion                                                      stopping instance failed: instance locked\n"+
+		"1        dying  juju-badd06-1  stop hooks (foo/0); containers (1/lxd/0); storage detach (volume 1/0)  \n")
+}
+
+func (s *MachineListCommandSuite) TestListPendingRemovalYaml(c *gc.C) {
+	command := machine.NewListPendingRemovalCommandForTest(newPendingRemovalAPI())
+	context, err := cmdtesting.RunCommand(c, command, "--pending-removal", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"\"0\":\n"+
+		"  life: dead\n"+
+		"  instance-id: juju-badd06-0\n"+
+		"  instance-status: destroying\n"+
+		"  message: 'stopping instance failed: instance locked'\n"+
+		"  marked-for-removal: true\n"+
+		"\"1\":\n"+
+		"  life: dying\n"+
+		"  instance-id: juju-badd06-1\n"+
+		"  units:\n"+
+		"  - foo/0\n"+
+		"  containers:\n"+
+		"  - 1/lxd/0\n"+
+		"  storage:\n"+
+		"  - volume 1/0\n")
+}
+
+func (s *MachineListCommandSuite) TestListPendingRemovalNone(c *gc.C) {
+	command := machine.NewListPendingRemovalCommandForTest(&fakePendingRemovalAPI{})
+	context, err := cmdtesting.RunCommand(c, command, "--pending-removal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "No machines pending removal.\n")
+}
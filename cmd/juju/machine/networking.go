@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NetworkDevice describes a link-layer network device of a machine,
+// for display.
+type NetworkDevice struct {
+	Type       string           `yaml:"type" json:"type"`
+	MACAddress string           `yaml:"mac-address,omitempty" json:"mac-address,omitempty"`
+	MTU        uint             `yaml:"mtu,omitempty" json:"mtu,omitempty"`
+	ProviderId string           `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Parent     string           `yaml:"parent,omitempty" json:"parent,omitempty"`
+	AutoStart  bool             `yaml:"auto-start" json:"auto-start"`
+	Up         bool             `yaml:"up" json:"up"`
+	Addresses  []NetworkAddress `yaml:"addresses,omitempty" json:"addresses,omitempty"`
+}
+
+// NetworkAddress describes an IP address assigned to a network device,
+// for display.
+type NetworkAddress struct {
+	Value        string `yaml:"value" json:"value"`
+	CIDR         string `yaml:"cidr,omitempty" json:"cidr,omitempty"`
+	ConfigMethod string `yaml:"config-method" json:"config-method"`
+	ProviderId   string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Gateway      string `yaml:"gateway,omitempty" json:"gateway,omitempty"`
+}
+
+// formatLinkLayerDevices returns the network devices of each machine
+// without an error result, keyed by machine ID and device name.
+func formatLinkLayerDevices(machineIds []string, results []params.MachineLinkLayerDevicesResult) map[string]map[string]NetworkDevice {
+	out := make(map[string]map[string]NetworkDevice)
+	for i, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		devices := make(map[string]NetworkDevice)
+		for _, d := range result.Devices {
+			device := NetworkDevice{
+				Type:       d.Type,
+				MACAddress: d.MACAddress,
+				MTU:        d.MTU,
+				ProviderId: d.ProviderId,
+				Parent:     d.ParentName,
+				AutoStart:  d.IsAutoStart,
+				Up:         d.IsUp,
+			}
+			for _, a := range d.Addresses {
+				device.Addresses = append(device.Addresses, NetworkAddress{
+					Value:        a.Value,
+					CIDR:         a.CIDR,
+					ConfigMethod: a.ConfigMethod,
+					ProviderId:   a.ProviderId,
+					Gateway:      a.GatewayAddress,
+				})
+			}
+			devices[d.Name] = device
+		}
+		out[machineIds[i]] = devices
+	}
+	return out
+}
+
+// formatTabular writes the machines, or the network devices of the
+// machines, in tabular format.
+func (c *showMachineCommand) formatTabular(writer io.Writer, value interface{}) error {
+	if !c.networking {
+		return c.tabular(writer, value)
+	}
+	machines, ok := value.(map[string]map[string]NetworkDevice)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", machines, value)
+	}
+	ids := make([]string, 0, len(machines))
+	for id := range machines {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Machine", "Device", "Type", "MAC address", "Parent", "Up", "Addresses")
+	for _, id := range ids {
+		devices := machines[id]
+		names := make([]string, 0, len(devices))
+		for name := range devices {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			d := devices[name]
+			addresses := make([]string, len(d.Addresses))
+			for i, a := range d.Addresses {
+				addresses[i] = fmt.Sprintf("%s (%s)", a.Value, a.ConfigMethod)
+			}
+			up := "no"
+			if d.Up {
+				up = "yes"
+			}
+			print(id, name, d.Type, d.MACAddress, d.Parent, up, strings.Join(addresses, ", "))
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// PendingRemoval describes what the removal of a dying or dead machine
+// is waiting on, for display.
+type PendingRemoval struct {
+	Life             string   `yaml:"life" json:"life"`
+	InstanceId       string   `yaml:"instance-id,omitempty" json:"instance-id,omitempty"`
+	InstanceStatus   string   `yaml:"instance-status,omitempty" json:"instance-status,omitempty"`
+	Message          string   `yaml:"message,omitempty" json:"message,omitempty"`
+	Units            []string `yaml:"units,omitempty" json:"units,omitempty"`
+	Containers       []string `yaml:"containers,omitempty" json:"containers,omitempty"`
+	Storage          []string `yaml:"storage,omitempty" json:"storage,omitempty"`
+	MarkedForRemoval bool     `yaml:"marked-for-removal,omitempty" json:"marked-for-removal,omitempty"`
+}
+
+// formatPendingRemovals returns the given pending removals, keyed by
+// machine ID.
+func formatPendingRemovals(removals []params.PendingRemoval) (map[string]PendingRemoval, error) {
+	out := make(map[string]PendingRemoval)
+	for _, r := range removals {
+		machineTag, err := names.ParseMachineTag(r.MachineTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		removal := PendingRemoval{
+			Life:             string(r.Life),
+			InstanceId:       r.InstanceId,
+			InstanceStatus:   r.InstanceStatus,
+			Message:          r.InstanceMessage,
+			MarkedForRemoval: r.MarkedForRemoval,
+		}
+		for _, e := range r.Units {
+			id, err := entityId(e)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			removal.Units = append(removal.Units, id)
+		}
+		for _, e := range r.Containers {
+			id, err := entityId(e)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			removal.Containers = append(removal.Containers, id)
+		}
+		for _, e := range append(r.Volumes, r.Filesystems...) {
+			tag, err := names.ParseTag(e.Tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			removal.Storage = append(removal.Storage, tag.Kind()+" "+tag.Id())
+		}
+		out[machineTag.Id()] = removal
+	}
+	return out, nil
+}
+
+func entityId(e params.Entity) (string, error) {
+	tag, err := names.ParseTag(e.Tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tag.Id(), nil
+}
+
+// waitingOn returns a summary of what the machine's removal is
+// waiting on.
+func (r PendingRemoval) waitingOn() string {
+	var waiting []string
+	if len(r.Units) > 0 {
+		waiting = append(waiting, fmt.Sprintf("stop hooks (%s)", strings.Join(r.Units, ", ")))
+	}
+	if len(r.Containers) > 0 {
+		waiting = append(waiting, fmt.Sprintf("containers (%s)", strings.Join(r.Containers, ", ")))
+	}
+	if len(r.Storage) > 0 {
+		waiting = append(waiting, fmt.Sprintf("storage detach (%s)", strings.Join(r.Storage, ", ")))
+	}
+	if r.MarkedForRemoval || (r.Life == string(params.Dead) && r.InstanceId != "") {
+		waiting = append(waiting, "instance deletion")
+	}
+	return strings.Join(waiting, "; ")
+}
+
+// formatTabular writes the machines, or the machines pending removal,
+// in tabular format.
+func (c *listMachinesCommand) formatTabular(writer io.Writer, value interface{}) error {
+	if !c.pendingRemoval {
+		return c.tabular(writer, value)
+	}
+	removals, ok := value.(map[string]PendingRemoval)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", removals, value)
+	}
+	if len(removals) == 0 {
+		fmt.Fprintln(writer, "No machines pending removal.")
+		return nil
+	}
+	ids := make([]string, 0, len(removals))
+	for id := range removals {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Machine", "Life", "Inst id", "Waiting on", "Message")
+	for _, id := range ids {
+		r := removals[id]
+		print(id, r.Life, r.InstanceId, r.waitingOn(), r.Message)
+	}
+	return tw.Flush()
+}
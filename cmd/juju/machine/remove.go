@@ -38,6 +38,11 @@ Machines responsible for the model cannot be removed.
 Machines running units or containers can be removed using the '--force'
 option; this will also remove those units and containers without giving
 them an opportunity to shut down cleanly.
+Where the cloud supports it, the resources associated with a machine's
+instance are released in order when it is stopped: volumes are detached,
+public (floating) addresses are released, the instance is stopped, and
+its security groups are deleted. The machine's instance status in the
+output of ` + "`juju status`" + ` reports the phase reached, and any failure.
 
 Examples:
 
@@ -4,8 +4,15 @@
 package machine
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/api/machinenetworking"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -21,6 +28,24 @@ Examples:
     # Display status for machines 1, 2 & 3
     juju show-machine 1 2 3
 
+    # Display the last 50 lines of the console output of machine 0's
+    # instance, as reported by the cloud
+    juju show-machine 0 --console-log --lines 50
+
+The --console-log option displays the console output of the machine's
+instance as reported by the cloud, which includes the output of
+cloud-init. This is useful for diagnosing machines that fail to start.
+Not all clouds support retrieving console output.
+
+The --networking option displays the network devices of the machines,
+such as interfaces, bridges, bonds and VLANs, with their parent devices
+and the IP addresses assigned to them, as discovered from the cloud and
+observed by the machine agents.
+
+Examples:
+    # Display the network devices of machines 0 and 1
+    juju show-machine 0 1 --networking
+
 `
 
 // NewShowMachineCommand returns a command that shows details on the specified machine[s].
@@ -35,9 +60,30 @@ func newShowMachineCommand(api statusAPI) *showMachineCommand {
 	return showCmd
 }
 
+// consoleLogAPI defines the API methods for retrieving the console
+// output of a machine's instance.
+type consoleLogAPI interface {
+	ConsoleLog(machineId string, maxLines int) (string, error)
+	Close() error
+}
+
+// networkingAPI defines the API methods for retrieving the network
+// devices of machines.
+type networkingAPI interface {
+	LinkLayerDevices(machineIds ...string) ([]params.MachineLinkLayerDevicesResult, error)
+	Close() error
+}
+
 // showMachineCommand struct holds details on the specified machine[s].
 type showMachineCommand struct {
 	baselistMachinesCommand
+
+	consoleLog    bool
+	lines         int
+	consoleLogAPI consoleLogAPI
+
+	networking    bool
+	networkingAPI networkingAPI
 }
 
 // Info implements Command.Info.
@@ -50,8 +96,114 @@ func (c *showMachineCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *showMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.consoleLog, "console-log", false, "Show the console output of the machine's instance")
+	f.IntVar(&c.lines, "lines", 0, "Maximum number of console output lines to show (default all)")
+	f.BoolVar(&c.networking, "networking", false, "Show the network devices of the machines")
+	c.out.AddFlags(f, c.defaultFormat, map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
 // Init captures machineId's to show from CL args.
 func (c *showMachineCommand) Init(args []string) error {
 	c.machineIds = args
+	if c.lines < 0 {
+		return errors.New("--lines must be a positive number")
+	}
+	if c.networking {
+		if c.consoleLog {
+			return errors.New("--networking cannot be used with --console-log")
+		}
+		if len(args) == 0 {
+			return errors.New("--networking requires at least one machine ID")
+		}
+	}
+	if !c.consoleLog {
+		if c.lines != 0 {
+			return errors.New("--lines can only be used with --console-log")
+		}
+		return nil
+	}
+	if len(args) != 1 {
+		return errors.New("--console-log requires a single machine ID")
+	}
 	return nil
 }
+
+// Run implements Command.Run.
+func (c *showMachineCommand) Run(ctx *cmd.Context) error {
+	if c.networking {
+		return c.showNetworking(ctx)
+	}
+	if !c.consoleLog {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+	client, err := c.getConsoleLogAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	output, err := client.ConsoleLog(c.machineIds[0], c.lines)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprint(ctx.Stdout, output)
+	return nil
+}
+
+func (c *showMachineCommand) getConsoleLogAPI() (consoleLogAPI, error) {
+	if c.consoleLogAPI != nil {
+		return c.consoleLogAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+func (c *showMachineCommand) showNetworking(ctx *cmd.Context) error {
+	client, err := c.getNetworkingAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.LinkLayerDevices(c.machineIds...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "machine %s: %v\n", c.machineIds[i], result.Error)
+			failed = true
+		}
+	}
+	if err := c.out.Write(ctx, formatLinkLayerDevices(c.machineIds, results)); err != nil {
+		return errors.Trace(err)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *showMachineCommand) getNetworkingAPI() (networkingAPI, error) {
+	if c.networkingAPI != nil {
+		return c.networkingAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinenetworking.NewClient(root), nil
+}
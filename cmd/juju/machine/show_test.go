@@ -6,9 +6,12 @@ package machine_test
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
@@ -131,3 +134,159 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
 		"{\"model\":\"dummyenv\",\"machines\":{\"0\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.1\",\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"instance-id\":\"juju-badd06-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"constraints\":\"mem=3584M\",\"hardware\":\"availability-zone=us-east-1\"},\"1\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.2\",\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"instance-id\":\"juju-badd06-1\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"containers\":{\"1/lxd/0\":{\"juju-status\":{\"current\":\"pending\"},\"dns-name\":\"10.0.0.3\",\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"instance-id\":\"juju-badd06-1-lxd-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}}}}}}}\n")
 }
+
+func (s *MachineShowCommandSuite) TestShowConsoleLog(c *gc.C) {
+	api := &fakeConsoleLogAPI{output: "cloud-init finished\n"}
+	context, err := cmdtesting.RunCommand(c, machine.NewShowConsoleLogCommandForTest(api), "0", "--console-log", "--lines", "50")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "cloud-init finished\n")
+	api.CheckCalls(c, []jujutesting.StubCall{
+		{"ConsoleLog", []interface{}{"0", 50}},
+		{"Close", nil},
+	})
+}
+
+func (s *MachineShowCommandSuite) TestShowConsoleLogError(c *gc.C) {
+	api := &fakeConsoleLogAPI{}
+	api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, machine.NewShowConsoleLogCommandForTest(api), "0", "--console-log")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachineShowCommandSuite) TestShowConsoleLogInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"0", "--lines", "10"},
+		err:  "--lines can only be used with --console-log",
+	}, {
+		args: []string{"0", "--console-log", "--lines=-1"},
+		err:  "--lines must be a positive number",
+	}, {
+		args: []string{"--console-log"},
+		err:  "--console-log requires a single machine ID",
+	}, {
+		args: []string{"0", "1", "--console-log"},
+		err:  "--console-log requires a single machine ID",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewShowConsoleLogCommandForTest(&fakeConsoleLogAPI{}), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+var testLinkLayerDevices = []params.MachineLinkLayerDevicesResult{{
+	Devices: []params.LinkLayerDeviceInfo{{
+		Name:        "br-eth0",
+		Type:        "bridge",
+		MACAddress:  "aa:bb:cc:dd:ee:f0",
+		IsAutoStart: true,
+		IsUp:        true,
+		Addresses: []params.LinkLayerDeviceAddressInfo{{
+			Value:        "10.0.0.4",
+			CIDR:         "10.0.0.0/24",
+			ConfigMethod: "static",
+		}},
+	}, {
+		Name:        "eth0",
+		Type:        "ethernet",
+		MACAddress:  "aa:bb:cc:dd:ee:f0",
+		MTU:         1500,
+		ProviderId:  "nic-0",
+		ParentName:  "br-eth0",
+		IsAutoStart: true,
+		IsUp:        true,
+	}},
+}, {
+	Error: &params.Error{Message: "machine 1 not found"},
+}}
+
+func (s *MachineShowCommandSuite) TestShowNetworking(c *gc.C) {
+	api := &fakeNetworkingAPI{results: testLinkLayerDevices}
+	context, err := cmdtesting.RunCommand(c, machine.NewShowNetworkingCommandForTest(api), "0", "1", "--networking")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "machine 1: machine 1 not found\n")
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+"0":
+  br-eth0:
+    type: bridge
+    mac-address: aa:bb:cc:dd:ee:f0
+    auto-start: true
+    up: true
+    addresses:
+    - value: 10.0.0.4
+      cidr: 10.0.0.0/24
+      config-method: static
+  eth0:
+    type: ethernet
+    mac-address: aa:bb:cc:dd:ee:f0
+    mtu: 1500
+    provider-id: nic-0
+    parent: br-eth0
+    auto-start: true
+    up: true
+`[1:])
+	api.CheckCalls(c, []jujutesting.StubCall{
+		{"LinkLayerDevices", []interface{}{[]string{"0", "1"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *MachineShowCommandSuite) TestShowNetworkingTabular(c *gc.C) {
+	api := &fakeNetworkingAPI{results: testLinkLayerDevices[:1]}
+	context, err := cmdtesting.RunCommand(c, machine.NewShowNetworkingCommandForTest(api), "0", "--networking", "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Machine  Device   Type      MAC address        Parent   Up   Addresses
+0        br-eth0  bridge    aa:bb:cc:dd:ee:f0           yes  10.0.0.4 (static)
+0        eth0     ethernet  aa:bb:cc:dd:ee:f0  br-eth0  yes  
+`[1:])
+}
+
+func (s *MachineShowCommandSuite) TestShowNetworkingInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--networking"},
+		err:  "--networking requires at least one machine ID",
+	}, {
+		args: []string{"0", "--networking", "--console-log"},
+		err:  "--networking cannot be used with --console-log",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewShowNetworkingCommandForTest(&fakeNetworkingAPI{}), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type fakeNetworkingAPI struct {
+	jujutesting.Stub
+	results []params.MachineLinkLayerDevicesResult
+}
+
+func (f *fakeNetworkingAPI) LinkLayerDevices(machineIds ...string) ([]params.MachineLinkLayerDevicesResult, error) {
+	f.MethodCall(f, "LinkLayerDevices", machineIds)
+	return f.results, f.NextErr()
+}
+
+func (f *fakeNetworkingAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+type fakeConsoleLogAPI struct {
+	jujutesting.Stub
+	output string
+}
+
+func (f *fakeConsoleLogAPI) ConsoleLog(machineId string, maxLines int) (string, error) {
+	f.MethodCall(f, "ConsoleLog", machineId, maxLines)
+	return f.output, f.NextErr()
+}
+
+func (f *fakeConsoleLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -8,7 +8,9 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -16,6 +18,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -32,6 +35,14 @@ are displayed.
 Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys. You can also specify a yaml file containing key values.
+
+Every change to the model's configuration is recorded as a new revision,
+along with the user that made it and when. The recorded changes are listed
+with --history. The differences between the configuration at a revision
+and the current configuration are displayed with --diff, and the
+configuration is restored to how it was at a revision with --rollback.
+Rolling back is itself recorded as a new revision. Revision 0 is the
+configuration before any recorded change.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -45,6 +56,9 @@ Examples:
     juju model-config path/to/file.yaml
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --history
+    juju model-config --diff 3
+    juju model-config --rollback 3
 
 See also:
     models
@@ -73,6 +87,11 @@ type configCommand struct {
 	reset      []string // Holds the keys to be reset until parsed.
 	resetKeys  []string // Holds the keys to be reset once parsed.
 	setOptions common.ConfigFlag
+
+	history  bool
+	diff     string // Holds the revision to diff against until parsed.
+	rollback string // Holds the revision to roll back to until parsed.
+	revision int
 }
 
 // configCommandAPI defines an API interface to be used during testing.
@@ -82,6 +101,9 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ModelConfigHistory() ([]params.ModelConfigRevision, error)
+	ModelConfigDiff(revision int) ([]params.ModelConfigChange, error)
+	ModelConfigRollback(revision int) error
 }
 
 // Info implements part of the cmd.Command interface.
@@ -117,6 +139,9 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.history, "history", false, "Display the recorded changes to the model configuration")
+	f.StringVar(&c.diff, "diff", "", "Display the differences between the configuration at the given revision and the current configuration")
+	f.StringVar(&c.rollback, "rollback", "", "Restore the configuration to how it was at the given revision")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -128,6 +153,9 @@ func (c *configCommand) Init(args []string) error {
 	if err := c.parseResetKeys(); err != nil {
 		return errors.Trace(err)
 	}
+	if c.history || c.diff != "" || c.rollback != "" {
+		return c.handleRevisionArgs(args)
+	}
 
 	switch len(args) {
 	case 0:
@@ -139,6 +167,43 @@ func (c *configCommand) Init(args []string) error {
 	}
 }
 
+// handleRevisionArgs handles the --history, --diff and --rollback
+// flags, which cannot be combined with each other or with any other
+// arguments.
+func (c *configCommand) handleRevisionArgs(args []string) error {
+	var options []string
+	if c.history {
+		options = append(options, "--history")
+		c.action = c.getHistory
+	}
+	if c.diff != "" {
+		options = append(options, "--diff")
+		c.action = c.getDiff
+	}
+	if c.rollback != "" {
+		options = append(options, "--rollback")
+		c.action = c.rollbackConfig
+	}
+	if len(options) > 1 {
+		return errors.Errorf("cannot specify both %s and %s", options[0], options[1])
+	}
+	if len(args) > 0 || len(c.resetKeys) > 0 {
+		return errors.Errorf("cannot get or set model values with %s", options[0])
+	}
+	revision := c.diff
+	if c.rollback != "" {
+		revision = c.rollback
+	}
+	if revision != "" {
+		rev, err := strconv.Atoi(revision)
+		if err != nil || rev < 0 {
+			return errors.Errorf("invalid revision %q", revision)
+		}
+		c.revision = rev
+	}
+	return nil
+}
+
 // handleZeroArgs handles the case where there are no positional args.
 func (c *configCommand) handleZeroArgs() error {
 	// If reset is empty we're getting configuration
@@ -339,6 +404,78 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return c.out.Write(ctx, attrs)
 }
 
+// configRevision holds a recorded change to the model config, for
+// display.
+type configRevision struct {
+	Revision int            `yaml:"revision" json:"revision"`
+	User     string         `yaml:"user,omitempty" json:"user,omitempty"`
+	Time     time.Time      `yaml:"time" json:"time"`
+	Changes  []configChange `yaml:"changes" json:"changes"`
+}
+
+// configChange holds a change to a model config setting, for display.
+type configChange struct {
+	Type     string      `yaml:"type" json:"type"`
+	Key      string      `yaml:"key" json:"key"`
+	OldValue interface{} `yaml:"old-value,omitempty" json:"old-value,omitempty"`
+	NewValue interface{} `yaml:"new-value,omitempty" json:"new-value,omitempty"`
+}
+
+func convertConfigChanges(changes []params.ModelConfigChange) []configChange {
+	result := make([]configChange, len(changes))
+	for i, change := range changes {
+		result[i] = configChange{
+			Type:     change.Type,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		}
+	}
+	return result
+}
+
+// getHistory writes the recorded changes to the model config to the
+// cmd.Context.
+func (c *configCommand) getHistory(client configCommandAPI, ctx *cmd.Context) error {
+	history, err := client.ModelConfigHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	revisions := make([]configRevision, len(history))
+	for i, rev := range history {
+		revisions[i] = configRevision{
+			Revision: rev.Revision,
+			User:     rev.User,
+			Time:     rev.Time,
+			Changes:  convertConfigChanges(rev.Changes),
+		}
+	}
+	if len(revisions) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model config changes recorded.")
+		return nil
+	}
+	return c.out.Write(ctx, revisions)
+}
+
+// getDiff writes the differences between the model config at a revision
+// and the current model config to the cmd.Context.
+func (c *configCommand) getDiff(client configCommandAPI, ctx *cmd.Context) error {
+	changes, err := client.ModelConfigDiff(c.revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(changes) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No changes since revision %d.", c.revision)
+		return nil
+	}
+	return c.out.Write(ctx, convertConfigChanges(changes))
+}
+
+// rollbackConfig restores the model config to how it was at a revision.
+func (c *configCommand) rollbackConfig(client configCommandAPI, ctx *cmd.Context) error {
+	return block.ProcessBlockedError(client.ModelConfigRollback(c.revision), block.BlockChange)
+}
+
 // verifyKnownKeys is a helper to validate the keys we are operating with
 // against the set of known attributes from the model.
 func (c *configCommand) verifyKnownKeys(client configCommandAPI, keys []string) error {
@@ -371,6 +508,12 @@ func (c *configCommand) isModelAttribute(attr string) bool {
 
 // formatConfigTabular writes a tabular summary of config information.
 func formatConfigTabular(writer io.Writer, value interface{}) error {
+	switch value := value.(type) {
+	case []configRevision:
+		return formatHistoryTabular(writer, value)
+	case []configChange:
+		return formatChangesTabular(writer, value)
+	}
 	configValues, ok := value.(config.ConfigValues)
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", configValues, value)
@@ -388,14 +531,10 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 
 	for _, name := range valueNames {
 		info := configValues[name]
-		out := &bytes.Buffer{}
-		err := cmd.FormatYaml(out, info.Value)
+		valString, err := formatConfigValue(info.Value)
 		if err != nil {
 			return errors.Annotatef(err, "formatting value for %q", name)
 		}
-		// Some attribute values have a newline appended
-		// which makes the output messy.
-		valString := strings.TrimSuffix(out.String(), "\n")
 		w.Println(name, info.Source, valString)
 	}
 
@@ -403,6 +542,74 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 	return nil
 }
 
+// formatHistoryTabular writes a tabular summary of the recorded changes
+// to the model config.
+func formatHistoryTabular(writer io.Writer, revisions []configRevision) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Revision", "Time", "User", "Attribute", "Old", "New")
+	for _, rev := range revisions {
+		revision := strconv.Itoa(rev.Revision)
+		when := common.FormatTime(&rev.Time, true)
+		user := rev.User
+		if user == "" {
+			user = "-"
+		}
+		for _, change := range rev.Changes {
+			oldValue, newValue, err := formatConfigChange(change)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			w.Println(revision, when, user, change.Key, oldValue, newValue)
+			// Only the first change of each revision is labelled.
+			revision, when, user = "", "", ""
+		}
+	}
+	tw.Flush()
+	return nil
+}
+
+// formatChangesTabular writes a tabular summary of changes to the model
+// config.
+func formatChangesTabular(writer io.Writer, changes []configChange) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Attribute", "Old", "New")
+	for _, change := range changes {
+		oldValue, newValue, err := formatConfigChange(change)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.Println(change.Key, oldValue, newValue)
+	}
+	tw.Flush()
+	return nil
+}
+
+func formatConfigChange(change configChange) (oldValue, newValue string, err error) {
+	if change.Type != "added" {
+		if oldValue, err = formatConfigValue(change.OldValue); err != nil {
+			return "", "", errors.Annotatef(err, "formatting value for %q", change.Key)
+		}
+	}
+	if change.Type != "deleted" {
+		if newValue, err = formatConfigValue(change.NewValue); err != nil {
+			return "", "", errors.Annotatef(err, "formatting value for %q", change.Key)
+		}
+	}
+	return oldValue, newValue, nil
+}
+
+func formatConfigValue(value interface{}) (string, error) {
+	out := &bytes.Buffer{}
+	if err := cmd.FormatYaml(out, value); err != nil {
+		return "", errors.Trace(err)
+	}
+	// Some attribute values have a newline appended
+	// which makes the output messy.
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
 // modelConfigDetails gets ModelDetails when a model is not available
 // to use.
 func (c *configCommand) modelConfigDetails() (map[string]interface{}, error) {
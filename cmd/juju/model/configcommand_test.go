@@ -5,6 +5,7 @@ package model_test
 import (
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -12,6 +13,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/testing"
 )
@@ -69,6 +71,35 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:   "test reset interspersed",
 			args:   []string{"--reset", "one", "special=foo", "--reset", "two"},
 			nilErr: true,
+		}, {
+			// Test history
+			desc:   "history succeeds",
+			args:   []string{"--history"},
+			nilErr: true,
+		}, {
+			desc:   "diff succeeds",
+			args:   []string{"--diff", "0"},
+			nilErr: true,
+		}, {
+			desc:       "diff requires a revision",
+			args:       []string{"--diff", "foo"},
+			errorMatch: `invalid revision "foo"`,
+		}, {
+			desc:       "rollback requires a valid revision",
+			args:       []string{"--rollback", "-1"},
+			errorMatch: `invalid revision "-1"`,
+		}, {
+			desc:       "history and rollback are exclusive",
+			args:       []string{"--history", "--rollback", "1"},
+			errorMatch: "cannot specify both --history and --rollback",
+		}, {
+			desc:       "history cannot get values",
+			args:       []string{"--history", "special"},
+			errorMatch: "cannot get or set model values with --history",
+		}, {
+			desc:       "rollback cannot reset values",
+			args:       []string{"--rollback", "1", "--reset", "special"},
+			errorMatch: "cannot get or set model values with --rollback",
 		},
 	} {
 		c.Logf("test %d: %s", i, test.desc)
@@ -254,3 +285,94 @@ func (s *ConfigCommandSuite) TestResetBlockedError(c *gc.C) {
 	_, err := s.run(c, "--reset", "special")
 	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
 }
+
+func (s *ConfigCommandSuite) setHistory() {
+	s.fake.history = []params.ModelConfigRevision{{
+		Revision: 1,
+		User:     "bob",
+		Time:     time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+		Changes: []params.ModelConfigChange{
+			{Type: "added", Key: "special", NewValue: "special value"},
+			{Type: "modified", Key: "running", OldValue: false, NewValue: true},
+		},
+	}, {
+		Revision: 2,
+		Time:     time.Date(2017, 6, 2, 12, 0, 0, 0, time.UTC),
+		Changes: []params.ModelConfigChange{
+			{Type: "deleted", Key: "other", OldValue: "foo"},
+		},
+	}}
+}
+
+func (s *ConfigCommandSuite) TestHistoryTabular(c *gc.C) {
+	s.setHistory()
+	context, err := s.run(c, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"Revision  Time                  User  Attribute  Old    New\n" +
+		"1         2017-06-01 12:00:00Z  bob   special           special value\n" +
+		"                                      running    false  true\n" +
+		"2         2017-06-02 12:00:00Z  -     other      foo    \n" +
+		"\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestHistoryYAML(c *gc.C) {
+	s.setHistory()
+	s.fake.history = s.fake.history[1:]
+	context, err := s.run(c, "--history", "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"- revision: 2\n" +
+		"  time: 2017-06-02T12:00:00Z\n" +
+		"  changes:\n" +
+		"  - type: deleted\n" +
+		"    key: other\n" +
+		"    old-value: foo\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestHistoryEmpty(c *gc.C) {
+	context, err := s.run(c, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "No model config changes recorded.\n")
+}
+
+func (s *ConfigCommandSuite) TestDiff(c *gc.C) {
+	s.fake.changes = []params.ModelConfigChange{
+		{Type: "modified", Key: "running", OldValue: false, NewValue: true},
+	}
+	context, err := s.run(c, "--diff", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.revision, gc.Equals, 3)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"Attribute  Old    New\n" +
+		"running    false  true\n" +
+		"\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestDiffNoChanges(c *gc.C) {
+	context, err := s.run(c, "--diff", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "No changes since revision 3.\n")
+}
+
+func (s *ConfigCommandSuite) TestRollback(c *gc.C) {
+	_, err := s.run(c, "--rollback", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.revision, gc.Equals, 2)
+}
+
+func (s *ConfigCommandSuite) TestRollbackBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "--rollback", "2")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
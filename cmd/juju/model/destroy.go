@@ -54,6 +54,7 @@ type destroyCommand struct {
 	assumeYes      bool
 	destroyStorage bool
 	releaseStorage bool
+	force          bool
 	api            DestroyModelAPI
 	configAPI      ModelConfigAPI
 	storageAPI     StorageAPI
@@ -70,12 +71,18 @@ If there is persistent storage in any of the models managed by the
 controller, then you must choose to either destroy or release the
 storage, using --destroy-storage or --release-storage respectively.
 
+If the model's cloud resources cannot all be removed, for example
+because a security group is still attached to a port that the model
+does not own, the command reports what could not be removed. Run it
+again with --force to remove such resources even if they are in use.
+
 Examples:
 
     juju destroy-model test
     juju destroy-model -y mymodel
     juju destroy-model -y mymodel --destroy-storage
     juju destroy-model -y mymodel --release-storage
+    juju destroy-model -y mymodel --force
 
 See also:
     destroy-controller
@@ -91,7 +98,7 @@ Continue [y/N]? `[1:]
 type DestroyModelAPI interface {
 	Close() error
 	BestAPIVersion() int
-	DestroyModel(tag names.ModelTag, destroyStorage *bool) error
+	DestroyModel(tag names.ModelTag, destroyStorage *bool, force bool) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
@@ -119,6 +126,7 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.assumeYes, "yes", false, "")
 	f.BoolVar(&c.destroyStorage, "destroy-storage", false, "Destroy all storage instances in the model")
 	f.BoolVar(&c.releaseStorage, "release-storage", false, "Release all storage instances from the model, and management of the controller, without destroying them")
+	f.BoolVar(&c.force, "force", false, "Remove the model's cloud resources even if they are still in use")
 }
 
 // Init implements Command.Init.
@@ -264,7 +272,7 @@ upgrade the controller to version 2.3 or greater.
 		destroyStorage = &c.destroyStorage
 	}
 	modelTag := names.NewModelTag(modelDetails.ModelUUID)
-	if err := api.DestroyModel(modelTag, destroyStorage); err != nil {
+	if err := api.DestroyModel(modelTag, destroyStorage, c.force); err != nil {
 		return c.handleError(
 			modelTag, modelName, api,
 			errors.Annotate(err, "cannot destroy model"),
@@ -275,7 +283,16 @@ upgrade the controller to version 2.3 or greater.
 	const modelStatusPollWait = 2 * time.Second
 	modelStatus := newTimedModelStatus(ctx, api, names.NewModelTag(modelDetails.ModelUUID), c.sleepFunc)
 	modelData := modelStatus(0)
+	// When forcing the destruction of a model that could not be
+	// destroyed before, the error recorded then is ignored until
+	// the controller tries again.
+	staleError := c.force
 	for modelData != nil {
+		if modelData.destroyError == "" {
+			staleError = false
+		} else if !staleError {
+			return c.destroyError(modelName, modelData.destroyError)
+		}
 		ctx.Infof(formatDestroyModelInfo(modelData) + "...")
 		modelData = modelStatus(modelStatusPollWait)
 	}
@@ -314,11 +331,26 @@ func (c *destroyCommand) removeModelBudget(uuid string) error {
 	return nil
 }
 
+// destroyError returns the error reported when the model's cloud
+// resources could not be removed.
+func (c *destroyCommand) destroyError(modelName, message string) error {
+	if c.force {
+		return errors.Errorf("cannot destroy model %q: %s", modelName, message)
+	}
+	return errors.Errorf(`cannot destroy model %q: %s
+
+To remove the resources even if they are still in use,
+run the destroy-model command again with the "--force"
+flag.
+`, modelName, message)
+}
+
 type modelData struct {
 	machineCount     int
 	applicationCount int
 	volumeCount      int
 	filesystemCount  int
+	destroyError     string
 }
 
 // newTimedModelStatus returns a function which waits a given period of time
@@ -342,6 +374,7 @@ func newTimedModelStatus(ctx *cmd.Context, api DestroyModelAPI, tag names.ModelT
 			applicationCount: status[0].ServiceCount,
 			volumeCount:      len(status[0].Volumes),
 			filesystemCount:  len(status[0].Filesystems),
+			destroyError:     status[0].DestroyError,
 		}
 	}
 }
@@ -48,6 +48,7 @@ type fakeAPI struct {
 	statusCallCount int
 	bestAPIVersion  int
 	modelInfoErr    []*params.Error
+	destroyErrors   []string
 }
 
 func (f *fakeAPI) Close() error { return nil }
@@ -56,13 +57,17 @@ func (f *fakeAPI) BestAPIVersion() int {
 	return f.bestAPIVersion
 }
 
-func (f *fakeAPI) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
-	f.MethodCall(f, "DestroyModel", tag, destroyStorage)
+func (f *fakeAPI) DestroyModel(tag names.ModelTag, destroyStorage *bool, force bool) error {
+	f.MethodCall(f, "DestroyModel", tag, destroyStorage, force)
 	return f.NextErr()
 }
 
 func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error) {
 	var err error
+	var destroyError string
+	if f.statusCallCount < len(f.destroyErrors) {
+		destroyError = f.destroyErrors[f.statusCallCount]
+	}
 	if f.statusCallCount < len(f.modelInfoErr) {
 		modelInfoErr := f.modelInfoErr[f.statusCallCount]
 		if modelInfoErr != nil {
@@ -77,7 +82,8 @@ func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, err
 			{Detachable: true},
 			{Detachable: true},
 		},
-		Filesystems: []base.Filesystem{{Detachable: true}},
+		Filesystems:  []base.Filesystem{{Detachable: true}},
+		DestroyError: destroyError,
 	}}, err
 }
 
@@ -190,7 +196,7 @@ func (s *DestroySuite) TestDestroy(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
 	s.stub.CheckCalls(c, []jutesting.StubCall{
-		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil)}},
+		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil), false}},
 	})
 }
 
@@ -203,6 +209,41 @@ func (s *DestroySuite) TestDestroyBlocks(c *gc.C) {
 	c.Assert(s.api.statusCallCount, gc.Equals, 1)
 }
 
+func (s *DestroySuite) TestDestroyForce(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+	s.stub.CheckCalls(c, []jutesting.StubCall{
+		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil), true}},
+	})
+}
+
+func (s *DestroySuite) TestDestroyReportsDestroyError(c *gc.C) {
+	s.api.modelInfoErr = []*params.Error{nil, nil}
+	s.api.destroyErrors = []string{"", "2 security groups could not be removed: juju-a, juju-b"}
+	_, err := s.runDestroyCommand(c, "test2", "-y")
+	c.Assert(err, gc.ErrorMatches, `cannot destroy model "test2": 2 security groups could not be removed: juju-a, juju-b
+
+To remove the resources even if they are still in use,
+run the destroy-model command again with the "--force"
+flag.
+`)
+	checkModelExistsInStore(c, "test1:admin/test2", s.store)
+	c.Assert(s.api.statusCallCount, gc.Equals, 2)
+}
+
+func (s *DestroySuite) TestDestroyForceIgnoresStaleDestroyError(c *gc.C) {
+	s.api.modelInfoErr = []*params.Error{nil, nil, nil}
+	s.api.destroyErrors = []string{
+		"2 security groups could not be removed: juju-a, juju-b",
+		"",
+		"1 security group could not be removed: juju-a",
+	}
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--force")
+	c.Assert(err, gc.ErrorMatches, `cannot destroy model "test2": 1 security group could not be removed: juju-a`)
+	c.Assert(s.api.statusCallCount, gc.Equals, 3)
+}
+
 func (s *DestroySuite) TestFailedDestroyModel(c *gc.C) {
 	s.stub.SetErrors(errors.New("permission denied"))
 	_, err := s.runDestroyCommand(c, "test1:test2", "-y")
@@ -222,7 +263,7 @@ func (s *DestroySuite) TestDestroyWithSupportedSLA(c *gc.C) {
 	_, err := s.runDestroyCommand(c, "test2", "-y")
 	c.Assert(err, jc.ErrorIsNil)
 	s.stub.CheckCalls(c, []jutesting.StubCall{
-		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil)}},
+		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil), false}},
 		{"DeleteBudget", []interface{}{"test2-uuid"}},
 	})
 }
@@ -233,7 +274,7 @@ func (s *DestroySuite) TestDestroyWithSupportedSLAFailure(c *gc.C) {
 	_, err := s.runDestroyCommand(c, "test2", "-y")
 	c.Assert(err, jc.ErrorIsNil)
 	s.stub.CheckCalls(c, []jutesting.StubCall{
-		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil)}},
+		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil), false}},
 		{"DeleteBudget", []interface{}{"test2-uuid"}},
 	})
 }
@@ -243,7 +284,7 @@ func (s *DestroySuite) TestDestroyDestroyStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	destroyStorage := true
 	s.stub.CheckCalls(c, []jutesting.StubCall{
-		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), &destroyStorage}},
+		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), &destroyStorage, false}},
 	})
 }
 
@@ -252,7 +293,7 @@ func (s *DestroySuite) TestDestroyReleaseStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	destroyStorage := false
 	s.stub.CheckCalls(c, []jutesting.StubCall{
-		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), &destroyStorage}},
+		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), &destroyStorage, false}},
 	})
 }
 
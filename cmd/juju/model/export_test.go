@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -42,6 +43,30 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetDescriptionCommandForTest returns a set-description command
+// with the api provided as specified.
+func NewSetDescriptionCommandForTest(api SetDescriptionAPI) cmd.Command {
+	return modelcmd.Wrap(&setDescriptionCommand{api: api})
+}
+
+// NewSuspendModelCommandForTest returns a suspend-model command with
+// the api provided as specified.
+func NewSuspendModelCommandForTest(api SuspendModelAPI) cmd.Command {
+	return modelcmd.Wrap(&suspendModelCommand{suspensionCommandBase{api: api}})
+}
+
+// NewResumeModelCommandForTest returns a resume-model command with
+// the api provided as specified.
+func NewResumeModelCommandForTest(api SuspendModelAPI) cmd.Command {
+	return modelcmd.Wrap(&resumeModelCommand{suspensionCommandBase{api: api}})
+}
+
+// NewHistoryCommandForTest returns a history command with the api
+// and clock provided as specified.
+func NewHistoryCommandForTest(api HistoryAPI, clock clock.Clock) cmd.Command {
+	return modelcmd.Wrap(&historyCommand{api: api, clock: clock})
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api}
@@ -116,3 +141,15 @@ func NewRevokeCommandForTest(modelsApi RevokeModelAPI, offersAPI RevokeOfferAPI,
 }
 
 var GetBudgetAPIClient = &getBudgetAPIClient
+
+// NewExportTopologyCommandForTest returns an exportTopologyCommand with
+// the api provided as specified.
+func NewExportTopologyCommandForTest(api TopologyStatusAPI) cmd.Command {
+	return modelcmd.Wrap(&exportTopologyCommand{api: api})
+}
+
+// NewApplyTopologyCommandForTest returns an applyTopologyCommand with
+// the api provided as specified.
+func NewApplyTopologyCommandForTest(api ApplyTopologyAPI) cmd.Command {
+	return modelcmd.Wrap(&applyTopologyCommand{api: api})
+}
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
@@ -45,6 +46,9 @@ type fakeEnvAPI struct {
 	err           error
 	keys          []string
 	resetKeys     []string
+	history       []params.ModelConfigRevision
+	changes       []params.ModelConfigChange
+	revision      int
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -73,6 +77,20 @@ func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) ModelConfigHistory() ([]params.ModelConfigRevision, error) {
+	return f.history, f.err
+}
+
+func (f *fakeEnvAPI) ModelConfigDiff(revision int) ([]params.ModelConfigChange, error) {
+	f.revision = revision
+	return f.changes, f.err
+}
+
+func (f *fakeEnvAPI) ModelConfigRollback(revision int) error {
+	f.revision = revision
+	return f.err
+}
+
 // ModelDefaults related fake environment for testing.
 
 type fakeModelDefaultEnvSuite struct {
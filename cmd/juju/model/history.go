@@ -0,0 +1,247 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/changelog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const historyDoc = `
+Shows the changes applied to the model, oldest first: applications
+deployed, upgraded and removed, config and constraints changes, units
+added, applications exposed and unexposed, and relations added and
+removed. Each change is shown with the user that made it and its
+parameters.
+
+The history is an operational timeline of the model. Unlike the audit
+log, it only records changes that were applied successfully.
+
+Examples:
+    juju history
+    juju history --application mysql
+    juju history --kind upgrade-charm
+    juju history --user bob --since 24h
+    juju history --limit 10 --format yaml
+
+See also:
+    model-config
+    status
+`
+
+// historyKinds are the kinds of change accepted by --kind.
+var historyKinds = []string{
+	"deploy",
+	"upgrade-charm",
+	"config",
+	"constraints",
+	"add-units",
+	"expose",
+	"unexpose",
+	"add-relation",
+	"remove-relation",
+	"remove-application",
+}
+
+// NewHistoryCommand returns a history command instance that will use
+// the default API.
+func NewHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&historyCommand{clock: clock.WallClock})
+}
+
+// historyCommand shows the change log of a model.
+type historyCommand struct {
+	modelcmd.ModelCommandBase
+	api   HistoryAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	kind        string
+	application string
+	user        string
+	since       time.Duration
+	limit       int
+}
+
+// HistoryAPI defines the API methods that the history command uses.
+type HistoryAPI interface {
+	Close() error
+	ChangeLog(filter changelog.Filter) ([]params.ChangeLogEntry, error)
+}
+
+// historyEntry holds a change log entry, for display.
+type historyEntry struct {
+	Seq         int               `yaml:"seq" json:"seq"`
+	Time        time.Time         `yaml:"time" json:"time"`
+	User        string            `yaml:"user,omitempty" json:"user,omitempty"`
+	Kind        string            `yaml:"kind" json:"kind"`
+	Application string            `yaml:"application,omitempty" json:"application,omitempty"`
+	Relation    string            `yaml:"relation,omitempty" json:"relation,omitempty"`
+	Params      map[string]string `yaml:"params,omitempty" json:"params,omitempty"`
+}
+
+// Info implements Command.
+func (c *historyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "history",
+		Purpose: "Shows the changes applied to a model.",
+		Doc:     historyDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *historyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.kind, "kind", "", "Show only changes of the given kind")
+	f.StringVar(&c.application, "application", "", "Show only changes to the named application")
+	f.StringVar(&c.user, "user", "", "Show only changes made by the named user")
+	f.DurationVar(&c.since, "since", 0, "Show only changes made within the given duration, eg 24h")
+	f.IntVar(&c.limit, "limit", 0, "Show only the latest changes, up to the given number")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHistoryEntriesTabular,
+	})
+}
+
+// Init implements Command.
+func (c *historyCommand) Init(args []string) error {
+	if c.kind != "" && !set.NewStrings(historyKinds...).Contains(c.kind) {
+		return errors.Errorf("unknown change kind %q, expected one of: %s",
+			c.kind, strings.Join(historyKinds, ", "))
+	}
+	if c.application != "" && !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if c.since < 0 {
+		return errors.NotValidf("negative --since %v", c.since)
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative --limit %d", c.limit)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *historyCommand) getAPI() (HistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return changelog.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *historyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	filter := changelog.Filter{
+		Kind:  c.kind,
+		Actor: c.user,
+		Limit: c.limit,
+	}
+	if c.application != "" {
+		filter.Entity = names.NewApplicationTag(c.application).String()
+	}
+	if c.since > 0 {
+		filter.Since = c.clock.Now().Add(-c.since)
+	}
+	changes, err := api.ChangeLog(filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(changes) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No changes recorded.")
+		return nil
+	}
+	entries := make([]historyEntry, len(changes))
+	for i, change := range changes {
+		entries[i] = historyEntry{
+			Seq:    change.Seq,
+			Time:   change.Time,
+			User:   change.Actor,
+			Kind:   change.Kind,
+			Params: change.Params,
+		}
+		tag, err := names.ParseTag(change.Entity)
+		if err != nil {
+			continue
+		}
+		switch tag.Kind() {
+		case names.ApplicationTagKind:
+			entries[i].Application = tag.Id()
+		case names.RelationTagKind:
+			entries[i].Relation = tag.Id()
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// formatHistoryEntriesTabular writes a tabular summary of the changes
+// applied to a model.
+func formatHistoryEntriesTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]historyEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Seq", "Time", "User", "Change", "Entity", "Parameters")
+	for _, entry := range entries {
+		entity := entry.Application
+		if entity == "" {
+			entity = entry.Relation
+		}
+		w.Println(
+			strconv.Itoa(entry.Seq),
+			common.FormatTime(&entry.Time, true),
+			entry.User,
+			entry.Kind,
+			entity,
+			formatHistoryParams(entry.Params),
+		)
+	}
+	tw.Flush()
+	return nil
+}
+
+// formatHistoryParams returns the parameters of a change as a sorted
+// list of key=value pairs.
+func formatHistoryParams(changeParams map[string]string) string {
+	keys := make([]string, 0, len(changeParams))
+	for key := range changeParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + changeParams[key]
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/changelog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type historySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeHistoryAPI
+	clock *gitjujutesting.Clock
+}
+
+var _ = gc.Suite(&historySuite{})
+
+var historyTime = time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *historySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.clock = gitjujutesting.NewClock(historyTime.Add(time.Hour))
+	s.api = &fakeHistoryAPI{
+		entries: []params.ChangeLogEntry{{
+			Seq:    1,
+			Time:   historyTime,
+			Actor:  "bob",
+			Kind:   "deploy",
+			Entity: "application-mysql",
+			Params: map[string]string{"charm": "cs:mysql-42", "num-units": "1"},
+		}, {
+			Seq:    2,
+			Time:   historyTime.Add(time.Minute),
+			Actor:  "admin",
+			Kind:   "add-relation",
+			Entity: "relation-wordpress.db#mysql.server",
+			Params: map[string]string{"endpoints": "wordpress mysql"},
+		}},
+	}
+}
+
+func (s *historySuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewHistoryCommandForTest(s.api, s.clock), args...)
+}
+
+func (s *historySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--kind", "bogus"},
+		err:  `unknown change kind "bogus", expected one of: deploy, .*`,
+	}, {
+		args: []string{"--application", "no/good"},
+		err:  `application name "no/good" not valid`,
+	}, {
+		args: []string{"--user", "no/good"},
+		err:  `user name "no/good" not valid`,
+	}, {
+		args: []string{"--since", "-1h"},
+		err:  `negative --since -1h0m0s not valid`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  `negative --limit -1 not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *historySuite) TestHistoryTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{FuncName: "ChangeLog", Args: []interface{}{changelog.Filter{}}},
+		{FuncName: "Close"},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Seq  Time                  User   Change        Entity                     Parameters\n"+
+		"1    2017-09-01 12:00:00Z  bob    deploy        mysql                      charm=cs:mysql-42 num-units=1\n"+
+		"2    2017-09-01 12:01:00Z  admin  add-relation  wordpress:db mysql:server  endpoints=wordpress mysql\n")
+}
+
+func (s *historySuite) TestHistoryYAML(c *gc.C) {
+	s.api.entries = s.api.entries[:1]
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- seq: 1
+  time: 2017-09-01T12:00:00Z
+  user: bob
+  kind: deploy
+  application: mysql
+  params:
+    charm: cs:mysql-42
+    num-units: "1"
+`[1:])
+}
+
+func (s *historySuite) TestHistoryFilter(c *gc.C) {
+	_, err := s.run(c,
+		"--kind", "config",
+		"--application", "mysql",
+		"--user", "bob",
+		"--since", "30m",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ChangeLog", changelog.Filter{
+		Kind:   "config",
+		Entity: "application-mysql",
+		Actor:  "bob",
+		Since:  historyTime.Add(30 * time.Minute),
+		Limit:  5,
+	})
+}
+
+func (s *historySuite) TestHistoryEmpty(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No changes recorded.\n")
+}
+
+func (s *historySuite) TestHistoryError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeHistoryAPI struct {
+	gitjujutesting.Stub
+	entries []params.ChangeLogEntry
+}
+
+func (f *fakeHistoryAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeHistoryAPI) ChangeLog(filter changelog.Filter) ([]params.ChangeLogEntry, error) {
+	f.MethodCall(f, "ChangeLog", filter)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -15,6 +16,34 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
+const retryProvisioningDoc = `
+Machines that could not be provisioned are left in an error state, and
+are not provisioned again until retry-provisioning is run for them.
+
+Where the cloud's response allows it, the error is classified, and its
+class shown in the machine's instance status data with advice on how to
+resolve it before retrying. The classes are:
+
+    quota            the cloud account's quota has been exceeded
+    image-not-found  no image suitable for the machine was found
+    az-capacity      the availability zone could not host the machine
+    credential       the cloud credential is invalid or lacks permission
+    network          the machine's networks could not be used
+    unknown          the error could not be classified
+
+Errors of the az-capacity class may be avoided by retrying in another
+availability zone, with --switch-zone, or with another instance type,
+with --switch-instance-type. The zone or instance type of the failed
+attempt is then not used again for the machine.
+
+Examples:
+    juju retry-provisioning 0
+    juju retry-provisioning 1 2 --switch-zone
+
+See also:
+    show-machine
+`
+
 func NewRetryProvisioningCommand() cmd.Command {
 	return modelcmd.Wrap(&retryProvisioningCommand{})
 }
@@ -25,6 +54,9 @@ type retryProvisioningCommand struct {
 	modelcmd.ModelCommandBase
 	Machines []names.MachineTag
 	api      RetryProvisioningAPI
+
+	switchZone         bool
+	switchInstanceType bool
 }
 
 // RetryProvisioningAPI defines methods on the client API
@@ -32,6 +64,7 @@ type retryProvisioningCommand struct {
 type RetryProvisioningAPI interface {
 	Close() error
 	RetryProvisioning(machines ...names.MachineTag) ([]params.ErrorResult, error)
+	RetryProvisioningWithOptions(switchZone, switchInstanceType bool, machines ...names.MachineTag) ([]params.ErrorResult, error)
 }
 
 func (c *retryProvisioningCommand) Info() *cmd.Info {
@@ -39,9 +72,16 @@ func (c *retryProvisioningCommand) Info() *cmd.Info {
 		Name:    "retry-provisioning",
 		Args:    "<machine> [...]",
 		Purpose: "Retries provisioning for failed machines.",
+		Doc:     retryProvisioningDoc,
 	}
 }
 
+func (c *retryProvisioningCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.switchZone, "switch-zone", false, "Avoid the availability zone that lacked capacity")
+	f.BoolVar(&c.switchInstanceType, "switch-instance-type", false, "Avoid the instance type that lacked capacity")
+}
+
 func (c *retryProvisioningCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no machine specified")
@@ -73,7 +113,15 @@ func (c *retryProvisioningCommand) Run(context *cmd.Context) error {
 	}
 	defer client.Close()
 
-	results, err := client.RetryProvisioning(c.Machines...)
+	var results []params.ErrorResult
+	if c.switchZone || c.switchInstanceType {
+		results, err = client.RetryProvisioningWithOptions(c.switchZone, c.switchInstanceType, c.Machines...)
+		if errors.IsNotSupported(err) {
+			return errors.New("this controller does not support switching availability zone or instance type")
+		}
+	} else {
+		results, err = client.RetryProvisioning(c.Machines...)
+	}
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
@@ -74,6 +74,23 @@ func (f *fakeRetryProvisioningClient) RetryProvisioning(machines ...names.Machin
 	return results, nil
 }
 
+func (f *fakeRetryProvisioningClient) RetryProvisioningWithOptions(switchZone, switchInstanceType bool, machines ...names.MachineTag) (
+	[]params.ErrorResult, error) {
+
+	results, err := f.RetryProvisioning(machines...)
+	if err != nil {
+		return nil, err
+	}
+	for i, machine := range machines {
+		if results[i].Error == nil {
+			m := f.m[machine.Id()]
+			m.data["switch-zone"] = switchZone
+			m.data["switch-instance-type"] = switchInstanceType
+		}
+	}
+	return results, nil
+}
+
 func (s *retryProvisioningSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 
@@ -155,3 +172,30 @@ func (s *retryProvisioningSuite) TestBlockRetryProvisioning(c *gc.C) {
 		testing.AssertOperationWasBlocked(c, err, ".*TestBlockRetryProvisioning.*")
 	}
 }
+
+func (s *retryProvisioningSuite) TestRetryProvisioningSwitchZone(c *gc.C) {
+	command := model.NewRetryProvisioningCommandForTest(s.fake)
+	_, err := cmdtesting.RunCommand(c, command, "0", "--switch-zone")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.m["0"].data, jc.DeepEquals, map[string]interface{}{
+		"transient":            true,
+		"switch-zone":          true,
+		"switch-instance-type": false,
+	})
+}
+
+func (s *retryProvisioningSuite) TestRetryProvisioningWithoutOptions(c *gc.C) {
+	command := model.NewRetryProvisioningCommandForTest(s.fake)
+	_, err := cmdtesting.RunCommand(c, command, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.m["0"].data, jc.DeepEquals, map[string]interface{}{
+		"transient": true,
+	})
+}
+
+func (s *retryProvisioningSuite) TestRetryProvisioningOptionsNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("retrying provisioning with options")
+	command := model.NewRetryProvisioningCommandForTest(s.fake)
+	_, err := cmdtesting.RunCommand(c, command, "0", "--switch-instance-type")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support switching availability zone or instance type")
+}
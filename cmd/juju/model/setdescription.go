@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/description"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const setDescriptionDoc = `
+Sets a free-text description of the model or, with --application, of
+an application in the model. Descriptions let the users of a shared
+controller record who owns a model or application and what it is for.
+
+The model description is shown by "juju models" and in the header of
+"juju status". Application descriptions are shown in the yaml and
+json output of "juju status".
+
+An empty description removes the current one.
+
+Examples:
+    juju set-description "Staging for the web team, contact web@example.com"
+    juju set-description --application mysql "Shared by the reporting jobs"
+    juju set-description ""
+
+See also:
+    models
+    status
+`
+
+// NewSetDescriptionCommand returns a set-description command instance
+// that will use the default API.
+func NewSetDescriptionCommand() cmd.Command {
+	return modelcmd.Wrap(&setDescriptionCommand{})
+}
+
+// setDescriptionCommand sets the description of a model or application.
+type setDescriptionCommand struct {
+	modelcmd.ModelCommandBase
+	api SetDescriptionAPI
+
+	application string
+	description string
+}
+
+// SetDescriptionAPI defines the API methods that the set-description
+// command uses.
+type SetDescriptionAPI interface {
+	Close() error
+	SetModelDescription(description string) error
+	SetApplicationDescription(application, description string) error
+}
+
+// Info implements Command.
+func (c *setDescriptionCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-description",
+		Args:    "<description>",
+		Purpose: "Sets the description of a model or application.",
+		Doc:     setDescriptionDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *setDescriptionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.application, "application", "", "Set the description of the named application")
+}
+
+// Init implements Command.
+func (c *setDescriptionCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no description specified")
+	}
+	if c.application != "" && !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	c.description = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *setDescriptionCommand) getAPI() (SetDescriptionAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return description.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *setDescriptionCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if c.application != "" {
+		return errors.Trace(api.SetApplicationDescription(c.application, c.description))
+	}
+	return errors.Trace(api.SetModelDescription(c.description))
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type setDescriptionSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeSetDescriptionAPI
+}
+
+var _ = gc.Suite(&setDescriptionSuite{})
+
+func (s *setDescriptionSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSetDescriptionAPI{}
+}
+
+func (s *setDescriptionSuite) run(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, model.NewSetDescriptionCommandForTest(s.api), args...)
+	return err
+}
+
+func (s *setDescriptionSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no description specified",
+	}, {
+		args: []string{"one", "two"},
+		err:  `unrecognized args: \["two"\]`,
+	}, {
+		args: []string{"--application", "no/good", "desc"},
+		err:  `application name "no/good" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *setDescriptionSuite) TestSetModelDescription(c *gc.C) {
+	err := s.run(c, "staging for the web team")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{FuncName: "SetModelDescription", Args: []interface{}{"staging for the web team"}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *setDescriptionSuite) TestClearModelDescription(c *gc.C) {
+	err := s.run(c, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetModelDescription", "")
+}
+
+func (s *setDescriptionSuite) TestSetApplicationDescription(c *gc.C) {
+	err := s.run(c, "--application", "mysql", "owned by the db team")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetApplicationDescription", "mysql", "owned by the db team")
+}
+
+func (s *setDescriptionSuite) TestSetDescriptionError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	err := s.run(c, "staging")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeSetDescriptionAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeSetDescriptionAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeSetDescriptionAPI) SetModelDescription(description string) error {
+	f.MethodCall(f, "SetModelDescription", description)
+	return f.NextErr()
+}
+
+func (f *fakeSetDescriptionAPI) SetApplicationDescription(application, description string) error {
+	f.MethodCall(f, "SetApplicationDescription", application, description)
+	return f.NextErr()
+}
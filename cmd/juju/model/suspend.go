@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelsuspension"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const suspendModelDoc = `
+Suspends a model by stopping the instances of its machines, without
+terminating them. Their disks are kept, so that the model may be
+resumed later with "juju resume-model"; on most clouds stopped
+instances are not charged for.
+
+Changes to a suspended model are rejected until it is resumed. The
+controller model may not be suspended, and not all clouds support
+suspending models.
+
+Examples:
+    juju suspend-model
+    juju suspend-model -m staging
+
+See also:
+    resume-model
+`
+
+const resumeModelDoc = `
+Resumes a model suspended with "juju suspend-model", starting the
+instances of its machines again and updating their addresses, which
+may have changed while they were stopped.
+
+Examples:
+    juju resume-model
+    juju resume-model -m staging
+
+See also:
+    suspend-model
+`
+
+// SuspendModelAPI defines the API methods that the suspend-model and
+// resume-model commands use.
+type SuspendModelAPI interface {
+	Close() error
+	Suspend() error
+	Resume() error
+}
+
+// NewSuspendModelCommand returns a suspend-model command instance that
+// will use the default API.
+func NewSuspendModelCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendModelCommand{})
+}
+
+// NewResumeModelCommand returns a resume-model command instance that
+// will use the default API.
+func NewResumeModelCommand() cmd.Command {
+	return modelcmd.Wrap(&resumeModelCommand{})
+}
+
+type suspensionCommandBase struct {
+	modelcmd.ModelCommandBase
+	api SuspendModelAPI
+}
+
+func (c *suspensionCommandBase) getAPI() (SuspendModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelsuspension.NewClient(root), nil
+}
+
+// suspendModelCommand stops the instances of a model.
+type suspendModelCommand struct {
+	suspensionCommandBase
+}
+
+// Info implements Command.
+func (c *suspendModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "suspend-model",
+		Purpose: "Stops the instances of a model until it is resumed.",
+		Doc:     suspendModelDoc,
+	}
+}
+
+// Run implements Command.
+func (c *suspendModelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.Suspend(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model suspended; use \"juju resume-model\" to start its instances again.")
+	return nil
+}
+
+// resumeModelCommand starts the instances of a suspended model.
+type resumeModelCommand struct {
+	suspensionCommandBase
+}
+
+// Info implements Command.
+func (c *resumeModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resume-model",
+		Purpose: "Starts the instances of a suspended model.",
+		Doc:     resumeModelDoc,
+	}
+}
+
+// Run implements Command.
+func (c *resumeModelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	return errors.Trace(api.Resume())
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type suspendModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeSuspendModelAPI
+}
+
+var _ = gc.Suite(&suspendModelSuite{})
+
+func (s *suspendModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSuspendModelAPI{}
+}
+
+func (s *suspendModelSuite) TestSuspend(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewSuspendModelCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, "Model suspended.*\n")
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{FuncName: "Suspend"},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *suspendModelSuite) TestSuspendUnexpectedArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewSuspendModelCommandForTest(s.api), "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *suspendModelSuite) TestSuspendError(c *gc.C) {
+	s.api.SetErrors(errors.New("suspending models on this cloud not supported"))
+	_, err := cmdtesting.RunCommand(c, model.NewSuspendModelCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "suspending models on this cloud not supported")
+}
+
+func (s *suspendModelSuite) TestResume(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewResumeModelCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{FuncName: "Resume"},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *suspendModelSuite) TestResumeError(c *gc.C) {
+	s.api.SetErrors(errors.New("model is not suspended"))
+	_, err := cmdtesting.RunCommand(c, model.NewResumeModelCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "model is not suspended")
+}
+
+type fakeSuspendModelAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeSuspendModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeSuspendModelAPI) Suspend() error {
+	f.MethodCall(f, "Suspend")
+	return f.NextErr()
+}
+
+func (f *fakeSuspendModelAPI) Resume() error {
+	f.MethodCall(f, "Resume")
+	return f.NextErr()
+}
@@ -0,0 +1,489 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Topology describes the placement of a model's application units on
+// machines, and of the machines in availability zones.
+type Topology struct {
+	// Machines holds the model's top level machines, keyed by
+	// machine ID.
+	Machines map[string]TopologyMachine `yaml:"machines" json:"machines"`
+
+	// Applications holds the placement of the units of each principal
+	// application, keyed by application name.
+	Applications map[string]TopologyApplication `yaml:"applications" json:"applications"`
+}
+
+// TopologyMachine describes a machine in a Topology.
+type TopologyMachine struct {
+	Zone        string `yaml:"zone,omitempty" json:"zone,omitempty"`
+	Series      string `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints string `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+// TopologyApplication describes the placement of an application's
+// units in a Topology.
+type TopologyApplication struct {
+	// Units holds the placement of each unit, as a machine ID or as a
+	// container type and the ID of the container's host, eg "lxd:0".
+	Units []string `yaml:"units" json:"units"`
+}
+
+// TopologyStatusAPI defines the API methods that the export-topology
+// command uses.
+type TopologyStatusAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// ApplyTopologyAPI defines the API methods that the apply-topology
+// command uses.
+type ApplyTopologyAPI interface {
+	TopologyStatusAPI
+	ModelUUID() (string, bool)
+	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
+	AddUnits(application.AddUnitsParams) ([]string, error)
+}
+
+// topologyAPI implements ApplyTopologyAPI.
+type topologyAPI struct {
+	*api.Client
+	application *application.Client
+}
+
+// AddUnits is part of the ApplyTopologyAPI interface.
+func (a *topologyAPI) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	return a.application.AddUnits(args)
+}
+
+func newTopologyAPI(c *modelcmd.ModelCommandBase) (*topologyAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &topologyAPI{
+		Client:      root.Client(),
+		application: application.NewClient(root),
+	}, nil
+}
+
+const exportTopologyDoc = `
+Exports the placement of the model's application units on machines, and
+of the machines in availability zones. The topology may be applied to
+another model, possibly in another cloud, with "juju apply-topology", to
+reproduce the same placement shape; for example, to clone an environment
+for disaster recovery testing.
+
+Subordinate applications are not included, as their units are placed
+with the units of their principals.
+
+Examples:
+    juju export-topology -o topology.yaml
+    juju export-topology -m production --format json
+
+See also:
+    apply-topology
+`
+
+// NewExportTopologyCommand returns a command that exports the
+// placement topology of a model.
+func NewExportTopologyCommand() cmd.Command {
+	return modelcmd.Wrap(&exportTopologyCommand{})
+}
+
+// exportTopologyCommand exports the placement topology of a model.
+type exportTopologyCommand struct {
+	modelcmd.ModelCommandBase
+	api TopologyStatusAPI
+	out cmd.Output
+}
+
+// Info implements Command.
+func (c *exportTopologyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-topology",
+		Purpose: "Exports the machine and zone placement of a model's units.",
+		Doc:     exportTopologyDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportTopologyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.
+func (c *exportTopologyCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *exportTopologyCommand) getAPI() (TopologyStatusAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run implements Command.
+func (c *exportTopologyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	status, err := api.Status(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, topologyFromStatus(status))
+}
+
+// topologyFromStatus returns the topology of the model with the
+// specified status.
+func topologyFromStatus(status *params.FullStatus) Topology {
+	topology := Topology{
+		Machines:     make(map[string]TopologyMachine),
+		Applications: make(map[string]TopologyApplication),
+	}
+	for id, machine := range status.Machines {
+		var zone string
+		if hc, err := instance.ParseHardware(machine.Hardware); err == nil && hc.AvailabilityZone != nil {
+			zone = *hc.AvailabilityZone
+		}
+		topology.Machines[id] = TopologyMachine{
+			Zone:        zone,
+			Series:      machine.Series,
+			Constraints: machine.Constraints,
+		}
+	}
+	for name, app := range status.Applications {
+		if len(app.SubordinateTo) > 0 {
+			continue
+		}
+		unitNames := make([]string, 0, len(app.Units))
+		for unitName := range app.Units {
+			unitNames = append(unitNames, unitName)
+		}
+		sort.Sort(byUnitNumber(unitNames))
+		var placements []string
+		for _, unitName := range unitNames {
+			placement := topologyPlacement(app.Units[unitName].Machine)
+			if placement == "" {
+				// The unit has not been assigned to a machine.
+				continue
+			}
+			placements = append(placements, placement)
+		}
+		topology.Applications[name] = TopologyApplication{Units: placements}
+	}
+	return topology
+}
+
+// topologyPlacement returns the topology placement for a unit assigned
+// to the machine with the specified ID.
+func topologyPlacement(machineId string) string {
+	parts := strings.Split(machineId, "/")
+	if len(parts) < 3 {
+		return machineId
+	}
+	// Containers are not recreated with the same IDs, so the unit is
+	// placed in a new container of the same type on the same host.
+	return parts[len(parts)-2] + ":" + strings.Join(parts[:len(parts)-2], "/")
+}
+
+// byUnitNumber sorts unit names of the same application by number.
+type byUnitNumber []string
+
+func (s byUnitNumber) Len() int      { return len(s) }
+func (s byUnitNumber) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byUnitNumber) Less(i, j int) bool {
+	return unitNumber(s[i]) < unitNumber(s[j])
+}
+
+func unitNumber(unitName string) int {
+	n, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return n
+}
+
+const applyTopologyDoc = `
+Reproduces the placement shape recorded by "juju export-topology" in the
+current model. A new machine is added for each machine in the topology,
+in the same availability zone and with the same series and constraints,
+and units are added to the applications on the new machines, in the same
+arrangement as the original model.
+
+The applications must already be deployed in the model, for example by
+deploying a bundle exported from the original model with no units. Where
+the placement cannot be reproduced, for instance because an availability
+zone does not exist in the model's cloud, the closest placement is used
+and the divergence is reported.
+
+Examples:
+    juju apply-topology topology.yaml
+    juju apply-topology -m dr-test topology.yaml
+
+See also:
+    export-topology
+    add-machine
+    add-unit
+`
+
+// NewApplyTopologyCommand returns a command that applies a placement
+// topology to a model.
+func NewApplyTopologyCommand() cmd.Command {
+	return modelcmd.Wrap(&applyTopologyCommand{})
+}
+
+// applyTopologyCommand applies a placement topology to a model.
+type applyTopologyCommand struct {
+	modelcmd.ModelCommandBase
+	api      ApplyTopologyAPI
+	filename string
+}
+
+// Info implements Command.
+func (c *applyTopologyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "apply-topology",
+		Args:    "<topology file>",
+		Purpose: "Reproduces an exported machine and zone placement in a model.",
+		Doc:     applyTopologyDoc,
+	}
+}
+
+// Init implements Command.
+func (c *applyTopologyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no topology file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *applyTopologyCommand) getAPI() (ApplyTopologyAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return newTopologyAPI(&c.ModelCommandBase)
+}
+
+// Run implements Command.
+func (c *applyTopologyCommand) Run(ctx *cmd.Context) error {
+	topology, err := c.readTopology(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	status, err := api.Status(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelUUID, ok := api.ModelUUID()
+	if !ok {
+		return errors.New("API connection is controller-only (should never happen)")
+	}
+	applier := topologyApplier{
+		api:       api,
+		ctx:       ctx,
+		modelUUID: modelUUID,
+		machines:  make(map[string]string),
+	}
+	if err := applier.addMachines(topology.Machines); err != nil {
+		return errors.Trace(err)
+	}
+	applier.addUnits(topology.Applications, status.Applications)
+
+	if len(applier.divergences) == 0 {
+		ctx.Infof("Topology applied with no divergences.")
+		return nil
+	}
+	ctx.Infof("Topology applied with %d divergence(s):", len(applier.divergences))
+	for _, divergence := range applier.divergences {
+		ctx.Infof("  %s", divergence)
+	}
+	return nil
+}
+
+func (c *applyTopologyCommand) readTopology(ctx *cmd.Context) (*Topology, error) {
+	path, err := utils.NormalizePath(c.filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(ctx.AbsPath(path))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading topology")
+	}
+	var topology Topology
+	if err := yaml.Unmarshal(data, &topology); err != nil {
+		return nil, errors.Annotate(err, "parsing topology")
+	}
+	return &topology, nil
+}
+
+// topologyApplier adds machines and units to a model to reproduce a
+// topology, recording where the placement diverges from it.
+type topologyApplier struct {
+	api       ApplyTopologyAPI
+	ctx       *cmd.Context
+	modelUUID string
+
+	// machines maps the IDs of the machines in the topology to the
+	// IDs of the machines added to the model.
+	machines    map[string]string
+	divergences []string
+}
+
+func (a *topologyApplier) diverged(format string, args ...interface{}) {
+	a.divergences = append(a.divergences, fmt.Sprintf(format, args...))
+}
+
+// addMachines adds a machine to the model for each machine in the
+// topology. If a machine cannot be added in the same zone, it is added
+// without a zone.
+func (a *topologyApplier) addMachines(machines map[string]TopologyMachine) error {
+	ids := make([]string, 0, len(machines))
+	for id := range machines {
+		ids = append(ids, id)
+	}
+	sort.Sort(byMachineNumber(ids))
+	for _, id := range ids {
+		machine := machines[id]
+		cons, err := constraints.Parse(machine.Constraints)
+		if err != nil {
+			return errors.Annotatef(err, "machine %s", id)
+		}
+		args := params.AddMachineParams{
+			Series:      machine.Series,
+			Constraints: cons,
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}
+		if machine.Zone != "" {
+			args.Placement = &instance.Placement{
+				Scope:     a.modelUUID,
+				Directive: "zone=" + machine.Zone,
+			}
+		}
+		newId, err := a.addMachine(args)
+		if err != nil && args.Placement != nil {
+			a.diverged("machine %s: not placed in zone %q: %v", id, machine.Zone, err)
+			args.Placement = nil
+			newId, err = a.addMachine(args)
+		}
+		if err != nil {
+			a.diverged("machine %s: not added: %v", id, err)
+			continue
+		}
+		a.machines[id] = newId
+		a.ctx.Infof("added machine %s for machine %s", newId, id)
+	}
+	return nil
+}
+
+func (a *topologyApplier) addMachine(args params.AddMachineParams) (string, error) {
+	results, err := a.api.AddMachines([]params.AddMachineParams{args})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return "", results[0].Error
+	}
+	return results[0].Machine, nil
+}
+
+// addUnits adds units to the deployed applications, on the machines
+// corresponding to those in the topology.
+func (a *topologyApplier) addUnits(applications map[string]TopologyApplication, deployed map[string]params.ApplicationStatus) {
+	names := make([]string, 0, len(applications))
+	for name := range applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := deployed[name]; !ok {
+			a.diverged("application %s: not deployed, %d unit(s) not added", name, len(applications[name].Units))
+			continue
+		}
+		for _, placement := range applications[name].Units {
+			target, err := a.unitPlacement(placement)
+			if err != nil {
+				a.diverged("application %s: unit for %q not added: %v", name, placement, err)
+				continue
+			}
+			units, err := a.api.AddUnits(application.AddUnitsParams{
+				ApplicationName: name,
+				NumUnits:        1,
+				Placement:       []*instance.Placement{target},
+			})
+			if err != nil {
+				a.diverged("application %s: unit for %q not added: %v", name, placement, err)
+				continue
+			}
+			where := "machine " + target.Directive
+			if target.Scope != instance.MachineScope {
+				where = fmt.Sprintf("new %s container on machine %s", target.Scope, target.Directive)
+			}
+			a.ctx.Infof("added unit %s to %s", strings.Join(units, ", "), where)
+		}
+	}
+}
+
+// unitPlacement returns the placement in the model corresponding to
+// the specified topology placement.
+func (a *topologyApplier) unitPlacement(placement string) (*instance.Placement, error) {
+	scope, machineId := instance.MachineScope, placement
+	if i := strings.Index(placement, ":"); i >= 0 {
+		scope, machineId = placement[:i], placement[i+1:]
+	}
+	newId, ok := a.machines[machineId]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", machineId)
+	}
+	return &instance.Placement{Scope: scope, Directive: newId}, nil
+}
+
+// byMachineNumber sorts top level machine IDs by number.
+type byMachineNumber []string
+
+func (s byMachineNumber) Len() int      { return len(s) }
+func (s byMachineNumber) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byMachineNumber) Less(i, j int) bool {
+	a, _ := strconv.Atoi(s[i])
+	b, _ := strconv.Atoi(s[j])
+	return a < b
+}
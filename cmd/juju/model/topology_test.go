@@ -0,0 +1,267 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/testing"
+)
+
+type topologySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeTopologyAPI
+}
+
+var _ = gc.Suite(&topologySuite{})
+
+func (s *topologySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeTopologyAPI{
+		status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {
+					Id:          "0",
+					Series:      "xenial",
+					Constraints: "mem=4096M",
+					Hardware:    "arch=amd64 mem=4096M availability-zone=az1",
+				},
+				"1": {
+					Id:       "1",
+					Series:   "xenial",
+					Hardware: "arch=amd64 availability-zone=az2",
+				},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Units: map[string]params.UnitStatus{
+						"mysql/10": {Machine: "1/lxd/0"},
+						"mysql/2":  {Machine: "0"},
+					},
+				},
+				"wordpress": {
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {Machine: "1"},
+						"wordpress/1": {},
+					},
+				},
+				"logging": {
+					SubordinateTo: []string{"wordpress"},
+				},
+			},
+		},
+	}
+}
+
+func (s *topologySuite) TestExport(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportTopologyCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+machines:
+  "0":
+    zone: az1
+    series: xenial
+    constraints: mem=4096M
+  "1":
+    zone: az2
+    series: xenial
+applications:
+  mysql:
+    units:
+    - "0"
+    - lxd:1
+  wordpress:
+    units:
+    - "1"
+`[1:])
+	s.api.CheckCallNames(c, "Status", "Close")
+}
+
+func (s *topologySuite) TestExportInitError(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewExportTopologyCommandForTest(s.api), "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *topologySuite) writeTopology(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "topology.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+const sampleTopology = `
+machines:
+  "0":
+    zone: az1
+    series: xenial
+    constraints: mem=4096M
+  "1":
+    zone: az2
+applications:
+  mysql:
+    units: ["0", "lxd:1"]
+  postgresql:
+    units: ["1"]
+`
+
+func (s *topologySuite) TestApply(c *gc.C) {
+	s.api.status.Applications["mysql"] = params.ApplicationStatus{}
+	path := s.writeTopology(c, sampleTopology)
+	ctx, err := cmdtesting.RunCommand(c, model.NewApplyTopologyCommandForTest(s.api), path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mem := uint64(4096)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Status", []interface{}{[]string(nil)}},
+		{"ModelUUID", nil},
+		{"AddMachines", []interface{}{[]params.AddMachineParams{{
+			Series:      "xenial",
+			Constraints: constraints.Value{Mem: &mem},
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			Placement:   &instance.Placement{Scope: "model-uuid", Directive: "zone=az1"},
+		}}}},
+		{"AddMachines", []interface{}{[]params.AddMachineParams{{
+			Jobs:      []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			Placement: &instance.Placement{Scope: "model-uuid", Directive: "zone=az2"},
+		}}}},
+		{"AddUnits", []interface{}{application.AddUnitsParams{
+			ApplicationName: "mysql",
+			NumUnits:        1,
+			Placement:       []*instance.Placement{{Scope: "#", Directive: "10"}},
+		}}},
+		{"AddUnits", []interface{}{application.AddUnitsParams{
+			ApplicationName: "mysql",
+			NumUnits:        1,
+			Placement:       []*instance.Placement{{Scope: "lxd", Directive: "11"}},
+		}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+added machine 10 for machine 0
+added machine 11 for machine 1
+added unit mysql/0 to machine 10
+added unit mysql/1 to new lxd container on machine 11
+Topology applied with 1 divergence(s):
+  application postgresql: not deployed, 1 unit(s) not added
+`[1:])
+}
+
+func (s *topologySuite) TestApplyZoneUnavailable(c *gc.C) {
+	s.api.addMachinesErrors = []*params.Error{{Message: `invalid availability zone "az1"`}}
+	path := s.writeTopology(c, `
+machines:
+  "0":
+    zone: az1
+applications:
+  wordpress:
+    units: ["0"]
+`)
+	ctx, err := cmdtesting.RunCommand(c, model.NewApplyTopologyCommandForTest(s.api), path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 3, "AddMachines", []params.AddMachineParams{{
+		Jobs: []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+	}})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+added machine 10 for machine 0
+added unit wordpress/0 to machine 10
+Topology applied with 1 divergence(s):
+  machine 0: not placed in zone "az1": invalid availability zone "az1"
+`[1:])
+}
+
+func (s *topologySuite) TestApplyAddUnitsError(c *gc.C) {
+	s.api.SetErrors(nil, nil, errors.New("no more units"))
+	path := s.writeTopology(c, `
+machines:
+  "0": {}
+applications:
+  wordpress:
+    units: ["0", "3"]
+`)
+	ctx, err := cmdtesting.RunCommand(c, model.NewApplyTopologyCommandForTest(s.api), path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+added machine 10 for machine 0
+Topology applied with 2 divergence(s):
+  application wordpress: unit for "0" not added: no more units
+  application wordpress: unit for "3" not added: machine 3 not found
+`[1:])
+}
+
+func (s *topologySuite) TestApplyInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewApplyTopologyCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "no topology file specified")
+	_, err = cmdtesting.RunCommand(c, model.NewApplyTopologyCommandForTest(s.api), "a.yaml", "b.yaml")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.yaml"\]`)
+}
+
+func (s *topologySuite) TestApplyInvalidTopology(c *gc.C) {
+	path := s.writeTopology(c, "machines: [")
+	_, err := cmdtesting.RunCommand(c, model.NewApplyTopologyCommandForTest(s.api), path)
+	c.Assert(err, gc.ErrorMatches, "parsing topology: .*")
+	s.api.CheckNoCalls(c)
+}
+
+type fakeTopologyAPI struct {
+	gitjujutesting.Stub
+	status            *params.FullStatus
+	addMachinesErrors []*params.Error
+	machines          int
+	units             map[string]int
+}
+
+func (f *fakeTopologyAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeTopologyAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *fakeTopologyAPI) ModelUUID() (string, bool) {
+	f.MethodCall(f, "ModelUUID")
+	return "model-uuid", true
+}
+
+func (f *fakeTopologyAPI) AddMachines(args []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	f.MethodCall(f, "AddMachines", args)
+	if len(f.addMachinesErrors) > 0 {
+		err := f.addMachinesErrors[0]
+		f.addMachinesErrors = f.addMachinesErrors[1:]
+		return []params.AddMachinesResult{{Error: err}}, nil
+	}
+	id := 10 + f.machines
+	f.machines++
+	return []params.AddMachinesResult{{Machine: strconv.Itoa(id)}}, f.NextErr()
+}
+
+func (f *fakeTopologyAPI) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	f.MethodCall(f, "AddUnits", args)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	if f.units == nil {
+		f.units = make(map[string]int)
+	}
+	n := f.units[args.ApplicationName]
+	f.units[args.ApplicationName]++
+	return []string{fmt.Sprintf("%s/%d", args.ApplicationName, n)}, nil
+}
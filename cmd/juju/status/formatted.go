@@ -41,6 +41,7 @@ type modelStatus struct {
 	Status           statusInfoContents `json:"model-status,omitempty" yaml:"model-status,omitempty"`
 	MeterStatus      *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 	SLA              string             `json:"sla,omitempty" yaml:"sla,omitempty"`
+	Description      string             `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 type networkInterface struct {
@@ -102,6 +103,7 @@ type applicationStatus struct {
 	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
 	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
+	Description   string                `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -192,6 +194,7 @@ type unitStatus struct {
 	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
 	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
+	PortConflicts []string              `json:"port-conflicts,omitempty" yaml:"port-conflicts,omitempty"`
 	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
 }
@@ -61,6 +61,7 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 			AvailableVersion: sf.status.Model.AvailableVersion,
 			Status:           sf.getStatusInfoContents(sf.status.Model.ModelStatus),
 			SLA:              sf.status.Model.SLA,
+			Description:      sf.status.Model.Description,
 		},
 		Machines:           make(map[string]machineStatus),
 		Applications:       make(map[string]applicationStatus),
@@ -197,6 +198,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Units:         make(map[string]unitStatus),
 		StatusInfo:    sf.getApplicationStatusInfo(application),
 		Version:       application.WorkloadVersion,
+		Description:   application.Description,
 	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
@@ -323,6 +325,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		JujuStatusInfo:     sf.getAgentStatusInfo(info.unit),
 		Machine:            info.unit.Machine,
 		OpenedPorts:        info.unit.OpenedPorts,
+		PortConflicts:      info.unit.PortConflicts,
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
@@ -36,9 +36,12 @@ type statusHistoryCommand struct {
 	backlogSize          int
 	backlogSizeDays      int
 	backlogDate          string
+	backlogToDate        string
+	backlogOffset        int
 	isoTime              bool
 	entityName           string
 	date                 time.Time
+	toDate               time.Time
 	includeStatusUpdates bool
 }
 
@@ -54,6 +57,8 @@ The statuses are available for the following types.
     machine: will show statuses for machines.
     juju-container: will show statuses for the container's juju agent.
     container: will show statuses for containers.
+    volume: will show statuses for storage volumes.
+    filesystem: will show statuses for storage filesystems.
  and sorted by time of occurrence.
  The default is unit.
 `
@@ -69,10 +74,12 @@ func (c *statusHistoryCommand) Info() *cmd.Info {
 
 func (c *statusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
-	f.StringVar(&c.outputContent, "type", "unit", "Type of statuses to be displayed [agent|workload|combined|machine|machineInstance|container|containerinstance]")
+	f.StringVar(&c.outputContent, "type", "unit", "Type of statuses to be displayed [agent|workload|combined|machine|machineInstance|container|containerinstance|volume|filesystem]")
 	f.IntVar(&c.backlogSize, "n", 0, "Returns the last N logs (cannot be combined with --days or --date)")
 	f.IntVar(&c.backlogSizeDays, "days", 0, "Returns the logs for the past <days> days (cannot be combined with -n or --date)")
 	f.StringVar(&c.backlogDate, "from-date", "", "Returns logs for any date after the passed one, the expected date format is YYYY-MM-DD (cannot be combined with -n or --days)")
+	f.StringVar(&c.backlogToDate, "to-date", "", "Returns logs for any date up to the end of the passed one, the expected date format is YYYY-MM-DD")
+	f.IntVar(&c.backlogOffset, "offset", 0, "Skips the most recent N logs (requires -n)")
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Inlcude update status hook messages in the returned logs")
 }
@@ -100,6 +107,9 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	emptyDate := c.backlogDate == ""
 	emptySize := c.backlogSize == 0
 	emptyDays := c.backlogSizeDays == 0
+	if c.backlogOffset != 0 && emptySize {
+		return errors.Errorf("offset requires backlog size")
+	}
 	if emptyDate && emptySize && emptyDays {
 		c.backlogSize = 20
 	}
@@ -113,6 +123,17 @@ func (c *statusHistoryCommand) Init(args []string) error {
 			return errors.Annotate(err, "parsing backlog date")
 		}
 	}
+	if c.backlogToDate != "" {
+		toDate, err := time.Parse("2006-01-02", c.backlogToDate)
+		if err != nil {
+			return errors.Annotate(err, "parsing backlog to date")
+		}
+		// Include the whole of the specified day.
+		c.toDate = toDate.Add(24*time.Hour - time.Nanosecond)
+		if !c.date.IsZero() && c.toDate.Before(c.date) {
+			return errors.Errorf("backlog to date cannot be before backlog date")
+		}
+	}
 
 	kind := status.HistoryKind(c.outputContent)
 	if kind.Valid() {
@@ -137,8 +158,9 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 		delta = &t
 	}
 	filterArgs := status.StatusHistoryFilter{
-		Size:  c.backlogSize,
-		Delta: delta,
+		Size:   c.backlogSize,
+		Delta:  delta,
+		Offset: c.backlogOffset,
 	}
 	if !c.includeStatusUpdates {
 		filterArgs.Exclude = set.NewStrings(runningHookMSG)
@@ -147,6 +169,9 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	if !c.date.IsZero() {
 		filterArgs.FromDate = &c.date
 	}
+	if !c.toDate.IsZero() {
+		filterArgs.ToDate = &c.toDate
+	}
 	var tag names.Tag
 	switch kind {
 	case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
@@ -154,6 +179,16 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
 		tag = names.NewUnitTag(c.entityName)
+	case status.KindVolume:
+		if !names.IsValidVolume(c.entityName) {
+			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+		}
+		tag = names.NewVolumeTag(c.entityName)
+	case status.KindFilesystem:
+		if !names.IsValidFilesystem(c.entityName) {
+			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+		}
+		tag = names.NewFilesystemTag(c.entityName)
 	default:
 		if !names.IsValidMachine(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
@@ -66,6 +66,10 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		header = append(header, "SLA")
 		values = append(values, fs.Model.SLA)
 	}
+	if fs.Model.Description != "" {
+		header = append(header, "Description")
+		values = append(values, fs.Model.Description)
+	}
 
 	// The first set of headers don't use outputHeaders because it adds the blank line.
 	p(header...)
@@ -139,6 +143,9 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		if agentDoing != "" {
 			message = fmt.Sprintf("(%s) %s", agentDoing, message)
 		}
+		if len(u.PortConflicts) > 0 {
+			message = strings.TrimSpace(fmt.Sprintf("%s (port conflict: %s)", message, strings.Join(u.PortConflicts, "; ")))
+		}
 		if u.Leader {
 			name += "*"
 		}
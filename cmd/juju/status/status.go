@@ -4,10 +4,12 @@
 package status
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -15,14 +17,17 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	StatusPage(patterns []string, cursor string, limit int) (*params.FullStatusPage, error)
 	Close() error
 }
 
@@ -37,6 +42,10 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	pageSize int
+	watch    bool
+	interval time.Duration
+	cached   bool
 	api      statusAPI
 
 	color bool
@@ -55,6 +64,20 @@ machines, applications, and units will also be displayed. If a subordinate unit
 is matched, then its principal unit will be displayed. If a principal unit is
 matched, then all of its subordinates will be displayed.
 
+The status of large models can be fetched in pages, each holding no more
+than --page-size machines, containers, applications and units, so that no
+single response from the controller is too large.
+
+With --watch, the status is output again whenever the model changes, until
+interrupted. Changes are combined so that the status is output no more than
+once every --interval. If changes cannot be watched, the status is output
+every --interval instead.
+
+The status of the whole model is cached locally whenever it is fetched. With
+--cached, the cached status is output without contacting the controller,
+together with when it was fetched; this is useful when the controller cannot
+be reached. Filter patterns cannot be used with --cached.
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -77,6 +100,9 @@ Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --page-size 500
+    juju show-status --watch
+    juju show-status --cached
 
 See also:
     machines
@@ -99,6 +125,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.IntVar(&c.pageSize, "page-size", 0, "Fetch status in pages of at most this many entities")
+	f.BoolVar(&c.watch, "watch", false, "Output the status again whenever the model changes")
+	f.DurationVar(&c.interval, "interval", 5*time.Second, "The least time between outputs of the status with --watch")
+	f.BoolVar(&c.cached, "cached", false, "Output the status last fetched from the controller, without contacting it")
 
 	defaultFormat := "tabular"
 
@@ -115,6 +145,20 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.pageSize < 0 {
+		return errors.Errorf("invalid page size %d", c.pageSize)
+	}
+	if c.interval <= 0 {
+		return errors.Errorf("invalid interval %v", c.interval)
+	}
+	if c.cached {
+		if c.watch {
+			return errors.New("--cached and --watch cannot be used together")
+		}
+		if len(c.patterns) > 0 {
+			return errors.New("filter patterns cannot be used with --cached")
+		}
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -134,13 +178,28 @@ var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 }
 
 func (c *statusCommand) Run(ctx *cmd.Context) error {
+	if c.cached {
+		return c.writeCachedStatus(ctx)
+	}
 	apiclient, err := newAPIClientForStatus(c)
 	if err != nil {
+		if _, cacheErr := c.readCachedStatus(); cacheErr == nil {
+			ctx.Infof("The controller cannot be reached; use --cached to show the last status fetched from it.")
+		}
 		return errors.Trace(err)
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	if c.watch {
+		return c.watchStatus(ctx, apiclient)
+	}
+	return c.writeStatus(ctx, apiclient)
+}
+
+// writeStatus fetches the status of the model, and writes it out in the
+// requested format.
+func (c *statusCommand) writeStatus(ctx *cmd.Context, apiclient statusAPI) error {
+	status, err := c.getStatus(apiclient)
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
@@ -150,8 +209,77 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
 		return errors.Errorf("unable to obtain the current status")
+	} else if len(c.patterns) == 0 {
+		// Only the status of the whole model is cached, so that
+		// --cached never shows a filtered view.
+		if err := c.cacheStatus(status); err != nil {
+			logger.Debugf("cannot cache status: %v", err)
+		}
 	}
+	return c.formatStatus(ctx, status)
+}
+
+// writeCachedStatus writes out the status last fetched for the model in
+// the requested format, after noting when it was fetched.
+func (c *statusCommand) writeCachedStatus(ctx *cmd.Context) error {
+	cached, err := c.readCachedStatus()
+	if errors.IsNotFound(err) {
+		return errors.New("no status has been cached for this model")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var status params.FullStatus
+	if err := json.Unmarshal(cached.Status, &status); err != nil {
+		return errors.Annotate(err, "cannot read cached status")
+	}
+	age := time.Since(cached.Fetched) / time.Second * time.Second
+	ctx.Infof("Showing the status cached at %s (%v ago); it may be out of date.",
+		common.FormatTime(&cached.Fetched, c.isoTime), age)
+	return c.formatStatus(ctx, &status)
+}
 
+// readCachedStatus returns the status last fetched for the model.
+func (c *statusCommand) readCachedStatus() (*jujuclient.CachedStatus, error) {
+	controllerName, modelName, err := c.cacheKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jujuclient.ReadCachedStatus(controllerName, modelName)
+}
+
+// cacheStatus caches the status of the model, so that it can be
+// written out with --cached when the controller cannot be reached.
+func (c *statusCommand) cacheStatus(status *params.FullStatus) error {
+	controllerName, modelName, err := c.cacheKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return jujuclient.WriteCachedStatus(controllerName, modelName, jujuclient.CachedStatus{
+		Fetched: time.Now().UTC(),
+		Status:  data,
+	})
+}
+
+// cacheKey returns the names of the controller and model that status
+// is cached under.
+func (c *statusCommand) cacheKey() (controllerName, modelName string, err error) {
+	controllerName, err = c.ControllerName()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	modelName, err = c.ModelName()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return controllerName, modelName, nil
+}
+
+// formatStatus writes out the given status in the requested format.
+func (c *statusCommand) formatStatus(ctx *cmd.Context, status *params.FullStatus) error {
 	controllerName, err := c.ControllerName()
 	if err != nil {
 		return errors.Trace(err)
@@ -164,6 +292,45 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, formatted)
 }
 
+// getStatus returns the status of the model, fetched in pages if a page
+// size was specified and the controller supports it.
+func (c *statusCommand) getStatus(apiclient statusAPI) (*params.FullStatus, error) {
+	if c.pageSize == 0 {
+		return apiclient.Status(c.patterns)
+	}
+	var status *params.FullStatus
+	cursor := ""
+	for {
+		page, err := apiclient.StatusPage(c.patterns, cursor, c.pageSize)
+		if errors.IsNotSupported(err) && status == nil {
+			logger.Debugf("paginated status not supported, fetching full status")
+			return apiclient.Status(c.patterns)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if status == nil {
+			status = &page.Status
+			if status.Machines == nil {
+				status.Machines = make(map[string]params.MachineStatus)
+			}
+			if status.Applications == nil {
+				status.Applications = make(map[string]params.ApplicationStatus)
+			}
+		} else {
+			for id, machine := range page.Status.Machines {
+				status.Machines[id] = machine
+			}
+			for name, application := range page.Status.Applications {
+				status.Applications[name] = application
+			}
+		}
+		if page.NextCursor == "" {
+			return status, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	return FormatTabular(writer, c.color, value)
 }
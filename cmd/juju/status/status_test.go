@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -3849,7 +3850,9 @@ func (s *StatusSuite) setupMigrationTest(c *gc.C) *state.State {
 
 type fakeAPIClient struct {
 	statusReturn *params.FullStatus
+	pages        []params.FullStatusPage
 	patternsUsed []string
+	cursorsUsed  []string
 	closeCalled  bool
 }
 
@@ -3858,6 +3861,17 @@ func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) StatusPage(patterns []string, cursor string, limit int) (*params.FullStatusPage, error) {
+	a.patternsUsed = patterns
+	a.cursorsUsed = append(a.cursorsUsed, cursor)
+	if a.pages == nil {
+		return nil, errors.NotSupportedf("paginated status")
+	}
+	page := a.pages[0]
+	a.pages = a.pages[1:]
+	return &page, nil
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -4169,6 +4183,70 @@ Machine  State  DNS  Inst id  Series  AZ  Message
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularPortConflicts(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						JujuStatusInfo: statusInfoContents{
+							Current: status.Idle,
+						},
+						WorkloadStatusInfo: statusInfoContents{
+							Current: status.Active,
+							Message: "ready",
+						},
+						Machine:       "0",
+						PublicAddress: "10.0.0.1",
+						OpenedPorts:   []string{"80/tcp"},
+						PortConflicts: []string{`port range 80/tcp conflicts with 80/tcp opened by unit "bar/0" on machine "0"`},
+					},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Notes
+foo                       1                  0      
+
+Unit   Workload  Agent  Machine  Public address  Ports   Message
+foo/0  active    idle   0        10.0.0.1        80/tcp  ready (port conflict: port range 80/tcp conflicts with 80/tcp opened by unit "bar/0" on machine "0")
+
+Machine  State  DNS  Inst id  Series  AZ  Message
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatTabularModelDescription(c *gc.C) {
+	status := formattedStatus{
+		Model: modelStatus{
+			Name:        "hosted",
+			Controller:  "kontroll",
+			Cloud:       "dummy",
+			Version:     "2.3.0",
+			Description: "staging for the web team",
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model   Controller  Cloud/Region  Version  Description
+hosted  kontroll    dummy         2.3.0    staging for the web team
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Notes
+
+Unit  Workload  Agent  Machine  Public address  Ports  Message
+
+Machine  State  DNS  Inst id  Series  AZ  Message
+`[1:])
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -4197,6 +4275,255 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	c.Check(string(stderr), gc.Equals, "ERROR unable to obtain the current status\n")
 }
 
+func (s *StatusSuite) TestStatusPaged(c *gc.C) {
+	client := fakeAPIClient{
+		pages: []params.FullStatusPage{{
+			Status: params.FullStatus{
+				Model: params.ModelStatusInfo{Name: "paged"},
+				Machines: map[string]params.MachineStatus{
+					"0": {Id: "0", Series: "xenial"},
+				},
+			},
+			NextCursor: "machine-0",
+		}, {
+			Status: params.FullStatus{
+				Machines: map[string]params.MachineStatus{
+					"1": {Id: "1", Series: "xenial"},
+				},
+			},
+		}},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--format", "json", "--page-size", "1", "foo")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(client.cursorsUsed, jc.DeepEquals, []string{"", "machine-0"})
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"foo"})
+	var result formattedStatus
+	err := json.Unmarshal(stdout, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Model.Name, gc.Equals, "paged")
+	c.Check(result.Machines, gc.HasLen, 2)
+}
+
+func (s *StatusSuite) TestStatusPagedNotSupported(c *gc.C) {
+	client := fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{Name: "unpaged"},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--format", "json", "--page-size", "1")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(client.cursorsUsed, jc.DeepEquals, []string{""})
+	var result formattedStatus
+	err := json.Unmarshal(stdout, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Model.Name, gc.Equals, "unpaged")
+}
+
+func (s *StatusSuite) TestStatusInvalidPageSize(c *gc.C) {
+	code, _, stderr := runStatus(c, "--page-size", "-1")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR invalid page size -1\n")
+}
+
+// fakeAllWatcher returns the errors sent on next from Next, with
+// no deltas.
+type fakeAllWatcher struct {
+	next    chan error
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	return nil, <-w.next
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+// notifyingAPIClient sends on called whenever the status is fetched.
+type notifyingAPIClient struct {
+	fakeAPIClient
+	called chan struct{}
+}
+
+func (a *notifyingAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	a.called <- struct{}{}
+	return a.fakeAPIClient.Status(patterns)
+}
+
+func (s *StatusSuite) TestStatusWatch(c *gc.C) {
+	client := notifyingAPIClient{
+		fakeAPIClient: fakeAPIClient{
+			statusReturn: &params.FullStatus{
+				Model: params.ModelStatusInfo{Name: "watched"},
+			},
+		},
+		called: make(chan struct{}, 10),
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+	watcher := &fakeAllWatcher{next: make(chan error)}
+	s.PatchValue(&newAllWatcherForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	waitCalled := func() {
+		select {
+		case <-client.called:
+		case <-time.After(coretesting.LongWait):
+			c.Errorf("timed out waiting for status")
+		}
+	}
+	go func() {
+		// The initial state of the model is output at once, and
+		// the burst of changes that follows is output only once.
+		watcher.next <- nil
+		waitCalled()
+		for i := 0; i < 5; i++ {
+			watcher.next <- nil
+		}
+		waitCalled()
+		watcher.next <- errors.New("boom")
+	}()
+
+	code, stdout, stderr := runStatus(c, "--format", "json", "--watch", "--interval", "100ms")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "ERROR watching for changes: boom\n")
+	c.Check(strings.Count(string(stdout), `{"model":{"name":"watched"`), gc.Equals, 2)
+	c.Check(watcher.stopped, jc.IsTrue)
+	c.Check(client.closeCalled, jc.IsTrue)
+}
+
+type pollingAPIClient struct {
+	fakeAPIClient
+	calls int
+}
+
+func (a *pollingAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	a.calls++
+	if a.calls > 3 {
+		return nil, errors.New("boom")
+	}
+	return a.fakeAPIClient.Status(patterns)
+}
+
+func (s *StatusSuite) TestStatusWatchPolling(c *gc.C) {
+	client := pollingAPIClient{
+		fakeAPIClient: fakeAPIClient{
+			statusReturn: &params.FullStatus{
+				Model: params.ModelStatusInfo{Name: "polled"},
+			},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--format", "json", "--watch", "--interval", "10ms")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, ""+
+		"cannot watch for changes, polling every 10ms: watching for changes not supported\n"+
+		"ERROR boom\n")
+	c.Check(strings.Count(string(stdout), `{"model":{"name":"polled"`), gc.Equals, 3)
+}
+
+func (s *StatusSuite) TestStatusInvalidInterval(c *gc.C) {
+	code, _, stderr := runStatus(c, "--watch", "--interval", "0s")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR invalid interval 0s\n")
+}
+
+func (s *StatusSuite) TestStatusCached(c *gc.C) {
+	client := fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{Name: "cached"},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+	code, _, stderr := runStatus(c, "--format", "json")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		c.Fatalf("unexpected connection to the controller")
+		return nil, nil
+	})
+	code, stdout, stderr := runStatus(c, "--format", "json", "--cached", "--utc")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(string(stderr), gc.Matches,
+		`Showing the status cached at \d{4}-\d\d-\d\d \d\d:\d\d:\d\dZ \(\d+s ago\); it may be out of date.\n`)
+	var result formattedStatus
+	err := json.Unmarshal(stdout, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Model.Name, gc.Equals, "cached")
+}
+
+func (s *StatusSuite) TestStatusCachedNone(c *gc.C) {
+	code, _, stderr := runStatus(c, "--cached")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "ERROR no status has been cached for this model\n")
+}
+
+func (s *StatusSuite) TestStatusFilteredNotCached(c *gc.C) {
+	client := fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{Name: "filtered"},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+	code, _, stderr := runStatus(c, "--format", "json", "mysql")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+
+	code, _, stderr = runStatus(c, "--cached")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "ERROR no status has been cached for this model\n")
+}
+
+func (s *StatusSuite) TestStatusUnreachableSuggestsCached(c *gc.C) {
+	client := fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{Name: "cached"},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+	code, _, stderr := runStatus(c, "--format", "json")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return nil, errors.New("connection refused")
+	})
+	code, _, stderr = runStatus(c)
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, ""+
+		"The controller cannot be reached; use --cached to show the last status fetched from it.\n"+
+		"ERROR connection refused\n")
+}
+
+func (s *StatusSuite) TestStatusCachedInvalidArgs(c *gc.C) {
+	code, _, stderr := runStatus(c, "--cached", "--watch")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR --cached and --watch cannot be used together\n")
+
+	code, _, stderr = runStatus(c, "--cached", "mysql")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR filter patterns cannot be used with --cached\n")
+}
+
 func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// allWatcher is the part of api.AllWatcher used to watch for changes
+// to the model.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching for changes")
+	}
+	return client.WatchAll()
+}
+
+// clearScreen moves the cursor to the top left of the terminal and
+// clears it.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// watchStatus writes out the status of the model whenever it changes,
+// until interrupted. Changes are coalesced so that the status is
+// fetched and written out at most once per the command's interval,
+// however busy the model is. If changes to the model cannot be
+// watched, the status is written out at the interval instead.
+func (c *statusCommand) watchStatus(ctx *cmd.Context, apiclient statusAPI) error {
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var changes <-chan error
+	var poll <-chan time.Time
+	watcher, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		fmt.Fprintf(ctx.Stderr, "cannot watch for changes, polling every %v: %v\n", c.interval, err)
+		poll = time.After(0)
+	} else {
+		done := make(chan struct{})
+		defer close(done)
+		defer watcher.Stop()
+		// The watcher's first changes hold the initial state of the
+		// model, so the status is first written out when they arrive.
+		changes = watchChanges(watcher, done)
+	}
+
+	clear := c.out.Name() == "tabular" && isTerminal(ctx.Stdout)
+	// pending is true when the status has changed since it was last
+	// written out, and wait is set until the interval since then has
+	// passed.
+	var pending bool
+	var wait <-chan time.Time
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case err := <-changes:
+			if err != nil {
+				return errors.Annotate(err, "watching for changes")
+			}
+			pending = true
+		case <-poll:
+			poll = time.After(c.interval)
+			pending = true
+		case <-wait:
+			wait = nil
+		}
+		if !pending || wait != nil {
+			continue
+		}
+		pending = false
+		wait = time.After(c.interval)
+		if clear {
+			fmt.Fprint(ctx.Stdout, clearScreen)
+		}
+		if err := c.writeStatus(ctx, apiclient); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// watchChanges returns a channel on which a nil error is sent whenever
+// the watcher reports changes, until the watcher fails or done is
+// closed. The watcher's error is sent when it fails.
+func watchChanges(watcher allWatcher, done <-chan struct{}) <-chan error {
+	changes := make(chan error)
+	go func() {
+		for {
+			_, err := watcher.Next()
+			select {
+			case changes <- err:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return changes
+}
+
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd())
+}
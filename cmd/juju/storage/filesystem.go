@@ -53,9 +53,20 @@ type FilesystemAttachments struct {
 }
 
 type MachineFilesystemAttachment struct {
-	MountPoint string `yaml:"mount-point" json:"mount-point"`
-	ReadOnly   bool   `yaml:"read-only" json:"read-only"`
-	Life       string `yaml:"life,omitempty" json:"life,omitempty"`
+	MountPoint string           `yaml:"mount-point" json:"mount-point"`
+	ReadOnly   bool             `yaml:"read-only" json:"read-only"`
+	Life       string           `yaml:"life,omitempty" json:"life,omitempty"`
+	Usage      *FilesystemUsage `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// FilesystemUsage describes the utilization of an attached filesystem,
+// as last reported by the machine agent. Sizes are in MiB.
+type FilesystemUsage struct {
+	Size        uint64 `yaml:"size" json:"size"`
+	Used        uint64 `yaml:"used" json:"used"`
+	Available   uint64 `yaml:"available" json:"available"`
+	PercentUsed int    `yaml:"percent-used" json:"percent-used"`
+	Updated     string `yaml:"updated" json:"updated"`
 }
 
 // generateListFilesystemOutput returns a map filesystem IDs to filesystem info
@@ -132,6 +143,7 @@ func createFilesystemInfo(details params.FilesystemDetails) (names.FilesystemTag
 				attachment.MountPoint,
 				attachment.ReadOnly,
 				string(attachment.Life),
+				createFilesystemUsage(attachment.Usage),
 			}
 		}
 		info.Attachments = &FilesystemAttachments{
@@ -155,3 +167,21 @@ func createFilesystemInfo(details params.FilesystemDetails) (names.FilesystemTag
 
 	return filesystemTag, info, nil
 }
+
+func createFilesystemUsage(usage *params.FilesystemUsage) *FilesystemUsage {
+	if usage == nil {
+		return nil
+	}
+	var percentUsed int
+	if usable := usage.Used + usage.Available; usable > 0 {
+		// Round up, as df(1) does.
+		percentUsed = int((usage.Used*100 + usable - 1) / usable)
+	}
+	return &FilesystemUsage{
+		Size:        usage.Size,
+		Used:        usage.Used,
+		Available:   usage.Available,
+		PercentUsed: percentUsed,
+		Updated:     common.FormatTime(&usage.Updated, true),
+	}
+}
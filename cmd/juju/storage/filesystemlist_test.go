@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -116,6 +117,40 @@ func (s *ListSuite) TestFilesystemListTabular(c *gc.C) {
 	s.assertValidFilesystemList(c, []string{}, expectedFilesystemListTabular)
 }
 
+var expectedFilesystemUsageTabular = `
+[Filesystem usage]
+Machine  Unit         Storage      Id   Mountpoint  Size    Used    Available  Use%  Updated
+0        abc/0        db-dir/1001  0/0  /mnt/fuji   512MiB  384MiB  128MiB     75%   2017-10-01 12:00:00Z
+0        transcode/0  shared-fs/0  4    /mnt/doom                                    not reported
+0                                  1                                                 not reported
+1        transcode/1  shared-fs/0  4    /mnt/huang                                   not reported
+1                                  2    /mnt/zion   3.0MiB  3.0MiB  0B         100%  2017-10-01 12:05:00Z
+1                                  3                                                 not reported
+
+`[1:]
+
+func (s *ListSuite) TestFilesystemListUtilizationTabular(c *gc.C) {
+	s.assertValidFilesystemList(c, []string{"--utilization"}, expectedFilesystemUsageTabular)
+}
+
+func (s *ListSuite) TestFilesystemListUtilizationYaml(c *gc.C) {
+	context, err := s.runFilesystemList(c, "--utilization", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var result struct {
+		Filesystems map[string]storage.FilesystemInfo
+	}
+	err = goyaml.Unmarshal([]byte(cmdtesting.Stdout(context)), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Filesystems["0/0"].Attachments.Machines["0"].Usage, jc.DeepEquals, &storage.FilesystemUsage{
+		Size:        512,
+		Used:        384,
+		Available:   128,
+		PercentUsed: 75,
+		Updated:     "2017-10-01 12:00:00Z",
+	})
+}
+
 func (s *ListSuite) assertUnmarshalledOutput(c *gc.C, unmarshal unmarshaller, expectedErr string, args ...string) {
 	context, err := s.runFilesystemList(c, args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -192,6 +227,12 @@ func (s mockListAPI) ListFilesystems(machines []string) ([]params.FilesystemDeta
 					FilesystemAttachmentInfo: params.FilesystemAttachmentInfo{
 						MountPoint: "/mnt/fuji",
 					},
+					Usage: &params.FilesystemUsage{
+						Size:      512,
+						Used:      384,
+						Available: 128,
+						Updated:   time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC),
+					},
 				},
 			},
 			Storage: &params.StorageDetails{
@@ -249,6 +290,12 @@ func (s mockListAPI) ListFilesystems(machines []string) ([]params.FilesystemDeta
 					FilesystemAttachmentInfo: params.FilesystemAttachmentInfo{
 						MountPoint: "/mnt/zion",
 					},
+					Usage: &params.FilesystemUsage{
+						Size:      3,
+						Used:      3,
+						Available: 0,
+						Updated:   time.Date(2017, 10, 1, 12, 5, 0, 0, time.UTC),
+					},
 				},
 			},
 		},
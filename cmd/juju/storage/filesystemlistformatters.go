@@ -24,6 +24,64 @@ func formatFilesystemListTabular(writer io.Writer, infos map[string]FilesystemIn
 	print("[Filesystems]")
 	print("Machine", "Unit", "Storage", "Id", "Volume", "Provider id", "Mountpoint", "Size", "State", "Message")
 
+	for _, info := range flattenFilesystemAttachments(infos) {
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(
+			info.MachineId, info.UnitId, info.Storage,
+			info.FilesystemId, info.Volume, info.ProviderFilesystemId,
+			info.MountPoint, size,
+			string(info.Status.Current), info.Status.Message,
+		)
+	}
+
+	return tw.Flush()
+}
+
+// formatFilesystemUsageTabular writes a tabular summary of the
+// utilization of the attached filesystems.
+func formatFilesystemUsageTabular(writer io.Writer, infos map[string]FilesystemInfo) error {
+	tw := output.TabWriter(writer)
+
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("[Filesystem usage]")
+	print("Machine", "Unit", "Storage", "Id", "Mountpoint", "Size", "Used", "Available", "Use%", "Updated")
+
+	for _, info := range flattenFilesystemAttachments(infos) {
+		if info.MachineId == "" {
+			continue
+		}
+		usage := info.Usage
+		if usage == nil {
+			print(
+				info.MachineId, info.UnitId, info.Storage,
+				info.FilesystemId, info.MountPoint,
+				"", "", "", "", "not reported",
+			)
+			continue
+		}
+		print(
+			info.MachineId, info.UnitId, info.Storage,
+			info.FilesystemId, info.MountPoint,
+			humanize.IBytes(usage.Size*humanize.MiByte),
+			humanize.IBytes(usage.Used*humanize.MiByte),
+			humanize.IBytes(usage.Available*humanize.MiByte),
+			fmt.Sprintf("%d%%", usage.PercentUsed),
+			usage.Updated,
+		)
+	}
+
+	return tw.Flush()
+}
+
+// flattenFilesystemAttachments returns a sorted entry for each machine
+// attachment of the given filesystems, along with the corresponding
+// unit attachment if any, and an entry for each unattached filesystem.
+func flattenFilesystemAttachments(infos map[string]FilesystemInfo) filesystemAttachmentInfos {
 	filesystemAttachmentInfos := make(filesystemAttachmentInfos, 0, len(infos))
 	for filesystemId, info := range infos {
 		filesystemAttachmentInfo := filesystemAttachmentInfo{
@@ -54,21 +112,7 @@ func formatFilesystemListTabular(writer io.Writer, infos map[string]FilesystemIn
 		}
 	}
 	sort.Sort(filesystemAttachmentInfos)
-
-	for _, info := range filesystemAttachmentInfos {
-		var size string
-		if info.Size > 0 {
-			size = humanize.IBytes(info.Size * humanize.MiByte)
-		}
-		print(
-			info.MachineId, info.UnitId, info.Storage,
-			info.FilesystemId, info.Volume, info.ProviderFilesystemId,
-			info.MountPoint, size,
-			string(info.Status.Current), info.Status.Message,
-		)
-	}
-
-	return tw.Flush()
+	return filesystemAttachmentInfos
 }
 
 type filesystemAttachmentInfo struct {
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewGrowStorageCommandWithAPI returns a command
+// used to grow storage instances.
+func NewGrowStorageCommandWithAPI() cmd.Command {
+	cmd := &growStorageCommand{}
+	cmd.newStorageGrowerCloser = func() (StorageGrowerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewGrowStorageCommand returns a command used to
+// grow storage instances.
+func NewGrowStorageCommand(new NewStorageGrowerCloserFunc) cmd.Command {
+	cmd := &growStorageCommand{}
+	cmd.newStorageGrowerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	growStorageCommandDoc = `
+Grows a storage instance to the specified size. Specify the unit/application
+storage ID, as output by "juju storage", and the new size, which must be
+larger than the current size.
+
+The volume backing the storage is grown by the storage provider. If the
+storage is a filesystem, the filesystem is then grown to fill the volume.
+Only volumes managed by the model, rather than by a single machine, can
+be grown, and only if the storage provider supports it.
+
+Examples:
+    juju grow-storage pgdata/0 100G
+`
+
+	growStorageCommandArgs = `<storage> <size>`
+)
+
+// growStorageCommand grows storage instances.
+type growStorageCommand struct {
+	StorageCommandBase
+	newStorageGrowerCloser NewStorageGrowerCloserFunc
+	storageId              string
+	size                   uint64
+}
+
+// Init implements Command.Init.
+func (c *growStorageCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("grow-storage requires a storage ID and a size")
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "parsing size")
+	}
+	if size == 0 {
+		return errors.New("size must be greater than zero")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *growStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "grow-storage",
+		Purpose: "Grows storage to a larger size.",
+		Doc:     growStorageCommandDoc,
+		Args:    growStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *growStorageCommand) Run(ctx *cmd.Context) error {
+	grower, err := c.newStorageGrowerCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer grower.Close()
+
+	if err := grower.Grow(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "grow storage")
+		}
+		return err
+	}
+	ctx.Infof("growing %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// NewStorageGrowerCloserFunc is the type of a function that returns a
+// StorageGrowerCloser.
+type NewStorageGrowerCloserFunc func() (StorageGrowerCloser, error)
+
+// StorageGrowerCloser extends StorageGrower with a Closer method.
+type StorageGrowerCloser interface {
+	StorageGrower
+	Close() error
+}
+
+// StorageGrower defines an interface for growing storage with the
+// specified ID.
+type StorageGrower interface {
+	Grow(storageId string, size uint64) error
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type GrowStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&GrowStorageSuite{})
+
+func (s *GrowStorageSuite) TestGrow(c *gc.C) {
+	var fake fakeStorageGrower
+	cmd := storage.NewGrowStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "2G")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageGrowerCloser", "Grow", "Close")
+	fake.CheckCall(c, 1, "Grow", "foo/0", uint64(2048))
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "growing foo/0 to 2048MiB\n")
+}
+
+func (s *GrowStorageSuite) TestGrowError(c *gc.C) {
+	var fake fakeStorageGrower
+	fake.SetErrors(nil, errors.New("new size 2048MiB must be larger than current size 4096MiB"))
+	cmd := storage.NewGrowStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "foo/0", "2G")
+	c.Assert(err, gc.ErrorMatches, "new size 2048MiB must be larger than current size 4096MiB")
+	fake.CheckCallNames(c, "NewStorageGrowerCloser", "Grow", "Close")
+}
+
+func (s *GrowStorageSuite) TestGrowUnauthorizedError(c *gc.C) {
+	var fake fakeStorageGrower
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewGrowStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "2G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to grow storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *GrowStorageSuite) TestGrowInitErrors(c *gc.C) {
+	s.testGrowInitError(c, []string{}, "grow-storage requires a storage ID and a size")
+	s.testGrowInitError(c, []string{"foo/0"}, "grow-storage requires a storage ID and a size")
+	s.testGrowInitError(c, []string{"foo/0", "2G", "3G"}, "grow-storage requires a storage ID and a size")
+	s.testGrowInitError(c, []string{"foo/0", "lots"}, `parsing size: .*`)
+	s.testGrowInitError(c, []string{"foo/0", "0"}, "size must be greater than zero")
+}
+
+func (s *GrowStorageSuite) testGrowInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewGrowStorageCommand(nil)
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageGrower struct {
+	testing.Stub
+}
+
+func (f *fakeStorageGrower) new() (storage.StorageGrowerCloser, error) {
+	f.MethodCall(f, "NewStorageGrowerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageGrower) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageGrower) Grow(storageId string, size uint64) error {
+	f.MethodCall(f, "Grow", storageId, size)
+	return f.NextErr()
+}
@@ -26,16 +26,27 @@ func NewListCommand() cmd.Command {
 
 const listCommandDoc = `
 List information about storage.
+
+With --utilization, the size, used and available space of each attached
+filesystem is listed, as last reported by the machine agents. Unit
+status warns when a filesystem is nearly full if the model's
+storage-usage-warning-threshold is set.
+
+Examples:
+
+    juju storage --utilization
+    juju storage --utilization --format yaml
 `
 
 // listCommand returns storage instances.
 type listCommand struct {
 	StorageCommandBase
-	out        cmd.Output
-	ids        []string
-	filesystem bool
-	volume     bool
-	newAPIFunc func() (StorageListAPI, error)
+	out         cmd.Output
+	ids         []string
+	filesystem  bool
+	volume      bool
+	utilization bool
+	newAPIFunc  func() (StorageListAPI, error)
 }
 
 // Info implements Command.Info.
@@ -61,6 +72,7 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	// for listing just filesystems or volumes.
 	f.BoolVar(&c.filesystem, "filesystem", false, "List filesystem storage")
 	f.BoolVar(&c.volume, "volume", false, "List volume storage")
+	f.BoolVar(&c.utilization, "utilization", false, "List the utilization of attached filesystems")
 }
 
 // Init implements Command.Init.
@@ -68,6 +80,12 @@ func (c *listCommand) Init(args []string) (err error) {
 	if c.filesystem && c.volume {
 		return errors.New("--filesystem and --volume can not be used together")
 	}
+	if c.utilization && c.volume {
+		return errors.New("--utilization and --volume can not be used together")
+	}
+	if c.utilization {
+		c.filesystem = true
+	}
 	if len(args) > 0 && !c.filesystem && !c.volume {
 		return errors.New("specifying IDs only supported with --filesystem and --volume flags")
 	}
@@ -95,7 +113,7 @@ func (c *listCommand) Run(ctx *cmd.Context) (err error) {
 		wantFilesystems = true
 	}
 
-	var combined combinedStorage
+	combined := combinedStorage{utilization: c.utilization}
 	if wantFilesystems {
 		filesystems, err := generateListFilesystemsOutput(ctx, api, c.ids)
 		if err != nil {
@@ -150,6 +168,10 @@ type combinedStorage struct {
 	StorageInstances map[string]StorageInfo    `yaml:"storage,omitempty" json:"storage,omitempty"`
 	Filesystems      map[string]FilesystemInfo `yaml:"filesystems,omitempty" json:"filesystems,omitempty"`
 	Volumes          map[string]VolumeInfo     `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+
+	// utilization records whether the utilization of the
+	// filesystems is to be listed in tabular format.
+	utilization bool
 }
 
 func (c *combinedStorage) empty() bool {
@@ -158,6 +180,9 @@ func (c *combinedStorage) empty() bool {
 
 func formatListTabular(writer io.Writer, value interface{}) error {
 	combined := value.(combinedStorage)
+	if combined.utilization {
+		return formatFilesystemUsageTabular(writer, combined.Filesystems)
+	}
 	var newline bool
 	if len(combined.StorageInstances) > 0 {
 		// If we're listing storage in tabular format, we combine all
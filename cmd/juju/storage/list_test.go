@@ -127,6 +127,12 @@ filesystems:
           mount-point: /mnt/fuji
           read-only: false
           life: alive
+          usage:
+            size: 512
+            used: 384
+            available: 128
+            percent-used: 75
+            updated: "?2017-10-01 12:00:00Z"?
       units:
         abc/0:
           machine: "0"
@@ -155,6 +161,12 @@ filesystems:
         "1":
           mount-point: /mnt/zion
           read-only: false
+          usage:
+            size: 3
+            used: 3
+            available: 0
+            percent-used: 100
+            updated: "?2017-10-01 12:05:00Z"?
     size: 3
     status:
       current: attached
@@ -287,6 +299,7 @@ volumes:
 
 func (s *ListSuite) TestListInitErrors(c *gc.C) {
 	s.testListInitError(c, []string{"--filesystem", "--volume"}, "--filesystem and --volume can not be used together")
+	s.testListInitError(c, []string{"--utilization", "--volume"}, "--utilization and --volume can not be used together")
 	s.testListInitError(c, []string{"storage-id"}, "specifying IDs only supported with --filesystem and --volume flags")
 }
 
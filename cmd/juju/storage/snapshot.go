@@ -0,0 +1,360 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewSnapshotStorageCommandWithAPI returns a command
+// used to snapshot storage instances.
+func NewSnapshotStorageCommandWithAPI() cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSnapshotStorageCommand returns a command used to
+// snapshot storage instances.
+func NewSnapshotStorageCommand(new NewStorageSnapshotterCloserFunc) cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	snapshotStorageCommandDoc = `
+Snapshots the volume underlying a storage instance. Specify the
+unit/application storage ID, as output by "juju storage".
+
+The snapshot is taken by the storage provider, while the storage remains
+attached. Filesystem storage can only be snapshotted if it is backed by a
+volume. New storage can be restored from the snapshot with
+"juju restore-storage".
+
+Examples:
+    juju snapshot-storage pgdata/0
+
+See also:
+    storage-snapshots
+    restore-storage
+`
+
+	snapshotStorageCommandArgs = `<storage>`
+)
+
+// snapshotStorageCommand snapshots storage instances.
+type snapshotStorageCommand struct {
+	StorageCommandBase
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	storageId                   string
+}
+
+// Init implements Command.Init.
+func (c *snapshotStorageCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.New("snapshot-storage requires a storage ID")
+	}
+	c.storageId = args[0]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Snapshots storage.",
+		Doc:     snapshotStorageCommandDoc,
+		Args:    snapshotStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *snapshotStorageCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snapshotter.Close()
+
+	snapshot, err := snapshotter.CreateSnapshot(c.storageId)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	ctx.Infof("created snapshot %s of %s", snapshot.Id, c.storageId)
+	return nil
+}
+
+// NewListStorageSnapshotsCommandWithAPI returns a command
+// used to list storage snapshots.
+func NewListStorageSnapshotsCommandWithAPI() cmd.Command {
+	cmd := &listStorageSnapshotsCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewListStorageSnapshotsCommand returns a command used to
+// list storage snapshots.
+func NewListStorageSnapshotsCommand(new NewStorageSnapshotterCloserFunc) cmd.Command {
+	cmd := &listStorageSnapshotsCommand{}
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const listStorageSnapshotsCommandDoc = `
+Lists the storage snapshots in the model, as created by
+"juju snapshot-storage".
+
+See also:
+    snapshot-storage
+    restore-storage
+`
+
+// listStorageSnapshotsCommand lists storage snapshots.
+type listStorageSnapshotsCommand struct {
+	StorageCommandBase
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	out                         cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listStorageSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists storage snapshots.",
+		Doc:     listStorageSnapshotsCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listStorageSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listStorageSnapshotsCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snapshotter.Close()
+
+	snapshots, err := snapshotter.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	return c.out.Write(ctx, formatSnapshotInfo(snapshots))
+}
+
+// SnapshotInfo defines the serialization behaviour of storage snapshot
+// information.
+type SnapshotInfo struct {
+	Storage    string `yaml:"storage" json:"storage"`
+	Volume     string `yaml:"volume" json:"volume"`
+	Kind       string `yaml:"kind" json:"kind"`
+	Pool       string `yaml:"pool" json:"pool"`
+	Size       uint64 `yaml:"size" json:"size"`
+	ProviderId string `yaml:"provider-id" json:"provider-id"`
+	Created    string `yaml:"created" json:"created"`
+}
+
+func formatSnapshotInfo(all []params.StorageSnapshotDetails) map[string]SnapshotInfo {
+	output := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		info := SnapshotInfo{
+			Kind:       one.Kind.String(),
+			Pool:       one.Pool,
+			Size:       one.Size,
+			ProviderId: one.SnapshotId,
+			Created:    common.FormatTime(&one.Created, true),
+		}
+		if tag, err := names.ParseStorageTag(one.StorageTag); err == nil {
+			info.Storage = tag.Id()
+		}
+		if tag, err := names.ParseVolumeTag(one.VolumeTag); err == nil {
+			info.Volume = tag.Id()
+		}
+		output[one.Id] = info
+	}
+	return output
+}
+
+// formatSnapshotListTabular writes a tabular summary of storage
+// snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Snapshot", "Storage", "Volume", "Kind", "Pool", "Size", "Provider id", "Created")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+	for _, id := range ids {
+		snapshot := snapshots[id]
+		print(
+			id,
+			snapshot.Storage,
+			snapshot.Volume,
+			snapshot.Kind,
+			snapshot.Pool,
+			humanize.IBytes(snapshot.Size*humanize.MiByte),
+			snapshot.ProviderId,
+			snapshot.Created,
+		)
+	}
+	return tw.Flush()
+}
+
+// NewRestoreStorageCommandWithAPI returns a command
+// used to restore storage from snapshots.
+func NewRestoreStorageCommandWithAPI() cmd.Command {
+	cmd := &restoreStorageCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRestoreStorageCommand returns a command used to
+// restore storage from snapshots.
+func NewRestoreStorageCommand(new NewStorageSnapshotterCloserFunc) cmd.Command {
+	cmd := &restoreStorageCommand{}
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	restoreStorageCommandDoc = `
+Restores new storage from a storage snapshot. Specify the snapshot ID,
+as output by "juju storage-snapshots".
+
+The storage provider creates a new volume from the snapshot, which is
+added to the model as detached storage, with the same name and kind as
+the storage that was snapshotted. The snapshotted storage is unaffected.
+The new storage may be attached to a new unit with
+"juju add-unit --attach-storage".
+
+Examples:
+    juju restore-storage 3
+
+See also:
+    snapshot-storage
+    storage-snapshots
+    add-unit
+`
+
+	restoreStorageCommandArgs = `<snapshot>`
+)
+
+// restoreStorageCommand restores storage from snapshots.
+type restoreStorageCommand struct {
+	StorageCommandBase
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	snapshotId                  string
+}
+
+// Init implements Command.Init.
+func (c *restoreStorageCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.New("restore-storage requires a snapshot ID")
+	}
+	c.snapshotId = args[0]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *restoreStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-storage",
+		Purpose: "Restores storage from a snapshot.",
+		Doc:     restoreStorageCommandDoc,
+		Args:    restoreStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *restoreStorageCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snapshotter.Close()
+
+	storageTag, err := snapshotter.RestoreSnapshot(c.snapshotId)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "restore storage")
+		}
+		return err
+	}
+	ctx.Infof(
+		"restored snapshot %s as storage %s; attach it to a new unit with:\n"+
+			"  juju add-unit <application> --attach-storage %s",
+		c.snapshotId, storageTag.Id(), storageTag.Id(),
+	)
+	return nil
+}
+
+// NewStorageSnapshotterCloserFunc is the type of a function that
+// returns a StorageSnapshotterCloser.
+type NewStorageSnapshotterCloserFunc func() (StorageSnapshotterCloser, error)
+
+// StorageSnapshotterCloser extends StorageSnapshotter with a Closer
+// method.
+type StorageSnapshotterCloser interface {
+	StorageSnapshotter
+	Close() error
+}
+
+// StorageSnapshotter defines an interface for snapshotting storage,
+// and restoring storage from snapshots.
+type StorageSnapshotter interface {
+	CreateSnapshot(storageId string) (params.StorageSnapshotDetails, error)
+	ListSnapshots() ([]params.StorageSnapshotDetails, error)
+	RestoreSnapshot(snapshotId string) (names.StorageTag, error)
+}
@@ -0,0 +1,194 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SnapshotStorageSuite{})
+
+func (s *SnapshotStorageSuite) TestSnapshot(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "CreateSnapshot", "Close")
+	fake.CheckCall(c, 1, "CreateSnapshot", "foo/0")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "created snapshot 3 of foo/0\n")
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, errors.New("snapshotting volume with storage provider \"loop\" not supported"))
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "foo/0")
+	c.Assert(err, gc.ErrorMatches, `snapshotting volume with storage provider "loop" not supported`)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "CreateSnapshot", "Close")
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotUnauthorizedError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to snapshot storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotInitErrors(c *gc.C) {
+	for _, args := range [][]string{{}, {"foo/0", "bar/1"}} {
+		cmd := storage.NewSnapshotStorageCommand(nil)
+		_, err := cmdtesting.RunCommand(c, cmd, args...)
+		c.Assert(err, gc.ErrorMatches, "snapshot-storage requires a storage ID")
+	}
+}
+
+func (s *SnapshotStorageSuite) TestListSnapshotsTabular(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	cmd := storage.NewListStorageSnapshotsCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "ListSnapshots", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Snapshot  Storage  Volume  Kind        Pool    Size    Provider id  Created
+2         foo/0    0       filesystem  cinder  1.0GiB  snap-2       2017-11-01 12:00:00Z
+10        foo/0    0       filesystem  cinder  10GiB   snap-10      2017-11-02 12:00:00Z
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestListSnapshotsYAML(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	cmd := storage.NewListStorageSnapshotsCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"2":
+  storage: foo/0
+  volume: "0"
+  kind: filesystem
+  pool: cinder
+  size: 1024
+  provider-id: snap-2
+  created: 2017-11-01 12:00:00Z
+"10":
+  storage: foo/0
+  volume: "0"
+  kind: filesystem
+  pool: cinder
+  size: 10240
+  provider-id: snap-10
+  created: 2017-11-02 12:00:00Z
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestListSnapshotsEmpty(c *gc.C) {
+	fake := fakeStorageSnapshotter{empty: true}
+	cmd := storage.NewListStorageSnapshotsCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *SnapshotStorageSuite) TestRestore(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	cmd := storage.NewRestoreStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "3")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "RestoreSnapshot", "Close")
+	fake.CheckCall(c, 1, "RestoreSnapshot", "3")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+restored snapshot 3 as storage foo/1; attach it to a new unit with:
+  juju add-unit <application> --attach-storage foo/1
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestRestoreError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, errors.New(`volume snapshot "3" not found`))
+	cmd := storage.NewRestoreStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "3")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "3" not found`)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "RestoreSnapshot", "Close")
+}
+
+func (s *SnapshotStorageSuite) TestRestoreInitErrors(c *gc.C) {
+	for _, args := range [][]string{{}, {"3", "4"}} {
+		cmd := storage.NewRestoreStorageCommand(nil)
+		_, err := cmdtesting.RunCommand(c, cmd, args...)
+		c.Assert(err, gc.ErrorMatches, "restore-storage requires a snapshot ID")
+	}
+}
+
+type fakeStorageSnapshotter struct {
+	testing.Stub
+	empty bool
+}
+
+func (f *fakeStorageSnapshotter) new() (storage.StorageSnapshotterCloser, error) {
+	f.MethodCall(f, "NewStorageSnapshotterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) CreateSnapshot(storageId string) (params.StorageSnapshotDetails, error) {
+	f.MethodCall(f, "CreateSnapshot", storageId)
+	return params.StorageSnapshotDetails{Id: "3"}, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	f.MethodCall(f, "ListSnapshots")
+	if f.empty {
+		return nil, f.NextErr()
+	}
+	return []params.StorageSnapshotDetails{{
+		Id:          "10",
+		SnapshotId:  "snap-10",
+		StorageTag:  "storage-foo-0",
+		VolumeTag:   "volume-0",
+		StorageName: "foo",
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "cinder",
+		Size:        10240,
+		Created:     time.Date(2017, 11, 2, 12, 0, 0, 0, time.UTC),
+	}, {
+		Id:          "2",
+		SnapshotId:  "snap-2",
+		StorageTag:  "storage-foo-0",
+		VolumeTag:   "volume-0",
+		StorageName: "foo",
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "cinder",
+		Size:        1024,
+		Created:     time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+	}}, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) RestoreSnapshot(snapshotId string) (names.StorageTag, error) {
+	f.MethodCall(f, "RestoreSnapshot", snapshotId)
+	return names.NewStorageTag("foo/1"), f.NextErr()
+}
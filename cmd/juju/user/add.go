@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -26,12 +27,17 @@ A user unique registration string will be printed. This registration string
 must be used by the newly added user as supplied to 
 complete the registration process. 
 
+The registration string embeds the controller's addresses and CA
+certificate, and may only be used once. Use --token to print only the
+registration string, for example when passing it on by other means.
+
 Some machine providers will require the user to be in possession of certain
 credentials in order to create a model.
 
 Examples:
     juju add-user bob
     juju add-user --controller mycontroller bob
+    juju add-user --token bob
 
 See also:
     register
@@ -59,6 +65,7 @@ type addCommand struct {
 	api         AddUserAPI
 	User        string
 	DisplayName string
+	TokenOnly   bool
 }
 
 // Info implements Command.Info.
@@ -71,6 +78,11 @@ func (c *addCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.TokenOnly, "token", false, "Print only the registration string")
+}
+
 // Init implements Command.Init.
 func (c *addCommand) Init(args []string) error {
 	if len(args) == 0 {
@@ -119,6 +131,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Annotate(err, "generating controller user access token")
 	}
+	if c.TokenOnly {
+		fmt.Fprintln(ctx.Stdout, base64RegistrationData)
+		return nil
+	}
 	fmt.Fprintf(ctx.Stdout, "User %q added\n", displayName)
 	fmt.Fprintf(ctx.Stdout, "Please send this command to %v:\n", c.User)
 	fmt.Fprintf(ctx.Stdout, "    juju register %s\n",
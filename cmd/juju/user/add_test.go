@@ -4,6 +4,9 @@
 package user_test
 
 import (
+	"encoding/asn1"
+	"encoding/base64"
+	"regexp"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -17,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
 )
 
@@ -89,12 +93,13 @@ func (s *UserAddCommandSuite) TestAddUserWithUsername(c *gc.C) {
 	expected := `
 User "foobar" added
 Please send this command to foobar:
-    juju register MEQTBmZvb2JhcjAPEw0wLjEuMi4zOjEyMzQ1BCBYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWBMHdGVzdGluZwAA
+    juju register (.+)
 
 "foobar" has not been granted access to any models. You can use "juju grant" to grant access.
 `[1:]
-	c.Assert(cmdtesting.Stdout(context), gc.Equals, expected)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, expected)
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+	s.assertRegistrationString(c, registrationString(c, cmdtesting.Stdout(context)))
 }
 
 func (s *UserAddCommandSuite) TestAddUserWithUsernameAndDisplayname(c *gc.C) {
@@ -103,28 +108,66 @@ func (s *UserAddCommandSuite) TestAddUserWithUsernameAndDisplayname(c *gc.C) {
 	c.Assert(s.mockAPI.username, gc.Equals, "foobar")
 	c.Assert(s.mockAPI.displayname, gc.Equals, "Foo Bar")
 	expected := `
-User "Foo Bar (foobar)" added
+User "Foo Bar \(foobar\)" added
 Please send this command to foobar:
-    juju register MEQTBmZvb2JhcjAPEw0wLjEuMi4zOjEyMzQ1BCBYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWBMHdGVzdGluZwAA
+    juju register (.+)
 
-"Foo Bar (foobar)" has not been granted access to any models. You can use "juju grant" to grant access.
+"Foo Bar \(foobar\)" has not been granted access to any models. You can use "juju grant" to grant access.
 `[1:]
-	c.Assert(cmdtesting.Stdout(context), gc.Equals, expected)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, expected)
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+	s.assertRegistrationString(c, registrationString(c, cmdtesting.Stdout(context)))
 }
 
 func (s *UserAddCommandSuite) TestUserRegistrationString(c *gc.C) {
-	// Ensure that the user registration string only contains alphanumerics.
+	// Ensure that the user registration string only contains
+	// characters that are easy to copy and paste in a terminal.
 	for i := 0; i < 3; i++ {
 		s.mockAPI.secretKey = []byte(strings.Repeat("X", 32+i))
 		context, err := s.run(c, "foobar", "Foo Bar")
 		c.Assert(err, jc.ErrorIsNil)
 		lines := strings.Split(cmdtesting.Stdout(context), "\n")
 		c.Assert(lines, gc.HasLen, 6)
-		c.Assert(lines[2], gc.Matches, `^\s+juju register [A-Za-z0-9]+$`)
+		c.Assert(lines[2], gc.Matches, `^\s+juju register [A-Za-z0-9_-]+$`)
 	}
 }
 
+func (s *UserAddCommandSuite) TestAddUserTokenOnly(c *gc.C) {
+	context, err := s.run(c, "--token", "foobar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.username, gc.Equals, "foobar")
+	stdout := cmdtesting.Stdout(context)
+	c.Assert(stdout, gc.Matches, "[A-Za-z0-9_-]+\n")
+	s.assertRegistrationString(c, strings.TrimSpace(stdout))
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
+// registrationString returns the registration string from the
+// output of "juju add-user".
+func registrationString(c *gc.C, stdout string) string {
+	match := regexp.MustCompile(`juju register (.+)`).FindStringSubmatch(stdout)
+	c.Assert(match, gc.HasLen, 2)
+	return match[1]
+}
+
+// assertRegistrationString asserts that the registration string
+// decodes to the registration information for user "foobar" on
+// the test controller, including its CA certificate.
+func (s *UserAddCommandSuite) assertRegistrationString(c *gc.C, registrationString string) {
+	data, err := base64.URLEncoding.DecodeString(registrationString)
+	c.Assert(err, jc.ErrorIsNil)
+	var info jujuclient.RegistrationInfo
+	_, err = asn1.Unmarshal(data, &info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, jujuclient.RegistrationInfo{
+		User:           "foobar",
+		Addrs:          []string{"0.1.2.3:12345"},
+		SecretKey:      s.mockAPI.secretKey,
+		ControllerName: "testing",
+		CACert:         testing.CACert,
+	})
+}
+
 type mockModelAPI struct{}
 
 func (m *mockModelAPI) ListModels(user string) ([]base.UserModel, error) {
@@ -31,6 +31,7 @@ func generateUserControllerAccessToken(command modelcmd.ControllerCommandBase, u
 		Addrs:          controllerDetails.APIEndpoints,
 		SecretKey:      secretKey,
 		ControllerName: controllerName,
+		CACert:         controllerDetails.CACert,
 	}
 	registrationData, err := asn1.Marshal(registrationInfo)
 	if err != nil {
@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The jujud-privhelper command runs the privileged operations of a
+// machine agent running as an unprivileged user. It is installed
+// setuid root; see the privsep package for the protocol.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/juju/utils/privsep"
+)
+
+func main() {
+	if len(os.Args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: jujud-privhelper < request")
+		os.Exit(2)
+	}
+	if err := privsep.RunHelper(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -41,6 +41,7 @@ var (
 		"action-pruner",
 		"charm-revision-updater",
 		"compute-provisioner",
+		"dns-updater",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -115,6 +116,7 @@ var (
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
 		"disk-manager",
+		// "fault-injection", not stable, exits when done
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
@@ -122,8 +124,10 @@ var (
 		"machiner",
 		"proxy-config-updater",
 		"reboot-executor",
+		"reverse-tunnel",
 		"ssh-authkeys-updater",
 		"storage-provisioner",
+		"storage-usage-reporter",
 		"unconverted-api-workers",
 		"unit-agent-deployer",
 	}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/voyeur"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/unit"
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/worker/dependency"
+)
+
+var (
+	// should be an explicit dependency, can't do it cleanly yet
+	inProcessUnitManifolds = unit.InProcessManifolds
+)
+
+// inProcessUnitAgent is the agent of a unit that runs inside the
+// machine agent, rather than in a jujud unit process of its own.
+type inProcessUnitAgent struct {
+	AgentConf
+	tag              names.UnitTag
+	configChangedVal *voyeur.Value
+}
+
+// Tag is part of the agent.Agent interface.
+func (a *inProcessUnitAgent) Tag() names.Tag {
+	return a.tag
+}
+
+// ChangeConfig is part of the agent.Agent interface.
+func (a *inProcessUnitAgent) ChangeConfig(mutate agent.ConfigMutator) error {
+	err := a.AgentConf.ChangeConfig(mutate)
+	a.configChangedVal.Set(true)
+	return errors.Trace(err)
+}
+
+func (a *inProcessUnitAgent) validateMigration(apiCaller base.APICaller) error {
+	return validateUnitMigration(apiCaller, a.tag, a.CurrentConfig().Model().Id())
+}
+
+// newInProcessUnitWorker returns a function that starts a dependency
+// engine running the responsibilities of the named unit's agent, for
+// the deployer to run inside the machine agent. Each unit has its own
+// engine, so that the failure of one unit's workers does not affect
+// any other unit.
+func newInProcessUnitWorker(dataDir string) func(unitName string) (worker.Worker, error) {
+	return func(unitName string) (worker.Worker, error) {
+		a := &inProcessUnitAgent{
+			AgentConf:        NewAgentConf(dataDir),
+			tag:              names.NewUnitTag(unitName),
+			configChangedVal: voyeur.NewValue(true),
+		}
+		if err := a.ReadConfig(a.tag.String()); err != nil {
+			return nil, errors.Annotatef(err, "reading config for unit %q", unitName)
+		}
+
+		manifolds := inProcessUnitManifolds(unit.ManifoldsConfig{
+			Agent:               agent.APIHostPortsSetter{a},
+			LeadershipGuarantee: 30 * time.Second,
+			AgentConfigChanged:  a.configChangedVal,
+			ValidateMigration:   a.validateMigration,
+		})
+
+		engine, err := dependency.NewEngine(dependency.EngineConfig{
+			IsFatal:     cmdutil.IsFatal,
+			WorstError:  cmdutil.MoreImportantError,
+			ErrorDelay:  3 * time.Second,
+			BounceDelay: 10 * time.Millisecond,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := dependency.Install(engine, manifolds); err != nil {
+			if err := worker.Stop(engine); err != nil {
+				logger.Errorf("while stopping engine with bad manifolds: %v", err)
+			}
+			return nil, errors.Trace(err)
+		}
+		return engine, nil
+	}
+}
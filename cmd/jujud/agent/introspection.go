@@ -13,6 +13,7 @@ import (
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/faultinject"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/introspection"
 )
@@ -55,6 +56,7 @@ func startIntrospection(cfg introspectionConfig) error {
 		StatePool:          cfg.StatePoolReporter,
 		PubSub:             cfg.PubSubReporter,
 		PrometheusGatherer: cfg.PrometheusGatherer,
+		Faults:             faultinject.Default,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -5,13 +5,13 @@ package agent
 
 import (
 	"fmt"
-	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/juju/cmd"
@@ -73,12 +73,15 @@ import (
 	"github.com/juju/juju/state/statemetrics"
 	"github.com/juju/juju/storage/looputil"
 	"github.com/juju/juju/upgrades"
+	"github.com/juju/juju/utils/privsep"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/catacomb"
 	"github.com/juju/juju/worker/certupdater"
+	"github.com/juju/juju/worker/controllerconfigwatcher"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
@@ -89,6 +92,7 @@ import (
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendermetrics"
 	"github.com/juju/juju/worker/migrationmaster"
+	"github.com/juju/juju/worker/modelexpiry"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
 	"github.com/juju/juju/worker/peergrouper"
@@ -96,6 +100,7 @@ import (
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/txnpruner"
+	"github.com/juju/juju/worker/txnresolver"
 	"github.com/juju/juju/worker/upgradesteps"
 )
 
@@ -483,6 +488,10 @@ func upgradeCertificateDNSNames(config agent.ConfigSetter) error {
 	return nil
 }
 
+// privileged reports whether the agent is running as root; it is a
+// variable so that it can be replaced in tests.
+var privileged = privsep.Privileged
+
 // Run runs a machine agent.
 func (a *MachineAgent) Run(*cmd.Context) error {
 
@@ -511,12 +520,25 @@ func (a *MachineAgent) Run(*cmd.Context) error {
 	}
 
 	agentConfig := a.CurrentConfig()
+	if err := agent.UpdateBootstrapInfo(agentConfig); err != nil {
+		// As in ChangeConfig, this isn't fatal.
+		logger.Errorf("cannot update bootstrap info: %v", err)
+	}
 	a.upgradeComplete = upgradesteps.NewLock(agentConfig)
 
 	createEngine := a.makeEngineCreator(agentConfig.UpgradedToVersion())
 	charmrepo.CacheDir = filepath.Join(agentConfig.DataDir(), "charmcache")
-	if err := a.createJujudSymlinks(agentConfig.DataDir()); err != nil {
-		return err
+	if privileged() {
+		if err := a.createJujudSymlinks(agentConfig.DataDir()); err != nil {
+			return err
+		}
+	} else {
+		// An agent running as an unprivileged user does everything
+		// that needs root through the privileged helper; the symlinks
+		// are created when the machine is provisioned.
+		if err := privsep.CheckHelper(); err != nil {
+			return errors.Annotate(err, "cannot run agent without root privileges")
+		}
 	}
 	a.runner.StartWorker("engine", createEngine)
 
@@ -639,7 +661,16 @@ func (a *MachineAgent) executeRebootOrShutdown(action params.RebootAction) error
 func (a *MachineAgent) ChangeConfig(mutate agent.ConfigMutator) error {
 	err := a.AgentConfigWriter.ChangeConfig(mutate)
 	a.configChangedVal.Set(true)
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Keep the bootstrap info in step with the controller
+	// addresses and CA cert. Failing to do so only matters
+	// if the controller is rebuilt, so it isn't fatal.
+	if err := agent.UpdateBootstrapInfo(a.CurrentConfig()); err != nil {
+		logger.Errorf("cannot update bootstrap info: %v", err)
+	}
+	return nil
 }
 
 func (a *MachineAgent) maybeStopMongo(ver mongo.Version, isMaster bool) error {
@@ -1217,6 +1248,18 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour, clock.WallClock), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "txnresolver", func() (worker.Worker, error) {
+				return txnresolver.New(st, state.StuckTransactionAge, time.Minute, clock.WallClock), nil
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "modelexpiry", func() (worker.Worker, error) {
+				return newModelExpiryWorker(st)
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "actionscheduler", func() (worker.Worker, error) {
+				return newActionSchedulerWorker(st)
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1319,11 +1362,36 @@ func (a *MachineAgent) newAPIserverWorker(
 	dataDir := agentConfig.DataDir()
 	logDir := agentConfig.LogDir()
 
-	endpoint := net.JoinHostPort("", strconv.Itoa(info.APIPort))
-	listener, err := net.Listen("tcp", endpoint)
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot fetch the controller config")
+	}
+	environs.RestrictPluginCredentials(controllerConfig.AllowedCredentialPlugins())
+
+	// The api port may be moved without restarting the api server;
+	// the old port is kept open as the additional api port until
+	// agents have learned the new address.
+	listener, err := apiserver.NewPortListener(controllerConfig.APIPorts()...)
 	if err != nil {
 		return nil, err
 	}
+	setAPIPorts := func(cfg controller.Config) error {
+		if err := listener.SetPorts(cfg.APIPorts()...); err != nil {
+			return errors.Trace(err)
+		}
+		if info.APIPort == cfg.APIPort() {
+			return nil
+		}
+		info.APIPort = cfg.APIPort()
+		return a.AgentConfigWriter.ChangeConfig(func(config agent.ConfigSetter) error {
+			config.SetStateServingInfo(info)
+			return nil
+		})
+	}
+	if err := setAPIPorts(controllerConfig); err != nil {
+		listener.Close()
+		return nil, errors.Trace(err)
+	}
 
 	// TODO(katco): We should be doing something more serious than
 	// logging audit errors. Failures in the auditing systems should
@@ -1332,13 +1400,21 @@ func (a *MachineAgent) newAPIserverWorker(
 		logger.Criticalf("%v", err)
 	}
 
-	controllerConfig, err := st.ControllerConfig()
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot fetch the controller config")
+	// Auditing may be enabled and disabled without restarting the
+	// api server; the change applies to connections made after it.
+	var auditingEnabled int32
+	setAuditingEnabled := func(cfg controller.Config) error {
+		var enabled int32
+		if cfg.AuditingEnabled() {
+			enabled = 1
+		}
+		atomic.StoreInt32(&auditingEnabled, enabled)
+		return nil
 	}
+	setAuditingEnabled(controllerConfig)
 
 	newObserver, err := newObserverFn(
-		controllerConfig,
+		func() bool { return atomic.LoadInt32(&auditingEnabled) == 1 },
 		clock.WallClock,
 		jujuversion.Current,
 		agentConfig.Model().Id(),
@@ -1390,6 +1466,16 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
 
+	// Apply controller config changes to the running api server.
+	configWatcher, err := controllerconfigwatcher.New(controllerconfigwatcher.Config{
+		Backend:   st,
+		Reloaders: []controllerconfigwatcher.ReloadFunc{setAuditingEnabled, setAPIPorts},
+	})
+	if err != nil {
+		worker.Stop(server)
+		return nil, errors.Annotate(err, "cannot start controller config watcher")
+	}
+
 	// Report state metrics.
 	stateMetricsRunner := worker.NewRunner(worker.RunnerParams{
 		IsFatal:       cmdutil.IsFatal,
@@ -1413,9 +1499,10 @@ func (a *MachineAgent) newAPIserverWorker(
 			// may still be using it.
 			server.Wait()
 			stateMetricsRunner.Wait()
+			configWatcher.Wait()
 			return apiserverWorker.Catacomb.ErrDying()
 		},
-		Init: []worker.Worker{server, stateMetricsRunner},
+		Init: []worker.Worker{server, stateMetricsRunner, configWatcher},
 	}); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1514,6 +1601,33 @@ func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
 		}
 		result.ConnUpperThreshold = val
 	}
+	if v := cfg.Value(agent.AgentMaxConcurrentRequests); v != "" {
+		val, err := strconv.Atoi(v)
+		if err != nil {
+			return apiserver.RateLimitConfig{}, errors.Annotatef(
+				err, "parsing %s", agent.AgentMaxConcurrentRequests,
+			)
+		}
+		result.MaxConcurrentRequests = val
+	}
+	if v := cfg.Value(agent.AgentRequestWeight); v != "" {
+		val, err := strconv.Atoi(v)
+		if err != nil {
+			return apiserver.RateLimitConfig{}, errors.Annotatef(
+				err, "parsing %s", agent.AgentRequestWeight,
+			)
+		}
+		result.AgentRequestWeight = val
+	}
+	if v := cfg.Value(agent.ClientRequestWeight); v != "" {
+		val, err := strconv.Atoi(v)
+		if err != nil {
+			return apiserver.RateLimitConfig{}, errors.Annotatef(
+				err, "parsing %s", agent.ClientRequestWeight,
+			)
+		}
+		result.ClientRequestWeight = val
+	}
 	return result, nil
 }
 
@@ -1542,7 +1656,7 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 }
 
 func newObserverFn(
-	controllerConfig controller.Config,
+	auditingEnabled func() bool,
 	clock clock.Clock,
 	jujuServerVersion version.Number,
 	modelUUID string,
@@ -1565,15 +1679,16 @@ func newObserverFn(
 
 	// Auditing observer
 	// TODO(katco): Auditing needs feature tests (lp:1604551)
-	if controllerConfig.AuditingEnabled() {
-		observerFactories = append(observerFactories, func() observer.Observer {
-			ctx := &observer.AuditContext{
-				JujuServerVersion: jujuServerVersion,
-				ModelUUID:         modelUUID,
-			}
-			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
-		})
-	}
+	observerFactories = append(observerFactories, func() observer.Observer {
+		if !auditingEnabled() {
+			return nil
+		}
+		ctx := &observer.AuditContext{
+			JujuServerVersion: jujuServerVersion,
+			ModelUUID:         modelUUID,
+		}
+		return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
+	})
 
 	// Metrics observer.
 	metricObserver, err := metricobserver.NewObserverFactory(metricobserver.Config{
@@ -1947,8 +2062,20 @@ func (c singularStateConn) Ping() error {
 // running the tests and (2) get access to the *State used internally, so that
 // tests can be run without waiting for the 5s watcher refresh time to which we would
 // otherwise be restricted.
-var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) deployer.Context {
-	return deployer.NewSimpleContext(agentConfig, st)
+//
+// Unit agents run inside the machine agent, unless the machine agent's
+// config asks for them to run as separate processes.
+var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) (deployer.Context, error) {
+	legacy := deployer.NewSimpleContext(agentConfig, st)
+	if agentConfig.Value(agent.SeparateUnitAgents) == "true" {
+		return legacy, nil
+	}
+	return deployer.NewInProcessContext(deployer.InProcessConfig{
+		AgentConfig:   agentConfig,
+		API:           st,
+		Legacy:        legacy,
+		NewUnitWorker: newInProcessUnitWorker(agentConfig.DataDir()),
+	})
 }
 
 func newStateMetricsWorker(statePool *state.StatePool, registry *prometheus.Registry) worker.Worker {
@@ -1963,6 +2090,63 @@ func newStateMetricsWorker(statePool *state.StatePool, registry *prometheus.Regi
 	})
 }
 
+// newModelExpiryWorker returns a worker that destroys expired models.
+func newModelExpiryWorker(st *state.State) (worker.Worker, error) {
+	return newStatePoolWorker(st, func(statePool *state.StatePool) (worker.Worker, error) {
+		return modelexpiry.New(modelexpiry.Config{
+			StatePool:     modelexpiry.NewStatePool(statePool),
+			Clock:         clock.WallClock,
+			CheckInterval: modelexpiry.DefaultCheckInterval,
+			WarningPeriod: modelexpiry.DefaultWarningPeriod,
+			Notify:        modelexpiry.PostNotice,
+		})
+	})
+}
+
+// newActionSchedulerWorker returns a worker that enqueues the actions
+// of the action schedules that are due.
+func newActionSchedulerWorker(st *state.State) (worker.Worker, error) {
+	return newStatePoolWorker(st, func(statePool *state.StatePool) (worker.Worker, error) {
+		return actionscheduler.New(actionscheduler.Config{
+			StatePool:     actionscheduler.NewStatePool(statePool),
+			Clock:         clock.WallClock,
+			CheckInterval: actionscheduler.DefaultCheckInterval,
+		})
+	})
+}
+
+// newStatePoolWorker returns the worker started by newWorker, using
+// its own state pool, which is closed when the worker stops.
+func newStatePoolWorker(
+	st *state.State,
+	newWorker func(*state.StatePool) (worker.Worker, error),
+) (worker.Worker, error) {
+	statePool := state.NewStatePool(st)
+	inner, err := newWorker(statePool)
+	if err != nil {
+		statePool.Close()
+		return nil, errors.Trace(err)
+	}
+	var w catacombWorker
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.Catacomb,
+		Work: func() error {
+			defer statePool.Close()
+			<-w.Catacomb.Dying()
+			// Wait for the worker to die before closing
+			// the state pool, as it may still be using it.
+			inner.Wait()
+			return w.Catacomb.ErrDying()
+		},
+		Init: []worker.Worker{inner},
+	}); err != nil {
+		worker.Stop(inner)
+		statePool.Close()
+		return nil, errors.Trace(err)
+	}
+	return &w, nil
+}
+
 func getLogSinkConfig(cfg agent.Config) (apiserver.LogSinkConfig, error) {
 	result := apiserver.DefaultLogSinkConfig()
 	var err error
@@ -23,6 +23,7 @@ import (
 	apideployer "github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/faultinject"
 	"github.com/juju/juju/state"
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
@@ -35,6 +36,7 @@ import (
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
+	"github.com/juju/juju/worker/faultinjection"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/hostkeyreporter"
@@ -49,9 +51,11 @@ import (
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/resumer"
+	"github.com/juju/juju/worker/reversetunnel"
 	workerstate "github.com/juju/juju/worker/state"
 	"github.com/juju/juju/worker/stateconfigwatcher"
 	"github.com/juju/juju/worker/storageprovisioner"
+	"github.com/juju/juju/worker/storageusage"
 	"github.com/juju/juju/worker/terminationworker"
 	"github.com/juju/juju/worker/toolsversionchecker"
 	"github.com/juju/juju/worker/upgrader"
@@ -126,7 +130,7 @@ type ManifoldsConfig struct {
 	// running the tests and (2) get access to the *State used internally, so that
 	// tests can be run without waiting for the 5s watcher refresh time to which we would
 	// otherwise be restricted.
-	NewDeployContext func(st *apideployer.State, agentConfig coreagent.Config) deployer.Context
+	NewDeployContext func(st *apideployer.State, agentConfig coreagent.Config) (deployer.Context, error)
 
 	// Clock supplies timekeeping services to various workers.
 	Clock clock.Clock
@@ -511,6 +515,36 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		// The fault injection manifold enables the agent's fault
+		// registry if the controller config allows it, and exits.
+		faultInjectionName: ifNotMigrating(faultinjection.Manifold(faultinjection.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Registry:      faultinject.Default,
+			NewFacade:     faultinjection.NewFacade,
+		})),
+
+		// The storage usage reporter periodically measures the
+		// filesystems attached to the machine, so that full data
+		// disks are visible in "juju storage" and unit status.
+		storageUsageReporterName: ifNotMigrating(storageusage.Manifold(storageusage.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			NewFacade:     storageusage.NewFacade,
+			NewWorker:     storageusage.NewWorker,
+		})),
+
+		// The reverse tunnel worker keeps a channel open to the
+		// controller in models where the controller cannot connect
+		// to the machines, and uses it to accept SSH tunnels.
+		reverseTunnelName: ifNotMigrating(reversetunnel.Manifold(reversetunnel.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     reversetunnel.NewFacade,
+			NewTunnels:    reversetunnel.NewTunnels,
+			NewWorker:     reversetunnel.NewWorker,
+		})),
 	}
 }
 
@@ -568,4 +602,7 @@ const (
 	toolsVersionCheckerName  = "tools-version-checker"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	faultInjectionName       = "fault-injection"
+	storageUsageReporterName = "storage-usage-reporter"
+	reverseTunnelName        = "reverse-tunnel"
 )
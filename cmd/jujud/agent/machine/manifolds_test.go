@@ -48,6 +48,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-config-watcher",
 		"central-hub",
 		"disk-manager",
+		"fault-injection",
 		"host-key-reporter",
 		"log-sender",
 		"logging-config-updater",
@@ -60,12 +61,14 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"proxy-config-updater",
 		"pubsub-forwarder",
 		"reboot-executor",
+		"reverse-tunnel",
 		"serving-info-setter",
 		"ssh-authkeys-updater",
 		"ssh-identity-writer",
 		"state",
 		"state-config-watcher",
 		"storage-provisioner",
+		"storage-usage-reporter",
 		"termination-signal-handler",
 		"tools-version-checker",
 		"unconverted-api-workers",
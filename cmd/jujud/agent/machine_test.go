@@ -52,6 +52,7 @@ import (
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 	"github.com/juju/juju/tools"
+	"github.com/juju/juju/utils/privsep"
 	jujuversion "github.com/juju/juju/version"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/authenticationworker"
@@ -334,6 +335,7 @@ func (s *MachineSuite) TestManageModel(c *gc.C) {
 	c.Logf("started test agent, waiting for workers...")
 	r0 := s.singularRecord.nextRunner(c)
 	r0.waitForWorker(c, "txnpruner")
+	r0.waitForWorker(c, "txnresolver")
 
 	// Check that the provisioner and firewaller are alive by doing
 	// a rudimentary check that it responds to state changes.
@@ -811,6 +813,23 @@ func (s *MachineSuite) TestMachineAgentSymlinks(c *gc.C) {
 	s.waitStopped(c, state.JobManageModel, a, done)
 }
 
+func (s *MachineSuite) TestMachineAgentUnprivilegedNeedsHelper(c *gc.C) {
+	s.PatchValue(&privileged, func() bool { return false })
+	s.PatchValue(&privsep.HelperPath, filepath.Join(c.MkDir(), "jujud-privhelper"))
+	m, _, _ := s.primeAgent(c, state.JobHostUnits)
+	a := s.newAgent(c, m)
+	a.rootDir = c.MkDir()
+
+	err := a.Run(nil)
+	c.Assert(err, gc.ErrorMatches, "cannot run agent without root privileges: privileged helper: .* no such file or directory")
+
+	// The symlinks are created when the machine is provisioned.
+	for _, link := range jujudSymlinks {
+		_, err := os.Lstat(utils.EnsureBaseDir(a.rootDir, link))
+		c.Assert(err, jc.Satisfies, os.IsNotExist)
+	}
+}
+
 func (s *MachineSuite) TestMachineAgentSymlinkJujuRunExists(c *gc.C) {
 	if runtime.GOOS == "windows" {
 		// Cannot make symlink to nonexistent file on windows or
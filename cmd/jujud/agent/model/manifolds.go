@@ -25,8 +25,10 @@ import (
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/dnsupdater"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
+	"github.com/juju/juju/worker/floatingipupdater"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/instancepoller"
@@ -41,9 +43,11 @@ import (
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/securitygroupgc"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
+	"github.com/juju/juju/worker/tagsync"
 	"github.com/juju/juju/worker/undertaker"
 	"github.com/juju/juju/worker/unitassigner"
 )
@@ -161,6 +165,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		environTrackerName: ifResponsible(environ.Manifold(environ.ManifoldConfig{
 			APICallerName:  apiCallerName,
 			NewEnvironFunc: config.NewEnvironFunc,
+			Clock:          config.Clock,
 		})),
 
 		// The model upgrader runs on all controller agents, and
@@ -268,6 +273,33 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFirewallerFacade:      firewaller.NewFirewallerFacade,
 			NewRemoteRelationsFacade: firewaller.NewRemoteRelationsFacade,
 		})),
+		dnsUpdaterName: ifNotMigrating(dnsupdater.Manifold(dnsupdater.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			NewFacade:     dnsupdater.NewFacade,
+			NewWorker:     dnsupdater.New,
+		})),
+		tagSyncName: ifNotMigrating(tagsync.Manifold(tagsync.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			Clock:         config.Clock,
+			NewFacade:     tagsync.NewFacade,
+			NewWorker:     tagsync.New,
+		})),
+		floatingIPUpdaterName: ifNotMigrating(floatingipupdater.Manifold(floatingipupdater.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			Clock:         config.Clock,
+			NewFacade:     floatingipupdater.NewFacade,
+			NewWorker:     floatingipupdater.New,
+		})),
+		securityGroupGCName: ifNotMigrating(securitygroupgc.Manifold(securitygroupgc.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			Clock:         config.Clock,
+			NewFacade:     securitygroupgc.NewFacade,
+			NewWorker:     securitygroupgc.New,
+		})),
 		unitAssignerName: ifNotMigrating(unitassigner.Manifold(unitassigner.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -428,6 +460,10 @@ const (
 	computeProvisionerName   = "compute-provisioner"
 	storageProvisionerName   = "storage-provisioner"
 	firewallerName           = "firewaller"
+	dnsUpdaterName           = "dns-updater"
+	tagSyncName              = "tag-sync"
+	floatingIPUpdaterName    = "floating-ip-updater"
+	securityGroupGCName      = "security-group-gc"
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
@@ -39,8 +39,10 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"dns-updater",
 		"environ-tracker",
 		"firewaller",
+		"floating-ip-updater",
 		"instance-poller",
 		"is-responsible-flag",
 		"log-forwarder",
@@ -55,9 +57,11 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"security-group-gc",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
+		"tag-sync",
 		"undertaker",
 		"unit-assigner",
 	})
@@ -155,8 +159,10 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"dns-updater",
 		"environ-tracker",
 		"firewaller",
+		"floating-ip-updater",
 		"instance-poller",
 		"is-responsible-flag",
 		"log-forwarder",
@@ -171,9 +177,11 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"security-group-gc",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
+		"tag-sync",
 		"undertaker",
 		"unit-assigner",
 	})
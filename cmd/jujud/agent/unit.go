@@ -205,8 +205,15 @@ func (a *UnitAgent) ChangeConfig(mutate agent.ConfigMutator) error {
 // validateMigration is called by the migrationminion to help check
 // that the agent will be ok when connected to a new controller.
 func (a *UnitAgent) validateMigration(apiCaller base.APICaller) error {
-	// TODO(mjs) - more extensive checks to come.
 	unitTag := names.NewUnitTag(a.UnitName)
+	return validateUnitMigration(apiCaller, unitTag, a.CurrentConfig().Model().Id())
+}
+
+// validateUnitMigration checks that the agent of the given unit, in
+// the model with the given UUID, will be ok when connected to a new
+// controller.
+func validateUnitMigration(apiCaller base.APICaller, unitTag names.UnitTag, curModelUUID string) error {
+	// TODO(mjs) - more extensive checks to come.
 	facade := uniter.NewState(apiCaller, unitTag)
 	_, err := facade.Unit(unitTag)
 	if err != nil {
@@ -216,7 +223,6 @@ func (a *UnitAgent) validateMigration(apiCaller base.APICaller) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	curModelUUID := a.CurrentConfig().Model().Id()
 	newModelUUID := model.UUID()
 	if newModelUUID != curModelUUID {
 		return errors.Errorf("model mismatch when validating: got %q, expected %q",
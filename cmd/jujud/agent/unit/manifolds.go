@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/base"
 	msapi "github.com/juju/juju/api/meterstatus"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/faultinject"
 	"github.com/juju/juju/utils/proxy"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
@@ -23,6 +24,7 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/faultinjection"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/leadership"
 	"github.com/juju/juju/worker/logger"
@@ -195,6 +197,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			InProcessUpdate: proxy.DefaultConfig.Set,
 		})),
 
+		// The fault injection manifold enables the agent's fault
+		// registry if the controller config allows it, and exits.
+		faultInjectionName: ifNotMigrating(faultinjection.Manifold(faultinjection.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Registry:      faultinject.Default,
+			NewFacade:     faultinjection.NewFacade,
+		})),
+
 		// The charmdir resource coordinates whether the charm directory is
 		// available or not; after 'start' hook and before 'stop' hook
 		// executes, and not during upgrades.
@@ -271,6 +281,25 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 	}
 }
 
+// InProcessManifolds returns the manifolds of a unit agent that runs
+// inside the machine agent. The machine agent already sends logs,
+// upgrades the agent binaries, and updates the process-wide logging
+// and proxy configuration, and configures the process-wide fault
+// registry, so the unit agent does not.
+func InProcessManifolds(config ManifoldsConfig) dependency.Manifolds {
+	manifolds := Manifolds(config)
+	for _, name := range []string{
+		logSenderName,
+		upgraderName,
+		loggingConfigUpdaterName,
+		proxyConfigUpdaterName,
+		faultInjectionName,
+	} {
+		delete(manifolds, name)
+	}
+	return manifolds
+}
+
 var ifNotMigrating = engine.Housing{
 	Flags: []string{
 		migrationInactiveFlagName,
@@ -292,6 +321,7 @@ const (
 	loggingConfigUpdaterName = "logging-config-updater"
 	proxyConfigUpdaterName   = "proxy-config-updater"
 	apiAddressUpdaterName    = "api-address-updater"
+	faultInjectionName       = "fault-injection"
 
 	charmDirName          = "charm-dir"
 	leadershipTrackerName = "leadership-tracker"
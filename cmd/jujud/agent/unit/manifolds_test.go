@@ -45,6 +45,33 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"logging-config-updater",
 		"proxy-config-updater",
 		"api-address-updater",
+		"fault-injection",
+		"charm-dir",
+		"leadership-tracker",
+		"hook-retry-strategy",
+		"uniter",
+		"metric-spool",
+		"meter-status",
+		"metric-collect",
+		"metric-sender",
+	}
+	keys := make([]string, 0, len(manifolds))
+	for k := range manifolds {
+		keys = append(keys, k)
+	}
+	c.Assert(expectedKeys, jc.SameContents, keys)
+}
+
+func (s *ManifoldsSuite) TestInProcessManifoldNames(c *gc.C) {
+	manifolds := unit.InProcessManifolds(unit.ManifoldsConfig{})
+	expectedKeys := []string{
+		"agent",
+		"api-config-watcher",
+		"api-caller",
+		"migration-fortress",
+		"migration-minion",
+		"migration-inactive-flag",
+		"api-address-updater",
 		"charm-dir",
 		"leadership-tracker",
 		"hook-retry-strategy",
@@ -0,0 +1,176 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/cert"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/network"
+)
+
+const updateControllerDoc = `
+Re-point the agents on this machine at a rebuilt controller, without
+re-provisioning the machine.
+
+Each machine keeps a record of the last known controller API addresses
+and CA certificate, which is updated by the machine agent whenever they
+change. This command writes the addresses and CA certificate given, or
+those recorded if none are given, to the configuration of every agent
+on the machine, and records them.
+
+The agents on the machine should be stopped before running this
+command, and started again afterwards.
+
+Examples:
+    jujud update-controller --api-addresses 10.0.0.1:17070,10.0.0.2:17070
+    jujud update-controller --ca-cert /tmp/ca.crt
+`
+
+type updateControllerCommand struct {
+	cmd.CommandBase
+	dataDir      string
+	apiAddresses string
+	caCertPath   string
+}
+
+// NewUpdateControllerCommand returns a command that updates the
+// controller API addresses and CA certificate of the agents on the
+// machine.
+func NewUpdateControllerCommand() cmd.Command {
+	return &updateControllerCommand{}
+}
+
+// Info is part of cmd.Command.
+func (c *updateControllerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-controller",
+		Purpose: "re-point the agents on this machine at a rebuilt controller",
+		Doc:     updateControllerDoc,
+	}
+}
+
+// SetFlags is part of cmd.Command.
+func (c *updateControllerCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.dataDir, "data-dir", util.DataDir, "directory for juju data")
+	f.StringVar(&c.apiAddresses, "api-addresses", "", "comma-separated controller API addresses")
+	f.StringVar(&c.caCertPath, "ca-cert", "", "path to the controller CA certificate")
+}
+
+// Init is part of cmd.Command.
+func (c *updateControllerCommand) Init(args []string) error {
+	if c.dataDir == "" {
+		return util.RequiredError("data-dir")
+	}
+	if c.apiAddresses != "" {
+		if _, err := network.ParseHostPorts(c.addresses()...); err != nil {
+			return errors.Annotate(err, "invalid --api-addresses")
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *updateControllerCommand) addresses() []string {
+	var addrs []string
+	for _, addr := range strings.Split(c.apiAddresses, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// Run is part of cmd.Command.
+func (c *updateControllerCommand) Run(ctx *cmd.Context) error {
+	info, err := agent.ReadBootstrapInfo(c.dataDir)
+	if errors.IsNotFound(err) {
+		if c.apiAddresses == "" || c.caCertPath == "" {
+			return errors.New("no bootstrap info recorded, --api-addresses and --ca-cert must be specified")
+		}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if c.apiAddresses != "" {
+		info.APIAddresses = c.addresses()
+	}
+	if c.caCertPath != "" {
+		caCert, err := ioutil.ReadFile(ctx.AbsPath(c.caCertPath))
+		if err != nil {
+			return errors.Annotate(err, "cannot read CA certificate")
+		}
+		if _, err := cert.ParseCert(string(caCert)); err != nil {
+			return errors.Annotate(err, "invalid CA certificate")
+		}
+		info.CACert = string(caCert)
+	}
+	if len(info.APIAddresses) == 0 {
+		return errors.New("no API addresses recorded, --api-addresses must be specified")
+	}
+	hostPorts, err := network.ParseHostPorts(info.APIAddresses...)
+	if err != nil {
+		return errors.Annotate(err, "invalid API addresses")
+	}
+	// Each address is treated as a separate API server, so that
+	// none are dropped in favour of another.
+	servers := make([][]network.HostPort, len(hostPorts))
+	for i, hp := range hostPorts {
+		servers[i] = []network.HostPort{hp}
+	}
+
+	tags, err := agentTags(c.dataDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, tag := range tags {
+		conf, err := agent.ReadConfig(agent.ConfigPath(c.dataDir, tag))
+		if err != nil {
+			return errors.Annotatef(err, "cannot read configuration for %s", tag)
+		}
+		conf.SetAPIHostPorts(servers)
+		conf.SetCACert(info.CACert)
+		if err := conf.Write(); err != nil {
+			return errors.Annotatef(err, "cannot write configuration for %s", tag)
+		}
+		ctx.Infof("updated %s", tag)
+	}
+	if err := agent.WriteBootstrapInfo(c.dataDir, info); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("restart the agents on this machine for the changes to take effect")
+	return nil
+}
+
+// agentTags returns the tags of the machine and unit agents with
+// configuration in the data directory.
+func agentTags(dataDir string) ([]names.Tag, error) {
+	infos, err := ioutil.ReadDir(agent.BaseDir(dataDir))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list agents")
+	}
+	var tags []names.Tag
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		tag, err := names.ParseTag(info.Name())
+		if err != nil {
+			continue
+		}
+		if tag.Kind() == names.MachineTagKind || tag.Kind() == names.UnitTagKind {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, errors.NotFoundf("agents in %q", dataDir)
+	}
+	return tags, nil
+}
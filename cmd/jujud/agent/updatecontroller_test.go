@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	agentcmd "github.com/juju/juju/cmd/jujud/agent"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type updateControllerSuite struct {
+	coretesting.BaseSuite
+	dataDir string
+}
+
+var _ = gc.Suite(&updateControllerSuite{})
+
+func (s *updateControllerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+	s.writeAgentConfig(c, names.NewMachineTag("0"))
+	s.writeAgentConfig(c, names.NewUnitTag("mysql/0"))
+}
+
+func (s *updateControllerSuite) writeAgentConfig(c *gc.C, tag names.Tag) {
+	conf, err := agent.NewAgentConfig(agent.AgentConfigParams{
+		Paths:             agent.Paths{DataDir: s.dataDir},
+		Tag:               tag,
+		UpgradedToVersion: jujuversion.Current,
+		Password:          "sekrit",
+		CACert:            "old ca cert",
+		APIAddresses:      []string{"10.0.0.1:17070"},
+		Nonce:             "a nonce",
+		Controller:        coretesting.ControllerTag,
+		Model:             coretesting.ModelTag,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Write(), jc.ErrorIsNil)
+}
+
+func (s *updateControllerSuite) run(c *gc.C, args ...string) error {
+	args = append([]string{"--data-dir", s.dataDir}, args...)
+	_, err := cmdtesting.RunCommand(c, agentcmd.NewUpdateControllerCommand(), args...)
+	return err
+}
+
+func (s *updateControllerSuite) assertAgentConfigs(c *gc.C, addrs []string, caCert string) {
+	for _, tag := range []names.Tag{names.NewMachineTag("0"), names.NewUnitTag("mysql/0")} {
+		conf, err := agent.ReadConfig(agent.ConfigPath(s.dataDir, tag))
+		c.Assert(err, jc.ErrorIsNil)
+		apiAddrs, err := conf.APIAddresses()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(apiAddrs, jc.DeepEquals, addrs)
+		c.Check(conf.CACert(), gc.Equals, caCert)
+	}
+}
+
+func (s *updateControllerSuite) TestInitInvalidAddresses(c *gc.C) {
+	err := cmdtesting.InitCommand(agentcmd.NewUpdateControllerCommand(), []string{"--api-addresses", "foo"})
+	c.Assert(err, gc.ErrorMatches, `invalid --api-addresses: .*`)
+}
+
+func (s *updateControllerSuite) TestRunNoBootstrapInfo(c *gc.C) {
+	err := s.run(c, "--api-addresses", "10.0.0.9:17070")
+	c.Assert(err, gc.ErrorMatches, "no bootstrap info recorded, --api-addresses and --ca-cert must be specified")
+}
+
+func (s *updateControllerSuite) TestRunAddressesAndCACert(c *gc.C) {
+	caCertPath := filepath.Join(c.MkDir(), "ca.crt")
+	err := ioutil.WriteFile(caCertPath, []byte(coretesting.CACert), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.run(c, "--api-addresses", "10.0.0.9:17070,10.0.0.10:17070", "--ca-cert", caCertPath)
+	c.Assert(err, jc.ErrorIsNil)
+	addrs := []string{"10.0.0.9:17070", "10.0.0.10:17070"}
+	s.assertAgentConfigs(c, addrs, coretesting.CACert)
+
+	info, err := agent.ReadBootstrapInfo(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.APIAddresses, jc.DeepEquals, addrs)
+	c.Assert(info.CACert, gc.Equals, coretesting.CACert)
+}
+
+func (s *updateControllerSuite) TestRunFromBootstrapInfo(c *gc.C) {
+	err := agent.WriteBootstrapInfo(s.dataDir, agent.BootstrapInfo{
+		APIAddresses: []string{"10.0.0.9:17070"},
+		CACert:       "new ca cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAgentConfigs(c, []string{"10.0.0.9:17070"}, "new ca cert")
+}
+
+func (s *updateControllerSuite) TestRunInvalidCACert(c *gc.C) {
+	caCertPath := filepath.Join(c.MkDir(), "ca.crt")
+	err := ioutil.WriteFile(caCertPath, []byte("rubbish"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.run(c, "--api-addresses", "10.0.0.9:17070", "--ca-cert", caCertPath)
+	c.Assert(err, gc.ErrorMatches, "invalid CA certificate: .*")
+	s.assertAgentConfigs(c, []string{"10.0.0.1:17070"}, "old ca cert")
+}
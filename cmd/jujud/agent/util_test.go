@@ -78,6 +78,7 @@ func (s *commonMachineSuite) TearDownSuite(c *gc.C) {
 func (s *commonMachineSuite) SetUpTest(c *gc.C) {
 	s.AgentSuite.SetUpTest(c)
 	s.PatchValue(&charmrepo.CacheDir, c.MkDir())
+	s.PatchValue(&privileged, func() bool { return true })
 
 	// Patch ssh user to avoid touching ~ubuntu/.ssh/authorized_keys.
 	s.PatchValue(&authenticationworker.SSHUser, "")
@@ -243,11 +244,11 @@ func patchDeployContext(c *gc.C, st *state.State) (*fakeContext, func()) {
 		deployed: make(set.Strings),
 	}
 	orig := newDeployContext
-	newDeployContext = func(dst *apideployer.State, agentConfig agent.Config) deployer.Context {
+	newDeployContext = func(dst *apideployer.State, agentConfig agent.Config) (deployer.Context, error) {
 		ctx.st = st
 		ctx.agentConfig = agentConfig
 		ctx.inited.trigger()
-		return ctx
+		return ctx, nil
 	}
 	return ctx, func() { newDeployContext = orig }
 }
@@ -126,6 +126,8 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 		}
 	}
 
+	environs.RestrictPluginCredentials(args.ControllerConfig.AllowedCredentialPlugins())
+
 	// Get the bootstrap machine's addresses from the provider.
 	cloudSpec, err := environs.MakeCloudSpec(
 		args.ControllerCloud,
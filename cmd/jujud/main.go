@@ -25,11 +25,13 @@ import (
 	"github.com/juju/juju/cmd/jujud/dumplogs"
 	"github.com/juju/juju/cmd/jujud/introspect"
 	components "github.com/juju/juju/component/all"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/sockets"
 	// Import the providers.
 	_ "github.com/juju/juju/provider/all"
 	"github.com/juju/juju/upgrades"
+	"github.com/juju/juju/utils/privsep"
 	"github.com/juju/juju/utils/proxy"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -150,6 +152,11 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 		return 1, errors.Trace(err)
 	}
 
+	// The agents use no credential plugins until they have read the
+	// controller's allowed-credential-plugins, as the plugins are
+	// described by credentials that users upload.
+	environs.RestrictPluginCredentials(nil)
+
 	jujud := jujucmd.NewSuperCommand(cmd.SuperCommandParams{
 		Name: "jujud",
 		Doc:  jujudDoc,
@@ -182,6 +189,7 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 
 	jujud.Register(NewUpgradeMongoCommand())
 	jujud.Register(agentcmd.NewCheckConnectionCommand(agentConf, agentcmd.ConnectAsAgent))
+	jujud.Register(agentcmd.NewUpdateControllerCommand())
 
 	code = cmd.Main(jujud, ctx, args[1:])
 	return code, nil
@@ -230,6 +238,12 @@ func Main(args []string) int {
 		code = cmd.Main(dumplogs.NewCommand(), ctx, args[1:])
 	case names.JujuIntrospect:
 		code = cmd.Main(&introspect.IntrospectCommand{}, ctx, args[1:])
+	case names.JujudPrivHelper:
+		// The privileged helper is a setuid root copy of jujud.
+		code = exit_err
+		if err = privsep.RunHelper(os.Stdin, os.Stdout); err == nil {
+			code = 0
+		}
 	default:
 		code, err = jujuCMain(commandName, ctx, args)
 	}
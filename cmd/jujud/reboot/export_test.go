@@ -7,4 +7,7 @@ package reboot
 var (
 	Timeout = &timeout
 	TmpFile = &tmpFile
+
+	Privileged    = &privileged
+	RunPrivileged = &runPrivileged
 )
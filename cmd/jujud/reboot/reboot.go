@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/utils/privsep"
 )
 
 var logger = loggo.GetLogger("juju.cmd.jujud.reboot")
@@ -36,6 +37,22 @@ func runCommand(args []string) error {
 	return errors.Trace(err)
 }
 
+// privileged reports whether the agent is running as root. Agents
+// running as other users reboot and shut down the machine through the
+// privileged helper.
+var privileged = privsep.Privileged
+
+// runPrivileged runs a command through the privileged helper.
+var runPrivileged = func(command string, args ...string) error {
+	output, err := privsep.Run(command, args...)
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			err = errors.Annotate(err, msg)
+		}
+	}
+	return errors.Trace(err)
+}
+
 var tmpFile = func() (*os.File, error) {
 	f, err := ioutil.TempFile(os.TempDir(), "juju-reboot")
 	return f, errors.Trace(err)
@@ -55,6 +55,12 @@ func scheduleAction(action params.RebootAction, after int) error {
 	case params.ShouldShutdown:
 		args = append(args, "-h")
 	}
+	if !privileged() {
+		// The privileged helper schedules the action itself, with
+		// a delay in whole minutes.
+		delay := fmt.Sprintf("+%d", (after+59)/60)
+		return errors.Trace(runPrivileged(args[0], args[1], delay))
+	}
 	args = append(args, "now")
 
 	script, err := writeScript(args, after)
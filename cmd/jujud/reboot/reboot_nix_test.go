@@ -64,3 +64,18 @@ func (s *RebootSuite) TestShutdownNoContainers(c *gc.C) {
 	testing.AssertEchoArgs(c, rebootBin, expectedShutdownParams...)
 	ft.File{s.rebootScriptName, expectedShutdownScript, 0755}.Check(c, s.tmpDir)
 }
+
+func (s *RebootSuite) TestRebootUnprivileged(c *gc.C) {
+	s.PatchValue(reboot.Privileged, func() bool { return false })
+	var called []string
+	s.PatchValue(reboot.RunPrivileged, func(command string, args ...string) error {
+		called = append([]string{command}, args...)
+		return nil
+	})
+	w, err := reboot.NewRebootWaiter(s.st, s.acfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = w.ExecuteReboot(params.ShouldReboot)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.DeepEquals, []string{"shutdown", "-r", "+1"})
+}
@@ -60,6 +60,7 @@ func (s *RebootSuite) SetUpSuite(c *gc.C) {
 func (s *RebootSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
 	testing.PatchExecutableAsEchoArgs(c, s, rebootBin)
+	s.PatchValue(reboot.Privileged, func() bool { return true })
 	s.PatchEnvironment("TEMP", c.MkDir())
 
 	s.tmpDir = c.MkDir()
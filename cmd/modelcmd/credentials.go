@@ -95,6 +95,11 @@ func GetCredentials(
 		)
 	}
 
+	if credential.AuthType() == cloud.PluginAuthType {
+		// The provider only knows about the credentials that
+		// are obtained from the plugin when it is used.
+		return credential, credentialName, regionName, nil
+	}
 	credential, err = provider.FinalizeCredential(
 		ctx, environs.FinalizeCredentialParams{
 			Credential:            *credential,
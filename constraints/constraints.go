@@ -21,6 +21,7 @@ import (
 // by the fields in the Value struct.
 const (
 	Arch      = "arch"
+	Bandwidth = "bandwidth"
 	Container = "container"
 	// cpuCores is an alias for Cores.
 	cpuCores     = "cpu-cores"
@@ -44,6 +45,12 @@ type Value struct {
 	// architecture.
 	Arch *string `json:"arch,omitempty" yaml:"arch,omitempty"`
 
+	// Bandwidth, if not nil, indicates that a machine's outgoing network
+	// traffic should be limited to that many megabits per second.
+	// Providers may satisfy it by choosing a suitable instance type, or
+	// by shaping the traffic on the machine itself.
+	Bandwidth *uint64 `json:"bandwidth,omitempty" yaml:"bandwidth,omitempty"`
+
 	// Container, if not nil, indicates that a machine must be the specified container type.
 	Container *instance.ContainerType `json:"container,omitempty" yaml:"container,omitempty"`
 
@@ -110,6 +117,12 @@ func (v *Value) HasArch() bool {
 	return v.Arch != nil && *v.Arch != ""
 }
 
+// HasBandwidth returns true if the constraints.Value specifies a
+// bandwidth limit.
+func (v *Value) HasBandwidth() bool {
+	return v.Bandwidth != nil && *v.Bandwidth > 0
+}
+
 // HasMem returns true if the constraints.Value specifies a minimum amount
 // of memory.
 func (v *Value) HasMem() bool {
@@ -186,6 +199,13 @@ func (v Value) String() string {
 	if v.Arch != nil {
 		strs = append(strs, "arch="+*v.Arch)
 	}
+	if v.Bandwidth != nil {
+		s := uintStr(*v.Bandwidth)
+		if s != "" {
+			s += "M"
+		}
+		strs = append(strs, "bandwidth="+s)
+	}
 	if v.Container != nil {
 		strs = append(strs, "container="+string(*v.Container))
 	}
@@ -233,6 +253,9 @@ func (v Value) GoString() string {
 	if v.Arch != nil {
 		values = append(values, fmt.Sprintf("Arch: %q", *v.Arch))
 	}
+	if v.Bandwidth != nil {
+		values = append(values, fmt.Sprintf("Bandwidth: %v", *v.Bandwidth))
+	}
 	if v.CpuCores != nil {
 		values = append(values, fmt.Sprintf("Cores: %v", *v.CpuCores))
 	}
@@ -388,6 +411,33 @@ func (v *Value) without(attrTags ...string) Value {
 	return fromAttributes(attributes)
 }
 
+// Unset returns a copy of the constraints without values for the
+// specified attributes. Unlike setting an attribute to an empty value,
+// which overrides any fallback value, an unset attribute allows the
+// fallback (for example, the model constraints) to apply again.
+func (v Value) Unset(attrTags ...string) (Value, error) {
+	for _, tag := range attrTags {
+		if !validAttributes[resolveAlias(tag)] {
+			return Value{}, errors.Errorf("unknown constraint %q", tag)
+		}
+	}
+	return v.without(attrTags...), nil
+}
+
+var validAttributes = map[string]bool{
+	Arch:         true,
+	Bandwidth:    true,
+	Container:    true,
+	Cores:        true,
+	CpuPower:     true,
+	Mem:          true,
+	RootDisk:     true,
+	Tags:         true,
+	InstanceType: true,
+	Spaces:       true,
+	VirtType:     true,
+}
+
 func splitRaw(s string) (name, val string, err error) {
 	eq := strings.Index(s, "=")
 	if eq <= 0 {
@@ -402,6 +452,8 @@ func (v *Value) setRaw(name, str string) error {
 	switch resolveAlias(name) {
 	case Arch:
 		err = v.setArch(str)
+	case Bandwidth:
+		err = v.setBandwidth(str)
 	case Container:
 		err = v.setContainer(str)
 	case Cores:
@@ -456,6 +508,8 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		switch canonical {
 		case Arch:
 			v.Arch = &vstr
+		case Bandwidth:
+			v.Bandwidth, err = parseUint64(vstr)
 		case Container:
 			ctype := instance.ContainerType(vstr)
 			v.Container = &ctype
@@ -526,6 +580,14 @@ func (v *Value) setArch(str string) error {
 	return nil
 }
 
+func (v *Value) setBandwidth(str string) (err error) {
+	if v.Bandwidth != nil {
+		return errors.Errorf("already set")
+	}
+	v.Bandwidth, err = parseBandwidth(str)
+	return
+}
+
 func (v *Value) setCpuCores(str string) (err error) {
 	if v.CpuCores != nil {
 		return errors.Errorf("already set")
@@ -637,6 +699,26 @@ func parseSize(str string) (*uint64, error) {
 	return &value, nil
 }
 
+// parseBandwidth parses a bandwidth in megabits per second, with an
+// optional M (megabit) or G (gigabit) suffix.
+func parseBandwidth(str string) (*uint64, error) {
+	var value uint64
+	if str != "" {
+		mult := 1.0
+		if m, ok := mbitSuffixes[str[len(str)-1:]]; ok {
+			str = str[:len(str)-1]
+			mult = m
+		}
+		val, err := strconv.ParseFloat(str, 64)
+		if err != nil || val < 0 {
+			return nil, errors.Errorf("must be a non-negative float with optional M/G suffix")
+		}
+		val *= mult
+		value = uint64(math.Ceil(val))
+	}
+	return &value, nil
+}
+
 // parseCommaDelimited returns the items in the value s. We expect the
 // items to be comma delimited strings.
 func parseCommaDelimited(s string) *[]string {
@@ -663,6 +745,11 @@ func parseYamlStrings(entityName string, val interface{}) (*[]string, error) {
 	return &items, nil
 }
 
+var mbitSuffixes = map[string]float64{
+	"M": 1,
+	"G": 1000,
+}
+
 var mbSuffixes = map[string]float64{
 	"M": 1,
 	"G": 1024,
@@ -254,6 +254,40 @@ var parseConstraintsTests = []struct {
 		err:     `bad "root-disk" constraint: already set`,
 	},
 
+	// "bandwidth" in detail.
+	{
+		summary: "set bandwidth empty",
+		args:    []string{"bandwidth="},
+	}, {
+		summary: "set bandwidth zero",
+		args:    []string{"bandwidth=0"},
+	}, {
+		summary: "set bandwidth without suffix",
+		args:    []string{"bandwidth=100"},
+	}, {
+		summary: "set bandwidth with M suffix",
+		args:    []string{"bandwidth=100M"},
+	}, {
+		summary: "set bandwidth with G suffix",
+		args:    []string{"bandwidth=2.5G"},
+	}, {
+		summary: "set nonsense bandwidth 1",
+		args:    []string{"bandwidth=cheese"},
+		err:     `bad "bandwidth" constraint: must be a non-negative float with optional M/G suffix`,
+	}, {
+		summary: "set nonsense bandwidth 2",
+		args:    []string{"bandwidth=-1"},
+		err:     `bad "bandwidth" constraint: must be a non-negative float with optional M/G suffix`,
+	}, {
+		summary: "set nonsense bandwidth 3",
+		args:    []string{"bandwidth=1T"},
+		err:     `bad "bandwidth" constraint: must be a non-negative float with optional M/G suffix`,
+	}, {
+		summary: "double set bandwidth together",
+		args:    []string{"bandwidth=1G  bandwidth=2G"},
+		err:     `bad "bandwidth" constraint: already set`,
+	},
+
 	// tags
 	{
 		summary: "single tag",
@@ -348,6 +382,24 @@ func (s *ConstraintsSuite) TestParseConstraints(c *gc.C) {
 	}
 }
 
+func (s *ConstraintsSuite) TestParseBandwidth(c *gc.C) {
+	for _, t := range []struct {
+		arg    string
+		expect uint64
+	}{
+		{"bandwidth=100", 100},
+		{"bandwidth=100M", 100},
+		{"bandwidth=2.5G", 2500},
+	} {
+		cons := constraints.MustParse(t.arg)
+		c.Check(cons.HasBandwidth(), jc.IsTrue)
+		c.Check(*cons.Bandwidth, gc.Equals, t.expect, gc.Commentf("%s", t.arg))
+	}
+	cons := constraints.MustParse("bandwidth=")
+	c.Check(cons.HasBandwidth(), jc.IsFalse)
+	c.Check(cons.String(), gc.Equals, "bandwidth=")
+}
+
 func (s *ConstraintsSuite) TestParseAliases(c *gc.C) {
 	v, aliases, err := constraints.ParseWithAliases("cpu-cores=5 arch=amd64")
 	c.Assert(err, jc.ErrorIsNil)
@@ -455,6 +507,8 @@ func (s *ConstraintsSuite) TestIsEmpty(c *gc.C) {
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 	con = constraints.MustParse("root-disk=")
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+	con = constraints.MustParse("bandwidth=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 	con = constraints.MustParse("cpu-power=")
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 	con = constraints.MustParse("cores=")
@@ -502,6 +556,9 @@ var constraintsRoundtripTests = []roundTrip{
 	{"RootDisk1", constraints.Value{RootDisk: nil}},
 	{"RootDisk2", constraints.Value{RootDisk: uint64p(0)}},
 	{"RootDisk2", constraints.Value{RootDisk: uint64p(109876)}},
+	{"Bandwidth1", constraints.Value{Bandwidth: nil}},
+	{"Bandwidth2", constraints.Value{Bandwidth: uint64p(0)}},
+	{"Bandwidth3", constraints.Value{Bandwidth: uint64p(2500)}},
 	{"Tags1", constraints.Value{Tags: nil}},
 	{"Tags2", constraints.Value{Tags: &[]string{}}},
 	{"Tags3", constraints.Value{Tags: &[]string{"foo", "bar"}}},
@@ -654,6 +711,20 @@ func (s *ConstraintsSuite) TestWithout(c *gc.C) {
 	}
 }
 
+func (s *ConstraintsSuite) TestUnset(c *gc.C) {
+	initial := constraints.MustParse("mem=4G cpu-cores=2 tags=")
+	final, err := initial.Unset("mem", "cpu-cores")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(final, jc.DeepEquals, constraints.MustParse("tags="))
+	// The original value is not modified.
+	c.Check(initial, jc.DeepEquals, constraints.MustParse("mem=4G cores=2 tags="))
+}
+
+func (s *ConstraintsSuite) TestUnsetUnknown(c *gc.C) {
+	_, err := constraints.MustParse("mem=4G").Unset("mem", "floors")
+	c.Assert(err, gc.ErrorMatches, `unknown constraint "floors"`)
+}
+
 var hasAnyTests = []struct {
 	cons     string
 	attrs    []string
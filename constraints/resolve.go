@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package constraints
+
+import (
+	"sort"
+)
+
+// Resolution describes how constraints were combined with fallback
+// constraints, such as the model constraints, to produce the effective
+// constraints.
+type Resolution struct {
+	// Effective holds the effective constraints.
+	Effective Value
+
+	// Inherited holds the names of the attributes of the effective
+	// constraints that were taken from the fallback constraints.
+	Inherited []string
+
+	// Overridden holds the names of the attributes of the fallback
+	// constraints that were replaced by, or conflict with, attributes
+	// of the constraints.
+	Overridden []string
+
+	// Unsupported holds the names of the attributes of the effective
+	// constraints that are not supported by the validator, and which
+	// will be ignored.
+	Unsupported []string
+}
+
+// Resolve merges cons into consFallback using the validator, as
+// Validator.Merge does, and reports where each attribute of the
+// result came from.
+func Resolve(v Validator, consFallback, cons Value) (Resolution, error) {
+	effective, err := v.Merge(consFallback, cons)
+	if err != nil {
+		return Resolution{}, err
+	}
+	unsupported, err := v.Validate(effective)
+	if err != nil {
+		return Resolution{}, err
+	}
+	consAttrs := cons.attributesWithValues()
+	effectiveAttrs := effective.attributesWithValues()
+	var inherited, overridden []string
+	for attr := range consFallback.attributesWithValues() {
+		if _, ok := consAttrs[attr]; ok {
+			overridden = append(overridden, attr)
+		} else if _, ok := effectiveAttrs[attr]; ok {
+			inherited = append(inherited, attr)
+		} else {
+			// The attribute was cleared because it conflicts
+			// with an attribute of cons.
+			overridden = append(overridden, attr)
+		}
+	}
+	sort.Strings(inherited)
+	sort.Strings(overridden)
+	sort.Strings(unsupported)
+	return Resolution{
+		Effective:   effective,
+		Inherited:   inherited,
+		Overridden:  overridden,
+		Unsupported: unsupported,
+	}, nil
+}
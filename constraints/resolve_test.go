@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package constraints_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+)
+
+type resolveSuite struct{}
+
+var _ = gc.Suite(&resolveSuite{})
+
+func (s *resolveSuite) TestResolve(c *gc.C) {
+	validator := constraints.NewValidator()
+	validator.RegisterConflicts([]string{"instance-type"}, []string{"mem"})
+	validator.RegisterUnsupported([]string{"cpu-power"})
+
+	res, err := constraints.Resolve(
+		validator,
+		constraints.MustParse("mem=4G cores=2 arch=amd64 cpu-power=100"),
+		constraints.MustParse("instance-type=big cores=4"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, constraints.Resolution{
+		Effective:   constraints.MustParse("instance-type=big cores=4 arch=amd64 cpu-power=100"),
+		Inherited:   []string{"arch", "cpu-power"},
+		Overridden:  []string{"cores", "mem"},
+		Unsupported: []string{"cpu-power"},
+	})
+}
+
+func (s *resolveSuite) TestResolveNoFallback(c *gc.C) {
+	res, err := constraints.Resolve(
+		constraints.NewValidator(),
+		constraints.Value{},
+		constraints.MustParse("mem=4G"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, constraints.Resolution{
+		Effective: constraints.MustParse("mem=4G"),
+	})
+}
+
+func (s *resolveSuite) TestResolveInvalid(c *gc.C) {
+	validator := constraints.NewValidator()
+	validator.RegisterConflicts([]string{"instance-type"}, []string{"mem"})
+	_, err := constraints.Resolve(
+		validator,
+		constraints.Value{},
+		constraints.MustParse("instance-type=big mem=4G"),
+	)
+	c.Assert(err, gc.ErrorMatches, `ambiguous constraints: "instance-type" overlaps with "mem"`)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/utils/privsep"
 )
 
 type containerInitialiser struct{}
@@ -50,15 +51,25 @@ func (ci *containerInitialiser) Initialise() error {
 }
 
 // getPackageManager is a helper function which returns the
-// package manager implementation for the current system.
+// package manager implementation for the current system. Agents
+// that are not running as root install packages through the
+// privileged helper.
 func getPackageManager() (manager.PackageManager, error) {
 	hostSeries, err := series.HostSeries()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return manager.NewPackageManager(hostSeries)
+	pacman, err := manager.NewPackageManager(hostSeries)
+	if err != nil || privileged() {
+		return pacman, err
+	}
+	return privsep.NewPackageManager(pacman, hostSeries)
 }
 
+// privileged reports whether the agent is running as root; it is a
+// variable so that it can be replaced in tests.
+var privileged = privsep.Privileged
+
 func ensureDependencies() error {
 	pacman, err := getPackageManager()
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/tools/lxdclient"
+	"github.com/juju/juju/utils/privsep"
 )
 
 const lxdBridgeFile = "/etc/default/lxd-bridge"
@@ -95,11 +96,21 @@ func (ci *containerInitialiser) Initialise() error {
 }
 
 // getPackageManager is a helper function which returns the
-// package manager implementation for the current system.
+// package manager implementation for the current system. Agents
+// that are not running as root install packages through the
+// privileged helper.
 func getPackageManager(series string) (manager.PackageManager, error) {
-	return manager.NewPackageManager(series)
+	pacman, err := manager.NewPackageManager(series)
+	if err != nil || privileged() {
+		return pacman, err
+	}
+	return privsep.NewPackageManager(pacman, series)
 }
 
+// privileged reports whether the agent is running as root; it is a
+// variable so that it can be replaced in tests.
+var privileged = privsep.Privileged
+
 // getPackagingConfigurer is a helper function which returns the
 // packaging configuration manager for the current system.
 func getPackagingConfigurer(series string) (config.PackagingConfigurer, error) {
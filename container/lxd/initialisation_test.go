@@ -8,7 +8,9 @@ package lxd
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"path/filepath"
 	"runtime"
 
 	"github.com/juju/testing"
@@ -20,6 +22,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/utils/privsep"
 )
 
 type InitialiserSuite struct {
@@ -79,6 +82,7 @@ func (s *InitialiserSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.calledCmds = []string{}
 	s.PatchValue(&manager.RunCommandWithRetry, getMockRunCommandWithRetry(&s.calledCmds))
+	s.PatchValue(&privileged, func() bool { return true })
 	s.PatchValue(&configureLXDBridge, func() error { return nil })
 	s.PatchValue(&getLXDConfigSetter, func() (configSetter, error) {
 		return &mockConfigSetter{}, nil
@@ -129,6 +133,29 @@ func (s *InitialiserSuite) TestNoSeriesPackages(c *gc.C) {
 	})
 }
 
+func (s *InitialiserSuite) TestUnprivilegedInstallsThroughHelper(c *gc.C) {
+	s.PatchValue(&privileged, func() bool { return false })
+	dir := c.MkDir()
+	helper := filepath.Join(dir, "helper")
+	err := ioutil.WriteFile(helper, []byte(fmt.Sprintf(`#!/bin/sh
+cat > %s/request
+echo '{"output":""}'
+`, dir)), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&privsep.HelperPath, helper)
+
+	s.PatchValue(&series.MustHostSeries, func() string { return "trusty" })
+	container := NewContainerInitialiser("trusty")
+	err = container.Initialise()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.calledCmds, gc.HasLen, 0)
+	request, err := ioutil.ReadFile(filepath.Join(dir, "request"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(request), gc.Equals,
+		`{"command":"apt-get","args":["install","--target-release","trusty-backports","lxd"]}`)
+}
+
 func (s *InitialiserSuite) TestLXDInit(c *gc.C) {
 	// Patch df so it always returns 100GB
 	df100 := func(path string) (uint64, error) {
@@ -6,6 +6,9 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -13,8 +16,10 @@ import (
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
 	"gopkg.in/macaroon-bakery.v1/bakery"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/constraints"
 )
 
 const (
@@ -28,6 +33,12 @@ const (
 	// APIPort is the port used for api connections.
 	APIPort = "api-port"
 
+	// AdditionalAPIPort is a port on which the controllers accept api
+	// connections in addition to APIPort. It is not published to
+	// agents and clients, and is used to keep the old port open while
+	// the api port is moved.
+	AdditionalAPIPort = "additional-api-port"
+
 	// AuditingEnabled determines whether the controller will record
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
@@ -68,6 +79,21 @@ const (
 	// they don't have any access rights to the controller itself.
 	AllowModelAccessKey = "allow-model-access"
 
+	// AllowCredentialGetKey sets whether units of applications that
+	// have been trusted with their model's cloud credential may
+	// retrieve it, with the credential-get hook tool. The credential
+	// is returned as stored, so the units can use it for anything
+	// that the credential allows.
+	AllowCredentialGetKey = "allow-credential-get"
+
+	// AllowedCredentialPlugins is a space separated list of the
+	// credential plugins from which the controller may obtain cloud
+	// credentials, eg "exec:/usr/local/bin/fetch-token env:OS_PASSWORD".
+	// Each entry names an environment variable or file that may be
+	// read, or an executable that may be run. By default, the
+	// controller uses no credential plugins.
+	AllowedCredentialPlugins = "allowed-credential-plugins"
+
 	// MongoMemoryProfile sets whether mongo uses the least possible memory or the
 	// detault
 	MongoMemoryProfile = "mongo-memory-profile"
@@ -82,6 +108,53 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// DeployAllowedCharmSources is a space separated list of charm URL
+	// prefixes, eg "cs:~my-team/ local:". When set, only charms whose
+	// URLs start with one of the prefixes may be deployed.
+	DeployAllowedCharmSources = "deploy-allowed-charm-sources"
+
+	// FaultInjectionEnabled sets whether faults may be injected into
+	// the agents of the controller and its models through their
+	// introspection sockets, so that the handling of failures can be
+	// rehearsed on staging controllers.
+	FaultInjectionEnabled = "fault-injection-enabled"
+
+	// DeployAllowedGitHosts is a space separated list of the hosts,
+	// eg "github.com git.example.com", from which charms may be built
+	// from git repositories. By default, charms may not be built from
+	// git repositories at all.
+	DeployAllowedGitHosts = "deploy-allowed-git-hosts"
+
+	// DeployDeniedSeries is a space separated list of series that
+	// applications may not be deployed to, and machines may not be
+	// added with.
+	DeployDeniedSeries = "deploy-denied-series"
+
+	// DeployRequiredResourceTags is a space separated list of tag names
+	// that must be set in a model's resource-tags before applications
+	// may be deployed or machines added to it.
+	DeployRequiredResourceTags = "deploy-required-resource-tags"
+
+	// DeployMinimumConstraints holds the minimum mem, cores, cpu-power
+	// and root-disk constraints that deployed applications and added
+	// machines must request, eg "mem=4G cores=2".
+	DeployMinimumConstraints = "deploy-minimum-constraints"
+
+	// ModelTemplates holds, as YAML, the named templates from which
+	// models may be created. Each template may choose the cloud
+	// region, credential, config and constraints of the model.
+	ModelTemplates = "model-templates"
+
+	// InstanceStartedWebhook is the URL to which details of every
+	// instance started by the controller's provisioners are POSTed,
+	// eg so that new machines can be registered in a CMDB or DNS.
+	InstanceStartedWebhook = "instance-started-webhook"
+
+	// ModelExpiryWebhook is the URL to which warnings of models about
+	// to expire, and notices of expired models, are POSTed, eg so that
+	// their owners can be told.
+	ModelExpiryWebhook = "model-expiry-webhook"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -116,7 +189,10 @@ const (
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
+	AllowCredentialGetKey,
+	AllowedCredentialPlugins,
 	APIPort,
+	AdditionalAPIPort,
 	AutocertDNSNameKey,
 	AutocertURLKey,
 	CACertKey,
@@ -129,6 +205,15 @@ var ControllerOnlyConfigAttributes = []string{
 	MaxLogsSize,
 	MaxLogsAge,
 	MaxTxnLogSize,
+	DeployAllowedCharmSources,
+	DeployAllowedGitHosts,
+	DeployDeniedSeries,
+	DeployRequiredResourceTags,
+	DeployMinimumConstraints,
+	ModelTemplates,
+	InstanceStartedWebhook,
+	ModelExpiryWebhook,
+	FaultInjectionEnabled,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -142,6 +227,62 @@ func ControllerOnlyAttribute(attr string) bool {
 	return false
 }
 
+// ImmutableConfigAttributes are the controller config attributes that
+// cannot be changed once the controller is bootstrapped.
+var ImmutableConfigAttributes = []string{
+	CACertKey,
+	ControllerUUIDKey,
+}
+
+// LiveReloadConfigAttributes are the controller config attributes
+// whose changes take effect in the running controller agents. Changes
+// to any other attribute only take effect once the controller agents
+// are restarted.
+var LiveReloadConfigAttributes = []string{
+	AllowCredentialGetKey,
+	APIPort,
+	AdditionalAPIPort,
+	AuditingEnabled,
+	MaxLogsAge,
+	MaxLogsSize,
+	DeployAllowedCharmSources,
+	DeployAllowedGitHosts,
+	DeployDeniedSeries,
+	DeployRequiredResourceTags,
+	DeployMinimumConstraints,
+	ModelTemplates,
+	ModelExpiryWebhook,
+}
+
+// RestartRequired returns the sorted names of the attributes that
+// differ between the old and new config, and whose changes only take
+// effect once the controller agents are restarted.
+func RestartRequired(old, new Config) []string {
+	live := make(map[string]bool)
+	for _, attr := range LiveReloadConfigAttributes {
+		live[attr] = true
+	}
+	changed := make(map[string]bool)
+	for attr, value := range old {
+		if !reflect.DeepEqual(value, new[attr]) {
+			changed[attr] = true
+		}
+	}
+	for attr := range new {
+		if _, ok := old[attr]; !ok {
+			changed[attr] = true
+		}
+	}
+	var result []string
+	for attr := range changed {
+		if !live[attr] {
+			result = append(result, attr)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
 type Config map[string]interface{}
 
 // Validate validates the controller configuration.
@@ -210,6 +351,27 @@ func (c Config) APIPort() int {
 	return c.mustInt(APIPort)
 }
 
+// AdditionalAPIPort returns the port on which the API server accepts
+// connections in addition to the api port, or 0 if there is none.
+func (c Config) AdditionalAPIPort() int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[AdditionalAPIPort].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[AdditionalAPIPort].(int)
+	return value
+}
+
+// APIPorts returns the ports on which the API server accepts
+// connections, starting with the api port.
+func (c Config) APIPorts() []int {
+	ports := []int{c.APIPort()}
+	if port := c.AdditionalAPIPort(); port != 0 {
+		ports = append(ports, port)
+	}
+	return ports
+}
+
 // AuditingEnabled returns whether or not auditing has been enabled
 // for the environment. The default is false.
 func (c Config) AuditingEnabled() bool {
@@ -291,6 +453,19 @@ func (c Config) AllowModelAccess() bool {
 	return value
 }
 
+// AllowCredentialGet reports whether units of trusted applications
+// may retrieve their model's cloud credential.
+func (c Config) AllowCredentialGet() bool {
+	value, _ := c[AllowCredentialGetKey].(bool)
+	return value
+}
+
+// AllowedCredentialPlugins returns the credential plugins from which
+// the controller may obtain cloud credentials.
+func (c Config) AllowedCredentialPlugins() []string {
+	return strings.Fields(c.asString(AllowedCredentialPlugins))
+}
+
 // MaxLogsAge is the maximum age of log entries before they are pruned.
 func (c Config) MaxLogsAge() time.Duration {
 	// Value has already been validated.
@@ -313,6 +488,93 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// DeployAllowedCharmSources returns the charm URL prefixes that
+// deployed charms must match, or nil if any charm may be deployed.
+func (c Config) DeployAllowedCharmSources() []string {
+	return strings.Fields(c.asString(DeployAllowedCharmSources))
+}
+
+// FaultInjectionEnabled reports whether faults may be injected into
+// the agents of the controller and its models.
+func (c Config) FaultInjectionEnabled() bool {
+	value, _ := c[FaultInjectionEnabled].(bool)
+	return value
+}
+
+// DeployAllowedGitHosts returns the hosts from which charms may be
+// built from git repositories.
+func (c Config) DeployAllowedGitHosts() []string {
+	return strings.Fields(c.asString(DeployAllowedGitHosts))
+}
+
+// DeployDeniedSeries returns the series that may not be deployed to.
+func (c Config) DeployDeniedSeries() []string {
+	return strings.Fields(c.asString(DeployDeniedSeries))
+}
+
+// DeployRequiredResourceTags returns the names of the resource tags
+// that models must set before deploying to them.
+func (c Config) DeployRequiredResourceTags() []string {
+	return strings.Fields(c.asString(DeployRequiredResourceTags))
+}
+
+// DeployMinimumConstraints returns the minimum constraints that
+// deployments must request.
+func (c Config) DeployMinimumConstraints() constraints.Value {
+	// Value has already been validated.
+	cons, _ := constraints.Parse(c.asString(DeployMinimumConstraints))
+	return cons
+}
+
+// InstanceStartedWebhook returns the URL to which details of started
+// instances are POSTed, or "" if there is none.
+func (c Config) InstanceStartedWebhook() string {
+	return c.asString(InstanceStartedWebhook)
+}
+
+// ModelExpiryWebhook returns the URL to which model expiry warnings
+// and notices are POSTed, or "" if there is none.
+func (c Config) ModelExpiryWebhook() string {
+	return c.asString(ModelExpiryWebhook)
+}
+
+// ModelTemplate holds the choices made for the models created from a
+// controller model template. Choices made explicitly when a model is
+// created take precedence.
+type ModelTemplate struct {
+	// Region is the name of the cloud region of the model.
+	Region string `yaml:"region,omitempty"`
+
+	// Credential is the name of the model owner's cloud credential
+	// used by the model.
+	Credential string `yaml:"credential,omitempty"`
+
+	// Config holds model config attributes.
+	Config map[string]interface{} `yaml:"config,omitempty"`
+
+	// Constraints holds the model constraints, eg "mem=2G cores=1",
+	// which limit the machines added to the model by default.
+	Constraints string `yaml:"constraints,omitempty"`
+}
+
+// ModelTemplates returns the controller's model templates, by name.
+func (c Config) ModelTemplates() (map[string]ModelTemplate, error) {
+	return parseModelTemplates(c.asString(ModelTemplates))
+}
+
+func parseModelTemplates(s string) (map[string]ModelTemplate, error) {
+	templates := make(map[string]ModelTemplate)
+	if err := yaml.Unmarshal([]byte(s), &templates); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, template := range templates {
+		if _, err := constraints.Parse(template.Constraints); err != nil {
+			return nil, errors.Annotatef(err, "model template %q constraints", name)
+		}
+	}
+	return templates, nil
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -348,6 +610,10 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if err := validateAPIPorts(c); err != nil {
+		return errors.Trace(err)
+	}
+
 	if mgoMemProfile, ok := c[MongoMemoryProfile].(string); ok {
 		if mgoMemProfile != MongoProfLow && mgoMemProfile != MongoProfDefault {
 			return errors.Errorf("mongo-memory-profile: expected one of %s or %s got string(%q)", MongoProfLow, MongoProfDefault, mgoMemProfile)
@@ -372,6 +638,119 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[InstanceStartedWebhook].(string); ok && v != "" {
+		if err := validateWebhook("instance started webhook", v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := c[ModelExpiryWebhook].(string); ok && v != "" {
+		if err := validateWebhook("model expiry webhook", v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := c[ModelTemplates].(string); ok {
+		if _, err := parseModelTemplates(v); err != nil {
+			return errors.Annotate(err, "invalid model templates in configuration")
+		}
+	}
+
+	if v, ok := c[AllowedCredentialPlugins].(string); ok {
+		for _, entry := range strings.Fields(v) {
+			if !validCredentialPlugin(entry) {
+				return errors.Errorf("invalid allowed credential plugin %q: expected env:<variable>, file:<path> or exec:<path>", entry)
+			}
+		}
+	}
+
+	if v, ok := c[DeployAllowedGitHosts].(string); ok {
+		for _, host := range strings.Fields(v) {
+			if strings.ContainsAny(host, ":/@") {
+				return errors.Errorf("invalid allowed git host %q: expected a host name", host)
+			}
+		}
+	}
+
+	if v, ok := c[DeployMinimumConstraints].(string); ok {
+		cons, err := constraints.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid deploy minimum constraints in configuration")
+		}
+		validator := constraints.NewValidator()
+		validator.RegisterUnsupported([]string{
+			constraints.Arch,
+			constraints.Container,
+			constraints.InstanceType,
+			constraints.Spaces,
+			constraints.Tags,
+			constraints.VirtType,
+		})
+		unsupported, err := validator.Validate(cons)
+		if err != nil {
+			return errors.Annotate(err, "invalid deploy minimum constraints in configuration")
+		}
+		if len(unsupported) > 0 {
+			return errors.Errorf("deploy minimum constraints: unsupported constraints %s", strings.Join(unsupported, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateAPIPorts returns an error if the api ports are out of range,
+// or clash with each other or the state port.
+func validateAPIPorts(c Config) error {
+	ports := make(map[string]int)
+	for _, attr := range []string{APIPort, AdditionalAPIPort, StatePort} {
+		switch v := c[attr].(type) {
+		case int:
+			ports[attr] = v
+		case float64:
+			ports[attr] = int(v)
+		default:
+			continue
+		}
+		if port := ports[attr]; port < 0 || port > 65535 {
+			return errors.Errorf("%s: port %d out of range", attr, port)
+		}
+	}
+	additional := ports[AdditionalAPIPort]
+	if additional == 0 {
+		return nil
+	}
+	for _, attr := range []string{APIPort, StatePort} {
+		if port, ok := ports[attr]; ok && port == additional {
+			return errors.Errorf("%s: must differ from %s", AdditionalAPIPort, attr)
+		}
+	}
+	return nil
+}
+
+// validCredentialPlugin reports whether the entry in the allowed
+// credential plugins names a plugin kind and its target.
+func validCredentialPlugin(entry string) bool {
+	parts := strings.SplitN(entry, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return false
+	}
+	switch parts[0] {
+	case "env", "file", "exec":
+		return true
+	}
+	return false
+}
+
+// validateWebhook returns an error if the value of the named webhook
+// attribute is not an http or https URL.
+func validateWebhook(name, value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s in configuration", name)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("%s: expected http or https URL, got %q", name, value)
+	}
 	return nil
 }
 
@@ -382,31 +761,55 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
-	MaxTxnLogSize:           schema.String(),
+	AuditingEnabled:            schema.Bool(),
+	APIPort:                    schema.ForceInt(),
+	AdditionalAPIPort:          schema.ForceInt(),
+	StatePort:                  schema.ForceInt(),
+	IdentityURL:                schema.String(),
+	IdentityPublicKey:          schema.String(),
+	SetNUMAControlPolicyKey:    schema.Bool(),
+	AutocertURLKey:             schema.String(),
+	AutocertDNSNameKey:         schema.String(),
+	AllowModelAccessKey:        schema.Bool(),
+	AllowCredentialGetKey:      schema.Bool(),
+	AllowedCredentialPlugins:   schema.String(),
+	MongoMemoryProfile:         schema.String(),
+	MaxLogsAge:                 schema.String(),
+	MaxLogsSize:                schema.String(),
+	MaxTxnLogSize:              schema.String(),
+	DeployAllowedCharmSources:  schema.String(),
+	DeployAllowedGitHosts:      schema.String(),
+	DeployDeniedSeries:         schema.String(),
+	DeployRequiredResourceTags: schema.String(),
+	DeployMinimumConstraints:   schema.String(),
+	ModelTemplates:             schema.String(),
+	InstanceStartedWebhook:     schema.String(),
+	ModelExpiryWebhook:         schema.String(),
+	FaultInjectionEnabled:      schema.Bool(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	APIPort:                    DefaultAPIPort,
+	AdditionalAPIPort:          schema.Omit,
+	AuditingEnabled:            DefaultAuditingEnabled,
+	StatePort:                  DefaultStatePort,
+	IdentityURL:                schema.Omit,
+	IdentityPublicKey:          schema.Omit,
+	SetNUMAControlPolicyKey:    DefaultNUMAControlPolicy,
+	AutocertURLKey:             schema.Omit,
+	AutocertDNSNameKey:         schema.Omit,
+	AllowModelAccessKey:        schema.Omit,
+	AllowCredentialGetKey:      schema.Omit,
+	AllowedCredentialPlugins:   schema.Omit,
+	MongoMemoryProfile:         schema.Omit,
+	MaxLogsAge:                 fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:                fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	MaxTxnLogSize:              fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	DeployAllowedCharmSources:  schema.Omit,
+	DeployAllowedGitHosts:      schema.Omit,
+	DeployDeniedSeries:         schema.Omit,
+	DeployRequiredResourceTags: schema.Omit,
+	DeployMinimumConstraints:   schema.Omit,
+	ModelTemplates:             schema.Omit,
+	InstanceStartedWebhook:     schema.Omit,
+	ModelExpiryWebhook:         schema.Omit,
+	FaultInjectionEnabled:      schema.Omit,
 })
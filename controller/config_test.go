@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/testing"
 )
@@ -121,6 +122,70 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "invalid deploy minimum constraints",
+	config: controller.Config{
+		controller.DeployMinimumConstraints: "mem=lots",
+		controller.CACertKey:                testing.CACert,
+	},
+	expectError: `invalid deploy minimum constraints in configuration: bad "mem" constraint: .*`,
+}, {
+	about: "unsupported deploy minimum constraints",
+	config: controller.Config{
+		controller.DeployMinimumConstraints: "mem=4G arch=amd64",
+		controller.CACertKey:                testing.CACert,
+	},
+	expectError: `deploy minimum constraints: unsupported constraints arch`,
+}, {
+	about: "instance started webhook",
+	config: controller.Config{
+		controller.InstanceStartedWebhook: "https://cmdb.example.com/machines",
+		controller.CACertKey:              testing.CACert,
+	},
+}, {
+	about: "invalid instance started webhook",
+	config: controller.Config{
+		controller.InstanceStartedWebhook: "ftp://cmdb.example.com/machines",
+		controller.CACertKey:              testing.CACert,
+	},
+	expectError: `instance started webhook: expected http or https URL, got "ftp://cmdb.example.com/machines"`,
+}, {
+	about: "invalid model expiry webhook",
+	config: controller.Config{
+		controller.ModelExpiryWebhook: "mailto:ops@example.com",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `model expiry webhook: expected http or https URL, got "mailto:ops@example.com"`,
+}, {
+	about: "additional api port",
+	config: controller.Config{
+		controller.APIPort:           443,
+		controller.AdditionalAPIPort: 17070,
+		controller.CACertKey:         testing.CACert,
+	},
+}, {
+	about: "additional api port same as api port",
+	config: controller.Config{
+		controller.APIPort:           17070,
+		controller.AdditionalAPIPort: 17070,
+		controller.CACertKey:         testing.CACert,
+	},
+	expectError: `additional-api-port: must differ from api-port`,
+}, {
+	about: "additional api port same as state port",
+	config: controller.Config{
+		controller.StatePort:         37017,
+		controller.AdditionalAPIPort: 37017,
+		controller.CACertKey:         testing.CACert,
+	},
+	expectError: `additional-api-port: must differ from state-port`,
+}, {
+	about: "api port out of range",
+	config: controller.Config{
+		controller.APIPort:   70000,
+		controller.CACertKey: testing.CACert,
+	},
+	expectError: `api-port: port 70000 out of range`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -173,3 +238,209 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestDeployPolicyDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DeployAllowedCharmSources(), gc.HasLen, 0)
+	c.Assert(cfg.DeployDeniedSeries(), gc.HasLen, 0)
+	c.Assert(cfg.DeployRequiredResourceTags(), gc.HasLen, 0)
+	cons := cfg.DeployMinimumConstraints()
+	c.Assert(constraints.IsEmpty(&cons), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestDeployPolicyValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"deploy-allowed-charm-sources":  "cs:~my-team/ local:",
+			"deploy-denied-series":          "precise trusty",
+			"deploy-required-resource-tags": "owner cost-centre",
+			"deploy-minimum-constraints":    "mem=4G cores=2",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DeployAllowedCharmSources(), jc.DeepEquals, []string{"cs:~my-team/", "local:"})
+	c.Assert(cfg.DeployDeniedSeries(), jc.DeepEquals, []string{"precise", "trusty"})
+	c.Assert(cfg.DeployRequiredResourceTags(), jc.DeepEquals, []string{"owner", "cost-centre"})
+	c.Assert(cfg.DeployMinimumConstraints(), jc.DeepEquals, constraints.MustParse("mem=4G cores=2"))
+}
+
+func (s *ConfigSuite) TestAllowedCredentialPlugins(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllowedCredentialPlugins(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"allowed-credential-plugins": "exec:/usr/local/bin/fetch-token env:OS_PASSWORD",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllowedCredentialPlugins(), jc.DeepEquals, []string{"exec:/usr/local/bin/fetch-token", "env:OS_PASSWORD"})
+}
+
+func (s *ConfigSuite) TestAllowedCredentialPluginsInvalid(c *gc.C) {
+	_, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"allowed-credential-plugins": "exec:/usr/local/bin/fetch-token carrier-pigeon:coop",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, `invalid allowed credential plugin "carrier-pigeon:coop": expected env:<variable>, file:<path> or exec:<path>`)
+}
+
+func (s *ConfigSuite) TestDeployAllowedGitHosts(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DeployAllowedGitHosts(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"deploy-allowed-git-hosts": "github.com git.example.com",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DeployAllowedGitHosts(), jc.DeepEquals, []string{"github.com", "git.example.com"})
+}
+
+func (s *ConfigSuite) TestDeployAllowedGitHostsInvalid(c *gc.C) {
+	_, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"deploy-allowed-git-hosts": "github.com https://git.example.com/",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, `invalid allowed git host "https://git.example.com/": expected a host name`)
+}
+
+func (s *ConfigSuite) TestFaultInjectionEnabled(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.FaultInjectionEnabled(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"fault-injection-enabled": true,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.FaultInjectionEnabled(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestRestartRequired(c *gc.C) {
+	old := controller.Config{
+		"state-port":       37017,
+		"auditing-enabled": false,
+		"max-logs-age":     "72h",
+		"identity-url":     "https://login.example.com",
+	}
+	new := controller.Config{
+		"state-port":         37018,
+		"auditing-enabled":   true,
+		"max-logs-age":       "72h",
+		"allow-model-access": true,
+	}
+	c.Assert(controller.RestartRequired(old, new), jc.DeepEquals, []string{
+		"allow-model-access", "identity-url", "state-port",
+	})
+}
+
+func (s *ConfigSuite) TestRestartRequiredLiveOnly(c *gc.C) {
+	old := controller.Config{"max-logs-size": "4G", "api-port": 17070}
+	new := controller.Config{
+		"max-logs-size":        "8G",
+		"auditing-enabled":     true,
+		"api-port":             443,
+		"additional-api-port":  17070,
+		"allow-credential-get": true,
+	}
+	c.Assert(controller.RestartRequired(old, new), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestAllowCredentialGet(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllowCredentialGet(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		"allow-credential-get": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllowCredentialGet(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestAPIPorts(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AdditionalAPIPort(), gc.Equals, 0)
+	c.Assert(cfg.APIPorts(), jc.DeepEquals, []int{17070})
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"api-port":            443,
+			"additional-api-port": 17070,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AdditionalAPIPort(), gc.Equals, 17070)
+	c.Assert(cfg.APIPorts(), jc.DeepEquals, []int{443, 17070})
+}
+
+func (s *ConfigSuite) TestModelTemplates(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"model-templates": `
+small-staging:
+  region: us-east-1
+  credential: staging
+  config:
+    logging-config: <root>=DEBUG
+  constraints: mem=2G cores=1
+`,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	templates, err := cfg.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, jc.DeepEquals, map[string]controller.ModelTemplate{
+		"small-staging": {
+			Region:      "us-east-1",
+			Credential:  "staging",
+			Config:      map[string]interface{}{"logging-config": "<root>=DEBUG"},
+			Constraints: "mem=2G cores=1",
+		},
+	})
+}
+
+func (s *ConfigSuite) TestModelTemplatesDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	templates, err := cfg.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestModelTemplatesInvalid(c *gc.C) {
+	_, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"model-templates": "small: {constraints: mem=lots}",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, `invalid model templates in configuration: model template "small" constraints: .*`)
+}
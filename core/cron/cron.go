@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron schedule expressions, and computes when
+// they are next due.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron expression of the standard form
+// "minute hour day-of-month month day-of-week".
+type Schedule struct {
+	expr string
+
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record whether the day of month and day of
+	// week fields started with "*". When both are restricted, a day
+	// matching either field matches, as in cron(8).
+	domAny, dowAny bool
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Parse parses a cron expression of five space-separated fields. Each
+// field is "*", a value, a range "a-b", or a comma-separated list of
+// those, each optionally followed by a step "/n". In the day of week
+// field both 0 and 7 mean Sunday.
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, errors.NotValidf(
+			"cron expression %q (expected %d fields, got %d)",
+			expr, len(fields), len(parts),
+		)
+	}
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing cron expression %q", expr)
+		}
+		bits[i] = b
+	}
+	s := &Schedule{
+		expr:   strings.Join(parts, " "),
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: strings.HasPrefix(parts[2], "*"),
+		dowAny: strings.HasPrefix(parts[4], "*"),
+	}
+	// Sunday may be written as 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+func parseField(spec string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(spec, ",") {
+		rangeSpec, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, errors.NotValidf("%s step %q", f.name, item[i+1:])
+			}
+			rangeSpec, step = item[:i], n
+		}
+		var lo, hi int
+		switch {
+		case rangeSpec == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangeSpec, "-"):
+			i := strings.Index(rangeSpec, "-")
+			var err error
+			if lo, err = parseValue(rangeSpec[:i], f); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = parseValue(rangeSpec[i+1:], f); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi < lo {
+				return 0, errors.NotValidf("%s range %q", f.name, rangeSpec)
+			}
+		default:
+			v, err := parseValue(rangeSpec, f)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.NotValidf("%s %q (expected %d-%d)", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String returns the schedule's cron expression.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// maxSearchYears bounds the search for the next matching time, for
+// schedules that can never match, such as "0 0 31 2 *".
+const maxSearchYears = 5
+
+// Next returns the earliest time after t, truncated to the minute,
+// that matches the schedule, in t's location. If there is no such
+// time within the next few years, the zero time is returned.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+func mustParseTime(c *gc.C, s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	c.Assert(err, jc.ErrorIsNil)
+	return t
+}
+
+var nextTests = []struct {
+	expr string
+	from string
+	next string
+}{
+	{"* * * * *", "2017-06-01 10:00", "2017-06-01 10:01"},
+	{"0 2 * * *", "2017-06-01 10:00", "2017-06-02 02:00"},
+	{"0 2 * * *", "2017-06-01 01:59", "2017-06-01 02:00"},
+	{"*/15 * * * *", "2017-06-01 10:16", "2017-06-01 10:30"},
+	{"5/20 * * * *", "2017-06-01 10:26", "2017-06-01 10:45"},
+	{"0 9-17/4 * * *", "2017-06-01 14:00", "2017-06-01 17:00"},
+	{"30 4 1,15 * *", "2017-06-02 00:00", "2017-06-15 04:30"},
+	{"0 0 * * 0", "2017-06-01 00:00", "2017-06-04 00:00"},
+	{"0 0 * * 7", "2017-06-01 00:00", "2017-06-04 00:00"},
+	{"0 0 13 * 5", "2017-06-01 00:00", "2017-06-02 00:00"},
+	{"0 0 1 1 *", "2017-06-01 00:00", "2018-01-01 00:00"},
+	{"0 0 29 2 *", "2017-03-01 00:00", "2020-02-29 00:00"},
+	{"59 23 31 12 *", "2017-12-31 23:59", "2018-12-31 23:59"},
+}
+
+func (s *CronSuite) TestNext(c *gc.C) {
+	for i, test := range nextTests {
+		c.Logf("test %d: %q from %s", i, test.expr, test.from)
+		schedule, err := cron.Parse(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		next := schedule.Next(mustParseTime(c, test.from))
+		c.Check(next, gc.Equals, mustParseTime(c, test.next))
+	}
+}
+
+func (s *CronSuite) TestNextNeverMatches(c *gc.C) {
+	schedule, err := cron.Parse("0 0 31 2 *")
+	c.Assert(err, jc.ErrorIsNil)
+	next := schedule.Next(mustParseTime(c, "2017-01-01 00:00"))
+	c.Assert(next.IsZero(), jc.IsTrue)
+}
+
+func (s *CronSuite) TestString(c *gc.C) {
+	schedule, err := cron.Parse("  0  2 * *   1-5 ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.String(), gc.Equals, "0 2 * * 1-5")
+}
+
+var parseErrorTests = []struct {
+	expr string
+	err  string
+}{
+	{"", `cron expression "" \(expected 5 fields, got 0\) not valid`},
+	{"* * * *", `cron expression "\* \* \* \*" \(expected 5 fields, got 4\) not valid`},
+	{"60 * * * *", `parsing cron expression .*: minute "60" \(expected 0-59\) not valid`},
+	{"* 24 * * *", `parsing cron expression .*: hour "24" \(expected 0-23\) not valid`},
+	{"* * 0 * *", `parsing cron expression .*: day of month "0" \(expected 1-31\) not valid`},
+	{"* * * 13 *", `parsing cron expression .*: month "13" \(expected 1-12\) not valid`},
+	{"* * * * 8", `parsing cron expression .*: day of week "8" \(expected 0-7\) not valid`},
+	{"*/0 * * * *", `parsing cron expression .*: minute step "0" not valid`},
+	{"5-1 * * * *", `parsing cron expression .*: minute range "5-1" not valid`},
+	{"a * * * *", `parsing cron expression .*: minute "a" \(expected 0-59\) not valid`},
+}
+
+func (s *CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range parseErrorTests {
+		c.Logf("test %d: %q", i, test.expr)
+		_, err := cron.Parse(test.expr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
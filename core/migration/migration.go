@@ -51,6 +51,18 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// CharmGitSources maps the URLs of the charms in use in the model
+	// that were built from git repositories to their git sources.
+	CharmGitSources map[string]CharmGitSource
+}
+
+// CharmGitSource identifies the git repository and commit from which
+// a charm was built.
+type CharmGitSource struct {
+	Repository string
+	Ref        string
+	Revision   string
 }
 
 // SerializedModelResource defines the resource revisions for a
@@ -68,6 +80,14 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// CloudCredential identifies the cloud credential used by the
+	// model, if any.
+	CloudCredential names.CloudCredentialTag
+
+	// StorageProviders holds the types of the storage providers
+	// referenced by the model's storage pools.
+	StorageProviders []string
 }
 
 func (i *ModelInfo) Validate() error {
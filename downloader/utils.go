@@ -11,6 +11,8 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+
+	"github.com/juju/juju/utils/httptransport"
 )
 
 // NewHTTPBlobOpener returns a blob opener func suitable for use with
@@ -19,7 +21,7 @@ import (
 func NewHTTPBlobOpener(hostnameVerification utils.SSLHostnameVerification) func(*url.URL) (io.ReadCloser, error) {
 	return func(url *url.URL) (io.ReadCloser, error) {
 		// TODO(rog) make the download operation interruptible.
-		client := utils.GetHTTPClient(hostnameVerification)
+		client := httptransport.NewClient(hostnameVerification)
 		resp, err := client.Get(url.String())
 		if err != nil {
 			return nil, err
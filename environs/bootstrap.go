@@ -60,6 +60,11 @@ type BootstrapParams struct {
 	// that rely on it for selecting images. This will be empty for
 	// providers that do not implements simplestreams.HasRegion.
 	ImageMetadata []*imagemetadata.ImageMetadata
+
+	// ResourceRecorder, if non-nil, should be informed of each cloud
+	// resource created while bootstrapping, so that exactly those
+	// resources can be removed if bootstrap fails.
+	ResourceRecorder ResourceRecorder
 }
 
 // BootstrapFinalizer is a function returned from Environ.Bootstrap.
@@ -123,3 +128,39 @@ type BootstrapContext interface {
 	// credentials should be verified.
 	ShouldVerifyCredentials() bool
 }
+
+// Kinds of cloud resource recorded while bootstrapping a controller.
+const (
+	ResourceKindInstance      = "instance"
+	ResourceKindSecurityGroup = "security-group"
+	ResourceKindVolume        = "volume"
+	ResourceKindKeyPair       = "keypair"
+)
+
+// BootstrapResource identifies a cloud resource created while
+// bootstrapping a controller.
+type BootstrapResource struct {
+	// Kind is the kind of the resource, e.g. ResourceKindInstance.
+	Kind string `json:"kind"`
+
+	// Id is the provider-specific ID of the resource.
+	Id string `json:"id"`
+}
+
+// ResourceRecorder records the cloud resources created while
+// bootstrapping a controller.
+type ResourceRecorder interface {
+	// RecordResource records that the resource of the given kind
+	// and ID has been created.
+	RecordResource(kind, id string)
+}
+
+// ResourceRemover is an interface that may be implemented by an Environ
+// that can remove individual resources, other than instances, recorded
+// while bootstrapping a controller. Instances are stopped with
+// StopInstances.
+type ResourceRemover interface {
+	// RemoveResources removes the specified resources. Resources
+	// that no longer exist are ignored.
+	RemoveResources(resources []BootstrapResource) error
+}
@@ -140,6 +140,10 @@ type BootstrapParams struct {
 
 	// DialOpts contains the bootstrap dial options.
 	DialOpts environs.BootstrapDialOpts
+
+	// ResourceRecorder, if non-nil, records the cloud resources
+	// created while bootstrapping the controller.
+	ResourceRecorder environs.ResourceRecorder
 }
 
 // Validate validates the bootstrap parameters.
@@ -366,6 +370,7 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 		Placement:            args.Placement,
 		AvailableTools:       availableTools,
 		ImageMetadata:        imageMetadata,
+		ResourceRecorder:     args.ResourceRecorder,
 	})
 	if err != nil {
 		return err
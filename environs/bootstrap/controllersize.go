@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+)
+
+// ControllerSize is a named controller sizing preset.
+type ControllerSize string
+
+const (
+	// ControllerSizeSmall is suitable for evaluation and for
+	// controllers managing a small number of machines.
+	ControllerSizeSmall ControllerSize = "small"
+
+	// ControllerSizeMedium is suitable for most production
+	// controllers.
+	ControllerSizeMedium ControllerSize = "medium"
+
+	// ControllerSizeLarge is suitable for controllers managing
+	// many models or thousands of agents.
+	ControllerSizeLarge ControllerSize = "large"
+)
+
+// ControllerSizes holds the valid controller sizing presets, from
+// smallest to largest.
+var ControllerSizes = []ControllerSize{
+	ControllerSizeSmall,
+	ControllerSizeMedium,
+	ControllerSizeLarge,
+}
+
+// genericControllerSizes holds the constraints used for each preset
+// by providers that do not implement environs.ControllerSizer.
+var genericControllerSizes = map[ControllerSize]string{
+	ControllerSizeSmall:  "cores=2 mem=8G",
+	ControllerSizeMedium: "cores=4 mem=16G",
+	ControllerSizeLarge:  "cores=8 mem=32G",
+}
+
+// ParseControllerSize returns the controller sizing preset with the
+// given name.
+func ParseControllerSize(name string) (ControllerSize, error) {
+	for _, size := range ControllerSizes {
+		if string(size) == name {
+			return size, nil
+		}
+	}
+	names := make([]string, len(ControllerSizes))
+	for i, size := range ControllerSizes {
+		names[i] = string(size)
+	}
+	return "", errors.NotValidf("controller size %q (expected one of %s)", name, strings.Join(names, ", "))
+}
+
+// ControllerSizeConstraints returns the constraints used to provision
+// controllers of the given size in the given environ. Providers may
+// map the presets to their own instance types by implementing
+// environs.ControllerSizer; otherwise generic CPU and memory
+// constraints are used.
+func ControllerSizeConstraints(env environs.Environ, size ControllerSize) (constraints.Value, error) {
+	generic, ok := genericControllerSizes[size]
+	if !ok {
+		return constraints.Value{}, errors.NotValidf("controller size %q", size)
+	}
+	if sizer, ok := env.(environs.ControllerSizer); ok {
+		cons, err := sizer.ControllerSizeConstraints(string(size))
+		if err == nil {
+			return cons, nil
+		} else if !errors.IsNotSupported(err) {
+			return constraints.Value{}, errors.Trace(err)
+		}
+	}
+	return constraints.MustParse(generic), nil
+}
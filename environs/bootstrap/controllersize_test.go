@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type controllerSizeSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&controllerSizeSuite{})
+
+func (s *controllerSizeSuite) TestParseControllerSize(c *gc.C) {
+	for _, size := range []string{"small", "medium", "large"} {
+		parsed, err := bootstrap.ParseControllerSize(size)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, bootstrap.ControllerSize(size))
+	}
+	_, err := bootstrap.ParseControllerSize("huge")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `controller size "huge" \(expected one of small, medium, large\) not valid`)
+}
+
+func (s *controllerSizeSuite) TestGenericConstraints(c *gc.C) {
+	env := &sizerEnviron{}
+	for size, expected := range map[bootstrap.ControllerSize]string{
+		bootstrap.ControllerSizeSmall:  "cores=2 mem=8G",
+		bootstrap.ControllerSizeMedium: "cores=4 mem=16G",
+		bootstrap.ControllerSizeLarge:  "cores=8 mem=32G",
+	} {
+		cons, err := bootstrap.ControllerSizeConstraints(env, size)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(cons, jc.DeepEquals, constraints.MustParse(expected))
+	}
+}
+
+func (s *controllerSizeSuite) TestProviderConstraints(c *gc.C) {
+	env := &controllerSizerEnviron{
+		sizes: map[string]constraints.Value{
+			"large": constraints.MustParse("instance-type=huge.2"),
+		},
+	}
+	cons, err := bootstrap.ControllerSizeConstraints(env, bootstrap.ControllerSizeLarge)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("instance-type=huge.2"))
+
+	// Sizes the provider does not map fall back to the generic
+	// constraints.
+	cons, err = bootstrap.ControllerSizeConstraints(env, bootstrap.ControllerSizeSmall)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("cores=2 mem=8G"))
+}
+
+func (s *controllerSizeSuite) TestProviderError(c *gc.C) {
+	env := &controllerSizerEnviron{err: errors.New("boom")}
+	_, err := bootstrap.ControllerSizeConstraints(env, bootstrap.ControllerSizeLarge)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSizeSuite) TestInvalidSize(c *gc.C) {
+	_, err := bootstrap.ControllerSizeConstraints(&sizerEnviron{}, bootstrap.ControllerSize("huge"))
+	c.Assert(err, gc.ErrorMatches, `controller size "huge" not valid`)
+}
+
+type sizerEnviron struct {
+	environs.Environ
+}
+
+type controllerSizerEnviron struct {
+	sizerEnviron
+	sizes map[string]constraints.Value
+	err   error
+}
+
+func (e *controllerSizerEnviron) ControllerSizeConstraints(size string) (constraints.Value, error) {
+	if e.err != nil {
+		return constraints.Value{}, e.err
+	}
+	cons, ok := e.sizes[size]
+	if !ok {
+		return constraints.Value{}, errors.NotSupportedf("controller size %q", size)
+	}
+	return cons, nil
+}
@@ -148,12 +148,18 @@ func prepare(
 		return nil, details, errors.Trace(err)
 	}
 
-	cfg, err = p.PrepareConfig(environs.PrepareConfigParams{args.Cloud, cfg})
+	// The plugin credential itself is recorded for the controller, so
+	// that credentials are obtained from the plugin when they are used.
+	cloudSpec, err := environs.ResolvePluginCredential(args.Cloud)
+	if err != nil {
+		return nil, details, errors.Trace(err)
+	}
+	cfg, err = p.PrepareConfig(environs.PrepareConfigParams{cloudSpec, cfg})
 	if err != nil {
 		return nil, details, errors.Trace(err)
 	}
 	env, err := p.Open(environs.OpenParams{
-		Cloud:  args.Cloud,
+		Cloud:  cloudSpec,
 		Config: cfg,
 	})
 	if err != nil {
@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// ResourceLogPath returns the path of the client-side log of the cloud
+// resources created while bootstrapping the named controller.
+func ResourceLogPath(controllerName string) string {
+	return osenv.JujuXDGDataHomePath("bootstrap-logs", controllerName+".log")
+}
+
+// ResourceLog is a client-side log of the cloud resources created while
+// bootstrapping a controller. Each resource is appended to the log file
+// as it is recorded, so that the log survives the client being killed
+// partway through bootstrap.
+type ResourceLog struct {
+	path string
+
+	mu sync.Mutex
+}
+
+// NewResourceLog returns a ResourceLog that records resources in the
+// file at the given path, replacing any existing log.
+func NewResourceLog(path string) (*ResourceLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ResourceLog{path: path}, nil
+}
+
+// RecordResource is part of the environs.ResourceRecorder interface.
+// Failure to write the log is logged, and does not fail bootstrap.
+func (l *ResourceLog) RecordResource(kind, id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.append(environs.BootstrapResource{Kind: kind, Id: id}); err != nil {
+		logger.Warningf("cannot record %s %q in bootstrap log: %v", kind, id, err)
+	}
+}
+
+func (l *ResourceLog) append(resource environs.BootstrapResource) error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Sync())
+}
+
+// Remove removes the log file.
+func (l *ResourceLog) Remove() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ReadResourceLog returns the resources recorded in the log file at
+// the given path, in the order in which they were recorded. If there
+// is no log file, an error satisfying errors.IsNotFound is returned.
+func ReadResourceLog(path string) ([]environs.BootstrapResource, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("bootstrap log %q", path)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+
+	var resources []environs.BootstrapResource
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var resource environs.BootstrapResource
+		if err := json.Unmarshal(scanner.Bytes(), &resource); err != nil {
+			// A partially written final line is expected if the
+			// client was killed while recording a resource.
+			logger.Warningf("ignoring malformed bootstrap log entry %q", scanner.Text())
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotatef(err, "reading bootstrap log %q", path)
+	}
+	return resources, nil
+}
+
+// RemoveResources removes exactly the specified resources, recorded
+// while bootstrapping a controller, from the environ. Instances are
+// stopped first, so that the resources they use may then be removed.
+// Resources other than instances can only be removed if the environ
+// implements environs.ResourceRemover.
+func RemoveResources(env environs.Environ, resources []environs.BootstrapResource) error {
+	var instanceIds []instance.Id
+	var others []environs.BootstrapResource
+	for _, resource := range resources {
+		if resource.Kind == environs.ResourceKindInstance {
+			instanceIds = append(instanceIds, instance.Id(resource.Id))
+		} else {
+			others = append(others, resource)
+		}
+	}
+	if len(instanceIds) > 0 {
+		if err := env.StopInstances(instanceIds...); err != nil {
+			return errors.Annotate(err, "stopping instances")
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	remover, ok := env.(environs.ResourceRemover)
+	if !ok {
+		return errors.NotSupportedf("removing resources other than instances")
+	}
+	return errors.Annotate(remover.RemoveResources(others), "removing resources")
+}
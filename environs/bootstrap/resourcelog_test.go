@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/instance"
+)
+
+type resourceLogSuite struct {
+	testing.IsolationSuite
+	path string
+}
+
+var _ = gc.Suite(&resourceLogSuite{})
+
+func (s *resourceLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.path = filepath.Join(c.MkDir(), "bootstrap-logs", "ctrl.log")
+}
+
+func (s *resourceLogSuite) TestRecordAndRead(c *gc.C) {
+	resourceLog, err := bootstrap.NewResourceLog(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	resourceLog.RecordResource(environs.ResourceKindSecurityGroup, "juju-group")
+	resourceLog.RecordResource(environs.ResourceKindInstance, "inst-0")
+
+	resources, err := bootstrap.ReadResourceLog(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, []environs.BootstrapResource{
+		{Kind: environs.ResourceKindSecurityGroup, Id: "juju-group"},
+		{Kind: environs.ResourceKindInstance, Id: "inst-0"},
+	})
+
+	err = resourceLog.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = bootstrap.ReadResourceLog(s.path)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *resourceLogSuite) TestNewResourceLogReplacesExisting(c *gc.C) {
+	resourceLog, err := bootstrap.NewResourceLog(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	resourceLog.RecordResource(environs.ResourceKindInstance, "inst-0")
+
+	_, err = bootstrap.NewResourceLog(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	resources, err := bootstrap.ReadResourceLog(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+}
+
+func (s *resourceLogSuite) TestReadIgnoresMalformedEntries(c *gc.C) {
+	err := os.MkdirAll(filepath.Dir(s.path), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(s.path, []byte(`{"kind":"instance","id":"inst-0"}
+
+{"kind":"volu`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	resources, err := bootstrap.ReadResourceLog(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, []environs.BootstrapResource{
+		{Kind: environs.ResourceKindInstance, Id: "inst-0"},
+	})
+}
+
+func (s *resourceLogSuite) TestReadNotFound(c *gc.C) {
+	_, err := bootstrap.ReadResourceLog(s.path)
+	c.Assert(err, gc.ErrorMatches, `bootstrap log ".*ctrl.log" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *resourceLogSuite) TestRemoveResources(c *gc.C) {
+	env := &resourceRemovingEnviron{}
+	err := bootstrap.RemoveResources(env, []environs.BootstrapResource{
+		{Kind: environs.ResourceKindSecurityGroup, Id: "juju-group"},
+		{Kind: environs.ResourceKindInstance, Id: "inst-0"},
+		{Kind: environs.ResourceKindVolume, Id: "vol-0"},
+		{Kind: environs.ResourceKindInstance, Id: "inst-1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckCalls(c, []testing.StubCall{
+		{"StopInstances", []interface{}{[]instance.Id{"inst-0", "inst-1"}}},
+		{"RemoveResources", []interface{}{[]environs.BootstrapResource{
+			{Kind: environs.ResourceKindSecurityGroup, Id: "juju-group"},
+			{Kind: environs.ResourceKindVolume, Id: "vol-0"},
+		}}},
+	})
+}
+
+func (s *resourceLogSuite) TestRemoveResourcesStopInstancesError(c *gc.C) {
+	env := &resourceRemovingEnviron{}
+	env.SetErrors(errors.New("nope"))
+	err := bootstrap.RemoveResources(env, []environs.BootstrapResource{
+		{Kind: environs.ResourceKindInstance, Id: "inst-0"},
+		{Kind: environs.ResourceKindVolume, Id: "vol-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "stopping instances: nope")
+	env.CheckCallNames(c, "StopInstances")
+}
+
+func (s *resourceLogSuite) TestRemoveResourcesNotSupported(c *gc.C) {
+	env := &instanceStoppingEnviron{}
+	err := bootstrap.RemoveResources(env, []environs.BootstrapResource{
+		{Kind: environs.ResourceKindInstance, Id: "inst-0"},
+		{Kind: environs.ResourceKindVolume, Id: "vol-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "removing resources other than instances not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	env.CheckCallNames(c, "StopInstances")
+}
+
+type instanceStoppingEnviron struct {
+	environs.Environ
+	testing.Stub
+}
+
+func (env *instanceStoppingEnviron) StopInstances(ids ...instance.Id) error {
+	env.MethodCall(env, "StopInstances", ids)
+	return env.NextErr()
+}
+
+type resourceRemovingEnviron struct {
+	instanceStoppingEnviron
+}
+
+func (env *resourceRemovingEnviron) RemoveResources(resources []environs.BootstrapResource) error {
+	env.MethodCall(env, "RemoveResources", resources)
+	return env.NextErr()
+}
@@ -91,6 +91,22 @@ type StartInstanceParams struct {
 	// changes in status. Its signature is consistent with other
 	// status-related functions to allow them to be used as callbacks.
 	StatusCallback StatusCallbackFunc
+
+	// ResourceRecorder, if non-nil, should be informed of each cloud
+	// resource, other than the instance itself, created while starting
+	// the instance. It is only set when bootstrapping.
+	ResourceRecorder ResourceRecorder
+
+	// ExcludedAvailabilityZones, if non-empty, holds the names of
+	// availability zones in which the instance should not be started,
+	// because an earlier attempt failed there for lack of capacity.
+	// It overrides any zone chosen by placement.
+	ExcludedAvailabilityZones []string
+
+	// ExcludedInstanceTypes, if non-empty, holds the names of instance
+	// types that should not be used to start the instance, because an
+	// earlier attempt failed with them for lack of capacity.
+	ExcludedInstanceTypes []string
 }
 
 // StartInstanceResult holds the result of an
@@ -146,3 +162,43 @@ type InstanceBroker interface {
 	// correct network configuration.
 	MaintainInstance(args StartInstanceParams) error
 }
+
+// InstanceTeardown is an optional interface that an InstanceBroker may
+// implement, so that the provisioner can release the resources associated
+// with instances in ordered phases when it stops them, rather than leaving
+// them to be discovered by the provider afterwards. All methods must be
+// idempotent, and must ignore unknown instance IDs.
+type InstanceTeardown interface {
+	// DetachInstanceVolumes detaches all volumes attached to the
+	// instances with the specified IDs.
+	DetachInstanceVolumes(...instance.Id) error
+
+	// ReleaseInstanceAddresses releases the public (floating) addresses
+	// associated with the instances with the specified IDs.
+	ReleaseInstanceAddresses(...instance.Id) error
+
+	// InstanceSecurityGroups returns the names of the security groups
+	// belonging specifically to the instances with the specified IDs.
+	// It is called before the instances are stopped, and the groups
+	// are deleted with DeleteSecurityGroups once they have been.
+	InstanceSecurityGroups(...instance.Id) ([]string, error)
+
+	// DeleteSecurityGroups deletes the security groups with the
+	// specified names. Unknown names are ignored.
+	DeleteSecurityGroups(...string) error
+}
+
+// InstanceSuspender is an optional interface that an InstanceBroker may
+// implement, so that the instances of a model may be shut down while it
+// is suspended and started again when it is resumed, keeping their
+// disks and identities. Both methods must be idempotent, and must
+// ignore unknown instance IDs.
+type InstanceSuspender interface {
+	// SuspendInstances shuts down the instances with the specified
+	// IDs without terminating them.
+	SuspendInstances(...instance.Id) error
+
+	// ResumeInstances starts the instances with the specified IDs
+	// that were shut down by SuspendInstances.
+	ResumeInstances(...instance.Id) error
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charmrepo.v2-unstable"
 	"gopkg.in/juju/environschema.v1"
@@ -45,6 +47,22 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// DirectAgentConnection means that the controller may connect
+	// directly to the machines in the model.
+	DirectAgentConnection = "direct"
+
+	// ReverseAgentConnection means that the controller cannot reach
+	// the machines in the model, so their agents open persistent
+	// reverse channels to the controller, through which connections
+	// to the machines are proxied.
+	ReverseAgentConnection = "reverse"
+)
+
+// DNSProviders holds the names of the DNS providers that may be
+// configured with DNSProviderKey.
+var DNSProviders = []string{"designate", "nsupdate", "route53"}
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -165,6 +183,76 @@ const (
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
 
+	// DefaultSpace is the space that application endpoints are bound
+	// to when they are not bound explicitly at deploy time.
+	DefaultSpace = "default-space"
+
+	// ManagementSpace is the space through which agents reach the
+	// controller. When set, machines are provisioned with an address
+	// in the space and agents connect to the controller only over it.
+	ManagementSpace = "management-space"
+
+	// MaxRelationSettingsSize is the maximum size in bytes of the
+	// settings a unit may store for a single relation, eg 1048576.
+	MaxRelationSettingsSize = "max-relation-settings-size"
+
+	// MaxRelationSettingsKeys is the maximum number of settings a unit
+	// may store for a single relation, eg 1000.
+	MaxRelationSettingsKeys = "max-relation-settings-keys"
+
+	// StorageUsageWarningThreshold is the percentage of a filesystem's
+	// space that may be used before the status of units using it
+	// warns that it is nearly full, eg 90.
+	StorageUsageWarningThreshold = "storage-usage-warning-threshold"
+
+	// HookEnvironmentKey is an optional list or space-separated string
+	// of k=v pairs, defining extra environment variables for all hook
+	// executions in the model.
+	HookEnvironmentKey = "hook-environment"
+
+	// AgentConnectionModeKey determines how connections to the machines
+	// in the model are made: "direct" or "reverse".
+	AgentConnectionModeKey = "agent-connection-mode"
+
+	// DNSProviderKey is the name of the DNS provider that maintains
+	// records for the addresses of exposed applications, eg "route53".
+	// No records are maintained if it is empty.
+	DNSProviderKey = "dns-provider"
+
+	// DNSZoneKey is the DNS zone in which records for the addresses of
+	// exposed applications are maintained, eg "apps.example.com".
+	DNSZoneKey = "dns-zone"
+
+	// DNSProviderConfigKey is an optional list or space-separated
+	// string of k=v pairs, configuring the DNS provider.
+	DNSProviderConfigKey = "dns-provider-config"
+
+	// SecurityGroupGCIntervalKey is how often security groups left
+	// behind by machines that no longer exist are deleted, eg "1h".
+	// Zero disables the cleanup.
+	SecurityGroupGCIntervalKey = "security-group-gc-interval"
+
+	// SecurityGroupGCDryRunKey, if true, causes orphaned security
+	// groups to be logged rather than deleted.
+	SecurityGroupGCDryRunKey = "security-group-gc-dry-run"
+
+	// PricingMetadataURLKey is the URL of the pricing metadata used to
+	// estimate the cost of deployments. If it is empty, only the
+	// prices reported by the provider are used.
+	PricingMetadataURLKey = "pricing-metadata-url"
+
+	// LeaderFirstConfigChangedKey, if true, causes the config-changed
+	// hooks of an application's units other than the leader to wait
+	// until the leader reports, with the config-ready hook tool, that
+	// it has finished handling the same config.
+	LeaderFirstConfigChangedKey = "leader-first-config-changed"
+
+	// AgentUserKey, if set, is the user that the agents of new
+	// machines, other than controllers, run as. Operations that need
+	// root privileges are done through a setuid helper that only
+	// allows the operations the agent needs.
+	AgentUserKey = "agent-user"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -327,6 +415,18 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultRelationSettingsSize is the default value for
+	// MaxRelationSettingsSize.
+	DefaultRelationSettingsSize = 1024 * 1024 // 1 MiB
+
+	// DefaultRelationSettingsKeys is the default value for
+	// MaxRelationSettingsKeys.
+	DefaultRelationSettingsKeys = 1000
+
+	// DefaultSecurityGroupGCInterval is the default value for
+	// SecurityGroupGCIntervalKey.
+	DefaultSecurityGroupGCInterval = "1h"
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -394,6 +494,28 @@ var defaultConfigValues = map[string]interface{}{
 	MaxStatusHistorySize: DefaultStatusHistorySize,
 	MaxActionResultsAge:  DefaultActionResultsAge,
 	MaxActionResultsSize: DefaultActionResultsSize,
+
+	// Relation settings limits
+	MaxRelationSettingsSize: DefaultRelationSettingsSize,
+	MaxRelationSettingsKeys: DefaultRelationSettingsKeys,
+	HookEnvironmentKey:      "",
+
+	AgentConnectionModeKey: DirectAgentConnection,
+
+	// DNS record settings
+	DNSProviderKey:       "",
+	DNSZoneKey:           "",
+	DNSProviderConfigKey: "",
+
+	// Security group cleanup settings
+	SecurityGroupGCIntervalKey: DefaultSecurityGroupGCInterval,
+	SecurityGroupGCDryRunKey:   false,
+
+	PricingMetadataURLKey: "",
+
+	LeaderFirstConfigChangedKey: false,
+
+	AgentUserKey: "",
 }
 
 // ConfigDefaults returns the config default values
@@ -443,7 +565,7 @@ func ProcessDeprecatedAttributes(attrs map[string]interface{}) map[string]interf
 func CoerceForStorage(attrs map[string]interface{}) map[string]interface{} {
 	coercedAttrs := make(map[string]interface{}, len(attrs))
 	for attrName, attrValue := range attrs {
-		if attrName == ResourceTagsKey {
+		if attrName == ResourceTagsKey || attrName == HookEnvironmentKey || attrName == DNSProviderConfigKey {
 			// Resource Tags are specified by the user as a string but transformed
 			// to a map when config is parsed. We want to store as a string.
 			var tagsSlice []string
@@ -536,6 +658,33 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	for _, key := range []string{MaxRelationSettingsSize, MaxRelationSettingsKeys} {
+		if v, ok := cfg.defined[key].(int); ok && v < 0 {
+			return errors.Errorf("%s: must not be negative, got %d", key, v)
+		}
+	}
+
+	if v, ok := cfg.defined[StorageUsageWarningThreshold].(int); ok && (v < 0 || v > 100) {
+		return errors.Errorf("%s: must be a percentage between 0 and 100, got %d", StorageUsageWarningThreshold, v)
+	}
+
+	if err := ValidateHookEnvironment(cfg.HookEnvironment()); err != nil {
+		return errors.Annotatef(err, "invalid %s", HookEnvironmentKey)
+	}
+
+	if provider := cfg.DNSProvider(); provider != "" {
+		valid := false
+		for _, name := range DNSProviders {
+			valid = valid || provider == name
+		}
+		if !valid {
+			return errors.NotValidf("%s %q", DNSProviderKey, provider)
+		}
+		if cfg.DNSZone() == "" {
+			return errors.Errorf("%s must be set when %s is %q", DNSZoneKey, DNSProviderKey, provider)
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -549,6 +698,23 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[AgentUserKey].(string); ok && v != "" {
+		if !agentUserRE.MatchString(v) {
+			return errors.NotValidf("%s %q", AgentUserKey, v)
+		}
+		if v == "root" {
+			return errors.Errorf("%s: must not be root", AgentUserKey)
+		}
+	}
+
+	if v, ok := cfg.defined[SecurityGroupGCIntervalKey].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", SecurityGroupGCIntervalKey)
+		} else if d < 0 {
+			return errors.Errorf("%s: must not be negative, got %v", SecurityGroupGCIntervalKey, d)
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -561,6 +727,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	for _, attr := range []string{DefaultSpace, ManagementSpace} {
+		if v, ok := cfg.defined[attr].(string); ok && v != "" && !names.IsValidSpace(v) {
+			return errors.NotValidf("%s %q", attr, v)
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -666,6 +838,76 @@ func (c *Config) ProxySSH() bool {
 	return value
 }
 
+// AgentConnectionMode returns how connections to the machines in the
+// model are made (DirectAgentConnection or ReverseAgentConnection).
+func (c *Config) AgentConnectionMode() string {
+	if value, ok := c.defined[AgentConnectionModeKey].(string); ok && value != "" {
+		return value
+	}
+	return DirectAgentConnection
+}
+
+// DNSProvider returns the name of the DNS provider that maintains
+// records for the addresses of exposed applications, or "" if none
+// is configured.
+func (c *Config) DNSProvider() string {
+	return c.asString(DNSProviderKey)
+}
+
+// DNSZone returns the DNS zone in which records for the addresses of
+// exposed applications are maintained.
+func (c *Config) DNSZone() string {
+	return c.asString(DNSZoneKey)
+}
+
+// DNSProviderConfig returns the configuration of the DNS provider.
+func (c *Config) DNSProviderConfig() map[string]string {
+	attrs, _ := c.defined[DNSProviderConfigKey].(map[string]string)
+	return attrs
+}
+
+// PricingMetadataURL returns the URL of the pricing metadata used to
+// estimate the cost of deployments.
+func (c *Config) PricingMetadataURL() string {
+	return c.asString(PricingMetadataURLKey)
+}
+
+// AgentUser returns the user that the agents of new machines, other
+// than controllers, run as, or "" if they run as root.
+func (c *Config) AgentUser() string {
+	return c.asString(AgentUserKey)
+}
+
+var agentUserRE = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+// SecurityGroupGCInterval returns how often security groups left
+// behind by machines that no longer exist are deleted. Zero means
+// they are never deleted.
+func (c *Config) SecurityGroupGCInterval() time.Duration {
+	raw := c.asString(SecurityGroupGCIntervalKey)
+	if raw == "" {
+		raw = DefaultSecurityGroupGCInterval
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// SecurityGroupGCDryRun reports whether orphaned security groups
+// should be logged rather than deleted.
+func (c *Config) SecurityGroupGCDryRun() bool {
+	value, _ := c.defined[SecurityGroupGCDryRunKey].(bool)
+	return value
+}
+
+// LeaderFirstConfigChanged reports whether the config-changed hooks of
+// an application's units other than the leader should wait until the
+// leader reports that it has finished handling the same config.
+func (c *Config) LeaderFirstConfigChanged() bool {
+	value, _ := c.defined[LeaderFirstConfigChangedKey].(bool)
+	return value
+}
+
 // NetBondReconfigureDelay returns the duration in seconds that should be
 // passed to the bridge script when bridging bonded interfaces.
 func (c *Config) NetBondReconfigureDelay() int {
@@ -1026,6 +1268,57 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// MaxRelationSettingsSize is the maximum size in bytes of the settings
+// a unit may store for a single relation. Zero means there is no limit.
+func (c *Config) MaxRelationSettingsSize() int {
+	value, _ := c.defined[MaxRelationSettingsSize].(int)
+	return value
+}
+
+// MaxRelationSettingsKeys is the maximum number of settings a unit may
+// store for a single relation. Zero means there is no limit.
+func (c *Config) MaxRelationSettingsKeys() int {
+	value, _ := c.defined[MaxRelationSettingsKeys].(int)
+	return value
+}
+
+// StorageUsageWarningThreshold is the percentage of a filesystem's
+// space that may be used before the status of units using it warns
+// that it is nearly full. Zero means there is no warning.
+func (c *Config) StorageUsageWarningThreshold() int {
+	value, _ := c.defined[StorageUsageWarningThreshold].(int)
+	return value
+}
+
+// HookEnvironment returns the extra environment variables to set for
+// all hook executions in the model.
+func (c *Config) HookEnvironment() map[string]string {
+	env, _ := c.defined[HookEnvironmentKey].(map[string]string)
+	return env
+}
+
+// reservedHookEnvironment holds environment variables that are set by
+// Juju for every hook execution, and so may not be set in the hook
+// environment.
+var reservedHookEnvironment = set.NewStrings("CHARM_DIR", "PATH")
+
+// validHookEnvironmentName matches valid environment variable names.
+var validHookEnvironmentName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateHookEnvironment returns an error if any of the given
+// environment variables may not be set for hook executions.
+func ValidateHookEnvironment(env map[string]string) error {
+	for name := range env {
+		if !validHookEnvironmentName.MatchString(name) {
+			return errors.NotValidf("environment variable name %q", name)
+		}
+		if strings.HasPrefix(name, "JUJU_") || reservedHookEnvironment.Contains(name) {
+			return errors.Errorf("environment variable %q is reserved", name)
+		}
+	}
+	return nil
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1042,6 +1335,18 @@ func (c *Config) EgressSubnets() []string {
 	return result
 }
 
+// DefaultSpace returns the space that application endpoints are bound
+// to when not bound explicitly, or "" if there is none.
+func (c *Config) DefaultSpace() string {
+	return c.asString(DefaultSpace)
+}
+
+// ManagementSpace returns the space through which agents reach the
+// controller, or "" if agents may use any network.
+func (c *Config) ManagementSpace() string {
+	return c.asString(ManagementSpace)
+}
+
 // UnknownAttrs returns a copy of the raw configuration attributes
 // that are supposedly specific to the environment type. They could
 // also be wrong attributes, though. Only the specific environment
@@ -1153,6 +1458,21 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressSubnets:                schema.Omit,
+	DefaultSpace:                 schema.Omit,
+	ManagementSpace:              schema.Omit,
+	MaxRelationSettingsSize:      schema.Omit,
+	MaxRelationSettingsKeys:      schema.Omit,
+	StorageUsageWarningThreshold: schema.Omit,
+	HookEnvironmentKey:           schema.Omit,
+	AgentConnectionModeKey:       schema.Omit,
+	DNSProviderKey:               schema.Omit,
+	DNSZoneKey:                   schema.Omit,
+	DNSProviderConfigKey:         schema.Omit,
+	SecurityGroupGCIntervalKey:   schema.Omit,
+	SecurityGroupGCDryRunKey:     schema.Omit,
+	PricingMetadataURLKey:        schema.Omit,
+	LeaderFirstConfigChangedKey:  schema.Omit,
+	AgentUserKey:                 schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1554,4 +1874,87 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	DefaultSpace: {
+		Description: "The space that application endpoints are bound to when not bound explicitly at deploy time",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ManagementSpace: {
+		Description: "The space through which agents connect to the controller",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxRelationSettingsSize: {
+		Description: "The maximum size in bytes of the settings a unit may store for a single relation (0 means no limit)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxRelationSettingsKeys: {
+		Description: "The maximum number of settings a unit may store for a single relation (0 means no limit)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	StorageUsageWarningThreshold: {
+		Description: "The percentage of a filesystem's space that may be used before unit status warns it is nearly full (0 means no warning)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	HookEnvironmentKey: {
+		Description: "Extra environment variables to set for all hook executions, as space-separated key=value pairs",
+		Type:        environschema.Tattrs,
+		Group:       environschema.EnvironGroup,
+	},
+	AgentConnectionModeKey: {
+		Description: `How connections to the machines in the model are made.
+
+'direct' means that the controller, and clients proxying through it,
+may connect directly to the machines.
+
+'reverse' is for machines the controller cannot reach, for example those
+behind NAT. Their agents open persistent reverse channels to the
+controller, through which SSH connections are proxied.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{DirectAgentConnection, ReverseAgentConnection},
+		Group:  environschema.EnvironGroup,
+	},
+	DNSProviderKey: {
+		Description: "The DNS provider that maintains A/AAAA records for the addresses of exposed applications: designate, nsupdate or route53 (default none)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSZoneKey: {
+		Description: "The DNS zone in which records for exposed applications are maintained, named <application>.<zone>",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSProviderConfigKey: {
+		Description: "Configuration of the DNS provider, as space-separated key=value pairs",
+		Type:        environschema.Tattrs,
+		Group:       environschema.EnvironGroup,
+	},
+	SecurityGroupGCIntervalKey: {
+		Description: "How often security groups left behind by machines that no longer exist are deleted, in human-readable time format (default 1h, 0 disables)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SecurityGroupGCDryRunKey: {
+		Description: "Whether orphaned security groups are only logged rather than deleted (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	PricingMetadataURLKey: {
+		Description: "The URL of the pricing metadata used to estimate the cost of deployments",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LeaderFirstConfigChangedKey: {
+		Description: "Whether the config-changed hooks of units other than the leader wait until the leader reports its config ready (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	AgentUserKey: {
+		Description: "The user that the agents of new machines, other than controllers, run as; if empty, they run as root",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
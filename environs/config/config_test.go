@@ -1131,6 +1131,247 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestRelationSettingsLimitsDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxRelationSettingsSize(), gc.Equals, 1024*1024)
+	c.Assert(cfg.MaxRelationSettingsKeys(), gc.Equals, 1000)
+}
+
+func (s *ConfigSuite) TestRelationSettingsLimitsValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"max-relation-settings-size": 4096,
+		"max-relation-settings-keys": 0,
+	})
+	c.Assert(cfg.MaxRelationSettingsSize(), gc.Equals, 4096)
+	c.Assert(cfg.MaxRelationSettingsKeys(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestRelationSettingsLimitsNegative(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"max-relation-settings-size": -1,
+	})
+	_, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, gc.ErrorMatches, "max-relation-settings-size: must not be negative, got -1")
+}
+
+func (s *ConfigSuite) TestStorageUsageWarningThreshold(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.StorageUsageWarningThreshold(), gc.Equals, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"storage-usage-warning-threshold": 90,
+	})
+	c.Assert(cfg.StorageUsageWarningThreshold(), gc.Equals, 90)
+}
+
+func (s *ConfigSuite) TestStorageUsageWarningThresholdInvalid(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"storage-usage-warning-threshold": 101,
+	})
+	_, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, gc.ErrorMatches, "storage-usage-warning-threshold: must be a percentage between 0 and 100, got 101")
+}
+
+func (s *ConfigSuite) TestSpaces(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.DefaultSpace(), gc.Equals, "")
+	c.Assert(cfg.ManagementSpace(), gc.Equals, "")
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"default-space":    "workload",
+		"management-space": "mgmt",
+	})
+	c.Assert(cfg.DefaultSpace(), gc.Equals, "workload")
+	c.Assert(cfg.ManagementSpace(), gc.Equals, "mgmt")
+}
+
+func (s *ConfigSuite) TestSpacesInvalid(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"management-space": "Not Valid",
+	})
+	_, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, gc.ErrorMatches, `management-space "Not Valid" not valid`)
+}
+
+func (s *ConfigSuite) TestHookEnvironment(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-environment": "SITE_ID=lon1 COMPLIANCE=pci",
+	})
+	c.Assert(cfg.HookEnvironment(), jc.DeepEquals, map[string]string{
+		"SITE_ID":    "lon1",
+		"COMPLIANCE": "pci",
+	})
+	env := config.CoerceForStorage(cfg.AllAttrs())["hook-environment"].(string)
+	c.Assert(strings.Split(env, " "), jc.SameContents, []string{"SITE_ID=lon1", "COMPLIANCE=pci"})
+}
+
+func (s *ConfigSuite) TestHookEnvironmentDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookEnvironment(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestHookEnvironmentInvalid(c *gc.C) {
+	for i, test := range []struct {
+		env string
+		err string
+	}{{
+		env: "1SITE=lon1",
+		err: `invalid hook-environment: environment variable name "1SITE" not valid`,
+	}, {
+		env: "JUJU_UNIT_NAME=foo/0",
+		err: `invalid hook-environment: environment variable "JUJU_UNIT_NAME" is reserved`,
+	}, {
+		env: "PATH=/tmp",
+		err: `invalid hook-environment: environment variable "PATH" is reserved`,
+	}} {
+		c.Logf("test %d: %s", i, test.env)
+		attrs := testing.FakeConfig().Merge(testing.Attrs{
+			"hook-environment": test.env,
+		})
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestAgentConnectionMode(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.AgentConnectionMode(), gc.Equals, config.DirectAgentConnection)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"agent-connection-mode": "reverse",
+	})
+	c.Assert(cfg.AgentConnectionMode(), gc.Equals, config.ReverseAgentConnection)
+}
+
+func (s *ConfigSuite) TestAgentConnectionModeInvalid(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"agent-connection-mode": "sideways",
+	})
+	_, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, gc.ErrorMatches, `agent-connection-mode: expected one of .*, got "sideways"`)
+}
+
+func (s *ConfigSuite) TestDNS(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.DNSProvider(), gc.Equals, "")
+	c.Assert(cfg.DNSProviderConfig(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"dns-provider":        "nsupdate",
+		"dns-zone":            "apps.example.com",
+		"dns-provider-config": "server=10.0.0.53 ttl=60",
+	})
+	c.Assert(cfg.DNSProvider(), gc.Equals, "nsupdate")
+	c.Assert(cfg.DNSZone(), gc.Equals, "apps.example.com")
+	c.Assert(cfg.DNSProviderConfig(), jc.DeepEquals, map[string]string{
+		"server": "10.0.0.53",
+		"ttl":    "60",
+	})
+}
+
+func (s *ConfigSuite) TestDNSInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"dns-provider": "bind", "dns-zone": "example.com"},
+		err:   `dns-provider "bind" not valid`,
+	}, {
+		attrs: testing.Attrs{"dns-provider": "route53"},
+		err:   `dns-zone must be set when dns-provider is "route53"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(test.attrs))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestSecurityGroupGC(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.SecurityGroupGCInterval(), gc.Equals, time.Hour)
+	c.Assert(cfg.SecurityGroupGCDryRun(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"security-group-gc-interval": "0",
+		"security-group-gc-dry-run":  true,
+	})
+	c.Assert(cfg.SecurityGroupGCInterval(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.SecurityGroupGCDryRun(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestPricingMetadataURL(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PricingMetadataURL(), gc.Equals, "")
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"pricing-metadata-url": "https://example.com/pricing.json",
+	})
+	c.Assert(cfg.PricingMetadataURL(), gc.Equals, "https://example.com/pricing.json")
+}
+
+func (s *ConfigSuite) TestLeaderFirstConfigChanged(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LeaderFirstConfigChanged(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"leader-first-config-changed": true,
+	})
+	c.Assert(cfg.LeaderFirstConfigChanged(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestAgentUser(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.AgentUser(), gc.Equals, "")
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"agent-user": "juju",
+	})
+	c.Assert(cfg.AgentUser(), gc.Equals, "juju")
+}
+
+func (s *ConfigSuite) TestAgentUserInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "root",
+		err:   `agent-user: must not be root`,
+	}, {
+		value: "juju; rm -rf /",
+		err:   `agent-user "juju; rm -rf /" not valid`,
+	}, {
+		value: "Juju",
+		err:   `agent-user "Juju" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		attrs := testing.FakeConfig().Merge(testing.Attrs{
+			"agent-user": test.value,
+		})
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestSecurityGroupGCIntervalInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "soon",
+		err:   `invalid security-group-gc-interval in model configuration: time: invalid duration .*`,
+	}, {
+		value: "-1h",
+		err:   `security-group-gc-interval: must not be negative, got -1h0m0s`,
+	}} {
+		c.Logf("test %d", i)
+		attrs := testing.FakeConfig().Merge(testing.Attrs{
+			"security-group-gc-interval": test.value,
+		})
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
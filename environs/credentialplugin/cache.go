@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialplugin
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/cloud"
+)
+
+// DefaultRefreshMargin is how long before they expire that credentials
+// obtained from plugins are obtained again.
+const DefaultRefreshMargin = 5 * time.Minute
+
+// Cache caches expiring credentials obtained from credential plugins,
+// and obtains them again when they are about to expire. Credentials
+// that do not expire are obtained from their plugins every time.
+type Cache struct {
+	clock     clock.Clock
+	margin    time.Duration
+	newSource func(cloud.Credential) (Source, error)
+
+	mu         sync.Mutex
+	entries    map[string]ExpiringCredential
+	restricted bool
+	allowed    []string
+}
+
+// NewCache returns a new Cache that refreshes credentials when they
+// are within the given margin of their expiry.
+func NewCache(clock clock.Clock, margin time.Duration) *Cache {
+	return &Cache{
+		clock:     clock,
+		margin:    margin,
+		newSource: NewSource,
+		entries:   make(map[string]ExpiringCredential),
+	}
+}
+
+// Restrict restricts the plugins from which the cache obtains
+// credentials to those in the given allow-list, as described by
+// CheckAllowed. Once restricted, the cache obtains no credentials
+// until it is given a non-empty allow-list.
+func (c *Cache) Restrict(allowed []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.restricted = true
+	c.allowed = allowed
+}
+
+// Credential returns the credential obtained from the given plugin
+// credential.
+func (c *Cache) Credential(plugin cloud.Credential) (ExpiringCredential, error) {
+	key := cacheKey(plugin)
+	now := c.clock.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.restricted {
+		if err := CheckAllowed(plugin, c.allowed); err != nil {
+			return ExpiringCredential{}, errors.Trace(err)
+		}
+	}
+	if cred, ok := c.entries[key]; ok {
+		if now.Before(cred.Expires.Add(-c.margin)) {
+			return cred, nil
+		}
+		delete(c.entries, key)
+	}
+
+	source, err := c.newSource(plugin)
+	if err != nil {
+		return ExpiringCredential{}, errors.Trace(err)
+	}
+	cred, err := source.Credential()
+	if err != nil {
+		return ExpiringCredential{}, errors.Annotate(err, "obtaining credential from plugin")
+	}
+	if !cred.Expires.IsZero() {
+		if !now.Before(cred.Expires) {
+			return ExpiringCredential{}, errors.Errorf("credential plugin returned credential that expired at %s", cred.Expires.Format(time.RFC3339))
+		}
+		c.entries[key] = cred
+	}
+	return cred, nil
+}
+
+// cacheKey returns a key identifying the plugin credential.
+func cacheKey(plugin cloud.Credential) string {
+	attrs := plugin.Attributes()
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + attrs[k]
+	}
+	return strings.Join(parts, "\x00")
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialplugin_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/credentialplugin"
+)
+
+type cacheSuite struct {
+	testing.IsolationSuite
+
+	clock  *testing.Clock
+	cache  *credentialplugin.Cache
+	source *fakeSource
+	plugin cloud.Credential
+}
+
+var _ = gc.Suite(&cacheSuite{})
+
+func (s *cacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.source = &fakeSource{expiry: time.Hour, clock: s.clock}
+	s.cache = credentialplugin.NewCache(s.clock, 5*time.Minute)
+	credentialplugin.SetNewSource(s.cache, func(cloud.Credential) (credentialplugin.Source, error) {
+		return s.source, nil
+	})
+	s.plugin = cloud.NewCredential(cloud.PluginAuthType, map[string]string{
+		"plugin":  "exec",
+		"command": "fetch-token",
+	})
+}
+
+func (s *cacheSuite) TestCredentialCached(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		cred, err := s.cache.Credential(s.plugin)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(cred.Attributes()["password"], gc.Equals, "token-1")
+	}
+	s.source.CheckCallNames(c, "Credential")
+}
+
+func (s *cacheSuite) TestCredentialRefreshedBeforeExpiry(c *gc.C) {
+	_, err := s.cache.Credential(s.plugin)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(55 * time.Minute)
+	cred, err := s.cache.Credential(s.plugin)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.Attributes()["password"], gc.Equals, "token-2")
+	s.source.CheckCallNames(c, "Credential", "Credential")
+}
+
+func (s *cacheSuite) TestCredentialWithoutExpiryNotCached(c *gc.C) {
+	s.source.expiry = 0
+	for i := 0; i < 2; i++ {
+		_, err := s.cache.Credential(s.plugin)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.source.CheckCallNames(c, "Credential", "Credential")
+}
+
+func (s *cacheSuite) TestCredentialExpired(c *gc.C) {
+	s.source.expiry = -time.Minute
+	_, err := s.cache.Credential(s.plugin)
+	c.Assert(err, gc.ErrorMatches, "credential plugin returned credential that expired at 2017-06-01T11:59:00Z")
+}
+
+func (s *cacheSuite) TestCredentialError(c *gc.C) {
+	s.source.SetErrors(fmt.Errorf("broker unavailable"))
+	_, err := s.cache.Credential(s.plugin)
+	c.Assert(err, gc.ErrorMatches, "obtaining credential from plugin: broker unavailable")
+}
+
+func (s *cacheSuite) TestRestrictAllowed(c *gc.C) {
+	s.cache.Restrict([]string{"exec:fetch-token"})
+	cred, err := s.cache.Credential(s.plugin)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.Attributes()["password"], gc.Equals, "token-1")
+	c.Assert(cred.Expires, gc.Equals, s.clock.Now().Add(time.Hour))
+}
+
+func (s *cacheSuite) TestRestrictRefused(c *gc.C) {
+	s.cache.Restrict(nil)
+	_, err := s.cache.Credential(s.plugin)
+	c.Assert(err, gc.ErrorMatches, `credential plugin "exec:fetch-token" not allowed`)
+	s.source.CheckNoCalls(c)
+}
+
+func (s *cacheSuite) TestRestrictAppliesToCached(c *gc.C) {
+	_, err := s.cache.Credential(s.plugin)
+	c.Assert(err, jc.ErrorIsNil)
+	s.cache.Restrict(nil)
+	_, err = s.cache.Credential(s.plugin)
+	c.Assert(err, gc.ErrorMatches, `credential plugin "exec:fetch-token" not allowed`)
+	s.source.CheckCallNames(c, "Credential")
+}
+
+type fakeSource struct {
+	testing.Stub
+	clock  *testing.Clock
+	expiry time.Duration
+	count  int
+}
+
+func (s *fakeSource) Credential() (credentialplugin.ExpiringCredential, error) {
+	s.MethodCall(s, "Credential")
+	if err := s.NextErr(); err != nil {
+		return credentialplugin.ExpiringCredential{}, err
+	}
+	s.count++
+	cred := credentialplugin.ExpiringCredential{
+		Credential: cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+			"username": "bob",
+			"password": fmt.Sprintf("token-%d", s.count),
+		}),
+	}
+	if s.expiry != 0 {
+		cred.Expires = s.clock.Now().Add(s.expiry)
+	}
+	return cred, nil
+}
@@ -0,0 +1,12 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialplugin
+
+import "github.com/juju/juju/cloud"
+
+// SetNewSource replaces the function the cache uses to create
+// credential sources.
+func SetNewSource(c *Cache, newSource func(cloud.Credential) (Source, error)) {
+	c.newSource = newSource
+}
@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package credentialplugin obtains cloud credentials from credential
+// plugins: environment variables, files, or external executables, such
+// as a broker handing out short-lived tokens. Plugin credentials have
+// the cloud.PluginAuthType auth-type, and are described by
+// cloud.PluginCredentialSchema.
+package credentialplugin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cloud"
+)
+
+// ExpiringCredential is a credential obtained from a credential plugin.
+type ExpiringCredential struct {
+	cloud.Credential
+
+	// Expires is the time at which the credential expires. It is
+	// zero if the credential does not expire.
+	Expires time.Time
+}
+
+// Source obtains credentials from a credential plugin.
+type Source interface {
+	// Credential returns a credential obtained from the plugin.
+	Credential() (ExpiringCredential, error)
+}
+
+// NewSource returns the Source described by the given plugin
+// credential.
+func NewSource(plugin cloud.Credential) (Source, error) {
+	if plugin.AuthType() != cloud.PluginAuthType {
+		return nil, errors.NotValidf("auth-type %q for credential plugin", plugin.AuthType())
+	}
+	attrs := plugin.Attributes()
+	switch kind := attrs[cloud.PluginAttr]; kind {
+	case "env":
+		authType := attrs[cloud.PluginAuthTypeAttr]
+		if authType == "" {
+			return nil, errors.NotValidf("env credential plugin without %s", cloud.PluginAuthTypeAttr)
+		}
+		vars, err := parseEnvVars(attrs[cloud.PluginEnvVarsAttr])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return envSource{authType: cloud.AuthType(authType), vars: vars}, nil
+	case "file":
+		path := attrs[cloud.PluginPathAttr]
+		if path == "" {
+			return nil, errors.NotValidf("file credential plugin without %s", cloud.PluginPathAttr)
+		}
+		return fileSource{path: path}, nil
+	case "exec":
+		args := strings.Fields(attrs[cloud.PluginCommandAttr])
+		if len(args) == 0 {
+			return nil, errors.NotValidf("exec credential plugin without %s", cloud.PluginCommandAttr)
+		}
+		return execSource{args: args}, nil
+	default:
+		return nil, errors.NotSupportedf("credential plugin %q", kind)
+	}
+}
+
+// CheckAllowed returns an error unless the given plugin credential
+// only uses the plugins in the allow-list. Each entry in the list is
+// one of "env:<variable>", "file:<path>" or "exec:<path>", naming an
+// environment variable or file that may be read, or an executable that
+// may be run. Executables are run with the arguments the credential
+// gives them, so only executables that are safe to run with any
+// arguments should be allowed.
+func CheckAllowed(plugin cloud.Credential, allowed []string) error {
+	source, err := NewSource(plugin)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var needed []string
+	switch source := source.(type) {
+	case envSource:
+		for _, name := range source.vars {
+			needed = append(needed, "env:"+name)
+		}
+		sort.Strings(needed)
+	case fileSource:
+		needed = []string{"file:" + source.path}
+	case execSource:
+		needed = []string{"exec:" + source.args[0]}
+	}
+	allowedSet := set.NewStrings(allowed...)
+	for _, entry := range needed {
+		if !allowedSet.Contains(entry) {
+			return errors.Errorf("credential plugin %q not allowed", entry)
+		}
+	}
+	return nil
+}
+
+// parseEnvVars parses a comma-separated list of attribute=VARIABLE
+// pairs, returning the variables keyed on attribute.
+func parseEnvVars(s string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.NotValidf("%s entry %q", cloud.PluginEnvVarsAttr, pair)
+		}
+		vars[parts[0]] = parts[1]
+	}
+	if len(vars) == 0 {
+		return nil, errors.NotValidf("env credential plugin without %s", cloud.PluginEnvVarsAttr)
+	}
+	return vars, nil
+}
+
+// envSource obtains credentials from environment variables.
+type envSource struct {
+	authType cloud.AuthType
+	vars     map[string]string
+}
+
+// Credential is part of the Source interface.
+func (s envSource) Credential() (ExpiringCredential, error) {
+	attrs := make(map[string]string)
+	for attr, name := range s.vars {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return ExpiringCredential{}, errors.NotFoundf("environment variable %q", name)
+		}
+		attrs[attr] = value
+	}
+	return ExpiringCredential{
+		Credential: cloud.NewCredential(s.authType, attrs),
+	}, nil
+}
+
+// fileSource obtains credentials from a file.
+type fileSource struct {
+	path string
+}
+
+// Credential is part of the Source interface.
+func (s fileSource) Credential() (ExpiringCredential, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return ExpiringCredential{}, errors.Annotate(err, "reading credential file")
+	}
+	cred, err := parseCredential(data)
+	if err != nil {
+		return ExpiringCredential{}, errors.Annotatef(err, "reading credential file %q", s.path)
+	}
+	return cred, nil
+}
+
+// execSource obtains credentials from the output of a command.
+type execSource struct {
+	args []string
+}
+
+// Credential is part of the Source interface.
+func (s execSource) Credential() (ExpiringCredential, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(s.args[0], s.args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return ExpiringCredential{}, errors.Annotatef(err, "running %q: %s", s.args[0], msg)
+		}
+		return ExpiringCredential{}, errors.Annotatef(err, "running %q", s.args[0])
+	}
+	cred, err := parseCredential(stdout.Bytes())
+	if err != nil {
+		return ExpiringCredential{}, errors.Annotatef(err, "reading output of %q", s.args[0])
+	}
+	return cred, nil
+}
+
+// credentialDoc is the YAML or JSON document holding a credential
+// obtained from a file or command.
+type credentialDoc struct {
+	AuthType   string            `yaml:"auth-type"`
+	Attributes map[string]string `yaml:"attributes"`
+	Expires    string            `yaml:"expires"`
+}
+
+func parseCredential(data []byte) (ExpiringCredential, error) {
+	var doc credentialDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return ExpiringCredential{}, errors.Trace(err)
+	}
+	if doc.AuthType == "" {
+		return ExpiringCredential{}, errors.NotValidf("credential without auth-type")
+	}
+	if cloud.AuthType(doc.AuthType) == cloud.PluginAuthType {
+		return ExpiringCredential{}, errors.NotValidf("credential plugin returning a plugin credential")
+	}
+	cred := ExpiringCredential{
+		Credential: cloud.NewCredential(cloud.AuthType(doc.AuthType), doc.Attributes),
+	}
+	if doc.Expires != "" {
+		expires, err := time.Parse(time.RFC3339, doc.Expires)
+		if err != nil {
+			return ExpiringCredential{}, errors.Annotate(err, "parsing expiry time")
+		}
+		cred.Expires = expires
+	}
+	return cred, nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialplugin_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/credentialplugin"
+)
+
+type pluginSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&pluginSuite{})
+
+func pluginCredential(attrs map[string]string) cloud.Credential {
+	return cloud.NewCredential(cloud.PluginAuthType, attrs)
+}
+
+func (s *pluginSuite) TestEnv(c *gc.C) {
+	s.PatchEnvironment("OS_USERNAME", "bob")
+	s.PatchEnvironment("OS_PASSWORD", "hunter2")
+	source, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin":    "env",
+		"auth-type": "userpass",
+		"env-vars":  "username=OS_USERNAME, password=OS_PASSWORD",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	cred, err := source.Credential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.AuthType(), gc.Equals, cloud.UserPassAuthType)
+	c.Assert(cred.Attributes(), jc.DeepEquals, map[string]string{
+		"username": "bob",
+		"password": "hunter2",
+	})
+	c.Assert(cred.Expires.IsZero(), jc.IsTrue)
+}
+
+func (s *pluginSuite) TestEnvMissingVariable(c *gc.C) {
+	source, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin":    "env",
+		"auth-type": "userpass",
+		"env-vars":  "username=JUJU_TEST_NO_SUCH_VARIABLE",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = source.Credential()
+	c.Assert(err, gc.ErrorMatches, `environment variable "JUJU_TEST_NO_SUCH_VARIABLE" not found`)
+}
+
+func (s *pluginSuite) TestEnvBadVariables(c *gc.C) {
+	_, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin":    "env",
+		"auth-type": "userpass",
+		"env-vars":  "username",
+	}))
+	c.Assert(err, gc.ErrorMatches, `env-vars entry "username" not valid`)
+}
+
+func (s *pluginSuite) TestFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "credential.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+auth-type: userpass
+attributes:
+  username: bob
+  password: token-1234
+expires: 2017-06-01T12:00:00Z
+`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin": "file",
+		"path":   path,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	cred, err := source.Credential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.AuthType(), gc.Equals, cloud.UserPassAuthType)
+	c.Assert(cred.Attributes(), jc.DeepEquals, map[string]string{
+		"username": "bob",
+		"password": "token-1234",
+	})
+	c.Assert(cred.Expires, gc.Equals, time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *pluginSuite) TestFileNoAuthType(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "credential.yaml")
+	err := ioutil.WriteFile(path, []byte(`{"attributes": {"username": "bob"}}`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin": "file",
+		"path":   path,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = source.Credential()
+	c.Assert(err, gc.ErrorMatches, `reading credential file ".*": credential without auth-type not valid`)
+}
+
+func (s *pluginSuite) TestExec(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("shell scripts are not supported on windows")
+	}
+	path := filepath.Join(c.MkDir(), "fetch-token")
+	err := ioutil.WriteFile(path, []byte(`#!/bin/sh
+echo '{"auth-type": "userpass", "attributes": {"username": "'$1'", "password": "token-5678"}}'
+`), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin":  "exec",
+		"command": path + " bob",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	cred, err := source.Credential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.Attributes(), jc.DeepEquals, map[string]string{
+		"username": "bob",
+		"password": "token-5678",
+	})
+}
+
+func (s *pluginSuite) TestExecFails(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("shell scripts are not supported on windows")
+	}
+	path := filepath.Join(c.MkDir(), "fetch-token")
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\necho 'broker unavailable' >&2\nexit 1\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin":  "exec",
+		"command": path,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = source.Credential()
+	c.Assert(err, gc.ErrorMatches, `running ".*": broker unavailable: exit status 1`)
+}
+
+func (s *pluginSuite) TestUnknownPlugin(c *gc.C) {
+	_, err := credentialplugin.NewSource(pluginCredential(map[string]string{
+		"plugin": "carrier-pigeon",
+	}))
+	c.Assert(err, gc.ErrorMatches, `credential plugin "carrier-pigeon" not supported`)
+}
+
+func (s *pluginSuite) TestNotPluginCredential(c *gc.C) {
+	_, err := credentialplugin.NewSource(cloud.NewCredential(cloud.UserPassAuthType, nil))
+	c.Assert(err, gc.ErrorMatches, `auth-type "userpass" for credential plugin not valid`)
+}
+
+func (s *pluginSuite) TestCheckAllowed(c *gc.C) {
+	allowed := []string{"env:OS_USERNAME", "env:OS_PASSWORD", "file:/etc/juju/cred.yaml", "exec:/usr/local/bin/fetch-token"}
+	for _, attrs := range []map[string]string{{
+		"plugin":    "env",
+		"auth-type": "userpass",
+		"env-vars":  "username=OS_USERNAME, password=OS_PASSWORD",
+	}, {
+		"plugin": "file",
+		"path":   "/etc/juju/cred.yaml",
+	}, {
+		"plugin":  "exec",
+		"command": "/usr/local/bin/fetch-token --role juju",
+	}} {
+		err := credentialplugin.CheckAllowed(pluginCredential(attrs), allowed)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *pluginSuite) TestCheckAllowedRefused(c *gc.C) {
+	allowed := []string{"env:OS_USERNAME", "file:/etc/juju/cred.yaml", "exec:/usr/local/bin/fetch-token"}
+	for i, test := range []struct {
+		attrs map[string]string
+		err   string
+	}{{
+		attrs: map[string]string{
+			"plugin":    "env",
+			"auth-type": "userpass",
+			"env-vars":  "username=OS_USERNAME, password=OS_PASSWORD",
+		},
+		err: `credential plugin "env:OS_PASSWORD" not allowed`,
+	}, {
+		attrs: map[string]string{
+			"plugin": "file",
+			"path":   "/root/.ssh/id_rsa",
+		},
+		err: `credential plugin "file:/root/.ssh/id_rsa" not allowed`,
+	}, {
+		attrs: map[string]string{
+			"plugin":  "exec",
+			"command": "/bin/sh -c reboot",
+		},
+		err: `credential plugin "exec:/bin/sh" not allowed`,
+	}, {
+		attrs: map[string]string{
+			"plugin": "carrier-pigeon",
+		},
+		err: `credential plugin "carrier-pigeon" not supported`,
+	}} {
+		c.Logf("test %d", i)
+		err := credentialplugin.CheckAllowed(pluginCredential(test.attrs), allowed)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -4,6 +4,7 @@
 package environs
 
 var (
-	Providers       = &globalProviders.providers
-	ProviderAliases = &globalProviders.aliases
+	Providers         = &globalProviders.providers
+	ProviderAliases   = &globalProviders.aliases
+	PluginCredentials = &pluginCredentials
 )
@@ -408,6 +408,49 @@ type Firewaller interface {
 	IngressRules() ([]network.IngressRule, error)
 }
 
+// ContainerFirewaller is an interface that can be implemented by
+// environments in which containers may have firewall rules of their
+// own, rather than sharing those of their host instance. It is only
+// used with the FwInstance firewall mode.
+type ContainerFirewaller interface {
+	// SupportsContainerFirewalls reports whether containers have
+	// firewall rules of their own. If not, the other methods must
+	// not be called.
+	SupportsContainerFirewalls() bool
+
+	// OpenContainerPorts opens the given port ranges for the
+	// container with the given machine ID.
+	OpenContainerPorts(containerId string, rules []network.IngressRule) error
+
+	// CloseContainerPorts closes the given port ranges for the
+	// container with the given machine ID.
+	CloseContainerPorts(containerId string, rules []network.IngressRule) error
+
+	// ContainerIngressRules returns the ingress rules applied to the
+	// container with the given machine ID.
+	ContainerIngressRules(containerId string) ([]network.IngressRule, error)
+}
+
+// ICMPFirewaller is an interface that can be implemented by
+// environments whose firewalls can open ICMP types and codes, held
+// in port ranges with the "icmp" protocol. ICMP port ranges are not
+// passed to the firewalls of other environments.
+type ICMPFirewaller interface {
+	// SupportsICMP reports whether ICMP port ranges may be opened
+	// and closed.
+	SupportsICMP() bool
+}
+
+// ControllerFirewaller is an interface that can be implemented by
+// environments whose firewall rules allow connections to the
+// controllers' API ports. It is used to update the rules when the API
+// ports are changed in the controller config.
+type ControllerFirewaller interface {
+	// SetControllerAPIPorts ensures that the controller instances
+	// accept API connections on exactly the given ports.
+	SetControllerAPIPorts(controllerUUID string, ports []int) error
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -417,6 +460,110 @@ type InstanceTagger interface {
 	TagInstance(id instance.Id, tags map[string]string) error
 }
 
+// InstanceTagRemover is an interface that can be implemented by
+// environments in which the tags of instances can be read and removed,
+// as well as set.
+type InstanceTagRemover interface {
+	InstanceTagger
+
+	// InstanceTags returns the tags of the given instance.
+	InstanceTags(id instance.Id) (map[string]string, error)
+
+	// UntagInstance removes the tags with the given names from the
+	// given instance. Names of tags that the instance does not have
+	// are ignored.
+	UntagInstance(id instance.Id, names []string) error
+}
+
+// FloatingIPAssigner is an interface that can be used for moving floating
+// (or elastic) IP addresses between instances.
+type FloatingIPAssigner interface {
+	// AssignFloatingIP associates the given floating IP address, which
+	// must already be allocated, with the specified instance. If the
+	// address is associated with another instance it is moved.
+	AssignFloatingIP(address string, id instance.Id) error
+}
+
+// SecurityGroupCollector is an interface that can be used for finding
+// and deleting the per-machine security groups of a model, so that
+// groups left behind by machines that failed to provision, or that
+// were not cleaned up when their instances were stopped, may be
+// removed before the model is destroyed.
+type SecurityGroupCollector interface {
+	// MachineSecurityGroups returns the per-machine security groups
+	// of the model.
+	MachineSecurityGroups() ([]MachineSecurityGroup, error)
+
+	// DeleteSecurityGroups deletes the security groups with the
+	// specified names. Groups that do not exist are ignored.
+	DeleteSecurityGroups(names ...string) error
+}
+
+// MachineSecurityGroup describes a security group created for a single
+// machine of a model.
+type MachineSecurityGroup struct {
+	// Name is the name of the security group.
+	Name string
+
+	// MachineId is the ID of the machine the group was created for.
+	MachineId string
+
+	// InUse is true if the group is attached to any port or instance,
+	// in which case it must not be deleted.
+	InUse bool
+}
+
+// InstanceConsoleLogger is an interface that can be used to retrieve
+// the console output of instances, such as the output of cloud-init.
+type InstanceConsoleLogger interface {
+	// InstanceConsoleLog returns the most recent console output of the
+	// specified instance. If maxLines is greater than zero, at most
+	// that many lines are returned, taken from the end of the output.
+	InstanceConsoleLog(id instance.Id, maxLines int) (string, error)
+}
+
+// InstanceConsoleType identifies a kind of instance console.
+type InstanceConsoleType string
+
+const (
+	// SerialConsole is an interactive serial console, accessed over
+	// a websocket.
+	SerialConsole InstanceConsoleType = "serial"
+
+	// NoVNCConsole is a graphical console, accessed with a web
+	// browser.
+	NoVNCConsole InstanceConsoleType = "novnc"
+)
+
+// InstanceConsole describes how to connect to the console of an
+// instance.
+type InstanceConsole struct {
+	// Type is the kind of console.
+	Type InstanceConsoleType
+
+	// URL is the address of the console. Console URLs typically
+	// contain a short-lived token that grants access to the console.
+	URL string
+}
+
+// InstanceConsoleAccessor is an interface that can be used to request
+// access to the consoles of instances.
+type InstanceConsoleAccessor interface {
+	// InstanceConsole returns details of how to connect to a console
+	// of the specified type for the specified instance.
+	InstanceConsole(id instance.Id, consoleType InstanceConsoleType) (InstanceConsole, error)
+}
+
+// ControllerSizer is an optional interface that may be implemented by
+// environs that map the named controller sizing presets ("small",
+// "medium" and "large") to provider-specific constraints, such as
+// instance types.
+type ControllerSizer interface {
+	// ControllerSizeConstraints returns the constraints used to
+	// provision controllers of the named size.
+	ControllerSizeConstraints(size string) (constraints.Value, error)
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
@@ -465,3 +612,24 @@ type UpgradeStep interface {
 	// Run executes the upgrade business logic.
 	Run() error
 }
+
+// ControllerKeyPairRotator is an optional interface that an Environ may
+// implement if it manages a controller-scoped keypair in the cloud, for
+// emergency access to the controller's machines.
+type ControllerKeyPairRotator interface {
+	// RotateControllerKeyPair replaces the public key of the keypair
+	// belonging to the controller with the specified UUID. The cloud
+	// only injects the keypair into machines when they are started,
+	// so existing machines are not affected.
+	RotateControllerKeyPair(controllerUUID, publicKey string) error
+}
+
+// ForceDestroyer is an optional interface that an Environ may implement
+// if it can remove cloud resources that would otherwise prevent a model
+// from being destroyed, such as security groups still attached to ports
+// that the model does not own.
+type ForceDestroyer interface {
+	// DestroyForce is like Destroy, but removes any such resources
+	// regardless of what is still using them.
+	DestroyForce() error
+}
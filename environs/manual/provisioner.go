@@ -12,6 +12,7 @@ import (
 	"github.com/juju/utils/winrm"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 )
 
 var (
@@ -51,6 +52,11 @@ type ProvisionMachineArgs struct {
 	// ubuntu user's ~/.ssh/authorized_keys.
 	AuthorizedKeys string
 
+	// InstanceId, if set, is the provider id of an existing cloud instance
+	// that is being imported into the model. If it is empty, the machine
+	// is recorded with an instance id derived from Host.
+	InstanceId instance.Id
+
 	// WinRM contains keys and client interface api with the remote windows machine
 	WinRM WinRMArgs
 
@@ -80,5 +86,6 @@ type WinrmClientAPI interface {
 type ProvisioningClientAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	ForceDestroyMachines(machines ...string) error
+	DestroyMachinesWithParams(force, keep bool, machines ...string) error
 	ProvisioningScript(params.ProvisioningScriptParams) (script string, err error)
 }
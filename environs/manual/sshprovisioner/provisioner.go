@@ -21,7 +21,15 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 	defer func() {
 		if machineId != "" && err != nil {
 			logger.Errorf("provisioning failed, removing machine %v: %v", machineId, err)
-			if cleanupErr := args.Client.ForceDestroyMachines(machineId); cleanupErr != nil {
+			var cleanupErr error
+			if args.InstanceId != "" {
+				// The instance was imported, so it must survive
+				// the removal of the machine.
+				cleanupErr = args.Client.DestroyMachinesWithParams(true, true, machineId)
+			} else {
+				cleanupErr = args.Client.ForceDestroyMachines(machineId)
+			}
+			if cleanupErr != nil {
 				logger.Errorf("error cleaning up machine: %s", cleanupErr)
 			}
 			machineId = ""
@@ -37,7 +45,7 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(args.Host)
+	machineParams, err := gatherMachineParams(args.Host, args.InstanceId)
 	if err != nil {
 		return "", err
 	}
@@ -127,6 +127,38 @@ func (s *provisionerSuite) TestProvisionMachine(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error checking if provisioned: subprocess encountered error code 255")
 }
 
+func (s *provisionerSuite) TestProvisionMachineWithInstanceId(c *gc.C) {
+	var series = series.LatestLts()
+	const arch = "amd64"
+
+	cfg := s.Environ.Config()
+	number, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	binVersion := version.Binary{
+		Number: number,
+		Series: series,
+		Arch:   arch,
+	}
+	envtesting.AssertUploadFakeToolsVersions(c, s.DefaultToolsStorage, "released", "released", binVersion)
+
+	defer fakeSSH{
+		Series:         series,
+		Arch:           arch,
+		InitUbuntuUser: true,
+	}.install(c).Restore()
+
+	args := s.getArgs(c)
+	args.User = "ubuntu"
+	args.InstanceId = "legacy-0"
+	machineId, err := sshprovisioner.ProvisionMachine(args)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceId, err := m.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceId, gc.Equals, instance.Id("legacy-0"))
+}
+
 func (s *provisionerSuite) TestFinishInstancConfig(c *gc.C) {
 	var series = series.LatestLts()
 	const arch = "amd64"
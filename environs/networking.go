@@ -24,6 +24,14 @@ var DefaultSpaceInfo *network.SpaceInfo
 // application endpoints are bound if no explicit binding is given).
 const DefaultSpaceName = ""
 
+// ManagementEndpointBinding is the pseudo-endpoint in the endpoint
+// bindings passed to StartInstance that names the provider id of the
+// model's management space, when one is configured. Providers that
+// honour endpoint bindings give the instance an address in that space
+// for agent traffic. Charm endpoints cannot clash with it, as the
+// "juju-" prefix is reserved.
+const ManagementEndpointBinding = "juju-management"
+
 // Networking interface defines methods that environments
 // with networking capabilities must implement.
 type Networking interface {
@@ -4,23 +4,70 @@
 package environs
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/credentialplugin"
 	"github.com/juju/juju/jujuclient"
 )
 
 // AdminUser is the initial admin user created for all controllers.
 const AdminUser = "admin"
 
+// pluginCredentials caches the credentials obtained from credential
+// plugins, until shortly before they expire.
+var pluginCredentials = credentialplugin.NewCache(clock.WallClock, credentialplugin.DefaultRefreshMargin)
+
 // New returns a new environment based on the provided configuration.
 func New(args OpenParams) (Environ, error) {
 	p, err := Provider(args.Cloud.Type)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	args.Cloud, err = ResolvePluginCredential(args.Cloud)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return p.Open(args)
 }
 
+// RestrictPluginCredentials restricts the credential plugins from
+// which credentials are obtained to those in the given allow-list, as
+// described by credentialplugin.CheckAllowed. The agents restrict them
+// to the controller's allowed-credential-plugins, so that uploading a
+// credential does not allow a user to run commands on the controller.
+func RestrictPluginCredentials(allowed []string) {
+	pluginCredentials.Restrict(allowed)
+}
+
+// ResolvePluginCredential returns the given cloud spec with any
+// credential that is to be obtained from a credential plugin replaced
+// by the credential obtained from the plugin. Credentials that expire
+// are obtained again when they are about to expire.
+func ResolvePluginCredential(spec CloudSpec) (CloudSpec, error) {
+	spec, _, err := ResolveExpiringPluginCredential(spec)
+	return spec, errors.Trace(err)
+}
+
+// ResolveExpiringPluginCredential is like ResolvePluginCredential,
+// but also returns the time at which the credential obtained from the
+// plugin expires. The time is zero if the credential does not expire,
+// or if the spec's credential is not obtained from a plugin.
+func ResolveExpiringPluginCredential(spec CloudSpec) (CloudSpec, time.Time, error) {
+	if spec.Credential == nil || spec.Credential.AuthType() != cloud.PluginAuthType {
+		return spec, time.Time{}, nil
+	}
+	credential, err := pluginCredentials.Credential(*spec.Credential)
+	if err != nil {
+		return CloudSpec{}, time.Time{}, errors.Annotate(err, "resolving plugin credential")
+	}
+	spec.Credential = &credential.Credential
+	return spec, credential.Expires, nil
+}
+
 // Destroy destroys the controller and, if successful,
 // its associated configuration data from the given store.
 func Destroy(
@@ -4,15 +4,22 @@
 package environs_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/credentialplugin"
 	"github.com/juju/juju/environs/filestorage"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	envtesting "github.com/juju/juju/environs/testing"
@@ -179,6 +186,89 @@ func (*OpenSuite) TestDestroyNotFound(c *gc.C) {
 	env.CheckCallNames(c) // no controller details, no call
 }
 
+func (s *OpenSuite) TestResolvePluginCredential(c *gc.C) {
+	s.PatchEnvironment("JUJU_TEST_USERNAME", "bob")
+	s.PatchEnvironment("JUJU_TEST_PASSWORD", "hunter2")
+	plugin := cloud.NewCredential(cloud.PluginAuthType, map[string]string{
+		"plugin":    "env",
+		"auth-type": "userpass",
+		"env-vars":  "username=JUJU_TEST_USERNAME,password=JUJU_TEST_PASSWORD",
+	})
+	spec, err := environs.ResolvePluginCredential(environs.CloudSpec{
+		Type:       "dummy",
+		Name:       "dummy",
+		Credential: &plugin,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Credential.AuthType(), gc.Equals, cloud.UserPassAuthType)
+	c.Assert(spec.Credential.Attributes(), jc.DeepEquals, map[string]string{
+		"username": "bob",
+		"password": "hunter2",
+	})
+}
+
+func (s *OpenSuite) TestResolveExpiringPluginCredential(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "cred.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+auth-type: userpass
+attributes:
+  username: bob
+  password: token-1234
+expires: 2100-01-01T00:00:00Z
+`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	plugin := cloud.NewCredential(cloud.PluginAuthType, map[string]string{
+		"plugin": "file",
+		"path":   path,
+	})
+	spec, expires, err := environs.ResolveExpiringPluginCredential(environs.CloudSpec{
+		Type:       "dummy",
+		Name:       "dummy",
+		Credential: &plugin,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Credential.Attributes()["password"], gc.Equals, "token-1234")
+	c.Assert(expires, gc.Equals, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (s *OpenSuite) TestRestrictPluginCredentials(c *gc.C) {
+	s.PatchValue(environs.PluginCredentials, credentialplugin.NewCache(clock.WallClock, time.Minute))
+	s.PatchEnvironment("JUJU_TEST_USERNAME", "bob")
+	s.PatchEnvironment("JUJU_TEST_PASSWORD", "hunter2")
+	plugin := cloud.NewCredential(cloud.PluginAuthType, map[string]string{
+		"plugin":    "env",
+		"auth-type": "userpass",
+		"env-vars":  "username=JUJU_TEST_USERNAME,password=JUJU_TEST_PASSWORD",
+	})
+	spec := environs.CloudSpec{
+		Type:       "dummy",
+		Name:       "dummy",
+		Credential: &plugin,
+	}
+
+	environs.RestrictPluginCredentials([]string{"env:JUJU_TEST_USERNAME"})
+	_, err := environs.ResolvePluginCredential(spec)
+	c.Assert(err, gc.ErrorMatches, `resolving plugin credential: credential plugin "env:JUJU_TEST_PASSWORD" not allowed`)
+
+	environs.RestrictPluginCredentials([]string{"env:JUJU_TEST_USERNAME", "env:JUJU_TEST_PASSWORD"})
+	_, err = environs.ResolvePluginCredential(spec)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OpenSuite) TestResolvePluginCredentialNotPlugin(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+	})
+	in := environs.CloudSpec{
+		Type:       "dummy",
+		Name:       "dummy",
+		Credential: &credential,
+	}
+	spec, err := environs.ResolvePluginCredential(in)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, in)
+}
+
 type destroyControllerEnv struct {
 	environs.Environ
 	gitjujutesting.Stub
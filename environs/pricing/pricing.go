@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package pricing reads the pricing metadata used to estimate the cost
+// of deploying to a cloud.
+//
+// Pricing metadata is a JSON document, published at the URL given by
+// the pricing-metadata-url model setting, that holds the prices of
+// instance types and storage for each region of a cloud:
+//
+//	{
+//	    "format": "pricing:1.0",
+//	    "currency": "USD",
+//	    "regions": {
+//	        "us-east-1": {
+//	            "instance-types": {"m4.large": 0.1, "m4.xlarge": 0.2},
+//	            "storage": {"ebs": 0.1, "ebs-ssd": 0.125}
+//	        }
+//	    }
+//	}
+//
+// Instance type prices are per hour. Storage prices are per GiB per
+// month, and are keyed by storage pool name or storage provider type.
+package pricing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/utils/httptransport"
+)
+
+// Format is the format of the pricing metadata understood by Parse.
+const Format = "pricing:1.0"
+
+// HoursPerMonth is the number of hours in a month, used to convert
+// monthly storage prices into hourly ones.
+const HoursPerMonth = 730
+
+// Metadata holds pricing metadata for the regions of a cloud.
+type Metadata struct {
+	Format   string            `json:"format"`
+	Currency string            `json:"currency"`
+	Regions  map[string]Prices `json:"regions"`
+}
+
+// Prices holds the prices of instance types and storage in a region.
+type Prices struct {
+	// InstanceTypes maps instance type names to their price per hour.
+	InstanceTypes map[string]float64 `json:"instance-types,omitempty"`
+
+	// Storage maps storage pool names or storage provider types to
+	// their price per GiB per month.
+	Storage map[string]float64 `json:"storage,omitempty"`
+}
+
+// Region returns the prices in the named region, and whether the
+// metadata has any for it.
+func (m *Metadata) Region(name string) (Prices, bool) {
+	prices, ok := m.Regions[name]
+	return prices, ok
+}
+
+// InstanceTypeCost returns the price per hour of the named instance
+// type, and whether it is known.
+func (p Prices) InstanceTypeCost(name string) (float64, bool) {
+	cost, ok := p.InstanceTypes[name]
+	return cost, ok
+}
+
+// StorageCost returns the price per hour of size MiB of storage from
+// the named pool, which has the given provider type, and whether it is
+// known. A price for the pool takes precedence over one for its
+// provider type.
+func (p Prices) StorageCost(pool, providerType string, size uint64) (float64, bool) {
+	perGiBMonth, ok := p.Storage[pool]
+	if !ok {
+		perGiBMonth, ok = p.Storage[providerType]
+	}
+	if !ok {
+		return 0, false
+	}
+	return perGiBMonth * float64(size) / 1024 / HoursPerMonth, true
+}
+
+// Parse parses pricing metadata.
+func Parse(data []byte) (*Metadata, error) {
+	var metadata Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal pricing metadata")
+	}
+	if metadata.Format != Format {
+		return nil, errors.NotSupportedf("pricing metadata format %q", metadata.Format)
+	}
+	if metadata.Currency == "" {
+		return nil, errors.NotValidf("pricing metadata with no currency")
+	}
+	return &metadata, nil
+}
+
+// Fetch fetches and parses the pricing metadata at the given URL.
+func Fetch(url string) (*Metadata, error) {
+	client := httptransport.NewClient(utils.VerifySSLHostnames)
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot fetch pricing metadata from %q", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("cannot fetch pricing metadata from %q: %s", url, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read pricing metadata from %q", url)
+	}
+	return Parse(data)
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pricing_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/pricing"
+)
+
+type pricingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&pricingSuite{})
+
+const metadataJSON = `{
+    "format": "pricing:1.0",
+    "currency": "USD",
+    "regions": {
+        "us-east-1": {
+            "instance-types": {"m4.large": 0.1},
+            "storage": {"ebs": 0.25, "fast": 0.5}
+        }
+    }
+}`
+
+func (s *pricingSuite) TestParse(c *gc.C) {
+	metadata, err := pricing.Parse([]byte(metadataJSON))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata, jc.DeepEquals, &pricing.Metadata{
+		Format:   "pricing:1.0",
+		Currency: "USD",
+		Regions: map[string]pricing.Prices{
+			"us-east-1": {
+				InstanceTypes: map[string]float64{"m4.large": 0.1},
+				Storage:       map[string]float64{"ebs": 0.25, "fast": 0.5},
+			},
+		},
+	})
+}
+
+func (s *pricingSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		data string
+		err  string
+	}{{
+		data: `[`,
+		err:  "cannot unmarshal pricing metadata: .*",
+	}, {
+		data: `{"format": "pricing:2.0", "currency": "USD"}`,
+		err:  `pricing metadata format "pricing:2.0" not supported`,
+	}, {
+		data: `{"format": "pricing:1.0"}`,
+		err:  "pricing metadata with no currency not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := pricing.Parse([]byte(test.data))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *pricingSuite) TestPrices(c *gc.C) {
+	metadata, err := pricing.Parse([]byte(metadataJSON))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := metadata.Region("eu-west-1")
+	c.Assert(ok, jc.IsFalse)
+	prices, ok := metadata.Region("us-east-1")
+	c.Assert(ok, jc.IsTrue)
+
+	cost, ok := prices.InstanceTypeCost("m4.large")
+	c.Check(ok, jc.IsTrue)
+	c.Check(cost, gc.Equals, 0.1)
+	_, ok = prices.InstanceTypeCost("m4.xlarge")
+	c.Check(ok, jc.IsFalse)
+
+	// Pool prices take precedence over provider type prices.
+	cost, ok = prices.StorageCost("fast", "ebs", 730*1024)
+	c.Check(ok, jc.IsTrue)
+	c.Check(cost, gc.Equals, 0.5)
+	cost, ok = prices.StorageCost("ebs-ssd", "ebs", 7300*1024)
+	c.Check(ok, jc.IsTrue)
+	c.Check(cost, gc.Equals, 2.5)
+	_, ok = prices.StorageCost("loop", "loop", 1024)
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *pricingSuite) TestFetch(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, metadataJSON)
+	}))
+	defer server.Close()
+	metadata, err := pricing.Fetch(server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata.Currency, gc.Equals, "USD")
+}
+
+func (s *pricingSuite) TestFetchNotFound(c *gc.C) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	_, err := pricing.Fetch(server.URL)
+	c.Assert(err, gc.ErrorMatches, `cannot fetch pricing metadata from ".*": 404 Not Found`)
+}
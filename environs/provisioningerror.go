@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/errors"
+)
+
+// ProvisioningErrorClass identifies the kind of failure that prevented
+// an instance from being started, so that it can be decided how best to
+// retry.
+type ProvisioningErrorClass string
+
+const (
+	// ProvisioningErrorUnknown is the class of errors that have not
+	// been classified.
+	ProvisioningErrorUnknown ProvisioningErrorClass = "unknown"
+
+	// ProvisioningErrorQuota is the class of errors caused by
+	// exceeding a quota of the cloud account.
+	ProvisioningErrorQuota ProvisioningErrorClass = "quota"
+
+	// ProvisioningErrorImageNotFound is the class of errors caused by
+	// there being no image suitable for the instance.
+	ProvisioningErrorImageNotFound ProvisioningErrorClass = "image-not-found"
+
+	// ProvisioningErrorAZCapacity is the class of errors caused by an
+	// availability zone lacking the capacity to host the instance.
+	ProvisioningErrorAZCapacity ProvisioningErrorClass = "az-capacity"
+
+	// ProvisioningErrorCredential is the class of errors caused by the
+	// cloud credential being invalid or lacking permissions.
+	ProvisioningErrorCredential ProvisioningErrorClass = "credential"
+
+	// ProvisioningErrorNetwork is the class of errors caused by the
+	// networks the instance should be started on.
+	ProvisioningErrorNetwork ProvisioningErrorClass = "network"
+)
+
+var provisioningErrorAdvice = map[ProvisioningErrorClass]string{
+	ProvisioningErrorUnknown:       "retry provisioning once the cause of the error has been resolved",
+	ProvisioningErrorQuota:         "release resources or raise the cloud account's quota, then retry provisioning",
+	ProvisioningErrorImageNotFound: "make an image available for the machine's series, or change its constraints, then retry provisioning",
+	ProvisioningErrorAZCapacity:    "retry provisioning, switching availability zone or instance type if needed",
+	ProvisioningErrorCredential:    "update the model's cloud credential, then retry provisioning",
+	ProvisioningErrorNetwork:       "check the model's network configuration, then retry provisioning",
+}
+
+// RetryAdvice returns a short suggestion for how to resolve errors of
+// the class.
+func (c ProvisioningErrorClass) RetryAdvice() string {
+	if advice, ok := provisioningErrorAdvice[c]; ok {
+		return advice
+	}
+	return provisioningErrorAdvice[ProvisioningErrorUnknown]
+}
+
+// IsCapacity reports whether errors of the class may be avoided by
+// starting the instance in another availability zone or with another
+// instance type.
+func (c ProvisioningErrorClass) IsCapacity() bool {
+	return c == ProvisioningErrorAZCapacity
+}
+
+// ProvisioningErrorInfo describes why an instance could not be started.
+type ProvisioningErrorInfo struct {
+	// Class is the class of the error.
+	Class ProvisioningErrorClass
+
+	// AvailabilityZone, if set, is the availability zone in which
+	// the last attempt to start the instance failed.
+	AvailabilityZone string
+
+	// InstanceType, if set, is the instance type with which the
+	// attempts to start the instance failed.
+	InstanceType string
+}
+
+// provisioningError wraps an error returned by StartInstance with the
+// information classifying it. It has the same message and cause as the
+// error it wraps.
+type provisioningError struct {
+	errors.Err
+	info ProvisioningErrorInfo
+}
+
+// NewProvisioningError returns an error that wraps err, an error
+// returned when starting an instance, with the given information
+// classifying it.
+func NewProvisioningError(err error, info ProvisioningErrorInfo) error {
+	if info.Class == "" {
+		info.Class = ProvisioningErrorUnknown
+	}
+	return &provisioningError{
+		Err:  errors.NewErrWithCause(err, errors.Cause(err), ""),
+		info: info,
+	}
+}
+
+// ClassifyProvisioningError returns the information classifying the
+// given error, which may have been annotated since it was returned from
+// NewProvisioningError. Errors not returned from NewProvisioningError
+// are of class ProvisioningErrorUnknown.
+func ClassifyProvisioningError(err error) ProvisioningErrorInfo {
+	for err != nil {
+		if perr, ok := err.(*provisioningError); ok {
+			return perr.info
+		}
+		wrapper, ok := err.(interface {
+			Underlying() error
+		})
+		if !ok {
+			break
+		}
+		err = wrapper.Underlying()
+	}
+	return ProvisioningErrorInfo{Class: ProvisioningErrorUnknown}
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/testing"
+)
+
+type ProvisioningErrorSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ProvisioningErrorSuite{})
+
+func (*ProvisioningErrorSuite) TestClassifyUnclassified(c *gc.C) {
+	info := environs.ClassifyProvisioningError(errors.New("boom"))
+	c.Assert(info, jc.DeepEquals, environs.ProvisioningErrorInfo{
+		Class: environs.ProvisioningErrorUnknown,
+	})
+}
+
+func (*ProvisioningErrorSuite) TestClassifyAnnotated(c *gc.C) {
+	cause := errors.NotFoundf("flavor")
+	err := environs.NewProvisioningError(errors.Annotate(cause, "cannot run instance"), environs.ProvisioningErrorInfo{
+		Class:            environs.ProvisioningErrorAZCapacity,
+		AvailabilityZone: "zone-a",
+		InstanceType:     "m1.small",
+	})
+	err = errors.Annotate(err, "starting machine 0")
+
+	c.Assert(err, gc.ErrorMatches, "starting machine 0: cannot run instance: flavor not found")
+	c.Assert(errors.Cause(err), gc.Equals, cause)
+	c.Assert(environs.ClassifyProvisioningError(err), jc.DeepEquals, environs.ProvisioningErrorInfo{
+		Class:            environs.ProvisioningErrorAZCapacity,
+		AvailabilityZone: "zone-a",
+		InstanceType:     "m1.small",
+	})
+}
+
+func (*ProvisioningErrorSuite) TestNewProvisioningErrorDefaultsClass(c *gc.C) {
+	err := environs.NewProvisioningError(errors.New("boom"), environs.ProvisioningErrorInfo{})
+	c.Assert(environs.ClassifyProvisioningError(err).Class, gc.Equals, environs.ProvisioningErrorUnknown)
+}
+
+func (*ProvisioningErrorSuite) TestRetryAdvice(c *gc.C) {
+	for _, class := range []environs.ProvisioningErrorClass{
+		environs.ProvisioningErrorUnknown,
+		environs.ProvisioningErrorQuota,
+		environs.ProvisioningErrorImageNotFound,
+		environs.ProvisioningErrorAZCapacity,
+		environs.ProvisioningErrorCredential,
+		environs.ProvisioningErrorNetwork,
+	} {
+		c.Check(class.RetryAdvice(), gc.Not(gc.Equals), "")
+	}
+	c.Check(environs.ProvisioningErrorClass("bogus").RetryAdvice(), gc.Equals,
+		environs.ProvisioningErrorUnknown.RetryAdvice())
+}
+
+func (*ProvisioningErrorSuite) TestIsCapacity(c *gc.C) {
+	c.Check(environs.ProvisioningErrorAZCapacity.IsCapacity(), jc.IsTrue)
+	c.Check(environs.ProvisioningErrorQuota.IsCapacity(), jc.IsFalse)
+	c.Check(environs.ProvisioningErrorUnknown.IsCapacity(), jc.IsFalse)
+}
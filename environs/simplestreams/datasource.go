@@ -11,6 +11,8 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+
+	"github.com/juju/juju/utils/httptransport"
 )
 
 // A DataSource retrieves simplestreams metadata.
@@ -119,7 +121,7 @@ func urlJoin(baseURL, relpath string) string {
 // Fetch is defined in simplestreams.DataSource.
 func (h *urlDataSource) Fetch(path string) (io.ReadCloser, string, error) {
 	dataURL := urlJoin(h.baseURL, path)
-	client := utils.GetHTTPClient(h.hostnameVerification)
+	client := httptransport.NewClient(h.hostnameVerification)
 	// dataURL can be http:// or file://
 	// MakeFileURL will only modify the URL if it's a file URL
 	dataURL = utils.MakeFileURL(dataURL)
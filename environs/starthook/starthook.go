@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package starthook provides an extension point for sites to act on
+// instances started by the provisioner, for example to register new
+// machines in a CMDB or DNS. Hooks are registered per provider type, or
+// configured for a controller with the instance-started-webhook
+// controller config attribute.
+package starthook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+)
+
+// InstanceDetails holds details of a started instance.
+type InstanceDetails struct {
+	// ModelUUID is the UUID of the model the instance is in.
+	ModelUUID string `json:"model-uuid"`
+
+	// ProviderType is the type of the provider that started the
+	// instance.
+	ProviderType string `json:"provider-type"`
+
+	// MachineId is the ID of the machine the instance was started for.
+	MachineId string `json:"machine-id"`
+
+	// InstanceId is the provider-specific ID of the instance.
+	InstanceId instance.Id `json:"instance-id"`
+
+	// Series is the series of the instance's OS.
+	Series string `json:"series"`
+
+	// Hardware describes the instance's hardware, if known.
+	Hardware *instance.HardwareCharacteristics `json:"hardware,omitempty"`
+
+	// Addresses holds the addresses of the instance known when it
+	// was started.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// Hook is called after the provisioner starts an instance. Hooks that
+// fail are retried, so they should be idempotent.
+type Hook interface {
+	// Name returns a name identifying the hook in logs and status.
+	Name() string
+
+	// InstanceStarted is called with the details of a started
+	// instance.
+	InstanceStarted(InstanceDetails) error
+}
+
+var (
+	mu            sync.Mutex
+	providerHooks = make(map[string][]Hook)
+)
+
+// Register registers a hook to be called whenever an instance of the
+// given provider type is started.
+func Register(providerType string, hook Hook) {
+	mu.Lock()
+	defer mu.Unlock()
+	providerHooks[providerType] = append(providerHooks[providerType], hook)
+}
+
+// ProviderHooks returns the hooks registered for the given provider
+// type.
+func ProviderHooks(providerType string) []Hook {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks := make([]Hook, len(providerHooks[providerType]))
+	copy(hooks, providerHooks[providerType])
+	return hooks
+}
+
+// webhookTimeout is how long a webhook request may take.
+const webhookTimeout = 30 * time.Second
+
+// NewWebhook returns a Hook that POSTs instance details, encoded as
+// JSON, to the given URL. Any response status other than 2xx is
+// treated as a failure.
+func NewWebhook(url string) Hook {
+	return &webhook{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// Name is part of the Hook interface.
+func (w *webhook) Name() string {
+	return fmt.Sprintf("webhook %s", w.url)
+}
+
+// InstanceStarted is part of the Hook interface.
+func (w *webhook) InstanceStarted(details InstanceDetails) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		msg := string(bytes.TrimSpace(body))
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		if msg == "" {
+			return errors.Errorf("webhook returned %s", resp.Status)
+		}
+		return errors.Errorf("webhook returned %s: %s", resp.Status, msg)
+	}
+	return nil
+}
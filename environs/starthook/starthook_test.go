@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package starthook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/starthook"
+	"github.com/juju/juju/instance"
+)
+
+type starthookSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&starthookSuite{})
+
+var testDetails = starthook.InstanceDetails{
+	ModelUUID:    "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	ProviderType: "openstack",
+	MachineId:    "3",
+	InstanceId:   instance.Id("inst-3"),
+	Series:       "xenial",
+	Hardware:     &instance.HardwareCharacteristics{},
+	Addresses:    []string{"10.0.0.3"},
+}
+
+func (s *starthookSuite) TestProviderHooks(c *gc.C) {
+	hook := starthook.NewWebhook("http://cmdb.example.com")
+	starthook.Register("starthook-test", hook)
+	c.Assert(starthook.ProviderHooks("starthook-test"), jc.DeepEquals, []starthook.Hook{hook})
+	c.Assert(starthook.ProviderHooks("starthook-other"), gc.HasLen, 0)
+}
+
+func (s *starthookSuite) TestWebhook(c *gc.C) {
+	var received starthook.InstanceDetails
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		c.Check(json.NewDecoder(r.Body).Decode(&received), jc.ErrorIsNil)
+	}))
+	defer server.Close()
+
+	hook := starthook.NewWebhook(server.URL)
+	c.Assert(hook.Name(), gc.Equals, "webhook "+server.URL)
+	err := hook.InstanceStarted(testDetails)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, testDetails)
+}
+
+func (s *starthookSuite) TestWebhookFailure(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "cmdb is read-only", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := starthook.NewWebhook(server.URL).InstanceStarted(testDetails)
+	c.Assert(err, gc.ErrorMatches, "webhook returned 503 Service Unavailable: cmdb is read-only")
+}
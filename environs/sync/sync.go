@@ -22,6 +22,7 @@ import (
 	envtools "github.com/juju/juju/environs/tools"
 	"github.com/juju/juju/juju/keys"
 	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/utils/httptransport"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -178,7 +179,7 @@ func copyTools(toolsDir, stream string, tools []*coretools.Tools, u ToolsUploade
 func copyOneToolsPackage(toolsDir, stream string, tools *coretools.Tools, u ToolsUploader) error {
 	toolsName := envtools.StorageName(tools.Version, toolsDir)
 	logger.Infof("downloading %q %v (%v)", stream, toolsName, tools.URL)
-	resp, err := utils.GetValidatingHTTPClient().Get(tools.URL)
+	resp, err := httptransport.NewClient(utils.VerifySSLHostnames).Get(tools.URL)
 	if err != nil {
 		return err
 	}
@@ -3,7 +3,11 @@
 
 package tags
 
-import "gopkg.in/juju/names.v2"
+import (
+	"strings"
+
+	"gopkg.in/juju/names.v2"
+)
 
 const (
 	// JujuTagPrefix is the prefix for Juju-managed tags.
@@ -40,6 +44,17 @@ const (
 	// the model and machine id corresponding to the
 	// provisioned machine instance.
 	JujuMachine = JujuTagPrefix + "machine-id"
+
+	// AnnotationPrefix is the prefix of the model and application
+	// annotations that are propagated to infrastructure resources
+	// as tags. For example, the annotation "resource-tag:owner=bob"
+	// becomes the tag "juju-annotation-owner=bob".
+	AnnotationPrefix = "resource-tag:"
+
+	// JujuAnnotationTagPrefix is the prefix of the tags defined by
+	// annotations. It identifies the tags that Juju owns, so that
+	// those no longer defined by annotations can be found and removed.
+	JujuAnnotationTagPrefix = JujuTagPrefix + "annotation-"
 )
 
 // ResourceTagger is an interface that can provide resource tags.
@@ -67,3 +82,23 @@ func ResourceTags(modelTag names.ModelTag, controllerTag names.ControllerTag, ta
 	allTags[JujuController] = controllerTag.Id()
 	return allTags
 }
+
+// AnnotationTags returns the resource tags defined by the given
+// annotations, which are those with keys that start with
+// AnnotationPrefix. The tags are named with JujuAnnotationTagPrefix.
+// Annotations naming tags with the JujuTagPrefix are ignored, as
+// those tags are reserved for Juju.
+func AnnotationTags(annotations map[string]string) map[string]string {
+	tags := make(map[string]string)
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, AnnotationPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, AnnotationPrefix)
+		if name == "" || strings.HasPrefix(name, JujuTagPrefix) {
+			continue
+		}
+		tags[JujuAnnotationTagPrefix+name] = value
+	}
+	return tags
+}
@@ -61,6 +61,20 @@ func (*tagsSuite) TestResourceTagsResourceTaggers(c *gc.C) {
 	})
 }
 
+func (*tagsSuite) TestAnnotationTags(c *gc.C) {
+	tags := tags.AnnotationTags(map[string]string{
+		"resource-tag:owner":        "bob",
+		"resource-tag:cost-centre":  "1234",
+		"resource-tag:":             "nameless",
+		"resource-tag:juju-machine": "reserved",
+		"owner":                     "alice",
+	})
+	c.Assert(tags, jc.DeepEquals, map[string]string{
+		"juju-annotation-owner":       "bob",
+		"juju-annotation-cost-centre": "1234",
+	})
+}
+
 func testResourceTags(c *gc.C, controller names.ControllerTag, model names.ModelTag, taggers []tags.ResourceTagger, expectTags map[string]string) {
 	tags := tags.ResourceTags(model, controller, taggers...)
 	c.Assert(tags, jc.DeepEquals, expectTags)
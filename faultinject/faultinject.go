@@ -0,0 +1,209 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package faultinject provides points in the agents at which faults
+// can be injected, so that operators and developers can rehearse the
+// handling of failures on staging controllers.
+//
+// Faults are only ever injected when set, which can only be done by
+// root through the introspection socket of agents of controllers with
+// the fault-injection-enabled config set.
+package faultinject
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+)
+
+var logger = loggo.GetLogger("juju.faultinject")
+
+// Point identifies a place in the agent at which faults can be
+// injected.
+type Point string
+
+const (
+	// APIConnection is injected when an API connection is opened,
+	// and whenever an open connection is pinged, so an error fault
+	// causes new connections to fail and open ones to be dropped.
+	APIConnection Point = "api-connection"
+
+	// HookDispatch is injected before a uniter runs a hook, so a
+	// delay fault slows hook dispatch, and an error fault fails
+	// hooks.
+	HookDispatch Point = "hook-dispatch"
+
+	// ProviderCall is injected into the calls made to the dummy
+	// provider, to simulate provider errors.
+	ProviderCall Point = "provider-call"
+)
+
+// Points holds all the known fault injection points.
+var Points = []Point{
+	APIConnection,
+	HookDispatch,
+	ProviderCall,
+}
+
+// Fault describes a fault injected at a point.
+type Fault struct {
+	// Error, if not empty, is the message of the error returned
+	// by Inject.
+	Error string `yaml:"error,omitempty"`
+
+	// Delay is how long Inject waits before returning.
+	Delay time.Duration `yaml:"delay,omitempty"`
+
+	// Count is the number of times the fault is injected before
+	// it is cleared. If it is zero, the fault is injected until it
+	// is cleared explicitly.
+	Count int `yaml:"count,omitempty"`
+}
+
+// Validate returns an error if the fault is not valid.
+func (f Fault) Validate() error {
+	if f.Error == "" && f.Delay == 0 {
+		return errors.NotValidf("fault without error or delay")
+	}
+	if f.Delay < 0 {
+		return errors.NotValidf("negative delay")
+	}
+	if f.Count < 0 {
+		return errors.NotValidf("negative count")
+	}
+	return nil
+}
+
+// Registry holds the faults set at each point.
+type Registry struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	enabled bool
+	faults  map[Point]Fault
+}
+
+// NewRegistry returns a new Registry without any faults set, which
+// uses the given clock to delay. Fault injection is disabled until
+// SetEnabled is called.
+func NewRegistry(clock clock.Clock) *Registry {
+	return &Registry{
+		clock:  clock,
+		faults: make(map[Point]Fault),
+	}
+}
+
+// SetEnabled enables or disables fault injection. While it is
+// disabled no faults can be set, and any already set are cleared.
+func (r *Registry) SetEnabled(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if enabled == r.enabled {
+		return
+	}
+	r.enabled = enabled
+	if enabled {
+		logger.Warningf("fault injection enabled")
+		return
+	}
+	r.faults = make(map[Point]Fault)
+	logger.Infof("fault injection disabled")
+}
+
+// Enabled reports whether fault injection is enabled.
+func (r *Registry) Enabled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled
+}
+
+// Set sets the fault injected at the given point, replacing any
+// fault already set there. It fails if fault injection is disabled.
+func (r *Registry) Set(point Point, fault Fault) error {
+	if !knownPoint(point) {
+		return errors.NotValidf("fault injection point %q", point)
+	}
+	if err := fault.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return errors.New("fault injection not enabled")
+	}
+	r.faults[point] = fault
+	logger.Warningf("injecting fault at %q: %+v", point, fault)
+	return nil
+}
+
+// Clear clears any fault set at the given point.
+func (r *Registry) Clear(point Point) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.faults[point]; ok {
+		delete(r.faults, point)
+		logger.Warningf("cleared fault at %q", point)
+	}
+}
+
+// Faults returns the faults currently set, by point.
+func (r *Registry) Faults() map[Point]Fault {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	faults := make(map[Point]Fault, len(r.faults))
+	for point, fault := range r.faults {
+		faults[point] = fault
+	}
+	return faults
+}
+
+// Inject injects the fault set at the given point, if any: it waits
+// for the fault's delay, and then returns its error.
+func (r *Registry) Inject(point Point) error {
+	r.mu.Lock()
+	fault, ok := r.faults[point]
+	if ok && fault.Count > 0 {
+		if fault.Count == 1 {
+			delete(r.faults, point)
+		} else {
+			r.faults[point] = Fault{
+				Error: fault.Error,
+				Delay: fault.Delay,
+				Count: fault.Count - 1,
+			}
+		}
+	}
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	logger.Debugf("injecting fault at %q", point)
+	if fault.Delay > 0 {
+		<-r.clock.After(fault.Delay)
+	}
+	if fault.Error != "" {
+		return errors.Errorf("injected fault: %s", fault.Error)
+	}
+	return nil
+}
+
+func knownPoint(point Point) bool {
+	for _, p := range Points {
+		if p == point {
+			return true
+		}
+	}
+	return false
+}
+
+// Default is the registry of the faults injected by Inject.
+var Default = NewRegistry(clock.WallClock)
+
+// Inject injects the fault set at the given point in the Default
+// registry, if any.
+func Inject(point Point) error {
+	return Default.Inject(point)
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package faultinject_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/faultinject"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RegistrySuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	registry *faultinject.Registry
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.registry = faultinject.NewRegistry(s.clock)
+	s.registry.SetEnabled(true)
+}
+
+func (s *RegistrySuite) TestSetDisabled(c *gc.C) {
+	registry := faultinject.NewRegistry(s.clock)
+	c.Assert(registry.Enabled(), jc.IsFalse)
+	err := registry.Set(faultinject.APIConnection, faultinject.Fault{Error: "boom"})
+	c.Assert(err, gc.ErrorMatches, "fault injection not enabled")
+	c.Assert(registry.Faults(), gc.HasLen, 0)
+}
+
+func (s *RegistrySuite) TestDisableClearsFaults(c *gc.C) {
+	err := s.registry.Set(faultinject.APIConnection, faultinject.Fault{Error: "boom"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.registry.SetEnabled(false)
+	c.Assert(s.registry.Enabled(), jc.IsFalse)
+	c.Assert(s.registry.Faults(), gc.HasLen, 0)
+	c.Assert(s.registry.Inject(faultinject.APIConnection), jc.ErrorIsNil)
+}
+
+func (s *RegistrySuite) TestInjectNoFault(c *gc.C) {
+	err := s.registry.Inject(faultinject.APIConnection)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RegistrySuite) TestInjectError(c *gc.C) {
+	err := s.registry.Set(faultinject.APIConnection, faultinject.Fault{Error: "boom"})
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 3; i++ {
+		err = s.registry.Inject(faultinject.APIConnection)
+		c.Assert(err, gc.ErrorMatches, "injected fault: boom")
+	}
+	err = s.registry.Inject(faultinject.HookDispatch)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RegistrySuite) TestInjectCount(c *gc.C) {
+	err := s.registry.Set(faultinject.ProviderCall, faultinject.Fault{Error: "boom", Count: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.registry.Inject(faultinject.ProviderCall), gc.ErrorMatches, "injected fault: boom")
+	c.Assert(s.registry.Faults(), jc.DeepEquals, map[faultinject.Point]faultinject.Fault{
+		faultinject.ProviderCall: {Error: "boom", Count: 1},
+	})
+	c.Assert(s.registry.Inject(faultinject.ProviderCall), gc.ErrorMatches, "injected fault: boom")
+	c.Assert(s.registry.Inject(faultinject.ProviderCall), jc.ErrorIsNil)
+	c.Assert(s.registry.Faults(), gc.HasLen, 0)
+}
+
+func (s *RegistrySuite) TestInjectDelay(c *gc.C) {
+	err := s.registry.Set(faultinject.HookDispatch, faultinject.Fault{Delay: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.registry.Inject(faultinject.HookDispatch)
+	}()
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Inject")
+	}
+}
+
+func (s *RegistrySuite) TestClear(c *gc.C) {
+	err := s.registry.Set(faultinject.APIConnection, faultinject.Fault{Error: "boom"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.registry.Clear(faultinject.APIConnection)
+	c.Assert(s.registry.Inject(faultinject.APIConnection), jc.ErrorIsNil)
+	c.Assert(s.registry.Faults(), gc.HasLen, 0)
+}
+
+func (s *RegistrySuite) TestSetInvalid(c *gc.C) {
+	err := s.registry.Set("bogus", faultinject.Fault{Error: "boom"})
+	c.Assert(err, gc.ErrorMatches, `fault injection point "bogus" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	for _, t := range []struct {
+		fault faultinject.Fault
+		err   string
+	}{{
+		fault: faultinject.Fault{},
+		err:   "fault without error or delay not valid",
+	}, {
+		fault: faultinject.Fault{Delay: -time.Second},
+		err:   "negative delay not valid",
+	}, {
+		fault: faultinject.Fault{Error: "boom", Count: -1},
+		err:   "negative count not valid",
+	}} {
+		err := s.registry.Set(faultinject.APIConnection, t.fault)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	c.Assert(s.registry.Faults(), gc.HasLen, 0)
+}
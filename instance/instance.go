@@ -83,6 +83,10 @@ type HardwareCharacteristics struct {
 
 	// AvailabilityZone defines the zone in which the machine resides.
 	AvailabilityZone *string `json:"availability-zone,omitempty" yaml:"availabilityzone,omitempty"`
+
+	// Bandwidth is the limit on outgoing network traffic in megabits
+	// per second.
+	Bandwidth *uint64 `json:"bandwidth,omitempty" yaml:"bandwidth,omitempty"`
 }
 
 func (hc HardwareCharacteristics) String() string {
@@ -102,6 +106,9 @@ func (hc HardwareCharacteristics) String() string {
 	if hc.RootDisk != nil {
 		strs = append(strs, fmt.Sprintf("root-disk=%dM", *hc.RootDisk))
 	}
+	if hc.Bandwidth != nil {
+		strs = append(strs, fmt.Sprintf("bandwidth=%dM", *hc.Bandwidth))
+	}
 	if hc.Tags != nil && len(*hc.Tags) > 0 {
 		strs = append(strs, fmt.Sprintf("tags=%s", strings.Join(*hc.Tags, ",")))
 	}
@@ -159,6 +166,8 @@ func (hc *HardwareCharacteristics) setRaw(raw string) error {
 		err = hc.setMem(str)
 	case "root-disk":
 		err = hc.setRootDisk(str)
+	case "bandwidth":
+		err = hc.setBandwidth(str)
 	case "tags":
 		err = hc.setTags(str)
 	case "availability-zone":
@@ -215,6 +224,14 @@ func (hc *HardwareCharacteristics) setRootDisk(str string) (err error) {
 	return
 }
 
+func (hc *HardwareCharacteristics) setBandwidth(str string) (err error) {
+	if hc.Bandwidth != nil {
+		return fmt.Errorf("already set")
+	}
+	hc.Bandwidth, err = parseUint64(strings.TrimSuffix(str, "M"))
+	return
+}
+
 func (hc *HardwareCharacteristics) setTags(str string) (err error) {
 	if hc.Tags != nil {
 		return fmt.Errorf("already set")
@@ -244,6 +244,26 @@ var parseHardwareTests = []parseHardwareTestSpec{
 		err:     `bad "root-disk" characteristic: already set`,
 	},
 
+	// "bandwidth" in detail.
+	{
+		summary: "set bandwidth empty",
+		args:    []string{"bandwidth="},
+	}, {
+		summary: "set bandwidth without suffix",
+		args:    []string{"bandwidth=100"},
+	}, {
+		summary: "set bandwidth with M suffix",
+		args:    []string{"bandwidth=100M"},
+	}, {
+		summary: "set nonsense bandwidth",
+		args:    []string{"bandwidth=fast"},
+		err:     `bad "bandwidth" characteristic: must be a non-negative integer`,
+	}, {
+		summary: "double set bandwidth",
+		args:    []string{"bandwidth=100M", "bandwidth=1000M"},
+		err:     `bad "bandwidth" characteristic: already set`,
+	},
+
 	// "availability-zone" in detail.
 	{
 		summary: "set availability-zone empty",
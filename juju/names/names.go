@@ -1,15 +1,17 @@
 // Copyright 2014 Cloudbase Solutions
 // Copyright 2014 Canonical Ltd.
 // Licensed under the AGPLv3, see LICENCE file for details.
+//go:build !windows
 // +build !windows
 
 package names
 
 const (
-	Juju           = "juju"
-	Jujud          = "jujud"
-	Jujuc          = "jujuc"
-	JujuRun        = "juju-run"
-	JujuDumpLogs   = "juju-dumplogs"
-	JujuIntrospect = "juju-introspect"
+	Juju            = "juju"
+	Jujud           = "jujud"
+	Jujuc           = "jujuc"
+	JujuRun         = "juju-run"
+	JujuDumpLogs    = "juju-dumplogs"
+	JujuIntrospect  = "juju-introspect"
+	JujudPrivHelper = "jujud-privhelper"
 )
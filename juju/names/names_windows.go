@@ -5,10 +5,11 @@
 package names
 
 const (
-	Juju           = "juju.exe"
-	Jujud          = "jujud.exe"
-	Jujuc          = "jujuc.exe"
-	JujuRun        = "juju-run.exe"
-	JujuDumpLogs   = "juju-dumplogs.exe"
-	JujuIntrospect = "juju-introspect.exe"
+	Juju            = "juju.exe"
+	Jujud           = "jujud.exe"
+	Jujuc           = "jujuc.exe"
+	JujuRun         = "juju-run.exe"
+	JujuDumpLogs    = "juju-dumplogs.exe"
+	JujuIntrospect  = "juju-introspect.exe"
+	JujudPrivHelper = "jujud-privhelper.exe"
 )
@@ -21,4 +21,11 @@ type RegistrationInfo struct {
 	// caller of "juju add-user" that will be used to suggest a name for
 	// the caller of "juju register".
 	ControllerName string
+
+	// CACert contains the CA certificate of the Juju controller, used
+	// by "juju register" to verify the controller's identity before
+	// sending the registration request. It is absent from registration
+	// strings printed by older clients, in which case the controller
+	// is authenticated by its response alone.
+	CACert string `asn1:"optional,utf8"`
 }
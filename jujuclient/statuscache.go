@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// CachedStatus holds the status of a model as it was last fetched
+// from the controller.
+type CachedStatus struct {
+	// Fetched is when the status was fetched from the controller.
+	Fetched time.Time `json:"fetched"`
+
+	// Status holds the status, in the JSON encoding used by the API.
+	Status json.RawMessage `json:"status"`
+}
+
+// JujuStatusCachePath is the location where the status last fetched
+// for the named model of the named controller is cached.
+func JujuStatusCachePath(controllerName, modelName string) string {
+	return osenv.JujuXDGDataHomePath("status-cache", controllerName, modelName+".json")
+}
+
+// ReadCachedStatus returns the status cached for the named model of
+// the named controller. If no status has been cached, an error
+// satisfying errors.IsNotFound is returned.
+func ReadCachedStatus(controllerName, modelName string) (*CachedStatus, error) {
+	data, err := ioutil.ReadFile(JujuStatusCachePath(controllerName, modelName))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("cached status for model %q", modelName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var cached CachedStatus
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal cached status")
+	}
+	return &cached, nil
+}
+
+// WriteCachedStatus replaces the status cached for the named model of
+// the named controller.
+func WriteCachedStatus(controllerName, modelName string, cached CachedStatus) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal cached status")
+	}
+	path := JujuStatusCachePath(controllerName, modelName)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	return utils.AtomicWriteFile(path, data, os.FileMode(0600))
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type StatusCacheSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&StatusCacheSuite{})
+
+func (s *StatusCacheSuite) TestReadNotCached(c *gc.C) {
+	_, err := jujuclient.ReadCachedStatus("ctrl", "admin/default")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cached status for model "admin/default" not found`)
+}
+
+func (s *StatusCacheSuite) TestWriteRead(c *gc.C) {
+	fetched := time.Date(2017, 9, 1, 10, 0, 0, 0, time.UTC)
+	err := jujuclient.WriteCachedStatus("ctrl", "admin/default", jujuclient.CachedStatus{
+		Fetched: fetched,
+		Status:  json.RawMessage(`{"model":{"name":"default"}}`),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cached, err := jujuclient.ReadCachedStatus("ctrl", "admin/default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached.Fetched.Equal(fetched), jc.IsTrue)
+	c.Assert(string(cached.Status), gc.Equals, `{"model":{"name":"default"}}`)
+
+	info, err := os.Stat(jujuclient.JujuStatusCachePath("ctrl", "admin/default"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	_, err = jujuclient.ReadCachedStatus("ctrl", "admin/other")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StatusCacheSuite) TestWriteReplaces(c *gc.C) {
+	for _, name := range []string{"first", "second"} {
+		err := jujuclient.WriteCachedStatus("ctrl", "admin/default", jujuclient.CachedStatus{
+			Fetched: time.Now(),
+			Status:  json.RawMessage(`"` + name + `"`),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	cached, err := jujuclient.ReadCachedStatus("ctrl", "admin/default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(cached.Status), gc.Equals, `"second"`)
+}
@@ -72,7 +72,10 @@ type CharmDownloader interface {
 // CharmUploader defines a single method that is used to upload a
 // charm to the target controller in a migration.
 type CharmUploader interface {
-	UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error)
+	// UploadCharm uploads the charm with the given URL and archive
+	// content. The git source is nil unless the charm was built from
+	// a git repository.
+	UploadCharm(*charm.URL, *migration.CharmGitSource, io.ReadSeeker) (*charm.URL, error)
 }
 
 // ToolsDownloader defines a single method that is used to download
@@ -106,6 +109,7 @@ type ResourceUploader interface {
 // with the default helper functions, use `NewUploadBinariesConfig`.
 type UploadBinariesConfig struct {
 	Charms          []string
+	CharmGitSources map[string]migration.CharmGitSource
 	CharmDownloader CharmDownloader
 	CharmUploader   CharmUploader
 
@@ -209,7 +213,11 @@ func uploadCharms(config UploadBinariesConfig) error {
 		}
 		defer cleanup()
 
-		if usedCurl, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
+		var gitSource *migration.CharmGitSource
+		if source, ok := config.CharmGitSources[charmURL]; ok {
+			gitSource = &source
+		}
+		if usedCurl, err := config.CharmUploader.UploadCharm(curl, gitSource, content); err != nil {
 			return errors.Annotate(err, "cannot upload charm")
 		} else if usedCurl.String() != curl.String() {
 			// The target controller shouldn't assign a different charm URL.
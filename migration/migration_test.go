@@ -146,6 +146,13 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 			"local:trusty/magic-2",
 			"cs:trusty/postgresql-42",
 		},
+		CharmGitSources: map[string]coremigration.CharmGitSource{
+			"local:trusty/magic-10": {
+				Repository: "git+ssh://git@example.com/magic.git",
+				Ref:        "stable",
+				Revision:   "0123456789abcdef",
+			},
+		},
 		CharmDownloader:    downloader,
 		CharmUploader:      uploader,
 		Tools:              toolsMap,
@@ -166,6 +173,13 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 	}
 	c.Assert(downloader.charms, jc.DeepEquals, expectedCharms)
 	c.Assert(uploader.charms, jc.DeepEquals, expectedCharms)
+	c.Assert(uploader.gitSources, jc.DeepEquals, map[string]coremigration.CharmGitSource{
+		"local:trusty/magic-10": {
+			Repository: "git+ssh://git@example.com/magic.git",
+			Ref:        "stable",
+			Revision:   "0123456789abcdef",
+		},
+	})
 
 	c.Assert(downloader.uris, jc.SameContents, []string{
 		"/tools/0",
@@ -235,6 +249,7 @@ func (d *fakeDownloader) OpenResource(app, name string) (io.ReadCloser, error) {
 type fakeUploader struct {
 	tools            map[version.Binary]string
 	charms           []string
+	gitSources       map[string]coremigration.CharmGitSource
 	resources        map[string]string
 	unitResources    []string
 	reassignCharmURL bool
@@ -249,7 +264,7 @@ func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...strin
 	return tools.List{&tools.Tools{Version: v}}, nil
 }
 
-func (f *fakeUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+func (f *fakeUploader) UploadCharm(u *charm.URL, gitSource *coremigration.CharmGitSource, r io.ReadSeeker) (*charm.URL, error) {
 	data, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -258,6 +273,12 @@ func (f *fakeUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, e
 		panic(fmt.Sprintf("unexpected charm body for %s: %s", u.String(), data))
 	}
 	f.charms = append(f.charms, u.String())
+	if gitSource != nil {
+		if f.gitSources == nil {
+			f.gitSources = make(map[string]coremigration.CharmGitSource)
+		}
+		f.gitSources[u.String()] = *gitSource
+	}
 
 	outU := *u
 	if f.reassignCharmURL {
@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/tools"
 )
 
@@ -34,6 +36,8 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackendCloser, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	Charm(*charm.URL) (PrecheckCharm, error)
+	StorageProviderTypes() ([]storage.ProviderType, error)
 }
 
 // PrecheckBackendCloser adds the Close method to the standard
@@ -80,6 +84,17 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	ExposedCIDRs() []string
+	IsTrusted() (bool, error)
+	AddressPolicy() state.AddressPolicy
+	FloatingIP() (address, unitName string)
+	Logging() state.ApplicationLogging
+}
+
+// PrecheckCharm describes the state interface for a charm needed by
+// migration prechecks.
+type PrecheckCharm interface {
+	IsUploaded() bool
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -125,6 +140,34 @@ func SourcePrecheck(backend PrecheckBackend) error {
 	if err := checkController(controllerBackend); err != nil {
 		return errors.Annotate(err, "controller")
 	}
+
+	var report precheckReport
+	if err := checkCharms(backend, &report); err != nil {
+		return errors.Trace(err)
+	}
+	return report.err()
+}
+
+// checkCharms reports any application whose charm would not be
+// available to the target controller.
+func checkCharms(backend PrecheckBackend, report *precheckReport) error {
+	apps, err := backend.AllApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving applications")
+	}
+	for _, app := range apps {
+		curl, _ := app.CharmURL()
+		ch, err := backend.Charm(curl)
+		if errors.IsNotFound(err) {
+			report.addf("charm %s for application %s not found", curl, app.Name())
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "retrieving charm %s", curl)
+		}
+		if !ch.IsUploaded() {
+			report.addf("charm %s for application %s has not been uploaded", curl, app.Name())
+		}
+	}
 	return nil
 }
 
@@ -214,6 +257,64 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		}
 	}
 
+	var report precheckReport
+	checkVersionSkew(controllerVersion, modelInfo, &report)
+	if err := checkTargetCredential(backend, modelInfo, &report); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkStorageProviders(backend, modelInfo, &report); err != nil {
+		return errors.Trace(err)
+	}
+	return report.err()
+}
+
+// checkVersionSkew reports if the model's agents are too far behind
+// the target controller to be managed by it.
+func checkVersionSkew(controllerVersion version.Number, modelInfo coremigration.ModelInfo, report *precheckReport) {
+	if modelInfo.AgentVersion.Major != controllerVersion.Major {
+		report.addf("model version %s cannot be managed by target controller version %s (major versions differ)",
+			modelInfo.AgentVersion, controllerVersion)
+	}
+}
+
+// checkTargetCredential reports if the target controller already holds
+// the model's cloud credential, but it can no longer be used.
+func checkTargetCredential(backend PrecheckBackend, modelInfo coremigration.ModelInfo, report *precheckReport) error {
+	if modelInfo.CloudCredential == (names.CloudCredentialTag{}) {
+		return nil
+	}
+	cred, err := backend.CloudCredential(modelInfo.CloudCredential)
+	if errors.IsNotFound(err) {
+		// The credential will be added when the model is imported.
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "retrieving cloud credential")
+	}
+	if cred.Revoked {
+		report.addf("cloud credential %s is revoked on target controller", modelInfo.CloudCredential.Id())
+	}
+	return nil
+}
+
+// checkStorageProviders reports any storage provider used by the
+// model that the target controller does not support.
+func checkStorageProviders(backend PrecheckBackend, modelInfo coremigration.ModelInfo, report *precheckReport) error {
+	if len(modelInfo.StorageProviders) == 0 {
+		return nil
+	}
+	providerTypes, err := backend.StorageProviderTypes()
+	if err != nil {
+		return errors.Annotate(err, "retrieving storage provider types")
+	}
+	supported := make(map[string]bool)
+	for _, providerType := range providerTypes {
+		supported[string(providerType)] = true
+	}
+	for _, providerType := range modelInfo.StorageProviders {
+		if !supported[providerType] {
+			report.addf("storage provider %q not supported by target controller", providerType)
+		}
+	}
 	return nil
 }
 
@@ -307,7 +408,33 @@ func checkApplications(backend PrecheckBackend) error {
 		if app.Life() != state.Alive {
 			return errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
-		err := checkUnits(app, modelVersion)
+		// The migration format cannot yet hold the CIDRs an
+		// application is exposed to, and dropping them would
+		// expose it to everyone.
+		if len(app.ExposedCIDRs()) > 0 {
+			return errors.Errorf("application %s is exposed to specific CIDRs", app.Name())
+		}
+		// Nor can it hold an application's trust, and silently
+		// dropping it would break the application's charm.
+		trusted, err := app.IsTrusted()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving trust for %s", app.Name())
+		}
+		if trusted {
+			return errors.Errorf("application %s is trusted with the cloud credential", app.Name())
+		}
+		// Nor can it hold an application's address policy,
+		// floating IP or logging settings.
+		if !app.AddressPolicy().IsZero() {
+			return errors.Errorf("application %s has an address policy", app.Name())
+		}
+		if address, _ := app.FloatingIP(); address != "" {
+			return errors.Errorf("application %s has a floating IP", app.Name())
+		}
+		if app.Logging() != (state.ApplicationLogging{}) {
+			return errors.Errorf("application %s has logging settings", app.Name())
+		}
+		err = checkUnits(app, modelVersion)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -379,6 +506,47 @@ type agentToolsGetter interface {
 	AgentTools() (*tools.Tools, error)
 }
 
+// PrecheckError is returned by the prechecks when they find problems
+// which must be resolved before the model can be migrated. All of the
+// problems found are reported, rather than only the first.
+type PrecheckError struct {
+	Problems []string
+}
+
+// Error is part of the error interface.
+func (e *PrecheckError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0]
+	}
+	return fmt.Sprintf("%d problems found:\n  %s",
+		len(e.Problems), strings.Join(e.Problems, "\n  "))
+}
+
+// IsPrecheckError returns whether the cause of err is a
+// *PrecheckError.
+func IsPrecheckError(err error) bool {
+	_, ok := errors.Cause(err).(*PrecheckError)
+	return ok
+}
+
+// precheckReport collects the problems found by the prechecks.
+type precheckReport struct {
+	problems []string
+}
+
+func (r *precheckReport) addf(format string, args ...interface{}) {
+	r.problems = append(r.problems, fmt.Sprintf(format, args...))
+}
+
+// err returns a *PrecheckError describing the problems reported, or
+// nil if there were none.
+func (r *precheckReport) err() error {
+	if len(r.problems) == 0 {
+		return nil
+	}
+	return &PrecheckError{Problems: r.problems}
+}
+
 func newStatusError(format, id string, s status.Status) error {
 	msg := fmt.Sprintf(format, id)
 	if s != status.Empty {
@@ -6,9 +6,13 @@ package migration
 import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
 )
 
 // PrecheckShim wraps a *state.State to implement PrecheckBackend.
@@ -97,6 +101,25 @@ func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, er
 	return resources, nil
 }
 
+// Charm implements PrecheckBackend.
+func (s *precheckShim) Charm(curl *charm.URL) (PrecheckCharm, error) {
+	ch, err := s.State.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+// StorageProviderTypes implements PrecheckBackend.
+func (s *precheckShim) StorageProviderTypes() ([]storage.ProviderType, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	providerTypes, err := stateenvirons.NewStorageProviderRegistry(env).StorageProviderTypes()
+	return providerTypes, errors.Trace(err)
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackendCloser, error) {
 	st, err := s.State.ForModel(s.State.ControllerModelTag())
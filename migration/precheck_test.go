@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/tools"
 )
@@ -133,6 +134,31 @@ func (s *SourcePrecheckSuite) TestMachineVersionsDontMatch(c *gc.C) {
 	s.checkMachineVersionsDontMatch(c, sourcePrecheck)
 }
 
+func (*SourcePrecheckSuite) TestMissingCharm(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	backend.missingCharms = []string{"cs:foo-1"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm cs:foo-1 for application foo not found")
+	c.Assert(migration.IsPrecheckError(err), jc.IsTrue)
+}
+
+func (*SourcePrecheckSuite) TestCharmsReportedTogether(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	backend.pendingCharms = []string{"cs:foo-1"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, `2 problems found:
+  charm cs:foo-1 for application foo has not been uploaded
+  charm cs:foo-1 for application bar has not been uploaded`)
+	c.Assert(err, jc.DeepEquals, &migration.PrecheckError{
+		Problems: []string{
+			"charm cs:foo-1 for application foo has not been uploaded",
+			"charm cs:foo-1 for application bar has not been uploaded",
+		},
+	})
+}
+
 func (s *SourcePrecheckSuite) TestDyingMachine(c *gc.C) {
 	backend := newBackendWithDyingMachine()
 	err := migration.SourcePrecheck(backend)
@@ -168,6 +194,71 @@ func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is dying")
 }
 
+func (s *SourcePrecheckSuite) TestApplicationExposedToCIDRs(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:  "foo",
+				cidrs: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo is exposed to specific CIDRs")
+}
+
+func (s *SourcePrecheckSuite) TestTrustedApplication(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:    "foo",
+				trusted: true,
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo is trusted with the cloud credential")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationWithAddressPolicy(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:   "foo",
+				policy: state.AddressPolicy{Prefer: state.AddressKindProvider},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has an address policy")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationWithFloatingIP(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:     "foo",
+				floating: "10.0.0.1",
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has a floating IP")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationWithLogging(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:    "foo",
+				logging: state.ApplicationLogging{DedicatedFile: true},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has logging settings")
+}
+
 func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -409,6 +500,69 @@ func (s *TargetPrecheckSuite) TestSourceControllerTagMismatch(c *gc.C) {
 	c.Assert(migration.TargetPrecheck(backend, nil, s.modelInfo), jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestModelMajorVersionBehind(c *gc.C) {
+	backend := newFakeBackend()
+	s.modelInfo.AgentVersion = version.MustParse("0.9.9")
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches,
+		`model version 0.9.9 cannot be managed by target controller version 1.2.3 \(major versions differ\)`)
+	c.Assert(migration.IsPrecheckError(err), jc.IsTrue)
+}
+
+func (s *TargetPrecheckSuite) TestCredentialRevoked(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentials = cloud.NewEmptyCredential()
+	backend.credentials.Revoked = true
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("cloud/owner/cred")
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "cloud credential cloud/owner/cred is revoked on target controller")
+}
+
+func (s *TargetPrecheckSuite) TestCredentialNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentialsErr = errors.NotFoundf("credential")
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("cloud/owner/cred")
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestCredentialError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentialsErr = errors.New("boom")
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("cloud/owner/cred")
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "retrieving cloud credential: boom")
+}
+
+func (s *TargetPrecheckSuite) TestStorageProviders(c *gc.C) {
+	backend := newFakeBackend()
+	backend.storageTypes = []storage.ProviderType{"loop", "ebs"}
+	s.modelInfo.StorageProviders = []string{"ebs", "loop"}
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestProblemsReportedTogether(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentials = cloud.NewEmptyCredential()
+	backend.credentials.Revoked = true
+	backend.storageTypes = []storage.ProviderType{"loop"}
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("cloud/owner/cred")
+	s.modelInfo.StorageProviders = []string{"cinder", "loop"}
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err.Error(), gc.Equals, `2 problems found:
+  cloud credential cloud/owner/cred is revoked on target controller
+  storage provider "cinder" not supported by target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestStorageProviderTypesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.storageTypeErr = errors.New("boom")
+	s.modelInfo.StorageProviders = []string{"ebs"}
+	err := migration.TargetPrecheck(backend, nil, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "retrieving storage provider types: boom")
+}
+
 func (s *TargetPrecheckSuite) TestDying(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.life = state.Dying
@@ -667,6 +821,11 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	missingCharms  []string
+	pendingCharms  []string
+	storageTypes   []storage.ProviderType
+	storageTypeErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -715,6 +874,24 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) Charm(curl *charm.URL) (migration.PrecheckCharm, error) {
+	for _, url := range b.missingCharms {
+		if url == curl.String() {
+			return nil, errors.NotFoundf("charm %q", url)
+		}
+	}
+	for _, url := range b.pendingCharms {
+		if url == curl.String() {
+			return &fakeCharm{}, nil
+		}
+	}
+	return &fakeCharm{uploaded: true}, nil
+}
+
+func (b *fakeBackend) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return b.storageTypes, b.storageTypeErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackendCloser, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -844,6 +1021,11 @@ type fakeApp struct {
 	charmURL string
 	units    []migration.PrecheckUnit
 	minunits int
+	cidrs    []string
+	trusted  bool
+	policy   state.AddressPolicy
+	floating string
+	logging  state.ApplicationLogging
 }
 
 func (a *fakeApp) Name() string {
@@ -870,6 +1052,34 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) ExposedCIDRs() []string {
+	return a.cidrs
+}
+
+func (a *fakeApp) IsTrusted() (bool, error) {
+	return a.trusted, nil
+}
+
+func (a *fakeApp) AddressPolicy() state.AddressPolicy {
+	return a.policy
+}
+
+func (a *fakeApp) FloatingIP() (string, string) {
+	return a.floating, ""
+}
+
+func (a *fakeApp) Logging() state.ApplicationLogging {
+	return a.logging
+}
+
+type fakeCharm struct {
+	uploaded bool
+}
+
+func (ch *fakeCharm) IsUploaded() bool {
+	return ch.uploaded
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -0,0 +1,199 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+	"gopkg.in/goose.v2/identity"
+	gooselogging "gopkg.in/goose.v2/logging"
+)
+
+// designateClient is the part of the goose client used to make
+// requests to the Designate API, which goose has no client for.
+type designateClient interface {
+	SendRequest(method, svcType, apiVersion, url string, requestData *goosehttp.RequestData) error
+}
+
+// designateProvider maintains records in an OpenStack Designate zone.
+// It is configured with the attributes:
+//
+//	auth-url:    the URL of the identity service (required)
+//	username:    the user to authenticate as (required)
+//	password:    the password of the user (required)
+//	tenant-name: the tenant (project) owning the zone (required)
+//	region:      the region of the DNS service
+//	domain-name: the domain of the user and project, for identity v3
+//	ttl:         the time to live of records, in seconds
+type designateProvider struct {
+	client designateClient
+	zone   string
+	ttl    int
+
+	mu     sync.Mutex
+	zoneID string
+}
+
+func newDesignateProvider(zone string, attrs map[string]string) (Provider, error) {
+	if err := requireAttrs(attrs, "auth-url", "username", "password", "tenant-name"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ttl, err := ttl(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cred := identity.Credentials{
+		URL:        attrs["auth-url"],
+		User:       attrs["username"],
+		Secrets:    attrs["password"],
+		TenantName: attrs["tenant-name"],
+		Region:     attrs["region"],
+	}
+	authMode := identity.AuthUserPass
+	if domain := attrs["domain-name"]; domain != "" {
+		cred.Domain = domain
+		authMode = identity.AuthUserPassV3
+	}
+	gooseLogger := gooselogging.LoggoLogger{loggo.GetLogger("goose")}
+	client := client.NewClient(&cred, authMode, gooseLogger)
+	client.SetRequiredServiceTypes([]string{"dns"})
+	return newDesignateProviderWithClient(client, zone, ttl), nil
+}
+
+func newDesignateProviderWithClient(client designateClient, zone string, ttl int) *designateProvider {
+	return &designateProvider{
+		client: client,
+		zone:   zone,
+		ttl:    ttl,
+	}
+}
+
+type designateZone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type designateRecordSet struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	TTL     int      `json:"ttl"`
+	Records []string `json:"records"`
+}
+
+// SetRecords is part of the Provider interface.
+func (p *designateProvider) SetRecords(name string, addresses []string) error {
+	ipv4, ipv6, err := splitAddresses(addresses)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.setRecordSet(name, "A", ipv4); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.setRecordSet(name, "AAAA", ipv6))
+}
+
+// RemoveRecords is part of the Provider interface.
+func (p *designateProvider) RemoveRecords(name string) error {
+	for _, recordType := range []string{"A", "AAAA"} {
+		if err := p.setRecordSet(name, recordType, nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// setRecordSet creates, updates or deletes the record set of the name
+// and type, so that it holds the given records.
+func (p *designateProvider) setRecordSet(name, recordType string, records []string) error {
+	zoneID, err := p.getZoneID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	existing, err := p.recordSet(zoneID, name, recordType)
+	if err != nil {
+		return errors.Annotatef(err, "getting %s records of %q", recordType, name)
+	}
+	recordSetsURL := "zones/" + zoneID + "/recordsets"
+	switch {
+	case existing == nil && len(records) == 0:
+		return nil
+	case existing == nil:
+		req := designateRecordSet{
+			Name:    name + ".",
+			Type:    recordType,
+			TTL:     p.ttl,
+			Records: records,
+		}
+		err = p.send(client.POST, recordSetsURL, req, nil, http.StatusCreated, http.StatusAccepted)
+		return errors.Annotatef(err, "creating %s records of %q", recordType, name)
+	case len(records) == 0:
+		err = p.send(client.DELETE, recordSetsURL+"/"+existing.ID, nil, nil, http.StatusAccepted, http.StatusNoContent)
+		return errors.Annotatef(err, "deleting %s records of %q", recordType, name)
+	default:
+		req := designateRecordSet{
+			TTL:     p.ttl,
+			Records: records,
+		}
+		err = p.send(client.PUT, recordSetsURL+"/"+existing.ID, req, nil, http.StatusOK, http.StatusAccepted)
+		return errors.Annotatef(err, "updating %s records of %q", recordType, name)
+	}
+}
+
+// recordSet returns the record set of the name and type, or nil if
+// there is none.
+func (p *designateProvider) recordSet(zoneID, name, recordType string) (*designateRecordSet, error) {
+	query := url.Values{
+		"name": {name + "."},
+		"type": {recordType},
+	}
+	var resp struct {
+		RecordSets []designateRecordSet `json:"recordsets"`
+	}
+	reqURL := "zones/" + zoneID + "/recordsets?" + query.Encode()
+	if err := p.send(client.GET, reqURL, nil, &resp, http.StatusOK); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(resp.RecordSets) == 0 {
+		return nil, nil
+	}
+	return &resp.RecordSets[0], nil
+}
+
+// getZoneID returns the ID of the provider's zone, which is looked up
+// on first use.
+func (p *designateProvider) getZoneID() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.zoneID != "" {
+		return p.zoneID, nil
+	}
+	var resp struct {
+		Zones []designateZone `json:"zones"`
+	}
+	reqURL := "zones?" + url.Values{"name": {p.zone + "."}}.Encode()
+	if err := p.send(client.GET, reqURL, nil, &resp, http.StatusOK); err != nil {
+		return "", errors.Annotatef(err, "getting zone %q", p.zone)
+	}
+	if len(resp.Zones) == 0 {
+		return "", errors.NotFoundf("zone %q", p.zone)
+	}
+	p.zoneID = resp.Zones[0].ID
+	return p.zoneID, nil
+}
+
+func (p *designateProvider) send(method, reqURL string, req, resp interface{}, expectedStatus ...int) error {
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      resp,
+		ExpectedStatus: expectedStatus,
+	}
+	return p.client.SendRequest(method, "dns", "v2", reqURL, &requestData)
+}
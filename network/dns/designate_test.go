@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/network/dns"
+)
+
+type designateSuite struct {
+	testing.IsolationSuite
+	testing.Stub
+
+	// recordSets holds the JSON record sets returned when listing
+	// record sets, by type.
+	recordSets map[string]string
+	provider   dns.Provider
+}
+
+var _ = gc.Suite(&designateSuite{})
+
+func (s *designateSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.Stub = testing.Stub{}
+	s.recordSets = make(map[string]string)
+	s.provider = dns.NewDesignateProvider(s.send, "apps.example.com")
+}
+
+func (s *designateSuite) send(method, svcType, apiVersion, url string, requestData *goosehttp.RequestData) error {
+	var req string
+	if requestData.ReqValue != nil {
+		data, err := json.Marshal(requestData.ReqValue)
+		if err != nil {
+			return err
+		}
+		req = string(data)
+	}
+	s.AddCall(method, svcType+"/"+apiVersion+"/"+url, req)
+	if err := s.NextErr(); err != nil {
+		return err
+	}
+	var resp string
+	switch {
+	case strings.HasPrefix(url, "zones?"):
+		resp = `{"zones": [{"id": "zone-id", "name": "apps.example.com."}]}`
+	case strings.Contains(url, "type=AAAA"):
+		resp = `{"recordsets": [` + s.recordSets["AAAA"] + `]}`
+	case strings.Contains(url, "type=A"):
+		resp = `{"recordsets": [` + s.recordSets["A"] + `]}`
+	default:
+		return nil
+	}
+	return json.Unmarshal([]byte(resp), requestData.RespValue)
+}
+
+func (s *designateSuite) TestSetRecords(c *gc.C) {
+	s.recordSets["A"] = `{"id": "a-id", "name": "wordpress.apps.example.com.", "type": "A", "records": ["10.0.0.9"]}`
+	s.recordSets["AAAA"] = `{"id": "aaaa-id", "name": "wordpress.apps.example.com.", "type": "AAAA", "records": ["2001:db8::9"]}`
+	err := s.provider.SetRecords("wordpress.apps.example.com", []string{"10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.CheckCalls(c, []testing.StubCall{
+		{"GET", []interface{}{"dns/v2/zones?name=apps.example.com.", ""}},
+		{"GET", []interface{}{"dns/v2/zones/zone-id/recordsets?name=wordpress.apps.example.com.&type=A", ""}},
+		{"PUT", []interface{}{"dns/v2/zones/zone-id/recordsets/a-id", `{"ttl":300,"records":["10.0.0.1"]}`}},
+		{"GET", []interface{}{"dns/v2/zones/zone-id/recordsets?name=wordpress.apps.example.com.&type=AAAA", ""}},
+		{"DELETE", []interface{}{"dns/v2/zones/zone-id/recordsets/aaaa-id", ""}},
+	})
+}
+
+func (s *designateSuite) TestSetRecordsCreates(c *gc.C) {
+	err := s.provider.SetRecords("wordpress.apps.example.com", []string{"2001:db8::1"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.CheckCalls(c, []testing.StubCall{
+		{"GET", []interface{}{"dns/v2/zones?name=apps.example.com.", ""}},
+		{"GET", []interface{}{"dns/v2/zones/zone-id/recordsets?name=wordpress.apps.example.com.&type=A", ""}},
+		{"GET", []interface{}{"dns/v2/zones/zone-id/recordsets?name=wordpress.apps.example.com.&type=AAAA", ""}},
+		{"POST", []interface{}{"dns/v2/zones/zone-id/recordsets", `{"name":"wordpress.apps.example.com.","type":"AAAA","ttl":300,"records":["2001:db8::1"]}`}},
+	})
+}
+
+func (s *designateSuite) TestRemoveRecordsCachesZone(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		err := s.provider.RemoveRecords("wordpress.apps.example.com")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.CheckCallNames(c, "GET", "GET", "GET", "GET", "GET")
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dns maintains DNS records for the addresses of exposed
+// applications, using one of several DNS providers.
+package dns
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+var logger = loggo.GetLogger("juju.network.dns")
+
+// DefaultTTL is the time to live, in seconds, of the records
+// maintained by a provider whose configuration does not set "ttl".
+const DefaultTTL = 300
+
+// Provider maintains the A and AAAA records of names in a DNS zone.
+type Provider interface {
+	// SetRecords replaces the A and AAAA records of the fully
+	// qualified name with records for the given addresses.
+	SetRecords(name string, addresses []string) error
+
+	// RemoveRecords removes the A and AAAA records of the fully
+	// qualified name. It is not an error if there are none.
+	RemoveRecords(name string) error
+}
+
+// ProviderFunc returns a Provider that maintains records in the
+// given zone, configured with the given attributes.
+type ProviderFunc func(zone string, attrs map[string]string) (Provider, error)
+
+// providers holds the known providers, by name.
+var providers = map[string]ProviderFunc{
+	"designate": newDesignateProvider,
+	"nsupdate":  newNsupdateProvider,
+	"route53":   newRoute53Provider,
+}
+
+// NewProvider returns the named Provider, which maintains records in
+// the given zone, configured with the given attributes.
+func NewProvider(name, zone string, attrs map[string]string) (Provider, error) {
+	newProvider, ok := providers[name]
+	if !ok {
+		return nil, errors.NotFoundf("DNS provider %q", name)
+	}
+	if zone == "" {
+		return nil, errors.NotValidf("empty DNS zone")
+	}
+	provider, err := newProvider(strings.TrimSuffix(zone, "."), attrs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating %s DNS provider", name)
+	}
+	return provider, nil
+}
+
+// RecordName returns the name of the records holding the addresses of
+// the application in the zone.
+func RecordName(application, zone string) string {
+	return application + "." + strings.TrimSuffix(zone, ".")
+}
+
+// splitAddresses returns the IPv4 and IPv6 addresses, sorted, for the
+// A and AAAA records respectively.
+func splitAddresses(addresses []string) (ipv4, ipv6 []string, _ error) {
+	for _, addr := range addresses {
+		ip := net.ParseIP(addr)
+		switch {
+		case ip == nil:
+			return nil, nil, errors.NotValidf("IP address %q", addr)
+		case ip.To4() != nil:
+			ipv4 = append(ipv4, ip.String())
+		default:
+			ipv6 = append(ipv6, ip.String())
+		}
+	}
+	sort.Strings(ipv4)
+	sort.Strings(ipv6)
+	return ipv4, ipv6, nil
+}
+
+// ttl returns the time to live configured by the "ttl" attribute.
+func ttl(attrs map[string]string) (int, error) {
+	value, ok := attrs["ttl"]
+	if !ok {
+		return DefaultTTL, nil
+	}
+	ttl, err := strconv.Atoi(value)
+	if err != nil || ttl <= 0 {
+		return 0, errors.NotValidf("ttl %q", value)
+	}
+	return ttl, nil
+}
+
+// requireAttrs returns an error if any of the named attributes is
+// not set.
+func requireAttrs(attrs map[string]string, names ...string) error {
+	for _, name := range names {
+		if attrs[name] == "" {
+			return errors.Errorf("%q not specified", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/dns"
+)
+
+type dnsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&dnsSuite{})
+
+func (s *dnsSuite) TestNewProviderUnknown(c *gc.C) {
+	_, err := dns.NewProvider("bind", "example.com", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `DNS provider "bind" not found`)
+}
+
+func (s *dnsSuite) TestNewProviderNoZone(c *gc.C) {
+	_, err := dns.NewProvider("nsupdate", "", map[string]string{"server": "ns1"})
+	c.Assert(err, gc.ErrorMatches, "empty DNS zone not valid")
+}
+
+func (s *dnsSuite) TestNewProviderMissingAttrs(c *gc.C) {
+	for name, err := range map[string]string{
+		"designate": `creating designate DNS provider: "auth-url" not specified`,
+		"nsupdate":  `creating nsupdate DNS provider: "server" not specified`,
+		"route53":   `creating route53 DNS provider: "hosted-zone-id" not specified`,
+	} {
+		_, e := dns.NewProvider(name, "example.com", nil)
+		c.Check(e, gc.ErrorMatches, err)
+	}
+}
+
+func (s *dnsSuite) TestNewProviderInvalidTTL(c *gc.C) {
+	_, err := dns.NewProvider("nsupdate", "example.com", map[string]string{
+		"server": "ns1",
+		"ttl":    "-1",
+	})
+	c.Assert(err, gc.ErrorMatches, `creating nsupdate DNS provider: ttl "-1" not valid`)
+}
+
+func (s *dnsSuite) TestRecordName(c *gc.C) {
+	c.Assert(dns.RecordName("wordpress", "apps.example.com"), gc.Equals, "wordpress.apps.example.com")
+	c.Assert(dns.RecordName("wordpress", "apps.example.com."), gc.Equals, "wordpress.apps.example.com")
+}
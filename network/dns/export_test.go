@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns
+
+import (
+	goosehttp "gopkg.in/goose.v2/http"
+)
+
+var RunNsupdate = &runNsupdate
+
+type designateClientFunc func(method, svcType, apiVersion, url string, requestData *goosehttp.RequestData) error
+
+func (f designateClientFunc) SendRequest(method, svcType, apiVersion, url string, requestData *goosehttp.RequestData) error {
+	return f(method, svcType, apiVersion, url, requestData)
+}
+
+// NewDesignateProvider returns a Designate provider that makes its
+// requests with send.
+func NewDesignateProvider(
+	send func(method, svcType, apiVersion, url string, requestData *goosehttp.RequestData) error,
+	zone string,
+) Provider {
+	return newDesignateProviderWithClient(designateClientFunc(send), zone, DefaultTTL)
+}
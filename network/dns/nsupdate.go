@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// runNsupdate runs nsupdate with the given arguments, feeding it the
+// script on its standard input.
+var runNsupdate = func(args []string, script string) error {
+	cmd := exec.Command("nsupdate", args...)
+	cmd.Stdin = strings.NewReader(script)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Annotatef(err, "running nsupdate: %s", bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// nsupdateProvider maintains records with dynamic updates sent to a
+// name server by nsupdate. It is configured with the attributes:
+//
+//	server:   the name server to send updates to (required)
+//	port:     the port of the name server
+//	key-file: the file holding the TSIG key to sign updates with
+//	ttl:      the time to live of records, in seconds
+type nsupdateProvider struct {
+	zone   string
+	server string
+	port   string
+	args   []string
+	ttl    int
+}
+
+func newNsupdateProvider(zone string, attrs map[string]string) (Provider, error) {
+	if err := requireAttrs(attrs, "server"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ttl, err := ttl(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var args []string
+	if keyFile := attrs["key-file"]; keyFile != "" {
+		args = append(args, "-k", keyFile)
+	}
+	return &nsupdateProvider{
+		zone:   zone,
+		server: attrs["server"],
+		port:   attrs["port"],
+		args:   args,
+		ttl:    ttl,
+	}, nil
+}
+
+// SetRecords is part of the Provider interface.
+func (p *nsupdateProvider) SetRecords(name string, addresses []string) error {
+	ipv4, ipv6, err := splitAddresses(addresses)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.update(name, func(script *bytes.Buffer) {
+		for _, addr := range ipv4 {
+			fmt.Fprintf(script, "update add %s. %d A %s\n", name, p.ttl, addr)
+		}
+		for _, addr := range ipv6 {
+			fmt.Fprintf(script, "update add %s. %d AAAA %s\n", name, p.ttl, addr)
+		}
+	}))
+}
+
+// RemoveRecords is part of the Provider interface.
+func (p *nsupdateProvider) RemoveRecords(name string) error {
+	return errors.Trace(p.update(name, func(*bytes.Buffer) {}))
+}
+
+// update sends an update that deletes the records of the name, and
+// then adds those written by add.
+func (p *nsupdateProvider) update(name string, add func(*bytes.Buffer)) error {
+	var script bytes.Buffer
+	fmt.Fprintf(&script, "server %s", p.server)
+	if p.port != "" {
+		fmt.Fprintf(&script, " %s", p.port)
+	}
+	fmt.Fprintf(&script, "\nzone %s.\n", p.zone)
+	fmt.Fprintf(&script, "update delete %s. A\n", name)
+	fmt.Fprintf(&script, "update delete %s. AAAA\n", name)
+	add(&script)
+	script.WriteString("send\n")
+	logger.Debugf("updating records of %q with nsupdate", name)
+	return runNsupdate(p.args, script.String())
+}
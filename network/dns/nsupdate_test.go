@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/dns"
+)
+
+type nsupdateSuite struct {
+	testing.IsolationSuite
+	testing.Stub
+}
+
+var _ = gc.Suite(&nsupdateSuite{})
+
+func (s *nsupdateSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.Stub = testing.Stub{}
+	s.PatchValue(dns.RunNsupdate, func(args []string, script string) error {
+		s.AddCall("nsupdate", args, script)
+		return s.NextErr()
+	})
+}
+
+func (s *nsupdateSuite) newProvider(c *gc.C, attrs map[string]string) dns.Provider {
+	provider, err := dns.NewProvider("nsupdate", "apps.example.com", attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return provider
+}
+
+func (s *nsupdateSuite) TestSetRecords(c *gc.C) {
+	provider := s.newProvider(c, map[string]string{
+		"server":   "10.0.0.53",
+		"key-file": "/etc/juju/dns.key",
+		"ttl":      "60",
+	})
+	err := provider.SetRecords("wordpress.apps.example.com", []string{"10.0.0.2", "2001:db8::1", "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.CheckCalls(c, []testing.StubCall{{"nsupdate", []interface{}{
+		[]string{"-k", "/etc/juju/dns.key"}, `
+server 10.0.0.53
+zone apps.example.com.
+update delete wordpress.apps.example.com. A
+update delete wordpress.apps.example.com. AAAA
+update add wordpress.apps.example.com. 60 A 10.0.0.1
+update add wordpress.apps.example.com. 60 A 10.0.0.2
+update add wordpress.apps.example.com. 60 AAAA 2001:db8::1
+send
+`[1:],
+	}}})
+}
+
+func (s *nsupdateSuite) TestSetRecordsInvalidAddress(c *gc.C) {
+	provider := s.newProvider(c, map[string]string{"server": "10.0.0.53"})
+	err := provider.SetRecords("wordpress.apps.example.com", []string{"ns1.example.com"})
+	c.Assert(err, gc.ErrorMatches, `IP address "ns1.example.com" not valid`)
+	s.CheckNoCalls(c)
+}
+
+func (s *nsupdateSuite) TestRemoveRecords(c *gc.C) {
+	provider := s.newProvider(c, map[string]string{
+		"server": "10.0.0.53",
+		"port":   "5353",
+	})
+	err := provider.RemoveRecords("wordpress.apps.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	s.CheckCalls(c, []testing.StubCall{{"nsupdate", []interface{}{
+		[]string(nil), `
+server 10.0.0.53 5353
+zone apps.example.com.
+update delete wordpress.apps.example.com. A
+update delete wordpress.apps.example.com. AAAA
+send
+`[1:],
+	}}})
+}
+
+func (s *nsupdateSuite) TestUpdateError(c *gc.C) {
+	s.SetErrors(errors.New("update failed: REFUSED"))
+	provider := s.newProvider(c, map[string]string{"server": "10.0.0.53"})
+	err := provider.RemoveRecords("wordpress.apps.example.com")
+	c.Assert(err, gc.ErrorMatches, "update failed: REFUSED")
+}
@@ -0,0 +1,216 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+)
+
+const (
+	// route53Endpoint is the default endpoint of the Route 53 API.
+	route53Endpoint = "https://route53.amazonaws.com"
+
+	route53Version = "2013-04-01"
+	route53XMLNS   = "https://route53.amazonaws.com/doc/2013-04-01/"
+)
+
+// route53Provider maintains records in an Amazon Route 53 hosted zone.
+// It is configured with the attributes:
+//
+//	hosted-zone-id: the ID of the hosted zone of the zone (required)
+//	access-key:     the AWS access key (required)
+//	secret-key:     the AWS secret key (required)
+//	endpoint:       the endpoint of the Route 53 API
+//	ttl:            the time to live of records, in seconds
+type route53Provider struct {
+	endpoint string
+	zoneID   string
+	auth     aws.Auth
+	sign     aws.Signer
+	ttl      int
+	client   *http.Client
+}
+
+func newRoute53Provider(zone string, attrs map[string]string) (Provider, error) {
+	if err := requireAttrs(attrs, "hosted-zone-id", "access-key", "secret-key"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ttl, err := ttl(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoint := attrs["endpoint"]
+	if endpoint == "" {
+		endpoint = route53Endpoint
+	}
+	return &route53Provider{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		zoneID:   strings.TrimPrefix(attrs["hosted-zone-id"], "/hostedzone/"),
+		auth: aws.Auth{
+			AccessKey: attrs["access-key"],
+			SecretKey: attrs["secret-key"],
+		},
+		// Route 53 is a global service, whose requests are
+		// signed for us-east-1.
+		sign:   aws.SignV4Factory("us-east-1", "route53"),
+		ttl:    ttl,
+		client: http.DefaultClient,
+	}, nil
+}
+
+type route53RecordSet struct {
+	Name    string   `xml:"Name"`
+	Type    string   `xml:"Type"`
+	TTL     int      `xml:"TTL"`
+	Records []string `xml:"ResourceRecords>ResourceRecord>Value"`
+}
+
+type route53Change struct {
+	Action    string           `xml:"Action"`
+	RecordSet route53RecordSet `xml:"ResourceRecordSet"`
+}
+
+type route53ChangeRequest struct {
+	XMLName xml.Name        `xml:"ChangeResourceRecordSetsRequest"`
+	XMLNS   string          `xml:"xmlns,attr"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+type route53ListResponse struct {
+	RecordSets []route53RecordSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+}
+
+type route53Error struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// SetRecords is part of the Provider interface.
+func (p *route53Provider) SetRecords(name string, addresses []string) error {
+	ipv4, ipv6, err := splitAddresses(addresses)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var changes []route53Change
+	for _, set := range []struct {
+		recordType string
+		addresses  []string
+	}{{"A", ipv4}, {"AAAA", ipv6}} {
+		if len(set.addresses) > 0 {
+			changes = append(changes, route53Change{
+				Action: "UPSERT",
+				RecordSet: route53RecordSet{
+					Name:    name + ".",
+					Type:    set.recordType,
+					TTL:     p.ttl,
+					Records: set.addresses,
+				},
+			})
+			continue
+		}
+		deletion, err := p.deletion(name, set.recordType)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		changes = append(changes, deletion...)
+	}
+	return errors.Trace(p.change(changes))
+}
+
+// RemoveRecords is part of the Provider interface.
+func (p *route53Provider) RemoveRecords(name string) error {
+	var changes []route53Change
+	for _, recordType := range []string{"A", "AAAA"} {
+		deletion, err := p.deletion(name, recordType)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		changes = append(changes, deletion...)
+	}
+	return errors.Trace(p.change(changes))
+}
+
+// deletion returns the changes that delete the records of the name
+// and type. Route 53 deletes only exactly matching record sets, so
+// the existing record set is fetched first.
+func (p *route53Provider) deletion(name, recordType string) ([]route53Change, error) {
+	query := url.Values{
+		"name":     {name + "."},
+		"type":     {recordType},
+		"maxitems": {"1"},
+	}
+	var resp route53ListResponse
+	if err := p.do("GET", "rrset?"+query.Encode(), nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "listing %s records of %q", recordType, name)
+	}
+	for _, set := range resp.RecordSets {
+		if strings.TrimSuffix(set.Name, ".") == name && set.Type == recordType {
+			return []route53Change{{Action: "DELETE", RecordSet: set}}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *route53Provider) change(changes []route53Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	req := route53ChangeRequest{
+		XMLNS:   route53XMLNS,
+		Changes: changes,
+	}
+	return errors.Annotate(p.do("POST", "rrset", req, nil), "changing records")
+}
+
+// do makes a signed request to the hosted zone resource at path, and
+// decodes the response into resp if it is not nil.
+func (p *route53Provider) do(method, path string, body, resp interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		data, err := xml.Marshal(body)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		reqBody = append([]byte(xml.Header), data...)
+	}
+	reqURL := p.endpoint + "/" + route53Version + "/hostedzone/" + p.zoneID + "/" + path
+	req, err := http.NewRequest(method, reqURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/xml")
+	}
+	if err := p.sign(req, p.auth); err != nil {
+		return errors.Annotate(err, "signing request")
+	}
+	httpResp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer httpResp.Body.Close()
+	data, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		var respErr route53Error
+		if xml.Unmarshal(data, &respErr) == nil && respErr.Code != "" {
+			return errors.Errorf("%s: %s", respErr.Code, respErr.Message)
+		}
+		return errors.Errorf("route53 returned %s", httpResp.Status)
+	}
+	if resp == nil {
+		return nil
+	}
+	return errors.Trace(xml.Unmarshal(data, resp))
+}
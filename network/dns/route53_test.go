@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dns_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/dns"
+)
+
+type route53Suite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	requests []string
+	bodies   []string
+	// responses holds the bodies of the responses to GET requests,
+	// in order.
+	responses []string
+}
+
+var _ = gc.Suite(&route53Suite{})
+
+const emptyRecordSets = `<ListResourceRecordSetsResponse><ResourceRecordSets></ResourceRecordSets></ListResourceRecordSetsResponse>`
+
+func (s *route53Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.responses = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Header.Get("Authorization"), jc.HasPrefix, "AWS4-HMAC-SHA256 Credential=access/")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req.Method+" "+req.URL.String())
+		if req.Method == "GET" {
+			resp := emptyRecordSets
+			if len(s.responses) > 0 {
+				resp, s.responses = s.responses[0], s.responses[1:]
+			}
+			w.Write([]byte(resp))
+			return
+		}
+		s.bodies = append(s.bodies, string(body))
+		w.Write([]byte(`<ChangeResourceRecordSetsResponse/>`))
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *route53Suite) newProvider(c *gc.C) dns.Provider {
+	provider, err := dns.NewProvider("route53", "apps.example.com", map[string]string{
+		"hosted-zone-id": "/hostedzone/Z1",
+		"access-key":     "access",
+		"secret-key":     "secret",
+		"endpoint":       s.server.URL,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return provider
+}
+
+func (s *route53Suite) TestSetRecords(c *gc.C) {
+	s.responses = []string{`
+<ListResourceRecordSetsResponse>
+  <ResourceRecordSets>
+    <ResourceRecordSet>
+      <Name>wordpress.apps.example.com.</Name>
+      <Type>AAAA</Type>
+      <TTL>300</TTL>
+      <ResourceRecords><ResourceRecord><Value>2001:db8::1</Value></ResourceRecord></ResourceRecords>
+    </ResourceRecordSet>
+  </ResourceRecordSets>
+</ListResourceRecordSetsResponse>`}
+	err := s.newProvider(c).SetRecords("wordpress.apps.example.com", []string{"10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, jc.DeepEquals, []string{
+		"GET /2013-04-01/hostedzone/Z1/rrset?maxitems=1&name=wordpress.apps.example.com.&type=AAAA",
+		"POST /2013-04-01/hostedzone/Z1/rrset",
+	})
+	c.Assert(s.bodies, gc.HasLen, 1)
+	body := s.bodies[0]
+	c.Assert(body, jc.Contains, `<Change><Action>UPSERT</Action><ResourceRecordSet><Name>wordpress.apps.example.com.</Name><Type>A</Type><TTL>300</TTL><ResourceRecords><ResourceRecord><Value>10.0.0.1</Value></ResourceRecord></ResourceRecords></ResourceRecordSet></Change>`)
+	c.Assert(body, jc.Contains, `<Change><Action>DELETE</Action><ResourceRecordSet><Name>wordpress.apps.example.com.</Name><Type>AAAA</Type><TTL>300</TTL><ResourceRecords><ResourceRecord><Value>2001:db8::1</Value></ResourceRecord></ResourceRecords></ResourceRecordSet></Change>`)
+}
+
+func (s *route53Suite) TestRemoveRecordsNone(c *gc.C) {
+	err := s.newProvider(c).RemoveRecords("wordpress.apps.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+	for _, req := range s.requests {
+		c.Check(strings.HasPrefix(req, "GET "), jc.IsTrue)
+	}
+}
+
+func (s *route53Suite) TestError(c *gc.C) {
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<ErrorResponse><Error><Code>AccessDenied</Code><Message>not authorized</Message></Error></ErrorResponse>`))
+	})
+	err := s.newProvider(c).SetRecords("wordpress.apps.example.com", []string{"10.0.0.1"})
+	c.Assert(err, gc.ErrorMatches, `listing AAAA records of "wordpress.apps.example.com": AccessDenied: not authorized`)
+}
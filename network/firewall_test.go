@@ -33,6 +33,9 @@ func (*FirewallSuite) TestStrings(c *gc.C) {
 	rule = network.MustNewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.1.0/24")
 	c.Assert(rule.String(), gc.Equals, "80-100/tcp from 0.0.0.0/0,192.168.1.0/24")
 	c.Assert(rule.GoString(), gc.Equals, "80-100/tcp from 0.0.0.0/0,192.168.1.0/24")
+
+	rule = network.MustNewIngressRule("icmp", 3, 4, "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "3:4/icmp from 192.168.1.0/24")
 }
 
 func (*FirewallSuite) TestSortIngressRules(c *gc.C) {
@@ -72,3 +75,64 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0")
+	c.Assert(rule.String(), gc.Equals, "53/udp")
+
+	rule = network.MustNewEgressRule("tcp", 5432, 5433, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "5432-5433/tcp to 10.0.0.0/8,192.168.1.0/24")
+	c.Assert(rule.GoString(), gc.Equals, "5432-5433/tcp to 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*FirewallSuite) TestNewEgressRuleBadCIDR(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 80, 100, "192.168.0/24")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
+}
+
+func (*FirewallSuite) TestParseEgressRule(c *gc.C) {
+	for i, t := range []struct {
+		in     string
+		expect network.EgressRule
+		err    string
+	}{{
+		in:     "443",
+		expect: network.MustNewEgressRule("tcp", 443, 443),
+	}, {
+		in:     "53/udp",
+		expect: network.MustNewEgressRule("udp", 53, 53),
+	}, {
+		in:     "5432-5433/tcp@10.0.0.0/8,::/0",
+		expect: network.MustNewEgressRule("tcp", 5432, 5433, "10.0.0.0/8", "::/0"),
+	}, {
+		in:  "http",
+		err: `invalid egress rule "http": .*`,
+	}, {
+		in:  "443/tcp@10.0.0/8",
+		err: `invalid egress rule "443/tcp@10.0.0/8": invalid CIDR address: 10.0.0/8`,
+	}} {
+		c.Logf("test %d: %s", i, t.in)
+		rule, err := network.ParseEgressRule(t.in)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(rule, jc.DeepEquals, t.expect)
+	}
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 53, 53)
+	rule2 := network.MustNewEgressRule("tcp", 443, 443)
+	rule3 := network.MustNewEgressRule("tcp", 80, 80, "10.0.0.0/8")
+	rule4 := network.MustNewEgressRule("tcp", 80, 80)
+
+	rules := []network.EgressRule{rule1, rule2, rule3, rule4}
+	network.SortEgressRules(rules)
+	c.Assert(rules, gc.DeepEquals, []network.EgressRule{rule4, rule3, rule2, rule1})
+}
@@ -13,17 +13,28 @@ import (
 )
 
 // PortRange represents a single range of ports.
+//
+// For the "icmp" protocol, FromPort holds the ICMP type and ToPort the
+// ICMP code, either of which may be -1 to match any type or code. A
+// code may only be given with a type.
 type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
 }
 
+// AnyICMP is the value of an ICMP port range's type or code that
+// matches any type or code.
+const AnyICMP = -1
+
 // IsValid determines if the port range is valid.
 func (p PortRange) Validate() error {
 	proto := strings.ToLower(p.Protocol)
+	if proto == "icmp" {
+		return p.validateICMP()
+	}
 	if proto != "tcp" && proto != "udp" {
-		return errors.Errorf(`invalid protocol %q, expected "tcp" or "udp"`, proto)
+		return errors.Errorf(`invalid protocol %q, expected "tcp", "udp" or "icmp"`, proto)
 	}
 	err := errors.Errorf(
 		"invalid port range %d-%d/%s",
@@ -42,15 +53,45 @@ func (p PortRange) Validate() error {
 	return nil
 }
 
-// ConflictsWith determines if the two port ranges conflict.
+func (p PortRange) validateICMP() error {
+	validType := p.FromPort >= AnyICMP && p.FromPort <= 255
+	validCode := p.ToPort >= AnyICMP && p.ToPort <= 255
+	if !validType || !validCode || (p.FromPort == AnyICMP && p.ToPort != AnyICMP) {
+		return errors.Errorf("invalid ICMP type %d and code %d", p.FromPort, p.ToPort)
+	}
+	return nil
+}
+
+// ConflictsWith determines if the two port ranges conflict. ICMP port
+// ranges conflict if they match the same ICMP type and code.
 func (a PortRange) ConflictsWith(b PortRange) bool {
 	if a.Protocol != b.Protocol {
 		return false
 	}
+	if strings.ToLower(a.Protocol) == "icmp" {
+		return icmpMatch(a.FromPort, b.FromPort) && icmpMatch(a.ToPort, b.ToPort)
+	}
 	return a.ToPort >= b.FromPort && b.ToPort >= a.FromPort
 }
 
+// icmpMatch reports whether two ICMP types, or codes, match.
+func icmpMatch(a, b int) bool {
+	return a == b || a == AnyICMP || b == AnyICMP
+}
+
+// String returns the port range as "<from>-<to>/<protocol>", or as
+// "<port>/<protocol>" if it holds a single port. ICMP port ranges are
+// returned as "icmp", "<type>/icmp" or "<type>:<code>/icmp".
 func (p PortRange) String() string {
+	if strings.ToLower(p.Protocol) == "icmp" {
+		switch {
+		case p.FromPort == AnyICMP:
+			return "icmp"
+		case p.ToPort == AnyICMP:
+			return fmt.Sprintf("%d/icmp", p.FromPort)
+		}
+		return fmt.Sprintf("%d:%d/icmp", p.FromPort, p.ToPort)
+	}
 	if p.FromPort == p.ToPort {
 		return fmt.Sprintf("%d/%s", p.FromPort, strings.ToLower(p.Protocol))
 	}
@@ -135,8 +176,13 @@ func CollapsePorts(ports []Port) (result []PortRange) {
 // string does not include a protocol then "tcp" is used. Validate()
 // gets called on the result before returning. If validation fails the
 // invalid PortRange is still returned.
-// Example strings: "80/tcp", "443", "12345-12349/udp".
+// Example strings: "80/tcp", "443", "12345-12349/udp", "icmp", "8/icmp",
+// "3:4/icmp".
 func ParsePortRange(inPortRange string) (PortRange, error) {
+	if strings.ToLower(inPortRange) == "icmp" {
+		return PortRange{FromPort: AnyICMP, ToPort: AnyICMP, Protocol: "icmp"}, nil
+	}
+
 	// Extract the protocol.
 	protocol := "tcp"
 	parts := strings.SplitN(inPortRange, "/", 2)
@@ -144,6 +190,13 @@ func ParsePortRange(inPortRange string) (PortRange, error) {
 		inPortRange = parts[0]
 		protocol = parts[1]
 	}
+	if strings.ToLower(protocol) == "icmp" {
+		portRange, err := parseICMPTypeCode(inPortRange)
+		if err != nil {
+			return portRange, errors.Trace(err)
+		}
+		return portRange, portRange.Validate()
+	}
 
 	// Parse the ports.
 	portRange, err := parsePortRange(inPortRange)
@@ -165,6 +218,26 @@ func MustParsePortRange(portRange string) PortRange {
 	return portrange
 }
 
+// parseICMPTypeCode parses an ICMP type, optionally followed by a
+// colon and an ICMP code.
+func parseICMPTypeCode(typeCode string) (PortRange, error) {
+	result := PortRange{FromPort: AnyICMP, ToPort: AnyICMP, Protocol: "icmp"}
+	parts := strings.SplitN(typeCode, ":", 2)
+	icmpType, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return result, errors.Annotatef(err, "invalid ICMP type %q", parts[0])
+	}
+	result.FromPort = icmpType
+	if len(parts) == 2 {
+		icmpCode, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return result, errors.Annotatef(err, "invalid ICMP code %q", parts[1])
+		}
+		result.ToPort = icmpCode
+	}
+	return result, nil
+}
+
 func parsePortRange(portRange string) (PortRange, error) {
 	var result PortRange
 	var start, end int
@@ -211,7 +284,9 @@ func CombinePortRanges(ranges ...PortRange) []PortRange {
 			current = &thispr
 			continue
 		}
-		if pr.Protocol == current.Protocol && pr.FromPort == current.ToPort+1 {
+		// ICMP port ranges hold a type and code, not ports,
+		// so they are never combined.
+		if pr.Protocol == current.Protocol && pr.Protocol != "icmp" && pr.FromPort == current.ToPort+1 {
 			current.ToPort = thispr.ToPort
 			continue
 		}
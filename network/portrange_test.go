@@ -63,6 +63,26 @@ func (*PortRangeSuite) TestConflictsWith(c *gc.C) {
 		network.PortRange{100, 200, "TCP"},
 		network.PortRange{120, 140, "TCP"},
 		true,
+	}, {
+		"any ICMP and ICMP type",
+		network.PortRange{-1, -1, "icmp"},
+		network.PortRange{8, -1, "icmp"},
+		true,
+	}, {
+		"ICMP type and ICMP type and code",
+		network.PortRange{3, -1, "icmp"},
+		network.PortRange{3, 4, "icmp"},
+		true,
+	}, {
+		"different ICMP types",
+		network.PortRange{3, -1, "icmp"},
+		network.PortRange{8, -1, "icmp"},
+		false,
+	}, {
+		"different ICMP codes",
+		network.PortRange{3, 1, "icmp"},
+		network.PortRange{3, 4, "icmp"},
+		false,
 	}}
 
 	for i, t := range testCases {
@@ -93,6 +113,21 @@ func (*PortRangeSuite) TestStrings(c *gc.C) {
 		gc.Equals,
 		"80-100/tcp",
 	)
+	c.Assert(
+		network.PortRange{-1, -1, "icmp"}.String(),
+		gc.Equals,
+		"icmp",
+	)
+	c.Assert(
+		network.PortRange{8, -1, "icmp"}.String(),
+		gc.Equals,
+		"8/icmp",
+	)
+	c.Assert(
+		network.PortRange{3, 4, "ICMP"}.String(),
+		gc.Equals,
+		"3:4/icmp",
+	)
 }
 
 func (*PortRangeSuite) TestValidate(c *gc.C) {
@@ -136,10 +171,34 @@ func (*PortRangeSuite) TestValidate(c *gc.C) {
 		"both ports 0",
 		network.PortRange{0, 0, "tcp"},
 		"invalid port range 0-0/tcp",
+	}, {
+		"any ICMP",
+		network.PortRange{-1, -1, "icmp"},
+		"",
+	}, {
+		"ICMP type",
+		network.PortRange{8, -1, "icmp"},
+		"",
+	}, {
+		"ICMP type and code",
+		network.PortRange{3, 4, "ICMP"},
+		"",
+	}, {
+		"ICMP code without type",
+		network.PortRange{-1, 4, "icmp"},
+		"invalid ICMP type -1 and code 4",
+	}, {
+		"ICMP type too large",
+		network.PortRange{256, -1, "icmp"},
+		"invalid ICMP type 256 and code -1",
+	}, {
+		"ICMP code too small",
+		network.PortRange{3, -2, "icmp"},
+		"invalid ICMP type 3 and code -2",
 	}, {
 		"invalid protocol",
 		network.PortRange{80, 80, "some protocol"},
-		`invalid protocol "some protocol", expected "tcp" or "udp"`,
+		`invalid protocol "some protocol", expected "tcp", "udp" or "icmp"`,
 	}}
 
 	for i, t := range testCases {
@@ -262,6 +321,37 @@ func (*PortRangeSuite) TestParsePortRangeNonIntPort(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `invalid port "spam".*`)
 }
 
+func (*PortRangeSuite) TestParsePortRangeICMP(c *gc.C) {
+	for i, t := range []struct {
+		in       string
+		expected network.PortRange
+	}{
+		{"icmp", network.PortRange{-1, -1, "icmp"}},
+		{"ICMP", network.PortRange{-1, -1, "icmp"}},
+		{"8/icmp", network.PortRange{8, -1, "icmp"}},
+		{"3:4/icmp", network.PortRange{3, 4, "icmp"}},
+	} {
+		c.Logf("test %d: %s", i, t.in)
+		portRange, err := network.ParsePortRange(t.in)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(portRange, gc.Equals, t.expected)
+		if err == nil {
+			roundTrip, err := network.ParsePortRange(portRange.String())
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(roundTrip, gc.Equals, portRange)
+		}
+	}
+}
+
+func (*PortRangeSuite) TestParsePortRangeICMPInvalid(c *gc.C) {
+	_, err := network.ParsePortRange("echo/icmp")
+	c.Check(err, gc.ErrorMatches, `invalid ICMP type "echo".*`)
+	_, err = network.ParsePortRange("3:x/icmp")
+	c.Check(err, gc.ErrorMatches, `invalid ICMP code "x".*`)
+	_, err = network.ParsePortRange("300/icmp")
+	c.Check(err, gc.ErrorMatches, `invalid ICMP type 300 and code -1`)
+}
+
 func (*PortRangeSuite) TestMustParsePortRange(c *gc.C) {
 	portRange := network.MustParsePortRange("8000-8099/tcp")
 
@@ -309,6 +399,9 @@ func (*PortRangeSuite) TestCombinePortRanges(c *gc.C) {
 	}, {
 		[]network.PortRange{{80, 82, "tcp"}, {81, 84, "udp"}, {84, 84, "tcp"}, {86, 87, "udp"}, {80, 80, "udp"}},
 		[]network.PortRange{{80, 82, "tcp"}, {84, 84, "tcp"}, {80, 84, "udp"}, {86, 87, "udp"}},
+	}, {
+		[]network.PortRange{{3, 4, "icmp"}, {3, 3, "icmp"}, {8, -1, "icmp"}},
+		[]network.PortRange{{3, 3, "icmp"}, {3, 4, "icmp"}, {8, -1, "icmp"}},
 	}}
 	for i, t := range testCases {
 		c.Logf("test %d", i)
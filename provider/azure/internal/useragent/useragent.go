@@ -6,12 +6,12 @@ package useragent
 import (
 	"github.com/Azure/go-autorest/autorest"
 
-	"github.com/juju/juju/version"
+	"github.com/juju/juju/utils/httptransport"
 )
 
 // JujuPrefix returns the User-Agent prefix set by Juju.
 func JujuPrefix() string {
-	return "Juju/" + version.Current.String()
+	return httptransport.UserAgent()
 }
 
 // UpdateClient updates the UserAgent field of the given autorest.Client.
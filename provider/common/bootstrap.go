@@ -172,18 +172,25 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		return nil
 	}
 	result, err := env.StartInstance(environs.StartInstanceParams{
-		ControllerUUID:  args.ControllerConfig.ControllerUUID(),
-		Constraints:     args.BootstrapConstraints,
-		Tools:           availableTools,
-		InstanceConfig:  instanceConfig,
-		Placement:       args.Placement,
-		ImageMetadata:   imageMetadata,
-		StatusCallback:  instanceStatus,
-		CleanupCallback: statusCleanup,
+		ControllerUUID:   args.ControllerConfig.ControllerUUID(),
+		Constraints:      args.BootstrapConstraints,
+		Tools:            availableTools,
+		InstanceConfig:   instanceConfig,
+		Placement:        args.Placement,
+		ImageMetadata:    imageMetadata,
+		StatusCallback:   instanceStatus,
+		CleanupCallback:  statusCleanup,
+		ResourceRecorder: args.ResourceRecorder,
 	})
 	if err != nil {
 		return nil, "", nil, errors.Annotate(err, "cannot start bootstrap instance")
 	}
+	if args.ResourceRecorder != nil {
+		args.ResourceRecorder.RecordResource(environs.ResourceKindInstance, string(result.Instance.Id()))
+		for _, v := range result.Volumes {
+			args.ResourceRecorder.RecordResource(environs.ResourceKindVolume, v.VolumeId)
+		}
+	}
 
 	msg := fmt.Sprintf(" - %s (%s)", result.Instance.Id(), formatHardware(result.Hardware))
 	// We need some padding below to overwrite any previous messages.
@@ -247,8 +247,10 @@ func (s *BootstrapSuite) TestSuccess(c *gc.C) {
 	}
 	inner := cmdtesting.Context(c)
 	ctx := modelcmd.BootstrapContext(inner)
+	var recorder resourceRecorder
 	result, err := common.Bootstrap(ctx, env, environs.BootstrapParams{
 		ControllerConfig: coretesting.FakeControllerConfig(),
+		ResourceRecorder: &recorder,
 		AvailableTools: tools.List{
 			&tools.Tools{
 				Version: version.Binary{
@@ -262,6 +264,9 @@ func (s *BootstrapSuite) TestSuccess(c *gc.C) {
 	c.Assert(result.Arch, gc.Equals, "ppc64el") // based on hardware characteristics
 	c.Assert(result.Series, gc.Equals, config.PreferredSeries(mocksConfig))
 	c.Assert(result.Finalize, gc.NotNil)
+	c.Assert(recorder, jc.DeepEquals, resourceRecorder{
+		{Kind: environs.ResourceKindInstance, Id: checkInstanceId},
+	})
 
 	// Check that we make the SSH connection with desired options.
 	var knownHosts string
@@ -549,3 +554,9 @@ func (s *FormatHardwareSuite) TestAll(c *gc.C) {
 	}
 	s.check(c, hw, "arch=ppc64 mem=123M cores=2")
 }
+
+type resourceRecorder []environs.BootstrapResource
+
+func (r *resourceRecorder) RecordResource(kind, id string) {
+	*r = append(*r, environs.BootstrapResource{Kind: kind, Id: id})
+}
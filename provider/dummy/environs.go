@@ -55,6 +55,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/faultinject"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/mongotest"
@@ -245,6 +246,7 @@ type environState struct {
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
 	globalRules    network.IngressRuleSlice
+	containerRules map[string]network.IngressRuleSlice
 	bootstrapped   bool
 	apiListener    net.Listener
 	apiServer      *apiserver.Server
@@ -421,6 +423,7 @@ func newState(name string, ops chan<- Operation, newStatePolicy state.NewPolicyF
 		ops:            ops,
 		newStatePolicy: newStatePolicy,
 		insts:          make(map[instance.Id]*dummyInstance),
+		containerRules: make(map[string]network.IngressRuleSlice),
 		creator:        string(buf),
 	}
 	return s
@@ -660,6 +663,9 @@ func (e *environ) checkBroken(method string) error {
 			return fmt.Errorf("dummy.%s is broken", method)
 		}
 	}
+	if err := faultinject.Inject(faultinject.ProviderCall); err != nil {
+		return errors.Annotatef(err, "dummy.%s", method)
+	}
 	return nil
 }
 
@@ -1491,6 +1497,75 @@ func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
 	return
 }
 
+var _ environs.ContainerFirewaller = (*environ)(nil)
+
+// SupportsContainerFirewalls is specified on environs.ContainerFirewaller.
+func (e *environ) SupportsContainerFirewalls() bool {
+	return e.ecfg().FirewallMode() == config.FwInstance
+}
+
+// OpenContainerPorts is specified on environs.ContainerFirewaller.
+func (e *environ) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range rules {
+		if len(r.SourceCIDRs) == 0 {
+			r.SourceCIDRs = []string{"0.0.0.0/0"}
+		}
+		found := false
+		for i, rule := range estate.containerRules[containerId] {
+			if r.PortRange == rule.PortRange {
+				estate.containerRules[containerId][i] = r
+				found = true
+				break
+			}
+		}
+		if !found {
+			estate.containerRules[containerId] = append(estate.containerRules[containerId], r)
+		}
+	}
+	return nil
+}
+
+// CloseContainerPorts is specified on environs.ContainerFirewaller.
+func (e *environ) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range rules {
+		containerRules := estate.containerRules[containerId]
+		for i, rule := range containerRules {
+			if r.PortRange == rule.PortRange {
+				estate.containerRules[containerId] = containerRules[:i+copy(containerRules[i:], containerRules[i+1:])]
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// ContainerIngressRules is specified on environs.ContainerFirewaller.
+func (e *environ) ContainerIngressRules(containerId string) (rules []network.IngressRule, err error) {
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range estate.containerRules[containerId] {
+		rules = append(rules, r)
+	}
+	network.SortIngressRules(rules)
+	return
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.InstanceConsoleLogger = (*environ)(nil)
+
+// InstanceConsoleLog implements environs.InstanceConsoleLogger. EC2
+// always returns the most recent 64KB of output, so the output is
+// trimmed to maxLines here.
+func (e *environ) InstanceConsoleLog(id instance.Id, maxLines int) (string, error) {
+	output, err := getConsoleOutput(e.ec2, id)
+	if err != nil {
+		return "", errors.Annotatef(err, "getting console output of instance %q", id)
+	}
+	return lastLines(output, maxLines), nil
+}
+
+// lastLines returns at most the last n lines of s, or all of s if n
+// is not greater than zero.
+func lastLines(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	trimmed := strings.TrimSuffix(s, "\n")
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[len(lines)-n:], "\n") + s[len(trimmed):]
+}
+
+// getConsoleOutputResp is the response to a GetConsoleOutput request.
+type getConsoleOutputResp struct {
+	RequestId  string `xml:"requestId"`
+	InstanceId string `xml:"instanceId"`
+	Output     string `xml:"output"`
+}
+
+// getConsoleOutput makes an EC2 GetConsoleOutput request for the
+// specified instance, and returns the decoded output.
+var getConsoleOutput = func(ec2inst *ec2.EC2, id instance.Id) (string, error) {
+	var resp getConsoleOutputResp
+	params := map[string]string{
+		"Action":     "GetConsoleOutput",
+		"InstanceId": string(id),
+	}
+	if err := query(ec2inst, params, &resp); err != nil {
+		return "", err
+	}
+	output, err := base64.StdEncoding.DecodeString(resp.Output)
+	if err != nil {
+		return "", errors.Annotate(err, "decoding console output")
+	}
+	return string(output), nil
+}
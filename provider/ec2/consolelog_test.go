@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/ec2"
+)
+
+func (s *querySuite) TestGetConsoleOutput(c *gc.C) {
+	s.response = fmt.Sprintf(`<GetConsoleOutputResponse>
+  <requestId>req-1</requestId>
+  <instanceId>i-123</instanceId>
+  <output>%s</output>
+</GetConsoleOutputResponse>`, base64.StdEncoding.EncodeToString([]byte("cloud-init done\n")))
+
+	output, err := (*ec2.GetConsoleOutput)(s.client(), instance.Id("i-123"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, "cloud-init done\n")
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Get("Action"), gc.Equals, "GetConsoleOutput")
+	c.Assert(s.requests[0].Get("InstanceId"), gc.Equals, "i-123")
+}
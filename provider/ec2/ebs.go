@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -74,6 +75,10 @@ const (
 
 	instanceStateShuttingDown = "shutting-down"
 	instanceStateTerminated   = "terminated"
+
+	volumeModificationStateOptimizing = "optimizing"
+	volumeModificationStateCompleted  = "completed"
+	volumeModificationStateFailed     = "failed"
 )
 
 // Limits for volume parameters. See:
@@ -255,6 +260,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeResizer = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -910,6 +916,91 @@ func (v *ebsVolumeSource) ImportVolume(volumeId string, tags map[string]string)
 	}, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]error, error) {
+	results := make([]error, len(params))
+	for i, p := range params {
+		results[i] = resizeVolume(v.env.ec2, p.VolumeId, mibToGib(p.Size))
+	}
+	return results, nil
+}
+
+var modifyVolumeAttempt = utils.AttemptStrategy{
+	Total: 5 * time.Minute,
+	Delay: 5 * time.Second,
+}
+
+// resizeVolume grows the volume to the specified size in GiB, and waits
+// for the modification to reach the "optimizing" state, at which point
+// the new size is available to the instance the volume is attached to.
+func resizeVolume(client *ec2.EC2, volumeId string, sizeGiB uint64) error {
+	logger.Debugf("resizing %q to %dGiB", volumeId, sizeGiB)
+	if err := modifyVolume(client, volumeId, sizeGiB); err != nil {
+		return errors.Annotatef(err, "modifying volume %q", volumeId)
+	}
+	var lastState string
+	for a := modifyVolumeAttempt.Start(); a.Next(); {
+		state, message, err := volumeModificationState(client, volumeId)
+		if err != nil {
+			return errors.Annotatef(err, "querying modification of volume %q", volumeId)
+		}
+		lastState = state
+		switch state {
+		case volumeModificationStateOptimizing, volumeModificationStateCompleted:
+			return nil
+		case volumeModificationStateFailed:
+			return errors.Errorf("modifying volume %q failed: %s", volumeId, message)
+		}
+	}
+	return errors.Errorf(
+		"timed out waiting for modification of volume %q to complete (%v)",
+		volumeId, lastState,
+	)
+}
+
+// volumeModification describes the most recent modification of a volume.
+type volumeModification struct {
+	VolumeId      string `xml:"volumeId"`
+	State         string `xml:"modificationState"`
+	StatusMessage string `xml:"statusMessage"`
+	TargetSize    int    `xml:"targetSize"`
+}
+
+// modifyVolume makes an EC2 ModifyVolume request to grow the volume to
+// the specified size in GiB. The request is not provided by the amz ec2
+// package, so is made directly.
+var modifyVolume = func(client *ec2.EC2, volumeId string, sizeGiB uint64) error {
+	var resp struct {
+		RequestId    string             `xml:"requestId"`
+		Modification volumeModification `xml:"volumeModification"`
+	}
+	return query(client, map[string]string{
+		"Action":   "ModifyVolume",
+		"VolumeId": volumeId,
+		"Size":     strconv.FormatUint(sizeGiB, 10),
+	}, &resp)
+}
+
+// volumeModificationState makes an EC2 DescribeVolumesModifications
+// request, and returns the state and status message of the most recent
+// modification of the volume.
+var volumeModificationState = func(client *ec2.EC2, volumeId string) (state, message string, _ error) {
+	var resp struct {
+		RequestId     string               `xml:"requestId"`
+		Modifications []volumeModification `xml:"volumeModificationSet>item"`
+	}
+	if err := query(client, map[string]string{
+		"Action":     "DescribeVolumesModifications",
+		"VolumeId.1": volumeId,
+	}, &resp); err != nil {
+		return "", "", err
+	}
+	if len(resp.Modifications) != 1 {
+		return "", "", errors.Errorf("expected 1 volume modification, got %d", len(resp.Modifications))
+	}
+	return resp.Modifications[0].State, resp.Modifications[0].StatusMessage, nil
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
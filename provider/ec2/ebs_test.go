@@ -44,6 +44,7 @@ var _ = gc.Suite(&ebsSuite{})
 func (s *ebsSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.PatchValue(&ec2.DestroyVolumeAttempt.Delay, time.Duration(0))
+	s.PatchValue(&ec2.ModifyVolumeAttempt.Delay, time.Duration(0))
 
 	modelConfig, err := config.New(config.NoDefaults, testing.FakeConfig().Merge(
 		testing.Attrs{"type": "ec2"},
@@ -795,6 +796,74 @@ func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot import volume with status "in-use"`)
 }
 
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeResizer))
+
+	type modification struct {
+		volumeId string
+		size     uint64
+	}
+	var modified []modification
+	s.PatchValue(ec2.ModifyVolume, func(client *awsec2.EC2, volumeId string, size uint64) error {
+		modified = append(modified, modification{volumeId, size})
+		return nil
+	})
+	states := []string{"modifying", "modifying", "optimizing"}
+	s.PatchValue(ec2.VolumeModificationState, func(client *awsec2.EC2, volumeId string) (string, string, error) {
+		state := states[0]
+		states = states[1:]
+		return state, "", nil
+	})
+
+	// The size is rounded up to the nearest GiB.
+	errs, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Provider: ec2.EBS_ProviderType,
+		Size:     2049,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	c.Assert(modified, jc.DeepEquals, []modification{{"vol-0", 3}})
+	c.Assert(states, gc.HasLen, 0)
+}
+
+func (s *ebsSuite) TestResizeVolumesModifyError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.PatchValue(ec2.ModifyVolume, func(client *awsec2.EC2, volumeId string, size uint64) error {
+		return errors.New("volume too large")
+	})
+	errs, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Provider: ec2.EBS_ProviderType,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, `modifying volume "vol-0": volume too large`)
+}
+
+func (s *ebsSuite) TestResizeVolumesModificationFailed(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.PatchValue(ec2.ModifyVolume, func(client *awsec2.EC2, volumeId string, size uint64) error {
+		return nil
+	})
+	s.PatchValue(ec2.VolumeModificationState, func(client *awsec2.EC2, volumeId string) (string, string, error) {
+		return "failed", "insufficient capacity", nil
+	})
+	errs, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Provider: ec2.EBS_ProviderType,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, `modifying volume "vol-0" failed: insufficient capacity`)
+}
+
 type blockDeviceMappingSuite struct {
 	testing.BaseSuite
 }
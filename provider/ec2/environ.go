@@ -55,10 +55,13 @@ var (
 	}
 
 	// aliveInstanceStates are the states which we filter by when listing
-	// instances in an environment.
-	aliveInstanceStates = []string{"pending", "running"}
+	// instances in an environment. Stopped instances are those of
+	// suspended models, and are still alive.
+	aliveInstanceStates = []string{"pending", "running", "stopping", "stopped"}
 )
 
+var _ environs.InstanceTagger = (*environ)(nil)
+
 type environ struct {
 	name  string
 	cloud environs.CloudSpec
@@ -177,6 +180,24 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 	return validator, nil
 }
 
+// controllerInstanceTypes maps the controller sizing presets to the
+// instance types used for them.
+var controllerInstanceTypes = map[string]string{
+	"small":  "m4.large",
+	"medium": "m4.xlarge",
+	"large":  "m4.2xlarge",
+}
+
+// ControllerSizeConstraints is defined on the environs.ControllerSizer
+// interface.
+func (e *environ) ControllerSizeConstraints(size string) (constraints.Value, error) {
+	instanceType, ok := controllerInstanceTypes[size]
+	if !ok {
+		return constraints.Value{}, errors.NotSupportedf("controller size %q", size)
+	}
+	return constraints.Value{InstanceType: &instanceType}, nil
+}
+
 func archMatches(arches []string, arch *string) bool {
 	if arch == nil {
 		return true
@@ -490,14 +511,14 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		return nil, errors.Annotate(err, "cannot make user data")
 	}
 	logger.Debugf("ec2 user data; %d bytes", len(userData))
-	var apiPort int
+	var apiPorts []int
 	if args.InstanceConfig.Controller != nil {
-		apiPort = args.InstanceConfig.Controller.Config.APIPort()
+		apiPorts = args.InstanceConfig.Controller.Config.APIPorts()
 	} else {
-		apiPort = args.InstanceConfig.APIInfo.Ports()[0]
+		apiPorts = []int{args.InstanceConfig.APIInfo.Ports()[0]}
 	}
 	callback(status.Allocating, "Setting up groups", nil)
-	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPorts)
 
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot set up groups")
@@ -735,6 +756,61 @@ func tagResources(e *ec2.EC2, tags map[string]string, resourceIds ...string) err
 	return err
 }
 
+// TagInstance implements environs.InstanceTagger.
+func (e *environ) TagInstance(id instance.Id, tags map[string]string) error {
+	if err := tagResources(e.ec2, tags, string(id)); err != nil {
+		return errors.Annotate(err, "tagging instance")
+	}
+	return nil
+}
+
+var _ environs.InstanceTagRemover = (*environ)(nil)
+
+// InstanceTags implements environs.InstanceTagRemover.
+func (e *environ) InstanceTags(id instance.Id) (map[string]string, error) {
+	resp, err := e.ec2.Instances([]string{string(id)}, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting instance %q", id)
+	}
+	for _, res := range resp.Reservations {
+		for _, inst := range res.Instances {
+			tags := make(map[string]string)
+			for _, tag := range inst.Tags {
+				tags[tag.Key] = tag.Value
+			}
+			return tags, nil
+		}
+	}
+	return nil, errors.NotFoundf("instance %q", id)
+}
+
+// UntagInstance implements environs.InstanceTagRemover.
+func (e *environ) UntagInstance(id instance.Id, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if err := deleteTags(e.ec2, []string{string(id)}, names); err != nil {
+		return errors.Annotate(err, "untagging instance")
+	}
+	return nil
+}
+
+// deleteTags makes an EC2 DeleteTags request, removing the tags with
+// the given names from the specified resources.
+var deleteTags = func(client *ec2.EC2, resourceIds, names []string) error {
+	params := map[string]string{"Action": "DeleteTags"}
+	for i, id := range resourceIds {
+		params[fmt.Sprintf("ResourceId.%d", i+1)] = id
+	}
+	for i, name := range names {
+		params[fmt.Sprintf("Tag.%d.Key", i+1)] = name
+	}
+	var resp struct {
+		RequestId string `xml:"requestId"`
+	}
+	return query(client, params, &resp)
+}
+
 func tagRootDisk(e *ec2.EC2, tags map[string]string, inst *ec2.Instance) error {
 	if len(tags) == 0 {
 		return nil
@@ -1115,7 +1191,7 @@ func (e *environ) AdoptResources(controllerUUID string, fromVersion version.Numb
 
 // AllInstances is part of the environs.InstanceBroker interface.
 func (e *environ) AllInstances() ([]instance.Instance, error) {
-	return e.AllInstancesByState("pending", "running")
+	return e.AllInstancesByState(aliveInstanceStates...)
 }
 
 // AllInstancesByState returns all instances in the environment
@@ -1540,6 +1616,58 @@ var terminateInstancesById = func(ec2inst *ec2.EC2, ids ...instance.Id) (*ec2.Te
 	return ec2inst.TerminateInstances(strs)
 }
 
+var _ environs.InstanceSuspender = (*environ)(nil)
+
+// SuspendInstances implements environs.InstanceSuspender. The
+// instances are stopped, keeping their EBS volumes.
+func (e *environ) SuspendInstances(ids ...instance.Id) error {
+	return errors.Annotate(e.changeInstanceStates(ids, stopInstancesById), "stopping instances")
+}
+
+// ResumeInstances implements environs.InstanceSuspender.
+func (e *environ) ResumeInstances(ids ...instance.Id) error {
+	return errors.Annotate(e.changeInstanceStates(ids, startInstancesById), "starting instances")
+}
+
+// changeInstanceStates calls change with the IDs of those of the
+// specified instances that still exist.
+func (e *environ) changeInstanceStates(ids []instance.Id, change func(*ec2.EC2, ...instance.Id) error) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	insts, err := e.Instances(ids)
+	if err == environs.ErrNoInstances {
+		return nil
+	} else if err != nil && err != environs.ErrPartialInstances {
+		return errors.Trace(err)
+	}
+	var found []instance.Id
+	for _, inst := range insts {
+		if inst != nil {
+			found = append(found, inst.Id())
+		}
+	}
+	return change(e.ec2, found...)
+}
+
+var stopInstancesById = func(ec2inst *ec2.EC2, ids ...instance.Id) error {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = string(id)
+	}
+	_, err := ec2inst.StopInstances(strs...)
+	return err
+}
+
+var startInstancesById = func(ec2inst *ec2.EC2, ids ...instance.Id) error {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = string(id)
+	}
+	_, err := ec2inst.StartInstances(strs...)
+	return err
+}
+
 func (e *environ) deleteSecurityGroupsForInstances(ids []instance.Id) {
 	if len(ids) == 0 {
 		logger.Debugf("no need to delete security groups: no intances were terminated successfully")
@@ -1641,34 +1769,10 @@ func (e *environ) jujuGroupName() string {
 // other instances that might be running on the same EC2 account.  In
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]ec2.SecurityGroup, error) {
+func (e *environ) setUpGroups(controllerUUID, machineId string, apiPorts []int) ([]ec2.SecurityGroup, error) {
 
 	// Ensure there's a global group for Juju-related traffic.
-	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
-		[]ec2.IPPerm{{
-			Protocol:  "tcp",
-			FromPort:  22,
-			ToPort:    22,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
-			Protocol:  "tcp",
-			FromPort:  apiPort,
-			ToPort:    apiPort,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
-			Protocol: "tcp",
-			FromPort: 0,
-			ToPort:   65535,
-		}, {
-			Protocol: "udp",
-			FromPort: 0,
-			ToPort:   65535,
-		}, {
-			Protocol: "icmp",
-			FromPort: -1,
-			ToPort:   -1,
-		}},
-	)
+	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(), jujuGroupPerms(apiPorts))
 	if err != nil {
 		return nil, err
 	}
@@ -1686,6 +1790,49 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 	return []ec2.SecurityGroup{jujuGroup, machineGroup}, nil
 }
 
+// jujuGroupPerms returns the permissions of the global juju group,
+// which allows SSH and API connections from anywhere, and all traffic
+// between the group's instances.
+func jujuGroupPerms(apiPorts []int) []ec2.IPPerm {
+	perms := []ec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  22,
+		ToPort:    22,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}}
+	for _, port := range apiPorts {
+		perms = append(perms, ec2.IPPerm{
+			Protocol:  "tcp",
+			FromPort:  port,
+			ToPort:    port,
+			SourceIPs: []string{"0.0.0.0/0"},
+		})
+	}
+	return append(perms, ec2.IPPerm{
+		Protocol: "tcp",
+		FromPort: 0,
+		ToPort:   65535,
+	}, ec2.IPPerm{
+		Protocol: "udp",
+		FromPort: 0,
+		ToPort:   65535,
+	}, ec2.IPPerm{
+		Protocol: "icmp",
+		FromPort: -1,
+		ToPort:   -1,
+	})
+}
+
+var _ environs.ControllerFirewaller = (*environ)(nil)
+
+// SetControllerAPIPorts is specified on environs.ControllerFirewaller.
+// The API ports are allowed by the juju group of the controller model,
+// which all of the controller instances belong to.
+func (e *environ) SetControllerAPIPorts(controllerUUID string, ports []int) error {
+	_, err := e.ensureGroup(controllerUUID, e.jujuGroupName(), jujuGroupPerms(ports))
+	return errors.Annotate(err, "updating controller api ports")
+}
+
 // zeroGroup holds the zero security group.
 var zeroGroup ec2.SecurityGroup
 
@@ -23,12 +23,28 @@ var (
 	_ config.ConfigSchemaSource  = (*environProvider)(nil)
 	_ simplestreams.HasRegion    = (*environ)(nil)
 	_ instance.Distributor       = (*environ)(nil)
+	_ environs.ControllerSizer   = (*environ)(nil)
 )
 
 type Suite struct{}
 
 var _ = gc.Suite(&Suite{})
 
+func (*Suite) TestControllerSizeConstraints(c *gc.C) {
+	env := &environ{}
+	for size, instanceType := range map[string]string{
+		"small":  "m4.large",
+		"medium": "m4.xlarge",
+		"large":  "m4.2xlarge",
+	} {
+		cons, err := env.ControllerSizeConstraints(size)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(cons, jc.DeepEquals, constraints.Value{InstanceType: &instanceType})
+	}
+	_, err := env.ControllerSizeConstraints("huge")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 type RootDiskTest struct {
 	series     string
 	name       string
@@ -80,8 +80,15 @@ func UseTestImageData(c *gc.C, files map[string]string) {
 var (
 	ShortAttempt                   = &shortAttempt
 	DestroyVolumeAttempt           = &destroyVolumeAttempt
+	ModifyVolumeAttempt            = &modifyVolumeAttempt
 	DeleteSecurityGroupInsistently = &deleteSecurityGroupInsistently
 	TerminateInstancesById         = &terminateInstancesById
+	StopInstancesById              = &stopInstancesById
+	StartInstancesById             = &startInstancesById
+	GetConsoleOutput               = &getConsoleOutput
+	ModifyVolume                   = &modifyVolume
+	VolumeModificationState        = &volumeModificationState
+	DeleteTags                     = &deleteTags
 )
 
 // FabricateInstance creates a new fictitious instance
@@ -568,6 +568,100 @@ func (t *localServerSuite) TestGetTerminatedInstances(c *gc.C) {
 	c.Assert(terminated[0].Id(), jc.DeepEquals, inst1.Id())
 }
 
+func (t *localServerSuite) TestInstanceSuspender(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst1, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+
+	var stopped, started []instance.Id
+	t.BaseSuite.PatchValue(ec2.StopInstancesById, func(ec2inst *amzec2.EC2, ids ...instance.Id) error {
+		stopped = append(stopped, ids...)
+		return nil
+	})
+	t.BaseSuite.PatchValue(ec2.StartInstancesById, func(ec2inst *amzec2.EC2, ids ...instance.Id) error {
+		started = append(started, ids...)
+		return nil
+	})
+	suspender := env.(environs.InstanceSuspender)
+
+	// Unknown instances are ignored.
+	err := suspender.SuspendInstances(inst1.Id(), "i-am-not-found")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stopped, jc.DeepEquals, []instance.Id{inst1.Id()})
+
+	err = suspender.ResumeInstances(inst1.Id(), "i-am-not-found")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started, jc.DeepEquals, []instance.Id{inst1.Id()})
+}
+
+func (t *localServerSuite) TestInstanceSuspenderError(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst1, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	t.BaseSuite.PatchValue(ec2.StopInstancesById, func(ec2inst *amzec2.EC2, ids ...instance.Id) error {
+		return errors.New("stop instances error")
+	})
+	err := env.(environs.InstanceSuspender).SuspendInstances(inst1.Id())
+	c.Assert(err, gc.ErrorMatches, "stopping instances: stop instances error")
+}
+
+func (t *localServerSuite) TestInstanceConsoleLog(c *gc.C) {
+	env := t.Prepare(c)
+	var requested instance.Id
+	t.BaseSuite.PatchValue(ec2.GetConsoleOutput, func(ec2inst *amzec2.EC2, id instance.Id) (string, error) {
+		requested = id
+		return "one\ntwo\nthree\n", nil
+	})
+	logger := env.(environs.InstanceConsoleLogger)
+
+	output, err := logger.InstanceConsoleLog("i-123", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, "one\ntwo\nthree\n")
+	c.Assert(requested, gc.Equals, instance.Id("i-123"))
+
+	output, err = logger.InstanceConsoleLog("i-123", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, "two\nthree\n")
+}
+
+func (t *localServerSuite) TestInstanceConsoleLogError(c *gc.C) {
+	env := t.Prepare(c)
+	t.BaseSuite.PatchValue(ec2.GetConsoleOutput, func(ec2inst *amzec2.EC2, id instance.Id) (string, error) {
+		return "", errors.New("boom")
+	})
+	_, err := env.(environs.InstanceConsoleLogger).InstanceConsoleLog("i-123", 0)
+	c.Assert(err, gc.ErrorMatches, `getting console output of instance "i-123": boom`)
+}
+
+func (t *localServerSuite) TestSetControllerAPIPorts(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	apiPort := coretesting.FakeControllerConfig().APIPort()
+	jujuGroupPerms := func() []amzec2.IPPerm {
+		resp, err := ec2.EnvironEC2(env).SecurityGroups(
+			[]amzec2.SecurityGroup{{Name: ec2.JujuGroupName(env)}}, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(resp.Groups, gc.HasLen, 1)
+		return resp.Groups[0].IPPerms
+	}
+	firewaller := env.(environs.ControllerFirewaller)
+
+	// Move the API port, keeping the old one open.
+	err := firewaller.SetControllerAPIPorts(t.ControllerUUID, []int{443, apiPort})
+	c.Assert(err, jc.ErrorIsNil)
+	perms := jujuGroupPerms()
+	c.Assert(perms, gc.HasLen, 6)
+	checkPortAllowed(c, perms, 443)
+	checkPortAllowed(c, perms, apiPort)
+
+	// Close the old port.
+	err = firewaller.SetControllerAPIPorts(t.ControllerUUID, []int{443})
+	c.Assert(err, jc.ErrorIsNil)
+	perms = jujuGroupPerms()
+	c.Assert(perms, gc.HasLen, 5)
+	checkPortAllowed(c, perms, 443)
+	for _, perm := range perms {
+		c.Check(perm.FromPort, gc.Not(gc.Equals), apiPort)
+	}
+}
+
 func (t *localServerSuite) TestInstanceSecurityGroupsWitheInstanceStatusFilter(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 
@@ -1647,6 +1741,58 @@ func (t *localServerSuite) TestInstanceTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestTagInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	instances, err := env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+
+	tagger := env.(environs.InstanceTagger)
+	err = tagger.TagInstance(instances[0].Id(), map[string]string{
+		"owner":           "bob",
+		"juju-model-uuid": coretesting.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	instances, err = env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	ec2Inst := ec2.InstanceEC2(instances[0])
+	c.Assert(ec2Inst.Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"juju-is-controller", "true"},
+		{"owner", "bob"},
+	})
+
+	tags, err := env.(environs.InstanceTagRemover).InstanceTags(instances[0].Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags["owner"], gc.Equals, "bob")
+}
+
+func (t *localServerSuite) TestUntagInstance(c *gc.C) {
+	env := t.Prepare(c)
+	var resourceIds, names []string
+	t.BaseSuite.PatchValue(ec2.DeleteTags, func(client *amzec2.EC2, ids, keys []string) error {
+		resourceIds, names = ids, keys
+		return nil
+	})
+	err := env.(environs.InstanceTagRemover).UntagInstance("i-123", []string{"owner"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resourceIds, jc.DeepEquals, []string{"i-123"})
+	c.Assert(names, jc.DeepEquals, []string{"owner"})
+}
+
+func (t *localServerSuite) TestUntagInstanceError(c *gc.C) {
+	env := t.Prepare(c)
+	t.BaseSuite.PatchValue(ec2.DeleteTags, func(*amzec2.EC2, []string, []string) error {
+		return errors.New("boom")
+	})
+	err := env.(environs.InstanceTagRemover).UntagInstance("i-123", []string{"owner"})
+	c.Assert(err, gc.ErrorMatches, "untagging instance: boom")
+}
+
 func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 
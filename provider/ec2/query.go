@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// queryAPIVersion is the version of the EC2 query API used for
+// requests that are not provided by the amz ec2 package.
+const queryAPIVersion = "2016-11-15"
+
+// ec2ErrorResp is the response to a failed EC2 request.
+type ec2ErrorResp struct {
+	RequestId string `xml:"RequestID"`
+	Errors    []struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Errors>Error"`
+}
+
+// query makes an EC2 query API request that is not provided by the amz
+// ec2 package, using the client's endpoint, credentials and signer, and
+// decodes the response into resp. Failed requests are reported as
+// *ec2.Error, as they are by the amz ec2 package.
+func query(client *ec2.EC2, params map[string]string, resp interface{}) error {
+	req, err := http.NewRequest("GET", client.Region.EC2Endpoint, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	values := req.URL.Query()
+	for name, value := range params {
+		values.Add(name, value)
+	}
+	values.Add("Version", queryAPIVersion)
+	values.Add("Timestamp", time.Now().UTC().Format(time.RFC3339))
+	req.URL.RawQuery = values.Encode()
+	if err := client.Sign(req, client.Auth); err != nil {
+		return errors.Trace(err)
+	}
+
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		var errResp ec2ErrorResp
+		ec2Err := &ec2.Error{StatusCode: r.StatusCode}
+		if err := xml.NewDecoder(r.Body).Decode(&errResp); err == nil {
+			ec2Err.RequestId = errResp.RequestId
+			if len(errResp.Errors) > 0 {
+				ec2Err.Code = errResp.Errors[0].Code
+				ec2Err.Message = errResp.Errors[0].Message
+			}
+		}
+		if ec2Err.Message == "" {
+			ec2Err.Message = r.Status
+		}
+		return ec2Err
+	}
+	if err := xml.NewDecoder(r.Body).Decode(resp); err != nil {
+		return errors.Annotate(err, "decoding response")
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	awsec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/ec2"
+	"github.com/juju/juju/testing"
+)
+
+// querySuite tests the EC2 requests that are not provided by the amz
+// ec2 package, and so are made directly by the provider.
+type querySuite struct {
+	testing.BaseSuite
+	requests []url.Values
+	status   int
+	response string
+}
+
+var _ = gc.Suite(&querySuite{})
+
+func (s *querySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.requests = nil
+	s.status = http.StatusOK
+	s.response = ""
+}
+
+// client returns an EC2 client for a server that records the query
+// parameters of each request, and responds with s.status and
+// s.response.
+func (s *querySuite) client() *awsec2.EC2 {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req.URL.Query())
+		w.WriteHeader(s.status)
+		fmt.Fprint(w, s.response)
+	}))
+	s.AddCleanup(func(*gc.C) { srv.Close() })
+	region := aws.Region{Name: "test", EC2Endpoint: srv.URL}
+	return awsec2.New(aws.Auth{}, region, aws.SignV4Factory(region.Name, "ec2"))
+}
+
+func (s *querySuite) TestQueryError(c *gc.C) {
+	s.status = http.StatusBadRequest
+	s.response = `<Response>
+  <Errors><Error><Code>InvalidVolume.NotFound</Code><Message>not found</Message></Error></Errors>
+  <RequestID>req-1</RequestID>
+</Response>`
+	err := (*ec2.ModifyVolume)(s.client(), "vol-0", 3)
+	ec2Err, ok := err.(*awsec2.Error)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ec2Err.StatusCode, gc.Equals, http.StatusBadRequest)
+	c.Assert(ec2Err.Code, gc.Equals, "InvalidVolume.NotFound")
+	c.Assert(ec2Err.Message, gc.Equals, "not found")
+	c.Assert(ec2Err.RequestId, gc.Equals, "req-1")
+}
+
+func (s *querySuite) TestModifyVolume(c *gc.C) {
+	s.response = `<ModifyVolumeResponse>
+  <requestId>req-1</requestId>
+  <volumeModification>
+    <volumeId>vol-0</volumeId>
+    <modificationState>modifying</modificationState>
+    <targetSize>3</targetSize>
+  </volumeModification>
+</ModifyVolumeResponse>`
+	err := (*ec2.ModifyVolume)(s.client(), "vol-0", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Get("Action"), gc.Equals, "ModifyVolume")
+	c.Assert(s.requests[0].Get("VolumeId"), gc.Equals, "vol-0")
+	c.Assert(s.requests[0].Get("Size"), gc.Equals, "3")
+}
+
+func (s *querySuite) TestVolumeModificationState(c *gc.C) {
+	s.response = `<DescribeVolumesModificationsResponse>
+  <requestId>req-1</requestId>
+  <volumeModificationSet>
+    <item>
+      <volumeId>vol-0</volumeId>
+      <modificationState>failed</modificationState>
+      <statusMessage>insufficient capacity</statusMessage>
+      <targetSize>3</targetSize>
+    </item>
+  </volumeModificationSet>
+</DescribeVolumesModificationsResponse>`
+	state, message, err := (*ec2.VolumeModificationState)(s.client(), "vol-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, gc.Equals, "failed")
+	c.Assert(message, gc.Equals, "insufficient capacity")
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Get("Action"), gc.Equals, "DescribeVolumesModifications")
+	c.Assert(s.requests[0].Get("VolumeId.1"), gc.Equals, "vol-0")
+}
+
+func (s *querySuite) TestDeleteTags(c *gc.C) {
+	s.response = `<DeleteTagsResponse>
+  <requestId>req-1</requestId>
+  <return>true</return>
+</DeleteTagsResponse>`
+	err := (*ec2.DeleteTags)(s.client(), []string{"i-0"}, []string{"owner", "tier"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Get("Action"), gc.Equals, "DeleteTags")
+	c.Assert(s.requests[0].Get("ResourceId.1"), gc.Equals, "i-0")
+	c.Assert(s.requests[0].Get("Tag.1.Key"), gc.Equals, "owner")
+	c.Assert(s.requests[0].Get("Tag.2.Key"), gc.Equals, "tier")
+}
+
+func (s *querySuite) TestVolumeModificationStateNotFound(c *gc.C) {
+	s.response = `<DescribeVolumesModificationsResponse>
+  <requestId>req-1</requestId>
+  <volumeModificationSet/>
+</DescribeVolumesModificationsResponse>`
+	_, _, err := (*ec2.VolumeModificationState)(s.client(), "vol-0")
+	c.Assert(err, gc.ErrorMatches, "expected 1 volume modification, got 0")
+}
@@ -4,10 +4,15 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
+	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 	goauth2 "golang.org/x/oauth2/google"
 	"google.golang.org/api/compute/v1"
+
+	"github.com/juju/juju/utils/httptransport"
 )
 
 var (
@@ -33,7 +38,12 @@ func newConnection(creds *Credentials) (*compute.Service, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	client := cfg.Client(oauth2.NoContext)
+	// The OAuth-wrapping transport sends the requests with the
+	// client in the context, so that they are instrumented.
+	ctx := context.WithValue(oauth2.NoContext, oauth2.HTTPClient, &http.Client{
+		Transport: &httptransport.Transport{},
+	})
+	client := cfg.Client(ctx)
 	service, err := compute.New(client)
 	return service, errors.Trace(err)
 }
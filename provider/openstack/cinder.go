@@ -5,6 +5,7 @@ package openstack
 
 import (
 	"math"
+	"net/http"
 	"net/url"
 	"sync"
 	"time"
@@ -12,6 +13,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/goose.v2/cinder"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
 	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/nova"
 
@@ -29,6 +32,7 @@ const (
 	volumeStatusAvailable = "available"
 	volumeStatusDeleting  = "deleting"
 	volumeStatusError     = "error"
+	volumeStatusExtending = "extending"
 	volumeStatusInUse     = "in-use"
 )
 
@@ -61,6 +65,7 @@ func (env *Environ) cinderProvider() (*cinderProvider, error) {
 		envName:        env.name,
 		modelUUID:      env.uuid,
 		namespace:      env.namespace,
+		retry:          env.retryStrategy(),
 	}, nil
 }
 
@@ -84,6 +89,7 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 	return &openstackStorageAdapter{
 		cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)},
 		novaClient{env.novaUnlocked},
+		client,
 	}, nil
 }
 
@@ -92,6 +98,7 @@ type cinderProvider struct {
 	envName        string
 	modelUUID      string
 	namespace      instance.Namespace
+	retry          retryStrategy
 }
 
 var _ storage.Provider = (*cinderProvider)(nil)
@@ -111,6 +118,7 @@ func (p *cinderProvider) VolumeSource(providerConfig *storage.Config) (storage.V
 		envName:        p.envName,
 		modelUUID:      p.modelUUID,
 		namespace:      p.namespace,
+		retry:          p.retry,
 	}
 	return source, nil
 }
@@ -161,9 +169,12 @@ type cinderVolumeSource struct {
 	envName        string // non unique, informational only
 	modelUUID      string
 	namespace      instance.Namespace
+	retry          retryStrategy
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeResizer = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -184,14 +195,18 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 	if len(arg.ResourceTags) > 0 {
 		metadata = arg.ResourceTags
 	}
-	cinderVolume, err := s.storageAdapter.CreateVolume(cinder.CreateVolumeVolumeParams{
-		// The Cinder documentation incorrectly states the
-		// size parameter is in GB. It is actually GiB.
-		Size: int(math.Ceil(float64(arg.Size / 1024))),
-		Name: resourceName(s.namespace, s.envName, arg.Tag.String()),
-		// TODO(axw) use the AZ of the initially attached machine.
-		AvailabilityZone: "",
-		Metadata:         metadata,
+	var cinderVolume *cinder.Volume
+	err := s.retry.call("creating volume", func() (err error) {
+		cinderVolume, err = s.storageAdapter.CreateVolume(cinder.CreateVolumeVolumeParams{
+			// The Cinder documentation incorrectly states the
+			// size parameter is in GB. It is actually GiB.
+			Size: int(math.Ceil(float64(arg.Size / 1024))),
+			Name: resourceName(s.namespace, s.envName, arg.Tag.String()),
+			// TODO(axw) use the AZ of the initially attached machine.
+			AvailabilityZone: "",
+			Metadata:         metadata,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -287,7 +302,9 @@ func (s *cinderVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.Desc
 
 // DestroyVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
-	return foreachVolume(s.storageAdapter, volumeIds, destroyVolume), nil
+	return foreachVolume(s.storageAdapter, volumeIds, func(storageAdapter OpenstackStorage, volumeId string) error {
+		return destroyVolume(storageAdapter, volumeId, s.retry)
+	}), nil
 }
 
 // ReleaseVolumes implements storage.VolumeSource.
@@ -295,6 +312,37 @@ func (s *cinderVolumeSource) ReleaseVolumes(volumeIds []string) ([]error, error)
 	return foreachVolume(s.storageAdapter, volumeIds, releaseVolume), nil
 }
 
+// ResizeVolumes implements storage.VolumeResizer.
+func (s *cinderVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		results[i] = s.resizeVolume(arg)
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	// Cinder sizes are in GiB; round up so the volume is
+	// at least as large as requested.
+	size := int((arg.Size + 1023) / 1024)
+	if err := s.storageAdapter.ExtendVolume(arg.VolumeId, size); err != nil {
+		return errors.Annotatef(err, "extending volume %q", arg.VolumeId)
+	}
+	_, err := waitVolume(s.storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusExtending:
+			return false, nil
+		case volumeStatusError:
+			return false, errors.New("volume entered error state")
+		}
+		return v.Size >= size, nil
+	})
+	if err != nil {
+		return errors.Annotatef(err, "waiting for volume %q to be extended", arg.VolumeId)
+	}
+	return nil
+}
+
 func foreachVolume(storageAdapter OpenstackStorage, volumeIds []string, f func(OpenstackStorage, string) error) []error {
 	var wg sync.WaitGroup
 	wg.Add(len(volumeIds))
@@ -309,7 +357,10 @@ func foreachVolume(storageAdapter OpenstackStorage, volumeIds []string, f func(O
 	return results
 }
 
-func destroyVolume(storageAdapter OpenstackStorage, volumeId string) error {
+// destroyVolume waits for the volume to be detached, detaching it if
+// necessary, and then deletes it, retrying the deletion according to
+// the given strategy.
+func destroyVolume(storageAdapter OpenstackStorage, volumeId string, strategy retryStrategy) error {
 	logger.Debugf("destroying volume %q", volumeId)
 	// Volumes must not be in-use when destroying. A volume may
 	// still be in-use when the instance it is attached to is
@@ -355,7 +406,9 @@ func destroyVolume(storageAdapter OpenstackStorage, volumeId string) error {
 		// Already being deleted, nothing to do.
 		return nil
 	}
-	if err := storageAdapter.DeleteVolume(volumeId); err != nil {
+	if err := strategy.call("deleting volume "+volumeId, func() error {
+		return storageAdapter.DeleteVolume(volumeId)
+	}); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
@@ -420,11 +473,14 @@ func (s *cinderVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*
 		}); err != nil {
 			return nil, errors.Annotate(err, "waiting for volume to become available")
 		}
-		novaAttachment, err = s.storageAdapter.AttachVolume(
-			string(arg.InstanceId),
-			arg.VolumeId,
-			autoAssignedMountPoint,
-		)
+		err = s.retry.call("attaching volume "+arg.VolumeId, func() (err error) {
+			novaAttachment, err = s.storageAdapter.AttachVolume(
+				string(arg.InstanceId),
+				arg.VolumeId,
+				autoAssignedMountPoint,
+			)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -458,6 +514,64 @@ func (s *cinderVolumeSource) ImportVolume(volumeId string, resourceTags map[stri
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// SnapshotVolume is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) SnapshotVolume(volumeId string, resourceTags map[string]string) (storage.SnapshotInfo, error) {
+	snapshot, err := s.storageAdapter.CreateSnapshot(volumeId, resourceTags)
+	if err != nil {
+		return storage.SnapshotInfo{}, errors.Annotatef(err, "creating snapshot of volume %q", volumeId)
+	}
+	for a := cinderAttempt.Start(); snapshot.Status != volumeStatusAvailable; {
+		if snapshot.Status == volumeStatusError {
+			return storage.SnapshotInfo{}, errors.Errorf("snapshot %q entered error state", snapshot.ID)
+		}
+		if !a.Next() {
+			return storage.SnapshotInfo{}, errors.Errorf("timed out waiting for snapshot %q", snapshot.ID)
+		}
+		if snapshot, err = s.storageAdapter.GetSnapshot(snapshot.ID); err != nil {
+			return storage.SnapshotInfo{}, errors.Annotate(err, "getting snapshot")
+		}
+	}
+	return storage.SnapshotInfo{
+		SnapshotId: snapshot.ID,
+		Size:       uint64(snapshot.Size * 1024),
+	}, nil
+}
+
+// RestoreVolume is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) RestoreVolume(snapshotId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	snapshot, err := s.storageAdapter.GetSnapshot(snapshotId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "getting snapshot")
+	}
+	var metadata interface{}
+	if len(resourceTags) > 0 {
+		metadata = resourceTags
+	}
+	cinderVolume, err := s.storageAdapter.CreateVolume(cinder.CreateVolumeVolumeParams{
+		Size:       snapshot.Size,
+		SnapshotId: snapshotId,
+		Name:       resourceName(s.namespace, s.envName, "snapshot-"+snapshotId),
+		Metadata:   metadata,
+	})
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "creating volume from snapshot %q", snapshotId)
+	}
+	volumeId := cinderVolume.ID
+	cinderVolume, err = waitVolume(s.storageAdapter, volumeId, func(v *cinder.Volume) (bool, error) {
+		if v.Status == volumeStatusError {
+			return false, errors.New("volume entered error state")
+		}
+		return v.Status == volumeStatusAvailable, nil
+	})
+	if err != nil {
+		if err := s.storageAdapter.DeleteVolume(volumeId); err != nil {
+			logger.Warningf("destroying volume %s: %s", volumeId, err)
+		}
+		return storage.VolumeInfo{}, errors.Annotatef(err, "waiting for volume %q to be restored", volumeId)
+	}
+	return cinderToJujuVolumeInfo(cinderVolume), nil
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
@@ -554,6 +668,18 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	ExtendVolume(volumeId string, size int) error
+	CreateSnapshot(volumeId string, metadata map[string]string) (*VolumeSnapshot, error)
+	GetSnapshot(snapshotId string) (*VolumeSnapshot, error)
+}
+
+// VolumeSnapshot holds the details of a Cinder volume snapshot that
+// Juju uses.
+type VolumeSnapshot struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// Size is the size of the snapshot, in GiB.
+	Size int `json:"size"`
 }
 
 type endpointResolver interface {
@@ -584,6 +710,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	client client.Client
 }
 
 type cinderClient struct {
@@ -625,3 +752,62 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error) {
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
+
+// ExtendVolume is part of the OpenstackStorage interface. The volume
+// actions are not exposed by the goose cinder client, so the request
+// is made directly. Extending volumes that are in use requires
+// microversion 3.42 of the block storage API; older endpoints can
+// only extend available volumes.
+func (ga *openstackStorageAdapter) ExtendVolume(volumeId string, size int) error {
+	requestData := goosehttp.RequestData{
+		ReqHeaders: http.Header{
+			"OpenStack-API-Version": []string{"volume 3.42"},
+		},
+		ReqValue: map[string]interface{}{
+			"os-extend": map[string]int{"new_size": size},
+		},
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	url := "volumes/" + volumeId + "/action"
+	return ga.client.SendRequest(client.POST, "volumev2", "v2", url, &requestData)
+}
+
+// CreateSnapshot is part of the OpenstackStorage interface. The goose
+// cinder client does not support setting metadata on snapshots, so
+// the request is made directly. Snapshots of in-use volumes are forced,
+// as Juju does not detach storage to snapshot it.
+func (ga *openstackStorageAdapter) CreateSnapshot(volumeId string, metadata map[string]string) (*VolumeSnapshot, error) {
+	var resp struct {
+		Snapshot VolumeSnapshot `json:"snapshot"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"snapshot": map[string]interface{}{
+				"volume_id": volumeId,
+				"force":     true,
+				"metadata":  metadata,
+			},
+		},
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	if err := ga.client.SendRequest(client.POST, "volumev2", "v2", "snapshots", &requestData); err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshot(snapshotId string) (*VolumeSnapshot, error) {
+	var resp struct {
+		Snapshot VolumeSnapshot `json:"snapshot"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := ga.client.SendRequest(client.GET, "volumev2", "v2", "snapshots/"+snapshotId, &requestData); err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
@@ -553,6 +553,150 @@ func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.PatchValue(openstack.CinderAttempt, utils.AttemptStrategy{Min: 3})
+	statuses := []string{"extending", "in-use"}
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			status := statuses[0]
+			statuses = statuses[1:]
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   3,
+				Status: status,
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeResizer))
+
+	// The size is rounded up to the nearest GiB.
+	errs, err := volSource.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Provider: openstack.CinderProviderType,
+		Size:     2049,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExtendVolume", []interface{}{mockVolId, 3}},
+		{"GetVolume", []interface{}{mockVolId}},
+		{"GetVolume", []interface{}{mockVolId}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesError(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		extendVolume: func(string, int) error {
+			return errors.New("insufficient quota")
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs, err := volSource.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Provider: openstack.CinderProviderType,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, `extending volume "0": insufficient quota`)
+	mockAdapter.CheckCallNames(c, "ExtendVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestSnapshotVolume(c *gc.C) {
+	s.PatchValue(openstack.CinderAttempt, utils.AttemptStrategy{Min: 3})
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(volumeId string, metadata map[string]string) (*openstack.VolumeSnapshot, error) {
+			return &openstack.VolumeSnapshot{ID: "snap-0", Status: "creating"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeSnapshotter))
+
+	tags := map[string]string{"juju-model-uuid": "foo"}
+	info, err := volSource.(storage.VolumeSnapshotter).SnapshotVolume(mockVolId, tags)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.SnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       1024,
+	})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CreateSnapshot", []interface{}{mockVolId, tags}},
+		{"GetSnapshot", []interface{}{"snap-0"}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestSnapshotVolumeError(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(string, map[string]string) (*openstack.VolumeSnapshot, error) {
+			return &openstack.VolumeSnapshot{ID: "snap-0", Status: "creating"}, nil
+		},
+		getSnapshot: func(snapshotId string) (*openstack.VolumeSnapshot, error) {
+			return &openstack.VolumeSnapshot{ID: snapshotId, Status: "error"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, err := volSource.(storage.VolumeSnapshotter).SnapshotVolume(mockVolId, nil)
+	c.Assert(err, gc.ErrorMatches, `snapshot "snap-0" entered error state`)
+	mockAdapter.CheckCallNames(c, "CreateSnapshot", "GetSnapshot")
+}
+
+func (s *cinderVolumeSourceSuite) TestRestoreVolume(c *gc.C) {
+	s.PatchValue(openstack.CinderAttempt, utils.AttemptStrategy{Min: 3})
+	statuses := []string{"creating", "available"}
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: "vol-restored"}, nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			status := statuses[0]
+			statuses = statuses[1:]
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   1,
+				Status: status,
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	tags := map[string]string{"juju-model-uuid": "foo"}
+	info, err := volSource.(storage.VolumeSnapshotter).RestoreVolume("snap-0", tags)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   "vol-restored",
+		Size:       1024,
+		Persistent: true,
+	})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetSnapshot", []interface{}{"snap-0"}},
+		{"CreateVolume", []interface{}{cinder.CreateVolumeVolumeParams{
+			Size:       1,
+			SnapshotId: "snap-0",
+			Name:       "juju-testenv-snapshot-snap-0",
+			Metadata:   tags,
+		}}},
+		{"GetVolume", []interface{}{"vol-restored"}},
+		{"GetVolume", []interface{}{"vol-restored"}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestRestoreVolumeError(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: "vol-restored"}, nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Status: "error"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, err := volSource.(storage.VolumeSnapshotter).RestoreVolume("snap-0", nil)
+	c.Assert(err, gc.ErrorMatches, `waiting for volume "vol-restored" to be restored: volume entered error state`)
+	mockAdapter.CheckCallNames(c, "GetSnapshot", "CreateVolume", "GetVolume", "DeleteVolume")
+}
+
 func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
 	mockAdapter := &mockAdapter{
 		getVolume: func(volumeId string) (*cinder.Volume, error) {
@@ -581,6 +725,9 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	extendVolume          func(string, int) error
+	createSnapshot        func(string, map[string]string) (*openstack.VolumeSnapshot, error)
+	getSnapshot           func(string) (*openstack.VolumeSnapshot, error)
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -650,6 +797,34 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) ExtendVolume(volumeId string, size int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, size)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, size)
+	}
+	return nil
+}
+
+func (ma *mockAdapter) CreateSnapshot(volumeId string, metadata map[string]string) (*openstack.VolumeSnapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", volumeId, metadata)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(volumeId, metadata)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshot(snapshotId string) (*openstack.VolumeSnapshot, error) {
+	ma.MethodCall(ma, "GetSnapshot", snapshotId)
+	if ma.getSnapshot != nil {
+		return ma.getSnapshot(snapshotId)
+	}
+	return &openstack.VolumeSnapshot{
+		ID:     snapshotId,
+		Status: "available",
+		Size:   1,
+	}, nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
@@ -5,11 +5,16 @@ package openstack
 
 import (
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 )
 
 var configSchema = environschema.Fields{
@@ -25,17 +30,106 @@ var configSchema = environschema.Fields{
 		Description: "The network label or UUID to bring machines up on when multiple networks exist.",
 		Type:        environschema.Tstring,
 	},
+	"networks": {
+		Description: "A space separated list of network labels or UUIDs to bring machines up on, with one network interface for each, in order. Security groups are not used if port security is disabled on any of the networks. May not be used with network.",
+		Type:        environschema.Tstring,
+	},
 	"external-network": {
 		Description: "The network label or UUID to create floating IP addresses on when multiple external networks exist.",
 		Type:        environschema.Tstring,
 	},
+	"egress-policy": {
+		Description: `Whether machines may send traffic anywhere ("allow-all"), or only to the destinations Juju needs and those allowed by egress-rules ("restricted").`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{egressPolicyAllowAll, egressPolicyRestricted},
+	},
+	"egress-rules": {
+		Description: `A space separated list of outgoing traffic to allow when egress-policy is "restricted", each of the form <port>[-<port>][/<protocol>][@<cidr>[,<cidr>...]], e.g. "5432/tcp@10.0.0.0/8".`,
+		Type:        environschema.Tstring,
+	},
+	"security-group-rule-concurrency": {
+		Description: "The maximum number of security group rules to create at the same time when opening ports.",
+		Type:        environschema.Tint,
+	},
+	"security-group-rule-attempts": {
+		Description: "The number of times to try creating a security group rule before giving up.",
+		Type:        environschema.Tint,
+	},
+	"firewall-implementation": {
+		Description: `The firewaller that manages security groups: "auto" to use Neutron if the cloud supports it and nova-network otherwise, or the name of a registered implementation such as "neutron", "nova", "fwaas" or "none".`,
+		Type:        environschema.Tstring,
+	},
+	"application-security-groups": {
+		Description: "Whether each application deployed to a new machine has its own security group, so that exposing the application opens ports in that group rather than in each machine's.",
+		Type:        environschema.Tbool,
+	},
+	"container-security-groups": {
+		Description: "Whether containers on the model's machines have their own Neutron ports and security groups, attached to the host machine's port as VLAN subports of a trunk. Requires the Neutron trunk extension.",
+		Type:        environschema.Tbool,
+	},
+	"ip-address-family": {
+		Description: `The IP address families on which ports opened to anywhere are reachable: "ipv4", "ipv6" or "dual-stack".`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{ipFamilyIPv4, ipFamilyIPv6, ipFamilyDualStack},
+	},
+	"allowed-ingress-cidrs": {
+		Description: `A space separated list of CIDRs from which the SSH and API ports of the model's machines may be reached, e.g. "10.0.0.0/8 192.168.1.0/24". If empty, they may be reached from anywhere.`,
+		Type:        environschema.Tstring,
+	},
+	"retry-attempts": {
+		Description: "The number of times to try a failing OpenStack API call before giving up. Slow or rate-limited clouds may need more.",
+		Type:        environschema.Tint,
+	},
+	"retry-delay": {
+		Description: `The time to wait before retrying a failing OpenStack API call, e.g. "1s". The delay doubles with each retry, up to retry-max-delay.`,
+		Type:        environschema.Tstring,
+	},
+	"retry-max-delay": {
+		Description: `The longest time to wait between attempts at a failing OpenStack API call, e.g. "10s".`,
+		Type:        environschema.Tstring,
+	},
 }
 
+const (
+	// egressPolicyAllowAll keeps the egress rules Neutron adds to new
+	// security groups, allowing machines to send traffic anywhere.
+	egressPolicyAllowAll = "allow-all"
+
+	// egressPolicyRestricted removes Neutron's default egress rules,
+	// allowing only the outgoing traffic Juju needs and that allowed
+	// by the egress-rules setting.
+	egressPolicyRestricted = "restricted"
+)
+
+const (
+	// ipFamilyIPv4 opens ports to anywhere on IPv4 only.
+	ipFamilyIPv4 = "ipv4"
+
+	// ipFamilyIPv6 opens ports to anywhere on IPv6 only.
+	ipFamilyIPv6 = "ipv6"
+
+	// ipFamilyDualStack opens ports to anywhere on both IPv4 and IPv6.
+	ipFamilyDualStack = "dual-stack"
+)
+
 var configDefaults = schema.Defaults{
-	"use-floating-ip":      false,
-	"use-default-secgroup": false,
-	"network":              "",
-	"external-network":     "",
+	"use-floating-ip":                 false,
+	"use-default-secgroup":            false,
+	"network":                         "",
+	"networks":                        "",
+	"external-network":                "",
+	"egress-policy":                   egressPolicyAllowAll,
+	"egress-rules":                    "",
+	"security-group-rule-concurrency": 8,
+	"security-group-rule-attempts":    3,
+	"ip-address-family":               ipFamilyIPv4,
+	"firewall-implementation":         FirewallerAuto,
+	"application-security-groups":     false,
+	"container-security-groups":       false,
+	"allowed-ingress-cidrs":           "",
+	"retry-attempts":                  10,
+	"retry-delay":                     "1s",
+	"retry-max-delay":                 "10s",
 }
 
 var configFields = func() schema.Fields {
@@ -63,10 +157,116 @@ func (c *environConfig) network() string {
 	return c.attrs["network"].(string)
 }
 
+// networks returns the labels or UUIDs of the networks to bring
+// machines up on, in the order of their network interfaces.
+func (c *environConfig) networks() []string {
+	if networks := strings.Fields(c.attrs["networks"].(string)); len(networks) > 0 {
+		return networks
+	}
+	if network := c.network(); network != "" {
+		return []string{network}
+	}
+	return nil
+}
+
 func (c *environConfig) externalNetwork() string {
 	return c.attrs["external-network"].(string)
 }
 
+func (c *environConfig) egressPolicy() string {
+	return c.attrs["egress-policy"].(string)
+}
+
+// egressRules returns the outgoing traffic allowed in addition to
+// that which Juju needs, when the egress policy is restricted.
+func (c *environConfig) egressRules() ([]network.EgressRule, error) {
+	var rules []network.EgressRule
+	for _, field := range strings.Fields(c.attrs["egress-rules"].(string)) {
+		rule, err := network.ParseEgressRule(field)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// securityGroupRuleConcurrency returns the maximum number of security
+// group rules to create at the same time.
+func (c *environConfig) securityGroupRuleConcurrency() int {
+	return c.attrs["security-group-rule-concurrency"].(int)
+}
+
+// securityGroupRuleAttempts returns the number of times to try creating
+// a security group rule.
+func (c *environConfig) securityGroupRuleAttempts() int {
+	return c.attrs["security-group-rule-attempts"].(int)
+}
+
+// retryStrategy returns the strategy for retrying failed OpenStack
+// API calls, without a clock.
+func (c *environConfig) retryStrategy() retryStrategy {
+	// The delays are validated by Validate.
+	delay, _ := time.ParseDuration(c.attrs["retry-delay"].(string))
+	maxDelay, _ := time.ParseDuration(c.attrs["retry-max-delay"].(string))
+	return retryStrategy{
+		Attempts: c.attrs["retry-attempts"].(int),
+		Delay:    delay,
+		MaxDelay: maxDelay,
+		Backoff:  2,
+		Jitter:   0.1,
+	}
+}
+
+// ipAddressFamily returns the IP address families on which ports opened
+// to anywhere are reachable.
+func (c *environConfig) ipAddressFamily() string {
+	return c.attrs["ip-address-family"].(string)
+}
+
+// firewallImplementation returns the name of the Firewaller
+// implementation to use, or "auto".
+func (c *environConfig) firewallImplementation() string {
+	return c.attrs["firewall-implementation"].(string)
+}
+
+// applicationSecurityGroups reports whether each application has its
+// own security group.
+func (c *environConfig) applicationSecurityGroups() bool {
+	return c.attrs["application-security-groups"].(bool)
+}
+
+// containerSecurityGroups reports whether containers have their own
+// ports and security groups.
+func (c *environConfig) containerSecurityGroups() bool {
+	return c.attrs["container-security-groups"].(bool)
+}
+
+// allowedIngressCIDRs returns the CIDRs from which the SSH and API ports
+// of the model's machines may be reached, or nil if they may be reached
+// from anywhere.
+func (c *environConfig) allowedIngressCIDRs() []string {
+	return strings.Fields(c.attrs["allowed-ingress-cidrs"].(string))
+}
+
+// validateRetryDelays returns an error if the retry-delay or
+// retry-max-delay settings are not positive durations, or the maximum
+// delay is less than the delay.
+func validateRetryDelays(delayValue, maxDelayValue string) error {
+	delay, err := time.ParseDuration(delayValue)
+	if err != nil || delay <= 0 {
+		return errors.NotValidf("retry-delay %q", delayValue)
+	}
+	maxDelay, err := time.ParseDuration(maxDelayValue)
+	if err != nil || maxDelay <= 0 {
+		return errors.NotValidf("retry-max-delay %q", maxDelayValue)
+	}
+	if maxDelay < delay {
+		return errors.NotValidf("retry-max-delay %v less than retry-delay %v", maxDelay, delay)
+	}
+	return nil
+}
+
 type AuthMode string
 
 const (
@@ -108,6 +308,35 @@ func (p EnvironProvider) Validate(cfg, old *config.Config) (valid *config.Config
 	}
 	ecfg := &environConfig{cfg, validated}
 
+	if _, err := ecfg.egressRules(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ecfg.attrs["egress-rules"] != "" && ecfg.egressPolicy() != egressPolicyRestricted {
+		return nil, errors.Errorf("egress-rules requires egress-policy %q", egressPolicyRestricted)
+	}
+	if ecfg.network() != "" && ecfg.attrs["networks"] != "" {
+		return nil, errors.New("network and networks may not both be set")
+	}
+	if err := validateFirewallImplementation(ecfg.firewallImplementation()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, key := range []string{"security-group-rule-concurrency", "security-group-rule-attempts", "retry-attempts"} {
+		if value := ecfg.attrs[key].(int); value < 1 {
+			return nil, errors.NotValidf("%s %d", key, value)
+		}
+	}
+	if err := validateRetryDelays(ecfg.attrs["retry-delay"].(string), ecfg.attrs["retry-max-delay"].(string)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, cidr := range ecfg.allowedIngressCIDRs() {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, errors.NotValidf("allowed-ingress-cidrs entry %q", cidr)
+		}
+	}
+	if ecfg.egressPolicy() == egressPolicyRestricted && ecfg.useDefaultSecurityGroup() {
+		logger.Warningf(`the "default" security group may allow outgoing traffic that egress-policy %q does not`, egressPolicyRestricted)
+	}
+
 	// Check for deprecated fields and log a warning. We also print to stderr to ensure the user sees the message
 	// even if they are not running with --debug.
 	cfgAttrs := cfg.AllAttrs()
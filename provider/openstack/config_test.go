@@ -144,6 +144,192 @@ var configTests = []configTest{
 			"use-default-secgroup": true,
 		}),
 		useDefaultSecurityGroup: true,
+	}, {
+		summary: "default egress policy",
+		config:  requiredConfig,
+		expect: testing.Attrs{
+			"egress-policy": "allow-all",
+			"egress-rules":  "",
+		},
+	}, {
+		summary: "restricted egress policy",
+		config: requiredConfig.Merge(testing.Attrs{
+			"egress-policy": "restricted",
+			"egress-rules":  "5432/tcp@10.0.0.0/8 8080-8089",
+		}),
+		expect: testing.Attrs{
+			"egress-policy": "restricted",
+			"egress-rules":  "5432/tcp@10.0.0.0/8 8080-8089",
+		},
+	}, {
+		summary: "invalid egress policy",
+		config: requiredConfig.Merge(testing.Attrs{
+			"egress-policy": "deny-all",
+		}),
+		err: `.*egress-policy.*`,
+	}, {
+		summary: "egress rules without restricted egress policy",
+		config: requiredConfig.Merge(testing.Attrs{
+			"egress-rules": "443/tcp",
+		}),
+		err: `egress-rules requires egress-policy "restricted"`,
+	}, {
+		summary: "invalid egress rule",
+		config: requiredConfig.Merge(testing.Attrs{
+			"egress-policy": "restricted",
+			"egress-rules":  "https",
+		}),
+		err: `invalid egress rule "https": .*`,
+	}, {
+		summary: "default security group rule creation",
+		config:  requiredConfig,
+		expect: testing.Attrs{
+			"security-group-rule-concurrency": 8,
+			"security-group-rule-attempts":    3,
+		},
+	}, {
+		summary: "security group rule creation",
+		config: requiredConfig.Merge(testing.Attrs{
+			"security-group-rule-concurrency": 16,
+			"security-group-rule-attempts":    5,
+		}),
+		expect: testing.Attrs{
+			"security-group-rule-concurrency": 16,
+			"security-group-rule-attempts":    5,
+		},
+	}, {
+		summary: "invalid security group rule concurrency",
+		config: requiredConfig.Merge(testing.Attrs{
+			"security-group-rule-concurrency": 0,
+		}),
+		err: `security-group-rule-concurrency 0 not valid`,
+	}, {
+		summary: "invalid security group rule attempts",
+		config: requiredConfig.Merge(testing.Attrs{
+			"security-group-rule-attempts": -1,
+		}),
+		err: `security-group-rule-attempts -1 not valid`,
+	}, {
+		summary: "default retry strategy",
+		config:  requiredConfig,
+		expect: testing.Attrs{
+			"retry-attempts":  10,
+			"retry-delay":     "1s",
+			"retry-max-delay": "10s",
+		},
+	}, {
+		summary: "retry strategy",
+		config: requiredConfig.Merge(testing.Attrs{
+			"retry-attempts":  20,
+			"retry-delay":     "5s",
+			"retry-max-delay": "1m",
+		}),
+		expect: testing.Attrs{
+			"retry-attempts":  20,
+			"retry-delay":     "5s",
+			"retry-max-delay": "1m",
+		},
+	}, {
+		summary: "allowed ingress CIDRs",
+		config: requiredConfig.Merge(testing.Attrs{
+			"allowed-ingress-cidrs": "10.0.0.0/8 2001:db8::/32",
+		}),
+		expect: testing.Attrs{
+			"allowed-ingress-cidrs": "10.0.0.0/8 2001:db8::/32",
+		},
+	}, {
+		summary: "invalid allowed ingress CIDR",
+		config: requiredConfig.Merge(testing.Attrs{
+			"allowed-ingress-cidrs": "10.0.0.0/8 10.0.0.1",
+		}),
+		err: `allowed-ingress-cidrs entry "10.0.0.1" not valid`,
+	}, {
+		summary: "invalid retry attempts",
+		config: requiredConfig.Merge(testing.Attrs{
+			"retry-attempts": 0,
+		}),
+		err: `retry-attempts 0 not valid`,
+	}, {
+		summary: "invalid retry delay",
+		config: requiredConfig.Merge(testing.Attrs{
+			"retry-delay": "soon",
+		}),
+		err: `retry-delay "soon" not valid`,
+	}, {
+		summary: "retry max delay less than delay",
+		config: requiredConfig.Merge(testing.Attrs{
+			"retry-delay":     "5s",
+			"retry-max-delay": "1s",
+		}),
+		err: `retry-max-delay 1s less than retry-delay 5s not valid`,
+	}, {
+		summary: "default ip address family",
+		config:  requiredConfig,
+		expect: testing.Attrs{
+			"ip-address-family": "ipv4",
+		},
+	}, {
+		summary: "dual-stack ip address family",
+		config: requiredConfig.Merge(testing.Attrs{
+			"ip-address-family": "dual-stack",
+		}),
+		expect: testing.Attrs{
+			"ip-address-family": "dual-stack",
+		},
+	}, {
+		summary: "invalid ip address family",
+		config: requiredConfig.Merge(testing.Attrs{
+			"ip-address-family": "ipx",
+		}),
+		err: `.*ip-address-family.*`,
+	}, {
+		summary: "default firewall implementation",
+		config:  requiredConfig,
+		expect: testing.Attrs{
+			"firewall-implementation": "auto",
+		},
+	}, {
+		summary: "registered firewall implementation",
+		config: requiredConfig.Merge(testing.Attrs{
+			"firewall-implementation": "none",
+		}),
+		expect: testing.Attrs{
+			"firewall-implementation": "none",
+		},
+	}, {
+		summary: "unknown firewall implementation",
+		config: requiredConfig.Merge(testing.Attrs{
+			"firewall-implementation": "iptables",
+		}),
+		err: `firewall-implementation "iptables" \(expected "auto" or one of \["fwaas" "neutron" "none" "nova"\]\) not valid`,
+	}, {
+		summary: "default application security groups",
+		config:  requiredConfig,
+		expect: testing.Attrs{
+			"application-security-groups": false,
+		},
+	}, {
+		summary: "application security groups",
+		config: requiredConfig.Merge(testing.Attrs{
+			"application-security-groups": true,
+		}),
+		expect: testing.Attrs{
+			"application-security-groups": true,
+		},
+	}, {
+		summary: "default container security groups",
+		config:  requiredConfig,
+		expect: testing.Attrs{
+			"container-security-groups": false,
+		},
+	}, {
+		summary: "container security groups",
+		config: requiredConfig.Merge(testing.Attrs{
+			"container-security-groups": true,
+		}),
+		expect: testing.Attrs{
+			"container-security-groups": true,
+		},
 	}, {
 		summary: "admin-secret given",
 		config: requiredConfig.Merge(testing.Attrs{
@@ -210,6 +396,21 @@ var configTests = []configTest{
 			"network": "a-network-label",
 		}),
 		network: "a-network-label",
+	}, {
+		summary: "networks",
+		config: requiredConfig.Merge(testing.Attrs{
+			"networks": "net-a net-b",
+		}),
+		expect: testing.Attrs{
+			"networks": "net-a net-b",
+		},
+	}, {
+		summary: "network and networks",
+		config: requiredConfig.Merge(testing.Attrs{
+			"network":  "net-a",
+			"networks": "net-b",
+		}),
+		err: "network and networks may not both be set",
 	}, {}, {
 		summary:         "default external network",
 		config:          requiredConfig,
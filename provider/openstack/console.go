@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.InstanceConsoleAccessor = (*Environ)(nil)
+
+// consoleActions maps console types to the nova server actions used to
+// request them.
+var consoleActions = map[environs.InstanceConsoleType]string{
+	environs.SerialConsole: "os-getSerialConsole",
+	environs.NoVNCConsole:  "os-getVNCConsole",
+}
+
+// InstanceConsole implements environs.InstanceConsoleAccessor.
+func (e *Environ) InstanceConsole(id instance.Id, consoleType environs.InstanceConsoleType) (environs.InstanceConsole, error) {
+	action, ok := consoleActions[consoleType]
+	if !ok {
+		return environs.InstanceConsole{}, errors.NotSupportedf("%q console", consoleType)
+	}
+	req := map[string]interface{}{
+		action: map[string]string{"type": string(consoleType)},
+	}
+	var resp struct {
+		Console struct {
+			Type string `json:"type"`
+			URL  string `json:"url"`
+		} `json:"console"`
+	}
+	if err := e.serverAction(id, req, &resp); err != nil {
+		return environs.InstanceConsole{}, errors.Annotatef(err, "getting %s console of instance %q", consoleType, id)
+	}
+	if resp.Console.URL == "" {
+		return environs.InstanceConsole{}, errors.Errorf("no %s console URL returned for instance %q", consoleType, id)
+	}
+	return environs.InstanceConsole{
+		Type: consoleType,
+		URL:  resp.Console.URL,
+	}, nil
+}
+
+// serverAction performs an action on the specified server. The server
+// actions used for consoles are not exposed by the goose nova client,
+// so the request is made directly.
+func (e *Environ) serverAction(id instance.Id, req, resp interface{}) error {
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	url := "servers/" + string(id) + "/action"
+	return e.client().SendRequest(client.POST, "compute", "v2", url, &requestData)
+}
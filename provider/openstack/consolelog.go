@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.InstanceConsoleLogger = (*Environ)(nil)
+
+// InstanceConsoleLog implements environs.InstanceConsoleLogger.
+func (e *Environ) InstanceConsoleLog(id instance.Id, maxLines int) (string, error) {
+	type getConsoleOutput struct {
+		Length *int `json:"length,omitempty"`
+	}
+	req := struct {
+		GetConsoleOutput getConsoleOutput `json:"os-getConsoleOutput"`
+	}{}
+	if maxLines > 0 {
+		req.GetConsoleOutput.Length = &maxLines
+	}
+	var resp struct {
+		Output string `json:"output"`
+	}
+	if err := e.serverAction(id, req, &resp); err != nil {
+		return "", errors.Annotatef(err, "getting console output of instance %q", id)
+	}
+	return resp.Output, nil
+}
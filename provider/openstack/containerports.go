@@ -0,0 +1,478 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/client"
+	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// NeutronPort is a Neutron port.
+type NeutronPort struct {
+	Id             string           `json:"id"`
+	Name           string           `json:"name"`
+	NetworkId      string           `json:"network_id"`
+	MACAddress     string           `json:"mac_address"`
+	FixedIPs       []NeutronFixedIP `json:"fixed_ips"`
+	SecurityGroups []string         `json:"security_groups"`
+}
+
+// NeutronFixedIP is an IP address of a Neutron port.
+type NeutronFixedIP struct {
+	SubnetId  string `json:"subnet_id"`
+	IPAddress string `json:"ip_address"`
+}
+
+// NeutronSubnet is a Neutron subnet.
+type NeutronSubnet struct {
+	Id             string   `json:"id"`
+	CIDR           string   `json:"cidr"`
+	GatewayIP      string   `json:"gateway_ip"`
+	DNSNameservers []string `json:"dns_nameservers"`
+}
+
+// NeutronTrunk is a Neutron trunk, which carries the traffic of its
+// subports over its parent port, each tagged with the subport's
+// segmentation ID.
+type NeutronTrunk struct {
+	Id       string           `json:"id"`
+	PortId   string           `json:"port_id"`
+	SubPorts []NeutronSubPort `json:"sub_ports"`
+}
+
+// NeutronSubPort is a subport of a Neutron trunk.
+type NeutronSubPort struct {
+	PortId           string `json:"port_id"`
+	SegmentationType string `json:"segmentation_type,omitempty"`
+	SegmentationId   int    `json:"segmentation_id,omitempty"`
+}
+
+// ContainerPortAPI manages the Neutron ports of containers, and the
+// trunks through which they reach their hosts' ports.
+type ContainerPortAPI interface {
+	// InstancePorts returns the ports of the instance with the
+	// specified ID.
+	InstancePorts(id instance.Id) ([]NeutronPort, error)
+
+	// Port returns the port with the specified ID, or an error
+	// satisfying errors.IsNotFound if it does not exist.
+	Port(id string) (NeutronPort, error)
+
+	// CreatePort creates a port on the network with the specified ID,
+	// in the security groups with the specified IDs, and returns it.
+	CreatePort(name, networkId string, groupIds []string) (NeutronPort, error)
+
+	// DeletePort deletes the port with the specified ID. It is not an
+	// error to delete a port that does not exist.
+	DeletePort(id string) error
+
+	// Subnet returns the subnet with the specified ID.
+	Subnet(id string) (NeutronSubnet, error)
+
+	// Trunks returns all of the trunks.
+	Trunks() ([]NeutronTrunk, error)
+
+	// CreateTrunk creates a trunk with the parent port with the
+	// specified ID, and returns it.
+	CreateTrunk(name, portId string) (NeutronTrunk, error)
+
+	// AddSubPort adds the subport to the trunk with the specified ID.
+	AddSubPort(trunkId string, subPort NeutronSubPort) error
+
+	// RemoveSubPort removes the port with the specified ID from the
+	// trunk with the specified ID.
+	RemoveSubPort(trunkId, portId string) error
+}
+
+var newContainerPortAPI = func(e *Environ) ContainerPortAPI {
+	return &neutronContainerPortAPI{e.client()}
+}
+
+// neutronContainerPortAPI implements ContainerPortAPI. The goose
+// neutron client does not support trunks, nor ports with security
+// groups, so requests are made directly.
+type neutronContainerPortAPI struct {
+	client client.Client
+}
+
+func (api *neutronContainerPortAPI) sendRequest(method, path string, requestData *goosehttp.RequestData) error {
+	return api.client.SendRequest(method, "network", "v2.0", path, requestData)
+}
+
+// InstancePorts is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) InstancePorts(id instance.Id) ([]NeutronPort, error) {
+	var resp struct {
+		Ports []NeutronPort `json:"ports"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	path := "ports?device_id=" + url.QueryEscape(string(id))
+	if err := api.sendRequest(client.GET, path, &requestData); err != nil {
+		return nil, err
+	}
+	return resp.Ports, nil
+}
+
+// Port is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) Port(id string) (NeutronPort, error) {
+	var resp struct {
+		Port NeutronPort `json:"port"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	err := api.sendRequest(client.GET, "ports/"+id, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return NeutronPort{}, errors.NotFoundf("port %q", id)
+	} else if err != nil {
+		return NeutronPort{}, err
+	}
+	return resp.Port, nil
+}
+
+// CreatePort is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) CreatePort(name, networkId string, groupIds []string) (NeutronPort, error) {
+	var resp struct {
+		Port NeutronPort `json:"port"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"port": map[string]interface{}{
+				"name":            name,
+				"network_id":      networkId,
+				"security_groups": groupIds,
+			},
+		},
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusCreated},
+	}
+	if err := api.sendRequest(client.POST, "ports", &requestData); err != nil {
+		return NeutronPort{}, err
+	}
+	return resp.Port, nil
+}
+
+// DeletePort is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) DeletePort(id string) error {
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusNoContent},
+	}
+	err := api.sendRequest(client.DELETE, "ports/"+id, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Subnet is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) Subnet(id string) (NeutronSubnet, error) {
+	var resp struct {
+		Subnet NeutronSubnet `json:"subnet"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := api.sendRequest(client.GET, "subnets/"+id, &requestData); err != nil {
+		return NeutronSubnet{}, err
+	}
+	return resp.Subnet, nil
+}
+
+// Trunks is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) Trunks() ([]NeutronTrunk, error) {
+	var resp struct {
+		Trunks []NeutronTrunk `json:"trunks"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := api.sendRequest(client.GET, "trunks", &requestData); err != nil {
+		return nil, err
+	}
+	return resp.Trunks, nil
+}
+
+// CreateTrunk is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) CreateTrunk(name, portId string) (NeutronTrunk, error) {
+	var resp struct {
+		Trunk NeutronTrunk `json:"trunk"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"trunk": map[string]string{
+				"name":    name,
+				"port_id": portId,
+			},
+		},
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusCreated},
+	}
+	if err := api.sendRequest(client.POST, "trunks", &requestData); err != nil {
+		return NeutronTrunk{}, err
+	}
+	return resp.Trunk, nil
+}
+
+// AddSubPort is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) AddSubPort(trunkId string, subPort NeutronSubPort) error {
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"sub_ports": []NeutronSubPort{subPort},
+		},
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	return api.sendRequest(client.PUT, "trunks/"+trunkId+"/add_subports", &requestData)
+}
+
+// RemoveSubPort is part of the ContainerPortAPI interface.
+func (api *neutronContainerPortAPI) RemoveSubPort(trunkId, portId string) error {
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"sub_ports": []NeutronSubPort{{PortId: portId}},
+		},
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	return api.sendRequest(client.PUT, "trunks/"+trunkId+"/remove_subports", &requestData)
+}
+
+const (
+	// containerSegmentationType is the segmentation type of the
+	// subports of containers' ports.
+	containerSegmentationType = "vlan"
+
+	// maxVLANTag is the highest valid VLAN tag.
+	maxVLANTag = 4094
+)
+
+// SupportsContainerAddresses is specified on environs.Networking.
+// Containers are given addresses only when they have their own ports,
+// which requires Neutron.
+func (e *Environ) SupportsContainerAddresses() (bool, error) {
+	if !e.ecfg().containerSecurityGroups() || !e.supportsNeutron() {
+		return false, errors.NotSupportedf("container address")
+	}
+	return true, nil
+}
+
+var _ environs.ContainerFirewaller = (*Environ)(nil)
+
+// SupportsContainerFirewalls is specified on environs.ContainerFirewaller.
+// Containers that are given addresses have security groups of their
+// own, which are used in the instance firewall mode.
+func (e *Environ) SupportsContainerFirewalls() bool {
+	supported, _ := e.SupportsContainerAddresses()
+	return supported && e.Config().FirewallMode() == config.FwInstance
+}
+
+// OpenContainerPorts is specified on environs.ContainerFirewaller.
+func (e *Environ) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	return e.firewaller.OpenContainerPorts(containerId, rules)
+}
+
+// CloseContainerPorts is specified on environs.ContainerFirewaller.
+func (e *Environ) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	return e.firewaller.CloseContainerPorts(containerId, rules)
+}
+
+// ContainerIngressRules is specified on environs.ContainerFirewaller.
+func (e *Environ) ContainerIngressRules(containerId string) ([]network.IngressRule, error) {
+	return e.firewaller.ContainerIngressRules(containerId)
+}
+
+// AllocateContainerAddresses is specified on environs.Networking. Each
+// of the container's interfaces is given a port of its own, in the
+// container's security groups and on the network of the host's first
+// port. The ports are added as VLAN subports of a trunk on the host's
+// port, which is created if the host does not yet have one.
+//
+// Some Neutron ML2 drivers do not allow a trunk to be created on a port
+// that is already bound, as the host's port is; the trunk extension
+// must support this for containers to be given ports.
+func (e *Environ) AllocateContainerAddresses(hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) (_ []network.InterfaceInfo, err error) {
+	if supported, err := e.SupportsContainerAddresses(); !supported {
+		return nil, errors.Trace(err)
+	}
+	server, err := e.nova().GetServer(string(hostInstanceID))
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting host instance %q", hostInstanceID)
+	}
+	controllerUUID := server.Metadata[tags.JujuController]
+	groupIds, err := e.firewaller.SetUpContainerGroups(controllerUUID, containerTag.Id())
+	if err != nil {
+		return nil, errors.Annotatef(err, "setting up security groups for %s", names.ReadableString(containerTag))
+	}
+
+	api := newContainerPortAPI(e)
+	trunk, parentPort, err := e.hostTrunk(api, hostInstanceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var createdPorts, addedSubPorts []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, portId := range addedSubPorts {
+			if err := api.RemoveSubPort(trunk.Id, portId); err != nil {
+				logger.Warningf("cannot remove port %q from trunk %q: %v", portId, trunk.Id, err)
+			}
+		}
+		for _, portId := range createdPorts {
+			if err := api.DeletePort(portId); err != nil {
+				logger.Warningf("cannot delete port %q: %v", portId, err)
+			}
+		}
+		if err := e.firewaller.DeleteContainerGroups(groupIds...); err != nil {
+			logger.Warningf("cannot delete security groups of %s: %v", names.ReadableString(containerTag), err)
+		}
+	}()
+
+	usedTags := make(map[int]bool)
+	for _, subPort := range trunk.SubPorts {
+		usedTags[subPort.SegmentationId] = true
+	}
+	nextTag := 1
+	result := make([]network.InterfaceInfo, len(preparedInfo))
+	for i, info := range preparedInfo {
+		for usedTags[nextTag] {
+			nextTag++
+		}
+		if nextTag > maxVLANTag {
+			return nil, errors.Errorf("no VLAN tags left on trunk %q", trunk.Id)
+		}
+		name := resourceName(e.namespace, e.name, containerTag.String()+"-"+info.InterfaceName)
+		port, err := api.CreatePort(name, parentPort.NetworkId, groupIds)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating port for interface %q", info.InterfaceName)
+		}
+		createdPorts = append(createdPorts, port.Id)
+		if err := api.AddSubPort(trunk.Id, NeutronSubPort{
+			PortId:           port.Id,
+			SegmentationType: containerSegmentationType,
+			SegmentationId:   nextTag,
+		}); err != nil {
+			return nil, errors.Annotatef(err, "adding port %q to trunk %q", port.Id, trunk.Id)
+		}
+		addedSubPorts = append(addedSubPorts, port.Id)
+		usedTags[nextTag] = true
+
+		info.ProviderId = network.Id(port.Id)
+		info.ProviderNetworkId = network.Id(port.NetworkId)
+		info.MACAddress = port.MACAddress
+		info.VLANTag = nextTag
+		info.ConfigType = network.ConfigStatic
+		if len(port.FixedIPs) > 0 {
+			fixedIP := port.FixedIPs[0]
+			subnet, err := api.Subnet(fixedIP.SubnetId)
+			if err != nil {
+				return nil, errors.Annotatef(err, "getting subnet %q", fixedIP.SubnetId)
+			}
+			info.ProviderSubnetId = network.Id(subnet.Id)
+			info.CIDR = subnet.CIDR
+			info.Address = network.NewAddress(fixedIP.IPAddress)
+			if subnet.GatewayIP != "" {
+				info.GatewayAddress = network.NewAddress(subnet.GatewayIP)
+			}
+			info.DNSServers = network.NewAddresses(subnet.DNSNameservers...)
+		}
+		result[i] = info
+	}
+	return result, nil
+}
+
+// hostTrunk returns the trunk through which containers on the instance
+// with the specified ID reach its port, along with its parent port. If
+// none of the instance's ports is the parent of a trunk, a trunk is
+// created on its first port.
+func (e *Environ) hostTrunk(api ContainerPortAPI, id instance.Id) (NeutronTrunk, NeutronPort, error) {
+	ports, err := api.InstancePorts(id)
+	if err != nil {
+		return NeutronTrunk{}, NeutronPort{}, errors.Annotatef(err, "getting ports of instance %q", id)
+	}
+	if len(ports) == 0 {
+		return NeutronTrunk{}, NeutronPort{}, errors.NotFoundf("ports of instance %q", id)
+	}
+	trunks, err := api.Trunks()
+	if err != nil {
+		return NeutronTrunk{}, NeutronPort{}, errors.Annotate(err, "listing trunks")
+	}
+	for _, port := range ports {
+		for _, trunk := range trunks {
+			if trunk.PortId == port.Id {
+				return trunk, port, nil
+			}
+		}
+	}
+	name := resourceName(e.namespace, e.name, "trunk-"+string(id))
+	trunk, err := api.CreateTrunk(name, ports[0].Id)
+	if err != nil {
+		return NeutronTrunk{}, NeutronPort{}, errors.Annotatef(err, "creating trunk on port %q", ports[0].Id)
+	}
+	return trunk, ports[0], nil
+}
+
+// ReleaseContainerAddresses is specified on environs.Networking. The
+// ports of the interfaces are removed from their trunks and deleted,
+// along with the security groups of the containers they belonged to.
+func (e *Environ) ReleaseContainerAddresses(interfaces []network.ProviderInterfaceInfo) error {
+	if supported, err := e.SupportsContainerAddresses(); !supported {
+		return errors.Trace(err)
+	}
+	api := newContainerPortAPI(e)
+	trunks, err := api.Trunks()
+	if err != nil {
+		return errors.Annotate(err, "listing trunks")
+	}
+	var groupIds []string
+	for _, iface := range interfaces {
+		portId := string(iface.ProviderId)
+		port, err := api.Port(portId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "getting port %q", portId)
+		}
+		groupIds = append(groupIds, port.SecurityGroups...)
+		for _, trunk := range trunks {
+			if !hasSubPort(trunk, portId) {
+				continue
+			}
+			if err := api.RemoveSubPort(trunk.Id, portId); err != nil {
+				return errors.Annotatef(err, "removing port %q from trunk %q", portId, trunk.Id)
+			}
+		}
+		if err := api.DeletePort(portId); err != nil {
+			return errors.Annotatef(err, "deleting port %q", portId)
+		}
+	}
+	return errors.Trace(e.firewaller.DeleteContainerGroups(groupIds...))
+}
+
+// hasSubPort reports whether the port with the specified ID is a
+// subport of the trunk.
+func hasSubPort(trunk NeutronTrunk, portId string) bool {
+	for _, subPort := range trunk.SubPorts {
+		if subPort.PortId == portId {
+			return true
+		}
+	}
+	return false
+}
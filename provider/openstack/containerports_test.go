@@ -0,0 +1,366 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/openstack"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func (s *localServerSuite) containerEnviron(c *gc.C) (environs.Environ, instance.Id) {
+	env := s.openEnviron(c, coretesting.Attrs{"container-security-groups": true})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	s.containerPortAPI.ports[inst.Id()] = []openstack.NeutronPort{{
+		Id:        "host-port",
+		NetworkId: "net-1",
+	}}
+	return env, inst.Id()
+}
+
+// machineGroupNames returns the names of the machine security groups of
+// the machine or container with the specified ID.
+func (s *localServerSuite) machineGroupNames(c *gc.C, env environs.Environ, machineId string) []string {
+	groups, err := openstack.GetNeutronClient(env).ListSecurityGroupsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	var result []string
+	for _, group := range groups {
+		if group.Name == fmt.Sprintf("juju-%s-%s-%s", s.ControllerUUID, env.Config().UUID(), machineId) {
+			result = append(result, group.Name)
+		}
+	}
+	return result
+}
+
+func (s *localServerSuite) TestSupportsContainerAddressesDisabled(c *gc.C) {
+	supported, err := s.env.(environs.Networking).SupportsContainerAddresses()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(supported, jc.IsFalse)
+}
+
+func (s *localServerSuite) TestSupportsContainerAddresses(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"container-security-groups": true})
+	supported, err := env.(environs.Networking).SupportsContainerAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+}
+
+func (s *localServerSuite) TestSupportsContainerFirewalls(c *gc.C) {
+	c.Assert(s.env.(environs.ContainerFirewaller).SupportsContainerFirewalls(), jc.IsFalse)
+	env := s.openEnviron(c, coretesting.Attrs{"container-security-groups": true})
+	c.Assert(env.(environs.ContainerFirewaller).SupportsContainerFirewalls(), jc.IsTrue)
+}
+
+func (s *localServerSuite) TestContainerPorts(c *gc.C) {
+	env, hostId := s.containerEnviron(c)
+	_, err := env.(environs.Networking).AllocateContainerAddresses(
+		hostId, names.NewMachineTag("100/lxd/0"), []network.InterfaceInfo{{InterfaceName: "eth0"}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	fwEnv := env.(environs.ContainerFirewaller)
+
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8090, "10.0.0.0/8"),
+	}
+	err = fwEnv.OpenContainerPorts("100/lxd/0", rules)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := fwEnv.ContainerIngressRules("100/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, rules)
+
+	// The ports are not opened on the host.
+	insts, err := env.Instances([]instance.Id{hostId})
+	c.Assert(err, jc.ErrorIsNil)
+	hostRules, err := insts[0].(instance.InstanceFirewaller).IngressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hostRules, gc.HasLen, 0)
+
+	err = fwEnv.CloseContainerPorts("100/lxd/0", rules[:1])
+	c.Assert(err, jc.ErrorIsNil)
+	got, err = fwEnv.ContainerIngressRules("100/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, rules[1:])
+}
+
+func (s *localServerSuite) TestAllocateContainerAddressesDisabled(c *gc.C) {
+	_, err := s.env.(environs.Networking).AllocateContainerAddresses(
+		"host", names.NewMachineTag("100/lxd/0"), []network.InterfaceInfo{{InterfaceName: "eth0"}},
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.containerPortAPI.CheckNoCalls(c)
+}
+
+func (s *localServerSuite) TestAllocateContainerAddresses(c *gc.C) {
+	env, hostId := s.containerEnviron(c)
+	result, err := env.(environs.Networking).AllocateContainerAddresses(
+		hostId, names.NewMachineTag("100/lxd/0"), []network.InterfaceInfo{
+			{InterfaceName: "eth0", DeviceIndex: 0},
+			{InterfaceName: "eth1", DeviceIndex: 1},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []network.InterfaceInfo{{
+		InterfaceName:     "eth0",
+		DeviceIndex:       0,
+		ProviderId:        "port-1",
+		ProviderNetworkId: "net-1",
+		ProviderSubnetId:  "subnet-1",
+		MACAddress:        "fa:16:3e:00:00:01",
+		CIDR:              "10.0.0.0/24",
+		Address:           network.NewAddress("10.0.0.1"),
+		GatewayAddress:    network.NewAddress("10.0.0.254"),
+		DNSServers:        network.NewAddresses("10.0.0.2"),
+		VLANTag:           1,
+		ConfigType:        network.ConfigStatic,
+	}, {
+		InterfaceName:     "eth1",
+		DeviceIndex:       1,
+		ProviderId:        "port-2",
+		ProviderNetworkId: "net-1",
+		ProviderSubnetId:  "subnet-1",
+		MACAddress:        "fa:16:3e:00:00:02",
+		CIDR:              "10.0.0.0/24",
+		Address:           network.NewAddress("10.0.0.2"),
+		GatewayAddress:    network.NewAddress("10.0.0.254"),
+		DNSServers:        network.NewAddresses("10.0.0.2"),
+		VLANTag:           2,
+		ConfigType:        network.ConfigStatic,
+	}})
+
+	c.Assert(s.containerPortAPI.trunks, jc.DeepEquals, []openstack.NeutronTrunk{{
+		Id:     "trunk-1",
+		PortId: "host-port",
+		SubPorts: []openstack.NeutronSubPort{
+			{PortId: "port-1", SegmentationType: "vlan", SegmentationId: 1},
+			{PortId: "port-2", SegmentationType: "vlan", SegmentationId: 2},
+		},
+	}})
+	// Each port is in the model's group and the container's own group.
+	for _, port := range s.containerPortAPI.created {
+		c.Check(port.SecurityGroups, gc.HasLen, 2)
+	}
+	c.Assert(s.machineGroupNames(c, env, "100/lxd/0"), gc.HasLen, 1)
+}
+
+func (s *localServerSuite) TestAllocateContainerAddressesUsesExistingTrunk(c *gc.C) {
+	env, hostId := s.containerEnviron(c)
+	s.containerPortAPI.trunks = []openstack.NeutronTrunk{{
+		Id:     "existing-trunk",
+		PortId: "host-port",
+		SubPorts: []openstack.NeutronSubPort{
+			{PortId: "other", SegmentationType: "vlan", SegmentationId: 1},
+		},
+	}}
+	result, err := env.(environs.Networking).AllocateContainerAddresses(
+		hostId, names.NewMachineTag("100/lxd/1"), []network.InterfaceInfo{{InterfaceName: "eth0"}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Assert(result[0].VLANTag, gc.Equals, 2)
+	c.Assert(s.containerPortAPI.trunks, gc.HasLen, 1)
+	c.Assert(s.containerPortAPI.trunks[0].SubPorts, gc.HasLen, 2)
+}
+
+func (s *localServerSuite) TestAllocateContainerAddressesCleansUpOnError(c *gc.C) {
+	env, hostId := s.containerEnviron(c)
+	// InstancePorts, Trunks, CreateTrunk and CreatePort succeed, and
+	// AddSubPort fails.
+	s.containerPortAPI.SetErrors(nil, nil, nil, nil, errors.New("trunks disabled"))
+	_, err := env.(environs.Networking).AllocateContainerAddresses(
+		hostId, names.NewMachineTag("100/lxd/0"), []network.InterfaceInfo{{InterfaceName: "eth0"}},
+	)
+	c.Assert(err, gc.ErrorMatches, `adding port "port-1" to trunk "trunk-1": trunks disabled`)
+	c.Assert(s.containerPortAPI.created, gc.HasLen, 0)
+	c.Assert(s.machineGroupNames(c, env, "100/lxd/0"), gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestReleaseContainerAddresses(c *gc.C) {
+	env, hostId := s.containerEnviron(c)
+	result, err := env.(environs.Networking).AllocateContainerAddresses(
+		hostId, names.NewMachineTag("100/lxd/0"), []network.InterfaceInfo{{InterfaceName: "eth0"}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.containerPortAPI.ResetCalls()
+
+	err = env.(environs.Networking).ReleaseContainerAddresses([]network.ProviderInterfaceInfo{{
+		InterfaceName: "eth0",
+		ProviderId:    result[0].ProviderId,
+		MACAddress:    result[0].MACAddress,
+	}, {
+		InterfaceName: "eth1",
+		ProviderId:    "gone",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.containerPortAPI.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Trunks", nil},
+		{"Port", []interface{}{"port-1"}},
+		{"RemoveSubPort", []interface{}{"trunk-1", "port-1"}},
+		{"DeletePort", []interface{}{"port-1"}},
+		{"Port", []interface{}{"gone"}},
+	})
+	c.Assert(s.containerPortAPI.trunks[0].SubPorts, gc.HasLen, 0)
+	c.Assert(s.containerPortAPI.created, gc.HasLen, 0)
+	c.Assert(s.machineGroupNames(c, env, "100/lxd/0"), gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestReleaseContainerAddressesKeepsSharedGroups(c *gc.C) {
+	env, hostId := s.containerEnviron(c)
+	for _, id := range []string{"100/lxd/0", "100/lxd/1"} {
+		_, err := env.(environs.Networking).AllocateContainerAddresses(
+			hostId, names.NewMachineTag(id), []network.InterfaceInfo{{InterfaceName: "eth0"}},
+		)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := env.(environs.Networking).ReleaseContainerAddresses([]network.ProviderInterfaceInfo{{
+		InterfaceName: "eth0",
+		ProviderId:    "port-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machineGroupNames(c, env, "100/lxd/0"), gc.HasLen, 0)
+	c.Assert(s.machineGroupNames(c, env, "100/lxd/1"), gc.HasLen, 1)
+	// The host's groups are left alone.
+	c.Assert(s.machineGroupNames(c, env, "100"), gc.HasLen, 1)
+}
+
+// fakeContainerPortAPI is an in-memory ContainerPortAPI. Every port it
+// creates has an address on the subnet "subnet-1", with the CIDR
+// 10.0.0.0/24.
+type fakeContainerPortAPI struct {
+	gitjujutesting.Stub
+	nextId  int
+	ports   map[instance.Id][]openstack.NeutronPort
+	created []openstack.NeutronPort
+	trunks  []openstack.NeutronTrunk
+}
+
+func newFakeContainerPortAPI() *fakeContainerPortAPI {
+	return &fakeContainerPortAPI{ports: make(map[instance.Id][]openstack.NeutronPort)}
+}
+
+func (f *fakeContainerPortAPI) InstancePorts(id instance.Id) ([]openstack.NeutronPort, error) {
+	f.MethodCall(f, "InstancePorts", id)
+	return f.ports[id], f.NextErr()
+}
+
+func (f *fakeContainerPortAPI) Port(id string) (openstack.NeutronPort, error) {
+	f.MethodCall(f, "Port", id)
+	if err := f.NextErr(); err != nil {
+		return openstack.NeutronPort{}, err
+	}
+	for _, port := range f.created {
+		if port.Id == id {
+			return port, nil
+		}
+	}
+	return openstack.NeutronPort{}, errors.NotFoundf("port %q", id)
+}
+
+func (f *fakeContainerPortAPI) CreatePort(name, networkId string, groupIds []string) (openstack.NeutronPort, error) {
+	f.MethodCall(f, "CreatePort", name, networkId, groupIds)
+	if err := f.NextErr(); err != nil {
+		return openstack.NeutronPort{}, err
+	}
+	f.nextId++
+	port := openstack.NeutronPort{
+		Id:         fmt.Sprintf("port-%d", f.nextId),
+		Name:       name,
+		NetworkId:  networkId,
+		MACAddress: fmt.Sprintf("fa:16:3e:00:00:%02x", f.nextId),
+		FixedIPs: []openstack.NeutronFixedIP{{
+			SubnetId:  "subnet-1",
+			IPAddress: fmt.Sprintf("10.0.0.%d", f.nextId),
+		}},
+		SecurityGroups: groupIds,
+	}
+	f.created = append(f.created, port)
+	return port, nil
+}
+
+func (f *fakeContainerPortAPI) DeletePort(id string) error {
+	f.MethodCall(f, "DeletePort", id)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	for i, port := range f.created {
+		if port.Id == id {
+			f.created = append(f.created[:i], f.created[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *fakeContainerPortAPI) Subnet(id string) (openstack.NeutronSubnet, error) {
+	f.MethodCall(f, "Subnet", id)
+	return openstack.NeutronSubnet{
+		Id:             id,
+		CIDR:           "10.0.0.0/24",
+		GatewayIP:      "10.0.0.254",
+		DNSNameservers: []string{"10.0.0.2"},
+	}, f.NextErr()
+}
+
+func (f *fakeContainerPortAPI) Trunks() ([]openstack.NeutronTrunk, error) {
+	f.MethodCall(f, "Trunks")
+	return f.trunks, f.NextErr()
+}
+
+func (f *fakeContainerPortAPI) CreateTrunk(name, portId string) (openstack.NeutronTrunk, error) {
+	f.MethodCall(f, "CreateTrunk", name, portId)
+	if err := f.NextErr(); err != nil {
+		return openstack.NeutronTrunk{}, err
+	}
+	trunk := openstack.NeutronTrunk{
+		Id:     fmt.Sprintf("trunk-%d", len(f.trunks)+1),
+		PortId: portId,
+	}
+	f.trunks = append(f.trunks, trunk)
+	return trunk, nil
+}
+
+func (f *fakeContainerPortAPI) trunk(id string) *openstack.NeutronTrunk {
+	for i := range f.trunks {
+		if f.trunks[i].Id == id {
+			return &f.trunks[i]
+		}
+	}
+	return nil
+}
+
+func (f *fakeContainerPortAPI) AddSubPort(trunkId string, subPort openstack.NeutronSubPort) error {
+	f.MethodCall(f, "AddSubPort", trunkId, subPort)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	trunk := f.trunk(trunkId)
+	trunk.SubPorts = append(trunk.SubPorts, subPort)
+	return nil
+}
+
+func (f *fakeContainerPortAPI) RemoveSubPort(trunkId, portId string) error {
+	f.MethodCall(f, "RemoveSubPort", trunkId, portId)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	trunk := f.trunk(trunkId)
+	for i, subPort := range trunk.SubPorts {
+		if subPort.PortId == portId {
+			trunk.SubPorts = append(trunk.SubPorts[:i], trunk.SubPorts[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 	"regexp"
 	"strings"
 	"text/template"
+	"time"
 
+	"github.com/juju/utils/clock"
 	"gopkg.in/goose.v2/errors"
 	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/neutron"
@@ -74,6 +76,12 @@ var (
 	NovaListAvailabilityZones   = &novaListAvailabilityZones
 	AvailabilityZoneAllocations = &availabilityZoneAllocations
 	NewOpenstackStorage         = &newOpenstackStorage
+	NewKeyPairAPI               = &newKeyPairAPI
+	NewFirewallAPI              = &newFirewallAPI
+	NewContainerPortAPI         = &newContainerPortAPI
+	ServerAction                = &serverAction
+	SecurityGroupsInUse         = &securityGroupsInUse
+	DetachSecurityGroup         = &detachSecurityGroup
 )
 
 func NewCinderVolumeSource(s OpenstackStorage) storage.VolumeSource {
@@ -87,6 +95,11 @@ func NewCinderVolumeSourceForModel(s OpenstackStorage, modelUUID string) storage
 		envName:        envName,
 		modelUUID:      modelUUID,
 		namespace:      fakeNamespace{},
+		retry: retryStrategy{
+			Attempts: 1,
+			Delay:    time.Millisecond,
+			Clock:    clock.WallClock,
+		},
 	}
 }
 
@@ -414,10 +427,16 @@ func RemoveTestImageData(stor envstorage.Storage) {
 	stor.RemoveAll()
 }
 
+// InvalidateSecurityGroups discards the environ's cached security groups.
+func InvalidateSecurityGroups(e environs.Environ) {
+	e.(*Environ).securityGroups().invalidate()
+}
+
 // DiscardSecurityGroup cleans up a security group, it is not an error to
 // delete something that doesn't exist.
 func DiscardSecurityGroup(e environs.Environ, name string) error {
 	env := e.(*Environ)
+	defer env.securityGroups().invalidate()
 	neutronClient := env.neutron()
 	groups, err := neutronClient.SecurityGroupByNameV2(name)
 	if err != nil || len(groups) == 0 {
@@ -446,7 +465,7 @@ func FindInstanceSpec(
 		Arches:      []string{arch},
 		Region:      env.cloud.Region,
 		Constraints: constraints.MustParse(cons),
-	}, imageMetadata)
+	}, imageMetadata, nil)
 }
 
 func SetUpGlobalGroup(e environs.Environ, name string, apiPort int) (neutron.SecurityGroupV2, error) {
@@ -454,7 +473,7 @@ func SetUpGlobalGroup(e environs.Environ, name string, apiPort int) (neutron.Sec
 	if err := switching.initFirewaller(); err != nil {
 		return neutron.SecurityGroupV2{}, err
 	}
-	return switching.fw.(*neutronFirewaller).setUpGlobalGroup(name, apiPort)
+	return switching.fw.(*neutronFirewaller).setUpGlobalGroup(name, nil, apiPort)
 }
 
 func EnsureGroup(e environs.Environ, name string, rules []neutron.RuleInfoV2) (neutron.SecurityGroupV2, error) {
@@ -462,7 +481,7 @@ func EnsureGroup(e environs.Environ, name string, rules []neutron.RuleInfoV2) (n
 	if err := switching.initFirewaller(); err != nil {
 		return neutron.SecurityGroupV2{}, err
 	}
-	return switching.fw.(*neutronFirewaller).ensureGroup(name, rules)
+	return switching.fw.(*neutronFirewaller).ensureGroup(name, nil, rules)
 }
 
 func MachineGroupRegexp(e environs.Environ, machineId string) string {
@@ -480,7 +499,7 @@ func MatchingGroup(e environs.Environ, nameRegExp string) (neutron.SecurityGroup
 	if err := switching.initFirewaller(); err != nil {
 		return neutron.SecurityGroupV2{}, err
 	}
-	return switching.fw.(*neutronFirewaller).matchingGroup(nameRegExp)
+	return switching.fw.(*neutronFirewaller).matchingGroup(groupSelector{nameRegexp: nameRegExp})
 }
 
 // ImageMetadataStorage returns a Storage object pointing where the goose
@@ -527,6 +546,12 @@ func ResolveNetwork(e environs.Environ, networkName string, external bool) (stri
 	return e.(*Environ).networking.ResolveNetwork(networkName, external)
 }
 
+// DefaultNetworks returns the networks that new instances of the
+// environ are brought up on.
+func DefaultNetworks(e environs.Environ) ([]nova.ServerNetworks, error) {
+	return e.(*Environ).networking.DefaultNetworks()
+}
+
 var PortsToRuleInfo = rulesToRuleInfo
 var SecGroupMatchesIngressRule = secGroupMatchesIngressRule
 
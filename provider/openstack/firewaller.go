@@ -5,18 +5,23 @@ package openstack
 
 import (
 	"fmt"
+	"net"
+	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/juju/errors"
-	"github.com/juju/retry"
-	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
 	"gopkg.in/goose.v2/neutron"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 )
@@ -49,8 +54,11 @@ type Firewaller interface {
 	IngressRules() ([]network.IngressRule, error)
 
 	// DeleteAllModelGroups deletes all security groups for the
-	// model.
-	DeleteAllModelGroups() error
+	// model. If force is true, the groups are first detached from
+	// any ports still using them, where the firewaller supports it.
+	// If any group cannot be deleted, an error satisfying
+	// IsSecurityGroupsNotDeletedError is returned.
+	DeleteAllModelGroups(force bool) error
 
 	// DeleteAllControllerGroups deletes all security groups for the
 	// controller, ie those for all hosted models.
@@ -59,6 +67,10 @@ type Firewaller interface {
 	// DeleteGroups deletes the security groups with the specified names.
 	DeleteGroups(names ...string) error
 
+	// MachineSecurityGroups returns the per-machine security groups
+	// of the model, and whether each is attached to any port.
+	MachineSecurityGroups() ([]environs.MachineSecurityGroup, error)
+
 	// UpdateGroupController updates all of the security groups for
 	// this model to refer to the specified controller, such that
 	// DeleteAllControllerGroups will remove them only when called
@@ -70,8 +82,9 @@ type Firewaller interface {
 	GetSecurityGroups(ids ...instance.Id) ([]string, error)
 
 	// SetUpGroups sets up initial security groups, if any, and returns
-	// their names.
-	SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error)
+	// their names. The application names are those of the applications
+	// with units to be deployed to the machine.
+	SetUpGroups(controllerUUID, machineId string, applicationNames []string, apiPort int) ([]string, error)
 
 	// OpenInstancePorts opens the given port ranges for the specified  instance.
 	OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error
@@ -81,6 +94,77 @@ type Firewaller interface {
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
 	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+
+	// OpenApplicationPorts opens the given port ranges in the
+	// application's security group, and so on all the machines
+	// running the application. It requires application-security-groups
+	// to be enabled.
+	OpenApplicationPorts(applicationName string, rules []network.IngressRule) error
+
+	// CloseApplicationPorts closes the given port ranges in the
+	// application's security group.
+	CloseApplicationPorts(applicationName string, rules []network.IngressRule) error
+
+	// ApplicationIngressRules returns the ingress rules applied to the
+	// application's security group.
+	ApplicationIngressRules(applicationName string) ([]network.IngressRule, error)
+
+	// DeleteApplicationGroup deletes the application's security group,
+	// if it has one. It is called when the application is removed.
+	DeleteApplicationGroup(applicationName string) error
+
+	// OpenEgressPorts allows outgoing traffic to the given port ranges
+	// for the whole environment.
+	OpenEgressPorts(rules []network.EgressRule) error
+
+	// CloseEgressPorts stops allowing outgoing traffic to the given
+	// port ranges for the whole environment.
+	CloseEgressPorts(rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the whole
+	// environment. Only rules with a protocol are reported; the
+	// catch-all rules added by default when egress is not restricted
+	// are not.
+	EgressRules() ([]network.EgressRule, error)
+
+	// OpenInstanceEgressPorts allows outgoing traffic to the given
+	// port ranges for the specified instance.
+	OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// CloseInstanceEgressPorts stops allowing outgoing traffic to the
+	// given port ranges for the specified instance.
+	CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// InstanceEgressRules returns the egress rules applied to the
+	// specified instance.
+	InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error)
+
+	// UpdateAllowedIngress updates the rules allowing SSH and API
+	// traffic to the model's machines to match the model's
+	// allowed-ingress-cidrs config.
+	UpdateAllowedIngress() error
+
+	// SetUpContainerGroups sets up the security groups for the
+	// container with the given machine ID, whose host has already
+	// been started, and returns their IDs.
+	SetUpContainerGroups(controllerUUID, containerId string) ([]string, error)
+
+	// DeleteContainerGroups deletes those of the security groups with
+	// the given IDs that were set up for a single container by
+	// SetUpContainerGroups.
+	DeleteContainerGroups(ids ...string) error
+
+	// OpenContainerPorts opens the given port ranges in the security
+	// group of the container with the given machine ID.
+	OpenContainerPorts(containerId string, rules []network.IngressRule) error
+
+	// CloseContainerPorts closes the given port ranges in the security
+	// group of the container with the given machine ID.
+	CloseContainerPorts(containerId string, rules []network.IngressRule) error
+
+	// ContainerIngressRules returns the ingress rules applied to the
+	// container with the given machine ID.
+	ContainerIngressRules(containerId string) ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -91,17 +175,22 @@ func (f *firewallerFactory) GetFirewaller(env environs.Environ) Firewaller {
 	return &switchingFirewaller{env: env.(*Environ)}
 }
 
+// switchingFirewaller defers to the Firewaller implementation selected
+// by the firewall-implementation model config, which is chosen when
+// first needed and again whenever the config changes.
 type switchingFirewaller struct {
 	env *Environ
 
-	mu sync.Mutex
-	fw Firewaller
+	mu     sync.Mutex
+	fw     Firewaller
+	fwName string
 }
 
 func (f *switchingFirewaller) initFirewaller() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	if f.fw != nil {
+	name := f.env.ecfg().firewallImplementation()
+	if f.fw != nil && f.fwName == name {
 		return nil
 	}
 
@@ -112,12 +201,20 @@ func (f *switchingFirewaller) initFirewaller() error {
 		}
 	}
 
-	base := firewallerBase{environ: f.env}
-	if f.env.supportsNeutron() {
-		f.fw = &neutronFirewaller{base}
-	} else {
-		f.fw = &legacyNovaFirewaller{base}
+	fwName := name
+	if fwName == FirewallerAuto {
+		fwName = FirewallerNova
+		if f.env.supportsNeutron() {
+			fwName = FirewallerNeutron
+		}
 	}
+	newFirewaller, err := registeredFirewaller(fwName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("using %s firewaller", fwName)
+	f.fw = newFirewaller(f.env)
+	f.fwName = name
 	return nil
 }
 
@@ -142,11 +239,11 @@ func (f *switchingFirewaller) IngressRules() ([]network.IngressRule, error) {
 	return f.fw.IngressRules()
 }
 
-func (f *switchingFirewaller) DeleteAllModelGroups() error {
+func (f *switchingFirewaller) DeleteAllModelGroups(force bool) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.DeleteAllModelGroups()
+	return f.fw.DeleteAllModelGroups(force)
 }
 
 func (f *switchingFirewaller) DeleteAllControllerGroups(controllerUUID string) error {
@@ -163,6 +260,13 @@ func (f *switchingFirewaller) DeleteGroups(names ...string) error {
 	return f.fw.DeleteGroups(names...)
 }
 
+func (f *switchingFirewaller) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.MachineSecurityGroups()
+}
+
 func (f *switchingFirewaller) UpdateGroupController(controllerUUID string) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
@@ -177,11 +281,11 @@ func (f *switchingFirewaller) GetSecurityGroups(ids ...instance.Id) ([]string, e
 	return f.fw.GetSecurityGroups(ids...)
 }
 
-func (f *switchingFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
+func (f *switchingFirewaller) SetUpGroups(controllerUUID, machineId string, applicationNames []string, apiPort int) ([]string, error) {
 	if err := f.initFirewaller(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return f.fw.SetUpGroups(controllerUUID, machineId, apiPort)
+	return f.fw.SetUpGroups(controllerUUID, machineId, applicationNames, apiPort)
 }
 
 func (f *switchingFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
@@ -205,6 +309,118 @@ func (f *switchingFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return f.fw.InstanceIngressRules(inst, machineId)
 }
 
+func (f *switchingFirewaller) OpenApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenApplicationPorts(applicationName, rules)
+}
+
+func (f *switchingFirewaller) CloseApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseApplicationPorts(applicationName, rules)
+}
+
+func (f *switchingFirewaller) ApplicationIngressRules(applicationName string) ([]network.IngressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.ApplicationIngressRules(applicationName)
+}
+
+func (f *switchingFirewaller) DeleteApplicationGroup(applicationName string) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.DeleteApplicationGroup(applicationName)
+}
+
+func (f *switchingFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenEgressPorts(rules)
+}
+
+func (f *switchingFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseEgressPorts(rules)
+}
+
+func (f *switchingFirewaller) EgressRules() ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.EgressRules()
+}
+
+func (f *switchingFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenInstanceEgressPorts(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseInstanceEgressPorts(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.InstanceEgressRules(inst, machineId)
+}
+
+func (f *switchingFirewaller) UpdateAllowedIngress() error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.UpdateAllowedIngress()
+}
+
+func (f *switchingFirewaller) SetUpContainerGroups(controllerUUID, containerId string) ([]string, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.SetUpContainerGroups(controllerUUID, containerId)
+}
+
+func (f *switchingFirewaller) DeleteContainerGroups(ids ...string) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.DeleteContainerGroups(ids...)
+}
+
+func (f *switchingFirewaller) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenContainerPorts(containerId, rules)
+}
+
+func (f *switchingFirewaller) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseContainerPorts(containerId, rules)
+}
+
+func (f *switchingFirewaller) ContainerIngressRules(containerId string) ([]network.IngressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.ContainerIngressRules(containerId)
+}
+
 type firewallerBase struct {
 	environ *Environ
 }
@@ -280,6 +496,43 @@ func deleteSecurityGroupsOneOfNames(
 	return deleteSecurityGroups(match)
 }
 
+// SecurityGroupsNotDeletedError is returned when one or more security
+// groups could not be deleted, most likely because they are still in
+// use. Errors holds the error for each group, keyed by name.
+type SecurityGroupsNotDeletedError struct {
+	Errors map[string]error
+}
+
+// Error is part of the error interface.
+func (e *SecurityGroupsNotDeletedError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	what := "security groups"
+	if len(names) == 1 {
+		what = "security group"
+	}
+	return fmt.Sprintf("%d %s could not be removed: %s", len(names), what, strings.Join(names, ", "))
+}
+
+// IsSecurityGroupsNotDeletedError reports whether the error was caused
+// by security groups that could not be deleted.
+func IsSecurityGroupsNotDeletedError(err error) bool {
+	_, ok := errors.Cause(err).(*SecurityGroupsNotDeletedError)
+	return ok
+}
+
+// securityGroupsNotDeleted returns a *SecurityGroupsNotDeletedError
+// for the failed deletions, or nil if there are none.
+func securityGroupsNotDeleted(failed map[string]error) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return &SecurityGroupsNotDeletedError{Errors: failed}
+}
+
 // deleteSecurityGroup attempts to delete the security group. Should it fail,
 // the deletion is retried due to timing issues in openstack. A security group
 // cannot be deleted while it is in use. Theoretically we terminate all the
@@ -292,40 +545,31 @@ func deleteSecurityGroupsOneOfNames(
 func deleteSecurityGroup(
 	deleteSecurityGroupById func(string) error,
 	name, id string,
-	clock clock.Clock,
-) {
+	strategy retryStrategy,
+) error {
 	logger.Debugf("deleting security group %q", name)
-	err := retry.Call(retry.CallArgs{
-		Func: func() error {
+	err := strategy.call(
+		fmt.Sprintf("deleting security group %q", name),
+		func() error {
 			return deleteSecurityGroupById(id)
 		},
-		NotifyFunc: func(err error, attempt int) {
-			if attempt%4 == 0 {
-				message := fmt.Sprintf("waiting to delete security group %q", name)
-				if attempt != 4 {
-					message = "still " + message
-				}
-				logger.Debugf(message)
-			}
-		},
-		Attempts: 30,
-		Delay:    time.Second,
-		Clock:    clock,
-	})
+	)
 	if err != nil {
-		logger.Warningf("cannot delete security group %q. Used by another model?", name)
+		logger.Warningf("cannot delete security group %q. Used by another model? %v", name, err)
+		return errors.Trace(err)
 	}
+	return nil
 }
 
 func (c *firewallerBase) openPorts(
-	openPortsInGroup func(string, []network.IngressRule) error,
+	openPortsInGroup func(groupSelector, []network.IngressRule) error,
 	rules []network.IngressRule,
 ) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := openPortsInGroup(c.globalGroupRegexp(), rules); err != nil {
+	if err := openPortsInGroup(c.globalGroupSelector(), rules); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Infof("opened ports in global group: %v", rules)
@@ -333,14 +577,14 @@ func (c *firewallerBase) openPorts(
 }
 
 func (c *firewallerBase) closePorts(
-	closePortsInGroup func(string, []network.IngressRule) error,
+	closePortsInGroup func(groupSelector, []network.IngressRule) error,
 	rules []network.IngressRule,
 ) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for closing ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := closePortsInGroup(c.globalGroupRegexp(), rules); err != nil {
+	if err := closePortsInGroup(c.globalGroupSelector(), rules); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Infof("closed ports in global group: %v", rules)
@@ -348,22 +592,21 @@ func (c *firewallerBase) closePorts(
 }
 
 func (c *firewallerBase) ingressRules(
-	ingressRulesInGroup func(string) ([]network.IngressRule, error),
+	ingressRulesInGroup func(groupSelector) ([]network.IngressRule, error),
 ) ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ingress rules from model",
 			c.environ.Config().FirewallMode())
 	}
-	return ingressRulesInGroup(c.globalGroupRegexp())
+	return ingressRulesInGroup(c.globalGroupSelector())
 }
 
 func (c *firewallerBase) openInstancePorts(
-	openPortsInGroup func(string, []network.IngressRule) error,
+	openPortsInGroup func(groupSelector, []network.IngressRule) error,
 	machineId string,
 	rules []network.IngressRule,
 ) error {
-	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := openPortsInGroup(nameRegexp, rules); err != nil {
+	if err := openPortsInGroup(c.machineGroupSelector(machineId), rules); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Infof("opened ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
@@ -371,12 +614,11 @@ func (c *firewallerBase) openInstancePorts(
 }
 
 func (c *firewallerBase) closeInstancePorts(
-	closePortsInGroup func(string, []network.IngressRule) error,
+	closePortsInGroup func(groupSelector, []network.IngressRule) error,
 	machineId string,
 	rules []network.IngressRule,
 ) error {
-	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := closePortsInGroup(nameRegexp, rules); err != nil {
+	if err := closePortsInGroup(c.machineGroupSelector(machineId), rules); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Infof("closed ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
@@ -384,11 +626,10 @@ func (c *firewallerBase) closeInstancePorts(
 }
 
 func (c *firewallerBase) instanceIngressRules(
-	ingressRulesInGroup func(string) ([]network.IngressRule, error),
+	ingressRulesInGroup func(groupSelector) ([]network.IngressRule, error),
 	machineId string,
 ) ([]network.IngressRule, error) {
-	nameRegexp := c.machineGroupRegexp(machineId)
-	portRanges, err := ingressRulesInGroup(nameRegexp)
+	portRanges, err := ingressRulesInGroup(c.machineGroupSelector(machineId))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -403,6 +644,10 @@ func (c *firewallerBase) machineGroupName(controllerUUID, machineId string) stri
 	return fmt.Sprintf("%s-%s", c.jujuGroupName(controllerUUID), machineId)
 }
 
+func (c *firewallerBase) applicationGroupName(controllerUUID, applicationName string) string {
+	return fmt.Sprintf("%s-app-%s", c.jujuGroupName(controllerUUID), applicationName)
+}
+
 func (c *firewallerBase) jujuGroupName(controllerUUID string) string {
 	cfg := c.environ.Config()
 	return fmt.Sprintf("juju-%v-%v", controllerUUID, cfg.UUID())
@@ -426,6 +671,71 @@ func (c *firewallerBase) machineGroupRegexp(machineId string) string {
 	return fmt.Sprintf("%s-%s$", c.jujuGroupRegexp(), machineId)
 }
 
+func (c *firewallerBase) applicationGroupRegexp(applicationName string) string {
+	return fmt.Sprintf("%s-app-%s$", c.jujuGroupRegexp(), regexp.QuoteMeta(applicationName))
+}
+
+// groupTags returns the tags for a security group of the given kind
+// belonging to the model. Machine groups are also tagged with the
+// machine ID and application groups with the application name, which
+// are given as id. All groups are tagged with the controller UUID if
+// it is given.
+func (c *firewallerBase) groupTags(controllerUUID, kind, id string) map[string]string {
+	groupTags := map[string]string{
+		tags.JujuModel:   c.environ.Config().UUID(),
+		jujuGroupKindTag: kind,
+	}
+	if controllerUUID != "" {
+		groupTags[tags.JujuController] = controllerUUID
+	}
+	switch kind {
+	case groupKindMachine:
+		groupTags[tags.JujuMachine] = id
+	case groupKindApplication:
+		groupTags[jujuApplicationTag] = id
+	}
+	return groupTags
+}
+
+// allowedIngressCIDRs returns the CIDRs from which the SSH and API
+// ports may be reached, or anywhere if none are configured.
+func (c *firewallerBase) allowedIngressCIDRs(anywhere []string) []string {
+	if cidrs := c.environ.ecfg().allowedIngressCIDRs(); len(cidrs) > 0 {
+		return cidrs
+	}
+	return anywhere
+}
+
+// modelGroupSelector returns the selector of the juju group, which all
+// of the model's machines are in.
+func (c *firewallerBase) modelGroupSelector() groupSelector {
+	return groupSelector{
+		tags:       c.groupTags("", groupKindModel, ""),
+		nameRegexp: c.jujuGroupRegexp() + "$",
+	}
+}
+
+func (c *firewallerBase) globalGroupSelector() groupSelector {
+	return groupSelector{
+		tags:       c.groupTags("", groupKindGlobal, ""),
+		nameRegexp: c.globalGroupRegexp(),
+	}
+}
+
+func (c *firewallerBase) machineGroupSelector(machineId string) groupSelector {
+	return groupSelector{
+		tags:       c.groupTags("", groupKindMachine, machineId),
+		nameRegexp: c.machineGroupRegexp(machineId),
+	}
+}
+
+func (c *firewallerBase) applicationGroupSelector(applicationName string) groupSelector {
+	return groupSelector{
+		tags:       c.groupTags("", groupKindApplication, applicationName),
+		nameRegexp: c.applicationGroupRegexp(applicationName),
+	}
+}
+
 type neutronFirewaller struct {
 	firewallerBase
 }
@@ -441,118 +751,349 @@ type neutronFirewaller struct {
 // Note: ideally we'd have a better way to determine group membership so that 2
 // people that happen to share an openstack account and name their environment
 // "openstack" don't end up destroying each other's machines.
-func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
-	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort)
+//
+// When the model's egress policy is restricted, the juju group only
+// allows outgoing traffic to other machines in the model and to the
+// ports Juju needs, and the model's egress rules are applied to the
+// machine group or the global group, according to the firewall mode.
+//
+// When application-security-groups is enabled, the machine is also
+// added to a group for each of the given applications, so that an
+// application's ports can be opened on all of its machines at once.
+func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, applicationNames []string, apiPort int) ([]string, error) {
+	jujuGroup, err := c.setUpGlobalGroup(
+		c.jujuGroupName(controllerUUID),
+		c.groupTags(controllerUUID, groupKindModel, ""),
+		apiPort,
+	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	var machineGroup neutron.SecurityGroupV2
-	switch c.environ.Config().FirewallMode() {
-	case config.FwInstance:
-		machineGroup, err = c.ensureGroup(c.machineGroupName(controllerUUID, machineId), nil)
-	case config.FwGlobal:
-		machineGroup, err = c.ensureGroup(c.globalGroupName(controllerUUID), nil)
-	}
+	machineGroup, err := c.setUpMachineGroup(controllerUUID, machineId)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	groups := []string{jujuGroup.Name, machineGroup.Name}
+	if c.environ.ecfg().applicationSecurityGroups() {
+		for _, applicationName := range applicationNames {
+			applicationGroup, err := c.ensureGroup(
+				c.applicationGroupName(controllerUUID, applicationName),
+				c.groupTags(controllerUUID, groupKindApplication, applicationName),
+				nil,
+			)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			groups = append(groups, applicationGroup.Name)
+		}
+	}
 	if c.environ.ecfg().useDefaultSecurityGroup() {
 		groups = append(groups, "default")
 	}
 	return groups, nil
 }
 
-func (c *neutronFirewaller) setUpGlobalGroup(groupName string, apiPort int) (neutron.SecurityGroupV2, error) {
-	return c.ensureGroup(groupName,
-		[]neutron.RuleInfoV2{
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   22,
-				PortRangeMin:   22,
-				RemoteIPPrefix: "::/0",
-				EthernetType:   "IPv6",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   22,
-				PortRangeMin:   22,
-				RemoteIPPrefix: "0.0.0.0/0",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   apiPort,
-				PortRangeMin:   apiPort,
-				RemoteIPPrefix: "::/0",
-				EthernetType:   "IPv6",
-			},
-			{
+// setUpMachineGroup ensures that the group holding the firewall rules
+// of the machine with the given ID exists, and returns it. In global
+// firewall mode, that is the group shared by all of the model's
+// machines.
+func (c *neutronFirewaller) setUpMachineGroup(controllerUUID, machineId string) (neutron.SecurityGroupV2, error) {
+	var egressRules []neutron.RuleInfoV2
+	if c.egressRestricted() {
+		rules, err := c.environ.ecfg().egressRules()
+		if err != nil {
+			return zeroGroup, errors.Trace(err)
+		}
+		egressRules = egressRulesToRuleInfo("", rules)
+	}
+	if c.environ.Config().FirewallMode() == config.FwGlobal {
+		return c.ensureGroup(
+			c.globalGroupName(controllerUUID),
+			c.groupTags(controllerUUID, groupKindGlobal, ""),
+			egressRules,
+		)
+	}
+	return c.ensureGroup(
+		c.machineGroupName(controllerUUID, machineId),
+		c.groupTags(controllerUUID, groupKindMachine, machineId),
+		egressRules,
+	)
+}
+
+// SetUpContainerGroups implements Firewaller interface. The container
+// is put in the model's group, which already exists as the host has
+// been started, and in a machine group of its own, so that the ports
+// opened for it are not opened on its host.
+func (c *neutronFirewaller) SetUpContainerGroups(controllerUUID, containerId string) ([]string, error) {
+	jujuGroup, err := c.matchingGroup(c.modelGroupSelector())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot find model security group")
+	}
+	containerGroup, err := c.setUpMachineGroup(controllerUUID, containerId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := []string{jujuGroup.Id, containerGroup.Id}
+	if c.environ.ecfg().useDefaultSecurityGroup() {
+		defaultGroups, err := c.environ.neutron().SecurityGroupByNameV2("default")
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot find default security group")
+		}
+		for _, group := range defaultGroups {
+			ids = append(ids, group.Id)
+		}
+	}
+	return ids, nil
+}
+
+// DeleteContainerGroups implements Firewaller interface. Only the
+// model's machine groups belonging to containers are deleted; the
+// other groups are shared with other machines.
+func (c *neutronFirewaller) DeleteContainerGroups(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	match, err := c.modelGroupMatcher()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	deleteIds := set.NewStrings(ids...)
+	return c.deleteMatchingGroups(func(group neutron.SecurityGroupV2) bool {
+		if !deleteIds.Contains(group.Id) || !match(group) {
+			return false
+		}
+		groupTags := securityGroupTags(group)
+		return groupTags != nil &&
+			groupTags[jujuGroupKindTag] == groupKindMachine &&
+			names.IsContainerMachine(groupTags[tags.JujuMachine])
+	}, false)
+}
+
+// OpenContainerPorts implements Firewaller interface. The ports are
+// opened in the container's own machine group, which is set up by
+// SetUpContainerGroups.
+func (c *neutronFirewaller) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for opening ports on container",
+			c.environ.Config().FirewallMode())
+	}
+	return c.openInstancePorts(c.openPortsInGroup, containerId, rules)
+}
+
+// CloseContainerPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for closing ports on container",
+			c.environ.Config().FirewallMode())
+	}
+	return c.closeInstancePorts(c.closePortsInGroup, containerId, rules)
+}
+
+// ContainerIngressRules implements Firewaller interface.
+func (c *neutronFirewaller) ContainerIngressRules(containerId string) ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving ingress rules from container",
+			c.environ.Config().FirewallMode())
+	}
+	return c.instanceIngressRules(c.ingressRulesInGroup, containerId)
+}
+
+// egressRestricted reports whether the model's egress policy replaces
+// Neutron's default egress rules.
+func (c *neutronFirewaller) egressRestricted() bool {
+	return c.environ.ecfg().egressPolicy() == egressPolicyRestricted
+}
+
+func (c *neutronFirewaller) setUpGlobalGroup(groupName string, groupTags map[string]string, apiPort int) (neutron.SecurityGroupV2, error) {
+	rules := c.allowedIngressRules(apiPort)
+	rules = append(rules, []neutron.RuleInfoV2{
+		{
+			Direction:    "ingress",
+			IPProtocol:   "tcp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "tcp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "udp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "udp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "icmp",
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:  "ingress",
+			IPProtocol: "icmp",
+		},
+	}...)
+	if c.egressRestricted() {
+		rules = append(rules, restrictedEgressRules(apiPort)...)
+	}
+	return c.ensureGroup(groupName, groupTags, rules)
+}
+
+// allowedIngressRules returns the rules of the juju group that allow
+// SSH and API traffic from the model's allowed ingress CIDRs.
+func (c *neutronFirewaller) allowedIngressRules(apiPort int) []neutron.RuleInfoV2 {
+	var rules []neutron.RuleInfoV2
+	for _, port := range []int{22, apiPort} {
+		for _, cidr := range c.allowedIngressCIDRs([]string{"::/0", "0.0.0.0/0"}) {
+			var ethernetType string
+			if ipVersion(cidr) == 6 {
+				ethernetType = "IPv6"
+			}
+			rules = append(rules, neutron.RuleInfoV2{
 				Direction:      "ingress",
 				IPProtocol:     "tcp",
-				PortRangeMax:   apiPort,
-				PortRangeMin:   apiPort,
-				RemoteIPPrefix: "0.0.0.0/0",
-			},
-			{
-				Direction:    "ingress",
-				IPProtocol:   "tcp",
-				PortRangeMin: 1,
-				PortRangeMax: 65535,
-				EthernetType: "IPv6",
-			},
-			{
-				Direction:    "ingress",
+				PortRangeMax:   port,
+				PortRangeMin:   port,
+				RemoteIPPrefix: cidr,
+				EthernetType:   ethernetType,
+			})
+		}
+	}
+	return rules
+}
+
+// UpdateAllowedIngress is part of the Firewaller interface.
+func (c *neutronFirewaller) UpdateAllowedIngress() error {
+	group, err := c.matchingGroup(c.modelGroupSelector())
+	if errors.IsNotFound(err) {
+		// No machines have been started yet; the group will be
+		// created with the allowed ingress rules.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	apiPort := 0
+	for _, rule := range group.Rules {
+		if rule.Direction == "ingress" && rule.IPProtocol != nil && *rule.IPProtocol == "tcp" &&
+			rule.RemoteIPPrefix != "" && rule.PortRangeMin != nil && rule.PortRangeMax != nil &&
+			*rule.PortRangeMin == *rule.PortRangeMax && *rule.PortRangeMin != 22 {
+			apiPort = *rule.PortRangeMin
+			break
+		}
+	}
+	if apiPort == 0 {
+		return errors.Errorf("cannot determine API port from security group %q", group.Name)
+	}
+	_, err = c.setUpGlobalGroup(group.Name, securityGroupTags(group), apiPort)
+	return errors.Trace(err)
+}
+
+// restrictedEgressRules returns the egress rules the juju group needs
+// when the egress policy is restricted: all traffic to other members
+// of the group, and DNS, NTP, HTTP, HTTPS and API traffic to anywhere.
+func restrictedEgressRules(apiPort int) []neutron.RuleInfoV2 {
+	var rules []neutron.RuleInfoV2
+	for _, ethernetType := range []string{"", "IPv6"} {
+		anywhere := "0.0.0.0/0"
+		if ethernetType == "IPv6" {
+			anywhere = "::/0"
+		}
+		// An empty RemoteIPPrefix refers to the group itself.
+		rules = append(rules,
+			neutron.RuleInfoV2{
+				Direction:    "egress",
 				IPProtocol:   "tcp",
 				PortRangeMin: 1,
 				PortRangeMax: 65535,
+				EthernetType: ethernetType,
 			},
-			{
-				Direction:    "ingress",
-				IPProtocol:   "udp",
-				PortRangeMin: 1,
-				PortRangeMax: 65535,
-				EthernetType: "IPv6",
-			},
-			{
-				Direction:    "ingress",
+			neutron.RuleInfoV2{
+				Direction:    "egress",
 				IPProtocol:   "udp",
 				PortRangeMin: 1,
 				PortRangeMax: 65535,
+				EthernetType: ethernetType,
 			},
-			{
-				Direction:    "ingress",
+			neutron.RuleInfoV2{
+				Direction:    "egress",
 				IPProtocol:   "icmp",
-				EthernetType: "IPv6",
-			},
-			{
-				Direction:  "ingress",
-				IPProtocol: "icmp",
+				EthernetType: ethernetType,
 			},
-		})
+		)
+		for _, portRange := range []network.PortRange{
+			{Protocol: "tcp", FromPort: 53, ToPort: 53},
+			{Protocol: "udp", FromPort: 53, ToPort: 53},
+			{Protocol: "udp", FromPort: 123, ToPort: 123},
+			{Protocol: "tcp", FromPort: 80, ToPort: 80},
+			{Protocol: "tcp", FromPort: 443, ToPort: 443},
+			{Protocol: "tcp", FromPort: apiPort, ToPort: apiPort},
+		} {
+			rules = append(rules, neutron.RuleInfoV2{
+				Direction:      "egress",
+				IPProtocol:     portRange.Protocol,
+				PortRangeMin:   portRange.FromPort,
+				PortRangeMax:   portRange.ToPort,
+				RemoteIPPrefix: anywhere,
+				EthernetType:   ethernetType,
+			})
+		}
+	}
+	return rules
 }
 
 // zeroGroup holds the zero security group.
 var zeroGroup neutron.SecurityGroupV2
 
-// ensureGroup returns the security group with name and rules.
-// If a group with name does not exist, one will be created.
-// If it exists, its permissions are set to rules.
-func (c *neutronFirewaller) ensureGroup(name string, rules []neutron.RuleInfoV2) (neutron.SecurityGroupV2, error) {
+// ensureGroup returns the security group with tags, or with name if
+// no group has the tags, and with rules. If no such group exists, one
+// will be created. If it exists, its permissions are set to rules, and
+// it is tagged if it was created before groups were tagged. Failures
+// are retried according to the environ's retry strategy.
+func (c *neutronFirewaller) ensureGroup(name string, groupTags map[string]string, rules []neutron.RuleInfoV2) (neutron.SecurityGroupV2, error) {
+	var group neutron.SecurityGroupV2
+	err := c.environ.retryStrategy().call(
+		fmt.Sprintf("ensuring security group %q", name),
+		func() (err error) {
+			group, err = c.ensureGroupOnce(name, groupTags, rules)
+			return err
+		},
+	)
+	if err != nil {
+		return zeroGroup, err
+	}
+	return group, nil
+}
+
+// ensureGroupOnce makes a single attempt at ensuring the group, as
+// described by ensureGroup.
+func (c *neutronFirewaller) ensureGroupOnce(name string, groupTags map[string]string, rules []neutron.RuleInfoV2) (neutron.SecurityGroupV2, error) {
 	neutronClient := c.environ.neutron()
+	defer c.environ.securityGroups().invalidate()
 	var group neutron.SecurityGroupV2
 
-	// First attempt to look up an existing group by name.
-	groupsFound, err := neutronClient.SecurityGroupByNameV2(name)
+	// First attempt to look up an existing group by its tags, in case
+	// it has been renamed, and then by name.
+	groupsFound, err := c.taggedGroups(groupTags)
+	if err == nil && len(groupsFound) == 0 {
+		groupsFound, err = neutronClient.SecurityGroupByNameV2(name)
+	}
 	// a list is returned, but there should be only one
 	if err == nil && len(groupsFound) == 1 {
 		group = groupsFound[0]
 	} else if err != nil && strings.Contains(err.Error(), "failed to find security group") {
 		// TODO(hml): We should use a typed error here.  SecurityGroupByNameV2
 		// doesn't currently return one for this case.
-		g, err := neutronClient.CreateSecurityGroupV2(name, "juju group")
+		g, err := neutronClient.CreateSecurityGroupV2(name, groupDescription(groupTags))
 		if err != nil {
 			return zeroGroup, err
 		}
@@ -563,6 +1104,13 @@ func (c *neutronFirewaller) ensureGroup(name string, rules []neutron.RuleInfoV2)
 	} else {
 		return zeroGroup, err
 	}
+	if len(groupTags) > 0 && securityGroupTags(group) == nil {
+		logger.Debugf("tagging security group %q", group.Name)
+		_, err := neutronClient.UpdateSecurityGroupV2(group.Id, group.Name, groupDescription(groupTags))
+		if err != nil {
+			return zeroGroup, errors.Annotatef(err, "cannot tag security group %q", group.Name)
+		}
+	}
 
 	have := newRuleInfoSetFromRules(group.Rules)
 	want := newRuleInfoSetFromRuleInfo(rules)
@@ -570,10 +1118,11 @@ func (c *neutronFirewaller) ensureGroup(name string, rules []neutron.RuleInfoV2)
 	// Find rules we want to delete, that we have but don't want, and
 	// delete them.
 	remove := make(ruleInfoSet)
+	restricted := c.egressRestricted()
 	for k := range have {
 		// Neutron creates 2 egress rules with any new Security Group.
-		// Keep them.
-		if _, ok := want[k]; !ok && k.Direction != "egress" {
+		// Keep them, unless the egress policy is restricted.
+		if _, ok := want[k]; !ok && (k.Direction != "egress" || restricted) {
 			remove[k] = have[k]
 		}
 	}
@@ -607,16 +1156,27 @@ func (c *neutronFirewaller) ensureGroup(name string, rules []neutron.RuleInfoV2)
 	// Since we may have done a few add or delete rules, get a new
 	// copy of the security group to return containing the end
 	// list of rules.
-	groupsFound, err = neutronClient.SecurityGroupByNameV2(name)
+	groupsFound, err = neutronClient.SecurityGroupByNameV2(group.Name)
 	if err != nil {
 		return zeroGroup, err
 	} else if len(groupsFound) > 1 {
 		// TODO(hml): Add unit test for this case
-		return zeroGroup, errors.New(fmt.Sprintf("More than one security group named %s was found after group was ensured", name))
+		return zeroGroup, errors.New(fmt.Sprintf("More than one security group named %s was found after group was ensured", group.Name))
 	}
 	return groupsFound[0], nil
 }
 
+// taggedGroups returns the security groups with all of the given tags.
+// No groups are returned if there are no tags.
+func (c *neutronFirewaller) taggedGroups(groupTags map[string]string) ([]neutron.SecurityGroupV2, error) {
+	if len(groupTags) == 0 {
+		return nil, nil
+	}
+	cache := c.environ.securityGroups()
+	cache.invalidate()
+	return cache.matching(groupSelector{tags: groupTags}.matchesTags)
+}
+
 // ruleInfoSet represents a Security Group Rule created for a Security Group.
 // The string will be the Security Group Rule Id, if the rule has previously been
 // created.
@@ -668,22 +1228,44 @@ func newRuleInfoSetFromRuleInfo(rules []neutron.RuleInfoV2) ruleInfoSet {
 }
 
 func (c *neutronFirewaller) deleteSecurityGroups(match func(name string) bool) error {
+	return c.deleteMatchingGroups(func(group neutron.SecurityGroupV2) bool {
+		return match(group.Name)
+	}, false)
+}
+
+// deleteMatchingGroups deletes the security groups for which match
+// returns true. If force is true, each group is first detached from
+// any ports still using it. Every matching group is tried, and those
+// that cannot be deleted are reported in a
+// *SecurityGroupsNotDeletedError.
+func (c *neutronFirewaller) deleteMatchingGroups(match func(neutron.SecurityGroupV2) bool, force bool) error {
 	neutronClient := c.environ.neutron()
 	securityGroups, err := neutronClient.ListSecurityGroupsV2()
 	if err != nil {
 		return errors.Annotate(err, "cannot list security groups")
 	}
+	defer c.environ.securityGroups().invalidate()
+	failed := make(map[string]error)
 	for _, group := range securityGroups {
-		if match(group.Name) {
-			deleteSecurityGroup(
-				neutronClient.DeleteSecurityGroupV2,
-				group.Name,
-				group.Id,
-				clock.WallClock,
-			)
+		if !match(group) {
+			continue
+		}
+		if force {
+			if err := detachSecurityGroup(c.environ, group.Id); err != nil {
+				failed[group.Name] = errors.Annotate(err, "detaching from ports")
+				continue
+			}
+		}
+		if err := deleteSecurityGroup(
+			neutronClient.DeleteSecurityGroupV2,
+			group.Name,
+			group.Id,
+			c.environ.retryStrategy(),
+		); err != nil {
+			failed[group.Name] = err
 		}
 	}
-	return nil
+	return securityGroupsNotDeleted(failed)
 }
 
 // DeleteGroups implements Firewaller interface.
@@ -693,12 +1275,165 @@ func (c *neutronFirewaller) DeleteGroups(names ...string) error {
 
 // DeleteAllControllerGroups implements Firewaller interface.
 func (c *neutronFirewaller) DeleteAllControllerGroups(controllerUUID string) error {
-	return deleteSecurityGroupsMatchingName(c.deleteSecurityGroups, c.jujuControllerGroupPrefix(controllerUUID))
-}
+	re, err := regexp.Compile("^" + c.jujuControllerGroupPrefix(controllerUUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerTags := map[string]string{tags.JujuController: controllerUUID}
+	return c.deleteMatchingGroups(func(group neutron.SecurityGroupV2) bool {
+		return re.MatchString(group.Name) || groupHasTags(group, controllerTags)
+	}, false)
+}
 
 // DeleteAllModelGroups implements Firewaller interface.
-func (c *neutronFirewaller) DeleteAllModelGroups() error {
-	return deleteSecurityGroupsMatchingName(c.deleteSecurityGroups, c.jujuGroupRegexp())
+func (c *neutronFirewaller) DeleteAllModelGroups(force bool) error {
+	match, err := c.modelGroupMatcher()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.deleteMatchingGroups(match, force)
+}
+
+// modelGroupMatcher returns a function reporting whether a security
+// group belongs to the model, by its tags or its name.
+func (c *neutronFirewaller) modelGroupMatcher() (func(neutron.SecurityGroupV2) bool, error) {
+	re, err := regexp.Compile(c.jujuGroupRegexp())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelTags := map[string]string{tags.JujuModel: c.environ.Config().UUID()}
+	return func(group neutron.SecurityGroupV2) bool {
+		if groupTags := securityGroupTags(group); groupTags != nil {
+			return tagsMatch(groupTags, modelTags)
+		}
+		return re.MatchString(group.Name)
+	}, nil
+}
+
+// groupHasTags reports whether the security group is tagged with all
+// of the given tags.
+func groupHasTags(group neutron.SecurityGroupV2, want map[string]string) bool {
+	groupTags := securityGroupTags(group)
+	return groupTags != nil && tagsMatch(groupTags, want)
+}
+
+// MachineSecurityGroups implements Firewaller interface. Machine groups
+// are identified by their tags or, for groups created before they were
+// tagged, by their names.
+func (c *neutronFirewaller) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	match, err := c.modelGroupMatcher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nameRe, err := regexp.Compile(c.jujuGroupRegexp() + "-([0-9]+)$")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups, err := c.environ.neutron().ListSecurityGroupsV2()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list security groups")
+	}
+	inUse, err := securityGroupsInUse(c.environ)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list ports")
+	}
+	var result []environs.MachineSecurityGroup
+	for _, group := range groups {
+		if !match(group) {
+			continue
+		}
+		var machineId string
+		if groupTags := securityGroupTags(group); groupTags != nil {
+			if groupTags[jujuGroupKindTag] != groupKindMachine {
+				continue
+			}
+			machineId = groupTags[tags.JujuMachine]
+		} else if m := nameRe.FindStringSubmatch(group.Name); m != nil {
+			machineId = m[1]
+		}
+		if machineId == "" {
+			continue
+		}
+		result = append(result, environs.MachineSecurityGroup{
+			Name:      group.Name,
+			MachineId: machineId,
+			InUse:     inUse.Contains(group.Id),
+		})
+	}
+	return result, nil
+}
+
+// securityGroupsInUse returns the IDs of the security groups attached
+// to any port. The goose neutron client does not support listing
+// ports, so the request is made directly.
+var securityGroupsInUse = func(e *Environ) (set.Strings, error) {
+	var resp struct {
+		Ports []struct {
+			SecurityGroups []string `json:"security_groups"`
+		} `json:"ports"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	err := e.client().SendRequest(client.GET, "network", "v2.0", "ports?fields=security_groups", &requestData)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	inUse := set.NewStrings()
+	for _, port := range resp.Ports {
+		for _, id := range port.SecurityGroups {
+			inUse.Add(id)
+		}
+	}
+	return inUse, nil
+}
+
+// detachSecurityGroup removes the security group from every port it
+// is attached to, so that it can be deleted. As with
+// securityGroupsInUse, the requests are made directly.
+var detachSecurityGroup = func(e *Environ, groupId string) error {
+	var resp struct {
+		Ports []struct {
+			Id             string   `json:"id"`
+			SecurityGroups []string `json:"security_groups"`
+		} `json:"ports"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	err := e.client().SendRequest(client.GET, "network", "v2.0", "ports?fields=id&fields=security_groups", &requestData)
+	if err != nil {
+		return errors.Annotate(err, "cannot list ports")
+	}
+	for _, port := range resp.Ports {
+		remaining := []string{}
+		for _, id := range port.SecurityGroups {
+			if id != groupId {
+				remaining = append(remaining, id)
+			}
+		}
+		if len(remaining) == len(port.SecurityGroups) {
+			continue
+		}
+		logger.Debugf("detaching security group %q from port %q", groupId, port.Id)
+		var req struct {
+			Port struct {
+				SecurityGroups []string `json:"security_groups"`
+			} `json:"port"`
+		}
+		req.Port.SecurityGroups = remaining
+		requestData := goosehttp.RequestData{
+			ReqValue:       req,
+			ExpectedStatus: []int{http.StatusOK},
+		}
+		err := e.client().SendRequest(client.PUT, "network", "v2.0", "ports/"+port.Id, &requestData)
+		if err != nil {
+			return errors.Annotatef(err, "cannot update port %q", port.Id)
+		}
+	}
+	return nil
 }
 
 // UpdateGroupController implements Firewaller interface.
@@ -708,14 +1443,15 @@ func (c *neutronFirewaller) UpdateGroupController(controllerUUID string) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	re, err := regexp.Compile(c.jujuGroupRegexp())
+	match, err := c.modelGroupMatcher()
 	if err != nil {
 		return errors.Trace(err)
 	}
+	defer c.environ.securityGroups().invalidate()
 
 	var failed []string
 	for _, group := range groups {
-		if !re.MatchString(group.Name) {
+		if !match(group) {
 			continue
 		}
 		err := c.updateGroupControllerUUID(&group, controllerUUID)
@@ -731,12 +1467,23 @@ func (c *neutronFirewaller) UpdateGroupController(controllerUUID string) error {
 }
 
 func (c *neutronFirewaller) updateGroupControllerUUID(group *neutron.SecurityGroupV2, controllerUUID string) error {
+	groupTags := securityGroupTags(*group)
 	newName, err := replaceControllerUUID(group.Name, controllerUUID)
 	if err != nil {
-		return errors.Trace(err)
+		if groupTags == nil {
+			return errors.Trace(err)
+		}
+		// The group has been renamed, so it is found by its
+		// tags alone; leave the name as it is.
+		newName = group.Name
+	}
+	description := group.Description
+	if groupTags != nil {
+		groupTags[tags.JujuController] = controllerUUID
+		description = groupDescription(groupTags)
 	}
 	client := c.environ.neutron()
-	_, err = client.UpdateSecurityGroupV2(group.Id, newName, group.Description)
+	_, err = client.UpdateSecurityGroupV2(group.Id, newName, description)
 	return errors.Trace(err)
 }
 
@@ -803,86 +1550,464 @@ func (c *neutronFirewaller) InstanceIngressRules(inst instance.Instance, machine
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenApplicationPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	if err := c.checkApplicationSecurityGroups(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.openPortsInGroup(c.applicationGroupSelector(applicationName), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in security group for application %q: %v", applicationName, rules)
+	return nil
+}
+
+// CloseApplicationPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	if err := c.checkApplicationSecurityGroups(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.closePortsInGroup(c.applicationGroupSelector(applicationName), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in security group for application %q: %v", applicationName, rules)
+	return nil
+}
+
+// ApplicationIngressRules implements Firewaller interface.
+func (c *neutronFirewaller) ApplicationIngressRules(applicationName string) ([]network.IngressRule, error) {
+	if err := c.checkApplicationSecurityGroups(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules, err := c.ingressRulesInGroup(c.applicationGroupSelector(applicationName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rules, nil
+}
+
+// DeleteApplicationGroup implements Firewaller interface.
+func (c *neutronFirewaller) DeleteApplicationGroup(applicationName string) error {
+	sel := c.applicationGroupSelector(applicationName)
+	re, err := regexp.Compile(sel.nameRegexp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.deleteMatchingGroups(func(group neutron.SecurityGroupV2) bool {
+		return sel.matchesTags(group) || sel.matchesName(re, group)
+	}, false)
+}
+
+// checkApplicationSecurityGroups returns an error if applications do
+// not have their own security groups.
+func (c *neutronFirewaller) checkApplicationSecurityGroups() error {
+	if !c.environ.ecfg().applicationSecurityGroups() {
+		return errors.NotSupportedf("application security groups without application-security-groups enabled")
+	}
+	return nil
+}
+
+// OpenEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for opening egress ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.openEgressPortsInGroup(c.globalGroupSelector(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress ports in global group: %v", rules)
+	return nil
+}
+
+// CloseEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for closing egress ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.closeEgressPortsInGroup(c.globalGroupSelector(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress ports in global group: %v", rules)
+	return nil
+}
+
+// EgressRules implements Firewaller interface.
+func (c *neutronFirewaller) EgressRules() ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from model",
+			c.environ.Config().FirewallMode())
+	}
+	return c.egressRulesInGroup(c.globalGroupSelector())
+}
+
+// OpenInstanceEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for opening egress ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See OpenInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	if err := c.openEgressPortsInGroup(c.machineGroupSelector(machineId), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// CloseInstanceEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for closing egress ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See CloseInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	if err := c.closeEgressPortsInGroup(c.machineGroupSelector(machineId), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// InstanceEgressRules implements Firewaller interface.
+func (c *neutronFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See InstanceIngressRules.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return []network.EgressRule{}, nil
+	}
+	rules, err := c.egressRulesInGroup(c.machineGroupSelector(machineId))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rules, nil
+}
+
 // Matching a security group by name only works if each name is unqiue.  Neutron
 // security groups are not required to have unique names.  Juju constructs unique
-// names, but there are frequently multiple matches to 'default'
-func (c *neutronFirewaller) matchingGroup(nameRegExp string) (neutron.SecurityGroupV2, error) {
-	re, err := regexp.Compile(nameRegExp)
+// names, but there are frequently multiple matches to 'default'.
+// Groups are matched by their tags first, so that they are still found if
+// renamed, and then by name for groups created before they were tagged.
+// The security groups are read from the Environ's cache.
+func (c *neutronFirewaller) matchingGroup(sel groupSelector) (neutron.SecurityGroupV2, error) {
+	re, err := regexp.Compile(sel.nameRegexp)
 	if err != nil {
 		return neutron.SecurityGroupV2{}, err
 	}
-	neutronClient := c.environ.neutron()
-	allGroups, err := neutronClient.ListSecurityGroupsV2()
+	cache := c.environ.securityGroups()
+	find := func() ([]neutron.SecurityGroupV2, error) {
+		groups, err := cache.matching(sel.matchesTags)
+		if err != nil || len(groups) > 0 {
+			return groups, err
+		}
+		return cache.matching(func(group neutron.SecurityGroupV2) bool {
+			return sel.matchesName(re, group)
+		})
+	}
+	matchingGroups, err := find()
+	if err == nil && len(matchingGroups) == 0 {
+		// The group may have been created since the security
+		// groups were cached.
+		cache.invalidate()
+		matchingGroups, err = find()
+	}
 	if err != nil {
 		return neutron.SecurityGroupV2{}, err
 	}
-	var matchingGroups []neutron.SecurityGroupV2
-	for _, group := range allGroups {
-		if re.MatchString(group.Name) {
-			matchingGroups = append(matchingGroups, group)
-		}
-	}
 	numMatching := len(matchingGroups)
 	if numMatching == 0 {
-		return neutron.SecurityGroupV2{}, errors.NotFoundf("security groups matching %q", nameRegExp)
+		return neutron.SecurityGroupV2{}, errors.NotFoundf("security groups matching %q", sel.nameRegexp)
 	} else if numMatching > 1 {
-		return neutron.SecurityGroupV2{}, errors.New(fmt.Sprintf("%d security groups found matching %q, expected 1", numMatching, nameRegExp))
+		return neutron.SecurityGroupV2{}, errors.New(fmt.Sprintf("%d security groups found matching %q, expected 1", numMatching, sel.nameRegexp))
 	}
 	return matchingGroups[0], nil
 }
 
-func (c *neutronFirewaller) openPortsInGroup(nameRegExp string, rules []network.IngressRule) error {
-	group, err := c.matchingGroup(nameRegExp)
+func (c *neutronFirewaller) openPortsInGroup(sel groupSelector, rules []network.IngressRule) error {
+	if err := checkICMPRules(rules); err != nil {
+		return errors.Trace(err)
+	}
+	group, err := c.matchingGroup(sel)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	rules = expandAnywhere(rules, c.anywherePrefixes())
+	return errors.Trace(c.createMissingRules(group, rulesToRuleInfo(group.Id, rules)))
+}
+
+// checkICMPRules returns an error if any of the rules is for ICMP type
+// or code 0. Security group rules hold the ICMP type and code in their
+// port range, where 0 is taken to mean any type or code.
+func checkICMPRules(rules []network.IngressRule) error {
+	for _, rule := range rules {
+		if rule.Protocol == "icmp" && (rule.FromPort == 0 || rule.ToPort == 0) {
+			return errors.NotSupportedf("ICMP type or code 0 in security group rule %v", rule)
+		}
+	}
+	return nil
+}
+
+// icmpTypeCode returns the ICMP type or code held in a security group
+// rule's port range minimum or maximum, which is any if not set.
+func icmpTypeCode(typeOrCode *int) int {
+	if typeOrCode == nil || *typeOrCode == 0 {
+		return network.AnyICMP
+	}
+	return *typeOrCode
+}
+
+// createMissingRules creates those of the given rules that the security
+// group does not already have. The rules are created concurrently, and
+// each is retried, according to the model's configuration.
+func (c *neutronFirewaller) createMissingRules(group neutron.SecurityGroupV2, rules []neutron.RuleInfoV2) error {
+	have := make(map[neutron.RuleInfoV2]bool)
+	for key := range newRuleInfoSetFromRules(group.Rules) {
+		have[normaliseRuleInfo(key)] = true
+	}
+	var missing []neutron.RuleInfoV2
+	for _, rule := range rules {
+		key := normaliseRuleInfo(rule)
+		if have[key] {
+			continue
+		}
+		have[key] = true
+		missing = append(missing, rule)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	logger.Debugf("creating %d of %d rules in security group %q", len(missing), len(rules), group.Name)
+	defer c.environ.securityGroups().invalidate()
+	ecfg := c.environ.ecfg()
 	neutronClient := c.environ.neutron()
-	ruleInfo := rulesToRuleInfo(group.Id, rules)
-	for _, rule := range ruleInfo {
-		_, err := neutronClient.CreateSecurityGroupRuleV2(rule)
-		if err != nil {
-			// TODO: if err is not rule already exists, raise?
-			logger.Debugf("error creating security group rule: %v", err.Error())
+	strategy := c.environ.retryStrategy()
+	strategy.Attempts = ecfg.securityGroupRuleAttempts()
+	return createSecurityGroupRules(
+		func(rule neutron.RuleInfoV2) error {
+			_, err := neutronClient.CreateSecurityGroupRuleV2(rule)
+			return err
+		},
+		missing,
+		ruleCreationParams{
+			Concurrency: ecfg.securityGroupRuleConcurrency(),
+			Retry:       strategy,
+		},
+	)
+}
+
+// normaliseRuleInfo returns the rule with only the fields that identify
+// it within a security group, and with the ethernet type Neutron infers
+// when none is given.
+func normaliseRuleInfo(rule neutron.RuleInfoV2) neutron.RuleInfoV2 {
+	key := neutron.RuleInfoV2{
+		Direction:      rule.Direction,
+		IPProtocol:     rule.IPProtocol,
+		PortRangeMin:   rule.PortRangeMin,
+		PortRangeMax:   rule.PortRangeMax,
+		EthernetType:   rule.EthernetType,
+		RemoteIPPrefix: rule.RemoteIPPrefix,
+	}
+	if key.EthernetType == "" {
+		key.EthernetType = "IPv4"
+		if ip, _, err := net.ParseCIDR(key.RemoteIPPrefix); err == nil && ip.To4() == nil {
+			key.EthernetType = "IPv6"
+		}
+	}
+	return key
+}
+
+// ruleCreationParams holds the parameters of createSecurityGroupRules.
+type ruleCreationParams struct {
+	// Concurrency is the maximum number of rules to create at once.
+	Concurrency int
+
+	// Retry is the strategy for retrying the creation of a rule.
+	Retry retryStrategy
+}
+
+// createSecurityGroupRules creates the given rules with create, at most
+// p.Concurrency at a time, retrying each according to p.Retry. Rules
+// that already exist are not treated as errors. It returns an error
+// describing the rules that could not be created, if any.
+func createSecurityGroupRules(
+	create func(neutron.RuleInfoV2) error,
+	rules []neutron.RuleInfoV2,
+	p ruleCreationParams,
+) error {
+	concurrency := p.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(rules))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, rule := range rules {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, rule neutron.RuleInfoV2) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = p.Retry.call("creating security group rule", func() error {
+				err := create(rule)
+				// TODO: use a typed error once goose returns one
+				// for duplicate rules.
+				if err != nil && strings.Contains(err.Error(), "already exists") {
+					return nil
+				}
+				return err
+			})
+		}(i, rule)
+	}
+	wg.Wait()
+
+	var failed []string
+	var lastErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
 		}
+		lastErr = err
+		rule := rules[i]
+		failed = append(failed, fmt.Sprintf("%s %d-%d from %s",
+			rule.IPProtocol, rule.PortRangeMin, rule.PortRangeMax, rule.RemoteIPPrefix,
+		))
+	}
+	if lastErr != nil {
+		return errors.Annotatef(lastErr, "cannot create %d security group rules (%s)",
+			len(failed), strings.Join(failed, ", "),
+		)
 	}
 	return nil
 }
 
 // secGroupMatchesIngressRule checks if supplied nova security group rule matches the ingress rule
 func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.IngressRule) bool {
-	if secGroupRule.IPProtocol == nil || *secGroupRule.PortRangeMax == 0 || *secGroupRule.PortRangeMin == 0 {
+	if secGroupRule.Direction == "egress" {
 		return false
 	}
-	portsMatch := *secGroupRule.IPProtocol == rule.Protocol &&
-		*secGroupRule.PortRangeMin == rule.FromPort &&
-		*secGroupRule.PortRangeMax == rule.ToPort
+	if secGroupRule.IPProtocol == nil || *secGroupRule.IPProtocol != rule.Protocol {
+		return false
+	}
+	var portsMatch bool
+	if rule.Protocol == "icmp" {
+		portsMatch = icmpTypeCode(secGroupRule.PortRangeMin) == rule.FromPort &&
+			icmpTypeCode(secGroupRule.PortRangeMax) == rule.ToPort
+	} else if secGroupRule.PortRangeMin != nil && secGroupRule.PortRangeMax != nil {
+		portsMatch = *secGroupRule.PortRangeMin == rule.FromPort &&
+			*secGroupRule.PortRangeMax == rule.ToPort
+	}
 	if !portsMatch {
 		return false
 	}
 	// The ports match, so if the security group RemoteIPPrefix matches *any* of the
 	// rule's source ranges, then that's a match.
+	remotePrefix := ruleRemotePrefix(secGroupRule)
 	if len(rule.SourceCIDRs) == 0 {
-		return secGroupRule.RemoteIPPrefix == "" || secGroupRule.RemoteIPPrefix == "0.0.0.0/0"
+		return remotePrefix == "0.0.0.0/0"
 	}
 	for _, r := range rule.SourceCIDRs {
-		if r == secGroupRule.RemoteIPPrefix {
+		if r == remotePrefix {
 			return true
 		}
 	}
 	return false
 }
 
-func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, rules []network.IngressRule) error {
+// ruleRemotePrefix returns the remote IP prefix of the security group
+// rule, which is anywhere in the rule's address family if none is set.
+func ruleRemotePrefix(rule neutron.SecurityGroupRuleV2) string {
+	if rule.RemoteIPPrefix != "" {
+		return rule.RemoteIPPrefix
+	}
+	if rule.EthernetType == "IPv6" {
+		return "::/0"
+	}
+	return "0.0.0.0/0"
+}
+
+// anywherePrefixes returns the remote IP prefixes that a rule opened to
+// anywhere is given, according to the model's IP address families.
+func (c *firewallerBase) anywherePrefixes() []string {
+	switch c.environ.ecfg().ipAddressFamily() {
+	case ipFamilyIPv6:
+		return []string{"::/0"}
+	case ipFamilyDualStack:
+		return []string{"0.0.0.0/0", "::/0"}
+	}
+	return []string{"0.0.0.0/0"}
+}
+
+// expandAnywhere returns the rules with any source of anywhere, given as
+// 0.0.0.0/0 or as no source CIDRs, replaced by the anywhere prefixes.
+// Rules are returned as they are if anywhere is only 0.0.0.0/0.
+func expandAnywhere(rules []network.IngressRule, anywhere []string) []network.IngressRule {
+	if len(anywhere) == 1 && anywhere[0] == "0.0.0.0/0" {
+		return rules
+	}
+	result := make([]network.IngressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = rule
+		if len(rule.SourceCIDRs) == 0 {
+			result[i].SourceCIDRs = anywhere
+			continue
+		}
+		var sourceCIDRs []string
+		for _, cidr := range rule.SourceCIDRs {
+			if cidr == "0.0.0.0/0" {
+				sourceCIDRs = append(sourceCIDRs, anywhere...)
+			} else {
+				sourceCIDRs = append(sourceCIDRs, cidr)
+			}
+		}
+		result[i].SourceCIDRs = sourceCIDRs
+	}
+	return result
+}
+
+// foldAnywhere returns the source CIDRs with the anywhere prefixes
+// replaced by 0.0.0.0/0, as anywhere is reported to the firewaller, if
+// all of them are present. Other CIDRs, including IPv6 ones, are
+// returned as they are.
+func foldAnywhere(sourceCIDRs []string, anywhere []string) []string {
+	if len(anywhere) == 1 && anywhere[0] == "0.0.0.0/0" {
+		return sourceCIDRs
+	}
+	present := set.NewStrings(sourceCIDRs...)
+	for _, prefix := range anywhere {
+		if !present.Contains(prefix) {
+			return sourceCIDRs
+		}
+	}
+	present = present.Difference(set.NewStrings(anywhere...))
+	present.Add("0.0.0.0/0")
+	return present.SortedValues()
+}
+
+func (c *neutronFirewaller) closePortsInGroup(sel groupSelector, rules []network.IngressRule) error {
 	if len(rules) == 0 {
 		return nil
 	}
-	group, err := c.matchingGroup(nameRegExp)
+	group, err := c.matchingGroup(sel)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	defer c.environ.securityGroups().invalidate()
 	neutronClient := c.environ.neutron()
+	rules = expandAnywhere(rules, c.anywherePrefixes())
 	// TODO: Hey look ma, it's quadratic
 	for _, rule := range rules {
+		// A rule with several source CIDRs is held as one security
+		// group rule per CIDR, so all the matching rules are deleted.
 		for _, p := range group.Rules {
 			if !secGroupMatchesIngressRule(p, rule) {
 				continue
@@ -891,14 +2016,13 @@ func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, rules []network
 			if err != nil {
 				return errors.Trace(err)
 			}
-			break
 		}
 	}
 	return nil
 }
 
-func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []network.IngressRule, err error) {
-	group, err := c.matchingGroup(nameRegexp)
+func (c *neutronFirewaller) ingressRulesInGroup(sel groupSelector) (rules []network.IngressRule, err error) {
+	group, err := c.matchingGroup(sel)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -912,17 +2036,19 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 		portRange := network.PortRange{
 			Protocol: *p.IPProtocol,
 		}
-		if p.PortRangeMin != nil {
-			portRange.FromPort = *p.PortRangeMin
-		}
-		if p.PortRangeMax != nil {
-			portRange.ToPort = *p.PortRangeMax
+		if portRange.Protocol == "icmp" {
+			portRange.FromPort = icmpTypeCode(p.PortRangeMin)
+			portRange.ToPort = icmpTypeCode(p.PortRangeMax)
+		} else {
+			if p.PortRangeMin != nil {
+				portRange.FromPort = *p.PortRangeMin
+			}
+			if p.PortRangeMax != nil {
+				portRange.ToPort = *p.PortRangeMax
+			}
 		}
 		// Record the RemoteIPPrefix for the port range.
-		remotePrefix := p.RemoteIPPrefix
-		if remotePrefix == "" {
-			remotePrefix = "0.0.0.0/0"
-		}
+		remotePrefix := ruleRemotePrefix(p)
 		sourceCIDRs, ok := portSourceCIDRs[portRange]
 		if !ok {
 			sourceCIDRs = &[]string{}
@@ -930,13 +2056,17 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 		}
 		*sourceCIDRs = append(*sourceCIDRs, remotePrefix)
 	}
-	// Combine all the port ranges and remote prefixes.
+	// Combine all the port ranges and remote prefixes. Rules opened to
+	// anywhere are reported with 0.0.0.0/0, whichever address families
+	// they were opened on.
+	anywhere := c.anywherePrefixes()
 	for portRange, sourceCIDRs := range portSourceCIDRs {
+		sort.Strings(*sourceCIDRs)
 		rule, err := network.NewIngressRule(
 			portRange.Protocol,
 			portRange.FromPort,
 			portRange.ToPort,
-			*sourceCIDRs...)
+			foldAnywhere(*sourceCIDRs, anywhere)...)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -946,6 +2076,115 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	return rules, nil
 }
 
+func (c *neutronFirewaller) openEgressPortsInGroup(sel groupSelector, rules []network.EgressRule) error {
+	group, err := c.matchingGroup(sel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.createMissingRules(group, egressRulesToRuleInfo(group.Id, rules)))
+}
+
+// secGroupMatchesEgressRule checks if supplied neutron security group rule matches the egress rule
+func secGroupMatchesEgressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.EgressRule) bool {
+	if secGroupRule.Direction != "egress" {
+		return false
+	}
+	if secGroupRule.IPProtocol == nil || secGroupRule.PortRangeMax == nil || secGroupRule.PortRangeMin == nil {
+		return false
+	}
+	portsMatch := *secGroupRule.IPProtocol == rule.Protocol &&
+		*secGroupRule.PortRangeMin == rule.FromPort &&
+		*secGroupRule.PortRangeMax == rule.ToPort
+	if !portsMatch {
+		return false
+	}
+	if len(rule.DestinationCIDRs) == 0 {
+		return secGroupRule.RemoteIPPrefix == "" || secGroupRule.RemoteIPPrefix == "0.0.0.0/0"
+	}
+	for _, r := range rule.DestinationCIDRs {
+		if r == secGroupRule.RemoteIPPrefix {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *neutronFirewaller) closeEgressPortsInGroup(sel groupSelector, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(sel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer c.environ.securityGroups().invalidate()
+	neutronClient := c.environ.neutron()
+	for _, rule := range rules {
+		for _, p := range group.Rules {
+			if !secGroupMatchesEgressRule(p, rule) {
+				continue
+			}
+			if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *neutronFirewaller) egressRulesInGroup(sel groupSelector) ([]network.EgressRule, error) {
+	group, err := c.matchingGroup(sel)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Keep track of all the RemoteIPPrefixes for each port range.
+	portDestinationCIDRs := make(map[network.PortRange][]string)
+	var portRanges []network.PortRange
+	for _, p := range group.Rules {
+		// Skip ingress rules, and the default egress rules created
+		// by Neutron, which have no protocol.
+		if p.Direction != "egress" || p.IPProtocol == nil {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+		}
+		if portRange.Protocol == "icmp" {
+			portRange.FromPort = icmpTypeCode(p.PortRangeMin)
+			portRange.ToPort = icmpTypeCode(p.PortRangeMax)
+		} else {
+			if p.PortRangeMin != nil {
+				portRange.FromPort = *p.PortRangeMin
+			}
+			if p.PortRangeMax != nil {
+				portRange.ToPort = *p.PortRangeMax
+			}
+		}
+		remotePrefix := p.RemoteIPPrefix
+		if remotePrefix == "" {
+			remotePrefix = "0.0.0.0/0"
+		}
+		if _, ok := portDestinationCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		portDestinationCIDRs[portRange] = append(portDestinationCIDRs[portRange], remotePrefix)
+	}
+	rules := make([]network.EgressRule, 0, len(portRanges))
+	for _, portRange := range portRanges {
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			portDestinationCIDRs[portRange]...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
@@ -0,0 +1,171 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v2/neutron"
+
+	"github.com/juju/juju/network"
+)
+
+type firewallerInternalSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&firewallerInternalSuite{})
+
+func makeRuleInfo(n int) []neutron.RuleInfoV2 {
+	rules := make([]neutron.RuleInfoV2, n)
+	for i := range rules {
+		rules[i] = neutron.RuleInfoV2{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMin:   1000 + i,
+			PortRangeMax:   1000 + i,
+			RemoteIPPrefix: "0.0.0.0/0",
+		}
+	}
+	return rules
+}
+
+var testRuleCreationParams = ruleCreationParams{
+	Concurrency: 4,
+	Retry: retryStrategy{
+		Attempts: 3,
+		Delay:    time.Millisecond,
+		Clock:    clock.WallClock,
+	},
+}
+
+func (s *firewallerInternalSuite) TestCreateSecurityGroupRules(c *gc.C) {
+	rules := makeRuleInfo(50)
+	var mu sync.Mutex
+	var created []neutron.RuleInfoV2
+	var running, maxRunning int
+	err := createSecurityGroupRules(func(rule neutron.RuleInfoV2) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running--
+		created = append(created, rule)
+		mu.Unlock()
+		return nil
+	}, rules, testRuleCreationParams)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(created, jc.SameContents, rules)
+	c.Assert(maxRunning <= 4, jc.IsTrue, gc.Commentf("%d rules created at once", maxRunning))
+}
+
+func (s *firewallerInternalSuite) TestCreateSecurityGroupRulesRetries(c *gc.C) {
+	rules := makeRuleInfo(2)
+	var mu sync.Mutex
+	calls := make(map[int]int)
+	err := createSecurityGroupRules(func(rule neutron.RuleInfoV2) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[rule.PortRangeMin]++
+		if rule.PortRangeMin == 1000 && calls[rule.PortRangeMin] < 3 {
+			return errors.New("service unavailable")
+		}
+		return nil
+	}, rules, testRuleCreationParams)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, map[int]int{1000: 3, 1001: 1})
+}
+
+func (s *firewallerInternalSuite) TestCreateSecurityGroupRulesAlreadyExists(c *gc.C) {
+	calls := 0
+	err := createSecurityGroupRules(func(rule neutron.RuleInfoV2) error {
+		calls++
+		return errors.New("Security group rule already exists. Rule id is 42.")
+	}, makeRuleInfo(1), testRuleCreationParams)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 1)
+}
+
+func (s *firewallerInternalSuite) TestCreateSecurityGroupRulesFails(c *gc.C) {
+	rules := makeRuleInfo(3)
+	err := createSecurityGroupRules(func(rule neutron.RuleInfoV2) error {
+		if rule.PortRangeMin == 1001 {
+			return errors.New("quota exceeded")
+		}
+		return nil
+	}, rules, testRuleCreationParams)
+	c.Assert(err, gc.ErrorMatches, `cannot create 1 security group rules \(tcp 1001-1001 from 0.0.0.0/0\): quota exceeded`)
+}
+
+func (s *firewallerInternalSuite) TestNormaliseRuleInfo(c *gc.C) {
+	c.Assert(normaliseRuleInfo(neutron.RuleInfoV2{
+		Direction:      "ingress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   80,
+		PortRangeMax:   80,
+		RemoteIPPrefix: "0.0.0.0/0",
+		ParentGroupId:  "group-id",
+	}), jc.DeepEquals, neutron.RuleInfoV2{
+		Direction:      "ingress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   80,
+		PortRangeMax:   80,
+		EthernetType:   "IPv4",
+		RemoteIPPrefix: "0.0.0.0/0",
+	})
+	c.Assert(normaliseRuleInfo(neutron.RuleInfoV2{
+		Direction:      "egress",
+		RemoteIPPrefix: "::/0",
+	}).EthernetType, gc.Equals, "IPv6")
+}
+
+func (s *firewallerInternalSuite) TestExpandAnywhere(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0", "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "2001:db8::/32"),
+	}
+	c.Assert(expandAnywhere(rules, []string{"0.0.0.0/0"}), jc.DeepEquals, rules)
+	c.Assert(expandAnywhere(rules, []string{"0.0.0.0/0", "::/0"}), jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0", "::/0", "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "2001:db8::/32"),
+	})
+	c.Assert(expandAnywhere(rules[:1], []string{"::/0"}), jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "::/0"),
+	})
+}
+
+func (s *firewallerInternalSuite) TestFoldAnywhere(c *gc.C) {
+	dualStack := []string{"0.0.0.0/0", "::/0"}
+	c.Assert(foldAnywhere([]string{"0.0.0.0/0", "::/0", "10.0.0.0/8"}, dualStack), jc.DeepEquals, []string{"0.0.0.0/0", "10.0.0.0/8"})
+	c.Assert(foldAnywhere([]string{"::/0"}, dualStack), jc.DeepEquals, []string{"::/0"})
+	c.Assert(foldAnywhere([]string{"::/0"}, []string{"::/0"}), jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(foldAnywhere([]string{"0.0.0.0/0", "::/0"}, []string{"0.0.0.0/0"}), jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+}
+
+func (s *firewallerInternalSuite) TestSecurityGroupsNotDeleted(c *gc.C) {
+	c.Assert(securityGroupsNotDeleted(nil), jc.ErrorIsNil)
+
+	err := securityGroupsNotDeleted(map[string]error{"juju-b": errors.New("in use")})
+	c.Assert(err, jc.Satisfies, IsSecurityGroupsNotDeletedError)
+	c.Assert(err, gc.ErrorMatches, "1 security group could not be removed: juju-b")
+
+	err = errors.Annotate(securityGroupsNotDeleted(map[string]error{
+		"juju-b": errors.New("in use"),
+		"juju-a": errors.New("in use"),
+	}), "destroying model")
+	c.Assert(err, jc.Satisfies, IsSecurityGroupsNotDeletedError)
+	c.Assert(err, gc.ErrorMatches, "destroying model: 2 security groups could not be removed: juju-a, juju-b")
+}
@@ -0,0 +1,276 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+const (
+	// FirewallerAuto selects the neutron firewaller if the cloud
+	// supports Neutron, and the nova-network firewaller otherwise.
+	FirewallerAuto = "auto"
+
+	// FirewallerNeutron selects the firewaller using Neutron
+	// security groups.
+	FirewallerNeutron = "neutron"
+
+	// FirewallerNova selects the firewaller using nova-network
+	// security groups, for clouds without Neutron.
+	FirewallerNova = "nova"
+
+	// FirewallerFWaaS selects the firewaller using Neutron FWaaS v2
+	// firewall groups, for clouds where security groups are disabled
+	// or the networks have port security turned off.
+	FirewallerFWaaS = "fwaas"
+
+	// FirewallerNone selects a firewaller that does nothing, for clouds
+	// whose firewalling is managed outside of Juju.
+	FirewallerNone = "none"
+)
+
+// NewFirewallerFunc returns a Firewaller for the given environ.
+type NewFirewallerFunc func(env *Environ) Firewaller
+
+var firewallerRegistry = struct {
+	mu          sync.Mutex
+	firewallers map[string]NewFirewallerFunc
+}{
+	firewallers: make(map[string]NewFirewallerFunc),
+}
+
+func init() {
+	RegisterFirewaller(FirewallerNeutron, func(env *Environ) Firewaller {
+		return &neutronFirewaller{firewallerBase{environ: env}}
+	})
+	RegisterFirewaller(FirewallerNova, func(env *Environ) Firewaller {
+		return &legacyNovaFirewaller{firewallerBase{environ: env}}
+	})
+	RegisterFirewaller(FirewallerFWaaS, func(env *Environ) Firewaller {
+		return &fwaasFirewaller{firewallerBase{environ: env}}
+	})
+	RegisterFirewaller(FirewallerNone, func(*Environ) Firewaller {
+		return noopFirewaller{}
+	})
+}
+
+// RegisterFirewaller registers a Firewaller implementation, which may
+// then be selected with the firewall-implementation model config.
+// Providers embedding the openstack provider may register their own.
+//
+// RegisterFirewaller will panic if the name is registered more than
+// once, or is "auto". The returned function can be used to unregister
+// the implementation, and is used by tests.
+func RegisterFirewaller(name string, newFirewaller NewFirewallerFunc) (unregister func()) {
+	firewallerRegistry.mu.Lock()
+	defer firewallerRegistry.mu.Unlock()
+	if _, ok := firewallerRegistry.firewallers[name]; ok || name == FirewallerAuto {
+		panic(fmt.Errorf("juju: duplicate firewaller implementation name %q", name))
+	}
+	firewallerRegistry.firewallers[name] = newFirewaller
+	return func() {
+		firewallerRegistry.mu.Lock()
+		defer firewallerRegistry.mu.Unlock()
+		delete(firewallerRegistry.firewallers, name)
+	}
+}
+
+// RegisteredFirewallers returns the sorted names of the registered
+// Firewaller implementations.
+func RegisteredFirewallers() []string {
+	firewallerRegistry.mu.Lock()
+	defer firewallerRegistry.mu.Unlock()
+	names := make([]string, 0, len(firewallerRegistry.firewallers))
+	for name := range firewallerRegistry.firewallers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// registeredFirewaller returns the constructor of the named Firewaller
+// implementation.
+func registeredFirewaller(name string) (NewFirewallerFunc, error) {
+	firewallerRegistry.mu.Lock()
+	defer firewallerRegistry.mu.Unlock()
+	newFirewaller, ok := firewallerRegistry.firewallers[name]
+	if !ok {
+		return nil, errors.NotFoundf("firewaller implementation %q", name)
+	}
+	return newFirewaller, nil
+}
+
+// validateFirewallImplementation returns an error if name is neither
+// "auto" nor a registered Firewaller implementation.
+func validateFirewallImplementation(name string) error {
+	if name == FirewallerAuto {
+		return nil
+	}
+	if _, err := registeredFirewaller(name); err != nil {
+		return errors.NotValidf(
+			"firewall-implementation %q (expected %q or one of %q)",
+			name, FirewallerAuto, RegisteredFirewallers(),
+		)
+	}
+	return nil
+}
+
+// noopFirewaller is a Firewaller that does nothing, leaving firewalling
+// to be managed outside of Juju.
+type noopFirewaller struct{}
+
+var _ Firewaller = noopFirewaller{}
+
+// OpenPorts implements Firewaller interface.
+func (noopFirewaller) OpenPorts(rules []network.IngressRule) error {
+	return nil
+}
+
+// ClosePorts implements Firewaller interface.
+func (noopFirewaller) ClosePorts(rules []network.IngressRule) error {
+	return nil
+}
+
+// IngressRules implements Firewaller interface.
+func (noopFirewaller) IngressRules() ([]network.IngressRule, error) {
+	return nil, nil
+}
+
+// DeleteAllModelGroups implements Firewaller interface.
+func (noopFirewaller) DeleteAllModelGroups(force bool) error {
+	return nil
+}
+
+// DeleteAllControllerGroups implements Firewaller interface.
+func (noopFirewaller) DeleteAllControllerGroups(controllerUUID string) error {
+	return nil
+}
+
+// DeleteGroups implements Firewaller interface.
+func (noopFirewaller) DeleteGroups(names ...string) error {
+	return nil
+}
+
+// UpdateGroupController implements Firewaller interface.
+func (noopFirewaller) UpdateGroupController(controllerUUID string) error {
+	return nil
+}
+
+// GetSecurityGroups implements Firewaller interface.
+func (noopFirewaller) GetSecurityGroups(ids ...instance.Id) ([]string, error) {
+	return nil, nil
+}
+
+// SetUpGroups implements Firewaller interface.
+func (noopFirewaller) SetUpGroups(controllerUUID, machineId string, applicationNames []string, apiPort int) ([]string, error) {
+	return nil, nil
+}
+
+// OpenInstancePorts implements Firewaller interface.
+func (noopFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	return nil
+}
+
+// CloseInstancePorts implements Firewaller interface.
+func (noopFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	return nil
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (noopFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	return nil, nil
+}
+
+// OpenApplicationPorts implements Firewaller interface.
+func (noopFirewaller) OpenApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return nil
+}
+
+// CloseApplicationPorts implements Firewaller interface.
+func (noopFirewaller) CloseApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return nil
+}
+
+// ApplicationIngressRules implements Firewaller interface.
+func (noopFirewaller) ApplicationIngressRules(applicationName string) ([]network.IngressRule, error) {
+	return nil, nil
+}
+
+// MachineSecurityGroups implements Firewaller interface.
+func (noopFirewaller) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	return nil, nil
+}
+
+// DeleteApplicationGroup implements Firewaller interface.
+func (noopFirewaller) DeleteApplicationGroup(applicationName string) error {
+	return nil
+}
+
+// OpenEgressPorts implements Firewaller interface.
+func (noopFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	return nil
+}
+
+// CloseEgressPorts implements Firewaller interface.
+func (noopFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	return nil
+}
+
+// EgressRules implements Firewaller interface.
+func (noopFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return nil, nil
+}
+
+// OpenInstanceEgressPorts implements Firewaller interface.
+func (noopFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return nil
+}
+
+// CloseInstanceEgressPorts implements Firewaller interface.
+func (noopFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return nil
+}
+
+// InstanceEgressRules implements Firewaller interface.
+func (noopFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, nil
+}
+
+// UpdateAllowedIngress implements Firewaller interface.
+func (noopFirewaller) UpdateAllowedIngress() error {
+	return nil
+}
+
+// SetUpContainerGroups implements Firewaller interface.
+func (noopFirewaller) SetUpContainerGroups(controllerUUID, containerId string) ([]string, error) {
+	return nil, nil
+}
+
+// DeleteContainerGroups implements Firewaller interface.
+func (noopFirewaller) DeleteContainerGroups(ids ...string) error {
+	return nil
+}
+
+// OpenContainerPorts implements Firewaller interface.
+func (noopFirewaller) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	return nil
+}
+
+// CloseContainerPorts implements Firewaller interface.
+func (noopFirewaller) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	return nil
+}
+
+// ContainerIngressRules implements Firewaller interface.
+func (noopFirewaller) ContainerIngressRules(containerId string) ([]network.IngressRule, error) {
+	return nil, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type firewallerRegistrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&firewallerRegistrySuite{})
+
+func (s *firewallerRegistrySuite) TestBuiltinFirewallers(c *gc.C) {
+	c.Assert(RegisteredFirewallers(), jc.DeepEquals, []string{"fwaas", "neutron", "none", "nova"})
+}
+
+func (s *firewallerRegistrySuite) TestRegisterFirewaller(c *gc.C) {
+	unregister := RegisterFirewaller("custom", func(*Environ) Firewaller {
+		return noopFirewaller{}
+	})
+	c.Assert(RegisteredFirewallers(), jc.DeepEquals, []string{"custom", "fwaas", "neutron", "none", "nova"})
+	c.Assert(validateFirewallImplementation("custom"), jc.ErrorIsNil)
+
+	unregister()
+	c.Assert(RegisteredFirewallers(), jc.DeepEquals, []string{"fwaas", "neutron", "none", "nova"})
+	_, err := registeredFirewaller("custom")
+	c.Assert(err, gc.ErrorMatches, `firewaller implementation "custom" not found`)
+}
+
+func (s *firewallerRegistrySuite) TestRegisterFirewallerDuplicate(c *gc.C) {
+	newFirewaller := func(*Environ) Firewaller { return noopFirewaller{} }
+	c.Assert(func() { RegisterFirewaller("neutron", newFirewaller) }, gc.PanicMatches, `juju: duplicate firewaller implementation name "neutron"`)
+	c.Assert(func() { RegisterFirewaller("auto", newFirewaller) }, gc.PanicMatches, `juju: duplicate firewaller implementation name "auto"`)
+}
+
+func (s *firewallerRegistrySuite) TestValidateFirewallImplementation(c *gc.C) {
+	c.Assert(validateFirewallImplementation("auto"), jc.ErrorIsNil)
+	c.Assert(validateFirewallImplementation("nova"), jc.ErrorIsNil)
+	err := validateFirewallImplementation("iptables")
+	c.Assert(err, gc.ErrorMatches, `firewall-implementation "iptables" \(expected "auto" or one of \["fwaas" "neutron" "none" "nova"\]\) not valid`)
+}
@@ -0,0 +1,1398 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/goose.v2/client"
+	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// FirewallGroup is a Neutron FWaaS v2 firewall group, which applies
+// its ingress and egress policies to the ports it holds.
+type FirewallGroup struct {
+	Id              string   `json:"id,omitempty"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	IngressPolicyId string   `json:"ingress_firewall_policy_id,omitempty"`
+	EgressPolicyId  string   `json:"egress_firewall_policy_id,omitempty"`
+	Ports           []string `json:"ports"`
+}
+
+// FirewallRule is a Neutron FWaaS v2 firewall rule. A rule with no
+// protocol applies to all protocols, and one with no destination port
+// to all ports. Destination ports are given as "port" or "from:to".
+type FirewallRule struct {
+	Id                   string `json:"id,omitempty"`
+	Description          string `json:"description"`
+	Protocol             string `json:"protocol,omitempty"`
+	IPVersion            int    `json:"ip_version"`
+	SourceIPAddress      string `json:"source_ip_address,omitempty"`
+	DestinationIPAddress string `json:"destination_ip_address,omitempty"`
+	DestinationPort      string `json:"destination_port,omitempty"`
+	Action               string `json:"action"`
+}
+
+// FirewallPort is a Neutron port that may be added to a firewall group.
+type FirewallPort struct {
+	Id        string
+	SubnetIds []string
+}
+
+// FirewallAPI manages Neutron FWaaS v2 firewall groups, policies and
+// rules, and looks up the ports and subnets they apply to.
+type FirewallAPI interface {
+	// FirewallGroups returns all of the firewall groups.
+	FirewallGroups() ([]FirewallGroup, error)
+
+	// CreateFirewallGroup creates the firewall group, and returns it
+	// with its ID.
+	CreateFirewallGroup(group FirewallGroup) (FirewallGroup, error)
+
+	// UpdateFirewallGroup sets the name, description and ports of the
+	// firewall group with the group's ID.
+	UpdateFirewallGroup(group FirewallGroup) error
+
+	// DeleteFirewallGroup deletes the firewall group with the
+	// specified ID. It is not an error to delete a firewall group
+	// that does not exist.
+	DeleteFirewallGroup(id string) error
+
+	// CreateFirewallPolicy creates an empty firewall policy, and
+	// returns its ID.
+	CreateFirewallPolicy(name, description string) (string, error)
+
+	// DeleteFirewallPolicy deletes the firewall policy with the
+	// specified ID, along with its rules. It is not an error to
+	// delete a firewall policy that does not exist.
+	DeleteFirewallPolicy(id string) error
+
+	// FirewallPolicyRules returns the rules of the firewall policy
+	// with the specified ID.
+	FirewallPolicyRules(policyId string) ([]FirewallRule, error)
+
+	// AddFirewallRule creates the rule, and appends it to the firewall
+	// policy with the specified ID.
+	AddFirewallRule(policyId string, rule FirewallRule) error
+
+	// RemoveFirewallRule removes the rule with the specified ID from
+	// the firewall policy, and deletes it.
+	RemoveFirewallRule(policyId, ruleId string) error
+
+	// ServerPorts returns the ports of the server with the specified
+	// ID.
+	ServerPorts(serverId string) ([]FirewallPort, error)
+
+	// SubnetCIDR returns the CIDR of the subnet with the specified ID.
+	SubnetCIDR(subnetId string) (string, error)
+}
+
+var newFirewallAPI = func(e *Environ) FirewallAPI {
+	return &neutronFirewallAPI{e.client()}
+}
+
+// neutronFirewallAPI implements FirewallAPI. The goose neutron client
+// does not support FWaaS, so requests are made directly.
+type neutronFirewallAPI struct {
+	client client.Client
+}
+
+func (api *neutronFirewallAPI) sendRequest(method, path string, requestData *goosehttp.RequestData) error {
+	return api.client.SendRequest(method, "network", "v2.0", path, requestData)
+}
+
+// FirewallGroups is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) FirewallGroups() ([]FirewallGroup, error) {
+	var resp struct {
+		Groups []FirewallGroup `json:"firewall_groups"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := api.sendRequest(client.GET, "fwaas/firewall_groups", &requestData); err != nil {
+		return nil, err
+	}
+	return resp.Groups, nil
+}
+
+// CreateFirewallGroup is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) CreateFirewallGroup(group FirewallGroup) (FirewallGroup, error) {
+	var resp struct {
+		Group FirewallGroup `json:"firewall_group"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       map[string]interface{}{"firewall_group": group},
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusCreated},
+	}
+	if err := api.sendRequest(client.POST, "fwaas/firewall_groups", &requestData); err != nil {
+		return FirewallGroup{}, err
+	}
+	return resp.Group, nil
+}
+
+// UpdateFirewallGroup is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) UpdateFirewallGroup(group FirewallGroup) error {
+	ports := group.Ports
+	if ports == nil {
+		ports = []string{}
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"firewall_group": map[string]interface{}{
+				"name":        group.Name,
+				"description": group.Description,
+				"ports":       ports,
+			},
+		},
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	return api.sendRequest(client.PUT, "fwaas/firewall_groups/"+group.Id, &requestData)
+}
+
+// DeleteFirewallGroup is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) DeleteFirewallGroup(id string) error {
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusNoContent},
+	}
+	err := api.sendRequest(client.DELETE, "fwaas/firewall_groups/"+id, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// CreateFirewallPolicy is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) CreateFirewallPolicy(name, description string) (string, error) {
+	var resp struct {
+		Policy struct {
+			Id string `json:"id"`
+		} `json:"firewall_policy"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"firewall_policy": map[string]string{
+				"name":        name,
+				"description": description,
+			},
+		},
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusCreated},
+	}
+	if err := api.sendRequest(client.POST, "fwaas/firewall_policies", &requestData); err != nil {
+		return "", err
+	}
+	return resp.Policy.Id, nil
+}
+
+// policyRuleIds returns the IDs of the rules of the firewall policy
+// with the specified ID.
+func (api *neutronFirewallAPI) policyRuleIds(policyId string) ([]string, error) {
+	var resp struct {
+		Policy struct {
+			Rules []string `json:"firewall_rules"`
+		} `json:"firewall_policy"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := api.sendRequest(client.GET, "fwaas/firewall_policies/"+policyId, &requestData); err != nil {
+		return nil, err
+	}
+	return resp.Policy.Rules, nil
+}
+
+// DeleteFirewallPolicy is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) DeleteFirewallPolicy(id string) error {
+	ruleIds, err := api.policyRuleIds(id)
+	if gooseerrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusNoContent},
+	}
+	err = api.sendRequest(client.DELETE, "fwaas/firewall_policies/"+id, &requestData)
+	if err != nil && !gooseerrors.IsNotFound(err) {
+		return err
+	}
+	// Rules outlive the policies they belong to, so they are deleted
+	// once the policy has gone.
+	for _, ruleId := range ruleIds {
+		if err := api.deleteRule(ruleId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FirewallPolicyRules is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) FirewallPolicyRules(policyId string) ([]FirewallRule, error) {
+	ruleIds, err := api.policyRuleIds(policyId)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]FirewallRule, len(ruleIds))
+	for i, ruleId := range ruleIds {
+		var resp struct {
+			Rule FirewallRule `json:"firewall_rule"`
+		}
+		requestData := goosehttp.RequestData{
+			RespValue:      &resp,
+			ExpectedStatus: []int{http.StatusOK},
+		}
+		if err := api.sendRequest(client.GET, "fwaas/firewall_rules/"+ruleId, &requestData); err != nil {
+			return nil, err
+		}
+		rules[i] = resp.Rule
+	}
+	return rules, nil
+}
+
+// AddFirewallRule is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) AddFirewallRule(policyId string, rule FirewallRule) error {
+	var resp struct {
+		Rule FirewallRule `json:"firewall_rule"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       map[string]interface{}{"firewall_rule": rule},
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusCreated},
+	}
+	if err := api.sendRequest(client.POST, "fwaas/firewall_rules", &requestData); err != nil {
+		return err
+	}
+	requestData = goosehttp.RequestData{
+		ReqValue:       map[string]string{"firewall_rule_id": resp.Rule.Id},
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := api.sendRequest(client.PUT, "fwaas/firewall_policies/"+policyId+"/insert_rule", &requestData); err != nil {
+		// Don't leave the rule behind if it can't be used.
+		if err := api.deleteRule(resp.Rule.Id); err != nil {
+			logger.Warningf("cannot delete firewall rule %q: %v", resp.Rule.Id, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveFirewallRule is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) RemoveFirewallRule(policyId, ruleId string) error {
+	requestData := goosehttp.RequestData{
+		ReqValue:       map[string]string{"firewall_rule_id": ruleId},
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	err := api.sendRequest(client.PUT, "fwaas/firewall_policies/"+policyId+"/remove_rule", &requestData)
+	if err != nil && !gooseerrors.IsNotFound(err) {
+		return err
+	}
+	return api.deleteRule(ruleId)
+}
+
+func (api *neutronFirewallAPI) deleteRule(id string) error {
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusNoContent},
+	}
+	err := api.sendRequest(client.DELETE, "fwaas/firewall_rules/"+id, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// ServerPorts is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) ServerPorts(serverId string) ([]FirewallPort, error) {
+	var resp struct {
+		Ports []struct {
+			Id       string `json:"id"`
+			FixedIPs []struct {
+				SubnetId string `json:"subnet_id"`
+			} `json:"fixed_ips"`
+		} `json:"ports"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	path := "ports?device_id=" + url.QueryEscape(serverId)
+	if err := api.sendRequest(client.GET, path, &requestData); err != nil {
+		return nil, err
+	}
+	ports := make([]FirewallPort, len(resp.Ports))
+	for i, port := range resp.Ports {
+		ports[i].Id = port.Id
+		for _, fixedIP := range port.FixedIPs {
+			ports[i].SubnetIds = append(ports[i].SubnetIds, fixedIP.SubnetId)
+		}
+	}
+	return ports, nil
+}
+
+// SubnetCIDR is part of the FirewallAPI interface.
+func (api *neutronFirewallAPI) SubnetCIDR(subnetId string) (string, error) {
+	var resp struct {
+		Subnet struct {
+			CIDR string `json:"cidr"`
+		} `json:"subnet"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := api.sendRequest(client.GET, "subnets/"+subnetId, &requestData); err != nil {
+		return "", err
+	}
+	return resp.Subnet.CIDR, nil
+}
+
+const (
+	// fwaasBaseRuleDescription describes the firewall rules Juju adds
+	// to every firewall group, allowing SSH, API and intra-model
+	// traffic, and the outgoing traffic Juju needs when the egress
+	// policy is restricted. They are not reported as opened ports.
+	fwaasBaseRuleDescription = "juju base rule"
+
+	// fwaasPortRuleDescription describes the firewall rules for the
+	// ports opened by the firewaller, and for the model's egress
+	// rules.
+	fwaasPortRuleDescription = "juju port rule"
+)
+
+// fwaasFirewaller is a Firewaller using Neutron FWaaS v2 firewall
+// groups rather than security groups, for clouds where security groups
+// are disabled or the networks have port security turned off.
+//
+// Each machine's ports, or all of the model's ports in the global
+// firewall mode, are added to a firewall group, whose ingress policy
+// holds the rules for the opened ports along with rules allowing SSH,
+// API and intra-model traffic. When the egress policy is restricted,
+// the group also has an egress policy. Firewall groups are applied to
+// ports rather than servers, so a machine's ports are added to its
+// group by the firewaller once the machine has started. A port may
+// only belong to one firewall group, so application groups are not
+// supported.
+type fwaasFirewaller struct {
+	firewallerBase
+}
+
+var _ Firewaller = (*fwaasFirewaller)(nil)
+
+func (c *fwaasFirewaller) api() FirewallAPI {
+	return newFirewallAPI(c.environ)
+}
+
+// egressRestricted reports whether the model's firewall groups have
+// egress policies.
+func (c *fwaasFirewaller) egressRestricted() bool {
+	return c.environ.ecfg().egressPolicy() == egressPolicyRestricted
+}
+
+// SetUpGroups implements Firewaller interface. It creates the machine's
+// firewall group, or the model's global firewall group, if it does not
+// already exist. Firewall groups are not security groups, so no group
+// names are returned.
+func (c *fwaasFirewaller) SetUpGroups(controllerUUID, machineId string, applicationNames []string, apiPort int) ([]string, error) {
+	var err error
+	switch c.environ.Config().FirewallMode() {
+	case config.FwInstance:
+		_, err = c.ensureFirewallGroup(
+			c.machineGroupName(controllerUUID, machineId),
+			c.groupTags(controllerUUID, groupKindMachine, machineId),
+			apiPort,
+		)
+	case config.FwGlobal:
+		_, err = c.ensureFirewallGroup(
+			c.globalGroupName(controllerUUID),
+			c.groupTags(controllerUUID, groupKindGlobal, ""),
+			apiPort,
+		)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return nil, nil
+}
+
+// ensureFirewallGroup returns the firewall group with the given tags,
+// creating it with its policies and base rules if it does not exist.
+func (c *fwaasFirewaller) ensureFirewallGroup(name string, groupTags map[string]string, apiPort int) (FirewallGroup, error) {
+	api := c.api()
+	groups, err := api.FirewallGroups()
+	if err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	for _, group := range groups {
+		if haveTags := descriptionTags(group.Description); haveTags != nil && tagsMatch(haveTags, groupTags) {
+			return group, nil
+		}
+	}
+
+	description := groupDescription(groupTags)
+	group := FirewallGroup{
+		Name:        name,
+		Description: description,
+	}
+	group.IngressPolicyId, err = c.createPolicy(name+"-ingress", description, baseIngressFirewallRules(apiPort, c.environ.ecfg().allowedIngressCIDRs()))
+	if err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	if c.egressRestricted() {
+		egressRules, err := c.environ.ecfg().egressRules()
+		if err != nil {
+			return FirewallGroup{}, errors.Trace(err)
+		}
+		rules := append(baseEgressFirewallRules(apiPort), egressFirewallRules(egressRules)...)
+		group.EgressPolicyId, err = c.createPolicy(name+"-egress", description, rules)
+		if err != nil {
+			return FirewallGroup{}, errors.Trace(err)
+		}
+	}
+	logger.Debugf("creating firewall group %q", name)
+	group, err = api.CreateFirewallGroup(group)
+	if err != nil {
+		return FirewallGroup{}, errors.Annotatef(err, "creating firewall group %q", name)
+	}
+	return group, nil
+}
+
+// createPolicy creates a firewall policy with the given rules, and
+// returns its ID.
+func (c *fwaasFirewaller) createPolicy(name, description string, rules []FirewallRule) (string, error) {
+	api := c.api()
+	policyId, err := api.CreateFirewallPolicy(name, description)
+	if err != nil {
+		return "", errors.Annotatef(err, "creating firewall policy %q", name)
+	}
+	for _, rule := range rules {
+		if err := api.AddFirewallRule(policyId, rule); err != nil {
+			return "", errors.Annotatef(err, "adding rule to firewall policy %q", name)
+		}
+	}
+	return policyId, nil
+}
+
+// matchingGroup returns the model's firewall group identified by the
+// selector's tags. Firewall groups were always tagged, so they are not
+// matched by name.
+func (c *fwaasFirewaller) matchingGroup(sel groupSelector) (FirewallGroup, error) {
+	groups, err := c.api().FirewallGroups()
+	if err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	var matches []FirewallGroup
+	for _, group := range groups {
+		if haveTags := descriptionTags(group.Description); haveTags != nil && tagsMatch(haveTags, sel.tags) {
+			matches = append(matches, group)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return FirewallGroup{}, errors.NotFoundf("firewall group matching %q", sel.nameRegexp)
+	case 1:
+		return matches[0], nil
+	}
+	return FirewallGroup{}, errors.Errorf("%d firewall groups found matching %q, expected 1", len(matches), sel.nameRegexp)
+}
+
+// addServerPorts adds the ports of the given servers to the firewall
+// group, if they are not already in it, and allows traffic between the
+// subnets the ports are on.
+func (c *fwaasFirewaller) addServerPorts(group *FirewallGroup, serverIds ...string) error {
+	api := c.api()
+	have := set.NewStrings(group.Ports...)
+	subnetIds := set.NewStrings()
+	var added bool
+	for _, serverId := range serverIds {
+		ports, err := api.ServerPorts(serverId)
+		if err != nil {
+			return errors.Annotatef(err, "getting ports of server %q", serverId)
+		}
+		for _, port := range ports {
+			if !have.Contains(port.Id) {
+				have.Add(port.Id)
+				group.Ports = append(group.Ports, port.Id)
+				added = true
+			}
+			subnetIds = subnetIds.Union(set.NewStrings(port.SubnetIds...))
+		}
+	}
+	if !added {
+		return nil
+	}
+
+	var subnetCIDRs []string
+	for _, subnetId := range subnetIds.SortedValues() {
+		cidr, err := api.SubnetCIDR(subnetId)
+		if err != nil {
+			return errors.Annotatef(err, "getting CIDR of subnet %q", subnetId)
+		}
+		subnetCIDRs = append(subnetCIDRs, cidr)
+	}
+	// The rules allowing intra-model traffic are added before the
+	// ports, so that the ports are never cut off from the model.
+	if err := c.addMissingRules(group.IngressPolicyId, subnetIngressFirewallRules(subnetCIDRs)); err != nil {
+		return errors.Trace(err)
+	}
+	if group.EgressPolicyId != "" {
+		if err := c.addMissingRules(group.EgressPolicyId, subnetEgressFirewallRules(subnetCIDRs)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	logger.Debugf("adding ports %v to firewall group %q", group.Ports, group.Name)
+	return errors.Annotatef(api.UpdateFirewallGroup(*group), "updating firewall group %q", group.Name)
+}
+
+// instanceGroup returns the instance's firewall group, having added
+// the instance's ports to it.
+func (c *fwaasFirewaller) instanceGroup(inst instance.Instance, machineId string) (FirewallGroup, error) {
+	group, err := c.matchingGroup(c.machineGroupSelector(machineId))
+	if err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	if err := c.addServerPorts(&group, string(inst.Id())); err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// globalGroup returns the model's global firewall group, having added
+// the ports of all of the model's instances to it.
+func (c *fwaasFirewaller) globalGroup() (FirewallGroup, error) {
+	group, err := c.matchingGroup(c.globalGroupSelector())
+	if err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	instances, err := c.environ.AllInstances()
+	if err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	serverIds := make([]string, len(instances))
+	for i, inst := range instances {
+		serverIds[i] = string(inst.Id())
+	}
+	if err := c.addServerPorts(&group, serverIds...); err != nil {
+		return FirewallGroup{}, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// addMissingRules adds those of the rules that the firewall policy
+// does not already have.
+func (c *fwaasFirewaller) addMissingRules(policyId string, rules []FirewallRule) error {
+	api := c.api()
+	existing, err := api.FirewallPolicyRules(policyId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	have := make(map[FirewallRule]bool)
+	for _, rule := range existing {
+		have[firewallRuleKey(rule)] = true
+	}
+	for _, rule := range rules {
+		key := firewallRuleKey(rule)
+		if have[key] {
+			continue
+		}
+		if err := api.AddFirewallRule(policyId, rule); err != nil {
+			return errors.Trace(err)
+		}
+		have[key] = true
+	}
+	return nil
+}
+
+// removeRules removes those of the rules that the firewall policy has.
+func (c *fwaasFirewaller) removeRules(policyId string, rules []FirewallRule) error {
+	api := c.api()
+	existing, err := api.FirewallPolicyRules(policyId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	remove := make(map[FirewallRule]bool)
+	for _, rule := range rules {
+		remove[firewallRuleKey(rule)] = true
+	}
+	for _, rule := range existing {
+		if !remove[firewallRuleKey(rule)] {
+			continue
+		}
+		if err := api.RemoveFirewallRule(policyId, rule.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ingressRulesInPolicy returns the opened ports recorded in the
+// firewall policy.
+func (c *fwaasFirewaller) ingressRulesInPolicy(policyId string) ([]network.IngressRule, error) {
+	existing, err := c.api().FirewallPolicyRules(policyId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var portRanges []network.PortRange
+	portSourceCIDRs := make(map[network.PortRange][]string)
+	for _, rule := range existing {
+		if rule.Description != fwaasPortRuleDescription {
+			continue
+		}
+		portRange, err := firewallRulePortRange(rule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := portSourceCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		portSourceCIDRs[portRange] = append(portSourceCIDRs[portRange], rule.SourceIPAddress)
+	}
+	// Rules opened to anywhere are reported with 0.0.0.0/0, whichever
+	// address families they were opened on.
+	anywhere := c.anywherePrefixes()
+	var rules []network.IngressRule
+	for _, portRange := range portRanges {
+		sourceCIDRs := portSourceCIDRs[portRange]
+		sort.Strings(sourceCIDRs)
+		rule, err := network.NewIngressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			foldAnywhere(sourceCIDRs, anywhere)...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// egressRulesInPolicy returns the egress rules recorded in the
+// firewall policy.
+func (c *fwaasFirewaller) egressRulesInPolicy(policyId string) ([]network.EgressRule, error) {
+	if policyId == "" {
+		return []network.EgressRule{}, nil
+	}
+	existing, err := c.api().FirewallPolicyRules(policyId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var portRanges []network.PortRange
+	portDestinationCIDRs := make(map[network.PortRange][]string)
+	for _, rule := range existing {
+		if rule.Description != fwaasPortRuleDescription {
+			continue
+		}
+		portRange, err := firewallRulePortRange(rule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := portDestinationCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		portDestinationCIDRs[portRange] = append(portDestinationCIDRs[portRange], rule.DestinationIPAddress)
+	}
+	rules := make([]network.EgressRule, 0, len(portRanges))
+	for _, portRange := range portRanges {
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			portDestinationCIDRs[portRange]...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// OpenPorts implements Firewaller interface.
+func (c *fwaasFirewaller) OpenPorts(rules []network.IngressRule) error {
+	return c.openPorts(c.openPortsInGroup, rules)
+}
+
+// ClosePorts implements Firewaller interface.
+func (c *fwaasFirewaller) ClosePorts(rules []network.IngressRule) error {
+	return c.closePorts(c.closePortsInGroup, rules)
+}
+
+// IngressRules implements Firewaller interface.
+func (c *fwaasFirewaller) IngressRules() ([]network.IngressRule, error) {
+	return c.ingressRules(func(groupSelector) ([]network.IngressRule, error) {
+		group, err := c.globalGroup()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return c.ingressRulesInPolicy(group.IngressPolicyId)
+	})
+}
+
+func (c *fwaasFirewaller) openPortsInGroup(sel groupSelector, rules []network.IngressRule) error {
+	group, err := c.globalGroup()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fwRules, err := ingressFirewallRules(rules, c.anywherePrefixes())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.addMissingRules(group.IngressPolicyId, fwRules)
+}
+
+func (c *fwaasFirewaller) closePortsInGroup(sel groupSelector, rules []network.IngressRule) error {
+	group, err := c.globalGroup()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fwRules, err := ingressFirewallRules(rules, c.anywherePrefixes())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.removeRules(group.IngressPolicyId, fwRules)
+}
+
+// OpenInstancePorts implements Firewaller interface.
+func (c *fwaasFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if err := c.checkInstanceMode("opening ports on instance"); err != nil {
+		return err
+	}
+	group, err := c.instanceGroup(inst, machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fwRules, err := ingressFirewallRules(rules, c.anywherePrefixes())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.addMissingRules(group.IngressPolicyId, fwRules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in firewall group %s: %v", group.Name, rules)
+	return nil
+}
+
+// CloseInstancePorts implements Firewaller interface.
+func (c *fwaasFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if err := c.checkInstanceMode("closing ports on instance"); err != nil {
+		return err
+	}
+	group, err := c.instanceGroup(inst, machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fwRules, err := ingressFirewallRules(rules, c.anywherePrefixes())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.removeRules(group.IngressPolicyId, fwRules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in firewall group %s: %v", group.Name, rules)
+	return nil
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (c *fwaasFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if err := c.checkInstanceMode("retrieving ingress rules from instance"); err != nil {
+		return nil, err
+	}
+	group, err := c.instanceGroup(inst, machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.ingressRulesInPolicy(group.IngressPolicyId)
+}
+
+func (c *fwaasFirewaller) checkInstanceMode(operation string) error {
+	if mode := c.environ.Config().FirewallMode(); mode != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for %s", mode, operation)
+	}
+	return nil
+}
+
+func (c *fwaasFirewaller) checkGlobalMode(operation string) error {
+	if mode := c.environ.Config().FirewallMode(); mode != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for %s", mode, operation)
+	}
+	return nil
+}
+
+// OpenApplicationPorts implements Firewaller interface.
+func (c *fwaasFirewaller) OpenApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("application security groups with the FWaaS firewaller")
+}
+
+// CloseApplicationPorts implements Firewaller interface.
+func (c *fwaasFirewaller) CloseApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("application security groups with the FWaaS firewaller")
+}
+
+// ApplicationIngressRules implements Firewaller interface.
+func (c *fwaasFirewaller) ApplicationIngressRules(applicationName string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("application security groups with the FWaaS firewaller")
+}
+
+// MachineSecurityGroups implements Firewaller interface. Firewall groups
+// are not collected; they are deleted with the model.
+func (c *fwaasFirewaller) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	return nil, errors.NotSupportedf("machine firewall group collection")
+}
+
+// DeleteApplicationGroup implements Firewaller interface. There are no
+// application groups to delete.
+func (c *fwaasFirewaller) DeleteApplicationGroup(applicationName string) error {
+	return nil
+}
+
+// openEgressPorts adds the egress rules to the group's egress policy.
+// Without an egress policy all outgoing traffic is already allowed, so
+// there is nothing to do.
+func (c *fwaasFirewaller) openEgressPorts(group FirewallGroup, rules []network.EgressRule) error {
+	if group.EgressPolicyId == "" {
+		logger.Debugf("firewall group %q allows all egress traffic", group.Name)
+		return nil
+	}
+	return errors.Trace(c.addMissingRules(group.EgressPolicyId, egressFirewallRules(rules)))
+}
+
+func (c *fwaasFirewaller) closeEgressPorts(group FirewallGroup, rules []network.EgressRule) error {
+	if group.EgressPolicyId == "" {
+		return nil
+	}
+	return errors.Trace(c.removeRules(group.EgressPolicyId, egressFirewallRules(rules)))
+}
+
+// OpenEgressPorts implements Firewaller interface.
+func (c *fwaasFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	if err := c.checkGlobalMode("opening egress ports on model"); err != nil {
+		return err
+	}
+	group, err := c.globalGroup()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.openEgressPorts(group, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress ports in global firewall group: %v", rules)
+	return nil
+}
+
+// CloseEgressPorts implements Firewaller interface.
+func (c *fwaasFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	if err := c.checkGlobalMode("closing egress ports on model"); err != nil {
+		return err
+	}
+	group, err := c.globalGroup()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.closeEgressPorts(group, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress ports in global firewall group: %v", rules)
+	return nil
+}
+
+// EgressRules implements Firewaller interface.
+func (c *fwaasFirewaller) EgressRules() ([]network.EgressRule, error) {
+	if err := c.checkGlobalMode("retrieving egress rules from model"); err != nil {
+		return nil, err
+	}
+	group, err := c.globalGroup()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.egressRulesInPolicy(group.EgressPolicyId)
+}
+
+// OpenInstanceEgressPorts implements Firewaller interface.
+func (c *fwaasFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := c.checkInstanceMode("opening egress ports on instance"); err != nil {
+		return err
+	}
+	group, err := c.instanceGroup(inst, machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.openEgressPorts(group, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress ports in firewall group %s: %v", group.Name, rules)
+	return nil
+}
+
+// CloseInstanceEgressPorts implements Firewaller interface.
+func (c *fwaasFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := c.checkInstanceMode("closing egress ports on instance"); err != nil {
+		return err
+	}
+	group, err := c.instanceGroup(inst, machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.closeEgressPorts(group, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress ports in firewall group %s: %v", group.Name, rules)
+	return nil
+}
+
+// InstanceEgressRules implements Firewaller interface.
+func (c *fwaasFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if err := c.checkInstanceMode("retrieving egress rules from instance"); err != nil {
+		return nil, err
+	}
+	group, err := c.instanceGroup(inst, machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.egressRulesInPolicy(group.EgressPolicyId)
+}
+
+// GetSecurityGroups implements Firewaller interface. In the instance
+// firewall mode, it returns the names of the instances' firewall
+// groups, so that they are deleted along with the instances.
+func (c *fwaasFirewaller) GetSecurityGroups(ids ...instance.Id) ([]string, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, nil
+	}
+	instances, err := c.environ.Instances(ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups, err := c.api().FirewallGroups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, inst := range instances {
+		if inst == nil {
+			continue
+		}
+		machineId, err := instServerId(inst)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineTags := c.groupTags("", groupKindMachine, machineId)
+		for _, group := range groups {
+			if haveTags := descriptionTags(group.Description); haveTags != nil && tagsMatch(haveTags, machineTags) {
+				names = append(names, group.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// deleteMatchingGroups deletes the firewall groups for which match
+// returns true, along with their policies and rules.
+func (c *fwaasFirewaller) deleteMatchingGroups(match func(FirewallGroup) bool) error {
+	api := c.api()
+	groups, err := api.FirewallGroups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, group := range groups {
+		if !match(group) {
+			continue
+		}
+		logger.Debugf("deleting firewall group %q", group.Name)
+		// A firewall group cannot be deleted while it has ports.
+		if len(group.Ports) > 0 {
+			group.Ports = nil
+			if err := api.UpdateFirewallGroup(group); err != nil {
+				return errors.Annotatef(err, "removing ports from firewall group %q", group.Name)
+			}
+		}
+		if err := api.DeleteFirewallGroup(group.Id); err != nil {
+			return errors.Annotatef(err, "deleting firewall group %q", group.Name)
+		}
+		for _, policyId := range []string{group.IngressPolicyId, group.EgressPolicyId} {
+			if policyId == "" {
+				continue
+			}
+			if err := api.DeleteFirewallPolicy(policyId); err != nil {
+				return errors.Annotatef(err, "deleting firewall policy of group %q", group.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteGroups implements Firewaller interface.
+func (c *fwaasFirewaller) DeleteGroups(names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	deleting := set.NewStrings(names...)
+	return c.deleteMatchingGroups(func(group FirewallGroup) bool {
+		return deleting.Contains(group.Name)
+	})
+}
+
+// DeleteAllControllerGroups implements Firewaller interface.
+func (c *fwaasFirewaller) DeleteAllControllerGroups(controllerUUID string) error {
+	prefix := c.jujuControllerGroupPrefix(controllerUUID)
+	controllerTags := map[string]string{tags.JujuController: controllerUUID}
+	return c.deleteMatchingGroups(func(group FirewallGroup) bool {
+		if groupTags := descriptionTags(group.Description); groupTags != nil {
+			return tagsMatch(groupTags, controllerTags)
+		}
+		return strings.HasPrefix(group.Name, prefix)
+	})
+}
+
+// DeleteAllModelGroups implements Firewaller interface. Firewall
+// groups are always detached from their ports before being deleted,
+// so force makes no difference.
+func (c *fwaasFirewaller) DeleteAllModelGroups(force bool) error {
+	match, err := c.modelGroupMatcher()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.deleteMatchingGroups(match)
+}
+
+// modelGroupMatcher returns a function reporting whether a firewall
+// group belongs to the model.
+func (c *fwaasFirewaller) modelGroupMatcher() (func(FirewallGroup) bool, error) {
+	re, err := regexp.Compile(c.jujuGroupRegexp())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelTags := map[string]string{tags.JujuModel: c.environ.Config().UUID()}
+	return func(group FirewallGroup) bool {
+		if groupTags := descriptionTags(group.Description); groupTags != nil {
+			return tagsMatch(groupTags, modelTags)
+		}
+		return re.MatchString(group.Name)
+	}, nil
+}
+
+// UpdateGroupController implements Firewaller interface.
+func (c *fwaasFirewaller) UpdateGroupController(controllerUUID string) error {
+	api := c.api()
+	groups, err := api.FirewallGroups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	match, err := c.modelGroupMatcher()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed []string
+	for _, group := range groups {
+		if !match(group) {
+			continue
+		}
+		if newName, err := replaceControllerUUID(group.Name, controllerUUID); err == nil {
+			group.Name = newName
+		}
+		if groupTags := descriptionTags(group.Description); groupTags != nil {
+			groupTags[tags.JujuController] = controllerUUID
+			group.Description = groupDescription(groupTags)
+		}
+		if err := api.UpdateFirewallGroup(group); err != nil {
+			logger.Errorf("error updating controller for firewall group %s: %v", group.Id, err)
+			failed = append(failed, group.Id)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("errors updating controller for firewall groups: %v", failed)
+	}
+	return nil
+}
+
+// UpdateAllowedIngress implements Firewaller interface. The base
+// ingress rules of each of the model's firewall groups are replaced by
+// ones allowing traffic from the allowed ingress CIDRs.
+func (c *fwaasFirewaller) UpdateAllowedIngress() error {
+	api := c.api()
+	groups, err := api.FirewallGroups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	match, err := c.modelGroupMatcher()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	allowed := c.environ.ecfg().allowedIngressCIDRs()
+	for _, group := range groups {
+		if !match(group) || group.IngressPolicyId == "" {
+			continue
+		}
+		existing, err := api.FirewallPolicyRules(group.IngressPolicyId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var base []FirewallRule
+		apiPort := 0
+		for _, rule := range existing {
+			if rule.Description != fwaasBaseRuleDescription {
+				continue
+			}
+			base = append(base, rule)
+			if port, err := strconv.Atoi(rule.DestinationPort); err == nil && port != 22 {
+				apiPort = port
+			}
+		}
+		wanted := baseIngressFirewallRules(apiPort, allowed)
+		want := make(map[FirewallRule]bool)
+		for _, rule := range wanted {
+			want[firewallRuleKey(rule)] = true
+		}
+		var unwanted []FirewallRule
+		for _, rule := range base {
+			if !want[firewallRuleKey(rule)] {
+				unwanted = append(unwanted, rule)
+			}
+		}
+		if err := c.removeRules(group.IngressPolicyId, unwanted); err != nil {
+			return errors.Annotatef(err, "updating firewall group %q", group.Name)
+		}
+		if err := c.addMissingRules(group.IngressPolicyId, wanted); err != nil {
+			return errors.Annotatef(err, "updating firewall group %q", group.Name)
+		}
+	}
+	return nil
+}
+
+// SetUpContainerGroups is not supported, as firewall groups are not
+// attached to the ports of containers.
+func (c *fwaasFirewaller) SetUpContainerGroups(controllerUUID, containerId string) ([]string, error) {
+	return nil, errors.NotSupportedf("container security groups with the %q firewaller", FirewallerFWaaS)
+}
+
+// DeleteContainerGroups is not supported, see SetUpContainerGroups.
+func (c *fwaasFirewaller) DeleteContainerGroups(ids ...string) error {
+	return errors.NotSupportedf("container security groups with the %q firewaller", FirewallerFWaaS)
+}
+
+// OpenContainerPorts is not supported, see SetUpContainerGroups.
+func (c *fwaasFirewaller) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("container security groups with the %q firewaller", FirewallerFWaaS)
+}
+
+// CloseContainerPorts is not supported, see SetUpContainerGroups.
+func (c *fwaasFirewaller) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("container security groups with the %q firewaller", FirewallerFWaaS)
+}
+
+// ContainerIngressRules is not supported, see SetUpContainerGroups.
+func (c *fwaasFirewaller) ContainerIngressRules(containerId string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("container security groups with the %q firewaller", FirewallerFWaaS)
+}
+
+// firewallRuleKey returns the rule without its ID, for comparing rules.
+func firewallRuleKey(rule FirewallRule) FirewallRule {
+	rule.Id = ""
+	return rule
+}
+
+// ipVersion returns the IP version of the CIDR.
+func ipVersion(cidr string) int {
+	if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() == nil {
+		return 6
+	}
+	return 4
+}
+
+// anywhereCIDR returns the CIDR for anywhere in the given IP version.
+func anywhereCIDR(version int) string {
+	if version == 6 {
+		return "::/0"
+	}
+	return "0.0.0.0/0"
+}
+
+// firewallRulePort returns the destination port of a firewall rule for
+// the port range.
+func firewallRulePort(portRange network.PortRange) string {
+	switch {
+	case portRange.Protocol == "icmp":
+		return ""
+	case portRange.FromPort == portRange.ToPort:
+		return strconv.Itoa(portRange.FromPort)
+	}
+	return fmt.Sprintf("%d:%d", portRange.FromPort, portRange.ToPort)
+}
+
+// firewallRulePortRange returns the port range of a firewall rule.
+func firewallRulePortRange(rule FirewallRule) (network.PortRange, error) {
+	portRange := network.PortRange{Protocol: rule.Protocol}
+	if rule.Protocol == "icmp" || rule.DestinationPort == "" {
+		portRange.FromPort, portRange.ToPort = -1, -1
+		return portRange, nil
+	}
+	from, to := rule.DestinationPort, rule.DestinationPort
+	if i := strings.Index(rule.DestinationPort, ":"); i >= 0 {
+		from, to = rule.DestinationPort[:i], rule.DestinationPort[i+1:]
+	}
+	var err error
+	if portRange.FromPort, err = strconv.Atoi(from); err != nil {
+		return network.PortRange{}, errors.Errorf("invalid destination port %q in firewall rule %q", rule.DestinationPort, rule.Id)
+	}
+	if portRange.ToPort, err = strconv.Atoi(to); err != nil {
+		return network.PortRange{}, errors.Errorf("invalid destination port %q in firewall rule %q", rule.DestinationPort, rule.Id)
+	}
+	return portRange, nil
+}
+
+// ingressFirewallRules returns the firewall rules for the ingress
+// rules, with one firewall rule for each source CIDR. Rules opened to
+// anywhere are opened to the anywhere prefixes. Firewall rules cannot
+// match ICMP types or codes, so only rules for any ICMP are allowed.
+func ingressFirewallRules(rules []network.IngressRule, anywhere []string) ([]FirewallRule, error) {
+	var result []FirewallRule
+	for _, rule := range expandAnywhere(rules, anywhere) {
+		if rule.Protocol == "icmp" && rule.FromPort != network.AnyICMP {
+			return nil, errors.NotSupportedf("ICMP type in firewall rule %v", rule)
+		}
+		sourceCIDRs := rule.SourceCIDRs
+		if len(sourceCIDRs) == 0 {
+			sourceCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range sourceCIDRs {
+			result = append(result, FirewallRule{
+				Description:     fwaasPortRuleDescription,
+				Protocol:        rule.Protocol,
+				IPVersion:       ipVersion(cidr),
+				SourceIPAddress: cidr,
+				DestinationPort: firewallRulePort(rule.PortRange),
+				Action:          "allow",
+			})
+		}
+	}
+	return result, nil
+}
+
+// egressFirewallRules returns the firewall rules for the egress rules,
+// with one firewall rule for each destination CIDR.
+func egressFirewallRules(rules []network.EgressRule) []FirewallRule {
+	var result []FirewallRule
+	for _, rule := range rules {
+		destinationCIDRs := rule.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range destinationCIDRs {
+			result = append(result, FirewallRule{
+				Description:          fwaasPortRuleDescription,
+				Protocol:             rule.Protocol,
+				IPVersion:            ipVersion(cidr),
+				DestinationIPAddress: cidr,
+				DestinationPort:      firewallRulePort(rule.PortRange),
+				Action:               "allow",
+			})
+		}
+	}
+	return result
+}
+
+// baseIngressFirewallRules returns the rules allowing SSH and API
+// traffic from the allowed CIDRs, which every firewall group has. If
+// no CIDRs are given, traffic is allowed from anywhere.
+func baseIngressFirewallRules(apiPort int, allowedCIDRs []string) []FirewallRule {
+	if len(allowedCIDRs) == 0 {
+		allowedCIDRs = []string{anywhereCIDR(4), anywhereCIDR(6)}
+	}
+	var rules []FirewallRule
+	for _, cidr := range allowedCIDRs {
+		for _, port := range []int{22, apiPort} {
+			if port == 0 {
+				continue
+			}
+			rules = append(rules, FirewallRule{
+				Description:     fwaasBaseRuleDescription,
+				Protocol:        "tcp",
+				IPVersion:       ipVersion(cidr),
+				SourceIPAddress: cidr,
+				DestinationPort: strconv.Itoa(port),
+				Action:          "allow",
+			})
+		}
+	}
+	return rules
+}
+
+// baseEgressFirewallRules returns the rules allowing the outgoing
+// traffic Juju needs when the egress policy is restricted: DNS, NTP,
+// HTTP, HTTPS and API traffic to anywhere.
+func baseEgressFirewallRules(apiPort int) []FirewallRule {
+	var rules []FirewallRule
+	for _, version := range []int{4, 6} {
+		for _, portRange := range []network.PortRange{
+			{Protocol: "tcp", FromPort: 53, ToPort: 53},
+			{Protocol: "udp", FromPort: 53, ToPort: 53},
+			{Protocol: "udp", FromPort: 123, ToPort: 123},
+			{Protocol: "tcp", FromPort: 80, ToPort: 80},
+			{Protocol: "tcp", FromPort: 443, ToPort: 443},
+			{Protocol: "tcp", FromPort: apiPort, ToPort: apiPort},
+		} {
+			if portRange.FromPort == 0 {
+				continue
+			}
+			rules = append(rules, FirewallRule{
+				Description:          fwaasBaseRuleDescription,
+				Protocol:             portRange.Protocol,
+				IPVersion:            version,
+				DestinationIPAddress: anywhereCIDR(version),
+				DestinationPort:      firewallRulePort(portRange),
+				Action:               "allow",
+			})
+		}
+	}
+	return rules
+}
+
+// subnetIngressFirewallRules returns the rules allowing all traffic
+// from the subnets the model's machines are on.
+func subnetIngressFirewallRules(subnetCIDRs []string) []FirewallRule {
+	rules := make([]FirewallRule, len(subnetCIDRs))
+	for i, cidr := range subnetCIDRs {
+		rules[i] = FirewallRule{
+			Description:     fwaasBaseRuleDescription,
+			IPVersion:       ipVersion(cidr),
+			SourceIPAddress: cidr,
+			Action:          "allow",
+		}
+	}
+	return rules
+}
+
+// subnetEgressFirewallRules returns the rules allowing all traffic to
+// the subnets the model's machines are on.
+func subnetEgressFirewallRules(subnetCIDRs []string) []FirewallRule {
+	rules := make([]FirewallRule, len(subnetCIDRs))
+	for i, cidr := range subnetCIDRs {
+		rules[i] = FirewallRule{
+			Description:          fwaasBaseRuleDescription,
+			IPVersion:            ipVersion(cidr),
+			DestinationIPAddress: cidr,
+			Action:               "allow",
+		}
+	}
+	return rules
+}
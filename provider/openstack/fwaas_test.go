@@ -0,0 +1,453 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/openstack"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func (s *localServerSuite) openFWaaSEnviron(c *gc.C, attrs coretesting.Attrs) environs.Environ {
+	return s.openEnviron(c, coretesting.Attrs{"firewall-implementation": "fwaas"}.Merge(attrs))
+}
+
+func (s *localServerSuite) TestFWaaSSetUpGroups(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+
+	c.Assert(s.firewallAPI.groups, gc.HasLen, 1)
+	group := s.firewallAPI.groups[0]
+	c.Assert(group.Name, gc.Matches, "juju-.*-100")
+	c.Assert(group.Description, gc.Matches, `juju group \[.*juju-machine-id=100.*\]`)
+	c.Assert(group.EgressPolicyId, gc.Equals, "")
+	ports := make(map[string]bool)
+	for _, rule := range s.firewallAPI.policies[group.IngressPolicyId] {
+		c.Check(rule.Description, gc.Equals, "juju base rule")
+		ports[rule.DestinationPort] = true
+	}
+	// SSH and API traffic is allowed.
+	c.Assert(ports, gc.HasLen, 2)
+	c.Assert(ports["22"], jc.IsTrue)
+
+	// No security groups are created for the machine.
+	groups, err := openstack.GetNeutronClient(env).ListSecurityGroupsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, group := range groups {
+		c.Check(group.Name, gc.Not(gc.Matches), "juju-.*")
+	}
+}
+
+func (s *localServerSuite) TestFWaaSInstancePorts(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24", "10.0.0.0/24"),
+		network.MustNewIngressRule("tcp", 8000, 8010),
+		network.MustNewIngressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The instance's ports are added to its firewall group, and
+	// traffic is allowed from the subnets they are on.
+	group := s.firewallAPI.groups[0]
+	c.Assert(group.Ports, jc.DeepEquals, []string{"port-" + string(inst.Id())})
+	c.Assert(s.firewallAPI.hasRule(group.IngressPolicyId, openstack.FirewallRule{
+		Description:     "juju base rule",
+		IPVersion:       4,
+		SourceIPAddress: "10.20.30.0/24",
+		Action:          "allow",
+	}), jc.IsTrue)
+
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+		network.MustNewIngressRule("tcp", 8000, 8010, "0.0.0.0/0"),
+		network.MustNewIngressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+
+	// Opening the same ports again doesn't add any rules.
+	count := len(s.firewallAPI.policies[group.IngressPolicyId])
+	err = fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8000, 8010),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.firewallAPI.policies[group.IngressPolicyId], gc.HasLen, count)
+
+	err = fw.CloseInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+		network.MustNewIngressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8000, 8010, "0.0.0.0/0"),
+	})
+}
+
+func (s *localServerSuite) TestFWaaSGlobalPorts(c *gc.C) {
+	env := s.openFWaaSEnviron(c, coretesting.Attrs{"firewall-mode": config.FwGlobal})
+	inst1, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	inst2, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "101")
+	fw := openstack.GetFirewaller(env)
+
+	// The machines share the global firewall group.
+	c.Assert(s.firewallAPI.groups, gc.HasLen, 1)
+	c.Assert(s.firewallAPI.groups[0].Name, gc.Matches, "juju-.*-global")
+
+	err := fw.OpenPorts([]network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.firewallAPI.groups[0].Ports, jc.SameContents, []string{
+		"port-" + string(inst1.Id()),
+		"port-" + string(inst2.Id()),
+	})
+
+	rules, err := fw.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+
+	err = fw.ClosePorts([]network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	_, err = fw.InstanceIngressRules(inst1, "100")
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for retrieving ingress rules from instance`)
+}
+
+func (s *localServerSuite) TestFWaaSICMP(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1, "0.0.0.0/0"),
+	})
+
+	err = fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("icmp", 8, -1),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `ICMP type in firewall rule 8/icmp.* not supported`)
+}
+
+func (s *localServerSuite) TestFWaaSDualStack(c *gc.C) {
+	env := s.openFWaaSEnviron(c, coretesting.Attrs{"ip-address-family": "dual-stack"})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	group := s.firewallAPI.groups[0]
+	c.Assert(s.firewallAPI.hasRule(group.IngressPolicyId, openstack.FirewallRule{
+		Description:     "juju port rule",
+		Protocol:        "tcp",
+		IPVersion:       6,
+		SourceIPAddress: "::/0",
+		DestinationPort: "80",
+		Action:          "allow",
+	}), jc.IsTrue)
+
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
+func (s *localServerSuite) TestFWaaSEgressRestricted(c *gc.C) {
+	env := s.openFWaaSEnviron(c, coretesting.Attrs{
+		"egress-policy": "restricted",
+		"egress-rules":  "8080-8089/tcp",
+	})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	rules, err := fw.InstanceEgressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 8080, 8089, "0.0.0.0/0"),
+	})
+	group := s.firewallAPI.groups[0]
+	c.Assert(s.firewallAPI.hasRule(group.EgressPolicyId, openstack.FirewallRule{
+		Description:          "juju base rule",
+		IPVersion:            4,
+		DestinationIPAddress: "10.20.30.0/24",
+		Action:               "allow",
+	}), jc.IsTrue)
+
+	err = fw.OpenInstanceEgressPorts(inst, "100", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fw.CloseInstanceEgressPorts(inst, "100", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 8080, 8089),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.InstanceEgressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+	})
+}
+
+func (s *localServerSuite) TestFWaaSEgressAllowAll(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	// Without an egress policy, all egress traffic is allowed.
+	err := fw.OpenInstanceEgressPorts(inst, "100", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5432),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.InstanceEgressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestFWaaSApplicationPortsNotSupported(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	fw := openstack.GetFirewaller(env)
+	err := fw.OpenApplicationPorts("mysql", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(fw.DeleteApplicationGroup("mysql"), jc.ErrorIsNil)
+}
+
+func (s *localServerSuite) TestFWaaSStopInstanceDeletesGroup(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = env.StopInstances(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.firewallAPI.groups, gc.HasLen, 0)
+	c.Assert(s.firewallAPI.policies, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestFWaaSDeleteAllModelGroups(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	s.firewallAPI.groups = append(s.firewallAPI.groups, openstack.FirewallGroup{
+		Id:          "other",
+		Name:        "other-group",
+		Description: "juju group [juju-model-uuid=deadbeef]",
+	})
+
+	err := openstack.GetFirewaller(env).DeleteAllModelGroups(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.firewallAPI.groups, gc.HasLen, 1)
+	c.Assert(s.firewallAPI.groups[0].Name, gc.Equals, "other-group")
+}
+
+func (s *localServerSuite) TestFWaaSUpdateGroupController(c *gc.C) {
+	env := s.openFWaaSEnviron(c, nil)
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+
+	newUUID := "aabbccdd-1234-8765-abcd-0123456789ab"
+	fw := openstack.GetFirewaller(env)
+	err := fw.UpdateGroupController(newUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	group := s.firewallAPI.groups[0]
+	c.Assert(group.Name, gc.Matches, "juju-"+newUUID+"-.*-100")
+	c.Assert(group.Description, gc.Matches, `juju group \[juju-controller-uuid=`+newUUID+` .*\]`)
+
+	err = fw.DeleteAllControllerGroups(s.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.firewallAPI.groups, gc.HasLen, 1)
+	err = fw.DeleteAllControllerGroups(newUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.firewallAPI.groups, gc.HasLen, 0)
+}
+
+// fakeFirewallAPI is an in-memory FirewallAPI. Each server has a single
+// port, on a subnet with the CIDR 10.20.30.0/24.
+type fakeFirewallAPI struct {
+	gitjujutesting.Stub
+	nextId   int
+	groups   []openstack.FirewallGroup
+	policies map[string][]openstack.FirewallRule
+}
+
+func newFakeFirewallAPI() *fakeFirewallAPI {
+	return &fakeFirewallAPI{policies: make(map[string][]openstack.FirewallRule)}
+}
+
+func (f *fakeFirewallAPI) newId(kind string) string {
+	f.nextId++
+	return fmt.Sprintf("%s-%d", kind, f.nextId)
+}
+
+func (f *fakeFirewallAPI) hasRule(policyId string, want openstack.FirewallRule) bool {
+	for _, rule := range f.policies[policyId] {
+		rule.Id = ""
+		if rule == want {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeFirewallAPI) FirewallGroups() ([]openstack.FirewallGroup, error) {
+	f.MethodCall(f, "FirewallGroups")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return append([]openstack.FirewallGroup(nil), f.groups...), nil
+}
+
+func (f *fakeFirewallAPI) CreateFirewallGroup(group openstack.FirewallGroup) (openstack.FirewallGroup, error) {
+	f.MethodCall(f, "CreateFirewallGroup", group)
+	if err := f.NextErr(); err != nil {
+		return openstack.FirewallGroup{}, err
+	}
+	group.Id = f.newId("group")
+	f.groups = append(f.groups, group)
+	return group, nil
+}
+
+func (f *fakeFirewallAPI) UpdateFirewallGroup(group openstack.FirewallGroup) error {
+	f.MethodCall(f, "UpdateFirewallGroup", group)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	for i, existing := range f.groups {
+		if existing.Id == group.Id {
+			existing.Name = group.Name
+			existing.Description = group.Description
+			existing.Ports = group.Ports
+			f.groups[i] = existing
+			return nil
+		}
+	}
+	return errors.NotFoundf("firewall group %q", group.Id)
+}
+
+func (f *fakeFirewallAPI) DeleteFirewallGroup(id string) error {
+	f.MethodCall(f, "DeleteFirewallGroup", id)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	for i, group := range f.groups {
+		if group.Id == id {
+			f.groups = append(f.groups[:i], f.groups[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *fakeFirewallAPI) CreateFirewallPolicy(name, description string) (string, error) {
+	f.MethodCall(f, "CreateFirewallPolicy", name, description)
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	id := f.newId("policy")
+	f.policies[id] = []openstack.FirewallRule{}
+	return id, nil
+}
+
+func (f *fakeFirewallAPI) DeleteFirewallPolicy(id string) error {
+	f.MethodCall(f, "DeleteFirewallPolicy", id)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	delete(f.policies, id)
+	return nil
+}
+
+func (f *fakeFirewallAPI) FirewallPolicyRules(policyId string) ([]openstack.FirewallRule, error) {
+	f.MethodCall(f, "FirewallPolicyRules", policyId)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	rules, ok := f.policies[policyId]
+	if !ok {
+		return nil, errors.NotFoundf("firewall policy %q", policyId)
+	}
+	return append([]openstack.FirewallRule(nil), rules...), nil
+}
+
+func (f *fakeFirewallAPI) AddFirewallRule(policyId string, rule openstack.FirewallRule) error {
+	f.MethodCall(f, "AddFirewallRule", policyId, rule)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	if _, ok := f.policies[policyId]; !ok {
+		return errors.NotFoundf("firewall policy %q", policyId)
+	}
+	rule.Id = f.newId("rule")
+	f.policies[policyId] = append(f.policies[policyId], rule)
+	return nil
+}
+
+func (f *fakeFirewallAPI) RemoveFirewallRule(policyId, ruleId string) error {
+	f.MethodCall(f, "RemoveFirewallRule", policyId, ruleId)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	rules := f.policies[policyId]
+	for i, rule := range rules {
+		if rule.Id == ruleId {
+			f.policies[policyId] = append(rules[:i], rules[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("firewall rule %q", ruleId)
+}
+
+func (f *fakeFirewallAPI) ServerPorts(serverId string) ([]openstack.FirewallPort, error) {
+	f.MethodCall(f, "ServerPorts", serverId)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return []openstack.FirewallPort{{
+		Id:        "port-" + serverId,
+		SubnetIds: []string{"subnet-1"},
+	}}, nil
+}
+
+func (f *fakeFirewallAPI) SubnetCIDR(subnetId string) (string, error) {
+	f.MethodCall(f, "SubnetCIDR", subnetId)
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	if subnetId != "subnet-1" {
+		return "", errors.NotFoundf("subnet %q", subnetId)
+	}
+	return "10.20.30.0/24", nil
+}
+
+var _ openstack.FirewallAPI = (*fakeFirewallAPI)(nil)
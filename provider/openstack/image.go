@@ -4,6 +4,7 @@
 package openstack
 
 import (
+	"github.com/juju/utils/set"
 	"gopkg.in/goose.v2/nova"
 
 	"github.com/juju/juju/environs/imagemetadata"
@@ -37,6 +38,7 @@ func findInstanceSpec(
 	e *Environ,
 	ic *instances.InstanceConstraint,
 	imageMetadata []*imagemetadata.ImageMetadata,
+	excludedInstanceTypes []string,
 ) (*instances.InstanceSpec, error) {
 	// First construct all available instance types from the supported flavors.
 	nova := e.nova()
@@ -48,11 +50,15 @@ func findInstanceSpec(
 	// for e.g. architectures or virtualisation types.
 	// For these properties, we assume that all instance types support
 	// all values.
+	excluded := set.NewStrings(excludedInstanceTypes...)
 	allInstanceTypes := []instances.InstanceType{}
 	for _, flavor := range flavors {
 		if !e.flavorFilter.AcceptFlavor(flavor) {
 			continue
 		}
+		if excluded.Contains(flavor.Name) || excluded.Contains(flavor.Id) {
+			continue
+		}
 		instanceType := instances.InstanceType{
 			Id:       flavor.Id,
 			Name:     flavor.Name,
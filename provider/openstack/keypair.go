@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/goose.v2/client"
+	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/environs"
+)
+
+// KeyPairAPI manages nova keypairs.
+type KeyPairAPI interface {
+	// CreateKeyPair creates a keypair with the specified name and
+	// public key.
+	CreateKeyPair(name, publicKey string) error
+
+	// KeyPairExists reports whether a keypair with the specified name
+	// exists.
+	KeyPairExists(name string) (bool, error)
+
+	// DeleteKeyPair deletes the keypair with the specified name. It is
+	// not an error to delete a keypair that does not exist.
+	DeleteKeyPair(name string) error
+}
+
+var newKeyPairAPI = func(e *Environ) KeyPairAPI {
+	return &novaKeyPairAPI{e.client()}
+}
+
+// novaKeyPairAPI implements KeyPairAPI. The goose nova client does not
+// support keypairs, so requests are made directly.
+type novaKeyPairAPI struct {
+	client client.Client
+}
+
+// CreateKeyPair is part of the KeyPairAPI interface.
+func (api *novaKeyPairAPI) CreateKeyPair(name, publicKey string) error {
+	requestData := goosehttp.RequestData{
+		ReqValue: map[string]interface{}{
+			"keypair": map[string]string{
+				"name":       name,
+				"public_key": publicKey,
+			},
+		},
+		ExpectedStatus: []int{http.StatusOK, http.StatusCreated},
+	}
+	return api.client.SendRequest(client.POST, "compute", "v2", "os-keypairs", &requestData)
+}
+
+// KeyPairExists is part of the KeyPairAPI interface.
+func (api *novaKeyPairAPI) KeyPairExists(name string) (bool, error) {
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	err := api.client.SendRequest(client.GET, "compute", "v2", "os-keypairs/"+name, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteKeyPair is part of the KeyPairAPI interface.
+func (api *novaKeyPairAPI) DeleteKeyPair(name string) error {
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusAccepted, http.StatusNoContent},
+	}
+	err := api.client.SendRequest(client.DELETE, "compute", "v2", "os-keypairs/"+name, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// controllerKeyPairName returns the name of the nova keypair belonging
+// to the controller with the specified UUID.
+func controllerKeyPairName(controllerUUID string) string {
+	return "juju-" + controllerUUID
+}
+
+// createControllerKeyPair creates the controller's keypair from the
+// first of the model's authorized keys, replacing any existing keypair
+// with the same name, and returns the keypair's name.
+func (e *Environ) createControllerKeyPair(controllerUUID string) (string, error) {
+	keys := ssh.SplitAuthorisedKeys(e.Config().AuthorizedKeys())
+	if len(keys) == 0 {
+		return "", errors.NotFoundf("authorized keys")
+	}
+	name := controllerKeyPairName(controllerUUID)
+	if err := e.replaceKeyPair(name, keys[0]); err != nil {
+		return "", errors.Trace(err)
+	}
+	return name, nil
+}
+
+// controllerKeyPair returns the name of the controller's keypair, or
+// the empty string if the controller does not have one. Controllers
+// bootstrapped before keypairs were managed by Juju have none.
+func (e *Environ) controllerKeyPair(controllerUUID string) (string, error) {
+	name := controllerKeyPairName(controllerUUID)
+	exists, err := newKeyPairAPI(e).KeyPairExists(name)
+	if err != nil {
+		return "", errors.Annotatef(err, "checking keypair %q", name)
+	}
+	if !exists {
+		return "", nil
+	}
+	return name, nil
+}
+
+func (e *Environ) replaceKeyPair(name, publicKey string) error {
+	api := newKeyPairAPI(e)
+	// Nova keypairs cannot be updated, so any existing keypair is
+	// replaced.
+	if err := api.DeleteKeyPair(name); err != nil {
+		return errors.Annotatef(err, "deleting keypair %q", name)
+	}
+	logger.Debugf("creating keypair %q", name)
+	if err := api.CreateKeyPair(name, publicKey); err != nil {
+		return errors.Annotatef(err, "creating keypair %q", name)
+	}
+	return nil
+}
+
+var _ environs.ControllerKeyPairRotator = (*Environ)(nil)
+
+// RotateControllerKeyPair implements environs.ControllerKeyPairRotator.
+func (e *Environ) RotateControllerKeyPair(controllerUUID, publicKey string) error {
+	if _, err := ssh.ParseAuthorisedKey(publicKey); err != nil {
+		return errors.NotValidf("public key")
+	}
+	name := controllerKeyPairName(controllerUUID)
+	exists, err := newKeyPairAPI(e).KeyPairExists(name)
+	if err != nil {
+		return errors.Annotatef(err, "checking keypair %q", name)
+	}
+	if !exists {
+		return errors.NotFoundf("keypair %q", name)
+	}
+	return errors.Trace(e.replaceKeyPair(name, publicKey))
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/ssh"
+	sshtesting "github.com/juju/utils/ssh/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/juju/testing"
+)
+
+func (s *localServerSuite) controllerKeyPairName() string {
+	return "juju-" + s.ControllerUUID
+}
+
+func (s *localServerSuite) TestBootstrapCreatesControllerKeyPair(c *gc.C) {
+	err := bootstrapEnv(c, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	authorizedKeys := ssh.SplitAuthorisedKeys(s.env.Config().AuthorizedKeys())
+	c.Assert(s.keyPairAPI.keyPairs, jc.DeepEquals, map[string]string{
+		s.controllerKeyPairName(): authorizedKeys[0],
+	})
+}
+
+func (s *localServerSuite) TestBootstrapKeyPairErrorNotFatal(c *gc.C) {
+	s.keyPairAPI.SetErrors(nil, errors.New("keypairs disabled"))
+	err := bootstrapEnv(c, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.keyPairAPI.keyPairs, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestStartInstanceChecksControllerKeyPair(c *gc.C) {
+	testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
+	s.keyPairAPI.CheckCalls(c, []gitjujutesting.StubCall{
+		{"KeyPairExists", []interface{}{s.controllerKeyPairName()}},
+	})
+}
+
+func (s *localServerSuite) TestRotateControllerKeyPair(c *gc.C) {
+	s.keyPairAPI.keyPairs[s.controllerKeyPairName()] = "old-key"
+	publicKey := sshtesting.ValidKeyTwo.Key + " new"
+	err := s.env.(environs.ControllerKeyPairRotator).RotateControllerKeyPair(s.ControllerUUID, publicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.keyPairAPI.keyPairs, jc.DeepEquals, map[string]string{
+		s.controllerKeyPairName(): publicKey,
+	})
+	s.keyPairAPI.CheckCallNames(c, "KeyPairExists", "DeleteKeyPair", "CreateKeyPair")
+}
+
+func (s *localServerSuite) TestRotateControllerKeyPairNotFound(c *gc.C) {
+	err := s.env.(environs.ControllerKeyPairRotator).RotateControllerKeyPair(
+		s.ControllerUUID, sshtesting.ValidKeyTwo.Key,
+	)
+	c.Assert(err, gc.ErrorMatches, `keypair "juju-.*" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.keyPairAPI.CheckCallNames(c, "KeyPairExists")
+}
+
+func (s *localServerSuite) TestRotateControllerKeyPairInvalidKey(c *gc.C) {
+	err := s.env.(environs.ControllerKeyPairRotator).RotateControllerKeyPair(s.ControllerUUID, "nonsense")
+	c.Assert(err, gc.ErrorMatches, "public key not valid")
+	s.keyPairAPI.CheckNoCalls(c)
+}
+
+func (s *localServerSuite) TestDestroyControllerDeletesKeyPair(c *gc.C) {
+	s.keyPairAPI.keyPairs[s.controllerKeyPairName()] = "key"
+	err := s.env.DestroyController(s.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.keyPairAPI.keyPairs, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestRemoveRecordedKeyPair(c *gc.C) {
+	s.keyPairAPI.keyPairs[s.controllerKeyPairName()] = "key"
+	err := s.env.(environs.ResourceRemover).RemoveResources([]environs.BootstrapResource{{
+		Kind: environs.ResourceKindKeyPair,
+		Id:   s.controllerKeyPairName(),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.keyPairAPI.keyPairs, gc.HasLen, 0)
+}
+
+type fakeKeyPairAPI struct {
+	gitjujutesting.Stub
+	keyPairs map[string]string
+}
+
+func (f *fakeKeyPairAPI) CreateKeyPair(name, publicKey string) error {
+	f.MethodCall(f, "CreateKeyPair", name, publicKey)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.keyPairs[name] = publicKey
+	return nil
+}
+
+func (f *fakeKeyPairAPI) KeyPairExists(name string) (bool, error) {
+	f.MethodCall(f, "KeyPairExists", name)
+	_, ok := f.keyPairs[name]
+	return ok, f.NextErr()
+}
+
+func (f *fakeKeyPairAPI) DeleteKeyPair(name string) error {
+	f.MethodCall(f, "DeleteKeyPair", name)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	delete(f.keyPairs, name)
+	return nil
+}
@@ -8,11 +8,11 @@ import (
 	"regexp"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils/clock"
 	gooseerrors "gopkg.in/goose.v2/errors"
 	"gopkg.in/goose.v2/neutron"
 	"gopkg.in/goose.v2/nova"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -29,7 +29,10 @@ type legacyNovaFirewaller struct {
 // other instances that might be running on the same OpenStack account.
 // In addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (c *legacyNovaFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
+//
+// Application security groups are not supported, so the application
+// names are ignored.
+func (c *legacyNovaFirewaller) SetUpGroups(controllerUUID, machineId string, applicationNames []string, apiPort int) ([]string, error) {
 	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -53,19 +56,7 @@ func (c *legacyNovaFirewaller) SetUpGroups(controllerUUID, machineId string, api
 
 func (c *legacyNovaFirewaller) setUpGlobalGroup(groupName string, apiPort int) (nova.SecurityGroup, error) {
 	return c.ensureGroup(groupName,
-		[]nova.RuleInfo{
-			{
-				IPProtocol: "tcp",
-				ToPort:     22,
-				FromPort:   22,
-				Cidr:       "0.0.0.0/0",
-			},
-			{
-				IPProtocol: "tcp",
-				ToPort:     apiPort,
-				FromPort:   apiPort,
-				Cidr:       "0.0.0.0/0",
-			},
+		append(c.allowedIngressRules(apiPort), []nova.RuleInfo{
 			{
 				IPProtocol: "tcp",
 				FromPort:   1,
@@ -81,7 +72,111 @@ func (c *legacyNovaFirewaller) setUpGlobalGroup(groupName string, apiPort int) (
 				FromPort:   -1,
 				ToPort:     -1,
 			},
-		})
+		}...))
+}
+
+// allowedIngressRules returns the rules of the juju group that allow
+// SSH and API traffic from the model's allowed ingress CIDRs.
+func (c *legacyNovaFirewaller) allowedIngressRules(apiPort int) []nova.RuleInfo {
+	var rules []nova.RuleInfo
+	for _, port := range []int{22, apiPort} {
+		for _, cidr := range c.allowedIngressCIDRs([]string{"0.0.0.0/0"}) {
+			rules = append(rules, nova.RuleInfo{
+				IPProtocol: "tcp",
+				ToPort:     port,
+				FromPort:   port,
+				Cidr:       cidr,
+			})
+		}
+	}
+	return rules
+}
+
+// UpdateAllowedIngress implements Firewaller interface. As ensureGroup
+// leaves existing groups as they are, the SSH and API rules of the juju
+// group are reconciled here.
+func (c *legacyNovaFirewaller) UpdateAllowedIngress() error {
+	group, err := c.matchingGroup(c.jujuGroupRegexp() + "$")
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// The SSH and API rules are the only single port rules with a CIDR.
+	isAllowedIngressRule := func(rule nova.SecurityGroupRule) bool {
+		return rule.IPProtocol != nil && *rule.IPProtocol == "tcp" &&
+			rule.IPRange["cidr"] != "" && rule.FromPort != nil && rule.ToPort != nil &&
+			*rule.FromPort == *rule.ToPort
+	}
+	apiPort := 0
+	for _, rule := range group.Rules {
+		if isAllowedIngressRule(rule) && *rule.FromPort != 22 {
+			apiPort = *rule.FromPort
+			break
+		}
+	}
+	if apiPort == 0 {
+		return errors.Errorf("cannot determine API port from security group %q", group.Name)
+	}
+	want := make(map[nova.RuleInfo]bool)
+	for _, rule := range c.allowedIngressRules(apiPort) {
+		want[rule] = true
+	}
+	novaclient := c.environ.nova()
+	for _, rule := range group.Rules {
+		if !isAllowedIngressRule(rule) {
+			continue
+		}
+		key := nova.RuleInfo{
+			IPProtocol: "tcp",
+			ToPort:     *rule.ToPort,
+			FromPort:   *rule.FromPort,
+			Cidr:       rule.IPRange["cidr"],
+		}
+		if want[key] {
+			delete(want, key)
+			continue
+		}
+		if err := novaclient.DeleteSecurityGroupRule(rule.Id); err != nil {
+			return errors.Annotatef(err, "deleting rule from security group %q", group.Name)
+		}
+	}
+	for _, rule := range c.allowedIngressRules(apiPort) {
+		if !want[rule] {
+			continue
+		}
+		rule.ParentGroupId = group.Id
+		if _, err := novaclient.CreateSecurityGroupRule(rule); err != nil && !gooseerrors.IsDuplicateValue(err) {
+			return errors.Annotatef(err, "adding rule to security group %q", group.Name)
+		}
+	}
+	return nil
+}
+
+// SetUpContainerGroups is not supported, as nova security groups cannot
+// be attached to neutron ports.
+func (c *legacyNovaFirewaller) SetUpContainerGroups(controllerUUID, containerId string) ([]string, error) {
+	return nil, errors.NotSupportedf("container security groups without neutron")
+}
+
+// DeleteContainerGroups is not supported, see SetUpContainerGroups.
+func (c *legacyNovaFirewaller) DeleteContainerGroups(ids ...string) error {
+	return errors.NotSupportedf("container security groups without neutron")
+}
+
+// OpenContainerPorts is not supported, see SetUpContainerGroups.
+func (c *legacyNovaFirewaller) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("container security groups without neutron")
+}
+
+// CloseContainerPorts is not supported, see SetUpContainerGroups.
+func (c *legacyNovaFirewaller) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("container security groups without neutron")
+}
+
+// ContainerIngressRules is not supported, see SetUpContainerGroups.
+func (c *legacyNovaFirewaller) ContainerIngressRules(containerId string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("container security groups without neutron")
 }
 
 // legacyZeroGroup holds the zero security group.
@@ -142,17 +237,21 @@ func (c *legacyNovaFirewaller) deleteSecurityGroups(match func(name string) bool
 	if err != nil {
 		return errors.Annotate(err, "cannot list security groups")
 	}
+	failed := make(map[string]error)
 	for _, group := range securityGroups {
-		if match(group.Name) {
-			deleteSecurityGroup(
-				novaclient.DeleteSecurityGroup,
-				group.Name,
-				group.Id,
-				clock.WallClock,
-			)
+		if !match(group.Name) {
+			continue
+		}
+		if err := deleteSecurityGroup(
+			novaclient.DeleteSecurityGroup,
+			group.Name,
+			group.Id,
+			c.environ.retryStrategy(),
+		); err != nil {
+			failed[group.Name] = err
 		}
 	}
-	return nil
+	return securityGroupsNotDeleted(failed)
 }
 
 // DeleteAllControllerGroups implements Firewaller interface.
@@ -160,8 +259,10 @@ func (c *legacyNovaFirewaller) DeleteAllControllerGroups(controllerUUID string)
 	return deleteSecurityGroupsMatchingName(c.deleteSecurityGroups, c.jujuControllerGroupPrefix(controllerUUID))
 }
 
-// DeleteAllModelGroups implements Firewaller interface.
-func (c *legacyNovaFirewaller) DeleteAllModelGroups() error {
+// DeleteAllModelGroups implements Firewaller interface. Nova offers
+// no way to detach a group from a server's ports, so force makes no
+// difference.
+func (c *legacyNovaFirewaller) DeleteAllModelGroups(force bool) error {
 	return deleteSecurityGroupsMatchingName(c.deleteSecurityGroups, c.jujuGroupRegexp())
 }
 
@@ -231,6 +332,64 @@ func (c *legacyNovaFirewaller) InstanceIngressRules(inst instance.Instance, mach
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenApplicationPorts is not supported.
+func (c *legacyNovaFirewaller) OpenApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("application security groups")
+}
+
+// CloseApplicationPorts is not supported.
+func (c *legacyNovaFirewaller) CloseApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("application security groups")
+}
+
+// ApplicationIngressRules is not supported.
+func (c *legacyNovaFirewaller) ApplicationIngressRules(applicationName string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("application security groups")
+}
+
+// MachineSecurityGroups is not supported, as Nova security groups do
+// not record which ports they are attached to.
+func (c *legacyNovaFirewaller) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	return nil, errors.NotSupportedf("machine security group collection")
+}
+
+// DeleteApplicationGroup does nothing, as application security groups
+// are never created.
+func (c *legacyNovaFirewaller) DeleteApplicationGroup(applicationName string) error {
+	return nil
+}
+
+// OpenEgressPorts is not supported, as Nova security groups
+// only have ingress rules.
+func (c *legacyNovaFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules")
+}
+
+// CloseEgressPorts is not supported.
+func (c *legacyNovaFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules")
+}
+
+// EgressRules is not supported.
+func (c *legacyNovaFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules")
+}
+
+// OpenInstanceEgressPorts is not supported.
+func (c *legacyNovaFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules")
+}
+
+// CloseInstanceEgressPorts is not supported.
+func (c *legacyNovaFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules")
+}
+
+// InstanceEgressRules is not supported.
+func (c *legacyNovaFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules")
+}
+
 func (c *legacyNovaFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
@@ -256,8 +415,11 @@ func (c *legacyNovaFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGr
 	return matchingGroups[0], nil
 }
 
-func (c *legacyNovaFirewaller) openPortsInGroup(nameRegExp string, rules []network.IngressRule) error {
-	group, err := c.matchingGroup(nameRegExp)
+func (c *legacyNovaFirewaller) openPortsInGroup(sel groupSelector, rules []network.IngressRule) error {
+	if err := checkICMPRules(rules); err != nil {
+		return errors.Trace(err)
+	}
+	group, err := c.matchingGroup(sel.nameRegexp)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -274,13 +436,20 @@ func (c *legacyNovaFirewaller) openPortsInGroup(nameRegExp string, rules []netwo
 }
 
 func legacyRuleInfo(in neutron.RuleInfoV2) nova.RuleInfo {
-	return nova.RuleInfo{
+	info := nova.RuleInfo{
 		ParentGroupId: in.ParentGroupId,
 		FromPort:      in.PortRangeMin,
 		ToPort:        in.PortRangeMax,
 		IPProtocol:    in.IPProtocol,
 		Cidr:          in.RemoteIPPrefix,
 	}
+	if in.IPProtocol == "icmp" {
+		// Nova, unlike Neutron, takes -1
+		// to mean any ICMP type or code.
+		info.FromPort = icmpTypeCode(&in.PortRangeMin)
+		info.ToPort = icmpTypeCode(&in.PortRangeMax)
+	}
+	return info
 }
 
 // ruleMatchesPortRange checks if supplied nova security group rule matches the port range
@@ -293,11 +462,11 @@ func legacyRuleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.I
 		*rule.ToPort == portRange.ToPort
 }
 
-func (c *legacyNovaFirewaller) closePortsInGroup(nameRegExp string, rules []network.IngressRule) error {
+func (c *legacyNovaFirewaller) closePortsInGroup(sel groupSelector, rules []network.IngressRule) error {
 	if len(rules) == 0 {
 		return nil
 	}
-	group, err := c.matchingGroup(nameRegExp)
+	group, err := c.matchingGroup(sel.nameRegexp)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -317,8 +486,8 @@ func (c *legacyNovaFirewaller) closePortsInGroup(nameRegExp string, rules []netw
 	return nil
 }
 
-func (c *legacyNovaFirewaller) ingressRulesInGroup(nameRegexp string) (rules []network.IngressRule, err error) {
-	group, err := c.matchingGroup(nameRegexp)
+func (c *legacyNovaFirewaller) ingressRulesInGroup(sel groupSelector) (rules []network.IngressRule, err error) {
+	group, err := c.matchingGroup(sel.nameRegexp)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -54,8 +54,8 @@ func (n *LegacyNovaNetworking) AllocatePublicIP(instId instance.Id) (*string, er
 }
 
 // DefaultNetworks is part of the Networking interface.
-func (*LegacyNovaNetworking) DefaultNetworks() ([]nova.ServerNetworks, error) {
-	return []nova.ServerNetworks{}, nil
+func (n *LegacyNovaNetworking) DefaultNetworks() ([]nova.ServerNetworks, error) {
+	return resolveDefaultNetworks(n, n.env.ecfg().networks())
 }
 
 // ResolveNetwork is part of the Networking interface.
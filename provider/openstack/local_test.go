@@ -92,6 +92,7 @@ func registerLocalTests() {
 	config["agent-version"] = coretesting.FakeVersionNumber.String()
 	config["authorized-keys"] = "fakekey"
 	config["network"] = "net"
+	config["retry-delay"] = "1ms"
 	gc.Suite(&localLiveSuite{
 		LiveTests: LiveTests{
 			cred: cred,
@@ -250,6 +251,9 @@ type localServerSuite struct {
 	toolsMetadataStorage envstorage.Storage
 	imageMetadataStorage envstorage.Storage
 	storageAdapter       *mockAdapter
+	keyPairAPI           *fakeKeyPairAPI
+	firewallAPI          *fakeFirewallAPI
+	containerPortAPI     *fakeContainerPortAPI
 }
 
 func (s *localServerSuite) SetUpSuite(c *gc.C) {
@@ -292,6 +296,18 @@ func (s *localServerSuite) SetUpTest(c *gc.C) {
 	openstack.UseTestImageData(s.imageMetadataStorage, s.cred)
 	s.storageAdapter = makeMockAdapter()
 	overrideCinderProvider(c, &s.CleanupSuite, s.storageAdapter)
+	s.keyPairAPI = &fakeKeyPairAPI{keyPairs: make(map[string]string)}
+	s.PatchValue(openstack.NewKeyPairAPI, func(*openstack.Environ) openstack.KeyPairAPI {
+		return s.keyPairAPI
+	})
+	s.firewallAPI = newFakeFirewallAPI()
+	s.PatchValue(openstack.NewFirewallAPI, func(*openstack.Environ) openstack.FirewallAPI {
+		return s.firewallAPI
+	})
+	s.containerPortAPI = newFakeContainerPortAPI()
+	s.PatchValue(openstack.NewContainerPortAPI, func(*openstack.Environ) openstack.ContainerPortAPI {
+		return s.containerPortAPI
+	})
 }
 
 func (s *localServerSuite) TearDownTest(c *gc.C) {
@@ -677,6 +693,161 @@ func (s *localServerSuite) TestStopInstance(c *gc.C) {
 	})
 }
 
+func (s *localServerSuite) TestInstanceTeardown(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"firewall-mode":   config.FwInstance,
+		"use-floating-ip": true,
+	})
+	instanceName := "100"
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, instanceName)
+	c.Assert(openstack.InstanceFloatingIP(inst), gc.NotNil)
+	teardown := env.(environs.InstanceTeardown)
+
+	err := teardown.DetachInstanceVolumes(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = teardown.ReleaseInstanceAddresses(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	insts, err := env.Instances([]instance.Id{inst.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openstack.InstanceFloatingIP(insts[0]), gc.IsNil)
+
+	modelUUID := env.Config().UUID()
+	instanceGroup := fmt.Sprintf("juju-%v-%v-%v", s.ControllerUUID, modelUUID, instanceName)
+	groups, err := teardown.InstanceSecurityGroups(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, []string{instanceGroup})
+
+	err = env.StopInstances(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = teardown.DeleteSecurityGroups(groups...)
+	c.Assert(err, jc.ErrorIsNil)
+	assertSecurityGroups(c, env, []string{
+		"default", fmt.Sprintf("juju-%v-%v", s.ControllerUUID, modelUUID),
+	})
+
+	// Once the instance has gone, there is nothing left to release.
+	err = teardown.ReleaseInstanceAddresses(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	groups, err = teardown.InstanceSecurityGroups(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestInstanceSuspender(c *gc.C) {
+	cleanup := s.srv.Nova.RegisterControlPoint(
+		"addServer",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			details := args[0].(*nova.ServerDetail)
+			if strings.HasSuffix(details.Name, "-101") {
+				details.Status = nova.StatusShutoff
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+	inst0, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
+	inst1, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "101")
+
+	var actions []string
+	s.PatchValue(openstack.ServerAction, func(e *openstack.Environ, serverId, action string) error {
+		actions = append(actions, action+" "+serverId)
+		return nil
+	})
+	suspender := s.env.(environs.InstanceSuspender)
+
+	// Servers that are already shut off are not stopped again, and
+	// unknown servers are ignored.
+	err := suspender.SuspendInstances(inst0.Id(), inst1.Id(), "unknown")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, jc.DeepEquals, []string{"os-stop " + string(inst0.Id())})
+
+	actions = nil
+	err = suspender.ResumeInstances(inst0.Id(), inst1.Id(), "unknown")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, jc.DeepEquals, []string{"os-start " + string(inst1.Id())})
+}
+
+func (s *localServerSuite) TestInstanceSuspenderError(c *gc.C) {
+	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
+	s.PatchValue(openstack.ServerAction, func(e *openstack.Environ, serverId, action string) error {
+		return errors.New("boom")
+	})
+	err := s.env.(environs.InstanceSuspender).SuspendInstances(inst.Id())
+	c.Assert(err, gc.ErrorMatches, "performing os-stop on server .*: boom")
+}
+
+func (s *localServerSuite) TestMachineSecurityGroups(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "101")
+	group100, err := openstack.MatchingGroup(env, openstack.MachineGroupRegexp(env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchValue(openstack.SecurityGroupsInUse, func(*openstack.Environ) (set.Strings, error) {
+		return set.NewStrings(group100.Id), nil
+	})
+	groups, err := env.(environs.SecurityGroupCollector).MachineSecurityGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.SameContents, []environs.MachineSecurityGroup{{
+		Name:      openstack.MachineGroupName(env, s.ControllerUUID, "100"),
+		MachineId: "100",
+		InUse:     true,
+	}, {
+		Name:      openstack.MachineGroupName(env, s.ControllerUUID, "101"),
+		MachineId: "101",
+	}})
+}
+
+func (s *localServerSuite) TestMachineSecurityGroupsPortsError(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	s.PatchValue(openstack.SecurityGroupsInUse, func(*openstack.Environ) (set.Strings, error) {
+		return nil, errors.New("boom")
+	})
+	_, err := env.(environs.SecurityGroupCollector).MachineSecurityGroups()
+	c.Assert(err, gc.ErrorMatches, "cannot list ports: boom")
+}
+
+type resourceRecorder []environs.BootstrapResource
+
+func (r *resourceRecorder) RecordResource(kind, id string) {
+	*r = append(*r, environs.BootstrapResource{Kind: kind, Id: id})
+}
+
+func (s *localServerSuite) TestRemoveRecordedResources(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	var recorder resourceRecorder
+	result, err := testing.StartInstanceWithParams(env, "100", environs.StartInstanceParams{
+		ControllerUUID:   s.ControllerUUID,
+		ResourceRecorder: &recorder,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The default group is never recorded, as it is not created by Juju.
+	modelUUID := env.Config().UUID()
+	modelGroup := fmt.Sprintf("juju-%v-%v", s.ControllerUUID, modelUUID)
+	instanceGroup := fmt.Sprintf("juju-%v-%v-100", s.ControllerUUID, modelUUID)
+	c.Assert(recorder, jc.DeepEquals, resourceRecorder{
+		{Kind: environs.ResourceKindSecurityGroup, Id: modelGroup},
+		{Kind: environs.ResourceKindSecurityGroup, Id: instanceGroup},
+	})
+
+	err = env.StopInstances(result.Instance.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.(environs.ResourceRemover).RemoveResources(recorder)
+	c.Assert(err, jc.ErrorIsNil)
+	assertSecurityGroups(c, env, []string{"default"})
+}
+
+func (s *localServerSuite) TestRemoveResourcesNotSupported(c *gc.C) {
+	err := s.env.(environs.ResourceRemover).RemoveResources([]environs.BootstrapResource{{
+		Kind: "floating-ip",
+		Id:   "10.0.0.1",
+	}})
+	c.Assert(err, gc.ErrorMatches, `removing floating-ip "10.0.0.1" not supported`)
+}
+
 // Due to bug #1300755 it can happen that the security group intended for
 // an instance is also used as the common security group of another
 // environment. If this is the case, the attempt to delete the instance's
@@ -742,6 +913,64 @@ func (s *localServerSuite) TestDestroyEnvironmentDeletesSecurityGroupsFWModeGlob
 	assertSecurityGroups(c, env, []string{"default"})
 }
 
+func (s *localServerSuite) TestDestroyEnvironmentSecurityGroupsNotDeleted(c *gc.C) {
+	cleanup := s.srv.Neutron.RegisterControlPoint(
+		"removeSecurityGroup",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("failed on purpose")
+		},
+	)
+	defer cleanup()
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	modelUUID := env.Config().UUID()
+	allSecurityGroups := []string{
+		"default", fmt.Sprintf("juju-%v-%v", s.ControllerUUID, modelUUID),
+		fmt.Sprintf("juju-%v-%v-100", s.ControllerUUID, modelUUID),
+	}
+
+	err := env.Destroy()
+	c.Assert(err, jc.Satisfies, openstack.IsSecurityGroupsNotDeletedError)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
+		"2 security groups could not be removed: juju-%[1]v-%[2]v, juju-%[1]v-%[2]v-100",
+		s.ControllerUUID, modelUUID,
+	))
+	assertSecurityGroups(c, env, allSecurityGroups)
+}
+
+func (s *localServerSuite) TestDestroyForceDetachesSecurityGroups(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	machineGroup, err := openstack.MatchingGroup(env, openstack.MachineGroupRegexp(env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var detached []string
+	s.PatchValue(openstack.DetachSecurityGroup, func(_ *openstack.Environ, groupId string) error {
+		detached = append(detached, groupId)
+		return nil
+	})
+	err = env.(environs.ForceDestroyer).DestroyForce()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(detached, gc.HasLen, 2)
+	c.Assert(detached, jc.Contains, machineGroup.Id)
+	assertSecurityGroups(c, env, []string{"default"})
+}
+
+func (s *localServerSuite) TestDestroyForceDetachError(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	modelUUID := env.Config().UUID()
+	s.PatchValue(openstack.DetachSecurityGroup, func(*openstack.Environ, string) error {
+		return errors.New("boom")
+	})
+	err := env.(environs.ForceDestroyer).DestroyForce()
+	c.Assert(err, jc.Satisfies, openstack.IsSecurityGroupsNotDeletedError)
+	assertSecurityGroups(c, env, []string{
+		"default", fmt.Sprintf("juju-%v-%v", s.ControllerUUID, modelUUID),
+		fmt.Sprintf("juju-%v-%v-100", s.ControllerUUID, modelUUID),
+	})
+}
+
 func (s *localServerSuite) TestDestroyController(c *gc.C) {
 	env := s.openEnviron(c, coretesting.Attrs{"uuid": utils.MustNewUUID().String()})
 	controllerEnv := s.env
@@ -870,6 +1099,44 @@ func (s *localServerSuite) TestAllInstancesFloatingIP(c *gc.C) {
 	}
 }
 
+func (s *localServerSuite) TestAssignFloatingIP(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"use-floating-ip": true})
+	inst0, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	inst1, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "101")
+	defer func() {
+		err := env.StopInstances(inst0.Id(), inst1.Id())
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	var called bool
+	cleanup := s.srv.Nova.RegisterControlPoint(
+		"addServerFloatingIP",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			called = true
+			return nil
+		},
+	)
+	defer cleanup()
+
+	address := *openstack.InstanceFloatingIP(inst0)
+	err := env.(environs.FloatingIPAssigner).AssignFloatingIP(address, inst1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *localServerSuite) TestAssignFloatingIPError(c *gc.C) {
+	cleanup := s.srv.Nova.RegisterControlPoint(
+		"addServerFloatingIP",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("floating IP not found")
+		},
+	)
+	defer cleanup()
+
+	err := s.env.(environs.FloatingIPAssigner).AssignFloatingIP("10.0.0.1", "42")
+	c.Assert(err, gc.ErrorMatches, "assigning floating IP 10.0.0.1 to server 42: .*floating IP not found.*")
+}
+
 func (s *localServerSuite) assertInstancesGathering(c *gc.C, withFloatingIP bool) {
 	env := s.openEnviron(c, coretesting.Attrs{"use-floating-ip": withFloatingIP})
 
@@ -1489,6 +1756,397 @@ func (s *localServerSuite) TestEnsureGroup(c *gc.C) {
 	c.Check(obtainedRulesThirdTime, jc.SameContents, obtainedRules)
 }
 
+// TestEnsureGroupRestrictedEgress checks that the egress rules Neutron
+// creates with a new security group are removed when the egress policy
+// is restricted.
+func (s *localServerSuite) TestEnsureGroupRestrictedEgress(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"egress-policy": "restricted"})
+	rules := []neutron.RuleInfoV2{
+		{
+			Direction:    "ingress",
+			IPProtocol:   "tcp",
+			PortRangeMin: 22,
+			PortRangeMax: 22,
+			EthernetType: "IPv4",
+		},
+		{
+			Direction:      "egress",
+			IPProtocol:     "tcp",
+			PortRangeMin:   443,
+			PortRangeMax:   443,
+			RemoteIPPrefix: "0.0.0.0/0",
+			EthernetType:   "IPv4",
+		},
+	}
+	group, err := openstack.EnsureGroup(env, "test group", rules)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ruleToRuleInfo(group.Rules), jc.SameContents, rules)
+}
+
+func (s *localServerSuite) TestEgressRulesFWModeGlobal(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"firewall-mode": config.FwGlobal,
+		"egress-policy": "restricted",
+		"egress-rules":  "5432/tcp@10.0.0.0/8",
+	})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	rules, err := fw.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+	})
+
+	err = fw.OpenEgressPorts([]network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fw.CloseEgressPorts([]network.EgressRule{network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8")})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+
+	// Ingress rules are unaffected by egress rules on the same ports.
+	ingress, err := fw.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingress, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestInstanceEgressRulesFWModeInstance(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"egress-policy": "restricted",
+		"egress-rules":  "8080-8089/tcp",
+	})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	rules, err := fw.InstanceEgressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 8080, 8089, "0.0.0.0/0"),
+	})
+
+	_, err = fw.EgressRules()
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for retrieving egress rules from model`)
+}
+
+func (s *localServerSuite) TestInstancePortsSourceCIDRs(c *gc.C) {
+	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(s.env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24", "10.0.0.0/24"),
+		network.MustNewIngressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+
+	// Closing a rule removes access from all of its source CIDRs.
+	err = fw.CloseInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+}
+
+func (s *localServerSuite) TestOpenInstancePortsOnlyCreatesMissingRules(c *gc.C) {
+	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(s.env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Opening the same ports again along with new ones leaves a single
+	// security group rule for each.
+	err = fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 8000, 8010),
+		network.MustNewIngressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	group, err := openstack.MatchingGroup(s.env, openstack.MachineGroupRegexp(s.env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+	var ingress int
+	for _, rule := range group.Rules {
+		if rule.Direction == "ingress" {
+			ingress++
+		}
+	}
+	c.Assert(ingress, gc.Equals, 3)
+
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8000, 8010, "0.0.0.0/0"),
+		network.MustNewIngressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+}
+
+func (s *localServerSuite) TestFirewallImplementationNone(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-implementation": "none"})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	groups, err := openstack.GetNeutronClient(env).ListSecurityGroupsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, group := range groups {
+		c.Check(group.Name, gc.Not(gc.Matches), "juju-.*")
+	}
+}
+
+func (s *localServerSuite) TestOpenInstancePortsDualStack(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"ip-address-family": "dual-stack"})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 443, 443, "2001:db8::/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	group, err := openstack.MatchingGroup(env, openstack.MachineGroupRegexp(env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+	var ipv6 []string
+	for _, rule := range group.Rules {
+		if rule.Direction == "ingress" && rule.EthernetType == "IPv6" {
+			ipv6 = append(ipv6, rule.RemoteIPPrefix)
+		}
+	}
+	c.Assert(ipv6, jc.SameContents, []string{"::/0", "2001:db8::/32"})
+
+	// Anywhere is reported as 0.0.0.0/0, as the firewaller expects.
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 443, 443, "2001:db8::/32"),
+	})
+
+	err = fw.CloseInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "2001:db8::/32"),
+	})
+}
+
+func (s *localServerSuite) TestOpenInstancePortsICMP(c *gc.C) {
+	env := s.openEnviron(c, nil)
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(env)
+
+	err := fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1, "10.0.0.0/24"),
+		network.MustNewIngressRule("icmp", 3, 4),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1, "10.0.0.0/24"),
+		network.MustNewIngressRule("icmp", 3, 4, "0.0.0.0/0"),
+	})
+
+	// Neutron cannot distinguish ICMP type 0 from any type.
+	err = fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("icmp", 0, -1),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	err = fw.CloseInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("icmp", 3, 4),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1, "10.0.0.0/24"),
+	})
+}
+
+func (s *localServerSuite) TestApplicationSecurityGroups(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"application-security-groups": true})
+	fw := openstack.GetFirewaller(env)
+
+	groupNames, err := fw.SetUpGroups(s.ControllerUUID, "100", []string{"mysql"}, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	modelUUID := env.Config().UUID()
+	applicationGroup := fmt.Sprintf("juju-%v-%v-app-mysql", s.ControllerUUID, modelUUID)
+	c.Assert(groupNames, jc.DeepEquals, []string{
+		fmt.Sprintf("juju-%v-%v", s.ControllerUUID, modelUUID),
+		fmt.Sprintf("juju-%v-%v-100", s.ControllerUUID, modelUUID),
+		applicationGroup,
+	})
+
+	err = fw.OpenApplicationPorts("mysql", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.ApplicationIngressRules("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306, "0.0.0.0/0"),
+	})
+
+	// Applications deployed to no machines have no group.
+	_, err = fw.ApplicationIngressRules("wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = fw.CloseApplicationPorts("mysql", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.ApplicationIngressRules("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = fw.DeleteApplicationGroup("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	groups, err := openstack.GetNeutronClient(env).ListSecurityGroupsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, group := range groups {
+		c.Check(group.Name, gc.Not(gc.Equals), applicationGroup)
+	}
+}
+
+// allowedIngressPrefixes returns the remote IP prefixes of the SSH
+// rules of the model's juju group.
+func allowedIngressPrefixes(c *gc.C, env environs.Environ, groupName string) []string {
+	groups, err := openstack.GetNeutronClient(env).ListSecurityGroupsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	var prefixes []string
+	for _, group := range groups {
+		if group.Name != groupName {
+			continue
+		}
+		for _, rule := range ruleToRuleInfo(group.Rules) {
+			if rule.Direction == "ingress" && rule.PortRangeMin == 22 && rule.RemoteIPPrefix != "" {
+				prefixes = append(prefixes, rule.RemoteIPPrefix)
+			}
+		}
+	}
+	return prefixes
+}
+
+func (s *localServerSuite) TestAllowedIngressCIDRs(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"allowed-ingress-cidrs": "10.0.0.0/8 192.168.1.0/24"})
+	fw := openstack.GetFirewaller(env)
+	_, err := fw.SetUpGroups(s.ControllerUUID, "100", nil, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	jujuGroup := fmt.Sprintf("juju-%v-%v", s.ControllerUUID, env.Config().UUID())
+	c.Assert(allowedIngressPrefixes(c, env, jujuGroup), jc.SameContents, []string{
+		"10.0.0.0/8", "192.168.1.0/24",
+	})
+}
+
+func (s *localServerSuite) TestAllowedIngressCIDRsChanged(c *gc.C) {
+	env := s.openEnviron(c, nil)
+	fw := openstack.GetFirewaller(env)
+	_, err := fw.SetUpGroups(s.ControllerUUID, "100", nil, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	jujuGroup := fmt.Sprintf("juju-%v-%v", s.ControllerUUID, env.Config().UUID())
+	c.Assert(allowedIngressPrefixes(c, env, jujuGroup), jc.SameContents, []string{
+		"0.0.0.0/0", "::/0",
+	})
+
+	cfg, err := env.Config().Apply(coretesting.Attrs{
+		"allowed-ingress-cidrs": "10.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowedIngressPrefixes(c, env, jujuGroup), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	// The API port is still allowed from the new CIDR.
+	groups, err := openstack.GetNeutronClient(env).ListSecurityGroupsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	var apiPrefixes []string
+	for _, group := range groups {
+		if group.Name != jujuGroup {
+			continue
+		}
+		for _, rule := range ruleToRuleInfo(group.Rules) {
+			if rule.PortRangeMin == 17070 {
+				apiPrefixes = append(apiPrefixes, rule.RemoteIPPrefix)
+			}
+		}
+	}
+	c.Assert(apiPrefixes, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	cfg, err = env.Config().Apply(coretesting.Attrs{
+		"allowed-ingress-cidrs": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowedIngressPrefixes(c, env, jujuGroup), jc.SameContents, []string{
+		"0.0.0.0/0", "::/0",
+	})
+}
+
+func (s *localServerSuite) TestApplicationSecurityGroupsDisabled(c *gc.C) {
+	fw := openstack.GetFirewaller(s.env)
+	groupNames, err := fw.SetUpGroups(s.ControllerUUID, "100", []string{"mysql"}, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groupNames, gc.HasLen, 2)
+
+	err = fw.OpenApplicationPorts("mysql", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *localServerSuite) TestRenamedMachineGroupFoundByTags(c *gc.C) {
+	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
+	fw := openstack.GetFirewaller(s.env)
+
+	group, err := openstack.MatchingGroup(s.env, openstack.MachineGroupRegexp(s.env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Description, gc.Matches, `juju group \[.*juju-machine-id=100.*\]`)
+	_, err = openstack.GetNeutronClient(s.env).UpdateSecurityGroupV2(group.Id, "renamed", group.Description)
+	c.Assert(err, jc.ErrorIsNil)
+	openstack.InvalidateSecurityGroups(s.env)
+
+	err = fw.OpenInstancePorts(inst, "100", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.InstanceIngressRules(inst, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
 // TestMatchingGroup checks that you receive the group you expected.  matchingGroup()
 // is used by the firewaller when opening and closing ports.  Unit test in response to bug 1675799.
 func (s *localServerSuite) TestMatchingGroup(c *gc.C) {
@@ -1829,6 +2487,76 @@ func (s *localServerSuite) TestResolveNetworkNotPresent(c *gc.C) {
 
 // TODO(gz): TestResolveNetworkMultipleMatching when can inject new networks
 
+func (s *localServerSuite) TestDefaultNetworksNone(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"network": ""})
+	networks, err := openstack.DefaultNetworks(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networks, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestDefaultNetworksNetwork(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"network": "net"})
+	networks, err := openstack.DefaultNetworks(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networks, jc.DeepEquals, []nova.ServerNetworks{{NetworkId: "1"}})
+}
+
+func (s *localServerSuite) TestDefaultNetworksNetworks(c *gc.C) {
+	var sampleUUID = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	env := s.openEnviron(c, coretesting.Attrs{
+		"network":  "",
+		"networks": "net-disabled net " + sampleUUID,
+	})
+	disabledId, err := openstack.ResolveNetwork(env, "net-disabled", false)
+	c.Assert(err, jc.ErrorIsNil)
+	networks, err := openstack.DefaultNetworks(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networks, jc.DeepEquals, []nova.ServerNetworks{
+		{NetworkId: disabledId},
+		{NetworkId: "1"},
+		{NetworkId: sampleUUID},
+	})
+}
+
+func (s *localServerSuite) TestDefaultNetworksUnknownLabel(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"network":  "",
+		"networks": "net no-network-with-this-label",
+	})
+	_, err := openstack.DefaultNetworks(env)
+	c.Assert(err, gc.ErrorMatches, `no networks exist with label "no-network-with-this-label"`)
+}
+
+func (s *localServerSuite) TestStartInstanceNetworksPortSecurityDisabled(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"network":  "",
+		"networks": "net net-disabled",
+	})
+	inst, _, _, err := testing.StartInstance(env, s.ControllerUUID, "100")
+	c.Assert(err, jc.ErrorIsNil)
+	novaClient := openstack.GetNovaClient(env)
+	detail, err := novaClient.GetServer(string(inst.Id()))
+	c.Assert(err, jc.ErrorIsNil)
+	// Security groups are not used when any of the networks has
+	// port security disabled.
+	c.Assert(detail.Groups, gc.IsNil)
+}
+
+func (s *localServerSuite) TestPrepareForBootstrapValidatesNetworks(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"network":  "",
+		"networks": "net f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+	})
+	err := env.PrepareForBootstrap(envtesting.BootstrapContext(c))
+	c.Assert(err, gc.ErrorMatches, `getting network "f81d4fae-7dec-11d0-a765-00a0c91e6bf6": (.|\n)*not found(.|\n)*`)
+}
+
+func (s *localServerSuite) TestPrepareForBootstrapUnknownNetworkLabel(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"network": "no-network-with-this-label"})
+	err := env.PrepareForBootstrap(envtesting.BootstrapContext(c))
+	c.Assert(err, gc.ErrorMatches, `resolving network "no-network-with-this-label": no networks exist with label .*`)
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZone(c *gc.C) {
 	inst, err := t.testStartInstanceAvailZone(c, "test-available")
 	c.Assert(err, jc.ErrorIsNil)
@@ -2020,6 +2748,78 @@ func (t *localServerSuite) TestStartInstanceWithUnknownAZError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "(?s).*Some unknown error.*")
 }
 
+func (t *localServerSuite) setTwoAvailabilityZones() {
+	t.srv.Nova.SetAvailabilityZones(
+		nova.AvailabilityZone{
+			Name: "az1",
+			State: nova.AvailabilityZoneState{
+				Available: true,
+			},
+		},
+		nova.AvailabilityZone{
+			Name: "az2",
+			State: nova.AvailabilityZoneState{
+				Available: true,
+			},
+		},
+	)
+}
+
+func (t *localServerSuite) TestStartInstanceNoValidHostClassified(c *gc.C) {
+	t.setTwoAvailabilityZones()
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cleanup := t.srv.Nova.RegisterControlPoint(
+		"addServer",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("No valid host was found")
+		},
+	)
+	defer cleanup()
+	_, _, _, err = testing.StartInstance(t.env, t.ControllerUUID, "1")
+	c.Assert(err, gc.ErrorMatches, "(?s).*No valid host was found.*")
+	info := environs.ClassifyProvisioningError(err)
+	c.Assert(info.Class, gc.Equals, environs.ProvisioningErrorAZCapacity)
+	c.Assert(info.AvailabilityZone, gc.Matches, "az[12]")
+	c.Assert(info.InstanceType, gc.Not(gc.Equals), "")
+}
+
+func (t *localServerSuite) TestStartInstanceExcludedAvailabilityZones(c *gc.C) {
+	t.setTwoAvailabilityZones()
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID:            t.ControllerUUID,
+		Placement:                 "zone=az1",
+		ExcludedAvailabilityZones: []string{"az1"},
+	}
+	result, err := testing.StartInstanceWithParams(t.env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openstack.InstanceServerDetail(result.Instance).AvailabilityZone, gc.Equals, "az2")
+
+	params.ExcludedAvailabilityZones = []string{"az1", "az2"}
+	_, err = testing.StartInstanceWithParams(t.env, "2", params)
+	c.Assert(err, gc.ErrorMatches, "no availability zones left to try, excluding az1, az2")
+}
+
+func (t *localServerSuite) TestStartInstanceExcludedInstanceTypes(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	inst, _ := testing.AssertStartInstance(c, t.env, t.ControllerUUID, "1")
+	flavor := openstack.InstanceServerDetail(inst).Flavor.Name
+
+	params := environs.StartInstanceParams{
+		ControllerUUID:        t.ControllerUUID,
+		ExcludedInstanceTypes: []string{flavor},
+	}
+	result, err := testing.StartInstanceWithParams(t.env, "2", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openstack.InstanceServerDetail(result.Instance).Flavor.Name, gc.Not(gc.Equals), flavor)
+}
+
 func (t *localServerSuite) TestStartInstanceVolumeAttachmentsAvailZone(c *gc.C) {
 	err := bootstrapEnv(c, t.env)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2246,7 +3046,7 @@ func (s *localServerSuite) TestAdoptResources(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	hostedModelUUID := "7e386e08-cba7-44a4-a76e-7c1633584210"
-	cfg, err := s.env.Config().Apply(map[string]interface{}{
+	cfg, err := s.env.Config().Apply(coretesting.Attrs{
 		"uuid": hostedModelUUID,
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -2292,7 +3092,7 @@ func (s *localServerSuite) TestAdoptResourcesNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	hostedModelUUID := "7e386e08-cba7-44a4-a76e-7c1633584210"
-	cfg, err := s.env.Config().Apply(map[string]interface{}{
+	cfg, err := s.env.Config().Apply(coretesting.Attrs{
 		"uuid": hostedModelUUID,
 	})
 	c.Assert(err, jc.ErrorIsNil)
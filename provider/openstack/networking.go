@@ -26,7 +26,8 @@ type Networking interface {
 	AllocatePublicIP(instance.Id) (*string, error)
 
 	// DefaultNetworks returns the set of networks that should be
-	// added by default to all new instances.
+	// added by default to all new instances, resolved from the
+	// model's network settings.
 	DefaultNetworks() ([]nova.ServerNetworks, error)
 
 	// ResolveNetwork takes either a network ID or label
@@ -131,6 +132,22 @@ type networkingBase struct {
 	env *Environ
 }
 
+// resolveDefaultNetworks resolves the given network labels or UUIDs
+// into the networks of a new instance, with one network interface for
+// each in the given order.
+func resolveDefaultNetworks(n Networking, names []string) ([]nova.ServerNetworks, error) {
+	networks := make([]nova.ServerNetworks, 0, len(names))
+	for _, name := range names {
+		networkId, err := n.ResolveNetwork(name, false)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("using network id %q", networkId)
+		networks = append(networks, nova.ServerNetworks{NetworkId: networkId})
+	}
+	return networks, nil
+}
+
 func processResolveNetworkIds(name string, networkIds []string) (string, error) {
 	switch len(networkIds) {
 	case 1:
@@ -263,7 +280,7 @@ func getExternalNeutronNetworksByAZ(e *Environ, azName string) ([]string, error)
 
 // DefaultNetworks is part of the Networking interface.
 func (n *NeutronNetworking) DefaultNetworks() ([]nova.ServerNetworks, error) {
-	return []nova.ServerNetworks{}, nil
+	return resolveDefaultNetworks(n, n.env.ecfg().networks())
 }
 
 // ResolveNetwork is part of the Networking interface.
@@ -7,7 +7,10 @@ package openstack
 
 import (
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -18,10 +21,12 @@ import (
 	"github.com/juju/retry"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/goose.v2/cinder"
 	"gopkg.in/goose.v2/client"
 	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
 	"gopkg.in/goose.v2/identity"
 	gooselogging "gopkg.in/goose.v2/logging"
 	"gopkg.in/goose.v2/neutron"
@@ -293,12 +298,17 @@ type Environ struct {
 
 	// Clock is defined so it can be replaced for testing
 	clock clock.Clock
+
+	// secGroupCache caches the security groups, for the firewaller.
+	secGroupCacheMutex sync.Mutex
+	secGroupCache      *securityGroupCache
 }
 
 var _ environs.Environ = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.FloatingIPAssigner = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -469,6 +479,14 @@ func (e *Environ) ecfg() *environConfig {
 	return ecfg
 }
 
+// retryStrategy returns the strategy for retrying failed OpenStack
+// API calls.
+func (e *Environ) retryStrategy() retryStrategy {
+	strategy := e.ecfg().retryStrategy()
+	strategy.Clock = e.clock
+	return strategy
+}
+
 func (e *Environ) client() client.AuthenticatingClient {
 	e.ecfgMutex.Lock()
 	client := e.clientUnlocked
@@ -490,6 +508,22 @@ func (e *Environ) neutron() *neutron.Client {
 	return neutron
 }
 
+// securityGroups returns the Environ's cache of security groups.
+func (e *Environ) securityGroups() *securityGroupCache {
+	e.secGroupCacheMutex.Lock()
+	defer e.secGroupCacheMutex.Unlock()
+	if e.secGroupCache == nil {
+		clk := e.clock
+		if clk == nil {
+			clk = clock.WallClock
+		}
+		e.secGroupCache = newSecurityGroupCache(func() ([]neutron.SecurityGroupV2, error) {
+			return e.neutron().ListSecurityGroupsV2()
+		}, clk)
+	}
+	return e.secGroupCache
+}
+
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
@@ -640,6 +674,25 @@ func (e *Environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
 `,
 		)
 	}
+	return errors.Trace(e.validateNetworks())
+}
+
+// validateNetworks checks that the networks named by the model's
+// network settings exist in the tenant. Network UUIDs are only checked
+// with Neutron; nova-network accepts them as they are.
+func (e *Environ) validateNetworks() error {
+	for _, name := range e.ecfg().networks() {
+		networkId, err := e.networking.ResolveNetwork(name, false)
+		if err != nil {
+			return errors.Annotatef(err, "resolving network %q", name)
+		}
+		if !e.supportsNeutron() {
+			continue
+		}
+		if _, err := e.neutron().GetNetworkV2(networkId); err != nil {
+			return errors.Annotatef(err, "getting network %q", name)
+		}
+	}
 	return nil
 }
 
@@ -814,9 +867,18 @@ func (e *Environ) SetConfig(cfg *config.Config) error {
 	// At this point, the authentication method config value has been validated so we extract it's value here
 	// to avoid having to validate again each time when creating the OpenStack client.
 	e.ecfgMutex.Lock()
-	defer e.ecfgMutex.Unlock()
+	old := e.ecfgUnlocked
 	e.ecfgUnlocked = ecfg
+	e.ecfgMutex.Unlock()
 
+	// The firewaller reads the new config, so it is only told of
+	// changes once the config has been updated.
+	if old != nil && e.firewaller != nil &&
+		strings.Join(old.allowedIngressCIDRs(), " ") != strings.Join(ecfg.allowedIngressCIDRs(), " ") {
+		if err := e.firewaller.UpdateAllowedIngress(); err != nil {
+			logger.Errorf("cannot update allowed ingress CIDRs: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -932,7 +994,27 @@ func (*Environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance is specified in the InstanceBroker interface.
+// Errors are classified, so that the provisioner can advise how best to
+// retry.
 func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	var attempt environs.ProvisioningErrorInfo
+	result, err := e.startInstance(args, &attempt)
+	if err != nil {
+		if attempt.Class == "" {
+			attempt.Class = classifyStartInstanceError(err)
+		}
+		return nil, environs.NewProvisioningError(err, attempt)
+	}
+	return result, nil
+}
+
+// startInstance starts an instance, recording in attempt the instance
+// type and availability zone last tried, and the class of any error
+// it can be sure of.
+func (e *Environ) startInstance(
+	args environs.StartInstanceParams,
+	attempt *environs.ProvisioningErrorInfo,
+) (*environs.StartInstanceResult, error) {
 	if args.ControllerUUID == "" {
 		return nil, errors.New("missing controller UUID")
 	}
@@ -949,10 +1031,11 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		Series:      series,
 		Arches:      arches,
 		Constraints: args.Constraints,
-	}, args.ImageMetadata)
+	}, args.ImageMetadata, args.ExcludedInstanceTypes)
 	if err != nil {
 		return nil, err
 	}
+	attempt.InstanceType = spec.InstanceType.Name
 	tools, err := args.Tools.Match(tools.Filter{Arch: spec.Image.Arch})
 	if err != nil {
 		return nil, errors.Errorf("chosen architecture %v not present in %v", spec.Image.Arch, arches)
@@ -977,16 +1060,8 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 
 	networks, err := e.networking.DefaultNetworks()
 	if err != nil {
-		return nil, errors.Annotate(err, "getting initial networks")
-	}
-	usingNetwork := e.ecfg().network()
-	if usingNetwork != "" {
-		networkId, err := e.networking.ResolveNetwork(usingNetwork, false)
-		if err != nil {
-			return nil, err
-		}
-		logger.Debugf("using network id %q", networkId)
-		networks = append(networks, nova.ServerNetworks{NetworkId: networkId})
+		attempt.Class = environs.ProvisioningErrorNetwork
+		return nil, errors.Trace(err)
 	}
 
 	// For BUG 1680787: openstack: add support for neutron networks where port
@@ -999,6 +1074,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		for _, n := range networks {
 			net, err := client.GetNetworkV2(n.NetworkId)
 			if err != nil {
+				attempt.Class = environs.ProvisioningErrorNetwork
 				return nil, err
 			}
 			if net.PortSecurityEnabled != nil &&
@@ -1010,6 +1086,12 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		}
 	}
 
+	// The FWaaS firewaller doesn't use security groups, so its firewall
+	// groups are set up whether or not port security is enabled.
+	if e.ecfg().firewallImplementation() == FirewallerFWaaS {
+		createSecurityGroups = true
+	}
+
 	var novaGroupNames = []nova.SecurityGroupName{}
 	if createSecurityGroups {
 		var apiPort int
@@ -1019,13 +1101,21 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 			// All ports are the same so pick the first.
 			apiPort = args.InstanceConfig.APIInfo.Ports()[0]
 		}
-		groupNames, err := e.firewaller.SetUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+		groupNames, err := e.firewaller.SetUpGroups(
+			args.ControllerUUID,
+			args.InstanceConfig.MachineId,
+			unitApplications(args.InstanceConfig.Tags[tags.JujuUnitsDeployed]),
+			apiPort,
+		)
 		if err != nil {
 			return nil, errors.Annotate(err, "cannot set up groups")
 		}
 		novaGroupNames = make([]nova.SecurityGroupName, len(groupNames))
 		for i, name := range groupNames {
 			novaGroupNames[i].Name = name
+			if args.ResourceRecorder != nil && name != "default" {
+				args.ResourceRecorder.RecordResource(environs.ResourceKindSecurityGroup, name)
+			}
 		}
 	}
 
@@ -1075,7 +1165,10 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		instanceOpts nova.RunServerOpts,
 	) (server *nova.Entity, err error) {
 		for a := attempts.Start(); a.Next(); {
-			server, err = client.RunServer(instanceOpts)
+			err = e.retryStrategy().call("starting instance", func() (err error) {
+				server, err = client.RunServer(instanceOpts)
+				return err
+			})
 			if err != nil {
 				break
 			}
@@ -1110,6 +1203,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	) (server *nova.Entity, err error) {
 		for _, zone := range availabilityZones {
 			logger.Infof("trying to build instance in availability zone %q", zone)
+			attempt.AvailabilityZone = zone
 			instanceOpts.AvailabilityZone = zone
 			e.configurator.ModifyRunServerOptions(&instanceOpts)
 			server, err = tryStartNovaInstance(attempts, client, instanceOpts)
@@ -1127,6 +1221,24 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		return server, err
 	}
 
+	// Machines are started with the controller's keypair, so that the
+	// emergency access key can be rotated centrally. The authorized keys
+	// are also injected by cloud-init, so failing to manage the keypair
+	// does not prevent the machine from being started.
+	var keyPairName string
+	if args.InstanceConfig.Bootstrap != nil {
+		keyPairName, err = e.createControllerKeyPair(args.ControllerUUID)
+		if err == nil && args.ResourceRecorder != nil {
+			args.ResourceRecorder.RecordResource(environs.ResourceKindKeyPair, keyPairName)
+		}
+	} else {
+		keyPairName, err = e.controllerKeyPair(args.ControllerUUID)
+	}
+	if err != nil {
+		logger.Warningf("starting instance without controller keypair: %v", err)
+		keyPairName = ""
+	}
+
 	var opts = nova.RunServerOpts{
 		Name:               machineName,
 		FlavorId:           spec.InstanceType.Id,
@@ -1135,6 +1247,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		SecurityGroupNames: novaGroupNames,
 		Networks:           networks,
 		Metadata:           args.InstanceConfig.Tags,
+		KeyPairName:        keyPairName,
 	}
 	server, err := tryStartNovaInstanceAcrossAvailZones(shortAttempt, e.nova(), opts, availabilityZones)
 	if err != nil {
@@ -1187,8 +1300,9 @@ func (e *Environ) startInstanceAvailabilityZones(args environs.StartInstancePara
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	excluded := set.NewStrings(args.ExcludedAvailabilityZones...)
 	var availabilityZones []string
-	if placementZone != "" {
+	if placementZone != "" && !excluded.Contains(placementZone) {
 		availabilityZones = []string{placementZone}
 	}
 
@@ -1212,8 +1326,15 @@ func (e *Environ) startInstanceAvailabilityZones(args environs.StartInstancePara
 			return nil, err
 		} else {
 			for _, zone := range zoneInstances {
+				if excluded.Contains(zone.ZoneName) {
+					continue
+				}
 				availabilityZones = append(availabilityZones, zone.ZoneName)
 			}
+			if len(availabilityZones) == 0 && len(zoneInstances) > 0 {
+				return nil, errors.Errorf("no availability zones left to try, excluding %s",
+					strings.Join(excluded.SortedValues(), ", "))
+			}
 		}
 		if len(availabilityZones) == 0 {
 			// No explicitly selectable zones available, so use an unspecified zone.
@@ -1282,6 +1403,34 @@ func (e *Environ) volumeAttachmentsZone(volumeAttachments []storage.VolumeAttach
 	return zone, nil
 }
 
+// classifyStartInstanceError returns the class of an error returned
+// when starting an instance, judged by the error's cause and message.
+func classifyStartInstanceError(err error) environs.ProvisioningErrorClass {
+	cause := errors.Cause(err)
+	message := strings.ToLower(err.Error())
+	switch {
+	case isNoValidHostsError(err):
+		return environs.ProvisioningErrorAZCapacity
+	case strings.Contains(message, "quota exceeded"),
+		strings.Contains(message, "overlimit"),
+		strings.Contains(message, "exceeds quota"):
+		return environs.ProvisioningErrorQuota
+	case gooseerrors.IsUnauthorised(cause),
+		strings.Contains(message, "authentication failed"):
+		return environs.ProvisioningErrorCredential
+	case imageNotFoundRegexp.MatchString(message),
+		gooseerrors.IsNotFound(cause) && strings.Contains(message, "image"):
+		return environs.ProvisioningErrorImageNotFound
+	case gooseerrors.IsNotFound(cause) && strings.Contains(message, "network"):
+		return environs.ProvisioningErrorNetwork
+	}
+	return environs.ProvisioningErrorUnknown
+}
+
+// imageNotFoundRegexp matches the errors returned when no image is
+// suitable for an instance.
+var imageNotFoundRegexp = regexp.MustCompile(`no "[^"]*" images in `)
+
 func isNoValidHostsError(err error) bool {
 	if cause := errors.Cause(err); cause != nil {
 		return strings.Contains(cause.Error(), "No valid host was found")
@@ -1303,11 +1452,206 @@ func (e *Environ) StopInstances(ids ...instance.Id) error {
 		return err
 	}
 	if securityGroupNames != nil {
-		return e.firewaller.DeleteGroups(securityGroupNames...)
+		return deleteInstanceGroups(e.firewaller, securityGroupNames)
+	}
+	return nil
+}
+
+// deleteInstanceGroups deletes the security groups of stopped
+// instances. Neutron may not have finished with the instances' ports
+// yet (lp:1300755), so a group that cannot be deleted is not an error;
+// it is left for the security group garbage collector, or for Destroy.
+func deleteInstanceGroups(fw Firewaller, names []string) error {
+	err := fw.DeleteGroups(names...)
+	if IsSecurityGroupsNotDeletedError(err) {
+		logger.Warningf("%v", err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+var _ environs.InstanceTeardown = (*Environ)(nil)
+
+// DetachInstanceVolumes implements environs.InstanceTeardown.
+func (e *Environ) DetachInstanceVolumes(ids ...instance.Id) error {
+	storageAdapter, err := newOpenstackStorage(e)
+	if errors.IsNotSupported(err) {
+		// Without Cinder there are no volumes to detach.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, id := range ids {
+		attachments, err := storageAdapter.ListVolumeAttachments(string(id))
+		if gooseerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "listing volume attachments of server %s", id)
+		}
+		for _, attachment := range attachments {
+			logger.Debugf("detaching volume %s from server %s", attachment.VolumeId, id)
+			err := storageAdapter.DetachVolume(string(id), attachment.VolumeId)
+			if err != nil && !gooseerrors.IsNotFound(err) {
+				return errors.Annotatef(err, "detaching volume %s from server %s", attachment.VolumeId, id)
+			}
+		}
+	}
+	return nil
+}
+
+var _ environs.ResourceRemover = (*Environ)(nil)
+
+// RemoveResources implements environs.ResourceRemover. Security groups
+// and keypairs are identified by name, and volumes by their Cinder
+// volume ID.
+func (e *Environ) RemoveResources(resources []environs.BootstrapResource) error {
+	var groupNames, keyPairNames, volumeIds []string
+	for _, resource := range resources {
+		switch resource.Kind {
+		case environs.ResourceKindSecurityGroup:
+			groupNames = append(groupNames, resource.Id)
+		case environs.ResourceKindKeyPair:
+			keyPairNames = append(keyPairNames, resource.Id)
+		case environs.ResourceKindVolume:
+			volumeIds = append(volumeIds, resource.Id)
+		default:
+			return errors.NotSupportedf("removing %s %q", resource.Kind, resource.Id)
+		}
+	}
+	if len(volumeIds) > 0 {
+		storageAdapter, err := newOpenstackStorage(e)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, volumeId := range volumeIds {
+			logger.Debugf("deleting volume %s", volumeId)
+			err := storageAdapter.DeleteVolume(volumeId)
+			if err != nil && !gooseerrors.IsNotFound(err) {
+				return errors.Annotatef(err, "deleting volume %s", volumeId)
+			}
+		}
+	}
+	if len(groupNames) > 0 {
+		if err := e.firewaller.DeleteGroups(groupNames...); err != nil {
+			return errors.Annotate(err, "deleting security groups")
+		}
+	}
+	for _, name := range keyPairNames {
+		logger.Debugf("deleting keypair %s", name)
+		if err := newKeyPairAPI(e).DeleteKeyPair(name); err != nil {
+			return errors.Annotatef(err, "deleting keypair %s", name)
+		}
 	}
 	return nil
 }
 
+// ReleaseInstanceAddresses implements environs.InstanceTeardown. The
+// floating IPs are disassociated from the servers, and left allocated
+// to the project so that they may be reused by new instances.
+func (e *Environ) ReleaseInstanceAddresses(ids ...instance.Id) error {
+	if !e.ecfg().useFloatingIP() {
+		return nil
+	}
+	insts, err := e.Instances(ids)
+	if err == environs.ErrNoInstances {
+		return nil
+	} else if err != nil && err != environs.ErrPartialInstances {
+		return errors.Trace(err)
+	}
+	for _, inst := range insts {
+		if inst == nil {
+			continue
+		}
+		fip := inst.(*openstackInstance).floatingIP
+		if fip == nil {
+			continue
+		}
+		logger.Debugf("releasing floating IP %s from server %s", *fip, inst.Id())
+		err := e.nova().RemoveServerFloatingIP(string(inst.Id()), *fip)
+		if err != nil && !gooseerrors.IsNotFound(err) {
+			return errors.Annotatef(err, "releasing floating IP %s from server %s", *fip, inst.Id())
+		}
+	}
+	return nil
+}
+
+// InstanceSecurityGroups implements environs.InstanceTeardown.
+func (e *Environ) InstanceSecurityGroups(ids ...instance.Id) ([]string, error) {
+	names, err := e.firewaller.GetSecurityGroups(ids...)
+	if errors.Cause(err) == environs.ErrNoInstances {
+		return nil, nil
+	}
+	return names, errors.Trace(err)
+}
+
+// DeleteSecurityGroups implements environs.InstanceTeardown.
+func (e *Environ) DeleteSecurityGroups(names ...string) error {
+	return deleteInstanceGroups(e.firewaller, names)
+}
+
+var _ environs.SecurityGroupCollector = (*Environ)(nil)
+
+// MachineSecurityGroups implements environs.SecurityGroupCollector.
+func (e *Environ) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	groups, err := e.firewaller.MachineSecurityGroups()
+	return groups, errors.Trace(err)
+}
+
+var _ environs.InstanceSuspender = (*Environ)(nil)
+
+// SuspendInstances implements environs.InstanceSuspender. Servers are
+// stopped with the nova "os-stop" action, which keeps their disks and
+// addresses.
+func (e *Environ) SuspendInstances(ids ...instance.Id) error {
+	return e.changeServerStates(ids, "os-stop", nova.StatusShutoff)
+}
+
+// ResumeInstances implements environs.InstanceSuspender. Servers are
+// started with the nova "os-start" action.
+func (e *Environ) ResumeInstances(ids ...instance.Id) error {
+	return e.changeServerStates(ids, "os-start", nova.StatusActive)
+}
+
+// changeServerStates performs the given nova server action on each of
+// the servers with the specified IDs that is not already in the target
+// status.
+func (e *Environ) changeServerStates(ids []instance.Id, action, target string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	insts, err := e.Instances(ids)
+	if err == environs.ErrNoInstances {
+		return nil
+	} else if err != nil && err != environs.ErrPartialInstances {
+		return errors.Trace(err)
+	}
+	for _, inst := range insts {
+		if inst == nil {
+			continue
+		}
+		if inst.(*openstackInstance).getServerDetail().Status == target {
+			continue
+		}
+		logger.Debugf("performing %s on server %s", action, inst.Id())
+		err := serverAction(e, string(inst.Id()), action)
+		if err != nil && !gooseerrors.IsNotFound(err) {
+			return errors.Annotatef(err, "performing %s on server %s", action, inst.Id())
+		}
+	}
+	return nil
+}
+
+// serverAction performs a nova server action that takes no arguments.
+// The goose nova client does not support the stop and start actions,
+// so the request is made directly.
+var serverAction = func(e *Environ, serverId, action string) error {
+	requestData := goosehttp.RequestData{
+		ReqValue:       map[string]interface{}{action: nil},
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	return e.client().SendRequest(client.POST, "compute", "v2", "servers/"+serverId+"/action", &requestData)
+}
+
 func (e *Environ) isAliveServer(server nova.ServerDetail) bool {
 	switch server.Status {
 	case nova.StatusActive, nova.StatusBuild, nova.StatusBuildSpawning, nova.StatusShutoff, nova.StatusSuspended:
@@ -1543,13 +1887,29 @@ func (e *Environ) allInstances(tagFilter tagValue, updateFloatingIPAddresses boo
 	return insts, nil
 }
 
+// Destroy implements the Environ interface. If any of the model's
+// security groups cannot be deleted, an error satisfying
+// IsSecurityGroupsNotDeletedError is returned.
 func (e *Environ) Destroy() error {
+	return e.destroy(false)
+}
+
+// DestroyForce implements environs.ForceDestroyer. The model's security
+// groups are detached from any ports still using them before they are
+// deleted.
+func (e *Environ) DestroyForce() error {
+	return e.destroy(true)
+}
+
+var _ environs.ForceDestroyer = (*Environ)(nil)
+
+func (e *Environ) destroy(force bool) error {
 	err := common.Destroy(e)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	// Delete all security groups remaining in the model.
-	return e.firewaller.DeleteAllModelGroups()
+	return errors.Trace(e.firewaller.DeleteAllModelGroups(force))
 }
 
 // DestroyController implements the Environ interface.
@@ -1563,6 +1923,9 @@ func (e *Environ) DestroyController(controllerUUID string) error {
 	if err := e.destroyControllerManagedEnvirons(controllerUUID); err != nil {
 		return errors.Annotate(err, "destroying managed models")
 	}
+	if err := newKeyPairAPI(e).DeleteKeyPair(controllerKeyPairName(controllerUUID)); err != nil {
+		return errors.Annotate(err, "deleting controller keypair")
+	}
 	return e.firewaller.DeleteAllControllerGroups(controllerUUID)
 }
 
@@ -1590,7 +1953,9 @@ func (e *Environ) destroyControllerManagedEnvirons(controllerUUID string) error
 			return errors.Annotate(err, "listing volumes")
 		}
 		volIds := volumeInfoToVolumeIds(cinderToJujuVolumeInfos(volumes))
-		errs := foreachVolume(cinder.storageAdapter, volIds, destroyVolume)
+		errs := foreachVolume(cinder.storageAdapter, volIds, func(storageAdapter OpenstackStorage, volumeId string) error {
+			return destroyVolume(storageAdapter, volumeId, cinder.retry)
+		})
 		for i, err := range errs {
 			if err == nil {
 				continue
@@ -1630,18 +1995,72 @@ func rulesToRuleInfo(groupId string, rules []network.IngressRule) []neutron.Rule
 			PortRangeMax:  r.ToPort,
 			IPProtocol:    r.Protocol,
 		}
+		if r.Protocol == "icmp" {
+			// Neutron holds the ICMP type and code
+			// in the port range.
+			ruleInfo.PortRangeMin = neutronICMPTypeCode(r.FromPort)
+			ruleInfo.PortRangeMax = neutronICMPTypeCode(r.ToPort)
+		}
 		sourceCIDRs := r.SourceCIDRs
 		if len(sourceCIDRs) == 0 {
 			sourceCIDRs = []string{"0.0.0.0/0"}
 		}
 		for _, sr := range sourceCIDRs {
 			ruleInfo.RemoteIPPrefix = sr
+			ruleInfo.EthernetType = ""
+			if ip, _, err := net.ParseCIDR(sr); err == nil && ip.To4() == nil {
+				ruleInfo.EthernetType = "IPv6"
+			}
 			result = append(result, ruleInfo)
 		}
 	}
 	return result
 }
 
+// neutronICMPTypeCode returns the neutron port range minimum or maximum
+// for an ICMP type or code, where zero means any.
+func neutronICMPTypeCode(typeOrCode int) int {
+	if typeOrCode == network.AnyICMP {
+		return 0
+	}
+	return typeOrCode
+}
+
+// egressRulesToRuleInfo maps egress rules to neutron rules.
+func egressRulesToRuleInfo(groupId string, rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:     "egress",
+			ParentGroupId: groupId,
+			PortRangeMin:  r.FromPort,
+			PortRangeMax:  r.ToPort,
+			IPProtocol:    r.Protocol,
+		}
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, dr := range destinationCIDRs {
+			ruleInfo.RemoteIPPrefix = dr
+			ruleInfo.EthernetType = ""
+			if ip, _, err := net.ParseCIDR(dr); err == nil && ip.To4() == nil {
+				ruleInfo.EthernetType = "IPv6"
+			}
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
+var _ environs.ICMPFirewaller = (*Environ)(nil)
+
+// SupportsICMP implements environs.ICMPFirewaller. Neutron security
+// group rules hold ICMP types and codes in their port ranges.
+func (e *Environ) SupportsICMP() bool {
+	return true
+}
+
 func (e *Environ) OpenPorts(rules []network.IngressRule) error {
 	return e.firewaller.OpenPorts(rules)
 }
@@ -1654,6 +2073,49 @@ func (e *Environ) IngressRules() ([]network.IngressRule, error) {
 	return e.firewaller.IngressRules()
 }
 
+// OpenApplicationPorts opens the given port ranges for the application
+// on all of its machines. It requires application-security-groups to be
+// enabled.
+func (e *Environ) OpenApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return e.firewaller.OpenApplicationPorts(applicationName, rules)
+}
+
+// CloseApplicationPorts closes the given port ranges for the application.
+func (e *Environ) CloseApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return e.firewaller.CloseApplicationPorts(applicationName, rules)
+}
+
+// ApplicationIngressRules returns the ingress rules applied to the
+// application.
+func (e *Environ) ApplicationIngressRules(applicationName string) ([]network.IngressRule, error) {
+	return e.firewaller.ApplicationIngressRules(applicationName)
+}
+
+// DeleteApplicationGroup deletes the security group of an application
+// that has been removed.
+func (e *Environ) DeleteApplicationGroup(applicationName string) error {
+	return e.firewaller.DeleteApplicationGroup(applicationName)
+}
+
+// unitApplications returns the sorted names of the applications of the
+// units in the space separated list, as recorded in the machine's
+// tags.JujuUnitsDeployed tag.
+func unitApplications(unitsDeployed string) []string {
+	applicationNames := set.NewStrings()
+	for _, unitName := range strings.Fields(unitsDeployed) {
+		if !names.IsValidUnit(unitName) {
+			logger.Warningf("ignoring invalid unit name %q", unitName)
+			continue
+		}
+		applicationName, err := names.UnitApplication(unitName)
+		if err != nil {
+			continue
+		}
+		applicationNames.Add(applicationName)
+	}
+	return applicationNames.SortedValues()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
@@ -1664,8 +2126,11 @@ func (e *Environ) terminateInstances(ids []instance.Id) error {
 	}
 	var firstErr error
 	novaClient := e.nova()
+	strategy := e.retryStrategy()
 	for _, id := range ids {
-		err := novaClient.DeleteServer(string(id))
+		err := strategy.call(fmt.Sprintf("terminating instance %q", id), func() error {
+			return novaClient.DeleteServer(string(id))
+		})
 		if gooseerrors.IsNotFound(err) {
 			err = nil
 		}
@@ -1713,6 +2178,16 @@ func (e *Environ) TagInstance(id instance.Id, tags map[string]string) error {
 	return nil
 }
 
+// AssignFloatingIP implements environs.FloatingIPAssigner.
+func (e *Environ) AssignFloatingIP(address string, id instance.Id) error {
+	// Nova disassociates the address from any server it is
+	// currently associated with.
+	if err := e.nova().AddServerFloatingIP(string(id), address); err != nil {
+		return errors.Annotatef(err, "assigning floating IP %s to server %s", address, id)
+	}
+	return nil
+}
+
 func (e *Environ) SetClock(clock clock.Clock) {
 	e.clock = clock
 }
@@ -1769,21 +2244,6 @@ func (e *Environ) Spaces() ([]network.SpaceInfo, error) {
 	return nil, errors.NotSupportedf("spaces")
 }
 
-// SupportsContainerAddresses is specified on environs.Networking.
-func (e *Environ) SupportsContainerAddresses() (bool, error) {
-	return false, errors.NotSupportedf("container address")
-}
-
-// AllocateContainerAddresses is specified on environs.Networking.
-func (e *Environ) AllocateContainerAddresses(hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
-	return nil, errors.NotSupportedf("allocate container address")
-}
-
-// ReleaseContainerAddresses is specified on environs.Networking.
-func (e *Environ) ReleaseContainerAddresses(interfaces []network.ProviderInterfaceInfo) error {
-	return errors.NotSupportedf("release container address")
-}
-
 // ProviderSpaceInfo is specified on environs.NetworkingEnviron.
 func (*Environ) ProviderSpaceInfo(space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
 	return nil, errors.NotSupportedf("provider space info")
@@ -40,9 +40,17 @@ func (c *defaultConfigurator) GetCloudConfig(args environs.StartInstanceParams)
 // GetConfigDefaults implements ProviderConfigurator interface.
 func (c *defaultConfigurator) GetConfigDefaults() schema.Defaults {
 	return schema.Defaults{
-		"use-floating-ip":      false,
-		"use-default-secgroup": false,
-		"network":              "",
-		"external-network":     "",
+		"use-floating-ip":                 false,
+		"use-default-secgroup":            false,
+		"network":                         "",
+		"networks":                        "",
+		"external-network":                "",
+		"egress-policy":                   egressPolicyAllowAll,
+		"egress-rules":                    "",
+		"security-group-rule-concurrency": 8,
+		"security-group-rule-attempts":    3,
+		"firewall-implementation":         FirewallerAuto,
+		"ip-address-family":               ipFamilyIPv4,
+		"application-security-groups":     false,
 	}
 }
@@ -261,6 +261,49 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 			RemoteIPPrefix: "0.0.0.0/0",
 			ParentGroupId:  groupId,
 		}},
+	}, {
+		about: "IPv6 source range",
+		rules: []network.IngressRule{network.MustNewIngressRule(
+			"tcp", 80, 80, "::/0")},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMin:   80,
+			PortRangeMax:   80,
+			RemoteIPPrefix: "::/0",
+			EthernetType:   "IPv6",
+			ParentGroupId:  groupId,
+		}},
+	}, {
+		about: "any ICMP",
+		rules: []network.IngressRule{network.MustNewIngressRule("icmp", -1, -1)},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "icmp",
+			RemoteIPPrefix: "0.0.0.0/0",
+			ParentGroupId:  groupId,
+		}},
+	}, {
+		about: "ICMP type",
+		rules: []network.IngressRule{network.MustNewIngressRule("icmp", 8, -1)},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "icmp",
+			PortRangeMin:   8,
+			RemoteIPPrefix: "0.0.0.0/0",
+			ParentGroupId:  groupId,
+		}},
+	}, {
+		about: "ICMP type and code",
+		rules: []network.IngressRule{network.MustNewIngressRule("icmp", 3, 4)},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "icmp",
+			PortRangeMin:   3,
+			PortRangeMax:   4,
+			RemoteIPPrefix: "0.0.0.0/0",
+			ParentGroupId:  groupId,
+		}},
 	}}
 
 	for i, t := range testCases {
@@ -274,8 +317,11 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
+	proto_icmp := "icmp"
 	port_80 := 80
 	port_85 := 85
+	icmp_3 := 3
+	icmp_4 := 4
 
 	testCases := []struct {
 		about        string
@@ -348,6 +394,51 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 			RemoteIPPrefix: "192.168.100.0/24",
 		},
 		expected: false,
+	}, {
+		about: "IPv6 default RemoteIPPrefix",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 85, "::/0"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_80,
+			PortRangeMax: &port_85,
+			EthernetType: "IPv6",
+		},
+		expected: true,
+	}, {
+		about: "IPv6 rule not matching anywhere",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 85),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_80,
+			PortRangeMax: &port_85,
+			EthernetType: "IPv6",
+		},
+		expected: false,
+	}, {
+		about: "any ICMP",
+		rule:  network.MustNewIngressRule(proto_icmp, -1, -1),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol: &proto_icmp,
+		},
+		expected: true,
+	}, {
+		about: "ICMP type and code",
+		rule:  network.MustNewIngressRule(proto_icmp, 3, 4),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:   &proto_icmp,
+			PortRangeMin: &icmp_3,
+			PortRangeMax: &icmp_4,
+		},
+		expected: true,
+	}, {
+		about: "mismatched ICMP code",
+		rule:  network.MustNewIngressRule(proto_icmp, 3, -1),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:   &proto_icmp,
+			PortRangeMin: &icmp_3,
+			PortRangeMax: &icmp_4,
+		},
+		expected: false,
 	}}
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
@@ -564,3 +655,34 @@ func (s *providerUnitTests) TestIdentityClientVersion_ParsesGoodURL(c *gc.C) {
 	_, err = identityClientVersion("https://keystone.internal/")
 	c.Check(err, jc.ErrorIsNil)
 }
+
+func (s *providerUnitTests) TestClassifyStartInstanceError(c *gc.C) {
+	for i, t := range []struct {
+		err    error
+		expect environs.ProvisioningErrorClass
+	}{{
+		err:    fmt.Errorf("cannot run instance: No valid host was found. There are not enough hosts available."),
+		expect: environs.ProvisioningErrorAZCapacity,
+	}, {
+		err:    fmt.Errorf("cannot run instance: Quota exceeded for cores: Requested 4, but already used 20 of 20 cores"),
+		expect: environs.ProvisioningErrorQuota,
+	}, {
+		err:    fmt.Errorf("authentication failed.\n\nPlease ensure the credentials are correct."),
+		expect: environs.ProvisioningErrorCredential,
+	}, {
+		err:    fmt.Errorf(`no "xenial" images in RegionOne with arches [amd64]`),
+		expect: environs.ProvisioningErrorImageNotFound,
+	}, {
+		err:    fmt.Errorf("boom"),
+		expect: environs.ProvisioningErrorUnknown,
+	}} {
+		c.Logf("test %d: %v", i, t.err)
+		c.Check(classifyStartInstanceError(t.err), gc.Equals, t.expect)
+	}
+}
+
+func (s *providerUnitTests) TestUnitApplications(c *gc.C) {
+	c.Assert(unitApplications(""), gc.HasLen, 0)
+	c.Assert(unitApplications("wordpress/1 mysql/0 wordpress/0"), jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Assert(unitApplications("mysql/0 not-a-unit"), jc.DeepEquals, []string{"mysql"})
+}
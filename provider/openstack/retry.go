@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"github.com/juju/utils/clock"
+	gooseerrors "gopkg.in/goose.v2/errors"
+)
+
+// retryStrategy describes how calls to Nova, Neutron and Cinder are
+// retried when they fail, as they may for a while on slow or
+// rate-limited clouds.
+type retryStrategy struct {
+	// Attempts is the number of times to make a call before giving up.
+	Attempts int
+
+	// Delay is the time to wait before the first retry.
+	Delay time.Duration
+
+	// MaxDelay is the longest time to wait between attempts. If zero,
+	// the delay is not limited.
+	MaxDelay time.Duration
+
+	// Backoff is the factor by which the delay grows after each
+	// retry. Values below 1 are taken to be 1, keeping the delay
+	// constant.
+	Backoff float64
+
+	// Jitter is the fraction of each delay by which it may be randomly
+	// shortened or lengthened, so that calls retried together do not
+	// all hit the cloud at once.
+	Jitter float64
+
+	// Clock is used to wait between attempts.
+	Clock clock.Clock
+}
+
+// call calls f until it succeeds, it fails with an error that retrying
+// cannot fix, or it has been called s.Attempts times, returning the
+// last error f returned. Each error that is retried is logged, along
+// with what is being done.
+func (s retryStrategy) call(what string, f func() error) error {
+	attempts := s.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	err := retry.Call(retry.CallArgs{
+		Func:         f,
+		IsFatalError: isFatalError,
+		NotifyFunc: func(err error, attempt int) {
+			logger.Debugf("%s failed (attempt %d of %d): %v", what, attempt, attempts, err)
+		},
+		Attempts:    attempts,
+		Delay:       s.Delay,
+		MaxDelay:    s.MaxDelay,
+		BackoffFunc: s.backoff,
+		Clock:       s.Clock,
+	})
+	if retry.IsAttemptsExceeded(err) {
+		err = retry.LastError(err)
+	}
+	return err
+}
+
+// backoff implements retry.BackoffFunc. The delay is computed afresh
+// for each attempt, so that the jitter does not accumulate.
+func (s retryStrategy) backoff(_ time.Duration, attempt int) time.Duration {
+	factor := s.Backoff
+	if factor < 1 {
+		factor = 1
+	}
+	delay := float64(s.Delay) * math.Pow(factor, float64(attempt-1))
+	if s.MaxDelay > 0 && delay > float64(s.MaxDelay) {
+		delay = float64(s.MaxDelay)
+	}
+	if s.Jitter > 0 {
+		delay += delay * s.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// isFatalError reports whether the error from an OpenStack call is one
+// that retrying the call cannot fix.
+func isFatalError(err error) bool {
+	cause := errors.Cause(err)
+	return gooseerrors.IsNotFound(cause) ||
+		gooseerrors.IsNotImplemented(cause) ||
+		errors.IsNotFound(cause) ||
+		errors.IsNotSupported(cause) ||
+		errors.IsNotValid(cause) ||
+		isNoValidHostsError(cause)
+}
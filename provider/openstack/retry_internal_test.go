@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+)
+
+type retrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&retrySuite{})
+
+var testRetryStrategy = retryStrategy{
+	Attempts: 3,
+	Delay:    time.Millisecond,
+	Backoff:  2,
+	Clock:    clock.WallClock,
+}
+
+func (s *retrySuite) TestCallRetries(c *gc.C) {
+	calls := 0
+	err := testRetryStrategy.call("testing", func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("rate limit exceeded")
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestCallReturnsLastError(c *gc.C) {
+	calls := 0
+	err := testRetryStrategy.call("testing", func() error {
+		calls++
+		return errors.Errorf("failure %d", calls)
+	})
+	c.Assert(err, gc.ErrorMatches, "failure 3")
+	c.Assert(calls, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestCallFatalError(c *gc.C) {
+	calls := 0
+	err := testRetryStrategy.call("testing", func() error {
+		calls++
+		return errors.NotFoundf("server")
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(calls, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestCallAtLeastOnce(c *gc.C) {
+	strategy := testRetryStrategy
+	strategy.Attempts = 0
+	calls := 0
+	err := strategy.call("testing", func() error {
+		calls++
+		return errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(calls, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestBackoff(c *gc.C) {
+	strategy := retryStrategy{
+		Delay:    time.Second,
+		MaxDelay: 5 * time.Second,
+		Backoff:  2,
+	}
+	var delays []time.Duration
+	for attempt := 1; attempt <= 5; attempt++ {
+		delays = append(delays, strategy.backoff(0, attempt))
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+	})
+}
+
+func (s *retrySuite) TestBackoffConstant(c *gc.C) {
+	strategy := retryStrategy{Delay: time.Second}
+	c.Assert(strategy.backoff(0, 1), gc.Equals, time.Second)
+	c.Assert(strategy.backoff(0, 4), gc.Equals, time.Second)
+}
+
+func (s *retrySuite) TestBackoffJitter(c *gc.C) {
+	strategy := retryStrategy{
+		Delay:   10 * time.Second,
+		Backoff: 1,
+		Jitter:  0.2,
+	}
+	for i := 0; i < 100; i++ {
+		delay := strategy.backoff(0, 1)
+		c.Assert(delay >= 8*time.Second, jc.IsTrue, gc.Commentf("delay %v", delay))
+		c.Assert(delay <= 12*time.Second, jc.IsTrue, gc.Commentf("delay %v", delay))
+	}
+}
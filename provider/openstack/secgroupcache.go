@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"gopkg.in/goose.v2/neutron"
+)
+
+// securityGroupCacheTTL is how long security groups listed from Neutron
+// are reused before being listed again.
+var securityGroupCacheTTL = 30 * time.Second
+
+// securityGroupCache caches the security groups listed from Neutron, so
+// that a burst of firewall operations, such as when deploying, doesn't
+// list them every time. The cache must be invalidated whenever the
+// security groups or their rules are changed.
+type securityGroupCache struct {
+	list  func() ([]neutron.SecurityGroupV2, error)
+	clock clock.Clock
+
+	mu      sync.Mutex
+	groups  []neutron.SecurityGroupV2
+	valid   bool
+	expires time.Time
+}
+
+func newSecurityGroupCache(list func() ([]neutron.SecurityGroupV2, error), clk clock.Clock) *securityGroupCache {
+	return &securityGroupCache{
+		list:  list,
+		clock: clk,
+	}
+}
+
+// all returns all the security groups, listing them from Neutron if
+// the cache is invalid or has expired.
+func (c *securityGroupCache) all() ([]neutron.SecurityGroupV2, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	if c.valid && now.Before(c.expires) {
+		return c.groups, nil
+	}
+	groups, err := c.list()
+	if err != nil {
+		return nil, err
+	}
+	c.groups = groups
+	c.valid = true
+	c.expires = now.Add(securityGroupCacheTTL)
+	return groups, nil
+}
+
+// matching returns the security groups for which match returns true.
+func (c *securityGroupCache) matching(match func(neutron.SecurityGroupV2) bool) ([]neutron.SecurityGroupV2, error) {
+	groups, err := c.all()
+	if err != nil {
+		return nil, err
+	}
+	var matched []neutron.SecurityGroupV2
+	for _, group := range groups {
+		if match(group) {
+			matched = append(matched, group)
+		}
+	}
+	return matched, nil
+}
+
+// invalidate discards the cached security groups, so that they are
+// listed again when next needed.
+func (c *securityGroupCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.groups = nil
+	c.valid = false
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v2/neutron"
+)
+
+type securityGroupCacheSuite struct {
+	testing.IsolationSuite
+
+	clock  *testing.Clock
+	calls  int
+	groups []neutron.SecurityGroupV2
+	err    error
+	cache  *securityGroupCache
+}
+
+var _ = gc.Suite(&securityGroupCacheSuite{})
+
+func (s *securityGroupCacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.calls = 0
+	s.groups = []neutron.SecurityGroupV2{
+		{Id: "1", Name: "juju-foo"},
+		{Id: "2", Name: "juju-foo-0"},
+		{Id: "3", Name: "default"},
+	}
+	s.err = nil
+	s.cache = newSecurityGroupCache(func() ([]neutron.SecurityGroupV2, error) {
+		s.calls++
+		return s.groups, s.err
+	}, s.clock)
+}
+
+func (s *securityGroupCacheSuite) TestAllCached(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		groups, err := s.cache.all()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(groups, jc.DeepEquals, s.groups)
+	}
+	c.Assert(s.calls, gc.Equals, 1)
+}
+
+func (s *securityGroupCacheSuite) TestAllExpires(c *gc.C) {
+	_, err := s.cache.all()
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(securityGroupCacheTTL)
+	_, err = s.cache.all()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, gc.Equals, 2)
+}
+
+func (s *securityGroupCacheSuite) TestInvalidate(c *gc.C) {
+	_, err := s.cache.all()
+	c.Assert(err, jc.ErrorIsNil)
+	s.groups = s.groups[:1]
+	s.cache.invalidate()
+	groups, err := s.cache.all()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, s.groups)
+	c.Assert(s.calls, gc.Equals, 2)
+}
+
+func (s *securityGroupCacheSuite) TestErrorNotCached(c *gc.C) {
+	s.err = errors.New("rate limit exceeded")
+	_, err := s.cache.all()
+	c.Assert(err, gc.ErrorMatches, "rate limit exceeded")
+	s.err = nil
+	_, err = s.cache.all()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, gc.Equals, 2)
+}
+
+func (s *securityGroupCacheSuite) TestMatching(c *gc.C) {
+	groups, err := s.cache.matching(func(group neutron.SecurityGroupV2) bool {
+		return group.Name == "juju-foo-0"
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, []neutron.SecurityGroupV2{{Id: "2", Name: "juju-foo-0"}})
+	groups, err = s.cache.matching(func(group neutron.SecurityGroupV2) bool {
+		return strings.HasPrefix(group.Name, "juju-")
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 2)
+	c.Assert(s.calls, gc.Equals, 1)
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/goose.v2/neutron"
+
+	"github.com/juju/juju/environs/tags"
+)
+
+// The security groups Juju creates are tagged with the controller and
+// model they belong to, and with their purpose. The version of the
+// Neutron API used doesn't support resource tags, so the tags are
+// recorded in the group's description, after jujuGroupDescription.
+const (
+	jujuGroupDescription = "juju group"
+
+	// jujuGroupKindTag is the tag recording the purpose of a security
+	// group.
+	jujuGroupKindTag = tags.JujuTagPrefix + "security-group"
+
+	// groupKindModel is the kind of the group every machine in the
+	// model belongs to.
+	groupKindModel = "model"
+
+	// groupKindGlobal is the kind of the group holding the model's
+	// firewall rules, when the firewall mode is global.
+	groupKindGlobal = "global"
+
+	// groupKindMachine is the kind of the group holding a machine's
+	// firewall rules, when the firewall mode is instance. Such groups
+	// are also tagged with the machine ID.
+	groupKindMachine = "machine"
+
+	// groupKindApplication is the kind of the group holding an
+	// application's firewall rules, when application-security-groups
+	// is enabled. Such groups are also tagged with the application
+	// name, using jujuApplicationTag.
+	groupKindApplication = "application"
+
+	// jujuApplicationTag is the tag recording the application an
+	// application group belongs to.
+	jujuApplicationTag = tags.JujuTagPrefix + "application"
+)
+
+var groupTagsRe = regexp.MustCompile(`\[([^\]]*)\]$`)
+
+// groupDescription returns the description of a security group with
+// the given tags.
+func groupDescription(groupTags map[string]string) string {
+	if len(groupTags) == 0 {
+		return jujuGroupDescription
+	}
+	keys := make([]string, 0, len(groupTags))
+	for key := range groupTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = key + "=" + groupTags[key]
+	}
+	return fmt.Sprintf("%s [%s]", jujuGroupDescription, strings.Join(fields, " "))
+}
+
+// securityGroupTags returns the tags recorded in the security group's
+// description, or nil if it has none.
+func securityGroupTags(group neutron.SecurityGroupV2) map[string]string {
+	return descriptionTags(group.Description)
+}
+
+// descriptionTags returns the tags recorded in a group description
+// made by groupDescription, or nil if it has none.
+func descriptionTags(description string) map[string]string {
+	if !strings.HasPrefix(description, jujuGroupDescription) {
+		return nil
+	}
+	match := groupTagsRe.FindStringSubmatch(description)
+	if match == nil {
+		return nil
+	}
+	groupTags := make(map[string]string)
+	for _, field := range strings.Fields(match[1]) {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		groupTags[parts[0]] = parts[1]
+	}
+	if len(groupTags) == 0 {
+		return nil
+	}
+	return groupTags
+}
+
+// tagsMatch reports whether have includes all of the tags in want.
+func tagsMatch(have, want map[string]string) bool {
+	for key, value := range want {
+		if have[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// groupSelector identifies one of the model's security groups, by its
+// tags or, for groups created before they were tagged, by its name.
+type groupSelector struct {
+	// tags holds the tags the group must have. If it is empty, the
+	// group is identified by name alone.
+	tags map[string]string
+
+	// nameRegexp matches the name of the group.
+	nameRegexp string
+}
+
+// matchesTags reports whether the selector identifies the group by its
+// tags. Groups without tags are never identified by their tags.
+func (s groupSelector) matchesTags(group neutron.SecurityGroupV2) bool {
+	if len(s.tags) == 0 {
+		return false
+	}
+	groupTags := securityGroupTags(group)
+	return groupTags != nil && tagsMatch(groupTags, s.tags)
+}
+
+// matchesName reports whether the selector identifies the group by its
+// name. A group with tags is only identified by name when the selector
+// has no tags, so that a group belonging to another model is never
+// mistaken for one of this model's groups.
+func (s groupSelector) matchesName(re *regexp.Regexp, group neutron.SecurityGroupV2) bool {
+	if len(s.tags) > 0 && securityGroupTags(group) != nil {
+		return false
+	}
+	return re.MatchString(group.Name)
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"regexp"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v2/neutron"
+)
+
+type securityGroupTagsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&securityGroupTagsSuite{})
+
+func (s *securityGroupTagsSuite) TestGroupDescription(c *gc.C) {
+	c.Assert(groupDescription(nil), gc.Equals, "juju group")
+	c.Assert(groupDescription(map[string]string{
+		"juju-model-uuid": "deadbeef",
+		"juju-machine-id": "0",
+	}), gc.Equals, "juju group [juju-machine-id=0 juju-model-uuid=deadbeef]")
+}
+
+func (s *securityGroupTagsSuite) TestSecurityGroupTagsRoundTrip(c *gc.C) {
+	groupTags := map[string]string{
+		"juju-model-uuid":     "deadbeef",
+		"juju-security-group": "machine",
+		"juju-machine-id":     "0",
+	}
+	group := neutron.SecurityGroupV2{Description: groupDescription(groupTags)}
+	c.Assert(securityGroupTags(group), jc.DeepEquals, groupTags)
+}
+
+func (s *securityGroupTagsSuite) TestSecurityGroupTagsUntagged(c *gc.C) {
+	for _, description := range []string{
+		"",
+		"juju group",
+		"juju group []",
+		"some group [juju-model-uuid=deadbeef]",
+	} {
+		c.Logf("description %q", description)
+		group := neutron.SecurityGroupV2{Description: description}
+		c.Check(securityGroupTags(group), gc.IsNil)
+	}
+}
+
+func (s *securityGroupTagsSuite) TestTagsMatch(c *gc.C) {
+	have := map[string]string{"a": "1", "b": "2"}
+	c.Assert(tagsMatch(have, nil), jc.IsTrue)
+	c.Assert(tagsMatch(have, map[string]string{"a": "1"}), jc.IsTrue)
+	c.Assert(tagsMatch(have, map[string]string{"a": "2"}), jc.IsFalse)
+	c.Assert(tagsMatch(have, map[string]string{"c": "3"}), jc.IsFalse)
+}
+
+func (s *securityGroupTagsSuite) TestGroupSelector(c *gc.C) {
+	sel := groupSelector{
+		tags:       map[string]string{"juju-model-uuid": "deadbeef"},
+		nameRegexp: "^juju-.*-deadbeef$",
+	}
+	re := regexp.MustCompile(sel.nameRegexp)
+
+	tagged := neutron.SecurityGroupV2{
+		Name:        "renamed",
+		Description: "juju group [juju-model-uuid=deadbeef]",
+	}
+	c.Assert(sel.matchesTags(tagged), jc.IsTrue)
+	c.Assert(sel.matchesName(re, tagged), jc.IsFalse)
+
+	legacy := neutron.SecurityGroupV2{
+		Name:        "juju-controller-deadbeef",
+		Description: "juju group",
+	}
+	c.Assert(sel.matchesTags(legacy), jc.IsFalse)
+	c.Assert(sel.matchesName(re, legacy), jc.IsTrue)
+
+	// A group tagged for another model is not matched by its name.
+	other := neutron.SecurityGroupV2{
+		Name:        "juju-controller-deadbeef",
+		Description: "juju group [juju-model-uuid=cafebabe]",
+	}
+	c.Assert(sel.matchesTags(other), jc.IsFalse)
+	c.Assert(sel.matchesName(re, other), jc.IsFalse)
+
+	// Without tags, groups are matched by name alone.
+	byName := groupSelector{nameRegexp: sel.nameRegexp}
+	c.Assert(byName.matchesTags(tagged), jc.IsFalse)
+	c.Assert(byName.matchesName(re, other), jc.IsTrue)
+}
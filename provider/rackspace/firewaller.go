@@ -50,7 +50,7 @@ func (c *rackspaceFirewaller) DeleteGroups(names ...string) error {
 }
 
 // DeleteAllModelGroups implements OpenstackFirewaller interface.
-func (c *rackspaceFirewaller) DeleteAllModelGroups() error {
+func (c *rackspaceFirewaller) DeleteAllModelGroups(force bool) error {
 	return nil
 }
 
@@ -69,7 +69,7 @@ func (c *rackspaceFirewaller) GetSecurityGroups(ids ...instance.Id) ([]string, e
 }
 
 // SetUpGroups implements OpenstackFirewaller interface.
-func (c *rackspaceFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
+func (c *rackspaceFirewaller) SetUpGroups(controllerUUID, machineId string, applicationNames []string, apiPort int) ([]string, error) {
 	return nil, nil
 }
 
@@ -92,6 +92,91 @@ func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return configurator.FindIngressRules()
 }
 
+// OpenApplicationPorts is not supported.
+func (c *rackspaceFirewaller) OpenApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenApplicationPorts")
+}
+
+// CloseApplicationPorts is not supported.
+func (c *rackspaceFirewaller) CloseApplicationPorts(applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseApplicationPorts")
+}
+
+// ApplicationIngressRules is not supported.
+func (c *rackspaceFirewaller) ApplicationIngressRules(applicationName string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("ApplicationIngressRules")
+}
+
+// MachineSecurityGroups is not supported.
+func (c *rackspaceFirewaller) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	return nil, errors.NotSupportedf("MachineSecurityGroups")
+}
+
+// DeleteApplicationGroup implements OpenstackFirewaller interface.
+func (c *rackspaceFirewaller) DeleteApplicationGroup(applicationName string) error {
+	return nil
+}
+
+// OpenEgressPorts is not supported.
+func (c *rackspaceFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("OpenEgressPorts")
+}
+
+// CloseEgressPorts is not supported.
+func (c *rackspaceFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("CloseEgressPorts")
+}
+
+// EgressRules is not supported.
+func (c *rackspaceFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("EgressRules")
+}
+
+// OpenInstanceEgressPorts is not supported.
+func (c *rackspaceFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("OpenInstanceEgressPorts")
+}
+
+// CloseInstanceEgressPorts is not supported.
+func (c *rackspaceFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("CloseInstanceEgressPorts")
+}
+
+// InstanceEgressRules is not supported.
+func (c *rackspaceFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("InstanceEgressRules")
+}
+
+// UpdateAllowedIngress does nothing, as no security groups are used.
+func (c *rackspaceFirewaller) UpdateAllowedIngress() error {
+	return nil
+}
+
+// SetUpContainerGroups is not supported.
+func (c *rackspaceFirewaller) SetUpContainerGroups(controllerUUID, containerId string) ([]string, error) {
+	return nil, errors.NotSupportedf("SetUpContainerGroups")
+}
+
+// DeleteContainerGroups does nothing, as no security groups are used.
+func (c *rackspaceFirewaller) DeleteContainerGroups(ids ...string) error {
+	return nil
+}
+
+// OpenContainerPorts is not supported.
+func (c *rackspaceFirewaller) OpenContainerPorts(containerId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenContainerPorts")
+}
+
+// CloseContainerPorts is not supported.
+func (c *rackspaceFirewaller) CloseContainerPorts(containerId string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseContainerPorts")
+}
+
+// ContainerIngressRules is not supported.
+func (c *rackspaceFirewaller) ContainerIngressRules(containerId string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("ContainerIngressRules")
+}
+
 func (c *rackspaceFirewaller) changeIngressRules(inst instance.Instance, insert bool, rules []network.IngressRule) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
@@ -4,6 +4,7 @@
 package rackspace
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/goose.v2/nova"
 
 	"github.com/juju/juju/provider/openstack"
@@ -20,12 +21,19 @@ type rackspaceNetworking struct {
 	openstack.Networking
 }
 
-// DefaultNetworks is part of the openstack.Networking interface.
-func (rackspaceNetworking) DefaultNetworks() ([]nova.ServerNetworks, error) {
+// DefaultNetworks is part of the openstack.Networking interface. The
+// networks configured for the model follow the default rackspace
+// networks.
+func (n rackspaceNetworking) DefaultNetworks() ([]nova.ServerNetworks, error) {
+	configured, err := n.Networking.DefaultNetworks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// These are the default rackspace networks, see:
 	// http://docs.rackspace.com/servers/api/v2/cs-devguide/content/provision_server_with_networks.html
-	return []nova.ServerNetworks{
+	networks := []nova.ServerNetworks{
 		{NetworkId: "00000000-0000-0000-0000-000000000000"}, //Racksapce PublicNet
 		{NetworkId: "11111111-1111-1111-1111-111111111111"}, //Rackspace ServiceNet
-	}, nil
+	}
+	return append(networks, configured...), nil
 }
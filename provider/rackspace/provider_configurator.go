@@ -37,9 +37,20 @@ func (c *rackspaceConfigurator) GetCloudConfig(args environs.StartInstanceParams
 // GetConfigDefaults implements ProviderConfigurator interface.
 func (c *rackspaceConfigurator) GetConfigDefaults() schema.Defaults {
 	return schema.Defaults{
-		"use-floating-ip":      false,
-		"use-default-secgroup": false,
-		"network":              "",
-		"external-network":     "",
+		"use-floating-ip":                 false,
+		"use-default-secgroup":            false,
+		"network":                         "",
+		"networks":                        "",
+		"external-network":                "",
+		"egress-policy":                   "allow-all",
+		"egress-rules":                    "",
+		"security-group-rule-concurrency": 8,
+		"security-group-rule-attempts":    3,
+		"firewall-implementation":         "auto",
+		"ip-address-family":               "ipv4",
+		"application-security-groups":     false,
+		"retry-attempts":                  10,
+		"retry-delay":                     "1s",
+		"retry-max-delay":                 "10s",
 	}
 }
@@ -0,0 +1,291 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Connect connects to the model described by the parameters.
+func Connect(p ConnectParams) (Client, error) {
+	if len(p.Addresses) == 0 {
+		return nil, errors.NotValidf("missing addresses")
+	}
+	if !names.IsValidModel(p.ModelUUID) {
+		return nil, errors.NotValidf("model UUID %q", p.ModelUUID)
+	}
+	if !names.IsValidUser(p.User) {
+		return nil, errors.NotValidf("user name %q", p.User)
+	}
+	opts := api.DefaultDialOpts()
+	if p.Timeout > 0 {
+		opts.Timeout = p.Timeout
+	}
+	conn, err := api.Open(&api.Info{
+		Addrs:    p.Addresses,
+		CACert:   p.CACert,
+		ModelTag: names.NewModelTag(p.ModelUUID),
+		Tag:      names.NewUserTag(p.User),
+		Password: p.Password,
+	}, opts)
+	if err != nil {
+		return nil, errors.Trace(api.TypedError(err))
+	}
+	apiClient := conn.Client()
+	return newClient(clientParams{
+		modelUUID:    p.ModelUUID,
+		closer:       conn,
+		status:       apiClient,
+		machines:     apiClient,
+		applications: application.NewClient(conn),
+		watchAll: func() (allWatcher, error) {
+			return apiClient.WatchAll()
+		},
+	}), nil
+}
+
+// statusAPI is the part of *api.Client used to get status.
+type statusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// machinesAPI is the part of *api.Client used to manage machines.
+type machinesAPI interface {
+	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
+	DestroyMachines(machines ...string) error
+	ForceDestroyMachines(machines ...string) error
+}
+
+// applicationsAPI is the part of *application.Client used to manage
+// applications.
+type applicationsAPI interface {
+	AddUnits(application.AddUnitsParams) ([]string, error)
+	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
+	Expose(application string) error
+	Unexpose(application string) error
+	Set(application string, options map[string]string) error
+}
+
+// allWatcher is the interface of *api.AllWatcher.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+type clientParams struct {
+	modelUUID    string
+	closer       io.Closer
+	status       statusAPI
+	machines     machinesAPI
+	applications applicationsAPI
+	watchAll     func() (allWatcher, error)
+}
+
+type client struct {
+	p clientParams
+}
+
+func newClient(p clientParams) *client {
+	return &client{p}
+}
+
+// ModelUUID is part of the Client interface.
+func (c *client) ModelUUID() string {
+	return c.p.modelUUID
+}
+
+// Status is part of the Client interface.
+func (c *client) Status(patterns ...string) (*ModelStatus, error) {
+	status, err := c.p.status.Status(patterns)
+	if err != nil {
+		return nil, typedError(err)
+	}
+	return modelStatusFromParams(status), nil
+}
+
+// Applications is part of the Client interface.
+func (c *client) Applications() Applications {
+	return applications{c.p.applications}
+}
+
+// Machines is part of the Client interface.
+func (c *client) Machines() Machines {
+	return machines{c.p.machines}
+}
+
+// WatchAll is part of the Client interface.
+func (c *client) WatchAll() (Watcher, error) {
+	w, err := c.p.watchAll()
+	if err != nil {
+		return nil, typedError(err)
+	}
+	return watcher{w}, nil
+}
+
+// Close is part of the Client interface.
+func (c *client) Close() error {
+	return errors.Trace(c.p.closer.Close())
+}
+
+type applications struct {
+	api applicationsAPI
+}
+
+// AddUnits is part of the Applications interface.
+func (a applications) AddUnits(appName string, n int) ([]string, error) {
+	units, err := a.api.AddUnits(application.AddUnitsParams{
+		ApplicationName: appName,
+		NumUnits:        n,
+	})
+	if err != nil {
+		return nil, typedError(err)
+	}
+	return units, nil
+}
+
+// DestroyUnits is part of the Applications interface.
+func (a applications) DestroyUnits(units ...string) error {
+	results, err := a.api.DestroyUnits(application.DestroyUnitsParams{
+		Units: units,
+	})
+	if err != nil {
+		return typedError(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return typedError(result.Error)
+		}
+	}
+	return nil
+}
+
+// Expose is part of the Applications interface.
+func (a applications) Expose(application string) error {
+	return typedError(a.api.Expose(application))
+}
+
+// Unexpose is part of the Applications interface.
+func (a applications) Unexpose(application string) error {
+	return typedError(a.api.Unexpose(application))
+}
+
+// SetConfig is part of the Applications interface.
+func (a applications) SetConfig(application string, config map[string]string) error {
+	return typedError(a.api.Set(application, config))
+}
+
+type machines struct {
+	api machinesAPI
+}
+
+// AddMachines is part of the Machines interface.
+func (m machines) AddMachines(n int, series string) ([]string, error) {
+	args := make([]params.AddMachineParams, n)
+	for i := range args {
+		args[i] = params.AddMachineParams{
+			Series: series,
+			Jobs:   []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}
+	}
+	results, err := m.api.AddMachines(args)
+	if err != nil {
+		return nil, typedError(err)
+	}
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Error != nil {
+			return ids, typedError(result.Error)
+		}
+		ids = append(ids, result.Machine)
+	}
+	return ids, nil
+}
+
+// DestroyMachines is part of the Machines interface.
+func (m machines) DestroyMachines(force bool, ids ...string) error {
+	if force {
+		return typedError(m.api.ForceDestroyMachines(ids...))
+	}
+	return typedError(m.api.DestroyMachines(ids...))
+}
+
+type watcher struct {
+	w allWatcher
+}
+
+// Next is part of the Watcher interface.
+func (w watcher) Next() ([]Change, error) {
+	deltas, err := w.w.Next()
+	if err != nil {
+		return nil, typedError(err)
+	}
+	changes := make([]Change, len(deltas))
+	for i, delta := range deltas {
+		id := delta.Entity.EntityId()
+		changes[i] = Change{
+			Kind:    id.Kind,
+			Id:      id.Id,
+			Removed: delta.Removed,
+		}
+	}
+	return changes, nil
+}
+
+// Stop is part of the Watcher interface.
+func (w watcher) Stop() error {
+	return typedError(w.w.Stop())
+}
+
+// typedError returns the error returned by the API, converted to the
+// typed error for its code.
+func typedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errors.Trace(api.TypedError(err))
+}
+
+func modelStatusFromParams(in *params.FullStatus) *ModelStatus {
+	out := &ModelStatus{
+		Name:         in.Model.Name,
+		Machines:     make(map[string]MachineStatus),
+		Applications: make(map[string]ApplicationStatus),
+	}
+	for id, m := range in.Machines {
+		out.Machines[id] = MachineStatus{
+			InstanceId:     string(m.InstanceId),
+			Series:         m.Series,
+			DNSName:        m.DNSName,
+			AgentStatus:    m.AgentStatus.Status,
+			InstanceStatus: m.InstanceStatus.Status,
+		}
+	}
+	for name, a := range in.Applications {
+		app := ApplicationStatus{
+			Charm:   a.Charm,
+			Exposed: a.Exposed,
+			Status:  a.Status.Status,
+			Units:   make(map[string]UnitStatus),
+		}
+		for unitName, u := range a.Units {
+			app.Units[unitName] = UnitStatus{
+				Machine:        u.Machine,
+				PublicAddress:  u.PublicAddress,
+				WorkloadStatus: u.WorkloadStatus.Status,
+				AgentStatus:    u.AgentStatus.Status,
+				Leader:         u.Leader,
+			}
+		}
+		out.Applications[name] = app
+	}
+	return out
+}
@@ -0,0 +1,271 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/sdk"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+	api    *fakeAPI
+	client sdk.Client
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeAPI{}
+	s.client = sdk.NewClientForTest("deadbeef-0bad-400d-8000-4b1d0d06f00d", s.api, s.api, s.api, s.api, s.api)
+}
+
+func (s *clientSuite) TestConnectInvalid(c *gc.C) {
+	_, err := sdk.Connect(sdk.ConnectParams{})
+	c.Assert(err, gc.ErrorMatches, "missing addresses not valid")
+	_, err = sdk.Connect(sdk.ConnectParams{
+		Addresses: []string{"localhost:17070"},
+		ModelUUID: "bad",
+	})
+	c.Assert(err, gc.ErrorMatches, `model UUID "bad" not valid`)
+	_, err = sdk.Connect(sdk.ConnectParams{
+		Addresses: []string{"localhost:17070"},
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		User:      "bad user",
+	})
+	c.Assert(err, gc.ErrorMatches, `user name "bad user" not valid`)
+}
+
+func (s *clientSuite) TestModelUUID(c *gc.C) {
+	c.Assert(s.client.ModelUUID(), gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *clientSuite) TestStatus(c *gc.C) {
+	s.api.status = &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "prod"},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				InstanceId:     "inst-0",
+				Series:         "xenial",
+				DNSName:        "10.0.0.1",
+				AgentStatus:    params.DetailedStatus{Status: "started"},
+				InstanceStatus: params.DetailedStatus{Status: "running"},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:   "cs:mysql-57",
+				Exposed: true,
+				Status:  params.DetailedStatus{Status: "active"},
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						PublicAddress:  "10.0.0.1",
+						WorkloadStatus: params.DetailedStatus{Status: "active"},
+						AgentStatus:    params.DetailedStatus{Status: "idle"},
+						Leader:         true,
+					},
+				},
+			},
+		},
+	}
+	status, err := s.client.Status("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Status", []string{"mysql"})
+	c.Assert(status, jc.DeepEquals, &sdk.ModelStatus{
+		Name: "prod",
+		Machines: map[string]sdk.MachineStatus{
+			"0": {
+				InstanceId:     "inst-0",
+				Series:         "xenial",
+				DNSName:        "10.0.0.1",
+				AgentStatus:    "started",
+				InstanceStatus: "running",
+			},
+		},
+		Applications: map[string]sdk.ApplicationStatus{
+			"mysql": {
+				Charm:   "cs:mysql-57",
+				Exposed: true,
+				Status:  "active",
+				Units: map[string]sdk.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						PublicAddress:  "10.0.0.1",
+						WorkloadStatus: "active",
+						AgentStatus:    "idle",
+						Leader:         true,
+					},
+				},
+			},
+		},
+	})
+}
+
+func (s *clientSuite) TestTypedErrors(c *gc.C) {
+	s.api.SetErrors(&params.Error{Message: "application \"foo\" not found", Code: params.CodeNotFound})
+	err := s.client.Applications().Expose("foo")
+	c.Assert(err, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestAddUnits(c *gc.C) {
+	units, err := s.client.Applications().AddUnits("mysql", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"mysql/1", "mysql/2"})
+	s.api.CheckCall(c, 0, "AddUnits", application.AddUnitsParams{
+		ApplicationName: "mysql",
+		NumUnits:        2,
+	})
+}
+
+func (s *clientSuite) TestDestroyUnitsResultError(c *gc.C) {
+	s.api.destroyUnitResults = []params.DestroyUnitResult{
+		{},
+		{Error: &params.Error{Message: "unit \"mysql/1\" not found", Code: params.CodeNotFound}},
+	}
+	err := s.client.Applications().DestroyUnits("mysql/0", "mysql/1")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/1" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.api.CheckCall(c, 0, "DestroyUnits", application.DestroyUnitsParams{
+		Units: []string{"mysql/0", "mysql/1"},
+	})
+}
+
+func (s *clientSuite) TestSetConfig(c *gc.C) {
+	err := s.client.Applications().SetConfig("mysql", map[string]string{"port": "3307"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Set", "mysql", map[string]string{"port": "3307"})
+}
+
+func (s *clientSuite) TestAddMachines(c *gc.C) {
+	ids, err := s.client.Machines().AddMachines(2, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"0", "1"})
+	machineParams := params.AddMachineParams{
+		Series: "xenial",
+		Jobs:   []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+	}
+	s.api.CheckCall(c, 0, "AddMachines", []params.AddMachineParams{machineParams, machineParams})
+}
+
+func (s *clientSuite) TestDestroyMachines(c *gc.C) {
+	err := s.client.Machines().DestroyMachines(false, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.Machines().DestroyMachines(true, "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{FuncName: "DestroyMachines", Args: []interface{}{[]string{"0"}}},
+		{FuncName: "ForceDestroyMachines", Args: []interface{}{[]string{"1", "2"}}},
+	})
+}
+
+func (s *clientSuite) TestWatchAll(c *gc.C) {
+	s.api.deltas = []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "mysql/0"},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "1"},
+	}}
+	w, err := s.client.WatchAll()
+	c.Assert(err, jc.ErrorIsNil)
+	changes, err := w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []sdk.Change{
+		{Kind: "unit", Id: "mysql/0"},
+		{Kind: "machine", Id: "1", Removed: true},
+	})
+	c.Assert(w.Stop(), jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Next", "Stop")
+}
+
+func (s *clientSuite) TestClose(c *gc.C) {
+	c.Assert(s.client.Close(), jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Close")
+}
+
+type fakeAPI struct {
+	testing.Stub
+	status             *params.FullStatus
+	destroyUnitResults []params.DestroyUnitResult
+	deltas             []multiwatcher.Delta
+}
+
+func (f *fakeAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.AddCall("Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *fakeAPI) AddMachines(args []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	f.AddCall("AddMachines", args)
+	results := make([]params.AddMachinesResult, len(args))
+	for i := range results {
+		results[i].Machine = fmt.Sprint(i)
+	}
+	return results, f.NextErr()
+}
+
+func (f *fakeAPI) DestroyMachines(machines ...string) error {
+	f.AddCall("DestroyMachines", machines)
+	return f.NextErr()
+}
+
+func (f *fakeAPI) ForceDestroyMachines(machines ...string) error {
+	f.AddCall("ForceDestroyMachines", machines)
+	return f.NextErr()
+}
+
+func (f *fakeAPI) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	f.AddCall("AddUnits", args)
+	var units []string
+	for i := 1; i <= args.NumUnits; i++ {
+		units = append(units, fmt.Sprintf("%s/%d", args.ApplicationName, i))
+	}
+	return units, f.NextErr()
+}
+
+func (f *fakeAPI) DestroyUnits(args application.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
+	f.AddCall("DestroyUnits", args)
+	return f.destroyUnitResults, f.NextErr()
+}
+
+func (f *fakeAPI) Expose(application string) error {
+	f.AddCall("Expose", application)
+	return f.NextErr()
+}
+
+func (f *fakeAPI) Unexpose(application string) error {
+	f.AddCall("Unexpose", application)
+	return f.NextErr()
+}
+
+func (f *fakeAPI) Set(application string, options map[string]string) error {
+	f.AddCall("Set", application, options)
+	return f.NextErr()
+}
+
+func (f *fakeAPI) Next() ([]multiwatcher.Delta, error) {
+	f.AddCall("Next")
+	return f.deltas, f.NextErr()
+}
+
+func (f *fakeAPI) Stop() error {
+	f.AddCall("Stop")
+	return f.NextErr()
+}
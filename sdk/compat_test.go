@@ -0,0 +1,152 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk_test
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/sdk"
+)
+
+// compatSuite pins the exported interface of the sdk package. Adding
+// to it is a compatible change, and only requires adding to the
+// expectations here; changing or removing anything needs a new major
+// sdk.Version.
+type compatSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&compatSuite{})
+
+func (s *compatSuite) TestVersion(c *gc.C) {
+	c.Assert(sdk.Version, gc.Matches, `1\.\d+\.\d+`)
+}
+
+func (s *compatSuite) TestConnect(c *gc.C) {
+	var connect func(sdk.ConnectParams) (sdk.Client, error) = sdk.Connect
+	c.Assert(connect, gc.NotNil)
+}
+
+var interfaceMethods = []struct {
+	iface   interface{}
+	methods []string
+}{{
+	iface: (*sdk.Client)(nil),
+	methods: []string{
+		"Applications func() sdk.Applications",
+		"Close func() error",
+		"Machines func() sdk.Machines",
+		"ModelUUID func() string",
+		"Status func(...string) (*sdk.ModelStatus, error)",
+		"WatchAll func() (sdk.Watcher, error)",
+	},
+}, {
+	iface: (*sdk.Applications)(nil),
+	methods: []string{
+		"AddUnits func(string, int) ([]string, error)",
+		"DestroyUnits func(...string) error",
+		"Expose func(string) error",
+		"SetConfig func(string, map[string]string) error",
+		"Unexpose func(string) error",
+	},
+}, {
+	iface: (*sdk.Machines)(nil),
+	methods: []string{
+		"AddMachines func(int, string) ([]string, error)",
+		"DestroyMachines func(bool, ...string) error",
+	},
+}, {
+	iface: (*sdk.Watcher)(nil),
+	methods: []string{
+		"Next func() ([]sdk.Change, error)",
+		"Stop func() error",
+	},
+}}
+
+func (s *compatSuite) TestInterfaces(c *gc.C) {
+	for _, t := range interfaceMethods {
+		iface := reflect.TypeOf(t.iface).Elem()
+		c.Logf("checking %s", iface)
+		var methods []string
+		for i := 0; i < iface.NumMethod(); i++ {
+			m := iface.Method(i)
+			methods = append(methods, fmt.Sprintf("%s %s", m.Name, m.Type))
+		}
+		c.Check(methods, jc.DeepEquals, t.methods)
+	}
+}
+
+var structFields = []struct {
+	value  interface{}
+	fields []string
+}{{
+	value: sdk.ConnectParams{},
+	fields: []string{
+		"Addresses []string",
+		"CACert string",
+		"ModelUUID string",
+		"User string",
+		"Password string",
+		"Timeout time.Duration",
+	},
+}, {
+	value: sdk.Change{},
+	fields: []string{
+		"Kind string",
+		"Id string",
+		"Removed bool",
+	},
+}, {
+	value: sdk.ModelStatus{},
+	fields: []string{
+		"Name string",
+		"Machines map[string]sdk.MachineStatus",
+		"Applications map[string]sdk.ApplicationStatus",
+	},
+}, {
+	value: sdk.MachineStatus{},
+	fields: []string{
+		"InstanceId string",
+		"Series string",
+		"DNSName string",
+		"AgentStatus string",
+		"InstanceStatus string",
+	},
+}, {
+	value: sdk.ApplicationStatus{},
+	fields: []string{
+		"Charm string",
+		"Exposed bool",
+		"Status string",
+		"Units map[string]sdk.UnitStatus",
+	},
+}, {
+	value: sdk.UnitStatus{},
+	fields: []string{
+		"Machine string",
+		"PublicAddress string",
+		"WorkloadStatus string",
+		"AgentStatus string",
+		"Leader bool",
+	},
+}}
+
+func (s *compatSuite) TestStructs(c *gc.C) {
+	for _, t := range structFields {
+		typ := reflect.TypeOf(t.value)
+		c.Logf("checking %s", typ)
+		// New fields may only be added at the end, so that
+		// unkeyed composite literals keep compiling.
+		c.Assert(typ.NumField() >= len(t.fields), jc.IsTrue)
+		for i, expect := range t.fields {
+			f := typ.Field(i)
+			c.Check(fmt.Sprintf("%s %s", f.Name, f.Type), gc.Equals, expect)
+		}
+	}
+}
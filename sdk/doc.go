@@ -0,0 +1,38 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package sdk is the supported Go interface for programs that drive
+// Juju models, such as deployment tooling and dashboards.
+//
+// The api packages used by the juju client and agents change with
+// every release, and are not meant to be imported by other programs.
+// This package wraps them behind interfaces and types of its own,
+// which are versioned semantically: within a major Version, exported
+// names are only ever added, never changed or removed. The package's
+// compatibility tests pin the exported interfaces, so that any
+// incompatible change fails them and must come with a new major
+// version.
+//
+// A program connects to a model with Connect, and uses the returned
+// Client to inspect and change it:
+//
+//	client, err := sdk.Connect(sdk.ConnectParams{
+//		Addresses: []string{"10.0.0.1:17070"},
+//		CACert:    caCert,
+//		ModelUUID: modelUUID,
+//		User:      "admin",
+//		Password:  password,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//	units, err := client.Applications().AddUnits("mysql", 2)
+//
+// Errors returned by the server carry their kind, and can be tested
+// with the functions in github.com/juju/errors, such as
+// errors.IsNotFound.
+package sdk
+
+// Version is the semantic version of this package's interface.
+const Version = "1.0.0"
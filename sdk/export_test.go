@@ -0,0 +1,34 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk
+
+import (
+	"io"
+
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// NewClientForTest returns a Client that uses the given APIs.
+func NewClientForTest(
+	modelUUID string,
+	closer io.Closer,
+	status statusAPI,
+	machines machinesAPI,
+	applications applicationsAPI,
+	w interface {
+		Next() ([]multiwatcher.Delta, error)
+		Stop() error
+	},
+) Client {
+	return newClient(clientParams{
+		modelUUID:    modelUUID,
+		closer:       closer,
+		status:       status,
+		machines:     machines,
+		applications: applications,
+		watchAll: func() (allWatcher, error) {
+			return w, nil
+		},
+	})
+}
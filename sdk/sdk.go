@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk
+
+import (
+	"time"
+)
+
+// ConnectParams holds the parameters for connecting to a model.
+type ConnectParams struct {
+	// Addresses holds the host:port addresses of the controller's
+	// API servers.
+	Addresses []string
+
+	// CACert holds the PEM-encoded CA certificate of the controller.
+	CACert string
+
+	// ModelUUID is the UUID of the model to connect to.
+	ModelUUID string
+
+	// User and Password are the credentials of the user to
+	// connect as.
+	User     string
+	Password string
+
+	// Timeout, if positive, bounds the time spent connecting.
+	Timeout time.Duration
+}
+
+// Client is a connection to a model.
+type Client interface {
+	// ModelUUID returns the UUID of the model connected to.
+	ModelUUID() string
+
+	// Status returns the status of the model, limited to the
+	// machines, applications and units matching the given patterns
+	// if any are given.
+	Status(patterns ...string) (*ModelStatus, error)
+
+	// Applications returns the operations on the model's
+	// applications.
+	Applications() Applications
+
+	// Machines returns the operations on the model's machines.
+	Machines() Machines
+
+	// WatchAll returns a Watcher that reports all changes to the
+	// model.
+	WatchAll() (Watcher, error)
+
+	// Close closes the connection.
+	Close() error
+}
+
+// Applications holds the operations on a model's applications.
+type Applications interface {
+	// AddUnits adds n units to the application, and returns their
+	// names.
+	AddUnits(application string, n int) ([]string, error)
+
+	// DestroyUnits destroys the named units.
+	DestroyUnits(units ...string) error
+
+	// Expose makes the application's open ports accessible from
+	// outside the model.
+	Expose(application string) error
+
+	// Unexpose undoes Expose.
+	Unexpose(application string) error
+
+	// SetConfig changes the application's charm configuration.
+	SetConfig(application string, config map[string]string) error
+}
+
+// Machines holds the operations on a model's machines.
+type Machines interface {
+	// AddMachines adds n machines, which can host units, with the
+	// given series, or the model's default series if it is empty,
+	// and returns their IDs.
+	AddMachines(n int, series string) ([]string, error)
+
+	// DestroyMachines destroys the machines with the given IDs. If
+	// force is true, the machines are destroyed along with any units
+	// they host.
+	DestroyMachines(force bool, ids ...string) error
+}
+
+// Watcher reports changes to a model.
+type Watcher interface {
+	// Next blocks until there are changes, and returns them.
+	Next() ([]Change, error)
+
+	// Stop stops the watcher.
+	Stop() error
+}
+
+// Change describes a change to an entity in a model.
+type Change struct {
+	// Kind is the kind of the entity, such as "machine",
+	// "application" or "unit".
+	Kind string
+
+	// Id identifies the entity within its kind.
+	Id string
+
+	// Removed is true if the entity was removed, and false if it
+	// was added or changed.
+	Removed bool
+}
+
+// ModelStatus holds the status of a model.
+type ModelStatus struct {
+	Name         string
+	Machines     map[string]MachineStatus
+	Applications map[string]ApplicationStatus
+}
+
+// MachineStatus holds the status of a machine.
+type MachineStatus struct {
+	InstanceId     string
+	Series         string
+	DNSName        string
+	AgentStatus    string
+	InstanceStatus string
+}
+
+// ApplicationStatus holds the status of an application.
+type ApplicationStatus struct {
+	Charm   string
+	Exposed bool
+	Status  string
+	Units   map[string]UnitStatus
+}
+
+// UnitStatus holds the status of a unit.
+type UnitStatus struct {
+	Machine        string
+	PublicAddress  string
+	WorkloadStatus string
+	AgentStatus    string
+	Leader         bool
+}
@@ -64,6 +64,11 @@ type Conf struct {
 
 	// ServiceArgs is a string array of unquoted arguments
 	ServiceArgs []string
+
+	// User, if set, is the user the service runs as, rather
+	// than root.
+	// Currently only supported by systemd.
+	User string
 }
 
 // IsZero determines whether or not the conf is a zero value.
@@ -66,8 +66,12 @@ func normalize(name string, conf common.Conf, scriptPath string, renderer confRe
 		cmds = append(cmds, renderer.Touch(filename, nil)...)
 		// TODO(ericsnow) We should drop the assumption that the logfile
 		// is syslog.
-		user, group := syslogUserGroup()
-		cmds = append(cmds, renderer.Chown(filename, user, group)...)
+		if conf.User == "" {
+			// A service running as another user cannot change
+			// the owner of its logfile, and owns it anyway.
+			user, group := syslogUserGroup()
+			cmds = append(cmds, renderer.Chown(filename, user, group)...)
+		}
 		cmds = append(cmds, renderer.Chmod(filename, 0600)...)
 		cmds = append(cmds, renderer.RedirectOutput(filename)...)
 		cmds = append(cmds, renderer.RedirectFD("out", "err")...)
@@ -210,6 +214,14 @@ func serializeService(conf common.Conf) []*unit.UnitOption {
 		})
 	}
 
+	if conf.User != "" {
+		unitOptions = append(unitOptions, &unit.UnitOption{
+			Section: "Service",
+			Name:    "User",
+			Value:   conf.User,
+		})
+	}
+
 	if conf.ExecStart != "" {
 		unitOptions = append(unitOptions, &unit.UnitOption{
 			Section: "Service",
@@ -313,6 +325,8 @@ func deserializeOptions(opts []*unit.UnitOption, renderer shell.Renderer) (commo
 						break
 					}
 				}
+			case uo.Name == "User":
+				conf.User = uo.Value
 			case uo.Name == "TimeoutSec":
 				timeout, err := strconv.Atoi(uo.Value)
 				if err != nil {
@@ -810,6 +810,35 @@ exec 2>&1
 	test.CheckCommands(c, commands)
 }
 
+func (s *initSystemSuite) TestInstallCommandsUser(c *gc.C) {
+	name := "jujud-machine-0"
+	s.conf.Logfile = "/var/log/juju/machine-0.log"
+	s.conf.User = "juju"
+	service := s.newService(c)
+	commands, err := service.InstallCommands()
+	c.Assert(err, jc.ErrorIsNil)
+
+	test := systemdtesting.WriteConfTest{
+		Service: name,
+		DataDir: s.dataDir,
+		Expected: strings.Replace(
+			s.newConfStr(name),
+			"ExecStart=/var/lib/juju/bin/jujud machine-0",
+			"User=juju\nExecStart=/var/lib/juju/init/jujud-machine-0/exec-start.sh",
+			-1),
+		Script: `
+# Set up logging.
+touch '/var/log/juju/machine-0.log'
+chmod 0600 '/var/log/juju/machine-0.log'
+exec >> '/var/log/juju/machine-0.log'
+exec 2>&1
+
+# Run the script.
+`[1:] + jujud + " machine-0",
+	}
+	test.CheckCommands(c, commands)
+}
+
 func (s *initSystemSuite) TestInstallCommandsShutdown(c *gc.C) {
 	name := "juju-shutdown-job"
 	conf, err := service.ShutdownAfterConf("cloud-final")
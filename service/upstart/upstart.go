@@ -137,6 +137,10 @@ func (s *Service) Validate() error {
 		return errors.Trace(err)
 	}
 
+	if s.Service.Conf.User != "" {
+		return errors.NotSupportedf("Conf.User")
+	}
+
 	if s.Service.Conf.Transient {
 		if len(s.Service.Conf.Env) > 0 {
 			return errors.NotSupportedf("Conf.Env (when transient)")
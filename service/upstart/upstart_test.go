@@ -210,6 +210,9 @@ func (s *UpstartSuite) TestInstallErrors(c *gc.C) {
 	check("missing Desc")
 	s.service.Service.Conf.Desc = "this is an upstart service"
 	check("missing ExecStart")
+	s.service.Service.Conf.ExecStart = "/path/to/some-command"
+	s.service.Service.Conf.User = "juju"
+	check("Conf.User not supported")
 }
 
 const expectStart = `description "this is an upstart service"
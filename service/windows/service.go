@@ -127,6 +127,10 @@ func (s *Service) Validate() error {
 		return errors.NotSupportedf("Conf.AfterStopped")
 	}
 
+	if s.Service.Conf.User != "" {
+		return errors.NotSupportedf("Conf.User")
+	}
+
 	return nil
 }
 
@@ -104,6 +104,20 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action while
+	// it runs.
+	Logs []ActionMessage `bson:"messages"`
+
+	// Progress is the percentage of the action that is complete, as
+	// last reported by the action.
+	Progress int `bson:"progress"`
+}
+
+// ActionMessage is a progress message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +176,17 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
+// Progress returns the percentage of the action that is complete, as
+// last reported by the action.
+func (a *action) Progress() int {
+	return a.doc.Progress
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -216,6 +241,49 @@ func (a *action) Finish(results ActionResults) (Action, error) {
 	return a.removeAndLog(results.Status, results.Results, results.Message)
 }
 
+// Log records a progress message for the action. It asserts that the
+// action is running.
+func (a *action) Log(message string) error {
+	return a.updateRunning(bson.D{{"$push", bson.D{
+		{"messages", ActionMessage{
+			Timestamp: a.st.clock().Now().UTC(),
+			Message:   message,
+		}},
+	}}})
+}
+
+// SetProgress records the percentage of the action that is complete,
+// and a progress message if one is given. It asserts that the action
+// is running.
+func (a *action) SetProgress(percent int, message string) error {
+	if percent < 0 || percent > 100 {
+		return errors.NotValidf("progress %d%%", percent)
+	}
+	update := bson.D{{"$set", bson.D{{"progress", percent}}}}
+	if message != "" {
+		update = append(update, bson.DocElem{"$push", bson.D{
+			{"messages", ActionMessage{
+				Timestamp: a.st.clock().Now().UTC(),
+				Message:   message,
+			}},
+		}})
+	}
+	return a.updateRunning(update)
+}
+
+func (a *action) updateRunning(update bson.D) error {
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: update,
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("action %q is not running", a.Id())
+	}
+	return errors.Trace(err)
+}
+
 // removeAndLog takes the action off of the pending queue, and creates
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
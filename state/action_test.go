@@ -484,6 +484,56 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLogAndProgress(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("starting backup")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.SetProgress(50, "copied 2 of 4 tables")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.SetProgress(75, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Progress(), gc.Equals, 75)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "starting backup")
+	c.Check(messages[1].Message, gc.Equals, "copied 2 of 4 tables")
+	c.Check(messages[0].Timestamp.IsZero(), jc.IsFalse)
+
+	// The messages are kept with the action's results.
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 2)
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too early")
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+	err = a.SetProgress(10, "")
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+}
+
+func (s *ActionSuite) TestSetProgressInvalid(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.SetProgress(101, "")
+	c.Assert(err, gc.ErrorMatches, "progress 101% not valid")
+	err = a.SetProgress(-1, "")
+	c.Assert(err, gc.ErrorMatches, "progress -1% not valid")
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
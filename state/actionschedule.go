@@ -0,0 +1,384 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cron"
+)
+
+// maxActionScheduleHistory is the number of runs recorded in the
+// history of each action schedule.
+const maxActionScheduleHistory = 10
+
+// ActionSchedule describes an action that is enqueued on a unit
+// periodically, or once at a future time.
+type ActionSchedule struct {
+	// Id identifies the schedule within the model.
+	Id string
+
+	// Receiver is the name of the unit the action is enqueued on.
+	Receiver string
+
+	// Name is the name of the action.
+	Name string
+
+	// Parameters holds the parameters the action is enqueued with.
+	Parameters map[string]interface{}
+
+	// Cron is the cron expression of a recurring schedule. It is
+	// empty for a schedule that runs once.
+	Cron string
+
+	// NextRun is when the action is next enqueued. It is zero once a
+	// schedule that runs once has run.
+	NextRun time.Time
+
+	// Owner is the name of the user that created the schedule.
+	Owner string
+
+	// Created is when the schedule was created.
+	Created time.Time
+
+	// History holds the most recent runs of the schedule, oldest
+	// first.
+	History []ActionScheduleRun
+}
+
+// ActionScheduleRun records a run of an action schedule.
+type ActionScheduleRun struct {
+	// Time is when the run happened.
+	Time time.Time
+
+	// ActionId is the ID of the action enqueued by the run. It is
+	// empty if no action was enqueued.
+	ActionId string
+
+	// Error describes why no action was enqueued, if none was.
+	Error string
+}
+
+// AddActionScheduleParams holds the parameters for adding an action
+// schedule.
+type AddActionScheduleParams struct {
+	// Receiver is the name of the unit the action is enqueued on.
+	Receiver string
+
+	// Name is the name of the action.
+	Name string
+
+	// Parameters holds the parameters the action is enqueued with.
+	Parameters map[string]interface{}
+
+	// Cron is the cron expression of a recurring schedule. Exactly
+	// one of Cron and At must be specified.
+	Cron string
+
+	// At is when a schedule that runs once is run.
+	At time.Time
+
+	// Owner is the name of the user creating the schedule.
+	Owner string
+}
+
+type actionScheduleDoc struct {
+	DocId      string                 `bson:"_id"`
+	ModelUUID  string                 `bson:"model-uuid"`
+	Id         string                 `bson:"id"`
+	Receiver   string                 `bson:"receiver"`
+	Name       string                 `bson:"name"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Cron       string                 `bson:"cron,omitempty"`
+	NextRun    int64                  `bson:"next-run"`
+	Owner      string                 `bson:"owner"`
+	Created    int64                  `bson:"created"`
+	History    []actionScheduleRunDoc `bson:"history,omitempty"`
+}
+
+type actionScheduleRunDoc struct {
+	Time     int64  `bson:"time"`
+	ActionId string `bson:"action-id,omitempty"`
+	Error    string `bson:"error,omitempty"`
+}
+
+func unixNanoTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t).UTC()
+}
+
+func (doc *actionScheduleDoc) schedule() ActionSchedule {
+	s := ActionSchedule{
+		Id:         doc.Id,
+		Receiver:   doc.Receiver,
+		Name:       doc.Name,
+		Parameters: doc.Parameters,
+		Cron:       doc.Cron,
+		NextRun:    unixNanoTime(doc.NextRun),
+		Owner:      doc.Owner,
+		Created:    unixNanoTime(doc.Created),
+	}
+	for _, run := range doc.History {
+		s.History = append(s.History, ActionScheduleRun{
+			Time:     unixNanoTime(run.Time),
+			ActionId: run.ActionId,
+			Error:    run.Error,
+		})
+	}
+	return s
+}
+
+// AddActionSchedule adds a schedule for enqueuing an action on a unit,
+// and returns it. The action and its parameters are validated against
+// the unit's charm when the schedule is added.
+func (st *State) AddActionSchedule(args AddActionScheduleParams) (ActionSchedule, error) {
+	fail := func(err error) (ActionSchedule, error) {
+		return ActionSchedule{}, errors.Annotatef(err, "cannot schedule action %q on unit %q", args.Name, args.Receiver)
+	}
+	var nextRun time.Time
+	now := st.clock().Now().UTC()
+	switch {
+	case args.Cron != "" && !args.At.IsZero():
+		return fail(errors.NotValidf("both cron expression and time"))
+	case args.Cron != "":
+		schedule, err := cron.Parse(args.Cron)
+		if err != nil {
+			return fail(err)
+		}
+		args.Cron = schedule.String()
+		if nextRun = schedule.Next(now); nextRun.IsZero() {
+			return fail(errors.NotValidf("cron expression %q that never runs", args.Cron))
+		}
+	case !args.At.IsZero():
+		if !args.At.After(now) {
+			return fail(errors.NotValidf("time %s in the past", args.At.UTC().Format(time.RFC3339)))
+		}
+		nextRun = args.At.UTC()
+	default:
+		return fail(errors.NotValidf("missing cron expression or time"))
+	}
+	unit, err := st.Unit(args.Receiver)
+	if err != nil {
+		return fail(err)
+	}
+	spec, err := unit.actionSpec(args.Name)
+	if err != nil {
+		return fail(err)
+	}
+	if err := spec.ValidateParams(args.Parameters); err != nil {
+		return fail(err)
+	}
+
+	seq, err := sequence(st, "actionschedule")
+	if err != nil {
+		return fail(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := &actionScheduleDoc{
+		DocId:      st.docID(id),
+		ModelUUID:  st.ModelUUID(),
+		Id:         id,
+		Receiver:   args.Receiver,
+		Name:       args.Name,
+		Parameters: args.Parameters,
+		Cron:       args.Cron,
+		NextRun:    nextRun.UnixNano(),
+		Owner:      args.Owner,
+		Created:    now.UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     unit.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return fail(errors.Errorf("unit is no longer alive"))
+	} else if err != nil {
+		return fail(err)
+	}
+	return doc.schedule(), nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (st *State) ActionSchedule(id string) (ActionSchedule, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	if err := schedules.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return ActionSchedule{}, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return ActionSchedule{}, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return doc.schedule(), nil
+}
+
+// ActionSchedules returns the model's action schedules, in the order
+// they were created.
+func (st *State) ActionSchedules() ([]ActionSchedule, error) {
+	return st.actionSchedules(nil)
+}
+
+// DueActionSchedules returns the model's action schedules that are
+// due to run at the given time, in the order they were created.
+func (st *State) DueActionSchedules(now time.Time) ([]ActionSchedule, error) {
+	return st.actionSchedules(bson.D{
+		{"next-run", bson.D{{"$gt", 0}, {"$lte", now.UnixNano()}}},
+	})
+}
+
+func (st *State) actionSchedules(query bson.D) ([]ActionSchedule, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(query).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	out := make([]ActionSchedule, len(docs))
+	for i, doc := range docs {
+		out[i] = doc.schedule()
+	}
+	return out, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions already enqueued by the schedule are not affected.
+func (st *State) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", id)
+	}
+	return nil
+}
+
+// RunActionSchedule runs the action schedule with the given id, if it
+// is due at the given time, enqueuing its action and recording the run
+// in the schedule's history. If the action most recently enqueued by
+// the schedule is still pending or running, no action is enqueued, so
+// that runs of a schedule never overlap. A recurring schedule is next
+// run at the first time matching its cron expression after now; runs
+// missed while the controller was unavailable are not caught up.
+func (st *State) RunActionSchedule(id string, now time.Time) (ActionScheduleRun, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	if err := schedules.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return ActionScheduleRun{}, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return ActionScheduleRun{}, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	if doc.NextRun == 0 || doc.NextRun > now.UnixNano() {
+		return ActionScheduleRun{}, errors.Errorf("action schedule %q is not due", id)
+	}
+
+	run := ActionScheduleRun{Time: now.UTC()}
+	if action, err := st.runningScheduledAction(doc.History); err != nil {
+		return ActionScheduleRun{}, errors.Trace(err)
+	} else if action != nil {
+		run.Error = fmt.Sprintf("skipped: action %s is still %s", action.Id(), action.Status())
+	} else if action, err := st.enqueueScheduledAction(doc); err != nil {
+		run.Error = err.Error()
+	} else {
+		run.ActionId = action.Id()
+	}
+
+	var nextRun int64
+	if doc.Cron != "" {
+		schedule, err := cron.Parse(doc.Cron)
+		if err != nil {
+			return ActionScheduleRun{}, errors.Annotatef(err, "action schedule %q", id)
+		}
+		if next := schedule.Next(now.UTC()); !next.IsZero() {
+			nextRun = next.UnixNano()
+		}
+	}
+	history := append(doc.History, actionScheduleRunDoc{
+		Time:     run.Time.UnixNano(),
+		ActionId: run.ActionId,
+		Error:    run.Error,
+	})
+	if len(history) > maxActionScheduleHistory {
+		history = history[len(history)-maxActionScheduleHistory:]
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"next-run", doc.NextRun}},
+		Update: bson.D{{"$set", bson.D{
+			{"next-run", nextRun},
+			{"history", history},
+		}}},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return ActionScheduleRun{}, errors.Errorf("action schedule %q changed while running", id)
+	} else if err != nil {
+		return ActionScheduleRun{}, errors.Annotatef(err, "cannot record run of action schedule %q", id)
+	}
+	return run, nil
+}
+
+// runningScheduledAction returns the action most recently enqueued by
+// a schedule with the given history, if it is still pending or
+// running.
+func (st *State) runningScheduledAction(history []actionScheduleRunDoc) (Action, error) {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ActionId == "" {
+			continue
+		}
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		action, err := model.Action(history[i].ActionId)
+		if errors.IsNotFound(err) {
+			// The action has been pruned.
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch action.Status() {
+		case ActionPending, ActionRunning:
+			return action, nil
+		}
+		return nil, nil
+	}
+	return nil, nil
+}
+
+func (st *State) enqueueScheduledAction(doc actionScheduleDoc) (Action, error) {
+	unit, err := st.Unit(doc.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// AddAction inserts defaults into the payload, so it must not
+	// be given the schedule's parameters.
+	payload := make(map[string]interface{}, len(doc.Parameters))
+	for k, v := range doc.Parameters {
+		payload[k] = v
+	}
+	return unit.AddAction(doc.Name, payload)
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit = unit
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, cron string, at time.Time) state.ActionSchedule {
+	schedule, err := s.State.AddActionSchedule(state.AddActionScheduleParams{
+		Receiver:   s.unit.Name(),
+		Name:       "snapshot",
+		Parameters: map[string]interface{}{"outfile": "out.tgz"},
+		Cron:       cron,
+		At:         at,
+		Owner:      "bob",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddRecurring(c *gc.C) {
+	now := s.Clock.Now().UTC()
+	schedule := s.addSchedule(c, "*/5  * * * *", time.Time{})
+	c.Assert(schedule.Cron, gc.Equals, "*/5 * * * *")
+	c.Assert(schedule.Receiver, gc.Equals, "dummy/0")
+	c.Assert(schedule.Owner, gc.Equals, "bob")
+	c.Assert(schedule.NextRun.After(now), jc.IsTrue)
+	c.Assert(schedule.NextRun.Sub(now) <= 5*time.Minute, jc.IsTrue)
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []state.ActionSchedule{schedule})
+}
+
+func (s *ActionScheduleSuite) TestAddInvalid(c *gc.C) {
+	now := s.Clock.Now()
+	for i, test := range []struct {
+		params state.AddActionScheduleParams
+		err    string
+	}{{
+		params: state.AddActionScheduleParams{Receiver: "dummy/0", Name: "snapshot"},
+		err:    `cannot schedule action "snapshot" on unit "dummy/0": missing cron expression or time not valid`,
+	}, {
+		params: state.AddActionScheduleParams{Receiver: "dummy/0", Name: "snapshot", Cron: "* * * * *", At: now.Add(time.Hour)},
+		err:    `.*: both cron expression and time not valid`,
+	}, {
+		params: state.AddActionScheduleParams{Receiver: "dummy/0", Name: "snapshot", Cron: "* * *"},
+		err:    `.*: cron expression "\* \* \*" \(expected 5 fields, got 3\) not valid`,
+	}, {
+		params: state.AddActionScheduleParams{Receiver: "dummy/0", Name: "snapshot", At: now.Add(-time.Hour)},
+		err:    `.*: time .* in the past not valid`,
+	}, {
+		params: state.AddActionScheduleParams{Receiver: "dummy/1", Name: "snapshot", At: now.Add(time.Hour)},
+		err:    `.*: unit "dummy/1" not found`,
+	}, {
+		params: state.AddActionScheduleParams{Receiver: "dummy/0", Name: "backup", At: now.Add(time.Hour)},
+		err:    `.*: action "backup" not defined on unit "dummy/0"`,
+	}, {
+		params: state.AddActionScheduleParams{
+			Receiver:   "dummy/0",
+			Name:       "snapshot",
+			Parameters: map[string]interface{}{"outfile": 5},
+			At:         now.Add(time.Hour),
+		},
+		err: `.*validation failed.*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddActionSchedule(test.params)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestRemove(c *gc.C) {
+	schedule := s.addSchedule(c, "0 2 * * *", time.Time{})
+	err := s.State.RemoveActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveActionSchedule(schedule.Id)
+	c.Assert(err, gc.ErrorMatches, `action schedule ".*" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRunOnce(c *gc.C) {
+	at := s.Clock.Now().Add(time.Hour)
+	schedule := s.addSchedule(c, "", at)
+
+	due, err := s.State.DueActionSchedules(at.Add(-time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+	_, err = s.State.RunActionSchedule(schedule.Id, at.Add(-time.Minute))
+	c.Assert(err, gc.ErrorMatches, `action schedule ".*" is not due`)
+
+	due, err = s.State.DueActionSchedules(at)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 1)
+	run, err := s.State.RunActionSchedule(schedule.Id, at)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.Error, gc.Equals, "")
+	c.Assert(run.Time, gc.Equals, at.UTC())
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := model.Action(run.ActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Name(), gc.Equals, "snapshot")
+	c.Assert(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.tgz"})
+
+	schedule, err = s.State.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun.IsZero(), jc.IsTrue)
+	c.Assert(schedule.History, jc.DeepEquals, []state.ActionScheduleRun{run})
+	due, err = s.State.DueActionSchedules(at.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRunRecurringSkipsOverlap(c *gc.C) {
+	schedule := s.addSchedule(c, "*/10 * * * *", time.Time{})
+	first, err := s.State.RunActionSchedule(schedule.Id, schedule.NextRun)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(first.ActionId, gc.Not(gc.Equals), "")
+
+	schedule, err = s.State.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun, gc.Equals, first.Time.Add(10*time.Minute))
+
+	// The first action is still pending, so the next run is skipped.
+	second, err := s.State.RunActionSchedule(schedule.Id, schedule.NextRun)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second.ActionId, gc.Equals, "")
+	c.Assert(second.Error, gc.Equals, "skipped: action "+first.ActionId+" is still pending")
+
+	// Once it completes, the next run enqueues another.
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := model.Action(first.ActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err = s.State.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	third, err := s.State.RunActionSchedule(schedule.Id, schedule.NextRun)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(third.ActionId, gc.Not(gc.Equals), "")
+	c.Assert(third.ActionId, gc.Not(gc.Equals), first.ActionId)
+
+	schedule, err = s.State.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.History, jc.DeepEquals, []state.ActionScheduleRun{first, second, third})
+}
+
+func (s *ActionScheduleSuite) TestRunHistoryIsCapped(c *gc.C) {
+	schedule := s.addSchedule(c, "* * * * *", time.Time{})
+	var runs []state.ActionScheduleRun
+	for i := 0; i < 12; i++ {
+		schedule, err := s.State.ActionSchedule(schedule.Id)
+		c.Assert(err, jc.ErrorIsNil)
+		run, err := s.State.RunActionSchedule(schedule.Id, schedule.NextRun)
+		c.Assert(err, jc.ErrorIsNil)
+		runs = append(runs, run)
+	}
+	schedule, err := s.State.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.History, jc.DeepEquals, runs[2:])
+}
@@ -92,6 +92,16 @@ type MachineTemplate struct {
 	// with the machine.
 	Placement string
 
+	// ImageStream holds the image stream from which the image for the
+	// machine's instance will be selected. If empty, the model's
+	// image-stream setting is used. It cannot be set for containers.
+	ImageStream string
+
+	// ImageId holds the id of the image that the machine's instance
+	// will be started with. The image must be found in the image
+	// stream. It cannot be set for containers.
+	ImageId string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -340,6 +350,9 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if template.InstanceId != "" {
 		return nil, nil, errors.New("cannot specify instance id for a new container")
 	}
+	if template.ImageStream != "" || template.ImageId != "" {
+		return nil, nil, errors.New("cannot specify image for a new container")
+	}
 	template, err := st.effectiveMachineTemplate(template, false)
 	if err != nil {
 		return nil, nil, err
@@ -395,6 +408,9 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if template.InstanceId != "" || parentTemplate.InstanceId != "" {
 		return nil, nil, errors.New("cannot specify instance id for a new container")
 	}
+	if template.ImageStream != "" || template.ImageId != "" {
+		return nil, nil, errors.New("cannot specify image for a new container")
+	}
 	seq, err := sequence(st, "machine")
 	if err != nil {
 		return nil, nil, err
@@ -507,6 +523,8 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
+		ImageStream:             template.ImageStream,
+		ImageId:                 template.ImageId,
 	}
 }
 
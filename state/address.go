@@ -145,6 +145,73 @@ func (st *State) APIHostPorts() ([][]network.HostPort, error) {
 	return networkHostsPorts(doc.APIHostPorts), nil
 }
 
+// APIHostPortsForAgents returns the API addresses that agents in the
+// model should use to connect to the controller. When the model has a
+// management space, only the addresses in that space are returned;
+// should no controller have such an address, all of them are returned
+// rather than cut the agents off.
+func (st *State) APIHostPortsForAgents() ([][]network.HostPort, error) {
+	hostPorts, err := st.APIHostPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceName := cfg.ManagementSpace()
+	if spaceName == "" {
+		return hostPorts, nil
+	}
+	space, err := st.Space(spaceName)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get management space")
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ipNets []*net.IPNet
+	for _, subnet := range subnets {
+		if _, ipNet, err := net.ParseCIDR(subnet.CIDR()); err == nil {
+			ipNets = append(ipNets, ipNet)
+		}
+	}
+	inSpace := func(hp network.HostPort) bool {
+		if hp.SpaceName == network.SpaceName(spaceName) {
+			return true
+		}
+		ip := net.ParseIP(hp.Value)
+		if ip == nil {
+			return false
+		}
+		for _, ipNet := range ipNets {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var filtered [][]network.HostPort
+	for _, server := range hostPorts {
+		var serverHostPorts []network.HostPort
+		for _, hp := range server {
+			if inSpace(hp) {
+				serverHostPorts = append(serverHostPorts, hp)
+			}
+		}
+		if len(serverHostPorts) > 0 {
+			filtered = append(filtered, serverHostPorts)
+		}
+	}
+	if len(filtered) == 0 {
+		logger.Warningf("no API addresses in management space %q, using all addresses", spaceName)
+		return hostPorts, nil
+	}
+	return filtered, nil
+}
+
 // address represents the location of a machine, including metadata
 // about what kind of location the address describes.
 //
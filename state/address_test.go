@@ -63,6 +63,49 @@ func (s *ControllerAddressesSuite) TestControllerEnv(c *gc.C) {
 	c.Assert(addresses, jc.SameContents, []string{"10.0.1.2:1234"})
 }
 
+func (s *ControllerAddressesSuite) TestAPIHostPortsForAgents(c *gc.C) {
+	_, err := s.State.AddSpace("mgmt", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+	hostPorts := [][]network.HostPort{
+		network.NewHostPorts(17070, "192.168.2.144", "10.0.1.2"),
+		network.NewHostPorts(17070, "192.168.2.145", "10.0.1.3"),
+	}
+	err = s.State.SetAPIHostPorts(hostPorts)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without a management space, agents may use any address.
+	agentHostPorts, err := s.State.APIHostPortsForAgents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentHostPorts, jc.DeepEquals, hostPorts)
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"management-space": "mgmt"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	agentHostPorts, err = s.State.APIHostPortsForAgents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentHostPorts, jc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.1.2"),
+		network.NewHostPorts(17070, "10.0.1.3"),
+	})
+}
+
+func (s *ControllerAddressesSuite) TestAPIHostPortsForAgentsNoneInSpace(c *gc.C) {
+	_, err := s.State.AddSpace("mgmt", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	hostPorts := [][]network.HostPort{
+		network.NewHostPorts(17070, "192.168.2.144"),
+	}
+	err = s.State.SetAPIHostPorts(hostPorts)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{"management-space": "mgmt"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	agentHostPorts, err := s.State.APIHostPortsForAgents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentHostPorts, jc.DeepEquals, hostPorts)
+}
+
 func (s *ControllerAddressesSuite) TestOtherEnv(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
@@ -0,0 +1,217 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// AddressKind identifies where an address on a machine comes from, for
+// the purpose of preferring one address over another.
+type AddressKind string
+
+const (
+	// AddressKindAny expresses no preference for any kind of address.
+	AddressKindAny AddressKind = ""
+
+	// AddressKindProvider is an address assigned by the provider.
+	AddressKindProvider AddressKind = "provider"
+
+	// AddressKindBridge is an address on a bridge device, other than a
+	// fan bridge.
+	AddressKindBridge AddressKind = "bridge"
+
+	// AddressKindFan is an address on a fan overlay bridge.
+	AddressKindFan AddressKind = "fan"
+)
+
+// fanBridgePrefix is the prefix of the names the fan gives the bridge
+// devices that it creates.
+const fanBridgePrefix = "fan-"
+
+// AddressPolicy governs which of a machine's addresses are published as
+// the private and public addresses of an application's units. The zero
+// policy leaves the choice to the machine's preferred addresses.
+type AddressPolicy struct {
+	// Prefer is the kind of address to prefer.
+	Prefer AddressKind `bson:"prefer,omitempty"`
+
+	// PreferIPv6 is whether to prefer IPv6 addresses over IPv4.
+	PreferIPv6 bool `bson:"prefer-ipv6,omitempty"`
+
+	// Space is the name of the space whose addresses to prefer.
+	Space string `bson:"space,omitempty"`
+}
+
+// IsZero returns whether the policy expresses no preference.
+func (p AddressPolicy) IsZero() bool {
+	return p == AddressPolicy{}
+}
+
+// Validate returns an error if the policy is not valid.
+func (p AddressPolicy) Validate() error {
+	switch p.Prefer {
+	case AddressKindAny, AddressKindProvider, AddressKindBridge, AddressKindFan:
+	default:
+		return errors.NotValidf("address kind %q", p.Prefer)
+	}
+	if p.Space != "" && !names.IsValidSpace(p.Space) {
+		return errors.NotValidf("space name %q", p.Space)
+	}
+	return nil
+}
+
+// score returns how well the address matches the policy; higher scores
+// are preferred. A preferred space outweighs a preferred kind, which in
+// turn outweighs a preference for IPv6.
+func (p AddressPolicy) score(addr network.Address, kind AddressKind) int {
+	score := 0
+	if p.Space != "" && addr.SpaceName == network.SpaceName(p.Space) {
+		score += 4
+	}
+	if p.Prefer != AddressKindAny && kind == p.Prefer {
+		score += 2
+	}
+	if p.PreferIPv6 && addr.Type == network.IPv6Address {
+		score++
+	}
+	return score
+}
+
+// pickAddress selects the address that best matches the policy from
+// the given addresses, using selectAddress to choose among addresses
+// that match it equally well. Addresses that selectAddress deems
+// unsuitable are never picked, however well they match the policy.
+func (p AddressPolicy) pickAddress(
+	addrs []network.Address,
+	kindOf func(network.Address) AddressKind,
+	selectAddress func([]network.Address) (network.Address, bool),
+) (network.Address, bool) {
+	groups := make(map[int][]network.Address)
+	best := 0
+	for _, addr := range addrs {
+		score := p.score(addr, kindOf(addr))
+		groups[score] = append(groups[score], addr)
+		if score > best {
+			best = score
+		}
+	}
+	for score := best; score >= 0; score-- {
+		if addr, ok := selectAddress(groups[score]); ok {
+			return addr, true
+		}
+	}
+	return network.Address{}, false
+}
+
+// policyAddress returns the machine's address that best matches the
+// given policy, public or private as requested. If no address is
+// suitable it returns the machine's preferred address.
+func (m *Machine) policyAddress(policy AddressPolicy, public bool) (network.Address, error) {
+	kindOf, err := m.addressKinds(policy)
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	selectAddress := func(addrs []network.Address) (network.Address, bool) {
+		return network.SelectInternalAddress(addrs, false)
+	}
+	if public {
+		selectAddress = network.SelectPublicAddress
+	}
+	if addr, ok := policy.pickAddress(m.Addresses(), kindOf, selectAddress); ok {
+		return addr, nil
+	}
+	if public {
+		return m.PublicAddress()
+	}
+	return m.PrivateAddress()
+}
+
+// addressKinds returns a function reporting the kind of each of the
+// machine's addresses. Link-layer devices are only consulted when the
+// policy prefers bridge or fan addresses.
+func (m *Machine) addressKinds(policy AddressPolicy) (func(network.Address) AddressKind, error) {
+	providerAddrs := make(map[string]bool)
+	for _, addr := range m.doc.Addresses {
+		providerAddrs[addr.Value] = true
+	}
+	deviceKinds := make(map[string]AddressKind)
+	if policy.Prefer == AddressKindBridge || policy.Prefer == AddressKindFan {
+		devices, err := m.AllLinkLayerDevices()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bridges := make(map[string]AddressKind)
+		for _, dev := range devices {
+			if dev.Type() != BridgeDevice {
+				continue
+			}
+			bridges[dev.Name()] = AddressKindBridge
+			if strings.HasPrefix(dev.Name(), fanBridgePrefix) {
+				bridges[dev.Name()] = AddressKindFan
+			}
+		}
+		addrs, err := m.AllAddresses()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, addr := range addrs {
+			if kind, ok := bridges[addr.DeviceName()]; ok {
+				deviceKinds[addr.Value()] = kind
+			}
+		}
+	}
+	return func(addr network.Address) AddressKind {
+		if kind, ok := deviceKinds[addr.Value]; ok {
+			return kind
+		}
+		if providerAddrs[addr.Value] {
+			return AddressKindProvider
+		}
+		return AddressKindAny
+	}, nil
+}
+
+// AddressPolicy returns the policy governing which addresses are
+// published for the application's units.
+func (a *Application) AddressPolicy() AddressPolicy {
+	if a.doc.AddressPolicy == nil {
+		return AddressPolicy{}
+	}
+	return *a.doc.AddressPolicy
+}
+
+// SetAddressPolicy sets the policy governing which addresses are
+// published for the application's units. The zero policy restores the
+// default choice of address.
+func (a *Application) SetAddressPolicy(policy AddressPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	update := bson.D{{"$unset", bson.D{{"address-policy", nil}}}}
+	if !policy.IsZero() {
+		update = bson.D{{"$set", bson.D{{"address-policy", policy}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errNotAlive), "cannot set address policy of application %q", a)
+	}
+	a.doc.AddressPolicy = nil
+	if !policy.IsZero() {
+		a.doc.AddressPolicy = &policy
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type AddressPolicySuite struct {
+	ConnSuite
+	app     *state.Application
+	unit    *state.Unit
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&AddressPolicySuite{})
+
+func (s *AddressPolicySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.unit, err = s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AddressPolicySuite) TestSetAddressPolicy(c *gc.C) {
+	c.Assert(s.app.AddressPolicy(), jc.DeepEquals, state.AddressPolicy{})
+
+	policy := state.AddressPolicy{
+		Prefer:     state.AddressKindFan,
+		PreferIPv6: true,
+		Space:      "public",
+	}
+	err := s.app.SetAddressPolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AddressPolicy(), jc.DeepEquals, policy)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AddressPolicy(), jc.DeepEquals, policy)
+
+	err = s.app.SetAddressPolicy(state.AddressPolicy{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AddressPolicy(), jc.DeepEquals, state.AddressPolicy{})
+}
+
+func (s *AddressPolicySuite) TestSetAddressPolicyInvalid(c *gc.C) {
+	err := s.app.SetAddressPolicy(state.AddressPolicy{Prefer: "carrier-pigeon"})
+	c.Assert(err, gc.ErrorMatches, `address kind "carrier-pigeon" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.app.SetAddressPolicy(state.AddressPolicy{Space: "Not Valid"})
+	c.Assert(err, gc.ErrorMatches, `space name "Not Valid" not valid`)
+}
+
+func (s *AddressPolicySuite) TestSetAddressPolicyNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAddressPolicy(state.AddressPolicy{PreferIPv6: true})
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
+func (s *AddressPolicySuite) TestNoPolicyUsesMachineAddresses(c *gc.C) {
+	err := s.machine.SetProviderAddresses(
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := s.unit.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+}
+
+func (s *AddressPolicySuite) TestPreferIPv6(c *gc.C) {
+	err := s.machine.SetProviderAddresses(
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAddressPolicy(state.AddressPolicy{PreferIPv6: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := s.unit.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "fc00::1")
+	addr, err = s.unit.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::1")
+}
+
+func (s *AddressPolicySuite) TestPreferSpace(c *gc.C) {
+	err := s.machine.SetProviderAddresses(
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewAddressOnSpace("storage", "10.1.0.1"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAddressPolicy(state.AddressPolicy{Space: "storage"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := s.unit.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.1.0.1")
+}
+
+func (s *AddressPolicySuite) TestPreferProvider(c *gc.C) {
+	err := s.machine.SetMachineAddresses(network.NewScopedAddress("10.0.0.2", network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetProviderAddresses(network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAddressPolicy(state.AddressPolicy{Prefer: state.AddressKindProvider})
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := s.unit.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+}
+
+func (s *AddressPolicySuite) TestPreferFan(c *gc.C) {
+	for _, cidr := range []string{"10.0.0.0/24", "252.0.0.0/8"} {
+		_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: cidr})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.machine.SetLinkLayerDevices(
+		state.LinkLayerDeviceArgs{Name: "eth0", Type: state.EthernetDevice},
+		state.LinkLayerDeviceArgs{Name: "fan-252", Type: state.BridgeDevice},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetDevicesAddresses(
+		state.LinkLayerDeviceAddress{DeviceName: "eth0", ConfigMethod: state.StaticAddress, CIDRAddress: "10.0.0.1/24"},
+		state.LinkLayerDeviceAddress{DeviceName: "fan-252", ConfigMethod: state.StaticAddress, CIDRAddress: "252.0.1.1/8"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetMachineAddresses(
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("252.0.1.1", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := s.unit.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+
+	err = s.app.SetAddressPolicy(state.AddressPolicy{Prefer: state.AddressKindFan})
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = s.unit.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "252.0.1.1")
+}
+
+func (s *AddressPolicySuite) TestUnsuitablePreferredAddressNotUsed(c *gc.C) {
+	// A preferred address is not published as the public address
+	// unless it is suitable as one.
+	err := s.machine.SetProviderAddresses(
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("fc00::1", network.ScopeMachineLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAddressPolicy(state.AddressPolicy{PreferIPv6: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := s.unit.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+}
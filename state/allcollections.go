@@ -104,6 +104,11 @@ func allCollections() collectionSchema {
 		// This collection holds Juju GUI current version and other settings.
 		guisettingsC: {global: true},
 
+		// This collection records the charm archives held in the
+		// controller's charm mirror, so that models on the controller
+		// need not download them from the charm store again.
+		charmMirrorC: {global: true},
+
 		// This collection holds model information; in particular its
 		// Life and its UUID.
 		modelsC: {global: true},
@@ -261,6 +266,10 @@ func allCollections() collectionSchema {
 		},
 		minUnitsC: {},
 
+		// This collection records the cleanup steps skipped when an
+		// application's units are forcibly removed.
+		applicationRemovalsC: {},
+
 		// This collection holds documents that indicate units which are queued
 		// to be assigned to machines. It is used exclusively by the
 		// AssignUnitWorker.
@@ -268,7 +277,16 @@ func allCollections() collectionSchema {
 
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC: {},
-		refcountsC:   {},
+
+		// cloudContainersC records the containers hosting units in
+		// CAAS models.
+		cloudContainersC: {},
+
+		// applicationTrustC records the applications that have been
+		// granted access to the model's cloud credential.
+		applicationTrustC: {},
+
+		refcountsC: {},
 		relationsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "endpoints.relationname"},
@@ -336,6 +354,14 @@ func allCollections() collectionSchema {
 		},
 		volumeAttachmentsC: {},
 
+		// volumeSnapshotsC records snapshots of volumes taken by the
+		// storage provider, from which new storage may be restored.
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "storageid"},
+			}},
+		},
+
 		// -----
 
 		providerIDsC:          {},
@@ -356,6 +382,21 @@ func allCollections() collectionSchema {
 			}},
 		},
 		actionNotificationsC: {},
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "next-run"},
+			}},
+		},
+
+		// -----
+
+		// This collection holds the locks charms use to serialize
+		// operations across the units of an application.
+		charmLocksC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "holder"},
+			}},
+		},
 
 		// -----
 
@@ -390,6 +431,23 @@ func allCollections() collectionSchema {
 		// unit relation settings, model config, etc etc etc.
 		settingsC: {},
 
+		// This collection records the changes made to each model's
+		// config, so they can be reviewed and rolled back.
+		modelConfigHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "revision"},
+			}},
+		},
+
+		// This collection records the changes applied to each
+		// model, such as deployments and relations added, as an
+		// operational timeline.
+		changeLogC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "seq"},
+			}},
+		},
+
 		constraintsC:        {},
 		storageConstraintsC: {},
 		statusesC: {
@@ -401,6 +459,9 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "globalkey", "updated"},
+			}, {
+				// used for time range and paged history queries
+				Key: []string{"globalkey", "updated"},
 			}, {
 				// used for migration and model-specific pruning
 				Key: []string{"model-uuid", "-updated", "-_id"},
@@ -465,19 +526,25 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
+	actionSchedulesC         = "actionschedules"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
+	applicationRemovalsC     = "applicationRemovals"
+	applicationTrustC        = "applicationtrust"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
 	auditingC                = "audit.log"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
+	charmLocksC              = "charmlocks"
+	charmMirrorC             = "charmMirror"
 	charmsC                  = "charms"
 	cleanupsC                = "cleanups"
 	cloudimagemetadataC      = "cloudimagemetadata"
 	cloudsC                  = "clouds"
 	cloudCredentialsC        = "cloudCredentials"
+	cloudContainersC         = "cloudcontainers"
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
@@ -499,6 +566,8 @@ const (
 	migrationsC              = "migrations"
 	migrationsMinionSyncC    = "migrations.minionsync"
 	migrationsStatusC        = "migrations.status"
+	modelConfigHistoryC      = "modelConfigHistory"
+	changeLogC               = "changelog"
 	modelUserLastConnectionC = "modelUserLastConnection"
 	modelUsersC              = "modelusers"
 	modelsC                  = "models"
@@ -536,6 +605,7 @@ const (
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
+	volumeSnapshotsC         = "volumesnapshots"
 	volumesC                 = "volumes"
 	// "resources" (see resource/persistence/mongo.go)
 
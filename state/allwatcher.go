@@ -94,6 +94,16 @@ func makeAllWatcherCollectionInfo(collNames ...string) map[string]allWatcherStat
 			collection.docType = reflect.TypeOf(backingRemoteApplication{})
 		case applicationOffersC:
 			collection.docType = reflect.TypeOf(backingApplicationOffer{})
+		case volumesC:
+			collection.docType = reflect.TypeOf(backingVolume{})
+		case volumeAttachmentsC:
+			collection.docType = reflect.TypeOf(backingVolumeAttachment{})
+			collection.subsidiary = true
+		case filesystemsC:
+			collection.docType = reflect.TypeOf(backingFilesystem{})
+		case filesystemAttachmentsC:
+			collection.docType = reflect.TypeOf(backingFilesystemAttachment{})
+			collection.subsidiary = true
 		default:
 			panic(errors.Errorf("unknown collection %q", collName))
 		}
@@ -778,6 +788,235 @@ func (a *backingBlock) mongoId() string {
 	return a.DocID
 }
 
+type backingVolume volumeDoc
+
+func (v *backingVolume) updated(st *State, store *multiwatcherStore, id string) error {
+	info := &multiwatcher.VolumeInfo{
+		ModelUUID:   st.ModelUUID(),
+		Id:          v.Name,
+		StorageId:   v.StorageId,
+		Provisioned: v.Info != nil,
+		Life:        multiwatcher.Life(v.Life.String()),
+	}
+	if v.Info != nil {
+		info.Pool = v.Info.Pool
+		info.Size = v.Info.Size
+	} else if v.Params != nil {
+		info.Pool = v.Params.Pool
+		info.Size = v.Params.Size
+	}
+	if oldInfo := store.Get(info.EntityId()); oldInfo == nil {
+		// We're adding the entry for the first time,
+		// so fetch the associated volume status.
+		volumeStatus, err := getStatus(st.db(), volumeGlobalKey(v.Name), "volume")
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "retrieving status for volume %q", v.Name)
+		}
+		info.Status = multiwatcher.NewStatusInfo(volumeStatus, nil)
+	} else {
+		info.Status = oldInfo.(*multiwatcher.VolumeInfo).Status
+	}
+	attachments, err := volumeMachineAttachments(st, v.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info.Attachments = attachments
+	store.Update(info)
+	return nil
+}
+
+func (v *backingVolume) removed(store *multiwatcherStore, modelUUID, id string, _ *State) error {
+	store.Remove(multiwatcher.EntityId{
+		Kind:      "volume",
+		ModelUUID: modelUUID,
+		Id:        id,
+	})
+	return nil
+}
+
+func (v *backingVolume) mongoId() string {
+	return v.DocID
+}
+
+// volumeMachineAttachments returns the attachments of the volume with
+// the given name, keyed by machine ID.
+func volumeMachineAttachments(st *State, volumeName string) (map[string]multiwatcher.MachineAttachmentInfo, error) {
+	coll, closer := st.db().GetCollection(volumeAttachmentsC)
+	defer closer()
+	var docs []volumeAttachmentDoc
+	if err := coll.Find(bson.D{{"volumeid", volumeName}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "retrieving attachments for volume %q", volumeName)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	attachments := make(map[string]multiwatcher.MachineAttachmentInfo)
+	for _, doc := range docs {
+		attachments[doc.Machine] = multiwatcher.MachineAttachmentInfo{
+			Provisioned: doc.Info != nil,
+			Life:        multiwatcher.Life(doc.Life.String()),
+		}
+	}
+	return attachments, nil
+}
+
+// backingVolumeAttachment is a subsidiary of backingVolume, recording
+// the attachments of a volume.
+type backingVolumeAttachment volumeAttachmentDoc
+
+func (a *backingVolumeAttachment) updated(st *State, store *multiwatcherStore, id string) error {
+	return updateVolumeAttachments(st, store, a.Volume)
+}
+
+func (a *backingVolumeAttachment) removed(store *multiwatcherStore, modelUUID, id string, st *State) error {
+	if st == nil {
+		return nil
+	}
+	_, volumeTag, err := ParseVolumeAttachmentId(id)
+	if err != nil {
+		return nil
+	}
+	return updateVolumeAttachments(st, store, volumeTag.Id())
+}
+
+func (a *backingVolumeAttachment) mongoId() string {
+	return a.DocID
+}
+
+// updateVolumeAttachments updates the attachments of the volume with
+// the given name, if the volume is known.
+func updateVolumeAttachments(st *State, store *multiwatcherStore, volumeName string) error {
+	id := (&multiwatcher.VolumeInfo{ModelUUID: st.ModelUUID(), Id: volumeName}).EntityId()
+	info, ok := store.Get(id).(*multiwatcher.VolumeInfo)
+	if !ok {
+		// The volume info doesn't exist. Ignore the attachment until it does.
+		return nil
+	}
+	attachments, err := volumeMachineAttachments(st, volumeName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newInfo := *info
+	newInfo.Attachments = attachments
+	store.Update(&newInfo)
+	return nil
+}
+
+type backingFilesystem filesystemDoc
+
+func (f *backingFilesystem) updated(st *State, store *multiwatcherStore, id string) error {
+	info := &multiwatcher.FilesystemInfo{
+		ModelUUID:   st.ModelUUID(),
+		Id:          f.FilesystemId,
+		StorageId:   f.StorageId,
+		VolumeId:    f.VolumeId,
+		Provisioned: f.Info != nil,
+		Life:        multiwatcher.Life(f.Life.String()),
+	}
+	if f.Info != nil {
+		info.Pool = f.Info.Pool
+		info.Size = f.Info.Size
+	} else if f.Params != nil {
+		info.Pool = f.Params.Pool
+		info.Size = f.Params.Size
+	}
+	if oldInfo := store.Get(info.EntityId()); oldInfo == nil {
+		// We're adding the entry for the first time,
+		// so fetch the associated filesystem status.
+		filesystemStatus, err := getStatus(st.db(), filesystemGlobalKey(f.FilesystemId), "filesystem")
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "retrieving status for filesystem %q", f.FilesystemId)
+		}
+		info.Status = multiwatcher.NewStatusInfo(filesystemStatus, nil)
+	} else {
+		info.Status = oldInfo.(*multiwatcher.FilesystemInfo).Status
+	}
+	attachments, err := filesystemMachineAttachments(st, f.FilesystemId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info.Attachments = attachments
+	store.Update(info)
+	return nil
+}
+
+func (f *backingFilesystem) removed(store *multiwatcherStore, modelUUID, id string, _ *State) error {
+	store.Remove(multiwatcher.EntityId{
+		Kind:      "filesystem",
+		ModelUUID: modelUUID,
+		Id:        id,
+	})
+	return nil
+}
+
+func (f *backingFilesystem) mongoId() string {
+	return f.DocID
+}
+
+// filesystemMachineAttachments returns the attachments of the
+// filesystem with the given ID, keyed by machine ID.
+func filesystemMachineAttachments(st *State, filesystemId string) (map[string]multiwatcher.MachineAttachmentInfo, error) {
+	coll, closer := st.db().GetCollection(filesystemAttachmentsC)
+	defer closer()
+	var docs []filesystemAttachmentDoc
+	if err := coll.Find(bson.D{{"filesystemid", filesystemId}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "retrieving attachments for filesystem %q", filesystemId)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	attachments := make(map[string]multiwatcher.MachineAttachmentInfo)
+	for _, doc := range docs {
+		attachments[doc.Machine] = multiwatcher.MachineAttachmentInfo{
+			Provisioned: doc.Info != nil,
+			Life:        multiwatcher.Life(doc.Life.String()),
+		}
+	}
+	return attachments, nil
+}
+
+// backingFilesystemAttachment is a subsidiary of backingFilesystem,
+// recording the attachments of a filesystem.
+type backingFilesystemAttachment filesystemAttachmentDoc
+
+func (a *backingFilesystemAttachment) updated(st *State, store *multiwatcherStore, id string) error {
+	return updateFilesystemAttachments(st, store, a.Filesystem)
+}
+
+func (a *backingFilesystemAttachment) removed(store *multiwatcherStore, modelUUID, id string, st *State) error {
+	if st == nil {
+		return nil
+	}
+	_, filesystemTag, err := ParseFilesystemAttachmentId(id)
+	if err != nil {
+		return nil
+	}
+	return updateFilesystemAttachments(st, store, filesystemTag.Id())
+}
+
+func (a *backingFilesystemAttachment) mongoId() string {
+	return a.DocID
+}
+
+// updateFilesystemAttachments updates the attachments of the
+// filesystem with the given ID, if the filesystem is known.
+func updateFilesystemAttachments(st *State, store *multiwatcherStore, filesystemId string) error {
+	id := (&multiwatcher.FilesystemInfo{ModelUUID: st.ModelUUID(), Id: filesystemId}).EntityId()
+	info, ok := store.Get(id).(*multiwatcher.FilesystemInfo)
+	if !ok {
+		// The filesystem info doesn't exist. Ignore the attachment until it does.
+		return nil
+	}
+	attachments, err := filesystemMachineAttachments(st, filesystemId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newInfo := *info
+	newInfo.Attachments = attachments
+	store.Update(&newInfo)
+	return nil
+}
+
 type backingStatus statusDoc
 
 func (s *backingStatus) toStatusInfo() multiwatcher.StatusInfo {
@@ -823,6 +1062,14 @@ func (s *backingStatus) updated(st *State, store *multiwatcherStore, id string)
 		newInfo := *info
 		newInfo.Status = s.toStatusInfo()
 		info0 = &newInfo
+	case *multiwatcher.VolumeInfo:
+		newInfo := *info
+		newInfo.Status = s.toStatusInfo()
+		info0 = &newInfo
+	case *multiwatcher.FilesystemInfo:
+		newInfo := *info
+		newInfo.Status = s.toStatusInfo()
+		info0 = &newInfo
 	case *multiwatcher.MachineInfo:
 		newInfo := *info
 		// lets dissambiguate between juju machine agent and provider instance statuses.
@@ -1186,6 +1433,16 @@ func backingEntityIdForGlobalKey(modelUUID, key string) (multiwatcher.EntityId,
 			ModelUUID: modelUUID,
 			Name:      id,
 		}).EntityId(), true
+	case 'v':
+		return (&multiwatcher.VolumeInfo{
+			ModelUUID: modelUUID,
+			Id:        id,
+		}).EntityId(), true
+	case 'f':
+		return (&multiwatcher.FilesystemInfo{
+			ModelUUID: modelUUID,
+			Id:        id,
+		}).EntityId(), true
 	default:
 		return multiwatcher.EntityId{}, false
 	}
@@ -1226,6 +1483,10 @@ func newAllWatcherStateBacking(st *State, params WatchParams) Backing {
 		actionsC,
 		blocksC,
 		remoteApplicationsC,
+		volumesC,
+		volumeAttachmentsC,
+		filesystemsC,
+		filesystemAttachmentsC,
 	}
 	if params.IncludeOffers {
 		collectionNames = append(collectionNames, applicationOffersC)
@@ -545,6 +545,14 @@ func substNilSinceTimeForEntities(c *gc.C, entities []multiwatcher.EntityInfo) {
 			substNilSinceTimeForStatus(c, &machineInfo.AgentStatus)
 			substNilSinceTimeForStatus(c, &machineInfo.InstanceStatus)
 			entities[i] = &machineInfo
+		case *multiwatcher.VolumeInfo:
+			volumeInfo := *e // must copy because this entity came out of the multiwatcher cache.
+			substNilSinceTimeForStatus(c, &volumeInfo.Status)
+			entities[i] = &volumeInfo
+		case *multiwatcher.FilesystemInfo:
+			filesystemInfo := *e // must copy because this entity came out of the multiwatcher cache.
+			substNilSinceTimeForStatus(c, &filesystemInfo.Status)
+			entities[i] = &filesystemInfo
 		}
 	}
 }
@@ -744,6 +752,112 @@ func (s *allWatcherStateSuite) TestChangeBlocks(c *gc.C) {
 	s.performChangeTestCases(c, changeTestFuncs)
 }
 
+// addTestingVolume adds a machine with a model-scoped volume attached,
+// and returns the volume's tag.
+func addTestingVolume(c *gc.C, st *State) names.VolumeTag {
+	_, err := st.AddOneMachine(MachineTemplate{
+		Series: "quantal",
+		Jobs:   []MachineJob{JobHostUnits},
+		Volumes: []MachineVolumeParams{{
+			Volume: VolumeParams{Pool: "modelscoped", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return names.NewVolumeTag("0")
+}
+
+func (s *allWatcherStateSuite) TestChangeVolumes(c *gc.C) {
+	pendingVolume := func(st *State) *multiwatcher.VolumeInfo {
+		return &multiwatcher.VolumeInfo{
+			ModelUUID: st.ModelUUID(),
+			Id:        "0",
+			Pool:      "modelscoped",
+			Size:      1024,
+			Life:      multiwatcher.Life("alive"),
+			Status: multiwatcher.StatusInfo{
+				Current: status.Pending,
+				Data:    map[string]interface{}{},
+			},
+			Attachments: map[string]multiwatcher.MachineAttachmentInfo{
+				"0": {Life: multiwatcher.Life("alive")},
+			},
+		}
+	}
+	changeTestFuncs := []changeTestFunc{
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about:  "no volume in state, no volume in store -> do nothing",
+				change: watcher.Change{C: volumesC, Id: st.docID("0")},
+			}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "volume is removed if it's not in backing",
+				initialContents: []multiwatcher.EntityInfo{&multiwatcher.VolumeInfo{
+					ModelUUID: st.ModelUUID(),
+					Id:        "0",
+				}},
+				change: watcher.Change{C: volumesC, Id: st.docID("0")},
+			}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			addTestingVolume(c, st)
+			return changeTestCase{
+				about:          "volume is added with its status and attachments if it's in backing but not in store",
+				change:         watcher.Change{C: volumesC, Id: st.docID("0")},
+				expectContents: []multiwatcher.EntityInfo{pendingVolume(st)},
+			}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			volumeTag := addTestingVolume(c, st)
+			im, err := st.IAASModel()
+			c.Assert(err, jc.ErrorIsNil)
+			err = im.SetVolumeInfo(volumeTag, VolumeInfo{VolumeId: "vol-0", Size: 1024})
+			c.Assert(err, jc.ErrorIsNil)
+			err = im.setVolumeAttachmentInfo(names.NewMachineTag("0"), volumeTag, VolumeAttachmentInfo{DeviceName: "sdb"})
+			c.Assert(err, jc.ErrorIsNil)
+			attached := pendingVolume(st)
+			attached.Attachments["0"] = multiwatcher.MachineAttachmentInfo{
+				Provisioned: true,
+				Life:        multiwatcher.Life("alive"),
+			}
+			return changeTestCase{
+				about:           "volume attachment change updates the volume in store",
+				initialContents: []multiwatcher.EntityInfo{pendingVolume(st)},
+				change:          watcher.Change{C: volumeAttachmentsC, Id: st.docID("0:0")},
+				expectContents:  []multiwatcher.EntityInfo{attached},
+			}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			detached := pendingVolume(st)
+			detached.Attachments = nil
+			return changeTestCase{
+				about:           "volume attachment removal updates the volume in store",
+				initialContents: []multiwatcher.EntityInfo{pendingVolume(st)},
+				change:          watcher.Change{C: volumeAttachmentsC, Id: st.docID("0:0")},
+				expectContents:  []multiwatcher.EntityInfo{detached},
+			}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			addTestingVolume(c, st)
+			im, err := st.IAASModel()
+			c.Assert(err, jc.ErrorIsNil)
+			now := testing.ZeroTime()
+			err = im.SetVolumeStatus(names.NewVolumeTag("0"), status.Attaching, "", nil, &now)
+			c.Assert(err, jc.ErrorIsNil)
+			attaching := pendingVolume(st)
+			attaching.Status.Current = status.Attaching
+			return changeTestCase{
+				about:           "volume status is changed if the volume exists in the store",
+				initialContents: []multiwatcher.EntityInfo{pendingVolume(st)},
+				change:          watcher.Change{C: statusesC, Id: st.docID("v#0")},
+				expectContents:  []multiwatcher.EntityInfo{attaching},
+			}
+		},
+	}
+	s.performChangeTestCases(c, changeTestFuncs)
+}
+
 func (s *allWatcherStateSuite) TestClosingPorts(c *gc.C) {
 	// Init the test model.
 	wordpress := AddTestingApplication(c, s.state, "wordpress", AddTestingCharm(c, s.state, "wordpress"))
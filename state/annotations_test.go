@@ -11,8 +11,10 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
 )
 
 type AnnotationsSuite struct {
@@ -134,6 +136,19 @@ func (s *AnnotationsSuite) TestSetAnnotationsConcurrently(c *gc.C) {
 	assertAnnotation(c, s.Model, s.testEntity, key, last)
 }
 
+func (s *AnnotationsSuite) TestWatchAnnotations(c *gc.C) {
+	w := s.State.WatchAnnotations()
+	defer workertest.CleanKill(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange() // Initial event.
+
+	s.assertSetAnnotation(c, "owner", "bob")
+	wc.AssertOneChange()
+
+	s.assertSetAnnotation(c, "owner", "")
+	wc.AssertOneChange()
+}
+
 type AnnotationsEnvSuite struct {
 	ConnSuite
 }
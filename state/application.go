@@ -6,14 +6,17 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
@@ -34,22 +37,29 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string     `bson:"_id"`
-	Name                 string     `bson:"name"`
-	ModelUUID            string     `bson:"model-uuid"`
-	Series               string     `bson:"series"`
-	Subordinate          bool       `bson:"subordinate"`
-	CharmURL             *charm.URL `bson:"charmurl"`
-	Channel              string     `bson:"cs-channel"`
-	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
-	ForceCharm           bool       `bson:"forcecharm"`
-	Life                 Life       `bson:"life"`
-	UnitCount            int        `bson:"unitcount"`
-	RelationCount        int        `bson:"relationcount"`
-	Exposed              bool       `bson:"exposed"`
-	MinUnits             int        `bson:"minunits"`
-	TxnRevno             int64      `bson:"txn-revno"`
-	MetricCredentials    []byte     `bson:"metric-credentials"`
+	DocID                string              `bson:"_id"`
+	Name                 string              `bson:"name"`
+	ModelUUID            string              `bson:"model-uuid"`
+	Series               string              `bson:"series"`
+	Subordinate          bool                `bson:"subordinate"`
+	CharmURL             *charm.URL          `bson:"charmurl"`
+	Channel              string              `bson:"cs-channel"`
+	CharmModifiedVersion int                 `bson:"charmmodifiedversion"`
+	ForceCharm           bool                `bson:"forcecharm"`
+	Life                 Life                `bson:"life"`
+	UnitCount            int                 `bson:"unitcount"`
+	RelationCount        int                 `bson:"relationcount"`
+	Exposed              bool                `bson:"exposed"`
+	ExposedCIDRs         []string            `bson:"exposed-cidrs,omitempty"`
+	MinUnits             int                 `bson:"minunits"`
+	TxnRevno             int64               `bson:"txn-revno"`
+	MetricCredentials    []byte              `bson:"metric-credentials"`
+	Description          string              `bson:"description,omitempty"`
+	AddressPolicy        *AddressPolicy      `bson:"address-policy,omitempty"`
+	FloatingIP           string              `bson:"floating-ip,omitempty"`
+	FloatingIPUnit       string              `bson:"floating-ip-unit,omitempty"`
+	ConfigReadyHash      string              `bson:"config-ready-hash,omitempty"`
+	Logging              *ApplicationLogging `bson:"logging,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -156,6 +166,12 @@ type DestroyApplicationOperation struct {
 	// to units of the application are destroyed. If this is false,
 	// then detachable storage will be detached and left in the model.
 	DestroyStorage bool
+
+	// HookTimeout, if non-zero, bounds the time the application's
+	// units may take to run their end-of-life hooks. Units that remain
+	// after the timeout are forcibly removed, and the cleanup steps
+	// skipped are recorded in the application's removal report.
+	HookTimeout time.Duration
 }
 
 // Build is part of the ModelOperation interface.
@@ -174,6 +190,15 @@ func (op *DestroyApplicationOperation) Build(attempt int) ([]txn.Op, error) {
 	case errAlreadyDying:
 		return nil, jujutxn.ErrNoOperations
 	case nil:
+		if op.HookTimeout > 0 && op.app.doc.UnitCount > 0 {
+			deadline := op.app.st.clock().Now().Add(op.HookTimeout)
+			ops = append(ops, newCleanupOp(
+				cleanupForceDestroyedApplication,
+				op.app.doc.Name,
+				deadline.UnixNano(),
+				int64(op.HookTimeout),
+			))
+		}
 		return ops, nil
 	}
 	return nil, err
@@ -327,8 +352,10 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	ops = append(ops,
 		removeEndpointBindingsOp(globalKey),
 		removeConstraintsOp(globalKey),
+		removeApplicationTrustOp(globalKey),
 		annotationRemoveOp(a.st, globalKey),
 		removeLeadershipSettingsOp(name),
+		removeApplicationHookEnvironmentOp(name),
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
 	)
@@ -342,29 +369,90 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedCIDRs returns the source CIDRs from which the open ports of an
+// exposed application may be accessed. An exposed application with no
+// CIDRs may be accessed from anywhere. See SetExposedToCIDRs.
+func (a *Application) ExposedCIDRs() []string {
+	if len(a.doc.ExposedCIDRs) == 0 {
+		return nil
+	}
+	cidrs := make([]string, len(a.doc.ExposedCIDRs))
+	copy(cidrs, a.doc.ExposedCIDRs)
+	return cidrs
+}
+
+// SetExposed marks the application as exposed, to access from anywhere.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
-	return a.setExposed(true)
+	return a.setExposed(true, nil)
+}
+
+// SetExposedToCIDRs marks the application as exposed, to access only
+// from the given source CIDRs. See SetExposed and ExposedCIDRs.
+func (a *Application) SetExposedToCIDRs(cidrs []string) error {
+	if len(cidrs) == 0 {
+		return errors.NotValidf("empty exposed CIDRs")
+	}
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return a.setExposed(true, cidrs)
 }
 
 // ClearExposed removes the exposed flag from the application.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false)
+	return a.setExposed(false, nil)
 }
 
-func (a *Application) setExposed(exposed bool) (err error) {
+func (a *Application) setExposed(exposed bool, cidrs []string) (err error) {
+	update := bson.D{
+		{"$set", bson.D{{"exposed", exposed}}},
+		{"$unset", bson.D{{"exposed-cidrs", nil}}},
+	}
+	if len(cidrs) > 0 {
+		update = bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-cidrs", cidrs},
+		}}}
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedCIDRs = cidrs
+	return nil
+}
+
+// Description returns the application's free-text description.
+func (a *Application) Description() string {
+	return a.doc.Description
+}
+
+// SetDescription sets the application's free-text description. An
+// empty description removes it.
+func (a *Application) SetDescription(description string) error {
+	if err := validateDescription(description); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"description", description}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errNotAlive), "cannot set description of application %q", a)
+	}
+	a.doc.Description = description
 	return nil
 }
 
@@ -599,10 +687,67 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 				name,
 			)
 		}
+		if newStorageMeta.MinimumSize > oldStorageMeta.MinimumSize {
+			owners := []names.Tag{a.Tag()}
+			if !newStorageMeta.Shared {
+				owners = owners[:0]
+				for _, u := range units {
+					owners = append(owners, u.Tag())
+				}
+			}
+			if err := im.checkStorageMinimumSize(owners, name, newStorageMeta.MinimumSize); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 	}
 	return ops, nil
 }
 
+// checkResourceUpgrade checks that the application's resources satisfy
+// the resources declared by the new charm: every resource must either
+// exist already with the declared type, or be provided in resourceIDs.
+func (a *Application) checkResourceUpgrade(newMeta *charm.Meta, resourceIDs map[string]string) error {
+	for name := range resourceIDs {
+		if _, ok := newMeta.Resources[name]; !ok {
+			return errors.Errorf("resource %q not declared by the new charm", name)
+		}
+	}
+	if len(newMeta.Resources) == 0 {
+		return nil
+	}
+	resources, err := a.st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appResources, err := resources.ListResources(a.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	existing := make(map[string]charmresource.Type)
+	for _, res := range appResources.Resources {
+		existing[res.Name] = res.Type
+	}
+	for name, meta := range newMeta.Resources {
+		if resourceIDs[name] != "" {
+			continue
+		}
+		resType, ok := existing[name]
+		if !ok {
+			return errors.Errorf(
+				"resource %q required by the new charm not provided (specify it with --resource %s=<file>)",
+				name, name,
+			)
+		}
+		if resType != meta.Type {
+			return errors.Errorf(
+				"existing resource %q type changed from %q to %q (specify a new %s with --resource %s=<file>)",
+				name, resType, meta.Type, meta.Type, name,
+			)
+		}
+	}
+	return nil
+}
+
 // changeCharmOps returns the operations necessary to set a application's
 // charm URL to a new value.
 func (a *Application) changeCharmOps(
@@ -686,6 +831,9 @@ func (a *Application) changeCharmOps(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := a.checkResourceUpgrade(ch.Meta(), resourceIDs); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Create or replace storage constraints. We take the existing storage
 	// constraints, remove any keys that are no longer referenced by the
@@ -1263,10 +1411,104 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		return "", nil, err
 	}
 
-	im, err := a.st.IAASModel()
+	model, err := a.st.Model()
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
+	var storageOps []txn.Op
+	var numStorageAttachments int
+	if model.Type() == ModelTypeCAAS {
+		// Units in CAAS models run in containers managed by the
+		// model's broker, which do not yet support storage.
+		if len(charm.Meta().Storage) > 0 || len(args.attachStorage) > 0 {
+			return "", nil, errors.NotSupportedf("storage in CAAS models")
+		}
+	} else {
+		storageOps, numStorageAttachments, err = a.addUnitStorageOps(args, unitTag, charm)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+	}
+
+	docID := a.st.docID(name)
+	globalKey := unitGlobalKey(name)
+	agentGlobalKey := unitAgentGlobalKey(name)
+	udoc := &unitDoc{
+		DocID:                  docID,
+		Name:                   name,
+		Application:            a.doc.Name,
+		Series:                 a.doc.Series,
+		Life:                   Alive,
+		Principal:              args.principalName,
+		StorageAttachmentCount: numStorageAttachments,
+	}
+	now := a.st.clock().Now()
+	agentStatusDoc := statusDoc{
+		Status:  status.Allocating,
+		Updated: now.UnixNano(),
+	}
+	waitMessage := status.MessageWaitForMachine
+	if model.Type() == ModelTypeCAAS {
+		waitMessage = status.MessageWaitForContainer
+	}
+	unitStatusDoc := statusDoc{
+		Status:     status.Waiting,
+		StatusInfo: waitMessage,
+		Updated:    now.UnixNano(),
+	}
+	workloadVersionDoc := statusDoc{
+		Status:  status.Unknown,
+		Updated: now.UnixNano(),
+	}
+
+	ops, err := addUnitOps(a.st, addUnitOpsArgs{
+		unitDoc:            udoc,
+		agentStatusDoc:     agentStatusDoc,
+		workloadStatusDoc:  unitStatusDoc,
+		workloadVersionDoc: workloadVersionDoc,
+		meterStatusDoc:     &meterStatusDoc{Code: MeterNotSet.String()},
+	})
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	ops = append(ops, storageOps...)
+
+	if a.doc.Subordinate {
+		ops = append(ops, txn.Op{
+			C:  unitsC,
+			Id: a.st.docID(args.principalName),
+			Assert: append(isAliveDoc, bson.DocElem{
+				"subordinates", bson.D{{"$not", bson.RegEx{Pattern: "^" + a.doc.Name + "/"}}},
+			}),
+			Update: bson.D{{"$addToSet", bson.D{{"subordinates", name}}}},
+		})
+	} else {
+		ops = append(ops, createConstraintsOp(agentGlobalKey, args.cons))
+	}
+
+	// At the last moment we still have the statusDocs in scope, set the initial
+	// history entries. This is risky, and may lead to extra entries, but that's
+	// an intrinsic problem with mixing txn and non-txn ops -- we can't sync
+	// them cleanly.
+	probablyUpdateStatusHistory(a.st.db(), globalKey, unitStatusDoc)
+	probablyUpdateStatusHistory(a.st.db(), agentGlobalKey, agentStatusDoc)
+	probablyUpdateStatusHistory(a.st.db(), globalWorkloadVersionKey(name), workloadVersionDoc)
+	return name, ops, nil
+}
+
+// addUnitStorageOps returns the operations required to create and attach
+// the storage for a new unit of an IAAS application, along with the
+// number of storage attachments that will be made.
+func (a *Application) addUnitStorageOps(
+	args applicationAddUnitOpsArgs,
+	unitTag names.UnitTag,
+	charm *Charm,
+) ([]txn.Op, int, error) {
+	im, err := a.st.IAASModel()
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
 
 	// Reduce the count of new storage created for each existing storage
 	// being attached.
@@ -1274,7 +1516,7 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	for _, tag := range args.attachStorage {
 		storageName, err := names.StorageName(tag.Id())
 		if err != nil {
-			return "", nil, errors.Trace(err)
+			return nil, 0, errors.Trace(err)
 		}
 		if cons, ok := args.storageCons[storageName]; ok && cons.Count > 0 {
 			if storageCons == nil {
@@ -1301,7 +1543,7 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	if a.doc.Subordinate {
 		pu, err := a.st.Unit(args.principalName)
 		if err != nil {
-			return "", nil, errors.Trace(err)
+			return nil, 0, errors.Trace(err)
 		}
 		machineAssignable = pu
 	}
@@ -1314,12 +1556,12 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		machineAssignable,
 	)
 	if err != nil {
-		return "", nil, errors.Trace(err)
+		return nil, 0, errors.Trace(err)
 	}
 	for _, storageTag := range args.attachStorage {
 		si, err := im.storageInstance(storageTag)
 		if err != nil {
-			return "", nil, errors.Annotatef(
+			return nil, 0, errors.Annotatef(
 				err, "attaching %s",
 				names.ReadableString(storageTag),
 			)
@@ -1332,7 +1574,7 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 			machineAssignable,
 		)
 		if err != nil {
-			return "", nil, errors.Trace(err)
+			return nil, 0, errors.Trace(err)
 		}
 		storageOps = append(storageOps, ops...)
 		numStorageAttachments++
@@ -1342,76 +1584,15 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		count := len(tags)
 		charmStorage := charm.Meta().Storage[name]
 		if err := validateCharmStorageCountChange(charmStorage, 0, count); err != nil {
-			return "", nil, errors.Trace(err)
+			return nil, 0, errors.Trace(err)
 		}
 		incRefOp, err := increfEntityStorageOp(a.st, unitTag, name, count)
 		if err != nil {
-			return "", nil, errors.Trace(err)
+			return nil, 0, errors.Trace(err)
 		}
 		storageOps = append(storageOps, incRefOp)
 	}
-
-	docID := a.st.docID(name)
-	globalKey := unitGlobalKey(name)
-	agentGlobalKey := unitAgentGlobalKey(name)
-	udoc := &unitDoc{
-		DocID:                  docID,
-		Name:                   name,
-		Application:            a.doc.Name,
-		Series:                 a.doc.Series,
-		Life:                   Alive,
-		Principal:              args.principalName,
-		StorageAttachmentCount: numStorageAttachments,
-	}
-	now := a.st.clock().Now()
-	agentStatusDoc := statusDoc{
-		Status:  status.Allocating,
-		Updated: now.UnixNano(),
-	}
-	unitStatusDoc := statusDoc{
-		Status:     status.Waiting,
-		StatusInfo: status.MessageWaitForMachine,
-		Updated:    now.UnixNano(),
-	}
-	workloadVersionDoc := statusDoc{
-		Status:  status.Unknown,
-		Updated: now.UnixNano(),
-	}
-
-	ops, err := addUnitOps(a.st, addUnitOpsArgs{
-		unitDoc:            udoc,
-		agentStatusDoc:     agentStatusDoc,
-		workloadStatusDoc:  unitStatusDoc,
-		workloadVersionDoc: workloadVersionDoc,
-		meterStatusDoc:     &meterStatusDoc{Code: MeterNotSet.String()},
-	})
-	if err != nil {
-		return "", nil, errors.Trace(err)
-	}
-
-	ops = append(ops, storageOps...)
-
-	if a.doc.Subordinate {
-		ops = append(ops, txn.Op{
-			C:  unitsC,
-			Id: a.st.docID(args.principalName),
-			Assert: append(isAliveDoc, bson.DocElem{
-				"subordinates", bson.D{{"$not", bson.RegEx{Pattern: "^" + a.doc.Name + "/"}}},
-			}),
-			Update: bson.D{{"$addToSet", bson.D{{"subordinates", name}}}},
-		})
-	} else {
-		ops = append(ops, createConstraintsOp(agentGlobalKey, args.cons))
-	}
-
-	// At the last moment we still have the statusDocs in scope, set the initial
-	// history entries. This is risky, and may lead to extra entries, but that's
-	// an intrinsic problem with mixing txn and non-txn ops -- we can't sync
-	// them cleanly.
-	probablyUpdateStatusHistory(a.st.db(), globalKey, unitStatusDoc)
-	probablyUpdateStatusHistory(a.st.db(), agentGlobalKey, agentStatusDoc)
-	probablyUpdateStatusHistory(a.st.db(), globalWorkloadVersionKey(name), workloadVersionDoc)
-	return name, ops, nil
+	return storageOps, numStorageAttachments, nil
 }
 
 // applicationOffersRefCountKey returns a key for refcounting offers
@@ -1511,13 +1692,20 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	if err != nil {
 		return nil, err
 	}
-	im, err := a.st.IAASModel()
+	model, err := a.st.Model()
 	if err != nil {
 		return nil, err
 	}
-	storageInstanceOps, err := removeStorageInstancesOps(im, u.Tag())
-	if err != nil {
-		return nil, err
+	var storageInstanceOps []txn.Op
+	if model.Type() == ModelTypeIAAS {
+		im, err := model.IAASModel()
+		if err != nil {
+			return nil, err
+		}
+		storageInstanceOps, err = removeStorageInstancesOps(im, u.Tag())
+		if err != nil {
+			return nil, err
+		}
 	}
 	resOps, err := removeUnitResourcesOps(a.st, u.doc.Name)
 	if err != nil {
@@ -1541,6 +1729,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeCloudContainerOp(u.globalKey()),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	)
 	ops = append(ops, portsOps...)
@@ -1718,9 +1907,10 @@ func (a *Application) UpdateLeaderSettings(token leadership.Token, updates map[s
 	// thus require an extra db read to access them -- but it stops the State
 	// type getting even more cluttered.
 
-	// We can calculate the actual update ahead of time; it's not dependent
-	// upon the current state of the document. (*Writing* it should depend
-	// on document state, but that's handled below.)
+	// We can calculate the changes ahead of time; they're not dependent
+	// upon the current state of the document. (*Writing* them should depend
+	// on document state, including whether the settings are compressed, but
+	// that's handled below.)
 	key := leadershipSettingsKey(a.doc.Name)
 	sets := bson.M{}
 	unsets := bson.M{}
@@ -1732,8 +1922,6 @@ func (a *Application) UpdateLeaderSettings(token leadership.Token, updates map[s
 			sets[key] = value
 		}
 	}
-	update := setUnsetUpdateSettings(sets, unsets)
-
 	isNullChange := func(rawMap map[string]interface{}) bool {
 		for key := range unsets {
 			if _, found := rawMap[key]; found {
@@ -1761,6 +1949,7 @@ func (a *Application) UpdateLeaderSettings(token leadership.Token, updates map[s
 		if isNullChange(doc.Settings) {
 			return nil, jujutxn.ErrNoOperations
 		}
+		update, _ := settingsUpdate(doc.Settings, sets, unsets)
 		return []txn.Op{{
 			C:      settingsC,
 			Id:     key,
@@ -1806,6 +1995,27 @@ func (a *Application) SetConstraints(cons constraints.Value) (err error) {
 	return onAbort(a.st.db().RunTransaction(ops), errNotAlive)
 }
 
+// EffectiveConstraints reports how the application constraints combine
+// with the model constraints to produce the constraints used when
+// provisioning machines for the application's units. Attributes that
+// the model's provider does not support are reported as unsupported.
+func (a *Application) EffectiveConstraints() (constraints.Resolution, error) {
+	appCons, err := a.Constraints()
+	if err != nil {
+		return constraints.Resolution{}, errors.Trace(err)
+	}
+	modelCons, err := a.st.ModelConstraints()
+	if err != nil {
+		return constraints.Resolution{}, errors.Trace(err)
+	}
+	validator, err := a.st.constraintsValidator()
+	if err != nil {
+		return constraints.Resolution{}, errors.Trace(err)
+	}
+	res, err := constraints.Resolve(validator, modelCons, appCons)
+	return res, errors.Trace(err)
+}
+
 // EndpointBindings returns the mapping for each endpoint name and the space
 // name it is bound to (or empty if unspecified). When no bindings are stored
 // for the application, defaults are returned.
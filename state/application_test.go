@@ -225,6 +225,32 @@ func (s *ApplicationSuite) TestSetCharmUpdatesBindings(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestAddApplicationBindsToModelDefaultSpace(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("client", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{"default-space": "db"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 44)
+
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "yoursql",
+		Charm: ch,
+		EndpointBindings: map[string]string{
+			"client": "client",
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{
+		"":        "db",
+		"server":  "db",
+		"client":  "client",
+		"cluster": "db",
+	})
+}
+
 var metaBase = `
 name: mysql
 summary: "Fake MySQL Database engine"
@@ -1688,6 +1714,45 @@ func (s *ApplicationSuite) TestServiceRefresh(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *ApplicationSuite) TestExposedToCIDRs(c *gc.C) {
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err := s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/24", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+
+	// Exposing without CIDRs allows access from anywhere again.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err = s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestExposedToCIDRsInvalid(c *gc.C) {
+	err := s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/24", "bogus"})
+	c.Assert(err, gc.ErrorMatches, `CIDR "bogus" not valid`)
+	err = s.mysql.SetExposedToCIDRs(nil)
+	c.Assert(err, gc.ErrorMatches, `empty exposed CIDRs not valid`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	// Check that querying for the exposed flag works correctly.
 	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
@@ -1735,6 +1800,36 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestDescription(c *gc.C) {
+	c.Assert(s.mysql.Description(), gc.Equals, "")
+
+	err := s.mysql.SetDescription("owned by the db team")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Description(), gc.Equals, "owned by the db team")
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Description(), gc.Equals, "owned by the db team")
+
+	err = s.mysql.SetDescription("")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Description(), gc.Equals, "")
+}
+
+func (s *ApplicationSuite) TestSetDescriptionTooLong(c *gc.C) {
+	err := s.mysql.SetDescription(strings.Repeat("x", 1025))
+	c.Assert(err, gc.ErrorMatches, "description longer than 1024 bytes not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationSuite) TestSetDescriptionNotAlive(c *gc.C) {
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetDescription("owned by the db team")
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -2281,6 +2376,28 @@ func (s *ApplicationSuite) TestSubordinateConstraints(c *gc.C) {
 	c.Assert(err, gc.Equals, state.ErrSubordinateConstraints)
 }
 
+func (s *ApplicationSuite) TestEffectiveConstraints(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("mem=4G cores=2 cpu-power=100"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetConstraints(constraints.MustParse("instance-type=big cores=4"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := s.mysql.EffectiveConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, constraints.Resolution{
+		Effective:   constraints.MustParse("instance-type=big cores=4 cpu-power=100"),
+		Inherited:   []string{"cpu-power"},
+		Overridden:  []string{"cores", "mem"},
+		Unsupported: []string{"cpu-power"},
+	})
+}
+
+func (s *ApplicationSuite) TestEffectiveConstraintsSubordinate(c *gc.C) {
+	logging := s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	_, err := logging.EffectiveConstraints()
+	c.Assert(errors.Cause(err), gc.Equals, state.ErrSubordinateConstraints)
+}
+
 func (s *ApplicationSuite) TestWatchUnitsBulkEvents(c *gc.C) {
 	// Alive unit...
 	alive, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -2814,6 +2931,51 @@ func (s *ApplicationSuite) TestSetCharmStorageCountMaxDecreased(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "test" to charm "local:quantal/quantal-mysql-3": existing storage "data0" range contracted: max decreased from 2 to 1`)
 }
 
+func (s *ApplicationSuite) TestSetCharmStorageMinimumSizeIncreased(c *gc.C) {
+	oldCh := s.AddMetaCharm(c, "mysql", mysqlBaseMeta+oneRequiredFilesystemStorageMeta+"    minimum-size: 1G\n", 2)
+	newCh := s.AddMetaCharm(c, "mysql", mysqlBaseMeta+oneRequiredFilesystemStorageMeta+"    minimum-size: 2G\n", 3)
+	app := s.AddTestingApplication(c, "test", oldCh)
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg := state.SetCharmConfig{
+		Charm: newCh,
+		StorageConstraints: map[string]state.StorageConstraints{
+			"data0": {Count: 1, Size: 2048},
+		},
+	}
+	err = app.SetCharm(cfg)
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "test" to charm "local:quantal/quantal-mysql-3": existing storage data0/0 is 1.0 GB, less than the new minimum size 2.0 GB \(detach and remove it before upgrading\)`)
+}
+
+const oneFileResourceMeta = `
+resources:
+  data:
+    type: file
+    filename: data.tgz
+`
+
+func (s *ApplicationSuite) TestSetCharmResourceNotProvided(c *gc.C) {
+	err := s.setCharmFromMeta(c,
+		mysqlBaseMeta,
+		mysqlBaseMeta+oneFileResourceMeta,
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "test" to charm "local:quantal/quantal-mysql-3": resource "data" required by the new charm not provided \(specify it with --resource data=<file>\)`)
+}
+
+func (s *ApplicationSuite) TestSetCharmResourceNotDeclared(c *gc.C) {
+	oldCh := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 2)
+	newCh := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 3)
+	app := s.AddTestingApplication(c, "test", oldCh)
+
+	cfg := state.SetCharmConfig{
+		Charm:       newCh,
+		ResourceIDs: map[string]string{"data": "pending-id"},
+	}
+	err := app.SetCharm(cfg)
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "test" to charm "local:quantal/quantal-mysql-3": resource "data" not declared by the new charm`)
+}
+
 func (s *ApplicationSuite) TestSetCharmStorageCountMaxUnboundedToBounded(c *gc.C) {
 	err := s.setCharmFromMeta(c,
 		mysqlBaseMeta+oneRequiredStorageMeta+storageRange(1, -1),
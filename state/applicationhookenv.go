@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// applicationHookEnvironmentKey returns the key of the settings
+// document holding the hook environment overrides of the named
+// application. The document only exists while the application
+// has overrides.
+func applicationHookEnvironmentKey(appName string) string {
+	return fmt.Sprintf("a#%s#hook-environment", appName)
+}
+
+// removeApplicationHookEnvironmentOp returns the operation needed to
+// remove the hook environment overrides of the named application, if
+// there are any.
+func removeApplicationHookEnvironmentOp(appName string) txn.Op {
+	return txn.Op{
+		C:      settingsC,
+		Id:     applicationHookEnvironmentKey(appName),
+		Remove: true,
+	}
+}
+
+// HookEnvironment returns the environment variables that are set for
+// hook executions of the application's units, in addition to (and
+// overriding) those set in the model's hook-environment config.
+func (a *Application) HookEnvironment() (map[string]string, error) {
+	settings, err := readSettings(a.st.db(), settingsC, applicationHookEnvironmentKey(a.Name()))
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read hook environment for application %q", a.Name())
+	}
+	env := make(map[string]string)
+	for k, v := range settings.Map() {
+		env[k] = fmt.Sprint(v)
+	}
+	return env, nil
+}
+
+// SetHookEnvironment replaces the environment variables that are set
+// for hook executions of the application's units, in addition to those
+// set in the model's hook-environment config. An empty map removes all
+// of the application's overrides.
+func (a *Application) SetHookEnvironment(env map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set hook environment for application %q", a.Name())
+	if err := config.ValidateHookEnvironment(env); err != nil {
+		return errors.Trace(err)
+	}
+	key := applicationHookEnvironmentKey(a.Name())
+	values := make(map[string]interface{})
+	for k, v := range env {
+		values[k] = v
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.Life() != Alive {
+			return nil, errNotAlive
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		if len(values) == 0 {
+			return append(ops, removeApplicationHookEnvironmentOp(a.Name())), nil
+		}
+		op, _, err := replaceSettingsOp(a.st.db(), settingsC, key, values)
+		if errors.IsNotFound(err) {
+			op = createSettingsOp(settingsC, key, values)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, op), nil
+	}
+	return a.st.db().Run(buildTxn)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationHookEnvironmentSuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&ApplicationHookEnvironmentSuite{})
+
+func (s *ApplicationHookEnvironmentSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.Factory.MakeApplication(c, nil)
+}
+
+func (s *ApplicationHookEnvironmentSuite) TestHookEnvironmentEmpty(c *gc.C) {
+	env, err := s.app.HookEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.HasLen, 0)
+}
+
+func (s *ApplicationHookEnvironmentSuite) TestSetHookEnvironment(c *gc.C) {
+	err := s.app.SetHookEnvironment(map[string]string{"SITE_ID": "lon1", "COMPLIANCE": "pci"})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := s.app.HookEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, jc.DeepEquals, map[string]string{"SITE_ID": "lon1", "COMPLIANCE": "pci"})
+
+	// Setting the environment replaces it.
+	err = s.app.SetHookEnvironment(map[string]string{"SITE_ID": "lon2"})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err = s.app.HookEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, jc.DeepEquals, map[string]string{"SITE_ID": "lon2"})
+
+	err = s.app.SetHookEnvironment(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	env, err = s.app.HookEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.HasLen, 0)
+
+	// Removing the environment again is not an error.
+	err = s.app.SetHookEnvironment(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationHookEnvironmentSuite) TestSetHookEnvironmentInvalid(c *gc.C) {
+	err := s.app.SetHookEnvironment(map[string]string{"JUJU_UNIT_NAME": "foo/0"})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook environment for application "mysql": environment variable "JUJU_UNIT_NAME" is reserved`)
+}
+
+func (s *ApplicationHookEnvironmentSuite) TestSetHookEnvironmentNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetHookEnvironment(map[string]string{"SITE_ID": "lon1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook environment for application "mysql": .*`)
+}
+
+func (s *ApplicationHookEnvironmentSuite) TestHookEnvironmentRemovedWithApplication(c *gc.C) {
+	err := s.app.SetHookEnvironment(map[string]string{"SITE_ID": "lon1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ReadSettings(state.SettingsC, "a#mysql#hook-environment")
+	c.Assert(err, gc.ErrorMatches, "settings not found")
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationLogging holds the logging settings of an application.
+type ApplicationLogging struct {
+	// Config is a logging configuration string, in the format of the
+	// model's logging-config, that is applied to the agents of the
+	// application's units on top of the model's logging-config.
+	Config string `bson:"config,omitempty"`
+
+	// DedicatedFile records whether the controller writes the logs
+	// of the application's units to a file of their own, rather than
+	// to the log file shared by all agents.
+	DedicatedFile bool `bson:"dedicated-file,omitempty"`
+}
+
+// Validate returns an error if the logging settings are not valid.
+func (l ApplicationLogging) Validate() error {
+	if l.Config == "" {
+		return nil
+	}
+	if _, err := loggo.ParseConfigString(l.Config); err != nil {
+		return errors.NotValidf("logging config %q", l.Config)
+	}
+	return nil
+}
+
+// Logging returns the logging settings of the application.
+func (a *Application) Logging() ApplicationLogging {
+	if a.doc.Logging == nil {
+		return ApplicationLogging{}
+	}
+	return *a.doc.Logging
+}
+
+// SetLogging sets the logging settings of the application. The zero
+// value restores the default of logging as the model does.
+func (a *Application) SetLogging(logging ApplicationLogging) error {
+	if err := logging.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	update := bson.D{{"$unset", bson.D{{"logging", nil}}}}
+	if logging != (ApplicationLogging{}) {
+		update = bson.D{{"$set", bson.D{{"logging", logging}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errNotAlive), "cannot set logging of application %q", a)
+	}
+	if logging == (ApplicationLogging{}) {
+		a.doc.Logging = nil
+	} else {
+		a.doc.Logging = &logging
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationLoggingSuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&ApplicationLoggingSuite{})
+
+func (s *ApplicationLoggingSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *ApplicationLoggingSuite) assertLogging(c *gc.C, expected state.ApplicationLogging) {
+	c.Assert(s.app.Logging(), jc.DeepEquals, expected)
+	err := s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.Logging(), jc.DeepEquals, expected)
+}
+
+func (s *ApplicationLoggingSuite) TestSetLogging(c *gc.C) {
+	s.assertLogging(c, state.ApplicationLogging{})
+
+	logging := state.ApplicationLogging{
+		Config:        "<root>=DEBUG;juju.worker.uniter=TRACE",
+		DedicatedFile: true,
+	}
+	err := s.app.SetLogging(logging)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLogging(c, logging)
+
+	err = s.app.SetLogging(state.ApplicationLogging{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLogging(c, state.ApplicationLogging{})
+}
+
+func (s *ApplicationLoggingSuite) TestSetLoggingInvalidConfig(c *gc.C) {
+	err := s.app.SetLogging(state.ApplicationLogging{Config: "<root>=LOUD"})
+	c.Assert(err, gc.ErrorMatches, `logging config "<root>=LOUD" not valid`)
+	s.assertLogging(c, state.ApplicationLogging{})
+}
+
+func (s *ApplicationLoggingSuite) TestSetLoggingDying(c *gc.C) {
+	_, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetLogging(state.ApplicationLogging{DedicatedFile: true})
+	c.Assert(err, gc.ErrorMatches, `cannot set logging of application "wordpress": not found or not alive`)
+}
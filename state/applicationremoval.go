@@ -0,0 +1,196 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationRemovalReport records the cleanup steps that were skipped
+// when an application's units were forcibly removed, because their
+// end-of-life hooks did not complete within the timeout given when the
+// application was destroyed.
+type ApplicationRemovalReport struct {
+	// Application is the name of the removed application.
+	Application string
+
+	// HookTimeout is the time the units were given to run their
+	// end-of-life hooks.
+	HookTimeout time.Duration
+
+	// Forced is when the units were forcibly removed.
+	Forced time.Time
+
+	// Skipped describes the cleanup steps that were skipped.
+	Skipped []string
+}
+
+// applicationRemovalDoc is the document recording the forced removal of
+// an application's units.
+type applicationRemovalDoc struct {
+	DocID       string   `bson:"_id"`
+	ModelUUID   string   `bson:"model-uuid"`
+	Application string   `bson:"application"`
+	HookTimeout int64    `bson:"hook-timeout"`
+	Forced      int64    `bson:"forced"`
+	Skipped     []string `bson:"skipped"`
+}
+
+// ApplicationRemovalReport returns the report of the forced removal of
+// the named application's units. It returns an error satisfying
+// errors.IsNotFound if the application's units were never forcibly
+// removed.
+func (st *State) ApplicationRemovalReport(name string) (*ApplicationRemovalReport, error) {
+	removals, closer := st.db().GetCollection(applicationRemovalsC)
+	defer closer()
+
+	var doc applicationRemovalDoc
+	err := removals.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("removal report for application %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get removal report for application %q", name)
+	}
+	return &ApplicationRemovalReport{
+		Application: doc.Application,
+		HookTimeout: time.Duration(doc.HookTimeout),
+		Forced:      time.Unix(0, doc.Forced).UTC(),
+		Skipped:     doc.Skipped,
+	}, nil
+}
+
+// errCleanupNotDue is returned by cleanups that must not run yet, so
+// that they are left in place to be run later.
+var errCleanupNotDue = errors.New("cleanup not due")
+
+// cleanupForceDestroyedApplication forcibly removes the units of the
+// named dying application, if they were not removed before the deadline
+// recorded in the cleanup's arguments, and records the cleanup steps
+// that were skipped in the application's removal report.
+func (st *State) cleanupForceDestroyedApplication(name string, cleanupArgs []bson.Raw) (err error) {
+	if n := len(cleanupArgs); n != 2 {
+		return errors.Errorf("expected 2 arguments, got %d", n)
+	}
+	var deadline, hookTimeout int64
+	if err := cleanupArgs[0].Unmarshal(&deadline); err != nil {
+		return errors.Annotate(err, "unmarshalling cleanup args")
+	}
+	if err := cleanupArgs[1].Unmarshal(&hookTimeout); err != nil {
+		return errors.Annotate(err, "unmarshalling cleanup args")
+	}
+	now := st.clock().Now()
+	if now.Before(time.Unix(0, deadline)) {
+		return errCleanupNotDue
+	}
+
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+	var docs []unitDoc
+	if err := units.Find(bson.D{{"application", name}}).All(&docs); err != nil {
+		return errors.Annotate(err, "reading unit documents")
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	var skipped []string
+	for _, doc := range docs {
+		unitSkipped, err := st.skippedUnitCleanup(doc.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		skipped = append(skipped, unitSkipped...)
+		if err := st.obliterateUnit(doc.Name); err != nil {
+			return errors.Annotatef(err, "cannot force removal of unit %q", doc.Name)
+		}
+	}
+	logger.Warningf("forcibly removed units of application %q after %v", name, time.Duration(hookTimeout))
+
+	report := applicationRemovalDoc{
+		DocID:       st.docID(name),
+		ModelUUID:   st.ModelUUID(),
+		Application: name,
+		HookTimeout: hookTimeout,
+		Forced:      now.UnixNano(),
+		Skipped:     skipped,
+	}
+	// A report left by an earlier application of the same name is
+	// replaced.
+	removals, closer := st.db().GetCollection(applicationRemovalsC)
+	defer closer()
+	var ops []txn.Op
+	if n, err := removals.FindId(name).Count(); err != nil {
+		return errors.Trace(err)
+	} else if n > 0 {
+		ops = append(ops, txn.Op{
+			C:      applicationRemovalsC,
+			Id:     report.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		})
+	}
+	ops = append(ops, txn.Op{
+		C:      applicationRemovalsC,
+		Id:     report.DocID,
+		Assert: txn.DocMissing,
+		Insert: &report,
+	})
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot record removal report for application %q", name)
+	}
+	return nil
+}
+
+// skippedUnitCleanup returns descriptions of the cleanup steps that
+// will be skipped by forcibly removing the named unit, and its
+// subordinates.
+func (st *State) skippedUnitCleanup(name string) ([]string, error) {
+	unit, err := st.Unit(name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var skipped []string
+	if unit.Life() != Dead {
+		skipped = append(skipped, fmt.Sprintf("unit %s: stop hook did not complete", name))
+	}
+	relations, err := unit.RelationsInScope()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		skipped = append(skipped, fmt.Sprintf(
+			"unit %s: left relation %q without running relation-departed and relation-broken hooks",
+			name, rel,
+		))
+	}
+	im, err := st.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachments, err := im.UnitStorageAttachments(unit.UnitTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, attachment := range attachments {
+		skipped = append(skipped, fmt.Sprintf(
+			"unit %s: detached storage %s without running storage-detaching hook",
+			name, attachment.StorageInstance().Id(),
+		))
+	}
+	for _, subName := range unit.SubordinateNames() {
+		subSkipped, err := st.skippedUnitCleanup(subName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		skipped = append(skipped, subSkipped...)
+	}
+	return skipped, nil
+}
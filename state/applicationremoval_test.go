@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationRemovalSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ApplicationRemovalSuite{})
+
+func (s *ApplicationRemovalSuite) TestNoReport(c *gc.C) {
+	_, err := s.State.ApplicationRemovalReport("riak")
+	c.Assert(err, gc.ErrorMatches, `removal report for application "riak" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationRemovalSuite) TestHookTimeoutForcesRemoval(c *gc.C) {
+	pr := newPeerRelation(c, s.State)
+	preventPeerUnitsDestroyRemove(c, pr)
+	err := pr.ru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	op := pr.app.DestroyOperation()
+	op.HookTimeout = time.Minute
+	err = s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Before the timeout, the units are left to run their hooks.
+	s.runCleanups(c)
+	err = pr.u0.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationRemovalReport("riak")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.Clock.Advance(time.Minute)
+	s.runCleanups(c)
+	for _, u := range []*state.Unit{pr.u0, pr.u1, pr.u2, pr.u3} {
+		err := u.Refresh()
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+
+	report, err := s.State.ApplicationRemovalReport("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Application, gc.Equals, "riak")
+	c.Assert(report.HookTimeout, gc.Equals, time.Minute)
+	c.Assert(report.Forced.IsZero(), jc.IsFalse)
+	c.Assert(report.Skipped, jc.SameContents, []string{
+		"unit riak/0: stop hook did not complete",
+		`unit riak/0: left relation "riak:ring" without running relation-departed and relation-broken hooks`,
+		"unit riak/1: stop hook did not complete",
+		"unit riak/2: stop hook did not complete",
+		"unit riak/3: stop hook did not complete",
+	})
+}
+
+func (s *ApplicationRemovalSuite) TestHookTimeoutUnitsRemovedInTime(c *gc.C) {
+	pr := newPeerRelation(c, s.State)
+	preventPeerUnitsDestroyRemove(c, pr)
+
+	op := pr.app.DestroyOperation()
+	op.HookTimeout = time.Minute
+	err := s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	s.runCleanups(c)
+	for _, u := range []*state.Unit{pr.u0, pr.u1, pr.u2, pr.u3} {
+		err := u.EnsureDead()
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	s.Clock.Advance(time.Minute)
+	s.runCleanups(c)
+	_, err = s.State.ApplicationRemovalReport("riak")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	state.AssertNoCleanups(c, s.State)
+}
+
+func (s *ApplicationRemovalSuite) runCleanups(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		err := s.State.Cleanup()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationTrust records that an application has been trusted with
+// access to the model's cloud credential, along with an audit of the
+// units that have used that access.
+type ApplicationTrust struct {
+	// Application is the name of the trusted application.
+	Application string
+
+	// GrantedBy is the user that granted the trust.
+	GrantedBy names.UserTag
+
+	// Granted is the time at which the trust was granted.
+	Granted time.Time
+
+	// LastUsed is the time at which a unit of the application last
+	// retrieved the cloud credential. It is the zero time if the
+	// credential has never been retrieved.
+	LastUsed time.Time
+
+	// LastUsedBy is the name of the unit that last retrieved the
+	// cloud credential.
+	LastUsedBy string
+
+	// UseCount is the number of times the cloud credential has been
+	// retrieved by units of the application.
+	UseCount int
+}
+
+// applicationTrustDoc records the trust granted to an application. The
+// document id is the application's global key.
+type applicationTrustDoc struct {
+	DocID       string    `bson:"_id"`
+	Application string    `bson:"application"`
+	GrantedBy   string    `bson:"granted-by"`
+	Granted     time.Time `bson:"granted"`
+	LastUsed    time.Time `bson:"last-used,omitempty"`
+	LastUsedBy  string    `bson:"last-used-by,omitempty"`
+	UseCount    int       `bson:"use-count"`
+}
+
+// Trust returns the trust granted to the application. A NotFound error
+// is returned if the application has not been trusted.
+func (a *Application) Trust() (ApplicationTrust, error) {
+	coll, closer := a.st.db().GetCollection(applicationTrustC)
+	defer closer()
+
+	var doc applicationTrustDoc
+	err := coll.FindId(a.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ApplicationTrust{}, errors.NotFoundf("trust for application %q", a.Name())
+	} else if err != nil {
+		return ApplicationTrust{}, errors.Annotatef(err, "cannot get trust for application %q", a.Name())
+	}
+	return ApplicationTrust{
+		Application: doc.Application,
+		GrantedBy:   names.NewUserTag(doc.GrantedBy),
+		Granted:     doc.Granted,
+		LastUsed:    doc.LastUsed,
+		LastUsedBy:  doc.LastUsedBy,
+		UseCount:    doc.UseCount,
+	}, nil
+}
+
+// IsTrusted reports whether the application has been trusted with
+// access to the model's cloud credential.
+func (a *Application) IsTrusted() (bool, error) {
+	_, err := a.Trust()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// SetTrust grants the application access to the model's cloud
+// credential on behalf of the specified user. Trusting an application
+// that is already trusted is a no-op.
+func (a *Application) SetTrust(grantedBy names.UserTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot trust application %q", a.Name())
+	id := a.globalKey()
+	doc := applicationTrustDoc{
+		DocID:       id,
+		Application: a.Name(),
+		GrantedBy:   grantedBy.Id(),
+		Granted:     a.st.clock().Now().UTC().Round(time.Second),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.Life() != Alive {
+			return nil, errNotAlive
+		}
+		trusted, err := a.IsTrusted()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if trusted {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      applicationTrustC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	return a.st.db().Run(buildTxn)
+}
+
+// RemoveTrust revokes the application's access to the model's cloud
+// credential. Removing trust from an application that is not trusted
+// is a no-op.
+func (a *Application) RemoveTrust() error {
+	err := a.st.db().RunTransaction([]txn.Op{removeApplicationTrustOp(a.globalKey())})
+	return errors.Annotatef(err, "cannot remove trust for application %q", a.Name())
+}
+
+// RecordTrustedCredentialAccess records that the named unit of the
+// application has just retrieved the model's cloud credential. An error
+// satisfying errors.IsNotFound is returned if the application is not
+// trusted.
+func (a *Application) RecordTrustedCredentialAccess(unitName string) error {
+	ops := []txn.Op{{
+		C:      applicationTrustC,
+		Id:     a.globalKey(),
+		Assert: txn.DocExists,
+		Update: bson.D{
+			{"$set", bson.D{
+				{"last-used", a.st.clock().Now().UTC().Round(time.Second)},
+				{"last-used-by", unitName},
+			}},
+			{"$inc", bson.D{{"use-count", 1}}},
+		},
+	}}
+	err := a.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("trust for application %q", a.Name())
+	}
+	return errors.Annotatef(err, "cannot record credential access for application %q", a.Name())
+}
+
+// removeApplicationTrustOp returns the operation needed to remove the
+// trust document associated with the given application global key.
+func removeApplicationTrustOp(globalKey string) txn.Op {
+	return txn.Op{
+		C:      applicationTrustC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationTrustSuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&ApplicationTrustSuite{})
+
+func (s *ApplicationTrustSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.Factory.MakeApplication(c, nil)
+}
+
+func (s *ApplicationTrustSuite) TestTrustNotFound(c *gc.C) {
+	_, err := s.app.Trust()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `trust for application "mysql" not found`)
+
+	trusted, err := s.app.IsTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trusted, jc.IsFalse)
+}
+
+func (s *ApplicationTrustSuite) TestSetTrust(c *gc.C) {
+	err := s.app.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	trust, err := s.app.Trust()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trust.Application, gc.Equals, "mysql")
+	c.Assert(trust.GrantedBy, gc.Equals, names.NewUserTag("admin"))
+	c.Assert(trust.Granted.IsZero(), jc.IsFalse)
+	c.Assert(trust.LastUsed.IsZero(), jc.IsTrue)
+	c.Assert(trust.LastUsedBy, gc.Equals, "")
+	c.Assert(trust.UseCount, gc.Equals, 0)
+
+	trusted, err := s.app.IsTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trusted, jc.IsTrue)
+}
+
+func (s *ApplicationTrustSuite) TestSetTrustIdempotent(c *gc.C) {
+	err := s.app.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetTrust(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The original grant is retained.
+	trust, err := s.app.Trust()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trust.GrantedBy, gc.Equals, names.NewUserTag("admin"))
+}
+
+func (s *ApplicationTrustSuite) TestSetTrustNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, gc.ErrorMatches, `cannot trust application "mysql": .*`)
+}
+
+func (s *ApplicationTrustSuite) TestRemoveTrust(c *gc.C) {
+	err := s.app.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.RemoveTrust()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.app.Trust()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing trust again is not an error.
+	err = s.app.RemoveTrust()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationTrustSuite) TestRecordTrustedCredentialAccess(c *gc.C) {
+	err := s.app.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.RecordTrustedCredentialAccess("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.app.RecordTrustedCredentialAccess("mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	trust, err := s.app.Trust()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trust.LastUsed.UTC(), gc.Equals, s.Clock.Now().UTC().Round(time.Second))
+	c.Assert(trust.LastUsedBy, gc.Equals, "mysql/1")
+	c.Assert(trust.UseCount, gc.Equals, 2)
+}
+
+func (s *ApplicationTrustSuite) TestRecordTrustedCredentialAccessNotTrusted(c *gc.C) {
+	err := s.app.RecordTrustedCredentialAccess("mysql/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationTrustSuite) TestRemoveApplicationRemovesTrust(c *gc.C) {
+	err := s.app.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.app.Trust()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -291,6 +291,56 @@ func (s *AssignSuite) TestAssignMachineDifferentSeries(c *gc.C) {
 		`cannot assign unit "wordpress/0" to machine 0: series does not match`)
 }
 
+func (s *AssignSuite) TestAssignMachineDifferentArch(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	hc := instance.MustParseHardware("arch=amd64")
+	err = machine.SetProvisioned("inst-id", "fake_nonce", &hc)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot assign unit "wordpress/0" to machine 0: machine 0 has architecture "amd64", but the application requires arch=arm64`)
+}
+
+func (s *AssignSuite) TestAssignMachineArchFromConstraints(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	amd64, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("arch=amd64"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	arm64, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("arch=arm64"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToMachine(amd64)
+	c.Assert(err, gc.ErrorMatches, `.*machine 0 has architecture "amd64", but the application requires arch=arm64`)
+	err = unit.AssignToMachine(arm64)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AssignSuite) TestAssignMachineUnknownArch(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *AssignSuite) TestPrincipals(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	mongoutils "github.com/juju/juju/mongo/utils"
+)
+
+// ChangeKind identifies the kind of a change recorded in a model's
+// change log.
+type ChangeKind string
+
+const (
+	ChangeDeploy            ChangeKind = "deploy"
+	ChangeUpgradeCharm      ChangeKind = "upgrade-charm"
+	ChangeConfig            ChangeKind = "config"
+	ChangeConstraints       ChangeKind = "constraints"
+	ChangeAddUnits          ChangeKind = "add-units"
+	ChangeExpose            ChangeKind = "expose"
+	ChangeUnexpose          ChangeKind = "unexpose"
+	ChangeAddRelation       ChangeKind = "add-relation"
+	ChangeRemoveRelation    ChangeKind = "remove-relation"
+	ChangeRemoveApplication ChangeKind = "remove-application"
+)
+
+// ChangeLogEntry records a change applied to a model.
+type ChangeLogEntry struct {
+	// Seq orders the entries in the model's change log. It is
+	// assigned when the change is recorded.
+	Seq int
+
+	// Time is when the change was recorded.
+	Time time.Time
+
+	// Actor is the name of the user that made the change.
+	Actor string
+
+	// Kind is the kind of change.
+	Kind ChangeKind
+
+	// Entity is the tag of the application or relation changed.
+	Entity string
+
+	// Params holds the parameters of the change, such as the charm
+	// deployed or the config settings changed.
+	Params map[string]string
+}
+
+// ChangeLogFilter selects entries of a model's change log. Fields
+// with zero values match all entries.
+type ChangeLogFilter struct {
+	// Kind selects entries of the given kind.
+	Kind ChangeKind
+
+	// Entity selects entries for the entity with the given tag.
+	Entity string
+
+	// Actor selects entries for changes made by the named user.
+	Actor string
+
+	// Since selects entries recorded at or after the given time.
+	Since time.Time
+
+	// Limit, if positive, selects only the latest matching entries.
+	Limit int
+}
+
+type changeLogDoc struct {
+	DocID     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	Seq       int               `bson:"seq"`
+	Time      int64             `bson:"time"`
+	Actor     string            `bson:"actor"`
+	Kind      string            `bson:"kind"`
+	Entity    string            `bson:"entity"`
+	Params    map[string]string `bson:"params,omitempty"`
+}
+
+func (doc *changeLogDoc) entry() ChangeLogEntry {
+	return ChangeLogEntry{
+		Seq:    doc.Seq,
+		Time:   time.Unix(0, doc.Time).UTC(),
+		Actor:  doc.Actor,
+		Kind:   ChangeKind(doc.Kind),
+		Entity: doc.Entity,
+		Params: doc.Params,
+	}
+}
+
+// RecordChange appends the change to the model's change log. The
+// entry's Seq and Time are assigned by the call.
+func (st *State) RecordChange(entry ChangeLogEntry) error {
+	if entry.Kind == "" {
+		return errors.NotValidf("empty change kind")
+	}
+	seq, err := sequence(st, "changelog")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Sequences start at 0; entries are numbered from 1.
+	seq++
+	doc := &changeLogDoc{
+		DocID:     st.docID(strconv.Itoa(seq)),
+		ModelUUID: st.ModelUUID(),
+		Seq:       seq,
+		Time:      st.clock().Now().UnixNano(),
+		Actor:     entry.Actor,
+		Kind:      string(entry.Kind),
+		Entity:    entry.Entity,
+		Params:    mapStringKeys(mongoutils.EscapeString, entry.Params),
+	}
+	ops := []txn.Op{{
+		C:      changeLogC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot record change")
+	}
+	return nil
+}
+
+// ChangeLog returns the entries of the model's change log selected
+// by the filter, oldest first.
+func (st *State) ChangeLog(filter ChangeLogFilter) ([]ChangeLogEntry, error) {
+	changeLog, closer := st.db().GetCollection(changeLogC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.Kind != "" {
+		query = append(query, bson.DocElem{"kind", string(filter.Kind)})
+	}
+	if filter.Entity != "" {
+		query = append(query, bson.DocElem{"entity", filter.Entity})
+	}
+	if filter.Actor != "" {
+		query = append(query, bson.DocElem{"actor", filter.Actor})
+	}
+	if !filter.Since.IsZero() {
+		query = append(query, bson.DocElem{"time", bson.D{{"$gte", filter.Since.UnixNano()}}})
+	}
+	q := changeLog.Find(query).Sort("-seq")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []changeLogDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read change log")
+	}
+	entries := make([]ChangeLogEntry, len(docs))
+	for i, doc := range docs {
+		doc.Params = mapStringKeys(mongoutils.UnescapeString, doc.Params)
+		entries[len(docs)-1-i] = doc.entry()
+	}
+	return entries, nil
+}
+
+// mapStringKeys returns a copy of the map, with its keys transformed
+// by f.
+func mapStringKeys(f func(string) string, in map[string]string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for key, value := range in {
+		out[f(key)] = value
+	}
+	return out
+}
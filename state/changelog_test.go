@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ChangeLogSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ChangeLogSuite{})
+
+func (s *ChangeLogSuite) recordChanges(c *gc.C) {
+	for _, entry := range []state.ChangeLogEntry{{
+		Actor:  "bob",
+		Kind:   state.ChangeDeploy,
+		Entity: "application-mysql",
+		Params: map[string]string{"charm": "cs:mysql-42", "num-units": "1"},
+	}, {
+		Actor:  "bob",
+		Kind:   state.ChangeDeploy,
+		Entity: "application-wordpress",
+		Params: map[string]string{"charm": "cs:wordpress-3"},
+	}, {
+		Actor:  "mary",
+		Kind:   state.ChangeConfig,
+		Entity: "application-mysql",
+		Params: map[string]string{"dataset.size": "80%"},
+	}, {
+		Actor:  "mary",
+		Kind:   state.ChangeAddRelation,
+		Entity: "relation-wordpress.db#mysql.server",
+	}} {
+		err := s.State.RecordChange(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ChangeLogSuite) TestEmpty(c *gc.C) {
+	entries, err := s.State.ChangeLog(state.ChangeLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *ChangeLogSuite) TestRecordChange(c *gc.C) {
+	s.recordChanges(c)
+	entries, err := s.State.ChangeLog(state.ChangeLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 4)
+	for i, entry := range entries {
+		c.Check(entry.Seq, gc.Equals, i+1)
+		c.Check(entry.Time.IsZero(), jc.IsFalse)
+	}
+	c.Check(entries[0].Actor, gc.Equals, "bob")
+	c.Check(entries[0].Kind, gc.Equals, state.ChangeDeploy)
+	c.Check(entries[0].Entity, gc.Equals, "application-mysql")
+	c.Check(entries[0].Params, jc.DeepEquals, map[string]string{"charm": "cs:mysql-42", "num-units": "1"})
+	c.Check(entries[2].Params, jc.DeepEquals, map[string]string{"dataset.size": "80%"})
+	c.Check(entries[3].Params, gc.HasLen, 0)
+}
+
+func (s *ChangeLogSuite) TestRecordChangeNoKind(c *gc.C) {
+	err := s.State.RecordChange(state.ChangeLogEntry{Actor: "bob"})
+	c.Assert(err, gc.ErrorMatches, "empty change kind not valid")
+}
+
+func (s *ChangeLogSuite) TestChangeLogFilter(c *gc.C) {
+	s.recordChanges(c)
+	seqs := func(filter state.ChangeLogFilter) []int {
+		entries, err := s.State.ChangeLog(filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var seqs []int
+		for _, entry := range entries {
+			seqs = append(seqs, entry.Seq)
+		}
+		return seqs
+	}
+	c.Check(seqs(state.ChangeLogFilter{Kind: state.ChangeDeploy}), jc.DeepEquals, []int{1, 2})
+	c.Check(seqs(state.ChangeLogFilter{Entity: "application-mysql"}), jc.DeepEquals, []int{1, 3})
+	c.Check(seqs(state.ChangeLogFilter{Actor: "mary"}), jc.DeepEquals, []int{3, 4})
+	c.Check(seqs(state.ChangeLogFilter{Limit: 2}), jc.DeepEquals, []int{3, 4})
+	c.Check(seqs(state.ChangeLogFilter{Since: time.Now().Add(time.Hour)}), gc.HasLen, 0)
+}
+
+func (s *ChangeLogSuite) TestChangeLogPerModel(c *gc.C) {
+	s.recordChanges(c)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := st.RecordChange(state.ChangeLogEntry{Actor: "bob", Kind: state.ChangeExpose})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := st.ChangeLog(state.ChangeLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Seq, gc.Equals, 1)
+	c.Assert(entries[0].Kind, gc.Equals, state.ChangeExpose)
+}
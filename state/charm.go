@@ -66,6 +66,10 @@ type charmDoc struct {
 	StoragePath  string `bson:"storagepath"`
 	Macaroon     []byte `bson:"macaroon"`
 
+	// GitSource records the git repository and commit the charm
+	// was built from, if it was built from one.
+	GitSource *charmGitSourceDoc `bson:"git-source,omitempty"`
+
 	// The remaining fields hold data sufficient to define a
 	// charm.Charm.
 
@@ -83,6 +87,25 @@ type charmDoc struct {
 	Metrics *charm.Metrics `bson:"metrics"`
 }
 
+type charmGitSourceDoc struct {
+	Repository string `bson:"repository"`
+	Ref        string `bson:"ref"`
+	Revision   string `bson:"revision"`
+}
+
+// CharmGitSource identifies the git repository and commit from which
+// a charm was built.
+type CharmGitSource struct {
+	// Repository is the URL of the git repository.
+	Repository string
+
+	// Ref is the branch, tag or commit that was requested.
+	Ref string
+
+	// Revision is the ID of the commit that Ref resolved to.
+	Revision string
+}
+
 // CharmInfo contains all the data necessary to store a charm's metadata.
 type CharmInfo struct {
 	Charm       charm.Charm
@@ -90,6 +113,18 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+	GitSource   *CharmGitSource
+}
+
+func newCharmGitSourceDoc(source *CharmGitSource) *charmGitSourceDoc {
+	if source == nil {
+		return nil
+	}
+	return &charmGitSourceDoc{
+		Repository: source.Repository,
+		Ref:        source.Ref,
+		Revision:   source.Revision,
+	}
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -108,6 +143,7 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		Actions:      info.Charm.Actions(),
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
+		GitSource:    newCharmGitSourceDoc(info.GitSource),
 	}
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
@@ -226,6 +262,9 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 		}
 		data = append(data, bson.DocElem{"macaroon", mac})
 	}
+	if info.GitSource != nil {
+		data = append(data, bson.DocElem{"git-source", newCharmGitSourceDoc(info.GitSource)})
+	}
 
 	op.Update = bson.D{{"$set", data}}
 	return []txn.Op{op}, nil
@@ -490,6 +529,19 @@ func (c *Charm) Macaroon() (macaroon.Slice, error) {
 	return m, nil
 }
 
+// GitSource returns the git repository and commit the charm was built
+// from, and whether it was built from one.
+func (c *Charm) GitSource() (CharmGitSource, bool) {
+	if c.doc.GitSource == nil {
+		return CharmGitSource{}, false
+	}
+	return CharmGitSource{
+		Repository: c.doc.GitSource.Repository,
+		Ref:        c.doc.GitSource.Ref,
+		Revision:   c.doc.GitSource.Revision,
+	}, true
+}
+
 // UpdateMacaroon updates the stored macaroon for this charm.
 func (c *Charm) UpdateMacaroon(m macaroon.Slice) error {
 	info := CharmInfo{
@@ -578,6 +630,28 @@ func (st *State) AllCharms() ([]*Charm, error) {
 	return charms, errors.Trace(iter.Close())
 }
 
+// GitSourceCharms returns the uploaded charms that were built from the
+// given commit of the given git repository.
+func (st *State) GitSourceCharms(repository, revision string) ([]*Charm, error) {
+	charms, closer := st.db().GetCollection(charmsC)
+	defer closer()
+
+	var docs []charmDoc
+	err := charms.Find(bson.D{
+		{"git-source.repository", repository},
+		{"git-source.revision", revision},
+		{"pendingupload", false},
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charms built from %s at %s", repository, revision)
+	}
+	result := make([]*Charm, len(docs))
+	for i := range docs {
+		result[i] = newCharm(st, &docs[i])
+	}
+	return result, nil
+}
+
 // Charm returns the charm with the given URL. Charms pending upload
 // to storage and placeholders are never returned.
 func (st *State) Charm(curl *charm.URL) (*Charm, error) {
@@ -480,6 +480,42 @@ func (s *CharmSuite) TestUpdateUploadedCharm(c *gc.C) {
 	c.Assert(ms, gc.DeepEquals, info.Macaroon)
 }
 
+func (s *CharmSuite) TestUpdateUploadedCharmGitSource(c *gc.C) {
+	info := s.dummyCharm(c, "local:quantal/dummy-7")
+	_, err := s.State.PrepareLocalCharmUpload(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source := state.CharmGitSource{
+		Repository: "git+ssh://git@example.com/dummy.git",
+		Ref:        "stable",
+		Revision:   "0123456789abcdef",
+	}
+	charms, err := s.State.GitSourceCharms(source.Repository, source.Revision)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charms, gc.HasLen, 0)
+
+	info.GitSource = &source
+	sch, err := s.State.UpdateUploadedCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	got, ok := sch.GitSource()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(got, jc.DeepEquals, source)
+
+	charms, err = s.State.GitSourceCharms(source.Repository, source.Revision)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charms, gc.HasLen, 1)
+	c.Assert(charms[0].URL(), jc.DeepEquals, info.ID)
+
+	charms, err = s.State.GitSourceCharms(source.Repository, "fedcba9876543210")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charms, gc.HasLen, 0)
+}
+
+func (s *CharmSuite) TestCharmWithoutGitSource(c *gc.C) {
+	_, ok := s.charm.GitSource()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *CharmSuite) TestUpdateUploadedCharmEscapesSpecialCharsInConfig(c *gc.C) {
 	// Make sure when we have mongodb special characters like "$" and
 	// "." in the name of any charm config option, we do proper
@@ -0,0 +1,213 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CharmLock describes a lock held by a unit, which serializes some
+// operation across all the units of its application.
+type CharmLock struct {
+	// Name is the name the charm gave the lock.
+	Name string
+
+	// Holder is the name of the unit holding the lock.
+	Holder string
+
+	// Expires is when the lock is released if its holder has not
+	// released or renewed it by then.
+	Expires time.Time
+}
+
+// ErrCharmLockHeld is returned when a unit tries to acquire a charm
+// lock that is held by another unit.
+type ErrCharmLockHeld struct {
+	Name   string
+	Holder string
+}
+
+func (e *ErrCharmLockHeld) Error() string {
+	return fmt.Sprintf("lock %q is held by unit %q", e.Name, e.Holder)
+}
+
+// IsCharmLockHeldError returns if the given error or its cause is
+// ErrCharmLockHeld.
+func IsCharmLockHeldError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrCharmLockHeld)
+	return ok
+}
+
+type charmLockDoc struct {
+	DocId       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+	Name        string `bson:"name"`
+	Holder      string `bson:"holder"`
+	Expires     int64  `bson:"expires"`
+}
+
+func (doc *charmLockDoc) lock() CharmLock {
+	return CharmLock{
+		Name:    doc.Name,
+		Holder:  doc.Holder,
+		Expires: unixNanoTime(doc.Expires),
+	}
+}
+
+// charmLockId returns the id of the named lock shared by the units of
+// the given application.
+func charmLockId(application, name string) string {
+	return application + "#" + name
+}
+
+func (st *State) charmLockDoc(application, name string) (*charmLockDoc, error) {
+	locks, closer := st.db().GetCollection(charmLocksC)
+	defer closer()
+
+	var doc charmLockDoc
+	err := locks.FindId(charmLockId(application, name)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("lock %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get lock %q", name)
+	}
+	return &doc, nil
+}
+
+// CharmLock returns the named lock shared by the units of the
+// application.
+func (a *Application) CharmLock(name string) (CharmLock, error) {
+	doc, err := a.st.charmLockDoc(a.doc.Name, name)
+	if err != nil {
+		return CharmLock{}, errors.Trace(err)
+	}
+	return doc.lock(), nil
+}
+
+// AcquireCharmLock acquires the named lock, shared by all the units of
+// the unit's application, on behalf of the unit, until the given time
+// to live has passed. If the unit already holds the lock, its expiry
+// time is extended; if another unit holds it and it has not expired,
+// an error satisfying IsCharmLockHeldError is returned.
+func (u *Unit) AcquireCharmLock(name string, ttl time.Duration) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot acquire lock %q", name)
+	if name == "" {
+		return errors.NotValidf("empty lock name")
+	}
+	if ttl <= 0 {
+		return errors.NotValidf("time to live %v", ttl)
+	}
+	id := u.st.docID(charmLockId(u.doc.Application, name))
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() != Alive {
+			return nil, errors.New("unit is not alive")
+		}
+		now := u.st.clock().Now()
+		expires := now.Add(ttl).UnixNano()
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		doc, err := u.st.charmLockDoc(u.doc.Application, name)
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      charmLocksC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &charmLockDoc{
+					DocId:       id,
+					ModelUUID:   u.st.ModelUUID(),
+					Application: u.doc.Application,
+					Name:        name,
+					Holder:      u.doc.Name,
+					Expires:     expires,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Holder != u.doc.Name && doc.Expires > now.UnixNano() {
+			return nil, &ErrCharmLockHeld{Name: name, Holder: doc.Holder}
+		}
+		return append(ops, txn.Op{
+			C:      charmLocksC,
+			Id:     id,
+			Assert: bson.D{{"holder", doc.Holder}, {"expires", doc.Expires}},
+			Update: bson.D{{"$set", bson.D{
+				{"holder", u.doc.Name},
+				{"expires", expires},
+			}}},
+		}), nil
+	}
+	return u.st.db().Run(buildTxn)
+}
+
+// ReleaseCharmLock releases the named lock, which must be held by the
+// unit.
+func (u *Unit) ReleaseCharmLock(name string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot release lock %q", name)
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, err := u.st.charmLockDoc(u.doc.Application, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Holder != u.doc.Name {
+			return nil, errors.Errorf("lock is held by unit %q", doc.Holder)
+		}
+		return []txn.Op{{
+			C:      charmLocksC,
+			Id:     doc.DocId,
+			Assert: bson.D{{"holder", u.doc.Name}},
+			Remove: true,
+		}}, nil
+	}
+	return u.st.db().Run(buildTxn)
+}
+
+// releaseCharmLocks releases all the charm locks held by the named unit.
+func (st *State) releaseCharmLocks(unitName string) error {
+	locks, closer := st.db().GetCollection(charmLocksC)
+	defer closer()
+
+	var docs []charmLockDoc
+	if err := locks.Find(bson.D{{"holder", unitName}}).All(&docs); err != nil {
+		return errors.Annotatef(err, "cannot get locks held by unit %q", unitName)
+	}
+	for _, doc := range docs {
+		ops := []txn.Op{{
+			C:      charmLocksC,
+			Id:     doc.DocId,
+			Assert: bson.D{{"holder", unitName}},
+			Remove: true,
+		}}
+		// If the lock has been taken over by another unit since it
+		// expired, there is nothing to release.
+		if err := st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+			return errors.Annotatef(err, "cannot release lock %q", doc.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmLockSuite struct {
+	ConnSuite
+	clock *jujutesting.Clock
+	app   *state.Application
+	unit0 *state.Unit
+	unit1 *state.Unit
+}
+
+var _ = gc.Suite(&CharmLockSuite{})
+
+func (s *CharmLockSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2017, 10, 1, 0, 0, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.app = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.unit0, err = s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit1, err = s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmLockSuite) TestAcquire(c *gc.C) {
+	err := s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	lock, err := s.app.CharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock, jc.DeepEquals, state.CharmLock{
+		Name:    "migrate",
+		Holder:  "mysql/0",
+		Expires: s.clock.Now().Add(time.Minute),
+	})
+}
+
+func (s *CharmLockSuite) TestAcquireHeld(c *gc.C) {
+	err := s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit1.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot acquire lock "migrate": lock "migrate" is held by unit "mysql/0"`)
+	c.Assert(err, jc.Satisfies, state.IsCharmLockHeldError)
+}
+
+func (s *CharmLockSuite) TestAcquireRenews(c *gc.C) {
+	err := s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(30 * time.Second)
+
+	err = s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err := s.app.CharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Expires, gc.Equals, s.clock.Now().Add(time.Minute))
+}
+
+func (s *CharmLockSuite) TestAcquireExpired(c *gc.C) {
+	err := s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Minute + time.Second)
+
+	err = s.unit1.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err := s.app.CharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Holder, gc.Equals, "mysql/1")
+}
+
+func (s *CharmLockSuite) TestLocksScopedToApplication(c *gc.C) {
+	other := s.AddTestingApplication(c, "other", s.AddTestingCharm(c, "mysql"))
+	otherUnit, err := other.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = otherUnit.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmLockSuite) TestAcquireInvalid(c *gc.C) {
+	err := s.unit0.AcquireCharmLock("", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot acquire lock "": empty lock name not valid`)
+	err = s.unit0.AcquireCharmLock("migrate", 0)
+	c.Assert(err, gc.ErrorMatches, `cannot acquire lock "migrate": time to live 0s not valid`)
+}
+
+func (s *CharmLockSuite) TestAcquireDyingUnit(c *gc.C) {
+	err := s.unit0.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit0.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot acquire lock "migrate": unit is not alive`)
+}
+
+func (s *CharmLockSuite) TestRelease(c *gc.C) {
+	err := s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit0.ReleaseCharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.app.CharmLock("migrate")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.unit1.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmLockSuite) TestReleaseNotHeld(c *gc.C) {
+	err := s.unit0.ReleaseCharmLock("migrate")
+	c.Assert(err, gc.ErrorMatches, `cannot release lock "migrate": lock "migrate" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit1.ReleaseCharmLock("migrate")
+	c.Assert(err, gc.ErrorMatches, `cannot release lock "migrate": lock is held by unit "mysql/0"`)
+}
+
+func (s *CharmLockSuite) TestReleasedWhenUnitRemoved(c *gc.C) {
+	err := s.unit0.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit0.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit0.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.app.CharmLock("migrate")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
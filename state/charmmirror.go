@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// charmMirrorDoc records a charm archive held in the controller's
+// charm mirror, in the storage of the controller model.
+type charmMirrorDoc struct {
+	URL         string `bson:"_id"`
+	StoragePath string `bson:"storage-path"`
+	SHA256      string `bson:"sha256"`
+	SHA384      string `bson:"sha384"`
+	Size        int64  `bson:"size"`
+}
+
+// MirroredCharm describes a charm archive held in the controller's
+// charm mirror, from which models on the controller are given the
+// charm instead of downloading it from the charm store again.
+type MirroredCharm struct {
+	// URL is the URL of the charm, including its revision.
+	URL *charm.URL
+
+	// StoragePath is the path of the archive in the storage of the
+	// controller model.
+	StoragePath string
+
+	// SHA256 and SHA384 are the hex-encoded hashes of the archive.
+	SHA256 string
+	SHA384 string
+
+	// Size is the size of the archive in bytes.
+	Size int64
+}
+
+// MirroredCharm returns the archive of the charm with the given URL in
+// the controller's charm mirror. It returns an error satisfying
+// errors.IsNotFound if the charm is not mirrored.
+func (st *State) MirroredCharm(curl *charm.URL) (MirroredCharm, error) {
+	coll, closer := st.db().GetCollection(charmMirrorC)
+	defer closer()
+
+	var doc charmMirrorDoc
+	if err := coll.FindId(curl.String()).One(&doc); err == mgo.ErrNotFound {
+		return MirroredCharm{}, errors.NotFoundf("mirrored charm %q", curl)
+	} else if err != nil {
+		return MirroredCharm{}, errors.Annotatef(err, "cannot get mirrored charm %q", curl)
+	}
+	return MirroredCharm{
+		URL:         curl,
+		StoragePath: doc.StoragePath,
+		SHA256:      doc.SHA256,
+		SHA384:      doc.SHA384,
+		Size:        doc.Size,
+	}, nil
+}
+
+// AddMirroredCharm records the archive of a charm in the controller's
+// charm mirror. It returns an error satisfying errors.IsAlreadyExists
+// if the charm is already mirrored.
+func (st *State) AddMirroredCharm(mc MirroredCharm) error {
+	if mc.URL == nil || mc.URL.Revision < 0 {
+		return errors.NotValidf("charm URL without revision")
+	}
+	if mc.StoragePath == "" {
+		return errors.NotValidf("empty storage path")
+	}
+	ops := []txn.Op{{
+		C:      charmMirrorC,
+		Id:     mc.URL.String(),
+		Assert: txn.DocMissing,
+		Insert: &charmMirrorDoc{
+			URL:         mc.URL.String(),
+			StoragePath: mc.StoragePath,
+			SHA256:      mc.SHA256,
+			SHA384:      mc.SHA384,
+			Size:        mc.Size,
+		},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.AlreadyExistsf("mirrored charm %q", mc.URL)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot add mirrored charm %q", mc.URL)
+	}
+	return nil
+}
+
+// RemoveMirroredCharm removes the record of the charm with the given
+// URL from the controller's charm mirror, if it is there. Its archive
+// must be removed from storage separately.
+func (st *State) RemoveMirroredCharm(curl *charm.URL) error {
+	ops := []txn.Op{{
+		C:      charmMirrorC,
+		Id:     curl.String(),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Annotatef(err, "cannot remove mirrored charm %q", curl)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmMirrorSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CharmMirrorSuite{})
+
+func (s *CharmMirrorSuite) TestAddMirroredCharm(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/wordpress-3")
+	_, err := s.State.MirroredCharm(curl)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	mc := state.MirroredCharm{
+		URL:         curl,
+		StoragePath: "charm-mirror/wordpress",
+		SHA256:      "sha256-hash",
+		SHA384:      "sha384-hash",
+		Size:        1234,
+	}
+	err = s.State.AddMirroredCharm(mc)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.State.MirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, mc)
+
+	err = s.State.AddMirroredCharm(mc)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *CharmMirrorSuite) TestMirroredCharmSharedByModels(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/wordpress-3")
+	err := s.State.AddMirroredCharm(state.MirroredCharm{
+		URL:         curl,
+		StoragePath: "charm-mirror/wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	result, err := otherState.MirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.StoragePath, gc.Equals, "charm-mirror/wordpress")
+}
+
+func (s *CharmMirrorSuite) TestAddMirroredCharmInvalid(c *gc.C) {
+	err := s.State.AddMirroredCharm(state.MirroredCharm{
+		URL:         charm.MustParseURL("cs:quantal/wordpress"),
+		StoragePath: "charm-mirror/wordpress",
+	})
+	c.Assert(err, gc.ErrorMatches, "charm URL without revision not valid")
+	err = s.State.AddMirroredCharm(state.MirroredCharm{
+		URL: charm.MustParseURL("cs:quantal/wordpress-3"),
+	})
+	c.Assert(err, gc.ErrorMatches, "empty storage path not valid")
+}
+
+func (s *CharmMirrorSuite) TestRemoveMirroredCharm(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/wordpress-3")
+	err := s.State.AddMirroredCharm(state.MirroredCharm{
+		URL:         curl,
+		StoragePath: "charm-mirror/wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveMirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.MirroredCharm(curl)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a charm that is not mirrored is not an error.
+	err = s.State.RemoveMirroredCharm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -33,6 +33,7 @@ const (
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
 	cleanupResourceBlob                  cleanupKind = "resourceBlob"
 	cleanupStorageForDyingModel          cleanupKind = "modelStorage"
+	cleanupForceDestroyedApplication     cleanupKind = "application"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -141,10 +142,14 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupResourceBlob(doc.Prefix)
 		case cleanupStorageForDyingModel:
 			err = st.cleanupStorageForDyingModel(args)
+		case cleanupForceDestroyedApplication:
+			err = st.cleanupForceDestroyedApplication(doc.Prefix, args)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
-		if err != nil {
+		if err == errCleanupNotDue {
+			continue
+		} else if err != nil {
 			logger.Errorf("cleanup failed for %v(%q): %v", doc.Kind, doc.Prefix, err)
 			continue
 		}
@@ -551,6 +556,10 @@ func (st *State) cleanupRemovedUnit(unitId string) error {
 		}
 	}
 
+	if err := st.releaseCharmLocks(unitId); err != nil {
+		return errors.Trace(err)
+	}
+
 	change := payloadCleanupChange{
 		Unit: unitId,
 	}
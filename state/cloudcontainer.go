@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CloudContainer represents the state of the container, for example a
+// Kubernetes pod, that hosts a unit in a CAAS model. Units in CAAS
+// models are not assigned to machines; they run in containers managed
+// by the model's container broker.
+type CloudContainer interface {
+	// Unit returns the name of the unit hosted by the container.
+	Unit() string
+
+	// ProviderId returns the id assigned to the container by the
+	// substrate.
+	ProviderId() string
+
+	// Address returns the address of the container, if known.
+	Address() string
+
+	// Ports returns the ports exposed by the container, in the form
+	// "<port>/<protocol>".
+	Ports() []string
+}
+
+// CloudContainerParams holds the values used to record the container
+// hosting a unit.
+type CloudContainerParams struct {
+	ProviderId string
+	Address    string
+	Ports      []string
+}
+
+// cloudContainerDoc records the container hosting a unit in a CAAS
+// model. The document id is the unit's global key.
+type cloudContainerDoc struct {
+	DocID      string   `bson:"_id"`
+	Unit       string   `bson:"unit"`
+	ProviderId string   `bson:"provider-id"`
+	Address    string   `bson:"address,omitempty"`
+	Ports      []string `bson:"ports,omitempty"`
+}
+
+type cloudContainer struct {
+	doc cloudContainerDoc
+}
+
+// Unit implements CloudContainer.
+func (c *cloudContainer) Unit() string {
+	return c.doc.Unit
+}
+
+// ProviderId implements CloudContainer.
+func (c *cloudContainer) ProviderId() string {
+	return c.doc.ProviderId
+}
+
+// Address implements CloudContainer.
+func (c *cloudContainer) Address() string {
+	return c.doc.Address
+}
+
+// Ports implements CloudContainer.
+func (c *cloudContainer) Ports() []string {
+	return c.doc.Ports
+}
+
+// CloudContainer returns the container hosting the unit. A NotFound
+// error is returned if no container has been recorded for the unit.
+func (u *Unit) CloudContainer() (CloudContainer, error) {
+	coll, closer := u.st.db().GetCollection(cloudContainersC)
+	defer closer()
+
+	var doc cloudContainerDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("container for unit %q", u.Name())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get container for unit %q", u.Name())
+	}
+	return &cloudContainer{doc: doc}, nil
+}
+
+// SetCloudContainer records the container hosting the unit, replacing
+// any container previously recorded. It is only supported for units in
+// CAAS models.
+func (u *Unit) SetCloudContainer(args CloudContainerParams) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set container for unit %q", u.Name())
+	if args.ProviderId == "" {
+		return errors.NotValidf("empty provider id")
+	}
+	model, err := u.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Type() != ModelTypeCAAS {
+		return errors.NotSupportedf("containers for units in %s models", model.Type())
+	}
+	id := u.globalKey()
+	doc := cloudContainerDoc{
+		DocID:      id,
+		Unit:       u.Name(),
+		ProviderId: args.ProviderId,
+		Address:    args.Address,
+		Ports:      args.Ports,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, ErrDead
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		if _, err := u.CloudContainer(); errors.IsNotFound(err) {
+			ops = append(ops, txn.Op{
+				C:      cloudContainersC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		} else {
+			ops = append(ops, txn.Op{
+				C:      cloudContainersC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"provider-id", doc.ProviderId},
+					{"address", doc.Address},
+					{"ports", doc.Ports},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	return u.st.db().Run(buildTxn)
+}
+
+// removeCloudContainerOp returns the operation needed to remove the
+// container document associated with the given unit global key.
+func removeCloudContainerOp(globalKey string) txn.Op {
+	return txn.Op{
+		C:      cloudContainersC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
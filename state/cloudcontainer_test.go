@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type CloudContainerSuite struct {
+	ConnSuite
+	caasSt *state.State
+	app    *state.Application
+}
+
+var _ = gc.Suite(&CloudContainerSuite{})
+
+func (s *CloudContainerSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.SetFeatureFlags(feature.CAAS)
+	s.caasSt = s.Factory.MakeModel(c, &factory.ModelParams{
+		Type: state.ModelTypeCAAS,
+	})
+	s.AddCleanup(func(*gc.C) { s.caasSt.Close() })
+	ch := state.AddTestingCharm(c, s.caasSt, "mysql")
+	app, err := s.caasSt.AddApplication(state.AddApplicationArgs{
+		Name:  "mysql",
+		Charm: ch,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.app = app
+}
+
+func (s *CloudContainerSuite) TestAddUnitWaitsForContainer(c *gc.C) {
+	unit, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.AssignedMachineId()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+
+	unitStatus, err := unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus.Status, gc.Equals, status.Waiting)
+	c.Assert(unitStatus.Message, gc.Equals, status.MessageWaitForContainer)
+}
+
+func (s *CloudContainerSuite) TestAssignUnitNotSupported(c *gc.C) {
+	unit, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.caasSt.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "mysql/0" to machine: assigning units to machines in CAAS models not supported`)
+}
+
+func (s *CloudContainerSuite) TestAddApplicationPlacementNotSupported(c *gc.C) {
+	ch := state.AddTestingCharm(c, s.caasSt, "wordpress")
+	_, err := s.caasSt.AddApplication(state.AddApplicationArgs{
+		Name:      "wordpress",
+		Charm:     ch,
+		NumUnits:  1,
+		Placement: []*instance.Placement{{Scope: instance.MachineScope, Directive: "0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "wordpress": placement directives in CAAS models not supported`)
+}
+
+func (s *CloudContainerSuite) TestAddApplicationStorageNotSupported(c *gc.C) {
+	ch := state.AddTestingCharm(c, s.caasSt, "storage-block")
+	_, err := s.caasSt.AddApplication(state.AddApplicationArgs{
+		Name:  "storage-block",
+		Charm: ch,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block": storage in CAAS models not supported`)
+}
+
+func (s *CloudContainerSuite) TestCloudContainerNotFound(c *gc.C) {
+	unit, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.CloudContainer()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `container for unit "mysql/0" not found`)
+}
+
+func (s *CloudContainerSuite) TestSetCloudContainer(c *gc.C) {
+	unit, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCloudContainer(state.CloudContainerParams{
+		ProviderId: "pod-uid",
+		Address:    "10.0.0.1",
+		Ports:      []string{"3306/tcp"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	container, err := unit.CloudContainer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(container.Unit(), gc.Equals, "mysql/0")
+	c.Assert(container.ProviderId(), gc.Equals, "pod-uid")
+	c.Assert(container.Address(), gc.Equals, "10.0.0.1")
+	c.Assert(container.Ports(), jc.DeepEquals, []string{"3306/tcp"})
+
+	// Setting the container again replaces it.
+	err = unit.SetCloudContainer(state.CloudContainerParams{
+		ProviderId: "another-pod-uid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	container, err = unit.CloudContainer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(container.ProviderId(), gc.Equals, "another-pod-uid")
+	c.Assert(container.Address(), gc.Equals, "")
+	c.Assert(container.Ports(), gc.HasLen, 0)
+}
+
+func (s *CloudContainerSuite) TestSetCloudContainerEmptyProviderId(c *gc.C) {
+	unit, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCloudContainer(state.CloudContainerParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot set container for unit "mysql/0": empty provider id not valid`)
+}
+
+func (s *CloudContainerSuite) TestSetCloudContainerIAASModel(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetCloudContainer(state.CloudContainerParams{ProviderId: "pod-uid"})
+	c.Assert(err, gc.ErrorMatches, `cannot set container for unit ".*": containers for units in iaas models not supported`)
+}
+
+func (s *CloudContainerSuite) TestRemoveUnitRemovesCloudContainer(c *gc.C) {
+	unit, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCloudContainer(state.CloudContainerParams{ProviderId: "pod-uid"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = unit.CloudContainer()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
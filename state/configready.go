@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
+)
+
+// ConfigReadyHash returns the hash of the config settings that the
+// application's leader last reported it had finished handling, or the
+// empty string if it has not reported any.
+func (a *Application) ConfigReadyHash() string {
+	return a.doc.ConfigReadyHash
+}
+
+// SetConfigReadyHash records that the application's leader has
+// finished handling the config settings with the given hash, but will
+// fail (with a suitable error) if the supplied Token, which should
+// attest to the leadership of the application, loses validity.
+//
+// When the model's leader-first-config-changed setting is enabled, the
+// application's other units hold back their config-changed hooks until
+// the hash recorded here matches that of their own config settings.
+func (a *Application) SetConfigReadyHash(token leadership.Token, hash string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set config ready hash of application %q", a)
+	if hash == "" {
+		return errors.NotValidf("empty hash")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if a.doc.ConfigReadyHash == hash {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"config-ready-hash", hash}}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxnWithLeadership(buildTxn, token)); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.ConfigReadyHash = hash
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ConfigReadySuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&ConfigReadySuite{})
+
+func (s *ConfigReadySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *ConfigReadySuite) assertConfigReadyHash(c *gc.C, hash string) {
+	c.Assert(s.app.ConfigReadyHash(), gc.Equals, hash)
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ConfigReadyHash(), gc.Equals, hash)
+}
+
+func (s *ConfigReadySuite) TestSetConfigReadyHash(c *gc.C) {
+	s.assertConfigReadyHash(c, "")
+
+	err := s.app.SetConfigReadyHash(&fakeToken{}, "abc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigReadyHash(c, "abc")
+
+	// Setting the same hash again is a no-op.
+	err = s.app.SetConfigReadyHash(&fakeToken{}, "abc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigReadyHash(c, "abc")
+
+	err = s.app.SetConfigReadyHash(&fakeToken{}, "def")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigReadyHash(c, "def")
+}
+
+func (s *ConfigReadySuite) TestSetConfigReadyHashStale(c *gc.C) {
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetConfigReadyHash(&fakeToken{}, "abc")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.SetConfigReadyHash(&fakeToken{}, "def")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigReadyHash(c, "def")
+}
+
+func (s *ConfigReadySuite) TestSetConfigReadyHashEmpty(c *gc.C) {
+	err := s.app.SetConfigReadyHash(&fakeToken{}, "")
+	c.Assert(err, gc.ErrorMatches, `cannot set config ready hash of application "wordpress": empty hash not valid`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ConfigReadySuite) TestSetConfigReadyHashTokenError(c *gc.C) {
+	err := s.app.SetConfigReadyHash(&failToken{}, "abc")
+	c.Assert(err, gc.ErrorMatches, `cannot set config ready hash of application "wordpress": prerequisites failed: something bad happened`)
+	s.assertConfigReadyHash(c, "")
+}
+
+func (s *ConfigReadySuite) TestSetConfigReadyHashNotAlive(c *gc.C) {
+	// Add a unit so that the application is not removed.
+	_, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetConfigReadyHash(&fakeToken{}, "abc")
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
@@ -23,6 +23,7 @@ type constraintsDoc struct {
 	CpuPower     *uint64
 	Mem          *uint64
 	RootDisk     *uint64
+	Bandwidth    *uint64
 	InstanceType *string
 	Container    *instance.ContainerType
 	Tags         *[]string
@@ -37,6 +38,7 @@ func (doc constraintsDoc) value() constraints.Value {
 		CpuPower:     doc.CpuPower,
 		Mem:          doc.Mem,
 		RootDisk:     doc.RootDisk,
+		Bandwidth:    doc.Bandwidth,
 		InstanceType: doc.InstanceType,
 		Container:    doc.Container,
 		Tags:         doc.Tags,
@@ -53,6 +55,7 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		CpuPower:     cons.CpuPower,
 		Mem:          cons.Mem,
 		RootDisk:     cons.RootDisk,
+		Bandwidth:    cons.Bandwidth,
 		InstanceType: cons.InstanceType,
 		Container:    cons.Container,
 		Tags:         cons.Tags,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	names "gopkg.in/juju/names.v2"
 	mgo "gopkg.in/mgo.v2"
 
@@ -87,3 +88,77 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// checkAPIPortChange returns an error if the api port is changed
+// without the old port being kept open as the additional api port, as
+// agents would lose their connections to the controllers before they
+// could learn the new addresses.
+func checkAPIPortChange(oldConfig, newConfig jujucontroller.Config) error {
+	oldPort := oldConfig.APIPort()
+	newPort := newConfig.APIPort()
+	if oldPort == newPort || newConfig.AdditionalAPIPort() == oldPort {
+		return nil
+	}
+	return errors.Errorf(
+		"cannot change %s from %d to %d unless %s is set to %d, "+
+			"so that agents can learn the new address before the old port is closed",
+		jujucontroller.APIPort, oldPort, newPort, jujucontroller.AdditionalAPIPort, oldPort,
+	)
+}
+
+// UpdateControllerConfig adds, updates or removes attributes in the
+// controller config, and returns the sorted names of the changed
+// attributes that only take effect once the controller agents are
+// restarted. Removed attributes revert to their defaults, if any.
+func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) ([]string, error) {
+	if len(updateAttrs)+len(removeAttrs) == 0 {
+		return nil, nil
+	}
+	removed := set.NewStrings(removeAttrs...)
+	for _, attr := range jujucontroller.ImmutableConfigAttributes {
+		_, updated := updateAttrs[attr]
+		if updated || removed.Contains(attr) {
+			return nil, errors.Errorf("cannot change %s after bootstrap", attr)
+		}
+	}
+
+	settings, err := readSettings(st.db(), controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	oldConfig := jujucontroller.Config(settings.Map())
+	attrs := settings.Map()
+	for _, attr := range removeAttrs {
+		delete(attrs, attr)
+	}
+	for attr, value := range updateAttrs {
+		attrs[attr] = value
+	}
+	caCert, _ := oldConfig.CACert()
+	newConfig, err := jujucontroller.NewConfig(oldConfig.ControllerUUID(), caCert, attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkAPIPortChange(oldConfig, newConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	for _, attr := range removeAttrs {
+		if value, ok := newConfig[attr]; ok {
+			settings.Set(attr, value)
+		} else {
+			settings.Delete(attr)
+		}
+	}
+	for attr := range updateAttrs {
+		value, ok := newConfig[attr]
+		if !ok {
+			return nil, errors.NotValidf("controller config attribute %q", attr)
+		}
+		settings.Set(attr, value)
+	}
+	if _, err := settings.Write(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jujucontroller.RestartRequired(oldConfig, settings.Map()), nil
+}
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"fmt"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -31,6 +33,19 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AutocertDNSNameKey:  true,
 		controller.AllowModelAccessKey: true,
 		controller.MongoMemoryProfile:  true,
+
+		controller.AllowCredentialGetKey:    true,
+		controller.AllowedCredentialPlugins: true,
+
+		controller.DeployAllowedCharmSources:  true,
+		controller.DeployAllowedGitHosts:      true,
+		controller.DeployDeniedSeries:         true,
+		controller.DeployRequiredResourceTags: true,
+		controller.DeployMinimumConstraints:   true,
+		controller.ModelTemplates:             true,
+		controller.InstanceStartedWebhook:     true,
+		controller.ModelExpiryWebhook:         true,
+		controller.FaultInjectionEnabled:      true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -55,6 +70,89 @@ func (s *ControllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(cfg["controller-uuid"], gc.Equals, s.State.ControllerUUID())
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfig(c *gc.C) {
+	restart, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: true,
+		controller.MaxLogsAge:      "96h",
+		controller.StatePort:       "37018",
+	}, []string{controller.MongoMemoryProfile})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restart, jc.DeepEquals, []string{controller.StatePort})
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+	c.Assert(cfg.MaxLogsAge().String(), gc.Equals, "96h0m0s")
+	c.Assert(cfg.StatePort(), gc.Equals, 37018)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigAPIPort(c *gc.C) {
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	oldPort := cfg.APIPort()
+	newPort := oldPort + 1
+
+	_, err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIPort: newPort,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
+		"cannot change api-port from %d to %d unless additional-api-port is set to %d, .*",
+		oldPort, newPort, oldPort,
+	))
+
+	// Moving the port while keeping the old one open takes effect
+	// without restarting the controller agents.
+	restart, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIPort:           newPort,
+		controller.AdditionalAPIPort: oldPort,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restart, gc.HasLen, 0)
+
+	// Once the agents have learned the new address, the old port can
+	// be closed.
+	_, err = s.State.UpdateControllerConfig(nil, []string{controller.AdditionalAPIPort})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIPorts(), jc.DeepEquals, []int{newPort})
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigRemoveRevertsToDefault(c *gc.C) {
+	_, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxLogsSize: "8G",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	restart, err := s.State.UpdateControllerConfig(nil, []string{controller.MaxLogsSize})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restart, gc.HasLen, 0)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxLogSizeMB(), gc.Equals, controller.DefaultMaxLogCollectionMB)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigImmutable(c *gc.C) {
+	_, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.ControllerUUIDKey: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "cannot change controller-uuid after bootstrap")
+	_, err = s.State.UpdateControllerConfig(nil, []string{controller.CACertKey})
+	c.Assert(err, gc.ErrorMatches, "cannot change ca-cert after bootstrap")
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigInvalid(c *gc.C) {
+	_, err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxLogsSize: "lots",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "invalid max logs size in configuration: .*")
+	_, err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"no-such-attribute": "value",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `controller config attribute "no-such-attribute" not valid`)
+}
+
 func (s *ControllerSuite) TestPing(c *gc.C) {
 	c.Assert(s.Controller.Ping(), gc.IsNil)
 	gitjujutesting.MgoServer.Restart()
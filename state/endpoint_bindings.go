@@ -144,6 +144,22 @@ func mergeBindings(newMap, oldMap map[string]string, meta *charm.Meta) (map[stri
 // determining defaults and to validate the effective bindings.
 func createEndpointBindingsOp(st *State, key string, givenMap map[string]string, meta *charm.Meta) (txn.Op, error) {
 
+	// Endpoints not bound explicitly go to the model's default space,
+	// unless a default binding was given.
+	if _, ok := givenMap[defaultEndpointName]; !ok {
+		cfg, err := st.ModelConfig()
+		if err != nil {
+			return txn.Op{}, errors.Trace(err)
+		}
+		if space := cfg.DefaultSpace(); space != "" {
+			withDefault := map[string]string{defaultEndpointName: space}
+			for endpoint, space := range givenMap {
+				withDefault[endpoint] = space
+			}
+			givenMap = withDefault
+		}
+	}
+
 	// No existing map to merge, just use the defaults.
 	initialMap, _, err := mergeBindings(givenMap, nil, meta)
 	if err != nil {
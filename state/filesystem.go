@@ -35,6 +35,7 @@ type Filesystem interface {
 	Lifer
 	status.StatusGetter
 	status.StatusSetter
+	status.StatusHistoryGetter
 
 	// FilesystemTag returns the tag for the filesystem.
 	FilesystemTag() names.FilesystemTag
@@ -93,6 +94,11 @@ type FilesystemAttachment interface {
 	// if it has not already been made. Params returns true if the returned
 	// parameters are usable for creating an attachment, otherwise false.
 	Params() (FilesystemAttachmentParams, bool)
+
+	// Usage returns the filesystem utilization last reported by the
+	// machine agent. Usage returns true if usage has been reported,
+	// otherwise false.
+	Usage() (FilesystemUsage, bool)
 }
 
 type filesystem struct {
@@ -134,6 +140,7 @@ type filesystemAttachmentDoc struct {
 	Life       Life                        `bson:"life"`
 	Info       *FilesystemAttachmentInfo   `bson:"info,omitempty"`
 	Params     *FilesystemAttachmentParams `bson:"params,omitempty"`
+	Usage      *FilesystemUsage            `bson:"usage,omitempty"`
 }
 
 // FilesystemParams records parameters for provisioning a new filesystem.
@@ -177,6 +184,33 @@ type FilesystemAttachmentInfo struct {
 	ReadOnly   bool   `bson:"read-only"`
 }
 
+// FilesystemUsage records the utilization of a mounted filesystem, as
+// measured on the machine to which it is attached.
+type FilesystemUsage struct {
+	// Size is the total size of the filesystem, in MiB.
+	Size uint64 `bson:"size"`
+
+	// Used is the amount of the filesystem in use, in MiB.
+	Used uint64 `bson:"used"`
+
+	// Available is the amount of the filesystem available to
+	// unprivileged users, in MiB.
+	Available uint64 `bson:"available"`
+
+	// Updated is the time at which the usage was measured.
+	Updated time.Time `bson:"updated"`
+}
+
+// PercentUsed returns the percentage of the filesystem's usable space
+// that is in use, rounded up, as reported by df(1).
+func (u FilesystemUsage) PercentUsed() int {
+	usable := u.Used + u.Available
+	if usable == 0 {
+		return 0
+	}
+	return int((u.Used*100 + usable - 1) / usable)
+}
+
 // FilesystemAttachmentParams records parameters for attaching a filesystem to a
 // machine.
 type FilesystemAttachmentParams struct {
@@ -252,6 +286,18 @@ func (f *filesystem) Releasing() bool {
 	return f.doc.Releasing
 }
 
+// StatusHistory returns a slice of at most filter.Size StatusInfo items
+// or items as old as filter.Date or items newer than now - filter.Delta time
+// representing past statuses for this filesystem.
+func (f *filesystem) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        f.im.mb.db(),
+		globalKey: f.globalKey(),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return f.im.FilesystemStatus(f.FilesystemTag())
@@ -293,6 +339,14 @@ func (f *filesystemAttachment) Params() (FilesystemAttachmentParams, bool) {
 	return *f.doc.Params, true
 }
 
+// Usage is required to implement FilesystemAttachment.
+func (f *filesystemAttachment) Usage() (FilesystemUsage, bool) {
+	if f.doc.Usage == nil {
+		return FilesystemUsage{}, false
+	}
+	return *f.doc.Usage, true
+}
+
 // Filesystem returns the Filesystem with the specified name.
 func (im *IAASModel) Filesystem(tag names.FilesystemTag) (Filesystem, error) {
 	f, err := im.filesystemByTag(tag)
@@ -1281,6 +1335,29 @@ func (im *IAASModel) SetFilesystemAttachmentInfo(
 	return im.mb.db().Run(buildTxn)
 }
 
+// SetFilesystemAttachmentUsage records the utilization of the filesystem
+// attachment with the specified machine and filesystem tags, as measured
+// by the machine agent.
+func (im *IAASModel) SetFilesystemAttachmentUsage(
+	machineTag names.MachineTag,
+	filesystemTag names.FilesystemTag,
+	usage FilesystemUsage,
+) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set usage for filesystem attachment %s:%s", filesystemTag.Id(), machineTag.Id())
+	ops := []txn.Op{{
+		C:      filesystemAttachmentsC,
+		Id:     filesystemAttachmentId(machineTag.Id(), filesystemTag.Id()),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"usage", &usage}}}},
+	}}
+	if err := im.mb.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("filesystem attachment")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func setFilesystemAttachmentInfoOps(
 	machine names.MachineTag,
 	filesystem names.FilesystemTag,
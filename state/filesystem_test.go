@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -676,6 +678,45 @@ func (s *FilesystemStateSuite) TestSetFilesystemAttachmentInfoMachineNotProvisio
 	c.Assert(err, gc.ErrorMatches, `cannot set info for filesystem attachment 0/0:0: machine 0 not provisioned`)
 }
 
+func (s *FilesystemStateSuite) TestSetFilesystemAttachmentUsage(c *gc.C) {
+	filesystem, machine := s.setupFilesystemAttachment(c, "rootfs")
+	attachment := s.filesystemAttachment(c, machine.MachineTag(), filesystem.FilesystemTag())
+	_, ok := attachment.Usage()
+	c.Assert(ok, jc.IsFalse)
+
+	usage := state.FilesystemUsage{
+		Size:      1024,
+		Used:      900,
+		Available: 100,
+		Updated:   time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	err := s.IAASModel.SetFilesystemAttachmentUsage(machine.MachineTag(), filesystem.FilesystemTag(), usage)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachment = s.filesystemAttachment(c, machine.MachineTag(), filesystem.FilesystemTag())
+	stored, ok := attachment.Usage()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(stored.Size, gc.Equals, uint64(1024))
+	c.Assert(stored.Used, gc.Equals, uint64(900))
+	c.Assert(stored.Available, gc.Equals, uint64(100))
+	c.Assert(stored.Updated.Equal(usage.Updated), jc.IsTrue)
+	c.Assert(stored.PercentUsed(), gc.Equals, 90)
+}
+
+func (s *FilesystemStateSuite) TestSetFilesystemAttachmentUsageNotFound(c *gc.C) {
+	err := s.IAASModel.SetFilesystemAttachmentUsage(
+		names.NewMachineTag("0"), names.NewFilesystemTag("0"), state.FilesystemUsage{},
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot set usage for filesystem attachment 0:0: filesystem attachment not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FilesystemStateSuite) TestFilesystemUsagePercentUsed(c *gc.C) {
+	c.Assert(state.FilesystemUsage{}.PercentUsed(), gc.Equals, 0)
+	c.Assert(state.FilesystemUsage{Used: 1, Available: 2}.PercentUsed(), gc.Equals, 34)
+	c.Assert(state.FilesystemUsage{Used: 10, Available: 0}.PercentUsed(), gc.Equals, 100)
+}
+
 func (s *FilesystemStateSuite) TestSetFilesystemInfoVolumeAttachmentNotProvisioned(c *gc.C) {
 	filesystem, _, _, _ := s.addUnitWithFilesystemUnprovisioned(c, "modelscoped-block", true)
 	err := s.IAASModel.SetFilesystemInfo(
@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// FloatingIP returns the provider floating IP address that follows the
+// application's leader, and the name of the unit whose machine the
+// address is currently associated with. Either may be empty.
+func (a *Application) FloatingIP() (address, unitName string) {
+	return a.doc.FloatingIP, a.doc.FloatingIPUnit
+}
+
+// SetFloatingIP sets the provider floating IP address that should
+// follow the application's leader. An empty address stops the address
+// following the leader. Setting the address forgets the unit it is
+// currently associated with, so that it is associated afresh.
+func (a *Application) SetFloatingIP(address string) error {
+	if address != "" && net.ParseIP(address) == nil {
+		return errors.NotValidf("floating IP address %q", address)
+	}
+	update := bson.D{{"$unset", bson.D{
+		{"floating-ip", nil},
+		{"floating-ip-unit", nil},
+	}}}
+	if address != "" {
+		update = bson.D{
+			{"$set", bson.D{{"floating-ip", address}}},
+			{"$unset", bson.D{{"floating-ip-unit", nil}}},
+		}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errNotAlive), "cannot set floating IP of application %q", a)
+	}
+	a.doc.FloatingIP = address
+	a.doc.FloatingIPUnit = ""
+	return nil
+}
+
+// SetFloatingIPUnit records that the application's floating IP address
+// has been associated with the machine of the named unit.
+func (a *Application) SetFloatingIPUnit(unitName string) error {
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	if appName, _ := names.UnitApplication(unitName); appName != a.doc.Name {
+		return errors.NotValidf("unit %q of application %q", unitName, a)
+	}
+	address := a.doc.FloatingIP
+	if address == "" {
+		return errors.NotFoundf("floating IP of application %q", a)
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: bson.D{{"life", Alive}, {"floating-ip", address}},
+		Update: bson.D{{"$set", bson.D{{"floating-ip-unit", unitName}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			if err := a.Refresh(); err != nil {
+				return errors.Trace(err)
+			}
+			if a.doc.Life == Alive {
+				err = errors.Errorf("floating IP has changed")
+			} else {
+				err = errNotAlive
+			}
+		}
+		return errors.Annotatef(err, "cannot set floating IP unit of application %q", a)
+	}
+	a.doc.FloatingIPUnit = unitName
+	return nil
+}
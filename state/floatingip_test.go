@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type FloatingIPSuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&FloatingIPSuite{})
+
+func (s *FloatingIPSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *FloatingIPSuite) assertFloatingIP(c *gc.C, address, unitName string) {
+	gotAddress, gotUnit := s.app.FloatingIP()
+	c.Assert(gotAddress, gc.Equals, address)
+	c.Assert(gotUnit, gc.Equals, unitName)
+	err := s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	gotAddress, gotUnit = s.app.FloatingIP()
+	c.Assert(gotAddress, gc.Equals, address)
+	c.Assert(gotUnit, gc.Equals, unitName)
+}
+
+func (s *FloatingIPSuite) TestSetFloatingIP(c *gc.C) {
+	s.assertFloatingIP(c, "", "")
+
+	err := s.app.SetFloatingIP("203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFloatingIP(c, "203.0.113.10", "")
+
+	err = s.app.SetFloatingIPUnit("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFloatingIP(c, "203.0.113.10", "wordpress/0")
+
+	// Changing the address forgets the unit it was associated with.
+	err = s.app.SetFloatingIP("203.0.113.11")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFloatingIP(c, "203.0.113.11", "")
+
+	err = s.app.SetFloatingIPUnit("wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetFloatingIP("")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFloatingIP(c, "", "")
+}
+
+func (s *FloatingIPSuite) TestSetFloatingIPInvalid(c *gc.C) {
+	err := s.app.SetFloatingIP("not-an-address")
+	c.Assert(err, gc.ErrorMatches, `floating IP address "not-an-address" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *FloatingIPSuite) TestSetFloatingIPNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetFloatingIP("203.0.113.10")
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
+func (s *FloatingIPSuite) TestSetFloatingIPUnitNoFloatingIP(c *gc.C) {
+	err := s.app.SetFloatingIPUnit("wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `floating IP of application "wordpress" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FloatingIPSuite) TestSetFloatingIPUnitOtherApplication(c *gc.C) {
+	err := s.app.SetFloatingIP("203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetFloatingIPUnit("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" of application "wordpress" not valid`)
+}
+
+func (s *FloatingIPSuite) TestSetFloatingIPUnitAddressChanged(c *gc.C) {
+	err := s.app.SetFloatingIP("203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetFloatingIP("203.0.113.11")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.SetFloatingIPUnit("wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `cannot set floating IP unit of application "wordpress": floating IP has changed`)
+	s.assertFloatingIP(c, "203.0.113.11", "")
+}
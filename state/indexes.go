@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/mgo.v2"
+)
+
+// indexBuildReportInterval is how often an index that is still being
+// built is reported.
+var indexBuildReportInterval = time.Minute
+
+// IndexReport describes what was done, and found, when ensuring the
+// indexes declared for the state collections. Indexes are described
+// as "collection(key,...)".
+type IndexReport struct {
+	// Created holds the declared indexes that were missing, and have
+	// been created.
+	Created []string
+
+	// Unknown holds the indexes that exist but are not declared. They
+	// may be left over from earlier versions of Juju, or have been
+	// added by hand.
+	Unknown []string
+}
+
+// EnsureIndexes creates any of the indexes declared for the state
+// collections that are missing, and warns about any indexes that are
+// not declared. The indexes are otherwise only created when the
+// controller is bootstrapped, so this is run when the controller is
+// upgraded to pick up indexes declared by the new version.
+func EnsureIndexes(st *State) (IndexReport, error) {
+	session := st.session.Copy()
+	defer session.Close()
+	report, err := allCollections().ensureIndexes(session.DB(jujuDB), st.clock())
+	return report, errors.Trace(err)
+}
+
+// ensureIndexes creates any of the schema's declared indexes that are
+// missing. The indexes are built in the background, so that the
+// collections remain usable while they are built.
+func (schema collectionSchema) ensureIndexes(db *mgo.Database, clk clock.Clock) (IndexReport, error) {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var report IndexReport
+	for _, name := range names {
+		created, unknown, err := ensureCollectionIndexes(db.C(name), schema[name].indexes, clk)
+		if err != nil {
+			return report, errors.Trace(err)
+		}
+		report.Created = append(report.Created, created...)
+		report.Unknown = append(report.Unknown, unknown...)
+	}
+	return report, nil
+}
+
+// ensureCollectionIndexes creates the declared indexes missing from
+// the collection, and returns the descriptions of those created and
+// of those found that are not declared.
+func ensureCollectionIndexes(coll *mgo.Collection, declared []mgo.Index, clk clock.Clock) (created, unknown []string, _ error) {
+	existing, err := coll.Indexes()
+	if err != nil && !isNamespaceNotFound(err) {
+		return nil, nil, errors.Annotatef(err, "cannot list indexes for collection %q", coll.Name)
+	}
+	have := make(map[string]bool)
+	for _, index := range existing {
+		have[indexKey(index.Key)] = true
+	}
+
+	want := make(map[string]bool)
+	for _, index := range declared {
+		key := indexKey(index.Key)
+		want[key] = true
+		if have[key] {
+			continue
+		}
+		description := indexDescription(coll.Name, index.Key)
+		logger.Infof("creating missing index %s", description)
+		if err := buildIndex(coll, index, description, clk); err != nil {
+			return nil, nil, maybeUnauthorized(err, fmt.Sprintf("cannot create index %s", description))
+		}
+		created = append(created, description)
+	}
+
+	for _, index := range existing {
+		key := indexKey(index.Key)
+		if key == "_id" || want[key] {
+			continue
+		}
+		description := indexDescription(coll.Name, index.Key)
+		logger.Warningf("found unknown index %s; it may be left over from an earlier version of Juju", description)
+		unknown = append(unknown, description)
+	}
+	return created, unknown, nil
+}
+
+// buildIndex builds the index in the background, reporting periodically
+// while it is being built.
+func buildIndex(coll *mgo.Collection, index mgo.Index, description string, clk clock.Clock) error {
+	index.Background = true
+	done := make(chan error, 1)
+	go func() {
+		done <- coll.EnsureIndex(index)
+	}()
+	start := clk.Now()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-clk.After(indexBuildReportInterval):
+			logger.Warningf("still building index %s after %s", description, clk.Now().Sub(start))
+		}
+	}
+}
+
+// indexKey returns a string identifying an index by its key.
+func indexKey(key []string) string {
+	return strings.Join(key, ",")
+}
+
+// indexDescription returns a description of an index for reporting.
+func indexDescription(collection string, key []string) string {
+	return fmt.Sprintf("%s(%s)", collection, indexKey(key))
+}
+
+// isNamespaceNotFound reports whether the error is due to a collection
+// not existing, as it won't until the first document is written to it.
+func isNamespaceNotFound(err error) bool {
+	if qerr, ok := errors.Cause(err).(*mgo.QueryError); ok && qerr.Code == 26 {
+		return true
+	}
+	return strings.Contains(err.Error(), "ns doesn't exist")
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+)
+
+type indexesSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&indexesSuite{})
+
+func (s *indexesSuite) rawUnits() *mgo.Collection {
+	return s.state.MongoSession().DB(jujuDB).C(unitsC)
+}
+
+func (s *indexesSuite) hasIndex(c *gc.C, key ...string) bool {
+	indexes, err := s.rawUnits().Indexes()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, index := range indexes {
+		if indexKey(index.Key) == indexKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *indexesSuite) TestEnsureIndexesNothingMissing(c *gc.C) {
+	report, err := EnsureIndexes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Created, gc.HasLen, 0)
+}
+
+func (s *indexesSuite) TestEnsureIndexesCreatesMissing(c *gc.C) {
+	err := s.rawUnits().DropIndex("model-uuid", "application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.hasIndex(c, "model-uuid", "application"), jc.IsFalse)
+
+	report, err := EnsureIndexes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Created, jc.DeepEquals, []string{"units(model-uuid,application)"})
+	c.Assert(s.hasIndex(c, "model-uuid", "application"), jc.IsTrue)
+
+	report, err = EnsureIndexes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Created, gc.HasLen, 0)
+}
+
+func (s *indexesSuite) TestEnsureIndexesReportsUnknown(c *gc.C) {
+	err := s.rawUnits().EnsureIndex(mgo.Index{Key: []string{"model-uuid", "legacy"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := EnsureIndexes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(set.NewStrings(report.Unknown...).Contains("units(model-uuid,legacy)"), jc.IsTrue)
+}
@@ -280,8 +280,10 @@ func (s *InitializeSuite) TestInitializeWithControllerInheritedConfig(c *gc.C) {
 			expected[k] = v
 		}
 	}
-	// Config as read from state has resources tags coerced to a map.
+	// Config as read from state has resources tags and the hook
+	// environment coerced to maps.
 	expected["resource-tags"] = map[string]string{}
+	expected["hook-environment"] = map[string]string{}
 	cfg, err = s.Model.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.AllAttrs(), jc.DeepEquals, expected)
@@ -210,6 +210,13 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action.
+	Messages() []ActionMessage
+
+	// Progress returns the percentage of the action that is complete,
+	// as last reported by the action.
+	Progress() int
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -221,6 +228,15 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Log records a progress message for the action. It asserts that
+	// the action is running.
+	Log(message string) error
+
+	// SetProgress records the percentage of the action that is
+	// complete, and a progress message if one is given. It asserts
+	// that the action is running.
+	SetProgress(percent int, message string) error
 }
 
 // ApplicationEntity represents a local or remote application.
@@ -137,6 +137,15 @@ type machineDoc struct {
 	// an instance for the machine.
 	Placement string `bson:",omitempty"`
 
+	// ImageStream is the image stream from which the image for the
+	// machine's instance is selected, overriding the model's
+	// image-stream setting.
+	ImageStream string `bson:",omitempty"`
+
+	// ImageId is the id of the image that the machine's instance
+	// must be started with.
+	ImageId string `bson:",omitempty"`
+
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
@@ -207,6 +216,7 @@ type instanceData struct {
 	CpuPower   *uint64     `bson:"cpupower,omitempty"`
 	Tags       *[]string   `bson:"tags,omitempty"`
 	AvailZone  *string     `bson:"availzone,omitempty"`
+	Bandwidth  *uint64     `bson:"bandwidth,omitempty"`
 
 	// KeepInstance is set to true if, on machine removal from Juju,
 	// the cloud instance should be retained.
@@ -222,6 +232,7 @@ func hardwareCharacteristics(instData instanceData) *instance.HardwareCharacteri
 		CpuPower:         instData.CpuPower,
 		Tags:             instData.Tags,
 		AvailabilityZone: instData.AvailZone,
+		Bandwidth:        instData.Bandwidth,
 	}
 }
 
@@ -234,6 +245,26 @@ func (m *Machine) HardwareCharacteristics() (*instance.HardwareCharacteristics,
 	return hardwareCharacteristics(instData), nil
 }
 
+// arch returns the architecture of the machine: that of its instance if
+// provisioned, or else that of its constraints. An empty string is
+// returned if the architecture is not yet known.
+func (m *Machine) arch() (string, error) {
+	hc, err := m.HardwareCharacteristics()
+	if err == nil && hc.Arch != nil {
+		return *hc.Arch, nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	cons, err := m.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	if cons.HasArch() {
+		return *cons.Arch, nil
+	}
+	return "", nil
+}
+
 func getInstanceData(st *State, id string) (instanceData, error) {
 	instanceDataCollection, closer := st.db().GetCollection(instanceDataC)
 	defer closer()
@@ -1228,6 +1259,7 @@ func (m *Machine) SetProvisioned(id instance.Id, nonce string, characteristics *
 		CpuPower:   characteristics.CpuPower,
 		Tags:       characteristics.Tags,
 		AvailZone:  characteristics.AvailabilityZone,
+		Bandwidth:  characteristics.Bandwidth,
 	}
 
 	ops := []txn.Op{
@@ -1615,6 +1647,19 @@ func (m *Machine) Placement() string {
 	return m.doc.Placement
 }
 
+// ImageStream returns the image stream from which the image for the
+// machine's instance should be selected, or "" if the model's
+// image-stream setting applies.
+func (m *Machine) ImageStream() string {
+	return m.doc.ImageStream
+}
+
+// ImageId returns the id of the image that the machine's instance
+// must be started with, or "" if any suitable image may be used.
+func (m *Machine) ImageId() string {
+	return m.doc.ImageId
+}
+
 // Constraints returns the exact constraints that should apply when provisioning
 // an instance for the machine.
 func (m *Machine) Constraints() (constraints.Value, error) {
@@ -882,6 +882,17 @@ func (s *MachineSuite) TestMachineSetProvisionedUpdatesCharacteristics(c *gc.C)
 	c.Assert(*md, gc.DeepEquals, *expected)
 }
 
+func (s *MachineSuite) TestMachineSetProvisionedStoresBandwidth(c *gc.C) {
+	bandwidth := uint64(100)
+	expected := &instance.HardwareCharacteristics{Bandwidth: &bandwidth}
+	err := s.machine.SetProvisioned("umbrella/0", "fake_nonce", expected)
+	c.Assert(err, jc.ErrorIsNil)
+	md, err := s.machine.HardwareCharacteristics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*md, jc.DeepEquals, *expected)
+	c.Assert(md.String(), gc.Equals, "bandwidth=100M")
+}
+
 func (s *MachineSuite) TestMachineAvailabilityZone(c *gc.C) {
 	zone := "a_zone"
 	hwc := &instance.HardwareCharacteristics{
@@ -1562,6 +1573,18 @@ func (s *MachineSuite) TestSetConstraints(c *gc.C) {
 	c.Assert(mcons, gc.DeepEquals, cons1)
 }
 
+func (s *MachineSuite) TestSetBandwidthConstraint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	cons := constraints.MustParse("mem=1G bandwidth=1G")
+	err = machine.SetConstraints(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	mcons, err := machine.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mcons, gc.DeepEquals, cons)
+	c.Assert(*mcons.Bandwidth, gc.Equals, uint64(1000))
+}
+
 func (s *MachineSuite) TestSetAmbiguousConstraints(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -674,6 +674,26 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	}
 	delete(e.modelSettings, leadershipKey)
 
+	if len(application.doc.ExposedCIDRs) > 0 {
+		return errors.NotSupportedf("exporting application %q exposed to specific CIDRs", appName)
+	}
+	trusted, err := application.IsTrusted()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if trusted {
+		return errors.NotSupportedf("exporting trusted application %q", appName)
+	}
+	if application.doc.AddressPolicy != nil {
+		return errors.NotSupportedf("exporting application %q with an address policy", appName)
+	}
+	if application.doc.FloatingIP != "" {
+		return errors.NotSupportedf("exporting application %q with a floating IP", appName)
+	}
+	if application.doc.Logging != nil {
+		return errors.NotSupportedf("exporting application %q with logging settings", appName)
+	}
+
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
 		Series:               application.doc.Series,
@@ -912,6 +932,13 @@ func (e *exporter) relations() error {
 		}
 		exRelation.SetStatus(statusArgs)
 
+		// The model description has no place for peer seed data;
+		// units that have already joined the relation have read it.
+		if _, found := e.modelSettings[peerSeedKey(relation.Id())]; found {
+			e.logger.Warningf("seed data for relation %v is not migrated", relation.Id())
+			delete(e.modelSettings, peerSeedKey(relation.Id()))
+		}
+
 		isRemote := false
 		for _, ep := range relation.Endpoints() {
 			if remoteApps.Contains(ep.ApplicationName) {
@@ -1426,6 +1453,11 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		e.logger.Tracef("no constraints found for key %q", globalKey)
 		return description.ConstraintsArgs{}, nil
 	}
+	// The migration format cannot yet hold a bandwidth constraint,
+	// and dropping it would change what the target provisions.
+	if doc["bandwidth"] != nil {
+		return description.ConstraintsArgs{}, errors.NotSupportedf("exporting bandwidth constraint for %q", globalKey)
+	}
 	// We capture any type error using a closure to avoid having to return
 	// multiple values from the optional functions. This does mean that we will
 	// only report on the last one, but that is fine as there shouldn't be any.
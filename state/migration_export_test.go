@@ -375,6 +375,60 @@ func (s *MigrationExportSuite) TestApplicationsWithVirtConstraint(c *gc.C) {
 	s.assertMigrateApplications(c, constraints.MustParse("arch=amd64 mem=8G virt-type=kvm"))
 }
 
+func (s *MigrationExportSuite) TestBandwidthConstraint(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Constraints: constraints.MustParse("bandwidth=1000"),
+	})
+
+	_, err := s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*exporting bandwidth constraint for "a#mysql" not supported`)
+}
+
+func (s *MigrationExportSuite) TestApplicationExposedToCIDRs(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetExposedToCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*exporting application "mysql" exposed to specific CIDRs not supported`)
+}
+
+func (s *MigrationExportSuite) TestTrustedApplication(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetTrust(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*exporting trusted application "mysql" not supported`)
+}
+
+func (s *MigrationExportSuite) TestApplicationWithAddressPolicy(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetAddressPolicy(state.AddressPolicy{Prefer: state.AddressKindProvider})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*exporting application "mysql" with an address policy not supported`)
+}
+
+func (s *MigrationExportSuite) TestApplicationWithFloatingIP(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetFloatingIP("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*exporting application "mysql" with a floating IP not supported`)
+}
+
+func (s *MigrationExportSuite) TestApplicationWithLogging(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetLogging(state.ApplicationLogging{DedicatedFile: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*exporting application "mysql" with logging settings not supported`)
+}
+
 func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constraints.Value) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Settings: map[string]interface{}{
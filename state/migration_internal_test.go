@@ -95,6 +95,27 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// The charm mirror is controller global, not migrated.
+		charmMirrorC,
+		// The history of model config changes is not migrated;
+		// the model config itself is.
+		modelConfigHistoryC,
+		// The change log is not migrated; it records the changes
+		// made through the source controller.
+		changeLogC,
+		// Action schedules are not migrated; they are run by the
+		// source controller, and may be added again after the
+		// migration.
+		actionSchedulesC,
+		// Charm locks are not migrated; they are short-lived, and
+		// their holders acquire them again if the migration
+		// interrupts their operation.
+		charmLocksC,
+		// Reports of forced application removals are not migrated.
+		applicationRemovalsC,
+		// Volume snapshots refer to resources of the source cloud,
+		// and are not yet migrated.
+		volumeSnapshotsC,
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
@@ -179,6 +200,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Metrics manager maintains controller specific state relating to
 		// the store and forward of charm metrics. Nothing to migrate here.
 		metricsManagerC,
+
+		// There is a precheck, and the export refuses, if any
+		// application is trusted with the cloud credential, so there
+		// is no trust to migrate.
+		applicationTrustC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -192,6 +218,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		externalControllersC,
 		relationNetworksC,
 		firewallRulesC,
+
+		// CAAS - TODO
+		cloudContainersC,
 	)
 
 	envCollections := set.NewStrings()
@@ -233,6 +262,20 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"SLA",
 		"MeterStatus",
 		"EnvironVersion",
+		// A freeze is an operational measure taken on the
+		// source controller, and is not migrated.
+		"Freeze",
+		// A suspension is undone by resuming the model on the
+		// source controller, and is not migrated.
+		"Suspension",
+		// Expiry is a policy of the source controller, and is
+		// not migrated.
+		"Expiry",
+		// The migration format does not yet hold descriptions.
+		"Description",
+		// ForceDestroyed is only set on a dying model, which
+		// cannot be migrated.
+		"ForceDestroyed",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -295,6 +338,11 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// ImageStream and ImageId are only used to provision the
+		// machine's instance, and migrated machines have already
+		// been provisioned.
+		"ImageStream",
+		"ImageId",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -323,6 +371,9 @@ func (s *MigrationSuite) TestInstanceDataFields(c *gc.C) {
 		// KeepInstance is only set when a machine is
 		// dying/dead (to be removed).
 		"KeepInstance",
+		// Bandwidth is not yet supported by the model
+		// description, and is lost on migration.
+		"Bandwidth",
 	)
 	migrated := set.NewStrings(
 		// DocID is the env + machine id
@@ -360,6 +411,22 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// The migration format does not yet hold descriptions.
+		"Description",
+		// Applications with address policies, floating IPs or
+		// logging settings are refused by the export, as the
+		// format cannot hold them. The floating IP's unit is only
+		// set along with the floating IP.
+		"AddressPolicy",
+		"FloatingIP",
+		"FloatingIPUnit",
+		"Logging",
+		// The leader reports its config ready again after
+		// migration, when it next runs config-changed.
+		"ConfigReadyHash",
+		// Applications exposed to specific CIDRs are refused
+		// by the export, as the format cannot hold them.
+		"ExposedCIDRs",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -538,6 +605,9 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		// Bandwidth constraints are refused by the export,
+		// as the format cannot hold them.
+		"Bandwidth",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -670,6 +740,10 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress reporting is only of interest while an
+		// action runs, and is not migrated.
+		"Logs",
+		"Progress",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -691,8 +765,9 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"MachineId", // recreated from pool properties
-		"Releasing", // only when dying; can't migrate dying storage
+		"MachineId",     // recreated from pool properties
+		"Releasing",     // only when dying; can't migrate dying storage
+		"RequestedSize", // the provisioner completes the resize
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -758,6 +833,7 @@ func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
+		"Usage", // reported again by the machine agent
 	)
 	migrated := set.NewStrings(
 		"Filesystem",
@@ -113,6 +113,23 @@ type modelDoc struct {
 
 	// MeterStatus is the current meter status of the model.
 	MeterStatus modelMeterStatusdoc `bson:"meter-status"`
+
+	// Freeze records the read-only freeze of the model, if any.
+	Freeze *modelFreezeDoc `bson:"freeze,omitempty"`
+
+	// Suspension records the suspension of the model, if any.
+	Suspension *modelSuspensionDoc `bson:"suspension,omitempty"`
+
+	// Expiry records when the model is to be destroyed, if ever.
+	Expiry *modelExpiryDoc `bson:"expiry,omitempty"`
+
+	// Description is free text describing the model, such as who
+	// owns it and what it is for.
+	Description string `bson:"description,omitempty"`
+
+	// ForceDestroyed records that the model's cloud resources should
+	// be removed even if they are still in use.
+	ForceDestroyed bool `bson:"force-destroyed,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -619,12 +636,54 @@ func (m *Model) Life() Life {
 	return m.doc.Life
 }
 
+// ForceDestroyed reports whether the model was destroyed with
+// DestroyModelParams.Force set, in which case its cloud resources
+// should be removed even if they are still in use.
+func (m *Model) ForceDestroyed() bool {
+	return m.doc.ForceDestroyed
+}
+
 // Owner returns tag representing the owner of the model.
 // The owner is the user that created the model.
 func (m *Model) Owner() names.UserTag {
 	return names.NewUserTag(m.doc.Owner)
 }
 
+// Description returns the model's free-text description.
+func (m *Model) Description() string {
+	return m.doc.Description
+}
+
+// SetDescription sets the model's free-text description. An empty
+// description removes it.
+func (m *Model) SetDescription(description string) error {
+	if err := validateDescription(description); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"description", description}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot set description of model %q", m.doc.Name)
+	}
+	m.doc.Description = description
+	return nil
+}
+
+// maxDescriptionLength is the length, in bytes, of the longest
+// description of a model or application.
+const maxDescriptionLength = 1024
+
+func validateDescription(description string) error {
+	if len(description) > maxDescriptionLength {
+		return errors.NotValidf("description longer than %d bytes", maxDescriptionLength)
+	}
+	return nil
+}
+
 // Status returns the status of the model.
 func (m *Model) Status() (status.StatusInfo, error) {
 	status, err := getStatus(m.st.db(), m.globalKey(), "model")
@@ -923,6 +982,13 @@ type DestroyModelParams struct {
 	// models), an error satisfying IsHasPersistentStorageError
 	// will be returned.
 	DestroyStorage *bool
+
+	// Force controls whether or not the model's cloud resources
+	// are removed even if they are still in use, for example
+	// security groups attached to ports the model does not own.
+	// A model that is already being destroyed may be destroyed
+	// again with Force set.
+	Force bool
 }
 
 func (m *Model) uniqueIndexID() string {
@@ -954,6 +1020,14 @@ func (m *Model) Destroy(args DestroyModelParams) (err error) {
 
 		ops, err := m.destroyOps(args, false, false)
 		if err == errModelNotAlive {
+			if args.Force && !m.doc.ForceDestroyed {
+				return []txn.Op{{
+					C:      modelsC,
+					Id:     m.UUID(),
+					Assert: txn.DocExists,
+					Update: bson.D{{"$set", bson.D{{"force-destroyed", true}}}},
+				}}, nil
+			}
 			return nil, jujutxn.ErrNoOperations
 		} else if err != nil {
 			return nil, errors.Trace(err)
@@ -1205,6 +1279,11 @@ func (m *Model) destroyOps(
 		{"life", nextLife},
 		{"time-of-dying", timeOfDying},
 	}
+	if args.Force {
+		modelUpdateValues = append(modelUpdateValues, bson.DocElem{
+			"force-destroyed", true,
+		})
+	}
 	var ops []txn.Op
 	if nextLife == Dead {
 		modelUpdateValues = append(modelUpdateValues, bson.DocElem{
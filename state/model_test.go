@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
@@ -46,6 +47,28 @@ func (s *ModelSuite) TestModel(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
 }
 
+func (s *ModelSuite) TestDescription(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Description(), gc.Equals, "")
+
+	err = model.SetDescription("staging for the web team")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Description(), gc.Equals, "staging for the web team")
+
+	model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Description(), gc.Equals, "staging for the web team")
+}
+
+func (s *ModelSuite) TestSetDescriptionTooLong(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetDescription(strings.Repeat("x", 1025))
+	c.Assert(err, gc.ErrorMatches, "description longer than 1024 bytes not valid")
+	c.Assert(model.Description(), gc.Equals, "")
+}
+
 func (s *ModelSuite) TestModelDestroy(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -789,6 +812,39 @@ func (s *ModelSuite) TestDestroyModelNonEmpty(c *gc.C) {
 	c.Assert(m.UniqueIndexExists(), jc.IsTrue)
 }
 
+func (s *ModelSuite) TestDestroyModelForce(c *gc.C) {
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	m, err := st2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.ForceDestroyed(), jc.IsFalse)
+
+	c.Assert(m.Destroy(state.DestroyModelParams{Force: true}), jc.ErrorIsNil)
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+	c.Assert(m.Life(), gc.Equals, state.Dead)
+	c.Assert(m.ForceDestroyed(), jc.IsTrue)
+}
+
+func (s *ModelSuite) TestDestroyModelForceAlreadyDying(c *gc.C) {
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeApplication(c, nil)
+
+	c.Assert(m.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+	c.Assert(m.Life(), gc.Equals, state.Dying)
+	c.Assert(m.ForceDestroyed(), jc.IsFalse)
+
+	// Destroying the model again with force records it.
+	c.Assert(m.Destroy(state.DestroyModelParams{Force: true}), jc.ErrorIsNil)
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+	c.Assert(m.Life(), gc.Equals, state.Dying)
+	c.Assert(m.ForceDestroyed(), jc.IsTrue)
+
+	// A further forced destroy is a no-op.
+	c.Assert(m.Destroy(state.DestroyModelParams{Force: true}), jc.ErrorIsNil)
+}
+
 func (s *ModelSuite) TestDestroyModelPersistentStorage(c *gc.C) {
 	m, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -280,6 +280,12 @@ type ValidateConfigFunc func(updateAttrs map[string]interface{}, removeAttrs []s
 // configuration of the model with the provided updateAttrs and
 // removeAttrs.
 func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) error {
+	return st.updateModelConfig("", updateAttrs, removeAttrs, additionalValidation...)
+}
+
+// updateModelConfig updates the model config, recording the change as
+// having been made by the named user.
+func (st *State) updateModelConfig(user string, updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) error {
 	if len(updateAttrs)+len(removeAttrs) == 0 {
 		return nil
 	}
@@ -352,8 +358,7 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 	validAttrs = config.CoerceForStorage(validAttrs)
 
 	modelSettings.Update(validAttrs)
-	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	return st.writeModelConfig(modelSettings, user)
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -0,0 +1,268 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// ModelConfigRevision records a change made to a model's config.
+type ModelConfigRevision struct {
+	// Revision is the revision of the model config resulting
+	// from the change. Revisions start at 1; revision 0 is the
+	// model config before any recorded change.
+	Revision int
+
+	// User is the name of the user that made the change, or
+	// empty if the change was made by Juju itself.
+	User string
+
+	// Time is when the change was made.
+	Time time.Time
+
+	// Changes holds the changed settings, sorted by key.
+	Changes []ItemChange
+}
+
+// modelConfigRevisionDoc is the document recording a change made to
+// a model's config.
+type modelConfigRevisionDoc struct {
+	DocID     string                 `bson:"_id"`
+	ModelUUID string                 `bson:"model-uuid"`
+	Revision  int                    `bson:"revision"`
+	User      string                 `bson:"user"`
+	Time      int64                  `bson:"time"`
+	Changes   []modelConfigChangeDoc `bson:"changes"`
+}
+
+// modelConfigChangeDoc records the change of a single setting.
+type modelConfigChangeDoc struct {
+	Type     int         `bson:"type"`
+	Key      string      `bson:"key"`
+	OldValue interface{} `bson:"old-value,omitempty"`
+	NewValue interface{} `bson:"new-value,omitempty"`
+}
+
+func (doc *modelConfigRevisionDoc) revision() ModelConfigRevision {
+	changes := make([]ItemChange, len(doc.Changes))
+	for i, change := range doc.Changes {
+		changes[i] = ItemChange{
+			Type:     change.Type,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		}
+	}
+	return ModelConfigRevision{
+		Revision: doc.Revision,
+		User:     doc.User,
+		Time:     time.Unix(0, doc.Time).UTC(),
+		Changes:  changes,
+	}
+}
+
+// UpdateModelConfigBy is like UpdateModelConfig, but records the
+// given user as having made the change in the model config history.
+func (st *State) UpdateModelConfigBy(user names.UserTag, updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) error {
+	return st.updateModelConfig(user.Id(), updateAttrs, removeAttrs, additionalValidation...)
+}
+
+// latestModelConfigRevision returns the latest revision of the model
+// config, or 0 if no changes have been recorded.
+func (st *State) latestModelConfigRevision() (int, error) {
+	history, closer := st.db().GetCollection(modelConfigHistoryC)
+	defer closer()
+
+	var doc modelConfigRevisionDoc
+	err := history.Find(nil).Sort("-revision").Select(bson.D{{"revision", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Annotate(err, "cannot read model config history")
+	}
+	return doc.Revision, nil
+}
+
+// writeModelConfig writes the changes made to the model settings,
+// recording them as a new revision of the model config made by the
+// given user.
+func (st *State) writeModelConfig(modelSettings *Settings, user string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := modelSettings.rebase(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		changes, ops := modelSettings.settingsUpdateOps()
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		latest, err := st.latestModelConfigRevision()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revision := latest + 1
+		doc := modelConfigRevisionDoc{
+			DocID:     st.docID(strconv.Itoa(revision)),
+			ModelUUID: st.ModelUUID(),
+			Revision:  revision,
+			User:      user,
+			Time:      st.clock().Now().UnixNano(),
+		}
+		for _, change := range changes {
+			doc.Changes = append(doc.Changes, modelConfigChangeDoc{
+				Type:     change.Type,
+				Key:      change.Key,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+		return append(ops, txn.Op{
+			C:      modelConfigHistoryC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot write model config")
+	}
+	modelSettings.disk = copyMap(modelSettings.core, nil)
+	return nil
+}
+
+// ModelConfigHistory returns the recorded changes to the model's
+// config, oldest first.
+func (st *State) ModelConfigHistory() ([]ModelConfigRevision, error) {
+	history, closer := st.db().GetCollection(modelConfigHistoryC)
+	defer closer()
+
+	var docs []modelConfigRevisionDoc
+	if err := history.Find(nil).Sort("revision").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read model config history")
+	}
+	revisions := make([]ModelConfigRevision, len(docs))
+	for i, doc := range docs {
+		revisions[i] = doc.revision()
+	}
+	return revisions, nil
+}
+
+// modelConfigRevisionsSince returns the recorded changes made to the
+// model's config after the given revision, newest first.
+func (st *State) modelConfigRevisionsSince(revision int) ([]modelConfigRevisionDoc, error) {
+	latest, err := st.latestModelConfigRevision()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if revision < 0 || revision > latest {
+		return nil, errors.NotFoundf("model config revision %d", revision)
+	}
+	history, closer := st.db().GetCollection(modelConfigHistoryC)
+	defer closer()
+
+	var docs []modelConfigRevisionDoc
+	query := bson.D{{"revision", bson.D{{"$gt", revision}}}}
+	if err := history.Find(query).Sort("-revision").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read model config history")
+	}
+	return docs, nil
+}
+
+// modelConfigAtRevision returns the values of the model settings
+// changed since the given revision, as they were at that revision.
+// Settings that did not exist at the revision are returned in absent.
+func (st *State) modelConfigAtRevision(revision int) (values map[string]interface{}, absent []string, err error) {
+	docs, err := st.modelConfigRevisionsSince(revision)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// Undo the changes, newest first, so that the oldest change
+	// to each setting determines its value at the revision.
+	values = make(map[string]interface{})
+	missing := make(map[string]bool)
+	for _, doc := range docs {
+		for _, change := range doc.Changes {
+			if change.Type == ItemAdded {
+				delete(values, change.Key)
+				missing[change.Key] = true
+			} else {
+				values[change.Key] = change.OldValue
+				delete(missing, change.Key)
+			}
+		}
+	}
+	for key := range missing {
+		absent = append(absent, key)
+	}
+	sort.Strings(absent)
+	return values, absent, nil
+}
+
+// ModelConfigChangesSince returns the differences between the model's
+// config at the given revision and its current config, sorted by key.
+func (st *State) ModelConfigChangesSince(revision int) ([]ItemChange, error) {
+	values, absent, err := st.modelConfigAtRevision(revision)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelSettings, err := readSettings(st.db(), settingsC, modelGlobalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current := modelSettings.Map()
+
+	var changes []ItemChange
+	for key, old := range values {
+		new, ok := current[key]
+		switch {
+		case !ok:
+			changes = append(changes, ItemChange{ItemDeleted, key, old, nil})
+		case !reflect.DeepEqual(old, new):
+			changes = append(changes, ItemChange{ItemModified, key, old, new})
+		}
+	}
+	for _, key := range absent {
+		if new, ok := current[key]; ok {
+			changes = append(changes, ItemChange{ItemAdded, key, nil, new})
+		}
+	}
+	sort.Sort(itemChangeSlice(changes))
+	return changes, nil
+}
+
+// RollbackModelConfig restores the model's config to how it was at
+// the given revision, recording the given user as having made the
+// change. The agent version is never rolled back, as it is changed
+// by upgrades.
+func (st *State) RollbackModelConfig(user names.UserTag, revision int, additionalValidation ...ValidateConfigFunc) error {
+	values, absent, err := st.modelConfigAtRevision(revision)
+	if err != nil {
+		return errors.Annotate(err, "cannot roll back model config")
+	}
+	delete(values, config.AgentVersionKey)
+	var remove []string
+	for _, key := range absent {
+		if key != config.AgentVersionKey {
+			remove = append(remove, key)
+		}
+	}
+	if len(values)+len(remove) == 0 {
+		return nil
+	}
+	err = st.updateModelConfig(user.Id(), values, remove, additionalValidation...)
+	return errors.Annotatef(err, "cannot roll back model config to revision %d", revision)
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ModelConfigHistorySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelConfigHistorySuite{})
+
+func (s *ModelConfigHistorySuite) TestHistoryInitiallyEmpty(c *gc.C) {
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *ModelConfigHistorySuite) TestUpdateRecordsRevision(c *gc.C) {
+	err := s.State.UpdateModelConfigBy(names.NewUserTag("bob"), map[string]interface{}{
+		"arbitrary-key":  "shazam!",
+		"logging-config": "<root>=DEBUG",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(nil, []string{"arbitrary-key"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+
+	c.Assert(history[0].Revision, gc.Equals, 1)
+	c.Assert(history[0].User, gc.Equals, "bob")
+	c.Assert(history[0].Time.IsZero(), jc.IsFalse)
+	c.Assert(history[0].Changes, gc.HasLen, 2)
+	c.Assert(history[0].Changes[0], jc.DeepEquals, state.ItemChange{
+		Type:     state.ItemAdded,
+		Key:      "arbitrary-key",
+		NewValue: "shazam!",
+	})
+	c.Assert(history[0].Changes[1].Key, gc.Equals, "logging-config")
+	c.Assert(history[0].Changes[1].NewValue, gc.Equals, "<root>=DEBUG")
+
+	c.Assert(history[1].Revision, gc.Equals, 2)
+	c.Assert(history[1].User, gc.Equals, "")
+	c.Assert(history[1].Changes, jc.DeepEquals, []state.ItemChange{{
+		Type:     state.ItemDeleted,
+		Key:      "arbitrary-key",
+		OldValue: "shazam!",
+	}})
+}
+
+func (s *ModelConfigHistorySuite) TestUpdateWithoutChangesNotRecorded(c *gc.C) {
+	cfg, err := s.IAASModel.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"logging-config": cfg.AllAttrs()["logging-config"],
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *ModelConfigHistorySuite) TestChangesSince(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "shazam!"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"arbitrary-key": "kazam!",
+		"other-key":     "foo",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err := s.State.ModelConfigChangesSince(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 0)
+
+	changes, err = s.State.ModelConfigChangesSince(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []state.ItemChange{{
+		Type:     state.ItemModified,
+		Key:      "arbitrary-key",
+		OldValue: "shazam!",
+		NewValue: "kazam!",
+	}, {
+		Type:     state.ItemAdded,
+		Key:      "other-key",
+		NewValue: "foo",
+	}})
+
+	changes, err = s.State.ModelConfigChangesSince(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []state.ItemChange{{
+		Type:     state.ItemAdded,
+		Key:      "arbitrary-key",
+		NewValue: "kazam!",
+	}, {
+		Type:     state.ItemAdded,
+		Key:      "other-key",
+		NewValue: "foo",
+	}})
+}
+
+func (s *ModelConfigHistorySuite) TestChangesSinceUnknownRevision(c *gc.C) {
+	_, err := s.State.ModelConfigChangesSince(1)
+	c.Assert(err, gc.ErrorMatches, "model config revision 1 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelConfigHistorySuite) TestRollback(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "shazam!"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"arbitrary-key": "kazam!",
+		"other-key":     "foo",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RollbackModelConfig(names.NewUserTag("bob"), 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.IAASModel.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	attrs := cfg.AllAttrs()
+	c.Assert(attrs["arbitrary-key"], gc.Equals, "shazam!")
+	_, ok := attrs["other-key"]
+	c.Assert(ok, jc.IsFalse)
+
+	// The rollback is itself recorded as a revision.
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Assert(history[2].User, gc.Equals, "bob")
+	changes, err := s.State.ModelConfigChangesSince(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (s *ModelConfigHistorySuite) TestRollbackUnknownRevision(c *gc.C) {
+	err := s.State.RollbackModelConfig(names.NewUserTag("bob"), 3)
+	c.Assert(err, gc.ErrorMatches, "cannot roll back model config: model config revision 3 not found")
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelExpiry describes when a temporary model is to be destroyed.
+type ModelExpiry struct {
+	// Deadline is when the model is destroyed.
+	Deadline time.Time
+
+	// DestroyStorage records whether the model's persistent storage
+	// is destroyed or released along with it. If nil, a model with
+	// persistent storage is not destroyed, and the failure is
+	// reported in the model's status.
+	DestroyStorage *bool
+
+	// Warned records whether the model's owner has been warned of
+	// the impending expiry.
+	Warned bool
+}
+
+type modelExpiryDoc struct {
+	Deadline       time.Time `bson:"deadline"`
+	DestroyStorage *bool     `bson:"destroy-storage,omitempty"`
+	Warned         bool      `bson:"warned"`
+}
+
+// Expiry returns the expiry of the model, and whether the model
+// expires at all.
+func (m *Model) Expiry() (ModelExpiry, bool) {
+	doc := m.doc.Expiry
+	if doc == nil {
+		return ModelExpiry{}, false
+	}
+	return ModelExpiry{
+		Deadline:       doc.Deadline,
+		DestroyStorage: doc.DestroyStorage,
+		Warned:         doc.Warned,
+	}, true
+}
+
+// SetExpiry records that the model is to be destroyed at the given
+// deadline. Setting the expiry of a model that already expires
+// replaces its expiry, so that the owner is warned again.
+func (m *Model) SetExpiry(deadline time.Time, destroyStorage *bool) error {
+	doc := &modelExpiryDoc{
+		Deadline:       deadline.UTC().Round(time.Second),
+		DestroyStorage: destroyStorage,
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"expiry", doc}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("model %q is no longer alive", m.Name())
+	} else if err != nil {
+		return errors.Annotate(err, "cannot set model expiry")
+	}
+	return m.Refresh()
+}
+
+// SetExpiryWarned records that the model's owner has been warned of
+// its impending expiry.
+func (m *Model) SetExpiryWarned() error {
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: bson.D{{"expiry", bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$set", bson.D{{"expiry.warned", true}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("expiry of model %q", m.Name())
+	} else if err != nil {
+		return errors.Annotate(err, "cannot record model expiry warning")
+	}
+	return m.Refresh()
+}
+
+// ClearExpiry removes the expiry of the model, if any.
+func (m *Model) ClearExpiry() error {
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"expiry", nil}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot clear model expiry")
+	}
+	return m.Refresh()
+}
+
+// ExpiringModelUUIDs returns the UUIDs of the alive models that have
+// an expiry.
+func (st *State) ExpiringModelUUIDs() ([]string, error) {
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+
+	var docs []bson.M
+	err := models.Find(bson.D{
+		{"expiry", bson.D{{"$exists", true}}},
+		{"life", Alive},
+	}).Select(bson.M{"_id": 1}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying expiring models")
+	}
+	out := make([]string, len(docs))
+	for i, doc := range docs {
+		out[i] = doc["_id"].(string)
+	}
+	return out, nil
+}
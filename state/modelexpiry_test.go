@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ModelExpirySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelExpirySuite{})
+
+func (s *ModelExpirySuite) TestNoExpiry(c *gc.C) {
+	_, expires := s.Model.Expiry()
+	c.Assert(expires, jc.IsFalse)
+
+	uuids, err := s.State.ExpiringModelUUIDs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, gc.HasLen, 0)
+}
+
+func (s *ModelExpirySuite) TestSetExpiry(c *gc.C) {
+	deadline := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	destroyStorage := true
+	err := s.Model.SetExpiry(deadline, &destroyStorage)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	expiry, expires := model.Expiry()
+	c.Assert(expires, jc.IsTrue)
+	c.Assert(expiry.Deadline.Equal(deadline), jc.IsTrue)
+	c.Assert(expiry.DestroyStorage, gc.NotNil)
+	c.Assert(*expiry.DestroyStorage, jc.IsTrue)
+	c.Assert(expiry.Warned, jc.IsFalse)
+
+	uuids, err := s.State.ExpiringModelUUIDs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, jc.DeepEquals, []string{s.Model.UUID()})
+}
+
+func (s *ModelExpirySuite) TestSetExpiryWarned(c *gc.C) {
+	err := s.Model.SetExpiryWarned()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.Model.SetExpiry(time.Now(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetExpiryWarned()
+	c.Assert(err, jc.ErrorIsNil)
+	expiry, _ := s.Model.Expiry()
+	c.Assert(expiry.Warned, jc.IsTrue)
+	c.Assert(expiry.DestroyStorage, gc.IsNil)
+
+	// Setting a new expiry warns the owner again.
+	err = s.Model.SetExpiry(time.Now().Add(time.Hour), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	expiry, _ = s.Model.Expiry()
+	c.Assert(expiry.Warned, jc.IsFalse)
+}
+
+func (s *ModelExpirySuite) TestClearExpiry(c *gc.C) {
+	err := s.Model.SetExpiry(time.Now(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.ClearExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	_, expires := s.Model.Expiry()
+	c.Assert(expires, jc.IsFalse)
+
+	uuids, err := s.State.ExpiringModelUUIDs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, gc.HasLen, 0)
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelFreeze describes a read-only freeze of a model. While a model
+// is frozen, users may not make changes to it through the API, but
+// agents continue to operate it.
+type ModelFreeze struct {
+	// Message is shown to users whose changes are rejected.
+	Message string
+
+	// By is the user that froze the model.
+	By names.UserTag
+
+	// Since is when the model was frozen.
+	Since time.Time
+}
+
+type modelFreezeDoc struct {
+	Message string    `bson:"message"`
+	By      string    `bson:"by"`
+	Since   time.Time `bson:"since"`
+}
+
+// Freeze returns the read-only freeze of the model, and whether the
+// model is frozen.
+func (m *Model) Freeze() (ModelFreeze, bool) {
+	doc := m.doc.Freeze
+	if doc == nil {
+		return ModelFreeze{}, false
+	}
+	return ModelFreeze{
+		Message: doc.Message,
+		By:      names.NewUserTag(doc.By),
+		Since:   doc.Since,
+	}, true
+}
+
+// SetFreeze makes the model read-only for users, showing the given
+// message to those whose changes are rejected. Freezing a frozen model
+// replaces its freeze.
+func (m *Model) SetFreeze(by names.UserTag, message string) error {
+	doc := &modelFreezeDoc{
+		Message: message,
+		By:      by.Id(),
+		Since:   m.st.clock().Now().UTC().Round(time.Second),
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"freeze", doc}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("model %q is no longer alive", m.Name())
+	} else if err != nil {
+		return errors.Annotate(err, "cannot freeze model")
+	}
+	return m.Refresh()
+}
+
+// ClearFreeze removes the read-only freeze of the model, if any.
+func (m *Model) ClearFreeze() error {
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"freeze", nil}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot unfreeze model")
+	}
+	return m.Refresh()
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+)
+
+type ModelFreezeSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelFreezeSuite{})
+
+func (s *ModelFreezeSuite) TestNotFrozen(c *gc.C) {
+	_, frozen := s.Model.Freeze()
+	c.Assert(frozen, jc.IsFalse)
+}
+
+func (s *ModelFreezeSuite) TestSetFreeze(c *gc.C) {
+	err := s.Model.SetFreeze(names.NewUserTag("bob"), "incident 42")
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	freeze, frozen := model.Freeze()
+	c.Assert(frozen, jc.IsTrue)
+	c.Assert(freeze.Message, gc.Equals, "incident 42")
+	c.Assert(freeze.By, gc.Equals, names.NewUserTag("bob"))
+	c.Assert(freeze.Since.IsZero(), jc.IsFalse)
+}
+
+func (s *ModelFreezeSuite) TestClearFreeze(c *gc.C) {
+	err := s.Model.SetFreeze(names.NewUserTag("bob"), "incident 42")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.ClearFreeze()
+	c.Assert(err, jc.ErrorIsNil)
+	_, frozen := s.Model.Freeze()
+	c.Assert(frozen, jc.IsFalse)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, frozen = model.Freeze()
+	c.Assert(frozen, jc.IsFalse)
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/instance"
+)
+
+// ModelSuspension describes the suspension of a model. While a model
+// is suspended its instances are stopped, and users may not make
+// changes to it through the API until it is resumed.
+type ModelSuspension struct {
+	// By is the user that suspended the model.
+	By names.UserTag
+
+	// Since is when the model was suspended.
+	Since time.Time
+
+	// Instances holds the IDs of the instances that were stopped
+	// when the model was suspended, and which are to be started
+	// again when it is resumed.
+	Instances []instance.Id
+}
+
+type modelSuspensionDoc struct {
+	By        string    `bson:"by"`
+	Since     time.Time `bson:"since"`
+	Instances []string  `bson:"instances,omitempty"`
+}
+
+// Suspension returns the suspension of the model, and whether the
+// model is suspended.
+func (m *Model) Suspension() (ModelSuspension, bool) {
+	doc := m.doc.Suspension
+	if doc == nil {
+		return ModelSuspension{}, false
+	}
+	ids := make([]instance.Id, len(doc.Instances))
+	for i, id := range doc.Instances {
+		ids[i] = instance.Id(id)
+	}
+	return ModelSuspension{
+		By:        names.NewUserTag(doc.By),
+		Since:     doc.Since,
+		Instances: ids,
+	}, true
+}
+
+// SetSuspension records that the model has been suspended by the given
+// user, and that the instances with the given IDs are to be started
+// again when it is resumed. Suspending a suspended model fails.
+func (m *Model) SetSuspension(by names.UserTag, ids []instance.Id) error {
+	instances := make([]string, len(ids))
+	for i, id := range ids {
+		instances[i] = string(id)
+	}
+	doc := &modelSuspensionDoc{
+		By:        by.Id(),
+		Since:     m.st.clock().Now().UTC().Round(time.Second),
+		Instances: instances,
+	}
+	ops := []txn.Op{{
+		C:  modelsC,
+		Id: m.doc.UUID,
+		Assert: append(isAliveDoc, bson.DocElem{
+			"suspension", bson.D{{"$exists", false}},
+		}),
+		Update: bson.D{{"$set", bson.D{{"suspension", doc}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		if err := m.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		if m.doc.Suspension != nil {
+			return errors.AlreadyExistsf("suspension of model %q", m.Name())
+		}
+		return errors.Errorf("model %q is no longer alive", m.Name())
+	} else if err != nil {
+		return errors.Annotate(err, "cannot suspend model")
+	}
+	return m.Refresh()
+}
+
+// ClearSuspension removes the suspension of the model, if any.
+func (m *Model) ClearSuspension() error {
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"suspension", nil}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot resume model")
+	}
+	return m.Refresh()
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+)
+
+type ModelSuspensionSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelSuspensionSuite{})
+
+func (s *ModelSuspensionSuite) TestNotSuspended(c *gc.C) {
+	_, suspended := s.Model.Suspension()
+	c.Assert(suspended, jc.IsFalse)
+}
+
+func (s *ModelSuspensionSuite) TestSetSuspension(c *gc.C) {
+	ids := []instance.Id{"inst-0", "inst-1"}
+	err := s.Model.SetSuspension(names.NewUserTag("bob"), ids)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	suspension, suspended := model.Suspension()
+	c.Assert(suspended, jc.IsTrue)
+	c.Assert(suspension.By, gc.Equals, names.NewUserTag("bob"))
+	c.Assert(suspension.Since.IsZero(), jc.IsFalse)
+	c.Assert(suspension.Instances, jc.DeepEquals, ids)
+}
+
+func (s *ModelSuspensionSuite) TestSetSuspensionAlreadySuspended(c *gc.C) {
+	err := s.Model.SetSuspension(names.NewUserTag("bob"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetSuspension(names.NewUserTag("mary"), nil)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	suspension, suspended := model.Suspension()
+	c.Assert(suspended, jc.IsTrue)
+	c.Assert(suspension.By, gc.Equals, names.NewUserTag("bob"))
+}
+
+func (s *ModelSuspensionSuite) TestClearSuspension(c *gc.C) {
+	err := s.Model.SetSuspension(names.NewUserTag("bob"), []instance.Id{"inst-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.ClearSuspension()
+	c.Assert(err, jc.ErrorIsNil)
+	_, suspended := s.Model.Suspension()
+	c.Assert(suspended, jc.IsFalse)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, suspended = model.Suspension()
+	c.Assert(suspended, jc.IsFalse)
+}
@@ -105,6 +105,10 @@ func (d *Delta) UnmarshalJSON(data []byte) error {
 		d.Entity = new(BlockInfo)
 	case "action":
 		d.Entity = new(ActionInfo)
+	case "volume":
+		d.Entity = new(VolumeInfo)
+	case "filesystem":
+		d.Entity = new(FilesystemInfo)
 	default:
 		return errors.Errorf("Unexpected entity name %q", entityKind)
 	}
@@ -457,3 +461,58 @@ func (i *ModelInfo) EntityId() EntityId {
 		Id:        i.ModelUUID,
 	}
 }
+
+// MachineAttachmentInfo holds the information about the attachment of
+// a volume or filesystem to a machine. Provisioned is true once the
+// storage has been attached to the machine.
+type MachineAttachmentInfo struct {
+	Provisioned bool `json:"provisioned"`
+	Life        Life `json:"life"`
+}
+
+// VolumeInfo holds the information about a volume that is tracked by
+// multiwatcherStore. Attachments are keyed by machine ID.
+type VolumeInfo struct {
+	ModelUUID   string                           `json:"model-uuid"`
+	Id          string                           `json:"id"`
+	StorageId   string                           `json:"storage-id,omitempty"`
+	Pool        string                           `json:"pool"`
+	Size        uint64                           `json:"size"`
+	Provisioned bool                             `json:"provisioned"`
+	Life        Life                             `json:"life"`
+	Status      StatusInfo                       `json:"status"`
+	Attachments map[string]MachineAttachmentInfo `json:"attachments,omitempty"`
+}
+
+// EntityId returns a unique identifier for a volume across models.
+func (i *VolumeInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "volume",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
+
+// FilesystemInfo holds the information about a filesystem that is
+// tracked by multiwatcherStore. Attachments are keyed by machine ID.
+type FilesystemInfo struct {
+	ModelUUID   string                           `json:"model-uuid"`
+	Id          string                           `json:"id"`
+	StorageId   string                           `json:"storage-id,omitempty"`
+	VolumeId    string                           `json:"volume-id,omitempty"`
+	Pool        string                           `json:"pool"`
+	Size        uint64                           `json:"size"`
+	Provisioned bool                             `json:"provisioned"`
+	Life        Life                             `json:"life"`
+	Status      StatusInfo                       `json:"status"`
+	Attachments map[string]MachineAttachmentInfo `json:"attachments,omitempty"`
+}
+
+// EntityId returns a unique identifier for a filesystem across models.
+func (i *FilesystemInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "filesystem",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
+)
+
+// peerSeedKey returns the settings key under which the seed data of the
+// peer relation with the given id is stored. It shares the prefix of the
+// relation's unit settings, so that it is cleaned up with them when the
+// relation is removed.
+func peerSeedKey(relationId int) string {
+	return fmt.Sprintf("r#%d#seed", relationId)
+}
+
+// IsPeer reports whether the relation is a peer relation.
+func (r *Relation) IsPeer() bool {
+	return len(r.doc.Endpoints) == 1 && r.doc.Endpoints[0].Role == charm.RolePeer
+}
+
+// PeerSeed returns the seed data written to the peer relation by the
+// application's leader. If nothing has been written yet, it returns an
+// empty map; this is not an error.
+func (r *Relation) PeerSeed() (map[string]string, error) {
+	if !r.IsPeer() {
+		return nil, errors.NotValidf("seed data for non-peer relation %q", r)
+	}
+	doc, err := readSettingsDoc(r.st.db(), settingsC, peerSeedKey(r.Id()))
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for escapedKey, interfaceValue := range doc.Settings {
+		key := unescapeReplacer.Replace(escapedKey)
+		if value, _ := interfaceValue.(string); value != "" {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// UpdatePeerSeed updates the seed data of the peer relation with the
+// supplied values, but will fail (with a suitable error) if the supplied
+// Token, which should attest to the leadership of the relation's
+// application, loses validity. Empty values in the supplied map will be
+// cleared in the database.
+//
+// Seed data lets the leader publish the data that its peers need to
+// join a cluster, such as a bootstrap token, before any of them have
+// joined the relation: every unit of the application can read it as
+// soon as it enters the relation's scope.
+func (r *Relation) UpdatePeerSeed(token leadership.Token, updates map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update seed data for relation %q", r)
+	if !r.IsPeer() {
+		return errors.NotValidf("seed data for non-peer relation")
+	}
+	key := peerSeedKey(r.Id())
+	sets := bson.M{}
+	unsets := bson.M{}
+	for unescapedKey, value := range updates {
+		key := escapeReplacer.Replace(unescapedKey)
+		if value == "" {
+			unsets[key] = 1
+		} else {
+			sets[key] = value
+		}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.Life() != Alive {
+			return nil, errors.New("relation is not alive")
+		}
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		doc, err := readSettingsDoc(r.st.db(), settingsC, key)
+		if errors.IsNotFound(err) {
+			if len(sets) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return append(ops, txn.Op{
+				C:      settingsC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: &settingsDoc{Settings: settingsMap(sets)},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isNullSettingsChange(doc.Settings, sets, unsets) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update, _ := settingsUpdate(doc.Settings, sets, unsets)
+		return append(ops, txn.Op{
+			C:      settingsC,
+			Id:     key,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: update,
+		}), nil
+	}
+	return r.st.db().Run(buildTxnWithLeadership(buildTxn, token))
+}
+
+// isNullSettingsChange reports whether setting and unsetting the
+// supplied keys would leave the settings unchanged.
+func isNullSettingsChange(current map[string]interface{}, sets, unsets bson.M) bool {
+	for key := range unsets {
+		if _, found := current[key]; found {
+			return false
+		}
+	}
+	for key, value := range sets {
+		if current[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type PeerSeedSuite struct {
+	ConnSuite
+	riak *state.Application
+	ring *state.Relation
+}
+
+var _ = gc.Suite(&PeerSeedSuite{})
+
+func (s *PeerSeedSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.riak = s.AddTestingApplication(c, "riak", s.AddTestingCharm(c, "riak"))
+	rels, err := s.riak.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	s.ring = rels[0]
+}
+
+func (s *PeerSeedSuite) TestIsPeer(c *gc.C) {
+	c.Assert(s.ring.IsPeer(), jc.IsTrue)
+	rel := s.addNonPeerRelation(c)
+	c.Assert(rel.IsPeer(), jc.IsFalse)
+}
+
+func (s *PeerSeedSuite) TestReadEmpty(c *gc.C) {
+	s.checkSeed(c, map[string]string{})
+}
+
+func (s *PeerSeedSuite) TestWrite(c *gc.C) {
+	err := s.ring.UpdatePeerSeed(&fakeToken{}, map[string]string{
+		"token":   "s3cret",
+		"baz.qux": "ping",
+		"pong":    "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkSeed(c, map[string]string{
+		"token":   "s3cret",
+		"baz.qux": "ping",
+	})
+
+	err = s.ring.UpdatePeerSeed(&fakeToken{}, map[string]string{
+		"token": "",
+		"new":   "value",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkSeed(c, map[string]string{
+		"baz.qux": "ping",
+		"new":     "value",
+	})
+}
+
+func (s *PeerSeedSuite) TestWriteOnlyUnsetsWhenEmpty(c *gc.C) {
+	err := s.ring.UpdatePeerSeed(&fakeToken{}, map[string]string{"gone": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkSeed(c, map[string]string{})
+}
+
+func (s *PeerSeedSuite) TestWriteLeadershipFailure(c *gc.C) {
+	err := s.ring.UpdatePeerSeed(&failToken{}, map[string]string{"token": "s3cret"})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot update seed data for relation "riak:ring": prerequisites failed: something bad happened`)
+	s.checkSeed(c, map[string]string{})
+}
+
+func (s *PeerSeedSuite) TestNonPeerRelation(c *gc.C) {
+	rel := s.addNonPeerRelation(c)
+	err := rel.UpdatePeerSeed(&fakeToken{}, map[string]string{"token": "s3cret"})
+	c.Assert(err, gc.ErrorMatches, `cannot update seed data for relation ".*": seed data for non-peer relation not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, err = rel.PeerSeed()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *PeerSeedSuite) TestWriteDyingRelation(c *gc.C) {
+	unit, err := s.riak.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := s.ring.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.ring.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.ring.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.ring.UpdatePeerSeed(&fakeToken{}, map[string]string{"token": "s3cret"})
+	c.Assert(err, gc.ErrorMatches, `cannot update seed data for relation "riak:ring": relation is not alive`)
+}
+
+func (s *PeerSeedSuite) TestRemovedWithRelation(c *gc.C) {
+	err := s.ring.UpdatePeerSeed(&fakeToken{}, map[string]string{"token": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.ring.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ReadSettings("settings", fmt.Sprintf("r#%d#seed", s.ring.Id()))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *PeerSeedSuite) addNonPeerRelation(c *gc.C) *state.Relation {
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *PeerSeedSuite) checkSeed(c *gc.C, expect map[string]string) {
+	actual, err := s.ring.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actual, jc.DeepEquals, expect)
+}
@@ -112,7 +112,7 @@ func (st *State) constraintsValidator() (constraints.Validator, error) {
 		}
 		arches, err := st.CloudImageMetadataStorage.SupportedArchitectures(
 			cloudimagemetadata.MetadataFilter{
-				Stream: cfg.AgentStream(),
+				Stream: cfg.ImageStream(),
 				Region: region,
 			},
 		)
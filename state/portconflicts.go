@@ -0,0 +1,236 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/network"
+)
+
+// PortsConflictError is returned when a unit cannot open a port range
+// because it conflicts with a port range opened by another unit, on
+// the same machine or on a machine sharing its network.
+type PortsConflictError struct {
+	// PortRange is the port range that could not be opened.
+	PortRange PortRange
+
+	// Existing is the conflicting port range, opened by another unit.
+	Existing PortRange
+
+	// MachineID is the ID of the machine on which Existing is open.
+	MachineID string
+}
+
+// Error is part of the error interface.
+func (e *PortsConflictError) Error() string {
+	return fmt.Sprintf(
+		"port range %v conflicts with %v opened by unit %q on machine %q",
+		networkPortRange(e.PortRange), networkPortRange(e.Existing), e.Existing.UnitName, e.MachineID,
+	)
+}
+
+// IsPortsConflictError returns whether the cause of err is a
+// *PortsConflictError.
+func IsPortsConflictError(err error) bool {
+	_, ok := errors.Cause(err).(*PortsConflictError)
+	return ok
+}
+
+func networkPortRange(p PortRange) network.PortRange {
+	return network.PortRange{
+		FromPort: p.FromPort,
+		ToPort:   p.ToPort,
+		Protocol: p.Protocol,
+	}
+}
+
+// portRangesOverlap returns whether the two port ranges have any port
+// of the same protocol in common.
+func portRangesOverlap(a, b PortRange) bool {
+	return a.Protocol == b.Protocol && a.ToPort >= b.FromPort && b.ToPort >= a.FromPort
+}
+
+// subnetsOverlap returns whether ports opened on the two subnets may
+// conflict. Ports opened without a subnet are open on all addresses.
+func subnetsOverlap(a, b string) bool {
+	return a == "" || b == "" || a == b
+}
+
+// networkPeers returns the IDs of the machines whose opened ports share
+// a network with those of the given machine: the machine itself and,
+// where containers share their host's networking, the host and those
+// of its containers.
+func networkPeers(st *State, machineID string) ([]string, error) {
+	peers := set.NewStrings(machineID)
+	hostID := ParentId(machineID)
+	if hostID != "" {
+		container, err := st.Machine(machineID)
+		if errors.IsNotFound(err) {
+			return peers.Values(), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		host, err := st.Machine(hostID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !sharesHostNetworking(container, host) {
+			return peers.Values(), nil
+		}
+		peers.Add(hostID)
+		if err := addNetworkSharingContainers(st, host, peers); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return peers.SortedValues(), nil
+	}
+	host, err := st.Machine(machineID)
+	if errors.IsNotFound(err) {
+		return peers.Values(), nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := addNetworkSharingContainers(st, host, peers); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return peers.SortedValues(), nil
+}
+
+// addNetworkSharingContainers adds the IDs of the host's containers
+// that share its networking to peers.
+func addNetworkSharingContainers(st *State, host *Machine, peers set.Strings) error {
+	containerIDs, err := host.Containers()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, id := range containerIDs {
+		container, err := st.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if sharesHostNetworking(container, host) {
+			peers.Add(id)
+		}
+	}
+	return nil
+}
+
+// sharesHostNetworking returns whether the container shares its host's
+// networking, which is taken to be the case when all of the container's
+// addresses are also addresses of the host.
+func sharesHostNetworking(container, host *Machine) bool {
+	containerAddrs := container.Addresses()
+	if len(containerAddrs) == 0 {
+		return false
+	}
+	hostAddrs := set.NewStrings()
+	for _, addr := range host.Addresses() {
+		hostAddrs.Add(addr.Value)
+	}
+	for _, addr := range containerAddrs {
+		if !hostAddrs.Contains(addr.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// peerPortsDocs returns the ports documents of the machines sharing a
+// network with the given machine.
+func peerPortsDocs(st *State, machineID string) ([]portsDoc, error) {
+	peers, err := networkPeers(st, machineID)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting machines sharing network")
+	}
+	openedPorts, closer := st.db().GetCollection(openedPortsC)
+	defer closer()
+
+	var docs []portsDoc
+	err = openedPorts.Find(bson.D{{"machine-id", bson.D{{"$in", peers}}}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
+// checkPeerPortsConflicts returns a *PortsConflictError if the port
+// range, to be opened in the given ports document, conflicts with one
+// opened by another unit in any other ports document of the machine or
+// of the machines sharing its network. Conflicts within the document
+// itself are checked by the caller.
+func checkPeerPortsConflicts(st *State, pDoc portsDoc, portRange PortRange) error {
+	docs, err := peerPortsDocs(st, pDoc.MachineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range docs {
+		if doc.DocID == pDoc.DocID || !subnetsOverlap(doc.SubnetID, pDoc.SubnetID) {
+			continue
+		}
+		for _, existing := range doc.Ports {
+			if existing.UnitName != portRange.UnitName && portRangesOverlap(existing, portRange) {
+				return &PortsConflictError{
+					PortRange: portRange,
+					Existing:  existing,
+					MachineID: doc.MachineID,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// PortConflicts returns descriptions of the conflicts between the port
+// ranges opened by the unit and those opened by other units, on the
+// unit's machine or on machines sharing its network. Such conflicts are
+// refused when ports are opened, but may remain from before that was
+// the case, or arise when containers come to share their host's
+// networking.
+func (u *Unit) PortConflicts() ([]string, error) {
+	machineID, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	docs, err := peerPortsDocs(u.st, machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	type subnetPortRange struct {
+		subnetID  string
+		portRange PortRange
+	}
+	var own []subnetPortRange
+	for _, doc := range docs {
+		for _, p := range doc.Ports {
+			if p.UnitName == u.Name() {
+				own = append(own, subnetPortRange{doc.SubnetID, p})
+			}
+		}
+	}
+	conflicts := set.NewStrings()
+	for _, doc := range docs {
+		for _, existing := range doc.Ports {
+			if existing.UnitName == u.Name() {
+				continue
+			}
+			for _, p := range own {
+				if subnetsOverlap(p.subnetID, doc.SubnetID) && portRangesOverlap(p.portRange, existing) {
+					err := &PortsConflictError{PortRange: p.portRange, Existing: existing, MachineID: doc.MachineID}
+					conflicts.Add(err.Error())
+				}
+			}
+		}
+	}
+	return conflicts.SortedValues(), nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type PortConflictsSuite struct {
+	ConnSuite
+
+	host      *state.Machine
+	container *state.Machine
+	subnet    *state.Subnet
+}
+
+var _ = gc.Suite(&PortConflictsSuite{})
+
+func (s *PortConflictsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.host, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.container, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.subnet, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "0.1.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.host.SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.container.SetProviderAddresses(network.NewAddress("10.0.3.1"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PortConflictsSuite) makeUnit(c *gc.C, machine *state.Machine) *state.Unit {
+	return s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+}
+
+func (s *PortConflictsSuite) shareHostNetworking(c *gc.C) {
+	err := s.container.SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PortConflictsSuite) TestConflictAcrossSubnets(c *gc.C) {
+	unit1 := s.makeUnit(c, s.host)
+	unit2 := s.makeUnit(c, s.host)
+	err := unit1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit2.OpenPortsOnSubnet(s.subnet.CIDR(), "tcp", 79, 81)
+	c.Assert(err, jc.Satisfies, state.IsPortsConflictError)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports .*: port range 79-81/tcp conflicts with 80/tcp opened by unit "`+unit1.Name()+`" on machine "`+s.host.Id()+`"`)
+}
+
+func (s *PortConflictsSuite) TestNoConflictSameUnitAcrossSubnets(c *gc.C) {
+	unit := s.makeUnit(c, s.host)
+	err := unit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenPortOnSubnet(s.subnet.CIDR(), "tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PortConflictsSuite) TestNoConflictSeparateContainerNetworking(c *gc.C) {
+	hostUnit := s.makeUnit(c, s.host)
+	containerUnit := s.makeUnit(c, s.container)
+	err := hostUnit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = containerUnit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	conflicts, err := containerUnit.PortConflicts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conflicts, gc.HasLen, 0)
+}
+
+func (s *PortConflictsSuite) TestConflictSharedHostNetworking(c *gc.C) {
+	s.shareHostNetworking(c)
+	hostUnit := s.makeUnit(c, s.host)
+	containerUnit := s.makeUnit(c, s.container)
+	err := hostUnit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = containerUnit.OpenPort("tcp", 80)
+	c.Assert(err, jc.Satisfies, state.IsPortsConflictError)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports .*: port range 80/tcp conflicts with 80/tcp opened by unit "`+hostUnit.Name()+`" on machine "`+s.host.Id()+`"`)
+
+	// Different protocols do not conflict.
+	err = containerUnit.OpenPort("udp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PortConflictsSuite) TestPortConflicts(c *gc.C) {
+	hostUnit := s.makeUnit(c, s.host)
+	containerUnit := s.makeUnit(c, s.container)
+	err := hostUnit.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	err = containerUnit.OpenPort("tcp", 85)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The conflict arises when the container comes to share its
+	// host's networking.
+	s.shareHostNetworking(c)
+	conflicts, err := containerUnit.PortConflicts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conflicts, jc.DeepEquals, []string{
+		`port range 85/tcp conflicts with 80-90/tcp opened by unit "` + hostUnit.Name() + `" on machine "` + s.host.Id() + `"`,
+	})
+	conflicts, err = hostUnit.PortConflicts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conflicts, jc.DeepEquals, []string{
+		`port range 80-90/tcp conflicts with 85/tcp opened by unit "` + containerUnit.Name() + `" on machine "` + s.container.Id() + `"`,
+	})
+}
+
+func (s *PortConflictsSuite) TestPortConflictsUnassigned(c *gc.C) {
+	unit, err := s.Factory.MakeApplication(c, nil).AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	conflicts, err := unit.PortConflicts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conflicts, gc.HasLen, 0)
+}
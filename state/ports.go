@@ -53,15 +53,19 @@ func NewPortRange(unitName string, fromPort, toPort int, protocol string) (PortR
 	return p, nil
 }
 
-// Validate checks if the port range is valid.
+// Validate checks if the port range is valid. ICMP port ranges hold
+// an ICMP type and code, as network.PortRange does.
 func (p PortRange) Validate() error {
 	proto := strings.ToLower(p.Protocol)
-	if proto != "tcp" && proto != "udp" {
+	if proto != "tcp" && proto != "udp" && proto != "icmp" {
 		return errors.Errorf("invalid protocol %q", proto)
 	}
 	if !names.IsValidUnit(p.UnitName) {
 		return errors.Errorf("invalid unit %q", p.UnitName)
 	}
+	if proto == "icmp" {
+		return p.networkPortRange().Validate()
+	}
 	if p.FromPort > p.ToPort {
 		return errors.Errorf("invalid port range %d-%d", p.FromPort, p.ToPort)
 	}
@@ -79,9 +83,21 @@ func (a PortRange) Length() int {
 		// Invalid range (from > to or something equally bad)
 		return 0
 	}
+	if strings.ToLower(a.Protocol) == "icmp" {
+		return 1
+	}
 	return (a.ToPort - a.FromPort) + 1
 }
 
+// networkPortRange returns the port range as a network.PortRange.
+func (p PortRange) networkPortRange() network.PortRange {
+	return network.PortRange{
+		FromPort: p.FromPort,
+		ToPort:   p.ToPort,
+		Protocol: strings.ToLower(p.Protocol),
+	}
+}
+
 // Sanitize returns a copy of the port range, which is guaranteed to
 // have FromPort >= ToPort and both FromPort and ToPort fit into the
 // valid range from 1 to 65535, inclusive.
@@ -119,7 +135,7 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	if prA.Protocol != prB.Protocol {
 		return nil
 	}
-	if prA.ToPort >= prB.FromPort && prB.ToPort >= prA.FromPort {
+	if prA.networkPortRange().ConflictsWith(prB.networkPortRange()) {
 		return errors.Errorf("port ranges %v and %v conflict", prA, prB)
 	}
 	return nil
@@ -127,6 +143,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if strings.ToLower(p.Protocol) == "icmp" {
+		return fmt.Sprintf("%s (%q)", p.networkPortRange(), p.UnitName)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
@@ -216,6 +235,11 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 				return nil, statetxn.ErrNoOperations
 			}
 		}
+		// Check for conflicts with ports opened on other subnets, and
+		// on machines sharing this machine's network.
+		if err := checkPeerPortsConflicts(p.st, p.doc, portRange); err != nil {
+			return nil, errors.Trace(err)
+		}
 
 		ops := []txn.Op{
 			assertModelActiveOp(p.st.ModelUUID()),
@@ -542,6 +542,16 @@ func (p *PortRangeSuite) TestPortRangeConflicts(c *gc.C) {
 		MustPortRange("mysql/0", 80, 100, "TCP"),
 		MustPortRange("wordpress/0", 90, 280, "TCP"),
 		"port ranges .* conflict",
+	}, {
+		"different ICMP types",
+		MustPortRange("mysql/0", 3, -1, "icmp"),
+		MustPortRange("wordpress/0", 8, -1, "icmp"),
+		nil,
+	}, {
+		"any ICMP and ICMP type",
+		MustPortRange("mysql/0", -1, -1, "icmp"),
+		MustPortRange("wordpress/0", 8, -1, "icmp"),
+		`port ranges icmp \("mysql/0"\) and 8/icmp \("wordpress/0"\) conflict`,
 	}}
 
 	for i, t := range testCases {
@@ -588,6 +598,10 @@ func (p *PortRangeSuite) TestPortRangeString(c *gc.C) {
 		gc.Equals,
 		`80-100/tcp ("wordpress/0")`,
 	)
+	c.Assert(state.PortRange{"wordpress/0", 3, 4, "icmp"}.String(),
+		gc.Equals,
+		`3:4/icmp ("wordpress/0")`,
+	)
 }
 
 func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
@@ -661,6 +675,21 @@ func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
 		state.PortRange{"wordpress/0", 1, 65535, "tcp"},
 		65535,
 		"",
+	}, {
+		"any ICMP",
+		state.PortRange{"wordpress/0", -1, -1, "icmp"},
+		1,
+		"",
+	}, {
+		"ICMP type and code",
+		state.PortRange{"wordpress/0", 3, 4, "icmp"},
+		1,
+		"",
+	}, {
+		"ICMP code without type",
+		state.PortRange{"wordpress/0", -1, 4, "icmp"},
+		0,
+		"invalid ICMP type -1 and code 4",
 	}}
 
 	for i, t := range testCases {
@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// RelationPreview describes the effect that adding or removing a
+// relation would have, without making any changes.
+type RelationPreview struct {
+	// Key is the key of the relation.
+	Key string
+
+	// Endpoints holds the endpoints of the relation. When adding a
+	// relation, their scope is the scope the relation would have.
+	Endpoints []Endpoint
+
+	// Units holds the names of the units that would enter the
+	// relation's scope when it is added, or that would leave it when
+	// it is removed. When a container-scoped relation is added, each
+	// principal unit listed also gains a subordinate unit, which
+	// enters scope alongside it.
+	Units []string
+
+	// Problems describes why the relation could not be added or
+	// removed. The change would succeed only if it is empty.
+	Problems []string
+}
+
+// PreviewAddRelation reports whether a relation between the given
+// endpoints could be added, and which units would enter its scope.
+// The checks made are those made by AddRelation, along with the
+// relation limits declared by the endpoints' charms; problems found
+// are reported in the preview rather than returned as errors.
+func (st *State) PreviewAddRelation(eps ...Endpoint) (*RelationPreview, error) {
+	if len(eps) != 2 {
+		return nil, errors.Errorf("relation must have two endpoints")
+	}
+	eps = append([]Endpoint(nil), eps...)
+	preview := &RelationPreview{
+		Key:       relationKey(eps),
+		Endpoints: eps,
+	}
+	problemf := func(format string, args ...interface{}) {
+		preview.Problems = append(preview.Problems, fmt.Sprintf(format, args...))
+	}
+	if !eps[0].CanRelateTo(eps[1]) {
+		problemf("endpoints do not relate")
+	}
+
+	apps := make([]ApplicationEntity, len(eps))
+	for i, ep := range eps {
+		app, err := applicationByName(st, ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			problemf("application %q does not exist", ep.ApplicationName)
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			problemf("application %q is not alive", ep.ApplicationName)
+		}
+		apps[i] = app
+	}
+	if apps[0] == nil || apps[1] == nil {
+		return preview, nil
+	}
+	if apps[0].IsRemote() && apps[1].IsRemote() {
+		problemf("cannot add relation between remote applications %q and %q",
+			eps[0].ApplicationName, eps[1].ApplicationName)
+		return preview, nil
+	}
+	remoteRelation := apps[0].IsRemote() || apps[1].IsRemote()
+	ep0ok := apps[0].IsRemote() || eps[0].Scope == charm.ScopeGlobal
+	ep1ok := apps[1].IsRemote() || eps[1].Scope == charm.ScopeGlobal
+	if remoteRelation && (!ep0ok || !ep1ok) {
+		problemf("local endpoint must be globally scoped for remote relations")
+	}
+
+	matchSeries := true
+	if eps[0].Scope == charm.ScopeContainer {
+		eps[1].Scope = charm.ScopeContainer
+	} else if eps[1].Scope == charm.ScopeContainer {
+		eps[0].Scope = charm.ScopeContainer
+	} else {
+		matchSeries = false
+	}
+	if exists, err := isNotDead(st, relationsC, preview.Key); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		problemf("relation %q already exists", preview.Key)
+	}
+
+	var subordinateCount int
+	series := make(map[string]bool)
+	var localApps []*Application
+	for i, ep := range eps {
+		app, ok := apps[i].(*Application)
+		if !ok {
+			continue
+		}
+		localApps = append(localApps, app)
+		if app.doc.Subordinate {
+			subordinateCount++
+		}
+		series[app.doc.Series] = true
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !ep.ImplementedBy(ch) {
+			problemf("%q does not implement %q", ep.ApplicationName, ep)
+		}
+		if err := checkRelationLimit(app, ep); err != nil {
+			problemf("%v", err)
+		}
+	}
+	if matchSeries && len(series) != 1 {
+		problemf("principal and subordinate applications' series must match")
+	}
+	if eps[0].Scope == charm.ScopeContainer && subordinateCount < 1 {
+		problemf("container scoped relation requires at least one subordinate application")
+	}
+	if len(preview.Problems) > 0 {
+		return preview, nil
+	}
+
+	for _, app := range localApps {
+		if eps[0].Scope == charm.ScopeContainer && app.doc.Subordinate {
+			// Subordinate units enter a container scope when they
+			// are created alongside the principal units.
+			continue
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			if unit.Life() == Alive {
+				preview.Units = append(preview.Units, unit.Name())
+			}
+		}
+	}
+	sort.Strings(preview.Units)
+	return preview, nil
+}
+
+// checkRelationLimit returns an error if the application's endpoint
+// already takes part in as many relations as its charm allows.
+func checkRelationLimit(app *Application, ep Endpoint) error {
+	if ep.Limit <= 0 {
+		return nil
+	}
+	relations, err := app.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	count := 0
+	for _, rel := range relations {
+		if rel.Life() != Alive {
+			continue
+		}
+		relEp, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if relEp.Name == ep.Name {
+			count++
+		}
+	}
+	if count >= ep.Limit {
+		return errors.Errorf("%q already has %d of at most %d relations", ep, count, ep.Limit)
+	}
+	return nil
+}
+
+// PreviewDestroy reports whether the relation could be removed, and
+// which units would leave its scope.
+func (r *Relation) PreviewDestroy() (*RelationPreview, error) {
+	preview := &RelationPreview{
+		Key:       r.doc.Key,
+		Endpoints: r.Endpoints(),
+	}
+	if r.doc.Life != Alive {
+		preview.Problems = append(preview.Problems, fmt.Sprintf("relation %q is already %s", r.doc.Key, r.doc.Life))
+		return preview, nil
+	}
+	relationScopes, closer := r.st.db().GetCollection(relationScopesC)
+	defer closer()
+	var docs []relationScopeDoc
+	sel := bson.D{
+		{"key", bson.D{{"$regex", "^" + r.globalScope() + "#"}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read relation scopes")
+	}
+	for _, doc := range docs {
+		preview.Units = append(preview.Units, doc.unitName())
+	}
+	sort.Strings(preview.Units)
+	return preview, nil
+}
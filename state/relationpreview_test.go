@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type RelationPreviewSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RelationPreviewSuite{})
+
+func (s *RelationPreviewSuite) endpoint(c *gc.C, app *state.Application, name string) state.Endpoint {
+	ep, err := app.Endpoint(name)
+	c.Assert(err, jc.ErrorIsNil)
+	return ep
+}
+
+func (s *RelationPreviewSuite) addUnit(c *gc.C, app *state.Application) *state.Unit {
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *RelationPreviewSuite) TestPreviewAddRelation(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.addUnit(c, wordpress)
+	s.addUnit(c, mysql)
+	s.addUnit(c, mysql)
+	wordpressEP := s.endpoint(c, wordpress, "db")
+	mysqlEP := s.endpoint(c, mysql, "server")
+
+	preview, err := s.State.PreviewAddRelation(wordpressEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview, jc.DeepEquals, &state.RelationPreview{
+		Key:       "wordpress:db mysql:server",
+		Endpoints: []state.Endpoint{wordpressEP, mysqlEP},
+		Units:     []string{"mysql/0", "mysql/1", "wordpress/0"},
+	})
+	// Nothing was changed.
+	_, err = s.State.EndpointsRelation(wordpressEP, mysqlEP)
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+}
+
+func (s *RelationPreviewSuite) TestPreviewAddRelationExists(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wordpressEP := s.endpoint(c, wordpress, "db")
+	mysqlEP := s.endpoint(c, mysql, "server")
+	_, err := s.State.AddRelation(wordpressEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+
+	preview, err := s.State.PreviewAddRelation(mysqlEP, wordpressEP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview.Problems, jc.DeepEquals, []string{
+		`relation "wordpress:db mysql:server" already exists`,
+		`"wordpress:db" already has 1 of at most 1 relations`,
+	})
+	c.Assert(preview.Units, gc.HasLen, 0)
+}
+
+func (s *RelationPreviewSuite) TestPreviewAddRelationLimit(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysqlCharm := s.AddTestingCharm(c, "mysql")
+	mysql := s.AddTestingApplication(c, "mysql", mysqlCharm)
+	mysql2 := s.AddTestingApplication(c, "mysql2", mysqlCharm)
+	wordpressEP := s.endpoint(c, wordpress, "db")
+	_, err := s.State.AddRelation(wordpressEP, s.endpoint(c, mysql, "server"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	preview, err := s.State.PreviewAddRelation(wordpressEP, s.endpoint(c, mysql2, "server"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview.Problems, jc.DeepEquals, []string{
+		`"wordpress:db" already has 1 of at most 1 relations`,
+	})
+}
+
+func (s *RelationPreviewSuite) TestPreviewAddRelationIncompatible(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wordpressEP := s.endpoint(c, wordpress, "url")
+	mysqlEP := s.endpoint(c, mysql, "server")
+
+	preview, err := s.State.PreviewAddRelation(wordpressEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview.Problems, jc.DeepEquals, []string{"endpoints do not relate"})
+}
+
+func (s *RelationPreviewSuite) TestPreviewAddRelationMissingApplication(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpressEP := s.endpoint(c, wordpress, "db")
+	missingEP := state.Endpoint{
+		ApplicationName: "missing",
+		Relation: charm.Relation{
+			Name:      "server",
+			Interface: "mysql",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		},
+	}
+
+	preview, err := s.State.PreviewAddRelation(wordpressEP, missingEP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview.Problems, jc.DeepEquals, []string{`application "missing" does not exist`})
+}
+
+func (s *RelationPreviewSuite) TestPreviewAddContainerRelation(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	logging := s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	s.addUnit(c, wordpress)
+	wordpressEP := s.endpoint(c, wordpress, "juju-info")
+	loggingEP := s.endpoint(c, logging, "info")
+
+	preview, err := s.State.PreviewAddRelation(wordpressEP, loggingEP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview.Problems, gc.HasLen, 0)
+	wordpressEP.Scope = charm.ScopeContainer
+	c.Assert(preview.Endpoints, jc.DeepEquals, []state.Endpoint{wordpressEP, loggingEP})
+	c.Assert(preview.Units, jc.DeepEquals, []string{"wordpress/0"})
+}
+
+func (s *RelationPreviewSuite) TestPreviewAddContainerRelationWithNoSubordinate(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpressEP := s.endpoint(c, wordpress, "db")
+	wordpressEP.Scope = charm.ScopeContainer
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlEP := s.endpoint(c, mysql, "server")
+
+	preview, err := s.State.PreviewAddRelation(mysqlEP, wordpressEP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview.Problems, jc.DeepEquals, []string{
+		"container scoped relation requires at least one subordinate application",
+	})
+}
+
+func (s *RelationPreviewSuite) TestPreviewDestroy(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, unit := range []*state.Unit{s.addUnit(c, wordpress), s.addUnit(c, mysql)} {
+		ru, err := rel.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	// A unit that never entered scope is not reported.
+	s.addUnit(c, mysql)
+
+	preview, err := rel.PreviewDestroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview, jc.DeepEquals, &state.RelationPreview{
+		Key:       "wordpress:db mysql:server",
+		Endpoints: rel.Endpoints(),
+		Units:     []string{"mysql/0", "wordpress/0"},
+	})
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Life(), gc.Equals, state.Alive)
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// RelationSettingsUsage describes the settings stored by a single unit
+// for a single relation.
+type RelationSettingsUsage struct {
+	// RelationKey is the key of the relation.
+	RelationKey string
+
+	// RelationId is the id of the relation.
+	RelationId int
+
+	// Unit is the name of the unit that stored the settings.
+	Unit string
+
+	// Keys is the number of settings stored.
+	Keys int
+
+	// Size is the size of the settings in bytes, as measured by
+	// SettingsUsage.
+	Size int
+}
+
+// SettingsUsage returns the number of keys in the given settings and
+// their size in bytes. The size is the total length of the keys and of
+// their values when formatted as strings.
+func SettingsUsage(settings map[string]interface{}) (keys, size int) {
+	for k, v := range settings {
+		keys++
+		size += len(k)
+		if s, ok := v.(string); ok {
+			size += len(s)
+		} else {
+			size += len(fmt.Sprint(v))
+		}
+	}
+	return keys, size
+}
+
+// RelationSettingsUsage returns the usage of relation settings by each
+// of the application's units that are in scope of one of its relations,
+// ordered by relation id and unit name.
+func (a *Application) RelationSettingsUsage() ([]RelationSettingsUsage, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []RelationSettingsUsage
+	for _, rel := range relations {
+		for _, unit := range units {
+			ru, err := rel.Unit(unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			settings, err := ru.Settings()
+			if errors.IsNotFound(err) {
+				// The unit has never entered scope.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			keys, size := SettingsUsage(settings.Map())
+			result = append(result, RelationSettingsUsage{
+				RelationKey: rel.String(),
+				RelationId:  rel.Id(),
+				Unit:        unit.Name(),
+				Keys:        keys,
+				Size:        size,
+			})
+		}
+	}
+	sort.Sort(relationSettingsUsageSlice(result))
+	return result, nil
+}
+
+type relationSettingsUsageSlice []RelationSettingsUsage
+
+func (s relationSettingsUsageSlice) Len() int      { return len(s) }
+func (s relationSettingsUsageSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s relationSettingsUsageSlice) Less(i, j int) bool {
+	if s[i].RelationId != s[j].RelationId {
+		return s[i].RelationId < s[j].RelationId
+	}
+	return s[i].Unit < s[j].Unit
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type RelationSettingsUsageSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RelationSettingsUsageSuite{})
+
+func (s *RelationSettingsUsageSuite) TestSettingsUsage(c *gc.C) {
+	keys, size := state.SettingsUsage(map[string]interface{}{
+		"foo":  "bar",
+		"baz":  "",
+		"port": 8080,
+	})
+	c.Assert(keys, gc.Equals, 3)
+	c.Assert(size, gc.Equals, len("foo")+len("bar")+len("baz")+len("port")+len("8080"))
+
+	keys, size = state.SettingsUsage(nil)
+	c.Assert(keys, gc.Equals, 0)
+	c.Assert(size, gc.Equals, 0)
+}
+
+func (s *RelationSettingsUsageSuite) TestApplicationRelationSettingsUsage(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	enterScope := func(app *state.Application, settings map[string]interface{}) {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		ru, err := rel.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(settings)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	enterScope(mysql, map[string]interface{}{"user": "wp", "password": "secret"})
+	enterScope(mysql, map[string]interface{}{"user": "wp"})
+	enterScope(wordpress, map[string]interface{}{"database": "wp"})
+
+	// A unit that never entered scope is not reported.
+	_, err = mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := mysql.RelationSettingsUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, []state.RelationSettingsUsage{{
+		RelationKey: rel.String(),
+		RelationId:  rel.Id(),
+		Unit:        "mysql/0",
+		Keys:        2,
+		Size:        len("user") + len("wp") + len("password") + len("secret"),
+	}, {
+		RelationKey: rel.String(),
+		RelationId:  rel.Id(),
+		Unit:        "mysql/1",
+		Keys:        1,
+		Size:        len("user") + len("wp"),
+	}})
+}
+
+func (s *RelationSettingsUsageSuite) TestApplicationRelationSettingsUsageNoRelations(c *gc.C) {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := mysql.RelationSettingsUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.HasLen, 0)
+}
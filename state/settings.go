@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -47,11 +48,19 @@ type settingsDoc struct {
 	Version int64 `bson:"version"`
 }
 
+// settingsMap holds the settings of a settings document. Settings
+// that are too large are stored compressed; see compressSettings.
 type settingsMap map[string]interface{}
 
 func (m *settingsMap) SetBSON(raw bson.Raw) error {
 	rawMap := make(map[string]interface{})
-	if err := raw.Unmarshal(rawMap); err != nil {
+	if raw.Kind == bsonBinaryKind {
+		decompressed, err := decompressSettings(raw)
+		if err != nil {
+			return err
+		}
+		rawMap = decompressed
+	} else if err := raw.Unmarshal(rawMap); err != nil {
 		return err
 	}
 	replaceKeys(rawMap, unescapeReplacer.Replace)
@@ -59,6 +68,13 @@ func (m *settingsMap) SetBSON(raw bson.Raw) error {
 	return nil
 }
 
+func (m settingsMap) GetBSON() (interface{}, error) {
+	if !shouldCompressSettings(m) {
+		return map[string]interface{}(m), nil
+	}
+	return compressSettings(m)
+}
+
 // ItemChange represents the change of an item in a settings.
 type ItemChange struct {
 	Type     int
@@ -196,24 +212,42 @@ func (s *Settings) settingsUpdateOps() ([]ItemChange, []txn.Op) {
 		return []ItemChange{}, nil
 	}
 	sort.Sort(itemChangeSlice(changes))
+	update, replace := settingsUpdate(s.disk, updates, deletions)
+	assert := uncompressedSettingsAssert
+	if replace {
+		assert = bson.D{{"version", s.version}}
+	}
 	ops := []txn.Op{{
 		C:      s.collection,
 		Id:     s.key,
-		Assert: txn.DocExists,
-		Update: setUnsetUpdateSettings(updates, deletions),
+		Assert: assert,
+		Update: update,
 	}}
 	return changes, ops
 }
 
-func (s *Settings) write(ops []txn.Op) error {
-	err := s.db.RunTransaction(ops)
-	if err == txn.ErrAborted {
-		return errors.NotFoundf("settings")
-	}
+// rebase rereads the node, and applies the changes made to c on top of
+// the latest version.
+func (s *Settings) rebase() error {
+	doc, err := readSettingsDoc(s.db, s.collection, s.key)
 	if err != nil {
-		return fmt.Errorf("cannot write settings: %v", err)
+		return err
 	}
-	s.disk = copyMap(s.core, nil)
+	core := copyMap(doc.Settings, nil)
+	for key := range cacheKeys(s.disk, s.core) {
+		old, ondisk := s.disk[key]
+		new, incore := s.core[key]
+		switch {
+		case ondisk && incore && new == old:
+		case incore:
+			core[key] = new
+		default:
+			delete(core, key)
+		}
+	}
+	s.version = doc.Version
+	s.disk = doc.Settings
+	s.core = core
 	return nil
 }
 
@@ -221,11 +255,30 @@ func (s *Settings) write(ops []txn.Op) error {
 // as a delta applied on top of the latest version of the node, to prevent
 // overwriting unrelated changes made to the node since it was last read.
 func (s *Settings) Write() ([]ItemChange, error) {
-	changes, ops := s.settingsUpdateOps()
-	err := s.write(ops)
-	if err != nil {
+	var changes []ItemChange
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			// Compressed settings are written as a whole, so
+			// the node must be reread if it has changed.
+			if err := s.rebase(); err != nil {
+				return nil, err
+			}
+		}
+		var ops []txn.Op
+		changes, ops = s.settingsUpdateOps()
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	err := s.db.Run(buildTxn)
+	if errors.IsNotFound(err) {
 		return nil, err
 	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot write settings: %v", err)
+	}
+	s.disk = copyMap(s.core, nil)
 	return changes, nil
 }
 
@@ -400,6 +453,9 @@ func replaceSettingsOp(db Database, collection, key string, values map[string]in
 	newValues := copyMap(values, escapeReplacer.Replace)
 	op := s.assertUnchangedOp()
 	op.Update = setUnsetUpdateSettings(bson.M(newValues), deletes)
+	if shouldCompressSettings(copyMap(s.disk, escapeReplacer.Replace)) || shouldCompressSettings(newValues) {
+		op.Update = replaceSettingsUpdate(newValues)
+	}
 	assertFailed := func() (bool, error) {
 		latest, err := readSettings(db, collection, key)
 		if err != nil {
@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// compressedSettingsKind is the BSON binary subtype used to mark the
+// settings field of a settings document as holding zlib-compressed
+// BSON, rather than a sub-document.
+const compressedSettingsKind byte = 0x80
+
+// BSON element kinds of the settings field of a settings document, as
+// found in bson.Raw.Kind.
+const (
+	// bsonDocumentKind is the kind of settings stored as a sub-document.
+	bsonDocumentKind byte = 0x03
+
+	// bsonBinaryKind is the kind of settings stored compressed.
+	bsonBinaryKind byte = 0x05
+)
+
+// compressSettingsThreshold is the size in bytes of the BSON encoding
+// of a document's settings above which they are stored compressed.
+var compressSettingsThreshold = 16 * 1024
+
+// settingsSize returns the size in bytes of the BSON encoding of
+// the given (escaped) settings.
+func settingsSize(settings map[string]interface{}) (int, error) {
+	data, err := bson.Marshal(settings)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(data), nil
+}
+
+// shouldCompressSettings reports whether the given (escaped) settings
+// are large enough to be stored compressed.
+func shouldCompressSettings(settings map[string]interface{}) bool {
+	size, err := settingsSize(settings)
+	if err != nil {
+		// Leave it to the real marshalling to report the error.
+		return false
+	}
+	return size > compressSettingsThreshold
+}
+
+// compressSettings returns the given (escaped) settings as
+// zlib-compressed BSON, marked with compressedSettingsKind.
+func compressSettings(settings map[string]interface{}) (bson.Binary, error) {
+	data, err := bson.Marshal(settings)
+	if err != nil {
+		return bson.Binary{}, errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return bson.Binary{}, errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return bson.Binary{}, errors.Trace(err)
+	}
+	return bson.Binary{Kind: compressedSettingsKind, Data: buf.Bytes()}, nil
+}
+
+// decompressSettings returns the settings held in the given raw
+// BSON binary value, as written by compressSettings.
+func decompressSettings(raw bson.Raw) (map[string]interface{}, error) {
+	var bin bson.Binary
+	if err := raw.Unmarshal(&bin); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if bin.Kind != compressedSettingsKind {
+		return nil, errors.NotValidf("settings binary kind %#x", bin.Kind)
+	}
+	r, err := zlib.NewReader(bytes.NewReader(bin.Data))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decompress settings")
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decompress settings")
+	}
+	settings := make(map[string]interface{})
+	if err := bson.Unmarshal(data, settings); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings, nil
+}
+
+// replaceSettingsUpdate returns an update that replaces the whole of
+// a settings document's settings with the given (escaped) values,
+// compressing them if they are large. Since it does not merge with
+// the stored settings, the op using it must assert the document's
+// version.
+func replaceSettingsUpdate(values map[string]interface{}) bson.D {
+	return bson.D{
+		{"$set", bson.D{{"settings", settingsMap(values)}}},
+		{"$inc", bson.D{{"version", 1}}},
+	}
+}
+
+// settingsUpdate returns the update that applies the given escaped
+// sets and unsets to a settings document that currently holds the
+// given (unescaped) values. If the settings are, or would become,
+// large enough to be compressed then they are replaced as a whole,
+// and replace is true: the op using the update must then assert the
+// document's version. Otherwise only the changed keys are updated.
+func settingsUpdate(current map[string]interface{}, sets, unsets bson.M) (update bson.D, replace bool) {
+	escaped := copyMap(current, escapeReplacer.Replace)
+	wasCompressed := shouldCompressSettings(escaped)
+	for key := range unsets {
+		delete(escaped, key)
+	}
+	for key, value := range sets {
+		escaped[key] = value
+	}
+	if !wasCompressed && !shouldCompressSettings(escaped) {
+		return setUnsetUpdateSettings(sets, unsets), false
+	}
+	return replaceSettingsUpdate(escaped), true
+}
+
+// uncompressedSettingsAssert is used by ops that update individual
+// settings, which cannot be applied to compressed settings.
+var uncompressedSettingsAssert = bson.D{{"settings", bson.D{{"$type", 3}}}}
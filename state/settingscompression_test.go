@@ -0,0 +1,191 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+type SettingsCompressionSuite struct {
+	internalStateSuite
+	key string
+}
+
+var _ = gc.Suite(&SettingsCompressionSuite{})
+
+func (s *SettingsCompressionSuite) SetUpTest(c *gc.C) {
+	s.internalStateSuite.SetUpTest(c)
+	s.key = "config"
+	s.PatchValue(&compressSettingsThreshold, 256)
+}
+
+func (s *SettingsCompressionSuite) rawSettingsKind(c *gc.C) byte {
+	var doc struct {
+		Settings bson.Raw `bson:"settings"`
+	}
+	settings, closer := s.state.db().GetCollection(settingsC)
+	defer closer()
+	err := settings.FindId(s.key).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	return doc.Settings.Kind
+}
+
+func (s *SettingsCompressionSuite) assertCompressed(c *gc.C, compressed bool) {
+	if compressed {
+		c.Assert(s.rawSettingsKind(c), gc.Equals, bsonBinaryKind)
+	} else {
+		c.Assert(s.rawSettingsKind(c), gc.Equals, bsonDocumentKind)
+	}
+}
+
+func (s *SettingsCompressionSuite) readSettings(c *gc.C) map[string]interface{} {
+	node, err := readSettings(s.state.db(), settingsC, s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	return node.Map()
+}
+
+var largeValue = strings.Repeat("large", 100)
+
+func (s *SettingsCompressionSuite) TestCreateLargeSettings(c *gc.C) {
+	values := map[string]interface{}{
+		"a.b":   largeValue,
+		"$c":    "d",
+		"count": 3,
+	}
+	_, err := createSettings(s.state.db(), settingsC, s.key, values)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, true)
+	c.Assert(s.readSettings(c), jc.DeepEquals, values)
+}
+
+func (s *SettingsCompressionSuite) TestCreateSmallSettings(c *gc.C) {
+	values := map[string]interface{}{"a": "b"}
+	_, err := createSettings(s.state.db(), settingsC, s.key, values)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, false)
+	c.Assert(s.readSettings(c), jc.DeepEquals, values)
+}
+
+func (s *SettingsCompressionSuite) TestWriteGrowsAndShrinks(c *gc.C) {
+	node, err := createSettings(s.state.db(), settingsC, s.key, map[string]interface{}{"a": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	node.Set("large", largeValue)
+	changes, err := node.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []ItemChange{
+		{ItemAdded, "large", nil, largeValue},
+	})
+	s.assertCompressed(c, true)
+	c.Assert(s.readSettings(c), jc.DeepEquals, map[string]interface{}{
+		"a":     "b",
+		"large": largeValue,
+	})
+
+	// Individual settings may be updated while compressed.
+	node.Set("a", "c")
+	_, err = node.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, true)
+	c.Assert(s.readSettings(c), jc.DeepEquals, map[string]interface{}{
+		"a":     "c",
+		"large": largeValue,
+	})
+
+	node.Delete("large")
+	_, err = node.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, false)
+	c.Assert(s.readSettings(c), jc.DeepEquals, map[string]interface{}{
+		"a": "c",
+	})
+}
+
+func (s *SettingsCompressionSuite) TestConcurrentWritesMerged(c *gc.C) {
+	_, err := createSettings(s.state.db(), settingsC, s.key, map[string]interface{}{"large": largeValue})
+	c.Assert(err, jc.ErrorIsNil)
+	nodeOne, err := readSettings(s.state.db(), settingsC, s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	nodeTwo, err := readSettings(s.state.db(), settingsC, s.key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	nodeOne.Set("one", 1)
+	_, err = nodeOne.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The second node's changes are applied on top of the first's,
+	// even though the settings are written as a whole.
+	nodeTwo.Set("two", 2)
+	changes, err := nodeTwo.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []ItemChange{
+		{ItemAdded, "two", nil, 2},
+	})
+	s.assertCompressed(c, true)
+	c.Assert(s.readSettings(c), jc.DeepEquals, map[string]interface{}{
+		"large": largeValue,
+		"one":   1,
+		"two":   2,
+	})
+}
+
+func (s *SettingsCompressionSuite) TestReplaceSettings(c *gc.C) {
+	_, err := createSettings(s.state.db(), settingsC, s.key, map[string]interface{}{"a": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	op, _, err := replaceSettingsOp(s.state.db(), settingsC, s.key, map[string]interface{}{"large": largeValue})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.state.db().RunTransaction([]txn.Op{op})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, true)
+	c.Assert(s.readSettings(c), jc.DeepEquals, map[string]interface{}{"large": largeValue})
+
+	op, _, err = replaceSettingsOp(s.state.db(), settingsC, s.key, map[string]interface{}{"c": "d"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.state.db().RunTransaction([]txn.Op{op})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, false)
+	c.Assert(s.readSettings(c), jc.DeepEquals, map[string]interface{}{"c": "d"})
+}
+
+func (s *SettingsCompressionSuite) TestReadUncompressedLargeSettings(c *gc.C) {
+	// Settings written before compression was introduced are
+	// still readable, and are compressed when next written.
+	s.PatchValue(&compressSettingsThreshold, 1<<20)
+	_, err := createSettings(s.state.db(), settingsC, s.key, map[string]interface{}{"large": largeValue})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, false)
+
+	s.PatchValue(&compressSettingsThreshold, 256)
+	node, err := readSettings(s.state.db(), settingsC, s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.Map(), jc.DeepEquals, map[string]interface{}{"large": largeValue})
+
+	node.Set("a", "b")
+	_, err = node.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompressed(c, true)
+	c.Assert(s.readSettings(c), jc.DeepEquals, map[string]interface{}{
+		"a":     "b",
+		"large": largeValue,
+	})
+}
+
+func (s *SettingsCompressionSuite) TestSettingsUpdate(c *gc.C) {
+	update, replace := settingsUpdate(map[string]interface{}{"a": "b"}, bson.M{"c": "d"}, bson.M{"a": 1})
+	c.Assert(replace, jc.IsFalse)
+	c.Assert(update, jc.DeepEquals, setUnsetUpdateSettings(bson.M{"c": "d"}, bson.M{"a": 1}))
+
+	update, replace = settingsUpdate(map[string]interface{}{"a": "b"}, bson.M{"large": largeValue}, nil)
+	c.Assert(replace, jc.IsTrue)
+	c.Assert(update, jc.DeepEquals, replaceSettingsUpdate(map[string]interface{}{
+		"a":     "b",
+		"large": largeValue,
+	}))
+}
@@ -707,6 +707,7 @@ func (st *State) SetModelAgentVersion(newVersion version.Number) (err error) {
 			return nil, errors.Trace(err)
 		}
 
+		update, _ := settingsUpdate(settings.Map(), bson.M{"agent-version": newVersion.String()}, nil)
 		ops := []txn.Op{
 			// Can't set agent-version if there's an active upgradeInfo doc.
 			{
@@ -717,9 +718,7 @@ func (st *State) SetModelAgentVersion(newVersion version.Number) (err error) {
 				C:      settingsC,
 				Id:     st.docID(modelGlobalKey),
 				Assert: bson.D{{"version", settings.version}},
-				Update: bson.D{
-					{"$set", bson.D{{"settings.agent-version", newVersion.String()}}},
-				},
+				Update: update,
 			},
 		}
 		return ops, nil
@@ -777,6 +776,74 @@ func (st *State) AllMachines() ([]*Machine, error) {
 	return st.allMachines(machinesCollection)
 }
 
+// AllMachineIds returns the ids of all machines in the model,
+// including containers, in the order AllMachines returns machines.
+// Only the ids are read, so it is much cheaper than AllMachines.
+func (st *State) AllMachineIds() ([]string, error) {
+	machinesCollection, closer := st.db().GetCollection(machinesC)
+	defer closer()
+
+	var docs []struct {
+		Id string `bson:"machineid"`
+	}
+	err := machinesCollection.Find(nil).Select(bson.D{{"machineid", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get all machine ids")
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.Id
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return machineIdLessThan(ids[i], ids[j])
+	})
+	return ids, nil
+}
+
+// MachinesById returns the machines with the specified ids, ordered
+// by id. Machines that do not exist are omitted.
+func (st *State) MachinesById(ids []string) ([]*Machine, error) {
+	machinesCollection, closer := st.db().GetCollection(machinesC)
+	defer closer()
+
+	mdocs := machineDocSlice{}
+	err := machinesCollection.Find(bson.D{{"machineid", bson.D{{"$in", ids}}}}).All(&mdocs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get machines")
+	}
+	sort.Sort(mdocs)
+	machines := make([]*Machine, len(mdocs))
+	for i, doc := range mdocs {
+		machines[i] = newMachine(st, &doc)
+	}
+	return machines, nil
+}
+
+// AllMachinesPage returns no more than limit machines, including
+// containers, that follow the machine with the specified id in the
+// order AllMachines returns machines. The first page follows the empty
+// id. The machine with the specified id need not exist.
+func (st *State) AllMachinesPage(after string, limit int) ([]*Machine, error) {
+	ids, err := st.AllMachineIds()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	first := 0
+	if after != "" {
+		first = sort.Search(len(ids), func(i int) bool {
+			return machineIdLessThan(after, ids[i])
+		})
+	}
+	last := first + limit
+	if last > len(ids) {
+		last = len(ids)
+	}
+	if first == last {
+		return nil, nil
+	}
+	return st.MachinesById(ids[first:last])
+}
+
 type machineDocSlice []machineDoc
 
 func (ms machineDocSlice) Len() int      { return len(ms) }
@@ -1057,15 +1124,27 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	if args.Storage == nil {
 		args.Storage = make(map[string]StorageConstraints)
 	}
-	im, err := st.IAASModel()
+	model, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if err := addDefaultStorageConstraints(im, args.Storage, args.Charm.Meta()); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := validateStorageConstraints(im, args.Storage, args.Charm.Meta()); err != nil {
-		return nil, errors.Trace(err)
+	if model.Type() == ModelTypeCAAS {
+		// Units in CAAS models run in containers managed by the
+		// model's broker, rather than on machines.
+		if err := validateCAASApplicationArgs(args); err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		im, err := model.IAASModel()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := addDefaultStorageConstraints(im, args.Storage, args.Charm.Meta()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := validateStorageConstraints(im, args.Storage, args.Charm.Meta()); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	storagePools := make(set.Strings)
 	for _, storageParams := range args.Storage {
@@ -1137,7 +1216,7 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			}
 			subordinate := args.Charm.Meta().Subordinate
 			if err := validateUnitMachineAssignment(
-				m, args.Series, subordinate, storagePools,
+				m, args.Series, subordinate, storagePools, args.Constraints,
 			); err != nil {
 				return nil, errors.Annotatef(
 					err, "cannot deploy to machine %s", m,
@@ -1309,6 +1388,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, unitOps...)
+			if model.Type() == ModelTypeCAAS {
+				continue
+			}
 			placement := instance.Placement{}
 			if x < len(args.Placement) {
 				placement = *args.Placement[x]
@@ -1339,6 +1421,19 @@ func removeNils(m map[string]interface{}) {
 	}
 }
 
+// validateCAASApplicationArgs checks that the arguments for adding an
+// application are suitable for a CAAS model. Units in CAAS models are
+// not placed on machines, and storage is not yet supported for them.
+func validateCAASApplicationArgs(args AddApplicationArgs) error {
+	if len(args.Placement) > 0 {
+		return errors.NotSupportedf("placement directives in CAAS models")
+	}
+	if len(args.AttachStorage) > 0 || len(args.Charm.Meta().Storage) > 0 {
+		return errors.NotSupportedf("storage in CAAS models")
+	}
+	return nil
+}
+
 // assignUnitOps returns the db ops to save unit assignment for use by the
 // UnitAssigner worker.
 func assignUnitOps(unitName string, placement instance.Placement) []txn.Op {
@@ -1559,6 +1654,28 @@ func (st *State) AllApplications() (applications []*Application, err error) {
 	return applications, nil
 }
 
+// ApplicationUnitCounts returns the number of units of each deployed
+// application in the model, keyed by application name. Only the
+// counts are read, so it is much cheaper than AllApplications.
+func (st *State) ApplicationUnitCounts() (map[string]int, error) {
+	applicationsCollection, closer := st.db().GetCollection(applicationsC)
+	defer closer()
+
+	var docs []struct {
+		Name      string `bson:"name"`
+		UnitCount int    `bson:"unitcount"`
+	}
+	err := applicationsCollection.Find(nil).Select(bson.D{{"name", 1}, {"unitcount", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get application unit counts")
+	}
+	counts := make(map[string]int)
+	for _, doc := range docs {
+		counts[doc.Name] = doc.UnitCount
+	}
+	return counts, nil
+}
+
 // InferEndpoints returns the endpoints corresponding to the supplied names.
 // There must be 1 or 2 supplied names, of the form <application>[:<relation>].
 // If the supplied names uniquely specify a possible relation, or if they
@@ -1968,6 +2085,13 @@ func (st *State) AssignUnit(u *Unit, policy AssignmentPolicy) (err error) {
 		return errors.Errorf("subordinate unit %q cannot be assigned directly to a machine", u)
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot assign unit %q to machine", u)
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Type() == ModelTypeCAAS {
+		return errors.NotSupportedf("assigning units to machines in CAAS models")
+	}
 	var m *Machine
 	switch policy {
 	case AssignLocal:
@@ -1025,6 +1025,49 @@ func (s *StateSuite) TestAddMachinePlacementIgnoresModelConstraints(c *gc.C) {
 	c.Assert(mcons, gc.DeepEquals, expectedCons)
 }
 
+func (s *StateSuite) TestAddMachineWithImage(c *gc.C) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		ImageStream: "daily",
+		ImageId:     "ami-0123",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.ImageStream(), gc.Equals, "daily")
+	c.Assert(m.ImageId(), gc.Equals, "ami-0123")
+
+	m, err = s.State.Machine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.ImageStream(), gc.Equals, "daily")
+	c.Assert(m.ImageId(), gc.Equals, "ami-0123")
+}
+
+func (s *StateSuite) TestAddContainerWithImage(c *gc.C) {
+	imageTemplate := state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		ImageStream: "daily",
+	}
+	normalTemplate := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	_, err := s.State.AddMachineInsideNewMachine(imageTemplate, normalTemplate, instance.LXD)
+	c.Check(err, gc.ErrorMatches, "cannot add a new machine: cannot specify image for a new container")
+
+	container, err := s.State.AddMachineInsideNewMachine(normalTemplate, imageTemplate, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(container.ImageStream(), gc.Equals, "")
+	parentId, ok := container.ParentId()
+	c.Assert(ok, jc.IsTrue)
+	parent, err := s.State.Machine(parentId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parent.ImageStream(), gc.Equals, "daily")
+
+	_, err = s.State.AddMachineInsideMachine(imageTemplate, parentId, instance.LXD)
+	c.Check(err, gc.ErrorMatches, "cannot add a new machine: cannot specify image for a new container")
+}
+
 func (s *StateSuite) TestAddMachineWithVolumes(c *gc.C) {
 	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
 	_, err := pm.Create("loop-pool", provider.LoopProviderType, map[string]interface{}{})
@@ -1452,6 +1495,91 @@ func (s *StateSuite) TestAllMachines(c *gc.C) {
 	}
 }
 
+func (s *StateSuite) TestAllMachineIds(c *gc.C) {
+	for i := 0; i < 11; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	_, err := s.State.AddMachineInsideMachine(template, "1", instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ids, err := s.State.AllMachineIds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{
+		"0", "1", "1/lxd/0", "2", "3", "4", "5", "6", "7", "8", "9", "10",
+	})
+}
+
+func (s *StateSuite) TestMachinesById(c *gc.C) {
+	for i := 0; i < 11; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	machines, err := s.State.MachinesById([]string{"10", "2", "99"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 2)
+	c.Assert(machines[0].Id(), gc.Equals, "2")
+	c.Assert(machines[1].Id(), gc.Equals, "10")
+}
+
+func (s *StateSuite) TestAllMachinesPage(c *gc.C) {
+	for i := 0; i < 11; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	machineIds := func(machines []*state.Machine) []string {
+		ids := make([]string, len(machines))
+		for i, m := range machines {
+			ids[i] = m.Id()
+		}
+		return ids
+	}
+
+	machines, err := s.State.AllMachinesPage("", 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineIds(machines), jc.DeepEquals, []string{"0", "1", "2", "3"})
+
+	machines, err = s.State.AllMachinesPage("8", 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineIds(machines), jc.DeepEquals, []string{"9", "10"})
+
+	// The cursor's machine need not exist.
+	machines, err = s.State.AllMachinesPage("5/lxd/0", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineIds(machines), jc.DeepEquals, []string{"6", "7"})
+
+	machines, err = s.State.AllMachinesPage("10", 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 0)
+}
+
+func (s *StateSuite) TestApplicationUnitCounts(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	counts, err := s.State.ApplicationUnitCounts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(counts, gc.HasLen, 0)
+
+	wordpress, err := s.State.AddApplication(state.AddApplicationArgs{Name: "wordpress", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddApplication(state.AddApplicationArgs{Name: "mysql", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 2; i++ {
+		_, err = wordpress.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	counts, err = s.State.ApplicationUnitCounts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(counts, jc.DeepEquals, map[string]int{
+		"wordpress": 2,
+		"mysql":     0,
+	})
+}
+
 func (s *StateSuite) TestAllRelations(c *gc.C) {
 	const numRelations = 32
 	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
@@ -1739,6 +1867,25 @@ func (s *StateSuite) TestAddServiceMachinePlacementInvalidSeries(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot add application \"wordpress\": cannot deploy to machine .*: series does not match")
 }
 
+func (s *StateSuite) TestAddServiceMachinePlacementInvalidArch(c *gc.C) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("arch=amd64"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charm := s.AddTestingCharm(c, "dummy")
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name: "wordpress", Charm: charm,
+		Constraints: constraints.MustParse("arch=arm64"),
+		Placement: []*instance.Placement{
+			{instance.MachineScope, m.Id()},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "wordpress": cannot deploy to machine .*: machine 0 has architecture "amd64", but the application requires arch=arm64`)
+}
+
 func (s *StateSuite) TestAddServiceIncompatibleOSWithSeriesInURL(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	// A charm with a series in its URL is implicitly supported by that
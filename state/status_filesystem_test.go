@@ -201,3 +201,16 @@ func (s *FilesystemStatusSuite) TestSetStatusPendingProvisioned(c *gc.C) {
 	err = s.filesystem.SetStatus(sInfo)
 	c.Check(err, gc.ErrorMatches, `cannot set status "pending"`)
 }
+
+func (s *FilesystemStatusSuite) TestStatusHistory(c *gc.C) {
+	for _, fsStatus := range []status.Status{status.Attaching, status.Attached} {
+		err := s.filesystem.SetStatus(status.StatusInfo{Status: fsStatus})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.filesystem.StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Status, gc.Equals, status.Attached)
+	c.Check(history[1].Status, gc.Equals, status.Attaching)
+}
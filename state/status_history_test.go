@@ -294,3 +294,61 @@ func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 	c.Assert(history[2].Message, gc.Equals, "2 days ago")
 }
+
+func (s *StatusHistorySuite) TestStatusHistoryFiltersByTimeRange(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	now := time.Now()
+	oneDayAgo := now.Add(-24 * time.Hour)
+	twoDaysAgo := now.Add(-48 * time.Hour)
+	threeDaysAgo := now.Add(-72 * time.Hour)
+	for _, sInfo := range []status.StatusInfo{{
+		Status:  status.Active,
+		Message: "1 day ago",
+		Since:   &oneDayAgo,
+	}, {
+		Status:  status.Active,
+		Message: "2 days ago",
+		Since:   &twoDaysAgo,
+	}, {
+		Status:  status.Active,
+		Message: "3 days ago",
+		Since:   &threeDaysAgo,
+	}} {
+		err := unit.SetStatus(sInfo)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	from := threeDaysAgo.Add(time.Hour)
+	to := oneDayAgo.Add(-time.Hour)
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{FromDate: &from, ToDate: &to})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "2 days ago")
+
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{ToDate: &to})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Message, gc.Equals, "2 days ago")
+	c.Assert(history[1].Message, gc.Equals, "3 days ago")
+}
+
+func (s *StatusHistorySuite) TestStatusHistoryPagination(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit, 10, 0)
+
+	all, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 11)
+
+	var paged []status.StatusInfo
+	for offset := 0; offset < len(all); offset += 4 {
+		page, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 4, Offset: offset})
+		c.Assert(err, jc.ErrorIsNil)
+		paged = append(paged, page...)
+	}
+	c.Assert(paged, gc.HasLen, len(all))
+	c.Assert(paged, jc.DeepEquals, all)
+}
@@ -214,6 +214,41 @@ func (s *ModelStatusSuite) TestUnitStatus(c *gc.C) {
 	c.Check(msWorkloadVersion, jc.DeepEquals, uWorkloadVersion)
 }
 
+func (s *ModelStatusSuite) TestLoadEntitiesStatus(c *gc.C) {
+	machine := s.factory.MakeMachine(c, nil)
+	other := s.factory.MakeMachine(c, nil)
+	unit := s.factory.MakeUnit(c, nil)
+	c.Assert(unit.SetAgentStatus(status.StatusInfo{Status: status.Idle}), jc.ErrorIsNil)
+
+	ms, err := s.model.LoadEntitiesStatus(
+		[]string{machine.Id()}, []string{unit.ApplicationName()}, []string{unit.Name()},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ms.Model()
+	c.Check(err, jc.ErrorIsNil)
+	msAgent, err := ms.MachineAgent(machine.Id())
+	c.Check(err, jc.ErrorIsNil)
+	mAgent, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msAgent, jc.DeepEquals, mAgent)
+	_, err = ms.MachineInstance(machine.Id())
+	c.Check(err, jc.ErrorIsNil)
+	_, err = ms.Application(unit.ApplicationName(), []string{unit.Name()})
+	c.Check(err, jc.ErrorIsNil)
+	uAgent, err := unit.AgentStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	msAgent, err = ms.UnitAgent(unit.Name())
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(msAgent, jc.DeepEquals, uAgent)
+	_, err = ms.UnitWorkload(unit.Name())
+	c.Check(err, jc.ErrorIsNil)
+
+	// The status of entities that were not requested is not loaded.
+	_, err = ms.MachineAgent(other.Id())
+	c.Check(err, gc.ErrorMatches, "cannot get status: machine not found")
+}
+
 func (s *ModelStatusSuite) TestUnitStatusWeirdness(c *gc.C) {
 	unit := s.factory.MakeUnit(c, nil)
 
@@ -207,3 +207,16 @@ func (s *VolumeStatusSuite) TestSetStatusPendingProvisioned(c *gc.C) {
 	err = s.volume.SetStatus(sInfo)
 	c.Check(err, gc.ErrorMatches, `cannot set status "pending"`)
 }
+
+func (s *VolumeStatusSuite) TestStatusHistory(c *gc.C) {
+	for _, volumeStatus := range []status.Status{status.Attaching, status.Attached} {
+		err := s.volume.SetStatus(status.StatusInfo{Status: volumeStatus})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.volume.StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Status, gc.Equals, status.Attached)
+	c.Check(history[1].Status, gc.Equals, status.Attaching)
+}
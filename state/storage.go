@@ -2040,6 +2040,62 @@ func (im *IAASModel) addUnitStorageOps(
 	return allTags, append(ops, storageOps...), nil
 }
 
+// checkStorageMinimumSize checks that the named storage instances owned
+// by the given entities are at least minimumSize MiB, as provisioned if
+// they have been, and otherwise as requested.
+func (im *IAASModel) checkStorageMinimumSize(owners []names.Tag, name string, minimumSize uint64) error {
+	for _, owner := range owners {
+		storageInstances, err := im.storageInstances(bson.D{
+			{"owner", owner.String()},
+			{"storagename", name},
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, s := range storageInstances {
+			size, err := im.storageInstanceSize(s)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if size < minimumSize {
+				return errors.Errorf(
+					"existing storage %s is %s, less than the new minimum size %s "+
+						"(detach and remove it before upgrading)",
+					s.StorageTag().Id(),
+					humanize.Bytes(size*humanize.MByte),
+					humanize.Bytes(minimumSize*humanize.MByte),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// storageInstanceSize returns the size of the storage instance in MiB.
+func (im *IAASModel) storageInstanceSize(s *storageInstance) (uint64, error) {
+	switch s.Kind() {
+	case StorageKindBlock:
+		v, err := im.StorageInstanceVolume(s.StorageTag())
+		if err != nil && !errors.IsNotFound(err) {
+			return 0, errors.Trace(err)
+		} else if err == nil {
+			if info, err := v.Info(); err == nil {
+				return info.Size, nil
+			}
+		}
+	case StorageKindFilesystem:
+		f, err := im.StorageInstanceFilesystem(s.StorageTag())
+		if err != nil && !errors.IsNotFound(err) {
+			return 0, errors.Trace(err)
+		} else if err == nil {
+			if info, err := f.Info(); err == nil {
+				return info.Size, nil
+			}
+		}
+	}
+	return s.doc.Constraints.Size, nil
+}
+
 func (im *IAASModel) countEntityStorageInstances(owner names.Tag, name string) (txn.Op, int, error) {
 	refcounts, closer := im.mb.db().GetCollection(refcountsC)
 	defer closer()
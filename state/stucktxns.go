@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// StuckTransactionAge is the age beyond which an incomplete
+// transaction is considered to be stuck. Transactions normally
+// complete in well under a second, and are resumed by any runner
+// that touches the documents they affect.
+const StuckTransactionAge = 10 * time.Minute
+
+// The states of a transaction, as recorded by mgo/txn.
+const (
+	txnPreparing = 1
+	txnPrepared  = 2
+	txnAborting  = 3
+	txnApplying  = 4
+)
+
+var txnStateNames = map[int]string{
+	txnPreparing: "preparing",
+	txnPrepared:  "prepared",
+	txnAborting:  "aborting",
+	txnApplying:  "applying",
+}
+
+// StuckTransaction describes a transaction that has not completed.
+type StuckTransaction struct {
+	// Id is the id of the transaction.
+	Id string
+
+	// State is the state the transaction is stuck in: one of
+	// "preparing", "prepared", "aborting" or "applying".
+	State string
+
+	// Started is when the transaction was started.
+	Started time.Time
+
+	// Ops describes the documents that the transaction operates on.
+	Ops []StuckTransactionOp
+}
+
+// StuckTransactionOp describes a document operated on by a stuck
+// transaction.
+type StuckTransactionOp struct {
+	Collection string
+	Id         string
+}
+
+type stuckTxnDoc struct {
+	Id    bson.ObjectId `bson:"_id"`
+	State int           `bson:"s"`
+	Ops   []txn.Op      `bson:"o"`
+}
+
+func (doc *stuckTxnDoc) stuckTransaction() StuckTransaction {
+	result := StuckTransaction{
+		Id:      doc.Id.Hex(),
+		State:   txnStateNames[doc.State],
+		Started: doc.Id.Time().UTC(),
+	}
+	for _, op := range doc.Ops {
+		result.Ops = append(result.Ops, StuckTransactionOp{
+			Collection: op.C,
+			Id:         fmt.Sprint(op.Id),
+		})
+	}
+	return result
+}
+
+// StuckTransactions returns the incomplete transactions that were
+// started before the given time, oldest first. Transaction ids are
+// stamped with the wall clock time of the runner that started them.
+func (st *State) StuckTransactions(startedBefore time.Time) ([]StuckTransaction, error) {
+	txns, closer := st.db().GetRawCollection(txnsC)
+	defer closer()
+
+	started := bson.NewObjectIdWithTime(startedBefore)
+	var docs []stuckTxnDoc
+	err := txns.Find(bson.D{
+		{"s", bson.D{{"$in", []int{txnPreparing, txnPrepared, txnAborting, txnApplying}}}},
+		{"_id", bson.D{{"$lt", started}}},
+	}).Sort("_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read stuck transactions")
+	}
+	result := make([]StuckTransaction, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.stuckTransaction()
+	}
+	return result, nil
+}
+
+// ResumeTransaction attempts to complete the transaction with the
+// given id, applying or aborting it as mgo/txn would had its runner
+// not been interrupted. Resuming a completed transaction does nothing.
+func (st *State) ResumeTransaction(id string) error {
+	if !bson.IsObjectIdHex(id) {
+		return errors.NotValidf("transaction id %q", id)
+	}
+	txnId := bson.ObjectIdHex(id)
+
+	txns, closer := st.db().GetRawCollection(txnsC)
+	defer closer()
+	if err := txns.FindId(txnId).One(&bson.D{}); err == mgo.ErrNotFound {
+		return errors.NotFoundf("transaction %q", id)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Changes must be recorded in the log, as they would be by
+	// any other runner, so that they are seen by watchers.
+	txnLog, logCloser := st.db().GetRawCollection(txnLogC)
+	defer logCloser()
+	runner := txn.NewRunner(txns)
+	runner.ChangeLog(txnLog)
+	if err := runner.Resume(txnId); err != nil {
+		return errors.Annotatef(err, "cannot resume transaction %q", id)
+	}
+	return nil
+}
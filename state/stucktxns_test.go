@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state"
+)
+
+type StuckTransactionsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&StuckTransactionsSuite{})
+
+// addMachineStuck adds a machine, interrupting the transaction that
+// does so once it has been prepared, as though the runner had died.
+func (s *StuckTransactionsSuite) addMachineStuck(c *gc.C) {
+	txn.SetChaos(txn.Chaos{KillChance: 1, Breakpoint: "set-applying"})
+	defer txn.SetChaos(txn.Chaos{})
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, ".*interrupted by chaos")
+}
+
+// inAMinute returns a time later than the start of any transaction
+// run by the test, allowing for transaction ids recording their start
+// time only to the second.
+func inAMinute() time.Time {
+	return time.Now().Add(time.Minute)
+}
+
+func (s *StuckTransactionsSuite) TestNoStuckTransactions(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	stuck, err := s.State.StuckTransactions(inAMinute())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, gc.HasLen, 0)
+}
+
+func (s *StuckTransactionsSuite) TestStuckTransactions(c *gc.C) {
+	s.addMachineStuck(c)
+
+	stuck, err := s.State.StuckTransactions(inAMinute())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, gc.HasLen, 1)
+	c.Check(stuck[0].State, gc.Equals, "prepared")
+	c.Check(stuck[0].Id, gc.Not(gc.Equals), "")
+	c.Check(stuck[0].Started.IsZero(), jc.IsFalse)
+	machineOp := state.StuckTransactionOp{
+		Collection: "machines",
+		Id:         s.State.ModelUUID() + ":0",
+	}
+	found := false
+	for _, op := range stuck[0].Ops {
+		found = found || op == machineOp
+	}
+	c.Check(found, jc.IsTrue, gc.Commentf("%v not in %v", machineOp, stuck[0].Ops))
+
+	// Transactions started more recently than the threshold are
+	// still in progress, as far as anyone can tell.
+	stuck, err = s.State.StuckTransactions(time.Now().Add(-state.StuckTransactionAge))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, gc.HasLen, 0)
+}
+
+func (s *StuckTransactionsSuite) TestResumeTransaction(c *gc.C) {
+	s.addMachineStuck(c)
+	stuck, err := s.State.StuckTransactions(inAMinute())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, gc.HasLen, 1)
+
+	err = s.State.ResumeTransaction(stuck[0].Id)
+	c.Assert(err, jc.ErrorIsNil)
+	stuck, err = s.State.StuckTransactions(inAMinute())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, gc.HasLen, 0)
+
+	_, err = s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StuckTransactionsSuite) TestResumeTransactionNotFound(c *gc.C) {
+	err := s.State.ResumeTransaction("5a0a4c3e0000000000000000")
+	c.Assert(err, gc.ErrorMatches, `transaction "5a0a4c3e0000000000000000" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StuckTransactionsSuite) TestResumeTransactionInvalidId(c *gc.C) {
+	err := s.State.ResumeTransaction("bad")
+	c.Assert(err, gc.ErrorMatches, `transaction id "bad" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -786,24 +786,49 @@ func (u *Unit) noAssignedMachineOp() txn.Op {
 	}
 }
 
-// PublicAddress returns the public address of the unit.
+// PublicAddress returns the public address of the unit, chosen
+// according to the application's address policy if it has one.
 func (u *Unit) PublicAddress() (network.Address, error) {
 	m, err := u.machine()
 	if err != nil {
 		unitLogger.Tracef("%v", err)
 		return network.Address{}, errors.Trace(err)
 	}
-	return m.PublicAddress()
+	policy, err := u.addressPolicy()
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	if policy.IsZero() {
+		return m.PublicAddress()
+	}
+	return m.policyAddress(policy, true)
 }
 
-// PrivateAddress returns the private address of the unit.
+// PrivateAddress returns the private address of the unit, chosen
+// according to the application's address policy if it has one.
 func (u *Unit) PrivateAddress() (network.Address, error) {
 	m, err := u.machine()
 	if err != nil {
 		unitLogger.Tracef("%v", err)
 		return network.Address{}, errors.Trace(err)
 	}
-	return m.PrivateAddress()
+	policy, err := u.addressPolicy()
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	if policy.IsZero() {
+		return m.PrivateAddress()
+	}
+	return m.policyAddress(policy, false)
+}
+
+// addressPolicy returns the address policy of the unit's application.
+func (u *Unit) addressPolicy() (AddressPolicy, error) {
+	app, err := u.Application()
+	if err != nil {
+		return AddressPolicy{}, errors.Trace(err)
+	}
+	return app.AddressPolicy(), nil
 }
 
 // AvailabilityZone returns the name of the availability zone into which
@@ -1359,8 +1384,18 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	var appCons constraints.Value
+	if u.doc.Principal == "" {
+		app, err := u.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if appCons, err = app.Constraints(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	if err := validateUnitMachineAssignment(
-		m, u.doc.Series, u.doc.Principal != "", storagePools,
+		m, u.doc.Series, u.doc.Principal != "", storagePools, appCons,
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1414,12 +1449,14 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 }
 
 // validateUnitMachineAssignment validates the parameters for assigning a unit
-// to a specified machine.
+// to a specified machine. The application constraints are used to check that
+// the machine has a suitable architecture.
 func validateUnitMachineAssignment(
 	m *Machine,
 	series string,
 	isSubordinate bool,
 	storagePools set.Strings,
+	appCons constraints.Value,
 ) (err error) {
 	if m.Life() != Alive {
 		return machineNotAliveErr
@@ -1443,6 +1480,30 @@ func validateUnitMachineAssignment(
 	if err := validateDynamicMachineStoragePools(m, storagePools); err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateMachineArch(m, appCons); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// validateMachineArch checks that the machine's architecture, if known,
+// satisfies the architecture constraint of the application, if any. In
+// a model mixing architectures, a unit placed explicitly on a machine of
+// another architecture would otherwise fail only once its charm runs.
+func validateMachineArch(m *Machine, appCons constraints.Value) error {
+	if !appCons.HasArch() {
+		return nil
+	}
+	machineArch, err := m.arch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if machineArch != "" && machineArch != *appCons.Arch {
+		return errors.Errorf(
+			"machine %s has architecture %q, but the application requires arch=%s",
+			m.Id(), machineArch, *appCons.Arch,
+		)
+	}
 	return nil
 }
 
@@ -2079,7 +2140,14 @@ var hasNoContainersTerm = bson.DocElem{
 // findCleanMachineQuery returns a Mongo query to find clean (and possibly empty) machines with
 // characteristics matching the specified constraints.
 func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value) (bson.D, error) {
-	db, closer := u.st.newDB()
+	return findCleanMachineQuery(u.st, u.doc.Series, requireEmpty, cons)
+}
+
+// findCleanMachineQuery returns a Mongo query to find clean (and possibly
+// empty) machines running the specified series, with characteristics
+// matching the specified constraints.
+func findCleanMachineQuery(st *State, series string, requireEmpty bool, cons *constraints.Value) (bson.D, error) {
+	db, closer := st.newDB()
 	defer closer()
 	containerRefsCollection, closer := db.GetCollection(containerRefsC)
 	defer closer()
@@ -2100,7 +2168,7 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	}
 	terms := bson.D{
 		{"life", Alive},
-		{"series", u.doc.Series},
+		{"series", series},
 		{"jobs", []MachineJob{JobHostUnits}},
 		{"clean", true},
 		{"machineid", bson.D{{"$nin", machinesWithContainers}}},
@@ -2355,24 +2423,12 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
-	if len(name) == 0 {
-		return nil, errors.New("no action name given")
-	}
-
-	// If the action is predefined inside juju, get spec from map
-	spec, ok := actions.PredefinedActionsSpec[name]
-	if !ok {
-		specs, err := u.ActionSpecs()
-		if err != nil {
-			return nil, err
-		}
-		spec, ok = specs[name]
-		if !ok {
-			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
-		}
+	spec, err := u.actionSpec(name)
+	if err != nil {
+		return nil, err
 	}
 	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
+	err = spec.ValidateParams(payload)
 	if err != nil {
 		return nil, err
 	}
@@ -2389,6 +2445,28 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	return model.EnqueueAction(u.Tag(), name, payloadWithDefaults)
 }
 
+// actionSpec returns the spec of the named action, which may be
+// predefined by juju or defined by the unit's charm.
+func (u *Unit) actionSpec(name string) (charm.ActionSpec, error) {
+	if len(name) == 0 {
+		return charm.ActionSpec{}, errors.New("no action name given")
+	}
+
+	// If the action is predefined inside juju, get spec from map
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		specs, err := u.ActionSpecs()
+		if err != nil {
+			return charm.ActionSpec{}, err
+		}
+		spec, ok = specs[name]
+		if !ok {
+			return charm.ActionSpec{}, errors.Errorf("action %q not defined on unit %q", name, u.Name())
+		}
+	}
+	return spec, nil
+}
+
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
 func (u *Unit) ActionSpecs() (ActionSpecsByName, error) {
 	none := ActionSpecsByName{}
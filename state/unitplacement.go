@@ -0,0 +1,186 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+// UnitPlacement describes where a prospective unit of an application
+// would be placed if it were added to the model.
+type UnitPlacement struct {
+	// MachineId holds the id of the existing machine or container
+	// that would host the unit. It is empty if a new machine or
+	// container would be created for the unit.
+	MachineId string
+
+	// ParentId holds the id of the existing machine that would host
+	// a new container for the unit. It is empty if the container
+	// would be created inside a new machine, or if no container
+	// would be created.
+	ParentId string
+
+	// ContainerType holds the type of the new container that would
+	// be created to host the unit, if any.
+	ContainerType instance.ContainerType
+
+	// Directive holds the provider-specific placement directive that
+	// would be used to create a new machine for the unit, if any.
+	Directive string
+}
+
+// NewMachine reports whether a new machine or container would be
+// created to host the unit.
+func (p UnitPlacement) NewMachine() bool {
+	return p.MachineId == ""
+}
+
+// PreviewUnitPlacement reports where count new units of the application
+// would be placed, given the supplied placement directives, without
+// making any changes to the model. Units beyond those with a placement
+// directive are placed according to the AssignCleanEmpty policy, as is
+// done when adding units through the API.
+//
+// The preview does not reserve anything: by the time units are actually
+// added, other changes to the model may cause them to be placed
+// differently. Where several existing machines are equally suitable,
+// the machine actually chosen may also differ.
+func (a *Application) PreviewUnitPlacement(count int, placement []*instance.Placement) ([]UnitPlacement, error) {
+	if count < 1 {
+		return nil, errors.NotValidf("unit count %d", count)
+	}
+	if !a.IsPrincipal() {
+		return nil, errors.Errorf("application %q is a subordinate", a.Name())
+	}
+	appCons, err := a.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := a.st.resolveConstraints(appCons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]UnitPlacement, count)
+	used := set.NewStrings()
+	for i := 0; i < count && i < len(placement); i++ {
+		result, err := a.previewPlacementDirective(placement[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "unit %d/%d", i+1, count)
+		}
+		if result.MachineId != "" {
+			used.Add(result.MachineId)
+		}
+		results[i] = result
+	}
+	if count <= len(placement) {
+		return results, nil
+	}
+
+	// When a container is required, clean and empty containers are
+	// reused first. Otherwise, a new container will be created on a
+	// clean and empty host machine if there is one.
+	candidates, err := a.cleanEmptyMachineIds(cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var hosts []string
+	if cons.HasContainer() {
+		hostCons := cons
+		noContainer := instance.NONE
+		hostCons.Container = &noContainer
+		if hosts, err = a.cleanEmptyMachineIds(hostCons); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	next := func(ids []string) (string, []string) {
+		for len(ids) > 0 {
+			id := ids[0]
+			ids = ids[1:]
+			if !used.Contains(id) {
+				used.Add(id)
+				return id, ids
+			}
+		}
+		return "", nil
+	}
+	for i := len(placement); i < count; i++ {
+		var id string
+		if id, candidates = next(candidates); id != "" {
+			results[i] = UnitPlacement{MachineId: id}
+			continue
+		}
+		if !cons.HasContainer() {
+			results[i] = UnitPlacement{}
+			continue
+		}
+		results[i] = UnitPlacement{ContainerType: *cons.Container}
+		results[i].ParentId, hosts = next(hosts)
+	}
+	return results, nil
+}
+
+// previewPlacementDirective reports where a unit of the application
+// would be placed given the placement directive.
+func (a *Application) previewPlacementDirective(placement *instance.Placement) (UnitPlacement, error) {
+	data, err := a.st.parsePlacement(placement)
+	if err != nil {
+		return UnitPlacement{}, errors.Trace(err)
+	}
+	switch data.placementType() {
+	case containerPlacement:
+		if data.machineId != "" {
+			if _, err := a.st.Machine(data.machineId); err != nil {
+				return UnitPlacement{}, errors.Trace(err)
+			}
+		}
+		return UnitPlacement{
+			ParentId:      data.machineId,
+			ContainerType: data.containerType,
+		}, nil
+	case directivePlacement:
+		return UnitPlacement{Directive: data.directive}, nil
+	}
+	m, err := a.st.Machine(data.machineId)
+	if err != nil {
+		return UnitPlacement{}, errors.Trace(err)
+	}
+	if m.Life() != Alive {
+		return UnitPlacement{}, errors.Errorf("machine %s is not alive", m.Id())
+	}
+	return UnitPlacement{MachineId: m.Id()}, nil
+}
+
+// cleanEmptyMachineIds returns the ids of the clean and empty machines
+// that could host a unit of the application with the given constraints.
+// Provisioned machines are returned ahead of unprovisioned ones, which
+// mirrors the preference used when assigning units.
+func (a *Application) cleanEmptyMachineIds(cons constraints.Value) ([]string, error) {
+	query, err := findCleanMachineQuery(a.st, a.doc.Series, true, &cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machinesCollection, closer := a.st.db().GetCollection(machinesC)
+	defer closer()
+	var mdocs []machineDoc
+	if err := machinesCollection.Find(query).All(&mdocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var provisioned, unprovisioned []string
+	for _, mdoc := range mdocs {
+		m := newMachine(a.st, &mdoc)
+		if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+			unprovisioned = append(unprovisioned, mdoc.Id)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		} else {
+			provisioned = append(provisioned, mdoc.Id)
+		}
+	}
+	return append(provisioned, unprovisioned...), nil
+}
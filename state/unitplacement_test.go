@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type UnitPlacementSuite struct {
+	ConnSuite
+	wordpress *state.Application
+}
+
+var _ = gc.Suite(&UnitPlacementSuite{})
+
+func (s *UnitPlacementSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *UnitPlacementSuite) TestPreviewReusesCleanEmptyMachines(c *gc.C) {
+	m1, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	m2, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A machine that already hosts a unit is not clean.
+	used, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(used)
+	c.Assert(err, jc.ErrorIsNil)
+
+	preview, err := s.wordpress.PreviewUnitPlacement(3, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview, gc.HasLen, 3)
+	reused := []string{preview[0].MachineId, preview[1].MachineId}
+	c.Assert(reused, jc.SameContents, []string{m1.Id(), m2.Id()})
+	c.Assert(preview[2].NewMachine(), jc.IsTrue)
+
+	// Nothing has changed in state.
+	units, err := s.wordpress.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	err = m1.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m1.Clean(), jc.IsTrue)
+}
+
+func (s *UnitPlacementSuite) TestPreviewWithPlacement(c *gc.C) {
+	m1, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	m2, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	preview, err := s.wordpress.PreviewUnitPlacement(4, []*instance.Placement{
+		{Scope: instance.MachineScope, Directive: m1.Id()},
+		{Scope: string(instance.LXD), Directive: m1.Id()},
+		{Scope: s.State.ModelUUID(), Directive: "zone=a"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview, jc.DeepEquals, []state.UnitPlacement{
+		{MachineId: m1.Id()},
+		{ParentId: m1.Id(), ContainerType: instance.LXD},
+		{Directive: "zone=a"},
+		{MachineId: m2.Id()},
+	})
+}
+
+func (s *UnitPlacementSuite) TestPreviewContainerConstraint(c *gc.C) {
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetConstraints(constraints.MustParse("container=lxd"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	preview, err := s.wordpress.PreviewUnitPlacement(2, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(preview, jc.DeepEquals, []state.UnitPlacement{
+		{ParentId: host.Id(), ContainerType: instance.LXD},
+		{ContainerType: instance.LXD},
+	})
+}
+
+func (s *UnitPlacementSuite) TestPreviewUnknownMachine(c *gc.C) {
+	_, err := s.wordpress.PreviewUnitPlacement(1, []*instance.Placement{
+		{Scope: instance.MachineScope, Directive: "42"},
+	})
+	c.Assert(err, gc.ErrorMatches, `unit 1/1: machine 42 not found`)
+}
+
+func (s *UnitPlacementSuite) TestPreviewSubordinate(c *gc.C) {
+	logging := s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	_, err := logging.PreviewUnitPlacement(1, nil)
+	c.Assert(err, gc.ErrorMatches, `application "logging" is a subordinate`)
+}
+
+func (s *UnitPlacementSuite) TestPreviewInvalidCount(c *gc.C) {
+	_, err := s.wordpress.PreviewUnitPlacement(0, nil)
+	c.Assert(err, gc.ErrorMatches, `unit count 0 not valid`)
+}
@@ -500,6 +500,17 @@ func (st *State) IsUpgrading() (bool, error) {
 	}
 }
 
+// CurrentUpgradeInfo returns the UpgradeInfo of the upgrade currently in
+// progress. It returns an error satisfying errors.IsNotFound if there is
+// no upgrade in progress.
+func (st *State) CurrentUpgradeInfo() (*UpgradeInfo, error) {
+	doc, err := currentUpgradeInfoDoc(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UpgradeInfo{st: st, doc: *doc}, nil
+}
+
 // AbortCurrentUpgrade archives any current UpgradeInfo and sets its
 // status to UpgradeAborted. Nothing happens if there's no current
 // UpgradeInfo.
@@ -648,6 +648,24 @@ func (s *UpgradeSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *UpgradeSuite) TestCurrentUpgradeInfo(c *gc.C) {
+	_, err := s.State.CurrentUpgradeInfo()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.1.1"), vers("1.2.3"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.State.CurrentUpgradeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.PreviousVersion(), gc.Equals, vers("1.1.1"))
+	c.Check(info.TargetVersion(), gc.Equals, vers("1.2.3"))
+	c.Check(info.Status(), gc.Equals, state.UpgradePending)
+
+	err = s.State.AbortCurrentUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CurrentUpgradeInfo()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *UpgradeSuite) TestClearUpgradeInfo(c *gc.C) {
 	v111 := vers("1.1.1")
 	v123 := vers("1.2.3")
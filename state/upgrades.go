@@ -1198,3 +1198,44 @@ func AddModelType(st *State) error {
 	}
 	return st.db().RunTransaction(ops)
 }
+
+// CompressLargeSettings compresses the settings of any settings
+// documents that are large enough to be stored compressed, but which
+// were written before settings compression was introduced.
+func CompressLargeSettings(st *State) error {
+	coll, closer := st.db().GetRawCollection(settingsC)
+	defer closer()
+
+	var doc struct {
+		DocID    string   `bson:"_id"`
+		Version  int64    `bson:"version"`
+		Settings bson.Raw `bson:"settings"`
+	}
+
+	var ops []txn.Op
+	iter := coll.Find(nil).Iter()
+	for iter.Next(&doc) {
+		// Settings that are already compressed are stored as binary,
+		// rather than as a sub-document.
+		if doc.Settings.Kind != bsonDocumentKind || len(doc.Settings.Data) <= compressSettingsThreshold {
+			continue
+		}
+		settings := make(map[string]interface{})
+		if err := doc.Settings.Unmarshal(settings); err != nil {
+			return errors.Annotatef(err, "cannot read settings %q", doc.DocID)
+		}
+		ops = append(ops, txn.Op{
+			C:      settingsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: replaceSettingsUpdate(settings),
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(ops) > 0 {
+		return errors.Trace(st.runRawTransaction(ops))
+	}
+	return nil
+}
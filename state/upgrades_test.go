@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -1854,3 +1855,45 @@ func (s *upgradesSuite) checkAddPruneSettings(c *gc.C, ageProp, sizeProp, defaul
 		expectUpgradedData{settingsColl, expectedSettings},
 	)
 }
+
+func (s *upgradesSuite) TestCompressLargeSettings(c *gc.C) {
+	s.PatchValue(&compressSettingsThreshold, 256)
+	settingsColl, closer := s.state.db().GetRawCollection(settingsC)
+	defer closer()
+
+	large := map[string]interface{}{"large": strings.Repeat("large", 100), "a" + fullWidthDot + "b": "c"}
+	err := settingsColl.Insert(bson.M{
+		"_id":      "large",
+		"settings": large,
+		"version":  1,
+	}, bson.M{
+		"_id":      "small",
+		"settings": bson.M{"a": "b"},
+		"version":  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Two rounds to check idempotency.
+	for i := 0; i < 2; i++ {
+		err := CompressLargeSettings(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+
+		var rawDocs []struct {
+			DocID    string   `bson:"_id"`
+			Settings bson.Raw `bson:"settings"`
+		}
+		err = settingsColl.Find(bson.D{{"_id", bson.D{{"$in", []string{"large", "small"}}}}}).Sort("_id").All(&rawDocs)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(rawDocs, gc.HasLen, 2)
+		c.Check(rawDocs[0].Settings.Kind, gc.Equals, bsonBinaryKind)
+		c.Check(rawDocs[1].Settings.Kind, gc.Equals, bsonDocumentKind)
+
+		var settings settingsMap
+		err = rawDocs[0].Settings.Unmarshal(&settings)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(settings, jc.DeepEquals, settingsMap{
+			"large": strings.Repeat("large", 100),
+			"a.b":   "c",
+		})
+	}
+}
@@ -25,6 +25,7 @@ type Volume interface {
 	Lifer
 	status.StatusGetter
 	status.StatusSetter
+	status.StatusHistoryGetter
 
 	// VolumeTag returns the tag for the volume.
 	VolumeTag() names.VolumeTag
@@ -53,6 +54,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to. RequestedSize returns true if there is a
+	// pending request to grow the volume, otherwise false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -104,6 +110,11 @@ type volumeDoc struct {
 	// the volume as being non-detachable, and to determine
 	// which volumes must be removed along with said machine.
 	MachineId string `bson:"machineid,omitempty"`
+
+	// RequestedSize is the size, in MiB, that the provisioned
+	// volume is to be grown to by the storage provisioner. It
+	// is unset once the volume's info records the new size.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -212,6 +223,26 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	if v.doc.RequestedSize == 0 {
+		return 0, false
+	}
+	return v.doc.RequestedSize, true
+}
+
+// StatusHistory returns a slice of at most filter.Size StatusInfo items
+// or items as old as filter.Date or items newer than now - filter.Delta time
+// representing past statuses for this volume.
+func (v *volume) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        v.im.mb.db(),
+		globalKey: v.globalKey(),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.im.VolumeStatus(v.VolumeTag())
@@ -1070,11 +1101,102 @@ func (im *IAASModel) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err er
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if size, ok := v.RequestedSize(); ok && info.Size >= size {
+			// The volume has been grown to (at least) the
+			// requested size, so the request is complete.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return im.mb.db().Run(buildTxn)
 }
 
+// GrowVolume requests that the specified provisioned volume be grown
+// to the given size, in MiB. The volume is grown by the model's storage
+// provisioner, which records the new size in the volume's info once
+// the volume has been grown.
+//
+// Only model-scoped volumes may be grown, and the size must be larger
+// than the volume's current size.
+func (im *IAASModel) GrowVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "growing volume %s", tag.Id())
+	if strings.Contains(tag.Id(), "/") {
+		return errors.NotSupportedf("growing machine-scoped volume")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := im.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if requested, ok := v.RequestedSize(); ok && requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: append(isAliveDoc,
+				bson.DocElem{"info.size", info.Size},
+			),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return im.mb.db().Run(buildTxn)
+}
+
+// GrowStorageInstance requests that the storage underlying the specified
+// storage instance be grown to the given size, in MiB. Block storage is
+// grown by growing its volume; filesystem storage is grown by growing
+// its backing volume, after which the machine agent grows the filesystem
+// to fill it.
+func (im *IAASModel) GrowStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "growing storage %s", tag.Id())
+	s, err := im.storageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	switch s.Kind() {
+	case StorageKindBlock:
+		v, err := im.storageInstanceVolume(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag = v.VolumeTag()
+	case StorageKindFilesystem:
+		f, err := im.storageInstanceFilesystem(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag, err = f.Volume()
+		if err == ErrNoBackingVolume {
+			return errors.NotSupportedf("growing filesystem without a backing volume")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.NotSupportedf("growing %s storage", s.Kind())
+	}
+	return im.GrowVolume(volumeTag, size)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -241,6 +241,90 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) setupProvisionedModelVolume(c *gc.C, kind, pool string) (names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, kind, pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := names.NewVolumeTag("0")
+	err = s.IAASModel.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, volumeTag
+}
+
+func (s *VolumeStateSuite) TestGrowStorageInstance(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+	err := s.IAASModel.GrowStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume, err := s.IAASModel.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+}
+
+func (s *VolumeStateSuite) TestGrowStorageInstanceFilesystem(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedModelVolume(c, "filesystem", "modelscoped-block")
+	err := s.IAASModel.GrowStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume, err := s.IAASModel.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+}
+
+func (s *VolumeStateSuite) TestGrowVolumeNotLarger(c *gc.C) {
+	_, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+	err := s.IAASModel.GrowVolume(volumeTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `growing volume 0: new size 1024MiB must be larger than current size 1024MiB`)
+}
+
+func (s *VolumeStateSuite) TestGrowVolumeUnprovisioned(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.GrowVolume(names.NewVolumeTag("0"), 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeStateSuite) TestGrowVolumeMachineScoped(c *gc.C) {
+	err := s.IAASModel.GrowVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *VolumeStateSuite) TestSetVolumeInfoCompletesResize(c *gc.C) {
+	_, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+	err := s.IAASModel.GrowVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumeInfoSet := state.VolumeInfo{Size: 2048, VolumeId: "vol-ume", Pool: "modelscoped"}
+	err = s.IAASModel.SetVolumeInfo(volumeTag, volumeInfoSet)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
+
+	volume, err := s.IAASModel.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *VolumeStateSuite) TestWatchModelVolumeResizes(c *gc.C) {
+	_, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+
+	w := s.IAASModel.WatchModelVolumeResizes()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0") // initial
+	wc.AssertNoChange()
+
+	err := s.IAASModel.GrowVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
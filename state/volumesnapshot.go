@@ -0,0 +1,285 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of the volume
+// underlying a storage instance, taken by the storage provider. New
+// storage instances may be restored from a snapshot.
+type VolumeSnapshot interface {
+	// Id returns the ID of the snapshot, unique within the model.
+	Id() string
+
+	// SnapshotId returns the provider-allocated unique ID of the
+	// snapshot.
+	SnapshotId() string
+
+	// Volume returns the tag of the volume that was snapshotted.
+	Volume() names.VolumeTag
+
+	// Storage returns the tag of the storage instance whose volume
+	// was snapshotted. The storage instance may since have been
+	// removed.
+	Storage() names.StorageTag
+
+	// StorageName returns the name of the storage instance whose
+	// volume was snapshotted, as defined in the charm.
+	StorageName() string
+
+	// Kind returns the kind of the storage instance whose volume
+	// was snapshotted.
+	Kind() StorageKind
+
+	// Pool returns the name of the storage pool of the volume that
+	// was snapshotted.
+	Pool() string
+
+	// Size returns the size, in MiB, of the volume that was
+	// snapshotted.
+	Size() uint64
+
+	// Created returns the time at which the snapshot was recorded.
+	Created() time.Time
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records a snapshot of a volume.
+type volumeSnapshotDoc struct {
+	DocID       string      `bson:"_id"`
+	ModelUUID   string      `bson:"model-uuid"`
+	Id          string      `bson:"id"`
+	SnapshotId  string      `bson:"snapshotid"`
+	VolumeId    string      `bson:"volumeid"`
+	StorageId   string      `bson:"storageid"`
+	StorageName string      `bson:"storagename"`
+	Kind        StorageKind `bson:"storagekind"`
+	Pool        string      `bson:"pool"`
+	Size        uint64      `bson:"size"`
+	Created     int64       `bson:"created"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// SnapshotId is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) SnapshotId() string {
+	return s.doc.SnapshotId
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.VolumeId)
+}
+
+// Storage is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Storage() names.StorageTag {
+	return names.NewStorageTag(s.doc.StorageId)
+}
+
+// StorageName is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) StorageName() string {
+	return s.doc.StorageName
+}
+
+// Kind is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Kind() StorageKind {
+	return s.doc.Kind
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Size is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Size() uint64 {
+	return s.doc.Size
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// AddVolumeSnapshot records a snapshot, taken by the storage provider,
+// of the volume with the specified tag. The volume must be provisioned,
+// and assigned to a storage instance.
+func (im *IAASModel) AddVolumeSnapshot(tag names.VolumeTag, snapshotId string) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add snapshot of volume %s", tag.Id())
+	if snapshotId == "" {
+		return nil, errors.NotValidf("empty snapshot ID")
+	}
+	v, err := im.volumeByTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if v.Life() != Alive {
+		return nil, errors.New("volume is not alive")
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageTag, err := v.StorageInstance()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s, err := im.storageInstance(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq, err := sequence(im.mb, "volumesnapshot")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := volumeSnapshotDoc{
+		DocID:       im.mb.docID(id),
+		ModelUUID:   im.mb.modelUUID(),
+		Id:          id,
+		SnapshotId:  snapshotId,
+		VolumeId:    tag.Id(),
+		StorageId:   storageTag.Id(),
+		StorageName: s.StorageName(),
+		Kind:        s.Kind(),
+		Pool:        info.Pool,
+		Size:        info.Size,
+		Created:     im.mb.clock().Now().UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     v.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      volumeSnapshotsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := im.mb.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.New("volume is not alive")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshot returns the volume snapshot with the specified ID.
+func (im *IAASModel) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	coll, closer := im.mb.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+
+	var doc volumeSnapshotDoc
+	if err := coll.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshots returns all of the volume snapshots in the model,
+// ordered by ID.
+func (im *IAASModel) VolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, closer := im.mb.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		a, _ := strconv.Atoi(docs[i].Id)
+		b, _ := strconv.Atoi(docs[j].Id)
+		return a < b
+	})
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// AddStorageFromSnapshot adds a new, detached storage instance to the
+// model, for a volume that the storage provider has restored from the
+// volume snapshot with the specified ID. The storage instance has the
+// same name and kind as the storage that was snapshotted, and may be
+// attached to a new unit of an application that uses that storage.
+func (im *IAASModel) AddStorageFromSnapshot(id string, info VolumeInfo) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore volume snapshot %q", id)
+	if info.VolumeId == "" {
+		return names.StorageTag{}, errors.NotValidf("empty volume ID")
+	}
+	snapshot, err := im.VolumeSnapshot(id)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	info.Pool = snapshot.Pool()
+	switch snapshot.Kind() {
+	case StorageKindFilesystem:
+		return im.AddExistingFilesystem(
+			FilesystemInfo{Pool: info.Pool, Size: info.Size},
+			&info, snapshot.StorageName(),
+		)
+	case StorageKindBlock:
+		return im.addExistingVolume(info, snapshot.StorageName())
+	}
+	return names.StorageTag{}, errors.NotSupportedf("restoring %s storage", snapshot.Kind())
+}
+
+// addExistingVolume adds a detached storage instance of kind block, for
+// an existing, already-provisioned volume.
+func (im *IAASModel) addExistingVolume(info VolumeInfo, storageName string) (names.StorageTag, error) {
+	storageId, err := newStorageInstanceId(im.mb, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag := names.NewStorageTag(storageId)
+	volumeOps, _, err := im.addVolumeOps(
+		VolumeParams{
+			storage:    storageTag,
+			volumeInfo: &info,
+			Pool:       info.Pool,
+			Size:       info.Size,
+		},
+		"", // no machine ID
+	)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindBlock,
+			StorageName: storageName,
+			Constraints: storageInstanceConstraints{
+				Pool: info.Pool,
+				Size: info.Size,
+			},
+		},
+	}}
+	ops = append(ops, volumeOps...)
+	if err := im.mb.db().RunTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+func (s *VolumeStateSuite) TestAddVolumeSnapshot(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+	snapshot, err := s.IAASModel.AddVolumeSnapshot(volumeTag, "snap-123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0")
+	c.Assert(snapshot.SnapshotId(), gc.Equals, "snap-123")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Storage(), gc.Equals, storageTag)
+	c.Assert(snapshot.StorageName(), gc.Equals, "data")
+	c.Assert(snapshot.Kind(), gc.Equals, state.StorageKindBlock)
+	c.Assert(snapshot.Pool(), gc.Equals, "modelscoped")
+	c.Assert(snapshot.Size(), gc.Equals, uint64(1024))
+	c.Assert(snapshot.Created().IsZero(), jc.IsFalse)
+
+	fetched, err := s.IAASModel.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched.SnapshotId(), gc.Equals, "snap-123")
+	c.Assert(fetched.Created(), gc.Equals, snapshot.Created())
+}
+
+func (s *VolumeStateSuite) TestAddVolumeSnapshotUnprovisioned(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.IAASModel.AddVolumeSnapshot(names.NewVolumeTag("0"), "snap-123")
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume 0: volume "0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeStateSuite) TestAddVolumeSnapshotEmptySnapshotId(c *gc.C) {
+	_, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+	_, err := s.IAASModel.AddVolumeSnapshot(volumeTag, "")
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume 0: empty snapshot ID not valid`)
+}
+
+func (s *VolumeStateSuite) TestVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.IAASModel.VolumeSnapshot("42")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeStateSuite) TestVolumeSnapshots(c *gc.C) {
+	_, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+	for _, snapshotId := range []string{"snap-a", "snap-b"} {
+		_, err := s.IAASModel.AddVolumeSnapshot(volumeTag, snapshotId)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	snapshots, err := s.IAASModel.VolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	c.Assert(snapshots[0].Id(), gc.Equals, "0")
+	c.Assert(snapshots[0].SnapshotId(), gc.Equals, "snap-a")
+	c.Assert(snapshots[1].Id(), gc.Equals, "1")
+	c.Assert(snapshots[1].SnapshotId(), gc.Equals, "snap-b")
+}
+
+func (s *VolumeStateSuite) TestAddStorageFromSnapshotBlock(c *gc.C) {
+	_, volumeTag := s.setupProvisionedModelVolume(c, "block", "modelscoped")
+	snapshot, err := s.IAASModel.AddVolumeSnapshot(volumeTag, "snap-123")
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageTag, err := s.IAASModel.AddStorageFromSnapshot(snapshot.Id(), state.VolumeInfo{
+		Size:     1024,
+		VolumeId: "vol-restored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/1"))
+
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindBlock)
+	_, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+
+	volume, err := s.IAASModel.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeInfo{
+		Pool:     "modelscoped",
+		Size:     1024,
+		VolumeId: "vol-restored",
+	})
+}
+
+func (s *VolumeStateSuite) TestAddStorageFromSnapshotFilesystem(c *gc.C) {
+	_, volumeTag := s.setupProvisionedModelVolume(c, "filesystem", "modelscoped-block")
+	snapshot, err := s.IAASModel.AddVolumeSnapshot(volumeTag, "snap-123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Kind(), gc.Equals, state.StorageKindFilesystem)
+
+	storageTag, err := s.IAASModel.AddStorageFromSnapshot(snapshot.Id(), state.VolumeInfo{
+		Size:     1024,
+		VolumeId: "vol-restored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindFilesystem)
+
+	filesystem, err := s.IAASModel.StorageInstanceFilesystem(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	backingVolume, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.IAASModel.Volume(backingVolume)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.VolumeId, gc.Equals, "vol-restored")
+}
+
+func (s *VolumeStateSuite) TestAddStorageFromSnapshotNotFound(c *gc.C) {
+	_, err := s.IAASModel.AddStorageFromSnapshot("42", state.VolumeInfo{VolumeId: "vol-restored"})
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "42": volume snapshot "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeStateSuite) TestAddStorageFromSnapshotEmptyVolumeId(c *gc.C) {
+	_, err := s.IAASModel.AddStorageFromSnapshot("0", state.VolumeInfo{})
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0": empty volume ID not valid`)
+}
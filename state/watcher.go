@@ -224,6 +224,24 @@ func (im *IAASModel) WatchModelVolumes() StringsWatcher {
 	return im.watchModelMachinestorage(volumesC)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, including requests to grow them.
+// The watcher does not distinguish resize requests from other changes;
+// consumers should check each volume's requested size.
+func (im *IAASModel) WatchModelVolumeResizes() StringsWatcher {
+	mb := im.mb
+	return newCollectionWatcher(mb, colWCfg{
+		col: volumesC,
+		filter: func(id interface{}) bool {
+			k, err := mb.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
 // WatchModelFilesystems returns a StringsWatcher that notifies of changes
 // to the lifecycles of all model-scoped filesystems.
 func (im *IAASModel) WatchModelFilesystems() StringsWatcher {
@@ -303,6 +321,27 @@ func (im *IAASModel) watchModelMachinestorageAttachments(collection string) Stri
 	return newLifecycleWatcher(mb, collection, members, filter, nil)
 }
 
+// WatchVolumeAttachmentChanges returns a StringsWatcher that notifies
+// of any change to the model's volume attachments, including their
+// provisioning and removal. Unlike WatchModelVolumeAttachments, it is
+// not limited to changes in life or to model-scoped volumes.
+func (im *IAASModel) WatchVolumeAttachmentChanges() StringsWatcher {
+	return newCollectionWatcher(im.mb, colWCfg{
+		col:            volumeAttachmentsC,
+		includeRemoved: true,
+	})
+}
+
+// WatchFilesystemAttachmentChanges returns a StringsWatcher that
+// notifies of any change to the model's filesystem attachments,
+// including their provisioning and removal.
+func (im *IAASModel) WatchFilesystemAttachmentChanges() StringsWatcher {
+	return newCollectionWatcher(im.mb, colWCfg{
+		col:            filesystemAttachmentsC,
+		includeRemoved: true,
+	})
+}
+
 // WatchMachineVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to the specified
 // machine, for volumes scoped to the machine.
@@ -2316,6 +2355,10 @@ type colWCfg struct {
 
 	// If global is true the watcher won't be limited to this model.
 	global bool
+
+	// If includeRemoved is true, the ids of removed documents are
+	// reported along with those of changed documents.
+	includeRemoved bool
 }
 
 // newCollectionWatcher starts and returns a new StringsWatcher configured
@@ -2469,6 +2512,11 @@ func (w *collectionWatcher) initial() ([]string, error) {
 // Additionally, mergeIds strips the model UUID prefix from the id
 // before emitting it through the watcher.
 func (w *collectionWatcher) mergeIds(changes *[]string, updates map[interface{}]bool) error {
+	if w.includeRemoved {
+		for id := range updates {
+			updates[id] = true
+		}
+	}
 	return mergeIds(w.backend, changes, updates, w.convertId)
 }
 
@@ -2928,6 +2976,12 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchAnnotations returns a NotifyWatcher which triggers whenever
+// the annotations of any entity in the model change.
+func (st *State) WatchAnnotations() NotifyWatcher {
+	return newNotifyCollWatcher(st, annotationsC, isLocalID(st))
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
@@ -209,7 +209,8 @@ const (
 
 	// Destroying indicates that the entity is being destroyed.
 	//
-	// This is valid for volumes, filesystems, and models.
+	// This is valid for volumes, filesystems, models, and machine
+	// instances.
 	Destroying Status = "destroying"
 )
 
@@ -223,6 +224,7 @@ const (
 
 const (
 	MessageWaitForMachine    = "waiting for machine"
+	MessageWaitForContainer  = "waiting for container"
 	MessageInstallingAgent   = "installing agent"
 	MessageInitializingAgent = "agent initializing"
 	MessageInstallingCharm   = "installing charm software"
@@ -235,6 +237,7 @@ func (status Status) KnownInstanceStatus() bool {
 		ProvisioningError,
 		Allocating,
 		Running,
+		Destroying,
 		Unknown:
 		return true
 	}
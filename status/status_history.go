@@ -19,6 +19,14 @@ type StatusHistoryFilter struct {
 	FromDate *time.Time
 	// Delta indicates the age of the oldest log expected.
 	Delta *time.Duration
+	// ToDate, if set, indicates the latest date for which logs are
+	// expected. It may be combined with any of the other filters to
+	// select a time range.
+	ToDate *time.Time
+	// Offset indicates how many of the most recent results matching
+	// the filter should be skipped. It is used together with Size to
+	// page through a long history.
+	Offset int
 	// Exclude indicates the status messages that should be excluded
 	// from the returned result.
 	Exclude set.Strings
@@ -29,9 +37,10 @@ func (f *StatusHistoryFilter) Validate() error {
 	s := f.Size > 0
 	t := f.FromDate != nil
 	d := f.Delta != nil
+	r := f.ToDate != nil
 
 	switch {
-	case !(s || t || d):
+	case !(s || t || d || r):
 		return errors.NotValidf("missing filter parameters")
 	case s && t:
 		return errors.NotValidf("Size and Date together")
@@ -39,6 +48,12 @@ func (f *StatusHistoryFilter) Validate() error {
 		return errors.NotValidf("Size and Delta together")
 	case t && d:
 		return errors.NotValidf("Date and Delta together")
+	case f.Offset < 0:
+		return errors.NotValidf("negative Offset")
+	case f.Offset > 0 && !s:
+		return errors.NotValidf("Offset without Size")
+	case t && f.ToDate != nil && f.ToDate.Before(*f.FromDate):
+		return errors.NotValidf("ToDate before Date")
 	}
 	return nil
 }
@@ -168,6 +183,10 @@ const (
 	KindContainerInstance HistoryKind = "container"
 	// KindContainer represents an entry for a container agent.
 	KindContainer HistoryKind = "juju-container"
+	// KindVolume represents an entry for a storage volume.
+	KindVolume HistoryKind = "volume"
+	// KindFilesystem represents an entry for a storage filesystem.
+	KindFilesystem HistoryKind = "filesystem"
 )
 
 // String returns a string representation of the HistoryKind.
@@ -180,7 +199,8 @@ func (k HistoryKind) Valid() bool {
 	switch k {
 	case KindUnit, KindUnitAgent, KindWorkload,
 		KindMachineInstance, KindMachine,
-		KindContainerInstance, KindContainer:
+		KindContainerInstance, KindContainer,
+		KindVolume, KindFilesystem:
 		return true
 	}
 	return false
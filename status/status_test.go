@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/status"
@@ -106,3 +107,34 @@ func (h *statusHistorySuite) TestStatusSquashing(c *gc.C) {
 
 	c.Assert(newStatuses, gc.DeepEquals, expectedStatuses)
 }
+
+func (h *statusHistorySuite) TestFilterValidateTimeRange(c *gc.C) {
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+	filter := status.StatusHistoryFilter{FromDate: &from, ToDate: &to}
+	c.Assert(filter.Validate(), jc.ErrorIsNil)
+
+	filter = status.StatusHistoryFilter{ToDate: &to}
+	c.Assert(filter.Validate(), jc.ErrorIsNil)
+
+	filter = status.StatusHistoryFilter{FromDate: &to, ToDate: &from}
+	c.Assert(filter.Validate(), gc.ErrorMatches, "ToDate before Date not valid")
+}
+
+func (h *statusHistorySuite) TestFilterValidateOffset(c *gc.C) {
+	filter := status.StatusHistoryFilter{Size: 10, Offset: 20}
+	c.Assert(filter.Validate(), jc.ErrorIsNil)
+
+	delta := time.Hour
+	filter = status.StatusHistoryFilter{Delta: &delta, Offset: 20}
+	c.Assert(filter.Validate(), gc.ErrorMatches, "Offset without Size not valid")
+
+	filter = status.StatusHistoryFilter{Size: 10, Offset: -1}
+	c.Assert(filter.Validate(), gc.ErrorMatches, "negative Offset not valid")
+}
+
+func (h *statusHistorySuite) TestHistoryKindValid(c *gc.C) {
+	c.Assert(status.KindVolume.Valid(), jc.IsTrue)
+	c.Assert(status.KindFilesystem.Valid(), jc.IsTrue)
+	c.Assert(status.HistoryKind("bogus").Valid(), jc.IsFalse)
+}
@@ -213,6 +213,45 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes that are
+// attached to, and in use by, a machine. Volume sources whose volumes
+// support online expansion implement VolumeResizer.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to the specified sizes. The volumes may be attached to, and in
+	// use by, a machine at the time.
+	ResizeVolumes(params []VolumeResizeParams) ([]error, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems,
+// after the storage underlying them has been grown.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to fill the storage underlying them.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]error, error)
+}
+
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes, and for creating new volumes from them.
+type VolumeSnapshotter interface {
+	// SnapshotVolume takes a snapshot of the volume with the
+	// specified volume provider ID, tagging the snapshot with the
+	// given resource tags. The volume may be in use at the time.
+	SnapshotVolume(
+		volumeId string,
+		resourceTags map[string]string,
+	) (SnapshotInfo, error)
+
+	// RestoreVolume creates a new volume from the snapshot with the
+	// specified snapshot provider ID, tagging the volume with the
+	// given resource tags. RestoreVolume returns the information of
+	// the new volume, which is initially detached.
+	RestoreVolume(
+		snapshotId string,
+		resourceTags map[string]string,
+	) (VolumeInfo, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -365,3 +404,41 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the tag of the volume to grow.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Provider is the name of the storage provider that
+	// manages the volume.
+	Provider ProviderType
+
+	// Size is the size, in MiB, that the volume is to be grown to.
+	Size uint64
+}
+
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the tag of the filesystem to grow.
+	Tag names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem.
+	FilesystemId string
+
+	// Size is the size, in MiB, of the storage underlying the
+	// filesystem, which the filesystem is to be grown to fill.
+	Size uint64
+}
+
+// SnapshotInfo describes a snapshot of a volume.
+type SnapshotInfo struct {
+	// SnapshotId is the unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size, in MiB, of the volume that was snapshotted.
+	Size uint64
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/juju/errors"
@@ -173,6 +174,34 @@ func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAtt
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		results[i] = s.resizeFilesystem(arg)
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) error {
+	filesystem, ok := s.filesystems[arg.Tag]
+	if !ok {
+		return errors.Errorf("filesystem %v is not yet provisioned", arg.Tag.Id())
+	}
+	blockDevice, err := s.backingVolumeBlockDevice(filesystem.Volume)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	return errors.Trace(growFilesystem(s.run, devicePath))
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -191,6 +220,32 @@ func createPartition(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	if output, err := run("growpart", devicePath, "1"); err != nil {
+		// growpart fails if the partition already fills the
+		// disk, which is the case if an earlier attempt to
+		// grow the filesystem failed after this point.
+		if strings.Contains(output, "NOCHANGE") {
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the device with the specified
+// path to fill the device. The filesystem may be mounted.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	return nil
+}
+
 func createFilesystem(run runCommandFunc, devicePath string) error {
 	logger.Debugf("attempting to create filesystem on %q", devicePath)
 	mkfscmd := "mkfs." + defaultFilesystemType
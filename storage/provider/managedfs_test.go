@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -185,3 +186,73 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, false)
 }
+
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c).(storage.FilesystemResizer)
+	// The partition on sda is grown before the filesystem.
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{DeviceName: "sda", Size: 4}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{DeviceName: "xvdf1", Size: 6}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	s.filesystems[names.NewFilesystemTag("0/1")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}
+	results, err := source.ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:  names.NewFilesystemTag("0/0"),
+		Size: 4,
+	}, {
+		Tag:  names.NewFilesystemTag("0/1"),
+		Size: 6,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil, nil})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsPartitionUnchanged(c *gc.C) {
+	source := s.initSource(c).(storage.FilesystemResizer)
+	s.commands.expect("growpart", "/dev/sda", "1").respond(
+		"NOCHANGE: partition 1 could only be grown by 0", errors.New("exit status 1"),
+	)
+	s.commands.expect("resize2fs", "/dev/sda1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{DeviceName: "sda", Size: 4}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	results, err := source.ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:  names.NewFilesystemTag("0/0"),
+		Size: 4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsFailed(c *gc.C) {
+	source := s.initSource(c).(storage.FilesystemResizer)
+	s.commands.expect("resize2fs", "/dev/xvdf1").respond("", errors.New("badness"))
+
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{DeviceName: "xvdf1", Size: 6}
+	s.filesystems[names.NewFilesystemTag("0/1")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}
+	results, err := source.ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:  names.NewFilesystemTag("0/1"),
+		Size: 6,
+	}, {
+		Tag:  names.NewFilesystemTag("0/2"),
+		Size: 6,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], gc.ErrorMatches, "resize2fs failed: badness")
+	c.Assert(results[1], gc.ErrorMatches, "filesystem 0/2 is not yet provisioned")
+}
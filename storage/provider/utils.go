@@ -4,11 +4,12 @@
 package provider
 
 import (
-	"os/exec"
 	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/utils/privsep"
 )
 
 var logger = loggo.GetLogger("juju.storage.provider")
@@ -20,11 +21,11 @@ type runCommandFunc func(cmd string, args ...string) (string, error)
 
 // logAndExec logs the specified command and arguments, executes
 // them, and returns the combined stdout/stderr and an error if
-// the command fails.
+// the command fails. The commands need root privileges, so they
+// are run through the privileged helper if the agent is not root.
 func logAndExec(cmd string, args ...string) (string, error) {
 	logger.Debugf("running: %s %s", cmd, strings.Join(args, " "))
-	c := exec.Command(cmd, args...)
-	output, err := c.CombinedOutput()
+	output, err := privsep.Run(cmd, args...)
 	if err != nil {
 		output := strings.TrimSpace(string(output))
 		if len(output) > 0 {
@@ -631,7 +631,7 @@ func (factory *Factory) MakeModel(c *gc.C, params *ModelParams) *state.State {
 	if params.CloudName == "" {
 		params.CloudName = "dummy"
 	}
-	if params.CloudRegion == "" {
+	if params.CloudRegion == "" && params.Type == state.ModelTypeIAAS {
 		params.CloudRegion = "dummy-region"
 	}
 	if params.Owner == nil {
@@ -639,7 +639,7 @@ func (factory *Factory) MakeModel(c *gc.C, params *ModelParams) *state.State {
 		c.Assert(err, jc.ErrorIsNil)
 		params.Owner = origEnv.Owner()
 	}
-	if params.StorageProviderRegistry == nil {
+	if params.StorageProviderRegistry == nil && params.Type == state.ModelTypeIAAS {
 		params.StorageProviderRegistry = provider.CommonStorageProviders()
 	}
 	// It only makes sense to make an model with the same provider
@@ -0,0 +1,186 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bindelta computes and applies binary deltas, so that agent
+// binaries can be upgraded by transferring only the differences between
+// the old and new versions.
+package bindelta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// deltaMagic starts every delta.
+	deltaMagic = "JUJUBDF1"
+
+	// blockSize is the size of the blocks of old data that are
+	// matched in new data. Matches are extended beyond the block
+	// in both directions, so this only bounds the shortest match.
+	blockSize = 32
+
+	// hashBase is the base of the rolling polynomial hash.
+	hashBase = 16777619
+)
+
+const (
+	opCopy byte = 'c'
+	opAdd  byte = 'a'
+)
+
+// Diff returns a delta that transforms old into new when applied with
+// Patch. The delta copies the runs of new that are found in old, and
+// holds the remaining bytes of new.
+func Diff(old, new []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(deltaMagic)
+	writeUvarint(&buf, uint64(len(new)))
+
+	// Index the hashes of the aligned blocks of old data.
+	index := make(map[uint32]int)
+	for i := 0; i+blockSize <= len(old); i += blockSize {
+		h := hashBlock(old[i : i+blockSize])
+		if _, ok := index[h]; !ok {
+			index[h] = i
+		}
+	}
+
+	// The highest power of the base, for removing the byte leaving
+	// the rolling hash.
+	var top uint32 = 1
+	for i := 1; i < blockSize; i++ {
+		top *= hashBase
+	}
+
+	pending, i := 0, 0
+	var h uint32
+	if len(new) >= blockSize {
+		h = hashBlock(new[:blockSize])
+	}
+	for i+blockSize <= len(new) {
+		off, ok := index[h]
+		if ok && bytes.Equal(old[off:off+blockSize], new[i:i+blockSize]) {
+			start, oldStart := i, off
+			for start > pending && oldStart > 0 && new[start-1] == old[oldStart-1] {
+				start--
+				oldStart--
+			}
+			end, oldEnd := i+blockSize, off+blockSize
+			for end < len(new) && oldEnd < len(old) && new[end] == old[oldEnd] {
+				end++
+				oldEnd++
+			}
+			writeAdd(&buf, new[pending:start])
+			writeCopy(&buf, oldStart, end-start)
+			pending, i = end, end
+			if i+blockSize <= len(new) {
+				h = hashBlock(new[i : i+blockSize])
+			}
+			continue
+		}
+		if i+blockSize < len(new) {
+			h = (h-uint32(new[i])*top)*hashBase + uint32(new[i+blockSize])
+		}
+		i++
+	}
+	writeAdd(&buf, new[pending:])
+	return buf.Bytes()
+}
+
+// Patch applies the delta, computed by Diff, to old and returns the
+// new data.
+func Patch(old []byte, delta io.Reader) ([]byte, error) {
+	r := bufio.NewReader(delta)
+	magic := make([]byte, len(deltaMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != deltaMagic {
+		return nil, errors.NotValidf("delta header")
+	}
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading delta size")
+	}
+	// The size is only a hint, as the delta is not yet verified.
+	capacity := size
+	if capacity > uint64(len(old))*2+1<<20 {
+		capacity = 0
+	}
+	new := make([]byte, 0, capacity)
+	for {
+		op, err := r.ReadByte()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "reading delta")
+		}
+		switch op {
+		case opCopy:
+			off, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+			n, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+			if off > uint64(len(old)) || n > uint64(len(old))-off {
+				return nil, errors.NotValidf("delta copy of %d bytes at %d", n, off)
+			}
+			new = append(new, old[off:off+n]...)
+		case opAdd:
+			n, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+			if uint64(len(new))+n > size {
+				return nil, errors.NotValidf("delta addition of %d bytes", n)
+			}
+			start := len(new)
+			new = append(new, make([]byte, n)...)
+			if _, err := io.ReadFull(r, new[start:]); err != nil {
+				return nil, errors.Annotate(err, "reading delta")
+			}
+		default:
+			return nil, errors.NotValidf("delta operation %q", op)
+		}
+		if uint64(len(new)) > size {
+			return nil, errors.NotValidf("delta output larger than %d bytes", size)
+		}
+	}
+	if uint64(len(new)) != size {
+		return nil, errors.Errorf("delta produced %d bytes, expected %d", len(new), size)
+	}
+	return new, nil
+}
+
+func hashBlock(b []byte) uint32 {
+	var h uint32
+	for _, c := range b {
+		h = h*hashBase + uint32(c)
+	}
+	return h
+}
+
+func writeAdd(buf *bytes.Buffer, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	buf.WriteByte(opAdd)
+	writeUvarint(buf, uint64(len(data)))
+	buf.Write(data)
+}
+
+func writeCopy(buf *bytes.Buffer, off, n int) {
+	buf.WriteByte(opCopy)
+	writeUvarint(buf, uint64(off))
+	writeUvarint(buf, uint64(n))
+}
+
+func writeUvarint(buf *bytes.Buffer, x uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], x)])
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bindelta_test
+
+import (
+	"bytes"
+	"math/rand"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools/bindelta"
+)
+
+type DiffSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&DiffSuite{})
+
+func randomData(r *rand.Rand, n int) []byte {
+	data := make([]byte, n)
+	r.Read(data)
+	return data
+}
+
+func (s *DiffSuite) assertRoundTrip(c *gc.C, old, new []byte) []byte {
+	delta := bindelta.Diff(old, new)
+	patched, err := bindelta.Patch(old, bytes.NewReader(delta))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bytes.Equal(patched, new), jc.IsTrue)
+	return delta
+}
+
+func (s *DiffSuite) TestRoundTrip(c *gc.C) {
+	r := rand.New(rand.NewSource(0))
+	old := randomData(r, 100000)
+	for i, new := range [][]byte{
+		nil,
+		old,
+		[]byte("short"),
+		randomData(r, 1000),
+		append(append([]byte("prefix"), old[:50000]...), old[60000:]...),
+	} {
+		c.Logf("test %d", i)
+		s.assertRoundTrip(c, old, new)
+		s.assertRoundTrip(c, nil, new)
+	}
+}
+
+func (s *DiffSuite) TestDeltaSmall(c *gc.C) {
+	r := rand.New(rand.NewSource(0))
+	old := randomData(r, 100000)
+	new := append([]byte(nil), old...)
+	// Change a few scattered bytes, and insert some new ones.
+	for _, i := range []int{100, 30000, 70000} {
+		new[i]++
+	}
+	new = append(append(new[:50000:50000], randomData(r, 500)...), new[50000:]...)
+	delta := s.assertRoundTrip(c, old, new)
+	c.Assert(len(delta) < 1000, jc.IsTrue, gc.Commentf("delta is %d bytes", len(delta)))
+}
+
+func (s *DiffSuite) TestPatchInvalid(c *gc.C) {
+	old := []byte("old data that is long enough to hold a block")
+	delta := bindelta.Diff(old, append(old, "more"...))
+	_, err := bindelta.Patch(old, bytes.NewReader([]byte("garbage")))
+	c.Assert(err, gc.ErrorMatches, "delta header not valid")
+	_, err = bindelta.Patch(old[:10], bytes.NewReader(delta))
+	c.Assert(err, gc.ErrorMatches, "delta copy of .* bytes at .* not valid")
+	_, err = bindelta.Patch(old, bytes.NewReader(delta[:len(delta)-2]))
+	c.Assert(err, gc.ErrorMatches, "reading delta: unexpected EOF")
+}
@@ -0,0 +1,232 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bindelta
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ContentType is the content type of a tools delta.
+const ContentType = "application/x-juju-tools-delta"
+
+// manifestName is the name of the tools delta entry holding its
+// Manifest.
+const manifestName = "manifest.json"
+
+// Manifest describes the files of the tools built by a tools delta.
+type Manifest struct {
+	Files []File `json:"files"`
+}
+
+// File describes a file of the tools built by a tools delta.
+type File struct {
+	// Name is the name of the file.
+	Name string `json:"name"`
+
+	// Mode holds the file's permission bits.
+	Mode int64 `json:"mode"`
+
+	// SHA256 is the hex-encoded SHA256 hash of the file.
+	SHA256 string `json:"sha256"`
+
+	// BaseSHA256 is the hex-encoded SHA256 hash of the file of the
+	// same name in the base tools, to which the delta for the file
+	// is applied. It is empty if the file is held whole.
+	BaseSHA256 string `json:"base-sha256,omitempty"`
+}
+
+// MakeToolsDelta writes a tools delta to w, which builds the files of
+// the target tools tarball from those of the base tools tarball. Both
+// tarballs are gzipped tar archives, as held in tools storage. Files
+// of the target are held as deltas from the base files of the same
+// name, or whole if there are none.
+func MakeToolsDelta(w io.Writer, base, target io.Reader) error {
+	baseFiles, _, err := readTarball(base)
+	if err != nil {
+		return errors.Annotate(err, "reading base tools")
+	}
+	targetFiles, order, err := readTarball(target)
+	if err != nil {
+		return errors.Annotate(err, "reading target tools")
+	}
+
+	var manifest Manifest
+	entries := make(map[string][]byte)
+	for _, name := range order {
+		f := targetFiles[name]
+		file := File{
+			Name:   name,
+			Mode:   f.mode,
+			SHA256: sha256Hex(f.data),
+		}
+		entry := f.data
+		if baseFile, ok := baseFiles[name]; ok {
+			file.BaseSHA256 = sha256Hex(baseFile.data)
+			entry = Diff(baseFile.data, f.data)
+		}
+		manifest.Files = append(manifest.Files, file)
+		entries[name] = entry
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	if err := writeEntry(tw, manifestName, manifestData); err != nil {
+		return errors.Trace(err)
+	}
+	for _, file := range manifest.Files {
+		if err := writeEntry(tw, file.Name, entries[file.Name]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(zw.Close())
+}
+
+// ApplyToolsDelta reads a tools delta from r, and writes the files it
+// builds from the tools in baseDir into targetDir. Every file written
+// is verified against the hash in the delta's manifest.
+func ApplyToolsDelta(r io.Reader, baseDir, targetDir string) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Annotate(err, "reading tools delta")
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return errors.Annotate(err, "reading tools delta")
+	}
+	if hdr.Name != manifestName {
+		return errors.NotValidf("tools delta without manifest")
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return errors.Annotate(err, "reading tools delta manifest")
+	}
+
+	for _, file := range manifest.Files {
+		if err := checkName(file.Name); err != nil {
+			return errors.Trace(err)
+		}
+		hdr, err := tr.Next()
+		if err != nil {
+			return errors.Annotatef(err, "reading tools delta entry for %q", file.Name)
+		}
+		if hdr.Name != file.Name {
+			return errors.Errorf("expected tools delta entry for %q, got %q", file.Name, hdr.Name)
+		}
+		data, err := buildFile(file, tr, baseDir)
+		if err != nil {
+			return errors.Annotatef(err, "building %q", file.Name)
+		}
+		if hash := sha256Hex(data); hash != file.SHA256 {
+			return errors.Errorf("sha256 mismatch for %q, expected %s, got %s", file.Name, file.SHA256, hash)
+		}
+		path := filepath.Join(targetDir, file.Name)
+		if err := ioutil.WriteFile(path, data, os.FileMode(file.Mode&0777)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// buildFile returns the contents of the file built from the delta
+// entry read from r.
+func buildFile(file File, r io.Reader, baseDir string) ([]byte, error) {
+	if file.BaseSHA256 == "" {
+		return ioutil.ReadAll(r)
+	}
+	base, err := ioutil.ReadFile(filepath.Join(baseDir, file.Name))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading base file")
+	}
+	if hash := sha256Hex(base); hash != file.BaseSHA256 {
+		return nil, errors.Errorf("base sha256 mismatch, expected %s, got %s", file.BaseSHA256, hash)
+	}
+	return Patch(base, r)
+}
+
+type tarballFile struct {
+	mode int64
+	data []byte
+}
+
+// readTarball returns the regular files in the given gzipped tar
+// archive, and their names in archive order.
+func readTarball(r io.Reader) (map[string]tarballFile, []string, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer zr.Close()
+	files := make(map[string]tarballFile)
+	var order []string
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, nil, errors.Errorf("bad file type %c in file %q in tools archive", hdr.Typeflag, hdr.Name)
+		}
+		if err := checkName(hdr.Name); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if _, ok := files[hdr.Name]; !ok {
+			order = append(order, hdr.Name)
+		}
+		files[hdr.Name] = tarballFile{mode: hdr.Mode, data: data}
+	}
+	return files, order, nil
+}
+
+func checkName(name string) error {
+	if name == "" || name == manifestName || strings.ContainsAny(name, "/\\") {
+		return errors.Errorf("bad name %q in tools archive", name)
+	}
+	return nil
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := io.Copy(tw, bytes.NewReader(data))
+	return errors.Trace(err)
+}
+
+func sha256Hex(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bindelta_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools/bindelta"
+)
+
+type ToolsDeltaSuite struct {
+	testing.BaseSuite
+
+	baseDir   string
+	targetDir string
+}
+
+var _ = gc.Suite(&ToolsDeltaSuite{})
+
+func (s *ToolsDeltaSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.baseDir = c.MkDir()
+	s.targetDir = c.MkDir()
+}
+
+var (
+	baseJujud   = strings.Repeat("jujud version 1 ", 100)
+	targetJujud = strings.Repeat("jujud version 1 ", 50) + "change" + strings.Repeat("jujud version 1 ", 50)
+)
+
+func (s *ToolsDeltaSuite) makeDelta(c *gc.C) []byte {
+	base, _ := testing.TarGz(
+		testing.NewTarFile("jujud", 0755, baseJujud),
+		testing.NewTarFile("FORCE-VERSION", 0644, "1"),
+	)
+	target, _ := testing.TarGz(
+		testing.NewTarFile("jujud", 0755, targetJujud),
+		testing.NewTarFile("juju-metadata", 0755, "new file"),
+	)
+	var buf bytes.Buffer
+	err := bindelta.MakeToolsDelta(&buf, bytes.NewReader(base), bytes.NewReader(target))
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *ToolsDeltaSuite) writeBase(c *gc.C, name, contents string) {
+	err := ioutil.WriteFile(filepath.Join(s.baseDir, name), []byte(contents), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ToolsDeltaSuite) TestApplyToolsDelta(c *gc.C) {
+	delta := s.makeDelta(c)
+	s.writeBase(c, "jujud", baseJujud)
+
+	err := bindelta.ApplyToolsDelta(bytes.NewReader(delta), s.baseDir, s.targetDir)
+	c.Assert(err, jc.ErrorIsNil)
+	for name, contents := range map[string]string{
+		"jujud":         targetJujud,
+		"juju-metadata": "new file",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(s.targetDir, name))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(data), gc.Equals, contents)
+	}
+	_, err = os.Stat(filepath.Join(s.targetDir, "FORCE-VERSION"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ToolsDeltaSuite) TestApplyToolsDeltaBaseMismatch(c *gc.C) {
+	delta := s.makeDelta(c)
+	s.writeBase(c, "jujud", "some other jujud")
+
+	err := bindelta.ApplyToolsDelta(bytes.NewReader(delta), s.baseDir, s.targetDir)
+	c.Assert(err, gc.ErrorMatches, `building "jujud": base sha256 mismatch, expected .*, got .*`)
+}
+
+func (s *ToolsDeltaSuite) TestApplyToolsDeltaMissingBase(c *gc.C) {
+	delta := s.makeDelta(c)
+
+	err := bindelta.ApplyToolsDelta(bytes.NewReader(delta), s.baseDir, s.targetDir)
+	c.Assert(err, gc.ErrorMatches, `building "jujud": reading base file: .*`)
+}
+
+func (s *ToolsDeltaSuite) TestMakeToolsDeltaBadTarball(c *gc.C) {
+	var buf bytes.Buffer
+	err := bindelta.MakeToolsDelta(&buf, strings.NewReader("junk"), strings.NewReader("junk"))
+	c.Assert(err, gc.ErrorMatches, "reading base tools: .*")
+}
@@ -32,6 +32,8 @@ type StateBackend interface {
 	CorrectRelationUnitCounts() error
 	AddModelEnvironVersion() error
 	AddModelType() error
+	CompressLargeSettings() error
+	EnsureIndexes() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -122,6 +124,17 @@ func (s stateBackend) AddModelType() error {
 	return state.AddModelType(s.st)
 }
 
+func (s stateBackend) CompressLargeSettings() error {
+	return state.CompressLargeSettings(s.st)
+}
+
+func (s stateBackend) EnsureIndexes() error {
+	// The indexes created, and any unknown indexes, are logged
+	// by state as they are found.
+	_, err := state.EnsureIndexes(s.st)
+	return err
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
@@ -13,5 +13,19 @@ func stateStepsFor23() []Step {
 				return context.State().AddModelType()
 			},
 		},
+		&upgradeStep{
+			description: "compress large settings documents",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().CompressLargeSettings()
+			},
+		},
+		&upgradeStep{
+			description: "create missing database indexes",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().EnsureIndexes()
+			},
+		},
 	}
 }
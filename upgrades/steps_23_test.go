@@ -25,3 +25,15 @@ func (s *steps23Suite) TestAddModelType(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps23Suite) TestCompressLargeSettings(c *gc.C) {
+	step := findStateStep(c, v23, "compress large settings documents")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
+func (s *steps23Suite) TestEnsureIndexes(c *gc.C) {
+	step := findStateStep(c, v23, "create missing database indexes")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
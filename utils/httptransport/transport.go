@@ -0,0 +1,167 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package httptransport provides the HTTP transport used for the
+// requests Juju makes to clouds and to simplestreams sources. Each
+// request is sent with a Juju User-Agent and a request ID, which is
+// logged, so that requests in a cloud's logs can be correlated with
+// the Juju operations that made them.
+package httptransport
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/version"
+)
+
+var logger = loggo.GetLogger("juju.utils.httptransport")
+
+const (
+	// RequestIDHeader is the header holding the ID of a request.
+	RequestIDHeader = "X-Juju-Request-Id"
+
+	// ControllerHeader is the header holding the UUID of the
+	// controller a request is made for, when it is known.
+	ControllerHeader = "X-Juju-Controller-Uuid"
+
+	// ModelHeader is the header holding the UUID of the model a
+	// request is made for, when it is known.
+	ModelHeader = "X-Juju-Model-Uuid"
+)
+
+// UserAgent returns the User-Agent sent with Juju's requests.
+func UserAgent() string {
+	return "Juju/" + version.Current.String()
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context that causes the requests made with
+// it to be sent with the given request ID, rather than one generated
+// for each request. It is used to correlate the requests made for a
+// single operation.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID set in the context by WithRequestID,
+// or "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Transport is an http.RoundTripper that adds Juju's User-Agent, a
+// request ID and, if they are set, the controller and model UUIDs to
+// each request, before sending it with the Base RoundTripper.
+type Transport struct {
+	// Base is the RoundTripper used to send requests. If it is nil,
+	// http.DefaultTransport is used, which in the agents uses the
+	// model's proxy settings.
+	Base http.RoundTripper
+
+	// ControllerUUID, if set, is the UUID of the controller the
+	// requests are made for.
+	ControllerUUID string
+
+	// ModelUUID, if set, is the UUID of the model the requests are
+	// made for.
+	ModelUUID string
+}
+
+// NewTransport returns a Transport sending requests with a new
+// http.Transport, which uses the given TLS configuration and the same
+// proxy settings as http.DefaultTransport.
+func NewTransport(tlsConfig *tls.Config) *Transport {
+	base := utils.NewHttpTLSTransport(tlsConfig)
+	base.Proxy = defaultProxy
+	return &Transport{Base: base}
+}
+
+// NewClient returns an HTTP client using a Transport that enforces the
+// given SSL hostname verification policy.
+func NewClient(hostnameVerification utils.SSLHostnameVerification) *http.Client {
+	if hostnameVerification == utils.VerifySSLHostnames {
+		return &http.Client{Transport: &Transport{}}
+	}
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.InsecureSkipVerify = true
+	return &http.Client{Transport: NewTransport(tlsConfig)}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given, so
+	// the headers are added to a copy.
+	req2 := new(http.Request)
+	*req2 = *req
+	req2.Header = make(http.Header, len(req.Header)+4)
+	for k, v := range req.Header {
+		req2.Header[k] = append([]string(nil), v...)
+	}
+
+	userAgent := UserAgent()
+	switch ua := req.Header.Get("User-Agent"); {
+	case ua == "":
+	case strings.HasPrefix(ua, userAgent):
+		userAgent = ua
+	default:
+		userAgent += " " + ua
+	}
+	req2.Header.Set("User-Agent", userAgent)
+
+	id := req.Header.Get(RequestIDHeader)
+	if id == "" {
+		id = RequestID(req.Context())
+	}
+	if id == "" {
+		uuid, err := utils.NewUUID()
+		if err != nil {
+			logger.Warningf("cannot generate request ID: %v", err)
+		} else {
+			id = uuid.String()
+		}
+	}
+	if id != "" {
+		req2.Header.Set(RequestIDHeader, id)
+	}
+	if t.ControllerUUID != "" {
+		req2.Header.Set(ControllerHeader, t.ControllerUUID)
+	}
+	if t.ModelUUID != "" {
+		req2.Header.Set(ModelHeader, t.ModelUUID)
+	}
+
+	logger.Tracef("request %s: %s %s", id, req.Method, req.URL)
+	resp, err := t.base().RoundTrip(req2)
+	if err != nil {
+		logger.Debugf("request %s: %s %s failed: %v", id, req.Method, req.URL, err)
+		return nil, err
+	}
+	logger.Tracef("request %s: %s", id, resp.Status)
+	return resp, nil
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// defaultProxy returns the proxy to use for the request, as chosen by
+// http.DefaultTransport. The agents set that to use the model's proxy
+// settings; the client uses those of the environment.
+func defaultProxy(req *http.Request) (*url.URL, error) {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok && transport.Proxy != nil {
+		return transport.Proxy(req)
+	}
+	return nil, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httptransport_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/utils/httptransport"
+)
+
+type transportSuite struct {
+	testing.IsolationSuite
+	server  *httptest.Server
+	headers []http.Header
+}
+
+var _ = gc.Suite(&transportSuite{})
+
+func (s *transportSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.headers = nil
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.headers = append(s.headers, r.Header)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *transportSuite) get(c *gc.C, transport http.RoundTripper, req *http.Request) http.Header {
+	client := &http.Client{Transport: transport}
+	resp, err := client.Do(req)
+	c.Assert(err, jc.ErrorIsNil)
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	return s.headers[len(s.headers)-1]
+}
+
+func (s *transportSuite) newRequest(c *gc.C) *http.Request {
+	req, err := http.NewRequest("GET", s.server.URL, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return req
+}
+
+func (s *transportSuite) newTransport() *httptransport.Transport {
+	return &httptransport.Transport{
+		Base: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+}
+
+func (s *transportSuite) TestUserAgentAndRequestID(c *gc.C) {
+	transport := s.newTransport()
+	header := s.get(c, transport, s.newRequest(c))
+	c.Assert(header.Get("User-Agent"), gc.Equals, httptransport.UserAgent())
+	id := header.Get(httptransport.RequestIDHeader)
+	c.Assert(utils.IsValidUUIDString(id), jc.IsTrue)
+	c.Assert(header.Get(httptransport.ControllerHeader), gc.Equals, "")
+	c.Assert(header.Get(httptransport.ModelHeader), gc.Equals, "")
+
+	// Each request has its own ID.
+	header = s.get(c, transport, s.newRequest(c))
+	c.Assert(header.Get(httptransport.RequestIDHeader), gc.Not(gc.Equals), id)
+}
+
+func (s *transportSuite) TestUserAgentPrefixed(c *gc.C) {
+	req := s.newRequest(c)
+	req.Header.Set("User-Agent", "google-api-go-client/0.5")
+	header := s.get(c, s.newTransport(), req)
+	c.Assert(header.Get("User-Agent"), gc.Equals, httptransport.UserAgent()+" google-api-go-client/0.5")
+
+	req = s.newRequest(c)
+	req.Header.Set("User-Agent", httptransport.UserAgent()+" azure")
+	header = s.get(c, s.newTransport(), req)
+	c.Assert(header.Get("User-Agent"), gc.Equals, httptransport.UserAgent()+" azure")
+}
+
+func (s *transportSuite) TestRequestIDFromContext(c *gc.C) {
+	req := s.newRequest(c)
+	req = req.WithContext(httptransport.WithRequestID(context.Background(), "deploy-mysql"))
+	header := s.get(c, s.newTransport(), req)
+	c.Assert(header.Get(httptransport.RequestIDHeader), gc.Equals, "deploy-mysql")
+}
+
+func (s *transportSuite) TestControllerAndModel(c *gc.C) {
+	transport := s.newTransport()
+	transport.ControllerUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	transport.ModelUUID = "deadbeef-0bad-400d-8000-5b1d0d06f00d"
+	header := s.get(c, transport, s.newRequest(c))
+	c.Assert(header.Get(httptransport.ControllerHeader), gc.Equals, transport.ControllerUUID)
+	c.Assert(header.Get(httptransport.ModelHeader), gc.Equals, transport.ModelUUID)
+}
+
+func (s *transportSuite) TestRequestNotModified(c *gc.C) {
+	req := s.newRequest(c)
+	s.get(c, s.newTransport(), req)
+	c.Assert(req.Header, gc.HasLen, 0)
+}
+
+func (s *transportSuite) TestNewClientNoVerify(c *gc.C) {
+	client := httptransport.NewClient(utils.NoVerifySSLHostnames)
+	resp, err := client.Get(s.server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	resp.Body.Close()
+	c.Assert(s.headers, gc.HasLen, 1)
+	c.Assert(s.headers[0].Get("User-Agent"), gc.Equals, httptransport.UserAgent())
+}
+
+func (s *transportSuite) TestNewClientVerify(c *gc.C) {
+	client := httptransport.NewClient(utils.VerifySSLHostnames)
+	_, err := client.Get(s.server.URL)
+	c.Assert(err, gc.ErrorMatches, ".*certificate signed by unknown authority")
+}
@@ -0,0 +1,429 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// argsChecker checks the arguments of an allowed command, and returns
+// the arguments the helper runs it with. Paths in the returned
+// arguments have their symlinks resolved, so that the command acts on
+// the path that was checked.
+type argsChecker func(args []string) ([]string, error)
+
+// allowed holds the commands the helper runs, with the checks on
+// their arguments. Every command accepts only the exact forms that
+// the agent uses; commands whose arguments cannot be constrained to
+// safe values, such as mkfs for arbitrary filesystem types or ip, are
+// not allowed at all.
+var allowed = map[string]argsChecker{
+	// Storage.
+	"mount":     checkMount,
+	"umount":    checkUmount,
+	"losetup":   checkLosetup,
+	"fallocate": checkFallocate,
+	"sgdisk":    checkSgdisk,
+	"growpart":  checkGrowpart,
+	"resize2fs": singleDevice(storageDevicePath),
+	"mkfs.ext4": singleDevice(devicePath),
+	"df":        checkDf,
+
+	// Packages.
+	"apt-get": checkAptGet,
+	"yum":     checkYum,
+
+	// Network configuration.
+	bridgeCommand: checkBridge,
+
+	// Reboot and shutdown.
+	"shutdown": checkShutdown,
+}
+
+var (
+	// devicePrefix is the directory holding the block devices that
+	// the helper works on.
+	devicePrefix = "/dev/"
+
+	// storagePrefix is the directory holding the agent's storage,
+	// including the files backing loop devices.
+	storagePrefix = "/var/lib/juju/storage/"
+
+	// mountPrefixes holds the directories under which the helper
+	// mounts filesystems.
+	mountPrefixes = []string{
+		"/var/lib/juju/storage/",
+		"/srv/",
+		"/mnt/",
+		"/media/",
+	}
+
+	// isBlockDevice reports whether the file is a block device.
+	isBlockDevice = func(info os.FileInfo) bool {
+		mode := info.Mode()
+		return mode&os.ModeDevice != 0 && mode&os.ModeCharDevice == 0
+	}
+)
+
+// CheckRequest returns the request the helper runs for req, or an
+// error if the helper must not run it.
+func CheckRequest(req Request) (Request, error) {
+	if strings.ContainsRune(req.Command, '/') {
+		return Request{}, errors.NotValidf("command %q with path", req.Command)
+	}
+	check, ok := allowed[req.Command]
+	if !ok {
+		return Request{}, errors.Errorf("command %q not allowed", req.Command)
+	}
+	args, err := check(req.Args)
+	if err != nil {
+		return Request{}, errors.Annotatef(err, "command %q", req.Command)
+	}
+	return Request{Command: req.Command, Args: args}, nil
+}
+
+// checkMount allows "mount [-o ro] <device> <mount-point>",
+// "mount --bind <storage-path> <mount-point>" and
+// "mount -t tmpfs <filesystem-tag> <mount-point> -o size=<N>m[,ro]".
+func checkMount(args []string) ([]string, error) {
+	switch {
+	case len(args) == 2:
+		return devicePair(nil, args[0], args[1])
+	case len(args) == 4 && args[0] == "-o" && args[1] == "ro":
+		return devicePair(args[:2], args[2], args[3])
+	case len(args) == 3 && args[0] == "--bind":
+		source, err := storagePath(args[1], true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		target, err := mountPoint(args[2])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []string{"--bind", source, target}, nil
+	case len(args) == 6 && args[0] == "-t" && args[1] == "tmpfs" && args[4] == "-o":
+		if !tmpfsSourceRE.MatchString(args[2]) {
+			return nil, errors.NotValidf("tmpfs source %q", args[2])
+		}
+		if !tmpfsOptionsRE.MatchString(args[5]) {
+			return nil, errors.NotValidf("tmpfs options %q", args[5])
+		}
+		target, err := mountPoint(args[3])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []string{"-t", "tmpfs", args[2], target, "-o", args[5]}, nil
+	}
+	return nil, errors.NotValidf("arguments %q", args)
+}
+
+var (
+	tmpfsSourceRE  = regexp.MustCompile(`^filesystem-[0-9]+(-[0-9]+)?$`)
+	tmpfsOptionsRE = regexp.MustCompile(`^size=[0-9]+m(,ro)?$`)
+)
+
+func devicePair(prefix []string, device, target string) ([]string, error) {
+	device, err := devicePath(device)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target, err = mountPoint(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(append([]string(nil), prefix...), device, target), nil
+}
+
+// checkUmount allows "umount <mount-point>".
+func checkUmount(args []string) ([]string, error) {
+	if len(args) != 1 {
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	target, err := mountPoint(args[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []string{target}, nil
+}
+
+// checkLosetup allows "losetup -f --show [-r] <storage-file>",
+// "losetup -d /dev/loop<N>" and "losetup -j <storage-file>".
+func checkLosetup(args []string) ([]string, error) {
+	switch {
+	case len(args) == 2 && args[0] == "-d":
+		if !loopDeviceRE.MatchString(args[1]) {
+			return nil, errors.NotValidf("loop device %q", args[1])
+		}
+		return args, nil
+	case len(args) == 2 && args[0] == "-j":
+		file, err := storagePath(args[1], true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []string{"-j", file}, nil
+	case len(args) >= 3 && args[0] == "-f" && args[1] == "--show":
+		flags := args[:len(args)-1]
+		if len(flags) == 3 && flags[2] != "-r" || len(flags) > 3 {
+			break
+		}
+		file, err := storagePath(args[len(args)-1], true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(append([]string(nil), flags...), file), nil
+	}
+	return nil, errors.NotValidf("arguments %q", args)
+}
+
+var loopDeviceRE = regexp.MustCompile(`^/dev/loop[0-9]+$`)
+
+// checkFallocate allows "fallocate -l <N>MiB <storage-file>".
+func checkFallocate(args []string) ([]string, error) {
+	if len(args) != 3 || args[0] != "-l" || !fallocateSizeRE.MatchString(args[1]) {
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	file, err := storagePath(args[2], false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []string{"-l", args[1], file}, nil
+}
+
+var fallocateSizeRE = regexp.MustCompile(`^[0-9]+MiB$`)
+
+// checkSgdisk allows "sgdisk --zap-all <device>" and
+// "sgdisk -n 1:0:-1 <device>".
+func checkSgdisk(args []string) ([]string, error) {
+	switch {
+	case len(args) == 2 && args[0] == "--zap-all":
+	case len(args) == 3 && args[0] == "-n" && args[1] == "1:0:-1":
+	default:
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	device, err := devicePath(args[len(args)-1])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(args[:len(args)-1:len(args)-1], device), nil
+}
+
+// checkGrowpart allows "growpart <device> 1".
+func checkGrowpart(args []string) ([]string, error) {
+	if len(args) != 2 || args[1] != "1" {
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	device, err := storageDevicePath(args[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []string{device, "1"}, nil
+}
+
+// singleDevice returns a checker that allows a single block device
+// argument, resolved with the given function.
+func singleDevice(resolve func(string) (string, error)) argsChecker {
+	return func(args []string) ([]string, error) {
+		if len(args) != 1 {
+			return nil, errors.NotValidf("arguments %q", args)
+		}
+		device, err := resolve(args[0])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []string{device}, nil
+	}
+}
+
+// checkDf allows "df --output=<field> <path>".
+func checkDf(args []string) ([]string, error) {
+	if len(args) != 2 {
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	switch args[0] {
+	case "--output=size", "--output=target", "--output=source":
+	default:
+		return nil, errors.NotValidf("option %q", args[0])
+	}
+	if err := checkAbs(args[1]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return args, nil
+}
+
+// aptGetOptions holds the options the helper always runs apt-get
+// with. The caller cannot add options of its own, as options such as
+// -o and -c can run arbitrary commands.
+var aptGetOptions = []string{
+	"--option=Dpkg::Options::=--force-confold",
+	"--option=Dpkg::Options::=--force-unsafe-io",
+	"--assume-yes",
+	"--quiet",
+}
+
+// yumOptions holds the options the helper always runs yum with. As
+// for apt-get, options such as --setopt are never passed through.
+var yumOptions = []string{"--assumeyes"}
+
+// checkAptGet allows "apt-get update" and
+// "apt-get install [--target-release <release>] <package>...".
+func checkAptGet(args []string) ([]string, error) {
+	if len(args) == 1 && args[0] == "update" {
+		return append(aptGetOptions[:len(aptGetOptions):len(aptGetOptions)], args...), nil
+	}
+	if len(args) == 0 || args[0] != "install" {
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	packages := args[1:]
+	if len(packages) >= 2 && packages[0] == "--target-release" {
+		if !releaseRE.MatchString(packages[1]) {
+			return nil, errors.NotValidf("target release %q", packages[1])
+		}
+		packages = packages[2:]
+	}
+	if err := checkPackages(packages); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(aptGetOptions[:len(aptGetOptions):len(aptGetOptions)], args...), nil
+}
+
+// checkYum allows "yum makecache" and "yum install <package>...".
+func checkYum(args []string) ([]string, error) {
+	switch {
+	case len(args) == 1 && args[0] == "makecache":
+	case len(args) > 0 && args[0] == "install":
+		if err := checkPackages(args[1:]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	return append(yumOptions[:len(yumOptions):len(yumOptions)], args...), nil
+}
+
+var (
+	packageRE = regexp.MustCompile(`^[a-z0-9][a-z0-9+.-]*(=[A-Za-z0-9.+:~-]+)?$`)
+	releaseRE = regexp.MustCompile(`^[a-z]+(-[a-z]+)?(/[a-z-]+)?$`)
+)
+
+func checkPackages(packages []string) error {
+	if len(packages) == 0 {
+		return errors.New("no packages")
+	}
+	for _, pkg := range packages {
+		if !packageRE.MatchString(pkg) {
+			return errors.NotValidf("package %q", pkg)
+		}
+	}
+	return nil
+}
+
+// checkShutdown allows "shutdown -r|-h +<minutes>".
+func checkShutdown(args []string) ([]string, error) {
+	if len(args) != 2 || args[0] != "-r" && args[0] != "-h" || !strings.HasPrefix(args[1], "+") {
+		return nil, errors.NotValidf("arguments %q", args)
+	}
+	if n, err := strconv.Atoi(args[1][1:]); err != nil || n < 0 || n > 60 {
+		return nil, errors.NotValidf("delay %q", args[1])
+	}
+	return args, nil
+}
+
+// checkAbs returns an error unless path is absolute and clean.
+func checkAbs(path string) error {
+	if !filepath.IsAbs(path) || filepath.Clean(path) != path {
+		return errors.NotValidf("path %q", path)
+	}
+	return nil
+}
+
+// resolvePath resolves the symlinks in path, and checks that the
+// result is under one of the prefixes. If mustExist is false, only
+// the parent directory of path needs to exist.
+func resolvePath(path string, mustExist bool, prefixes ...string) (string, error) {
+	if err := checkAbs(path); err != nil {
+		return "", errors.Trace(err)
+	}
+	var resolved string
+	var err error
+	if mustExist {
+		resolved, err = filepath.EvalSymlinks(path)
+	} else {
+		resolved, err = filepath.EvalSymlinks(filepath.Dir(path))
+		resolved = filepath.Join(resolved, filepath.Base(path))
+		if err == nil {
+			if info, lerr := os.Lstat(resolved); lerr == nil && info.Mode()&os.ModeSymlink != 0 {
+				return "", errors.NotValidf("symlink %q", path)
+			}
+		}
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(resolved, prefix) {
+			return resolved, nil
+		}
+	}
+	return "", errors.NotValidf("path %q outside %s", path, strings.Join(prefixes, ", "))
+}
+
+// devicePath returns the resolved path of the block device, which
+// must not be in use.
+func devicePath(path string) (string, error) {
+	return blockDevicePath(path, false)
+}
+
+// storageDevicePath returns the resolved path of the block device,
+// which may only be in use by filesystems mounted under the helper's
+// mount points.
+func storageDevicePath(path string) (string, error) {
+	return blockDevicePath(path, true)
+}
+
+func blockDevicePath(path string, allowStorage bool) (string, error) {
+	resolved, err := resolvePath(path, true, devicePrefix)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !isBlockDevice(info) {
+		return "", errors.NotValidf("%q: not a block device", path)
+	}
+	if err := checkDeviceUnused(resolved, allowStorage); err != nil {
+		return "", errors.Trace(err)
+	}
+	return resolved, nil
+}
+
+// storagePath returns the resolved path of a file in the storage
+// directory.
+func storagePath(path string, mustExist bool) (string, error) {
+	return resolvePath(path, mustExist, storagePrefix)
+}
+
+// mountPoint returns the resolved path of a mount point, which must
+// be an existing directory.
+func mountPoint(path string) (string, error) {
+	resolved, err := resolvePath(path, true, mountPrefixes...)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !info.IsDir() {
+		return "", errors.NotValidf("mount point %q: not a directory", path)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// bridgeCommand is the helper request that bridges network devices.
+// It is run by the helper itself rather than by an external command,
+// so that the agent only supplies the names of the devices, and never
+// the network configuration that is written.
+const bridgeCommand = "bridge-interfaces"
+
+const (
+	networkInterfacesFile = "/etc/network/interfaces"
+	netplanDirectory      = "/etc/netplan"
+	bridgeTimeout         = 5 * time.Minute
+	maxReconfigureDelay   = 300
+)
+
+var (
+	interfaceNameRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:@-]{0,14}$`)
+	macAddressRE    = regexp.MustCompile(`^([0-9a-f]{2}:){5}[0-9a-f]{2}$`)
+)
+
+// checkBridge allows "bridge-interfaces <reconfigure-delay>
+// <device>=<bridge>[=<mac-address>]...".
+func checkBridge(args []string) ([]string, error) {
+	if _, _, err := parseBridgeArgs(args); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return args, nil
+}
+
+func parseBridgeArgs(args []string) ([]network.DeviceToBridge, int, error) {
+	if len(args) < 2 {
+		return nil, 0, errors.NotValidf("arguments %q", args)
+	}
+	delay, err := strconv.Atoi(args[0])
+	if err != nil || delay < 0 || delay > maxReconfigureDelay {
+		return nil, 0, errors.NotValidf("reconfigure delay %q", args[0])
+	}
+	devices := make([]network.DeviceToBridge, len(args)-1)
+	for i, arg := range args[1:] {
+		parts := strings.Split(arg, "=")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, 0, errors.NotValidf("device %q", arg)
+		}
+		for _, name := range parts[:2] {
+			if !interfaceNameRE.MatchString(name) {
+				return nil, 0, errors.NotValidf("interface name %q", name)
+			}
+		}
+		devices[i].DeviceName = parts[0]
+		devices[i].BridgeName = parts[1]
+		if len(parts) == 3 {
+			if !macAddressRE.MatchString(parts[2]) {
+				return nil, 0, errors.NotValidf("MAC address %q", parts[2])
+			}
+			devices[i].MACAddress = parts[2]
+		}
+	}
+	return devices, delay, nil
+}
+
+// newSystemBridger returns the network.Bridger for the host's network
+// configuration; it is a variable so that it can be replaced in tests.
+var newSystemBridger = func() (network.Bridger, error) {
+	if _, err := os.Stat(networkInterfacesFile); err == nil {
+		return network.DefaultEtcNetworkInterfacesBridger(bridgeTimeout, networkInterfacesFile)
+	}
+	return network.DefaultNetplanBridger(bridgeTimeout, netplanDirectory)
+}
+
+// bridge bridges the devices in the (checked) arguments.
+func bridge(args []string) ([]byte, error) {
+	devices, delay, err := parseBridgeArgs(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bridger, err := newSystemBridger()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return nil, errors.Trace(bridger.Bridge(devices, delay))
+}
+
+// NewBridger returns a network.Bridger that asks the helper to bridge
+// the host's devices, for use by agents that are not running as root.
+func NewBridger() network.Bridger {
+	return helperBridger{helperRunner{HelperPath}}
+}
+
+type helperBridger struct {
+	runner Runner
+}
+
+// Bridge is part of the network.Bridger interface.
+func (b helperBridger) Bridge(devices []network.DeviceToBridge, reconfigureDelay int) error {
+	args := []string{strconv.Itoa(reconfigureDelay)}
+	for _, device := range devices {
+		arg := device.DeviceName + "=" + device.BridgeName
+		if device.MACAddress != "" {
+			arg += "=" + strings.ToLower(device.MACAddress)
+		}
+		args = append(args, arg)
+	}
+	output, err := b.runner.Run(bridgeCommand, args...)
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			err = errors.Annotate(err, msg)
+		}
+		return errors.Annotate(err, "bridging devices")
+	}
+	return nil
+}
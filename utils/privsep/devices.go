@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// mountInfoPath is the file listing the mounts seen by the helper.
+	mountInfoPath = "/proc/self/mountinfo"
+
+	// sysBlockPrefix is the directory describing the block devices
+	// and their partitions.
+	sysBlockPrefix = "/sys/class/block/"
+)
+
+// mountInfo describes a mounted filesystem.
+type mountInfo struct {
+	// device holds the "major:minor" number of the mounted device.
+	device string
+
+	// target holds the mount point.
+	target string
+}
+
+// mountInfoUnescaper undoes the escaping of white space and
+// backslashes in mount points in /proc/self/mountinfo.
+var mountInfoUnescaper = strings.NewReplacer(
+	`\040`, " ",
+	`\011`, "\t",
+	`\012`, "\n",
+	`\134`, `\`,
+)
+
+// readMounts returns the filesystems mounted on the machine.
+func readMounts() ([]mountInfo, error) {
+	f, err := os.Open(mountInfoPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	var mounts []mountInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mounts = append(mounts, mountInfo{
+			device: fields[2],
+			target: mountInfoUnescaper.Replace(fields[4]),
+		})
+	}
+	return mounts, errors.Trace(scanner.Err())
+}
+
+// blockDevices returns the names of the block device with the given
+// resolved path, and of its partitions.
+func blockDevices(device string) ([]string, error) {
+	name := filepath.Base(device)
+	if _, err := os.Stat(filepath.Join(sysBlockPrefix, name)); err != nil {
+		return nil, errors.Annotatef(err, "finding block device %q", device)
+	}
+	names := []string{name}
+	entries, err := ioutil.ReadDir(filepath.Join(sysBlockPrefix, name))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(sysBlockPrefix, name, entry.Name(), "partition")); err == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// checkDeviceUnused returns an error if the block device with the
+// given resolved path, or any partition on it, is mounted or held by
+// another device, such as a device mapper or RAID device. If
+// allowStorage is true, the device may be mounted under the helper's
+// mount points, so that the filesystems of the agent's storage can be
+// grown while they are in use.
+func checkDeviceUnused(device string, allowStorage bool) error {
+	names, err := blockDevices(device)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mounts, err := readMounts()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range names {
+		holders, err := ioutil.ReadDir(filepath.Join(sysBlockPrefix, name, "holders"))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		if len(holders) > 0 {
+			return errors.Errorf("device %q is held by %q", "/dev/"+name, holders[0].Name())
+		}
+		number, err := ioutil.ReadFile(filepath.Join(sysBlockPrefix, name, "dev"))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, mount := range mounts {
+			if mount.device != strings.TrimSpace(string(number)) {
+				continue
+			}
+			if allowStorage && underMountPrefix(mount.target) {
+				continue
+			}
+			return errors.Errorf("device %q is mounted on %q", "/dev/"+name, mount.target)
+		}
+	}
+	return nil
+}
+
+// underMountPrefix reports whether path is under one of the
+// directories in which the helper mounts filesystems.
+func underMountPrefix(path string) bool {
+	for _, prefix := range mountPrefixes {
+		if strings.HasPrefix(path+"/", prefix) {
+			return true
+		}
+	}
+	return false
+}
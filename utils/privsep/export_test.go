@@ -0,0 +1,16 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep
+
+var (
+	HelperSearchPath = &helperPath
+	Geteuid          = &geteuid
+	DevicePrefix     = &devicePrefix
+	StoragePrefix    = &storagePrefix
+	MountPrefixes    = &mountPrefixes
+	IsBlockDevice    = &isBlockDevice
+	MountInfoPath    = &mountInfoPath
+	SysBlockPrefix   = &sysBlockPrefix
+	NewSystemBridger = &newSystemBridger
+)
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep
+
+import (
+	"encoding/json"
+	"io"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/juju/errors"
+)
+
+// helperPath holds the directories searched for the helper's
+// commands; the caller's PATH is never used.
+var helperPath = []string{"/usr/sbin", "/usr/bin", "/sbin", "/bin"}
+
+// helperEnv is the environment of the commands run by the helper.
+var helperEnv = []string{
+	"PATH=/usr/sbin:/usr/bin:/sbin:/bin",
+	"LANG=C",
+	"DEBIAN_FRONTEND=noninteractive",
+}
+
+// Serve reads a Request from r, runs the command if it is allowed,
+// and writes the Response to w. It is run by the setuid helper. An
+// error is returned only if the request cannot be read or the
+// response written; other failures are reported in the response.
+func Serve(r io.Reader, w io.Writer) error {
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return errors.Annotate(err, "reading request")
+	}
+	var resp Response
+	output, err := serve(req)
+	resp.Output = string(output)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return errors.Annotate(json.NewEncoder(w).Encode(resp), "writing response")
+}
+
+// RunHelper runs the setuid helper, reading the request from r and
+// writing the response to w.
+func RunHelper(r io.Reader, w io.Writer) error {
+	reran, err := rerunAsRoot(r, w)
+	if reran || err != nil {
+		return errors.Trace(err)
+	}
+	return Serve(r, w)
+}
+
+func serve(req Request) ([]byte, error) {
+	req, err := CheckRequest(req)
+	if err != nil {
+		logger.Warningf("refusing privileged request: %v", err)
+		return nil, errors.Trace(err)
+	}
+	if req.Command == bridgeCommand {
+		return bridge(req.Args)
+	}
+	path, err := lookPath(req.Command)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cmd := execCommand(path, req.Args...)
+	cmd.Env = helperEnv
+	cmd.Dir = "/"
+	return cmd.CombinedOutput()
+}
+
+var execCommand = exec.Command
+
+// lookPath returns the path of the command in helperPath.
+func lookPath(command string) (string, error) {
+	for _, dir := range helperPath {
+		path := filepath.Join(dir, command)
+		if _, err := exec.LookPath(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.NotFoundf("command %q", command)
+}
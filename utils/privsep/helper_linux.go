@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// rerunAsRoot runs the helper again with root as its real user and
+// group, and reports whether it did so. A setuid helper keeps the
+// agent user as its real user, and commands such as shell scripts
+// drop their privileges when the real and effective users differ.
+func rerunAsRoot(r io.Reader, w io.Writer) (bool, error) {
+	if os.Getuid() == 0 {
+		return false, nil
+	}
+	if os.Geteuid() != 0 {
+		return false, errors.New("helper is not setuid root")
+	}
+	cmd := exec.Command("/proc/self/exe")
+	// Keep the helper's name, which selects the helper when it is
+	// a link to jujud.
+	cmd.Args = os.Args[:1]
+	cmd.Env = helperEnv
+	cmd.Dir = "/"
+	cmd.Stdin = r
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: 0, Gid: 0},
+	}
+	return true, errors.Trace(cmd.Run())
+}
+
+func ownedByRoot(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	return ok && stat.Uid == 0
+}
@@ -0,0 +1,21 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package privsep
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/errors"
+)
+
+func rerunAsRoot(r io.Reader, w io.Writer) (bool, error) {
+	return false, errors.NotSupportedf("privileged helper")
+}
+
+func ownedByRoot(os.FileInfo) bool {
+	return false
+}
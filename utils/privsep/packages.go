@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/utils/os"
+	"github.com/juju/utils/packaging/manager"
+	"github.com/juju/utils/series"
+)
+
+// NewPackageManager returns a manager.PackageManager that installs
+// packages on the series through the helper, for use by agents that
+// are not running as root. Other operations are done by the package
+// manager pm, and fail unless they need no privileges.
+func NewPackageManager(pm manager.PackageManager, hostSeries string) (manager.PackageManager, error) {
+	hostOS, err := series.GetOSFromSeries(hostSeries)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var command string
+	switch hostOS {
+	case jujuos.Ubuntu:
+		command = "apt-get"
+	case jujuos.CentOS:
+		command = "yum"
+	default:
+		return nil, errors.NotSupportedf("installing packages on %s without root privileges", hostOS)
+	}
+	return &helperPackageManager{
+		PackageManager: pm,
+		command:        command,
+		runner:         helperRunner{HelperPath},
+	}, nil
+}
+
+type helperPackageManager struct {
+	manager.PackageManager
+	command string
+	runner  Runner
+}
+
+// Install is part of the manager.PackageManager interface. Each of
+// packs may hold several arguments separated by spaces, such as
+// "--target-release trusty-backports lxd", as for the other package
+// managers.
+func (m *helperPackageManager) Install(packs ...string) error {
+	args := []string{"install"}
+	for _, pack := range packs {
+		args = append(args, strings.Fields(pack)...)
+	}
+	output, err := m.runner.Run(m.command, args...)
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			err = errors.Annotate(err, msg)
+		}
+		return errors.Annotatef(err, "installing %s", strings.Join(args[1:], " "))
+	}
+	return nil
+}
+
+// Update is part of the manager.PackageManager interface.
+func (m *helperPackageManager) Update() error {
+	arg := "update"
+	if m.command == "yum" {
+		arg = "makecache"
+	}
+	_, err := m.runner.Run(m.command, arg)
+	return errors.Annotate(err, "updating package lists")
+}
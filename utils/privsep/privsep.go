@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package privsep separates the privileged operations of a machine
+// agent from the agent itself, so that the agent can run as an
+// unprivileged user on hardened hosts.
+//
+// An agent running as root runs commands directly. An agent running
+// as any other user asks the setuid helper, jujud-privhelper, to run
+// them. The helper reads a single Request as JSON from its standard
+// input, checks it against an allow list of commands and arguments,
+// runs the command with a fixed environment, and writes a Response as
+// JSON to its standard output. The helper should be owned by root,
+// with mode 04750, and belong to the group of the agent user, so that
+// no other users can run it. Machines provisioned with the model's
+// agent-user setting get such a helper, a copy of jujud, and run their
+// machine agent as that user.
+//
+// The helper runs storage commands only on block devices, on files in
+// the agent's storage directory, and on mount points under that
+// directory or, if they already exist, under /srv, /mnt or /media,
+// as the agent cannot create them there; it installs packages with fixed
+// options; it bridges network devices itself, given only their names;
+// and it reboots and shuts down the machine. Everything else needs
+// the agent to run as root, including running unit agents as separate
+// services rather than in the machine agent.
+package privsep
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+var logger = loggo.GetLogger("juju.utils.privsep")
+
+// HelperPath is the path of the setuid helper.
+var HelperPath = "/usr/lib/juju/bin/jujud-privhelper"
+
+// Request asks the helper to run a command.
+type Request struct {
+	// Command is the name of the command, which must be allowed.
+	Command string `json:"command"`
+
+	// Args holds the command's arguments.
+	Args []string `json:"args,omitempty"`
+}
+
+// Response holds the outcome of a Request.
+type Response struct {
+	// Output holds the combined standard output and error of the
+	// command.
+	Output string `json:"output"`
+
+	// Error holds the error running the command, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Runner runs commands that need root privileges.
+type Runner interface {
+	// Run runs the command with the given arguments, and returns
+	// its combined standard output and error.
+	Run(command string, args ...string) ([]byte, error)
+}
+
+// Privileged reports whether the process is running as root.
+func Privileged() bool {
+	return geteuid() == 0
+}
+
+var geteuid = os.Geteuid
+
+// NewRunner returns a Runner that runs commands directly if the
+// process is running as root, and through the helper otherwise.
+func NewRunner() Runner {
+	if Privileged() {
+		return directRunner{}
+	}
+	return helperRunner{HelperPath}
+}
+
+// CheckHelper returns an error if the helper is not installed, or is
+// not setuid root.
+func CheckHelper() error {
+	info, err := os.Stat(HelperPath)
+	if err != nil {
+		return errors.Annotate(err, "privileged helper")
+	}
+	if info.Mode()&os.ModeSetuid == 0 || !ownedByRoot(info) {
+		return errors.Errorf("privileged helper %q is not setuid root", HelperPath)
+	}
+	return nil
+}
+
+// Run runs the command with a Runner returned by NewRunner.
+func Run(command string, args ...string) ([]byte, error) {
+	return NewRunner().Run(command, args...)
+}
+
+type directRunner struct{}
+
+// Run is part of the Runner interface.
+func (directRunner) Run(command string, args ...string) ([]byte, error) {
+	return exec.Command(command, args...).CombinedOutput()
+}
+
+type helperRunner struct {
+	path string
+}
+
+// Run is part of the Runner interface.
+func (r helperRunner) Run(command string, args ...string) ([]byte, error) {
+	req, err := json.Marshal(Request{Command: command, Args: args})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(r.path)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.Annotate(err, msg)
+		}
+		return nil, errors.Annotate(err, "running privileged helper")
+	}
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, errors.Annotate(err, "reading privileged helper response")
+	}
+	if resp.Error != "" {
+		return []byte(resp.Output), errors.New(resp.Error)
+	}
+	return []byte(resp.Output), nil
+}
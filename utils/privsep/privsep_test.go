@@ -0,0 +1,528 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package privsep_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/utils/privsep"
+)
+
+type privsepSuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&privsepSuite{})
+
+func (s *privsepSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("privilege separation only supported on linux")
+	}
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.PatchValue(privsep.HelperSearchPath, []string{s.dir})
+}
+
+func (s *privsepSuite) writeScript(c *gc.C, name, script string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *privsepSuite) TestCheckRequest(c *gc.C) {
+	for i, t := range []struct {
+		req      privsep.Request
+		expected []string
+	}{{
+		req:      privsep.Request{Command: "apt-get", Args: []string{"update"}},
+		expected: append(aptGetOptions, "update"),
+	}, {
+		req:      privsep.Request{Command: "apt-get", Args: []string{"install", "--target-release", "trusty-backports", "lxd"}},
+		expected: append(aptGetOptions, "install", "--target-release", "trusty-backports", "lxd"),
+	}, {
+		req:      privsep.Request{Command: "yum", Args: []string{"install", "qemu-kvm", "libvirt"}},
+		expected: []string{"--assumeyes", "install", "qemu-kvm", "libvirt"},
+	}, {
+		req:      privsep.Request{Command: "shutdown", Args: []string{"-r", "+1"}},
+		expected: []string{"-r", "+1"},
+	}, {
+		req:      privsep.Request{Command: "losetup", Args: []string{"-d", "/dev/loop3"}},
+		expected: []string{"-d", "/dev/loop3"},
+	}, {
+		req:      privsep.Request{Command: "bridge-interfaces", Args: []string{"0", "eth0=br-eth0", "ens3=br-ens3=52:54:00:12:34:56"}},
+		expected: []string{"0", "eth0=br-eth0", "ens3=br-ens3=52:54:00:12:34:56"},
+	}} {
+		c.Logf("test %d: %+v", i, t.req)
+		req, err := privsep.CheckRequest(t.req)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(req, jc.DeepEquals, privsep.Request{Command: t.req.Command, Args: t.expected})
+	}
+}
+
+var aptGetOptions = []string{
+	"--option=Dpkg::Options::=--force-confold",
+	"--option=Dpkg::Options::=--force-unsafe-io",
+	"--assume-yes",
+	"--quiet",
+}
+
+func (s *privsepSuite) TestCheckRequestRejected(c *gc.C) {
+	for i, t := range []struct {
+		req privsep.Request
+		err string
+	}{{
+		req: privsep.Request{Command: "rm", Args: []string{"-rf", "/"}},
+		err: `command "rm" not allowed`,
+	}, {
+		req: privsep.Request{Command: "/bin/mount"},
+		err: `command "/bin/mount" with path not valid`,
+	}, {
+		req: privsep.Request{Command: "ip", Args: []string{"link", "set", "eth0", "up"}},
+		err: `command "ip" not allowed`,
+	}, {
+		req: privsep.Request{Command: "mkfs", Args: []string{"-t", "ext4", "/dev/sdb"}},
+		err: `command "mkfs" not allowed`,
+	}, {
+		req: privsep.Request{Command: "apt-get", Args: []string{"-o", "DPkg::Pre-Invoke::=touch /tmp/pwned", "install", "lxd"}},
+		err: `command "apt-get": arguments .* not valid`,
+	}, {
+		req: privsep.Request{Command: "apt-get", Args: []string{"install", "-o", "APT::Update::Pre-Invoke::=sh", "lxd"}},
+		err: `command "apt-get": package "-o" not valid`,
+	}, {
+		req: privsep.Request{Command: "apt-get", Args: []string{"install", "-c", "/tmp/apt.conf", "lxd"}},
+		err: `command "apt-get": package "-c" not valid`,
+	}, {
+		req: privsep.Request{Command: "apt-get", Args: []string{"install", "--target-release", "-oFoo=bar", "lxd"}},
+		err: `command "apt-get": target release "-oFoo=bar" not valid`,
+	}, {
+		req: privsep.Request{Command: "apt-get", Args: []string{"remove", "openssh-server"}},
+		err: `command "apt-get": arguments .* not valid`,
+	}, {
+		req: privsep.Request{Command: "yum", Args: []string{"--setopt=tsflags=noscripts", "install", "lxd"}},
+		err: `command "yum": arguments .* not valid`,
+	}, {
+		req: privsep.Request{Command: "yum", Args: []string{"install", "--setopt=tsflags=noscripts", "lxd"}},
+		err: `command "yum": package "--setopt=tsflags=noscripts" not valid`,
+	}, {
+		req: privsep.Request{Command: "shutdown", Args: []string{"-r", "+1", "message"}},
+		err: `command "shutdown": arguments .* not valid`,
+	}, {
+		req: privsep.Request{Command: "losetup", Args: []string{"-d", "/dev/sda"}},
+		err: `command "losetup": loop device "/dev/sda" not valid`,
+	}, {
+		req: privsep.Request{Command: "bridge-interfaces", Args: []string{"0", "eth0=br-eth0\nauto evil"}},
+		err: `command "bridge-interfaces": interface name .* not valid`,
+	}, {
+		req: privsep.Request{Command: "bridge-interfaces", Args: []string{"3600", "eth0=br-eth0"}},
+		err: `command "bridge-interfaces": reconfigure delay "3600" not valid`,
+	}} {
+		c.Logf("test %d: %+v", i, t.req)
+		_, err := privsep.CheckRequest(t.req)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+// makeTree creates devices, storage and mount points under a new
+// directory, and patches the helper to use them.
+func (s *privsepSuite) makeTree(c *gc.C) string {
+	root, err := filepath.EvalSymlinks(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	for _, dir := range []string{"dev/disk/by-id", "storage/fs", "mnt/data", "etc"} {
+		err := os.MkdirAll(filepath.Join(root, dir), 0755)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, file := range []string{"dev/sda", "dev/sda1", "dev/sdb", "dev/sdc", "dev/sdd", "dev/sdd1", "dev/tty0", "storage/volume-0", "etc/shadow"} {
+		err := ioutil.WriteFile(filepath.Join(root, file), nil, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	// The root disk sda has its partition mounted on /, sdc is held
+	// by a device mapper device, and sdd has its partition mounted
+	// on the agent's storage.
+	for file, content := range map[string]string{
+		"sys/block/sda/dev":            "8:0\n",
+		"sys/block/sda/sda1/partition": "1\n",
+		"sys/block/sda1/dev":           "8:1\n",
+		"sys/block/sdb/dev":            "8:16\n",
+		"sys/block/sdc/dev":            "8:32\n",
+		"sys/block/sdc/holders/dm-0":   "",
+		"sys/block/sdd/dev":            "8:48\n",
+		"sys/block/sdd/sdd1/partition": "1\n",
+		"sys/block/sdd1/dev":           "8:49\n",
+		"mountinfo": "25 1 8:1 / / rw,relatime shared:1 - ext4 /dev/root rw\n" +
+			"30 25 8:49 / " + root + "/storage/fs rw,relatime - ext4 /dev/sdd1 rw\n",
+	} {
+		path := filepath.Join(root, file)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for link, target := range map[string]string{
+		"dev/disk/by-id/scsi-0": "../../sdb",
+		"dev/evil":              "../etc/shadow",
+		"storage/evil":          "../etc/shadow",
+		"mnt/evil":              "../etc",
+	} {
+		err := os.Symlink(target, filepath.Join(root, link))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.PatchValue(privsep.DevicePrefix, root+"/dev/")
+	s.PatchValue(privsep.StoragePrefix, root+"/storage/")
+	s.PatchValue(privsep.MountPrefixes, []string{root + "/storage/", root + "/mnt/"})
+	s.PatchValue(privsep.MountInfoPath, root+"/mountinfo")
+	s.PatchValue(privsep.SysBlockPrefix, root+"/sys/block/")
+	s.PatchValue(privsep.IsBlockDevice, func(info os.FileInfo) bool {
+		return info.Mode().IsRegular() && info.Name() != "tty0"
+	})
+	return root
+}
+
+func (s *privsepSuite) TestCheckRequestPaths(c *gc.C) {
+	root := s.makeTree(c)
+	for i, t := range []struct {
+		command  string
+		args     []string
+		expected []string
+	}{{
+		command:  "mount",
+		args:     []string{"ROOT/dev/disk/by-id/scsi-0", "ROOT/mnt/data"},
+		expected: []string{"ROOT/dev/sdb", "ROOT/mnt/data"},
+	}, {
+		command:  "mount",
+		args:     []string{"-o", "ro", "ROOT/dev/sdb", "ROOT/storage/fs"},
+		expected: []string{"-o", "ro", "ROOT/dev/sdb", "ROOT/storage/fs"},
+	}, {
+		command:  "mount",
+		args:     []string{"--bind", "ROOT/storage/fs", "ROOT/mnt/data"},
+		expected: []string{"--bind", "ROOT/storage/fs", "ROOT/mnt/data"},
+	}, {
+		command:  "mount",
+		args:     []string{"-t", "tmpfs", "filesystem-0", "ROOT/mnt/data", "-o", "size=1024m,ro"},
+		expected: []string{"-t", "tmpfs", "filesystem-0", "ROOT/mnt/data", "-o", "size=1024m,ro"},
+	}, {
+		command:  "umount",
+		args:     []string{"ROOT/mnt/data"},
+		expected: []string{"ROOT/mnt/data"},
+	}, {
+		command:  "losetup",
+		args:     []string{"-f", "--show", "-r", "ROOT/storage/volume-0"},
+		expected: []string{"-f", "--show", "-r", "ROOT/storage/volume-0"},
+	}, {
+		command:  "losetup",
+		args:     []string{"-j", "ROOT/storage/volume-0"},
+		expected: []string{"-j", "ROOT/storage/volume-0"},
+	}, {
+		command:  "fallocate",
+		args:     []string{"-l", "1024MiB", "ROOT/storage/volume-1"},
+		expected: []string{"-l", "1024MiB", "ROOT/storage/volume-1"},
+	}, {
+		command:  "sgdisk",
+		args:     []string{"-n", "1:0:-1", "ROOT/dev/sdb"},
+		expected: []string{"-n", "1:0:-1", "ROOT/dev/sdb"},
+	}, {
+		command:  "growpart",
+		args:     []string{"ROOT/dev/sdb", "1"},
+		expected: []string{"ROOT/dev/sdb", "1"},
+	}, {
+		command:  "mkfs.ext4",
+		args:     []string{"ROOT/dev/sdb"},
+		expected: []string{"ROOT/dev/sdb"},
+	}, {
+		command:  "growpart",
+		args:     []string{"ROOT/dev/sdd", "1"},
+		expected: []string{"ROOT/dev/sdd", "1"},
+	}, {
+		command:  "resize2fs",
+		args:     []string{"ROOT/dev/sdd1"},
+		expected: []string{"ROOT/dev/sdd1"},
+	}} {
+		expand := func(args []string) []string {
+			result := make([]string, len(args))
+			for i, arg := range args {
+				result[i] = strings.Replace(arg, "ROOT", root, 1)
+			}
+			return result
+		}
+		req := privsep.Request{Command: t.command, Args: expand(t.args)}
+		c.Logf("test %d: %+v", i, req)
+		checked, err := privsep.CheckRequest(req)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(checked, jc.DeepEquals, privsep.Request{Command: t.command, Args: expand(t.expected)})
+	}
+}
+
+func (s *privsepSuite) TestCheckRequestPathsRejected(c *gc.C) {
+	root := s.makeTree(c)
+	for i, t := range []struct {
+		command string
+		args    []string
+		err     string
+	}{{
+		command: "mount",
+		args:    []string{"ROOT/dev/sdb", "/etc"},
+		err:     `path "/etc" outside .* not valid`,
+	}, {
+		command: "mount",
+		args:    []string{"ROOT/dev/sdb", "ROOT/mnt/evil"},
+		err:     `path ".*/mnt/evil" outside .* not valid`,
+	}, {
+		command: "mount",
+		args:    []string{"ROOT/dev/evil", "ROOT/mnt/data"},
+		err:     `path ".*/dev/evil" outside .* not valid`,
+	}, {
+		command: "mount",
+		args:    []string{"ROOT/dev/tty0", "ROOT/mnt/data"},
+		err:     `".*/dev/tty0": not a block device not valid`,
+	}, {
+		command: "mount",
+		args:    []string{"-o", "remount,suid", "ROOT/dev/sdb", "ROOT/mnt/data"},
+		err:     `arguments .* not valid`,
+	}, {
+		command: "mount",
+		args:    []string{"ROOT/dev/../etc/shadow", "ROOT/mnt/data"},
+		err:     `path ".*/dev/../etc/shadow" not valid`,
+	}, {
+		command: "mount",
+		args:    []string{"--bind", "/", "ROOT/mnt/data"},
+		err:     `path "/" outside .* not valid`,
+	}, {
+		command: "mount",
+		args:    []string{"-t", "tmpfs", "filesystem-0", "ROOT/mnt/data", "-o", "size=1m,exec,suid"},
+		err:     `tmpfs options "size=1m,exec,suid" not valid`,
+	}, {
+		command: "umount",
+		args:    []string{"/"},
+		err:     `path "/" outside .* not valid`,
+	}, {
+		command: "losetup",
+		args:    []string{"-f", "--show", "ROOT/storage/evil"},
+		err:     `path ".*/storage/evil" outside .* not valid`,
+	}, {
+		command: "fallocate",
+		args:    []string{"-l", "1MiB", "ROOT/storage/evil"},
+		err:     `symlink ".*/storage/evil" not valid`,
+	}, {
+		command: "fallocate",
+		args:    []string{"-l", "1MiB", "relative/path"},
+		err:     `path "relative/path" not valid`,
+	}, {
+		command: "sgdisk",
+		args:    []string{"--delete=1", "ROOT/dev/sdb"},
+		err:     `arguments .* not valid`,
+	}, {
+		command: "mkfs.ext4",
+		args:    []string{"ROOT/dev/sda"},
+		err:     `device "/dev/sda1" is mounted on "/"`,
+	}, {
+		command: "sgdisk",
+		args:    []string{"--zap-all", "ROOT/dev/sda"},
+		err:     `device "/dev/sda1" is mounted on "/"`,
+	}, {
+		command: "mount",
+		args:    []string{"ROOT/dev/sda1", "ROOT/mnt/data"},
+		err:     `device "/dev/sda1" is mounted on "/"`,
+	}, {
+		command: "growpart",
+		args:    []string{"ROOT/dev/sda", "1"},
+		err:     `device "/dev/sda1" is mounted on "/"`,
+	}, {
+		command: "resize2fs",
+		args:    []string{"ROOT/dev/sda1"},
+		err:     `device "/dev/sda1" is mounted on "/"`,
+	}, {
+		command: "mkfs.ext4",
+		args:    []string{"ROOT/dev/sdc"},
+		err:     `device "/dev/sdc" is held by "dm-0"`,
+	}, {
+		command: "mkfs.ext4",
+		args:    []string{"ROOT/dev/sdd"},
+		err:     `device "/dev/sdd1" is mounted on ".*/storage/fs"`,
+	}, {
+		command: "mount",
+		args:    []string{"ROOT/dev/sdd1", "ROOT/mnt/data"},
+		err:     `device "/dev/sdd1" is mounted on ".*/storage/fs"`,
+	}} {
+		args := make([]string, len(t.args))
+		for i, arg := range t.args {
+			args[i] = strings.Replace(arg, "ROOT", root, 1)
+		}
+		req := privsep.Request{Command: t.command, Args: args}
+		c.Logf("test %d: %+v", i, req)
+		_, err := privsep.CheckRequest(req)
+		c.Check(err, gc.ErrorMatches, fmt.Sprintf(`command %q: %s`, t.command, t.err))
+	}
+}
+
+func (s *privsepSuite) serve(c *gc.C, req privsep.Request) privsep.Response {
+	in, err := json.Marshal(req)
+	c.Assert(err, jc.ErrorIsNil)
+	var out bytes.Buffer
+	err = privsep.Serve(bytes.NewReader(in), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	var resp privsep.Response
+	err = json.Unmarshal(out.Bytes(), &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	return resp
+}
+
+func (s *privsepSuite) TestServe(c *gc.C) {
+	s.writeScript(c, "yum", `echo "$PATH" "$@"`)
+	resp := s.serve(c, privsep.Request{Command: "yum", Args: []string{"install", "lxd"}})
+	c.Assert(resp, jc.DeepEquals, privsep.Response{
+		Output: "/usr/sbin:/usr/bin:/sbin:/bin --assumeyes install lxd\n",
+	})
+}
+
+func (s *privsepSuite) TestServeCommandFails(c *gc.C) {
+	s.writeScript(c, "yum", "echo oops\nexit 1")
+	resp := s.serve(c, privsep.Request{Command: "yum", Args: []string{"makecache"}})
+	c.Assert(resp, jc.DeepEquals, privsep.Response{
+		Output: "oops\n",
+		Error:  "exit status 1",
+	})
+}
+
+func (s *privsepSuite) TestServeNotAllowed(c *gc.C) {
+	s.writeScript(c, "rm", "echo ran")
+	resp := s.serve(c, privsep.Request{Command: "rm", Args: []string{"-rf", "/"}})
+	c.Assert(resp, jc.DeepEquals, privsep.Response{
+		Error: `command "rm" not allowed`,
+	})
+}
+
+func (s *privsepSuite) TestServeCommandNotFound(c *gc.C) {
+	resp := s.serve(c, privsep.Request{Command: "losetup", Args: []string{"-d", "/dev/loop0"}})
+	c.Assert(resp, jc.DeepEquals, privsep.Response{
+		Error: `command "losetup" not found`,
+	})
+}
+
+func (s *privsepSuite) TestServeBadRequest(c *gc.C) {
+	err := privsep.Serve(strings.NewReader("{"), ioutil.Discard)
+	c.Assert(err, gc.ErrorMatches, "reading request: .*")
+}
+
+func (s *privsepSuite) TestRunAsRoot(c *gc.C) {
+	s.PatchValue(privsep.Geteuid, func() int { return 0 })
+	output, err := privsep.Run("echo", "hello")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "hello\n")
+}
+
+func (s *privsepSuite) TestRunThroughHelper(c *gc.C) {
+	s.PatchValue(privsep.Geteuid, func() int { return 1000 })
+	helper := s.writeScript(c, "helper", `
+req=$(cat)
+test "$req" = '{"command":"mount","args":["/dev/sdb","/mnt"]}' || exit 1
+echo '{"output":"mounted"}'
+`)
+	s.PatchValue(&privsep.HelperPath, helper)
+	output, err := privsep.Run("mount", "/dev/sdb", "/mnt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "mounted")
+}
+
+func (s *privsepSuite) TestRunThroughHelperError(c *gc.C) {
+	s.PatchValue(privsep.Geteuid, func() int { return 1000 })
+	helper := s.writeScript(c, "helper", `cat > /dev/null
+echo '{"output":"no such device","error":"exit status 32"}'
+`)
+	s.PatchValue(&privsep.HelperPath, helper)
+	output, err := privsep.Run("mount", "/dev/sdb", "/mnt")
+	c.Assert(err, gc.ErrorMatches, "exit status 32")
+	c.Assert(string(output), gc.Equals, "no such device")
+}
+
+func (s *privsepSuite) TestRunHelperFails(c *gc.C) {
+	s.PatchValue(privsep.Geteuid, func() int { return 1000 })
+	helper := s.writeScript(c, "helper", "echo 'permission denied' >&2\nexit 1\n")
+	s.PatchValue(&privsep.HelperPath, helper)
+	_, err := privsep.Run("mount", "/dev/sdb", "/mnt")
+	c.Assert(err, gc.ErrorMatches, "running privileged helper: permission denied: exit status 1")
+}
+
+type fakeBridger struct {
+	devices []network.DeviceToBridge
+	delay   int
+}
+
+func (b *fakeBridger) Bridge(devices []network.DeviceToBridge, reconfigureDelay int) error {
+	b.devices = devices
+	b.delay = reconfigureDelay
+	return nil
+}
+
+func (s *privsepSuite) TestServeBridge(c *gc.C) {
+	var bridger fakeBridger
+	s.PatchValue(privsep.NewSystemBridger, func() (network.Bridger, error) {
+		return &bridger, nil
+	})
+	resp := s.serve(c, privsep.Request{
+		Command: "bridge-interfaces",
+		Args:    []string{"10", "eth0=br-eth0", "ens3=br-ens3=52:54:00:12:34:56"},
+	})
+	c.Assert(resp, jc.DeepEquals, privsep.Response{})
+	c.Assert(bridger.delay, gc.Equals, 10)
+	c.Assert(bridger.devices, jc.DeepEquals, []network.DeviceToBridge{{
+		DeviceName: "eth0",
+		BridgeName: "br-eth0",
+	}, {
+		DeviceName: "ens3",
+		BridgeName: "br-ens3",
+		MACAddress: "52:54:00:12:34:56",
+	}})
+}
+
+func (s *privsepSuite) TestBridgerThroughHelper(c *gc.C) {
+	helper := s.writeScript(c, "helper", `
+req=$(cat)
+test "$req" = '{"command":"bridge-interfaces","args":["5","eth0=br-eth0=52:54:00:12:34:56"]}' || exit 1
+echo '{"output":""}'
+`)
+	s.PatchValue(&privsep.HelperPath, helper)
+	err := privsep.NewBridger().Bridge([]network.DeviceToBridge{{
+		DeviceName: "eth0",
+		BridgeName: "br-eth0",
+		MACAddress: "52:54:00:12:34:56",
+	}}, 5)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *privsepSuite) TestPackageManagerInstall(c *gc.C) {
+	helper := s.writeScript(c, "helper", `
+req=$(cat)
+test "$req" = '{"command":"apt-get","args":["install","--target-release","trusty-backports","lxd"]}' || exit 1
+echo '{"output":""}'
+`)
+	s.PatchValue(&privsep.HelperPath, helper)
+	pacman, err := privsep.NewPackageManager(nil, "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	err = pacman.Install("--target-release trusty-backports lxd")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *privsepSuite) TestPackageManagerInstallFails(c *gc.C) {
+	helper := s.writeScript(c, "helper", `cat > /dev/null
+echo '{"output":"E: Unable to locate package lxd","error":"exit status 100"}'
+`)
+	s.PatchValue(&privsep.HelperPath, helper)
+	pacman, err := privsep.NewPackageManager(nil, "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = pacman.Install("lxd")
+	c.Assert(err, gc.ErrorMatches, "installing lxd: E: Unable to locate package lxd: exit status 100")
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/juju/state"
+)
+
+// StatePool provides access to the controller's models.
+type StatePool interface {
+	// ModelUUIDs returns the UUIDs of all the controller's models.
+	ModelUUIDs() ([]string, error)
+
+	// Get returns the action schedules of the model with the given
+	// UUID, and a function to release them once they are no longer
+	// needed.
+	Get(modelUUID string) (Schedules, state.StatePoolReleaser, error)
+}
+
+// Schedules provides access to the action schedules of a model.
+type Schedules interface {
+	// DueActionSchedules returns the schedules due to run at the
+	// given time.
+	DueActionSchedules(now time.Time) ([]state.ActionSchedule, error)
+
+	// RunActionSchedule runs the schedule with the given id.
+	RunActionSchedule(id string, now time.Time) (state.ActionScheduleRun, error)
+}
+
+// NewStatePool takes a *state.StatePool, and returns a StatePool
+// value backed by it.
+func NewStatePool(pool *state.StatePool) StatePool {
+	return statePoolShim{pool}
+}
+
+type statePoolShim struct {
+	pool *state.StatePool
+}
+
+func (p statePoolShim) ModelUUIDs() ([]string, error) {
+	return p.pool.SystemState().AllModelUUIDs()
+}
+
+func (p statePoolShim) Get(modelUUID string) (Schedules, state.StatePoolReleaser, error) {
+	st, releaser, err := p.pool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return st, releaser, nil
+}
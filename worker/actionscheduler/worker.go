@@ -0,0 +1,152 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker that enqueues the actions
+// of the controller's action schedules when they are due.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// DefaultCheckInterval is how often the action schedules are checked
+// by default. Schedules have a resolution of a minute.
+const DefaultCheckInterval = 20 * time.Second
+
+// Config holds the configuration and dependencies for a Worker.
+type Config struct {
+	// StatePool provides access to the controller's models.
+	StatePool StatePool
+
+	// Clock is used to time checks, and to decide which schedules
+	// are due.
+	Clock clock.Clock
+
+	// CheckInterval is how often the schedules are checked.
+	CheckInterval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.StatePool == nil {
+		return errors.NotValidf("nil StatePool")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	return nil
+}
+
+// Worker periodically runs the action schedules that are due in each
+// of the controller's models.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		if err := w.check(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.CheckInterval):
+		}
+	}
+}
+
+// check runs the schedules that are due in each model.
+func (w *Worker) check() error {
+	uuids, err := w.config.StatePool.ModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	for _, uuid := range uuids {
+		err := w.checkModel(uuid, now)
+		if errors.IsNotFound(err) {
+			// The model has been removed since it was listed.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "running action schedules of model %s", uuid)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) checkModel(uuid string, now time.Time) error {
+	schedules, release, err := w.config.StatePool.Get(uuid)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+
+	due, err := schedules.DueActionSchedules(now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, schedule := range due {
+		// A schedule that cannot be run must not stop the others
+		// from running; it is retried at the next check.
+		run, err := schedules.RunActionSchedule(schedule.Id, now)
+		if err != nil {
+			logger.Errorf(
+				"cannot run action schedule %s (%s on %s) in model %s: %v",
+				schedule.Id, schedule.Name, schedule.Receiver, uuid, err,
+			)
+			continue
+		}
+		if run.ActionId != "" {
+			logger.Debugf(
+				"action schedule %s enqueued action %s (%s on %s) in model %s",
+				schedule.Id, run.ActionId, schedule.Name, schedule.Receiver, uuid,
+			)
+		} else {
+			logger.Infof(
+				"action schedule %s (%s on %s) in model %s enqueued no action: %s",
+				schedule.Id, schedule.Name, schedule.Receiver, uuid, run.Error,
+			)
+		}
+	}
+	return nil
+}
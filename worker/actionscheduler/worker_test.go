@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	clock     *jujutesting.Clock
+	pool      *fakeStatePool
+	schedules *fakeSchedules
+	config    actionscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var start = time.Date(2017, 9, 4, 1, 59, 30, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(start)
+	s.schedules = &fakeSchedules{
+		due: []state.ActionSchedule{
+			{Id: "1", Receiver: "mysql/0", Name: "backup"},
+			{Id: "2", Receiver: "mysql/1", Name: "backup"},
+		},
+	}
+	s.pool = &fakeStatePool{
+		schedules: map[string]*fakeSchedules{"deadbeef": s.schedules},
+	}
+	s.config = actionscheduler.Config{
+		StatePool:     s.pool,
+		Clock:         s.clock,
+		CheckInterval: 20 * time.Second,
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	config := s.config
+	config.StatePool = nil
+	_, err := actionscheduler.New(config)
+	c.Check(err, gc.ErrorMatches, "nil StatePool not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	config = s.config
+	config.Clock = nil
+	_, err = actionscheduler.New(config)
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config
+	config.CheckInterval = 0
+	_, err = actionscheduler.New(config)
+	c.Check(err, gc.ErrorMatches, "non-positive CheckInterval not valid")
+}
+
+// advance waits for the worker to finish a check, and then advances
+// the clock by the given duration, which triggers another check if it
+// is at least the check interval.
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *actionscheduler.Worker {
+	w, err := actionscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestRunsDueSchedules(c *gc.C) {
+	w := s.startWorker(c)
+	s.advance(c, 20*time.Second)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	later := start.Add(20 * time.Second)
+	s.schedules.CheckCalls(c, []jujutesting.StubCall{
+		{"DueActionSchedules", []interface{}{start}},
+		{"RunActionSchedule", []interface{}{"1", start}},
+		{"RunActionSchedule", []interface{}{"2", start}},
+		{"DueActionSchedules", []interface{}{later}},
+		{"RunActionSchedule", []interface{}{"1", later}},
+		{"RunActionSchedule", []interface{}{"2", later}},
+	})
+}
+
+func (s *WorkerSuite) TestRunErrorDoesNotStopOthers(c *gc.C) {
+	s.schedules.SetErrors(nil, errors.New("boom"))
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.schedules.CheckCallNames(c, "DueActionSchedules", "RunActionSchedule", "RunActionSchedule")
+}
+
+func (s *WorkerSuite) TestDueSchedulesError(c *gc.C) {
+	s.schedules.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "running action schedules of model deadbeef: boom")
+}
+
+func (s *WorkerSuite) TestModelRemoved(c *gc.C) {
+	s.pool.uuids = []string{"cafebabe", "deadbeef"}
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+	s.schedules.CheckCallNames(c, "DueActionSchedules", "RunActionSchedule", "RunActionSchedule")
+}
+
+type fakeStatePool struct {
+	uuids     []string
+	schedules map[string]*fakeSchedules
+}
+
+func (p *fakeStatePool) ModelUUIDs() ([]string, error) {
+	if p.uuids != nil {
+		return p.uuids, nil
+	}
+	return []string{"deadbeef"}, nil
+}
+
+func (p *fakeStatePool) Get(uuid string) (actionscheduler.Schedules, state.StatePoolReleaser, error) {
+	schedules, ok := p.schedules[uuid]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", uuid)
+	}
+	return schedules, func() bool { return false }, nil
+}
+
+type fakeSchedules struct {
+	jujutesting.Stub
+	due []state.ActionSchedule
+}
+
+func (f *fakeSchedules) DueActionSchedules(now time.Time) ([]state.ActionSchedule, error) {
+	f.MethodCall(f, "DueActionSchedules", now)
+	return f.due, f.NextErr()
+}
+
+func (f *fakeSchedules) RunActionSchedule(id string, now time.Time) (state.ActionScheduleRun, error) {
+	f.MethodCall(f, "RunActionSchedule", id, now)
+	if err := f.NextErr(); err != nil {
+		return state.ActionScheduleRun{}, err
+	}
+	return state.ActionScheduleRun{Time: now, ActionId: "action-" + id}, nil
+}
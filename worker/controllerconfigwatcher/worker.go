@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerconfigwatcher provides a worker that applies
+// changes to the controller config in the running controller agent,
+// and reports the changes that only take effect once it is restarted.
+package controllerconfigwatcher
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.controllerconfigwatcher")
+
+// Backend defines the state methods required by the worker.
+type Backend interface {
+	// ControllerConfig returns the current controller config.
+	ControllerConfig() (controller.Config, error)
+
+	// WatchControllerConfig returns a watcher that notifies when
+	// the controller config changes.
+	WatchControllerConfig() state.NotifyWatcher
+}
+
+// ReloadFunc applies the given controller config to a part of the
+// running agent.
+type ReloadFunc func(controller.Config) error
+
+// Config defines a worker's dependencies.
+type Config struct {
+	// Backend is used to watch and read the controller config.
+	Backend Backend
+
+	// Reloaders are called with the controller config when the
+	// worker starts, and whenever the config changes.
+	Reloaders []ReloadFunc
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	for _, reload := range config.Reloaders {
+		if reload == nil {
+			return errors.NotValidf("nil reloader")
+		}
+	}
+	return nil
+}
+
+// New returns a worker that calls the configured reloaders whenever
+// the controller config changes. Changes to attributes that are not
+// reloaded live are logged, until the agent is restarted.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &configWatcher{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type configWatcher struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// started holds the controller config the agent started with.
+	started controller.Config
+
+	// restartRequired holds the attributes last reported as
+	// requiring a restart.
+	restartRequired []string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *configWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *configWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *configWatcher) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			if err := w.reload(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *configWatcher) reload() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller config")
+	}
+	if w.started == nil {
+		w.started = cfg
+	}
+	for _, reload := range w.config.Reloaders {
+		if err := reload(cfg); err != nil {
+			return errors.Annotate(err, "cannot reload controller config")
+		}
+	}
+	restartRequired := controller.RestartRequired(w.started, cfg)
+	if !reflect.DeepEqual(restartRequired, w.restartRequired) {
+		if len(restartRequired) > 0 {
+			logger.Warningf(
+				"controller config changes to %s take effect once the agent is restarted",
+				strings.Join(restartRequired, ", "),
+			)
+		}
+		w.restartRequired = restartRequired
+	}
+	return nil
+}
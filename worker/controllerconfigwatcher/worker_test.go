@@ -0,0 +1,148 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerconfigwatcher_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/controllerconfigwatcher"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	backend *fakeBackend
+	reloads chan controller.Config
+	config  controllerconfigwatcher.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		watcher: workertest.NewFakeWatcher(2, 1),
+		config: controller.Config{
+			"api-port":         17070,
+			"auditing-enabled": false,
+		},
+	}
+	s.reloads = make(chan controller.Config, 2)
+	s.config = controllerconfigwatcher.Config{
+		Backend: s.backend,
+		Reloaders: []controllerconfigwatcher.ReloadFunc{
+			func(cfg controller.Config) error {
+				s.reloads <- cfg
+				return nil
+			},
+		},
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	config := s.config
+	config.Backend = nil
+	_, err := controllerconfigwatcher.New(config)
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	config = s.config
+	config.Reloaders = []controllerconfigwatcher.ReloadFunc{nil}
+	_, err = controllerconfigwatcher.New(config)
+	c.Check(err, gc.ErrorMatches, "nil reloader not valid")
+}
+
+func (s *WorkerSuite) TestReloadsInitialConfig(c *gc.C) {
+	w, err := controllerconfigwatcher.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := s.waitReload(c)
+	c.Assert(cfg.AuditingEnabled(), jc.IsFalse)
+}
+
+func (s *WorkerSuite) TestReloadsChangedConfig(c *gc.C) {
+	w, err := controllerconfigwatcher.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.waitReload(c)
+
+	s.backend.setConfig(controller.Config{
+		"api-port":         17070,
+		"state-port":       37018,
+		"auditing-enabled": true,
+	})
+	s.backend.watcher.Ping()
+	cfg := s.waitReload(c)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+
+	workertest.CleanKill(c, w)
+	c.Assert(c.GetTestLog(), jc.Contains,
+		"controller config changes to state-port take effect once the agent is restarted")
+}
+
+func (s *WorkerSuite) TestReloadError(c *gc.C) {
+	s.config.Reloaders = append(s.config.Reloaders, func(controller.Config) error {
+		return errors.New("boom")
+	})
+	w, err := controllerconfigwatcher.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitReload(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot reload controller config: boom")
+}
+
+func (s *WorkerSuite) TestConfigError(c *gc.C) {
+	s.backend.err = errors.New("no config")
+	w, err := controllerconfigwatcher.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot read controller config: no config")
+}
+
+func (s *WorkerSuite) waitReload(c *gc.C) controller.Config {
+	select {
+	case cfg := <-s.reloads:
+		return cfg
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for reload")
+	}
+	panic("unreachable")
+}
+
+type fakeBackend struct {
+	watcher workertest.NotAWatcher
+	mu      sync.Mutex
+	config  controller.Config
+	err     error
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.config, nil
+}
+
+func (b *fakeBackend) setConfig(cfg controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config = cfg
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
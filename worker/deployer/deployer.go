@@ -198,6 +198,9 @@ func (d *Deployer) remove(unit *apideployer.Unit) error {
 }
 
 func (d *Deployer) TearDown() error {
-	// Nothing to do here.
+	// Stop any unit agents run by the context itself.
+	if w, ok := d.ctx.(worker.Worker); ok {
+		return worker.Stop(w)
+	}
 	return nil
 }
@@ -4,6 +4,8 @@
 package deployer
 
 import (
+	worker "gopkg.in/juju/worker.v1"
+
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/service/common"
@@ -33,3 +35,16 @@ func NewTestSimpleContext(agentConfig agent.Config, logDir string, data *svctest
 		},
 	}
 }
+
+func NewTestInProcessContext(
+	agentConfig agent.Config,
+	legacy Context,
+	newUnitWorker func(string) (worker.Worker, error),
+) (*InProcessContext, error) {
+	return NewInProcessContext(InProcessConfig{
+		AgentConfig:   agentConfig,
+		API:           &fakeAPI{},
+		Legacy:        legacy,
+		NewUnitWorker: newUnitWorker,
+	})
+}
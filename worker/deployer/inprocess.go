@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/tools"
+	jworker "github.com/juju/juju/worker"
+)
+
+// inProcessMarker is the name of the file, in a unit agent's directory,
+// that records that the unit agent runs inside the machine agent.
+const inProcessMarker = "in-process"
+
+// InProcessConfig holds the configuration of an InProcessContext.
+type InProcessConfig struct {
+	// AgentConfig is the config of the machine agent running the
+	// deployer.
+	AgentConfig agent.Config
+
+	// API is used to get the current controller addresses at the
+	// time a unit is deployed.
+	API APICalls
+
+	// Legacy is the context responsible for units deployed, as
+	// separate unit agent processes, before the machine agent ran
+	// unit agents itself.
+	Legacy Context
+
+	// NewUnitWorker returns a worker that runs the agent of the
+	// named unit, using the agent config written on deployment.
+	NewUnitWorker func(unitName string) (worker.Worker, error)
+}
+
+// Validate returns an error if the config cannot be used to create
+// an InProcessContext.
+func (config InProcessConfig) Validate() error {
+	if config.AgentConfig == nil {
+		return errors.NotValidf("nil AgentConfig")
+	}
+	if config.API == nil {
+		return errors.NotValidf("nil API")
+	}
+	if config.Legacy == nil {
+		return errors.NotValidf("nil Legacy")
+	}
+	if config.NewUnitWorker == nil {
+		return errors.NotValidf("nil NewUnitWorker")
+	}
+	return nil
+}
+
+// InProcessContext is a Context that runs unit agents as workers inside
+// the machine agent, rather than as separate processes. Units deployed
+// by an earlier agent as separate processes are left to the Legacy
+// context until they are recalled.
+//
+// An InProcessContext is also a worker, which must be stopped when it
+// is no longer needed, to stop the unit agents it runs.
+type InProcessContext struct {
+	config InProcessConfig
+	runner *worker.Runner
+}
+
+// NewInProcessContext returns an InProcessContext that runs the agents
+// of the units it has already deployed.
+func NewInProcessContext(config InProcessConfig) (*InProcessContext, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx := &InProcessContext{
+		config: config,
+		runner: worker.NewRunner(worker.RunnerParams{
+			IsFatal:       func(error) bool { return false },
+			MoreImportant: func(err0, err1 error) bool { return true },
+			RestartDelay:  jworker.RestartDelay,
+		}),
+	}
+	unitNames, err := ctx.inProcessUnits()
+	if err != nil {
+		worker.Stop(ctx)
+		return nil, errors.Trace(err)
+	}
+	for _, unitName := range unitNames {
+		if err := ctx.startUnit(unitName); err != nil {
+			worker.Stop(ctx)
+			return nil, errors.Trace(err)
+		}
+	}
+	return ctx, nil
+}
+
+// AgentConfig is part of the Context interface.
+func (ctx *InProcessContext) AgentConfig() agent.Config {
+	return ctx.config.AgentConfig
+}
+
+// DeployUnit is part of the Context interface.
+func (ctx *InProcessContext) DeployUnit(unitName, initialPassword string) (err error) {
+	tag := names.NewUnitTag(unitName)
+	dataDir := ctx.config.AgentConfig.DataDir()
+	if _, err := os.Stat(agent.ConfigPath(dataDir, tag)); err == nil {
+		return errors.Errorf("unit %q is already deployed", unitName)
+	}
+	if err := installUnitAgent(ctx.config.AgentConfig, ctx.config.API, unitName, initialPassword); err != nil {
+		return errors.Trace(err)
+	}
+	defer removeOnErr(&err, tools.ToolsDir(dataDir, tag.String()))
+	defer removeOnErr(&err, agent.Dir(dataDir, tag))
+
+	marker := filepath.Join(agent.Dir(dataDir, tag), inProcessMarker)
+	if err := ioutil.WriteFile(marker, nil, 0644); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ctx.startUnit(unitName))
+}
+
+// RecallUnit is part of the Context interface.
+func (ctx *InProcessContext) RecallUnit(unitName string) error {
+	inProcess, err := ctx.isInProcess(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !inProcess {
+		return ctx.config.Legacy.RecallUnit(unitName)
+	}
+	if err := ctx.runner.StopWorker(unitName); err != nil {
+		return errors.Annotatef(err, "stopping unit %q", unitName)
+	}
+	return errors.Trace(removeUnitAgent(ctx.config.AgentConfig.DataDir(), unitName))
+}
+
+// DeployedUnits is part of the Context interface.
+func (ctx *InProcessContext) DeployedUnits() ([]string, error) {
+	unitNames, err := ctx.inProcessUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	legacy, err := ctx.config.Legacy.DeployedUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(unitNames, legacy...), nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (ctx *InProcessContext) Kill() {
+	ctx.runner.Kill()
+}
+
+// Wait is part of the worker.Worker interface.
+func (ctx *InProcessContext) Wait() error {
+	return ctx.runner.Wait()
+}
+
+func (ctx *InProcessContext) startUnit(unitName string) error {
+	return ctx.runner.StartWorker(unitName, func() (worker.Worker, error) {
+		return ctx.config.NewUnitWorker(unitName)
+	})
+}
+
+func (ctx *InProcessContext) isInProcess(unitName string) (bool, error) {
+	tag := names.NewUnitTag(unitName)
+	marker := filepath.Join(agent.Dir(ctx.config.AgentConfig.DataDir(), tag), inProcessMarker)
+	if _, err := os.Stat(marker); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// inProcessUnits returns the names of the units whose agents were
+// deployed to run inside the machine agent.
+func (ctx *InProcessContext) inProcessUnits() ([]string, error) {
+	agentsDir := agent.BaseDir(ctx.config.AgentConfig.DataDir())
+	infos, err := ioutil.ReadDir(agentsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unitNames []string
+	for _, info := range infos {
+		if !info.IsDir() || !strings.HasPrefix(info.Name(), names.UnitTagKind+"-") {
+			continue
+		}
+		tag, err := names.ParseUnitTag(info.Name())
+		if err != nil {
+			continue
+		}
+		inProcess, err := ctx.isInProcess(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if inProcess {
+			unitNames = append(unitNames, tag.Id())
+		}
+	}
+	return unitNames, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/deployer"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type InProcessContextSuite struct {
+	SimpleToolsFixture
+
+	legacy  *deployer.SimpleContext
+	started chan unitWorker
+}
+
+type unitWorker struct {
+	unitName string
+	worker   worker.Worker
+}
+
+var _ = gc.Suite(&InProcessContextSuite{})
+
+func (s *InProcessContextSuite) SetUpTest(c *gc.C) {
+	s.SimpleToolsFixture.SetUp(c, c.MkDir())
+	s.legacy = s.getContext(c)
+	s.started = make(chan unitWorker, 10)
+}
+
+func (s *InProcessContextSuite) TearDownTest(c *gc.C) {
+	s.SimpleToolsFixture.TearDown(c)
+}
+
+func (s *InProcessContextSuite) newUnitWorker(unitName string) (worker.Worker, error) {
+	w := workertest.NewErrorWorker(nil)
+	s.started <- unitWorker{unitName, w}
+	return w, nil
+}
+
+func (s *InProcessContextSuite) waitStarted(c *gc.C, unitName string) worker.Worker {
+	select {
+	case started := <-s.started:
+		c.Assert(started.unitName, gc.Equals, unitName)
+		return started.worker
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for unit %q to start", unitName)
+	}
+	panic("unreachable")
+}
+
+func (s *InProcessContextSuite) newContext(c *gc.C) *deployer.InProcessContext {
+	config := agentConfig(names.NewMachineTag("99"), s.dataDir, s.logDir)
+	ctx, err := deployer.NewTestInProcessContext(config, s.legacy, s.newUnitWorker)
+	c.Assert(err, jc.ErrorIsNil)
+	return ctx
+}
+
+func (s *InProcessContextSuite) TestValidate(c *gc.C) {
+	_, err := deployer.NewInProcessContext(deployer.InProcessConfig{})
+	c.Assert(err, gc.ErrorMatches, "nil AgentConfig not valid")
+}
+
+func (s *InProcessContextSuite) TestDeployRecall(c *gc.C) {
+	ctx := s.newContext(c)
+	defer workertest.CleanKill(c, ctx)
+
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.DeepEquals, []string{"foo/123"})
+	s.assertUpstartCount(c, 0)
+	w := s.waitStarted(c, "foo/123")
+
+	tag := names.NewUnitTag("foo/123")
+	conf, err := agent.ReadConfig(agent.ConfigPath(s.dataDir, tag))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Tag(), gc.Equals, tag)
+	c.Assert(conf.OldPassword(), gc.Equals, "some-password")
+
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/123" is already deployed`)
+
+	err = ctx.RecallUnit("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+	s.checkUnitRemoved(c, "foo/123")
+	workertest.CheckKilled(c, w)
+}
+
+func (s *InProcessContextSuite) TestRestartsDeployedUnits(c *gc.C) {
+	ctx := s.newContext(c)
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	w := s.waitStarted(c, "foo/123")
+	workertest.CleanKill(c, ctx)
+	workertest.CheckKilled(c, w)
+
+	ctx = s.newContext(c)
+	defer workertest.CleanKill(c, ctx)
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.DeepEquals, []string{"foo/123"})
+	s.waitStarted(c, "foo/123")
+}
+
+func (s *InProcessContextSuite) TestLegacyUnits(c *gc.C) {
+	err := s.legacy.DeployUnit("mysql/0", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.newContext(c)
+	defer workertest.CleanKill(c, ctx)
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(units)
+	c.Assert(units, gc.DeepEquals, []string{"foo/123", "mysql/0"})
+
+	err = ctx.RecallUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpstartCount(c, 0)
+	s.checkUnitRemoved(c, "mysql/0")
+
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.DeepEquals, []string{"foo/123"})
+}
+
+func (s *InProcessContextSuite) TestDeployRemovesAgentOnError(c *gc.C) {
+	ctx := s.newContext(c)
+	workertest.CleanKill(c, ctx)
+
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, gc.NotNil)
+	agentDir, _ := s.paths(names.NewUnitTag("foo/123"))
+	_, err = os.Stat(filepath.Join(agentDir, "agent.conf"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
@@ -106,11 +106,29 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 	if installed {
 		return fmt.Errorf("unit %q is already deployed", unitName)
 	}
+	if err := installUnitAgent(ctx.agentConfig, ctx.api, unitName, initialPassword); err != nil {
+		return errors.Trace(err)
+	}
+	tag := names.NewUnitTag(unitName)
+	dataDir := ctx.agentConfig.DataDir()
+	defer removeOnErr(&err, tools.ToolsDir(dataDir, tag.String()))
+	defer removeOnErr(&err, agent.Dir(dataDir, tag))
+
+	// Install an init service that runs the unit agent.
+	if err := service.InstallAndStart(svc); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
 
+// installUnitAgent links the current tools for the agent of the named
+// unit, and writes its agent config, on behalf of the machine agent
+// with the given config.
+func installUnitAgent(agentConfig agent.Config, api APICalls, unitName, initialPassword string) (err error) {
 	// Link the current tools for use by the new agent.
 	tag := names.NewUnitTag(unitName)
-	dataDir := ctx.agentConfig.DataDir()
-	logDir := ctx.agentConfig.LogDir()
+	dataDir := agentConfig.DataDir()
+	logDir := agentConfig.LogDir()
 	hostSeries, err := series.HostSeries()
 	if err != nil {
 		return errors.Trace(err)
@@ -127,14 +145,14 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 		return errors.Trace(err)
 	}
 
-	result, err := ctx.api.ConnectionInfo()
+	result, err := api.ConnectionInfo()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("state addresses: %q", result.StateAddresses)
 	logger.Debugf("API addresses: %q", result.APIAddresses)
-	containerType := ctx.agentConfig.Value(agent.ContainerType)
-	namespace := ctx.agentConfig.Value(agent.Namespace)
+	containerType := agentConfig.Value(agent.ContainerType)
+	namespace := agentConfig.Value(agent.Namespace)
 	conf, err := agent.NewAgentConfig(
 		agent.AgentConfigParams{
 			Paths: agent.Paths{
@@ -146,12 +164,12 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 			Tag:               tag,
 			Password:          initialPassword,
 			Nonce:             "unused",
-			Controller:        ctx.agentConfig.Controller(),
-			Model:             ctx.agentConfig.Model(),
+			Controller:        agentConfig.Controller(),
+			Model:             agentConfig.Model(),
 			// TODO: remove the state addresses here and test when api only.
 			StateAddresses: result.StateAddresses,
 			APIAddresses:   result.APIAddresses,
-			CACert:         ctx.agentConfig.CACert(),
+			CACert:         agentConfig.CACert(),
 			Values: map[string]string{
 				agent.ContainerType: containerType,
 				agent.Namespace:     namespace,
@@ -163,13 +181,26 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 	if err := conf.Write(); err != nil {
 		return err
 	}
-	defer removeOnErr(&err, conf.Dir())
+	return nil
+}
 
-	// Install an init service that runs the unit agent.
-	if err := service.InstallAndStart(svc); err != nil {
-		return errors.Trace(err)
+// removeUnitAgent removes the agent config and tools of the named
+// unit's agent.
+func removeUnitAgent(dataDir, unitName string) error {
+	tag := names.NewUnitTag(unitName)
+	agentDir := agent.Dir(dataDir, tag)
+	// Recursivley change mode to 777 on windows to avoid
+	// Operation not permitted errors when deleting the agentDir
+	err := recursiveChmod(agentDir, os.FileMode(0777))
+	if err != nil {
+		return err
 	}
-	return nil
+	if err := os.RemoveAll(agentDir); err != nil {
+		return err
+	}
+	// TODO(dfc) should take a Tag
+	toolsDir := tools.ToolsDir(dataDir, tag.String())
+	return os.Remove(toolsDir)
 }
 
 type deployerService interface {
@@ -213,21 +244,7 @@ func (ctx *SimpleContext) RecallUnit(unitName string) error {
 	if err := svc.Remove(); err != nil {
 		return err
 	}
-	tag := names.NewUnitTag(unitName)
-	dataDir := ctx.agentConfig.DataDir()
-	agentDir := agent.Dir(dataDir, tag)
-	// Recursivley change mode to 777 on windows to avoid
-	// Operation not permitted errors when deleting the agentDir
-	err = recursiveChmod(agentDir, os.FileMode(0777))
-	if err != nil {
-		return err
-	}
-	if err := os.RemoveAll(agentDir); err != nil {
-		return err
-	}
-	// TODO(dfc) should take a Tag
-	toolsDir := tools.ToolsDir(dataDir, tag.String())
-	return os.Remove(toolsDir)
+	return removeUnitAgent(ctx.agentConfig.DataDir(), unitName)
 }
 
 var deployedRe = regexp.MustCompile("^(jujud-.*unit-([a-z0-9-]+)-([0-9]+))$")
@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/dnsupdater"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/network/dns"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig holds dependencies and configuration for a
+// dnsupdater worker.
+type ManifoldConfig struct {
+	APICallerName string
+	Clock         clock.Clock
+	NewFacade     func(base.APICaller) (Facade, error)
+	NewWorker     func(Config) (worker.Worker, error)
+}
+
+func (config ManifoldConfig) start(apiCaller base.APICaller) (worker.Worker, error) {
+	if config.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return config.NewWorker(Config{
+		Facade:       facade,
+		Clock:        config.Clock,
+		PollInterval: DefaultPollInterval,
+		NewProvider:  dns.NewProvider,
+	})
+}
+
+// Manifold returns a dependency.Manifold that runs a dnsupdater worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return engine.APIManifold(
+		engine.APIManifoldConfig{config.APICallerName},
+		config.start,
+	)
+}
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return dnsupdater.NewAPI(apiCaller), nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/dnsupdater"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := dnsupdater.Manifold(dnsupdater.ManifoldConfig{
+		APICallerName: "api-caller",
+	})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	manifold := dnsupdater.Manifold(dnsupdater.ManifoldConfig{
+		APICallerName: "api-caller",
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartFacadeError(c *gc.C) {
+	manifold := dnsupdater.Manifold(dnsupdater.ManifoldConfig{
+		APICallerName: "api-caller",
+		Clock:         testing.NewClock(time.Time{}),
+		NewFacade: func(base.APICaller) (dnsupdater.Facade, error) {
+			return nil, errors.New("blort")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	facade := &fakeFacade{}
+	expectWorker := &fakeWorker{}
+	manifold := dnsupdater.Manifold(dnsupdater.ManifoldConfig{
+		APICallerName: "api-caller",
+		Clock:         clock,
+		NewFacade: func(base.APICaller) (dnsupdater.Facade, error) {
+			return facade, nil
+		},
+		NewWorker: func(config dnsupdater.Config) (worker.Worker, error) {
+			c.Check(config.Validate(), jc.ErrorIsNil)
+			c.Check(config.Facade, gc.Equals, facade)
+			c.Check(config.Clock, gc.Equals, clock)
+			c.Check(config.PollInterval, gc.Equals, dnsupdater.DefaultPollInterval)
+			return expectWorker, nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
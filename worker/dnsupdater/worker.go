@@ -0,0 +1,250 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsupdater provides a worker that maintains DNS records for
+// the public addresses of the exposed applications in a model, using
+// the DNS provider configured in the model's config.
+package dnsupdater
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/dnsupdater"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network/dns"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.dnsupdater")
+
+// DefaultPollInterval is how often the addresses of applications are
+// checked for changes.
+const DefaultPollInterval = time.Minute
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+	// ModelConfig returns the current model configuration.
+	ModelConfig() (*config.Config, error)
+
+	// WatchForModelConfigChanges returns a watcher that notifies
+	// when the model configuration changes.
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+
+	// ApplicationAddresses returns the public addresses of the alive
+	// units of every application in the model.
+	ApplicationAddresses() ([]dnsupdater.ApplicationAddresses, error)
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade       Facade
+	Clock        clock.Clock
+	PollInterval time.Duration
+	NewProvider  func(name, zone string, attrs map[string]string) (dns.Provider, error)
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	if config.NewProvider == nil {
+		return errors.NotValidf("nil NewProvider")
+	}
+	return nil
+}
+
+// New returns a worker that maintains A and AAAA records, named
+// <application>.<zone>, for the public addresses of the exposed
+// applications in the model. Records are removed when an application
+// is unexposed, or has no units with public addresses left.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &updaterWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type updaterWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// providerConfig holds the model config of the current provider.
+	providerConfig providerConfig
+	provider       dns.Provider
+
+	// records holds the addresses in the records maintained by the
+	// current provider, by application. It is nil until the first
+	// update made with the provider.
+	records map[string][]string
+}
+
+// providerConfig holds the model config of a DNS provider.
+type providerConfig struct {
+	name  string
+	zone  string
+	attrs map[string]string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *updaterWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *updaterWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *updaterWorker) loop() error {
+	configWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	var poll <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.configure(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-poll:
+		}
+		if w.provider == nil {
+			poll = nil
+			continue
+		}
+		if err := w.update(); err != nil {
+			return errors.Trace(err)
+		}
+		poll = w.config.Clock.After(w.config.PollInterval)
+	}
+}
+
+// configure sets up the DNS provider configured in the model config,
+// if it has changed. The records maintained by the previous provider
+// are removed.
+func (w *updaterWorker) configure() error {
+	modelConfig, err := w.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newConfig := providerConfig{
+		name:  modelConfig.DNSProvider(),
+		zone:  modelConfig.DNSZone(),
+		attrs: modelConfig.DNSProviderConfig(),
+	}
+	if reflect.DeepEqual(newConfig, w.providerConfig) {
+		return nil
+	}
+	if w.provider != nil {
+		for name := range w.records {
+			w.removeRecords(name)
+		}
+	}
+	w.providerConfig = newConfig
+	w.provider = nil
+	w.records = nil
+	if newConfig.name == "" {
+		logger.Debugf("no DNS provider configured")
+		return nil
+	}
+	provider, err := w.config.NewProvider(newConfig.name, newConfig.zone, newConfig.attrs)
+	if err != nil {
+		// The configuration may be fixed by the user, so don't
+		// restart the worker.
+		logger.Errorf("cannot configure DNS provider: %v", err)
+		return nil
+	}
+	logger.Infof("maintaining records in %q with %s", newConfig.zone, newConfig.name)
+	w.provider = provider
+	return nil
+}
+
+// update sets the records of the exposed applications, and removes
+// those of the applications that are no longer exposed, or have no
+// addresses. Records that cannot be updated are retried on the next
+// update.
+func (w *updaterWorker) update() error {
+	apps, err := w.config.Facade.ApplicationAddresses()
+	if err != nil {
+		return errors.Annotate(err, "getting application addresses")
+	}
+	firstUpdate := w.records == nil
+	if firstUpdate {
+		w.records = make(map[string][]string)
+	}
+	wanted := make(map[string]bool)
+	for _, app := range apps {
+		if !app.Exposed || len(app.Addresses) == 0 {
+			// Records left behind by a previous run of the worker
+			// are removed on the first update.
+			if firstUpdate {
+				w.records[app.Name] = nil
+			}
+			continue
+		}
+		addresses := append([]string(nil), app.Addresses...)
+		sort.Strings(addresses)
+		wanted[app.Name] = true
+		if current, ok := w.records[app.Name]; ok && reflect.DeepEqual(current, addresses) {
+			continue
+		}
+		name := dns.RecordName(app.Name, w.providerConfig.zone)
+		if err := w.provider.SetRecords(name, addresses); err != nil {
+			logger.Errorf("cannot set records of %q: %v", name, err)
+			continue
+		}
+		logger.Debugf("set records of %q to %v", name, addresses)
+		w.records[app.Name] = addresses
+	}
+	for app := range w.records {
+		if !wanted[app] {
+			w.removeRecords(app)
+		}
+	}
+	return nil
+}
+
+// removeRecords removes the records of the application, forgetting
+// them if successful.
+func (w *updaterWorker) removeRecords(app string) {
+	name := dns.RecordName(app, w.providerConfig.zone)
+	if err := w.provider.RemoveRecords(name); err != nil {
+		logger.Errorf("cannot remove records of %q: %v", name, err)
+		return
+	}
+	logger.Debugf("removed records of %q", name)
+	delete(w.records, app)
+}
@@ -0,0 +1,256 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apidnsupdater "github.com/juju/juju/api/dnsupdater"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network/dns"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/dnsupdater"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	clock  *jujutesting.Clock
+	facade *fakeFacade
+	config dnsupdater.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.clock = jujutesting.NewClock(time.Time{})
+	s.facade = &fakeFacade{
+		watcher: notAWatcher{workertest.NewFakeWatcher(2, 1)},
+		attrs: coretesting.Attrs{
+			config.DNSProviderKey:       "nsupdate",
+			config.DNSZoneKey:           "apps.example.com",
+			config.DNSProviderConfigKey: "server=10.0.0.53",
+		},
+		apps: []apidnsupdater.ApplicationAddresses{{
+			Name:      "wordpress",
+			Exposed:   true,
+			Addresses: []string{"10.0.0.2", "10.0.0.1"},
+		}, {
+			Name:      "mysql",
+			Addresses: []string{"10.0.0.3"},
+		}},
+	}
+	s.config = dnsupdater.Config{
+		Facade:       s.facade,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+		NewProvider: func(name, zone string, attrs map[string]string) (dns.Provider, error) {
+			s.stub.AddCall("NewProvider", name, zone, attrs)
+			if err := s.stub.NextErr(); err != nil {
+				return nil, err
+			}
+			return &fakeProvider{s.stub}, nil
+		},
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	for i, test := range []struct {
+		update func(*dnsupdater.Config)
+		err    string
+	}{{
+		update: func(config *dnsupdater.Config) { config.Facade = nil },
+		err:    "nil Facade not valid",
+	}, {
+		update: func(config *dnsupdater.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		update: func(config *dnsupdater.Config) { config.PollInterval = 0 },
+		err:    "non-positive PollInterval not valid",
+	}, {
+		update: func(config *dnsupdater.Config) { config.NewProvider = nil },
+		err:    "nil NewProvider not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := dnsupdater.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestNoProvider(c *gc.C) {
+	s.facade.setAttrs(coretesting.Attrs{})
+	w, err := dnsupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestSetsAndRemovesRecords(c *gc.C) {
+	w, err := dnsupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPoll(c, 0)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"NewProvider", []interface{}{"nsupdate", "apps.example.com", map[string]string{"server": "10.0.0.53"}}},
+		{"SetRecords", []interface{}{"wordpress.apps.example.com", []string{"10.0.0.1", "10.0.0.2"}}},
+		{"RemoveRecords", []interface{}{"mysql.apps.example.com"}},
+	})
+
+	// Unchanged records are not set again.
+	s.stub.ResetCalls()
+	s.waitPoll(c, time.Minute)
+	s.stub.CheckNoCalls(c)
+
+	s.facade.setApps([]apidnsupdater.ApplicationAddresses{{
+		Name:      "wordpress",
+		Addresses: []string{"10.0.0.1", "10.0.0.2"},
+	}, {
+		Name:      "mysql",
+		Exposed:   true,
+		Addresses: []string{"2001:db8::1"},
+	}})
+	s.waitPoll(c, time.Minute)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"SetRecords", []interface{}{"mysql.apps.example.com", []string{"2001:db8::1"}}},
+		{"RemoveRecords", []interface{}{"wordpress.apps.example.com"}},
+	})
+}
+
+func (s *WorkerSuite) TestRetriesFailedUpdates(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("REFUSED"), errors.New("REFUSED"))
+	w, err := dnsupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPoll(c, 0)
+	s.stub.CheckCallNames(c, "NewProvider", "SetRecords", "RemoveRecords")
+
+	s.stub.ResetCalls()
+	s.waitPoll(c, time.Minute)
+	s.stub.CheckCallNames(c, "SetRecords", "RemoveRecords")
+}
+
+func (s *WorkerSuite) TestConfigChangeRemovesRecords(c *gc.C) {
+	w, err := dnsupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.waitPoll(c, 0)
+
+	s.stub.ResetCalls()
+	s.facade.setAttrs(coretesting.Attrs{})
+	s.facade.watcher.Ping()
+	s.waitCalls(c, 1)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveRecords", []interface{}{"wordpress.apps.example.com"}},
+	})
+}
+
+func (s *WorkerSuite) TestApplicationAddressesError(c *gc.C) {
+	s.facade.setAppsErr(errors.New("boom"))
+	w, err := dnsupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting application addresses: boom")
+}
+
+// waitPoll advances the clock by d, and waits for the worker to
+// finish the next update and wait to poll again.
+func (s *WorkerSuite) waitPoll(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	if d > 0 {
+		err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *WorkerSuite) waitCalls(c *gc.C, n int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.stub.Calls()) >= n {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d calls", n)
+}
+
+type notAWatcher struct {
+	workertest.NotAWatcher
+}
+
+func (w notAWatcher) Changes() watcher.NotifyChannel {
+	return w.NotAWatcher.Changes()
+}
+
+type fakeFacade struct {
+	watcher notAWatcher
+
+	mu      sync.Mutex
+	attrs   coretesting.Attrs
+	apps    []apidnsupdater.ApplicationAddresses
+	appsErr error
+}
+
+func (f *fakeFacade) setAttrs(attrs coretesting.Attrs) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attrs = attrs
+}
+
+func (f *fakeFacade) setApps(apps []apidnsupdater.ApplicationAddresses) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.apps = apps
+}
+
+func (f *fakeFacade) setAppsErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.appsErr = err
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(f.attrs))
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *fakeFacade) ApplicationAddresses() ([]apidnsupdater.ApplicationAddresses, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.apps, f.appsErr
+}
+
+type fakeProvider struct {
+	stub *jujutesting.Stub
+}
+
+func (p *fakeProvider) SetRecords(name string, addresses []string) error {
+	p.stub.AddCall("SetRecords", name, addresses)
+	return p.stub.NextErr()
+}
+
+func (p *fakeProvider) RemoveRecords(name string) error {
+	p.stub.AddCall("RemoveRecords", name)
+	return p.stub.NextErr()
+}
@@ -4,13 +4,18 @@
 package environ
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/credentialplugin"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/dependency"
 )
 
 var logger = loggo.GetLogger("juju.worker.environ")
@@ -30,6 +35,7 @@ type ConfigObserver interface {
 type Config struct {
 	Observer       ConfigObserver
 	NewEnvironFunc environs.NewEnvironFunc
+	Clock          clock.Clock
 }
 
 // Validate returns an error if the config cannot be used to start a Tracker.
@@ -40,6 +46,9 @@ func (config Config) Validate() error {
 	if config.NewEnvironFunc == nil {
 		return errors.NotValidf("nil NewEnvironFunc")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	return nil
 }
 
@@ -49,6 +58,10 @@ type Tracker struct {
 	config   Config
 	catacomb catacomb.Catacomb
 	environ  environs.Environ
+
+	// expires is the time at which the credential the environ was
+	// opened with expires, or zero if it does not expire.
+	expires time.Time
 }
 
 // NewTracker loads an environment from the observer and returns a new Tracker,
@@ -61,7 +74,7 @@ func NewTracker(config Config) (*Tracker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	environ, err := environs.GetEnviron(config.Observer, config.NewEnvironFunc)
+	environ, expires, err := openEnviron(config)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create environ")
 	}
@@ -69,6 +82,7 @@ func NewTracker(config Config) (*Tracker, error) {
 	t := &Tracker{
 		config:  config,
 		environ: environ,
+		expires: expires,
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &t.catacomb,
@@ -80,6 +94,33 @@ func NewTracker(config Config) (*Tracker, error) {
 	return t, nil
 }
 
+// openEnviron opens the environ described by the observer, and
+// returns it along with the time at which the credential obtained from
+// any credential plugin expires. The credential is resolved here,
+// rather than by the NewEnvironFunc, so that its expiry is known.
+func openEnviron(config Config) (environs.Environ, time.Time, error) {
+	modelConfig, err := config.Observer.ModelConfig()
+	if err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	cloudSpec, err := config.Observer.CloudSpec()
+	if err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	cloudSpec, expires, err := environs.ResolveExpiringPluginCredential(cloudSpec)
+	if err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	environ, err := config.NewEnvironFunc(environs.OpenParams{
+		Cloud:  cloudSpec,
+		Config: modelConfig,
+	})
+	if err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	return environ, expires, nil
+}
+
 // Environ returns the encapsulated Environ. It will continue to be updated in
 // the background for as long as the Tracker continues to run.
 func (t *Tracker) Environ() environs.Environ {
@@ -94,11 +135,28 @@ func (t *Tracker) loop() error {
 	if err := t.catacomb.Add(environWatcher); err != nil {
 		return errors.Trace(err)
 	}
+
+	// The environ cannot be given a new credential, so the tracker is
+	// restarted, along with the workers using the environ, shortly
+	// before its credential expires; the credential is obtained again
+	// from the plugin when the environ is next opened.
+	var expiring <-chan time.Time
+	if !t.expires.IsZero() {
+		lifetime := t.expires.Sub(t.config.Clock.Now())
+		delay := lifetime - credentialplugin.DefaultRefreshMargin
+		if delay <= 0 {
+			delay = lifetime / 2
+		}
+		expiring = t.config.Clock.After(delay)
+	}
 	for {
 		logger.Debugf("waiting for environ watch notification")
 		select {
 		case <-t.catacomb.Dying():
 			return t.catacomb.ErrDying()
+		case <-expiring:
+			logger.Infof("cloud credential expires at %s, reopening environ", t.expires.Format(time.RFC3339))
+			return dependency.ErrBounce
 		case _, ok := <-environWatcher.Changes():
 			if !ok {
 				return errors.New("environ config watch closed")
@@ -4,14 +4,21 @@
 package environ_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/workertest"
 )
@@ -40,6 +47,17 @@ func (s *TrackerSuite) TestValidateNewEnvironFunc(c *gc.C) {
 	})
 }
 
+func (s *TrackerSuite) TestValidateClock(c *gc.C) {
+	config := environ.Config{
+		Observer:       &runContext{},
+		NewEnvironFunc: newMockEnviron,
+	}
+	s.testValidate(c, config, func(err error) {
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+	})
+}
+
 func (s *TrackerSuite) testValidate(c *gc.C, config environ.Config, check func(err error)) {
 	err := config.Validate()
 	check(err)
@@ -59,6 +77,7 @@ func (s *TrackerSuite) TestModelConfigFails(c *gc.C) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
 			NewEnvironFunc: newMockEnviron,
+			Clock:          clock.WallClock,
 		})
 		c.Check(err, gc.ErrorMatches, "cannot create environ: no yuo")
 		c.Check(tracker, gc.IsNil)
@@ -74,6 +93,7 @@ func (s *TrackerSuite) TestModelConfigInvalid(c *gc.C) {
 			NewEnvironFunc: func(environs.OpenParams) (environs.Environ, error) {
 				return nil, errors.NotValidf("config")
 			},
+			Clock: clock.WallClock,
 		})
 		c.Check(err, gc.ErrorMatches, `cannot create environ: config not valid`)
 		c.Check(tracker, gc.IsNil)
@@ -91,6 +111,7 @@ func (s *TrackerSuite) TestModelConfigValid(c *gc.C) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
 			NewEnvironFunc: newMockEnviron,
+			Clock:          clock.WallClock,
 		})
 		c.Assert(err, jc.ErrorIsNil)
 		defer workertest.CleanKill(c, tracker)
@@ -115,6 +136,7 @@ func (s *TrackerSuite) TestCloudSpec(c *gc.C) {
 				c.Assert(args.Cloud, jc.DeepEquals, cloudSpec)
 				return nil, errors.NotValidf("cloud spec")
 			},
+			Clock: clock.WallClock,
 		})
 		c.Check(err, gc.ErrorMatches, `cannot create environ: cloud spec not valid`)
 		c.Check(tracker, gc.IsNil)
@@ -132,6 +154,7 @@ func (s *TrackerSuite) TestWatchFails(c *gc.C) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
 			NewEnvironFunc: newMockEnviron,
+			Clock:          clock.WallClock,
 		})
 		c.Assert(err, jc.ErrorIsNil)
 		defer workertest.DirtyKill(c, tracker)
@@ -148,6 +171,7 @@ func (s *TrackerSuite) TestWatchCloses(c *gc.C) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
 			NewEnvironFunc: newMockEnviron,
+			Clock:          clock.WallClock,
 		})
 		c.Assert(err, jc.ErrorIsNil)
 		defer workertest.DirtyKill(c, tracker)
@@ -169,6 +193,7 @@ func (s *TrackerSuite) TestWatchedModelConfigFails(c *gc.C) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
 			NewEnvironFunc: newMockEnviron,
+			Clock:          clock.WallClock,
 		})
 		c.Check(err, jc.ErrorIsNil)
 		defer workertest.DirtyKill(c, tracker)
@@ -190,6 +215,7 @@ func (s *TrackerSuite) TestWatchedModelConfigIncompatible(c *gc.C) {
 				env.SetErrors(errors.New("SetConfig is broken"))
 				return env, nil
 			},
+			Clock: clock.WallClock,
 		})
 		c.Check(err, jc.ErrorIsNil)
 		defer workertest.DirtyKill(c, tracker)
@@ -211,6 +237,7 @@ func (s *TrackerSuite) TestWatchedModelConfigUpdates(c *gc.C) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
 			NewEnvironFunc: newMockEnviron,
+			Clock:          clock.WallClock,
 		})
 		c.Check(err, jc.ErrorIsNil)
 		defer workertest.CleanKill(c, tracker)
@@ -241,3 +268,43 @@ func (s *TrackerSuite) TestWatchedModelConfigUpdates(c *gc.C) {
 		context.CheckCallNames(c, "ModelConfig", "CloudSpec", "WatchForModelConfigChanges", "ModelConfig")
 	})
 }
+
+func (s *TrackerSuite) TestExpiringCredentialBounces(c *gc.C) {
+	now := time.Now()
+	path := filepath.Join(c.MkDir(), "cred.yaml")
+	err := ioutil.WriteFile(path, []byte(fmt.Sprintf(`
+auth-type: userpass
+attributes:
+  username: bob
+  password: token-1234
+expires: %s
+`, now.Add(time.Hour).UTC().Format(time.RFC3339))), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	plugin := cloud.NewCredential(cloud.PluginAuthType, map[string]string{
+		"plugin": "file",
+		"path":   path,
+	})
+	fix := &fixture{cloud: environs.CloudSpec{
+		Name:       "foo",
+		Type:       "bar",
+		Credential: &plugin,
+	}}
+	fix.Run(c, func(context *runContext) {
+		testClock := testing.NewClock(now)
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer: context,
+			NewEnvironFunc: func(args environs.OpenParams) (environs.Environ, error) {
+				c.Check(args.Cloud.Credential.Attributes()["password"], gc.Equals, "token-1234")
+				return newMockEnviron(args)
+			},
+			Clock: testClock,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		err = testClock.WaitAdvance(55*time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.Equals, dependency.ErrBounce)
+	})
+}
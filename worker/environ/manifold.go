@@ -5,6 +5,7 @@ package environ
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/agent"
@@ -17,6 +18,7 @@ import (
 type ManifoldConfig struct {
 	APICallerName  string
 	NewEnvironFunc environs.NewEnvironFunc
+	Clock          clock.Clock
 }
 
 // Manifold returns a Manifold that encapsulates a *Tracker and exposes it as
@@ -39,6 +41,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			w, err := NewTracker(Config{
 				Observer:       apiSt,
 				NewEnvironFunc: config.NewEnvironFunc,
+				Clock:          config.Clock,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
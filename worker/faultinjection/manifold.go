@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package faultinjection provides a manifold that enables or disables
+// fault injection in an agent, according to its controller's config.
+package faultinjection
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/faultinject"
+	"github.com/juju/juju/worker/dependency"
+)
+
+var logger = loggo.GetLogger("juju.worker.faultinjection")
+
+// Facade exposes the controller config to the manifold.
+type Facade interface {
+	ControllerConfig() (controller.Config, error)
+}
+
+// NewFacade returns a Facade backed by the Agent API facade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	facade, err := apiagent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+// ManifoldConfig defines the names of the manifolds on which the
+// fault injection manifold depends, and the registry it configures.
+type ManifoldConfig struct {
+	APICallerName string
+	Registry      *faultinject.Registry
+	NewFacade     func(base.APICaller) (Facade, error)
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Registry == nil {
+		return errors.NotValidf("nil Registry")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold. It enables fault
+// injection in the registry if the controller's config allows it,
+// and then uninstalls itself: changes to the config take effect when
+// the agent is restarted. Fault injection is never disabled here, so
+// that faults which break the API connection are not cleared by it.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerConfig, err := facade.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read controller config")
+	}
+	if controllerConfig.FaultInjectionEnabled() {
+		config.Registry.SetEnabled(true)
+	} else {
+		logger.Debugf("fault injection not enabled by the controller")
+	}
+	return nil, dependency.ErrUninstall
+}
+
+// Manifold returns a dependency manifold that enables fault injection
+// when the controller's config allows it.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package faultinjection_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/faultinject"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/faultinjection"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+
+	registry *faultinject.Registry
+	facade   *stubFacade
+	config   faultinjection.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.registry = faultinject.NewRegistry(testing.NewClock(time.Time{}))
+	s.facade = &stubFacade{config: controller.Config{}}
+	s.config = faultinjection.ManifoldConfig{
+		APICallerName: "api-caller",
+		Registry:      s.registry,
+		NewFacade: func(base.APICaller) (faultinjection.Facade, error) {
+			return s.facade, nil
+		},
+	}
+}
+
+func (s *ManifoldSuite) start(c *gc.C) error {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": struct{ base.APICaller }{},
+	})
+	w, err := faultinjection.Manifold(s.config).Start(context)
+	c.Check(w, gc.IsNil)
+	return err
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := faultinjection.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (s *ManifoldSuite) TestStartMissingRegistry(c *gc.C) {
+	s.config.Registry = nil
+	err := s.start(c)
+	c.Check(err, gc.ErrorMatches, "nil Registry not valid")
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+	w, err := faultinjection.Manifold(s.config).Start(context)
+	c.Check(w, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *ManifoldSuite) TestStartControllerConfigError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	err := s.start(c)
+	c.Check(err, gc.ErrorMatches, "cannot read controller config: boom")
+	c.Check(s.registry.Enabled(), jc.IsFalse)
+}
+
+func (s *ManifoldSuite) TestStartNotEnabled(c *gc.C) {
+	err := s.start(c)
+	c.Check(err, gc.Equals, dependency.ErrUninstall)
+	c.Check(s.registry.Enabled(), jc.IsFalse)
+}
+
+func (s *ManifoldSuite) TestStartEnabled(c *gc.C) {
+	s.facade.config[controller.FaultInjectionEnabled] = true
+	err := s.start(c)
+	c.Check(err, gc.Equals, dependency.ErrUninstall)
+	c.Check(s.registry.Enabled(), jc.IsTrue)
+}
+
+type stubFacade struct {
+	config controller.Config
+	err    error
+}
+
+func (f *stubFacade) ControllerConfig() (controller.Config, error) {
+	return f.config, f.err
+}
@@ -63,6 +63,13 @@ type EnvironFirewaller interface {
 	environs.Firewaller
 }
 
+// EnvironContainerFirewaller defines methods to allow the worker to
+// perform firewall operations on the containers of a Juju cloud
+// environment, where containers have firewall rules of their own.
+type EnvironContainerFirewaller interface {
+	environs.ContainerFirewaller
+}
+
 // EnvironInstances defines methods to allow the worker to perform
 // operations on instances in a Juju cloud environment.
 type EnvironInstances interface {
@@ -80,6 +87,15 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	// EnvironContainerFirewaller is used to open and close the ports
+	// of containers in the instance firewall mode, if the environment
+	// supports it; it may be nil.
+	EnvironContainerFirewaller EnvironContainerFirewaller
+
+	// SupportsICMP is true if the environment's firewalls can open
+	// ICMP port ranges; if not, they are ignored.
+	SupportsICMP bool
+
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
@@ -114,15 +130,18 @@ type portRanges map[network.PortRange]bool
 // machines and reflects those changes onto the backing environment.
 // Uses Firewaller API V1.
 type Firewaller struct {
-	catacomb           catacomb.Catacomb
-	firewallerApi      FirewallerAPI
-	remoteRelationsApi *remoterelations.Client
-	environFirewaller  EnvironFirewaller
-	environInstances   EnvironInstances
+	catacomb            catacomb.Catacomb
+	firewallerApi       FirewallerAPI
+	remoteRelationsApi  *remoterelations.Client
+	environFirewaller   EnvironFirewaller
+	environInstances    EnvironInstances
+	containerFirewaller EnvironContainerFirewaller
+	supportsICMP        bool
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
+	containersChange     chan []string
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
 	applicationids       map[names.ApplicationTag]*applicationData
@@ -153,9 +172,12 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		remoteRelationsApi:          cfg.RemoteRelationsApi,
 		environFirewaller:           cfg.EnvironFirewaller,
 		environInstances:            cfg.EnvironInstances,
+		containerFirewaller:         cfg.EnvironContainerFirewaller,
+		supportsICMP:                cfg.SupportsICMP,
 		newRemoteFirewallerAPIFunc:  cfg.NewCrossModelFacadeFunc,
 		modelUUID:                   cfg.ModelUUID,
 		machineds:                   make(map[names.MachineTag]*machineData),
+		containersChange:            make(chan []string),
 		unitsChange:                 make(chan *unitsChange),
 		unitds:                      make(map[names.UnitTag]*unitData),
 		applicationids:              make(map[names.ApplicationTag]*applicationData),
@@ -281,12 +303,19 @@ func (fw *Firewaller) loop() error {
 			if err := fw.relationIngressChanged(change); err != nil {
 				return errors.Trace(err)
 			}
+		case change := <-fw.containersChange:
+			for _, machineId := range change {
+				if err := fw.machineLifeChanged(names.NewMachineTag(machineId)); err != nil {
+					return err
+				}
+			}
 		case change := <-fw.unitsChange:
 			if err := fw.unitsChanged(change); err != nil {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedCIDRs = change.exposedCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -383,6 +412,13 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	} else if err != nil {
 		return errors.Annotate(err, "cannot watch machine units")
 	}
+	if names.IsContainerMachine(tag.Id()) {
+		// Start from the container's current rules, so that any
+		// that are no longer wanted are closed.
+		if err := fw.initContainerRules(machined, m); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	unitw, err := m.WatchUnits()
 	if err != nil {
 		return errors.Trace(err)
@@ -398,6 +434,17 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	if err := fw.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
 	}
+	var containerw watcher.StringsWatcher
+	if fw.containerFirewalls() && !names.IsContainerMachine(tag.Id()) {
+		containerw, err = m.WatchContainers()
+		if errors.IsNotSupported(err) {
+			logger.Warningf("cannot open ports for containers of %q: %v", tag, err)
+		} else if err != nil {
+			return errors.Trace(err)
+		} else if err := fw.catacomb.Add(containerw); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	select {
 	case <-fw.catacomb.Dying():
 		return fw.catacomb.ErrDying()
@@ -416,7 +463,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &machined.catacomb,
 		Work: func() error {
-			return machined.watchLoop(unitw)
+			return machined.watchLoop(unitw, containerw)
 		},
 	})
 	if err != nil {
@@ -428,6 +475,30 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	return fw.catacomb.Add(machined)
 }
 
+// containerFirewalls reports whether containers have firewall rules
+// of their own, which are opened and closed by the firewaller.
+func (fw *Firewaller) containerFirewalls() bool {
+	return !fw.globalMode && fw.containerFirewaller != nil &&
+		fw.containerFirewaller.SupportsContainerFirewalls()
+}
+
+// initContainerRules sets the ingress rules of the container machine
+// to those applied in the environment. A container that has not been
+// provisioned has none.
+func (fw *Firewaller) initContainerRules(machined *machineData, m *firewaller.Machine) error {
+	if _, err := m.InstanceId(); params.IsCodeNotProvisioned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	rules, err := fw.containerFirewaller.ContainerIngressRules(machined.tag.Id())
+	if err != nil {
+		return errors.Annotatef(err, "cannot get ingress rules of %q", machined.tag)
+	}
+	machined.ingressRules = rules
+	return nil
+}
+
 // startUnit creates a new data value for tracking details of the unit
 // The provided machineTag must be the tag for the machine the unit was last
 // observed to be assigned to.
@@ -484,22 +555,23 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedCIDRs, err := applicationExposure(app)
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:           fw,
+		application:  app,
+		exposed:      exposed,
+		exposedCIDRs: exposedCIDRs,
+		unitds:       make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedCIDRs)
 		},
 	})
 	if err != nil {
@@ -547,6 +619,11 @@ func (fw *Firewaller) reconcileGlobal() error {
 // opens and closes the appropriate ports for each instance.
 func (fw *Firewaller) reconcileInstances() error {
 	for _, machined := range fw.machineds {
+		if names.IsContainerMachine(machined.tag.Id()) {
+			// Containers' rules are reconciled when they
+			// are started.
+			continue
+		}
 		m, err := machined.machine()
 		if params.IsCodeNotFound(err) {
 			if err := fw.forgetMachine(machined); err != nil {
@@ -767,9 +844,15 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 			}
 
 			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
+			// If the unit is exposed, allow access from the exposed
+			// CIDRs, or from everywhere if there are none.
 			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
+				if len(unitd.applicationd.exposedCIDRs) == 0 {
+					cidrs.Add("0.0.0.0/0")
+				}
+				for _, cidr := range unitd.applicationd.exposedCIDRs {
+					cidrs.Add(cidr)
+				}
 			} else {
 				// Not exposed, so add any ingress rules required by remote relations.
 				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
@@ -779,6 +862,10 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 			}
 			if cidrs.Size() > 0 {
 				for portRange := range portRanges {
+					if strings.ToLower(portRange.Protocol) == "icmp" && !fw.supportsICMP {
+						logger.Warningf("ignoring %v opened by %v: ICMP not supported by the cloud's firewall", portRange, unitTag)
+						continue
+					}
 					sourceCidrs := cidrs.SortedValues()
 					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
 					if err != nil {
@@ -861,6 +948,9 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if len(toOpen) == 0 && len(toClose) == 0 {
 		return nil
 	}
+	if names.IsContainerMachine(machined.tag.Id()) {
+		return fw.flushContainerPorts(machined, toOpen, toClose)
+	}
 	m, err := machined.machine()
 	if params.IsCodeNotFound(err) {
 		return nil
@@ -903,6 +993,27 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// flushContainerPorts opens and closes ports on the container, which
+// has firewall rules of its own.
+func (fw *Firewaller) flushContainerPorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
+	containerId := machined.tag.Id()
+	if len(toOpen) > 0 {
+		if err := fw.containerFirewaller.OpenContainerPorts(containerId, toOpen); err != nil {
+			return errors.Trace(err)
+		}
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+	}
+	if len(toClose) > 0 {
+		if err := fw.containerFirewaller.CloseContainerPorts(containerId, toClose); err != nil {
+			return errors.Trace(err)
+		}
+		network.SortIngressRules(toClose)
+		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+	}
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -1006,11 +1117,19 @@ func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.firewallerApi.Machine(md.tag)
 }
 
-// watchLoop watches the machine for units added or removed.
-func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
+// watchLoop watches the machine for units added or removed, and
+// for containers added or removed if containerw is not nil.
+func (md *machineData) watchLoop(unitw, containerw watcher.StringsWatcher) error {
 	if err := md.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
 	}
+	var containerChanges watcher.StringsChannel
+	if containerw != nil {
+		if err := md.catacomb.Add(containerw); err != nil {
+			return errors.Trace(err)
+		}
+		containerChanges = containerw.Changes()
+	}
 	for {
 		select {
 		case <-md.catacomb.Dying():
@@ -1024,6 +1143,15 @@ func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
 				return md.catacomb.ErrDying()
 			case md.fw.unitsChange <- &unitsChange{md, change}:
 			}
+		case change, ok := <-containerChanges:
+			if !ok {
+				return errors.New("machine containers watcher closed")
+			}
+			select {
+			case <-md.catacomb.Dying():
+				return md.catacomb.ErrDying()
+			case md.fw.containersChange <- change:
+			}
 		}
 	}
 }
@@ -1047,23 +1175,44 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and exposed CIDRs
+// for one specific application.
 type exposedChange struct {
 	applicationd *applicationData
 	exposed      bool
+	exposedCIDRs []string
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	application  *firewaller.Application
+	exposed      bool
+	exposedCIDRs []string
+	unitds       map[names.UnitTag]*unitData
+}
+
+// applicationExposure returns whether the application is exposed, and
+// the source CIDRs it is exposed to.
+func applicationExposure(app *firewaller.Application) (bool, []string, error) {
+	exposed, err := app.IsExposed()
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	if !exposed {
+		return false, nil, nil
+	}
+	cidrs, err := app.ExposedCIDRs()
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	return true, cidrs, nil
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and exposed CIDRs
+// for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedCIDRs []string) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1088,24 +1237,31 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
+			change, changeCIDRs, err := applicationExposure(ad.application)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			if change == exposed && sameCIDRs(changeCIDRs, exposedCIDRs) {
 				continue
 			}
 
 			exposed = change
+			exposedCIDRs = changeCIDRs
 			select {
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.exposedChange <- &exposedChange{ad, change, changeCIDRs}:
 			}
 		}
 	}
 }
 
+// sameCIDRs reports whether the two lists hold the same CIDRs.
+func sameCIDRs(a, b []string) bool {
+	setA, setB := set.NewStrings(a...), set.NewStrings(b...)
+	return setA.Size() == setB.Size() && setA.Difference(setB).IsEmpty()
+}
+
 // Kill is part of the worker.Worker interface.
 func (ad *applicationData) Kill() {
 	ad.catacomb.Kill(nil)
@@ -134,6 +134,34 @@ func (s *firewallerBaseSuite) assertPorts(c *gc.C, inst instance.Instance, machi
 	}
 }
 
+// assertContainerPorts retrieves the open ports of the container and
+// compares them to the expected.
+func (s *firewallerBaseSuite) assertContainerPorts(c *gc.C, containerId string, expected []network.IngressRule) {
+	fwEnv, ok := s.Environ.(environs.ContainerFirewaller)
+	c.Assert(ok, gc.Equals, true)
+
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := fwEnv.ContainerIngressRules(containerId)
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortIngressRules(got)
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 // assertEnvironPorts retrieves the open ports of environment and compares them
 // to the expected.
 func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.IngressRule) {
@@ -212,14 +240,17 @@ func (s *InstanceModeSuite) newFirewaller(c *gc.C) worker.Worker {
 	s.mockClock = &mockClock{c: c}
 	fwEnv, ok := s.Environ.(environs.Firewaller)
 	c.Assert(ok, gc.Equals, true)
+	containerFwEnv, ok := s.Environ.(environs.ContainerFirewaller)
+	c.Assert(ok, gc.Equals, true)
 
 	cfg := firewaller.Config{
-		ModelUUID:          s.State.ModelUUID(),
-		Mode:               config.FwInstance,
-		EnvironFirewaller:  fwEnv,
-		EnvironInstances:   s.Environ,
-		FirewallerAPI:      s.firewaller,
-		RemoteRelationsApi: s.remoteRelations,
+		ModelUUID:                  s.State.ModelUUID(),
+		Mode:                       config.FwInstance,
+		EnvironFirewaller:          fwEnv,
+		EnvironInstances:           s.Environ,
+		EnvironContainerFirewaller: containerFwEnv,
+		FirewallerAPI:              s.firewaller,
+		RemoteRelationsApi:         s.remoteRelations,
 		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
 			return s.crossmodelFirewaller, nil
 		},
@@ -329,6 +360,43 @@ func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	s.assertPorts(c, inst2, m2.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestContainerPorts(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	inst := s.startInstance(c, host)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetProvisioned("juju-lxd-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The ports are opened on the container, and not on its host.
+	s.assertContainerPorts(c, container.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
+	})
+	s.assertPorts(c, inst, host.Id(), nil)
+
+	err = u.ClosePorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertContainerPorts(c, container.Id(), nil)
+}
+
 func (s *InstanceModeSuite) TestMachineWithoutInstanceId(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -506,6 +574,41 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposedApplicationToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.SetExposedToCIDRs([]string{"10.0.0.0/24", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+	})
+
+	// Changing the CIDRs closes access from those no longer wanted.
+	err = app.SetExposedToCIDRs([]string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Exposing without CIDRs allows access from anywhere.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -1177,6 +1280,27 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestICMPIgnoredWhenNotSupported(c *gc.C) {
+	// The dummy environ's firewall does not support ICMP.
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = u.OpenPorts("icmp", network.AnyICMP, network.AnyICMP)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedApplication(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
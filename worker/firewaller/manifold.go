@@ -92,6 +92,15 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 	// nil value, as it won't be used.
 	fwEnv, fwEnvOK := environ.(environs.Firewaller)
 
+	// Containers only have their own firewall rules in environs
+	// that support them; otherwise, their ports are not managed.
+	containerFwEnv, _ := environ.(environs.ContainerFirewaller)
+
+	// Charms may open ICMP, but only some environs can pass it
+	// on to their firewalls.
+	icmpFwEnv, ok := environ.(environs.ICMPFirewaller)
+	supportsICMP := ok && icmpFwEnv.SupportsICMP()
+
 	mode := environ.Config().FirewallMode()
 	if mode == config.FwNone {
 		logger.Infof("stopping firewaller (not required)")
@@ -113,13 +122,15 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 	}
 
 	w, err := cfg.NewFirewallerWorker(Config{
-		ModelUUID:          agent.CurrentConfig().Model().Id(),
-		RemoteRelationsApi: remoteRelationsAPI,
-		FirewallerAPI:      firewallerAPI,
-		EnvironFirewaller:  fwEnv,
-		EnvironInstances:   environ,
-		Mode:               mode,
-		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
+		ModelUUID:                  agent.CurrentConfig().Model().Id(),
+		RemoteRelationsApi:         remoteRelationsAPI,
+		FirewallerAPI:              firewallerAPI,
+		EnvironFirewaller:          fwEnv,
+		EnvironInstances:           environ,
+		EnvironContainerFirewaller: containerFwEnv,
+		SupportsICMP:               supportsICMP,
+		Mode:                       mode,
+		NewCrossModelFacadeFunc:    crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package floatingipupdater_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/floatingipupdater"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) manifold(config floatingipupdater.ManifoldConfig) dependency.Manifold {
+	config.APICallerName = "api-caller"
+	config.EnvironName = "environ"
+	return floatingipupdater.Manifold(config)
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := s.manifold(floatingipupdater.ManifoldConfig{})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller", "environ"})
+}
+
+func (s *ManifoldSuite) TestStartMissingEnviron(c *gc.C) {
+	manifold := s.manifold(floatingipupdater.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    dependency.ErrMissing,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartUnsupportedEnviron(c *gc.C) {
+	manifold := s.manifold(floatingipupdater.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    &fakeEnviron{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartFacadeError(c *gc.C) {
+	manifold := s.manifold(floatingipupdater.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+		NewFacade: func(base.APICaller) (floatingipupdater.Facade, error) {
+			return nil, errors.New("blort")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    &assigningEnviron{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	facade := &fakeFacade{}
+	environ := &assigningEnviron{}
+	expectWorker := &fakeWorker{}
+	manifold := s.manifold(floatingipupdater.ManifoldConfig{
+		Clock: clock,
+		NewFacade: func(base.APICaller) (floatingipupdater.Facade, error) {
+			return facade, nil
+		},
+		NewWorker: func(config floatingipupdater.Config) (worker.Worker, error) {
+			c.Check(config.Validate(), jc.ErrorIsNil)
+			c.Check(config.Facade, gc.Equals, facade)
+			c.Check(config.Assigner, gc.Equals, environ)
+			c.Check(config.Clock, gc.Equals, clock)
+			c.Check(config.PollInterval, gc.Equals, floatingipupdater.DefaultPollInterval)
+			return expectWorker, nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    environ,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
+
+type fakeEnviron struct {
+	environs.Environ
+}
+
+type assigningEnviron struct {
+	fakeEnviron
+}
+
+func (*assigningEnviron) AssignFloatingIP(string, instance.Id) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package floatingipupdater provides a worker that makes the floating
+// IP addresses of exposed applications follow their leaders, by
+// associating each address with the machine of the application's
+// leader whenever leadership changes.
+package floatingipupdater
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/floatingipupdater"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.floatingipupdater")
+
+// DefaultPollInterval is how often the leaders of applications with
+// floating IP addresses are checked for changes. It is short, as the
+// address is unreachable until it follows a new leader.
+const DefaultPollInterval = 10 * time.Second
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+	// FloatingIPs returns the floating IP addresses of the alive,
+	// exposed applications in the model, together with their
+	// leaders.
+	FloatingIPs() ([]floatingipupdater.FloatingIP, error)
+
+	// SetFloatingIPUnit records that the given floating IP address
+	// of the named application has been associated with the machine
+	// of the named unit.
+	SetFloatingIPUnit(application, address, unitName string) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade       Facade
+	Assigner     environs.FloatingIPAssigner
+	Clock        clock.Clock
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Assigner == nil {
+		return errors.NotValidf("nil Assigner")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// New returns a worker that associates the floating IP address of
+// each exposed application with the machine of its leader, whenever
+// the address is not already associated with the leader's machine.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &updaterWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type updaterWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *updaterWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *updaterWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *updaterWorker) loop() error {
+	for {
+		if err := w.update(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.PollInterval):
+		}
+	}
+}
+
+// update associates each floating IP address that does not follow its
+// application's leader with the leader's machine. Addresses that cannot
+// be associated are retried on the next update.
+func (w *updaterWorker) update() error {
+	fips, err := w.config.Facade.FloatingIPs()
+	if err != nil {
+		return errors.Annotate(err, "getting floating IPs")
+	}
+	for _, fip := range fips {
+		if fip.Leader == "" || fip.Unit == fip.Leader {
+			continue
+		}
+		if fip.LeaderInstanceId == "" {
+			logger.Debugf("leader %q of %q is not provisioned", fip.Leader, fip.Application)
+			continue
+		}
+		if err := w.config.Assigner.AssignFloatingIP(fip.Address, fip.LeaderInstanceId); err != nil {
+			logger.Errorf("cannot move floating IP %s of %q to %q: %v", fip.Address, fip.Application, fip.Leader, err)
+			continue
+		}
+		if err := w.config.Facade.SetFloatingIPUnit(fip.Application, fip.Address, fip.Leader); err != nil {
+			logger.Errorf("cannot record floating IP %s of %q: %v", fip.Address, fip.Application, err)
+			continue
+		}
+		logger.Infof("moved floating IP %s of %q to %q", fip.Address, fip.Application, fip.Leader)
+	}
+	return nil
+}
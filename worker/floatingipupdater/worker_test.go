@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package floatingipupdater_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apifloatingipupdater "github.com/juju/juju/api/floatingipupdater"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/floatingipupdater"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	clock  *jujutesting.Clock
+	facade *fakeFacade
+	config floatingipupdater.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.clock = jujutesting.NewClock(time.Time{})
+	s.facade = &fakeFacade{
+		stub: s.stub,
+		fips: []apifloatingipupdater.FloatingIP{{
+			Application:      "postgresql",
+			Address:          "203.0.113.10",
+			Unit:             "postgresql/0",
+			Leader:           "postgresql/1",
+			LeaderInstanceId: "i-1",
+		}, {
+			Application:      "mysql",
+			Address:          "203.0.113.11",
+			Unit:             "mysql/0",
+			Leader:           "mysql/0",
+			LeaderInstanceId: "i-2",
+		}, {
+			Application: "redis",
+			Address:     "203.0.113.12",
+		}, {
+			Application: "mongodb",
+			Address:     "203.0.113.13",
+			Leader:      "mongodb/0",
+		}},
+	}
+	s.config = floatingipupdater.Config{
+		Facade:       s.facade,
+		Assigner:     &fakeAssigner{s.stub},
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	for i, test := range []struct {
+		update func(*floatingipupdater.Config)
+		err    string
+	}{{
+		update: func(config *floatingipupdater.Config) { config.Facade = nil },
+		err:    "nil Facade not valid",
+	}, {
+		update: func(config *floatingipupdater.Config) { config.Assigner = nil },
+		err:    "nil Assigner not valid",
+	}, {
+		update: func(config *floatingipupdater.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		update: func(config *floatingipupdater.Config) { config.PollInterval = 0 },
+		err:    "non-positive PollInterval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := floatingipupdater.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestMovesFloatingIPToLeader(c *gc.C) {
+	w, err := floatingipupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPoll(c, 0)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"AssignFloatingIP", []interface{}{"203.0.113.10", instance.Id("i-1")}},
+		{"SetFloatingIPUnit", []interface{}{"postgresql", "203.0.113.10", "postgresql/1"}},
+	})
+
+	s.stub.ResetCalls()
+	s.facade.setFloatingIPs([]apifloatingipupdater.FloatingIP{{
+		Application:      "postgresql",
+		Address:          "203.0.113.10",
+		Unit:             "postgresql/1",
+		Leader:           "postgresql/1",
+		LeaderInstanceId: "i-1",
+	}})
+	s.waitPoll(c, time.Minute)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestRetriesFailedAssignment(c *gc.C) {
+	s.stub.SetErrors(errors.New("throttled"))
+	w, err := floatingipupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPoll(c, 0)
+	s.stub.CheckCallNames(c, "AssignFloatingIP")
+
+	s.stub.ResetCalls()
+	s.waitPoll(c, time.Minute)
+	s.stub.CheckCallNames(c, "AssignFloatingIP", "SetFloatingIPUnit")
+}
+
+func (s *WorkerSuite) TestFloatingIPsError(c *gc.C) {
+	s.facade.setFloatingIPsErr(errors.New("boom"))
+	w, err := floatingipupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting floating IPs: boom")
+}
+
+// waitPoll advances the clock by d, and waits for the worker to
+// finish the next update and wait to poll again.
+func (s *WorkerSuite) waitPoll(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	if d > 0 {
+		err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+type fakeFacade struct {
+	stub *jujutesting.Stub
+
+	mu      sync.Mutex
+	fips    []apifloatingipupdater.FloatingIP
+	fipsErr error
+}
+
+func (f *fakeFacade) setFloatingIPs(fips []apifloatingipupdater.FloatingIP) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fips = fips
+}
+
+func (f *fakeFacade) setFloatingIPsErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fipsErr = err
+}
+
+func (f *fakeFacade) FloatingIPs() ([]apifloatingipupdater.FloatingIP, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fips, f.fipsErr
+}
+
+func (f *fakeFacade) SetFloatingIPUnit(application, address, unitName string) error {
+	f.stub.AddCall("SetFloatingIPUnit", application, address, unitName)
+	return f.stub.NextErr()
+}
+
+type fakeAssigner struct {
+	stub *jujutesting.Stub
+}
+
+func (a *fakeAssigner) AssignFloatingIP(address string, id instance.Id) error {
+	a.stub.AddCall("AssignFloatingIP", address, id)
+	return a.stub.NextErr()
+}
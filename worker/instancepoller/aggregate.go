@@ -14,6 +14,11 @@ import (
 	"github.com/juju/juju/worker/catacomb"
 )
 
+// MaxBatchSize holds the maximum number of instances queried from the
+// provider in a single request. Larger batches are split into several
+// requests, so that the provider can page through its instances.
+var MaxBatchSize = 100
+
 type InstanceGetter interface {
 	Instances(ids []instance.Id) ([]instance.Instance, error)
 }
@@ -121,6 +126,22 @@ func (a *aggregator) loop() error {
 }
 
 func (a *aggregator) doRequests(reqs []instanceInfoReq) error {
+	for len(reqs) > 0 {
+		n := len(reqs)
+		if MaxBatchSize > 0 && n > MaxBatchSize {
+			n = MaxBatchSize
+		}
+		if err := a.doBatch(reqs[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		reqs = reqs[n:]
+	}
+	return nil
+}
+
+// doBatch queries the provider for the instances of the given requests
+// in a single call, and replies to each of them.
+func (a *aggregator) doBatch(reqs []instanceInfoReq) error {
 	ids := make([]instance.Id, len(reqs))
 	for i, req := range reqs {
 		ids[i] = req.instId
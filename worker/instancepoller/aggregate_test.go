@@ -56,6 +56,7 @@ type testInstanceGetter struct {
 	sync.RWMutex
 	// ids is set when the Instances method is called.
 	ids     []instance.Id
+	batches [][]instance.Id
 	results map[instance.Id]instance.Instance
 	err     error
 	counter int32
@@ -63,6 +64,7 @@ type testInstanceGetter struct {
 
 func (tig *testInstanceGetter) Instances(ids []instance.Id) (result []instance.Instance, err error) {
 	tig.ids = ids
+	tig.batches = append(tig.batches, ids)
 	atomic.AddInt32(&tig.counter, 1)
 	results := make([]instance.Instance, len(ids))
 	for i, id := range ids {
@@ -192,6 +194,48 @@ func (s *aggregateSuite) TestMultipleResponseHandling(c *gc.C) {
 	c.Assert(testGetter.counter, gc.DeepEquals, int32(1))
 }
 
+// Test that batches larger than MaxBatchSize are split.
+func (s *aggregateSuite) TestBatchesSplitAtMaxBatchSize(c *gc.C) {
+	s.PatchValue(&MaxBatchSize, 2)
+	testGetter := new(testInstanceGetter)
+	clock := jujutesting.NewClock(time.Now())
+	delay := time.Minute
+	cfg := aggregatorConfig{
+		Clock:   clock,
+		Delay:   delay,
+		Environ: testGetter,
+	}
+	ids := []instance.Id{"foo", "foo2", "foo3"}
+	for _, id := range ids {
+		testGetter.newTestInstance(id, "ok-"+string(id), []string{"192.168.1.1"})
+	}
+
+	aggregator, err := newAggregator(cfg)
+	c.Check(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, aggregator)
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id instance.Id) {
+			defer wg.Done()
+			info, err := aggregator.instanceInfo(id)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(info.status.Message, gc.Equals, "ok-"+string(id))
+		}(id)
+	}
+	waitAlarms(c, clock, 3)
+	clock.Advance(delay)
+	wg.Wait()
+	workertest.CleanKill(c, aggregator)
+
+	c.Assert(testGetter.counter, gc.Equals, int32(2))
+	c.Assert(testGetter.batches, gc.HasLen, 2)
+	c.Assert(testGetter.batches[0], gc.HasLen, 2)
+	c.Assert(testGetter.batches[1], gc.HasLen, 1)
+	c.Assert(append(testGetter.batches[0], testGetter.batches[1]...), jc.SameContents, ids)
+}
+
 // Test that advancing delay-time.Nanosecond and then killing causes all
 // pending reqs to fail.
 func (s *aggregateSuite) TestKillingWorkerKillsPendinReqs(c *gc.C) {
@@ -193,6 +193,95 @@ func (s *machineSuite) TestLongPollIntervalWhenHasAllInstanceInfo(c *gc.C) {
 	clock.CheckCall(c, 0, "After", LongPoll)
 }
 
+func (s *machineSuite) TestLongPollBackoffWhenStable(c *gc.C) {
+	pollDurations := []time.Duration{
+		15 * time.Minute, // LongPoll
+		30 * time.Minute,
+		60 * time.Minute,
+		120 * time.Minute, // limit is 2 hours (StablePoll)
+		120 * time.Minute,
+	}
+
+	clock := newTestClock()
+	testRunMachine(c, testAddrs, "i1234", "running", status.Started, clock, func() {
+		for _, d := range pollDurations {
+			c.Assert(clock.WaitAdvance(d, 0, 1), jc.ErrorIsNil)
+		}
+	})
+	for i, d := range pollDurations {
+		clock.CheckCall(c, i, "After", d)
+	}
+}
+
+func (s *machineSuite) TestLongPollResetWhenInstanceInfoChanges(c *gc.C) {
+	var mu sync.Mutex
+	instStatus := "running"
+	context := &testMachineContext{
+		getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return instanceInfo{testAddrs, instance.InstanceStatus{Status: status.Unknown, Message: instStatus}}, nil
+		},
+		dyingc: make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		refresh:    func() error { return nil },
+		addresses:  testAddrs,
+		life:       params.Alive,
+		status:     status.Started,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, died, clock)
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+	// Wait for the second poll before changing the instance status.
+	c.Assert(clock.WaitAdvance(0, 0, 1), jc.ErrorIsNil)
+	mu.Lock()
+	instStatus = "rebooted"
+	mu.Unlock()
+	c.Assert(clock.WaitAdvance(2*LongPoll, 0, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(0, 0, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	clock.CheckCall(c, 0, "After", LongPoll)
+	clock.CheckCall(c, 1, "After", 2*LongPoll)
+	clock.CheckCall(c, 2, "After", LongPoll)
+}
+
+func (s *machineSuite) TestShortPollWhenTransitional(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+			return instanceInfo{testAddrs, instance.InstanceStatus{Status: status.Allocating}}, nil
+		},
+		dyingc: make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		refresh:    func() error { return nil },
+		addresses:  testAddrs,
+		life:       params.Alive,
+		status:     status.Started,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, died, clock)
+	for i := 0; i < 3; i++ {
+		c.Assert(clock.WaitAdvance(ShortPoll, 0, 1), jc.ErrorIsNil)
+	}
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	for i := 0; i < 3; i++ {
+		clock.CheckCall(c, i, "After", ShortPoll)
+	}
+}
+
 func testRunMachine(
 	c *gc.C,
 	addrs []network.Address,
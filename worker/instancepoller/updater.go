@@ -20,17 +20,23 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.instancepoller")
 
-// ShortPoll and LongPoll hold the polling intervals for the instance
-// updater. When a machine has no address or is not started, it will be
-// polled at ShortPoll intervals until it does, exponentially backing off
-// with an exponent of ShortPollBackoff until a maximum(ish) of LongPoll.
+// ShortPoll, LongPoll and StablePoll hold the polling intervals for the
+// instance updater. While a machine's instance is in a transitional
+// state, such as allocating, it is polled at ShortPoll intervals. When a
+// machine has no address or is not started, it will be polled at
+// ShortPoll intervals until it does, exponentially backing off with an
+// exponent of ShortPollBackoff until a maximum(ish) of LongPoll.
 //
 // When a machine has an address and is started LongPoll will be used to
-// check that the instance address or status has not changed.
+// check that the instance address or status has not changed. For as long
+// as they do not change, the interval backs off exponentially with an
+// exponent of LongPollBackoff until a maximum(ish) of StablePoll.
 var (
 	ShortPoll        = 1 * time.Second
 	ShortPollBackoff = 2.0
 	LongPoll         = 15 * time.Minute
+	LongPollBackoff  = 2.0
+	StablePoll       = 2 * time.Hour
 )
 
 type machine interface {
@@ -184,8 +190,13 @@ func runMachine(context machineContext, m machine, changed <-chan struct{}, died
 func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{}, clock clock.Clock) error {
 	// Use a short poll interval when initially waiting for
 	// a machine's address and machine agent to start, and a long one when it already
-	// has an address and the machine agent is started.
+	// has an address and the machine agent is started, which grows for as long as
+	// the instance's addresses and status remain the same.
 	pollInterval := ShortPoll
+	var (
+		stable   bool
+		lastInfo instanceInfo
+	)
 	pollInstance := func() error {
 		instInfo, err := pollInstanceInfo(context, m)
 		if err != nil {
@@ -202,19 +213,38 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 			}
 		}
 
+		wasStable := stable
+		defer func() { lastInfo = instInfo }()
+
 		// the extra condition below (checking allocating/pending) is here to improve user experience
 		// without it the instance status will say "pending" for +10 minutes after the agent comes up to "started"
-		if instInfo.status.Status != status.Allocating && instInfo.status.Status != status.Pending {
-			if len(instInfo.addresses) > 0 && machineStatus == status.Started {
-				// We've got at least one address and a status and instance is started, so poll infrequently.
+		if isTransitional(instInfo.status.Status) {
+			stable = false
+			pollInterval = ShortPoll
+			return nil
+		}
+		stable = len(instInfo.addresses) > 0 && machineStatus == status.Started
+		switch {
+		case stable && wasStable && instanceInfoEqual(lastInfo, instInfo):
+			// Nothing has changed since the last poll, so poll
+			// increasingly rarely until something does.
+			pollInterval = time.Duration(float64(pollInterval) * LongPollBackoff)
+			if pollInterval > StablePoll {
+				pollInterval = StablePoll
+			}
+		case stable:
+			// We've got at least one address and a status and instance is started, so poll infrequently.
+			pollInterval = LongPoll
+		case wasStable:
+			// The machine has lost its addresses or stopped, so
+			// start polling frequently again.
+			pollInterval = ShortPoll
+		case pollInterval < LongPoll:
+			// We have no addresses or not started - poll increasingly rarely
+			// until we do.
+			pollInterval = time.Duration(float64(pollInterval) * ShortPollBackoff)
+			if pollInterval > LongPoll {
 				pollInterval = LongPoll
-			} else if pollInterval < LongPoll {
-				// We have no addresses or not started - poll increasingly rarely
-				// until we do.
-				pollInterval = time.Duration(float64(pollInterval) * ShortPollBackoff)
-				if pollInterval > LongPoll {
-					pollInterval = LongPoll
-				}
 			}
 		}
 		return nil
@@ -303,6 +333,22 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 	return instInfo, nil
 }
 
+// isTransitional reports whether an instance with the given status is
+// expected to change shortly, and so should be polled frequently.
+func isTransitional(instStatus status.Status) bool {
+	switch instStatus {
+	case status.Allocating, status.Pending, status.Rebooting:
+		return true
+	}
+	return false
+}
+
+// instanceInfoEqual reports whether the two instance infos have the
+// same addresses and status.
+func instanceInfoEqual(info0, info1 instanceInfo) bool {
+	return info0.status == info1.status && addressesEqual(info0.addresses, info1.addresses)
+}
+
 // addressesEqual compares the addresses of the machine and the instance information.
 func addressesEqual(a0, a1 []network.Address) bool {
 	if len(a0) != len(a1) {
@@ -54,6 +54,7 @@ func (s *workerSuite) TestWorker(c *gc.C) {
 	// TODO(redir): per fwereade these should be in the worker config.
 	s.PatchValue(&ShortPoll, 10*time.Millisecond)
 	s.PatchValue(&LongPoll, 10*time.Millisecond)
+	s.PatchValue(&StablePoll, 10*time.Millisecond)
 
 	machines, insts := s.setupScenario(c)
 	s.State.StartSync()
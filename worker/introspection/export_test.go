@@ -0,0 +1,6 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+var PeerUID = &peerUID
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/faultinject"
+)
+
+// faultsHandler reports the faults injected into the agent, and sets
+// and clears them. As juju-introspect can only make GET requests, the
+// faults are changed with query parameters: "point" names the point
+// whose fault is changed, which is set from "error", "delay" and
+// "count", or cleared if "clear" is "true". Only root may change the
+// faults.
+type faultsHandler struct {
+	registry *faultinject.Registry
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h faultsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.registry == nil || !h.registry.Enabled() {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "fault injection not enabled")
+		return
+	}
+	if err := h.update(r); err != nil {
+		status := http.StatusBadRequest
+		if errors.IsUnauthorized(err) {
+			status = http.StatusForbidden
+		}
+		w.WriteHeader(status)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+	bytes, err := yaml.Marshal(h.registry.Faults())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	fmt.Fprint(w, "Fault Injection Report\n\n")
+	w.Write(bytes)
+}
+
+func (h faultsHandler) update(r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errors.Trace(err)
+	}
+	point := faultinject.Point(r.Form.Get("point"))
+	if point == "" {
+		return nil
+	}
+	if uid, ok := peerUID(r); !ok || uid != 0 {
+		return errors.Unauthorizedf("changing faults requires root")
+	}
+	if r.Form.Get("clear") == "true" {
+		h.registry.Clear(point)
+		return nil
+	}
+	fault := faultinject.Fault{
+		Error: r.Form.Get("error"),
+	}
+	if delay := r.Form.Get("delay"); delay != "" {
+		d, err := time.ParseDuration(delay)
+		if err != nil {
+			return errors.NotValidf("delay %q", delay)
+		}
+		fault.Delay = d
+	}
+	if count := r.Form.Get("count"); count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return errors.NotValidf("count %q", count)
+		}
+		fault.Count = n
+	}
+	return errors.Trace(h.registry.Set(point, fault))
+}
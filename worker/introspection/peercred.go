@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"net"
+	"net/http"
+)
+
+// peerCredListener records the uid of the peer of each connection it
+// accepts, so that handlers can tell who is making a request.
+type peerCredListener struct {
+	*net.UnixListener
+}
+
+// Accept is part of the net.Listener interface.
+func (l peerCredListener) Accept() (net.Conn, error) {
+	conn, err := l.AcceptUnix()
+	if err != nil {
+		return nil, err
+	}
+	uid, err := connPeerUID(conn)
+	if err != nil {
+		logger.Debugf("cannot identify introspection client: %v", err)
+		return conn, nil
+	}
+	return peerCredConn{conn, peerCredAddr{conn.LocalAddr(), uid}}, nil
+}
+
+// peerCredConn is a connection whose peer has been identified. The
+// http server makes the connection's local address available to
+// handlers, so the peer's uid is carried in it.
+type peerCredConn struct {
+	*net.UnixConn
+	addr peerCredAddr
+}
+
+// LocalAddr is part of the net.Conn interface.
+func (c peerCredConn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+// peerCredAddr is the local address of a peerCredConn.
+type peerCredAddr struct {
+	net.Addr
+	uid uint32
+}
+
+// peerUID returns the uid of the process that made the request, and
+// whether it is known.
+var peerUID = func(r *http.Request) (uint32, bool) {
+	addr, ok := r.Context().Value(http.LocalAddrContextKey).(peerCredAddr)
+	if !ok {
+		return 0, false
+	}
+	return addr.uid, true
+}
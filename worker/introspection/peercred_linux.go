@@ -0,0 +1,32 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package introspection
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// connPeerUID returns the uid of the process at the other end of the
+// connection, as reported by SO_PEERCRED.
+func connPeerUID(conn *net.UnixConn) (uint32, error) {
+	f, err := conn.File()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+	fd := int(f.Fd())
+	// File puts the shared socket into blocking mode, which the
+	// http server's reads and writes do not expect.
+	defer syscall.SetNonblock(fd, true)
+	cred, err := syscall.GetsockoptUcred(fd, syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot read peer credentials")
+	}
+	return cred.Uid, nil
+}
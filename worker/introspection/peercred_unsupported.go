@@ -0,0 +1,18 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package introspection
+
+import (
+	"net"
+	"runtime"
+
+	"github.com/juju/errors"
+)
+
+// connPeerUID is not supported on this platform.
+func connPeerUID(conn *net.UnixConn) (uint32, error) {
+	return 0, errors.NotSupportedf("peer credentials on %s", runtime.GOOS)
+}
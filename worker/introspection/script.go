@@ -99,6 +99,14 @@ juju-statetracker-report () {
   jujuMachineOrUnit debug/pprof/juju/state/tracker?debug=1 $@
 }
 
+juju-faults () {
+  # Optional first arg is the query, eg "?point=hook-dispatch&delay=30s".
+  # Changing faults must be done as root.
+  local query=$1
+  shift
+  jujuMachineOrUnit "faults/$query" $@
+}
+
 export -f jujuAgentCall
 export -f jujuMachineAgentName
 export -f jujuMachineOrUnit
@@ -109,4 +117,5 @@ export -f juju-engine-report
 export -f juju-statepool-report
 export -f juju-statetracker-report
 export -f juju-pubsub-report
+export -f juju-faults
 `
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/tomb.v1"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/faultinject"
 	"github.com/juju/juju/worker/introspection/pprof"
 )
 
@@ -42,6 +43,10 @@ type Config struct {
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
 	PrometheusGatherer prometheus.Gatherer
+
+	// Faults, if not nil, holds the faults injected into the
+	// agent, which can then be changed through the socket.
+	Faults *faultinject.Registry
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -63,6 +68,7 @@ type socketListener struct {
 	statePool          IntrospectionReporter
 	pubsub             IntrospectionReporter
 	prometheusGatherer prometheus.Gatherer
+	faults             *faultinject.Registry
 	done               chan struct{}
 }
 
@@ -94,6 +100,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		statePool:          config.StatePool,
 		pubsub:             config.PubSub,
 		prometheusGatherer: config.PrometheusGatherer,
+		faults:             config.Faults,
 		done:               make(chan struct{}),
 	}
 	go w.serve()
@@ -109,13 +116,14 @@ func (w *socketListener) serve() {
 			StatePool:          w.statePool,
 			PubSub:             w.pubsub,
 			PrometheusGatherer: w.prometheusGatherer,
+			Faults:             w.faults,
 		}, mux.Handle)
 
 	srv := http.Server{Handler: mux}
 	logger.Debugf("stats worker now serving")
 	defer logger.Debugf("stats worker serving finished")
 	defer close(w.done)
-	srv.Serve(w.listener)
+	srv.Serve(peerCredListener{w.listener})
 }
 
 func (w *socketListener) run() {
@@ -145,6 +153,7 @@ type ReportSources struct {
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
 	PrometheusGatherer prometheus.Gatherer
+	Faults             *faultinject.Registry
 }
 
 // AddHandlers calls the given function with http.Handlers
@@ -168,6 +177,7 @@ func RegisterHTTPHandlers(
 		name:     "PubSub Report",
 		reporter: sources.PubSub,
 	})
+	handle("/faults/", faultsHandler{sources.Faults})
 	handle("/metrics", promhttp.HandlerFor(sources.PrometheusGatherer, promhttp.HandlerOpts{}))
 }
 
@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"regexp"
 	"runtime"
+	"time"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -20,6 +22,7 @@ import (
 	worker "gopkg.in/juju/worker.v1"
 
 	// Bring in the state package for the tracker profile.
+	"github.com/juju/juju/faultinject"
 	_ "github.com/juju/juju/state"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/workertest"
@@ -57,6 +60,7 @@ type introspectionSuite struct {
 	worker   worker.Worker
 	reporter introspection.DepEngineReporter
 	gatherer prometheus.Gatherer
+	faults   *faultinject.Registry
 }
 
 var _ = gc.Suite(&introspectionSuite{})
@@ -69,6 +73,10 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	s.reporter = nil
 	s.worker = nil
 	s.gatherer = newPrometheusGatherer()
+	s.faults = nil
+	s.PatchValue(introspection.PeerUID, func(*http.Request) (uint32, bool) {
+		return 0, true
+	})
 	s.startWorker(c)
 }
 
@@ -78,6 +86,7 @@ func (s *introspectionSuite) startWorker(c *gc.C) {
 		SocketName:         s.name,
 		DepEngine:          s.reporter,
 		PrometheusGatherer: s.gatherer,
+		Faults:             s.faults,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.worker = w
@@ -160,6 +169,108 @@ func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	matches(c, buf, "tau 6.283185")
 }
 
+func (s *introspectionSuite) TestFaultsNotEnabled(c *gc.C) {
+	buf := s.call(c, "/faults/")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "fault injection not enabled")
+}
+
+func (s *introspectionSuite) TestFaultsRegistryNotEnabled(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.faults = faultinject.NewRegistry(testing.NewClock(time.Time{}))
+	s.startWorker(c)
+
+	buf := s.call(c, "/faults/?point=api-connection&error=boom")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "fault injection not enabled")
+	c.Assert(s.faults.Faults(), gc.HasLen, 0)
+}
+
+func (s *introspectionSuite) startFaultsWorker(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.faults = faultinject.NewRegistry(testing.NewClock(time.Time{}))
+	s.faults.SetEnabled(true)
+	s.startWorker(c)
+}
+
+func (s *introspectionSuite) TestFaults(c *gc.C) {
+	s.startFaultsWorker(c)
+
+	buf := s.call(c, "/faults/?point=api-connection&error=boom&count=2")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "^api-connection:")
+	matches(c, buf, "^  error: boom")
+	matches(c, buf, "^  count: 2")
+	c.Assert(s.faults.Faults(), jc.DeepEquals, map[faultinject.Point]faultinject.Fault{
+		faultinject.APIConnection: {Error: "boom", Count: 2},
+	})
+
+	buf = s.call(c, "/faults/?point=api-connection&clear=true")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "^{}")
+	c.Assert(s.faults.Faults(), gc.HasLen, 0)
+}
+
+func (s *introspectionSuite) TestFaultsInvalid(c *gc.C) {
+	s.startFaultsWorker(c)
+
+	buf := s.call(c, "/faults/?point=hook-dispatch&delay=soon")
+	matches(c, buf, "400 Bad Request")
+	matches(c, buf, `error: delay "soon" not valid`)
+	buf = s.call(c, "/faults/?point=bogus&error=boom")
+	matches(c, buf, "400 Bad Request")
+	matches(c, buf, `error: fault injection point "bogus" not valid`)
+	c.Assert(s.faults.Faults(), gc.HasLen, 0)
+}
+
+func (s *introspectionSuite) TestFaultsRequireRoot(c *gc.C) {
+	s.PatchValue(introspection.PeerUID, func(*http.Request) (uint32, bool) {
+		return 1000, true
+	})
+	s.startFaultsWorker(c)
+
+	buf := s.call(c, "/faults/?point=api-connection&error=boom")
+	matches(c, buf, "403 Forbidden")
+	matches(c, buf, "error: changing faults requires root")
+	c.Assert(s.faults.Faults(), gc.HasLen, 0)
+
+	buf = s.call(c, "/faults/")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "^{}")
+}
+
+func (s *introspectionSuite) TestFaultsUnknownPeer(c *gc.C) {
+	s.PatchValue(introspection.PeerUID, func(*http.Request) (uint32, bool) {
+		return 0, false
+	})
+	s.startFaultsWorker(c)
+
+	buf := s.call(c, "/faults/?point=api-connection&clear=true")
+	matches(c, buf, "403 Forbidden")
+	matches(c, buf, "error: changing faults requires root")
+}
+
+// realPeerUID is the peer uid lookup, saved before the suite
+// patches it.
+var realPeerUID = *introspection.PeerUID
+
+func (s *introspectionSuite) TestPeerUID(c *gc.C) {
+	// The tests are not usually run as root, so record the uid
+	// found through the socket rather than making a change with it.
+	uids := make(chan uint32, 1)
+	s.PatchValue(introspection.PeerUID, func(r *http.Request) (uint32, bool) {
+		uid, ok := realPeerUID(r)
+		c.Check(ok, jc.IsTrue)
+		uids <- uid
+		return 0, false
+	})
+	s.startFaultsWorker(c)
+
+	buf := s.call(c, "/faults/?point=api-connection&clear=true")
+	matches(c, buf, "403 Forbidden")
+	c.Assert(<-uids, gc.Equals, uint32(os.Getuid()))
+}
+
 // matches fails if regex is not found in the contents of b.
 // b is expected to be the response from the pprof http server, and will
 // contain some HTTP preamble that should be ignored.
@@ -369,8 +369,8 @@ func (w *uploadWrapper) UploadTools(r io.ReadSeeker, vers version.Binary, additi
 }
 
 // UploadCharm prepends the model UUID to the args passed to the migration client.
-func (w *uploadWrapper) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
-	return w.client.UploadCharm(w.modelUUID, curl, content)
+func (w *uploadWrapper) UploadCharm(curl *charm.URL, gitSource *coremigration.CharmGitSource, content io.ReadSeeker) (*charm.URL, error) {
+	return w.client.UploadCharm(w.modelUUID, curl, gitSource, content)
 }
 
 // UploadResource prepends the model UUID to the args passed to the migration client.
@@ -411,6 +411,7 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	wrapper := &uploadWrapper{targetClient, modelUUID}
 	err = w.config.UploadBinaries(migration.UploadBinariesConfig{
 		Charms:          serialized.Charms,
+		CharmGitSources: serialized.CharmGitSources,
 		CharmDownloader: w.config.CharmDownloader,
 		CharmUploader:   wrapper,
 
@@ -245,6 +245,9 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 			importCall,
 			{"UploadBinaries", []interface{}{
 				[]string{"charm0", "charm1"},
+				map[string]coremigration.CharmGitSource{
+					"charm1": {Repository: "git+ssh://git@example.com/charm1.git", Revision: "0123456789abcdef"},
+				},
 				fakeCharmDownloader,
 				map[version.Binary]string{
 					version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
@@ -1184,6 +1187,9 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 	return coremigration.SerializedModel{
 		Bytes:  fakeModelBytes,
 		Charms: []string{"charm0", "charm1"},
+		CharmGitSources: map[string]coremigration.CharmGitSource{
+			"charm1": {Repository: "git+ssh://git@example.com/charm1.git", Revision: "0123456789abcdef"},
+		},
 		Tools: map[version.Binary]string{
 			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
 		},
@@ -1318,6 +1324,7 @@ func makeStubUploadBinaries(stub *jujutesting.Stub) func(migration.UploadBinarie
 		stub.AddCall(
 			"UploadBinaries",
 			config.Charms,
+			config.CharmGitSources,
 			config.CharmDownloader,
 			config.Tools,
 			config.ToolsDownloader,
@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// StatePool provides access to the controller's models.
+type StatePool interface {
+	// ControllerConfig returns the controller's config.
+	ControllerConfig() (controller.Config, error)
+
+	// ExpiringModelUUIDs returns the UUIDs of the alive models that
+	// have an expiry.
+	ExpiringModelUUIDs() ([]string, error)
+
+	// GetModel returns the model with the given UUID, and a function
+	// to release it once it is no longer needed.
+	GetModel(modelUUID string) (Model, state.StatePoolReleaser, error)
+}
+
+// Model represents a model that may expire.
+type Model interface {
+	Name() string
+	UUID() string
+	Owner() names.UserTag
+	Expiry() (state.ModelExpiry, bool)
+	SetExpiryWarned() error
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+	Destroy(state.DestroyModelParams) error
+}
+
+// NewStatePool takes a *state.StatePool, and returns a StatePool
+// value backed by it.
+func NewStatePool(pool *state.StatePool) StatePool {
+	return statePoolShim{pool}
+}
+
+type statePoolShim struct {
+	pool *state.StatePool
+}
+
+func (p statePoolShim) ControllerConfig() (controller.Config, error) {
+	return p.pool.SystemState().ControllerConfig()
+}
+
+func (p statePoolShim) ExpiringModelUUIDs() ([]string, error) {
+	return p.pool.SystemState().ExpiringModelUUIDs()
+}
+
+func (p statePoolShim) GetModel(modelUUID string) (Model, state.StatePoolReleaser, error) {
+	model, releaser, err := p.pool.GetModel(modelUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return model, releaser, nil
+}
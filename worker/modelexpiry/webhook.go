@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Notice describes a model that is about to expire, or that has
+// expired.
+type Notice struct {
+	// ModelUUID is the UUID of the model.
+	ModelUUID string `json:"model-uuid"`
+
+	// ModelName is the name of the model.
+	ModelName string `json:"model-name"`
+
+	// Owner is the name of the user that owns the model.
+	Owner string `json:"owner"`
+
+	// Expires is when the model expires.
+	Expires time.Time `json:"expires"`
+
+	// Expired is true once the model has been destroyed.
+	Expired bool `json:"expired"`
+}
+
+// webhookTimeout is how long a webhook request may take.
+const webhookTimeout = 30 * time.Second
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// PostNotice POSTs the notice, encoded as JSON, to the given URL. Any
+// response status other than 2xx is treated as a failure.
+func PostNotice(url string, notice Notice) error {
+	data, err := json.Marshal(notice)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := webhookClient.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		msg := string(bytes.TrimSpace(body))
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		if msg == "" {
+			return errors.Errorf("webhook returned %s", resp.Status)
+		}
+		return errors.Errorf("webhook returned %s: %s", resp.Status, msg)
+	}
+	return nil
+}
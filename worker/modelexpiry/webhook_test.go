@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/modelexpiry"
+)
+
+type WebhookSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&WebhookSuite{})
+
+func (s *WebhookSuite) TestPostNotice(c *gc.C) {
+	var received modelexpiry.Notice
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		c.Check(json.NewDecoder(req.Body).Decode(&received), jc.ErrorIsNil)
+	}))
+	defer server.Close()
+
+	notice := modelexpiry.Notice{
+		ModelUUID: "deadbeef",
+		ModelName: "temporary",
+		Owner:     "bob",
+		Expires:   time.Date(2017, 9, 4, 12, 0, 0, 0, time.UTC),
+	}
+	err := modelexpiry.PostNotice(server.URL, notice)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, notice)
+}
+
+func (s *WebhookSuite) TestPostNoticeFailure(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "no such team", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := modelexpiry.PostNotice(server.URL, modelexpiry.Notice{})
+	c.Assert(err, gc.ErrorMatches, "webhook returned 400 Bad Request: no such team")
+}
@@ -0,0 +1,239 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelexpiry provides a worker that destroys temporary models
+// once they expire, warning their owners shortly beforehand.
+package modelexpiry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.modelexpiry")
+
+var isHasPersistentStorageError = state.IsHasPersistentStorageError
+
+const (
+	// DefaultCheckInterval is how often models are checked for
+	// expiry by default.
+	DefaultCheckInterval = time.Minute
+
+	// DefaultWarningPeriod is how long before a model expires its
+	// owner is warned by default.
+	DefaultWarningPeriod = 24 * time.Hour
+)
+
+// Config holds the configuration and dependencies for a Worker.
+type Config struct {
+	// StatePool provides access to the controller's models.
+	StatePool StatePool
+
+	// Clock is used to time checks and compare expiry deadlines.
+	Clock clock.Clock
+
+	// CheckInterval is how often the models are checked for expiry.
+	CheckInterval time.Duration
+
+	// WarningPeriod is how long before a model expires its owner is
+	// warned.
+	WarningPeriod time.Duration
+
+	// Notify is called to send a notice to the controller's model
+	// expiry webhook, if it has one.
+	Notify func(url string, notice Notice) error
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.StatePool == nil {
+		return errors.NotValidf("nil StatePool")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	if config.WarningPeriod < 0 {
+		return errors.NotValidf("negative WarningPeriod")
+	}
+	if config.Notify == nil {
+		return errors.NotValidf("nil Notify")
+	}
+	return nil
+}
+
+// Worker periodically checks the expiring models, warning the owners
+// of those about to expire, and destroying those that have.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		if err := w.check(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.CheckInterval):
+		}
+	}
+}
+
+// check warns the owners of the models about to expire, and destroys
+// the models that have expired.
+func (w *Worker) check() error {
+	uuids, err := w.config.StatePool.ExpiringModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(uuids) == 0 {
+		return nil
+	}
+	controllerConfig, err := w.config.StatePool.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot load controller configuration")
+	}
+	webhook := controllerConfig.ModelExpiryWebhook()
+	now := w.config.Clock.Now()
+	for _, uuid := range uuids {
+		err := w.checkModel(uuid, now, webhook)
+		if errors.IsNotFound(err) {
+			// The model has been removed since it was listed.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "checking expiry of model %s", uuid)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) checkModel(uuid string, now time.Time, webhook string) error {
+	model, release, err := w.config.StatePool.GetModel(uuid)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+
+	expiry, ok := model.Expiry()
+	if !ok {
+		return nil
+	}
+	if now.Before(expiry.Deadline) {
+		if expiry.Warned || expiry.Deadline.Sub(now) > w.config.WarningPeriod {
+			return nil
+		}
+		return errors.Trace(w.warn(model, expiry, now, webhook))
+	}
+	return errors.Trace(w.expire(model, expiry, now, webhook))
+}
+
+// warn tells the model's owner, through the model's status and the
+// webhook, that the model is about to expire.
+func (w *Worker) warn(model Model, expiry state.ModelExpiry, now time.Time, webhook string) error {
+	logger.Infof("model %q expires at %s", model.Name(), expiry.Deadline)
+	message := fmt.Sprintf("model expires at %s", expiry.Deadline.UTC().Format(time.RFC3339))
+	if err := w.setStatusMessage(model, message, now); err != nil {
+		return errors.Trace(err)
+	}
+	if !w.notify(webhook, model, expiry, false) {
+		// Warn again at the next check.
+		return nil
+	}
+	return errors.Trace(model.SetExpiryWarned())
+}
+
+// expire destroys the model. A model with persistent storage is only
+// destroyed if its expiry says what to do with the storage; otherwise
+// its status records that it was not destroyed.
+func (w *Worker) expire(model Model, expiry state.ModelExpiry, now time.Time, webhook string) error {
+	err := model.Destroy(state.DestroyModelParams{
+		DestroyStorage: expiry.DestroyStorage,
+	})
+	if isHasPersistentStorageError(err) {
+		message := "model expired, but has persistent storage and was not destroyed"
+		return errors.Trace(w.setStatusMessage(model, message, now))
+	} else if err != nil {
+		return errors.Annotate(err, "destroying expired model")
+	}
+	logger.Infof("destroyed expired model %q", model.Name())
+	w.notify(webhook, model, expiry, true)
+	return nil
+}
+
+// setStatusMessage sets the message of the model's status, if it has
+// changed, leaving the status value alone.
+func (w *Worker) setStatusMessage(model Model, message string, now time.Time) error {
+	current, err := model.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Message == message {
+		return nil
+	}
+	return errors.Trace(model.SetStatus(status.StatusInfo{
+		Status:  current.Status,
+		Message: message,
+		Data:    current.Data,
+		Since:   &now,
+	}))
+}
+
+// notify sends a notice about the model to the webhook, if there is
+// one, and reports whether the notice was sent.
+func (w *Worker) notify(webhook string, model Model, expiry state.ModelExpiry, expired bool) bool {
+	if webhook == "" {
+		return true
+	}
+	notice := Notice{
+		ModelUUID: model.UUID(),
+		ModelName: model.Name(),
+		Owner:     model.Owner().Id(),
+		Expires:   expiry.Deadline,
+		Expired:   expired,
+	}
+	if err := w.config.Notify(webhook, notice); err != nil {
+		logger.Warningf("cannot send expiry notice for model %q: %v", model.Name(), err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,285 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/modelexpiry"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	clock   *jujutesting.Clock
+	pool    *fakeStatePool
+	model   *fakeModel
+	notices []modelexpiry.Notice
+	config  modelexpiry.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var deadline = time.Date(2017, 9, 4, 12, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(deadline.Add(-48 * time.Hour))
+	s.model = &fakeModel{
+		expiry: state.ModelExpiry{Deadline: deadline},
+		status: status.StatusInfo{Status: status.Available},
+	}
+	s.pool = &fakeStatePool{
+		config: controller.Config{
+			controller.ModelExpiryWebhook: "https://ops.example.com/expiry",
+		},
+		models: map[string]*fakeModel{"deadbeef": s.model},
+	}
+	s.notices = nil
+	s.config = modelexpiry.Config{
+		StatePool:     s.pool,
+		Clock:         s.clock,
+		CheckInterval: time.Minute,
+		WarningPeriod: 24 * time.Hour,
+		Notify: func(url string, notice modelexpiry.Notice) error {
+			c.Check(url, gc.Equals, "https://ops.example.com/expiry")
+			s.notices = append(s.notices, notice)
+			return s.pool.NextErr()
+		},
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	config := s.config
+	config.StatePool = nil
+	_, err := modelexpiry.New(config)
+	c.Check(err, gc.ErrorMatches, "nil StatePool not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	config = s.config
+	config.Clock = nil
+	_, err = modelexpiry.New(config)
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config
+	config.CheckInterval = 0
+	_, err = modelexpiry.New(config)
+	c.Check(err, gc.ErrorMatches, "non-positive CheckInterval not valid")
+
+	config = s.config
+	config.Notify = nil
+	_, err = modelexpiry.New(config)
+	c.Check(err, gc.ErrorMatches, "nil Notify not valid")
+}
+
+// advance waits for the worker to finish a check, and then advances
+// the clock by the given duration, which triggers another check if it
+// is at least the check interval.
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *modelexpiry.Worker {
+	w, err := modelexpiry.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestNotYetExpiring(c *gc.C) {
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	c.Check(s.notices, gc.HasLen, 0)
+	s.model.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestWarns(c *gc.C) {
+	s.clock.Advance(25 * time.Hour)
+	w := s.startWorker(c)
+	s.advance(c, time.Minute)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.model.CheckCallNames(c, "Status", "SetStatus", "SetExpiryWarned")
+	c.Check(s.model.status.Message, gc.Equals, "model expires at 2017-09-04T12:00:00Z")
+	c.Check(s.model.status.Status, gc.Equals, status.Available)
+	c.Check(s.notices, jc.DeepEquals, []modelexpiry.Notice{{
+		ModelUUID: "deadbeef",
+		ModelName: "temporary",
+		Owner:     "bob",
+		Expires:   deadline,
+	}})
+}
+
+func (s *WorkerSuite) TestWarnsAgainIfNotifyFails(c *gc.C) {
+	s.pool.SetErrors(errors.New("boom"))
+	s.clock.Advance(25 * time.Hour)
+	w := s.startWorker(c)
+	s.advance(c, time.Minute)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.model.CheckCallNames(c, "Status", "SetStatus", "Status", "SetExpiryWarned")
+	c.Check(s.notices, gc.HasLen, 2)
+}
+
+func (s *WorkerSuite) TestWarnsWithoutWebhook(c *gc.C) {
+	delete(s.pool.config, controller.ModelExpiryWebhook)
+	s.clock.Advance(25 * time.Hour)
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.model.CheckCallNames(c, "Status", "SetStatus", "SetExpiryWarned")
+	c.Check(s.notices, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestDestroysExpired(c *gc.C) {
+	destroyStorage := true
+	s.model.expiry.Warned = true
+	s.model.expiry.DestroyStorage = &destroyStorage
+	s.clock.Advance(48 * time.Hour)
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.model.CheckCalls(c, []jujutesting.StubCall{{
+		FuncName: "Destroy",
+		Args:     []interface{}{state.DestroyModelParams{DestroyStorage: &destroyStorage}},
+	}})
+	c.Check(s.notices, jc.DeepEquals, []modelexpiry.Notice{{
+		ModelUUID: "deadbeef",
+		ModelName: "temporary",
+		Owner:     "bob",
+		Expires:   deadline,
+		Expired:   true,
+	}})
+}
+
+func (s *WorkerSuite) TestExpiredWithPersistentStorage(c *gc.C) {
+	errPersistentStorage := errors.New("model has persistent storage")
+	s.PatchValue(modelexpiry.IsHasPersistentStorageError, func(err error) bool {
+		return err == errPersistentStorage
+	})
+	s.model.SetErrors(errPersistentStorage, errPersistentStorage)
+	s.model.expiry.Warned = true
+	s.clock.Advance(48 * time.Hour)
+	w := s.startWorker(c)
+	s.advance(c, time.Minute)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.model.CheckCallNames(c, "Destroy", "Status", "SetStatus", "Destroy", "Status")
+	c.Check(s.model.status.Message, gc.Equals, "model expired, but has persistent storage and was not destroyed")
+	c.Check(s.notices, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestDestroyError(c *gc.C) {
+	s.model.SetErrors(errors.New("boom"))
+	s.clock.Advance(48 * time.Hour)
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "checking expiry of model deadbeef: destroying expired model: boom")
+}
+
+func (s *WorkerSuite) TestModelRemoved(c *gc.C) {
+	s.pool.uuids = []string{"cafebabe", "deadbeef"}
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+}
+
+type fakeStatePool struct {
+	jujutesting.Stub
+	config controller.Config
+	uuids  []string
+	models map[string]*fakeModel
+}
+
+func (p *fakeStatePool) ControllerConfig() (controller.Config, error) {
+	return p.config, nil
+}
+
+func (p *fakeStatePool) ExpiringModelUUIDs() ([]string, error) {
+	if p.uuids != nil {
+		return p.uuids, nil
+	}
+	return []string{"deadbeef"}, nil
+}
+
+func (p *fakeStatePool) GetModel(uuid string) (modelexpiry.Model, state.StatePoolReleaser, error) {
+	model, ok := p.models[uuid]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", uuid)
+	}
+	return model, func() bool { return false }, nil
+}
+
+type fakeModel struct {
+	jujutesting.Stub
+	mu     sync.Mutex
+	expiry state.ModelExpiry
+	status status.StatusInfo
+}
+
+func (m *fakeModel) Name() string {
+	return "temporary"
+}
+
+func (m *fakeModel) UUID() string {
+	return "deadbeef"
+}
+
+func (m *fakeModel) Owner() names.UserTag {
+	return names.NewUserTag("bob")
+}
+
+func (m *fakeModel) Expiry() (state.ModelExpiry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.expiry, true
+}
+
+func (m *fakeModel) SetExpiryWarned() error {
+	m.MethodCall(m, "SetExpiryWarned")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expiry.Warned = true
+	return m.NextErr()
+}
+
+func (m *fakeModel) Status() (status.StatusInfo, error) {
+	m.MethodCall(m, "Status")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status, nil
+}
+
+func (m *fakeModel) SetStatus(info status.StatusInfo) error {
+	m.MethodCall(m, "SetStatus", info)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = info
+	return nil
+}
+
+func (m *fakeModel) Destroy(args state.DestroyModelParams) error {
+	m.MethodCall(m, "Destroy", args)
+	return m.NextErr()
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -37,6 +38,7 @@ type fakeState struct {
 	machines    map[string]*fakeMachine
 	controllers voyeur.Value // of *state.ControllerInfo
 	statuses    voyeur.Value // of statuses collection
+	config      voyeur.Value // of controller.Config
 	session     *fakeMongoSession
 	check       func(st *fakeState) error
 }
@@ -113,6 +115,7 @@ func NewFakeState() *fakeState {
 	}
 	st.session = newFakeMongoSession(st, &st.errors)
 	st.controllers.Set(&state.ControllerInfo{})
+	st.config.Set(controller.Config{controller.APIPort: apiPort})
 	return st
 }
 
@@ -237,6 +240,21 @@ func (st *fakeState) WatchControllerStatusChanges() state.StringsWatcher {
 	return WatchStrings(&st.statuses)
 }
 
+func (st *fakeState) ControllerConfig() (controller.Config, error) {
+	if err := st.errors.errorFor("State.ControllerConfig"); err != nil {
+		return nil, err
+	}
+	return st.config.Get().(controller.Config), nil
+}
+
+func (st *fakeState) WatchControllerConfig() state.NotifyWatcher {
+	return WatchValue(&st.config)
+}
+
+func (st *fakeState) setAPIPort(port int) {
+	st.config.Set(controller.Config{controller.APIPort: port})
+}
+
 func (st *fakeState) Space(name string) (SpaceReader, error) {
 	foo := []networkingcommon.BackingSpace{
 		&testing.FakeSpace{SpaceName: "Space" + name},
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/pubsub/apiserver"
@@ -24,6 +25,12 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.peergrouper")
 
+// ErrAPIPortChanged is returned by the worker when the api-port in
+// the controller config changes. The machines' API addresses are held
+// with the port, so the worker must be restarted to publish them with
+// the new one.
+var ErrAPIPortChanged = errors.New("api-port changed")
+
 type stateInterface interface {
 	Machine(id string) (stateMachine, error)
 	WatchControllerInfo() state.NotifyWatcher
@@ -33,6 +40,8 @@ type stateInterface interface {
 	Space(id string) (SpaceReader, error)
 	SetOrGetMongoSpaceName(spaceName network.SpaceName) (network.SpaceName, error)
 	SetMongoSpaceState(mongoSpaceState state.MongoSpaceStates) error
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() state.NotifyWatcher
 }
 
 type stateMachine interface {
@@ -165,6 +174,15 @@ func (w *pgWorker) loop() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	configWatcher := w.st.WatchControllerConfig()
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := w.st.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller config")
+	}
+	apiPort := cfg.APIPort()
 
 	var updateChan <-chan time.Time
 	retryInterval := initialRetryInterval
@@ -187,6 +205,17 @@ func (w *pgWorker) loop() error {
 				updateChan = w.clock.After(0)
 			}
 
+		case <-configWatcher.Changes():
+			logger.Tracef("<-configWatcher.Changes()")
+			cfg, err := w.st.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot read controller config")
+			}
+			if cfg.APIPort() != apiPort {
+				logger.Infof("api-port changed from %d to %d, restarting", apiPort, cfg.APIPort())
+				return ErrAPIPortChanged
+			}
+
 		case <-w.machineChanges:
 			logger.Tracef("<-w.machineChanges")
 			// One of the controller machines changed, update the
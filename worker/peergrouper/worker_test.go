@@ -378,6 +378,9 @@ var fatalErrorsTests = []struct {
 }, {
 	errPattern: "Machine.InstanceId *",
 	expectErr:  `cannot get API server info: sample`,
+}, {
+	errPattern: "State.ControllerConfig",
+	expectErr:  `cannot read controller config: sample`,
 }}
 
 func (s *workerSuite) TestFatalErrors(c *gc.C) {
@@ -409,6 +412,19 @@ func (s *workerSuite) TestFatalErrors(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestRestartsWhenAPIPortChanges(c *gc.C) {
+	st := NewFakeState()
+	st.session.InstantlyReady = true
+	InitState(c, st, 3, testIPv4)
+	w, err := newNoPublishWorker(st, s.clock, &noOpHub{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	st.setAPIPort(apiPort + 1)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, ErrAPIPortChanged)
+}
+
 func (s *workerSuite) TestSetMembersErrorIsNotFatal(c *gc.C) {
 	coretesting.SkipIfI386(c, "lp:1425569")
 
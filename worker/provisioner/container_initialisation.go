@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/utils/privsep"
 	"github.com/juju/juju/watcher"
 )
 
@@ -218,6 +219,11 @@ func observeNetwork() ([]params.NetworkConfig, error) {
 }
 
 func defaultBridger() (network.Bridger, error) {
+	if !privsep.Privileged() {
+		// The privileged helper chooses the host's network
+		// configuration itself.
+		return privsep.NewBridger(), nil
+	}
 	if _, err := os.Stat(systemNetworkInterfacesFile); err == nil {
 		return network.DefaultEtcNetworkInterfacesBridger(activateBridgesTimeout, systemNetworkInterfacesFile)
 	} else {
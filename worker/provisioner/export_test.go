@@ -4,7 +4,10 @@
 package provisioner
 
 import (
+	"github.com/juju/utils/clock"
+
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/starthook"
 	"github.com/juju/juju/watcher"
 )
 
@@ -33,6 +36,12 @@ var (
 	RetryStrategyDelay       = &retryStrategyDelay
 	RetryStrategyCount       = &retryStrategyCount
 	GetObservedNetworkConfig = &getObservedNetworkConfig
+	RecordEgressBandwidth    = recordEgressBandwidth
 )
 
 var ClassifyMachine = classifyMachine
+
+// NewStartHookRunner returns a new runner for instance started hooks.
+func NewStartHookRunner(hooks []starthook.Hook, clock clock.Clock) (*startHookRunner, error) {
+	return newStartHookRunner(hooks, clock)
+}
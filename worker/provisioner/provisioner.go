@@ -14,9 +14,11 @@ import (
 
 	"github.com/juju/juju/agent"
 	apiprovisioner "github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/starthook"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
@@ -65,6 +67,12 @@ type provisioner struct {
 	broker      environs.InstanceBroker
 	toolsFinder ToolsFinder
 	catacomb    catacomb.Catacomb
+
+	// providerType is the type of the provider that the
+	// provisioner starts instances with. It is empty for
+	// container provisioners, which do not call instance
+	// started hooks.
+	providerType string
 }
 
 // RetryStrategy defines the retry behavior when encountering a retryable
@@ -161,6 +169,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		auth,
 		modelCfg.ImageStream(),
 		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		p.instanceStartedHooks(controllerCfg),
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -168,15 +177,34 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 	return task, nil
 }
 
+// instanceStartedHooks returns the hooks to call for each instance
+// started by the provisioner: those registered for the provider, and
+// the controller's instance started webhook.
+func (p *provisioner) instanceStartedHooks(controllerCfg controller.Config) InstanceStartedHooks {
+	if p.providerType == "" {
+		return InstanceStartedHooks{}
+	}
+	hooks := starthook.ProviderHooks(p.providerType)
+	if url := controllerCfg.InstanceStartedWebhook(); url != "" {
+		hooks = append(hooks, starthook.NewWebhook(url))
+	}
+	return InstanceStartedHooks{
+		ModelUUID:    p.agentConfig.Model().Id(),
+		ProviderType: p.providerType,
+		Hooks:        hooks,
+	}
+}
+
 // NewEnvironProvisioner returns a new Provisioner for an environment.
 // When new machines are added to the state, it allocates instances
 // from the environment and allocates them to the new machines.
 func NewEnvironProvisioner(st *apiprovisioner.State, agentConfig agent.Config, environ environs.Environ) (Provisioner, error) {
 	p := &environProvisioner{
 		provisioner: provisioner{
-			st:          st,
-			agentConfig: agentConfig,
-			toolsFinder: getToolsFinder(st),
+			st:           st,
+			agentConfig:  agentConfig,
+			toolsFinder:  getToolsFinder(st),
+			providerType: environ.Config().Type(),
 		},
 		environ: environ,
 	}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/starthook"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -71,6 +73,7 @@ func NewProvisionerTask(
 	auth authentication.AuthenticationProvider,
 	imageStream string,
 	retryStartInstanceStrategy RetryStrategy,
+	startHooks InstanceStartedHooks,
 ) (ProvisionerTask, error) {
 	machineChanges := machineWatcher.Changes()
 	workers := []worker.Worker{machineWatcher}
@@ -79,6 +82,15 @@ func NewProvisionerTask(
 		retryChanges = retryWatcher.Changes()
 		workers = append(workers, retryWatcher)
 	}
+	var startHookRunner *startHookRunner
+	if len(startHooks.Hooks) > 0 {
+		var err error
+		startHookRunner, err = newStartHookRunner(startHooks.Hooks, clock.WallClock)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		workers = append(workers, startHookRunner)
+	}
 	task := &provisionerTask{
 		controllerUUID:             controllerUUID,
 		machineTag:                 machineTag,
@@ -93,6 +105,9 @@ func NewProvisionerTask(
 		machines:                   make(map[string]*apiprovisioner.Machine),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
+		startHooks:                 startHooks,
+		startHookRunner:            startHookRunner,
+		startExclusions:            make(map[string]startExclusions),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &task.catacomb,
@@ -119,10 +134,21 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	startHooks                 InstanceStartedHooks
+	startHookRunner            *startHookRunner
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
 	machines map[string]*apiprovisioner.Machine
+	// machine id -> exclusions to apply when starting the machine
+	startExclusions map[string]startExclusions
+}
+
+// startExclusions records the availability zones and instance types
+// that must not be used when retrying the provisioning of a machine.
+type startExclusions struct {
+	zones         []string
+	instanceTypes []string
 }
 
 // Kill implements worker.Worker.Kill.
@@ -211,12 +237,43 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 			logger.Errorf("cannot reset instance status of machine %q: %v", machine.Id(), err)
 			continue
 		}
+		task.noteStartExclusions(machine, result.Status.Data)
 		task.machines[machine.Tag().String()] = machine
 		pending = append(pending, machine)
 	}
 	return task.startMachines(pending)
 }
 
+// noteStartExclusions records the availability zone or instance type
+// that a machine failed to start with, if the provisioning of the
+// machine was retried with a request to switch them, so that they are
+// not used again. Only errors caused by a lack of capacity are worth
+// avoiding in this way.
+func (task *provisionerTask) noteStartExclusions(machine *apiprovisioner.Machine, data map[string]interface{}) {
+	class, _ := data["error-class"].(string)
+	if !environs.ProvisioningErrorClass(class).IsCapacity() {
+		return
+	}
+	exclusions := task.startExclusions[machine.Id()]
+	if switchZone, _ := data["switch-zone"].(bool); switchZone {
+		if zone, _ := data["availability-zone"].(string); zone != "" {
+			exclusions.zones = append(exclusions.zones, zone)
+		}
+	}
+	if switchType, _ := data["switch-instance-type"].(bool); switchType {
+		if instanceType, _ := data["instance-type"].(string); instanceType != "" {
+			exclusions.instanceTypes = append(exclusions.instanceTypes, instanceType)
+		}
+	}
+	if len(exclusions.zones) > 0 || len(exclusions.instanceTypes) > 0 {
+		logger.Infof(
+			"retrying machine %s excluding availability zones %v and instance types %v",
+			machine, exclusions.zones, exclusions.instanceTypes,
+		)
+		task.startExclusions[machine.Id()] = exclusions
+	}
+}
+
 func (task *provisionerTask) processMachines(ids []string) error {
 	logger.Tracef("processMachines(%v)", ids)
 
@@ -268,7 +325,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 	// pending ones, because if we start an instance and then fail to
 	// set its InstanceId on the machine we don't want to start a new
 	// instance for the same machine ID.
-	if err := task.stopInstances(append(stopping, unknown...)); err != nil {
+	if err := task.stopInstances(append(stopping, unknown...), dead); err != nil {
 		return err
 	}
 
@@ -483,7 +540,11 @@ func (task *provisionerTask) instancesForDeadMachines(deadMachines []*apiprovisi
 	return instances
 }
 
-func (task *provisionerTask) stopInstances(instances []instance.Instance) error {
+// stopInstances stops the given instances. If the broker supports it,
+// the resources associated with the instances are released in ordered
+// phases around stopping them, and the instance status of each of the
+// given dead machines reports the phase reached.
+func (task *provisionerTask) stopInstances(instances []instance.Instance, dead []*apiprovisioner.Machine) error {
 	// Although calling StopInstance with an empty slice should produce no change in the
 	// provider, environs like dummy do not consider this a noop.
 	if len(instances) == 0 {
@@ -497,9 +558,76 @@ func (task *provisionerTask) stopInstances(instances []instance.Instance) error
 	for i, inst := range instances {
 		ids[i] = inst.Id()
 	}
-	if err := task.broker.StopInstances(ids...); err != nil {
+	machines := make(map[instance.Id]*apiprovisioner.Machine)
+	for _, machine := range dead {
+		if instId, err := machine.InstanceId(); err == nil {
+			machines[instId] = machine
+		}
+	}
+	setStatus := func(message string) {
+		for _, id := range ids {
+			machine, ok := machines[id]
+			if !ok {
+				continue
+			}
+			if err := machine.SetInstanceStatus(status.Destroying, message, nil); err != nil {
+				logger.Errorf("cannot set instance status for machine %q: %v", machine, err)
+			}
+		}
+	}
+	runPhase := func(phase string, f func() error) error {
+		logger.Debugf("%s for instances %v", phase, ids)
+		setStatus(phase)
+		if err := f(); err != nil {
+			setStatus(fmt.Sprintf("%s failed: %v", phase, err))
+			return errors.Trace(err)
+		}
+		return nil
+	}
+
+	teardown, ok := task.broker.(environs.InstanceTeardown)
+	if !ok {
+		if err := task.broker.StopInstances(ids...); err != nil {
+			// Record the failure against the machines, so that it is
+			// reported for machines pending removal.
+			setStatus(fmt.Sprintf("stopping instance failed: %v", err))
+			return errors.Annotate(err, "broker failed to stop instances")
+		}
+		return nil
+	}
+
+	if err := runPhase("detaching volumes", func() error {
+		return teardown.DetachInstanceVolumes(ids...)
+	}); err != nil {
+		return errors.Annotate(err, "cannot detach volumes")
+	}
+	if err := runPhase("releasing addresses", func() error {
+		return teardown.ReleaseInstanceAddresses(ids...)
+	}); err != nil {
+		return errors.Annotate(err, "cannot release addresses")
+	}
+	// The security groups must be identified before the instances
+	// are stopped, but can only be deleted afterwards.
+	var groups []string
+	if err := runPhase("finding security groups", func() (err error) {
+		groups, err = teardown.InstanceSecurityGroups(ids...)
+		return err
+	}); err != nil {
+		return errors.Annotate(err, "cannot find security groups")
+	}
+	if err := runPhase("stopping instance", func() error {
+		return task.broker.StopInstances(ids...)
+	}); err != nil {
 		return errors.Annotate(err, "broker failed to stop instances")
 	}
+	if len(groups) == 0 {
+		return nil
+	}
+	if err := runPhase("deleting security groups", func() error {
+		return teardown.DeleteSecurityGroups(groups...)
+	}); err != nil {
+		return errors.Annotate(err, "cannot delete security groups")
+	}
 	return nil
 }
 
@@ -536,6 +664,9 @@ func (task *provisionerTask) constructInstanceConfig(
 	}
 
 	instanceConfig.Tags = pInfo.Tags
+	if pInfo.Constraints.HasBandwidth() {
+		instanceConfig.EgressBandwidth = *pInfo.Constraints.Bandwidth
+	}
 	if len(pInfo.Jobs) > 0 {
 		instanceConfig.Jobs = pInfo.Jobs
 	}
@@ -738,6 +869,10 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 		if err != nil {
 			return task.setErrorStatus("cannot construct params for machine %q: %v", m, err)
 		}
+		if exclusions, ok := task.startExclusions[m.Id()]; ok {
+			startInstanceParams.ExcludedAvailabilityZones = exclusions.zones
+			startInstanceParams.ExcludedInstanceTypes = exclusions.instanceTypes
+		}
 
 		if err := task.startMachine(m, pInfo, startInstanceParams); err != nil {
 			return errors.Annotatef(err, "cannot start machine %v", m)
@@ -748,7 +883,7 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 
 func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisioner.Machine, err error) error {
 	logger.Errorf(message, machine, err)
-	if err := machine.SetInstanceStatus(status.ProvisioningError, err.Error(), nil); err != nil {
+	if err := machine.SetInstanceStatus(status.ProvisioningError, err.Error(), provisioningErrorData(err)); err != nil {
 		// Something is wrong with this machine, better report it back.
 		return errors.Annotatef(err, "cannot set error status for machine %q", machine)
 	}
@@ -791,6 +926,7 @@ func (task *provisionerTask) startMachine(
 		}
 	}
 
+	result.Hardware = recordEgressBandwidth(result.Hardware, startInstanceParams.InstanceConfig)
 	networkConfig := networkingcommon.NetworkConfigFromInterfaceInfo(result.NetworkInfo)
 	volumes := volumesToAPIserver(result.Volumes)
 	volumeNameToAttachmentInfo := volumeAttachmentsToAPIserver(result.VolumeAttachments)
@@ -812,6 +948,13 @@ func (task *provisionerTask) startMachine(
 		}
 		return errors.Annotate(err, "cannot set instance info")
 	}
+	delete(task.startExclusions, machine.Id())
+	if task.startHookRunner != nil {
+		details := task.instanceStartedDetails(machine, provisioningInfo, result)
+		if err := task.startHookRunner.InstanceStarted(machine, details); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	logger.Infof(
 		"started machine %s as instance %s with hardware %q, network config %+v, volumes %v, volume attachments %v, subnets to zones %v",
@@ -826,6 +969,74 @@ func (task *provisionerTask) startMachine(
 	return nil
 }
 
+// provisioningErrorData returns the status data classifying an error
+// that prevented a machine from being provisioned, so that users and
+// retry-provisioning can tell how best to resolve it. Errors that have
+// not been classified by the provider have no data.
+func provisioningErrorData(err error) map[string]interface{} {
+	info := environs.ClassifyProvisioningError(err)
+	if info.Class == environs.ProvisioningErrorUnknown && info.AvailabilityZone == "" && info.InstanceType == "" {
+		return nil
+	}
+	data := map[string]interface{}{
+		"error-class":  string(info.Class),
+		"retry-advice": info.Class.RetryAdvice(),
+	}
+	if info.AvailabilityZone != "" {
+		data["availability-zone"] = info.AvailabilityZone
+	}
+	if info.InstanceType != "" {
+		data["instance-type"] = info.InstanceType
+	}
+	return data
+}
+
+// recordEgressBandwidth returns the hardware characteristics of a new
+// instance, including the bandwidth limit applied by traffic shaping
+// on the instance, unless the provider reported a limit of its own.
+func recordEgressBandwidth(hc *instance.HardwareCharacteristics, icfg *instancecfg.InstanceConfig) *instance.HardwareCharacteristics {
+	if icfg == nil || icfg.EgressBandwidth == 0 {
+		return hc
+	}
+	var result instance.HardwareCharacteristics
+	if hc != nil {
+		if hc.Bandwidth != nil {
+			return hc
+		}
+		result = *hc
+	}
+	bandwidth := icfg.EgressBandwidth
+	result.Bandwidth = &bandwidth
+	return &result
+}
+
+// instanceStartedDetails returns the details of the instance started
+// for the machine, for passing to instance started hooks.
+func (task *provisionerTask) instanceStartedDetails(
+	machine *apiprovisioner.Machine,
+	provisioningInfo *params.ProvisioningInfo,
+	result *environs.StartInstanceResult,
+) starthook.InstanceDetails {
+	details := starthook.InstanceDetails{
+		ModelUUID:    task.startHooks.ModelUUID,
+		ProviderType: task.startHooks.ProviderType,
+		MachineId:    machine.Id(),
+		InstanceId:   result.Instance.Id(),
+		Series:       provisioningInfo.Series,
+		Hardware:     result.Hardware,
+	}
+	addresses, err := result.Instance.Addresses()
+	if err != nil {
+		// The addresses may not be known yet; hooks
+		// can look them up with the instance ID.
+		logger.Debugf("cannot get addresses of instance %s: %v", result.Instance.Id(), err)
+	}
+	for _, addr := range addresses {
+		details.Addresses = append(details.Addresses, addr.Value)
+	}
+	return details
+}
+
 type provisioningInfo struct {
 	Constraints    constraints.Value
 	Series         string
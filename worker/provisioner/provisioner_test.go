@@ -6,9 +6,11 @@ package provisioner_test
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/utils/arch"
@@ -24,6 +26,7 @@ import (
 	apiprovisioner "github.com/juju/juju/api/provisioner"
 	apiserverprovisioner "github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller/authentication"
 	"github.com/juju/juju/environs"
@@ -717,6 +720,39 @@ func (s *ProvisionerSuite) TestProvisioningDoesNotOccurForKVM(c *gc.C) {
 	s.waitForRemovalMark(c, m)
 }
 
+type RecordEgressBandwidthSuite struct{}
+
+var _ = gc.Suite(&RecordEgressBandwidthSuite{})
+
+func (s *RecordEgressBandwidthSuite) TestRecorded(c *gc.C) {
+	arch := "amd64"
+	hc := &instance.HardwareCharacteristics{Arch: &arch}
+	icfg := &instancecfg.InstanceConfig{EgressBandwidth: 100}
+	result := provisioner.RecordEgressBandwidth(hc, icfg)
+	c.Assert(result.Bandwidth, gc.NotNil)
+	c.Assert(*result.Bandwidth, gc.Equals, uint64(100))
+	c.Assert(*result.Arch, gc.Equals, "amd64")
+	c.Assert(hc.Bandwidth, gc.IsNil)
+
+	result = provisioner.RecordEgressBandwidth(nil, icfg)
+	c.Assert(result.Bandwidth, gc.NotNil)
+	c.Assert(*result.Bandwidth, gc.Equals, uint64(100))
+}
+
+func (s *RecordEgressBandwidthSuite) TestProviderBandwidthKept(c *gc.C) {
+	bandwidth := uint64(1000)
+	hc := &instance.HardwareCharacteristics{Bandwidth: &bandwidth}
+	result := provisioner.RecordEgressBandwidth(hc, &instancecfg.InstanceConfig{EgressBandwidth: 100})
+	c.Assert(result, gc.Equals, hc)
+	c.Assert(*result.Bandwidth, gc.Equals, uint64(1000))
+}
+
+func (s *RecordEgressBandwidthSuite) TestNotShaped(c *gc.C) {
+	hc := &instance.HardwareCharacteristics{}
+	c.Assert(provisioner.RecordEgressBandwidth(hc, &instancecfg.InstanceConfig{}), gc.Equals, hc)
+	c.Assert(provisioner.RecordEgressBandwidth(hc, nil), gc.Equals, hc)
+}
+
 type MachineClassifySuite struct {
 }
 
@@ -1180,6 +1216,7 @@ func (s *ProvisionerSuite) newProvisionerTask(
 		auth,
 		imagemetadata.ReleasedStream,
 		retryStrategy,
+		provisioner.InstanceStartedHooks{},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
@@ -1311,6 +1348,111 @@ func (s *ProvisionerSuite) TestStopInstancesIgnoresMachinesWithKeep(c *gc.C) {
 	s.waitForRemovalMark(c, m1)
 }
 
+func (s *ProvisionerSuite) TestStopInstancesTeardownPhases(c *gc.C) {
+	broker := &teardownBroker{
+		Environ: s.Environ,
+		stub:    &jujutesting.Stub{},
+		groups:  []string{"juju-machine-group"},
+	}
+	task := s.newProvisionerTask(c,
+		config.HarvestDestroyed,
+		broker,
+		s.provisioner,
+		mockToolsFinder{},
+	)
+	defer stop(c, task)
+
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	i0 := s.checkStartInstance(c, m0)
+	c.Assert(m0.EnsureDead(), gc.IsNil)
+
+	s.checkStopInstances(c, i0)
+	s.waitForRemovalMark(c, m0)
+
+	broker.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"DetachInstanceVolumes", []interface{}{[]instance.Id{i0.Id()}}},
+		{"ReleaseInstanceAddresses", []interface{}{[]instance.Id{i0.Id()}}},
+		{"InstanceSecurityGroups", []interface{}{[]instance.Id{i0.Id()}}},
+		{"StopInstances", []interface{}{[]instance.Id{i0.Id()}}},
+		{"DeleteSecurityGroups", []interface{}{[]string{"juju-machine-group"}}},
+	})
+	instStatus, err := m0.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instStatus.Status, gc.Equals, status.Destroying)
+	c.Assert(instStatus.Message, gc.Equals, "deleting security groups")
+}
+
+func (s *ProvisionerSuite) TestStopInstancesTeardownPhaseFailure(c *gc.C) {
+	broker := &teardownBroker{
+		Environ: s.Environ,
+		stub:    &jujutesting.Stub{},
+	}
+	broker.stub.SetErrors(errors.New("volume busy"))
+	task := s.newProvisionerTask(c,
+		config.HarvestDestroyed,
+		broker,
+		s.provisioner,
+		mockToolsFinder{},
+	)
+	defer func() {
+		err := worker.Stop(task)
+		c.Assert(err, gc.ErrorMatches, "failed to process updated machines: cannot detach volumes: volume busy")
+	}()
+
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m0)
+	c.Assert(m0.EnsureDead(), gc.IsNil)
+	s.BackingState.StartSync()
+
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		instStatus, err := m0.InstanceStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		if instStatus.Message == "detaching volumes failed: volume busy" {
+			c.Assert(instStatus.Status, gc.Equals, status.Destroying)
+			break
+		}
+		if !attempt.HasNext() {
+			c.Fatalf("instance status not updated: %+v", instStatus)
+		}
+	}
+	// The instance is not stopped until its volumes are detached.
+	s.checkNoOperations(c)
+}
+
+func (s *ProvisionerSuite) TestStopInstancesFailureRecorded(c *gc.C) {
+	broker := &stopFailBroker{Environ: s.Environ}
+	task := s.newProvisionerTask(c,
+		config.HarvestDestroyed,
+		broker,
+		s.provisioner,
+		mockToolsFinder{},
+	)
+	defer func() {
+		err := worker.Stop(task)
+		c.Assert(err, gc.ErrorMatches, "failed to process updated machines: broker failed to stop instances: instance locked")
+	}()
+
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m0)
+	c.Assert(m0.EnsureDead(), gc.IsNil)
+	s.BackingState.StartSync()
+
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		instStatus, err := m0.InstanceStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		if instStatus.Message == "stopping instance failed: instance locked" {
+			c.Assert(instStatus.Status, gc.Equals, status.Destroying)
+			break
+		}
+		if !attempt.HasNext() {
+			c.Fatalf("instance status not updated: %+v", instStatus)
+		}
+	}
+}
+
 func (s *ProvisionerSuite) TestProvisionerRetriesTransientErrors(c *gc.C) {
 	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
 	e := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
@@ -1363,6 +1505,65 @@ func (s *ProvisionerSuite) TestProvisionerRetriesTransientErrors(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
 }
 
+func (s *ProvisionerSuite) TestProvisionerRecordsProvisioningErrorClass(c *gc.C) {
+	broker := &capacityBroker{Environ: s.Environ}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo := s.waitForProvisioningError(c, m)
+	c.Assert(statusInfo.Message, gc.Equals, "no capacity in zone-a")
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+		"error-class":       "az-capacity",
+		"retry-advice":      environs.ProvisioningErrorAZCapacity.RetryAdvice(),
+		"availability-zone": "zone-a",
+		"instance-type":     "m1.small",
+	})
+}
+
+func (s *ProvisionerSuite) TestProvisionerRetriesCapacityErrorsSwitchingZone(c *gc.C) {
+	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
+	broker := &capacityBroker{Environ: s.Environ}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo := s.waitForProvisioningError(c, m)
+
+	data := statusInfo.Data
+	data["transient"] = true
+	data["switch-zone"] = true
+	now := time.Now()
+	err = m.SetInstanceStatus(status.StatusInfo{
+		Status:  status.ProvisioningError,
+		Message: statusInfo.Message,
+		Data:    data,
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m)
+
+	excluded := broker.excludedZones()
+	c.Assert(excluded, gc.HasLen, 2)
+	c.Assert(excluded[0], gc.HasLen, 0)
+	c.Assert(excluded[1], jc.DeepEquals, []string{"zone-a"})
+}
+
+func (s *ProvisionerSuite) waitForProvisioningError(c *gc.C, m *state.Machine) status.StatusInfo {
+	s.BackingState.StartSync()
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		statusInfo, err := m.InstanceStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Status == status.ProvisioningError {
+			return statusInfo
+		}
+	}
+	c.Fatalf("machine %v instance status not set to provisioning error", m)
+	panic("unreachable")
+}
+
 func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
 	broker := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
@@ -1402,6 +1603,82 @@ func (b *mockBroker) StartInstance(args environs.StartInstanceParams) (*environs
 	return nil, fmt.Errorf("error: some error")
 }
 
+// capacityBroker is an InstanceBroker that fails to start instances in
+// zone-a, for lack of capacity, unless that zone is excluded.
+type capacityBroker struct {
+	environs.Environ
+
+	mu       sync.Mutex
+	excluded [][]string
+}
+
+func (b *capacityBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	b.mu.Lock()
+	b.excluded = append(b.excluded, args.ExcludedAvailabilityZones)
+	b.mu.Unlock()
+	for _, zone := range args.ExcludedAvailabilityZones {
+		if zone == "zone-a" {
+			return b.Environ.StartInstance(args)
+		}
+	}
+	return nil, environs.NewProvisioningError(errors.New("no capacity in zone-a"), environs.ProvisioningErrorInfo{
+		Class:            environs.ProvisioningErrorAZCapacity,
+		AvailabilityZone: "zone-a",
+		InstanceType:     "m1.small",
+	})
+}
+
+func (b *capacityBroker) excludedZones() [][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.excluded
+}
+
+// stopFailBroker is an InstanceBroker that fails to stop instances.
+type stopFailBroker struct {
+	environs.Environ
+}
+
+func (b *stopFailBroker) StopInstances(ids ...instance.Id) error {
+	return errors.New("instance locked")
+}
+
+// teardownBroker is an InstanceBroker that implements
+// environs.InstanceTeardown, recording the calls made.
+type teardownBroker struct {
+	environs.Environ
+	stub   *jujutesting.Stub
+	groups []string
+}
+
+func (b *teardownBroker) DetachInstanceVolumes(ids ...instance.Id) error {
+	b.stub.AddCall("DetachInstanceVolumes", ids)
+	return b.stub.NextErr()
+}
+
+func (b *teardownBroker) ReleaseInstanceAddresses(ids ...instance.Id) error {
+	b.stub.AddCall("ReleaseInstanceAddresses", ids)
+	return b.stub.NextErr()
+}
+
+func (b *teardownBroker) InstanceSecurityGroups(ids ...instance.Id) ([]string, error) {
+	b.stub.AddCall("InstanceSecurityGroups", ids)
+	return b.groups, b.stub.NextErr()
+}
+
+func (b *teardownBroker) StopInstances(ids ...instance.Id) error {
+	b.stub.AddCall("StopInstances", ids)
+	if err := b.stub.NextErr(); err != nil {
+		return err
+	}
+	return b.Environ.StopInstances(ids...)
+}
+
+func (b *teardownBroker) DeleteSecurityGroups(names ...string) error {
+	b.stub.AddCall("DeleteSecurityGroups", names)
+	return b.stub.NextErr()
+}
+
 type mockToolsFinder struct {
 }
 
@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs/starthook"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+const (
+	// startHookRetryDelay is how long to wait before first retrying
+	// instance started hooks that failed. The delay doubles on each
+	// further failure, up to startHookMaxRetryDelay.
+	startHookRetryDelay    = 10 * time.Second
+	startHookMaxRetryDelay = 5 * time.Minute
+)
+
+// InstanceStartedHooks holds the hooks called by a provisioner task for
+// each instance it starts.
+type InstanceStartedHooks struct {
+	// ModelUUID is the UUID of the model being provisioned.
+	ModelUUID string
+
+	// ProviderType is the type of the provider starting instances.
+	ProviderType string
+
+	// Hooks holds the hooks to call.
+	Hooks []starthook.Hook
+}
+
+// StartHookMachine is the machine whose status is updated when its
+// instance started hooks fail.
+type StartHookMachine interface {
+	Id() string
+	Status() (status.Status, string, error)
+	SetStatus(status.Status, string, map[string]interface{}) error
+}
+
+// startHookRunner calls instance started hooks, retrying those that
+// fail until they succeed. While hooks are failing, the failures are
+// recorded in the status of pending machines.
+type startHookRunner struct {
+	catacomb catacomb.Catacomb
+	hooks    []starthook.Hook
+	clock    clock.Clock
+	runs     chan *startHookRun
+}
+
+type startHookRun struct {
+	machine StartHookMachine
+	details starthook.InstanceDetails
+	pending []starthook.Hook
+	failed  bool
+	delay   time.Duration
+	next    time.Time
+}
+
+func newStartHookRunner(hooks []starthook.Hook, clock clock.Clock) (*startHookRunner, error) {
+	r := &startHookRunner{
+		hooks: hooks,
+		clock: clock,
+		runs:  make(chan *startHookRun),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &r.catacomb,
+		Work: r.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+// Kill implements worker.Worker.Kill.
+func (r *startHookRunner) Kill() {
+	r.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.Wait.
+func (r *startHookRunner) Wait() error {
+	return r.catacomb.Wait()
+}
+
+// InstanceStarted queues the hooks to be called for the instance
+// started for the given machine.
+func (r *startHookRunner) InstanceStarted(machine StartHookMachine, details starthook.InstanceDetails) error {
+	run := &startHookRun{
+		machine: machine,
+		details: details,
+		pending: r.hooks,
+		delay:   startHookRetryDelay,
+	}
+	select {
+	case r.runs <- run:
+		return nil
+	case <-r.catacomb.Dying():
+		return r.catacomb.ErrDying()
+	}
+}
+
+func (r *startHookRunner) loop() error {
+	var retries []*startHookRun
+	for {
+		var retry <-chan time.Time
+		if len(retries) > 0 {
+			next := retries[0].next
+			for _, run := range retries[1:] {
+				if run.next.Before(next) {
+					next = run.next
+				}
+			}
+			retry = r.clock.After(next.Sub(r.clock.Now()))
+		}
+		select {
+		case <-r.catacomb.Dying():
+			return r.catacomb.ErrDying()
+		case run := <-r.runs:
+			if !r.callHooks(run) {
+				retries = append(retries, run)
+			}
+		case <-retry:
+			now := r.clock.Now()
+			var remaining []*startHookRun
+			for _, run := range retries {
+				if now.Before(run.next) || !r.callHooks(run) {
+					remaining = append(remaining, run)
+				}
+			}
+			retries = remaining
+		}
+	}
+}
+
+// callHooks calls the pending hooks of the run, and reports whether
+// they all succeeded.
+func (r *startHookRunner) callHooks(run *startHookRun) bool {
+	var failed []starthook.Hook
+	var messages []string
+	for _, hook := range run.pending {
+		if err := hook.InstanceStarted(run.details); err != nil {
+			logger.Warningf("instance started %s for machine %s failed: %v", hook.Name(), run.machine.Id(), err)
+			failed = append(failed, hook)
+			messages = append(messages, fmt.Sprintf("%s: %v", hook.Name(), err))
+		}
+	}
+	run.pending = failed
+	if len(failed) == 0 {
+		if run.failed {
+			logger.Infof("instance started hooks for machine %s succeeded", run.machine.Id())
+			r.setPendingStatus(run.machine, "")
+		}
+		return true
+	}
+	run.failed = true
+	r.setPendingStatus(run.machine, fmt.Sprintf(
+		"instance started hooks failed, retrying in %v: %s",
+		run.delay, strings.Join(messages, "; "),
+	))
+	run.next = r.clock.Now().Add(run.delay)
+	run.delay *= 2
+	if run.delay > startHookMaxRetryDelay {
+		run.delay = startHookMaxRetryDelay
+	}
+	return false
+}
+
+// setPendingStatus sets the status message of the machine, if its
+// agent has not yet started and set the status itself.
+func (r *startHookRunner) setPendingStatus(machine StartHookMachine, message string) {
+	current, _, err := machine.Status()
+	if err != nil {
+		logger.Errorf("cannot get status of machine %s: %v", machine.Id(), err)
+		return
+	}
+	if current != status.Pending {
+		return
+	}
+	if err := machine.SetStatus(status.Pending, message, nil); err != nil {
+		logger.Errorf("cannot set status of machine %s: %v", machine.Id(), err)
+	}
+}
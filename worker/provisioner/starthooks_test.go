@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/environs/starthook"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/provisioner"
+)
+
+type startHookRunnerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testing.Clock
+	hook    *fakeStartHook
+	machine *fakeStartHookMachine
+	details starthook.InstanceDetails
+}
+
+var _ = gc.Suite(&startHookRunnerSuite{})
+
+func (s *startHookRunnerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.hook = &fakeStartHook{calls: make(chan starthook.InstanceDetails, 10)}
+	s.machine = &fakeStartHookMachine{status: status.Pending}
+	s.details = starthook.InstanceDetails{
+		MachineId:  "1",
+		InstanceId: instance.Id("inst-1"),
+		Series:     "xenial",
+	}
+}
+
+func (s *startHookRunnerSuite) waitHookCall(c *gc.C) {
+	select {
+	case details := <-s.hook.calls:
+		c.Assert(details, jc.DeepEquals, s.details)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for hook call")
+	}
+}
+
+func (s *startHookRunnerSuite) TestHookCalled(c *gc.C) {
+	runner, err := provisioner.NewStartHookRunner([]starthook.Hook{s.hook}, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, runner)
+
+	err = runner.InstanceStarted(s.machine, s.details)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitHookCall(c)
+	workertest.CleanKill(c, runner)
+	s.machine.CheckNoCalls(c)
+}
+
+func (s *startHookRunnerSuite) TestHookRetried(c *gc.C) {
+	s.hook.SetErrors(errors.New("cmdb unavailable"))
+	runner, err := provisioner.NewStartHookRunner([]starthook.Hook{s.hook}, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, runner)
+
+	err = runner.InstanceStarted(s.machine, s.details)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitHookCall(c)
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.waitHookCall(c)
+	workertest.CleanKill(c, runner)
+
+	s.machine.CheckCalls(c, []testing.StubCall{
+		{"Status", nil},
+		{"SetStatus", []interface{}{status.Pending, "instance started hooks failed, retrying in 10s: fake: cmdb unavailable"}},
+		{"Status", nil},
+		{"SetStatus", []interface{}{status.Pending, ""}},
+	})
+}
+
+func (s *startHookRunnerSuite) TestHookFailureNotRecordedOnceStarted(c *gc.C) {
+	s.machine.status = status.Started
+	s.hook.SetErrors(errors.New("cmdb unavailable"))
+	runner, err := provisioner.NewStartHookRunner([]starthook.Hook{s.hook}, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, runner)
+
+	err = runner.InstanceStarted(s.machine, s.details)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitHookCall(c)
+	workertest.CleanKill(c, runner)
+	s.machine.CheckCallNames(c, "Status")
+}
+
+type fakeStartHook struct {
+	testing.Stub
+	calls chan starthook.InstanceDetails
+}
+
+func (h *fakeStartHook) Name() string {
+	return "fake"
+}
+
+func (h *fakeStartHook) InstanceStarted(details starthook.InstanceDetails) error {
+	err := h.NextErr()
+	h.calls <- details
+	return err
+}
+
+type fakeStartHookMachine struct {
+	testing.Stub
+	mu     sync.Mutex
+	status status.Status
+}
+
+func (m *fakeStartHookMachine) Id() string {
+	return "1"
+}
+
+func (m *fakeStartHookMachine) Status() (status.Status, string, error) {
+	m.MethodCall(m, "Status")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status, "", m.NextErr()
+}
+
+func (m *fakeStartHookMachine) SetStatus(s status.Status, info string, _ map[string]interface{}) error {
+	m.MethodCall(m, "SetStatus", s, info)
+	return m.NextErr()
+}
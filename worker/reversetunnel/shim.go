@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package reversetunnel
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
+
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	apitunnel "github.com/juju/juju/api/tunnel"
+)
+
+// sshAddress is the address of the machine's SSH server, to which
+// tunnels are connected.
+const sshAddress = "localhost:22"
+
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	facade, err := apiagent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+func NewTunnels(apiCaller base.APICaller) Tunnels {
+	return tunnels{apiCaller}
+}
+
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// DialSSH connects to the machine's SSH server.
+func DialSSH() (io.ReadWriteCloser, error) {
+	return net.DialTimeout("tcp", sshAddress, 10*time.Second)
+}
+
+// tunnels implements Tunnels using the api/tunnel package.
+type tunnels struct {
+	connector base.StreamConnector
+}
+
+// OpenChannel is part of the Tunnels interface.
+func (t tunnels) OpenChannel() (Channel, error) {
+	channel, err := apitunnel.OpenChannel(t.connector)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return channel, nil
+}
+
+// Accept is part of the Tunnels interface.
+func (t tunnels) Accept(id string) (io.ReadWriteCloser, error) {
+	return apitunnel.Accept(t.connector, id)
+}
+
+// Reject is part of the Tunnels interface.
+func (t tunnels) Reject(id, reason string) error {
+	return apitunnel.Reject(t.connector, id, reason)
+}
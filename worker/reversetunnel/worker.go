@@ -0,0 +1,288 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package reversetunnel provides a worker that keeps a machine's
+// reverse channel open to the controller, in models whose agents
+// connect in reverse mode, and connects the tunnels requested over
+// the channel to the machine's SSH server.
+package reversetunnel
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/dependency"
+)
+
+var logger = loggo.GetLogger("juju.worker.reversetunnel")
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+}
+
+// Tunnels provides access to the machine's reverse channel, and to the
+// tunnels requested over it.
+type Tunnels interface {
+	OpenChannel() (Channel, error)
+	Accept(id string) (io.ReadWriteCloser, error)
+	Reject(id, reason string) error
+}
+
+// Channel is a reverse channel, over which tunnel requests arrive.
+type Channel interface {
+	Next() (string, error)
+	Close() error
+}
+
+// Config defines the parameters of the reversetunnel worker.
+type Config struct {
+	Facade  Facade
+	Tunnels Tunnels
+	DialSSH func() (io.ReadWriteCloser, error)
+}
+
+// Validate returns an error if Config cannot drive a reversetunnel
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Tunnels == nil {
+		return errors.NotValidf("nil Tunnels")
+	}
+	if config.DialSSH == nil {
+		return errors.NotValidf("nil DialSSH")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &reverseTunnel{
+		config:   config,
+		sessions: make(map[io.Closer]bool),
+		stopping: make(chan struct{}),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// reverseTunnel is the worker returned by New.
+type reverseTunnel struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// stopping is closed when the worker's loop exits, to stop the
+	// goroutines it started.
+	stopping chan struct{}
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	sessions map[io.Closer]bool
+}
+
+// Kill implements worker.Worker.
+func (w *reverseTunnel) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *reverseTunnel) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *reverseTunnel) loop() error {
+	defer w.closeSessions()
+
+	configWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		mode     string
+		requests <-chan string
+		failed   <-chan error
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			cfg, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load model configuration")
+			}
+			newMode := cfg.AgentConnectionMode()
+			if mode != "" && newMode != mode {
+				logger.Infof("agent connection mode changed to %q", newMode)
+				return dependency.ErrBounce
+			}
+			if mode != "" || newMode != config.ReverseAgentConnection {
+				mode = newMode
+				continue
+			}
+			mode = newMode
+			requests, failed, err = w.openChannel()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			logger.Infof("reverse channel opened")
+
+		case id := <-requests:
+			w.startSession(id)
+
+		case err := <-failed:
+			return errors.Annotate(err, "reverse channel closed")
+		}
+	}
+}
+
+// openChannel opens the reverse channel, and starts reading tunnel
+// requests from it. The requests are delivered on the returned requests
+// channel, until reading fails.
+func (w *reverseTunnel) openChannel() (<-chan string, <-chan error, error) {
+	channel, err := w.config.Tunnels.OpenChannel()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot open reverse channel")
+	}
+	requests := make(chan string)
+	failed := make(chan error, 1)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for {
+			id, err := channel.Next()
+			if err != nil {
+				failed <- err
+				return
+			}
+			select {
+			case requests <- id:
+			case <-w.stopping:
+				return
+			}
+		}
+	}()
+	// Closing the channel unblocks the reading goroutine.
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		<-w.stopping
+		channel.Close()
+	}()
+	return requests, failed, nil
+}
+
+// startSession connects the requested tunnel to the SSH server, in
+// the background.
+func (w *reverseTunnel) startSession(id string) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		if err := w.runSession(id); err != nil {
+			logger.Warningf("tunnel %s: %v", id, err)
+		}
+	}()
+}
+
+func (w *reverseTunnel) runSession(id string) error {
+	ssh, err := w.config.DialSSH()
+	if err != nil {
+		if rejectErr := w.config.Tunnels.Reject(id, err.Error()); rejectErr != nil {
+			logger.Debugf("rejecting tunnel %s: %v", id, rejectErr)
+		}
+		return errors.Annotate(err, "cannot connect to SSH server")
+	}
+	if !w.addSession(ssh) {
+		return nil
+	}
+	defer w.removeSession(ssh)
+
+	tunnel, err := w.config.Tunnels.Accept(id)
+	if err != nil {
+		return errors.Annotate(err, "cannot accept tunnel")
+	}
+	if !w.addSession(tunnel) {
+		return nil
+	}
+	defer w.removeSession(tunnel)
+
+	logger.Debugf("tunnel %s connected", id)
+	done := make(chan struct{}, 2)
+	relay := func(dst io.Writer, src io.Reader) {
+		io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go relay(ssh, tunnel)
+	go relay(tunnel, ssh)
+	// When either side closes, closing both ends the other copy.
+	<-done
+	ssh.Close()
+	tunnel.Close()
+	<-done
+	logger.Debugf("tunnel %s closed", id)
+	return nil
+}
+
+// addSession records a connection that must be closed when the worker
+// stops. It returns false, having closed the connection, if the worker
+// is already stopping.
+func (w *reverseTunnel) addSession(conn io.Closer) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.sessions == nil {
+		conn.Close()
+		return false
+	}
+	w.sessions[conn] = true
+	return true
+}
+
+// removeSession closes a connection recorded by addSession.
+func (w *reverseTunnel) removeSession(conn io.Closer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.sessions != nil {
+		delete(w.sessions, conn)
+	}
+	conn.Close()
+}
+
+// closeSessions closes the reverse channel and the connections of all
+// open tunnels, and waits for the worker's goroutines to finish.
+func (w *reverseTunnel) closeSessions() {
+	close(w.stopping)
+	w.mu.Lock()
+	for conn := range w.sessions {
+		conn.Close()
+	}
+	w.sessions = nil
+	w.mu.Unlock()
+	w.wg.Wait()
+}
@@ -0,0 +1,262 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package reversetunnel_test
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/reversetunnel"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	stub    *jujutesting.Stub
+	facade  *fakeFacade
+	tunnels *fakeTunnels
+	ssh     chan net.Conn
+	config  reversetunnel.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.facade = &fakeFacade{
+		watcher: notAWatcher{workertest.NewFakeWatcher(1, 1)},
+		mode:    config.ReverseAgentConnection,
+	}
+	s.tunnels = &fakeTunnels{
+		stub:     s.stub,
+		requests: make(chan string),
+		accepted: make(chan net.Conn, 1),
+	}
+	s.ssh = make(chan net.Conn, 1)
+	s.config = reversetunnel.Config{
+		Facade:  s.facade,
+		Tunnels: s.tunnels,
+		DialSSH: func() (io.ReadWriteCloser, error) {
+			s.stub.AddCall("DialSSH")
+			if err := s.stub.NextErr(); err != nil {
+				return nil, err
+			}
+			agentEnd, serverEnd := net.Pipe()
+			s.ssh <- serverEnd
+			return agentEnd, nil
+		},
+	}
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.Facade = nil
+	_, err := reversetunnel.New(s.config)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+
+	s.config.Facade = s.facade
+	s.config.DialSSH = nil
+	_, err = reversetunnel.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil DialSSH not valid")
+}
+
+func (s *Suite) TestDirectMode(c *gc.C) {
+	s.facade.setMode(config.DirectAgentConnection)
+	w, err := reversetunnel.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *Suite) TestRelaysTunnel(c *gc.C) {
+	w, err := reversetunnel.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendRequest(c, "abc")
+	server := s.waitSSH(c)
+	defer server.Close()
+	var client net.Conn
+	select {
+	case client = <-s.tunnels.accepted:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("tunnel not accepted")
+	}
+	defer client.Close()
+
+	go io.WriteString(client, "hello")
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(server, buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(buf), gc.Equals, "hello")
+
+	go io.WriteString(server, "SSH-2.0")
+	buf = make([]byte, 7)
+	_, err = io.ReadFull(client, buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(buf), gc.Equals, "SSH-2.0")
+
+	s.stub.CheckCallNames(c, "OpenChannel", "DialSSH", "Accept")
+	s.stub.CheckCall(c, 2, "Accept", "abc")
+}
+
+func (s *Suite) TestRejectsTunnel(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("connection refused"))
+	w, err := reversetunnel.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendRequest(c, "abc")
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.stub.Calls()) == 3 {
+			break
+		}
+	}
+	s.stub.CheckCallNames(c, "OpenChannel", "DialSSH", "Reject")
+	s.stub.CheckCall(c, 2, "Reject", "abc", "connection refused")
+}
+
+func (s *Suite) TestOpenChannelError(c *gc.C) {
+	s.stub.SetErrors(errors.New("reverse channels not supported"))
+	w, err := reversetunnel.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot open reverse channel: reverse channels not supported")
+}
+
+func (s *Suite) TestChannelClosed(c *gc.C) {
+	w, err := reversetunnel.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	close(s.tunnels.requests)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "reverse channel closed: EOF")
+}
+
+func (s *Suite) TestModeChangeBounces(c *gc.C) {
+	w, err := reversetunnel.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.sendRequest(c, "abc")
+
+	s.facade.setMode(config.DirectAgentConnection)
+	s.facade.watcher.Ping()
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (s *Suite) sendRequest(c *gc.C, id string) {
+	select {
+	case s.tunnels.requests <- id:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("reverse channel not read")
+	}
+}
+
+func (s *Suite) waitSSH(c *gc.C) net.Conn {
+	select {
+	case conn := <-s.ssh:
+		return conn
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("SSH server not dialled")
+	}
+	panic("unreachable")
+}
+
+type notAWatcher struct {
+	workertest.NotAWatcher
+}
+
+func (w notAWatcher) Changes() watcher.NotifyChannel {
+	return w.NotAWatcher.Changes()
+}
+
+type fakeFacade struct {
+	watcher notAWatcher
+
+	mu   sync.Mutex
+	mode string
+}
+
+func (f *fakeFacade) setMode(mode string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mode = mode
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		config.AgentConnectionModeKey: f.mode,
+	}))
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+type fakeTunnels struct {
+	stub     *jujutesting.Stub
+	requests chan string
+	accepted chan net.Conn
+}
+
+func (f *fakeTunnels) OpenChannel() (reversetunnel.Channel, error) {
+	f.stub.AddCall("OpenChannel")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &fakeChannel{requests: f.requests, closed: make(chan struct{})}, nil
+}
+
+func (f *fakeTunnels) Accept(id string) (io.ReadWriteCloser, error) {
+	f.stub.AddCall("Accept", id)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	agentEnd, clientEnd := net.Pipe()
+	f.accepted <- clientEnd
+	return agentEnd, nil
+}
+
+func (f *fakeTunnels) Reject(id, reason string) error {
+	f.stub.AddCall("Reject", id, reason)
+	return f.stub.NextErr()
+}
+
+type fakeChannel struct {
+	requests  chan string
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (f *fakeChannel) Next() (string, error) {
+	select {
+	case id, ok := <-f.requests:
+		if !ok {
+			return "", io.EOF
+		}
+		return id, nil
+	case <-f.closed:
+		return "", errors.New("channel closed")
+	}
+}
+
+func (f *fakeChannel) Close() error {
+	f.closeOnce.Do(func() { close(f.closed) })
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securitygroupgc
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/securitygroupgc"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig holds dependencies and configuration for a
+// securitygroupgc worker.
+type ManifoldConfig struct {
+	APICallerName string
+	EnvironName   string
+	Clock         clock.Clock
+	NewFacade     func(base.APICaller) (Facade, error)
+	NewWorker     func(Config) (worker.Worker, error)
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if config.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
+	var environ environs.Environ
+	if err := context.Get(config.EnvironName, &environ); err != nil {
+		return nil, errors.Trace(err)
+	}
+	collector, ok := environ.(Collector)
+	if !ok {
+		logger.Debugf("provider does not support collecting security groups")
+		return nil, dependency.ErrUninstall
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return config.NewWorker(Config{
+		Facade:    facade,
+		Collector: collector,
+		Clock:     config.Clock,
+	})
+}
+
+// Manifold returns a dependency.Manifold that runs a securitygroupgc
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.EnvironName,
+		},
+		Start: config.start,
+	}
+}
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return securitygroupgc.NewAPI(apiCaller), nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securitygroupgc_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/securitygroupgc"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) manifold(config securitygroupgc.ManifoldConfig) dependency.Manifold {
+	config.APICallerName = "api-caller"
+	config.EnvironName = "environ"
+	return securitygroupgc.Manifold(config)
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := s.manifold(securitygroupgc.ManifoldConfig{})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller", "environ"})
+}
+
+func (s *ManifoldSuite) TestStartMissingEnviron(c *gc.C) {
+	manifold := s.manifold(securitygroupgc.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    dependency.ErrMissing,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartUnsupportedEnviron(c *gc.C) {
+	manifold := s.manifold(securitygroupgc.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    &fakeEnviron{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartFacadeError(c *gc.C) {
+	manifold := s.manifold(securitygroupgc.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+		NewFacade: func(base.APICaller) (securitygroupgc.Facade, error) {
+			return nil, errors.New("blort")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    &collectingEnviron{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	facade := &fakeFacade{}
+	environ := &collectingEnviron{}
+	expectWorker := &fakeWorker{}
+	manifold := s.manifold(securitygroupgc.ManifoldConfig{
+		Clock: clock,
+		NewFacade: func(base.APICaller) (securitygroupgc.Facade, error) {
+			return facade, nil
+		},
+		NewWorker: func(config securitygroupgc.Config) (worker.Worker, error) {
+			c.Check(config.Validate(), jc.ErrorIsNil)
+			c.Check(config.Facade, gc.Equals, facade)
+			c.Check(config.Collector, gc.Equals, environ)
+			c.Check(config.Clock, gc.Equals, clock)
+			return expectWorker, nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    environ,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
+
+type fakeEnviron struct {
+	environs.Environ
+}
+
+type collectingEnviron struct {
+	fakeEnviron
+}
+
+func (*collectingEnviron) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	return nil, nil
+}
+
+func (*collectingEnviron) DeleteSecurityGroups(...string) error {
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package securitygroupgc provides a worker that periodically deletes
+// the per-machine security groups of a model whose machines no longer
+// exist, such as those left behind when an instance fails to
+// provision.
+package securitygroupgc
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.securitygroupgc")
+
+// DisabledPollInterval is how often the model config is checked for
+// a change to security-group-gc-interval while collection is disabled.
+const DisabledPollInterval = 10 * time.Minute
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+	// MachineIds returns the IDs of all machines in the model.
+	MachineIds() ([]string, error)
+}
+
+// Collector finds and deletes the machine security groups of a model,
+// and provides the model config that controls how often it is done.
+type Collector interface {
+	environs.SecurityGroupCollector
+
+	// Config returns the current model config.
+	Config() *config.Config
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade    Facade
+	Collector Collector
+	Clock     clock.Clock
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Collector == nil {
+		return errors.NotValidf("nil Collector")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// New returns a worker that deletes machine security groups that
+// belong to machines no longer in the model, and that are not attached
+// to any port, every security-group-gc-interval. If
+// security-group-gc-dry-run is set, the groups are logged instead.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &gcWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type gcWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *gcWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *gcWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *gcWorker) loop() error {
+	for {
+		interval := w.config.Collector.Config().SecurityGroupGCInterval()
+		enabled := interval > 0
+		if !enabled {
+			interval = DisabledPollInterval
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(interval):
+		}
+		if !enabled {
+			continue
+		}
+		if err := w.collect(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// collect deletes the orphaned machine security groups. Provider
+// errors are logged rather than returned, and the groups are tried
+// again next time.
+func (w *gcWorker) collect() error {
+	ids, err := w.config.Facade.MachineIds()
+	if err != nil {
+		return errors.Annotate(err, "getting machine ids")
+	}
+	machines := set.NewStrings(ids...)
+
+	groups, err := w.config.Collector.MachineSecurityGroups()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not collecting security groups: %v", err)
+		return nil
+	} else if err != nil {
+		logger.Warningf("cannot list machine security groups: %v", err)
+		return nil
+	}
+	var orphaned []string
+	for _, group := range groups {
+		if machines.Contains(group.MachineId) || group.InUse {
+			continue
+		}
+		orphaned = append(orphaned, group.Name)
+	}
+	if len(orphaned) == 0 {
+		return nil
+	}
+	if w.config.Collector.Config().SecurityGroupGCDryRun() {
+		logger.Infof("would delete orphaned security groups %q (dry run)", orphaned)
+		return nil
+	}
+	logger.Infof("deleting orphaned security groups %q", orphaned)
+	if err := w.config.Collector.DeleteSecurityGroups(orphaned...); err != nil {
+		logger.Warningf("cannot delete orphaned security groups: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securitygroupgc_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/securitygroupgc"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub      *jujutesting.Stub
+	clock     *jujutesting.Clock
+	facade    *fakeFacade
+	collector *fakeCollector
+	config    securitygroupgc.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.clock = jujutesting.NewClock(time.Time{})
+	s.facade = &fakeFacade{ids: []string{"0", "1"}}
+	s.collector = &fakeCollector{
+		stub: s.stub,
+		groups: []environs.MachineSecurityGroup{{
+			Name:      "juju-ctrl-model-0",
+			MachineId: "0",
+		}, {
+			Name:      "juju-ctrl-model-2",
+			MachineId: "2",
+		}, {
+			Name:      "juju-ctrl-model-3",
+			MachineId: "3",
+			InUse:     true,
+		}, {
+			Name:      "juju-ctrl-model-4",
+			MachineId: "4",
+		}},
+	}
+	s.collector.setConfig(c, coretesting.Attrs{})
+	s.config = securitygroupgc.Config{
+		Facade:    s.facade,
+		Collector: s.collector,
+		Clock:     s.clock,
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	for i, test := range []struct {
+		update func(*securitygroupgc.Config)
+		err    string
+	}{{
+		update: func(config *securitygroupgc.Config) { config.Facade = nil },
+		err:    "nil Facade not valid",
+	}, {
+		update: func(config *securitygroupgc.Config) { config.Collector = nil },
+		err:    "nil Collector not valid",
+	}, {
+		update: func(config *securitygroupgc.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := securitygroupgc.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestDeletesOrphanedGroups(c *gc.C) {
+	w, err := securitygroupgc.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCollect(c, time.Hour)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MachineSecurityGroups", nil},
+		{"DeleteSecurityGroups", []interface{}{[]string{"juju-ctrl-model-2", "juju-ctrl-model-4"}}},
+	})
+}
+
+func (s *WorkerSuite) TestDryRun(c *gc.C) {
+	s.collector.setConfig(c, coretesting.Attrs{
+		"security-group-gc-interval": "10m",
+		"security-group-gc-dry-run":  true,
+	})
+	w, err := securitygroupgc.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCollect(c, 10*time.Minute)
+	s.stub.CheckCallNames(c, "MachineSecurityGroups")
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.collector.setConfig(c, coretesting.Attrs{
+		"security-group-gc-interval": "0",
+	})
+	w, err := securitygroupgc.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCollect(c, securitygroupgc.DisabledPollInterval)
+	s.stub.CheckNoCalls(c)
+
+	// Enabling collection takes effect after the next check.
+	s.collector.setConfig(c, coretesting.Attrs{
+		"security-group-gc-interval": "1m",
+	})
+	s.waitCollect(c, securitygroupgc.DisabledPollInterval)
+	s.stub.CheckNoCalls(c)
+	s.waitCollect(c, time.Minute)
+	s.stub.CheckCallNames(c, "MachineSecurityGroups", "DeleteSecurityGroups")
+}
+
+func (s *WorkerSuite) TestNotSupported(c *gc.C) {
+	s.stub.SetErrors(errors.NotSupportedf("machine security group collection"))
+	w, err := securitygroupgc.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCollect(c, time.Hour)
+	s.stub.CheckCallNames(c, "MachineSecurityGroups")
+}
+
+func (s *WorkerSuite) TestDeleteErrorRetried(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("conflict"))
+	w, err := securitygroupgc.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCollect(c, time.Hour)
+	s.stub.CheckCallNames(c, "MachineSecurityGroups", "DeleteSecurityGroups")
+
+	s.stub.ResetCalls()
+	s.waitCollect(c, time.Hour)
+	s.stub.CheckCallNames(c, "MachineSecurityGroups", "DeleteSecurityGroups")
+}
+
+func (s *WorkerSuite) TestMachineIdsError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := securitygroupgc.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting machine ids: boom")
+}
+
+// waitCollect advances the clock by d, and waits for the worker to
+// finish collecting and wait to collect again.
+func (s *WorkerSuite) waitCollect(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeFacade struct {
+	ids []string
+	err error
+}
+
+func (f *fakeFacade) MachineIds() ([]string, error) {
+	return f.ids, f.err
+}
+
+type fakeCollector struct {
+	stub   *jujutesting.Stub
+	groups []environs.MachineSecurityGroup
+
+	mu  sync.Mutex
+	cfg *config.Config
+}
+
+func (f *fakeCollector) setConfig(c *gc.C, attrs coretesting.Attrs) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cfg = coretesting.CustomModelConfig(c, attrs)
+}
+
+func (f *fakeCollector) Config() *config.Config {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cfg
+}
+
+func (f *fakeCollector) MachineSecurityGroups() ([]environs.MachineSecurityGroup, error) {
+	f.stub.AddCall("MachineSecurityGroups")
+	return f.groups, f.stub.NextErr()
+}
+
+func (f *fakeCollector) DeleteSecurityGroups(names ...string) error {
+	f.stub.AddCall("DeleteSecurityGroups", names)
+	return f.stub.NextErr()
+}
@@ -13,7 +13,8 @@ import (
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
 // machine have been seen to have changed. This triggers a refresh of all
-// block devices for attached volumes backing pending filesystems.
+// block devices for attached volumes backing pending filesystems, and for
+// volumes backing provisioned filesystems, which may have been grown.
 func machineBlockDevicesChanged(ctx *context) error {
 	volumeTags := make([]names.VolumeTag, 0, len(ctx.incompleteFilesystemParams))
 	// We must query volumes for both incomplete filesystems
@@ -58,6 +59,22 @@ func machineBlockDevicesChanged(ctx *context) error {
 			volumeTags = append(volumeTags, filesystem.Volume)
 		}
 	}
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			// Filesystem is not volume-backed.
+			continue
+		}
+		var found bool
+		for _, tag := range volumeTags {
+			if filesystem.Volume == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			volumeTags = append(volumeTags, filesystem.Volume)
+		}
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
@@ -118,6 +135,13 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 					updatePendingFilesystemAttachment(ctx, id, params)
 				}
 			}
+			for _, filesystem := range ctx.filesystems {
+				if filesystem.Volume == volumeTags[i] && result.Result.Size > filesystem.Size {
+					// The volume has been grown, so the
+					// filesystem must be grown to fill it.
+					updatePendingFilesystemResize(ctx, filesystem, result.Result.Size)
+				}
+			}
 		} else if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFound(result.Error) {
 			// Either the volume (attachment) isn't provisioned,
 			// or the corresponding block device is not yet known.
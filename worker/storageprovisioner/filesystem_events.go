@@ -148,6 +148,18 @@ func removePendingFilesystem(ctx *context, tag names.FilesystemTag) {
 	ctx.schedule.Remove(tag)
 }
 
+// updatePendingFilesystemResize schedules the growing of the given
+// filesystem to fill its backing volume's block device, which has grown
+// to the given size. Any previously scheduled resize is superseded.
+func updatePendingFilesystemResize(ctx *context, filesystem storage.Filesystem, size uint64) {
+	ctx.schedule.Remove(resizeFilesystemKey{filesystem.Tag})
+	scheduleOperations(ctx, &resizeFilesystemOp{args: storage.FilesystemResizeParams{
+		Tag:          filesystem.Tag,
+		FilesystemId: filesystem.FilesystemId,
+		Size:         size,
+	}})
+}
+
 // updatePendingFilesystemAttachment adds the given filesystem attachment params to
 // either the incomplete set or the schedule. If the params are incomplete
 // due to a missing instance ID, updatePendingFilesystemAttachment will request
@@ -4,6 +4,7 @@
 package storageprovisioner
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/juju/errors"
@@ -487,6 +488,98 @@ func setFilesystemAttachmentInfo(ctx *context, filesystemAttachments []storage.F
 	return nil
 }
 
+// resizeFilesystems grows volume-backed filesystems to fill their volumes,
+// once the volumes have been grown.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		logger.Debugf("managed filesystem source does not support growing filesystems")
+		return nil
+	}
+	resizeParams := make([]storage.FilesystemResizeParams, 0, len(ops))
+	for _, op := range ops {
+		resizeParams = append(resizeParams, op.args)
+	}
+	logger.Debugf("growing filesystems: %+v", resizeParams)
+	errs, err := resizer.ResizeFilesystems(resizeParams)
+	if err != nil {
+		return errors.Annotate(err, "growing managed filesystems")
+	}
+	var reschedule []scheduleOp
+	var resized []storage.FilesystemResizeParams
+	var statuses []params.EntityStatusArgs
+	for i, err := range errs {
+		p := resizeParams[i]
+		if err != nil {
+			reschedule = append(reschedule, ops[p.Tag])
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    p.Tag.String(),
+				Status: status.Error.String(),
+				Info:   fmt.Sprintf("growing filesystem: %v", err),
+			})
+			logger.Debugf(
+				"failed to grow %s: %v",
+				names.ReadableString(p.Tag), err,
+			)
+			continue
+		}
+		resized = append(resized, p)
+		statuses = append(statuses, params.EntityStatusArgs{
+			Tag:    p.Tag.String(),
+			Status: status.Attached.String(),
+		})
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes of the filesystems, leaving the rest
+	// of their info as it is in state.
+	tags := make([]names.FilesystemTag, len(resized))
+	for i, p := range resized {
+		tags[i] = p.Tag
+	}
+	filesystemResults, err := ctx.config.Filesystems.Filesystems(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem information")
+	}
+	filesystems := make([]params.Filesystem, 0, len(filesystemResults))
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"getting information for grown filesystem %s: %v",
+				tags[i].Id(), result.Error,
+			)
+			continue
+		}
+		filesystem := result.Result
+		filesystem.Info.Size = resized[i].Size
+		filesystems = append(filesystems, filesystem)
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystems)
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing filesystem %s to state: %v",
+				filesystems[i].FilesystemTag,
+				result.Error,
+			)
+			continue
+		}
+		filesystem, err := filesystemFromParams(filesystems[i])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		updateFilesystem(ctx, filesystem)
+	}
+	return nil
+}
+
 func filesystemsFromStorage(in []storage.Filesystem) []params.Filesystem {
 	out := make([]params.Filesystem, len(in))
 	for i, f := range in {
@@ -552,6 +645,21 @@ func (op *attachFilesystemOp) key() interface{} {
 	}
 }
 
+// resizeFilesystemKey is the schedule key for resizeFilesystemOps,
+// distinct from that of other operations on the same filesystem.
+type resizeFilesystemKey struct {
+	tag names.FilesystemTag
+}
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args storage.FilesystemResizeParams
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeFilesystemKey{op.args.Tag}
+}
+
 type detachFilesystemOp struct {
 	exponentialBackoff
 	args storage.FilesystemAttachmentParams
@@ -97,10 +97,12 @@ type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
+	resizesWatcher         *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	resizeRequests         map[string]uint64
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -129,6 +131,10 @@ func (w *mockVolumeAccessor) WatchBlockDevices(tag names.MachineTag) (watcher.No
 	return w.blockDevicesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (v *mockVolumeAccessor) Volumes(volumes []names.VolumeTag) ([]params.VolumeResult, error) {
 	var result []params.VolumeResult
 	for _, tag := range volumes {
@@ -234,6 +240,26 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) ResizeVolumeParams(volumes []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	var result []params.ResizeVolumeParamsResult
+	for _, tag := range volumes {
+		size, ok := v.resizeRequests[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeVolumeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("resize request for %s", names.ReadableString(tag))),
+			})
+			continue
+		}
+		result = append(result, params.ResizeVolumeParamsResult{Result: params.ResizeVolumeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  v.provisionedVolumes[tag.String()].Info.VolumeId,
+			Provider:  "dummy",
+			Size:      size,
+		}})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -253,10 +279,12 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		volumesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		resizeRequests:         make(map[string]uint64),
 	}
 }
 
@@ -470,6 +498,7 @@ type dummyProvider struct {
 	attachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error)
 	detachVolumesFunc            func([]storage.VolumeAttachmentParams) ([]error, error)
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	releaseVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
@@ -591,6 +620,14 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes grows volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]error, error) {
+	if s.provider != nil && s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	return make([]error, len(params)), nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -741,6 +778,10 @@ func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.Filesys
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]error, error) {
+	return make([]error, len(args)), nil
+}
+
 type mockMachineAccessor struct {
 	instanceIds map[names.MachineTag]instance.Id
 	watcher     *mockNotifyWatcher
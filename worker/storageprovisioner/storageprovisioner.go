@@ -60,6 +60,11 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes, including
+	// requests to grow them, that this storage provisioner is
+	// responsible for.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -79,6 +84,10 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// ResizeVolumeParams returns the parameters for growing the
+	// volumes with the specified tags.
+	ResizeVolumeParams([]names.VolumeTag) ([]params.ResizeVolumeParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -206,6 +215,7 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
@@ -235,6 +245,23 @@ func (w *storageProvisioner) loop() error {
 	}
 	volumesChanges = volumesWatcher.Changes()
 
+	// Model-scoped provisioners grow volumes on request. Machine-scoped
+	// provisioners grow the filesystems on those volumes, when the block
+	// devices are seen to have grown.
+	if _, ok := w.config.Scope.(names.ModelTag); ok {
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+		if errors.IsNotSupported(err) {
+			logger.Debugf("not watching volume resizes: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		} else {
+			if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
+	}
+
 	filesystemsWatcher, err := w.config.Filesystems.WatchFilesystems()
 	if err != nil {
 		return errors.Annotate(err, "watching filesystems")
@@ -300,6 +327,13 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -348,10 +382,12 @@ func processSchedule(ctx *context) error {
 	removeVolumeOps := make(map[names.VolumeTag]*removeVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	removeFilesystemOps := make(map[names.FilesystemTag]*removeFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -364,6 +400,8 @@ func processSchedule(ctx *context) error {
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
 		case *createFilesystemOp:
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *removeFilesystemOp:
@@ -372,6 +410,8 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.args.Tag] = op
 		}
 	}
 	if len(removeVolumeOps) > 0 {
@@ -394,6 +434,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "growing volumes")
+		}
+	}
 	if len(removeFilesystemOps) > 0 {
 		if err := removeFilesystems(ctx, removeFilesystemOps); err != nil {
 			return errors.Annotate(err, "removing filesystems")
@@ -414,6 +459,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "growing filesystems")
+		}
+	}
 	return nil
 }
 
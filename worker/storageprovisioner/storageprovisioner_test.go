@@ -1083,6 +1083,103 @@ func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 	}})
 }
 
+func (s *storageProvisionerSuite) TestVolumeResized(c *gc.C) {
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]error, error) {
+		resizedChan <- args
+		return make([]error, len(args)), nil
+	}
+
+	infoSet := make(chan interface{}, 1)
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.resizeRequests["volume-1"] = 2048
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		infoSet <- volumes
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volumes without a pending resize request are ignored.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Provider: "dummy",
+		Size:     2048,
+	}})
+	volumes := waitChannel(c, infoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2048,
+		},
+	}})
+	assertNoEvent(c, resizedChan, "volume resized again")
+}
+
+func (s *storageProvisionerSuite) TestFilesystemResized(c *gc.C) {
+	infoSet := make(chan interface{}, 1)
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		infoSet <- filesystems
+		return nil, nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         123,
+		},
+	}
+	blockDeviceId := params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}
+	args.volumes.blockDevices[blockDeviceId] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       123,
+	}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+	assertNoEvent(c, infoSet, "filesystem info set")
+
+	// Growing the backing volume's block device causes the
+	// filesystem to be grown to fill it.
+	args.volumes.blockDevices[blockDeviceId] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       246,
+	}
+	args.volumes.blockDevicesWatcher.changes <- struct{}{}
+
+	filesystems := waitChannel(c, infoSet, "waiting for filesystem info to be set")
+	c.Assert(filesystems, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         246,
+		},
+	}})
+}
+
 func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
@@ -95,6 +95,44 @@ func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageI
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, possibly because they have been
+// requested to grow.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		// Any previously scheduled resize is superseded by
+		// the current request, if there is one.
+		ctx.schedule.Remove(resizeVolumeKey{tags[i]})
+		if result.Error != nil {
+			if params.IsCodeNotFoundOrCodeUnauthorized(result.Error) {
+				// There is no pending request to grow the
+				// volume, or the volume has been removed.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		args, err := resizeVolumeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, &resizeVolumeOp{args: args})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // processDyingVolumes processes the VolumeResults for Dying volumes,
 // removing them from provisioning-pending as necessary.
 func processDyingVolumes(ctx *context, tags []names.Tag) error {
@@ -497,6 +535,19 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 	}, nil
 }
 
+func resizeVolumeParamsFromParams(in params.ResizeVolumeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Tag:      volumeTag,
+		VolumeId: in.VolumeId,
+		Provider: storage.ProviderType(in.Provider),
+		Size:     in.Size,
+	}, nil
+}
+
 func volumeAttachmentParamsFromParams(in params.VolumeAttachmentParams) (storage.VolumeAttachmentParams, error) {
 	machineTag, err := names.ParseMachineTag(in.MachineTag)
 	if err != nil {
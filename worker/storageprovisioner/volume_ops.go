@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -360,6 +362,134 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes grows volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for _, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      op.args.Tag,
+			Provider: op.args.Provider,
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var resized []storage.VolumeResizeParams
+	var statuses []params.EntityStatusArgs
+	for sourceName, volumeParams := range paramsBySource {
+		resizeParams := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, p := range volumeParams {
+			resizeParams[i] = ops[p.Tag].args
+		}
+		logger.Debugf("growing volumes: %+v", resizeParams)
+		resizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			// The volumes cannot be grown; there is no point
+			// in retrying until the request is changed.
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info: fmt.Sprintf(
+						"growing volumes not supported by storage provider %q",
+						p.Provider,
+					),
+				})
+			}
+			continue
+		}
+		errs, err := resizer.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "growing volumes from source %q", sourceName)
+		}
+		for i, err := range errs {
+			p := resizeParams[i]
+			if err != nil {
+				reschedule = append(reschedule, ops[p.Tag])
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info:   fmt.Sprintf("growing volume: %v", err),
+				})
+				logger.Debugf(
+					"failed to grow %s: %v",
+					names.ReadableString(p.Tag), err,
+				)
+				continue
+			}
+			resized = append(resized, p)
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    p.Tag.String(),
+				Status: provisionedVolumeStatus(ctx, p.Tag).String(),
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes of the volumes, leaving the rest of
+	// their info as it is in state.
+	tags := make([]names.VolumeTag, len(resized))
+	for i, p := range resized {
+		tags[i] = p.Tag
+	}
+	volumeResults, err := ctx.config.Volumes.Volumes(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]params.Volume, 0, len(volumeResults))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"getting information for grown volume %s: %v",
+				tags[i].Id(), result.Error,
+			)
+			continue
+		}
+		volume := result.Result
+		volume.Info.Size = resized[i].Size
+		volumes = append(volumes, volume)
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].VolumeTag,
+				result.Error,
+			)
+			continue
+		}
+		volume, err := volumeFromParams(volumes[i])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		updateVolume(ctx, volume)
+	}
+	return nil
+}
+
+// provisionedVolumeStatus returns the status of the provisioned volume
+// with the specified tag, according to whether or not it is attached.
+func provisionedVolumeStatus(ctx *context, tag names.VolumeTag) status.Status {
+	for id := range ctx.volumeAttachments {
+		if id.AttachmentTag == tag.String() {
+			return status.Attached
+		}
+	}
+	return status.Detached
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -510,6 +640,21 @@ func (op *attachVolumeOp) key() interface{} {
 	}
 }
 
+// resizeVolumeKey is the schedule key for resizeVolumeOps, distinct
+// from that of other operations on the same volume.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.VolumeResizeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
+
 type detachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
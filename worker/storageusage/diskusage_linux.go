@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package storageusage
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+const mib = 1024 * 1024
+
+// DefaultDiskUsage measures the filesystem mounted at path with
+// statfs(2), in the same way as df(1).
+func DefaultDiskUsage(path string) (DiskUsage, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return DiskUsage{}, errors.Trace(err)
+	}
+	bsize := uint64(st.Bsize)
+	return DiskUsage{
+		Size:      st.Blocks * bsize / mib,
+		Used:      (st.Blocks - st.Bfree) * bsize / mib,
+		Available: st.Bavail * bsize / mib,
+	}, nil
+}
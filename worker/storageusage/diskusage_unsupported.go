@@ -0,0 +1,17 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package storageusage
+
+import (
+	"runtime"
+
+	"github.com/juju/errors"
+)
+
+// DefaultDiskUsage is not supported on this platform.
+func DefaultDiskUsage(path string) (DiskUsage, error) {
+	return DiskUsage{}, errors.NotSupportedf("measuring filesystems on %s", runtime.GOOS)
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageusage
+
+import (
+	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/storageusage"
+)
+
+// NewFacade returns a Facade backed by the StorageUsage API facade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return storageusage.NewFacade(apiCaller), nil
+}
+
+// NewWorker wraps New to return a worker.Worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package storageusage provides a worker that periodically measures
+// the utilization of the Juju-managed filesystems mounted on a machine,
+// and records it in the controller.
+package storageusage
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/storageusage"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.storageusage")
+
+// DefaultInterval is how often filesystem utilization is reported by
+// default.
+const DefaultInterval = 5 * time.Minute
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	FilesystemAttachments(names.MachineTag) ([]storageusage.FilesystemAttachment, error)
+	SetFilesystemUsage(names.MachineTag, []storageusage.FilesystemUsage) error
+}
+
+// DiskUsage describes the utilization of a mounted filesystem. Sizes
+// are in MiB.
+type DiskUsage struct {
+	Size      uint64
+	Used      uint64
+	Available uint64
+}
+
+// DiskUsageFunc returns the utilization of the filesystem mounted at
+// the given path.
+type DiskUsageFunc func(path string) (DiskUsage, error)
+
+// Config holds the configuration and dependencies for a Worker.
+type Config struct {
+	// Facade is used to list the machine's filesystem attachments,
+	// and to record their utilization.
+	Facade Facade
+
+	// Machine is the tag of the machine whose filesystems are
+	// measured.
+	Machine names.MachineTag
+
+	// Clock is used to time the reports.
+	Clock clock.Clock
+
+	// Interval is how often the filesystems are measured.
+	Interval time.Duration
+
+	// DiskUsage measures the filesystem mounted at a path.
+	DiskUsage DiskUsageFunc
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Machine.Id() == "" {
+		return errors.NotValidf("empty Machine")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.DiskUsage == nil {
+		return errors.NotValidf("nil DiskUsage")
+	}
+	return nil
+}
+
+// Worker periodically reports the utilization of the filesystems
+// attached to a machine.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		if err := w.report(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// report measures the mounted filesystems attached to the machine,
+// and records their utilization. Filesystems that cannot be measured
+// are skipped, so that one bad mount does not hide the others.
+func (w *Worker) report() error {
+	attachments, err := w.config.Facade.FilesystemAttachments(w.config.Machine)
+	if err != nil {
+		return errors.Annotate(err, "listing filesystem attachments")
+	}
+	now := w.config.Clock.Now()
+	var usage []storageusage.FilesystemUsage
+	for _, attachment := range attachments {
+		if attachment.MountPoint == "" {
+			continue
+		}
+		du, err := w.config.DiskUsage(attachment.MountPoint)
+		if err != nil {
+			logger.Warningf(
+				"cannot measure %s at %q: %v",
+				names.ReadableString(attachment.Filesystem), attachment.MountPoint, err,
+			)
+			continue
+		}
+		usage = append(usage, storageusage.FilesystemUsage{
+			Filesystem: attachment.Filesystem,
+			Size:       du.Size,
+			Used:       du.Used,
+			Available:  du.Available,
+			Updated:    now,
+		})
+	}
+	if len(usage) == 0 {
+		return nil
+	}
+	if err := w.config.Facade.SetFilesystemUsage(w.config.Machine, usage); err != nil {
+		return errors.Annotate(err, "recording filesystem usage")
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageusage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	apistorageusage "github.com/juju/juju/api/storageusage"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/storageusage"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	clock  *jujutesting.Clock
+	facade *fakeFacade
+	usage  map[string]storageusage.DiskUsage
+	config storageusage.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var now = time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(now)
+	s.facade = &fakeFacade{
+		attachments: []apistorageusage.FilesystemAttachment{{
+			Filesystem: names.NewFilesystemTag("0"),
+			MountPoint: "/srv/data",
+		}, {
+			Filesystem: names.NewFilesystemTag("1"),
+		}, {
+			Filesystem: names.NewFilesystemTag("2"),
+			MountPoint: "/srv/logs",
+		}},
+	}
+	s.usage = map[string]storageusage.DiskUsage{
+		"/srv/data": {Size: 1024, Used: 900, Available: 124},
+	}
+	s.config = storageusage.Config{
+		Facade:   s.facade,
+		Machine:  names.NewMachineTag("1"),
+		Clock:    s.clock,
+		Interval: time.Minute,
+		DiskUsage: func(path string) (storageusage.DiskUsage, error) {
+			usage, ok := s.usage[path]
+			if !ok {
+				return storageusage.DiskUsage{}, errors.NotFoundf("%s", path)
+			}
+			return usage, nil
+		},
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	config := s.config
+	config.Facade = nil
+	_, err := storageusage.New(config)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	config = s.config
+	config.Machine = names.MachineTag{}
+	_, err = storageusage.New(config)
+	c.Check(err, gc.ErrorMatches, "empty Machine not valid")
+
+	config = s.config
+	config.Clock = nil
+	_, err = storageusage.New(config)
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config
+	config.Interval = 0
+	_, err = storageusage.New(config)
+	c.Check(err, gc.ErrorMatches, "non-positive Interval not valid")
+
+	config = s.config
+	config.DiskUsage = nil
+	_, err = storageusage.New(config)
+	c.Check(err, gc.ErrorMatches, "nil DiskUsage not valid")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *storageusage.Worker {
+	w, err := storageusage.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+// advance waits for the worker to finish a report, and then advances
+// the clock by the given duration.
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestReportsMountedFilesystems(c *gc.C) {
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.facade.CheckCalls(c, []jujutesting.StubCall{{
+		"FilesystemAttachments", []interface{}{names.NewMachineTag("1")},
+	}, {
+		"SetFilesystemUsage", []interface{}{
+			names.NewMachineTag("1"),
+			[]apistorageusage.FilesystemUsage{{
+				Filesystem: names.NewFilesystemTag("0"),
+				Size:       1024,
+				Used:       900,
+				Available:  124,
+				Updated:    now,
+			}},
+		},
+	}})
+}
+
+func (s *WorkerSuite) TestReportsPeriodically(c *gc.C) {
+	w := s.startWorker(c)
+	s.advance(c, time.Minute)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.facade.CheckCallNames(c,
+		"FilesystemAttachments", "SetFilesystemUsage",
+		"FilesystemAttachments", "SetFilesystemUsage",
+	)
+}
+
+func (s *WorkerSuite) TestNothingToReport(c *gc.C) {
+	s.usage = nil
+	w := s.startWorker(c)
+	s.advance(c, 0)
+	workertest.CleanKill(c, w)
+
+	s.facade.CheckCallNames(c, "FilesystemAttachments")
+}
+
+func (s *WorkerSuite) TestFilesystemAttachmentsError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "listing filesystem attachments: boom")
+}
+
+func (s *WorkerSuite) TestSetFilesystemUsageError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("boom"))
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "recording filesystem usage: boom")
+}
+
+type fakeFacade struct {
+	jujutesting.Stub
+	attachments []apistorageusage.FilesystemAttachment
+}
+
+func (f *fakeFacade) FilesystemAttachments(machine names.MachineTag) ([]apistorageusage.FilesystemAttachment, error) {
+	f.AddCall("FilesystemAttachments", machine)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.attachments, nil
+}
+
+func (f *fakeFacade) SetFilesystemUsage(machine names.MachineTag, usage []apistorageusage.FilesystemUsage) error {
+	f.AddCall("SetFilesystemUsage", machine, usage)
+	return f.NextErr()
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagsync_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/tagsync"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) manifold(config tagsync.ManifoldConfig) dependency.Manifold {
+	config.APICallerName = "api-caller"
+	config.EnvironName = "environ"
+	return tagsync.Manifold(config)
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := s.manifold(tagsync.ManifoldConfig{})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller", "environ"})
+}
+
+func (s *ManifoldSuite) TestStartMissingEnviron(c *gc.C) {
+	manifold := s.manifold(tagsync.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    dependency.ErrMissing,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartUnsupportedEnviron(c *gc.C) {
+	manifold := s.manifold(tagsync.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    &fakeEnviron{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartFacadeError(c *gc.C) {
+	manifold := s.manifold(tagsync.ManifoldConfig{
+		Clock: testing.NewClock(time.Time{}),
+		NewFacade: func(base.APICaller) (tagsync.Facade, error) {
+			return nil, errors.New("blort")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    &taggingEnviron{},
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	facade := &fakeFacade{}
+	environ := &taggingEnviron{}
+	expectWorker := &fakeWorker{}
+	manifold := s.manifold(tagsync.ManifoldConfig{
+		Clock: clock,
+		NewFacade: func(base.APICaller) (tagsync.Facade, error) {
+			return facade, nil
+		},
+		NewWorker: func(config tagsync.Config) (worker.Worker, error) {
+			c.Check(config.Validate(), jc.ErrorIsNil)
+			c.Check(config.Facade, gc.Equals, facade)
+			c.Check(config.Tagger, gc.Equals, environ)
+			c.Check(config.Clock, gc.Equals, clock)
+			c.Check(config.PollInterval, gc.Equals, tagsync.DefaultPollInterval)
+			return expectWorker, nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+		"environ":    environ,
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
+
+type fakeEnviron struct {
+	environs.Environ
+}
+
+type taggingEnviron struct {
+	fakeEnviron
+}
+
+func (*taggingEnviron) TagInstance(instance.Id, map[string]string) error {
+	return nil
+}
+
+func (*taggingEnviron) InstanceTags(instance.Id) (map[string]string, error) {
+	return nil, nil
+}
+
+func (*taggingEnviron) UntagInstance(instance.Id, []string) error {
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tagsync provides a worker that sets the resource tags defined
+// by model and application annotations on the instances of a model's
+// machines, so that provider tags follow the annotations.
+package tagsync
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/tagsync"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.tagsync")
+
+// DefaultPollInterval is how often instances are checked for tags to
+// set, so that newly provisioned instances are tagged even if no
+// annotations change.
+const DefaultPollInterval = 5 * time.Minute
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+	// WatchAnnotations returns a watcher that notifies when the
+	// annotations of any entity in the model change.
+	WatchAnnotations() (watcher.NotifyWatcher, error)
+
+	// InstanceTags returns the tags, defined by annotations, that
+	// should be set on the instance of each provisioned machine.
+	InstanceTags() ([]tagsync.InstanceTags, error)
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade       Facade
+	Tagger       environs.InstanceTagRemover
+	Clock        clock.Clock
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Tagger == nil {
+		return errors.NotValidf("nil Tagger")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// New returns a worker that sets the tags defined by annotations on
+// instances, whenever annotations change. The instances' tags that
+// have the JujuAnnotationTagPrefix, but are no longer defined by
+// annotations, are removed.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &syncWorker{
+		config: config,
+		synced: make(map[instance.Id]map[string]string),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type syncWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// synced holds the tags each instance was last synced with.
+	synced map[instance.Id]map[string]string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *syncWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *syncWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *syncWorker) loop() error {
+	annotationsWatcher, err := w.config.Facade.WatchAnnotations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(annotationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	var poll <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-annotationsWatcher.Changes():
+			if !ok {
+				return errors.New("annotations watcher closed")
+			}
+		case <-poll:
+		}
+		if err := w.update(); err != nil {
+			return errors.Trace(err)
+		}
+		poll = w.config.Clock.After(w.config.PollInterval)
+	}
+}
+
+// update syncs the tags of the instances whose tags have changed since
+// they were last synced. Instances that cannot be synced are retried
+// on the next update.
+func (w *syncWorker) update() error {
+	instances, err := w.config.Facade.InstanceTags()
+	if err != nil {
+		return errors.Annotate(err, "getting instance tags")
+	}
+	current := make(map[instance.Id]bool)
+	for _, inst := range instances {
+		current[inst.InstanceId] = true
+		synced, ok := w.synced[inst.InstanceId]
+		if ok && reflect.DeepEqual(synced, inst.Tags) {
+			continue
+		}
+		if err := w.syncInstance(inst); err != nil {
+			logger.Errorf("cannot tag instance %q of machine %q: %v", inst.InstanceId, inst.MachineId, err)
+			continue
+		}
+		w.synced[inst.InstanceId] = inst.Tags
+	}
+	for id := range w.synced {
+		if !current[id] {
+			delete(w.synced, id)
+		}
+	}
+	return nil
+}
+
+// syncInstance sets the tags defined by annotations on the instance,
+// and removes the annotation tags that the instance has, but that are
+// no longer defined.
+func (w *syncWorker) syncInstance(inst tagsync.InstanceTags) error {
+	have, err := w.config.Tagger.InstanceTags(inst.InstanceId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	set := make(map[string]string)
+	for name, value := range inst.Tags {
+		if current, ok := have[name]; !ok || current != value {
+			set[name] = value
+		}
+	}
+	var stale []string
+	for name := range have {
+		if _, ok := inst.Tags[name]; !ok && strings.HasPrefix(name, tags.JujuAnnotationTagPrefix) {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+
+	if len(set) > 0 {
+		if err := w.config.Tagger.TagInstance(inst.InstanceId, set); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("set tags %v of instance %q", set, inst.InstanceId)
+	}
+	if len(stale) > 0 {
+		if err := w.config.Tagger.UntagInstance(inst.InstanceId, stale); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("removed tags %v of instance %q", stale, inst.InstanceId)
+	}
+	return nil
+}
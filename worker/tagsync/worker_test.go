@@ -0,0 +1,271 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagsync_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitagsync "github.com/juju/juju/api/tagsync"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/tagsync"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	clock  *jujutesting.Clock
+	facade *fakeFacade
+	tagger *fakeTagger
+	config tagsync.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.clock = jujutesting.NewClock(time.Time{})
+	s.facade = &fakeFacade{
+		watcher: notAWatcher{workertest.NewFakeWatcher(2, 1)},
+		instances: []apitagsync.InstanceTags{{
+			MachineId:  "0",
+			InstanceId: "i-0",
+			Tags:       map[string]string{"juju-annotation-owner": "bob"},
+		}, {
+			MachineId:  "1",
+			InstanceId: "i-1",
+		}},
+	}
+	// Instance i-1 has a tag from an annotation that has since
+	// been removed.
+	s.tagger = &fakeTagger{
+		stub: s.stub,
+		tags: map[instance.Id]map[string]string{
+			"i-0": {"Name": "juju-machine-0"},
+			"i-1": {"Name": "juju-machine-1", "juju-annotation-owner": "alice"},
+		},
+	}
+	s.config = tagsync.Config{
+		Facade:       s.facade,
+		Tagger:       s.tagger,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	for i, test := range []struct {
+		update func(*tagsync.Config)
+		err    string
+	}{{
+		update: func(config *tagsync.Config) { config.Facade = nil },
+		err:    "nil Facade not valid",
+	}, {
+		update: func(config *tagsync.Config) { config.Tagger = nil },
+		err:    "nil Tagger not valid",
+	}, {
+		update: func(config *tagsync.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		update: func(config *tagsync.Config) { config.PollInterval = 0 },
+		err:    "non-positive PollInterval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := tagsync.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestSetsTags(c *gc.C) {
+	w, err := tagsync.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPoll(c, 0)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"InstanceTags", []interface{}{instance.Id("i-0")}},
+		{"TagInstance", []interface{}{instance.Id("i-0"), map[string]string{"juju-annotation-owner": "bob"}}},
+		{"InstanceTags", []interface{}{instance.Id("i-1")}},
+		{"UntagInstance", []interface{}{instance.Id("i-1"), []string{"juju-annotation-owner"}}},
+	})
+
+	// Unchanged tags are not synced again.
+	s.stub.ResetCalls()
+	s.waitPoll(c, time.Minute)
+	s.stub.CheckNoCalls(c)
+
+	s.facade.setInstances([]apitagsync.InstanceTags{{
+		MachineId:  "0",
+		InstanceId: "i-0",
+		Tags:       map[string]string{"juju-annotation-tier": "web"},
+	}, {
+		MachineId:  "1",
+		InstanceId: "i-1",
+	}})
+	s.facade.watcher.Ping()
+	s.waitCalls(c, 3)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"InstanceTags", []interface{}{instance.Id("i-0")}},
+		{"TagInstance", []interface{}{instance.Id("i-0"), map[string]string{"juju-annotation-tier": "web"}}},
+		{"UntagInstance", []interface{}{instance.Id("i-0"), []string{"juju-annotation-owner"}}},
+	})
+	c.Assert(s.tagger.instanceTags("i-0"), jc.DeepEquals, map[string]string{
+		"Name":                 "juju-machine-0",
+		"juju-annotation-tier": "web",
+	})
+}
+
+func (s *WorkerSuite) TestKeepsTagsAlreadySet(c *gc.C) {
+	s.tagger.tags["i-0"]["juju-annotation-owner"] = "bob"
+	w, err := tagsync.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPoll(c, 0)
+	s.stub.CheckCallNames(c, "InstanceTags", "InstanceTags", "UntagInstance")
+}
+
+func (s *WorkerSuite) TestRetriesFailedTagging(c *gc.C) {
+	s.stub.SetErrors(errors.New("throttled"))
+	w, err := tagsync.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPoll(c, 0)
+	s.stub.CheckCallNames(c, "InstanceTags", "InstanceTags", "UntagInstance")
+
+	s.stub.ResetCalls()
+	s.waitPoll(c, time.Minute)
+	s.stub.CheckCallNames(c, "InstanceTags", "TagInstance")
+}
+
+func (s *WorkerSuite) TestInstanceTagsError(c *gc.C) {
+	s.facade.setInstancesErr(errors.New("boom"))
+	w, err := tagsync.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting instance tags: boom")
+}
+
+// waitPoll advances the clock by d, and waits for the worker to
+// finish the next update and wait to poll again.
+func (s *WorkerSuite) waitPoll(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	if d > 0 {
+		err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *WorkerSuite) waitCalls(c *gc.C, n int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.stub.Calls()) >= n {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d calls", n)
+}
+
+type notAWatcher struct {
+	workertest.NotAWatcher
+}
+
+func (w notAWatcher) Changes() watcher.NotifyChannel {
+	return w.NotAWatcher.Changes()
+}
+
+type fakeFacade struct {
+	watcher notAWatcher
+
+	mu           sync.Mutex
+	instances    []apitagsync.InstanceTags
+	instancesErr error
+}
+
+func (f *fakeFacade) setInstances(instances []apitagsync.InstanceTags) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.instances = instances
+}
+
+func (f *fakeFacade) setInstancesErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.instancesErr = err
+}
+
+func (f *fakeFacade) WatchAnnotations() (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *fakeFacade) InstanceTags() ([]apitagsync.InstanceTags, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.instances, f.instancesErr
+}
+
+type fakeTagger struct {
+	stub *jujutesting.Stub
+
+	mu   sync.Mutex
+	tags map[instance.Id]map[string]string
+}
+
+func (t *fakeTagger) instanceTags(id instance.Id) map[string]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tags := make(map[string]string)
+	for name, value := range t.tags[id] {
+		tags[name] = value
+	}
+	return tags
+}
+
+func (t *fakeTagger) InstanceTags(id instance.Id) (map[string]string, error) {
+	t.stub.AddCall("InstanceTags", id)
+	if err := t.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return t.instanceTags(id), nil
+}
+
+func (t *fakeTagger) TagInstance(id instance.Id, tags map[string]string) error {
+	t.stub.AddCall("TagInstance", id, tags)
+	if err := t.stub.NextErr(); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for name, value := range tags {
+		t.tags[id][name] = value
+	}
+	return nil
+}
+
+func (t *fakeTagger) UntagInstance(id instance.Id, names []string) error {
+	t.stub.AddCall("UntagInstance", id, names)
+	if err := t.stub.NextErr(); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, name := range names {
+		delete(t.tags[id], name)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package txnresolver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.txnresolver")
+
+// Backend defines the interface for types capable of finding and
+// resuming stuck transactions.
+type Backend interface {
+	StuckTransactions(startedBefore time.Time) ([]state.StuckTransaction, error)
+	ResumeTransaction(id string) error
+}
+
+// New returns a worker which periodically looks for transactions that
+// have been incomplete for longer than the given age, and attempts to
+// resume them. A single stuck transaction blocks every later
+// transaction on the documents it touches, which can leave a model
+// unable to make progress. Transactions that cannot be resumed are
+// logged, and are reported by "juju controller-txns".
+func New(backend Backend, age, interval time.Duration, clock clock.Clock) worker.Worker {
+	return jworker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		for {
+			select {
+			case <-clock.After(interval):
+				if err := resolve(backend, clock.Now().Add(-age)); err != nil {
+					return errors.Annotate(err, "txnresolver stopping")
+				}
+			case <-stopCh:
+				return nil
+			}
+		}
+	})
+}
+
+func resolve(backend Backend, startedBefore time.Time) error {
+	stuck, err := backend.StuckTransactions(startedBefore)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, txn := range stuck {
+		if err := backend.ResumeTransaction(txn.Id); err != nil {
+			logger.Warningf(
+				"cannot resume transaction %s (%s since %s): %v",
+				txn.Id, txn.State, txn.Started.Format(time.RFC3339), err,
+			)
+			continue
+		}
+		logger.Infof(
+			"resumed transaction %s (%s since %s)",
+			txn.Id, txn.State, txn.Started.Format(time.RFC3339),
+		)
+	}
+	return nil
+}
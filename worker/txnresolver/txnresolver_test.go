@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package txnresolver_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/txnresolver"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type TxnResolverSuite struct {
+	coretesting.BaseSuite
+	clock   *testing.Clock
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&TxnResolverSuite{})
+
+func (s *TxnResolverSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		called: make(chan struct{}, 10),
+		stuck: []state.StuckTransaction{
+			{Id: "a", State: "prepared"},
+			{Id: "b", State: "applying"},
+		},
+	}
+}
+
+func (s *TxnResolverSuite) waitForCall(c *gc.C) {
+	select {
+	case <-s.backend.called:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for stuck transactions to be resolved")
+	}
+}
+
+func (s *TxnResolverSuite) TestResumesStuckTransactions(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	w := txnresolver.New(s.backend, 10*time.Minute, time.Minute, s.clock)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForCall(c)
+
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"StuckTransactions", []interface{}{time.Date(2017, 11, 1, 11, 51, 0, 0, time.UTC)}},
+		{"ResumeTransaction", []interface{}{"a"}},
+		{"ResumeTransaction", []interface{}{"b"}},
+	})
+}
+
+func (s *TxnResolverSuite) TestRepeats(c *gc.C) {
+	w := txnresolver.New(s.backend, 10*time.Minute, time.Minute, s.clock)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 3; i++ {
+		err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		s.waitForCall(c)
+	}
+}
+
+func (s *TxnResolverSuite) TestStuckTransactionsError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	w := txnresolver.New(s.backend, 10*time.Minute, time.Minute, s.clock)
+	defer workertest.DirtyKill(c, w)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "txnresolver stopping: boom")
+}
+
+type fakeBackend struct {
+	testing.Stub
+	stuck  []state.StuckTransaction
+	called chan struct{}
+}
+
+func (b *fakeBackend) StuckTransactions(startedBefore time.Time) ([]state.StuckTransaction, error) {
+	b.MethodCall(b, "StuckTransactions", startedBefore)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.stuck, nil
+}
+
+func (b *fakeBackend) ResumeTransaction(id string) error {
+	b.MethodCall(b, "ResumeTransaction", id)
+	err := b.NextErr()
+	if id == b.stuck[len(b.stuck)-1].Id {
+		b.called <- struct{}{}
+	}
+	return err
+}
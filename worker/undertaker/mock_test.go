@@ -71,6 +71,15 @@ func (mock *mockEnviron) Destroy() error {
 	return mock.stub.NextErr()
 }
 
+type mockForceDestroyer struct {
+	mockEnviron
+}
+
+func (mock *mockForceDestroyer) DestroyForce() error {
+	mock.stub.AddCall("DestroyForce")
+	return mock.stub.NextErr()
+}
+
 type mockWatcher struct {
 	worker.Worker
 	changes chan struct{}
@@ -84,6 +93,7 @@ type fixture struct {
 	info   params.UndertakerModelInfoResult
 	errors []error
 	dirty  bool
+	force  bool
 }
 
 func (fix fixture) cleanup(c *gc.C, w worker.Worker) {
@@ -96,9 +106,12 @@ func (fix fixture) cleanup(c *gc.C, w worker.Worker) {
 
 func (fix fixture) run(c *gc.C, test func(worker.Worker)) *testing.Stub {
 	stub := &testing.Stub{}
-	environ := &mockEnviron{
+	var environ environs.Environ = &mockEnviron{
 		stub: stub,
 	}
+	if fix.force {
+		environ = &mockForceDestroyer{mockEnviron{stub: stub}}
+	}
 	facade := &mockFacade{
 		stub: stub,
 		info: fix.info,
@@ -131,8 +131,20 @@ func (u *Undertaker) run() error {
 	); err != nil {
 		return errors.Trace(err)
 	}
-	if err := u.config.Environ.Destroy(); err != nil {
-		return errors.Trace(err)
+	destroy := u.config.Environ.Destroy
+	if modelInfo.ForceDestroyed {
+		if forceDestroyer, ok := u.config.Environ.(environs.ForceDestroyer); ok {
+			destroy = forceDestroyer.DestroyForce
+		}
+	}
+	if destroyErr := destroy(); destroyErr != nil {
+		// Record why the environment could not be torn down, so
+		// that it is reported to anyone waiting for the model to
+		// be removed. The worker will be restarted to try again.
+		if err := u.setStatus(status.Error, destroyErr.Error()); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(destroyErr)
 	}
 
 	// Finally, remove the model.
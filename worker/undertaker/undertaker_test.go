@@ -174,7 +174,27 @@ func (s *UndertakerSuite) TestDestroyErrorFatal(c *gc.C) {
 		err := workertest.CheckKilled(c, w)
 		c.Check(err, gc.ErrorMatches, "pow")
 	})
-	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "Destroy")
+	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "Destroy", "SetStatus")
+	stub.CheckCall(c, 3, "SetStatus", status.Error, "pow", map[string]interface{}(nil))
+}
+
+func (s *UndertakerSuite) TestForceDestroyed(c *gc.C) {
+	s.fix.info.Result.Life = "dead"
+	s.fix.info.Result.ForceDestroyed = true
+	s.fix.force = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "DestroyForce", "RemoveModel")
+}
+
+func (s *UndertakerSuite) TestForceDestroyedNotSupported(c *gc.C) {
+	s.fix.info.Result.Life = "dead"
+	s.fix.info.Result.ForceDestroyed = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "Destroy", "RemoveModel")
 }
 
 func (s *UndertakerSuite) TestRemoveModelErrorFatal(c *gc.C) {
@@ -16,6 +16,7 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+	FloatingIPChanged     hooks.Kind = "floating-ip-changed"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -58,7 +59,7 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, FloatingIPChanged:
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
@@ -50,6 +50,7 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.FloatingIPChanged}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/faultinject"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -92,7 +93,10 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
-	err := rh.runner.RunHook(rh.name)
+	err := faultinject.Inject(faultinject.HookDispatch)
+	if err == nil {
+		err = rh.runner.RunHook(rh.name)
+	}
 	cause := errors.Cause(err)
 	switch {
 	case context.IsMissingHookError(cause):
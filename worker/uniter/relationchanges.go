@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultRelationChangesWindow is the length of time for which the
+// uniter merges bursts of relation settings changes, unless the
+// deployed charm opts out.
+const DefaultRelationChangesWindow = 2 * time.Second
+
+// NewRelationChangesWindow returns a func returning the length of time
+// for which relation settings changes should be merged before running
+// relation-changed hooks for the charm deployed in charmDir. Charms
+// that need to see every intermediate settings change may opt out by
+// declaring
+//
+//	coalesce-relation-changes: false
+//
+// in their metadata.yaml.
+func NewRelationChangesWindow(charmDir string) func() time.Duration {
+	return func() time.Duration {
+		data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+		if err != nil {
+			// The charm may not have been deployed yet; there
+			// are no relations to coalesce changes for then.
+			return DefaultRelationChangesWindow
+		}
+		var meta struct {
+			CoalesceRelationChanges *bool `yaml:"coalesce-relation-changes"`
+		}
+		if err := yaml.Unmarshal(data, &meta); err != nil {
+			logger.Warningf("cannot read charm metadata: %v", err)
+			return DefaultRelationChangesWindow
+		}
+		if meta.CoalesceRelationChanges != nil && !*meta.CoalesceRelationChanges {
+			return 0
+		}
+		return DefaultRelationChangesWindow
+	}
+}
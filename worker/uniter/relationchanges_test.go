@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter"
+)
+
+type relationChangesWindowSuite struct{}
+
+var _ = gc.Suite(&relationChangesWindowSuite{})
+
+func (s *relationChangesWindowSuite) TestWindow(c *gc.C) {
+	for i, test := range []struct {
+		metadata string
+		expect   time.Duration
+	}{{
+		metadata: "name: wordpress\n",
+		expect:   uniter.DefaultRelationChangesWindow,
+	}, {
+		metadata: "name: wordpress\ncoalesce-relation-changes: true\n",
+		expect:   uniter.DefaultRelationChangesWindow,
+	}, {
+		metadata: "name: wordpress\ncoalesce-relation-changes: false\n",
+		expect:   0,
+	}, {
+		metadata: "][",
+		expect:   uniter.DefaultRelationChangesWindow,
+	}} {
+		c.Logf("test %d", i)
+		charmDir := c.MkDir()
+		err := ioutil.WriteFile(filepath.Join(charmDir, "metadata.yaml"), []byte(test.metadata), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+		window := uniter.NewRelationChangesWindow(charmDir)
+		c.Check(window(), gc.Equals, test.expect)
+	}
+}
+
+func (s *relationChangesWindowSuite) TestWindowNoCharm(c *gc.C) {
+	window := uniter.NewRelationChangesWindow(filepath.Join(c.MkDir(), "charm"))
+	c.Assert(window(), gc.Equals, uniter.DefaultRelationChangesWindow)
+}
+
+func (s *relationChangesWindowSuite) TestWindowReadsCurrentCharm(c *gc.C) {
+	charmDir := c.MkDir()
+	window := uniter.NewRelationChangesWindow(charmDir)
+	err := ioutil.WriteFile(filepath.Join(charmDir, "metadata.yaml"), []byte("coalesce-relation-changes: false\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window(), gc.Equals, time.Duration(0))
+}
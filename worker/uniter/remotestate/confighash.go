@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remotestate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// ConfigSettingsHash returns a hash of the given charm config
+// settings. The hash is the same for all units that see the same
+// settings, so the leader can report which settings it is ready
+// for and followers can tell whether they have the same ones.
+func ConfigSettingsHash(settings charm.Settings) (string, error) {
+	// Maps are marshalled with sorted keys, so the
+	// encoding does not depend on iteration order.
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
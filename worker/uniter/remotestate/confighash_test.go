@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remotestate_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/worker/uniter/remotestate"
+)
+
+type ConfigHashSuite struct{}
+
+var _ = gc.Suite(&ConfigHashSuite{})
+
+func (s *ConfigHashSuite) TestConfigSettingsHash(c *gc.C) {
+	hash1, err := remotestate.ConfigSettingsHash(charm.Settings{"a": "foo", "b": int64(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	hash2, err := remotestate.ConfigSettingsHash(charm.Settings{"b": int64(1), "a": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hash1, gc.Equals, hash2)
+	c.Assert(hash1, gc.HasLen, 64)
+
+	hash3, err := remotestate.ConfigSettingsHash(charm.Settings{"a": "bar", "b": int64(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hash3, gc.Not(gc.Equals), hash1)
+}
@@ -103,6 +103,7 @@ type mockState struct {
 	storageAttachment         map[params.StorageAttachmentId]params.StorageAttachment
 	relationUnitsWatchers     map[names.RelationTag]*mockRelationUnitsWatcher
 	storageAttachmentWatchers map[names.StorageTag]*mockNotifyWatcher
+	leaderFirstConfigChanged  bool
 }
 
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
@@ -186,12 +187,17 @@ func (st *mockState) UpdateStatusHookInterval() (time.Duration, error) {
 	return 5 * time.Minute, nil
 }
 
+func (st *mockState) LeaderFirstConfigChanged() (bool, error) {
+	return st.leaderFirstConfigChanged, nil
+}
+
 type mockUnit struct {
 	tag                   names.UnitTag
 	life                  params.Life
 	resolved              params.ResolvedMode
 	series                string
 	application           mockApplication
+	configSettings        charm.Settings
 	unitWatcher           *mockNotifyWatcher
 	addressesWatcher      *mockNotifyWatcher
 	configSettingsWatcher *mockNotifyWatcher
@@ -216,6 +222,10 @@ func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
 
+func (u *mockUnit) ConfigSettings() (charm.Settings, error) {
+	return u.configSettings, nil
+}
+
 func (u *mockUnit) Series() string {
 	return u.series
 }
@@ -254,6 +264,8 @@ type mockApplication struct {
 	curl                  *charm.URL
 	charmModifiedVersion  int
 	forceUpgrade          bool
+	floatingIPUnit        string
+	configReadyHash       string
 	applicationWatcher    *mockNotifyWatcher
 	leaderSettingsWatcher *mockNotifyWatcher
 }
@@ -266,6 +278,14 @@ func (s *mockApplication) CharmURL() (*charm.URL, bool, error) {
 	return s.curl, s.forceUpgrade, nil
 }
 
+func (s *mockApplication) ConfigReadyHash() (string, error) {
+	return s.configReadyHash, nil
+}
+
+func (s *mockApplication) FloatingIPUnit() (string, error) {
+	return s.floatingIPUnit, nil
+}
+
 func (s *mockApplication) Life() params.Life {
 	return s.life
 }
@@ -4,7 +4,10 @@
 package remotestate
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/watcher"
@@ -16,6 +19,8 @@ type relationUnitsWatcher struct {
 	relationId int
 	changes    watcher.RelationUnitsChannel
 	out        chan<- relationUnitsChange
+	clock      clock.Clock
+	window     func() time.Duration
 }
 
 type relationUnitsChange struct {
@@ -27,17 +32,27 @@ type relationUnitsChange struct {
 // supplied watcher's Changes chan, annotates them with the supplied relation
 // id, and delivers then on the supplied out chan.
 //
+// If the supplied window function returns a positive duration when a
+// change arrives, the change is held back for that long, and any
+// further changes that arrive in the meantime are merged into it, so
+// that a burst of settings changes is delivered as a single change
+// holding the final settings versions.
+//
 // The caller releases responsibility for stopping the supplied watcher and
 // waiting for errors, *whether or not this method succeeds*.
 func newRelationUnitsWatcher(
 	relationId int,
 	watcher watcher.RelationUnitsWatcher,
 	out chan<- relationUnitsChange,
+	clock clock.Clock,
+	window func() time.Duration,
 ) (*relationUnitsWatcher, error) {
 	ruw := &relationUnitsWatcher{
 		relationId: relationId,
 		changes:    watcher.Changes(),
 		out:        out,
+		clock:      clock,
+		window:     window,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &ruw.catacomb,
@@ -61,6 +76,11 @@ func (w *relationUnitsWatcher) Wait() error {
 }
 
 func (w *relationUnitsWatcher) loop() error {
+	var (
+		pending relationUnitsChange
+		out     chan<- relationUnitsChange
+		timeout <-chan time.Time
+	)
 	for {
 		select {
 		case <-w.catacomb.Dying():
@@ -69,11 +89,54 @@ func (w *relationUnitsWatcher) loop() error {
 			if !ok {
 				return errors.New("watcher closed channel")
 			}
-			select {
-			case <-w.catacomb.Dying():
-				return w.catacomb.ErrDying()
-			case w.out <- relationUnitsChange{w.relationId, change}:
+			if pending.Changed == nil {
+				pending = relationUnitsChange{
+					relationId: w.relationId,
+					RelationUnitsChange: watcher.RelationUnitsChange{
+						Changed: make(map[string]watcher.UnitSettings),
+					},
+				}
+				if window := w.window(); window > 0 {
+					timeout = w.clock.After(window)
+				} else {
+					out = w.out
+				}
 			}
+			mergeRelationUnitsChange(&pending.RelationUnitsChange, change)
+		case <-timeout:
+			timeout = nil
+			out = w.out
+		case out <- pending:
+			pending = relationUnitsChange{}
+			out = nil
+		}
+	}
+}
+
+// mergeRelationUnitsChange merges the later change into the pending one,
+// so that the pending change records the latest settings version of each
+// unit that has joined or changed, and each unit that has departed.
+func mergeRelationUnitsChange(pending *watcher.RelationUnitsChange, change watcher.RelationUnitsChange) {
+	for unit, settings := range change.Changed {
+		pending.Changed[unit] = settings
+		for i, departed := range pending.Departed {
+			if departed == unit {
+				pending.Departed = append(pending.Departed[:i], pending.Departed[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, unit := range change.Departed {
+		delete(pending.Changed, unit)
+		found := false
+		for _, departed := range pending.Departed {
+			if departed == unit {
+				found = true
+				break
+			}
+		}
+		if !found {
+			pending.Departed = append(pending.Departed, unit)
 		}
 	}
 }
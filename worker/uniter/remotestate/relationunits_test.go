@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remotestate_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/uniter/remotestate"
+)
+
+type mergeRelationUnitsChangeSuite struct{}
+
+var _ = gc.Suite(&mergeRelationUnitsChangeSuite{})
+
+func (s *mergeRelationUnitsChangeSuite) TestMerge(c *gc.C) {
+	pending := watcher.RelationUnitsChange{
+		Changed: make(map[string]watcher.UnitSettings),
+	}
+	for _, change := range []watcher.RelationUnitsChange{{
+		Changed: map[string]watcher.UnitSettings{"mysql/0": {1}, "mysql/1": {1}},
+	}, {
+		Changed:  map[string]watcher.UnitSettings{"mysql/0": {2}},
+		Departed: []string{"mysql/1", "mysql/2"},
+	}, {
+		Changed:  map[string]watcher.UnitSettings{"mysql/2": {5}},
+		Departed: []string{"mysql/1"},
+	}} {
+		remotestate.MergeRelationUnitsChange(&pending, change)
+	}
+	c.Assert(pending, jc.DeepEquals, watcher.RelationUnitsChange{
+		Changed:  map[string]watcher.UnitSettings{"mysql/0": {2}, "mysql/2": {5}},
+		Departed: []string{"mysql/1"},
+	})
+}
@@ -49,6 +49,21 @@ type Snapshot struct {
 	// the unit's config settings.
 	ConfigVersion int
 
+	// ConfigHash is the hash of the unit's charm config
+	// settings. It is only set when LeaderFirstConfigChanged
+	// is true.
+	ConfigHash string
+
+	// ConfigReadyHash is the hash of the charm config settings
+	// that the application's leader last reported itself ready
+	// for by running config-ready.
+	ConfigReadyHash string
+
+	// LeaderFirstConfigChanged reports whether followers should
+	// defer their config-changed hooks until the leader reports
+	// it is ready for the current config settings.
+	LeaderFirstConfigChanged bool
+
 	// Leader indicates whether or not the unit is the
 	// elected leader.
 	Leader bool
@@ -57,6 +72,10 @@ type Snapshot struct {
 	// version of the leader settings for the application.
 	LeaderSettingsVersion int
 
+	// FloatingIPUnit is the name of the unit whose machine the
+	// application's floating IP address is associated with.
+	FloatingIPUnit string
+
 	// UpdateStatusVersion increments each time an
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
@@ -28,6 +28,7 @@ type State interface {
 	WatchRelationUnits(names.RelationTag, names.UnitTag) (watcher.RelationUnitsWatcher, error)
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) (watcher.NotifyWatcher, error)
 	UpdateStatusHookInterval() (time.Duration, error)
+	LeaderFirstConfigChanged() (bool, error)
 }
 
 type Unit interface {
@@ -35,6 +36,7 @@ type Unit interface {
 	Refresh() error
 	Resolved() params.ResolvedMode
 	Application() (Application, error)
+	ConfigSettings() (charm.Settings, error)
 	Series() string
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
@@ -53,6 +55,12 @@ type Application interface {
 	CharmModifiedVersion() (int, error)
 	// CharmURL returns the url for the charm for this service.
 	CharmURL() (*charm.URL, bool, error)
+	// ConfigReadyHash returns the hash of the charm config settings
+	// that the service's leader last reported itself ready for.
+	ConfigReadyHash() (string, error)
+	// FloatingIPUnit returns the name of the unit whose machine the
+	// service's floating IP address is associated with.
+	FloatingIPUnit() (string, error)
 	// Life returns whether the service is alive.
 	Life() params.Life
 	// Refresh syncs this value with the api server.
@@ -5,9 +5,11 @@ package remotestate
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
@@ -35,6 +37,8 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       UpdateStatusTimerFunc
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	clock                     clock.Clock
+	relationChangesWindow     func() time.Duration
 
 	catacomb catacomb.Catacomb
 
@@ -52,11 +56,30 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// Clock is used to time the relation changes window. If it is
+	// nil, the wall clock is used.
+	Clock clock.Clock
+
+	// RelationChangesWindow, if non-nil, returns the length of time
+	// for which relation units changes are held back so that bursts
+	// of changes are merged, and run as a single relation-changed
+	// hook with the final settings. It is called at the start of
+	// each burst; if it returns zero, changes are not held back.
+	RelationChangesWindow func() time.Duration
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
 // supplied unit.
 func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	window := config.RelationChangesWindow
+	if window == nil {
+		window = func() time.Duration { return 0 }
+	}
 	w := &RemoteStateWatcher{
 		st:                        config.State,
 		relations:                 make(map[names.RelationTag]*relationUnitsWatcher),
@@ -67,6 +90,8 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		clock:                     clk,
+		relationChangesWindow:     window,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -499,17 +524,43 @@ func (w *RemoteStateWatcher) applicationChanged() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	floatingIPUnit, err := w.service.FloatingIPUnit()
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
+	configReadyHash, err := w.service.ConfigReadyHash()
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
 	w.mu.Lock()
 	w.current.CharmURL = url
 	w.current.ForceCharmUpgrade = force
 	w.current.CharmModifiedVersion = ver
+	w.current.FloatingIPUnit = floatingIPUnit
+	w.current.ConfigReadyHash = configReadyHash
 	w.mu.Unlock()
 	return nil
 }
 
 func (w *RemoteStateWatcher) configChanged() error {
+	leaderFirst, err := w.st.LeaderFirstConfigChanged()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var hash string
+	if leaderFirst {
+		settings, err := w.unit.ConfigSettings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if hash, err = ConfigSettingsHash(settings); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	w.mu.Lock()
 	w.current.ConfigVersion++
+	w.current.ConfigHash = hash
+	w.current.LeaderFirstConfigChanged = leaderFirst
 	w.mu.Unlock()
 	return nil
 }
@@ -613,7 +664,9 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 			relationSnapshot.Members[unit] = settings.Version
 		}
 	}
-	innerRUW, err := newRelationUnitsWatcher(rel.Id(), ruw, w.relationUnitsChanges)
+	innerRUW, err := newRelationUnitsWatcher(
+		rel.Id(), ruw, w.relationUnitsChanges, w.clock, w.relationChangesWindow,
+	)
 	if err != nil {
 		return errors.Trace(err)
 	}
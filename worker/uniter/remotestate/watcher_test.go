@@ -195,6 +195,16 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ForceCharmUpgrade, jc.IsTrue)
 
+	s.st.unit.application.floatingIPUnit = "mysql/1"
+	s.st.unit.application.applicationWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().FloatingIPUnit, gc.Equals, "mysql/1")
+
+	s.st.unit.application.configReadyHash = "abc"
+	s.st.unit.application.applicationWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().ConfigReadyHash, gc.Equals, "abc")
+
 	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().LeaderSettingsVersion, gc.Equals, initial.LeaderSettingsVersion+1)
@@ -215,6 +225,34 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestConfigHashLeaderFirst(c *gc.C) {
+	s.st.leaderFirstConfigChanged = true
+	s.st.unit.configSettings = charm.Settings{"blog-title": "foo"}
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	hash, err := remotestate.ConfigSettingsHash(charm.Settings{"blog-title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	snap := s.watcher.Snapshot()
+	c.Assert(snap.LeaderFirstConfigChanged, jc.IsTrue)
+	c.Assert(snap.ConfigHash, gc.Equals, hash)
+
+	s.st.unit.configSettings = charm.Settings{"blog-title": "bar"}
+	s.st.unit.configSettingsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().ConfigHash, gc.Not(gc.Equals), hash)
+}
+
+func (s *WatcherSuite) TestConfigHashNotLeaderFirst(c *gc.C) {
+	s.st.unit.configSettings = charm.Settings{"blog-title": "foo"}
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	snap := s.watcher.Snapshot()
+	c.Assert(snap.LeaderFirstConfigChanged, jc.IsFalse)
+	c.Assert(snap.ConfigHash, gc.Equals, "")
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	signalAll(s.st, s.leadership)
@@ -611,3 +649,94 @@ func (s *WatcherSuite) waitAlarmsStable(c *gc.C) {
 		}
 	}
 }
+
+func (s *WatcherSuite) TestRelationUnitsChangesCoalesced(c *gc.C) {
+	relationClock := testing.NewClock(time.Now())
+	s.restartWatcher(c, relationClock, func() time.Duration { return 5 * time.Second })
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:peer")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Alive,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+
+	// The initial change is never held back.
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {1}, "mysql/2": {1}},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	for _, change := range []watcher.RelationUnitsChange{{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {2}},
+	}, {
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {3}, "mysql/3": {1}},
+	}, {
+		Departed: []string{"mysql/2"},
+	}} {
+		s.st.relationUnitsWatchers[relationTag].changes <- change
+	}
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+	c.Assert(
+		s.watcher.Snapshot().Relations[123].Members,
+		jc.DeepEquals,
+		map[string]int64{"mysql/1": 1, "mysql/2": 1},
+	)
+
+	err := relationClock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(
+		s.watcher.Snapshot().Relations[123].Members,
+		jc.DeepEquals,
+		map[string]int64{"mysql/1": 3, "mysql/3": 1},
+	)
+}
+
+func (s *WatcherSuite) TestRelationUnitsChangesNotCoalescedWithZeroWindow(c *gc.C) {
+	relationClock := testing.NewClock(time.Now())
+	s.restartWatcher(c, relationClock, func() time.Duration { return 0 })
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:peer")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Alive,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {1}},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {2}},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(
+		s.watcher.Snapshot().Relations[123].Members,
+		jc.DeepEquals,
+		map[string]int64{"mysql/1": 2},
+	)
+}
+
+func (s *WatcherSuite) restartWatcher(c *gc.C, clock *testing.Clock, window func() time.Duration) {
+	s.watcher.Kill()
+	err := s.watcher.Wait()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.watcher, err = remotestate.NewWatcher(remotestate.WatcherConfig{
+		State:             s.st,
+		LeadershipTracker: s.leadership,
+		UnitTag:           s.st.unit.tag,
+		UpdateStatusChannel: func(wait time.Duration) remotestate.Waiter {
+			return dummyWaiter{s.clock.After(statusTickDuration)}
+		},
+		Clock:                 clock,
+		RelationChangesWindow: window,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -203,6 +203,16 @@ func (s *uniterResolver) nextOpHookError(
 	}
 }
 
+// awaitingLeaderConfig reports whether a started follower should defer
+// its config-changed hook until the application's leader has reported
+// that it is ready for the unit's current config settings.
+func awaitingLeaderConfig(local resolver.LocalState, remote remotestate.Snapshot) bool {
+	if !remote.LeaderFirstConfigChanged || remote.Leader || !local.Started {
+		return false
+	}
+	return remote.ConfigHash != remote.ConfigReadyHash
+}
+
 func charmModified(local resolver.LocalState, remote remotestate.Snapshot) bool {
 	if *local.CharmURL != *remote.CharmURL {
 		logger.Debugf("upgrade from %v to %v", local.CharmURL, remote.CharmURL)
@@ -260,10 +270,19 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewUpgrade(remoteState.CharmURL)
 	}
 
-	if localState.ConfigVersion != remoteState.ConfigVersion ||
-		localState.Series != remoteState.Series {
+	if localState.Series != remoteState.Series {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
 	}
+	if localState.ConfigVersion != remoteState.ConfigVersion {
+		if !awaitingLeaderConfig(localState, remoteState) {
+			return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		}
+		logger.Debugf("deferring config-changed until the leader is ready")
+	}
+
+	if localState.FloatingIPUnit != remoteState.FloatingIPUnit {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.FloatingIPChanged})
+	}
 
 	op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
@@ -90,6 +90,11 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// FloatingIPUnit is the name of the unit, from remotestate.Snapshot,
+	// holding the application's floating IP address for which a
+	// floating-ip-changed hook has been committed.
+	FloatingIPUnit string
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
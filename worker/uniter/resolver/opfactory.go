@@ -122,6 +122,11 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.FloatingIPChanged:
+		unitName := s.RemoteState.FloatingIPUnit
+		op = onCommitWrapper{op, func() {
+			s.LocalState.FloatingIPUnit = unitName
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
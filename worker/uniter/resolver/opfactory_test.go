@@ -129,6 +129,30 @@ func (s *ResolverOpFactorySuite) testLeaderSettingsChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 3)
 }
 
+func (s *ResolverOpFactorySuite) TestFloatingIPChanged(c *gc.C) {
+	s.testFloatingIPChanged(c, resolver.ResolverOpFactory.NewRunHook)
+	s.testFloatingIPChanged(c, resolver.ResolverOpFactory.NewSkipHook)
+}
+
+func (s *ResolverOpFactorySuite) testFloatingIPChanged(
+	c *gc.C, meth func(resolver.ResolverOpFactory, hook.Info) (operation.Operation, error),
+) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.FloatingIPUnit = "mysql/0"
+
+	op, err := meth(f, hook.Info{Kind: hook.FloatingIPChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.FloatingIPUnit = "mysql/1"
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Local state's FloatingIPUnit should be set to what
+	// RemoteState's FloatingIPUnit was when the operation
+	// was constructed.
+	c.Assert(f.LocalState.FloatingIPUnit, gc.Equals, "mysql/0")
+}
+
 func (s *ResolverOpFactorySuite) TestUpgrade(c *gc.C) {
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewUpgrade)
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewRevertUpgrade)
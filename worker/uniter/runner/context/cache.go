@@ -15,6 +15,10 @@ type SettingsFunc func(unitName string) (params.Settings, error)
 // SettingsMap is a map from unit name to relation settings.
 type SettingsMap map[string]params.Settings
 
+// BulkSettingsFunc returns the relation settings for several units.
+// Units whose settings cannot be read are omitted from the result.
+type BulkSettingsFunc func(unitNames []string) (map[string]params.Settings, error)
+
 // RelationCache stores a relation's remote unit membership and settings.
 // Member settings are stored until invalidated or removed by name; settings
 // of non-member units are stored only until the cache is pruned.
@@ -95,3 +99,33 @@ func (cache *RelationCache) InvalidateMember(memberName string) {
 func (cache *RelationCache) RemoveMember(memberName string) {
 	delete(cache.members, memberName)
 }
+
+// Prefetch reads the settings of all the members whose settings are not
+// already cached, using a single call to readSettings, so that they can
+// later be got without further calls.
+func (cache *RelationCache) Prefetch(readSettings BulkSettingsFunc) error {
+	var unitNames []string
+	for _, memberName := range cache.MemberNames() {
+		if cache.members[memberName] == nil {
+			unitNames = append(unitNames, memberName)
+		}
+	}
+	if len(unitNames) == 0 {
+		return nil
+	}
+	settings, err := readSettings(unitNames)
+	if err != nil {
+		return err
+	}
+	for _, unitName := range unitNames {
+		unitSettings, ok := settings[unitName]
+		if !ok {
+			continue
+		}
+		if unitSettings == nil {
+			unitSettings = params.Settings{}
+		}
+		cache.members[unitName] = unitSettings
+	}
+	return nil
+}
@@ -213,3 +213,53 @@ func (s *RelationCacheSuite) TestPruneUncachesOtherSettings(c *gc.C) {
 	c.Assert(settings, jc.DeepEquals, params.Settings{"baz": "qux"})
 	c.Assert(s.calls, jc.DeepEquals, []string{"x/2", "x/2"})
 }
+
+func (s *RelationCacheSuite) TestPrefetch(c *gc.C) {
+	s.results = []settingsResult{{
+		params.Settings{"foo": "bar"}, nil,
+	}}
+	cache := context.NewRelationCache(s.ReadSettings, []string{"x/1", "x/2", "x/3"})
+	settings, err := cache.Settings("x/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"foo": "bar"})
+
+	var requested [][]string
+	err = cache.Prefetch(func(unitNames []string) (map[string]params.Settings, error) {
+		requested = append(requested, unitNames)
+		// x/3's settings could not be read.
+		return map[string]params.Settings{"x/2": {"baz": "qux"}}, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requested, jc.DeepEquals, [][]string{{"x/2", "x/3"}})
+
+	settings, err = cache.Settings("x/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"baz": "qux"})
+	c.Assert(s.calls, jc.DeepEquals, []string{"x/1"})
+}
+
+func (s *RelationCacheSuite) TestPrefetchNothingToRead(c *gc.C) {
+	cache := context.NewRelationCache(s.ReadSettings, nil)
+	err := cache.Prefetch(func([]string) (map[string]params.Settings, error) {
+		c.Fatalf("unexpected read")
+		return nil, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RelationCacheSuite) TestPrefetchError(c *gc.C) {
+	s.results = []settingsResult{{
+		params.Settings{"foo": "bar"}, nil,
+	}}
+	cache := context.NewRelationCache(s.ReadSettings, []string{"x/1"})
+	err := cache.Prefetch(func([]string) (map[string]params.Settings, error) {
+		return nil, errors.New("blam")
+	})
+	c.Assert(err, gc.ErrorMatches, "blam")
+
+	// The settings are read when needed instead.
+	settings, err := cache.Settings("x/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"foo": "bar"})
+	c.Assert(s.calls, jc.DeepEquals, []string{"x/1"})
+}
@@ -7,6 +7,7 @@ package context
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/version"
+	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -163,6 +165,10 @@ type HookContext struct {
 	// proxySettings are the current proxy settings that the uniter knows about.
 	proxySettings proxy.Settings
 
+	// hookEnvironment holds the extra environment variables set for
+	// hook executions, from the model config and application overrides.
+	hookEnvironment map[string]string
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -348,6 +354,25 @@ func (ctx *HookContext) ApplicationStatus() (jujuc.ApplicationStatusInfo, error)
 	}, nil
 }
 
+// RelatedApplicationStatuses returns the workload status of each
+// application related to the unit's application, keyed by application
+// name.
+func (ctx *HookContext) RelatedApplicationStatuses() (map[string]jujuc.StatusInfo, error) {
+	results, err := ctx.unit.RelatedApplicationStatuses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statuses := make(map[string]jujuc.StatusInfo, len(results))
+	for name, result := range results {
+		statuses[name] = jujuc.StatusInfo{
+			Tag:    names.NewApplicationTag(name).String(),
+			Status: result.Status,
+			Info:   result.Info,
+		}
+	}
+	return statuses, nil
+}
+
 // SetUnitStatus will set the given status for this unit.
 func (ctx *HookContext) SetUnitStatus(unitStatus jujuc.StatusInfo) error {
 	ctx.hasRunStatusSet = true
@@ -516,6 +541,25 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the Action. The
+// message is sent to the controller immediately, so that it can be
+// followed while the Action runs.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return errors.Trace(ctx.state.ActionLog(ctx.actionData.Tag, message))
+}
+
+// SetActionProgress records the percentage of the Action that is
+// complete, and a progress message if one is given.
+func (ctx *HookContext) SetActionProgress(percent int, message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return errors.Trace(ctx.state.ActionProgress(ctx.actionData.Tag, percent, message))
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the controller
 // upon completion of the Action.  It returns an error if not called on an
@@ -574,7 +618,10 @@ func (c *HookContext) ActionData() (*ActionData, error) {
 // such that it can know what environment it's operating in, and can call back
 // into context.
 func (context *HookContext) HookVars(paths Paths) ([]string, error) {
-	vars := context.proxySettings.AsEnvironmentValues()
+	// The extra hook environment comes first, so that it can never
+	// take precedence over the variables set by Juju itself.
+	vars := context.hookEnvironmentValues()
+	vars = append(vars, context.proxySettings.AsEnvironmentValues()...)
 	vars = append(vars,
 		"CHARM_DIR="+paths.GetCharmDir(), // legacy, embarrassing
 		"JUJU_CHARM_DIR="+paths.GetCharmDir(),
@@ -834,6 +881,62 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	return result.OneError()
 }
 
+// CloudSpec returns the cloud spec, including the cloud credential, of
+// the unit's model. The spec is retrieved from the controller on every
+// call and is not cached in the context.
+func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
+	return ctx.state.CloudSpec()
+}
+
+// AcquireCharmLock acquires the named lock, shared by all the units of
+// the application. Unlike most changes made by hooks, it takes effect
+// immediately, so that other units see the lock while the hook runs.
+func (ctx *HookContext) AcquireCharmLock(name string, ttl time.Duration) error {
+	return ctx.unit.AcquireCharmLock(name, ttl)
+}
+
+// ReleaseCharmLock releases the named lock held by the unit. It takes
+// effect immediately.
+func (ctx *HookContext) ReleaseCharmLock(name string) error {
+	return ctx.unit.ReleaseCharmLock(name)
+}
+
+// SetConfigReady records that the unit, which must be the leader, is
+// ready for followers to run config-changed with the config settings
+// seen by the hook. It takes effect immediately.
+func (ctx *HookContext) SetConfigReady() error {
+	settings, err := ctx.ConfigSettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hash, err := remotestate.ConfigSettingsHash(settings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.unit.SetConfigReadyHash(hash)
+}
+
+// HookEnvironment returns the extra environment variables set for the
+// unit's hook executions.
+func (ctx *HookContext) HookEnvironment() (map[string]string, error) {
+	env := make(map[string]string, len(ctx.hookEnvironment))
+	for k, v := range ctx.hookEnvironment {
+		env[k] = v
+	}
+	return env, nil
+}
+
+// hookEnvironmentValues returns the extra hook environment as a sorted
+// os.Environ-style list of strings.
+func (ctx *HookContext) hookEnvironmentValues() []string {
+	vars := make([]string, 0, len(ctx.hookEnvironment))
+	for k, v := range ctx.hookEnvironment {
+		vars = append(vars, k+"="+v)
+	}
+	sort.Strings(vars)
+	return vars
+}
+
 // NetworkInfo returns the network info for the given bindings on the given relation.
 func (ctx *HookContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
 	var relId *int
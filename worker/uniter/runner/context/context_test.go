@@ -11,10 +11,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -157,6 +160,81 @@ func (s *InterfaceSuite) TestGetSetWorkloadVersion(c *gc.C) {
 	c.Assert(result, gc.Equals, "Pipey")
 }
 
+func (s *InterfaceSuite) TestCloudSpec(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	_, err := ctx.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, `cloud credential access is disabled by the controller's "allow-credential-get" config`)
+
+	_, err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AllowCredentialGetKey: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ctx.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = s.service.SetTrust(names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := ctx.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Type, gc.Equals, "dummy")
+}
+
+func (s *InterfaceSuite) TestCharmLocks(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	err := ctx.AcquireCharmLock("migrate", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err := s.service.CharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Holder, gc.Equals, s.unit.Name())
+
+	err = ctx.ReleaseCharmLock("migrate")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.ReleaseCharmLock("migrate")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *InterfaceSuite) TestSetConfigReady(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	err := ctx.SetConfigReady()
+	c.Assert(err, gc.ErrorMatches, `cannot set config ready hash of application "u": .*"u/0" is not leader of "u"`)
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("u", "u/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.SetConfigReady()
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := ctx.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	hash, err := remotestate.ConfigSettingsHash(settings)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.service.ConfigReadyHash(), gc.Equals, hash)
+}
+
+func (s *InterfaceSuite) TestRelatedApplicationStatuses(c *gc.C) {
+	db0, err := s.State.Application("db0")
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = db0.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.GetContext(c, -1, "")
+	statuses, err := ctx.RelatedApplicationStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses, gc.HasLen, 2)
+	c.Assert(statuses["db0"], jc.DeepEquals, jujuc.StatusInfo{
+		Tag:    "application-db0",
+		Status: "active",
+		Info:   "ready",
+	})
+	c.Assert(statuses["db1"].Tag, gc.Equals, "application-db1")
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -240,6 +318,10 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.LogActionMessage("foo")
+	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.SetActionProgress(50, "foo")
+	c.Check(err, gc.ErrorMatches, "not running an action")
 }
 
 // TestUpdateActionResults demonstrates that UpdateActionResults functions
@@ -215,6 +215,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
+	prefetchRelationSettings(ctx.relations)
 	if hookInfo.Kind.IsStorage() {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
@@ -268,6 +269,18 @@ func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
 	return contextRelations
 }
 
+// prefetchRelationSettings reads the settings of the remote units in
+// each relation that aren't already cached, with a single API call per
+// relation, so that relation-get doesn't make an API call each time it
+// is run. Settings that cannot be prefetched are read when needed.
+func prefetchRelationSettings(relations map[int]*ContextRelation) {
+	for id, relation := range relations {
+		if err := relation.prefetchSettings(); err != nil {
+			logger.Warningf("cannot prefetch settings for relation %d: %v", id, err)
+		}
+	}
+}
+
 // updateContext fills in all unspecialized fields that require an API call to
 // discover.
 //
@@ -310,6 +323,12 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.proxySettings = modelConfig.ProxySettings()
 
+	// Controllers that predate hook environments have none to offer.
+	ctx.hookEnvironment, err = f.unit.HookEnvironment()
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Annotate(err, "could not retrieve the hook environment")
+	}
+
 	// Calling these last, because there's a potential race: they're not guaranteed
 	// to be set in time to be needed for a hook. If they're not, we just leave them
 	// unset as we always have; this isn't great but it's about behaviour preservation.
@@ -128,6 +128,24 @@ func (s *ContextFactorySuite) TestNewHookContextRetrievesSLALevel(c *gc.C) {
 	c.Assert(ctx.SLALevel(), gc.Equals, "essential")
 }
 
+func (s *ContextFactorySuite) TestNewHookContextRetrievesHookEnvironment(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"hook-environment": "SITE_ID=lon1 HTTP_TIMEOUT=30",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetHookEnvironment(map[string]string{"SITE_ID": "ams2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := ctx.HookEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, jc.DeepEquals, map[string]string{
+		"HTTP_TIMEOUT": "30",
+		"SITE_ID":      "ams2",
+	})
+}
+
 func (s *ContextFactorySuite) TestNewHookContextLeadershipContext(c *gc.C) {
 	s.testLeadershipContextWiring(c, func() *context.HookContext {
 		ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
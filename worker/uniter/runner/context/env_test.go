@@ -155,3 +155,31 @@ func (s *EnvSuite) TestEnvUbuntu(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars)
 }
+
+func (s *EnvSuite) TestEnvHookEnvironment(c *gc.C) {
+	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.Ubuntu })
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.2.3"))
+	os.Setenv("PATH", "foo:bar")
+	ubuntuVars := []string{
+		"PATH=path-to-tools:foo:bar",
+		"APT_LISTCHANGES_FRONTEND=none",
+		"DEBIAN_FRONTEND=noninteractive",
+	}
+
+	ctx, contextVars := s.getContext()
+	context.SetHookEnvironment(ctx, map[string]string{
+		"SITE_ID":      "lon1",
+		"HTTP_TIMEOUT": "30",
+	})
+	paths, pathsVars := s.getPaths()
+	actualVars, err := ctx.HookVars(paths)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The extra variables come first, so that those set
+	// by Juju take precedence.
+	c.Assert(actualVars[:2], jc.DeepEquals, []string{
+		"HTTP_TIMEOUT=30",
+		"SITE_ID=lon1",
+	})
+	s.assertVars(c, actualVars[2:], contextVars, pathsVars, ubuntuVars)
+}
@@ -17,9 +17,10 @@ import (
 )
 
 var (
-	ValidatePortRange = validatePortRange
-	TryOpenPorts      = tryOpenPorts
-	TryClosePorts     = tryClosePorts
+	PrefetchRelationSettings = prefetchRelationSettings
+	ValidatePortRange        = validatePortRange
+	TryOpenPorts             = tryOpenPorts
+	TryClosePorts            = tryClosePorts
 )
 
 func NewHookContext(
@@ -162,6 +163,10 @@ func NewModelHookContext(
 	}
 }
 
+func SetHookEnvironment(hctx *HookContext, env map[string]string) {
+	hctx.hookEnvironment = env
+}
+
 func ContextEnvInfo(hctx *HookContext) (name, uuid string) {
 	return hctx.envName, hctx.uuid
 }
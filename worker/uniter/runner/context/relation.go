@@ -28,6 +28,9 @@ type ContextRelation struct {
 
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
+
+	// peerSeed holds the peer relation's seed data, once read.
+	peerSeed params.Settings
 }
 
 // NewContextRelation creates a new context for the given relation unit.
@@ -89,3 +92,41 @@ func (ctx *ContextRelation) Suspended() bool {
 func (ctx *ContextRelation) SetStatus(status relation.Status) error {
 	return ctx.ru.Relation().SetStatus(status)
 }
+
+// PeerSeed returns the seed data written to the peer relation by the
+// application's leader. The data is read once per context, until it is
+// updated.
+func (ctx *ContextRelation) PeerSeed() (params.Settings, error) {
+	if ctx.peerSeed == nil {
+		seed, err := ctx.ru.PeerSeed()
+		if err != nil {
+			return nil, err
+		}
+		if seed == nil {
+			seed = params.Settings{}
+		}
+		ctx.peerSeed = seed
+	}
+	result := make(params.Settings, len(ctx.peerSeed))
+	for k, v := range ctx.peerSeed {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// UpdatePeerSeed writes seed data to the peer relation.
+func (ctx *ContextRelation) UpdatePeerSeed(settings map[string]string) error {
+	update := make(params.Settings, len(settings))
+	for k, v := range settings {
+		update[k] = v
+	}
+	// Whatever the outcome, the seed data must be read again.
+	ctx.peerSeed = nil
+	return ctx.ru.UpdatePeerSeed(update)
+}
+
+// prefetchSettings reads the settings of all the relation's remote
+// members that aren't already cached, with a single API call.
+func (ctx *ContextRelation) prefetchSettings() error {
+	return ctx.cache.Prefetch(ctx.ru.ReadRemoteSettings)
+}
@@ -140,6 +140,37 @@ func (s *ContextRelationSuite) TestNonMemberCaching(c *gc.C) {
 	c.Assert(m, gc.DeepEquals, expectSettings)
 }
 
+func (s *ContextRelationSuite) TestPrefetchSettings(c *gc.C) {
+	unit, err := s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := s.rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"blib": "blob"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var reads []string
+	cache := context.NewRelationCache(func(unitName string) (params.Settings, error) {
+		reads = append(reads, unitName)
+		return s.apiRelUnit.ReadSettings(unitName)
+	}, []string{"u/1"})
+	ctx := context.NewContextRelation(s.apiRelUnit, cache)
+	context.PrefetchRelationSettings(map[int]*context.ContextRelation{
+		ctx.Id(): ctx,
+	})
+
+	// Changes to state after the settings were prefetched are not
+	// seen, and the settings aren't read again.
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("blib", "blub")
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := ctx.ReadSettings("u/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, gc.DeepEquals, params.Settings{"blib": "blob"})
+	c.Assert(reads, gc.HasLen, 0)
+}
+
 func (s *ContextRelationSuite) TestLocalSettings(c *gc.C) {
 	ctx := context.NewContextRelation(s.apiRelUnit, nil)
 
@@ -206,3 +237,45 @@ func (s *ContextRelationSuite) TestSetStatus(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(relStatus.Status, gc.Equals, status.Suspended)
 }
+
+func (s *ContextRelationSuite) TestPeerSeed(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("u", "u/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+	err = ctx.UpdatePeerSeed(map[string]string{"token": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	seed, err := s.rel.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seed, gc.DeepEquals, map[string]string{"token": "s3cret"})
+
+	read, err := ctx.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, gc.DeepEquals, params.Settings{"token": "s3cret"})
+}
+
+func (s *ContextRelationSuite) TestPeerSeedCaching(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("u", "u/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiRelUnit.UpdatePeerSeed(params.Settings{"token": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+	read, err := ctx.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, gc.DeepEquals, params.Settings{"token": "one"})
+
+	// The seed is cached for the context...
+	err = s.apiRelUnit.UpdatePeerSeed(params.Settings{"token": "two"})
+	c.Assert(err, jc.ErrorIsNil)
+	read, err = ctx.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, gc.DeepEquals, params.Settings{"token": "one"})
+
+	// ...until it is updated through the context.
+	err = ctx.UpdatePeerSeed(map[string]string{"token": "three"})
+	c.Assert(err, jc.ErrorIsNil)
+	read, err = ctx.PeerSeed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, gc.DeepEquals, params.Settings{"token": "three"})
+}
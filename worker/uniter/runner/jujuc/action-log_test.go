@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionLogSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ActionLogSuite{})
+
+type actionLogContext struct {
+	jujuc.Context
+	messages []string
+	percent  int
+	err      error
+}
+
+func (ctx *actionLogContext) LogActionMessage(message string) error {
+	if ctx.err != nil {
+		return ctx.err
+	}
+	ctx.messages = append(ctx.messages, message)
+	return nil
+}
+
+func (ctx *actionLogContext) SetActionProgress(percent int, message string) error {
+	if ctx.err != nil {
+		return ctx.err
+	}
+	ctx.percent = percent
+	if message != "" {
+		ctx.messages = append(ctx.messages, message)
+	}
+	return nil
+}
+
+func (s *ActionLogSuite) TestActionLog(c *gc.C) {
+	var actionLogTests = []struct {
+		summary  string
+		command  []string
+		messages []string
+		errMsg   string
+		code     int
+	}{{
+		summary: "no message is an error",
+		command: []string{},
+		errMsg:  "ERROR no message specified\n",
+		code:    2,
+	}, {
+		summary:  "a single argument is logged",
+		command:  []string{"copying tables"},
+		messages: []string{"copying tables"},
+	}, {
+		summary:  "multiple arguments are joined",
+		command:  []string{"copying", "tables"},
+		messages: []string{"copying tables"},
+	}}
+
+	for i, t := range actionLogTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionLogContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.command)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.messages, jc.DeepEquals, t.messages)
+	}
+}
+
+func (s *ActionLogSuite) TestNonActionLogFails(c *gc.C) {
+	hctx := &actionLogContext{err: fmt.Errorf("not running an action")}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"hello"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR not running an action\n")
+}
+
+func (s *ActionLogSuite) TestHelp(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `Usage: action-log <message>
+
+Summary:
+record a progress message for the running action
+
+Details:
+action-log records a progress message for the running action. The
+messages are shown as they are logged by "juju run-action --wait", and
+are kept with the action's results.
+`)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
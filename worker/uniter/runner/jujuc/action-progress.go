@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// ActionProgressCommand implements the action-progress command.
+type ActionProgressCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	percent int
+	message string
+}
+
+// NewActionProgressCommand returns a new ActionProgressCommand with the
+// given context.
+func NewActionProgressCommand(ctx Context) (cmd.Command, error) {
+	return &ActionProgressCommand{ctx: ctx}, nil
+}
+
+// Info returns the content for --help.
+func (c *ActionProgressCommand) Info() *cmd.Info {
+	doc := `
+action-progress records the percentage of the running action that is
+complete, and optionally a progress message. The progress is shown by
+"juju run-action --wait" and "juju show-action-status", and any message
+is logged as with action-log.
+`
+	return &cmd.Info{
+		Name:    "action-progress",
+		Args:    "<percent> [<message>]",
+		Purpose: "record the progress of the running action",
+		Doc:     doc,
+	}
+}
+
+// SetFlags handles any option flags, but there are none.
+func (c *ActionProgressCommand) SetFlags(f *gnuflag.FlagSet) {
+}
+
+// Init parses the percentage complete and the optional message.
+func (c *ActionProgressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no progress specified")
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(args[0], "%"))
+	if err != nil || percent < 0 || percent > 100 {
+		return errors.Errorf("invalid progress %q, expected a percentage between 0 and 100", args[0])
+	}
+	c.percent = percent
+	c.message = strings.Join(args[1:], " ")
+	return nil
+}
+
+// Run records the progress of the action.
+func (c *ActionProgressCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.SetActionProgress(c.percent, c.message)
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionProgressSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ActionProgressSuite{})
+
+func (s *ActionProgressSuite) TestActionProgress(c *gc.C) {
+	var actionProgressTests = []struct {
+		summary  string
+		command  []string
+		percent  int
+		messages []string
+		errMsg   string
+		code     int
+	}{{
+		summary: "no progress is an error",
+		command: []string{},
+		errMsg:  "ERROR no progress specified\n",
+		code:    2,
+	}, {
+		summary: "progress alone is recorded",
+		command: []string{"40"},
+		percent: 40,
+	}, {
+		summary: "a percent sign is accepted",
+		command: []string{"40%"},
+		percent: 40,
+	}, {
+		summary:  "a message is logged",
+		command:  []string{"100", "all", "done"},
+		percent:  100,
+		messages: []string{"all done"},
+	}, {
+		summary: "progress must be a number",
+		command: []string{"lots"},
+		errMsg:  "ERROR invalid progress \"lots\", expected a percentage between 0 and 100\n",
+		code:    2,
+	}, {
+		summary: "progress must be at most 100",
+		command: []string{"101"},
+		errMsg:  "ERROR invalid progress \"101\", expected a percentage between 0 and 100\n",
+		code:    2,
+	}}
+
+	for i, t := range actionProgressTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionLogContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-progress"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.command)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.percent, gc.Equals, t.percent)
+		c.Check(hctx.messages, jc.DeepEquals, t.messages)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// configReadyCommand implements the config-ready command.
+type configReadyCommand struct {
+	cmd.CommandBase
+	ctx Context
+}
+
+// NewConfigReadyCommand returns a new configReadyCommand with the given
+// context.
+func NewConfigReadyCommand(ctx Context) (cmd.Command, error) {
+	return &configReadyCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *configReadyCommand) Info() *cmd.Info {
+	doc := `
+config-ready reports that the application leader has finished preparing for
+the current config settings. When the model's leader-first-config-changed
+setting is enabled, the other units of the application defer running their
+config-changed hooks until the leader has done so. It will fail if called
+by a unit that is not currently application leader.
+`
+	return &cmd.Info{
+		Name:    "config-ready",
+		Purpose: "report that the leader is ready for the current config",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *configReadyCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *configReadyCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.SetConfigReady()
+	return errors.Annotate(err, "cannot report config ready")
+}
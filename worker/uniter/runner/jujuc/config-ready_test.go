@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ConfigReadySuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ConfigReadySuite{})
+
+func (s *ConfigReadySuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("config-ready"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *ConfigReadySuite) TestConfigReady(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.Leadership.ConfigReady, jc.IsTrue)
+	s.Stub.CheckCallNames(c, "SetConfigReady")
+}
+
+func (s *ConfigReadySuite) TestConfigReadyError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New(`"mysql/1" is not leader of "mysql"`))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot report config ready: "mysql/1" is not leader of "mysql"`+"\n")
+	c.Check(hctx.info.Leadership.ConfigReady, jc.IsFalse)
+}
+
+func (s *ConfigReadySuite) TestInitError(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, []string{"foo"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
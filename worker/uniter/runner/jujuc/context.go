@@ -54,6 +54,9 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextCloudCredential
+	ContextHookEnvironment
+	ContextCharmLocks
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -98,6 +101,14 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// LogActionMessage records a progress message for the running
+	// Action.
+	LogActionMessage(string) error
+
+	// SetActionProgress records the percentage of the running Action
+	// that is complete, and a progress message if one is given.
+	SetActionProgress(percent int, message string) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -123,6 +134,11 @@ type ContextStatus interface {
 
 	// SetApplicationStatus updates the status for the unit's service.
 	SetApplicationStatus(StatusInfo) error
+
+	// RelatedApplicationStatuses returns the workload status of each
+	// application related to the unit's service, keyed by application
+	// name.
+	RelatedApplicationStatuses() (map[string]StatusInfo, error)
 }
 
 // ContextInstance is the part of a hook context related to the unit's instance.
@@ -179,6 +195,11 @@ type ContextLeadership interface {
 	// WriteLeaderSettings writes the supplied settings directly to state, or
 	// fails if the local unit is not the service's leader.
 	WriteLeaderSettings(map[string]string) error
+
+	// SetConfigReady records that the leader is ready for followers to
+	// run config-changed with the current config settings, or fails if
+	// the local unit is not the service's leader.
+	SetConfigReady() error
 }
 
 // ContextMetrics is the part of a hook context related to metrics.
@@ -271,6 +292,15 @@ type ContextRelation interface {
 
 	// SetStatus sets the relation's status.
 	SetStatus(relation.Status) error
+
+	// PeerSeed returns the seed data written to a peer relation by the
+	// application's leader.
+	PeerSeed() (params.Settings, error)
+
+	// UpdatePeerSeed writes seed data to a peer relation. Keys with
+	// empty values are deleted. Only the leader may write seed data,
+	// and the changes take effect immediately.
+	UpdatePeerSeed(map[string]string) error
 }
 
 // ContextStorageAttachment expresses the capabilities of a hook with
@@ -301,6 +331,38 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextCloudCredential expresses the parts of a hook context related
+// to the cloud credential of the unit's model.
+type ContextCloudCredential interface {
+	// CloudSpec returns the cloud spec, including the cloud credential,
+	// of the unit's model. It is only available to units of trusted
+	// applications.
+	CloudSpec() (*params.CloudSpec, error)
+}
+
+// ContextHookEnvironment expresses the parts of a hook context related
+// to the extra environment variables set for hook executions.
+type ContextHookEnvironment interface {
+
+	// HookEnvironment returns the extra environment variables set for
+	// the unit's hook executions, from the model's hook-environment
+	// config and the application's overrides.
+	HookEnvironment() (map[string]string, error)
+}
+
+// ContextCharmLocks expresses the parts of a hook context related to
+// the locks shared by the units of the application.
+type ContextCharmLocks interface {
+
+	// AcquireCharmLock acquires the named lock, shared by all the
+	// units of the application, until the given time to live has
+	// passed, or extends it if the unit already holds the lock.
+	AcquireCharmLock(name string, ttl time.Duration) error
+
+	// ReleaseCharmLock releases the named lock held by the unit.
+	ReleaseCharmLock(name string) error
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// credentialGetCommand implements the credential-get command.
+type credentialGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewCredentialGetCommand returns a new credentialGetCommand with the
+// given context.
+func NewCredentialGetCommand(ctx Context) (cmd.Command, error) {
+	return &credentialGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *credentialGetCommand) Info() *cmd.Info {
+	doc := `
+credential-get prints the cloud and credential used by the unit's model.
+The credential is only available to applications that have been granted
+access to it with "juju trust", and only if the controller's
+"allow-credential-get" config is enabled. The credential is the model's
+credential as stored, not a scoped or temporary one. It is retrieved from
+the controller each time the command is run, and should not be stored by
+the charm.
+`
+	return &cmd.Info{
+		Name:    "credential-get",
+		Purpose: "print the cloud credential of the model",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *credentialGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *credentialGetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *credentialGetCommand) Run(ctx *cmd.Context) error {
+	spec, err := c.ctx.CloudSpec()
+	if err != nil {
+		return errors.Annotate(err, "cannot get cloud credential")
+	}
+	out := map[string]interface{}{
+		"type": spec.Type,
+		"name": spec.Name,
+	}
+	set := func(key, value string) {
+		if value != "" {
+			out[key] = value
+		}
+	}
+	set("region", spec.Region)
+	set("endpoint", spec.Endpoint)
+	set("identity-endpoint", spec.IdentityEndpoint)
+	set("storage-endpoint", spec.StorageEndpoint)
+	if spec.Credential != nil {
+		out["credential"] = map[string]interface{}{
+			"auth-type": spec.Credential.AuthType,
+			"attrs":     spec.Credential.Attributes,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
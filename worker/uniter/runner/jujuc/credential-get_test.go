@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CredentialGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CredentialGetSuite{})
+
+func (s *CredentialGetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CloudCredential.CloudSpec = &params.CloudSpec{
+		Type:     "openstack",
+		Name:     "canonistack",
+		Region:   "lcy02",
+		Endpoint: "https://keystone.example.com:5000/v3",
+		Credential: &params.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "fred",
+				"password": "secret",
+			},
+		},
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *CredentialGetSuite) TestCredentialGet(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+credential:
+  attrs:
+    password: secret
+    username: fred
+  auth-type: userpass
+endpoint: https://keystone.example.com:5000/v3
+name: canonistack
+region: lcy02
+type: openstack
+`[1:])
+	s.Stub.CheckCallNames(c, "CloudSpec")
+}
+
+func (s *CredentialGetSuite) TestCredentialGetJSON(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "json"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `{"credential":{"attrs":{"password":"secret","username":"fred"},"auth-type":"userpass"},"endpoint":"https://keystone.example.com:5000/v3","name":"canonistack","region":"lcy02","type":"openstack"}`+"\n")
+}
+
+func (s *CredentialGetSuite) TestCredentialGetNotTrusted(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("permission denied"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot get cloud credential: permission denied\n")
+}
+
+func (s *CredentialGetSuite) TestCredentialGetTooManyArgs(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"foo"})
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR unrecognized args: [\"foo\"]\n")
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// hookEnvGetCommand implements the hook-env-get command.
+type hookEnvGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	key string
+	out cmd.Output
+}
+
+// NewHookEnvGetCommand returns a new hookEnvGetCommand with the given
+// context.
+func NewHookEnvGetCommand(ctx Context) (cmd.Command, error) {
+	return &hookEnvGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *hookEnvGetCommand) Info() *cmd.Info {
+	doc := `
+hook-env-get prints the extra environment variables that are set for the
+unit's hook executions, from the model's hook-environment config and the
+application's overrides. When no <key> is supplied, all variables are
+printed.
+`
+	return &cmd.Info{
+		Name:    "hook-env-get",
+		Args:    "[<key>]",
+		Purpose: "print extra hook environment variables",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *hookEnvGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *hookEnvGetCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.key = args[0]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *hookEnvGetCommand) Run(ctx *cmd.Context) error {
+	env, err := c.ctx.HookEnvironment()
+	if err != nil {
+		return errors.Annotate(err, "cannot get hook environment")
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, env)
+	}
+	if value, ok := env[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
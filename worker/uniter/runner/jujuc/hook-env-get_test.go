@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type HookEnvGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&HookEnvGetSuite{})
+
+func (s *HookEnvGetSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.HookEnvironment.Environment = map[string]string{
+		"HTTP_TIMEOUT": "30",
+		"SITE_ID":      "lon1",
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("hook-env-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *HookEnvGetSuite) TestHookEnvGetAll(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+HTTP_TIMEOUT: "30"
+SITE_ID: lon1
+`[1:])
+	s.Stub.CheckCallNames(c, "HookEnvironment")
+}
+
+func (s *HookEnvGetSuite) TestHookEnvGetAllJSON(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "json"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `{"HTTP_TIMEOUT":"30","SITE_ID":"lon1"}`+"\n")
+}
+
+func (s *HookEnvGetSuite) TestHookEnvGetKey(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"SITE_ID"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "lon1\n")
+}
+
+func (s *HookEnvGetSuite) TestHookEnvGetMissingKey(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"MISSING"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *HookEnvGetSuite) TestHookEnvGetError(c *gc.C) {
+	com := s.createCommand(c, errors.New("boom"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot get hook environment: boom\n")
+}
+
+func (s *HookEnvGetSuite) TestHookEnvGetTooManyArgs(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"A", "B"})
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR unrecognized args: [\"B\"]\n")
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// defaultLockTTL is how long a lock is held for, unless it is released
+// or acquired again, if no --ttl is given.
+const defaultLockTTL = 5 * time.Minute
+
+// lockAcquireCommand implements the lock-acquire command.
+type lockAcquireCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	name string
+	ttl  time.Duration
+}
+
+// NewLockAcquireCommand returns a new lockAcquireCommand with the given
+// context.
+func NewLockAcquireCommand(ctx Context) (cmd.Command, error) {
+	return &lockAcquireCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *lockAcquireCommand) Info() *cmd.Info {
+	doc := `
+lock-acquire acquires the named lock, which is shared by all the units of
+the application, so that operations such as schema migrations or rolling
+restarts can be performed by one unit at a time. The lock is held by the
+controller, and takes effect immediately rather than when the hook
+completes.
+
+If another unit holds the lock, lock-acquire fails, and may be retried.
+If the unit already holds the lock, it is held for the time to live again.
+The lock is released when lock-release is run, when the time to live has
+passed, or when the unit is removed; a unit performing a long operation
+should acquire the lock again before it expires.
+`
+	return &cmd.Info{
+		Name:    "lock-acquire",
+		Args:    "<name>",
+		Purpose: "acquire a lock shared by the units of the application",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *lockAcquireCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.DurationVar(&c.ttl, "ttl", defaultLockTTL, "how long the lock is held for if it is not released")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *lockAcquireCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no lock name specified")
+	}
+	c.name = args[0]
+	if c.ttl <= 0 {
+		return errors.Errorf("invalid time to live %v", c.ttl)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *lockAcquireCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.AcquireCharmLock(c.name, c.ttl)
+}
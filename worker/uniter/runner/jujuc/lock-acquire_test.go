@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type LockAcquireSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&LockAcquireSuite{})
+
+func (s *LockAcquireSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("lock-acquire"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *LockAcquireSuite) TestAcquire(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"migrate"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmLocks.Locks, jc.DeepEquals, map[string]time.Duration{
+		"migrate": 5 * time.Minute,
+	})
+}
+
+func (s *LockAcquireSuite) TestAcquireTTL(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--ttl", "90s", "migrate"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(hctx.info.CharmLocks.Locks, jc.DeepEquals, map[string]time.Duration{
+		"migrate": 90 * time.Second,
+	})
+}
+
+func (s *LockAcquireSuite) TestAcquireHeld(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New(`cannot acquire lock "migrate": lock "migrate" is held by unit "mysql/1"`))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"migrate"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals,
+		`ERROR cannot acquire lock "migrate": lock "migrate" is held by unit "mysql/1"`+"\n")
+	c.Check(hctx.info.CharmLocks.Locks, gc.HasLen, 0)
+}
+
+func (s *LockAcquireSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no lock name specified",
+	}, {
+		args: []string{"migrate", "restart"},
+		err:  `unrecognized args: \["restart"\]`,
+	}, {
+		args: []string{"--ttl", "0", "migrate"},
+		err:  "invalid time to live 0s",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, com := s.createCommand(c, nil)
+		err := cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *LockAcquireSuite) TestHelp(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+Usage: lock-acquire [options] <name>
+
+Summary:
+acquire a lock shared by the units of the application
+
+Options:
+--ttl  (= 5m0s)
+    how long the lock is held for if it is not released
+
+Details:
+lock-acquire acquires the named lock, which is shared by all the units of
+the application, so that operations such as schema migrations or rolling
+restarts can be performed by one unit at a time. The lock is held by the
+controller, and takes effect immediately rather than when the hook
+completes.
+
+If another unit holds the lock, lock-acquire fails, and may be retried.
+If the unit already holds the lock, it is held for the time to live again.
+The lock is released when lock-release is run, when the time to live has
+passed, or when the unit is removed; a unit performing a long operation
+should acquire the lock again before it expires.
+`[1:])
+}
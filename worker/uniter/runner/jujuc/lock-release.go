@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// lockReleaseCommand implements the lock-release command.
+type lockReleaseCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	name string
+}
+
+// NewLockReleaseCommand returns a new lockReleaseCommand with the given
+// context.
+func NewLockReleaseCommand(ctx Context) (cmd.Command, error) {
+	return &lockReleaseCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *lockReleaseCommand) Info() *cmd.Info {
+	doc := `
+lock-release releases the named lock acquired by lock-acquire, so that
+another unit of the application may acquire it. It fails if the unit does
+not hold the lock.
+`
+	return &cmd.Info{
+		Name:    "lock-release",
+		Args:    "<name>",
+		Purpose: "release a lock shared by the units of the application",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *lockReleaseCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no lock name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *lockReleaseCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.ReleaseCharmLock(c.name)
+}
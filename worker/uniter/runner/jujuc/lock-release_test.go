@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type LockReleaseSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&LockReleaseSuite{})
+
+func (s *LockReleaseSuite) createCommand(c *gc.C) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmLocks.Locks = map[string]time.Duration{"migrate": time.Minute}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("lock-release"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *LockReleaseSuite) TestRelease(c *gc.C) {
+	hctx, com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"migrate"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmLocks.Locks, gc.HasLen, 0)
+	s.Stub.CheckCall(c, 0, "ReleaseCharmLock", "migrate")
+}
+
+func (s *LockReleaseSuite) TestReleaseNotHeld(c *gc.C) {
+	_, com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"restart"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR lock "restart" not found`+"\n")
+}
+
+func (s *LockReleaseSuite) TestInitErrors(c *gc.C) {
+	_, com := s.createCommand(c)
+	err := cmdtesting.InitCommand(com, nil)
+	c.Check(err, gc.ErrorMatches, "no lock name specified")
+	_, com = s.createCommand(c)
+	err = cmdtesting.InitCommand(com, []string{"migrate", "restart"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["restart"\]`)
+}
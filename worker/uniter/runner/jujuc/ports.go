@@ -12,6 +12,8 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/network"
 )
 
 const (
@@ -23,6 +25,9 @@ const (
 
 var validPortOrRange = regexp.MustCompile("^" + portExp + "(?:-" + portExp + ")?(/" + protoExp + ")?$")
 
+// validICMP matches "icmp", and an ICMP type with an optional code.
+var validICMP = regexp.MustCompile("^(?:icmp|" + portExp + "(?::" + portExp + ")?/icmp)$")
+
 type port struct {
 	number   int
 	protocol string
@@ -69,6 +74,16 @@ func (pr portRange) validate() error {
 
 func parseArguments(args []string) (portRange, error) {
 	arg := strings.ToLower(args[0])
+	if strings.HasSuffix(arg, "/icmp") && !validICMP.MatchString(arg) {
+		return portRange{}, errors.Errorf(`expected "icmp" or "<type>[:<code>]/icmp" for ICMP; got %q`, args[0])
+	}
+	if validICMP.MatchString(arg) {
+		icmp, err := network.ParsePortRange(arg)
+		if err != nil {
+			return portRange{}, errors.Trace(err)
+		}
+		return portRange{icmp.FromPort, icmp.ToPort, icmp.Protocol}, nil
+	}
 	if !validPortOrRange.MatchString(arg) {
 		return portRange{}, errors.Errorf("expected %s; got %q", portFormat, args[0])
 	}
@@ -148,7 +163,12 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+ICMP may be opened with "icmp", or just an ICMP type, and optionally an ICMP
+code, with "<type>[:<code>]/icmp".
+`,
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
@@ -5,9 +5,6 @@
 package jujuc_test
 
 import (
-	"strconv"
-	"strings"
-
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
@@ -37,32 +34,17 @@ var portsTests = []struct {
 	{[]string{"close-port", "443/udp"}, makeRanges("99/tcp")},
 	{[]string{"open-port", "123/udp"}, makeRanges("99/tcp", "123/udp")},
 	{[]string{"close-port", "9999/UDP"}, makeRanges("99/tcp", "123/udp")},
+	{[]string{"open-port", "icmp"}, makeRanges("99/tcp", "123/udp", "icmp")},
+	{[]string{"close-port", "ICMP"}, makeRanges("99/tcp", "123/udp")},
+	{[]string{"open-port", "3:4/icmp"}, makeRanges("99/tcp", "123/udp", "3:4/icmp")},
+	{[]string{"open-port", "8/icmp"}, makeRanges("99/tcp", "123/udp", "3:4/icmp", "8/icmp")},
+	{[]string{"close-port", "3:4/icmp"}, makeRanges("99/tcp", "123/udp", "8/icmp")},
 }
 
 func makeRanges(stringRanges ...string) []network.PortRange {
 	var results []network.PortRange
 	for _, s := range stringRanges {
-		if strings.Contains(s, "-") {
-			parts := strings.Split(s, "-")
-			fromPort, _ := strconv.Atoi(parts[0])
-			parts = strings.Split(parts[1], "/")
-			toPort, _ := strconv.Atoi(parts[0])
-			proto := parts[1]
-			results = append(results, network.PortRange{
-				FromPort: fromPort,
-				ToPort:   toPort,
-				Protocol: proto,
-			})
-		} else {
-			parts := strings.Split(s, "/")
-			port, _ := strconv.Atoi(parts[0])
-			proto := parts[1]
-			results = append(results, network.PortRange{
-				FromPort: port,
-				ToPort:   port,
-				Protocol: proto,
-			})
-		}
+		results = append(results, network.MustParsePortRange(s))
 	}
 	network.SortPortRanges(results)
 	return results
@@ -99,6 +81,9 @@ var badPortsTests = []struct {
 	{[]string{"9999/foo"}, `protocol must be "tcp" or "udp"; got "foo"`},
 	{[]string{"80-90/http"}, `protocol must be "tcp" or "udp"; got "http"`},
 	{[]string{"20-10/tcp"}, `invalid port range 20-10/tcp; expected fromPort <= toPort`},
+	{[]string{"256/icmp"}, `invalid ICMP type 256 and code -1`},
+	{[]string{"3:256/icmp"}, `invalid ICMP type 3 and code 256`},
+	{[]string{"1-2/icmp"}, `expected "icmp" or "<type>\[:<code>\]/icmp" for ICMP; got "1-2/icmp"`},
 }
 
 func (s *PortsSuite) TestBadArgs(c *gc.C) {
@@ -126,6 +111,9 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+ICMP may be opened with "icmp", or just an ICMP type, and optionally an ICMP
+code, with "<type>[:<code>]/icmp".
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
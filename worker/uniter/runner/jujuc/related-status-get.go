@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+)
+
+// relatedStatusGetCommand implements the related-status-get command.
+type relatedStatusGetCommand struct {
+	cmd.CommandBase
+	ctx         Context
+	application string
+	out         cmd.Output
+}
+
+// NewRelatedStatusGetCommand returns a new relatedStatusGetCommand with
+// the given context.
+func NewRelatedStatusGetCommand(ctx Context) (cmd.Command, error) {
+	return &relatedStatusGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *relatedStatusGetCommand) Info() *cmd.Info {
+	doc := `
+related-status-get prints the workload status of the applications related to
+this unit's application. Only the status of each application as a whole is
+reported, not the statuses of its units.
+
+If an application name is given, only the status of that application is
+printed. By default, only the status values are printed; other output formats
+include the status messages also.
+`
+	return &cmd.Info{
+		Name:    "related-status-get",
+		Args:    "[<application name>]",
+		Purpose: "print the status of related applications",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *relatedStatusGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *relatedStatusGetCommand) Init(args []string) error {
+	if len(args) > 0 {
+		if !names.IsValidApplication(args[0]) {
+			return errors.Errorf("invalid application name %q", args[0])
+		}
+		c.application, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *relatedStatusGetCommand) Run(ctx *cmd.Context) error {
+	statuses, err := c.ctx.RelatedApplicationStatuses()
+	if err != nil {
+		return errors.Annotate(err, "finding related application statuses")
+	}
+	if c.application != "" {
+		info, ok := statuses[c.application]
+		if !ok {
+			return errors.NotFoundf("related application %q", c.application)
+		}
+		return c.out.Write(ctx, c.details(info))
+	}
+	details := make(map[string]interface{}, len(statuses))
+	for name, info := range statuses {
+		details[name] = c.details(info)
+	}
+	return c.out.Write(ctx, details)
+}
+
+// details returns the status value alone for smart output, and the
+// status and message otherwise.
+func (c *relatedStatusGetCommand) details(info StatusInfo) interface{} {
+	if c.out.Name() == "smart" {
+		return info.Status
+	}
+	return map[string]interface{}{
+		"status":  info.Status,
+		"message": info.Info,
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type relatedStatusGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&relatedStatusGetSuite{})
+
+func (s *relatedStatusGetSuite) createCommand(c *gc.C) (*Context, cmd.Command) {
+	hctx := s.GetStatusHookContext(c)
+	hctx.info.Status.RelatedApplicationStatuses = map[string]jujuc.StatusInfo{
+		"mysql": {
+			Tag:    "application-mysql",
+			Status: "active",
+			Info:   "ready",
+		},
+		"memcached": {
+			Tag:    "application-memcached",
+			Status: "waiting",
+			Info:   "waiting for peers",
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("related-status-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+var relatedStatusGetTests = []struct {
+	args []string
+	out  string
+}{{
+	args: nil,
+	out:  "memcached: waiting\nmysql: active\n",
+}, {
+	args: []string{"mysql"},
+	out:  "active\n",
+}, {
+	args: []string{"--format", "json", "mysql"},
+	out:  `{"message":"ready","status":"active"}` + "\n",
+}, {
+	args: []string{"--format", "yaml"},
+	out: "" +
+		"memcached:\n" +
+		"  message: waiting for peers\n" +
+		"  status: waiting\n" +
+		"mysql:\n" +
+		"  message: ready\n" +
+		"  status: active\n",
+}}
+
+func (s *relatedStatusGetSuite) TestOutput(c *gc.C) {
+	for i, t := range relatedStatusGetTests {
+		c.Logf("test %d: %v", i, t.args)
+		_, com := s.createCommand(c)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *relatedStatusGetSuite) TestOutputJSON(c *gc.C) {
+	_, com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "json"})
+	c.Assert(code, gc.Equals, 0)
+
+	var out map[string]interface{}
+	c.Assert(json.Unmarshal(bufferBytes(ctx.Stdout), &out), jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, map[string]interface{}{
+		"memcached": map[string]interface{}{"status": "waiting", "message": "waiting for peers"},
+		"mysql":     map[string]interface{}{"status": "active", "message": "ready"},
+	})
+}
+
+func (s *relatedStatusGetSuite) TestNotRelated(c *gc.C) {
+	_, com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"wordpress"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR related application "wordpress" not found`+"\n")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *relatedStatusGetSuite) TestError(c *gc.C) {
+	_, com := s.createCommand(c)
+	s.Stub.SetErrors(errors.New("boom"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR finding related application statuses: boom\n")
+}
+
+func (s *relatedStatusGetSuite) TestInitError(c *gc.C) {
+	_, com := s.createCommand(c)
+	err := cmdtesting.InitCommand(com, []string{"not_valid"})
+	c.Check(err, gc.ErrorMatches, `invalid application name "not_valid"`)
+
+	_, com = s.createCommand(c)
+	err = cmdtesting.InitCommand(com, []string{"mysql", "foo"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
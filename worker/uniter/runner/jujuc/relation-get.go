@@ -23,6 +23,7 @@ type RelationGetCommand struct {
 
 	Key      string
 	UnitName string
+	Seed     bool
 	out      cmd.Output
 }
 
@@ -43,6 +44,8 @@ func (c *RelationGetCommand) Info() *cmd.Info {
 	doc := `
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+With --seed, the seed data written to a peer relation by the application's
+leader is printed instead; no unit id may then be given.
 `
 	// There's nothing we can really do about the error here.
 	if name, err := c.ctx.RemoteUnitName(); err == nil {
@@ -64,6 +67,7 @@ func (c *RelationGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(c.relationIdProxy, "r", "specify a relation by id")
 	f.Var(c.relationIdProxy, "relation", "")
+	f.BoolVar(&c.Seed, "seed", false, "get the seed data of a peer relation")
 }
 
 // Init is part of the cmd.Command interface.
@@ -78,6 +82,9 @@ func (c *RelationGetCommand) Init(args []string) error {
 		}
 		args = args[1:]
 	}
+	if c.Seed {
+		return cmd.CheckEmpty(args)
+	}
 	name, err := c.ctx.RemoteUnitName()
 	if err == nil {
 		c.UnitName = name
@@ -100,7 +107,12 @@ func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	var settings params.Settings
-	if c.UnitName == c.ctx.UnitName() {
+	if c.Seed {
+		settings, err = r.PeerSeed()
+		if err != nil {
+			return err
+		}
+	} else if c.UnitName == c.ctx.UnitName() {
 		node, err := r.Settings()
 		if err != nil {
 			return err
@@ -14,6 +14,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
 )
@@ -29,6 +30,7 @@ func (s *RelationGetSuite) newHookContext(relid int, remote string) (jujuc.Conte
 	info.rels[0].Units["u/0"]["private-address"] = "foo: bar\n"
 	info.rels[1].SetRelated("m/0", jujuctesting.Settings{"pew": "pew\npew\n"})
 	info.rels[1].SetRelated("u/1", jujuctesting.Settings{"value": "12345"})
+	info.rels[1].Seed = params.Settings{"token": "s3cret"}
 	return hctx, info
 }
 
@@ -137,6 +139,28 @@ var relationGetTests = []struct {
 		relid:   1,
 		args:    []string{"missing", "u/1", "--format", "smart"},
 		out:     "",
+	}, {
+		summary: "all seed keys",
+		relid:   1,
+		args:    []string{"--seed"},
+		out:     "token: s3cret",
+	}, {
+		summary: "specific seed key with implicit member",
+		relid:   1,
+		unit:    "m/0",
+		args:    []string{"--seed", "token"},
+		out:     "s3cret",
+	}, {
+		summary: "missing seed key",
+		relid:   1,
+		args:    []string{"--seed", "missing"},
+		out:     "",
+	}, {
+		summary: "seed with unit",
+		relid:   1,
+		code:    2,
+		args:    []string{"--seed", "token", "m/0"},
+		out:     `unrecognized args: \["m/0"\]`,
 	},
 }
 
@@ -228,10 +252,14 @@ Options:
     Specify an output file
 -r, --relation  (= %s)
     specify a relation by id
+--seed  (= false)
+    get the seed data of a peer relation
 
 Details:
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+With --seed, the seed data written to a peer relation by the application's
+leader is printed instead; no unit id may then be given.
 %s`[1:]
 
 var relationGetHelpTests = []struct {
@@ -27,6 +27,14 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The --seed option writes the seed data of a peer relation instead of
+the local unit's settings. Only the application's leader may write
+seed data, and unlike unit settings the change takes effect
+immediately, rather than when the hook completes. Seed data can be
+read by every unit of the application as soon as it joins the
+relation, so the leader can use it to publish the data that its
+peers need to join a cluster.
 `
 
 // RelationSetCommand implements the relation-set command.
@@ -37,6 +45,7 @@ type RelationSetCommand struct {
 	relationIdProxy gnuflag.Value
 	Settings        map[string]string
 	settingsFile    cmd.FileVar
+	Seed            bool
 	formatFlag      string // deprecated
 }
 
@@ -67,6 +76,7 @@ func (c *RelationSetCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	c.settingsFile.SetStdin()
 	f.Var(&c.settingsFile, "file", "file containing key-value pairs")
+	f.BoolVar(&c.Seed, "seed", false, "write the seed data of a peer relation")
 
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
 }
@@ -135,6 +145,12 @@ func (c *RelationSetCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.Seed {
+		if err := r.UpdatePeerSeed(c.Settings); err != nil {
+			return errors.Annotate(err, "cannot write seed data")
+		}
+		return nil
+	}
 	settings, err := r.Settings()
 	if err != nil {
 		return errors.Annotate(err, "cannot read relation settings")
@@ -12,9 +12,11 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
 )
@@ -52,6 +54,8 @@ Options:
     deprecated format flag
 -r, --relation  (= %s)
     specify a relation by id
+--seed  (= false)
+    write the seed data of a peer relation
 
 Details:
 "relation-set" writes the local unit's settings for some relation.
@@ -65,6 +69,14 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The --seed option writes the seed data of a peer relation instead of
+the local unit's settings. Only the application's leader may write
+seed data, and unlike unit settings the change takes effect
+immediately, rather than when the hook completes. Seed data can be
+read by every unit of the application as soon as it joins the
+relation, so the leader can use it to publish the data that its
+peers need to join a cluster.
 `[1:], t.expect))
 		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
 	}
@@ -373,6 +385,31 @@ func (s *RelationSetSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *RelationSetSuite) TestRunSeed(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	info.rels[1].Seed = params.Settings{"base": "value", "gone": "soon"}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, com, "--seed", "token=s3cret", "gone=")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(info.rels[1].Seed, gc.DeepEquals, params.Settings{"base": "value", "token": "s3cret"})
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{
+		"private-address": "u-0.testing.invalid",
+	})
+}
+
+func (s *RelationSetSuite) TestRunSeedError(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	info.stub.SetErrors(errors.New(`"u/0" is not leader of "u"`))
+
+	_, err = cmdtesting.RunCommand(c, com, "--seed", "token=s3cret")
+	c.Assert(err, gc.ErrorMatches, `cannot write seed data: "u/0" is not leader of "u"`)
+}
+
 func (s *RelationSetSuite) TestRunDeprecationWarning(c *gc.C) {
 	hctx, _ := s.newHookContext(0, "")
 	com, _ := jujuc.NewCommand(hctx, cmdString("relation-set"))
@@ -39,6 +39,11 @@ func (*RestrictedContext) ApplicationStatus() (ApplicationStatusInfo, error) {
 // SetApplicationStatus implements jujuc.Context.
 func (*RestrictedContext) SetApplicationStatus(StatusInfo) error { return ErrRestrictedContext }
 
+// RelatedApplicationStatuses implements jujuc.Context.
+func (*RestrictedContext) RelatedApplicationStatuses() (map[string]StatusInfo, error) {
+	return nil, ErrRestrictedContext
+}
+
 // AvailabilityZone implements jujuc.Context.
 func (*RestrictedContext) AvailabilityZone() (string, error) { return "", ErrRestrictedContext }
 
@@ -80,6 +85,9 @@ func (*RestrictedContext) LeaderSettings() (map[string]string, error) {
 // WriteLeaderSettings implements jujuc.Context.
 func (*RestrictedContext) WriteLeaderSettings(map[string]string) error { return ErrRestrictedContext }
 
+// SetConfigReady implements jujuc.Context.
+func (*RestrictedContext) SetConfigReady() error { return ErrRestrictedContext }
+
 // AddMetric implements jujuc.Context.
 func (*RestrictedContext) AddMetric(string, string, time.Time) error { return ErrRestrictedContext }
 
@@ -133,6 +141,12 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
+// SetActionProgress implements jujuc.Context.
+func (*RestrictedContext) SetActionProgress(int, string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
@@ -147,3 +161,21 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// CloudSpec implements jujuc.Context.
+func (*RestrictedContext) CloudSpec() (*params.CloudSpec, error) {
+	return nil, ErrRestrictedContext
+}
+
+// HookEnvironment implements jujuc.Context.
+func (*RestrictedContext) HookEnvironment() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// AcquireCharmLock implements jujuc.Context.
+func (*RestrictedContext) AcquireCharmLock(string, time.Duration) error {
+	return ErrRestrictedContext
+}
+
+// ReleaseCharmLock implements jujuc.Context.
+func (*RestrictedContext) ReleaseCharmLock(string) error { return ErrRestrictedContext }
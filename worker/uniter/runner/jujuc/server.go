@@ -46,16 +46,23 @@ func RegisterCommand(name string, f creator) {
 var baseCommands = map[string]creator{
 	"close-port" + cmdSuffix:              NewClosePortCommand,
 	"config-get" + cmdSuffix:              NewConfigGetCommand,
+	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
+	"hook-env-get" + cmdSuffix:            NewHookEnvGetCommand,
 	"juju-log" + cmdSuffix:                NewJujuLogCommand,
+	"lock-acquire" + cmdSuffix:            NewLockAcquireCommand,
+	"lock-release" + cmdSuffix:            NewLockReleaseCommand,
 	"open-port" + cmdSuffix:               NewOpenPortCommand,
 	"opened-ports" + cmdSuffix:            NewOpenedPortsCommand,
 	"relation-get" + cmdSuffix:            NewRelationGetCommand,
 	"action-get" + cmdSuffix:              NewActionGetCommand,
 	"action-set" + cmdSuffix:              NewActionSetCommand,
 	"action-fail" + cmdSuffix:             NewActionFailCommand,
+	"action-log" + cmdSuffix:              NewActionLogCommand,
+	"action-progress" + cmdSuffix:         NewActionProgressCommand,
 	"relation-ids" + cmdSuffix:            NewRelationIdsCommand,
 	"relation-list" + cmdSuffix:           NewRelationListCommand,
 	"relation-set" + cmdSuffix:            NewRelationSetCommand,
+	"related-status-get" + cmdSuffix:      NewRelatedStatusGetCommand,
 	"unit-get" + cmdSuffix:                NewUnitGetCommand,
 	"add-metric" + cmdSuffix:              NewAddMetricCommand,
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
@@ -72,9 +79,10 @@ var storageCommands = map[string]creator{
 }
 
 var leaderCommands = map[string]creator{
-	"is-leader" + cmdSuffix:  NewIsLeaderCommand,
-	"leader-get" + cmdSuffix: NewLeaderGetCommand,
-	"leader-set" + cmdSuffix: NewLeaderSetCommand,
+	"config-ready" + cmdSuffix: NewConfigReadyCommand,
+	"is-leader" + cmdSuffix:    NewIsLeaderCommand,
+	"leader-get" + cmdSuffix:   NewLeaderGetCommand,
+	"leader-set" + cmdSuffix:   NewLeaderSetCommand,
 }
 
 func allEnabledCommands() map[string]creator {
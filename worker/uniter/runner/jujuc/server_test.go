@@ -253,13 +253,19 @@ var newCommandTests = []struct {
 }{
 	{"close-port", ""},
 	{"config-get", ""},
+	{"config-ready", ""},
+	{"credential-get", ""},
+	{"hook-env-get", ""},
 	{"juju-log", ""},
+	{"lock-acquire", ""},
+	{"lock-release", ""},
 	{"open-port", ""},
 	{"opened-ports", ""},
 	{"relation-get", ""},
 	{"relation-ids", ""},
 	{"relation-list", ""},
 	{"relation-set", ""},
+	{"related-status-get", ""},
 	{"unit-get", ""},
 	{"storage-add", ""},
 	{"storage-get", ""},
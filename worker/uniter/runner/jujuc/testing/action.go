@@ -69,3 +69,29 @@ func (c *ContextActionHook) SetActionFailed() error {
 	}
 	return nil
 }
+
+// LogActionMessage implements jujuc.ActionHookContext.
+func (c *ContextActionHook) LogActionMessage(message string) error {
+	c.stub.AddCall("LogActionMessage", message)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.ActionParams == nil {
+		return errors.Errorf("not running an action")
+	}
+	return nil
+}
+
+// SetActionProgress implements jujuc.ActionHookContext.
+func (c *ContextActionHook) SetActionProgress(percent int, message string) error {
+	c.stub.AddCall("SetActionProgress", percent, message)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.ActionParams == nil {
+		return errors.Errorf("not running an action")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// CharmLocks holds values for the hook context.
+type CharmLocks struct {
+	// Locks maps the names of the locks held by the unit to
+	// their times to live.
+	Locks map[string]time.Duration
+}
+
+// ContextCharmLocks is a test double for jujuc.ContextCharmLocks.
+type ContextCharmLocks struct {
+	contextBase
+	info *CharmLocks
+}
+
+// AcquireCharmLock implements jujuc.ContextCharmLocks.
+func (c *ContextCharmLocks) AcquireCharmLock(name string, ttl time.Duration) error {
+	c.stub.AddCall("AcquireCharmLock", name, ttl)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.Locks == nil {
+		c.info.Locks = make(map[string]time.Duration)
+	}
+	c.info.Locks[name] = ttl
+	return nil
+}
+
+// ReleaseCharmLock implements jujuc.ContextCharmLocks.
+func (c *ContextCharmLocks) ReleaseCharmLock(name string) error {
+	c.stub.AddCall("ReleaseCharmLock", name)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := c.info.Locks[name]; !ok {
+		return errors.NotFoundf("lock %q", name)
+	}
+	delete(c.info.Locks, name)
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CloudCredential holds values for the hook context.
+type CloudCredential struct {
+	CloudSpec *params.CloudSpec
+}
+
+// ContextCloudCredential is a test double for jujuc.ContextCloudCredential.
+type ContextCloudCredential struct {
+	contextBase
+	info *CloudCredential
+}
+
+// CloudSpec implements jujuc.ContextCloudCredential.
+func (c *ContextCloudCredential) CloudSpec() (*params.CloudSpec, error) {
+	c.stub.AddCall("CloudSpec")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.CloudSpec, nil
+}
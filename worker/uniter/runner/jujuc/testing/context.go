@@ -23,6 +23,9 @@ type ContextInfo struct {
 	RelationHook
 	ActionHook
 	Version
+	CloudCredential
+	HookEnvironment
+	CharmLocks
 }
 
 // Context returns a Context that wraps the info.
@@ -63,6 +66,9 @@ type Context struct {
 	ContextRelationHook
 	ContextActionHook
 	ContextVersion
+	ContextCloudCredential
+	ContextHookEnvironment
+	ContextCharmLocks
 }
 
 // NewContext builds a jujuc.Context test double.
@@ -92,5 +98,11 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextActionHook.info = &info.ActionHook
 	ctx.ContextVersion.stub = stub
 	ctx.ContextVersion.info = &info.Version
+	ctx.ContextCloudCredential.stub = stub
+	ctx.ContextCloudCredential.info = &info.CloudCredential
+	ctx.ContextHookEnvironment.stub = stub
+	ctx.ContextHookEnvironment.info = &info.HookEnvironment
+	ctx.ContextCharmLocks.stub = stub
+	ctx.ContextCharmLocks.info = &info.CharmLocks
 	return &ctx
 }
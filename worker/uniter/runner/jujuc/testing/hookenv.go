@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+)
+
+// HookEnvironment holds values for the hook context.
+type HookEnvironment struct {
+	Environment map[string]string
+}
+
+// ContextHookEnvironment is a test double for jujuc.ContextHookEnvironment.
+type ContextHookEnvironment struct {
+	contextBase
+	info *HookEnvironment
+}
+
+// HookEnvironment implements jujuc.ContextHookEnvironment.
+func (c *ContextHookEnvironment) HookEnvironment() (map[string]string, error) {
+	c.stub.AddCall("HookEnvironment")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.Environment, nil
+}
@@ -11,6 +11,7 @@ import (
 type Leadership struct {
 	IsLeader       bool
 	LeaderSettings map[string]string
+	ConfigReady    bool
 }
 
 // ContextLeader is a test double for jujuc.ContextLeader.
@@ -49,3 +50,14 @@ func (c *ContextLeader) WriteLeaderSettings(settings map[string]string) error {
 	c.info.LeaderSettings = settings
 	return nil
 }
+
+// SetConfigReady implements jujuc.ContextLeader.
+func (c *ContextLeader) SetConfigReady() error {
+	c.stub.AddCall("SetConfigReady")
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.ConfigReady = true
+	return nil
+}
@@ -24,6 +24,8 @@ type Relation struct {
 	Units map[string]Settings
 	// UnitName is data for jujuc.ContextRelation.
 	UnitName string
+	// Seed is data for jujuc.ContextRelation.
+	Seed params.Settings
 }
 
 // Reset clears the Relation's settings.
@@ -119,3 +121,37 @@ func (r *ContextRelation) Suspended() bool {
 func (r *ContextRelation) SetStatus(status relation.Status) error {
 	return nil
 }
+
+// PeerSeed implements jujuc.ContextRelation.
+func (r *ContextRelation) PeerSeed() (params.Settings, error) {
+	r.stub.AddCall("PeerSeed")
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	seed := make(params.Settings)
+	for k, v := range r.info.Seed {
+		seed[k] = v
+	}
+	return seed, nil
+}
+
+// UpdatePeerSeed implements jujuc.ContextRelation.
+func (r *ContextRelation) UpdatePeerSeed(settings map[string]string) error {
+	r.stub.AddCall("UpdatePeerSeed", settings)
+	if err := r.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if r.info.Seed == nil {
+		r.info.Seed = make(params.Settings)
+	}
+	for k, v := range settings {
+		if v == "" {
+			delete(r.info.Seed, k)
+		} else {
+			r.info.Seed[k] = v
+		}
+	}
+	return nil
+}
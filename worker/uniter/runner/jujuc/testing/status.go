@@ -11,8 +11,9 @@ import (
 
 // Status  holds the values for the hook context.
 type Status struct {
-	UnitStatus        jujuc.StatusInfo
-	ApplicationStatus jujuc.ApplicationStatusInfo
+	UnitStatus                 jujuc.StatusInfo
+	ApplicationStatus          jujuc.ApplicationStatusInfo
+	RelatedApplicationStatuses map[string]jujuc.StatusInfo
 }
 
 // SetApplicationStatus builds a service status and sets it on the Status.
@@ -70,3 +71,13 @@ func (c *ContextStatus) SetApplicationStatus(status jujuc.StatusInfo) error {
 	c.info.SetApplicationStatus(status, nil)
 	return nil
 }
+
+// RelatedApplicationStatuses implements jujuc.ContextStatus.
+func (c *ContextStatus) RelatedApplicationStatuses() (map[string]jujuc.StatusInfo, error) {
+	c.stub.AddCall("RelatedApplicationStatuses")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return c.info.RelatedApplicationStatuses, nil
+}
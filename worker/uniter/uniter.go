@@ -245,6 +245,10 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				Clock:               u.clock,
+				RelationChangesWindow: NewRelationChangesWindow(
+					u.paths.State.CharmDir,
+				),
 			})
 		if err != nil {
 			return errors.Trace(err)
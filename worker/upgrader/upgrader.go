@@ -6,6 +6,7 @@ package upgrader
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/juju/errors"
@@ -20,6 +21,7 @@ import (
 	agenttools "github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api/upgrader"
 	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/tools/bindelta"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker/catacomb"
 	"github.com/juju/juju/worker/gate"
@@ -250,10 +252,39 @@ func (u *Upgrader) newUpgradeReadyError(newVersion version.Binary) *UpgradeReady
 }
 
 func (u *Upgrader) ensureTools(agentTools *coretools.Tools) error {
-	logger.Infof("fetching agent binaries from %q", agentTools.URL)
+	// Try to build the new tools from a delta against the current
+	// tools, which is much smaller than the whole tarball, falling
+	// back to the tarball if that fails.
+	current := toBinaryVersion(jujuversion.Current)
+	if u.toolsAlreadyDownloaded(current) {
+		err := u.fetchTools(agentTools, current)
+		if err == nil {
+			return nil
+		}
+		logger.Warningf("cannot upgrade agent binaries using delta from %s: %v", current, err)
+	}
+	return u.fetchTools(agentTools, version.Binary{})
+}
+
+// fetchTools downloads the given tools and unpacks them. If a base
+// version is given, the controller is asked for a delta from the tools
+// with that version, which it may send instead of the tools tarball.
+func (u *Upgrader) fetchTools(agentTools *coretools.Tools, base version.Binary) error {
+	toolsURL := agentTools.URL
+	if base != (version.Binary{}) {
+		parsed, err := url.Parse(toolsURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		query := parsed.Query()
+		query.Set("delta-from", base.String())
+		parsed.RawQuery = query.Encode()
+		toolsURL = parsed.String()
+	}
+	logger.Infof("fetching agent binaries from %q", toolsURL)
 	// The reader MUST verify the tools' hash, so there is no
 	// need to validate the peer. We cannot anyway: see http://pad.lv/1261780.
-	resp, err := utils.GetNonValidatingHTTPClient().Get(agentTools.URL)
+	resp, err := utils.GetNonValidatingHTTPClient().Get(toolsURL)
 	if err != nil {
 		return err
 	}
@@ -261,7 +292,16 @@ func (u *Upgrader) ensureTools(agentTools *coretools.Tools) error {
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("bad HTTP response: %v", resp.Status)
 	}
-	err = agenttools.UnpackTools(u.dataDir, agentTools, resp.Body)
+	if resp.Header.Get("Content-Type") == bindelta.ContentType {
+		// The delta holds the hashes of the files it builds, which
+		// are verified as they are built.
+		if base == (version.Binary{}) {
+			return errors.New("unexpected agent binaries delta")
+		}
+		err = agenttools.UnpackToolsDelta(u.dataDir, base, agentTools, resp.Body)
+	} else {
+		err = agenttools.UnpackTools(u.dataDir, agentTools, resp.Body)
+	}
 	if err != nil {
 		return fmt.Errorf("cannot unpack agent binaries: %v", err)
 	}